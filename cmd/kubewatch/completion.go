@@ -0,0 +1,360 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/k8s"
+)
+
+// completeHelperCommand is the hidden subcommand the generated scripts call
+// back into for dynamic values (context and namespace names)
+const completeHelperCommand = "__complete"
+
+// namespaceCompletionTimeout bounds the cluster query made while completing
+// --namespace so an unreachable API server never stalls the shell
+const namespaceCompletionTimeout = 1 * time.Second
+
+// completionShells lists the shells `kubewatch completion` can generate for
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// runCompletion writes the completion script for the requested shell
+func runCompletion(args []string, w io.Writer) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: kubewatch completion %s", strings.Join(completionShells, "|"))
+	}
+
+	flags := collectFlagInfo()
+	aliases := allResourceTypeAliases()
+
+	switch args[0] {
+	case "bash":
+		writeBashCompletion(w, flags, aliases)
+	case "zsh":
+		writeZshCompletion(w, flags, aliases)
+	case "fish":
+		writeFishCompletion(w, flags, aliases)
+	default:
+		return fmt.Errorf("unsupported shell %q (supported: %s)", args[0], strings.Join(completionShells, ", "))
+	}
+	return nil
+}
+
+// runCompleteHelper prints dynamic completion candidates one per line.
+// Failures print nothing so the shell simply offers no suggestions.
+func runCompleteHelper(args []string, w io.Writer) {
+	if len(args) == 0 {
+		return
+	}
+
+	fs := flag.NewFlagSet(completeHelperCommand, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	kubeconfig := fs.String("kubeconfig", "", "")
+	contextName := fs.String("context", "", "")
+	if err := fs.Parse(args[1:]); err != nil {
+		return
+	}
+
+	var names []string
+	switch args[0] {
+	case "contexts":
+		names = completeContexts(expandHome(*kubeconfig))
+	case "namespaces":
+		names = completeNamespaces(expandHome(*kubeconfig), *contextName)
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintln(w, name)
+	}
+}
+
+// completeContexts returns the context names from the kubeconfig
+func completeContexts(kubeconfig string) []string {
+	contexts, _, err := k8s.GetAvailableContextsFromKubeconfig(kubeconfig)
+	if err != nil {
+		return nil
+	}
+	return contexts
+}
+
+// completeNamespaces queries the cluster for namespace names, giving up
+// quietly after namespaceCompletionTimeout
+func completeNamespaces(kubeconfig, contextName string) []string {
+	// --context may hold a comma-separated list; complete against the first
+	if i := strings.Index(contextName, ","); i >= 0 {
+		contextName = contextName[:i]
+	}
+
+	client, err := k8s.NewClientWithOptions(kubeconfig, &k8s.ClientOptions{
+		Context: strings.TrimSpace(contextName),
+		Timeout: namespaceCompletionTimeout.String(),
+	})
+	if err != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), namespaceCompletionTimeout)
+	defer cancel()
+
+	namespaces, err := client.ListNamespaces(ctx)
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		names = append(names, ns.Name)
+	}
+	return names
+}
+
+// expandHome expands a leading ~/ since shells pass the flag value through unexpanded
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
+
+// flagNamesByKind returns the dashed names of flags with the given value kind
+func flagNamesByKind(flags []flagInfo, kind flagValueKind) []string {
+	var names []string
+	for _, f := range flags {
+		if !f.isBool && f.kind == kind {
+			names = append(names, dashedFlagName(f.name))
+		}
+	}
+	return names
+}
+
+func writeBashCompletion(w io.Writer, flags []flagInfo, aliases []string) {
+	var allFlags []string
+	for _, f := range flags {
+		allFlags = append(allFlags, dashedFlagName(f.name))
+	}
+
+	fmt.Fprintf(w, `# bash completion for kubewatch
+# Load with: source <(kubewatch completion bash)
+
+__kubewatch_flag_value() {
+    local i word
+    for ((i = 1; i < ${#COMP_WORDS[@]}; i++)); do
+        word="${COMP_WORDS[i]}"
+        if [[ "$word" == "--$1" || "$word" == "-$1" ]]; then
+            if [[ "${COMP_WORDS[i+1]}" == "=" ]]; then
+                echo "${COMP_WORDS[i+2]}"
+            else
+                echo "${COMP_WORDS[i+1]}"
+            fi
+            return
+        fi
+    done
+}
+
+__kubewatch_dynamic() {
+    "${COMP_WORDS[0]}" %s "$1" --kubeconfig="$(__kubewatch_flag_value kubeconfig)" --context="$(__kubewatch_flag_value context)" 2>/dev/null
+}
+
+_kubewatch() {
+    local cur prev flag
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    COMPREPLY=()
+
+    # COMP_WORDBREAKS splits --flag=value into "--flag" "=" "value"
+    if [[ "$cur" == "=" ]]; then
+        flag="$prev"
+        cur=""
+    elif [[ "$prev" == "=" ]]; then
+        flag="${COMP_WORDS[COMP_CWORD-2]}"
+    else
+        flag="$prev"
+    fi
+
+    case "$flag" in
+`, completeHelperCommand)
+
+	if names := flagNamesByKind(flags, flagValueContext); len(names) > 0 {
+		fmt.Fprintf(w, "        %s)\n", strings.Join(names, "|"))
+		fmt.Fprintf(w, "            COMPREPLY=($(compgen -W \"$(__kubewatch_dynamic contexts)\" -- \"$cur\"))\n            return\n            ;;\n")
+	}
+	if names := flagNamesByKind(flags, flagValueNamespace); len(names) > 0 {
+		fmt.Fprintf(w, "        %s)\n", strings.Join(names, "|"))
+		fmt.Fprintf(w, "            COMPREPLY=($(compgen -W \"$(__kubewatch_dynamic namespaces)\" -- \"$cur\"))\n            return\n            ;;\n")
+	}
+	if names := flagNamesByKind(flags, flagValueFile); len(names) > 0 {
+		fmt.Fprintf(w, "        %s)\n", strings.Join(names, "|"))
+		fmt.Fprintf(w, "            COMPREPLY=($(compgen -f -- \"$cur\"))\n            return\n            ;;\n")
+	}
+	for _, f := range flags {
+		if f.kind == flagValueChoice {
+			fmt.Fprintf(w, "        %s)\n", dashedFlagName(f.name))
+			fmt.Fprintf(w, "            COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n            return\n            ;;\n", strings.Join(f.choices, " "))
+		}
+	}
+	if names := flagNamesByKind(flags, flagValueNone); len(names) > 0 {
+		// Free-form values: offer nothing rather than resource types
+		fmt.Fprintf(w, "        %s)\n            return\n            ;;\n", strings.Join(names, "|"))
+	}
+
+	fmt.Fprintf(w, `    esac
+
+    if [[ "$cur" == -* ]]; then
+        COMPREPLY=($(compgen -W "%s" -- "$cur"))
+        return
+    fi
+
+    if [[ "${COMP_WORDS[1]}" == "completion" ]]; then
+        if [[ $COMP_CWORD -eq 2 ]]; then
+            COMPREPLY=($(compgen -W "%s" -- "$cur"))
+        fi
+        return
+    fi
+
+    local words="%s"
+    if [[ $COMP_CWORD -eq 1 ]]; then
+        words="completion validate-config $words"
+    fi
+    COMPREPLY=($(compgen -W "$words" -- "$cur"))
+}
+
+complete -F _kubewatch kubewatch
+`, strings.Join(allFlags, " "), strings.Join(completionShells, " "), strings.Join(aliases, " "))
+}
+
+// zshDescription escapes a flag usage string for an _arguments spec
+func zshDescription(s string) string {
+	r := strings.NewReplacer(`'`, `'\''`, `[`, `\[`, `]`, `\]`, `:`, `\:`)
+	return r.Replace(s)
+}
+
+func writeZshCompletion(w io.Writer, flags []flagInfo, aliases []string) {
+	fmt.Fprintf(w, `#compdef kubewatch
+# zsh completion for kubewatch
+# Load with: source <(kubewatch completion zsh)
+
+__kubewatch_dynamic() {
+    local -a names
+    names=(${(f)"$(${words[1]} %s $1 --kubeconfig="${opt_args[--kubeconfig]}" --context="${opt_args[--context]}" 2>/dev/null)"})
+    compadd -a names
+}
+
+__kubewatch_positional() {
+    compadd completion validate-config %s
+}
+
+_kubewatch() {
+    _arguments \
+`, completeHelperCommand, strings.Join(aliases, " "))
+
+	for _, f := range flags {
+		name := dashedFlagName(f.name)
+		desc := zshDescription(f.usage)
+		if f.isBool {
+			fmt.Fprintf(w, "        '%s[%s]' \\\n", name, desc)
+			continue
+		}
+
+		var action string
+		switch f.kind {
+		case flagValueFile:
+			action = "_files"
+		case flagValueContext:
+			action = "{__kubewatch_dynamic contexts}"
+		case flagValueNamespace:
+			action = "{__kubewatch_dynamic namespaces}"
+		case flagValueChoice:
+			action = "(" + strings.Join(f.choices, " ") + ")"
+		default:
+			action = " "
+		}
+		fmt.Fprintf(w, "        '%s=[%s]:%s:%s' \\\n", name, desc, f.name, action)
+	}
+
+	fmt.Fprintf(w, `        '1:resource type:__kubewatch_positional' \
+        '2::shell:(%s)'
+}
+
+if [[ "$funcstack[1]" = "_kubewatch" ]]; then
+    _kubewatch "$@"
+else
+    compdef _kubewatch kubewatch
+fi
+`, strings.Join(completionShells, " "))
+}
+
+// fishQuote single-quotes s for fish
+func fishQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return "'" + r.Replace(s) + "'"
+}
+
+func writeFishCompletion(w io.Writer, flags []flagInfo, aliases []string) {
+	fmt.Fprintf(w, `# fish completion for kubewatch
+# Load with: kubewatch completion fish | source
+
+function __kubewatch_flag_value
+    set -l tokens (commandline -opc)
+    for i in (seq (count $tokens))
+        switch $tokens[$i]
+            case "--$argv[1]=*" "-$argv[1]=*"
+                string replace -r '^[^=]*=' '' -- $tokens[$i]
+                return
+            case "--$argv[1]" "-$argv[1]"
+                set -l next (math $i + 1)
+                if test $next -le (count $tokens)
+                    echo $tokens[$next]
+                end
+                return
+        end
+    end
+end
+
+function __kubewatch_dynamic
+    set -l cmd (commandline -opc)[1]
+    $cmd %s $argv[1] --kubeconfig=(__kubewatch_flag_value kubeconfig) --context=(__kubewatch_flag_value context) 2>/dev/null
+end
+
+complete -c kubewatch -f
+complete -c kubewatch -n __fish_use_subcommand -a completion -d 'Generate shell completion script'
+complete -c kubewatch -n __fish_use_subcommand -a validate-config -d 'Check the config file'
+complete -c kubewatch -n '__fish_seen_subcommand_from completion' -a %s
+complete -c kubewatch -n __fish_use_subcommand -a %s -d 'Resource type'
+`, completeHelperCommand, fishQuote(strings.Join(completionShells, " ")), fishQuote(strings.Join(aliases, " ")))
+
+	for _, f := range flags {
+		opt := "-l " + f.name
+		if len(f.name) == 1 {
+			opt = "-s " + f.name
+		}
+
+		var value string
+		switch {
+		case f.isBool:
+			value = ""
+		case f.kind == flagValueFile:
+			value = " -r -F"
+		case f.kind == flagValueContext:
+			value = " -x -a '(__kubewatch_dynamic contexts)'"
+		case f.kind == flagValueNamespace:
+			value = " -x -a '(__kubewatch_dynamic namespaces)'"
+		case f.kind == flagValueChoice:
+			value = " -x -a " + fishQuote(strings.Join(f.choices, " "))
+		default:
+			value = " -x"
+		}
+		fmt.Fprintf(w, "complete -c kubewatch %s%s -d %s\n", opt, value, fishQuote(f.usage))
+	}
+}
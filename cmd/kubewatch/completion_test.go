@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompletionScriptsReferenceAllAliases(t *testing.T) {
+	for _, shell := range completionShells {
+		t.Run(shell, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := runCompletion([]string{shell}, &buf); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			script := buf.String()
+
+			for _, alias := range allResourceTypeAliases() {
+				pattern := regexp.MustCompile(`(^|[\s'"(])` + regexp.QuoteMeta(alias) + `($|[\s'")])`)
+				if !pattern.MatchString(script) {
+					t.Errorf("%s script does not reference alias %q", shell, alias)
+				}
+			}
+
+			for _, f := range collectFlagInfo() {
+				if !strings.Contains(script, f.name) {
+					t.Errorf("%s script does not reference flag %q", shell, f.name)
+				}
+			}
+
+			if !strings.Contains(script, completeHelperCommand+" ") {
+				t.Errorf("%s script does not call back for dynamic completion", shell)
+			}
+		})
+	}
+}
+
+func TestCompletionUnsupportedShell(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no shell", nil},
+		{"unknown shell", []string{"tcsh"}},
+		{"too many args", []string{"bash", "zsh"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := runCompletion(tt.args, &buf); err == nil {
+				t.Error("Expected error but got none")
+			}
+			if buf.Len() != 0 {
+				t.Errorf("Expected no output, got %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestCollectFlagInfo(t *testing.T) {
+	infos := make(map[string]flagInfo)
+	for _, f := range collectFlagInfo() {
+		infos[f.name] = f
+	}
+
+	tests := []struct {
+		name   string
+		isBool bool
+		kind   flagValueKind
+	}{
+		{"context", false, flagValueContext},
+		{"n", false, flagValueNamespace},
+		{"namespace", false, flagValueNamespace},
+		{"kubeconfig", false, flagValueFile},
+		{"color-scheme", false, flagValueChoice},
+		{"as-group", false, flagValueNone},
+		{"A", true, flagValueNone},
+		{"verbose", true, flagValueNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, ok := infos[tt.name]
+			if !ok {
+				t.Fatalf("Flag %q not registered", tt.name)
+			}
+			if info.isBool != tt.isBool {
+				t.Errorf("Expected isBool=%v, got %v", tt.isBool, info.isBool)
+			}
+			if info.kind != tt.kind {
+				t.Errorf("Expected kind %v, got %v", tt.kind, info.kind)
+			}
+		})
+	}
+}
+
+func writeTestKubeconfig(t *testing.T) string {
+	t.Helper()
+	content := `apiVersion: v1
+kind: Config
+clusters:
+- name: unreachable
+  cluster:
+    server: https://127.0.0.1:1
+users:
+- name: test-user
+  user: {}
+contexts:
+- name: staging
+  context: {cluster: unreachable, user: test-user}
+- name: prod
+  context: {cluster: unreachable, user: test-user}
+current-context: prod
+`
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	return path
+}
+
+func TestCompleteHelperContexts(t *testing.T) {
+	kubeconfig := writeTestKubeconfig(t)
+
+	var buf bytes.Buffer
+	runCompleteHelper([]string{"contexts", "--kubeconfig", kubeconfig}, &buf)
+
+	if got, want := buf.String(), "prod\nstaging\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestCompleteHelperNamespacesFallsBackToNothing(t *testing.T) {
+	kubeconfig := writeTestKubeconfig(t)
+
+	var buf bytes.Buffer
+	start := time.Now()
+	runCompleteHelper([]string{"namespaces", "--kubeconfig", kubeconfig, "--context", "prod,staging"}, &buf)
+
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output for unreachable cluster, got %q", buf.String())
+	}
+	if elapsed := time.Since(start); elapsed > 3*namespaceCompletionTimeout {
+		t.Errorf("Namespace completion took %v, expected to give up after about %v", elapsed, namespaceCompletionTimeout)
+	}
+}
+
+func TestCompleteHelperIgnoresBadInput(t *testing.T) {
+	tests := [][]string{
+		nil,
+		{"unknown"},
+		{"contexts", "--bogus-flag"},
+	}
+
+	for _, args := range tests {
+		var buf bytes.Buffer
+		runCompleteHelper(args, &buf)
+		if buf.Len() != 0 {
+			t.Errorf("Expected no output for %v, got %q", args, buf.String())
+		}
+	}
+}
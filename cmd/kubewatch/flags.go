@@ -0,0 +1,228 @@
+package main
+
+import (
+	"flag"
+	"strings"
+)
+
+// resourceTypeAlias maps the names accepted on the command line to a resource type
+type resourceTypeAlias struct {
+	resourceType string
+	aliases      []string
+}
+
+// resourceTypeAliases is the registry of resource-type positionals; it drives
+// both config loading and shell completion
+var resourceTypeAliases = []resourceTypeAlias{
+	{resourceType: "pod", aliases: []string{"pods", "pod", "po"}},
+	{resourceType: "deployment", aliases: []string{"deployments", "deployment", "deploy"}},
+	{resourceType: "statefulset", aliases: []string{"statefulsets", "statefulset", "sts"}},
+	{resourceType: "job", aliases: []string{"jobs", "job"}},
+	{resourceType: "cronjob", aliases: []string{"cronjobs", "cronjob", "cj"}},
+	{resourceType: "service", aliases: []string{"services", "service", "svc"}},
+	{resourceType: "ingress", aliases: []string{"ingresses", "ingress", "ing"}},
+	{resourceType: "gateway", aliases: []string{"gateways", "gateway", "gw"}},
+	{resourceType: "httproute", aliases: []string{"httproutes", "httproute"}},
+	{resourceType: "configmap", aliases: []string{"configmaps", "configmap", "cm"}},
+	{resourceType: "secret", aliases: []string{"secrets", "secret"}},
+	{resourceType: "serviceaccount", aliases: []string{"serviceaccounts", "serviceaccount", "sa"}},
+	{resourceType: "role", aliases: []string{"roles", "role"}},
+	{resourceType: "clusterrole", aliases: []string{"clusterroles", "clusterrole"}},
+	{resourceType: "rolebinding", aliases: []string{"rolebindings", "rolebinding", "rb"}},
+	{resourceType: "clusterrolebinding", aliases: []string{"clusterrolebindings", "clusterrolebinding", "crb"}},
+	{resourceType: "node", aliases: []string{"nodes", "node", "no"}},
+}
+
+// usagePositional opens the usage overlay in place of a resource type, as
+// in `kubewatch top`
+const usagePositional = "top"
+
+// resolveResourceType returns the resource type for an alias, or the input
+// unchanged if it is not a known alias
+func resolveResourceType(name string) string {
+	lower := strings.ToLower(name)
+	for _, entry := range resourceTypeAliases {
+		for _, alias := range entry.aliases {
+			if alias == lower {
+				return entry.resourceType
+			}
+		}
+	}
+	return name
+}
+
+// allResourceTypeAliases returns every registered alias in registry order,
+// then the usage positional
+func allResourceTypeAliases() []string {
+	var aliases []string
+	for _, entry := range resourceTypeAliases {
+		aliases = append(aliases, entry.aliases...)
+	}
+	return append(aliases, usagePositional)
+}
+
+// registerFlags defines all command-line flags on fs, binding them to flags
+func registerFlags(fs *flag.FlagSet, flags *CLIFlags) {
+	// Define flags similar to kubectl
+	fs.StringVar(&flags.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file to use for CLI requests (can also use KUBECONFIG env var)")
+	fs.StringVar(&flags.context, "context", "", "Kubernetes context(s) to use. Single: 'prod' or Multiple: 'prod,staging,dev'")
+	fs.StringVar(&flags.namespace, "namespace", "", "If present, the namespace scope for this CLI request")
+	fs.StringVar(&flags.namespace, "n", "", "Shorthand for --namespace")
+	fs.BoolVar(&flags.allNamespaces, "all-namespaces", false, "If present, list the requested object(s) across all namespaces")
+	fs.BoolVar(&flags.allNamespaces, "A", false, "Shorthand for --all-namespaces")
+
+	// Authentication flags
+	fs.StringVar(&flags.user, "user", "", "The name of the kubeconfig user to use")
+	fs.StringVar(&flags.cluster, "cluster", "", "The name of the kubeconfig cluster to use")
+	fs.StringVar(&flags.authInfoName, "auth-info-name", "", "The name of the kubeconfig auth info to use")
+	fs.StringVar(&flags.clientCertificate, "client-certificate", "", "Path to a client certificate file for TLS")
+	fs.StringVar(&flags.clientKey, "client-key", "", "Path to a client key file for TLS")
+	fs.StringVar(&flags.certificateAuthority, "certificate-authority", "", "Path to a cert file for the certificate authority")
+	fs.BoolVar(&flags.insecureSkipVerify, "insecure-skip-tls-verify", false, "If true, the server's certificate will not be checked for validity")
+	fs.StringVar(&flags.token, "token", "", "Bearer token for authentication to the API server")
+	fs.StringVar(&flags.tokenFile, "token-file", "", "Path to a file containing a bearer token for authentication")
+	fs.StringVar(&flags.asUser, "as", "", "Username to impersonate for the operation")
+	fs.Func("as-group", "Group to impersonate for the operation (can be repeated)", func(s string) error {
+		flags.asGroup = append(flags.asGroup, s)
+		return nil
+	})
+	fs.StringVar(&flags.asUID, "as-uid", "", "UID to impersonate for the operation")
+
+	// Request flags
+	fs.StringVar(&flags.timeout, "timeout", "0s", "The length of time to wait before giving up on a single server request")
+	fs.StringVar(&flags.requestTimeout, "request-timeout", "0s", "The length of time to wait before giving up on a single server request")
+
+	// UI-specific flags
+	fs.IntVar(&flags.refreshInterval, "refresh-interval", 2, "Refresh interval in seconds for updating resources")
+	fs.IntVar(&flags.logTailLines, "log-tail-lines", 100, "Number of log lines to tail when viewing logs")
+	fs.StringVar(&flags.logGrep, "log-grep", "", "Keep only log lines matching this regular expression as they are read, e.g. 'req-7f3a'")
+	fs.IntVar(&flags.maxResourcesShown, "max-resources", 500, "Maximum number of resources to display")
+	fs.StringVar(&flags.colorScheme, "color-scheme", "default", "Color scheme to use (default, dark, light)")
+	fs.BoolVar(&flags.correctClockSkew, "correct-clock-skew", false, "Add detected cluster clock skew to displayed ages")
+	fs.BoolVar(&flags.accessible, "accessible", false, "Plain text for screen readers: ASCII borders, no colors, the selected row read out under the list")
+
+	// View flags, which reopen a view copied with y
+	fs.StringVar(&flags.filter, "filter", "", "Filter expression to start with, e.g. 'status=CrashLoopBackOff'")
+	fs.StringVar(&flags.selector, "selector", "", "Label selector for pods, workloads, jobs and services, e.g. 'app=frontend,tier!=cache'")
+	fs.StringVar(&flags.selector, "l", "", "Shorthand for --selector")
+	fs.StringVar(&flags.fieldSelector, "field-selector", "", "Field selector for pods, e.g. 'spec.nodeName=worker-3'")
+	fs.StringVar(&flags.sort, "sort", "", "Column and direction to sort by, e.g. RESTARTS:desc")
+	fs.StringVar(&flags.columns, "columns", "", "Comma-separated columns to show (NAME is always shown)")
+	fs.StringVar(&flags.selected, "select", "", "Resource to select once listed, as name or namespace/name")
+	fs.StringVar(&flags.fleetSearch, "fleet-search", "", "Search every kubeconfig context for pods named like this, as pattern or namespace/pattern")
+
+	// Context file flag
+	fs.StringVar(&flags.contextFile, "context-file", "", "File containing list of contexts (one per line)")
+
+	// Metrics flags
+	fs.StringVar(&flags.metricsListen, "metrics-listen", "", "Serve Prometheus metrics on this address, e.g. 127.0.0.1:9123")
+	fs.BoolVar(&flags.metricsAllowExternal, "metrics-allow-external", false, "Allow --metrics-listen to use a non-loopback address")
+
+	// Network flags
+	fs.BoolVar(&flags.noExternalNetwork, "no-external-network", false, "Refuse connections to anything but the API servers and their proxies")
+
+	// Other flags
+	fs.BoolVar(&flags.version, "version", false, "Print version information and quit")
+	fs.BoolVar(&flags.version, "v", false, "Shorthand for --version")
+	fs.BoolVar(&flags.help, "help", false, "Show help message")
+	fs.BoolVar(&flags.help, "h", false, "Shorthand for --help")
+	fs.BoolVar(&flags.verbose, "verbose", false, "Enable verbose output")
+	fs.StringVar(&flags.logLevel, "log-level", "info", "Log level (debug, info, warn, error)")
+	fs.StringVar(&flags.cacheDir, "cache-dir", "", "Default cache directory")
+}
+
+// parseArgs parses args into flags with fs. Flags may come before or after
+// the resource-type positional, as in a command copied from a view; only the
+// first positional is used.
+func parseArgs(fs *flag.FlagSet, flags *CLIFlags, args []string) error {
+	var positionals []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positionals = append(positionals, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	flags.setFlags = explicitFlags(fs)
+
+	if len(positionals) > 0 {
+		if positionals[0] == usagePositional {
+			flags.usage = true
+		} else {
+			flags.resourceType = positionals[0]
+		}
+	}
+	return nil
+}
+
+// flagValueKind describes how the value of a flag should be completed
+type flagValueKind int
+
+const (
+	flagValueNone flagValueKind = iota
+	flagValueFile
+	flagValueContext
+	flagValueNamespace
+	flagValueChoice
+)
+
+// flagValueCompletions lists flags whose values can be completed; flags not
+// listed here take a free-form value (or none, for booleans)
+var flagValueCompletions = map[string]struct {
+	kind    flagValueKind
+	choices []string
+}{
+	"kubeconfig":            {kind: flagValueFile},
+	"client-certificate":    {kind: flagValueFile},
+	"client-key":            {kind: flagValueFile},
+	"certificate-authority": {kind: flagValueFile},
+	"token-file":            {kind: flagValueFile},
+	"context-file":          {kind: flagValueFile},
+	"cache-dir":             {kind: flagValueFile},
+	"context":               {kind: flagValueContext},
+	"namespace":             {kind: flagValueNamespace},
+	"n":                     {kind: flagValueNamespace},
+	"color-scheme":          {kind: flagValueChoice, choices: []string{"default", "dark", "light"}},
+	"log-level":             {kind: flagValueChoice, choices: []string{"debug", "info", "warn", "error"}},
+}
+
+// flagInfo is the metadata the completion generator needs about a flag
+type flagInfo struct {
+	name    string
+	usage   string
+	isBool  bool
+	kind    flagValueKind
+	choices []string
+}
+
+// collectFlagInfo returns metadata for every registered flag, sorted by name
+func collectFlagInfo() []flagInfo {
+	fs := flag.NewFlagSet("kubewatch", flag.ContinueOnError)
+	registerFlags(fs, &CLIFlags{})
+
+	var infos []flagInfo
+	fs.VisitAll(func(f *flag.Flag) {
+		info := flagInfo{name: f.Name, usage: f.Usage}
+		if bf, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && bf.IsBoolFlag() {
+			info.isBool = true
+		}
+		if c, ok := flagValueCompletions[f.Name]; ok {
+			info.kind = c.kind
+			info.choices = c.choices
+		}
+		infos = append(infos, info)
+	})
+	return infos
+}
+
+// dashedFlagName returns the flag as typed on the command line: single-letter
+// flags use one dash, everything else two
+func dashedFlagName(name string) string {
+	if len(name) == 1 {
+		return "-" + name
+	}
+	return "--" + name
+}
@@ -5,16 +5,23 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
+	"github.com/HamStudy/kubewatch/internal/config"
 	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/metrics"
+	"github.com/HamStudy/kubewatch/internal/notify"
 	"github.com/HamStudy/kubewatch/internal/ui"
+	"github.com/HamStudy/kubewatch/internal/update"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -53,80 +60,75 @@ type CLIFlags struct {
 	// UI flags
 	refreshInterval   int
 	logTailLines      int
+	logGrep           string // Log lines must match it to be kept as they are read
 	maxResourcesShown int
 	colorScheme       string
 	resourceType      string // Initial resource type to display
+	usage             bool   // Start in the usage overlay
+	fleetSearch       string // Pods to search every context for at start
+	correctClockSkew  bool
+	accessible        bool // Plain-text rendering for screen readers
+
+	// View flags
+	filter        string
+	selector      string // Label selector, as kubectl -l takes it
+	fieldSelector string // Field selector for pods, as kubectl --field-selector takes it
+	sort          string
+	columns       string
+	selected      string
 
 	// Context flags
 	contextFile string // File containing list of contexts
 
+	// Metrics flags
+	metricsListen        string // Address to serve Prometheus metrics on; off when empty
+	metricsAllowExternal bool
+
+	// Network flags
+	noExternalNetwork bool // Only the API servers and their proxies may be dialed
+
 	// Other flags
 	version  bool
 	help     bool
 	verbose  bool
 	logLevel string
 	cacheDir string
+
+	// Names of flags given explicitly on the command line
+	setFlags map[string]bool
 }
 
 func parseFlags() *CLIFlags {
 	flags := &CLIFlags{}
 
-	// Define flags similar to kubectl
-	flag.StringVar(&flags.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file to use for CLI requests (can also use KUBECONFIG env var)")
-	flag.StringVar(&flags.context, "context", "", "Kubernetes context(s) to use. Single: 'prod' or Multiple: 'prod,staging,dev'")
-	flag.StringVar(&flags.namespace, "namespace", "", "If present, the namespace scope for this CLI request")
-	flag.StringVar(&flags.namespace, "n", "", "Shorthand for --namespace")
-	flag.BoolVar(&flags.allNamespaces, "all-namespaces", false, "If present, list the requested object(s) across all namespaces")
-	flag.BoolVar(&flags.allNamespaces, "A", false, "Shorthand for --all-namespaces")
-
-	// Authentication flags
-	flag.StringVar(&flags.user, "user", "", "The name of the kubeconfig user to use")
-	flag.StringVar(&flags.cluster, "cluster", "", "The name of the kubeconfig cluster to use")
-	flag.StringVar(&flags.authInfoName, "auth-info-name", "", "The name of the kubeconfig auth info to use")
-	flag.StringVar(&flags.clientCertificate, "client-certificate", "", "Path to a client certificate file for TLS")
-	flag.StringVar(&flags.clientKey, "client-key", "", "Path to a client key file for TLS")
-	flag.StringVar(&flags.certificateAuthority, "certificate-authority", "", "Path to a cert file for the certificate authority")
-	flag.BoolVar(&flags.insecureSkipVerify, "insecure-skip-tls-verify", false, "If true, the server's certificate will not be checked for validity")
-	flag.StringVar(&flags.token, "token", "", "Bearer token for authentication to the API server")
-	flag.StringVar(&flags.tokenFile, "token-file", "", "Path to a file containing a bearer token for authentication")
-	flag.StringVar(&flags.asUser, "as", "", "Username to impersonate for the operation")
-	flag.StringVar(&flags.asUID, "as-uid", "", "UID to impersonate for the operation")
-
-	// Request flags
-	flag.StringVar(&flags.timeout, "timeout", "0s", "The length of time to wait before giving up on a single server request")
-	flag.StringVar(&flags.requestTimeout, "request-timeout", "0s", "The length of time to wait before giving up on a single server request")
-
-	// UI-specific flags
-	flag.IntVar(&flags.refreshInterval, "refresh-interval", 2, "Refresh interval in seconds for updating resources")
-	flag.IntVar(&flags.logTailLines, "log-tail-lines", 100, "Number of log lines to tail when viewing logs")
-	flag.IntVar(&flags.maxResourcesShown, "max-resources", 500, "Maximum number of resources to display")
-	flag.StringVar(&flags.colorScheme, "color-scheme", "default", "Color scheme to use (default, dark, light)")
-
-	// Context file flag
-	flag.StringVar(&flags.contextFile, "context-file", "", "File containing list of contexts (one per line)")
-
-	// Other flags
-	flag.BoolVar(&flags.version, "version", false, "Print version information and quit")
-	flag.BoolVar(&flags.version, "v", false, "Shorthand for --version")
-	flag.BoolVar(&flags.help, "help", false, "Show help message")
-	flag.BoolVar(&flags.help, "h", false, "Shorthand for --help")
-	flag.BoolVar(&flags.verbose, "verbose", false, "Enable verbose output")
-	flag.StringVar(&flags.logLevel, "log-level", "info", "Log level (debug, info, warn, error)")
-	flag.StringVar(&flags.cacheDir, "cache-dir", "", "Default cache directory")
+	registerFlags(flag.CommandLine, flags)
 
 	// Custom usage message
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Kubewatch TUI - Terminal-based Kubernetes Dashboard\n\n")
 		fmt.Fprintf(os.Stderr, "Usage:\n")
-		fmt.Fprintf(os.Stderr, "  kubewatch [flags] [resource-type]\n\n")
+		fmt.Fprintf(os.Stderr, "  kubewatch [flags] [resource-type]\n")
+		fmt.Fprintf(os.Stderr, "  kubewatch [flags] top\n")
+		fmt.Fprintf(os.Stderr, "  kubewatch completion bash|zsh|fish\n")
+		fmt.Fprintf(os.Stderr, "  kubewatch validate-config [path]\n")
+		fmt.Fprintf(os.Stderr, "  kubewatch self-update\n\n")
 		fmt.Fprintf(os.Stderr, "Resource Types:\n")
 		fmt.Fprintf(os.Stderr, "  pods, pod, po          - Show pods (default)\n")
 		fmt.Fprintf(os.Stderr, "  deployments, deploy    - Show deployments\n")
 		fmt.Fprintf(os.Stderr, "  statefulsets, sts      - Show statefulsets\n")
+		fmt.Fprintf(os.Stderr, "  jobs                   - Show jobs\n")
+		fmt.Fprintf(os.Stderr, "  cronjobs, cj           - Show cron jobs\n")
 		fmt.Fprintf(os.Stderr, "  services, svc          - Show services\n")
 		fmt.Fprintf(os.Stderr, "  ingresses, ing         - Show ingresses\n")
+		fmt.Fprintf(os.Stderr, "  gateways, gw           - Show Gateway API gateways, if installed\n")
+		fmt.Fprintf(os.Stderr, "  httproutes, httproute  - Show Gateway API HTTP routes, if installed\n")
 		fmt.Fprintf(os.Stderr, "  configmaps, cm         - Show configmaps\n")
-		fmt.Fprintf(os.Stderr, "  secrets                - Show secrets\n\n")
+		fmt.Fprintf(os.Stderr, "  secrets                - Show secrets\n")
+		fmt.Fprintf(os.Stderr, "  serviceaccounts, sa    - Show service accounts\n")
+		fmt.Fprintf(os.Stderr, "  roles, clusterroles    - Show RBAC roles, or cluster-wide ones\n")
+		fmt.Fprintf(os.Stderr, "  rolebindings, rb       - Show RBAC role bindings\n")
+		fmt.Fprintf(os.Stderr, "  clusterrolebindings    - Show RBAC cluster role bindings (crb)\n")
+		fmt.Fprintf(os.Stderr, "  nodes, no              - Show nodes\n\n")
 		fmt.Fprintf(os.Stderr, "Examples:\n")
 		fmt.Fprintf(os.Stderr, "  # Use kubewatch with default kubeconfig\n")
 		fmt.Fprintf(os.Stderr, "  kubewatch\n\n")
@@ -140,38 +142,65 @@ func parseFlags() *CLIFlags {
 		fmt.Fprintf(os.Stderr, "  kubewatch --kubeconfig=/path/to/config\n\n")
 		fmt.Fprintf(os.Stderr, "  # Watch deployments in prod namespace\n")
 		fmt.Fprintf(os.Stderr, "  kubewatch -n prod deployments\n\n")
+		fmt.Fprintf(os.Stderr, "  # Start with CPU and memory usage by namespace\n")
+		fmt.Fprintf(os.Stderr, "  kubewatch top\n\n")
 		fmt.Fprintf(os.Stderr, "  # Watch all namespaces\n")
 		fmt.Fprintf(os.Stderr, "  kubewatch --all-namespaces\n\n")
+		fmt.Fprintf(os.Stderr, "  # Reopen a view copied with y\n")
+		fmt.Fprintf(os.Stderr, "  kubewatch --context prod -n payments pods --filter 'status=CrashLoopBackOff' --sort RESTARTS:desc --select checkout-7f9c\n\n")
+		fmt.Fprintf(os.Stderr, "  # Only the frontend's pods, workloads, jobs and services\n")
+		fmt.Fprintf(os.Stderr, "  kubewatch -l app=frontend\n\n")
+		fmt.Fprintf(os.Stderr, "  # Only the pods scheduled on a node\n")
+		fmt.Fprintf(os.Stderr, "  kubewatch pods --field-selector spec.nodeName=worker-3\n\n")
+		fmt.Fprintf(os.Stderr, "  # Find the checkout pods in every context of the kubeconfig\n")
+		fmt.Fprintf(os.Stderr, "  kubewatch --fleet-search 'payments/checkout-*'\n\n")
+		fmt.Fprintf(os.Stderr, "  # Plain text for screen readers (NO_COLOR=1 only drops colors)\n")
+		fmt.Fprintf(os.Stderr, "  kubewatch --accessible\n\n")
+		fmt.Fprintf(os.Stderr, "  # Serve Prometheus metrics while watching\n")
+		fmt.Fprintf(os.Stderr, "  kubewatch --metrics-listen 127.0.0.1:9123\n\n")
+		fmt.Fprintf(os.Stderr, "  # Enable shell completion for the current bash session\n")
+		fmt.Fprintf(os.Stderr, "  source <(kubewatch completion bash)\n\n")
+		fmt.Fprintf(os.Stderr, "  # Check the config file, e.g. in CI\n")
+		fmt.Fprintf(os.Stderr, "  kubewatch validate-config ~/.config/kubewatch/config.yaml\n\n")
+		fmt.Fprintf(os.Stderr, "  # Replace this binary with the latest release, keeping a backup\n")
+		fmt.Fprintf(os.Stderr, "  kubewatch self-update\n\n")
 		fmt.Fprintf(os.Stderr, "Flags:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nKeyboard Shortcuts:\n")
 		fmt.Fprintf(os.Stderr, "  Tab        - Switch between resource types\n")
 		fmt.Fprintf(os.Stderr, "  j/k        - Navigate up/down\n")
 		fmt.Fprintf(os.Stderr, "  g/G        - Go to top/bottom\n")
+		fmt.Fprintf(os.Stderr, "  d/i        - Describe selected resource\n")
 		fmt.Fprintf(os.Stderr, "  Del/D      - Delete selected resource\n")
 		fmt.Fprintf(os.Stderr, "  l          - View logs (pods only)\n")
 		fmt.Fprintf(os.Stderr, "  n          - Change namespace\n")
 		fmt.Fprintf(os.Stderr, "  c          - Switch contexts (multi-context mode)\n")
+		fmt.Fprintf(os.Stderr, "               (mark two and press = to compare them side by side)\n")
 		fmt.Fprintf(os.Stderr, "  s          - Cycle sort column/direction\n")
 		fmt.Fprintf(os.Stderr, "  /          - Search/filter resources\n")
+		fmt.Fprintf(os.Stderr, "               (Ctrl+G applies the filter to every resource type)\n")
+		fmt.Fprintf(os.Stderr, "  F          - Saved filters\n")
+		fmt.Fprintf(os.Stderr, "  L          - Change the label selector\n")
+		fmt.Fprintf(os.Stderr, "  H          - Scrub table history\n")
+		fmt.Fprintf(os.Stderr, "  y          - Copy view as command\n")
+		fmt.Fprintf(os.Stderr, "  Ctrl+S     - Save view as the startup layout\n")
+		fmt.Fprintf(os.Stderr, "  !          - Quick actions (user-defined commands)\n")
+		fmt.Fprintf(os.Stderr, "  +          - Create from template (x in the picker cleans up)\n")
+		fmt.Fprintf(os.Stderr, "  x          - Related resources (Enter jumps, Backspace returns)\n")
+		fmt.Fprintf(os.Stderr, "  N          - Node of the selected pod (c cordons/uncordons)\n")
+		fmt.Fprintf(os.Stderr, "  P          - Toggle the SECURITY column\n")
+		fmt.Fprintf(os.Stderr, "  U          - Usage by namespace (Enter shows its pods)\n")
+		fmt.Fprintf(os.Stderr, "  E          - Tail the namespace's events (w: warnings only)\n")
+		fmt.Fprintf(os.Stderr, "  Ctrl+A     - What you may do in the namespace (r checks again)\n")
+		fmt.Fprintf(os.Stderr, "  Ctrl+F     - Find pods by name in every context (Enter shows logs)\n")
+		fmt.Fprintf(os.Stderr, "  a          - Show READY/STATUS changes under the list (A jumps to one)\n")
 		fmt.Fprintf(os.Stderr, "  ?          - Show help\n")
-		fmt.Fprintf(os.Stderr, "  q/Ctrl+C   - Quit\n")
+		fmt.Fprintf(os.Stderr, "  q          - Quit (closes other views, like Esc)\n")
+		fmt.Fprintf(os.Stderr, "  Ctrl+C     - Quit from anywhere\n")
 	}
 
-	flag.Parse()
-
-	// Handle multi-value flags
-	flag.Func("as-group", "Group to impersonate for the operation (can be repeated)", func(s string) error {
-		flags.asGroup = append(flags.asGroup, s)
-		return nil
-	})
-
-	// Check for positional argument (resource type)
-	args := flag.Args()
-	if len(args) > 0 {
-		// First positional argument is the resource type
-		flags.resourceType = args[0]
-	}
+	// The command line exits on a parse error, so there is none to handle
+	_ = parseArgs(flag.CommandLine, flags, os.Args[1:])
 
 	return flags
 }
@@ -234,6 +263,36 @@ func parseContexts(flags *CLIFlags) ([]string, error) {
 }
 
 func main() {
+	// Subcommands are dispatched before flag parsing so they can take their own arguments
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "completion":
+			if err := runCompletion(os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		case completeHelperCommand:
+			runCompleteHelper(os.Args[2:], os.Stdout)
+			os.Exit(0)
+		case validateConfigCommand:
+			ok, err := runValidateConfig(os.Args[2:], os.Stdout)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+			if !ok {
+				os.Exit(1)
+			}
+			os.Exit(0)
+		case selfUpdateCommand:
+			if err := runSelfUpdate(os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+	}
+
 	flags := parseFlags()
 
 	// Handle version flag
@@ -248,6 +307,10 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Keep the latest log lines for a crash report
+	logTail := ui.NewLogTail(ui.CrashLogLines)
+	log.SetOutput(io.MultiWriter(os.Stderr, logTail))
+
 	// Create context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -266,8 +329,33 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Apply settings saved from the settings overlay
+	settingsLoader := loadSavedSettings(config, flags)
+	startup := applyStartupLayout(config, flags, settingsLoader)
+	resolveStartNamespace(config, flags, settingsLoader)
+
 	// Initialize application state
 	state := core.NewState(config)
+	if err := applyViewFlags(state, flags); err != nil {
+		log.Fatalf("Invalid view flags: %v", err)
+	}
+
+	// Serve metrics if asked, before any client makes a request
+	var metricsServer *metrics.Server
+	if flags.metricsListen != "" {
+		exporter := metrics.NewExporter(state)
+		metricsServer, err = metrics.Start(flags.metricsListen, flags.metricsAllowExternal, exporter)
+		if err != nil {
+			log.Fatalf("Failed to serve metrics: %v", err)
+		}
+		k8s.SetRequestObserver(exporter.ObserveRequest)
+	}
+
+	// Restrict the network before any client is created, so every client's
+	// dialer is guarded
+	if flags.noExternalNetwork {
+		k8s.RestrictNetwork(true)
+	}
 
 	// Determine if we should use multi-context mode
 	contexts, err := parseContexts(flags)
@@ -275,6 +363,12 @@ func main() {
 		log.Fatalf("Failed to parse contexts: %v", err)
 	}
 
+	// A broken kubeconfig file is left out rather than stopping startup
+	skippedKubeconfigs := k8s.CheckKubeconfig(config.KubeConfig)
+	for _, skipped := range skippedKubeconfigs {
+		log.Printf("Skipping kubeconfig %s", skipped)
+	}
+
 	var multiClient *k8s.MultiContextClient
 	var singleClient *k8s.Client
 	isMultiContext := len(contexts) > 1
@@ -328,12 +422,86 @@ func main() {
 	} else {
 		app = ui.NewApp(ctx, singleClient, state, config)
 	}
-	// Create Bubble Tea program
-	p := tea.NewProgram(app, tea.WithAltScreen())
+	app.SetSkippedKubeconfigs(skippedKubeconfigs)
+	if flags.selected != "" {
+		app.SelectOnStart(flags.selected)
+	}
+	if err := app.SetLogStreamFilter(flags.logGrep); err != nil {
+		log.Fatalf("Invalid --log-grep: %v", err)
+	}
+	if flags.usage {
+		app.ShowUsageOnStart()
+	}
+	if flags.fleetSearch != "" {
+		app.SearchFleetOnStart(flags.fleetSearch)
+	}
+	if startup != nil {
+		app.OpenOnStart(startup)
+	}
+	if settingsLoader != nil {
+		app.SetSettingsSaver(settingsLoader.SaveRuntimeSettings)
+		app.SetSavedFilters(settingsLoader.SavedFilters())
+		app.SetFilterSaver(settingsLoader.SaveFilter)
+		for resourceType, order := range settingsLoader.ColumnOrders() {
+			state.SetColumnOrder(resourceType, order)
+		}
+		app.SetColumnOrderSaver(settingsLoader.SaveColumnOrder)
+		app.SetStartupLayoutSaver(settingsLoader.SaveStartupLayout)
+		if err := app.SetLogRecordGrouping(settingsLoader.LogSettings()); err != nil {
+			log.Printf("Ignoring log settings: %v", err)
+		}
+		app.SetLogColors(settingsLoader.LogColors())
+		app.SetFinalizerRemoval(settingsLoader.AllowFinalizerRemoval())
+		app.SetPodMetricsHeadline(settingsLoader.PodMetricsSettings())
+		app.SetNoiseRules(settingsLoader.NoiseRules())
+		app.SetQuitKeyBehavior(settingsLoader.QuitKeyBehavior())
+		app.SetCacheTTL(settingsLoader.CacheTTL())
+		if options, ok := settingsLoader.Notifications(); ok {
+			// Written to stderr, like the clipboard escape, so it cannot
+			// land in the middle of a frame
+			app.SetNotifications(notify.NewDispatcher(notify.NewTerminal(os.Stderr, options.Method, os.Getenv), options))
+			if !settingsLoader.FocusReporting() {
+				log.Print("Desktop notifications are only sent while the terminal is unfocused, which is not known with focusReporting off")
+			}
+		}
+		core.SetTimeFormat(settingsLoader.TimeFormat())
+		for _, warning := range app.SetUserActions(settingsLoader.UserActions()) {
+			log.Print(warning)
+		}
+		app.SetManifestTemplates(settingsLoader.ManifestTemplates())
+		app.SetDescribeTemplates(settingsLoader.DescribeTemplates())
+		// The release check is opt-in and never runs without external network
+		if enabled, dismissed := settingsLoader.UpdateCheck(); enabled && !flags.noExternalNetwork {
+			client := &http.Client{Timeout: 5 * time.Second}
+			app.SetUpdateCheck(func(ctx context.Context) (string, error) {
+				return update.Check(ctx, client, update.LatestReleaseURL, Version)
+			}, dismissed, settingsLoader.DismissUpdate)
+		}
+	}
+	// Create Bubble Tea program; with focus reporting, kubewatch does less
+	// while its terminal is in the background. A panic is caught by the
+	// crash guard, which gives the terminal back and writes a crash report.
+	options := []tea.ProgramOption{tea.WithAltScreen(), tea.WithoutCatchPanics()}
+	if settingsLoader == nil || settingsLoader.FocusReporting() {
+		options = append(options, tea.WithReportFocus())
+	}
+	guard := ui.NewCrashGuard(app, Version, logTail)
+	p := tea.NewProgram(guard, options...)
+	guard.SetTerminalRestore(p.ReleaseTerminal)
 
 	// Run the application
-	if _, err := p.Run(); err != nil {
-		log.Fatalf("Error running application: %v", err)
+	_, runErr := p.Run()
+
+	if metricsServer != nil {
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 2*time.Second)
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Metrics server: %v", err)
+		}
+		cancelShutdown()
+	}
+
+	if runErr != nil {
+		log.Fatalf("Error running application: %v", runErr)
 	}
 }
 
@@ -350,10 +518,10 @@ func loadConfigWithFlags(flags *CLIFlags) (*core.Config, error) {
 		config.KubeConfig = flags.kubeconfig
 	}
 
-	if flags.namespace != "" {
-		config.CurrentNamespace = flags.namespace
-	} else if flags.allNamespaces {
+	if flags.allNamespaces {
 		config.CurrentNamespace = "" // Empty namespace means all namespaces
+	} else if flags.namespace != "" {
+		config.CurrentNamespace = flags.namespace
 	}
 
 	if flags.context != "" {
@@ -376,28 +544,18 @@ func loadConfigWithFlags(flags *CLIFlags) (*core.Config, error) {
 		config.ColorScheme = flags.colorScheme
 	}
 
+	config.CorrectClockSkew = flags.correctClockSkew
+	config.Accessible = flags.accessible
+
+	fieldSelector, err := core.ParseFieldSelector(flags.fieldSelector)
+	if err != nil {
+		return nil, fmt.Errorf("--field-selector: %w", err)
+	}
+	config.FieldSelector = fieldSelector
+
 	// Set initial resource type if specified
 	if flags.resourceType != "" {
-		// Parse resource type aliases
-		switch strings.ToLower(flags.resourceType) {
-		case "pods", "pod", "po":
-			config.InitialResourceType = "pod"
-		case "deployments", "deployment", "deploy":
-			config.InitialResourceType = "deployment"
-		case "statefulsets", "statefulset", "sts":
-			config.InitialResourceType = "statefulset"
-		case "services", "service", "svc":
-			config.InitialResourceType = "service"
-		case "ingresses", "ingress", "ing":
-			config.InitialResourceType = "ingress"
-		case "configmaps", "configmap", "cm":
-			config.InitialResourceType = "configmap"
-		case "secrets", "secret":
-			config.InitialResourceType = "secret"
-		default:
-			// Default to the provided value
-			config.InitialResourceType = flags.resourceType
-		}
+		config.InitialResourceType = resolveResourceType(flags.resourceType)
 	}
 
 	// Handle cache directory
@@ -412,3 +570,156 @@ func loadConfigWithFlags(flags *CLIFlags) (*core.Config, error) {
 
 	return config, nil
 }
+
+// applyViewFlags sets the filter, label selector, sort and columns given on
+// the command line on state
+func applyViewFlags(state *core.State, flags *CLIFlags) error {
+	if flags.filter != "" {
+		if _, err := core.ParseFilter(flags.filter); err != nil {
+			return fmt.Errorf("--filter: %w", err)
+		}
+		state.SetFilter(flags.filter, "")
+	}
+
+	if err := state.SetLabelSelector(flags.selector); err != nil {
+		return fmt.Errorf("--selector: %w", err)
+	}
+
+	if flags.sort != "" {
+		column, ascending, err := core.ParseSort(flags.sort)
+		if err != nil {
+			return fmt.Errorf("--sort: %w", err)
+		}
+		state.SetSortState(column, ascending)
+	}
+
+	if flags.columns != "" {
+		state.SetColumns(core.ParseColumns(flags.columns))
+	}
+	return nil
+}
+
+// applyStartupLayout fills in the view the command line left out from the
+// startup layout of the config file, as if its parts had been given as
+// flags; each flag given wins over its part. It returns the layout when it
+// still has a log split or comparison to open once the list loads, else
+// nil. A comparison is only opened over the layout's own contexts, and a log
+// split only in its own resource type. loader may be nil.
+func applyStartupLayout(cfg *core.Config, flags *CLIFlags, loader *config.Loader) *config.StartupLayout {
+	if loader == nil || loader.StartupLayout() == nil {
+		return nil
+	}
+	layout := loader.StartupLayout()
+	pending := *layout
+
+	if flags.context == "" && flags.contextFile == "" && len(layout.Contexts) > 0 {
+		flags.context = strings.Join(layout.Contexts, ",")
+		cfg.CurrentContext = flags.context
+	} else {
+		pending.Compare = false
+	}
+
+	if !flags.allNamespaces && flags.namespace == "" && os.Getenv("KUBEWATCH_NAMESPACE") == "" {
+		switch layout.Namespace {
+		case "":
+		case "all":
+			flags.allNamespaces = true
+			cfg.CurrentNamespace = ""
+		default:
+			flags.namespace = layout.Namespace
+			cfg.CurrentNamespace = layout.Namespace
+		}
+	}
+
+	if flags.resourceType != "" {
+		pending.Logs = ""
+	} else if layout.ResourceType != "" {
+		flags.resourceType = layout.ResourceType
+		cfg.InitialResourceType = resolveResourceType(flags.resourceType)
+	}
+
+	if flags.filter == "" {
+		flags.filter = layout.Filter
+	}
+	if flags.selector == "" {
+		flags.selector = layout.Selector
+	}
+	if flags.sort == "" {
+		flags.sort = layout.Sort
+	}
+	if flags.columns == "" {
+		flags.columns = strings.Join(layout.Columns, ",")
+	}
+
+	// The usage overlay or fleet search asked for opens instead
+	if flags.usage || flags.fleetSearch != "" || (!pending.Compare && pending.Logs == "") {
+		return nil
+	}
+	return &pending
+}
+
+// explicitFlags returns the names of the flags that were set on the command line
+func explicitFlags(fs *flag.FlagSet) map[string]bool {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	return set
+}
+
+// resolveStartNamespace picks the namespace to start in when neither -A, -n
+// nor KUBEWATCH_NAMESPACE chose one: the namespace saved in the config file
+// for the starting context, else the one the kubeconfig context sets, as
+// kubectl does, else "default". loader may be nil.
+func resolveStartNamespace(cfg *core.Config, flags *CLIFlags, loader *config.Loader) {
+	if flags.allNamespaces || flags.namespace != "" || os.Getenv("KUBEWATCH_NAMESPACE") != "" {
+		return
+	}
+
+	// The first context given, or the kubeconfig's current one
+	var contextName string
+	if contexts, err := parseContexts(flags); err == nil && len(contexts) > 0 {
+		contextName = contexts[0]
+	}
+	contextName, namespace, err := k8s.GetContextNamespace(cfg.KubeConfig, contextName)
+	if err != nil {
+		log.Printf("Reading the kubeconfig context's namespace: %v", err)
+	}
+
+	switch {
+	case loader != nil && loader.ContextNamespace(contextName) != "":
+		cfg.CurrentNamespace = loader.ContextNamespace(contextName)
+		cfg.NamespaceOrigin = core.NamespaceFromConfig
+	case namespace != "":
+		cfg.CurrentNamespace = namespace
+		cfg.NamespaceOrigin = core.NamespaceFromKubeconfig
+	default:
+		cfg.CurrentNamespace = "default"
+	}
+}
+
+// loadSavedSettings applies runtime settings persisted in the config file to
+// cfg. Settings whose flag was given explicitly keep the flag value. The
+// returned loader is used to save settings again, or nil if the config file
+// could not be loaded.
+func loadSavedSettings(cfg *core.Config, flags *CLIFlags) *config.Loader {
+	loader := config.NewLoader("")
+	if err := loader.Load(); err != nil {
+		log.Printf("Ignoring saved settings: %v", err)
+		return nil
+	}
+
+	skip := func(s *core.Setting) bool {
+		return s.Flag != "" && flags.setFlags[s.Flag]
+	}
+	if err := core.ApplySettingValues(cfg, loader.RuntimeSettings(), skip); err != nil {
+		log.Printf("Ignoring some saved settings: %v", err)
+	}
+
+	// Bad saved filters are dropped rather than stopping startup
+	for _, warning := range loader.Warnings() {
+		log.Printf("Ignoring %s", warning)
+	}
+
+	return loader
+}
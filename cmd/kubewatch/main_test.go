@@ -1,10 +1,19 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/config"
+	"github.com/HamStudy/kubewatch/internal/core"
 )
 
 // parseFlagsFromArgs creates an isolated flag set and parses the given arguments
@@ -15,58 +24,10 @@ func parseFlagsFromArgs(args []string) *CLIFlags {
 	// Create a new flag set for this test
 	fs := flag.NewFlagSet("test", flag.ContinueOnError)
 
-	// Define flags similar to parseFlags() but on the isolated flag set
-	fs.StringVar(&flags.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file to use for CLI requests")
-	fs.StringVar(&flags.context, "context", "", "Kubernetes context(s) to use")
-	fs.StringVar(&flags.namespace, "namespace", "", "If present, the namespace scope for this CLI request")
-	fs.StringVar(&flags.namespace, "n", "", "Shorthand for --namespace")
-	fs.BoolVar(&flags.allNamespaces, "all-namespaces", false, "If present, list the requested object(s) across all namespaces")
-	fs.BoolVar(&flags.allNamespaces, "A", false, "Shorthand for --all-namespaces")
-
-	// Authentication flags
-	fs.StringVar(&flags.user, "user", "", "The name of the kubeconfig user to use")
-	fs.StringVar(&flags.cluster, "cluster", "", "The name of the kubeconfig cluster to use")
-	fs.StringVar(&flags.authInfoName, "auth-info-name", "", "The name of the kubeconfig auth info to use")
-	fs.StringVar(&flags.clientCertificate, "client-certificate", "", "Path to a client certificate file for TLS")
-	fs.StringVar(&flags.clientKey, "client-key", "", "Path to a client key file for TLS")
-	fs.StringVar(&flags.certificateAuthority, "certificate-authority", "", "Path to a cert file for the certificate authority")
-	fs.BoolVar(&flags.insecureSkipVerify, "insecure-skip-tls-verify", false, "If true, the server's certificate will not be checked for validity")
-	fs.StringVar(&flags.token, "token", "", "Bearer token for authentication to the API server")
-	fs.StringVar(&flags.tokenFile, "token-file", "", "Path to a file containing a bearer token for authentication")
-	fs.StringVar(&flags.asUser, "as", "", "Username to impersonate for the operation")
-	fs.StringVar(&flags.asUID, "as-uid", "", "UID to impersonate for the operation")
-
-	// Request flags
-	fs.StringVar(&flags.timeout, "timeout", "0s", "The length of time to wait before giving up on a single server request")
-	fs.StringVar(&flags.requestTimeout, "request-timeout", "0s", "The length of time to wait before giving up on a single server request")
-
-	// UI-specific flags
-	fs.IntVar(&flags.refreshInterval, "refresh-interval", 2, "Refresh interval in seconds for updating resources")
-	fs.IntVar(&flags.logTailLines, "log-tail-lines", 100, "Number of log lines to tail when viewing logs")
-	fs.IntVar(&flags.maxResourcesShown, "max-resources", 500, "Maximum number of resources to display")
-	fs.StringVar(&flags.colorScheme, "color-scheme", "default", "Color scheme to use (default, dark, light)")
-
-	// Context file flag
-	fs.StringVar(&flags.contextFile, "context-file", "", "File containing list of contexts (one per line)")
-
-	// Other flags
-	fs.BoolVar(&flags.version, "version", false, "Print version information and quit")
-	fs.BoolVar(&flags.version, "v", false, "Shorthand for --version")
-	fs.BoolVar(&flags.help, "help", false, "Show help message")
-	fs.BoolVar(&flags.help, "h", false, "Shorthand for --help")
-	fs.BoolVar(&flags.verbose, "verbose", false, "Enable verbose output")
-	fs.StringVar(&flags.logLevel, "log-level", "info", "Log level (debug, info, warn, error)")
-	fs.StringVar(&flags.cacheDir, "cache-dir", "", "Default cache directory")
+	registerFlags(fs, flags)
 
 	// Parse the arguments
-	fs.Parse(args)
-
-	// Check for positional argument (resource type)
-	args = fs.Args()
-	if len(args) > 0 {
-		// First positional argument is the resource type
-		flags.resourceType = args[0]
-	}
+	parseArgs(fs, flags, args)
 
 	return flags
 }
@@ -328,17 +289,30 @@ func TestResourceTypeAliases(t *testing.T) {
 		{"statefulsets", "statefulset"},
 		{"statefulset", "statefulset"},
 		{"sts", "statefulset"},
+		{"jobs", "job"},
+		{"cronjobs", "cronjob"},
+		{"cj", "cronjob"},
 		{"services", "service"},
 		{"service", "service"},
 		{"svc", "service"},
 		{"ingresses", "ingress"},
 		{"ingress", "ingress"},
 		{"ing", "ingress"},
+		{"gateways", "gateway"},
+		{"gw", "gateway"},
+		{"httproutes", "httproute"},
+		{"httproute", "httproute"},
 		{"configmaps", "configmap"},
 		{"configmap", "configmap"},
 		{"cm", "configmap"},
 		{"secrets", "secret"},
 		{"secret", "secret"},
+		{"sa", "serviceaccount"},
+		{"clusterroles", "clusterrole"},
+		{"rb", "rolebinding"},
+		{"crb", "clusterrolebinding"},
+		{"nodes", "node"},
+		{"no", "node"},
 		{"unknown", "unknown"}, // Should pass through unchanged
 	}
 
@@ -489,3 +463,367 @@ func TestFlagParsingEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadSavedSettings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	configDir := filepath.Join(home, ".config", "kubewatch")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	saved := "settings:\n  runtime:\n    refreshInterval: \"9\"\n    logTailLines: \"400\"\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(saved), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	flags := parseFlagsFromArgs([]string{"--refresh-interval=3"})
+	cfg, err := loadConfigWithFlags(flags)
+	if err != nil {
+		t.Fatalf("loadConfigWithFlags failed: %v", err)
+	}
+
+	if loader := loadSavedSettings(cfg, flags); loader == nil {
+		t.Fatal("Expected a loader for saving settings")
+	}
+
+	// The explicit flag wins over the saved value
+	if cfg.RefreshInterval != 3 {
+		t.Errorf("Expected refresh interval 3 from flag, got %d", cfg.RefreshInterval)
+	}
+	if cfg.LogTailLines != 400 {
+		t.Errorf("Expected saved log tail lines 400, got %d", cfg.LogTailLines)
+	}
+}
+
+// TestResolveStartNamespace tests the order the starting namespace is taken
+// in: -A, -n, the namespace saved for the context, the kubeconfig context's
+// namespace, then "default"
+func TestResolveStartNamespace(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("KUBEWATCH_NAMESPACE", "")
+
+	kubeconfig := filepath.Join(home, "kubeconfig")
+	err := os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+current-context: payments
+clusters:
+- name: prod
+  cluster: {server: "https://prod.example.com"}
+users:
+- name: me
+  user: {token: secret}
+contexts:
+- name: payments
+  context: {cluster: prod, user: me, namespace: payments}
+- name: checkout
+  context: {cluster: prod, user: me, namespace: checkout}
+- name: bare
+  context: {cluster: prod, user: me}
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	configDir := filepath.Join(home, ".config", "kubewatch")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	saved := "settings:\n  namespaces:\n    checkout: orders\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(saved), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		args          []string
+		wantNamespace string
+		wantOrigin    string
+	}{
+		{"all namespaces wins", []string{"-A", "-n", "ops", "--context", "checkout"}, "", ""},
+		{"namespace flag wins over saved", []string{"-n", "ops", "--context", "checkout"}, "ops", ""},
+		{"saved wins over kubeconfig", []string{"--context", "checkout"}, "orders", core.NamespaceFromConfig},
+		{"current context's namespace", nil, "payments", core.NamespaceFromKubeconfig},
+		{"first of several contexts", []string{"--context", "payments,bare"}, "payments", core.NamespaceFromKubeconfig},
+		{"context without a namespace", []string{"--context", "bare"}, "default", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := parseFlagsFromArgs(append([]string{"--kubeconfig", kubeconfig}, tt.args...))
+			cfg, err := loadConfigWithFlags(flags)
+			if err != nil {
+				t.Fatalf("loadConfigWithFlags failed: %v", err)
+			}
+			resolveStartNamespace(cfg, flags, loadSavedSettings(cfg, flags))
+
+			if cfg.CurrentNamespace != tt.wantNamespace || cfg.NamespaceOrigin != tt.wantOrigin {
+				t.Errorf("Got namespace %q from %q, want %q from %q", cfg.CurrentNamespace, cfg.NamespaceOrigin, tt.wantNamespace, tt.wantOrigin)
+			}
+			if origin := core.NewState(cfg).NamespaceOrigin(); origin != tt.wantOrigin {
+				t.Errorf("Expected the state to show origin %q, got %q", tt.wantOrigin, origin)
+			}
+		})
+	}
+
+	// KUBEWATCH_NAMESPACE counts as choosing one
+	t.Setenv("KUBEWATCH_NAMESPACE", "tools")
+	flags := parseFlagsFromArgs([]string{"--kubeconfig", kubeconfig})
+	cfg, err := loadConfigWithFlags(flags)
+	if err != nil {
+		t.Fatalf("loadConfigWithFlags failed: %v", err)
+	}
+	resolveStartNamespace(cfg, flags, nil)
+	if cfg.CurrentNamespace != "tools" || cfg.NamespaceOrigin != "" {
+		t.Errorf("Expected KUBEWATCH_NAMESPACE kept, got %q from %q", cfg.CurrentNamespace, cfg.NamespaceOrigin)
+	}
+}
+
+// stateFromArgs builds the starting state the way main does
+func stateFromArgs(t *testing.T, args []string) (*core.State, *CLIFlags) {
+	t.Helper()
+	flags := parseFlagsFromArgs(args)
+	cfg, err := loadConfigWithFlags(flags)
+	if err != nil {
+		t.Fatalf("loadConfigWithFlags failed: %v", err)
+	}
+	state := core.NewState(cfg)
+	if err := applyViewFlags(state, flags); err != nil {
+		t.Fatalf("applyViewFlags failed: %v", err)
+	}
+	contexts, err := parseContexts(flags)
+	if err != nil {
+		t.Fatalf("parseContexts failed: %v", err)
+	}
+	if len(contexts) > 1 {
+		state.SetMultiContextMode(true)
+		state.SetCurrentContexts(contexts)
+	}
+	return state, flags
+}
+
+func TestViewLinkRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		name  string
+		setup func(*core.State)
+	}{
+		{
+			name: "filtered and sorted pods",
+			setup: func(s *core.State) {
+				s.CurrentContext = "prod"
+				s.SetNamespace("payments")
+				s.SetFilter("status=CrashLoopBackOff", "")
+				_ = s.SetLabelSelector("app=checkout")
+				s.SetSortState("RESTARTS", false)
+			},
+		},
+		{
+			name: "deployments across contexts and namespaces",
+			setup: func(s *core.State) {
+				s.SetMultiContextMode(true)
+				s.SetCurrentContexts([]string{"east", "west"})
+				s.SetNamespace("")
+				s.SetResourceType(core.ResourceTypeDeployment)
+				s.SetFilter("web status!=Running", "")
+				s.SetColumns([]string{"READY", "IMAGES"})
+			},
+		},
+		{
+			name: "defaults",
+			setup: func(s *core.State) {
+				s.SetNamespace("default")
+				s.SetResourceType(core.ResourceTypeSecret)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := core.NewState(&core.Config{})
+			tt.setup(original)
+			want := original.ViewLink()
+			want.Selected = "checkout-7f9c"
+
+			state, flags := stateFromArgs(t, want.Args())
+			got := state.ViewLink()
+			got.Selected = flags.selected
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Round trip of %v:\nwant %+v\ngot  %+v", want.Args(), want, got)
+			}
+		})
+	}
+}
+
+func TestStartupLayoutRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("KUBEWATCH_NAMESPACE", "")
+
+	original := core.NewState(&core.Config{})
+	original.SetMultiContextMode(true)
+	original.SetCurrentContexts([]string{"east", "west"})
+	original.SetNamespace("")
+	original.SetResourceType(core.ResourceTypeDeployment)
+	original.SetFilter("web status!=Running", "")
+	_ = original.SetLabelSelector("tier=web")
+	original.SetSortState("AGE", false)
+	original.SetColumns([]string{"READY", "IMAGES"})
+	want := original.ViewLink()
+
+	loader := config.NewLoader("")
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	layout := config.NewStartupLayout(want)
+	layout.Logs = "web-*"
+	if err := loader.SaveStartupLayout(layout); err != nil {
+		t.Fatalf("SaveStartupLayout failed: %v", err)
+	}
+
+	// startFromArgs builds the starting state the way main does, with the
+	// startup layout saved above
+	startFromArgs := func(args []string) (*core.State, *config.StartupLayout) {
+		flags := parseFlagsFromArgs(args)
+		cfg, err := loadConfigWithFlags(flags)
+		if err != nil {
+			t.Fatalf("loadConfigWithFlags failed: %v", err)
+		}
+		pending := applyStartupLayout(cfg, flags, loadSavedSettings(cfg, flags))
+		state := core.NewState(cfg)
+		if err := applyViewFlags(state, flags); err != nil {
+			t.Fatalf("applyViewFlags failed: %v", err)
+		}
+		if contexts, _ := parseContexts(flags); len(contexts) > 1 {
+			state.SetMultiContextMode(true)
+			state.SetCurrentContexts(contexts)
+		}
+		return state, pending
+	}
+
+	state, pending := startFromArgs(nil)
+	if got := state.ViewLink(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Round trip of the startup layout:\nwant %+v\ngot  %+v", want, got)
+	}
+	if pending == nil || pending.Logs != "web-*" {
+		t.Errorf("Expected the log split left to open once the list loads, got %+v", pending)
+	}
+
+	// Flags win over their part of the layout; the log split belongs to the
+	// layout's resource type
+	state, pending = startFromArgs([]string{"-n", "ops", "pods", "--filter", "api"})
+	got := state.ViewLink()
+	if got.Namespace != "ops" || got.ResourceType != core.ResourceTypePod || got.Filter != "api" {
+		t.Errorf("Expected the flags to win, got %+v", got)
+	}
+	if !reflect.DeepEqual(got.Contexts, want.Contexts) || got.SortColumn != "AGE" || got.SortAscending {
+		t.Errorf("Expected the rest of the layout kept, got %+v", got)
+	}
+	if pending != nil {
+		t.Errorf("Expected no log split for another resource type, got %+v", pending)
+	}
+}
+
+func TestFlagsAfterResourceType(t *testing.T) {
+	flags := parseFlagsFromArgs([]string{"--context", "prod", "pods", "--sort", "RESTARTS:desc", "services"})
+	if flags.resourceType != "pods" || flags.sort != "RESTARTS:desc" || flags.context != "prod" {
+		t.Errorf("Expected flags on both sides of the resource type, got %+v", flags)
+	}
+	if !flags.setFlags["sort"] || !flags.setFlags["context"] {
+		t.Errorf("Expected both flags recorded as set, got %v", flags.setFlags)
+	}
+}
+
+func TestApplyViewFlagsRejectsBadValues(t *testing.T) {
+	for _, args := range [][]string{
+		{"--sort", "AGE:sideways"},
+		{"--filter", "=Running"},
+		{"-l", "app in (web"},
+	} {
+		flags := parseFlagsFromArgs(args)
+		if err := applyViewFlags(core.NewState(&core.Config{}), flags); err == nil {
+			t.Errorf("Expected %v to be rejected", args)
+		}
+	}
+}
+
+func TestFieldSelectorFlag(t *testing.T) {
+	state, _ := stateFromArgs(t, []string{"pods", "--field-selector", "spec.nodeName=worker-3"})
+	if got := state.PodFieldSelector(); got != "spec.nodeName=worker-3" {
+		t.Errorf("Expected the pods narrowed to worker-3, got %q", got)
+	}
+
+	if _, err := loadConfigWithFlags(parseFlagsFromArgs([]string{"--field-selector", "spec.nodeName"})); err == nil {
+		t.Error("Expected a field selector without an operator rejected")
+	}
+}
+
+func TestUsagePositional(t *testing.T) {
+	flags := parseFlagsFromArgs([]string{"--context", "prod", "top"})
+	if !flags.usage || flags.resourceType != "" {
+		t.Errorf("Expected top to open the usage overlay, got usage=%v resource type %q", flags.usage, flags.resourceType)
+	}
+	if flags := parseFlagsFromArgs([]string{"pods"}); flags.usage {
+		t.Error("Expected a resource type not to open the usage overlay")
+	}
+}
+
+func TestRunValidateConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+	}
+
+	write("templates:\n  pod_describe:\n    template: \"{{ .Name }}\"\n")
+	var out strings.Builder
+	ok, err := runValidateConfig([]string{path}, &out)
+	if err != nil || !ok {
+		t.Fatalf("Expected a valid config, got %v:\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "templates") || !strings.Contains(out.String(), "No problems found") {
+		t.Errorf("Expected a summary, got:\n%s", out.String())
+	}
+
+	write("templates:\n  pod_describe:\n    template: \"{{ .Spec.Nodename }}\"\ntheme: neon\n")
+	out.Reset()
+	ok, err = runValidateConfig([]string{path}, &out)
+	if err != nil || ok {
+		t.Fatalf("Expected problems reported, got ok=%v err=%v", ok, err)
+	}
+	if !strings.Contains(out.String(), `✗ template "pod_describe" at line 1`) || !strings.Contains(out.String(), "2 problems found") {
+		t.Errorf("Expected both problems listed, got:\n%s", out.String())
+	}
+
+	if _, err := runValidateConfig([]string{path, "extra"}, &out); err == nil {
+		t.Error("Expected a usage error for two paths")
+	}
+}
+
+func TestSelfUpdateUpToDate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"tag_name": "v0.9.3"}`)
+	}))
+	defer server.Close()
+
+	var out strings.Builder
+	if err := selfUpdate(context.Background(), server.Client(), server.URL, "0.9.3", "/nonexistent", &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "kubewatch 0.9.3 is up to date") {
+		t.Errorf("Expected up to date, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := selfUpdate(context.Background(), server.Client(), server.URL, "dev", "/nonexistent", &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "development build") {
+		t.Errorf("Expected a dev build left alone, got %q", out.String())
+	}
+
+	// A newer release is installed, which fails here for lack of an archive
+	if err := selfUpdate(context.Background(), server.Client(), server.URL, "0.9.2", "/nonexistent", &out); err == nil || !strings.Contains(err.Error(), "has no kubewatch_") {
+		t.Errorf("Expected the missing archive reported, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/update"
+)
+
+// selfUpdateCommand is the subcommand that installs the latest release
+const selfUpdateCommand = "self-update"
+
+// selfUpdateTimeout bounds the whole self-update, download included
+const selfUpdateTimeout = 5 * time.Minute
+
+// runSelfUpdate replaces the running binary with the latest release, when
+// that is newer, keeping the old binary beside it
+func runSelfUpdate(args []string, w io.Writer) error {
+	if len(args) > 0 {
+		return fmt.Errorf("usage: kubewatch %s", selfUpdateCommand)
+	}
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot find the kubewatch binary: %w", err)
+	}
+	// Replace the binary itself, not a symlink to it
+	if resolved, err := filepath.EvalSymlinks(exePath); err == nil {
+		exePath = resolved
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), selfUpdateTimeout)
+	defer cancel()
+	return selfUpdate(ctx, &http.Client{}, update.LatestReleaseURL, Version, exePath, w)
+}
+
+// selfUpdate installs the latest release found at url over exePath when it
+// is newer than current
+func selfUpdate(ctx context.Context, client *http.Client, url, current, exePath string, w io.Writer) error {
+	release, err := update.Latest(ctx, client, url, "kubewatch/"+current)
+	if err != nil {
+		return fmt.Errorf("checking for a newer release: %w", err)
+	}
+	if !update.Newer(release.Version(), current) {
+		if current == "dev" {
+			fmt.Fprintf(w, "This is a development build; the latest release is v%s (%s)\n", release.Version(), update.ReleasesPage)
+			return nil
+		}
+		fmt.Fprintf(w, "kubewatch %s is up to date\n", current)
+		return nil
+	}
+
+	fmt.Fprintf(w, "Updating kubewatch %s to v%s...\n", current, release.Version())
+	backup, err := update.Install(ctx, client, release, runtime.GOOS, runtime.GOARCH, exePath)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "✓ Installed v%s at %s\n", release.Version(), exePath)
+	fmt.Fprintf(w, "  The previous binary is at %s\n", backup)
+	return nil
+}
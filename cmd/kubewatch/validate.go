@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/HamStudy/kubewatch/internal/config"
+)
+
+// validateConfigCommand is the subcommand that checks the config file
+const validateConfigCommand = "validate-config"
+
+// runValidateConfig checks the config file given, or the user's config
+// file, and writes what it checked and every problem found. It returns false
+// when there are problems, so CI can fail on a bad config.
+func runValidateConfig(args []string, w io.Writer) (bool, error) {
+	if len(args) > 1 {
+		return false, fmt.Errorf("usage: kubewatch %s [path]", validateConfigCommand)
+	}
+	path := config.NewLoader("").ConfigPath()
+	if len(args) == 1 {
+		path = expandHome(args[0])
+	}
+
+	report, err := config.ValidateFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Fprintf(w, "Validating %s\n", report.Path)
+	for _, section := range report.Sections {
+		fmt.Fprintf(w, "  %-20s %d\n", section.Name, section.Count)
+	}
+	for _, problem := range report.Problems {
+		fmt.Fprintf(w, "✗ %s\n", problem)
+	}
+	switch n := len(report.Problems); n {
+	case 0:
+		fmt.Fprintln(w, "✓ No problems found")
+	case 1:
+		fmt.Fprintln(w, "1 problem found")
+	default:
+		fmt.Fprintf(w, "%d problems found\n", n)
+	}
+	return report.OK(), nil
+}
@@ -282,6 +282,8 @@ func (m *Manager) getStatusColor(status string) lipgloss.Color {
 	status = strings.ToLower(status)
 
 	switch {
+	case strings.HasPrefix(status, "stuck"):
+		return m.theme.Colors.Status.Failed
 	case strings.Contains(status, "running"):
 		return m.theme.Colors.Status.Running
 	case strings.Contains(status, "pending"), strings.Contains(status, "creating"):
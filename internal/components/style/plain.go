@@ -0,0 +1,50 @@
+package style
+
+import "strings"
+
+// PlainText replaces the box-drawing and block characters of rendered
+// output with ASCII, for screen readers that read them out one by one.
+// Each character is replaced by exactly one, so layouts keep their width.
+func PlainText(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	return strings.Map(plainRune, s)
+}
+
+// plainRune returns the ASCII stand-in for a box-drawing or block
+// character, and any other rune unchanged
+func plainRune(r rune) rune {
+	switch r {
+	case '─', '━', '┄', '┅', '┈', '┉', '╌', '╍', '═', '╴', '╶', '╸', '╺', '╼', '╾':
+		return '-'
+	case '│', '┃', '┆', '┇', '┊', '┋', '╎', '╏', '║', '╵', '╷', '╹', '╻', '╽', '╿',
+		'▏', '▕', '▌', '▐':
+		return '|'
+	case '╱':
+		return '/'
+	case '╲':
+		return '\\'
+	case '╳':
+		return 'X'
+	case '█', '▓':
+		return '#'
+	case '▒', '░':
+		return '.'
+	}
+	// Corners, tees and crosses
+	if r >= 0x2500 && r <= 0x257f {
+		return '+'
+	}
+	return r
+}
+
+// isASCII reports whether s has nothing to replace
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,22 @@
+package style
+
+import "testing"
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"╭────╮\n│ ok │\n╰────╯", "+----+\n| ok |\n+----+"},
+		{"┌─┬─┐ ╔═╗", "+-+-+ +-+"},
+		{"CPU [███░░]  60%", "CPU [###..]  60%"},
+		{"NAME  STATUS", "NAME  STATUS"},
+		// Text symbols are left alone, screen readers name them
+		{"✓ Saved ↑ …", "✓ Saved ↑ …"},
+	}
+	for _, tt := range tests {
+		if got := PlainText(tt.in); got != tt.want {
+			t.Errorf("PlainText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -1,12 +1,21 @@
 package table
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/muesli/reflow/truncate"
 )
 
+// fitPadding is the space a FitContent column leaves after its widest value
+const fitPadding = 2
+
+// selectedMarker is drawn before the selected row when SetSelectionMarker is
+// on, and as many spaces before the others
+const selectedMarker = "> "
+
 // Column represents a table column configuration
 type Column struct {
 	Title      string
@@ -16,8 +25,25 @@ type Column struct {
 	Flex       bool // If true, column can expand to fill available space
 	Align      lipgloss.Position
 	TruncateAt string // Where to truncate: "end", "middle", "start"
+
+	// FitContent sizes the column to its widest value or title, plus
+	// padding, within MinWidth and MaxWidth. Values wider than MaxWidth are
+	// truncated.
+	FitContent bool
+
+	// Hidden columns keep their values but are not rendered
+	Hidden bool
 }
 
+// CellStyler renders one cell at exactly width cells. values are the cells
+// of its row; value is already truncated to fit; selected reports whether
+// the cell is in the selected row.
+type CellStyler func(values []string, column Column, value string, width int, selected bool) string
+
+// RowMarker returns the marker drawn before a row, e.g. to flag rows that
+// need attention. Every marker should be the same width.
+type RowMarker func(values []string) string
+
 // Row represents a single row of data
 type Row struct {
 	ID     string   // Unique identifier for the row
@@ -40,11 +66,23 @@ type Model struct {
 	rowStyle          lipgloss.Style
 	alternateRowStyle lipgloss.Style
 
+	// Rendering hooks and options
+	cellStyler       CellStyler
+	rowMarker        RowMarker
+	markerWidth      int
+	selectionMarker  bool  // Mark the selected row with "> "
+	headerRule       bool  // Draw a rule under the header
+	scrollIndicator  bool  // Show which rows are visible when not all fit
+	horizontalOffset int   // Display cells scrolled off the left edge
+	columnOrder      []int // Indexes of the columns in display order; nil for as given
+
 	// State
 	selectedIndex int
 	viewportStart int
 	viewportSize  int
 	columnWidths  []int // Calculated column widths
+	widthsDirty   bool  // Columns or rows changed since widths were calculated
+	valueRows     []Row // Rows built by SetValues, reused by its next call
 
 	// Behavior
 	selectable bool
@@ -68,12 +106,200 @@ func New(columns []Column) *Model {
 		rowStyle:          lipgloss.NewStyle(),
 		alternateRowStyle: lipgloss.NewStyle(),
 		borderStyle:       lipgloss.NewStyle(),
+		widthsDirty:       true,
+	}
+}
+
+// SetColumns replaces the column configuration
+func (m *Model) SetColumns(columns []Column) {
+	m.columns = columns
+	m.widthsDirty = true
+}
+
+// Columns returns the column configuration
+func (m *Model) Columns() []Column {
+	return m.columns
+}
+
+// SetColumnOrder sets the order columns are shown in, as indexes into the
+// columns; values stay in the columns' order. An order that does not name
+// every column once is ignored, as is nil.
+func (m *Model) SetColumnOrder(order []int) {
+	m.columnOrder = order
+}
+
+// ColumnOrder returns the indexes of the columns in the order shown
+func (m *Model) ColumnOrder() []int {
+	if m.validOrder() {
+		return m.columnOrder
+	}
+	order := make([]int, len(m.columns))
+	for i := range order {
+		order[i] = i
+	}
+	return order
+}
+
+// validOrder reports whether the column order names every column once
+func (m *Model) validOrder() bool {
+	if len(m.columnOrder) != len(m.columns) {
+		return false
+	}
+	seen := make([]bool, len(m.columns))
+	for _, i := range m.columnOrder {
+		if i < 0 || i >= len(seen) || seen[i] {
+			return false
+		}
+		seen[i] = true
+	}
+	return true
+}
+
+// Titles returns the column titles, hidden columns included
+func (m *Model) Titles() []string {
+	titles := make([]string, len(m.columns))
+	for i, col := range m.columns {
+		titles[i] = col.Title
+	}
+	return titles
+}
+
+// SetValues replaces the rows with rows holding values
+func (m *Model) SetValues(values [][]string) {
+	// Large lists are replaced on every refresh, so the rows are rebuilt in
+	// place rather than reallocated
+	rows := m.valueRows
+	if cap(rows) < len(values) {
+		rows = make([]Row, len(values))
+	} else {
+		clear(rows[len(values):cap(rows)])
+		rows = rows[:len(values)]
+	}
+	for i, v := range values {
+		rows[i] = Row{Values: v}
+	}
+	m.valueRows = rows
+	m.SetRows(rows)
+}
+
+// Values returns the values of every row. The outer slice is new; the rows
+// are shared with the table.
+func (m *Model) Values() [][]string {
+	values := make([][]string, len(m.rows))
+	for i, row := range m.rows {
+		values[i] = row.Values
+	}
+	return values
+}
+
+// RowValues returns the values of the row at index, or nil when there is none
+func (m *Model) RowValues(index int) []string {
+	if index < 0 || index >= len(m.rows) {
+		return nil
+	}
+	return m.rows[index].Values
+}
+
+// SetCell changes one value in place. It reports false when the row or
+// column does not exist.
+func (m *Model) SetCell(row, column int, value string) bool {
+	if row < 0 || row >= len(m.rows) || column < 0 || column >= len(m.rows[row].Values) {
+		return false
+	}
+	m.rows[row].Values[column] = value
+	m.widthsDirty = true
+	return true
+}
+
+// SetCellStyler sets the hook that renders cells, e.g. to color a status
+// column by value. With no styler, cells are aligned plain text and the
+// selected row takes the selected style while focused.
+func (m *Model) SetCellStyler(styler CellStyler) {
+	m.cellStyler = styler
+}
+
+// SetRowMarker sets the hook that draws a marker of width cells before each
+// row; the header is indented to match. A nil marker draws none.
+func (m *Model) SetRowMarker(width int, marker RowMarker) {
+	m.rowMarker = marker
+	m.markerWidth = width
+	if marker == nil {
+		m.markerWidth = 0
+	}
+}
+
+// SetSelectionMarker marks the selected row with "> " before it, for when
+// the selected style's colors do not show
+func (m *Model) SetSelectionMarker(show bool) {
+	m.selectionMarker = show
+}
+
+// prefixWidth returns the width of what is drawn before each row's cells
+func (m *Model) prefixWidth() int {
+	if m.selectionMarker {
+		return m.markerWidth + len(selectedMarker)
 	}
+	return m.markerWidth
+}
+
+// SetCompact drops the rule under the header, leaving one more line for rows
+func (m *Model) SetCompact(compact bool) {
+	m.headerRule = !compact
+	m.calculateViewportSize()
+	m.updateViewport()
+}
+
+// SetHeaderRule draws a rule under the header
+func (m *Model) SetHeaderRule(show bool) {
+	m.headerRule = show
+	m.calculateViewportSize()
+	m.updateViewport()
+}
+
+// SetScrollIndicator shows a line such as "[11-20 of 45]" under the rows
+// when they do not all fit. The line is reserved whether or not it is shown.
+func (m *Model) SetScrollIndicator(show bool) {
+	m.scrollIndicator = show
+	m.calculateViewportSize()
+	m.updateViewport()
+}
+
+// SetHorizontalOffset scrolls the table right by offset display cells. The
+// offset is clamped so the right edge of the widest row stays in view.
+func (m *Model) SetHorizontalOffset(offset int) {
+	m.horizontalOffset = offset
+	m.clampHorizontalOffset()
+}
+
+// HorizontalOffset returns the clamped horizontal scroll offset
+func (m *Model) HorizontalOffset() int {
+	m.clampHorizontalOffset()
+	return m.horizontalOffset
+}
+
+// SetViewportStart scrolls so that the row at start is the first shown,
+// within the rows available
+func (m *Model) SetViewportStart(start int) {
+	m.viewportStart = start
+	m.updateViewport()
+}
+
+// GetViewportStart returns the index of the first row shown
+func (m *Model) GetViewportStart() int {
+	return m.viewportStart
+}
+
+// ColumnWidths returns the rendered width of each column; hidden columns
+// have width 0
+func (m *Model) ColumnWidths() []int {
+	m.ensureColumnWidths()
+	return m.columnWidths
 }
 
 // SetRows sets the table rows
 func (m *Model) SetRows(rows []Row) {
 	m.rows = rows
+	m.widthsDirty = true
 	if m.selectedIndex >= len(rows) && len(rows) > 0 {
 		m.selectedIndex = len(rows) - 1
 	}
@@ -83,21 +309,26 @@ func (m *Model) SetRows(rows []Row) {
 // AddRow adds a single row to the table
 func (m *Model) AddRow(row Row) {
 	m.rows = append(m.rows, row)
+	m.widthsDirty = true
 	m.updateViewport()
 }
 
 // ClearRows removes all rows from the table
 func (m *Model) ClearRows() {
 	m.rows = []Row{}
+	m.widthsDirty = true
 	m.selectedIndex = 0
 	m.viewportStart = 0
 }
 
 // SetSize sets the table dimensions
 func (m *Model) SetSize(width, height int) {
+	if width != m.width {
+		m.widthsDirty = true
+	}
 	m.width = width
 	m.height = height
-	m.calculateColumnWidths()
+	m.ensureColumnWidths()
 	m.calculateViewportSize()
 	m.updateViewport()
 }
@@ -105,6 +336,7 @@ func (m *Model) SetSize(width, height int) {
 // SetWordWrap enables or disables word wrapping
 func (m *Model) SetWordWrap(enabled bool) {
 	m.wordWrap = enabled
+	m.widthsDirty = true
 }
 
 // SetShowHeader shows or hides the header row
@@ -251,7 +483,7 @@ func (m *Model) View() string {
 		return ""
 	}
 
-	m.calculateColumnWidths()
+	m.ensureColumnWidths()
 
 	var lines []string
 
@@ -259,6 +491,10 @@ func (m *Model) View() string {
 	if m.showHeader {
 		header := m.renderHeader()
 		lines = append(lines, header)
+		if m.headerRule {
+			rule := strings.Repeat("─", lipgloss.Width(header))
+			lines = append(lines, m.borderStyle.Render(rule))
+		}
 	}
 
 	// Render visible rows
@@ -270,6 +506,22 @@ func (m *Model) View() string {
 		lines = append(lines, rowStr)
 	}
 
+	// Scroll the table, but not the indicator under it, horizontally
+	m.clampHorizontalOffset()
+	if m.horizontalOffset > 0 {
+		for i := range lines {
+			lines[i] = ansi.TruncateLeft(lines[i], m.horizontalOffset, "")
+		}
+	}
+
+	if m.scrollIndicator {
+		end := m.viewportStart + len(visibleRows)
+		if m.viewportStart > 0 || end < len(m.rows) {
+			info := fmt.Sprintf(" [%d-%d of %d]", m.viewportStart+1, end, len(m.rows))
+			lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(info))
+		}
+	}
+
 	// Fill remaining space with empty lines if needed
 	for len(lines) < m.height {
 		lines = append(lines, strings.Repeat(" ", m.width))
@@ -285,18 +537,36 @@ func (m *Model) View() string {
 
 // Private methods
 
+// ensureColumnWidths recalculates the column widths when columns, rows or
+// the size changed since they were last calculated
+func (m *Model) ensureColumnWidths() {
+	if m.widthsDirty || len(m.columnWidths) != len(m.columns) {
+		m.calculateColumnWidths()
+	}
+}
+
 func (m *Model) calculateColumnWidths() {
 	if len(m.columns) == 0 || m.width == 0 {
 		return
 	}
+	m.widthsDirty = false
 
 	m.columnWidths = make([]int, len(m.columns))
 	totalFixed := 0
 	flexCount := 0
+	visibleCount := 0
 
 	// Calculate fixed widths and count flex columns
 	for i, col := range m.columns {
-		if col.Width > 0 {
+		if col.Hidden {
+			continue
+		}
+		visibleCount++
+		if col.FitContent {
+			width := m.fitWidth(i, col)
+			m.columnWidths[i] = width
+			totalFixed += width
+		} else if col.Width > 0 {
 			// Fixed width column
 			width := col.Width
 			if col.MaxWidth > 0 && width > col.MaxWidth {
@@ -323,7 +593,7 @@ func (m *Model) calculateColumnWidths() {
 	// Distribute remaining space among flex columns
 	if flexCount > 0 {
 		// Account for column separators (1 space between columns)
-		separatorWidth := len(m.columns) - 1
+		separatorWidth := visibleCount - 1
 		if separatorWidth < 0 {
 			separatorWidth = 0
 		}
@@ -415,10 +685,52 @@ func (m *Model) calculateColumnWidths() {
 	}
 }
 
+// fitWidth returns the width of a FitContent column: its widest value or
+// title plus padding, within the column's limits
+func (m *Model) fitWidth(index int, col Column) int {
+	widest := 0
+	for _, row := range m.rows {
+		if index < len(row.Values) {
+			widest = max(widest, ansi.StringWidth(row.Values[index]))
+		}
+	}
+	width := widest + fitPadding
+	if col.MaxWidth > 0 {
+		width = min(width, col.MaxWidth)
+	}
+	return max(width, ansi.StringWidth(col.Title)+fitPadding, col.MinWidth)
+}
+
+// clampHorizontalOffset keeps the horizontal offset between 0 and the
+// width of the table beyond the view
+func (m *Model) clampHorizontalOffset() {
+	m.ensureColumnWidths()
+	total := m.prefixWidth()
+	visible := 0
+	for i, col := range m.columns {
+		if col.Hidden || i >= len(m.columnWidths) {
+			continue
+		}
+		total += m.columnWidths[i]
+		visible++
+	}
+	if visible > 1 {
+		total += visible - 1 // Separators
+	}
+	m.horizontalOffset = min(m.horizontalOffset, total-m.width)
+	m.horizontalOffset = max(m.horizontalOffset, 0)
+}
+
 func (m *Model) calculateViewportSize() {
 	m.viewportSize = m.height
 	if m.showHeader {
 		m.viewportSize--
+		if m.headerRule {
+			m.viewportSize--
+		}
+	}
+	if m.scrollIndicator {
+		m.viewportSize--
 	}
 	if m.viewportSize < 0 {
 		m.viewportSize = 0
@@ -478,10 +790,11 @@ func (m *Model) renderHeader() string {
 		return ""
 	}
 
-	cells := make([]string, len(m.columns))
-	for i, col := range m.columns {
+	var cells []string
+	for _, i := range m.ColumnOrder() {
+		col := m.columns[i]
 		width := m.columnWidths[i]
-		if width <= 0 {
+		if col.Hidden || width <= 0 {
 			continue
 		}
 
@@ -500,10 +813,10 @@ func (m *Model) renderHeader() string {
 			text = lipgloss.NewStyle().Width(width).Align(lipgloss.Left).Render(text)
 		}
 
-		cells[i] = text
+		cells = append(cells, text)
 	}
 
-	row := strings.Join(cells, " ")
+	row := strings.Repeat(" ", m.prefixWidth()) + strings.Join(cells, " ")
 	return m.headerStyle.Render(row)
 }
 
@@ -512,10 +825,11 @@ func (m *Model) renderRow(row Row, index int, isSelected bool) string {
 		return ""
 	}
 
-	cells := make([]string, len(m.columns))
-	for i, col := range m.columns {
+	var cells []string
+	for _, i := range m.ColumnOrder() {
+		col := m.columns[i]
 		width := m.columnWidths[i]
-		if width <= 0 {
+		if col.Hidden || width <= 0 {
 			continue
 		}
 
@@ -525,7 +839,12 @@ func (m *Model) renderRow(row Row, index int, isSelected bool) string {
 		}
 
 		// Handle word wrap or truncation
-		if m.wordWrap {
+		if col.FitContent {
+			// Only a MaxWidth makes values overflow a fitted column
+			if limit := width - fitPadding; ansi.StringWidth(text) > limit && limit > 0 {
+				text = truncate.StringWithTail(text, uint(limit), "…")
+			}
+		} else if m.wordWrap {
 			// When word wrap is ON, allow content to expand beyond column width
 			// Don't truncate - let the content flow naturally
 			// For now, we don't implement multi-line wrapping in table cells
@@ -551,6 +870,11 @@ func (m *Model) renderRow(row Row, index int, isSelected bool) string {
 			}
 		}
 
+		if m.cellStyler != nil {
+			cells = append(cells, m.cellStyler(row.Values, col, text, width, isSelected))
+			continue
+		}
+
 		// Apply alignment
 		switch col.Align {
 		case lipgloss.Right:
@@ -561,7 +885,7 @@ func (m *Model) renderRow(row Row, index int, isSelected bool) string {
 			text = lipgloss.NewStyle().Width(width).Align(lipgloss.Left).Render(text)
 		}
 
-		cells[i] = text
+		cells = append(cells, text)
 	}
 
 	rowStr := strings.Join(cells, " ")
@@ -575,9 +899,20 @@ func (m *Model) renderRow(row Row, index int, isSelected bool) string {
 	if row.Style.String() != "" {
 		style = row.Style
 	}
-	if isSelected && m.focused {
+	if isSelected && m.focused && m.cellStyler == nil {
 		style = m.selectedStyle
 	}
 
-	return style.Render(rowStr)
+	rendered := style.Render(rowStr)
+	if m.rowMarker != nil {
+		rendered = m.rowMarker(row.Values) + rendered
+	}
+	if m.selectionMarker {
+		marker := strings.Repeat(" ", len(selectedMarker))
+		if isSelected {
+			marker = selectedMarker
+		}
+		rendered = marker + rendered
+	}
+	return rendered
 }
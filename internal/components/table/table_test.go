@@ -2,6 +2,7 @@ package table
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 
@@ -593,6 +594,173 @@ func TestTruncation(t *testing.T) {
 	}
 }
 
+func TestFitContentAndHiddenColumns(t *testing.T) {
+	table := New([]Column{
+		{Title: "NAME", FitContent: true, MinWidth: 7},
+		{Title: "SECRET", FitContent: true, Hidden: true},
+		{Title: "AGE", FitContent: true, MinWidth: 7},
+	})
+	table.SetValues([][]string{
+		{"pod-with-a-long-name", "hidden-value", "5m"},
+		{"pod-2", "hidden-value", "10m"},
+	})
+	table.SetSize(80, 10)
+
+	widths := table.ColumnWidths()
+	if widths[0] != len("pod-with-a-long-name")+fitPadding {
+		t.Errorf("Expected NAME to fit its widest value, got width %d", widths[0])
+	}
+	if widths[2] != 7 {
+		t.Errorf("Expected AGE to keep its minimum width, got %d", widths[2])
+	}
+
+	view := table.View()
+	if strings.Contains(view, "SECRET") || strings.Contains(view, "hidden-value") {
+		t.Error("Expected hidden column to be left out of the view")
+	}
+	if !strings.Contains(view, "pod-with-a-long-name") {
+		t.Error("Expected fitted column to show its full value")
+	}
+}
+
+func TestSetCell(t *testing.T) {
+	table := New([]Column{{Title: "NAME"}, {Title: "LOG"}})
+	table.SetValues([][]string{{"pod-1", "…"}})
+
+	if !table.SetCell(0, 1, "~12/m") {
+		t.Fatal("Expected SetCell to update an existing cell")
+	}
+	if got := table.RowValues(0)[1]; got != "~12/m" {
+		t.Errorf("Expected cell to be updated, got %q", got)
+	}
+	if table.SetCell(1, 0, "x") || table.SetCell(0, 5, "x") {
+		t.Error("Expected SetCell to reject cells outside the table")
+	}
+}
+
+func TestHeaderRuleAndCompact(t *testing.T) {
+	table := New([]Column{{Title: "NAME", Width: 10}})
+	table.SetValues([][]string{{"pod-1"}})
+	table.SetHeaderRule(true)
+	table.SetSize(20, 5)
+
+	if !strings.Contains(table.View(), "─") {
+		t.Error("Expected a rule under the header")
+	}
+
+	table.SetCompact(true)
+	if strings.Contains(table.View(), "─") {
+		t.Error("Expected compact mode to drop the header rule")
+	}
+}
+
+func TestScrollIndicator(t *testing.T) {
+	table := New([]Column{{Title: "NAME", Width: 10}})
+	values := make([][]string, 20)
+	for i := range values {
+		values[i] = []string{fmt.Sprintf("pod-%d", i)}
+	}
+	table.SetValues(values)
+	table.SetScrollIndicator(true)
+	table.SetSize(20, 6)
+
+	if !strings.Contains(table.View(), "of 20]") {
+		t.Error("Expected the scroll indicator to show the row count")
+	}
+}
+
+func TestHorizontalOffset(t *testing.T) {
+	table := New([]Column{
+		{Title: "NAME", Width: 20},
+		{Title: "STATUS", Width: 10},
+	})
+	table.SetValues([][]string{{"pod-1", "Running"}})
+	table.SetSize(20, 5)
+
+	table.SetHorizontalOffset(20)
+	if got := table.HorizontalOffset(); got != 11 {
+		t.Errorf("Expected offset to be clamped to the hidden width 11, got %d", got)
+	}
+	view := table.View()
+	if strings.Contains(view, "pod-1") || !strings.Contains(view, "Running") {
+		t.Errorf("Expected the view to scroll past NAME, got:\n%s", view)
+	}
+
+	table.SetHorizontalOffset(-5)
+	if got := table.HorizontalOffset(); got != 0 {
+		t.Errorf("Expected negative offset to be clamped to 0, got %d", got)
+	}
+}
+
+func TestCellStylerAndRowMarker(t *testing.T) {
+	table := New([]Column{{Title: "NAME", Width: 10}})
+	table.SetValues([][]string{{"pod-1"}, {"pod-2"}})
+	table.SetCellStyler(func(values []string, column Column, value string, width int, selected bool) string {
+		if selected {
+			return lipgloss.NewStyle().Width(width).Render("*" + value)
+		}
+		return lipgloss.NewStyle().Width(width).Render(value)
+	})
+	table.SetRowMarker(2, func(values []string) string {
+		if values[0] == "pod-2" {
+			return "≠ "
+		}
+		return "  "
+	})
+	table.SetSize(20, 5)
+
+	view := table.View()
+	if !strings.Contains(view, "*pod-1") {
+		t.Error("Expected the styler to style the selected row")
+	}
+	if !strings.Contains(view, "≠ pod-2") {
+		t.Error("Expected the row marker before the row")
+	}
+}
+
+func TestSelectionMarker(t *testing.T) {
+	table := New([]Column{{Title: "NAME", Width: 8}, {Title: "STATUS", Width: 8}})
+	table.SetValues([][]string{{"pod-1", "Running"}, {"pod-2", "Pending"}})
+	table.SetHeaderRule(true)
+	table.SetSelectionMarker(true)
+	table.SetSelectedIndex(1)
+	table.SetSize(20, 4)
+
+	want := []string{
+		"  NAME     STATUS  ",
+		strings.Repeat("─", 19),
+		"  pod-1    Running ",
+		"> pod-2    Pending ",
+	}
+	if got := strings.Split(table.View(), "\n"); !slices.Equal(got, want) {
+		t.Errorf("Expected the selected row marked:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestColumnOrder(t *testing.T) {
+	table := New([]Column{{Title: "NAME", Width: 6}, {Title: "STATUS", Width: 8}, {Title: "AGE", Width: 4}})
+	table.SetValues([][]string{{"pod-1", "Running", "5m"}})
+	table.SetSize(20, 2)
+
+	table.SetColumnOrder([]int{1, 0, 2})
+	want := []string{
+		"STATUS   NAME   AGE ",
+		"Running  pod-1  5m  ",
+	}
+	if got := strings.Split(table.View(), "\n"); !slices.Equal(got, want) {
+		t.Errorf("Expected STATUS first:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+	if got := table.RowValues(0); got[0] != "pod-1" {
+		t.Errorf("Expected values kept in column order, got %v", got)
+	}
+
+	// An order that leaves out a column is ignored
+	table.SetColumnOrder([]int{2, 0})
+	if got := table.ColumnOrder(); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("Expected the usual order for a partial one, got %v", got)
+	}
+}
+
 func BenchmarkTableRender(b *testing.B) {
 	columns := []Column{
 		{Title: "Name", Width: 20},
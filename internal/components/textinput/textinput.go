@@ -0,0 +1,154 @@
+// Package textinput is a single-line text input for prompts, optionally
+// checked as it is typed by a Validator
+package textinput
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Model is a single line of text being typed, with a cursor. It owns its
+// text: only the keys it is given change it, so the data shown around it can
+// be refreshed any number of times while the user types. The zero value is an
+// empty input that accepts anything.
+type Model struct {
+	runes    []rune
+	cursor   int // Index into runes the next character is inserted at
+	validate Validator
+	err      error // What validate said about the current text
+	touched  bool  // Edited, or submitted, so err is worth showing
+}
+
+// New creates an empty input whose text is checked by validate
+func New(validate Validator) Model {
+	m := Model{validate: validate}
+	m.check()
+	return m
+}
+
+// SetValidator changes how the text is checked, clearing any error shown
+func (t *Model) SetValidator(validate Validator) {
+	t.validate = validate
+	t.touched = false
+	t.check()
+}
+
+// Value returns the text typed
+func (t *Model) Value() string {
+	return string(t.runes)
+}
+
+// SetValue replaces the text, putting the cursor at its end
+func (t *Model) SetValue(value string) {
+	t.runes = []rune(value)
+	t.cursor = len(t.runes)
+	t.check()
+}
+
+// Reset clears the text and any error shown
+func (t *Model) Reset() {
+	t.SetValue("")
+	t.touched = false
+}
+
+// HandleKey edits the text and moves the cursor, and returns false for keys
+// that are not editing keys. Keys typed quickly, or pasted, can arrive as
+// several runes in one message; all of them are inserted. The text is checked
+// again after every edit.
+func (t *Model) HandleKey(msg tea.KeyMsg) bool {
+	before := string(t.runes)
+	switch msg.Type {
+	case tea.KeyRunes:
+		if msg.Alt {
+			return false
+		}
+		t.insert(msg.Runes)
+	case tea.KeySpace:
+		t.insert([]rune{' '})
+	case tea.KeyBackspace:
+		if t.cursor > 0 {
+			t.runes = append(t.runes[:t.cursor-1], t.runes[t.cursor:]...)
+			t.cursor--
+		}
+	case tea.KeyDelete:
+		if t.cursor < len(t.runes) {
+			t.runes = append(t.runes[:t.cursor], t.runes[t.cursor+1:]...)
+		}
+	case tea.KeyLeft:
+		if t.cursor > 0 {
+			t.cursor--
+		}
+	case tea.KeyRight:
+		if t.cursor < len(t.runes) {
+			t.cursor++
+		}
+	case tea.KeyHome, tea.KeyCtrlA:
+		t.cursor = 0
+	case tea.KeyEnd, tea.KeyCtrlE:
+		t.cursor = len(t.runes)
+	case tea.KeyCtrlU:
+		t.runes, t.cursor = nil, 0
+	default:
+		return false
+	}
+	if string(t.runes) != before {
+		t.touched = true
+		t.check()
+	}
+	return true
+}
+
+// check runs the validator over the current text
+func (t *Model) check() {
+	t.err = nil
+	if t.validate != nil {
+		t.err = t.validate(string(t.runes))
+	}
+}
+
+// Err returns why the text is not acceptable, or nil when it is
+func (t *Model) Err() error {
+	return t.err
+}
+
+// Valid reports whether the text passes the validator. Prompts do not submit
+// while it is false.
+func (t *Model) Valid() bool {
+	return t.err == nil
+}
+
+// Submit reports whether the text can be submitted, and otherwise makes its
+// error show even if nothing has been typed yet
+func (t *Model) Submit() bool {
+	t.touched = true
+	return t.Valid()
+}
+
+// insert adds runes at the cursor and moves the cursor past them
+func (t *Model) insert(runes []rune) {
+	inserted := make([]rune, 0, len(t.runes)+len(runes))
+	inserted = append(inserted, t.runes[:t.cursor]...)
+	inserted = append(inserted, runes...)
+	inserted = append(inserted, t.runes[t.cursor:]...)
+	t.runes = inserted
+	t.cursor += len(runes)
+}
+
+// View renders the text with the cursor: an underscore after the text, or
+// the character under it reversed
+func (t *Model) View() string {
+	if t.cursor >= len(t.runes) {
+		return string(t.runes) + "_"
+	}
+	cursorStyle := lipgloss.NewStyle().Reverse(true)
+	return string(t.runes[:t.cursor]) + cursorStyle.Render(string(t.runes[t.cursor])) + string(t.runes[t.cursor+1:])
+}
+
+// ErrorView renders the error to show under the field: empty while the text
+// is valid, or before it has been edited or submitted
+func (t *Model) ErrorView() string {
+	if t.err == nil || !t.touched {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("✗ " + t.err.Error())
+}
@@ -0,0 +1,87 @@
+package textinput
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestEditing(t *testing.T) {
+	var input Model
+	input.SetValue("status=Run")
+
+	// Fast typing arrives as several runes at once
+	input.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ning")})
+	if input.Value() != "status=Running" {
+		t.Fatalf("Expected every rune inserted, got %q", input.Value())
+	}
+
+	// Edit in the middle: the cursor stays where the user put it
+	for i := 0; i < len("=Running"); i++ {
+		input.HandleKey(tea.KeyMsg{Type: tea.KeyLeft})
+	}
+	input.HandleKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	input.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	if input.Value() != "statuS=Running" || input.cursor != 6 {
+		t.Errorf("Expected the edit at the cursor, got %q with the cursor at %d", input.Value(), input.cursor)
+	}
+
+	// Runes, not bytes, are deleted
+	input.SetValue("naïve")
+	input.HandleKey(tea.KeyMsg{Type: tea.KeyLeft})
+	input.HandleKey(tea.KeyMsg{Type: tea.KeyLeft})
+	input.HandleKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	if input.Value() != "nave" {
+		t.Errorf("Expected the ï deleted whole, got %q", input.Value())
+	}
+
+	if input.HandleKey(tea.KeyMsg{Type: tea.KeyEnter}) || input.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x"), Alt: true}) {
+		t.Error("Expected Enter and Alt keys left to the caller")
+	}
+	input.HandleKey(tea.KeyMsg{Type: tea.KeyCtrlU})
+	if input.Value() != "" || input.View() != "_" {
+		t.Errorf("Expected Ctrl+U to clear the input, got %q", input.View())
+	}
+}
+
+func TestValidation(t *testing.T) {
+	input := New(IntRange(1, 10))
+	if input.Valid() || input.ErrorView() != "" {
+		t.Fatalf("Expected an empty input invalid but quiet until edited, got %q", input.ErrorView())
+	}
+
+	// Submitting shows the error without anything typed
+	if input.Submit() {
+		t.Fatal("Expected submit refused while invalid")
+	}
+	if !strings.Contains(input.ErrorView(), "must be a whole number") {
+		t.Errorf("Expected the error under the field, got %q", input.ErrorView())
+	}
+
+	// The error follows the text as it is typed
+	input.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	if !input.Valid() || input.ErrorView() != "" {
+		t.Errorf("Expected 1 valid, got %v", input.Err())
+	}
+	input.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	if input.Valid() || !strings.Contains(input.ErrorView(), "must be between 1 and 10") {
+		t.Errorf("Expected 11 out of range, got %q", input.ErrorView())
+	}
+	if input.Submit() {
+		t.Error("Expected submit refused while out of range")
+	}
+
+	// Moving the cursor is not an edit
+	input.Reset()
+	input.HandleKey(tea.KeyMsg{Type: tea.KeyLeft})
+	if input.ErrorView() != "" {
+		t.Errorf("Expected no error after a reset, got %q", input.ErrorView())
+	}
+
+	// Without a validator anything goes
+	var free Model
+	if !free.Submit() || free.ErrorView() != "" {
+		t.Error("Expected an input without a validator always valid")
+	}
+}
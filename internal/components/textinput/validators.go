@@ -0,0 +1,92 @@
+package textinput
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Validator checks the text of an input, returning why it is not acceptable.
+// Its message is shown under the field, so it is short and says what is
+// wanted rather than what went wrong.
+type Validator func(value string) error
+
+// KubernetesName accepts an RFC 1123 label, the form of namespace names and
+// most resource names: at most 63 lowercase letters, digits and '-', starting
+// and ending with a letter or digit
+func KubernetesName() Validator {
+	return func(value string) error {
+		switch {
+		case value == "":
+			return errors.New("a name is required")
+		case len(value) > validation.DNS1123LabelMaxLength:
+			return fmt.Errorf("must be at most %d characters", validation.DNS1123LabelMaxLength)
+		case len(validation.IsDNS1123Label(value)) > 0:
+			return errors.New("must be lowercase letters, digits and '-', starting and ending with a letter or digit")
+		}
+		return nil
+	}
+}
+
+// IntRange accepts a whole number from min to max inclusive
+func IntRange(min, max int) Validator {
+	return func(value string) error {
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return errors.New("must be a whole number")
+		}
+		if n < min || n > max {
+			return fmt.Errorf("must be between %d and %d", min, max)
+		}
+		return nil
+	}
+}
+
+// Duration accepts a Go duration that is not negative, such as 30s or 1h30m
+func Duration() Validator {
+	return func(value string) error {
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return errors.New("must be a duration such as 30s, 5m or 1h")
+		}
+		if d < 0 {
+			return errors.New("must not be negative")
+		}
+		return nil
+	}
+}
+
+// PortPair accepts a port to forward, as kubectl port-forward takes it:
+// LOCAL:REMOTE, such as 8080:80, or one port used for both
+func PortPair() Validator {
+	return func(value string) error {
+		local, remote, paired := strings.Cut(strings.TrimSpace(value), ":")
+		if !paired {
+			remote = local
+		}
+		for _, port := range []string{local, remote} {
+			n, err := strconv.Atoi(port)
+			if err != nil {
+				return errors.New("must be a port or LOCAL:REMOTE, such as 8080:80")
+			}
+			if n < 1 || n > 65535 {
+				return fmt.Errorf("port %d must be between 1 and 65535", n)
+			}
+		}
+		return nil
+	}
+}
+
+// LabelSelector accepts a label selector as kubectl -l takes it, such as
+// app=web,tier!=cache. An empty selector selects everything.
+func LabelSelector() Validator {
+	return func(value string) error {
+		_, err := labels.Parse(value)
+		return err
+	}
+}
@@ -0,0 +1,102 @@
+package textinput
+
+import (
+	"strings"
+	"testing"
+)
+
+// checkValidator runs validate over each value, expecting an error containing
+// want, or no error when want is empty
+func checkValidator(t *testing.T, validate Validator, cases map[string]string) {
+	t.Helper()
+	for value, want := range cases {
+		err := validate(value)
+		switch {
+		case want == "" && err != nil:
+			t.Errorf("%q: expected valid, got %v", value, err)
+		case want != "" && err == nil:
+			t.Errorf("%q: expected an error containing %q", value, want)
+		case want != "" && !strings.Contains(err.Error(), want):
+			t.Errorf("%q: expected an error containing %q, got %v", value, want, err)
+		}
+	}
+}
+
+func TestKubernetesName(t *testing.T) {
+	checkValidator(t, KubernetesName(), map[string]string{
+		"web":                   "",
+		"a":                     "",
+		"0":                     "",
+		"web-1":                 "",
+		strings.Repeat("a", 63): "",
+		strings.Repeat("a", 64): "at most 63 characters",
+		"":                      "required",
+		"Web":                   "lowercase",
+		"-web":                  "starting and ending",
+		"web-":                  "starting and ending",
+		"web.example":           "lowercase",
+		"web_1":                 "lowercase",
+	})
+}
+
+func TestIntRange(t *testing.T) {
+	checkValidator(t, IntRange(1, 300), map[string]string{
+		"1":    "",
+		"300":  "",
+		" 42 ": "",
+		"0":    "between 1 and 300",
+		"301":  "between 1 and 300",
+		"-1":   "between 1 and 300",
+		"":     "whole number",
+		"1.5":  "whole number",
+		"abc":  "whole number",
+	})
+}
+
+func TestDuration(t *testing.T) {
+	checkValidator(t, Duration(), map[string]string{
+		"30s":   "",
+		"1h30m": "",
+		"0":     "",
+		"0s":    "",
+		"-1s":   "not be negative",
+		"-5m":   "not be negative",
+		"":      "duration such as",
+		"5":     "duration such as",
+		"soon":  "duration such as",
+	})
+}
+
+func TestPortPair(t *testing.T) {
+	checkValidator(t, PortPair(), map[string]string{
+		"8080:80":     "",
+		"8080":        "",
+		"1:65535":     "",
+		"65535:1":     "",
+		"0:80":        "port 0 must be between 1 and 65535",
+		"8080:0":      "port 0 must be between 1 and 65535",
+		"65536:80":    "port 65536 must be between 1 and 65535",
+		"8080:65536":  "port 65536 must be between 1 and 65535",
+		"":            "LOCAL:REMOTE",
+		":80":         "LOCAL:REMOTE",
+		"8080:":       "LOCAL:REMOTE",
+		"8080:80:443": "LOCAL:REMOTE",
+		"http":        "LOCAL:REMOTE",
+	})
+}
+
+func TestLabelSelector(t *testing.T) {
+	checkValidator(t, LabelSelector(), map[string]string{
+		"":                             "",
+		"app=web":                      "",
+		"app=web,tier!=cache":          "",
+		"env in (prod,staging)":        "",
+		"!canary":                      "",
+		"app=":                         "",
+		"app==web==x":                  "found '=='",
+		"app=web,":                     "found ''",
+		"env in (prod":                 "expected: ',' or ')'",
+		strings.Repeat("a", 63) + "=x": "",
+		strings.Repeat("a", 64) + "=x": "no more than 63 characters",
+	})
+}
@@ -5,9 +5,15 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/notify"
 	"gopkg.in/yaml.v3"
 )
 
@@ -20,6 +26,16 @@ type Config struct {
 	Filters   map[string]*FilterConfig   `yaml:"filters"`
 	Layouts   map[string]*LayoutConfig   `yaml:"layouts"`
 	Settings  *Settings                  `yaml:"settings"`
+
+	SavedFilters []*SavedFilter `yaml:"savedFilters,omitempty"`
+	Actions      []*UserAction  `yaml:"actions,omitempty"`
+
+	ManifestTemplates []*ManifestTemplate `yaml:"manifestTemplates,omitempty"`
+
+	Startup *StartupLayout `yaml:"startup,omitempty"`
+
+	// warnings collects non-fatal problems found while validating
+	warnings []string
 }
 
 // ColumnConfig defines column display configuration
@@ -98,6 +114,25 @@ type QuickFilter struct {
 	Expression string `yaml:"expression"`
 }
 
+// SavedFilter is a named list filter picked from the saved filters menu
+type SavedFilter struct {
+	Name         string      `yaml:"name"`
+	ResourceType string      `yaml:"resourceType"`
+	Expression   string      `yaml:"expression"`
+	Namespace    string      `yaml:"namespace,omitempty"` // Switch to this namespace when applied
+	Context      string      `yaml:"context,omitempty"`   // Only offer the filter in this context
+	Sort         *SortConfig `yaml:"sort,omitempty"`
+}
+
+// UserAction is an external command run against the selected resource
+type UserAction struct {
+	Name         string `yaml:"name"`
+	Key          string `yaml:"key,omitempty"`          // Empty to offer it only in the quick-action menu
+	ResourceType string `yaml:"resourceType,omitempty"` // Empty for every resource type
+	Command      string `yaml:"command"`                // Placeholders: {{.Name}} {{.Namespace}} {{.Context}} {{.Node}}
+	Interactive  *bool  `yaml:"interactive,omitempty"`  // Default true: suspend the UI and hand over the terminal
+}
+
 // LayoutConfig defines a view layout
 type LayoutConfig struct {
 	Name      string         `yaml:"name"`
@@ -129,6 +164,120 @@ type Settings struct {
 	ShowMetrics      bool               `yaml:"showMetrics"`
 	WordWrap         bool               `yaml:"wordWrap"`
 	Shortcuts        []*Shortcut        `yaml:"shortcuts"`
+	Runtime          map[string]string  `yaml:"runtime,omitempty"` // Values from the runtime settings registry
+	Logs             *LogsConfig        `yaml:"logs,omitempty"`
+	Metrics          *MetricsConfig     `yaml:"metrics,omitempty"`
+	Time             *TimeConfig        `yaml:"time,omitempty"`
+	Noise            *NoiseConfig       `yaml:"noise,omitempty"`
+	Cache            *CacheConfig       `yaml:"cache,omitempty"`
+	Advanced         *AdvancedConfig    `yaml:"advanced,omitempty"`
+	QuitKeyBehavior  string             `yaml:"quitKeyBehavior,omitempty"` // "contextual" (default) or "global"
+	// Namespaces is the namespace to start in by kubeconfig context, ahead
+	// of the one the context sets
+	Namespaces map[string]string `yaml:"namespaces,omitempty"`
+	Updates    *UpdatesConfig    `yaml:"updates,omitempty"`
+	// Notifications are desktop notifications of alerts while the terminal
+	// is in the background
+	Notifications *NotificationsConfig `yaml:"notifications,omitempty"`
+	// ColumnOrder is the order columns are shown in by resource type, e.g.
+	// pods: [NAMESPACE, NAME, STATUS]. Columns left out follow as usual.
+	ColumnOrder map[string][]string `yaml:"columnOrder,omitempty"`
+}
+
+// UpdatesConfig defines the check for newer kubewatch releases
+type UpdatesConfig struct {
+	// Check looks for a newer release on GitHub at startup (default off).
+	// It never runs with --no-external-network.
+	Check bool `yaml:"check,omitempty"`
+	// Dismissed is the release whose notice was dismissed; it is not
+	// mentioned again, but a later release is
+	Dismissed string `yaml:"dismissed,omitempty"`
+}
+
+// NotificationsConfig defines the desktop notifications sent of alerts
+// raised while the terminal is unfocused
+type NotificationsConfig struct {
+	// Enabled turns notifications on (default off)
+	Enabled bool `yaml:"enabled,omitempty"`
+	// Alerts are the categories that notify: crashloop, disconnected and
+	// deletion; unset notifies of all of them
+	Alerts []string `yaml:"alerts,omitempty"`
+	// MinInterval is the least time between two notifications, e.g. "1m";
+	// unset is 30s
+	MinInterval string `yaml:"minInterval,omitempty"`
+	// Method is how the terminal is asked to notify: "auto" (default),
+	// "osc777", "osc9" or "bell"
+	Method string `yaml:"method,omitempty"`
+}
+
+// AdvancedConfig enables actions that can leave the cluster in a bad state
+type AdvancedConfig struct {
+	// AllowFinalizerRemoval lets the describe view remove a finalizer from a
+	// resource stuck terminating, skipping its controller's cleanup
+	AllowFinalizerRemoval bool `yaml:"allowFinalizerRemoval,omitempty"`
+	// FocusReporting asks the terminal to report focus, so kubewatch does
+	// less while unfocused (default on). Some terminals report it wrongly.
+	FocusReporting *bool `yaml:"focusReporting,omitempty"`
+}
+
+// LogsConfig defines log view settings
+type LogsConfig struct {
+	// RecordStart is a regular expression matching the first line of a
+	// multi-line log record; other lines continue the record before them.
+	// Empty uses the built-in timestamp/log level pattern.
+	RecordStart string `yaml:"recordStart,omitempty"`
+	// GroupRecords turns multi-line record grouping on or off (default on)
+	GroupRecords *bool `yaml:"groupRecords,omitempty"`
+	// Colors shows the colors programs write into their logs (default on);
+	// off strips them. Other escape sequences are always removed.
+	Colors *bool `yaml:"colors,omitempty"`
+}
+
+// MetricsConfig defines how pod metrics are shown
+type MetricsConfig struct {
+	// ContainerUsage chooses what a pod's CPU/MEMORY cells show: "pod" sums
+	// its containers (default), "largest" shows the container using the most
+	// CPU and "main" the only container that is not a sidecar
+	ContainerUsage string `yaml:"containerUsage,omitempty"`
+	// Sidecars are glob patterns of container names "main" leaves out;
+	// unset uses istio-proxy and linkerd-proxy
+	Sidecars []string `yaml:"sidecars,omitempty"`
+}
+
+// CacheConfig defines how long resource lists are kept in memory
+type CacheConfig struct {
+	// TTL is how long the list of a resource type no longer viewed is kept,
+	// e.g. "30m"; unset keeps it 10 minutes
+	TTL string `yaml:"ttl,omitempty"`
+}
+
+// TimeConfig defines how timestamps are shown
+type TimeConfig struct {
+	Clock  string `yaml:"clock,omitempty"`  // "24h" (default) or "12h"
+	Zone   string `yaml:"zone,omitempty"`   // "local" (default) or "utc"
+	Layout string `yaml:"layout,omitempty"` // Go time layout replacing the built-in ones
+}
+
+// NoiseConfig chooses what the noise toggle (z) hides, by resource type
+type NoiseConfig struct {
+	Pods         *PodNoiseConfig      `yaml:"pods,omitempty"`
+	Deployments  *WorkloadNoiseConfig `yaml:"deployments,omitempty"`
+	StatefulSets *WorkloadNoiseConfig `yaml:"statefulSets,omitempty"`
+}
+
+// PodNoiseConfig defines which pods are noise
+type PodNoiseConfig struct {
+	// Completed hides pods that ran to completion (default on)
+	Completed *bool `yaml:"completed,omitempty"`
+	// FailedAfter hides failed and evicted pods stopped at least this long,
+	// e.g. "1h"; unset keeps them
+	FailedAfter string `yaml:"failedAfter,omitempty"`
+}
+
+// WorkloadNoiseConfig defines which deployments or statefulsets are noise
+type WorkloadNoiseConfig struct {
+	// ScaledToZero hides those with no desired replicas
+	ScaledToZero bool `yaml:"scaledToZero,omitempty"`
 }
 
 // AutoRefreshConfig defines auto-refresh settings
@@ -166,6 +315,11 @@ func NewLoader(configDir string) *Loader {
 	}
 }
 
+// ConfigPath returns the path of the user config file
+func (l *Loader) ConfigPath() string {
+	return filepath.Join(l.configDir, "config.yaml")
+}
+
 // Load loads the configuration from disk
 func (l *Loader) Load() error {
 	l.mu.Lock()
@@ -177,7 +331,7 @@ func (l *Loader) Load() error {
 	}
 
 	// Load user config if it exists
-	configPath := filepath.Join(l.configDir, "config.yaml")
+	configPath := l.ConfigPath()
 	if _, err := os.Stat(configPath); err == nil {
 		userConfig, err := l.loadConfigFile(configPath)
 		if err != nil {
@@ -242,6 +396,10 @@ func (l *Loader) validateConfig(config *Config) error {
 	if config.Theme == "" {
 		config.Theme = "default"
 	}
+	if warning := validateTheme(config.Theme); warning != "" {
+		config.warnings = append(config.warnings, warning)
+		config.Theme = "default"
+	}
 
 	// Validate columns
 	for name, col := range config.Columns {
@@ -269,9 +427,276 @@ func (l *Loader) validateConfig(config *Config) error {
 		}
 	}
 
+	config.warnings = append(config.warnings, validateColumnTemplates(config.Columns)...)
+
+	var userTemplateWarnings []string
+	config.Templates, userTemplateWarnings = validateTemplates(config.Templates)
+	config.warnings = append(config.warnings, userTemplateWarnings...)
+
+	var filterWarnings []string
+	config.SavedFilters, filterWarnings = validateSavedFilters(config.SavedFilters)
+	config.warnings = append(config.warnings, filterWarnings...)
+
+	var actionWarnings []string
+	config.Actions, actionWarnings = validateUserActions(config.Actions)
+	config.warnings = append(config.warnings, actionWarnings...)
+
+	var templateWarnings []string
+	config.ManifestTemplates, templateWarnings = validateManifestTemplates(config.ManifestTemplates)
+	config.warnings = append(config.warnings, templateWarnings...)
+
+	if config.Startup != nil {
+		if err := config.Startup.Validate(); err != nil {
+			config.warnings = append(config.warnings, fmt.Sprintf("startup: %v", err))
+			config.Startup = nil
+		}
+	}
+
+	if config.Settings != nil {
+		var shortcutWarnings []string
+		config.Settings.Shortcuts, shortcutWarnings = validateShortcuts(config.Settings.Shortcuts)
+		config.warnings = append(config.warnings, shortcutWarnings...)
+	}
+
+	// A bad log record pattern falls back to the default rather than failing
+	if config.Settings != nil && config.Settings.Logs != nil && config.Settings.Logs.RecordStart != "" {
+		if _, err := regexp.Compile(config.Settings.Logs.RecordStart); err != nil {
+			config.warnings = append(config.warnings, fmt.Sprintf("logs.recordStart: %v", err))
+			config.Settings.Logs.RecordStart = ""
+		}
+	}
+
+	// Bad metrics settings fall back to the defaults too
+	if config.Settings != nil && config.Settings.Metrics != nil {
+		metrics := config.Settings.Metrics
+		if _, err := k8s.ParseMetricsHeadline(metrics.ContainerUsage); err != nil {
+			config.warnings = append(config.warnings, fmt.Sprintf("metrics.containerUsage: %v", err))
+			metrics.ContainerUsage = ""
+		}
+		if err := k8s.ValidateSidecars(metrics.Sidecars); err != nil {
+			config.warnings = append(config.warnings, fmt.Sprintf("metrics.sidecars: %v", err))
+			metrics.Sidecars = nil
+		}
+	}
+
+	// With an unknown quit key behavior, q closes views as by default
+	if config.Settings != nil {
+		if _, err := core.ParseQuitKeyBehavior(config.Settings.QuitKeyBehavior); err != nil {
+			config.warnings = append(config.warnings, fmt.Sprintf("quitKeyBehavior: %v", err))
+			config.Settings.QuitKeyBehavior = ""
+		}
+	}
+
+	if config.Settings != nil && config.Settings.Time != nil {
+		t := config.Settings.Time
+		if _, err := core.ParseTimeFormat(t.Clock, t.Zone, t.Layout); err != nil {
+			config.warnings = append(config.warnings, fmt.Sprintf("time: %v", err))
+			config.Settings.Time = nil
+		}
+	}
+
+	if config.Settings != nil && config.Settings.Noise != nil && config.Settings.Noise.Pods != nil {
+		pods := config.Settings.Noise.Pods
+		if pods.FailedAfter != "" {
+			if after, err := time.ParseDuration(pods.FailedAfter); err != nil || after <= 0 {
+				config.warnings = append(config.warnings, fmt.Sprintf("noise.pods.failedAfter: %q is not a positive duration such as 1h", pods.FailedAfter))
+				pods.FailedAfter = ""
+			}
+		}
+	}
+
+	if config.Settings != nil {
+		config.warnings = append(config.warnings, validateColumnOrder(config.Settings.ColumnOrder)...)
+	}
+
+	if config.Settings != nil && config.Settings.Cache != nil && config.Settings.Cache.TTL != "" {
+		cache := config.Settings.Cache
+		if ttl, err := time.ParseDuration(cache.TTL); err != nil || ttl <= 0 {
+			config.warnings = append(config.warnings, fmt.Sprintf("cache.ttl: %q is not a positive duration such as 10m", cache.TTL))
+			cache.TTL = ""
+		}
+	}
+
+	if config.Settings != nil && config.Settings.Notifications != nil {
+		config.warnings = append(config.warnings, validateNotifications(config.Settings.Notifications)...)
+	}
+
+	return nil
+}
+
+// validateNotifications drops the unknown alert categories and falls back
+// to the default interval and method for bad ones, and describes why
+func validateNotifications(notifications *NotificationsConfig) []string {
+	var warnings []string
+	alerts := notifications.Alerts[:0]
+	for _, alert := range notifications.Alerts {
+		if _, err := core.ParseAlertCategory(alert); err != nil {
+			warnings = append(warnings, fmt.Sprintf("notifications.alerts: %v", err))
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+	notifications.Alerts = alerts
+	if notifications.MinInterval != "" {
+		if interval, err := time.ParseDuration(notifications.MinInterval); err != nil || interval <= 0 {
+			warnings = append(warnings, fmt.Sprintf("notifications.minInterval: %q is not a positive duration such as 1m", notifications.MinInterval))
+			notifications.MinInterval = ""
+		}
+	}
+	if _, err := notify.ParseMethod(notifications.Method); err != nil {
+		warnings = append(warnings, fmt.Sprintf("notifications.method: %v", err))
+		notifications.Method = ""
+	}
+	return warnings
+}
+
+// validateColumnOrder drops the column orders of unknown resource types or
+// naming unknown columns, and describes why; those types keep their usual
+// order. Column names are upper-cased as the lists show them.
+func validateColumnOrder(orders map[string][]string) []string {
+	var warnings []string
+	for name, order := range orders {
+		resourceType, ok := core.ParseResourceType(name)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("columnOrder.%s: unknown resource type; ignored", name))
+			delete(orders, name)
+			continue
+		}
+		for i, column := range order {
+			order[i] = strings.ToUpper(strings.TrimSpace(column))
+			if !core.IsResourceColumn(resourceType, order[i]) {
+				warnings = append(warnings, fmt.Sprintf("columnOrder.%s: %s has no %s column; ignored", name, resourceType, column))
+				delete(orders, name)
+				break
+			}
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// validateSavedFilters drops saved filters that cannot be used and describes
+// why. Bad entries should not stop kubewatch from starting.
+func validateSavedFilters(filters []*SavedFilter) ([]*SavedFilter, []string) {
+	var valid []*SavedFilter
+	var warnings []string
+	seen := make(map[string]bool)
+
+	for i, f := range filters {
+		if f == nil {
+			continue
+		}
+		if err := f.Validate(); err != nil {
+			label := fmt.Sprintf("saved filter %d", i+1)
+			if f.Name != "" {
+				label = fmt.Sprintf("saved filter %q", f.Name)
+			}
+			warnings = append(warnings, fmt.Sprintf("%s: %v", label, err))
+			continue
+		}
+		if seen[f.Name] {
+			warnings = append(warnings, fmt.Sprintf("saved filter %q: duplicate name", f.Name))
+			continue
+		}
+		seen[f.Name] = true
+		valid = append(valid, f)
+	}
+
+	return valid, warnings
+}
+
+// Validate checks that the saved filter names a known resource type and has a
+// parseable expression. Columns are checked when the filter is applied, since
+// they depend on what the list shows at that point.
+func (f *SavedFilter) Validate() error {
+	if strings.TrimSpace(f.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if _, ok := core.ParseResourceType(f.ResourceType); !ok {
+		return fmt.Errorf("unknown resource type %q", f.ResourceType)
+	}
+	if _, err := core.ParseFilter(f.Expression); err != nil {
+		return err
+	}
 	return nil
 }
 
+// validateUserActions drops user actions that cannot be run and describes why
+func validateUserActions(actions []*UserAction) ([]*UserAction, []string) {
+	var valid []*UserAction
+	var warnings []string
+	seenNames := make(map[string]bool)
+	seenKeys := make(map[string]string)
+
+	for i, a := range actions {
+		if a == nil {
+			continue
+		}
+		label := fmt.Sprintf("action %d", i+1)
+		if a.Name != "" {
+			label = fmt.Sprintf("action %q", a.Name)
+		}
+		if err := a.Validate(); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", label, err))
+			continue
+		}
+		if seenNames[a.Name] {
+			warnings = append(warnings, fmt.Sprintf("%s: duplicate name", label))
+			continue
+		}
+		if other, taken := seenKeys[a.Key]; a.Key != "" && taken {
+			warnings = append(warnings, fmt.Sprintf("%s: key %q is already used by action %q", label, a.Key, other))
+			continue
+		}
+		seenNames[a.Name] = true
+		if a.Key != "" {
+			seenKeys[a.Key] = a.Name
+		}
+		valid = append(valid, a)
+	}
+
+	return valid, warnings
+}
+
+// Validate checks that the action has a name, a known resource type if one
+// is given, and a command template that parses
+func (a *UserAction) Validate() error {
+	if strings.TrimSpace(a.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if a.ResourceType != "" {
+		if _, ok := core.ParseResourceType(a.ResourceType); !ok {
+			return fmt.Errorf("unknown resource type %q", a.ResourceType)
+		}
+	}
+	_, err := a.Template()
+	return err
+}
+
+// Template parses the action's command template
+func (a *UserAction) Template() (*core.CommandTemplate, error) {
+	return core.ParseCommandTemplate(a.Command)
+}
+
+// AppliesTo returns true when the action can run against resourceType
+func (a *UserAction) AppliesTo(resourceType core.ResourceType) bool {
+	if a.ResourceType == "" {
+		return true
+	}
+	t, ok := core.ParseResourceType(a.ResourceType)
+	return ok && t == resourceType
+}
+
+// IsInteractive returns true unless the action is marked non-interactive
+func (a *UserAction) IsInteractive() bool {
+	return a.Interactive == nil || *a.Interactive
+}
+
+// Warnings returns the non-fatal problems found when the config was loaded
+func (c *Config) Warnings() []string {
+	return c.warnings
+}
+
 // mergeConfigs merges user config over defaults
 func (l *Loader) mergeConfigs(defaults, user *Config) *Config {
 	if user == nil {
@@ -329,6 +754,13 @@ func (l *Loader) mergeConfigs(defaults, user *Config) *Config {
 		merged.Settings = user.Settings
 	}
 
+	// Saved filters only come from the user config
+	merged.SavedFilters = user.SavedFilters
+	merged.Actions = user.Actions
+	merged.ManifestTemplates = user.ManifestTemplates
+	merged.Startup = user.Startup
+	merged.warnings = user.warnings
+
 	return &merged
 }
 
@@ -365,6 +797,289 @@ func (l *Loader) GetTemplate(name string) *TemplateConfig {
 	return nil
 }
 
+// RuntimeSettings returns the persisted runtime setting values, keyed by setting key
+func (l *Loader) RuntimeSettings() map[string]string {
+	config := l.Get()
+	if config.Settings == nil {
+		return nil
+	}
+	return config.Settings.Runtime
+}
+
+// SaveRuntimeSettings stores runtime setting values in the user config and writes it to disk
+func (l *Loader) SaveRuntimeSettings(values map[string]string) error {
+	l.mu.Lock()
+	settings := l.loadUserSettingsForWrite()
+	settings.Runtime = make(map[string]string, len(values))
+	for k, v := range values {
+		settings.Runtime[k] = v
+	}
+	l.merged = l.mergeConfigs(l.defaults, l.user)
+	l.mu.Unlock()
+
+	return l.Save()
+}
+
+// loadUserSettingsForWrite returns the user config's settings for a change
+// to be saved, creating them when the user config has none. User settings
+// replace the defaults wholesale when merged, so new ones start from the
+// defaults. The caller holds l.mu.
+func (l *Loader) loadUserSettingsForWrite() *Settings {
+	if l.user == nil {
+		l.user = &Config{}
+	}
+	if l.user.Settings == nil {
+		l.user.Settings = &Settings{}
+		if l.defaults != nil && l.defaults.Settings != nil {
+			*l.user.Settings = *l.defaults.Settings
+		}
+	}
+	return l.user.Settings
+}
+
+// SavedFilters returns the valid saved filters from the config
+func (l *Loader) SavedFilters() []*SavedFilter {
+	return l.Get().SavedFilters
+}
+
+// UserActions returns the valid user-defined actions from the config
+func (l *Loader) UserActions() []*UserAction {
+	return l.Get().Actions
+}
+
+// ManifestTemplates returns the built-in manifest templates and the valid
+// ones from the config
+func (l *Loader) ManifestTemplates() []*ManifestTemplate {
+	return mergeManifestTemplates(l.Get().ManifestTemplates)
+}
+
+// LogSettings returns the log view settings, with grouping on unless disabled
+func (l *Loader) LogSettings() (recordStart string, groupRecords bool) {
+	config := l.Get()
+	if config.Settings == nil || config.Settings.Logs == nil {
+		return "", true
+	}
+	logs := config.Settings.Logs
+	return logs.RecordStart, logs.GroupRecords == nil || *logs.GroupRecords
+}
+
+// LogColors reports whether the log view shows the colors in log lines
+func (l *Loader) LogColors() bool {
+	config := l.Get()
+	if config.Settings == nil || config.Settings.Logs == nil {
+		return true
+	}
+	return config.Settings.Logs.Colors == nil || *config.Settings.Logs.Colors
+}
+
+// PodMetricsSettings returns what a pod's CPU/MEMORY cells show and the
+// sidecar patterns the "main" mode leaves out
+func (l *Loader) PodMetricsSettings() (k8s.MetricsHeadline, []string) {
+	config := l.Get()
+	if config.Settings == nil || config.Settings.Metrics == nil {
+		return k8s.HeadlinePod, k8s.DefaultSidecars
+	}
+	metrics := config.Settings.Metrics
+	mode, _ := k8s.ParseMetricsHeadline(metrics.ContainerUsage)
+	sidecars := metrics.Sidecars
+	if sidecars == nil {
+		sidecars = k8s.DefaultSidecars
+	}
+	return mode, sidecars
+}
+
+// TimeFormat returns how timestamps are shown
+func (l *Loader) TimeFormat() core.TimeFormat {
+	config := l.Get()
+	if config.Settings == nil || config.Settings.Time == nil {
+		return core.TimeFormat{}
+	}
+	t := config.Settings.Time
+	format, _ := core.ParseTimeFormat(t.Clock, t.Zone, t.Layout)
+	return format
+}
+
+// QuitKeyBehavior returns what q does outside the resource list
+func (l *Loader) QuitKeyBehavior() core.QuitKeyBehavior {
+	config := l.Get()
+	if config.Settings == nil {
+		return core.QuitKeyContextual
+	}
+	behavior, _ := core.ParseQuitKeyBehavior(config.Settings.QuitKeyBehavior)
+	return behavior
+}
+
+// NoiseRules returns which resources the noise toggle hides, with completed
+// pods hidden unless the config says otherwise
+func (l *Loader) NoiseRules() core.NoiseRules {
+	rules := core.DefaultNoiseRules()
+	config := l.Get()
+	if config.Settings == nil || config.Settings.Noise == nil {
+		return rules
+	}
+	noise := config.Settings.Noise
+	if pods := noise.Pods; pods != nil {
+		rules.CompletedPods = pods.Completed == nil || *pods.Completed
+		rules.FailedPodsAfter, _ = time.ParseDuration(pods.FailedAfter)
+	}
+	rules.ScaledToZeroDeployments = noise.Deployments != nil && noise.Deployments.ScaledToZero
+	rules.ScaledToZeroStatefulSets = noise.StatefulSets != nil && noise.StatefulSets.ScaledToZero
+	return rules
+}
+
+// CacheTTL returns how long the list of a resource type no longer viewed is
+// kept in memory
+func (l *Loader) CacheTTL() time.Duration {
+	config := l.Get()
+	if config.Settings == nil || config.Settings.Cache == nil || config.Settings.Cache.TTL == "" {
+		return core.DefaultCacheTTL
+	}
+	ttl, _ := time.ParseDuration(config.Settings.Cache.TTL)
+	return ttl
+}
+
+// AllowFinalizerRemoval returns true when removing finalizers is enabled
+func (l *Loader) AllowFinalizerRemoval() bool {
+	config := l.Get()
+	return config.Settings != nil && config.Settings.Advanced != nil && config.Settings.Advanced.AllowFinalizerRemoval
+}
+
+// Notifications returns the desktop notification options, and false when
+// notifications are off
+func (l *Loader) Notifications() (notify.Options, bool) {
+	config := l.Get()
+	if config.Settings == nil || config.Settings.Notifications == nil || !config.Settings.Notifications.Enabled {
+		return notify.Options{}, false
+	}
+	notifications := config.Settings.Notifications
+	var options notify.Options
+	for _, alert := range notifications.Alerts {
+		if category, err := core.ParseAlertCategory(alert); err == nil {
+			options.Categories = append(options.Categories, category)
+		}
+	}
+	options.MinInterval, _ = time.ParseDuration(notifications.MinInterval)
+	options.Method, _ = notify.ParseMethod(notifications.Method)
+	return options, true
+}
+
+// FocusReporting returns true unless terminal focus reporting is turned off
+func (l *Loader) FocusReporting() bool {
+	config := l.Get()
+	if config.Settings == nil || config.Settings.Advanced == nil {
+		return true
+	}
+	return config.Settings.Advanced.FocusReporting == nil || *config.Settings.Advanced.FocusReporting
+}
+
+// ContextNamespace returns the namespace saved for a kubeconfig context, or
+// "" when there is none
+func (l *Loader) ContextNamespace(contextName string) string {
+	config := l.Get()
+	if config.Settings == nil {
+		return ""
+	}
+	return config.Settings.Namespaces[contextName]
+}
+
+// UpdateCheck returns whether to look for a newer release at startup, and
+// the release whose notice was dismissed
+func (l *Loader) UpdateCheck() (enabled bool, dismissed string) {
+	config := l.Get()
+	if config.Settings == nil || config.Settings.Updates == nil {
+		return false, ""
+	}
+	return config.Settings.Updates.Check, config.Settings.Updates.Dismissed
+}
+
+// DismissUpdate records that the notice of release version was dismissed,
+// and writes the user config to disk
+func (l *Loader) DismissUpdate(version string) error {
+	l.mu.Lock()
+	settings := l.loadUserSettingsForWrite()
+	updates := UpdatesConfig{}
+	if settings.Updates != nil {
+		updates = *settings.Updates
+	}
+	updates.Dismissed = version
+	settings.Updates = &updates
+	l.merged = l.mergeConfigs(l.defaults, l.user)
+	l.mu.Unlock()
+
+	return l.Save()
+}
+
+// ColumnOrders returns the valid column orders from the config by resource
+// type
+func (l *Loader) ColumnOrders() map[core.ResourceType][]string {
+	config := l.Get()
+	if config.Settings == nil || len(config.Settings.ColumnOrder) == 0 {
+		return nil
+	}
+	orders := make(map[core.ResourceType][]string, len(config.Settings.ColumnOrder))
+	for name, order := range config.Settings.ColumnOrder {
+		if resourceType, ok := core.ParseResourceType(name); ok {
+			orders[resourceType] = order
+		}
+	}
+	return orders
+}
+
+// SaveColumnOrder stores a resource type's column order in the user config,
+// or removes it for nil, and writes the config to disk
+func (l *Loader) SaveColumnOrder(resourceType core.ResourceType, order []string) error {
+	l.mu.Lock()
+	settings := l.loadUserSettingsForWrite()
+	orders := make(map[string][]string, len(settings.ColumnOrder)+1)
+	for name, existing := range settings.ColumnOrder {
+		// Drop other spellings of the same type
+		if t, ok := core.ParseResourceType(name); !ok || t != resourceType {
+			orders[name] = existing
+		}
+	}
+	if len(order) > 0 {
+		orders[strings.ToLower(string(resourceType))] = order
+	}
+	settings.ColumnOrder = orders
+	l.merged = l.mergeConfigs(l.defaults, l.user)
+	l.mu.Unlock()
+
+	return l.Save()
+}
+
+// Warnings returns the non-fatal problems found in the user config
+func (l *Loader) Warnings() []string {
+	return l.Get().Warnings()
+}
+
+// SaveFilter adds a saved filter to the user config, replacing any filter of
+// the same name, and writes it to disk
+func (l *Loader) SaveFilter(filter *SavedFilter) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	if l.user == nil {
+		l.user = &Config{}
+	}
+	replaced := false
+	for i, existing := range l.user.SavedFilters {
+		if existing.Name == filter.Name {
+			l.user.SavedFilters[i] = filter
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		l.user.SavedFilters = append(l.user.SavedFilters, filter)
+	}
+	l.merged = l.mergeConfigs(l.defaults, l.user)
+	l.mu.Unlock()
+
+	return l.Save()
+}
+
 // Save saves the current configuration to disk
 func (l *Loader) Save() error {
 	l.mu.RLock()
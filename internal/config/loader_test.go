@@ -0,0 +1,832 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/notify"
+)
+
+func TestLoaderRuntimeSettingsRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	loader := NewLoader(dir)
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := loader.SaveRuntimeSettings(map[string]string{"refreshInterval": "7"}); err != nil {
+		t.Fatalf("SaveRuntimeSettings failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatalf("Expected config file to be written: %v", err)
+	}
+	if !strings.Contains(string(data), "runtime:") {
+		t.Errorf("Expected runtime section in saved config, got:\n%s", data)
+	}
+
+	reloaded := NewLoader(dir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if got := reloaded.RuntimeSettings()["refreshInterval"]; got != "7" {
+		t.Errorf("Expected refreshInterval 7 after reload, got %q", got)
+	}
+	// Defaults for the rest of the settings block survive the save
+	if !reloaded.Get().Settings.ShowMetrics {
+		t.Error("Expected default showMetrics to be preserved")
+	}
+}
+
+func TestLoaderSavedFiltersValidation(t *testing.T) {
+	dir := t.TempDir()
+	content := `savedFilters:
+  - name: crashing
+    resourceType: pods
+    expression: status=CrashLoopBackOff
+    sort:
+      column: RESTARTS
+  - name: broken
+    resourceType: pods
+    expression: status=
+  - name: jobs
+    resourceType: daemonsets
+    expression: foo
+  - resourceType: pods
+    expression: web
+  - name: crashing
+    resourceType: deployments
+    expression: web
+`
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	loader := NewLoader(dir)
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Bad saved filters should not fail the load: %v", err)
+	}
+
+	filters := loader.SavedFilters()
+	if len(filters) != 1 || filters[0].Name != "crashing" || filters[0].ResourceType != "pods" {
+		t.Fatalf("Expected only the first crashing filter to survive, got %+v", filters)
+	}
+
+	warnings := loader.Warnings()
+	expected := []string{`"broken"`, `"jobs": unknown resource type`, "saved filter 4: name is required", "duplicate name"}
+	if len(warnings) != len(expected) {
+		t.Fatalf("Expected %d warnings, got %v", len(expected), warnings)
+	}
+	for i, want := range expected {
+		if !strings.Contains(warnings[i], want) {
+			t.Errorf("Warning %d: expected %q in %q", i, want, warnings[i])
+		}
+	}
+}
+
+func TestLoaderUserActionsValidation(t *testing.T) {
+	dir := t.TempDir()
+	content := `actions:
+  - name: cost
+    key: C
+    resourceType: pods
+    command: cost --pod {{.Name}}
+  - name: trace
+    command: trace {{.Name}}
+    interactive: false
+  - name: broken
+    command: trace {{.Name
+  - name: jobs
+    resourceType: daemonsets
+    command: jobs
+  - name: same-key
+    key: C
+    command: other
+  - name: cost
+    command: again
+`
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	loader := NewLoader(dir)
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Bad actions should not fail the load: %v", err)
+	}
+
+	actions := loader.UserActions()
+	if len(actions) != 2 || actions[0].Name != "cost" || actions[1].Name != "trace" {
+		t.Fatalf("Expected cost and trace to survive, got %+v", actions)
+	}
+	if !actions[0].IsInteractive() || actions[1].IsInteractive() {
+		t.Error("Expected cost to be interactive and trace to run in the background")
+	}
+	if !actions[0].AppliesTo(core.ResourceTypePod) || actions[0].AppliesTo(core.ResourceTypeDeployment) {
+		t.Error("Expected cost to apply to pods only")
+	}
+	if !actions[1].AppliesTo(core.ResourceTypeDeployment) {
+		t.Error("Expected an action without a resource type to apply to every type")
+	}
+
+	warnings := loader.Warnings()
+	expected := []string{`"broken": invalid command template`, `"jobs": unknown resource type`, `key "C" is already used`, "duplicate name"}
+	if len(warnings) != len(expected) {
+		t.Fatalf("Expected %d warnings, got %v", len(expected), warnings)
+	}
+	for i, want := range expected {
+		if !strings.Contains(warnings[i], want) {
+			t.Errorf("Warning %d: expected %q in %q", i, want, warnings[i])
+		}
+	}
+}
+
+func TestLoaderManifestTemplates(t *testing.T) {
+	dir := t.TempDir()
+	content := `manifestTemplates:
+  - name: Curl pod
+    parameters: [Namespace, URL]
+    manifest: |
+      apiVersion: v1
+      kind: Pod
+      metadata:
+        generateName: curl-
+        namespace: {{.Namespace}}
+      spec:
+        containers:
+        - name: curl
+          image: curlimages/curl
+          args: ["{{.URL}}"]
+  - name: Busybox sleep
+    parameters: [Namespace]
+    manifest: |
+      apiVersion: v1
+      kind: Pod
+      metadata:
+        namespace: {{.Namespace}}
+  - name: undeclared
+    manifest: "namespace: {{.Namespace}}"
+  - name: bad-param
+    parameters: [not-valid]
+    manifest: "kind: Pod"
+  - name: empty
+`
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	loader := NewLoader(dir)
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Bad templates should not fail the load: %v", err)
+	}
+
+	var names []string
+	for _, tmpl := range loader.ManifestTemplates() {
+		names = append(names, tmpl.Name)
+	}
+	expectedNames := []string{"Debug pod", "Curl pod", "Busybox sleep"}
+	if strings.Join(names, ",") != strings.Join(expectedNames, ",") {
+		t.Fatalf("Expected templates %v, got %v", expectedNames, names)
+	}
+	if params := loader.ManifestTemplates()[2].Parameters; len(params) != 1 {
+		t.Errorf("Expected the user's Busybox sleep to replace the built-in, got parameters %v", params)
+	}
+
+	warnings := loader.Warnings()
+	expected := []string{`"undeclared": rendering manifest`, `"bad-param": invalid parameter name`, `"empty": manifest is required`}
+	if len(warnings) != len(expected) {
+		t.Fatalf("Expected %d warnings, got %v", len(expected), warnings)
+	}
+	for i, want := range expected {
+		if !strings.Contains(warnings[i], want) {
+			t.Errorf("Warning %d: expected %q in %q", i, want, warnings[i])
+		}
+	}
+}
+
+func TestManifestTemplateRender(t *testing.T) {
+	for _, tmpl := range builtinManifestTemplates() {
+		if err := tmpl.Validate(); err != nil {
+			t.Errorf("Built-in template %q is invalid: %v", tmpl.Name, err)
+		}
+	}
+
+	debug := builtinManifestTemplates()[0]
+	manifest, err := debug.Render(map[string]string{"Namespace": "payments", "Node": "node-1"})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(manifest, "namespace: payments") || !strings.Contains(manifest, "nodeName: node-1") {
+		t.Errorf("Expected namespace and node in the manifest, got:\n%s", manifest)
+	}
+
+	manifest, err = debug.Render(map[string]string{"Namespace": "payments", "Node": ""})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if strings.Contains(manifest, "nodeName") {
+		t.Errorf("Expected no nodeName without a node, got:\n%s", manifest)
+	}
+
+	if _, err := debug.Render(map[string]string{"Namespace": "payments\nhostNetwork: true", "Node": ""}); err == nil {
+		t.Error("Expected an error for a multi-line value")
+	}
+	if _, err := debug.Render(map[string]string{"Namespace": "payments"}); err == nil {
+		t.Error("Expected an error for a missing parameter")
+	}
+}
+
+func TestLoaderSaveFilter(t *testing.T) {
+	dir := t.TempDir()
+
+	loader := NewLoader(dir)
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := loader.SaveFilter(&SavedFilter{Name: "web", ResourceType: "Pods", Expression: "web"}); err != nil {
+		t.Fatalf("SaveFilter failed: %v", err)
+	}
+	// Saving under the same name replaces the filter
+	if err := loader.SaveFilter(&SavedFilter{Name: "web", ResourceType: "Pods", Expression: "web status=Running", Namespace: "prod"}); err != nil {
+		t.Fatalf("SaveFilter failed: %v", err)
+	}
+	if err := loader.SaveFilter(&SavedFilter{Name: "bad", ResourceType: "Pods", Expression: "=x"}); err == nil {
+		t.Error("Expected invalid filter to be rejected")
+	}
+
+	reloaded := NewLoader(dir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	filters := reloaded.SavedFilters()
+	if len(filters) != 1 {
+		t.Fatalf("Expected 1 saved filter after reload, got %d", len(filters))
+	}
+	if filters[0].Expression != "web status=Running" || filters[0].Namespace != "prod" {
+		t.Errorf("Expected replaced filter, got %+v", filters[0])
+	}
+}
+
+func TestLoaderPodMetricsSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		expectMode     k8s.MetricsHeadline
+		expectSidecars []string
+		expectWarning  string
+	}{
+		{"defaults", "theme: default\n", k8s.HeadlinePod, k8s.DefaultSidecars, ""},
+		{"largest", "settings:\n  metrics:\n    containerUsage: largest\n", k8s.HeadlineLargest, k8s.DefaultSidecars, ""},
+		{"main with sidecars", "settings:\n  metrics:\n    containerUsage: main\n    sidecars: [vault-agent, 'fluent-*']\n", k8s.HeadlineMain, []string{"vault-agent", "fluent-*"}, ""},
+		{"unknown mode falls back", "settings:\n  metrics:\n    containerUsage: biggest\n", k8s.HeadlinePod, k8s.DefaultSidecars, "metrics.containerUsage"},
+		{"bad pattern falls back", "settings:\n  metrics:\n    containerUsage: main\n    sidecars: ['[oops']\n", k8s.HeadlineMain, k8s.DefaultSidecars, "metrics.sidecars"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			loader := NewLoader(dir)
+			if err := loader.Load(); err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+
+			mode, sidecars := loader.PodMetricsSettings()
+			if mode != tt.expectMode || strings.Join(sidecars, ",") != strings.Join(tt.expectSidecars, ",") {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tt.expectMode, tt.expectSidecars, mode, sidecars)
+			}
+
+			warnings := strings.Join(loader.Warnings(), "\n")
+			if tt.expectWarning == "" && warnings != "" {
+				t.Errorf("Expected no warnings, got %s", warnings)
+			}
+			if !strings.Contains(warnings, tt.expectWarning) {
+				t.Errorf("Expected warning containing %q, got %q", tt.expectWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestLoaderTimeFormat(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expect        core.TimeFormat
+		expectWarning string
+	}{
+		{"defaults", "theme: default\n", core.TimeFormat{}, ""},
+		{"12h UTC", "settings:\n  time:\n    clock: 12h\n    zone: utc\n", core.TimeFormat{Clock12: true, UTC: true}, ""},
+		{"custom layout", "settings:\n  time:\n    layout: 'Jan 2 15:04'\n", core.TimeFormat{Layout: "Jan 2 15:04"}, ""},
+		{"bad clock falls back", "settings:\n  time:\n    clock: 13h\n    zone: utc\n", core.TimeFormat{}, "time: unknown clock"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			loader := NewLoader(dir)
+			if err := loader.Load(); err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+
+			if got := loader.TimeFormat(); got != tt.expect {
+				t.Errorf("Expected %+v, got %+v", tt.expect, got)
+			}
+			warnings := strings.Join(loader.Warnings(), "\n")
+			if tt.expectWarning == "" && warnings != "" {
+				t.Errorf("Expected no warnings, got %s", warnings)
+			}
+			if !strings.Contains(warnings, tt.expectWarning) {
+				t.Errorf("Expected warning containing %q, got %q", tt.expectWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestLoaderFocusReporting(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		expect  bool
+	}{
+		{"default", "theme: default\n", true},
+		{"other advanced settings", "settings:\n  advanced:\n    allowFinalizerRemoval: true\n", true},
+		{"turned off", "settings:\n  advanced:\n    focusReporting: false\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			loader := NewLoader(dir)
+			if err := loader.Load(); err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if got := loader.FocusReporting(); got != tt.expect {
+				t.Errorf("Expected focus reporting %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestLoaderUpdateCheck(t *testing.T) {
+	dir := t.TempDir()
+	content := "settings:\n  updates:\n    check: true\n  wordWrap: true\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	loader := NewLoader(dir)
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if enabled, dismissed := loader.UpdateCheck(); !enabled || dismissed != "" {
+		t.Fatalf("Expected the check on with nothing dismissed, got %v %q", enabled, dismissed)
+	}
+
+	// A dismissal is remembered without turning the check off
+	if err := loader.DismissUpdate("0.9.3"); err != nil {
+		t.Fatalf("DismissUpdate failed: %v", err)
+	}
+	reloaded := NewLoader(dir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if enabled, dismissed := reloaded.UpdateCheck(); !enabled || dismissed != "0.9.3" {
+		t.Errorf("Expected 0.9.3 dismissed with the check still on, got %v %q", enabled, dismissed)
+	}
+	if !reloaded.Get().Settings.WordWrap {
+		t.Error("Expected the other settings kept")
+	}
+
+	// Off by default
+	if enabled, _ := NewLoader(t.TempDir()).UpdateCheck(); enabled {
+		t.Error("Expected the check off without a config")
+	}
+}
+
+func TestLoaderQuitKeyBehavior(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expect        core.QuitKeyBehavior
+		expectWarning string
+	}{
+		{"default", "theme: default\n", core.QuitKeyContextual, ""},
+		{"global", "settings:\n  quitKeyBehavior: global\n", core.QuitKeyGlobal, ""},
+		{"unknown", "settings:\n  quitKeyBehavior: sometimes\n", core.QuitKeyContextual, "quitKeyBehavior"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			loader := NewLoader(dir)
+			if err := loader.Load(); err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if got := loader.QuitKeyBehavior(); got != tt.expect {
+				t.Errorf("Expected %v, got %v", tt.expect, got)
+			}
+			warnings := strings.Join(loader.Warnings(), "\n")
+			if tt.expectWarning == "" && warnings != "" {
+				t.Errorf("Expected no warnings, got %s", warnings)
+			}
+			if !strings.Contains(warnings, tt.expectWarning) {
+				t.Errorf("Expected warning containing %q, got %q", tt.expectWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestLoaderColumnOrder(t *testing.T) {
+	dir := t.TempDir()
+	content := "settings:\n  columnOrder:\n    pods: [namespace, NAME, STATUS]\n    services: [NAME, STATUS]\n    widgets: [NAME]\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	loader := NewLoader(dir)
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	orders := loader.ColumnOrders()
+	if got := orders[core.ResourceTypePod]; !slices.Equal(got, []string{"NAMESPACE", "NAME", "STATUS"}) {
+		t.Errorf("Expected the pods order upper-cased, got %v", got)
+	}
+	if len(orders) != 1 {
+		t.Errorf("Expected only the valid order kept, got %v", orders)
+	}
+	warnings := strings.Join(loader.Warnings(), "\n")
+	for _, want := range []string{"columnOrder.services: Services has no STATUS column", "columnOrder.widgets: unknown resource type"} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("Expected a warning containing %q, got %q", want, warnings)
+		}
+	}
+
+	// A saved order replaces the type's, and nil removes it
+	if err := loader.SaveColumnOrder(core.ResourceTypeService, []string{"TYPE", "NAME"}); err != nil {
+		t.Fatalf("SaveColumnOrder failed: %v", err)
+	}
+	if err := loader.SaveColumnOrder(core.ResourceTypePod, nil); err != nil {
+		t.Fatalf("SaveColumnOrder failed: %v", err)
+	}
+	reloaded := NewLoader(dir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	orders = reloaded.ColumnOrders()
+	if len(orders) != 1 || !slices.Equal(orders[core.ResourceTypeService], []string{"TYPE", "NAME"}) {
+		t.Errorf("Expected only the saved services order, got %v", orders)
+	}
+}
+
+func TestLoaderCacheTTL(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expect        time.Duration
+		expectWarning string
+	}{
+		{"default", "theme: default\n", core.DefaultCacheTTL, ""},
+		{"set", "settings:\n  cache:\n    ttl: 30m\n", 30 * time.Minute, ""},
+		{"not a duration", "settings:\n  cache:\n    ttl: soon\n", core.DefaultCacheTTL, "cache.ttl"},
+		{"negative", "settings:\n  cache:\n    ttl: -5m\n", core.DefaultCacheTTL, "cache.ttl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			loader := NewLoader(dir)
+			if err := loader.Load(); err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if got := loader.CacheTTL(); got != tt.expect {
+				t.Errorf("Expected %v, got %v", tt.expect, got)
+			}
+			warnings := strings.Join(loader.Warnings(), "\n")
+			if tt.expectWarning == "" && warnings != "" {
+				t.Errorf("Expected no warnings, got %s", warnings)
+			}
+			if !strings.Contains(warnings, tt.expectWarning) {
+				t.Errorf("Expected warning containing %q, got %q", tt.expectWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestLoaderNotifications(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectOn      bool
+		expect        notify.Options
+		expectWarning string
+	}{
+		{"default off", "theme: default\n", false, notify.Options{}, ""},
+		{"on", "settings:\n  notifications:\n    enabled: true\n", true, notify.Options{Method: notify.MethodAuto}, ""},
+		{"chosen", "settings:\n  notifications:\n    enabled: true\n    alerts: [crashloop, deletion]\n    minInterval: 2m\n    method: osc9\n", true,
+			notify.Options{Categories: []core.AlertCategory{core.AlertCrashLoop, core.AlertDeletion}, MinInterval: 2 * time.Minute, Method: notify.MethodOSC9}, ""},
+		{"bad values", "settings:\n  notifications:\n    enabled: true\n    alerts: [crashloop, oom]\n    minInterval: often\n    method: popup\n", true,
+			notify.Options{Categories: []core.AlertCategory{core.AlertCrashLoop}, Method: notify.MethodAuto}, "notifications.alerts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			loader := NewLoader(dir)
+			if err := loader.Load(); err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			options, on := loader.Notifications()
+			if on != tt.expectOn || !reflect.DeepEqual(options, tt.expect) {
+				t.Errorf("Expected %v %+v, got %v %+v", tt.expectOn, tt.expect, on, options)
+			}
+			warnings := strings.Join(loader.Warnings(), "\n")
+			if tt.expectWarning == "" && warnings != "" {
+				t.Errorf("Expected no warnings, got %s", warnings)
+			}
+			if !strings.Contains(warnings, tt.expectWarning) {
+				t.Errorf("Expected warning containing %q, got %q", tt.expectWarning, warnings)
+			}
+			if tt.expectWarning != "" && (!strings.Contains(warnings, "notifications.minInterval") || !strings.Contains(warnings, "notifications.method")) {
+				t.Errorf("Expected the interval and method warned of, got %q", warnings)
+			}
+		})
+	}
+}
+
+func TestLoaderLogSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectStart   string
+		expectGroup   bool
+		expectColors  bool
+		expectWarning string
+	}{
+		{"defaults", "theme: default\n", "", true, true, ""},
+		{"custom pattern", "settings:\n  logs:\n    recordStart: '^\\d{4}-'\n", `^\d{4}-`, true, true, ""},
+		{"grouping disabled", "settings:\n  logs:\n    groupRecords: false\n", "", false, true, ""},
+		{"colors disabled", "settings:\n  logs:\n    colors: false\n", "", true, false, ""},
+		{"bad pattern falls back", "settings:\n  logs:\n    recordStart: '^(oops'\n", "", true, true, "logs.recordStart"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			loader := NewLoader(dir)
+			if err := loader.Load(); err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+
+			start, group := loader.LogSettings()
+			if start != tt.expectStart || group != tt.expectGroup {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tt.expectStart, tt.expectGroup, start, group)
+			}
+			if colors := loader.LogColors(); colors != tt.expectColors {
+				t.Errorf("Expected colors %v, got %v", tt.expectColors, colors)
+			}
+
+			warnings := strings.Join(loader.Warnings(), "\n")
+			if tt.expectWarning == "" && warnings != "" {
+				t.Errorf("Expected no warnings, got %s", warnings)
+			}
+			if !strings.Contains(warnings, tt.expectWarning) {
+				t.Errorf("Expected warning containing %q, got %q", tt.expectWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestLoaderNoiseRules(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expect        core.NoiseRules
+		expectWarning string
+	}{
+		{"defaults", "theme: default\n", core.NoiseRules{CompletedPods: true}, ""},
+		{
+			"per resource type",
+			"settings:\n  noise:\n    pods:\n      completed: false\n      failedAfter: 1h\n    deployments:\n      scaledToZero: true\n",
+			core.NoiseRules{FailedPodsAfter: time.Hour, ScaledToZeroDeployments: true},
+			"",
+		},
+		{"statefulsets", "settings:\n  noise:\n    statefulSets:\n      scaledToZero: true\n", core.NoiseRules{CompletedPods: true, ScaledToZeroStatefulSets: true}, ""},
+		{"bad duration falls back", "settings:\n  noise:\n    pods:\n      failedAfter: soon\n", core.NoiseRules{CompletedPods: true}, "noise.pods.failedAfter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			loader := NewLoader(dir)
+			if err := loader.Load(); err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+
+			if rules := loader.NoiseRules(); rules != tt.expect {
+				t.Errorf("Expected %+v, got %+v", tt.expect, rules)
+			}
+
+			warnings := strings.Join(loader.Warnings(), "\n")
+			if tt.expectWarning == "" && warnings != "" {
+				t.Errorf("Expected no warnings, got %s", warnings)
+			}
+			if !strings.Contains(warnings, tt.expectWarning) {
+				t.Errorf("Expected warning containing %q, got %q", tt.expectWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestLoaderValidatesTemplates(t *testing.T) {
+	content := `theme: solarized
+templates:
+  pod_describe:
+    template: "{{ .Name }} on {{ .Spec.NodeName }}"
+  deployment_describe:
+    template: |
+      {{ .Name }}
+      Replicas: {{ .Spec.Replica }}
+  banner:
+    template: "{{ shout .Name }}"
+columns:
+  pods:
+    columns:
+      - name: NODE
+        template: "{{ .Spec.Nodename }}"
+settings:
+  shortcuts:
+    - key: ctrl+d
+      action: describe
+    - key: ctrl+d
+      action: logs
+    - key: ctrl+e
+`
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	loader := NewLoader(dir)
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	warnings := strings.Join(loader.Warnings(), "\n")
+	for _, expect := range []string{
+		`template "deployment_describe" at line 2, column 18: <.Spec.Replica>: can't evaluate field Replica`,
+		`template "banner" at line 1: function "shout" not defined`,
+		`column template "pods.NODE"`,
+		`theme "solarized"`,
+		`shortcut "ctrl+d": duplicate key`,
+		`shortcut "ctrl+e": action is required`,
+	} {
+		if !strings.Contains(warnings, expect) {
+			t.Errorf("Expected a warning containing %q, got:\n%s", expect, warnings)
+		}
+	}
+
+	templates := loader.DescribeTemplates()
+	if len(templates) != 1 || templates[core.ResourceTypePod] != "{{ .Name }} on {{ .Spec.NodeName }}" {
+		t.Errorf("Expected only the valid pod template kept, got %v", templates)
+	}
+	config := loader.Get()
+	if config.Theme != "default" || len(config.Settings.Shortcuts) != 1 || config.Columns["pods"].Columns[0].Template != "" {
+		t.Error("Expected the bad theme, shortcuts and column template dropped")
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	good := "templates:\n  pod_describe:\n    template: \"{{ .Name }}\"\nsavedFilters:\n  - name: failing\n    resourceType: pods\n    expression: status=Failed\n"
+	if err := os.WriteFile(path, []byte(good), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	report, err := ValidateFile(path)
+	if err != nil {
+		t.Fatalf("ValidateFile failed: %v", err)
+	}
+	if !report.OK() {
+		t.Errorf("Expected no problems, got %v", report.Problems)
+	}
+	counts := make(map[string]int)
+	for _, section := range report.Sections {
+		counts[section.Name] = section.Count
+	}
+	if counts["templates"] != 1 || counts["saved filters"] != 1 {
+		t.Errorf("Expected one template and one saved filter counted, got %v", report.Sections)
+	}
+
+	if err := os.WriteFile(path, []byte("themes: dark\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	report, err = ValidateFile(path)
+	if err != nil || report.OK() || !strings.Contains(report.Problems[0], "failed to parse config") {
+		t.Errorf("Expected an unknown field reported as a problem, got %v (%v)", report, err)
+	}
+
+	if _, err := ValidateFile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestLoaderStartupLayout(t *testing.T) {
+	dir := t.TempDir()
+	content := "startup:\n  resourceType: deployments\n  contexts: [prod, staging, dev]\n  compare: true\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	loader := NewLoader(dir)
+	if err := loader.Load(); err != nil {
+		t.Fatalf("A bad startup layout should not fail the load: %v", err)
+	}
+	if layout := loader.StartupLayout(); layout != nil {
+		t.Errorf("Expected the bad layout to be dropped, got %+v", layout)
+	}
+	if warnings := strings.Join(loader.Warnings(), "\n"); !strings.Contains(warnings, "startup: compare needs exactly two contexts, not 3") {
+		t.Errorf("Expected a warning about the comparison, got %q", warnings)
+	}
+
+	// A live view saved as the layout reopens the same view
+	link := core.ViewLink{
+		Contexts:      []string{"prod"},
+		Namespace:     "payments",
+		ResourceType:  core.ResourceTypePod,
+		Filter:        "status=CrashLoopBackOff",
+		SortColumn:    "RESTARTS",
+		SortAscending: false,
+		Columns:       []string{"STATUS", "RESTARTS"},
+		Selected:      "checkout-7f9c",
+	}
+	layout := NewStartupLayout(link)
+	layout.Logs = "payments/checkout-7f9c-*"
+	if err := loader.SaveStartupLayout(layout); err != nil {
+		t.Fatalf("SaveStartupLayout failed: %v", err)
+	}
+	if err := loader.SaveStartupLayout(&StartupLayout{ResourceType: "configmaps", Logs: "web"}); err == nil {
+		t.Error("Expected logs of a type without logs to be rejected")
+	}
+
+	reloaded := NewLoader(dir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	got := reloaded.StartupLayout()
+	want := &StartupLayout{
+		ResourceType: "pods",
+		Namespace:    "payments",
+		Contexts:     []string{"prod"},
+		Filter:       "status=CrashLoopBackOff",
+		Sort:         "RESTARTS:desc",
+		Columns:      []string{"STATUS", "RESTARTS"},
+		Logs:         "payments/checkout-7f9c-*",
+	}
+	if got == nil || got.ResourceType != want.ResourceType || got.Namespace != want.Namespace ||
+		!slices.Equal(got.Contexts, want.Contexts) || got.Filter != want.Filter || got.Sort != want.Sort ||
+		!slices.Equal(got.Columns, want.Columns) || got.Logs != want.Logs || got.Compare {
+		t.Errorf("Expected %+v after reload, got %+v", want, got)
+	}
+
+	// Every namespace is saved as "all"
+	if all := NewStartupLayout(core.ViewLink{ResourceType: core.ResourceTypeService, SortColumn: "NAME", SortAscending: true}); all.Namespace != "all" || all.Sort != "" {
+		t.Errorf("Expected namespace all and the default sort left out, got %+v", all)
+	}
+}
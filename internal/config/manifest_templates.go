@@ -0,0 +1,158 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// ManifestTemplate is a resource manifest created from the template picker,
+// such as a throwaway debug pod. Parameters are filled in from a small form
+// and replace placeholders like {{.Namespace}} and {{.Node}}.
+type ManifestTemplate struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Parameters  []string `yaml:"parameters,omitempty"` // Asked for in the form, in order
+	Manifest    string   `yaml:"manifest"`             // One or more YAML documents
+}
+
+// parameterNamePattern matches parameter names usable as {{.Name}} placeholders
+var parameterNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Validate checks that the template has a name, well-formed parameter names,
+// and a manifest that renders using only its declared parameters
+func (t *ManifestTemplate) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if strings.TrimSpace(t.Manifest) == "" {
+		return fmt.Errorf("manifest is required")
+	}
+	values := make(map[string]string, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if !parameterNamePattern.MatchString(p) {
+			return fmt.Errorf("invalid parameter name %q", p)
+		}
+		values[p] = ""
+	}
+	_, err := t.Render(values)
+	return err
+}
+
+// Render fills in the manifest's placeholders. Every placeholder must have a
+// value, and values must fit on one line so they cannot add YAML fields.
+func (t *ManifestTemplate) Render(values map[string]string) (string, error) {
+	for name, value := range values {
+		if strings.ContainsAny(value, "\r\n") {
+			return "", fmt.Errorf("parameter %s must be a single line", name)
+		}
+	}
+
+	tmpl, err := template.New(t.Name).Option("missingkey=error").Parse(t.Manifest)
+	if err != nil {
+		return "", fmt.Errorf("invalid manifest template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, values); err != nil {
+		return "", fmt.Errorf("rendering manifest: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// validateManifestTemplates drops templates that cannot be rendered and
+// describes why
+func validateManifestTemplates(templates []*ManifestTemplate) ([]*ManifestTemplate, []string) {
+	var valid []*ManifestTemplate
+	var warnings []string
+	seen := make(map[string]bool)
+
+	for i, t := range templates {
+		if t == nil {
+			continue
+		}
+		label := fmt.Sprintf("manifest template %d", i+1)
+		if t.Name != "" {
+			label = fmt.Sprintf("manifest template %q", t.Name)
+		}
+		if err := t.Validate(); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", label, err))
+			continue
+		}
+		if seen[t.Name] {
+			warnings = append(warnings, fmt.Sprintf("%s: duplicate name", label))
+			continue
+		}
+		seen[t.Name] = true
+		valid = append(valid, t)
+	}
+
+	return valid, warnings
+}
+
+// builtinManifestTemplates are offered in every config. A user template with
+// the same name replaces one of these.
+func builtinManifestTemplates() []*ManifestTemplate {
+	return []*ManifestTemplate{
+		{
+			Name:        "Debug pod",
+			Description: "netshoot with network tools, sleeping for an hour",
+			Parameters:  []string{"Namespace", "Node"},
+			Manifest: `apiVersion: v1
+kind: Pod
+metadata:
+  generateName: debug-
+  namespace: {{.Namespace}}
+spec:
+{{- if .Node}}
+  nodeName: {{.Node}}
+{{- end}}
+  restartPolicy: Never
+  terminationGracePeriodSeconds: 0
+  containers:
+  - name: debug
+    image: nicolaka/netshoot
+    command: ["sleep", "3600"]
+`,
+		},
+		{
+			Name:        "Busybox sleep",
+			Description: "Minimal busybox pod, sleeping for an hour",
+			Parameters:  []string{"Namespace", "Node"},
+			Manifest: `apiVersion: v1
+kind: Pod
+metadata:
+  generateName: busybox-
+  namespace: {{.Namespace}}
+spec:
+{{- if .Node}}
+  nodeName: {{.Node}}
+{{- end}}
+  restartPolicy: Never
+  terminationGracePeriodSeconds: 0
+  containers:
+  - name: busybox
+    image: busybox
+    command: ["sleep", "3600"]
+`,
+		},
+	}
+}
+
+// mergeManifestTemplates returns the built-in templates followed by the user's,
+// with user templates replacing built-ins of the same name
+func mergeManifestTemplates(user []*ManifestTemplate) []*ManifestTemplate {
+	overridden := make(map[string]bool, len(user))
+	for _, t := range user {
+		overridden[t.Name] = true
+	}
+
+	var merged []*ManifestTemplate
+	for _, t := range builtinManifestTemplates() {
+		if !overridden[t.Name] {
+			merged = append(merged, t)
+		}
+	}
+	return append(merged, user...)
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+)
+
+// StartupLayout is the view kubewatch opens into: the persisted form of the
+// view flags, plus a log split or comparison to open once the list loads.
+// Flags given on the command line win over it.
+type StartupLayout struct {
+	ResourceType string   `yaml:"resourceType,omitempty"`
+	Namespace    string   `yaml:"namespace,omitempty"` // "all" for every namespace
+	Contexts     []string `yaml:"contexts,omitempty"`
+	Compare      bool     `yaml:"compare,omitempty"` // Compare the two contexts side by side
+	Filter       string   `yaml:"filter,omitempty"`
+	Selector     string   `yaml:"selector,omitempty"` // Label selector, as kubectl -l takes it
+	Sort         string   `yaml:"sort,omitempty"`     // COLUMN:asc or COLUMN:desc
+	Columns      []string `yaml:"columns,omitempty"`
+	Logs         string   `yaml:"logs,omitempty"` // Open the log split for the first resource matching, e.g. "payments/checkout-*"
+}
+
+// NewStartupLayout returns the layout that reopens the view link describes.
+// The selection is left out: a layout names what to show, not where the
+// cursor was.
+func NewStartupLayout(link core.ViewLink) *StartupLayout {
+	layout := &StartupLayout{
+		Namespace: link.Namespace,
+		Contexts:  link.Contexts,
+		Filter:    strings.TrimSpace(link.Filter),
+		Selector:  link.LabelSelector,
+		Columns:   link.Columns,
+	}
+	if layout.Namespace == "" {
+		layout.Namespace = "all"
+	}
+	if link.ResourceType != "" {
+		layout.ResourceType = strings.ToLower(string(link.ResourceType))
+	}
+	if link.SortColumn != "" && (link.SortColumn != "NAME" || !link.SortAscending) {
+		layout.Sort = core.FormatSort(link.SortColumn, link.SortAscending)
+	}
+	return layout
+}
+
+// Type returns the resource type the layout shows; pods when it names none
+func (s *StartupLayout) Type() core.ResourceType {
+	if resourceType, ok := core.ParseResourceType(s.ResourceType); ok {
+		return resourceType
+	}
+	return core.ResourceTypePod
+}
+
+// Validate checks what can be checked before connecting: the resource type,
+// filter, sort and columns, and that a comparison names two contexts. Whether
+// the contexts and the resource to show the logs of exist is only known once
+// the list loads.
+func (s *StartupLayout) Validate() error {
+	if s.ResourceType != "" {
+		if _, ok := core.ParseResourceType(s.ResourceType); !ok {
+			return fmt.Errorf("unknown resource type %q", s.ResourceType)
+		}
+	}
+	if _, err := core.ParseFilter(s.Filter); err != nil {
+		return err
+	}
+	if s.Sort != "" {
+		if _, _, err := core.ParseSort(s.Sort); err != nil {
+			return err
+		}
+	}
+	for _, column := range s.Columns {
+		if !core.IsResourceColumn(s.Type(), strings.ToUpper(column)) {
+			return fmt.Errorf("%s has no %s column", s.Type(), column)
+		}
+	}
+	if s.Compare && len(s.Contexts) != 2 {
+		return fmt.Errorf("compare needs exactly two contexts, not %d", len(s.Contexts))
+	}
+	if s.Compare && s.Logs != "" {
+		return fmt.Errorf("logs cannot be opened in a comparison")
+	}
+	if s.Logs != "" && !core.LogTargetFor(s.Type()).Applies() {
+		return fmt.Errorf("%s have no logs", s.Type())
+	}
+	return nil
+}
+
+// StartupLayout returns the valid startup layout from the config, or nil
+func (l *Loader) StartupLayout() *StartupLayout {
+	return l.Get().Startup
+}
+
+// SaveStartupLayout sets the startup layout of the user config, replacing
+// any before it, and writes it to disk
+func (l *Loader) SaveStartupLayout(layout *StartupLayout) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	if l.user == nil {
+		l.user = &Config{}
+	}
+	l.user.Startup = layout
+	l.merged = l.mergeConfigs(l.defaults, l.user)
+	l.mu.Unlock()
+
+	return l.Save()
+}
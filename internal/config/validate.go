@@ -0,0 +1,196 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/template"
+	"gopkg.in/yaml.v3"
+)
+
+// knownThemes are the theme names the config accepts
+var knownThemes = []string{"default", "dark", "light", "high-contrast"}
+
+// validateTemplates drops the user templates that do not parse or, for a
+// resource type's describe template, do not render that type's sample
+// object, and describes why with the line and the function or field at
+// fault. Templates are checked in name order so the warnings are stable.
+func validateTemplates(templates map[string]*TemplateConfig) (map[string]*TemplateConfig, []string) {
+	var warnings []string
+	engine := template.NewEngine()
+
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		t := templates[name]
+		if t == nil || strings.TrimSpace(t.Template) == "" {
+			warnings = append(warnings, fmt.Sprintf("template %q: template is required", name))
+			delete(templates, name)
+			continue
+		}
+		kind, _ := template.DescribeTemplateKind(name)
+		if err := engine.ValidateUserTemplate(name, t.Template, kind); err != nil {
+			warnings = append(warnings, err.Error())
+			delete(templates, name)
+		}
+	}
+	return templates, warnings
+}
+
+// validateColumnTemplates clears the templates of custom columns that do not
+// render a sample object of the column's resource type, and describes why
+func validateColumnTemplates(columns map[string]*ColumnConfig) []string {
+	var warnings []string
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	engine := template.NewEngine()
+	for _, name := range names {
+		col := columns[name]
+		kind, _ := core.ParseResourceType(col.ResourceType)
+		for _, def := range col.Columns {
+			if def.Template == "" {
+				continue
+			}
+			label := fmt.Sprintf("%s.%s", name, def.Name)
+			if err := engine.ValidateUserTemplate(label, def.Template, kind); err != nil {
+				warnings = append(warnings, fmt.Sprintf("column %s", err))
+				def.Template = ""
+			}
+		}
+	}
+	return warnings
+}
+
+// validateShortcuts drops shortcuts without a key or action, or whose key
+// another shortcut already uses, and describes why
+func validateShortcuts(shortcuts []*Shortcut) ([]*Shortcut, []string) {
+	var valid []*Shortcut
+	var warnings []string
+	seen := make(map[string]bool)
+
+	for i, s := range shortcuts {
+		if s == nil {
+			continue
+		}
+		label := fmt.Sprintf("shortcut %d", i+1)
+		if s.Key != "" {
+			label = fmt.Sprintf("shortcut %q", s.Key)
+		}
+		switch {
+		case s.Key == "":
+			warnings = append(warnings, fmt.Sprintf("%s: key is required", label))
+		case s.Action == "":
+			warnings = append(warnings, fmt.Sprintf("%s: action is required", label))
+		case seen[s.Key]:
+			warnings = append(warnings, fmt.Sprintf("%s: duplicate key", label))
+		default:
+			seen[s.Key] = true
+			valid = append(valid, s)
+		}
+	}
+	return valid, warnings
+}
+
+// validateTheme returns a warning for a theme kubewatch does not know
+func validateTheme(theme string) string {
+	for _, known := range knownThemes {
+		if theme == known {
+			return ""
+		}
+	}
+	return fmt.Sprintf("theme %q is not one of %s", theme, strings.Join(knownThemes, ", "))
+}
+
+// ValidationSection is how many entries of one kind a config file has
+type ValidationSection struct {
+	Name  string
+	Count int
+}
+
+// ValidationReport is what validating a config file found: the entries of
+// each kind checked and every problem, whether it would stop kubewatch from
+// starting or only drop the entry
+type ValidationReport struct {
+	Path     string
+	Sections []ValidationSection
+	Problems []string
+}
+
+// OK returns true when the config has no problems
+func (r *ValidationReport) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// ValidateFile checks the config file at path as kubewatch would load it,
+// for `kubewatch validate-config`. Only a file that cannot be read is an
+// error; a file that does not parse is reported as a problem.
+func ValidateFile(path string) (*ValidationReport, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	report := &ValidationReport{Path: path}
+	var config Config
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil {
+		report.Problems = append(report.Problems, fmt.Sprintf("failed to parse config: %v", err))
+		return report, nil
+	}
+
+	columnTemplates := 0
+	for _, col := range config.Columns {
+		for _, def := range col.Columns {
+			if def.Template != "" {
+				columnTemplates++
+			}
+		}
+	}
+	shortcuts := 0
+	if config.Settings != nil {
+		shortcuts = len(config.Settings.Shortcuts)
+	}
+	themes := 0
+	if config.Theme != "" {
+		themes = 1
+	}
+	report.Sections = []ValidationSection{
+		{"templates", len(config.Templates) + columnTemplates},
+		{"keymaps", shortcuts + len(config.Actions)},
+		{"saved filters", len(config.SavedFilters)},
+		{"themes", themes},
+		{"manifest templates", len(config.ManifestTemplates)},
+	}
+
+	loader := &Loader{}
+	if err := loader.validateConfig(&config); err != nil {
+		report.Problems = append(report.Problems, err.Error())
+	}
+	report.Problems = append(report.Problems, config.Warnings()...)
+	return report, nil
+}
+
+// DescribeTemplates returns the user's templates that replace a resource
+// type's describe output, by resource type
+func (l *Loader) DescribeTemplates() map[core.ResourceType]string {
+	templates := make(map[core.ResourceType]string)
+	for name, t := range l.Get().Templates {
+		if kind, ok := template.DescribeTemplateKind(name); ok && t != nil {
+			templates[kind] = t.Template
+		}
+	}
+	return templates
+}
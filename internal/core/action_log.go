@@ -0,0 +1,71 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// maxActionLogEntries caps the actions kept for the session
+const maxActionLogEntries = 500
+
+// ActionLogEntry records one change made to the cluster from kubewatch
+type ActionLogEntry struct {
+	Time      time.Time
+	Action    string // e.g. "delete", "remove finalizer"
+	Context   string
+	Namespace string
+	Resource  string // Resource type, e.g. "Pods"
+	Name      string
+	Detail    string // What exactly was changed, e.g. the finalizer removed
+	Err       error  // Nil when the action succeeded
+}
+
+// String formats the entry as a single audit line
+func (e ActionLogEntry) String() string {
+	target := fmt.Sprintf("%s %s/%s", e.Resource, e.Namespace, e.Name)
+	if e.Context != "" {
+		target = e.Context + ": " + target
+	}
+	line := fmt.Sprintf("%s %s %s", FormatTimestamp(e.Time), e.Action, target)
+	if e.Detail != "" {
+		line += " (" + e.Detail + ")"
+	}
+	if e.Err != nil {
+		return line + " failed: " + e.Err.Error()
+	}
+	return line + " succeeded"
+}
+
+// ActionLog is the session's audit trail of changes made to the cluster,
+// successful or not. It is safe for concurrent use.
+type ActionLog struct {
+	mu      sync.Mutex
+	entries []ActionLogEntry
+}
+
+// NewActionLog creates an empty action log
+func NewActionLog() *ActionLog {
+	return &ActionLog{}
+}
+
+// Record adds an entry, stamping it with the current time if it has none
+func (l *ActionLog) Record(entry ActionLogEntry) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+	if len(l.entries) > maxActionLogEntries {
+		l.entries = append([]ActionLogEntry(nil), l.entries[len(l.entries)-maxActionLogEntries:]...)
+	}
+}
+
+// Entries returns the recorded entries, oldest first
+func (l *ActionLog) Entries() []ActionLogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]ActionLogEntry(nil), l.entries...)
+}
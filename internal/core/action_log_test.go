@@ -0,0 +1,31 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestActionLogEntryString(t *testing.T) {
+	defer SetTimeFormat(TimeFormat{})
+	SetTimeFormat(TimeFormat{UTC: true})
+
+	entry := ActionLogEntry{
+		Time:      time.Date(2024, 3, 5, 14, 2, 11, 0, time.UTC),
+		Action:    "remove finalizer",
+		Context:   "east",
+		Namespace: "prod",
+		Resource:  "Pods",
+		Name:      "web-0",
+		Detail:    "example.com/cleanup",
+	}
+	want := "2024-03-05 14:02:11 UTC remove finalizer east: Pods prod/web-0 (example.com/cleanup) succeeded"
+	if got := entry.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	entry.Err = errors.New("forbidden")
+	if got := entry.String(); got != want[:len(want)-len("succeeded")]+"failed: forbidden" {
+		t.Errorf("Expected the failure, got %q", got)
+	}
+}
@@ -0,0 +1,198 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxActivityEntries is how many transitions the activity feed keeps
+	MaxActivityEntries = 4
+
+	// ActivityBurstThreshold is how many resources making the same
+	// transition in one refresh are summarized as one entry, so a large
+	// rollout does not scroll the feed uselessly
+	ActivityBurstThreshold = 3
+)
+
+// activityWarningStatuses are the statuses a transition to is a warning
+var activityWarningStatuses = map[string]bool{
+	"Failed":                     true,
+	"Error":                      true,
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"OOMKilled":                  true,
+	"Evicted":                    true,
+	"CreateContainerConfigError": true,
+	"Unknown":                    true,
+}
+
+// ActivityRow is what the activity feed follows of a listed resource: its
+// READY and STATUS cells, empty when the list has no such column
+type ActivityRow struct {
+	Ref    ResourceRef
+	Ready  string
+	Status string
+}
+
+// ActivityEntry is a line of the activity feed
+type ActivityEntry struct {
+	At time.Time
+	// The resource the entry is about; the first of them for a burst
+	Ref     ResourceRef
+	Text    string // e.g. "pod checkout-7f9c Ready" or "+37 pods became Ready"
+	Warning bool
+	Count   int // How many resources made the transition
+}
+
+// Line renders the entry as shown, e.g. "14:02:11 pod checkout-7f9c Ready"
+func (e ActivityEntry) Line() string {
+	return FormatClock(e.At) + " " + e.Text
+}
+
+// ActivityFeed turns the READY and STATUS changes seen between refreshes
+// of a list into a short feed of transitions, summarizing bursts. A list of
+// another type or namespace starts over: its first refresh only sets what
+// later ones are compared with.
+type ActivityFeed struct {
+	key     string
+	rows    map[ResourceRef]ActivityRow
+	entries []ActivityEntry
+}
+
+// NewActivityFeed creates an empty activity feed
+func NewActivityFeed() *ActivityFeed {
+	return &ActivityFeed{}
+}
+
+// Observe compares the rows of a refresh with those of the last one for the
+// same list, keyed by key, and records their transitions. kind is the
+// list's resource type; rows may be changed.
+func (f *ActivityFeed) Observe(key string, kind ResourceType, rows []ActivityRow, now time.Time) {
+	previous := f.rows
+	f.rows = make(map[ResourceRef]ActivityRow, len(rows))
+	for i := range rows {
+		// How long a deletion has been stuck grows on every refresh
+		if IsStuckTerminating(rows[i].Status) {
+			rows[i].Status = "stuck terminating"
+		}
+		f.rows[rows[i].Ref] = rows[i]
+	}
+	if key != f.key || previous == nil {
+		f.key = key
+		f.entries = nil
+		return
+	}
+
+	// Transitions to the same state are grouped, in the order first seen
+	groups := make(map[string][]ResourceRef)
+	var order []string
+	for _, row := range rows {
+		before, known := previous[row.Ref]
+		if known && before == row {
+			continue
+		}
+		state := activityState(before, row, known)
+		if state == "" {
+			continue
+		}
+		if _, ok := groups[state]; !ok {
+			order = append(order, state)
+		}
+		groups[state] = append(groups[state], row.Ref)
+	}
+
+	singular := activityKind(kind)
+	for _, state := range order {
+		refs := groups[state]
+		entry := ActivityEntry{At: now, Ref: refs[0], Warning: activityWarning(state), Count: len(refs)}
+		if len(refs) >= ActivityBurstThreshold {
+			entry.Text = fmt.Sprintf("+%d %ss became %s", len(refs), singular, state)
+			f.add(entry)
+			continue
+		}
+		for _, ref := range refs {
+			entry.Ref, entry.Count = ref, 1
+			entry.Text = fmt.Sprintf("%s %s %s", singular, ref.Name, state)
+			f.add(entry)
+		}
+	}
+}
+
+// add appends an entry, dropping the oldest beyond MaxActivityEntries
+func (f *ActivityFeed) add(entry ActivityEntry) {
+	f.entries = append(f.entries, entry)
+	if excess := len(f.entries) - MaxActivityEntries; excess > 0 {
+		f.entries = f.entries[excess:]
+	}
+}
+
+// Entries returns the feed, oldest first
+func (f *ActivityFeed) Entries() []ActivityEntry {
+	return append([]ActivityEntry(nil), f.entries...)
+}
+
+// Reset forgets the feed and what it compares with
+func (f *ActivityFeed) Reset() {
+	f.key = ""
+	f.rows = nil
+	f.entries = nil
+}
+
+// activityState returns the state a row moved to, or "" when nothing the
+// feed follows changed. A change of readiness is the news unless the status
+// moved elsewhere than Running; a resource that was not listed before
+// reports its status.
+func activityState(before, after ActivityRow, known bool) string {
+	readyChanged := !known || readyCount(before.Ready) != readyCount(after.Ready)
+	statusChanged := !known || before.Status != after.Status
+	switch {
+	case !known && after.Status != "":
+		return after.Status
+	case statusChanged && after.Status != "" && !(readyChanged && after.Status == "Running"):
+		return after.Status
+	case readyChanged && after.Ready != "":
+		return readyState(after.Ready)
+	}
+	return ""
+}
+
+// readyCount returns the "ready/total" part of a READY cell, without the
+// names of unready containers a pod's cell may list
+func readyCount(cell string) string {
+	count, _, _ := strings.Cut(cell, " ")
+	return count
+}
+
+// readyState describes a READY cell: "Ready" when everything is, "Not
+// ready" when nothing is, else "Ready 2/3"
+func readyState(cell string) string {
+	var ready, total int
+	if _, err := fmt.Sscanf(readyCount(cell), "%d/%d", &ready, &total); err != nil {
+		return "Ready " + readyCount(cell)
+	}
+	switch {
+	case ready == total:
+		return "Ready"
+	case ready == 0:
+		return "Not ready"
+	}
+	return fmt.Sprintf("Ready %d/%d", ready, total)
+}
+
+// activityWarning reports whether a transition to state needs a look
+func activityWarning(state string) bool {
+	return activityWarningStatuses[state] || state == "Not ready" || IsStuckTerminating(state)
+}
+
+// activityKind returns the singular, lower-case name of a resource type,
+// as in "pod" or "ingress"
+func activityKind(kind ResourceType) string {
+	name := strings.ToLower(string(kind))
+	if strings.HasSuffix(name, "sses") {
+		return strings.TrimSuffix(name, "es")
+	}
+	return strings.TrimSuffix(name, "s")
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestActivityFeedTransitions(t *testing.T) {
+	start := time.Date(2024, 3, 5, 14, 2, 11, 0, time.Local)
+	pod := func(name, ready, status string) ActivityRow {
+		return ActivityRow{Ref: ResourceRef{Namespace: "web", Name: name}, Ready: ready, Status: status}
+	}
+	feed := NewActivityFeed()
+
+	// The first refresh is only compared with
+	feed.Observe("Pods/web", ResourceTypePod, []ActivityRow{
+		pod("checkout-7f9c", "0/1", "ContainerCreating"),
+		pod("checkout-2a1b", "1/1", "Running"),
+		pod("cart-1", "1/1", "Running"),
+	}, start)
+	if entries := feed.Entries(); len(entries) != 0 {
+		t.Fatalf("Expected no entries from the first refresh, got %v", entries)
+	}
+
+	feed.Observe("Pods/web", ResourceTypePod, []ActivityRow{
+		pod("checkout-7f9c", "1/1", "Running"),
+		pod("checkout-2a1b", "0/1", "CrashLoopBackOff"),
+		pod("cart-1", "1/1", "Running"),
+		pod("cart-2", "0/1", "Pending"),
+	}, start.Add(4*time.Second))
+
+	want := []struct {
+		line    string
+		warning bool
+	}{
+		{"14:02:15 pod checkout-7f9c Ready", false},
+		{"14:02:15 pod checkout-2a1b CrashLoopBackOff", true},
+		{"14:02:15 pod cart-2 Pending", false},
+	}
+	entries := feed.Entries()
+	if len(entries) != len(want) {
+		t.Fatalf("Expected %d entries, got %v", len(want), entries)
+	}
+	for i, w := range want {
+		if entries[i].Line() != w.line || entries[i].Warning != w.warning {
+			t.Errorf("Entry %d: got %q (warning %v), want %q (warning %v)", i, entries[i].Line(), entries[i].Warning, w.line, w.warning)
+		}
+	}
+	if entries[1].Ref.Name != "checkout-2a1b" {
+		t.Errorf("Expected the entry to refer to its pod, got %+v", entries[1].Ref)
+	}
+
+	// Another list starts over
+	feed.Observe("Deployments/web", ResourceTypeDeployment, nil, start.Add(time.Minute))
+	if entries := feed.Entries(); len(entries) != 0 {
+		t.Errorf("Expected a new list to start over, got %v", entries)
+	}
+}
+
+func TestActivityFeedSummarizesBursts(t *testing.T) {
+	now := time.Now()
+	rows := func(ready string, n int) []ActivityRow {
+		var rows []ActivityRow
+		for i := 0; i < n; i++ {
+			rows = append(rows, ActivityRow{Ref: ResourceRef{Name: fmt.Sprintf("checkout-%d", i)}, Ready: ready, Status: "Running"})
+		}
+		return rows
+	}
+	feed := NewActivityFeed()
+	feed.Observe("Pods/web", ResourceTypePod, rows("0/1", 37), now)
+	feed.Observe("Pods/web", ResourceTypePod, rows("1/1", 37), now)
+
+	entries := feed.Entries()
+	if len(entries) != 1 || entries[0].Text != "+37 pods became Ready" || entries[0].Count != 37 {
+		t.Fatalf("Expected one summary of the burst, got %v", entries)
+	}
+	if entries[0].Ref.Name != "checkout-0" {
+		t.Errorf("Expected the summary to refer to the first pod, got %+v", entries[0].Ref)
+	}
+
+	// Nothing changing adds nothing, and the feed keeps its last entries
+	feed.Observe("Pods/web", ResourceTypePod, rows("1/1", 37), now)
+	for i := 0; i < MaxActivityEntries+2; i++ {
+		ready := "0/1"
+		if i%2 == 1 {
+			ready = "1/1"
+		}
+		feed.Observe("Pods/web", ResourceTypePod, rows(ready, 1), now)
+	}
+	if entries := feed.Entries(); len(entries) != MaxActivityEntries {
+		t.Errorf("Expected the feed capped at %d entries, got %d", MaxActivityEntries, len(entries))
+	}
+}
+
+func TestActivityFeedStuckTerminating(t *testing.T) {
+	now := time.Now()
+	feed := NewActivityFeed()
+	ref := ResourceRef{Name: "old"}
+	feed.Observe("Pods/web", ResourceTypePod, []ActivityRow{{Ref: ref, Ready: "1/1", Status: "Terminating"}}, now)
+	feed.Observe("Pods/web", ResourceTypePod, []ActivityRow{{Ref: ref, Ready: "1/1", Status: "stuck terminating (5m)"}}, now)
+	feed.Observe("Pods/web", ResourceTypePod, []ActivityRow{{Ref: ref, Ready: "1/1", Status: "stuck terminating (6m)"}}, now)
+
+	entries := feed.Entries()
+	if len(entries) != 1 || entries[0].Text != "pod old stuck terminating" || !entries[0].Warning {
+		t.Errorf("Expected one warning as the deletion got stuck, got %v", entries)
+	}
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// UnknownAge is shown for objects without a creation timestamp
+	UnknownAge = "<unknown>"
+
+	// ClockSkewTolerance is how far in the future a timestamp may be before it
+	// counts as evidence of clock skew, allowing for ordinary NTP drift
+	ClockSkewTolerance = 5 * time.Second
+
+	// ClockSkewMinObjects is how many future-dated objects it takes to report
+	// skew, so a single odd timestamp does not trigger a warning
+	ClockSkewMinObjects = 3
+)
+
+// ageOffset is added to every age when clock skew correction is enabled
+var ageOffset atomic.Int64
+
+// SetAgeOffset sets the correction added to displayed ages. A cluster whose
+// clock runs ahead of ours needs a positive offset.
+func SetAgeOffset(offset time.Duration) {
+	ageOffset.Store(int64(offset))
+}
+
+// AgeOffset returns the correction added to displayed ages
+func AgeOffset() time.Duration {
+	return time.Duration(ageOffset.Load())
+}
+
+// FormatAge returns a kubectl-style age for an object created at t, e.g.
+// "5m" or "3d". Timestamps in the future show as "0s".
+func FormatAge(t time.Time) string {
+	if t.IsZero() {
+		return UnknownAge
+	}
+	var buf [8]byte
+	return string(AppendAge(buf[:0], t, time.Now()))
+}
+
+// AppendAge appends the age FormatAge would show at now to dst, for callers
+// formatting many ages that want to reuse one buffer
+func AppendAge(dst []byte, t, now time.Time) []byte {
+	if t.IsZero() {
+		return append(dst, UnknownAge...)
+	}
+	return AppendDuration(dst, now.Sub(t)+AgeOffset())
+}
+
+// FormatDuration formats d in the largest whole unit (s, m, h, d, mo, y).
+// Negative durations are clamped to "0s".
+func FormatDuration(d time.Duration) string {
+	var buf [8]byte
+	return string(AppendDuration(buf[:0], d))
+}
+
+// AppendDuration appends d as FormatDuration formats it to dst
+func AppendDuration(dst []byte, d time.Duration) []byte {
+	if d < 0 {
+		d = 0
+	}
+
+	var n int64
+	var unit string
+	switch {
+	case d < time.Minute:
+		n, unit = int64(d/time.Second), "s"
+	case d < time.Hour:
+		n, unit = int64(d/time.Minute), "m"
+	case d < 24*time.Hour:
+		n, unit = int64(d/time.Hour), "h"
+	case d < 30*24*time.Hour:
+		n, unit = int64(d/(24*time.Hour)), "d"
+	case d < 365*24*time.Hour:
+		n, unit = int64(d/(30*24*time.Hour)), "mo"
+	default:
+		n, unit = int64(d/(365*24*time.Hour)), "y"
+	}
+	return append(strconv.AppendInt(dst, n, 10), unit...)
+}
+
+// ClockSkewDetector looks for a cluster clock running ahead of ours, which
+// shows up as freshly created objects with creation timestamps in the future.
+// It reports skew once per session.
+type ClockSkewDetector struct {
+	mu       sync.Mutex
+	reported bool
+	offset   time.Duration
+}
+
+// NewClockSkewDetector creates a clock skew detector
+func NewClockSkewDetector() *ClockSkewDetector {
+	return &ClockSkewDetector{}
+}
+
+// Observe checks creation timestamps against now. It returns the measured
+// offset and true the first time enough objects are dated in the future;
+// after that it always returns false.
+func (d *ClockSkewDetector) Observe(timestamps []time.Time, now time.Time) (time.Duration, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.reported {
+		return 0, false
+	}
+
+	future := 0
+	var offset time.Duration
+	for _, t := range timestamps {
+		if t.IsZero() {
+			continue
+		}
+		ahead := t.Sub(now)
+		if ahead <= ClockSkewTolerance {
+			continue
+		}
+		future++
+		// The newest object was created closest to "now" on the cluster's
+		// clock, so it gives the best estimate of the offset
+		if ahead > offset {
+			offset = ahead
+		}
+	}
+
+	if future < ClockSkewMinObjects {
+		return 0, false
+	}
+
+	d.reported = true
+	d.offset = offset
+	return offset, true
+}
+
+// Offset returns the skew measured when it was reported, or 0
+func (d *ClockSkewDetector) Offset() time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.offset
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		name     string
+		created  time.Time
+		expected string
+	}{
+		{"zero timestamp", time.Time{}, UnknownAge},
+		{"future timestamp", time.Now().Add(10 * time.Minute), "0s"},
+		{"seconds", time.Now().Add(-30 * time.Second), "30s"},
+		{"minutes", time.Now().Add(-5 * time.Minute), "5m"},
+		{"hours", time.Now().Add(-3 * time.Hour), "3h"},
+		{"days", time.Now().Add(-4 * 24 * time.Hour), "4d"},
+		{"months", time.Now().Add(-90 * 24 * time.Hour), "3mo"},
+		{"extreme past", time.Now().Add(-200 * 365 * 24 * time.Hour), "200y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatAge(tt.created); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFormatDurationClampsNegative(t *testing.T) {
+	if got := FormatDuration(-time.Hour); got != "0s" {
+		t.Errorf("Expected 0s for a negative duration, got %q", got)
+	}
+	if got := FormatDuration(0); got != "0s" {
+		t.Errorf("Expected 0s for a zero duration, got %q", got)
+	}
+}
+
+func TestFormatAgeWithOffset(t *testing.T) {
+	defer SetAgeOffset(0)
+
+	// A cluster two minutes ahead stamps a just-created object two minutes in our future
+	created := time.Now().Add(2 * time.Minute)
+	if got := FormatAge(created); got != "0s" {
+		t.Fatalf("Expected 0s without correction, got %q", got)
+	}
+
+	SetAgeOffset(2*time.Minute + 30*time.Second)
+	if got := FormatAge(created); got != "30s" {
+		t.Errorf("Expected 30s with correction, got %q", got)
+	}
+}
+
+func TestClockSkewDetector(t *testing.T) {
+	now := time.Now()
+	ahead := func(d time.Duration) time.Time { return now.Add(d) }
+
+	t.Run("ignores drift within tolerance", func(t *testing.T) {
+		d := NewClockSkewDetector()
+		_, detected := d.Observe([]time.Time{ahead(time.Second), ahead(2 * time.Second), ahead(3 * time.Second)}, now)
+		if detected {
+			t.Error("Expected small drift to be ignored")
+		}
+	})
+
+	t.Run("needs several future objects", func(t *testing.T) {
+		d := NewClockSkewDetector()
+		_, detected := d.Observe([]time.Time{ahead(time.Minute), ahead(time.Minute), now.Add(-time.Hour), {}}, now)
+		if detected {
+			t.Error("Expected two future objects not to be enough")
+		}
+	})
+
+	t.Run("reports the largest offset once", func(t *testing.T) {
+		d := NewClockSkewDetector()
+		timestamps := []time.Time{ahead(30 * time.Second), ahead(90 * time.Second), ahead(time.Minute), now.Add(-time.Hour)}
+
+		offset, detected := d.Observe(timestamps, now)
+		if !detected {
+			t.Fatal("Expected skew to be detected")
+		}
+		if offset != 90*time.Second {
+			t.Errorf("Expected offset 1m30s, got %s", offset)
+		}
+		if d.Offset() != 90*time.Second {
+			t.Errorf("Expected Offset() 1m30s, got %s", d.Offset())
+		}
+
+		if _, detected := d.Observe(timestamps, now); detected {
+			t.Error("Expected skew to be reported only once")
+		}
+	})
+}
+
+func TestAppendAgeMatchesFormatAge(t *testing.T) {
+	now := time.Now()
+	buf := []byte("age=")
+	for _, created := range []time.Time{{}, now.Add(-90 * time.Second), now.Add(-50 * time.Hour), now.Add(time.Hour)} {
+		got := string(AppendAge(buf[:4], created, now))
+		want := "age=" + FormatAge(created)
+		if got != want {
+			t.Errorf("AppendAge(%v) = %q, want %q", created, got, want)
+		}
+	}
+
+	buf = make([]byte, 0, 16)
+	created := now.Add(-3 * time.Hour)
+	if allocs := testing.AllocsPerRun(100, func() { buf = AppendAge(buf[:0], created, now) }); allocs != 0 {
+		t.Errorf("Expected AppendAge into a large enough buffer not to allocate, got %v allocs", allocs)
+	}
+}
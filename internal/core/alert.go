@@ -0,0 +1,110 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AlertCategory is a kind of condition worth telling someone who is looking
+// at another window
+type AlertCategory string
+
+const (
+	// AlertCrashLoop is a listed pod going into CrashLoopBackOff
+	AlertCrashLoop AlertCategory = "crashloop"
+	// AlertDisconnected is a context whose data went stale because it could
+	// not be refreshed
+	AlertDisconnected AlertCategory = "disconnected"
+	// AlertDeletion is a delete kubewatch follows finishing or getting stuck
+	AlertDeletion AlertCategory = "deletion"
+)
+
+// AlertCategories are the known categories, in the order they are listed
+var AlertCategories = []AlertCategory{AlertCrashLoop, AlertDisconnected, AlertDeletion}
+
+// ParseAlertCategory parses an alert category as the config file names it
+func ParseAlertCategory(s string) (AlertCategory, error) {
+	for _, category := range AlertCategories {
+		if strings.EqualFold(strings.TrimSpace(s), string(category)) {
+			return category, nil
+		}
+	}
+	names := make([]string, len(AlertCategories))
+	for i, category := range AlertCategories {
+		names[i] = string(category)
+	}
+	return "", fmt.Errorf("unknown alert %q, expected one of %s", s, strings.Join(names, ", "))
+}
+
+// Title is what a notification of the category is headed with
+func (c AlertCategory) Title() string {
+	switch c {
+	case AlertCrashLoop:
+		return "CrashLoopBackOff"
+	case AlertDisconnected:
+		return "Data stale"
+	case AlertDeletion:
+		return "Delete"
+	}
+	return string(c)
+}
+
+// Alert is a condition that began holding
+type Alert struct {
+	Category  AlertCategory
+	Context   string
+	Namespace string
+	Resource  string // e.g. "pod web-1"; "" when the alert is about a whole context
+	Message   string // What holds, e.g. "is in CrashLoopBackOff"
+}
+
+// Key identifies what the alert is about, whatever its message says
+func (a Alert) Key() string {
+	return strings.Join([]string{string(a.Category), a.Context, a.Namespace, a.Resource}, "\x00")
+}
+
+// Text describes the alert with where it holds, e.g. "prod/payments: pod
+// web-1 is in CrashLoopBackOff"
+func (a Alert) Text() string {
+	var where []string
+	for _, part := range []string{a.Context, a.Namespace} {
+		if part != "" {
+			where = append(where, part)
+		}
+	}
+	what := strings.TrimSpace(a.Resource + " " + a.Message)
+	if len(where) == 0 {
+		return what
+	}
+	return strings.Join(where, "/") + ": " + what
+}
+
+// AlertWatcher turns conditions observed over and over into alerts when
+// they begin to hold. What holds when a scope is first observed is taken as
+// it was, and a condition still holding is not alerted again until it
+// clears.
+type AlertWatcher struct {
+	holding map[string]map[string]bool // Alert keys by scope
+}
+
+// NewAlertWatcher creates a watcher that has observed nothing
+func NewAlertWatcher() *AlertWatcher {
+	return &AlertWatcher{holding: make(map[string]map[string]bool)}
+}
+
+// Observe records the conditions holding in a scope, such as the pods of
+// one list, and returns those that did not hold when it was last observed
+func (w *AlertWatcher) Observe(scope string, holding []Alert) []Alert {
+	previous, seen := w.holding[scope]
+	current := make(map[string]bool, len(holding))
+	var began []Alert
+	for _, alert := range holding {
+		key := alert.Key()
+		if seen && !previous[key] && !current[key] {
+			began = append(began, alert)
+		}
+		current[key] = true
+	}
+	w.holding[scope] = current
+	return began
+}
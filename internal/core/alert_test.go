@@ -0,0 +1,62 @@
+package core
+
+import "testing"
+
+func TestAlertWatcher(t *testing.T) {
+	crash := func(pod string) Alert {
+		return Alert{Category: AlertCrashLoop, Context: "prod", Namespace: "payments", Resource: "pod " + pod, Message: "is in CrashLoopBackOff"}
+	}
+	w := NewAlertWatcher()
+
+	// What holds when a scope is first seen is not news
+	if began := w.Observe("pods", []Alert{crash("web-1")}); len(began) != 0 {
+		t.Fatalf("Expected nothing alerted on the first observation, got %v", began)
+	}
+	// Still holding is not alerted again; a new one is
+	began := w.Observe("pods", []Alert{crash("web-1"), crash("web-2")})
+	if len(began) != 1 || began[0].Resource != "pod web-2" {
+		t.Fatalf("Expected web-2 alerted, got %v", began)
+	}
+	if began := w.Observe("pods", []Alert{crash("web-1"), crash("web-2")}); len(began) != 0 {
+		t.Errorf("Expected nothing alerted while unchanged, got %v", began)
+	}
+	// Cleared, then holding again, is alerted again
+	w.Observe("pods", []Alert{crash("web-2")})
+	if began := w.Observe("pods", []Alert{crash("web-1"), crash("web-2")}); len(began) != 1 {
+		t.Errorf("Expected web-1 alerted again, got %v", began)
+	}
+
+	// Scopes are apart; a message that changes does not make a new alert
+	stale := Alert{Category: AlertDisconnected, Context: "prod", Message: "data stale for 45s"}
+	w.Observe("contexts", nil)
+	if began := w.Observe("contexts", []Alert{stale}); len(began) != 1 {
+		t.Fatalf("Expected the stale context alerted, got %v", began)
+	}
+	stale.Message = "data stale for 50s"
+	if began := w.Observe("contexts", []Alert{stale}); len(began) != 0 {
+		t.Errorf("Expected the stale context alerted once, got %v", began)
+	}
+}
+
+func TestAlertText(t *testing.T) {
+	tests := []struct {
+		alert    Alert
+		expected string
+	}{
+		{Alert{Context: "prod", Namespace: "payments", Resource: "pod web-1", Message: "is in CrashLoopBackOff"}, "prod/payments: pod web-1 is in CrashLoopBackOff"},
+		{Alert{Context: "prod", Message: "data stale for 45s, reconnecting"}, "prod: data stale for 45s, reconnecting"},
+		{Alert{Resource: "pod web-1", Message: "deleted"}, "pod web-1 deleted"},
+	}
+	for _, tt := range tests {
+		if got := tt.alert.Text(); got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+
+	if _, err := ParseAlertCategory("CrashLoop"); err != nil {
+		t.Errorf("Expected crashloop parsed, got %v", err)
+	}
+	if _, err := ParseAlertCategory("oom"); err == nil {
+		t.Error("Expected an unknown alert rejected")
+	}
+}
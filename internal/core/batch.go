@@ -0,0 +1,51 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// JobCompletions is a job's COMPLETIONS cell as kubectl shows it: pods
+// succeeded out of those wanted, e.g. "2/3", or "0/1 of 4" for a work queue
+// job run by several pods in parallel
+func JobCompletions(job *batchv1.Job) string {
+	if job.Spec.Completions != nil {
+		return fmt.Sprintf("%d/%d", job.Status.Succeeded, *job.Spec.Completions)
+	}
+	if job.Spec.Parallelism != nil && *job.Spec.Parallelism > 1 {
+		return fmt.Sprintf("%d/1 of %d", job.Status.Succeeded, *job.Spec.Parallelism)
+	}
+	return fmt.Sprintf("%d/1", job.Status.Succeeded)
+}
+
+// JobDuration is how long a job ran until it completed, or has been running
+// at now; "" before it starts
+func JobDuration(job *batchv1.Job, now time.Time) string {
+	if job.Status.StartTime == nil {
+		return ""
+	}
+	end := now
+	if job.Status.CompletionTime != nil {
+		end = job.Status.CompletionTime.Time
+	}
+	return FormatDuration(end.Sub(job.Status.StartTime.Time))
+}
+
+// CronJobSuspend is a cron job's SUSPEND cell, "True" or "False"
+func CronJobSuspend(cronJob *batchv1.CronJob) string {
+	if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
+		return "True"
+	}
+	return "False"
+}
+
+// CronJobLastSchedule is how long ago a cron job last started a job, or
+// "<none>" when it never has
+func CronJobLastSchedule(cronJob *batchv1.CronJob, now time.Time) string {
+	if cronJob.Status.LastScheduleTime == nil {
+		return "<none>"
+	}
+	return FormatDuration(now.Sub(cronJob.Status.LastScheduleTime.Time) + AgeOffset())
+}
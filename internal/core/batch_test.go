@@ -0,0 +1,67 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestJobCompletions(t *testing.T) {
+	three, four := int32(3), int32(4)
+	tests := []struct {
+		name     string
+		spec     batchv1.JobSpec
+		expected string
+	}{
+		{"single pod", batchv1.JobSpec{}, "2/1"},
+		{"fixed completions", batchv1.JobSpec{Completions: &three}, "2/3"},
+		{"work queue", batchv1.JobSpec{Parallelism: &four}, "2/1 of 4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := batchv1.Job{Spec: tt.spec, Status: batchv1.JobStatus{Succeeded: 2}}
+			if got := JobCompletions(&job); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestJobDuration(t *testing.T) {
+	now := time.Now()
+	started := metav1.NewTime(now.Add(-10 * time.Minute))
+	completed := metav1.NewTime(now.Add(-7 * time.Minute))
+
+	if got := JobDuration(&batchv1.Job{}, now); got != "" {
+		t.Errorf("Expected no duration before the job starts, got %q", got)
+	}
+	running := batchv1.Job{Status: batchv1.JobStatus{StartTime: &started}}
+	if got := JobDuration(&running, now); got != "10m" {
+		t.Errorf("Expected a running job's duration to now, got %q", got)
+	}
+	done := batchv1.Job{Status: batchv1.JobStatus{StartTime: &started, CompletionTime: &completed}}
+	if got := JobDuration(&done, now); got != "3m" {
+		t.Errorf("Expected a finished job's duration to its completion, got %q", got)
+	}
+}
+
+func TestCronJobCells(t *testing.T) {
+	now := time.Now()
+	suspend := true
+	lastRun := metav1.NewTime(now.Add(-2 * time.Hour))
+
+	cronJob := batchv1.CronJob{}
+	if CronJobSuspend(&cronJob) != "False" || CronJobLastSchedule(&cronJob, now) != "<none>" {
+		t.Errorf("Expected a new cron job not suspended and never run, got %q and %q",
+			CronJobSuspend(&cronJob), CronJobLastSchedule(&cronJob, now))
+	}
+
+	cronJob.Spec.Suspend = &suspend
+	cronJob.Status.LastScheduleTime = &lastRun
+	if CronJobSuspend(&cronJob) != "True" || CronJobLastSchedule(&cronJob, now) != "2h" {
+		t.Errorf("Expected a suspended cron job last run 2h ago, got %q and %q",
+			CronJobSuspend(&cronJob), CronJobLastSchedule(&cronJob, now))
+	}
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultCacheTTL is how long the cached list of a resource type is kept
+// once it is no longer viewed
+const DefaultCacheTTL = 10 * time.Minute
+
+// LastAppliedAnnotation is where kubectl apply keeps a copy of the whole
+// object, which kubewatch never shows
+const LastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// CacheKey identifies one list State caches
+type CacheKey struct {
+	Kind ResourceType
+	// Context is empty for the kind's main list, which holds every active
+	// context's objects, and names the context of a multi-context list
+	Context string
+}
+
+// CacheEntry is what is known of one cached list
+type CacheEntry struct {
+	CacheKey
+	Namespace string // Empty for all namespaces
+	Objects   int
+	Bytes     int64 // Encoded size of the objects, close to the memory they hold
+	Viewed    time.Time
+}
+
+// CacheUsage sums the lists State caches
+type CacheUsage struct {
+	Lists   int
+	Objects int
+	Bytes   int64
+}
+
+// String renders the usage, e.g. "3 lists, 1204 objects, ~2.4 MiB"
+func (u CacheUsage) String() string {
+	return fmt.Sprintf("%d lists, %d objects, ~%s", u.Lists, u.Objects, formatBytes(u.Bytes))
+}
+
+// formatBytes renders a size with one decimal in the largest fitting unit
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	size := float64(bytes) / unit
+	for _, name := range []string{"KiB", "MiB"} {
+		if size < unit {
+			return fmt.Sprintf("%.1f %s", size, name)
+		}
+		size /= unit
+	}
+	return fmt.Sprintf("%.1f GiB", size)
+}
+
+// CacheManager tracks how big State's cached lists are and when each was
+// last viewed, so lists nobody has looked at for a while can be dropped
+type CacheManager struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[CacheKey]*CacheEntry
+}
+
+// NewCacheManager creates a manager dropping lists not viewed for ttl; a ttl
+// of zero keeps them all
+func NewCacheManager(ttl time.Duration) *CacheManager {
+	return &CacheManager{ttl: ttl, entries: make(map[CacheKey]*CacheEntry)}
+}
+
+// SetTTL sets how long lists are kept once no longer viewed
+func (m *CacheManager) SetTTL(ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ttl = ttl
+}
+
+// TTL returns how long lists are kept once no longer viewed
+func (m *CacheManager) TTL() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ttl
+}
+
+// Record notes a list just stored, replacing what was known of the list
+// under the same key. A refresh is a view, so the list counts as viewed now.
+func (m *CacheManager) Record(key CacheKey, namespace string, objects int, bytes int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = &CacheEntry{
+		CacheKey:  key,
+		Namespace: namespace,
+		Objects:   objects,
+		Bytes:     bytes,
+		Viewed:    time.Now(),
+	}
+}
+
+// Sweep marks the lists active reports as viewed at now, then forgets and
+// returns the lists not viewed within the TTL, the largest first
+func (m *CacheManager) Sweep(now time.Time, active func(CacheKey) bool) []CacheEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var stale []CacheEntry
+	for key, entry := range m.entries {
+		if active(key) {
+			entry.Viewed = now
+			continue
+		}
+		if m.ttl > 0 && now.Sub(entry.Viewed) >= m.ttl {
+			stale = append(stale, *entry)
+			delete(m.entries, key)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].Bytes > stale[j].Bytes })
+	return stale
+}
+
+// Entries returns what is known of each cached list, the largest first
+func (m *CacheManager) Entries() []CacheEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := make([]CacheEntry, 0, len(m.entries))
+	for _, entry := range m.entries {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Bytes != entries[j].Bytes {
+			return entries[i].Bytes > entries[j].Bytes
+		}
+		if entries[i].Kind != entries[j].Kind {
+			return entries[i].Kind < entries[j].Kind
+		}
+		return entries[i].Context < entries[j].Context
+	})
+	return entries
+}
+
+// Usage sums the cached lists
+func (m *CacheManager) Usage() CacheUsage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	usage := CacheUsage{Lists: len(m.entries)}
+	for _, entry := range m.entries {
+		usage.Objects += entry.Objects
+		usage.Bytes += entry.Bytes
+	}
+	return usage
+}
+
+// stripUnusedFields removes what kubewatch never shows from listed objects,
+// often most of their size: managed fields and the last applied
+// configuration. It returns the objects' size afterwards.
+func stripUnusedFields[T any, P interface {
+	*T
+	metav1.Object
+	Size() int
+}](objects []T) int64 {
+	var bytes int64
+	for i := range objects {
+		obj := P(&objects[i])
+		obj.SetManagedFields(nil)
+		if annotations := obj.GetAnnotations(); annotations != nil {
+			delete(annotations, LastAppliedAnnotation)
+		}
+		bytes += int64(obj.Size())
+	}
+	return bytes
+}
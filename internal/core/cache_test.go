@@ -0,0 +1,108 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// cacheTestMeta is the metadata of an object applied with kubectl, carrying
+// the fields kubewatch never shows
+func cacheTestMeta(namespace, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace:     namespace,
+		Name:          name,
+		Annotations:   map[string]string{LastAppliedAnnotation: strings.Repeat("x", 4096), "team": "web"},
+		ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}},
+	}
+}
+
+func TestEvictStaleCachesWhileVisitingNamespaces(t *testing.T) {
+	state := NewState(&Config{})
+	state.Caches.SetTTL(5 * time.Minute)
+
+	// Visit 21 namespaces a minute apart, switching between secrets,
+	// configmaps and pods; the refresh of each visit stores the type's list
+	kinds := []ResourceType{ResourceTypeSecret, ResourceTypeConfigMap, ResourceTypePod}
+	start := time.Now()
+	for i := 0; i < 21; i++ {
+		namespace := fmt.Sprintf("team-%d", i)
+		state.SetNamespace(namespace)
+		state.SetResourceType(kinds[i%len(kinds)])
+		switch state.CurrentResourceType {
+		case ResourceTypeSecret:
+			state.UpdateSecrets([]v1.Secret{{ObjectMeta: cacheTestMeta(namespace, "token")}})
+		case ResourceTypeConfigMap:
+			state.UpdateConfigMaps([]v1.ConfigMap{{ObjectMeta: cacheTestMeta(namespace, "settings")}})
+		case ResourceTypePod:
+			state.UpdatePods([]v1.Pod{{ObjectMeta: cacheTestMeta(namespace, "web")}})
+		}
+		state.EvictStaleCaches(start.Add(time.Duration(i) * time.Minute))
+	}
+	if usage := state.Caches.Usage(); usage.Lists != 3 {
+		t.Fatalf("Expected one list per type while each is viewed in turn, got %s", usage)
+	}
+
+	// Staying on pods for a while drops the other types but not the pods
+	state.EvictStaleCaches(start.Add(30 * time.Minute))
+	if state.Secrets != nil || state.ConfigMaps != nil {
+		t.Errorf("Expected secrets and configmaps not viewed for 10+ minutes dropped, got %d and %d", len(state.Secrets), len(state.ConfigMaps))
+	}
+	if len(state.Pods) != 1 || state.Pods[0].Namespace != "team-20" {
+		t.Errorf("Expected the viewed pods of team-20 kept, got %+v", state.Pods)
+	}
+	entries := state.Caches.Entries()
+	if len(entries) != 1 || entries[0].Kind != ResourceTypePod || entries[0].Namespace != "team-20" {
+		t.Errorf("Expected only the pods tracked, got %+v", entries)
+	}
+}
+
+func TestEvictStaleCachesOfDroppedContexts(t *testing.T) {
+	state := NewState(&Config{})
+	state.SetMultiContextMode(true)
+	state.SetCurrentContexts([]string{"prod", "staging"})
+	state.SetResourceType(ResourceTypeDeployment)
+	state.UpdateDeploymentsByContext("prod", []appsv1.Deployment{{ObjectMeta: cacheTestMeta("web", "api")}})
+	state.UpdateDeploymentsByContext("staging", []appsv1.Deployment{{ObjectMeta: cacheTestMeta("web", "api")}})
+
+	state.SetCurrentContexts([]string{"prod"})
+	state.EvictStaleCaches(time.Now().Add(DefaultCacheTTL))
+	if _, ok := state.DeploymentsByContext["staging"]; ok {
+		t.Error("Expected the deployments of a context no longer shown dropped")
+	}
+	if len(state.ContextDeployments("prod")) != 1 {
+		t.Error("Expected the shown context's deployments kept")
+	}
+}
+
+func TestUpdateStripsUnusedFields(t *testing.T) {
+	state := NewState(&Config{})
+	pods := []v1.Pod{{ObjectMeta: cacheTestMeta("web", "api-1")}}
+	before := int64(pods[0].Size())
+
+	state.UpdatePods(pods)
+
+	pod := state.Pods[0]
+	if pod.ManagedFields != nil || pod.Annotations[LastAppliedAnnotation] != "" {
+		t.Errorf("Expected managed fields and the last applied configuration stripped, got %+v", pod.ObjectMeta)
+	}
+	if pod.Annotations["team"] != "web" {
+		t.Error("Expected other annotations kept")
+	}
+	usage := state.Caches.Usage()
+	if usage.Objects != 1 || usage.Bytes <= 0 || usage.Bytes >= before-4096 {
+		t.Errorf("Expected the stripped size tracked, got %d bytes of %d before", usage.Bytes, before)
+	}
+}
+
+func TestCacheUsageString(t *testing.T) {
+	usage := CacheUsage{Lists: 3, Objects: 1204, Bytes: 2516582}
+	if got := usage.String(); got != "3 lists, 1204 objects, ~2.4 MiB" {
+		t.Errorf("Expected a rounded size, got %q", got)
+	}
+}
@@ -0,0 +1,105 @@
+package core
+
+import "slices"
+
+// ResourceColumns are the columns a list of each resource type may show
+// after NAME and NAMESPACE. LOG and SECURITY are shown only while turned on.
+var ResourceColumns = map[ResourceType][]string{
+	ResourceTypePod:         {"READY", "STATUS", "RESTARTS", "AGE", "CPU", "MEMORY", "IP", "NODE", "LOG", "SECURITY"},
+	ResourceTypeDeployment:  {"READY", "UP-TO-DATE", "AVAILABLE", "AGE", "CONTAINERS", "IMAGES", "SELECTOR", "SECURITY"},
+	ResourceTypeStatefulSet: {"READY", "AGE", "CONTAINERS", "IMAGES"},
+	ResourceTypeJob:         {"COMPLETIONS", "DURATION", "AGE"},
+	ResourceTypeCronJob:     {"SCHEDULE", "SUSPEND", "ACTIVE", "LAST-SCHEDULE", "AGE"},
+	ResourceTypeService:     {"TYPE", "CLUSTER-IP", "EXTERNAL-IP", "PORT(S)", "AGE"},
+	ResourceTypeIngress:     {"CLASS", "HOSTS", "ADDRESS", "PORTS", "AGE"},
+	ResourceTypeGateway:     {"CLASS", "ADDRESS", "PROGRAMMED", "AGE"},
+	ResourceTypeHTTPRoute:   {"HOSTNAMES", "PARENT-REFS", "AGE"},
+	ResourceTypeConfigMap:   {"DATA", "AGE"},
+	ResourceTypeSecret:      {"TYPE", "DATA", "AGE"},
+
+	ResourceTypeServiceAccount:     {"SECRETS", "AGE"},
+	ResourceTypeRole:               {"RULES", "AGE"},
+	ResourceTypeClusterRole:        {"RULES", "AGE"},
+	ResourceTypeRoleBinding:        {"ROLE", "SUBJECTS", "AGE"},
+	ResourceTypeClusterRoleBinding: {"ROLE", "SUBJECTS", "AGE"},
+
+	ResourceTypeNode: {"STATUS", "ROLES", "AGE", "VERSION", "INTERNAL-IP", "CPU", "MEMORY"},
+}
+
+// IsResourceColumn reports whether a list of resourceType can show column,
+// CONTEXT, NAME and NAMESPACE included
+func IsResourceColumn(resourceType ResourceType, column string) bool {
+	switch column {
+	case "CONTEXT", "NAME", "NAMESPACE":
+		return true
+	}
+	return slices.Contains(ResourceColumns[resourceType], column)
+}
+
+// OrderColumns returns the indexes of headers in the order they are shown
+// under a user-defined order: the columns order names first, as it orders
+// them, then the rest as usual. CONTEXT stays pinned first unless order
+// names it. Columns order names that headers lack are skipped. Nil means
+// the usual order.
+func OrderColumns(headers, order []string) []int {
+	if len(order) == 0 {
+		return nil
+	}
+	result := make([]int, 0, len(headers))
+	placed := make([]bool, len(headers))
+	place := func(i int) {
+		if !placed[i] {
+			placed[i] = true
+			result = append(result, i)
+		}
+	}
+
+	if i := slices.Index(headers, "CONTEXT"); i >= 0 && !slices.Contains(order, "CONTEXT") {
+		place(i)
+	}
+	for _, column := range order {
+		if i := slices.Index(headers, column); i >= 0 {
+			place(i)
+		}
+	}
+	for i := range headers {
+		place(i)
+	}
+	return result
+}
+
+// ApplyColumnOrder returns headers in the order OrderColumns shows them
+func ApplyColumnOrder(headers, order []string) []string {
+	indexes := OrderColumns(headers, order)
+	if indexes == nil {
+		return headers
+	}
+	ordered := make([]string, len(indexes))
+	for i, index := range indexes {
+		ordered[i] = headers[index]
+	}
+	return ordered
+}
+
+// SetColumnOrder sets the order a resource type's columns are shown in;
+// nil restores the usual order
+func (s *State) SetColumnOrder(resourceType ResourceType, order []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.columnOrders == nil {
+		s.columnOrders = make(map[ResourceType][]string)
+	}
+	if len(order) == 0 {
+		delete(s.columnOrders, resourceType)
+		return
+	}
+	s.columnOrders[resourceType] = order
+}
+
+// GetColumnOrder returns the order a resource type's columns are shown in,
+// or nil for the usual order
+func (s *State) GetColumnOrder(resourceType ResourceType) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.columnOrders[resourceType]
+}
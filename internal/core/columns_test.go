@@ -0,0 +1,32 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOrderColumns(t *testing.T) {
+	headers := []string{"CONTEXT", "NAME", "NAMESPACE", "READY", "STATUS", "AGE"}
+	tests := []struct {
+		name  string
+		order []string
+		want  []string
+	}{
+		{"usual", nil, headers},
+		{"namespace first, context pinned", []string{"NAMESPACE"}, []string{"CONTEXT", "NAMESPACE", "NAME", "READY", "STATUS", "AGE"}},
+		{"status after name", []string{"NAME", "STATUS"}, []string{"CONTEXT", "NAME", "STATUS", "NAMESPACE", "READY", "AGE"}},
+		{"context moved", []string{"NAME", "CONTEXT"}, []string{"NAME", "CONTEXT", "NAMESPACE", "READY", "STATUS", "AGE"}},
+		{"missing columns skipped", []string{"IP", "AGE"}, []string{"CONTEXT", "AGE", "NAME", "NAMESPACE", "READY", "STATUS"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ApplyColumnOrder(headers, tt.order); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if !IsResourceColumn(ResourceTypeService, "PORT(S)") || IsResourceColumn(ResourceTypeService, "STATUS") {
+		t.Error("Expected services to have PORT(S) and no STATUS")
+	}
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// CommandTarget is the selected resource a user-defined command runs against
+type CommandTarget struct {
+	Name      string
+	Namespace string
+	Context   string
+	Node      string // Empty for kinds that are not scheduled on a node
+}
+
+// CommandTemplate is a parsed user-defined command line. Placeholders such as
+// {{.Name}}, {{.Namespace}}, {{.Context}} and {{.Node}} are replaced with
+// shell-quoted values, so the result is safe to pass to sh -c.
+type CommandTemplate struct {
+	text string
+	tmpl *template.Template
+}
+
+// ParseCommandTemplate parses a command line with placeholders
+func ParseCommandTemplate(text string) (*CommandTemplate, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("command is required")
+	}
+	tmpl, err := template.New("command").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid command template: %w", err)
+	}
+	return &CommandTemplate{text: text, tmpl: tmpl}, nil
+}
+
+// String returns the command template as written
+func (c *CommandTemplate) String() string {
+	return c.text
+}
+
+// Render fills in the placeholders from target, quoting each value for the shell
+func (c *CommandTemplate) Render(target CommandTarget) (string, error) {
+	quoted := CommandTarget{
+		Name:      ShellQuote(target.Name),
+		Namespace: ShellQuote(target.Namespace),
+		Context:   ShellQuote(target.Context),
+		Node:      ShellQuote(target.Node),
+	}
+
+	var buf bytes.Buffer
+	if err := c.tmpl.Execute(&buf, quoted); err != nil {
+		return "", fmt.Errorf("rendering command: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// ShellQuote quotes s as a single POSIX shell word
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"web-1", `'web-1'`},
+		{"", `''`},
+		{"it's", `'it'\''s'`},
+		{"$(rm -rf /)", `'$(rm -rf /)'`},
+	}
+
+	for _, tt := range tests {
+		if got := ShellQuote(tt.input); got != tt.expected {
+			t.Errorf("ShellQuote(%q): expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestCommandTemplateRender(t *testing.T) {
+	tmpl, err := ParseCommandTemplate("cost --pod {{.Name}} -n {{.Namespace}} --context {{.Context}} --node {{.Node}}")
+	if err != nil {
+		t.Fatalf("ParseCommandTemplate failed: %v", err)
+	}
+
+	got, err := tmpl.Render(CommandTarget{Name: "web-1", Namespace: "prod", Context: "east", Node: "node-a"})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	expected := "cost --pod 'web-1' -n 'prod' --context 'east' --node 'node-a'"
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	if _, err := ParseCommandTemplate("  "); err == nil {
+		t.Error("Expected an error for an empty command")
+	}
+	if _, err := ParseCommandTemplate("cost {{.Name"); err == nil {
+		t.Error("Expected an error for an unterminated placeholder")
+	}
+
+	unknown, err := ParseCommandTemplate("cost {{.Pod}}")
+	if err != nil {
+		t.Fatalf("ParseCommandTemplate failed: %v", err)
+	}
+	if _, err := unknown.Render(CommandTarget{Name: "web-1"}); err == nil {
+		t.Error("Expected an error rendering an unknown placeholder")
+	}
+}
+
+func TestCommandTemplateRenderIsShellSafe(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	tmpl, err := ParseCommandTemplate("printf '%s|' {{.Name}} {{.Namespace}}")
+	if err != nil {
+		t.Fatalf("ParseCommandTemplate failed: %v", err)
+	}
+	name := "x'; echo injected; '"
+	command, err := tmpl.Render(CommandTarget{Name: name, Namespace: "$(echo ns)"})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	output, err := exec.Command("sh", "-c", command).Output()
+	if err != nil {
+		t.Fatalf("Running %q failed: %v", command, err)
+	}
+	if got := string(output); got != name+"|$(echo ns)|" || strings.Contains(got, "injected\n") {
+		t.Errorf("Expected values passed through literally, got %q", got)
+	}
+}
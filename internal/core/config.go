@@ -3,6 +3,7 @@ package core
 import (
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // Config holds the application configuration
@@ -11,19 +12,67 @@ type Config struct {
 	CurrentContext      string
 	CurrentNamespace    string
 	InitialResourceType string
-	RefreshInterval     int // in seconds
+	RefreshInterval     int                  // in seconds
+	RefreshIntervals    map[ResourceType]int // in seconds by resource type, overriding RefreshInterval; RefreshOff turns it off
 	LogTailLines        int
 	MaxResourcesShown   int
+	MetricsInterval     int // in seconds, 0 fetches metrics on every refresh
+	CoalesceWindowMs    int // automatic refreshes within this window of the last one are skipped
+	HistoryMinutes      int // minutes of table states kept for the history scrubber, 0 = off
+	BatchConcurrency    int // operations a batch action runs at once
+	StaleAfterIntervals int // refresh intervals without an update before the data is marked stale
+	LogRateInterval     int // in seconds, how often each visible pod's log rate is sampled, 0 = off
+	ChurnGraceMs        int // how long pods deleted during high churn stay listed, dimmed, 0 = off
 	ColorScheme         string
+	CorrectClockSkew    bool   // add detected cluster clock skew to displayed ages
+	HideNoise           bool   // hide completed pods and the other resources the noise rules match
+	NoColor             bool   // NO_COLOR is set: nothing may be told by color alone
+	Accessible          bool   // plain-text rendering for screen readers
+	HideHints           bool   // hide the bar of key hints at the bottom
+	FieldSelector       string // narrows pod lists and watches, as kubectl --field-selector takes it
+
+	// Where CurrentNamespace came from when no flag chose it, one of the
+	// NamespaceFrom constants, or "" for the "default" fallback
+	NamespaceOrigin string
+}
+
+// Per-kind refresh interval values with a special meaning
+const (
+	// RefreshDefault refreshes a kind at the refresh interval
+	RefreshDefault = -1
+	// RefreshOff refreshes a kind only on watch events and r
+	RefreshOff = 0
+)
+
+// RefreshIntervalFor returns how often the list of a resource type is
+// refreshed, and false when it is not refreshed periodically
+func (c *Config) RefreshIntervalFor(t ResourceType) (time.Duration, bool) {
+	seconds, ok := c.RefreshIntervals[t]
+	if !ok {
+		seconds = c.RefreshInterval
+	}
+	if seconds <= RefreshOff {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
 }
 
+// Where the starting namespace came from when neither -n nor -A chose it
+const (
+	NamespaceFromConfig     = "kubewatch config"
+	NamespaceFromKubeconfig = "kubeconfig"
+)
+
 // LoadConfig loads the application configuration
 func LoadConfig() (*Config, error) {
 	config := &Config{
-		RefreshInterval:   2,
-		LogTailLines:      100,
-		MaxResourcesShown: 500,
-		ColorScheme:       "default",
+		RefreshInterval:     2,
+		LogTailLines:        100,
+		MaxResourcesShown:   500,
+		BatchConcurrency:    5,
+		StaleAfterIntervals: 3,
+		ChurnGraceMs:        3000,
+		ColorScheme:         "default",
 	}
 
 	// Get kubeconfig path - pass the raw KUBECONFIG env var value
@@ -46,5 +95,8 @@ func LoadConfig() (*Config, error) {
 	}
 	config.CurrentNamespace = namespace
 
+	// https://no-color.org: any value but an empty one turns colors off
+	config.NoColor = os.Getenv("NO_COLOR") != ""
+
 	return config, nil
 }
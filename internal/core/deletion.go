@@ -0,0 +1,228 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	// DeletionStuckMargin is how long past its grace period a deletion may
+	// take before it counts as stuck
+	DeletionStuckMargin = 30 * time.Second
+
+	// DeletionShownFor is how long a finished deletion is still reported
+	DeletionShownFor = 5 * time.Second
+)
+
+// DeletionPhase is how far a requested deletion has got
+type DeletionPhase int
+
+const (
+	// DeletionRequested means the API server accepted the delete, but the
+	// object has not been seen being deleted yet
+	DeletionRequested DeletionPhase = iota
+	// DeletionTerminating means the object is being deleted, within its
+	// grace period and the margin after it
+	DeletionTerminating
+	// DeletionStuck means the object outlived its grace period by more than
+	// DeletionStuckMargin, usually held by a finalizer
+	DeletionStuck
+	// DeletionDone means the object is gone
+	DeletionDone
+)
+
+// PendingDeletion is a delete kubewatch requested, followed until the
+// object is gone
+type PendingDeletion struct {
+	Kind        ResourceType
+	Ref         ResourceRef
+	RequestedAt time.Time
+
+	// What the object said when last seen being deleted. Deadline is its
+	// deletionTimestamp, which the API server sets to when the grace period
+	// ends: the request time plus deletionGracePeriodSeconds.
+	Terminating bool
+	Grace       time.Duration
+	Deadline    time.Time
+	Finalizers  []string
+
+	// When the object was seen gone, zero until then
+	DoneAt time.Time
+
+	uid types.UID // The object's UID once seen, telling it from a namesake
+}
+
+// Phase returns how far the deletion has got at now
+func (d *PendingDeletion) Phase(now time.Time) DeletionPhase {
+	switch {
+	case !d.DoneAt.IsZero():
+		return DeletionDone
+	case !d.Terminating:
+		return DeletionRequested
+	case now.After(d.Deadline.Add(DeletionStuckMargin)):
+		return DeletionStuck
+	}
+	return DeletionTerminating
+}
+
+// Status describes the deletion at now: "delete requested", "terminating
+// (grace 30s, 12s left)", "stuck — finalizers: kubernetes.io/pvc-protection"
+// or "deleted"
+func (d *PendingDeletion) Status(now time.Time) string {
+	switch d.Phase(now) {
+	case DeletionDone:
+		return "deleted"
+	case DeletionRequested:
+		return "delete requested"
+	case DeletionStuck:
+		if len(d.Finalizers) > 0 {
+			return "stuck — finalizers: " + strings.Join(d.Finalizers, ", ")
+		}
+		return fmt.Sprintf("stuck — %s past its grace period", FormatDuration(now.Sub(d.Deadline)))
+	}
+
+	if left := d.Deadline.Sub(now); d.Grace > 0 && left > 0 {
+		return fmt.Sprintf("terminating (grace %s, %s left)", FormatDuration(d.Grace), FormatDuration(left.Round(time.Second)))
+	}
+	if len(d.Finalizers) > 0 {
+		return "terminating (finalizers: " + strings.Join(d.Finalizers, ", ") + ")"
+	}
+	return "terminating"
+}
+
+// observe records the object as last seen
+func (d *PendingDeletion) observe(obj metav1.Object) {
+	d.uid = obj.GetUID()
+	d.Finalizers = obj.GetFinalizers()
+	if deletion := obj.GetDeletionTimestamp(); deletion != nil {
+		d.Terminating = true
+		d.Deadline = deletion.Time
+		d.Grace = 0
+		if grace := obj.GetDeletionGracePeriodSeconds(); grace != nil {
+			d.Grace = time.Duration(*grace) * time.Second
+		}
+	}
+}
+
+// DeletionTracker follows the deletes kubewatch requested, single and batch
+// alike, from the request until the object is gone. Lists and watch events
+// of the kinds deleted keep it up to date.
+type DeletionTracker struct {
+	mu      sync.Mutex
+	pending map[deletionKey]*PendingDeletion
+}
+
+// deletionKey identifies a deleted object
+type deletionKey struct {
+	kind ResourceType
+	ref  ResourceRef
+}
+
+// NewDeletionTracker creates an empty tracker
+func NewDeletionTracker() *DeletionTracker {
+	return &DeletionTracker{pending: make(map[deletionKey]*PendingDeletion)}
+}
+
+// Requested records a delete the API server accepted at at
+func (t *DeletionTracker) Requested(kind ResourceType, ref ResourceRef, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending[deletionKey{kind, ref}] = &PendingDeletion{Kind: kind, Ref: ref, RequestedAt: at}
+}
+
+// ObserveList updates the deletions of a kind from a list of it in context
+// and namespace. A deleted object the list no longer has, or has only a new
+// namesake of, is gone.
+func (t *DeletionTracker) ObserveList(kind ResourceType, context, namespace string, objects []metav1.Object, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, d := range t.pending {
+		if key.kind != kind || !d.DoneAt.IsZero() || !d.covered(context, namespace) {
+			continue
+		}
+		var found metav1.Object
+		for _, obj := range objects {
+			if obj.GetName() == d.Ref.Name && obj.GetNamespace() == d.Ref.Namespace {
+				found = obj
+				break
+			}
+		}
+		if found == nil || (d.uid != "" && found.GetUID() != d.uid) {
+			d.DoneAt = now
+			continue
+		}
+		d.observe(found)
+	}
+}
+
+// ObserveEvent updates the deletions of a kind from a watch event in
+// context; a Deleted event finishes the deletion of its object
+func (t *DeletionTracker) ObserveEvent(kind ResourceType, context string, event watch.Event, now time.Time) {
+	obj, err := meta.Accessor(event.Object)
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, d := range t.pending {
+		if key.kind != kind || !d.DoneAt.IsZero() || !d.Ref.Matches(context, obj.GetNamespace(), obj.GetName()) {
+			continue
+		}
+		if d.uid != "" && obj.GetUID() != d.uid {
+			continue
+		}
+		switch event.Type {
+		case watch.Deleted:
+			d.DoneAt = now
+		case watch.Added, watch.Modified:
+			d.observe(obj)
+		}
+	}
+}
+
+// covered reports whether a list in context and namespace, "" or "all" for
+// every namespace, would have the deleted object
+func (d *PendingDeletion) covered(context, namespace string) bool {
+	return (d.Ref.Context == "" || d.Ref.Context == context) &&
+		(namespace == "" || namespace == "all" || namespace == d.Ref.Namespace)
+}
+
+// Get returns the deletion of a kind's object, if one was requested
+func (t *DeletionTracker) Get(kind ResourceType, context, namespace, name string) (PendingDeletion, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, d := range t.pending {
+		if key.kind == kind && d.Ref.Name == name && d.Ref.Namespace == namespace &&
+			(d.Ref.Context == "" || context == "" || d.Ref.Context == context) {
+			return *d, true
+		}
+	}
+	return PendingDeletion{}, false
+}
+
+// Pending returns the deletions still followed, the latest requested first.
+// Deletions finished more than DeletionShownFor ago are forgotten.
+func (t *DeletionTracker) Pending(now time.Time) []PendingDeletion {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := make([]PendingDeletion, 0, len(t.pending))
+	for key, d := range t.pending {
+		if !d.DoneAt.IsZero() && now.Sub(d.DoneAt) > DeletionShownFor {
+			delete(t.pending, key)
+			continue
+		}
+		result = append(result, *d)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RequestedAt.After(result[j].RequestedAt)
+	})
+	return result
+}
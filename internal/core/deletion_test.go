@@ -0,0 +1,92 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func TestDeletionTrackerPhases(t *testing.T) {
+	start := time.Date(2024, 3, 5, 14, 2, 0, 0, time.UTC)
+	ref := ResourceRef{Namespace: "web", Name: "cart-1"}
+	pod := func(uid types.UID, deadline time.Time, finalizers ...string) metav1.Object {
+		grace := int64(30)
+		deletion := metav1.NewTime(deadline)
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "web", Name: "cart-1", UID: uid, Finalizers: finalizers,
+			DeletionTimestamp: &deletion, DeletionGracePeriodSeconds: &grace,
+		}}
+	}
+	status := func(tracker *DeletionTracker, now time.Time) string {
+		t.Helper()
+		d, ok := tracker.Get(ResourceTypePod, "", "web", "cart-1")
+		if !ok {
+			t.Fatalf("Expected cart-1's deletion to be followed")
+		}
+		return d.Status(now)
+	}
+
+	tracker := NewDeletionTracker()
+	tracker.Requested(ResourceTypePod, ref, start)
+	if got := status(tracker, start); got != "delete requested" {
+		t.Errorf("Expected a requested delete, got %q", got)
+	}
+
+	// The pod is listed terminating, with its grace period counting down
+	deadline := start.Add(30 * time.Second)
+	tracker.ObserveList(ResourceTypePod, "", "web", []metav1.Object{pod("a", deadline)}, start.Add(time.Second))
+	if got := status(tracker, start.Add(18*time.Second)); got != "terminating (grace 30s, 12s left)" {
+		t.Errorf("Expected the grace period counting down, got %q", got)
+	}
+
+	// Long past its grace period it is stuck, on its finalizers when it has any
+	late := deadline.Add(DeletionStuckMargin + 15*time.Second)
+	if got := status(tracker, late); got != "stuck — 45s past its grace period" {
+		t.Errorf("Expected a stuck deletion, got %q", got)
+	}
+	tracker.ObserveList(ResourceTypePod, "", "web", []metav1.Object{pod("a", deadline, "example.com/cleanup")}, late)
+	if got := status(tracker, late); got != "stuck — finalizers: example.com/cleanup" {
+		t.Errorf("Expected the finalizers holding the deletion, got %q", got)
+	}
+
+	// A list of another namespace says nothing about it
+	tracker.ObserveList(ResourceTypePod, "", "shop", nil, late)
+	if got := status(tracker, late); got == "deleted" {
+		t.Errorf("Expected a list of another namespace not to finish the deletion")
+	}
+
+	// A namesake with another UID means the deleted pod is gone
+	tracker.ObserveList(ResourceTypePod, "", "all", []metav1.Object{pod("b", time.Time{})}, late)
+	if got := status(tracker, late); got != "deleted" {
+		t.Errorf("Expected a recreated namesake to finish the deletion, got %q", got)
+	}
+	if pending := tracker.Pending(late.Add(DeletionShownFor)); len(pending) != 1 {
+		t.Errorf("Expected a finished deletion shown for a while, got %d", len(pending))
+	}
+	if pending := tracker.Pending(late.Add(DeletionShownFor + time.Second)); len(pending) != 0 {
+		t.Errorf("Expected a finished deletion forgotten after a while, got %d", len(pending))
+	}
+}
+
+func TestDeletionTrackerEvents(t *testing.T) {
+	now := time.Now()
+	tracker := NewDeletionTracker()
+	tracker.Requested(ResourceTypeService, ResourceRef{Namespace: "web", Name: "api"}, now)
+	tracker.Requested(ResourceTypeService, ResourceRef{Namespace: "web", Name: "db"}, now.Add(time.Second))
+
+	service := &v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "web", Name: "api"}}
+	tracker.ObserveEvent(ResourceTypePod, "", watch.Event{Type: watch.Deleted, Object: service}, now)
+	if d, _ := tracker.Get(ResourceTypeService, "", "web", "api"); d.Phase(now) != DeletionRequested {
+		t.Errorf("Expected an event of another kind to be ignored")
+	}
+
+	tracker.ObserveEvent(ResourceTypeService, "", watch.Event{Type: watch.Deleted, Object: service}, now)
+	pending := tracker.Pending(now)
+	if len(pending) != 2 || pending[0].Ref.Name != "db" || pending[1].Phase(now) != DeletionDone {
+		t.Errorf("Expected db still pending, latest first, and api deleted, got %+v", pending)
+	}
+}
@@ -0,0 +1,30 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// ParseFieldSelector checks a field selector as kubectl --field-selector
+// takes it, such as spec.nodeName=worker-3, and returns it trimmed. An empty
+// selector selects everything.
+func ParseFieldSelector(selector string) (string, error) {
+	selector = strings.TrimSpace(selector)
+	if _, err := fields.ParseSelector(selector); err != nil {
+		return "", fmt.Errorf("invalid field selector %q: %w", selector, err)
+	}
+	return selector, nil
+}
+
+// PodFieldSelector returns the field selector pod lists and watches are
+// narrowed by, or "" when there is none
+func (s *State) PodFieldSelector() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.config == nil {
+		return ""
+	}
+	return s.config.FieldSelector
+}
@@ -0,0 +1,22 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFieldSelector(t *testing.T) {
+	selector, err := ParseFieldSelector(" spec.nodeName=worker-3,status.phase!=Succeeded ")
+	if err != nil || selector != "spec.nodeName=worker-3,status.phase!=Succeeded" {
+		t.Errorf("Expected the selector trimmed, got %q, %v", selector, err)
+	}
+
+	if _, err := ParseFieldSelector("spec.nodeName"); err == nil || !strings.Contains(err.Error(), "invalid field selector") {
+		t.Errorf("Expected a selector without an operator rejected, got %v", err)
+	}
+
+	state := NewState(&Config{FieldSelector: "spec.nodeName=worker-3"})
+	if got := state.PodFieldSelector(); got != "spec.nodeName=worker-3" {
+		t.Errorf("Expected the configured selector, got %q", got)
+	}
+}
@@ -0,0 +1,387 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// FilterOp is how a filter term compares a column with its value
+type FilterOp int
+
+const (
+	// FilterContains matches rows where any cell contains the value
+	FilterContains FilterOp = iota
+	// FilterEquals matches rows whose column equals the value (with * wildcards)
+	FilterEquals
+	// FilterNotEquals matches rows whose column does not equal the value
+	FilterNotEquals
+	// FilterQualifiedName matches the resource named by namespace/name, or
+	// rows where any cell contains the term, such as an image
+	FilterQualifiedName
+)
+
+// FilterTerm is one whitespace-separated part of a filter expression
+type FilterTerm struct {
+	Column string // Upper-cased column header, empty for FilterContains
+	Op     FilterOp
+	Value  string // Lower-cased value
+
+	// Namespace and Name are the lower-cased parts of a FilterQualifiedName
+	// term; Name may use * wildcards
+	Namespace string
+	Name      string
+}
+
+// Filter is a parsed list filter. A row matches when every term matches.
+//
+// Terms are separated by whitespace:
+//
+//	nginx            any column contains "nginx"
+//	status=Running   the STATUS column is "Running"
+//	node!=worker-*   the NODE column does not match "worker-*"
+//	prod/web-*       resources named "web-*" in the prod namespace
+//
+// Comparisons are case-insensitive and values may use * wildcards.
+type Filter struct {
+	Expression string
+	Terms      []FilterTerm
+}
+
+// ParseFilter parses a filter expression. An empty expression matches everything.
+func ParseFilter(expression string) (*Filter, error) {
+	f := &Filter{Expression: strings.TrimSpace(expression)}
+
+	for _, field := range strings.Fields(expression) {
+		term := FilterTerm{Op: FilterContains, Value: strings.ToLower(field)}
+
+		if i := strings.Index(field, "!="); i >= 0 {
+			term = FilterTerm{Column: field[:i], Op: FilterNotEquals, Value: field[i+2:]}
+		} else if i := strings.Index(field, "="); i >= 0 {
+			term = FilterTerm{Column: field[:i], Op: FilterEquals, Value: field[i+1:]}
+		} else if ref := ParseResourceRef(term.Value); ref.Namespace != "" {
+			term.Op = FilterQualifiedName
+			term.Namespace, term.Name = ref.Namespace, ref.Name
+		}
+
+		if term.Op == FilterEquals || term.Op == FilterNotEquals {
+			if term.Column == "" {
+				return nil, fmt.Errorf("filter term %q is missing a column name", field)
+			}
+			if term.Value == "" {
+				return nil, fmt.Errorf("filter term %q is missing a value", field)
+			}
+			term.Column = strings.ToUpper(term.Column)
+			term.Value = strings.ToLower(term.Value)
+		}
+
+		f.Terms = append(f.Terms, term)
+	}
+
+	return f, nil
+}
+
+// IsEmpty returns true when the filter matches every row
+func (f *Filter) IsEmpty() bool {
+	return f == nil || len(f.Terms) == 0
+}
+
+// Columns returns the column names the filter refers to, in order
+func (f *Filter) Columns() []string {
+	if f == nil {
+		return nil
+	}
+	var columns []string
+	seen := make(map[string]bool)
+	for _, term := range f.Terms {
+		if term.Column != "" && !seen[term.Column] {
+			seen[term.Column] = true
+			columns = append(columns, term.Column)
+		}
+	}
+	return columns
+}
+
+// UnknownColumns returns the filter's columns that are not among headers
+func (f *Filter) UnknownColumns(headers []string) []string {
+	known := make(map[string]bool, len(headers))
+	for _, h := range headers {
+		known[strings.ToUpper(h)] = true
+	}
+
+	var unknown []string
+	for _, column := range f.Columns() {
+		if !known[column] {
+			unknown = append(unknown, column)
+		}
+	}
+	return unknown
+}
+
+// Narrows returns true when every row f matches is also matched by prev, as
+// when a character or a term is typed onto prev's expression. Only rows prev
+// matched then need checking against f.
+func (f *Filter) Narrows(prev *Filter) bool {
+	if prev.IsEmpty() {
+		return true
+	}
+	if f.IsEmpty() || len(f.Terms) < len(prev.Terms) {
+		return false
+	}
+	for i, term := range prev.Terms {
+		next := f.Terms[i]
+		if next == term {
+			continue
+		}
+		// A longer substring is contained only where a shorter one of it is;
+		// a longer equals or qualified name is not so bounded
+		if next.Op != FilterContains || term.Op != FilterContains || !strings.Contains(next.Value, term.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match reports whether row, laid out by headers, satisfies every term.
+// Terms on a column that is not shown never match.
+func (f *Filter) Match(headers []string, row []string) bool {
+	return f.MatchInNamespace("", headers, row)
+}
+
+// MatchInNamespace is Match for a list of one namespace, which has no
+// NAMESPACE column; namespace/name terms compare namespace instead
+func (f *Filter) MatchInNamespace(namespace string, headers []string, row []string) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	for _, term := range f.Terms {
+		switch term.Op {
+		case FilterContains:
+			if !rowContains(row, term.Value) {
+				return false
+			}
+			continue
+
+		case FilterQualifiedName:
+			rowNamespace := strings.ToLower(namespace)
+			if index := columnIndex(headers, "NAMESPACE"); index >= 0 && index < len(row) {
+				rowNamespace = strings.ToLower(row[index])
+			}
+			name := ""
+			if index := columnIndex(headers, "NAME"); index >= 0 && index < len(row) {
+				name = strings.ToLower(row[index])
+			}
+			named := rowNamespace == term.Namespace && matchFilterValue(term.Name, name)
+			if !named && !rowContains(row, term.Value) {
+				return false
+			}
+			continue
+		}
+
+		index := columnIndex(headers, term.Column)
+		if index < 0 || index >= len(row) {
+			return false
+		}
+
+		matched := matchFilterValue(term.Value, strings.ToLower(row[index]))
+		if matched != (term.Op == FilterEquals) {
+			return false
+		}
+	}
+	return true
+}
+
+// serverFields maps the columns whose cells are exactly a field the API
+// server can select on. Their values are DNS names, always lower case, so the
+// filter's case-insensitive comparison and the server's exact one agree.
+var serverFields = map[string]string{
+	"NAME":      "metadata.name",
+	"NAMESPACE": "metadata.namespace",
+}
+
+// serverPodFields adds the pod columns the API server can select on
+var serverPodFields = map[string]string{
+	"NODE": "spec.nodeName",
+}
+
+// FieldSelector returns a field selector for listing resourceType so that
+// the API server leaves out what the filter would drop anyway, or "" when no
+// term maps to one. Only exact comparisons map: the selector never drops a
+// row the filter keeps, and the filter still applies to what is listed.
+func (f *Filter) FieldSelector(resourceType ResourceType) string {
+	if f.IsEmpty() {
+		return ""
+	}
+	var selectors []fields.Selector
+	for _, term := range f.Terms {
+		if term.Op != FilterEquals && term.Op != FilterNotEquals {
+			continue
+		}
+		// * wildcards and "-", which stands for an empty cell, have no
+		// field selector
+		if strings.Contains(term.Value, "*") || term.Value == "-" {
+			continue
+		}
+		field, ok := serverFields[term.Column]
+		if !ok && resourceType == ResourceTypePod {
+			field, ok = serverPodFields[term.Column]
+		}
+		if !ok {
+			continue
+		}
+		if term.Op == FilterEquals {
+			selectors = append(selectors, fields.OneTermEqualSelector(field, term.Value))
+		} else {
+			selectors = append(selectors, fields.OneTermNotEqualSelector(field, term.Value))
+		}
+	}
+	if len(selectors) == 0 {
+		return ""
+	}
+	return fields.AndSelectors(selectors...).String()
+}
+
+// rowContains reports whether any cell of row contains the lower-cased value
+func rowContains(row []string, value string) bool {
+	for _, cell := range row {
+		if containsLower(cell, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsLower reports whether s contains the lower-cased substr, ignoring
+// case. ASCII cells, which are nearly all of them, are compared in place
+// rather than lower-cased into a new string for every row.
+func containsLower(s, substr string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return strings.Contains(strings.ToLower(s), substr)
+		}
+	}
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if hasLowerPrefix(s[i:], substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLowerPrefix reports whether the ASCII s starts with the lower-cased
+// prefix, ignoring case
+func hasLowerPrefix(s, prefix string) bool {
+	for j := 0; j < len(prefix); j++ {
+		c := s[j]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != prefix[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// columnIndex returns the index of the upper-cased column among headers, or -1
+func columnIndex(headers []string, column string) int {
+	for i, h := range headers {
+		if strings.EqualFold(h, column) {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchFilterValue compares a lower-cased cell with a lower-cased term value,
+// where * in the value matches any run of characters
+func matchFilterValue(pattern, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, last)
+}
+
+// maxFilterSteps is how many of the latest results an IncrementalFilter
+// keeps to start from
+const maxFilterSteps = 32
+
+// IncrementalFilter filters a fixed set of rows as a filter expression is
+// edited, one keystroke after another. A filter that narrows an earlier one
+// only checks the rows that one matched; deleting a character starts from
+// the latest result it narrows, or from every row.
+type IncrementalFilter struct {
+	namespace string
+	headers   []string
+	rows      [][]string
+	steps     []filterStep // Latest last
+}
+
+// filterStep is a filter and the indexes of the rows it matched
+type filterStep struct {
+	filter  *Filter
+	matches []int
+}
+
+// NewIncrementalFilter creates an incremental filter over rows, laid out by
+// headers, of a list of namespace ("" for all namespaces)
+func NewIncrementalFilter(namespace string, headers []string, rows [][]string) *IncrementalFilter {
+	return &IncrementalFilter{namespace: namespace, headers: headers, rows: rows}
+}
+
+// Apply returns the indexes, in order, of the rows filter matches
+func (m *IncrementalFilter) Apply(filter *Filter) []int {
+	var base []int
+	all := true
+	for i := len(m.steps) - 1; i >= 0; i-- {
+		step := m.steps[i]
+		if step.filter.Expression == filter.Expression {
+			return step.matches
+		}
+		if filter.Narrows(step.filter) {
+			base, all = step.matches, false
+			break
+		}
+	}
+
+	var matches []int
+	if all {
+		matches = make([]int, 0, len(m.rows))
+		for i, row := range m.rows {
+			if filter.MatchInNamespace(m.namespace, m.headers, row) {
+				matches = append(matches, i)
+			}
+		}
+	} else {
+		matches = make([]int, 0, len(base))
+		for _, i := range base {
+			if filter.MatchInNamespace(m.namespace, m.headers, m.rows[i]) {
+				matches = append(matches, i)
+			}
+		}
+	}
+
+	m.steps = append(m.steps, filterStep{filter: filter, matches: matches})
+	if len(m.steps) > maxFilterSteps {
+		m.steps = append([]filterStep(nil), m.steps[len(m.steps)-maxFilterSteps:]...)
+	}
+	return matches
+}
@@ -0,0 +1,290 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		expr      string
+		expectErr bool
+		columns   []string
+	}{
+		{"empty", "   ", false, nil},
+		{"bare text", "nginx", false, nil},
+		{"column terms", "status=Running node!=worker-*", false, []string{"STATUS", "NODE"}},
+		{"repeated column listed once", "status!=Running status!=Completed", false, []string{"STATUS"}},
+		{"missing column", "=Running", true, nil},
+		{"missing value", "status=", true, nil},
+		{"missing value not equals", "status!=", true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilter(tt.expr)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("Expected parse error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := f.Columns(); !reflect.DeepEqual(got, tt.columns) {
+				t.Errorf("Expected columns %v, got %v", tt.columns, got)
+			}
+		})
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	headers := []string{"NAME", "READY", "STATUS", "NODE"}
+	row := []string{"web-7d9f", "1/1", "Running", "worker-2"}
+
+	tests := []struct {
+		expr     string
+		expected bool
+	}{
+		{"", true},
+		{"web", true},
+		{"WEB", true},
+		{"api", false},
+		{"status=running", true},
+		{"status=Pending", false},
+		{"status!=Pending", true},
+		{"node=worker-*", true},
+		{"node=*-2", true},
+		{"node!=worker-*", false},
+		{"ready=1/*", true},
+		{"web status=Running node=worker-2", true},
+		{"web status=Pending", false},
+		{"7D9F", true},
+		{"7d9fx", false},
+		{"zone=us-east-1a", false}, // Unknown columns never match
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := ParseFilter(tt.expr)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := f.Match(headers, row); got != tt.expected {
+				t.Errorf("Match(%q) = %v, expected %v", tt.expr, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterQualifiedName(t *testing.T) {
+	headers := []string{"NAME", "NAMESPACE", "STATUS", "IMAGES"}
+	rows := map[string][]string{
+		"prod":    {"web", "prod", "Running", "nginx:1.25"},
+		"staging": {"web", "staging", "Running", "nginx:1.25"},
+		"dev":     {"web", "dev", "Running", "library/nginx:1.25"},
+	}
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"prod/web", []string{"prod"}},
+		{"STAGING/web", []string{"staging"}},
+		{"prod/w*", []string{"prod"}},
+		{"prod/api", nil},
+		{"library/nginx", []string{"dev"}}, // Still matches cells containing the text
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := ParseFilter(tt.expr)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var got []string
+			for _, namespace := range []string{"dev", "prod", "staging"} {
+				if f.Match(headers, rows[namespace]) {
+					got = append(got, namespace)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Match(%q) matched %v, expected %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+
+	// A list of one namespace has no NAMESPACE column
+	f, _ := ParseFilter("prod/web")
+	single := []string{"NAME", "STATUS"}
+	if !f.MatchInNamespace("prod", single, []string{"web", "Running"}) {
+		t.Error("Expected prod/web to match web in the prod list")
+	}
+	if f.MatchInNamespace("staging", single, []string{"web", "Running"}) {
+		t.Error("Expected prod/web not to match web in the staging list")
+	}
+}
+
+func TestFilterUnknownColumns(t *testing.T) {
+	f, err := ParseFilter("status=Running zone=a Node=x")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	unknown := f.UnknownColumns([]string{"NAME", "STATUS", "NODE"})
+	if !reflect.DeepEqual(unknown, []string{"ZONE"}) {
+		t.Errorf("Expected [ZONE], got %v", unknown)
+	}
+}
+
+func TestFilterFieldSelector(t *testing.T) {
+	tests := []struct {
+		expr         string
+		resourceType ResourceType
+		want         string
+	}{
+		{"", ResourceTypePod, ""},
+		{"nginx status=Running", ResourceTypePod, ""},
+		{"name=Web-1", ResourceTypePod, "metadata.name=web-1"},
+		{"node=worker-1 namespace!=kube-system", ResourceTypePod, "spec.nodeName=worker-1,metadata.namespace!=kube-system"},
+		{"node=worker-1", ResourceTypeDeployment, ""},
+		{"name=web-* node=-", ResourceTypePod, ""},
+		{"prod/web", ResourceTypePod, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := ParseFilter(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseFilter failed: %v", err)
+			}
+			if got := f.FieldSelector(tt.resourceType); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseResourceType(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected ResourceType
+		ok       bool
+	}{
+		{"Pods", ResourceTypePod, true},
+		{"pod", ResourceTypePod, true},
+		{"deployments", ResourceTypeDeployment, true},
+		{"ingress", ResourceTypeIngress, true},
+		{" ConfigMap ", ResourceTypeConfigMap, true},
+		{"cronjobs", ResourceTypeCronJob, true},
+		{"daemonsets", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseResourceType(tt.name)
+			if ok != tt.ok || got != tt.expected {
+				t.Errorf("ParseResourceType(%q) = %q, %v; expected %q, %v", tt.name, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestFilterNarrows(t *testing.T) {
+	tests := []struct {
+		prev, next string
+		expected   bool
+	}{
+		{"", "web", true},
+		{"web", "web-", true},
+		{"web", "web status=Running", true},
+		{"eb", "web", true},
+		{"web-", "web", false},
+		{"web status=Running", "web", false},
+		{"status=Run", "status=Runn", false}, // Equals is exact, not a prefix
+		{"node!=w", "node!=wo", false},
+		{"prod", "prod/w", false}, // A qualified name may match where prod is in no cell
+		{"web", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prev+"→"+tt.next, func(t *testing.T) {
+			prev, _ := ParseFilter(tt.prev)
+			next, _ := ParseFilter(tt.next)
+			if got := next.Narrows(prev); got != tt.expected {
+				t.Errorf("Narrows = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// filterRowsForTest returns n pod rows, alternately Running and Pending, in
+// namespaces team-0 to team-9
+func filterRowsForTest(n int) [][]string {
+	rows := make([][]string, n)
+	for i := range rows {
+		status := "Running"
+		if i%2 == 1 {
+			status = "Pending"
+		}
+		rows[i] = []string{fmt.Sprintf("Pod-%d", i), fmt.Sprintf("team-%d", i%10), status}
+	}
+	return rows
+}
+
+// TestIncrementalFilter tests that typing and deleting through an
+// expression matches what filtering every row afresh does
+func TestIncrementalFilter(t *testing.T) {
+	headers := []string{"NAME", "NAMESPACE", "STATUS"}
+	rows := filterRowsForTest(500)
+	incremental := NewIncrementalFilter("", headers, rows)
+
+	for _, expr := range []string{"p", "po", "pod-4", "pod-42", "pod-4", "pod-", "pod-1 status=Running", "pod-1 status=Pendin",
+		"pod-1 status=Pending", "team-3/pod-3", "team-3/pod-3*", "", "pod-42"} {
+		f, err := ParseFilter(expr)
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", expr, err)
+		}
+		var expected []int
+		for i, row := range rows {
+			if f.Match(headers, row) {
+				expected = append(expected, i)
+			}
+		}
+		got := incremental.Apply(f)
+		if len(got) != len(expected) || (len(got) > 0 && !reflect.DeepEqual(got, expected)) {
+			t.Errorf("%q matched %d rows incrementally, expected %d", expr, len(got), len(expected))
+		}
+	}
+}
+
+// BenchmarkFilterKeystroke measures typing one more character onto a filter
+// over 10k rows, re-scanning every row against checking only the matches of
+// the expression before it
+func BenchmarkFilterKeystroke(b *testing.B) {
+	headers := []string{"NAME", "NAMESPACE", "STATUS"}
+	rows := filterRowsForTest(10000)
+	prev, _ := ParseFilter("pod-42")
+	next, _ := ParseFilter("pod-421")
+
+	b.Run("full", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, row := range rows {
+				next.Match(headers, row)
+			}
+		}
+	})
+
+	b.Run("incremental", func(b *testing.B) {
+		b.ReportAllocs()
+		incremental := NewIncrementalFilter("", headers, rows)
+		incremental.Apply(prev)
+		for i := 0; i < b.N; i++ {
+			// Fresh steps, so every pass filters the previous result again
+			incremental.steps = incremental.steps[:1]
+			incremental.Apply(next)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// FleetPod is a pod a fleet search found, with the context it runs in
+type FleetPod struct {
+	Context string
+	Pod     v1.Pod
+}
+
+// FleetQuery is what a fleet search looks for: pods whose names match
+// Pattern, in Namespace or, when it is empty, in every namespace
+type FleetQuery struct {
+	Namespace string
+	Pattern   string
+}
+
+// ParseFleetQuery parses "pattern" or "namespace/pattern", the form a fleet
+// search is typed in, like a filter's namespace/name term
+func ParseFleetQuery(text string) FleetQuery {
+	text = strings.TrimSpace(text)
+	if namespace, pattern, ok := strings.Cut(text, "/"); ok {
+		return FleetQuery{Namespace: namespace, Pattern: pattern}
+	}
+	return FleetQuery{Pattern: text}
+}
+
+// String returns the query as it is typed
+func (q FleetQuery) String() string {
+	if q.Namespace != "" {
+		return q.Namespace + "/" + q.Pattern
+	}
+	return q.Pattern
+}
+
+// MatchesName reports whether a pod name matches the query's pattern,
+// ignoring case: a pattern with * wildcards must match the whole name, any
+// other pattern need only be part of it
+func (q FleetQuery) MatchesName(name string) bool {
+	pattern, name := strings.ToLower(q.Pattern), strings.ToLower(name)
+	if strings.Contains(pattern, "*") {
+		return matchFilterValue(pattern, name)
+	}
+	return strings.Contains(name, pattern)
+}
+
+// PodRestarts returns how often a pod's containers have restarted
+func PodRestarts(pod *v1.Pod) int32 {
+	var restarts int32
+	for _, cs := range pod.Status.ContainerStatuses {
+		restarts += cs.RestartCount
+	}
+	return restarts
+}
+
+// PodUnhealthy returns true for a pod that needs a look: one that failed,
+// is not running yet, has containers that are not ready, or is stuck
+// terminating. A pod that ran to completion is healthy.
+func PodUnhealthy(pod *v1.Pod, now time.Time) bool {
+	if IsStuckTerminating(TerminationStatus(pod.ObjectMeta, now)) {
+		return true
+	}
+	switch pod.Status.Phase {
+	case v1.PodSucceeded:
+		return false
+	case v1.PodRunning:
+		return len(NotReadyContainers(pod)) > 0
+	}
+	return true
+}
+
+// SortFleetPods orders pods unhealthy first, then by most restarts, then by
+// context, namespace and name
+func SortFleetPods(pods []FleetPod, now time.Time) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		a, b := &pods[i], &pods[j]
+		if ua, ub := PodUnhealthy(&a.Pod, now), PodUnhealthy(&b.Pod, now); ua != ub {
+			return ua
+		}
+		if ra, rb := PodRestarts(&a.Pod), PodRestarts(&b.Pod); ra != rb {
+			return ra > rb
+		}
+		if a.Context != b.Context {
+			return a.Context < b.Context
+		}
+		if a.Pod.Namespace != b.Pod.Namespace {
+			return a.Pod.Namespace < b.Pod.Namespace
+		}
+		return a.Pod.Name < b.Pod.Name
+	})
+}
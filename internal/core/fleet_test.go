@@ -0,0 +1,81 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseFleetQuery(t *testing.T) {
+	tests := []struct {
+		text string
+		want FleetQuery
+	}{
+		{"checkout", FleetQuery{Pattern: "checkout"}},
+		{"  payments/checkout-*  ", FleetQuery{Namespace: "payments", Pattern: "checkout-*"}},
+		{"/checkout", FleetQuery{Pattern: "checkout"}},
+	}
+	for _, tt := range tests {
+		got := ParseFleetQuery(tt.text)
+		if got != tt.want {
+			t.Errorf("ParseFleetQuery(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+		if ParseFleetQuery(got.String()) != got {
+			t.Errorf("Expected %+v to survive String, got %q", got, got.String())
+		}
+	}
+}
+
+func TestFleetQueryMatchesName(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"checkout", "checkout-7f9c-abcde", true},
+		{"CHECKOUT", "api-checkout-1", true},
+		{"checkout", "cart-1", false},
+		{"checkout-*", "checkout-7f9c-abcde", true},
+		{"checkout-*", "api-checkout-1", false},
+		{"*-worker-*", "payments-worker-0", true},
+	}
+	for _, tt := range tests {
+		if got := (FleetQuery{Pattern: tt.pattern}).MatchesName(tt.name); got != tt.want {
+			t.Errorf("Pattern %q against %q = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSortFleetPods(t *testing.T) {
+	now := time.Now()
+	pod := func(context, name string, phase v1.PodPhase, ready bool, restarts int32) FleetPod {
+		return FleetPod{Context: context, Pod: v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "web", Name: name},
+			Status: v1.PodStatus{Phase: phase, ContainerStatuses: []v1.ContainerStatus{
+				{Name: "app", Ready: ready, RestartCount: restarts},
+			}},
+		}}
+	}
+	terminating := pod("eu", "stuck", v1.PodRunning, true, 0)
+	terminating.Pod.DeletionTimestamp = &metav1.Time{Time: now.Add(-time.Hour)}
+
+	pods := []FleetPod{
+		pod("us", "healthy", v1.PodRunning, true, 0),
+		pod("eu", "restarting", v1.PodRunning, true, 4),
+		pod("us", "pending", v1.PodPending, false, 0),
+		pod("eu", "done", v1.PodSucceeded, false, 0),
+		pod("eu", "crashing", v1.PodRunning, false, 9),
+		pod("ap", "healthy", v1.PodRunning, true, 0),
+		terminating,
+	}
+	SortFleetPods(pods, now)
+
+	want := []string{"eu/crashing", "eu/stuck", "us/pending", "eu/restarting", "ap/healthy", "eu/done", "us/healthy"}
+	for i, found := range pods {
+		if got := found.Context + "/" + found.Pod.Name; got != want[i] {
+			t.Errorf("Position %d: got %s, want %s", i, got, want[i])
+		}
+	}
+}
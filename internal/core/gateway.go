@@ -0,0 +1,303 @@
+package core
+
+import (
+	"encoding/json"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// GatewayAPIGroup is the API group of the Gateway API's resources, which a
+// cluster has only once their CRDs are installed
+const GatewayAPIGroup = "gateway.networking.k8s.io"
+
+// Gateway is a Gateway API gateway, holding the fields kubewatch shows. The
+// Gateway API's Go types are not a dependency, so gateways are decoded from
+// the dynamic client's objects into this.
+type Gateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GatewaySpec   `json:"spec,omitempty"`
+	Status GatewayStatus `json:"status,omitempty"`
+}
+
+// GatewaySpec is what a gateway asks for
+type GatewaySpec struct {
+	GatewayClassName string            `json:"gatewayClassName"`
+	Listeners        []GatewayListener `json:"listeners,omitempty"`
+	Addresses        []GatewayAddress  `json:"addresses,omitempty"`
+}
+
+// GatewayListener is a port, protocol and hostname a gateway accepts
+// traffic on
+type GatewayListener struct {
+	Name     string `json:"name"`
+	Hostname string `json:"hostname,omitempty"`
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+// GatewayAddress is an address a gateway is asked for or was given
+type GatewayAddress struct {
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value"`
+}
+
+// GatewayStatus is what the gateway's controller reports
+type GatewayStatus struct {
+	Addresses  []GatewayAddress        `json:"addresses,omitempty"`
+	Conditions []metav1.Condition      `json:"conditions,omitempty"`
+	Listeners  []GatewayListenerStatus `json:"listeners,omitempty"`
+}
+
+// GatewayListenerStatus is the state of one of a gateway's listeners
+type GatewayListenerStatus struct {
+	Name           string             `json:"name"`
+	AttachedRoutes int32              `json:"attachedRoutes"`
+	Conditions     []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// HTTPRoute is a Gateway API HTTP route, holding the fields kubewatch shows
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HTTPRouteSpec   `json:"spec,omitempty"`
+	Status HTTPRouteStatus `json:"status,omitempty"`
+}
+
+// HTTPRouteSpec is the gateways a route attaches to and how it routes
+type HTTPRouteSpec struct {
+	ParentRefs []ParentReference `json:"parentRefs,omitempty"`
+	Hostnames  []string          `json:"hostnames,omitempty"`
+	Rules      []HTTPRouteRule   `json:"rules,omitempty"`
+}
+
+// ParentReference names what a route attaches to, usually a gateway
+type ParentReference struct {
+	Group       string `json:"group,omitempty"`
+	Kind        string `json:"kind,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+	SectionName string `json:"sectionName,omitempty"`
+	Port        *int32 `json:"port,omitempty"`
+}
+
+// HTTPRouteRule sends the requests matching any of its matches to its
+// backends
+type HTTPRouteRule struct {
+	Matches     []HTTPRouteMatch `json:"matches,omitempty"`
+	BackendRefs []HTTPBackendRef `json:"backendRefs,omitempty"`
+}
+
+// HTTPRouteMatch is what a request must have to match a rule
+type HTTPRouteMatch struct {
+	Path    *HTTPPathMatch    `json:"path,omitempty"`
+	Headers []HTTPHeaderMatch `json:"headers,omitempty"`
+	Method  string            `json:"method,omitempty"`
+}
+
+// HTTPPathMatch matches a request's path
+type HTTPPathMatch struct {
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// HTTPHeaderMatch matches a request header
+type HTTPHeaderMatch struct {
+	Type  string `json:"type,omitempty"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HTTPBackendRef is a backend a rule sends requests to, usually a service
+type HTTPBackendRef struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Port      *int32 `json:"port,omitempty"`
+	Weight    *int32 `json:"weight,omitempty"`
+}
+
+// HTTPRouteStatus is what the controllers of a route's parents report
+type HTTPRouteStatus struct {
+	Parents []RouteParentStatus `json:"parents,omitempty"`
+}
+
+// RouteParentStatus is one parent's view of a route
+type RouteParentStatus struct {
+	ParentRef      ParentReference    `json:"parentRef"`
+	ControllerName string             `json:"controllerName"`
+	Conditions     []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// DeepCopyObject copies the gateway, as runtime.Object requires
+func (g *Gateway) DeepCopyObject() runtime.Object {
+	out := &Gateway{}
+	copyJSON(g, out)
+	return out
+}
+
+// Size returns the gateway's encoded size, for the cache's accounting
+func (g *Gateway) Size() int {
+	return jsonSize(g)
+}
+
+// DeepCopyObject copies the route, as runtime.Object requires
+func (r *HTTPRoute) DeepCopyObject() runtime.Object {
+	out := &HTTPRoute{}
+	copyJSON(r, out)
+	return out
+}
+
+// Size returns the route's encoded size, for the cache's accounting
+func (r *HTTPRoute) Size() int {
+	return jsonSize(r)
+}
+
+// copyJSON deep copies in to out through their JSON encoding, which the
+// Gateway API types round-trip without loss
+func copyJSON(in, out interface{}) {
+	if data, err := json.Marshal(in); err == nil {
+		_ = json.Unmarshal(data, out)
+	}
+}
+
+func jsonSize(obj interface{}) int {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// AddressList returns the addresses the gateway was given, joined by
+// commas, or "<none>"
+func (g *Gateway) AddressList() string {
+	var addresses []string
+	for _, address := range g.Status.Addresses {
+		addresses = append(addresses, address.Value)
+	}
+	if len(addresses) == 0 {
+		return "<none>"
+	}
+	return strings.Join(addresses, ",")
+}
+
+// Programmed returns the status of the gateway's Programmed condition:
+// "True", "False" or "Unknown" when its controller has not said
+func (g *Gateway) Programmed() string {
+	if cond := findCondition(g.Status.Conditions, "Programmed"); cond != nil {
+		return string(cond.Status)
+	}
+	return string(metav1.ConditionUnknown)
+}
+
+// ParentNamespace returns the namespace of a route's parent, which defaults
+// to the route's own
+func (r *HTTPRoute) ParentNamespace(ref ParentReference) string {
+	if ref.Namespace != "" {
+		return ref.Namespace
+	}
+	return r.Namespace
+}
+
+// ParentNames returns the route's parents as "name", or "namespace/name"
+// for a parent in another namespace, with a listener's section after a dot
+func (r *HTTPRoute) ParentNames() []string {
+	var names []string
+	for _, ref := range r.Spec.ParentRefs {
+		name := ref.Name
+		if ns := r.ParentNamespace(ref); ns != r.Namespace {
+			name = ns + "/" + name
+		}
+		if ref.SectionName != "" {
+			name += "." + ref.SectionName
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// IsGatewayParent returns true when ref names a gateway, the default kind
+// of parent
+func IsGatewayParent(ref ParentReference) bool {
+	return (ref.Group == "" || ref.Group == GatewayAPIGroup) && (ref.Kind == "" || ref.Kind == "Gateway")
+}
+
+// ParentAcceptance is whether a parent accepted a route, from the route's
+// status
+type ParentAcceptance struct {
+	Reported bool   // The parent's controller has reported on the route
+	Accepted bool   // The Accepted condition is True
+	Reason   string // The condition's reason and message when not accepted
+}
+
+// Acceptance returns whether the parent ref accepted the route, from the
+// Accepted condition its controller set in the route's status
+func (r *HTTPRoute) Acceptance(ref ParentReference) ParentAcceptance {
+	for _, parent := range r.Status.Parents {
+		if parent.ParentRef.Name != ref.Name || r.ParentNamespace(parent.ParentRef) != r.ParentNamespace(ref) ||
+			parent.ParentRef.SectionName != ref.SectionName {
+			continue
+		}
+		cond := findCondition(parent.Conditions, "Accepted")
+		if cond == nil {
+			return ParentAcceptance{}
+		}
+		acceptance := ParentAcceptance{Reported: true, Accepted: cond.Status == metav1.ConditionTrue}
+		if !acceptance.Accepted {
+			acceptance.Reason = cond.Reason
+			if cond.Message != "" {
+				acceptance.Reason += ": " + cond.Message
+			}
+		}
+		return acceptance
+	}
+	return ParentAcceptance{}
+}
+
+// BackendNamespace returns the namespace of a route's backend, which
+// defaults to the route's own
+func (r *HTTPRoute) BackendNamespace(ref HTTPBackendRef) string {
+	if ref.Namespace != "" {
+		return ref.Namespace
+	}
+	return r.Namespace
+}
+
+// IsServiceBackend returns true when ref names a service, the default kind
+// of backend
+func IsServiceBackend(ref HTTPBackendRef) bool {
+	return ref.Group == "" && (ref.Kind == "" || ref.Kind == "Service")
+}
+
+// BackendServices returns the service backends of all the route's rules,
+// each once, in the order they appear
+func (r *HTTPRoute) BackendServices() []HTTPBackendRef {
+	var backends []HTTPBackendRef
+	seen := make(map[string]bool)
+	for _, rule := range r.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			key := r.BackendNamespace(ref) + "/" + ref.Name
+			if IsServiceBackend(ref) && !seen[key] {
+				seen[key] = true
+				backends = append(backends, ref)
+			}
+		}
+	}
+	return backends
+}
+
+// findCondition returns the condition of a type, or nil
+func findCondition(conditions []metav1.Condition, conditionType string) *metav1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	// MaxImageChanges is how many image changes are kept per deployment
+	MaxImageChanges = 20
+
+	// MaxImageDeployments is how many deployments the image history tracks;
+	// the one observed longest ago is forgotten first
+	MaxImageDeployments = 2000
+)
+
+// ImageChange is a change of a container image seen in a deployment's spec
+type ImageChange struct {
+	At        time.Time
+	Container string // Empty when the deployment has a single container
+	Old       string
+	New       string
+}
+
+// Describe renders the change for the detail line, relative to now, e.g.
+// "image changed 22m ago: v1.41.2 → v1.42.0"
+func (c ImageChange) Describe(now time.Time) string {
+	what := "image"
+	if c.Container != "" {
+		what = "image of " + c.Container
+	}
+	before, after := ShortImageRefs(c.Old, c.New)
+	return fmt.Sprintf("%s changed %s ago: %s → %s", what, FormatDuration(now.Sub(c.At)), before, after)
+}
+
+// ShortImageRefs shortens two image references to their tags or digests
+// when both name the same repository, so "registry/app:v1" and
+// "registry/app:v2" read "v1" and "v2"
+func ShortImageRefs(before, after string) (string, string) {
+	beforeRepo, beforeTag := splitImageRef(before)
+	afterRepo, afterTag := splitImageRef(after)
+	if beforeRepo != afterRepo || beforeTag == "" || afterTag == "" {
+		return before, after
+	}
+	return beforeTag, afterTag
+}
+
+// splitImageRef splits an image reference into its repository and its tag
+// or digest, which is empty when it has neither
+func splitImageRef(ref string) (string, string) {
+	if i := strings.Index(ref, "@"); i >= 0 {
+		digest := ref[i+1:]
+		// A digest is long; 12 hex characters identify it well enough
+		if _, hex, ok := strings.Cut(digest, ":"); ok && len(hex) > 12 {
+			digest = hex[:12]
+		}
+		return ref[:i], digest
+	}
+	// A colon after the last slash starts the tag; one before it is a port
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
+
+// deploymentImages is what the history knows about one deployment
+type deploymentImages struct {
+	images   map[string]string // Image by container name, as last seen
+	changes  []ImageChange     // Oldest first
+	observed time.Time
+}
+
+// ImageHistory records the image changes of deployments seen during the
+// session, keyed by deployment UID, so a deployment keeps its history when
+// the list moves to another namespace and back. The first sighting of a
+// deployment only records its images.
+type ImageHistory struct {
+	mu          sync.Mutex
+	deployments map[types.UID]*deploymentImages
+}
+
+// NewImageHistory creates an empty image history
+func NewImageHistory() *ImageHistory {
+	return &ImageHistory{deployments: make(map[types.UID]*deploymentImages)}
+}
+
+// Observe records the images of a deployment seen at a time, and returns
+// the changes since it was last seen
+func (h *ImageHistory) Observe(deployment *appsv1.Deployment, at time.Time) []ImageChange {
+	if deployment.UID == "" {
+		return nil
+	}
+	containers := deployment.Spec.Template.Spec.Containers
+	images := make(map[string]string, len(containers))
+	for _, container := range containers {
+		images[container.Name] = container.Image
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	known, ok := h.deployments[deployment.UID]
+	if !ok {
+		h.evictOldest()
+		h.deployments[deployment.UID] = &deploymentImages{images: images, observed: at}
+		return nil
+	}
+
+	var changes []ImageChange
+	for _, container := range containers {
+		old, seen := known.images[container.Name]
+		if !seen || old == container.Image {
+			continue
+		}
+		change := ImageChange{At: at, Old: old, New: container.Image}
+		if len(containers) > 1 {
+			change.Container = container.Name
+		}
+		changes = append(changes, change)
+	}
+	known.images = images
+	known.observed = at
+	known.changes = append(known.changes, changes...)
+	if excess := len(known.changes) - MaxImageChanges; excess > 0 {
+		known.changes = known.changes[excess:]
+	}
+	return changes
+}
+
+// ObserveEvent records the deployment in a watch event. A deleted
+// deployment is forgotten.
+func (h *ImageHistory) ObserveEvent(event watch.Event, at time.Time) []ImageChange {
+	deployment, ok := event.Object.(*appsv1.Deployment)
+	if !ok {
+		return nil
+	}
+	switch event.Type {
+	case watch.Added, watch.Modified:
+		return h.Observe(deployment, at)
+	case watch.Deleted:
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.deployments, deployment.UID)
+	}
+	return nil
+}
+
+// Changes returns the image changes of a deployment, oldest first
+func (h *ImageHistory) Changes(uid types.UID) []ImageChange {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if known, ok := h.deployments[uid]; ok {
+		return append([]ImageChange(nil), known.changes...)
+	}
+	return nil
+}
+
+// Latest returns the most recent image change of a deployment
+func (h *ImageHistory) Latest(uid types.UID) (ImageChange, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if known, ok := h.deployments[uid]; ok && len(known.changes) > 0 {
+		return known.changes[len(known.changes)-1], true
+	}
+	return ImageChange{}, false
+}
+
+// evictOldest makes room for one more deployment by forgetting the one
+// observed longest ago. The caller must hold h.mu.
+func (h *ImageHistory) evictOldest() {
+	if len(h.deployments) < MaxImageDeployments {
+		return
+	}
+	var oldest types.UID
+	var oldestAt time.Time
+	for uid, known := range h.deployments {
+		if oldest == "" || known.observed.Before(oldestAt) {
+			oldest, oldestAt = uid, known.observed
+		}
+	}
+	delete(h.deployments, oldest)
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func deploymentWithImages(uid, namespace string, images ...string) *appsv1.Deployment {
+	d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{UID: types.UID(uid), Name: "web", Namespace: namespace}}
+	names := []string{"web", "sidecar"}
+	for i, image := range images {
+		d.Spec.Template.Spec.Containers = append(d.Spec.Template.Spec.Containers, v1.Container{Name: names[i], Image: image})
+	}
+	return d
+}
+
+func TestImageHistoryWatchEvents(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	events := []watch.Event{
+		{Type: watch.Added, Object: deploymentWithImages("a", "prod", "registry/web:v1.41.2")},
+		{Type: watch.Modified, Object: deploymentWithImages("a", "prod", "registry/web:v1.41.2")}, // Scaled, same image
+		{Type: watch.Modified, Object: deploymentWithImages("a", "prod", "registry/web:v1.42.0")},
+		{Type: watch.Added, Object: deploymentWithImages("b", "staging", "registry/web:v2")},
+		{Type: watch.Modified, Object: deploymentWithImages("a", "prod", "registry/web:v1.42.1")},
+	}
+
+	history := NewImageHistory()
+	for i, event := range events {
+		history.ObserveEvent(event, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	changes := history.Changes("a")
+	want := []ImageChange{
+		{At: start.Add(2 * time.Minute), Old: "registry/web:v1.41.2", New: "registry/web:v1.42.0"},
+		{At: start.Add(4 * time.Minute), Old: "registry/web:v1.42.0", New: "registry/web:v1.42.1"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Expected %d changes, got %+v", len(want), changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("Change %d: expected %+v, got %+v", i, want[i], changes[i])
+		}
+	}
+
+	if _, ok := history.Latest("b"); ok {
+		t.Error("Expected no change for a deployment seen once")
+	}
+
+	history.ObserveEvent(watch.Event{Type: watch.Deleted, Object: deploymentWithImages("a", "prod", "registry/web:v1.42.1")}, start.Add(5*time.Minute))
+	if changes := history.Changes("a"); changes != nil {
+		t.Errorf("Expected a deleted deployment to be forgotten, got %+v", changes)
+	}
+}
+
+func TestImageHistoryMultipleContainers(t *testing.T) {
+	now := time.Now()
+	history := NewImageHistory()
+	history.Observe(deploymentWithImages("a", "prod", "web:v1", "proxy:v1"), now)
+
+	changes := history.Observe(deploymentWithImages("a", "prod", "web:v1", "proxy:v2"), now)
+	if len(changes) != 1 || changes[0].Container != "sidecar" {
+		t.Fatalf("Expected one change naming the sidecar container, got %+v", changes)
+	}
+	if got := changes[0].Describe(now.Add(3 * time.Minute)); got != "image of sidecar changed 3m ago: v1 → v2" {
+		t.Errorf("Unexpected description %q", got)
+	}
+}
+
+func TestImageHistoryBounds(t *testing.T) {
+	now := time.Now()
+	history := NewImageHistory()
+	for i := 0; i <= MaxImageChanges+5; i++ {
+		history.Observe(deploymentWithImages("a", "prod", "web:"+string(rune('a'+i))), now)
+	}
+	if got := len(history.Changes("a")); got != MaxImageChanges {
+		t.Errorf("Expected changes capped at %d, got %d", MaxImageChanges, got)
+	}
+
+	for i := 0; i < MaxImageDeployments; i++ {
+		history.Observe(deploymentWithImages("other-"+string(rune(i)), "prod", "web:v1"), now.Add(time.Second))
+	}
+	if history.Changes("a") != nil {
+		t.Error("Expected the deployment observed longest ago to be evicted")
+	}
+}
+
+func TestImageChangeDescribe(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		old, new string
+		want     string
+	}{
+		{"registry/web:v1.41.2", "registry/web:v1.42.0", "image changed 22m ago: v1.41.2 → v1.42.0"},
+		{"registry:5000/web:v1", "registry:5000/web:v2", "image changed 22m ago: v1 → v2"},
+		{"web:v1", "other:v1", "image changed 22m ago: web:v1 → other:v1"},
+		{"web", "web:v2", "image changed 22m ago: web → web:v2"},
+		{"web@sha256:0123456789abcdef0123", "web@sha256:fedcba9876543210fedc", "image changed 22m ago: 0123456789ab → fedcba987654"},
+	}
+	for _, tt := range tests {
+		change := ImageChange{At: at, Old: tt.old, New: tt.new}
+		if got := change.Describe(at.Add(22 * time.Minute)); got != tt.want {
+			t.Errorf("Describe(%q → %q) = %q, want %q", tt.old, tt.new, got, tt.want)
+		}
+	}
+}
+
+func TestStateRecordsImageChangesAcrossNamespaces(t *testing.T) {
+	state := NewState(&Config{})
+	state.UpdateDeployments([]appsv1.Deployment{*deploymentWithImages("a", "prod", "web:v1")})
+	state.UpdateDeployments([]appsv1.Deployment{*deploymentWithImages("b", "staging", "web:v1")})
+	state.UpdateDeployments([]appsv1.Deployment{*deploymentWithImages("a", "prod", "web:v2")})
+
+	change, ok := state.Images.Latest("a")
+	if !ok || change.Old != "web:v1" || change.New != "web:v2" {
+		t.Errorf("Expected the change to survive the namespace switch, got %+v", change)
+	}
+	if d, ok := state.FindDeployment("", "prod", "web"); !ok || d.UID != "a" {
+		t.Errorf("Expected to find the listed deployment, got %v", d)
+	}
+}
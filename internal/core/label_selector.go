@@ -0,0 +1,66 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SupportsLabelSelector reports whether the list of a resource type is
+// narrowed by the label selector; the others ignore it
+func SupportsLabelSelector(t ResourceType) bool {
+	switch t {
+	case ResourceTypePod, ResourceTypeDeployment, ResourceTypeStatefulSet, ResourceTypeService,
+		ResourceTypeJob, ResourceTypeCronJob:
+		return true
+	}
+	return false
+}
+
+// ParseLabelSelector checks a label selector as kubectl -l takes it, such
+// as app=frontend,tier!=cache, and returns it trimmed. An empty selector
+// selects everything.
+func ParseLabelSelector(selector string) (string, error) {
+	selector = strings.TrimSpace(selector)
+	if _, err := labels.Parse(selector); err != nil {
+		return "", fmt.Errorf("invalid label selector %q: %w", selector, err)
+	}
+	return selector, nil
+}
+
+// SetLabelSelector narrows the lists of the resource types that support it
+// to the objects a label selector matches; empty lists them all. A selector
+// that does not parse is rejected and the current one kept.
+func (s *State) SetLabelSelector(selector string) error {
+	selector, err := ParseLabelSelector(selector)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if selector != s.labelSelector {
+		s.labelSelector = selector
+		s.SelectedIndex = 0
+		s.ScrollOffset = 0
+	}
+	return nil
+}
+
+// GetLabelSelector returns the label selector, or "" when there is none
+func (s *State) GetLabelSelector() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.labelSelector
+}
+
+// ListLabelSelector returns the label selector the current resource type's
+// list is narrowed by: the label selector, when the type supports it
+func (s *State) ListLabelSelector() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !SupportsLabelSelector(s.CurrentResourceType) {
+		return ""
+	}
+	return s.labelSelector
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStateLabelSelector(t *testing.T) {
+	state := NewState(&Config{})
+	state.SetResourceType(ResourceTypePod)
+
+	if err := state.SetLabelSelector(" app=frontend,tier!=cache "); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := state.ListLabelSelector(); got != "app=frontend,tier!=cache" {
+		t.Errorf("Expected the pods narrowed by the trimmed selector, got %q", got)
+	}
+
+	// A selector that does not parse keeps the one in effect
+	err := state.SetLabelSelector("app in (web")
+	if err == nil || !strings.Contains(err.Error(), "invalid label selector") {
+		t.Errorf("Expected the selector rejected, got %v", err)
+	}
+	if got := state.GetLabelSelector(); got != "app=frontend,tier!=cache" {
+		t.Errorf("Expected the selector kept, got %q", got)
+	}
+
+	// Types without label support list everything
+	state.SetResourceType(ResourceTypeConfigMap)
+	if got := state.ListLabelSelector(); got != "" {
+		t.Errorf("Expected configmaps not narrowed, got %q", got)
+	}
+	state.SetResourceType(ResourceTypeService)
+	if got := state.ListLabelSelector(); got != "app=frontend,tier!=cache" {
+		t.Errorf("Expected services narrowed, got %q", got)
+	}
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Thresholds for flagging a change in how much a pod logs
+const (
+	// LogSpikeFactor is how many times its previous rate a pod must log at
+	// for the new rate to be a spike
+	LogSpikeFactor = 5
+
+	// LogSpikeMinRate is the lowest rate, in lines per minute, that counts
+	// as a spike, so a quiet pod going from 2 to 12 lines is not flagged
+	LogSpikeMinRate = 100
+
+	// LogChattyRate is the rate, in lines per minute, above which a pod
+	// that goes silent is flagged
+	LogChattyRate = 10
+)
+
+// LogRate is how fast a pod logs, in lines per minute, estimated from a
+// sample of its recent logs
+type LogRate struct {
+	Lines  float64
+	Errors float64 // Lines that look like errors
+}
+
+// LogRateChange is how a log rate compares with the one sampled before it
+type LogRateChange int
+
+const (
+	LogRateSteady LogRateChange = iota
+	LogRateSpike                // Far more than before
+	LogRateSilent               // Nothing from a pod that was chatty
+)
+
+// NewLogRate returns the rate of lines and errors counted over window. A
+// window of zero or less gives a zero rate.
+func NewLogRate(lines, errors int, window time.Duration) LogRate {
+	if window <= 0 {
+		return LogRate{}
+	}
+	minutes := window.Minutes()
+	return LogRate{Lines: float64(lines) / minutes, Errors: float64(errors) / minutes}
+}
+
+// Compare reports how r changed from the previous rate of the same pod
+func (r LogRate) Compare(previous LogRate) LogRateChange {
+	switch {
+	case r.Lines == 0 && previous.Lines >= LogChattyRate:
+		return LogRateSilent
+	case r.Lines >= LogSpikeMinRate && r.Lines >= previous.Lines*LogSpikeFactor:
+		return LogRateSpike
+	}
+	return LogRateSteady
+}
+
+// FormatLogRate renders a rate for the LOG column. Rates are estimates, so
+// they start with "~"; errors per minute follow a ⚠, and a spike or silence
+// is called out, e.g. "~1.2k/m ⚠32" or "~0/m silent".
+func FormatLogRate(r LogRate, change LogRateChange) string {
+	cell := "~" + formatCount(r.Lines) + "/m"
+	if r.Errors >= 0.5 {
+		cell += " ⚠" + formatCount(r.Errors)
+	}
+	switch change {
+	case LogRateSpike:
+		cell += " ▲"
+	case LogRateSilent:
+		cell += " silent"
+	}
+	return cell
+}
+
+// IsLogRateAlert reports whether a LOG cell calls out a spike or silence
+func IsLogRateAlert(cell string) bool {
+	return strings.HasSuffix(cell, " ▲") || strings.HasSuffix(cell, " silent")
+}
+
+// formatCount abbreviates a count, e.g. 950, 1.2k or 3M
+func formatCount(n float64) string {
+	// The bounds are where rounding would print 1000 of the smaller unit
+	switch {
+	case n >= 999_950:
+		return trimZero(fmt.Sprintf("%.1f", n/1e6)) + "M"
+	case n >= 999.5:
+		return trimZero(fmt.Sprintf("%.1f", n/1e3)) + "k"
+	}
+	return fmt.Sprintf("%.0f", n)
+}
+
+// trimZero drops a trailing ".0"
+func trimZero(s string) string {
+	return strings.TrimSuffix(s, ".0")
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewLogRate(t *testing.T) {
+	tests := []struct {
+		name   string
+		lines  int
+		errors int
+		window time.Duration
+		want   LogRate
+	}{
+		{"one minute", 120, 6, time.Minute, LogRate{Lines: 120, Errors: 6}},
+		{"half a minute", 30, 1, 30 * time.Second, LogRate{Lines: 60, Errors: 2}},
+		{"two minutes", 100, 0, 2 * time.Minute, LogRate{Lines: 50}},
+		{"empty window", 10, 1, 0, LogRate{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewLogRate(tt.lines, tt.errors, tt.window); got != tt.want {
+				t.Errorf("NewLogRate(%d, %d, %v) = %+v, want %+v", tt.lines, tt.errors, tt.window, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogRateCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     float64
+		previous float64
+		want     LogRateChange
+	}{
+		{"steady", 100, 90, LogRateSteady},
+		{"spike", 1000, 100, LogRateSpike},
+		{"quiet pod growing is not a spike", 50, 2, LogRateSteady},
+		{"chatty pod goes silent", 0, 40, LogRateSilent},
+		{"quiet pod stays quiet", 0, 3, LogRateSteady},
+		{"spike from silence", 200, 0, LogRateSpike},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LogRate{Lines: tt.rate}.Compare(LogRate{Lines: tt.previous})
+			if got != tt.want {
+				t.Errorf("Compare(%v from %v) = %v, want %v", tt.rate, tt.previous, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatLogRate(t *testing.T) {
+	tests := []struct {
+		name   string
+		rate   LogRate
+		change LogRateChange
+		want   string
+	}{
+		{"quiet", LogRate{Lines: 12}, LogRateSteady, "~12/m"},
+		{"thousands with errors", LogRate{Lines: 1234, Errors: 32}, LogRateSteady, "~1.2k/m ⚠32"},
+		{"round thousands", LogRate{Lines: 2000}, LogRateSteady, "~2k/m"},
+		{"rounds up to k", LogRate{Lines: 999.7}, LogRateSteady, "~1k/m"},
+		{"millions", LogRate{Lines: 3_400_000}, LogRateSteady, "~3.4M/m"},
+		{"rounds up to M", LogRate{Lines: 999_960}, LogRateSteady, "~1M/m"},
+		{"fewer than one error a minute", LogRate{Lines: 60, Errors: 0.4}, LogRateSteady, "~60/m"},
+		{"spike", LogRate{Lines: 5000, Errors: 1}, LogRateSpike, "~5k/m ⚠1 ▲"},
+		{"silent", LogRate{}, LogRateSilent, "~0/m silent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatLogRate(tt.rate, tt.change)
+			if got != tt.want {
+				t.Errorf("FormatLogRate() = %q, want %q", got, tt.want)
+			}
+			if alert := IsLogRateAlert(got); alert != (tt.change != LogRateSteady) {
+				t.Errorf("IsLogRateAlert(%q) = %v", got, alert)
+			}
+		})
+	}
+}
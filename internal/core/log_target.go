@@ -0,0 +1,99 @@
+package core
+
+// LogPods says whose logs `l` streams for a resource
+type LogPods int
+
+const (
+	// LogPodsNone means the resource has no logs
+	LogPodsNone LogPods = iota
+	// LogPodsSelf streams the pod's own containers
+	LogPodsSelf
+	// LogPodsOwned streams the pods a workload owns
+	LogPodsOwned
+	// LogPodsSelected streams the pods a service's selector matches
+	LogPodsSelected
+)
+
+// LogTarget is what viewing logs does for a resource type
+type LogTarget struct {
+	Pods LogPods
+	// Description says what the logs are, in help and the quick actions
+	Description string
+	// Hint says why there are no logs and what to do instead; shown when
+	// logs are asked for a resource type without them
+	Hint string
+}
+
+// Applies returns true when the resource type has logs to view
+func (t LogTarget) Applies() bool {
+	return t.Pods != LogPodsNone
+}
+
+// LogTargets is what viewing logs does for each resource type. The key
+// binding, the quick actions and help all follow it, so they agree.
+var LogTargets = map[ResourceType]LogTarget{
+	ResourceTypePod: {
+		Pods:        LogPodsSelf,
+		Description: "View the pod's logs",
+	},
+	ResourceTypeDeployment: {
+		Pods:        LogPodsOwned,
+		Description: "View the logs of the deployment's pods",
+	},
+	ResourceTypeStatefulSet: {
+		Pods:        LogPodsOwned,
+		Description: "View the logs of the statefulset's pods",
+	},
+	ResourceTypeJob: {
+		Pods:        LogPodsOwned,
+		Description: "View the logs of the job's pods",
+	},
+	ResourceTypeCronJob: {
+		Hint: "No logs for cron jobs — list Jobs to see the runs it started",
+	},
+	ResourceTypeService: {
+		Pods:        LogPodsSelected,
+		Description: "View the logs of the pods the service selects",
+	},
+	ResourceTypeIngress: {
+		Hint: "No logs for ingresses — press x to see the services behind it",
+	},
+	ResourceTypeGateway: {
+		Hint: "No logs for gateways — press x to see the routes attached to it",
+	},
+	ResourceTypeHTTPRoute: {
+		Hint: "No logs for HTTP routes — press x to see the services behind it",
+	},
+	ResourceTypeConfigMap: {
+		Hint: "No logs for configmaps — press d to describe and browse the data",
+	},
+	ResourceTypeSecret: {
+		Hint: "No logs for secrets — press d to describe and see the keys",
+	},
+	ResourceTypeServiceAccount: {
+		Hint: "No logs for service accounts — press x to see the pods using it",
+	},
+	ResourceTypeRole: {
+		Hint: "No logs for roles — press d to describe and see the rules",
+	},
+	ResourceTypeClusterRole: {
+		Hint: "No logs for cluster roles — press d to describe and see the rules",
+	},
+	ResourceTypeRoleBinding: {
+		Hint: "No logs for role bindings — press d to describe and see who is granted what",
+	},
+	ResourceTypeClusterRoleBinding: {
+		Hint: "No logs for cluster role bindings — press d to describe and see who is granted what",
+	},
+	ResourceTypeNode: {
+		Hint: "No logs for nodes — press N to see the pods on it",
+	},
+}
+
+// LogTargetFor returns what viewing logs does for a resource type
+func LogTargetFor(resourceType ResourceType) LogTarget {
+	if target, ok := LogTargets[resourceType]; ok {
+		return target
+	}
+	return LogTarget{Hint: "No logs for " + string(resourceType)}
+}
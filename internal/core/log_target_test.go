@@ -0,0 +1,37 @@
+package core
+
+import "testing"
+
+func TestLogTargetsCoverResourceTypes(t *testing.T) {
+	for _, resourceType := range AllResourceTypes {
+		target, ok := LogTargets[resourceType]
+		if !ok {
+			t.Errorf("%s: no log target", resourceType)
+			continue
+		}
+		if target.Applies() && (target.Description == "" || target.Hint != "") {
+			t.Errorf("%s: has logs, so wants a description and no hint, got %+v", resourceType, target)
+		}
+		if !target.Applies() && (target.Hint == "" || target.Description != "") {
+			t.Errorf("%s: has no logs, so wants a hint and no description, got %+v", resourceType, target)
+		}
+	}
+
+	want := map[ResourceType]LogPods{
+		ResourceTypePod:         LogPodsSelf,
+		ResourceTypeDeployment:  LogPodsOwned,
+		ResourceTypeStatefulSet: LogPodsOwned,
+		ResourceTypeService:     LogPodsSelected,
+		ResourceTypeConfigMap:   LogPodsNone,
+		ResourceTypeSecret:      LogPodsNone,
+		ResourceTypeIngress:     LogPodsNone,
+	}
+	for resourceType, pods := range want {
+		if got := LogTargetFor(resourceType).Pods; got != pods {
+			t.Errorf("%s: expected logs of %v, got %v", resourceType, pods, got)
+		}
+	}
+	if LogTargetFor("Widgets").Applies() {
+		t.Error("Expected unknown types to have no logs")
+	}
+}
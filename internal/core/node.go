@@ -0,0 +1,65 @@
+package core
+
+import (
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// nodeRoleLabelPrefix marks a node's roles in its labels, e.g.
+// node-role.kubernetes.io/control-plane
+const nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+// NodeStatus summarizes a node as kubectl get nodes does: Ready, NotReady or
+// Unknown from its Ready condition, with SchedulingDisabled added once it is
+// cordoned
+func NodeStatus(node *v1.Node) string {
+	status := "Unknown"
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != v1.NodeReady {
+			continue
+		}
+		switch condition.Status {
+		case v1.ConditionTrue:
+			status = "Ready"
+		case v1.ConditionFalse:
+			status = "NotReady"
+		}
+	}
+	if node.Spec.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return status
+}
+
+// NodeRoles lists a node's roles from its node-role labels, or the older
+// kubernetes.io/role one, as kubectl does: "<none>" when it has none
+func NodeRoles(node *v1.Node) string {
+	var roles []string
+	for label, value := range node.Labels {
+		switch {
+		case strings.HasPrefix(label, nodeRoleLabelPrefix):
+			if role := strings.TrimPrefix(label, nodeRoleLabelPrefix); role != "" {
+				roles = append(roles, role)
+			}
+		case label == "kubernetes.io/role" && value != "":
+			roles = append(roles, value)
+		}
+	}
+	if len(roles) == 0 {
+		return "<none>"
+	}
+	sort.Strings(roles)
+	return strings.Join(roles, ",")
+}
+
+// NodeInternalIP returns a node's internal address, or "<none>"
+func NodeInternalIP(node *v1.Node) string {
+	for _, address := range node.Status.Addresses {
+		if address.Type == v1.NodeInternalIP {
+			return address.Address
+		}
+	}
+	return "<none>"
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeStatus(t *testing.T) {
+	ready := v1.NodeCondition{Type: v1.NodeReady, Status: v1.ConditionTrue}
+	notReady := v1.NodeCondition{Type: v1.NodeReady, Status: v1.ConditionFalse}
+
+	tests := []struct {
+		name     string
+		node     v1.Node
+		expected string
+	}{
+		{"ready", v1.Node{Status: v1.NodeStatus{Conditions: []v1.NodeCondition{ready}}}, "Ready"},
+		{"not ready", v1.Node{Status: v1.NodeStatus{Conditions: []v1.NodeCondition{notReady}}}, "NotReady"},
+		{"no condition", v1.Node{}, "Unknown"},
+		{"cordoned", v1.Node{
+			Spec:   v1.NodeSpec{Unschedulable: true},
+			Status: v1.NodeStatus{Conditions: []v1.NodeCondition{ready}},
+		}, "Ready,SchedulingDisabled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NodeStatus(&tt.node); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNodeRoles(t *testing.T) {
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		expected string
+	}{
+		{"none", map[string]string{"kubernetes.io/hostname": "a"}, "<none>"},
+		{"control plane", map[string]string{
+			"node-role.kubernetes.io/master":        "",
+			"node-role.kubernetes.io/control-plane": "",
+		}, "control-plane,master"},
+		{"legacy label", map[string]string{"kubernetes.io/role": "worker"}, "worker"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := v1.Node{ObjectMeta: metav1.ObjectMeta{Labels: tt.labels}}
+			if got := NodeRoles(&node); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNodeInternalIP(t *testing.T) {
+	node := v1.Node{Status: v1.NodeStatus{Addresses: []v1.NodeAddress{
+		{Type: v1.NodeHostName, Address: "node-a"},
+		{Type: v1.NodeInternalIP, Address: "10.0.0.4"},
+	}}}
+	if got := NodeInternalIP(&node); got != "10.0.0.4" {
+		t.Errorf("Expected 10.0.0.4, got %q", got)
+	}
+	if got := NodeInternalIP(&v1.Node{}); got != "<none>" {
+		t.Errorf("Expected <none> without addresses, got %q", got)
+	}
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// Reasons a resource counts as noise, as shown in the list header
+const (
+	NoiseCompleted    = "completed"
+	NoiseFailed       = "failed"
+	NoiseEvicted      = "evicted"
+	NoiseScaledToZero = "scaled to zero"
+)
+
+// NoiseRules say which resources the noise toggle hides, such as the pods
+// finished cron jobs leave behind
+type NoiseRules struct {
+	// CompletedPods hides pods that ran to completion
+	CompletedPods bool
+	// FailedPodsAfter hides failed and evicted pods once they have been
+	// stopped this long; 0 keeps them
+	FailedPodsAfter time.Duration
+	// ScaledToZeroDeployments hides deployments with no desired replicas
+	ScaledToZeroDeployments bool
+	// ScaledToZeroStatefulSets hides statefulsets with no desired replicas
+	ScaledToZeroStatefulSets bool
+}
+
+// DefaultNoiseRules returns the rules used when the config sets none:
+// completed pods are hidden and nothing else
+func DefaultNoiseRules() NoiseRules {
+	return NoiseRules{CompletedPods: true}
+}
+
+// PodNoise returns why a pod is noise, or "" when it is not
+func (r NoiseRules) PodNoise(pod *v1.Pod, now time.Time) string {
+	switch pod.Status.Phase {
+	case v1.PodSucceeded:
+		if r.CompletedPods {
+			return NoiseCompleted
+		}
+	case v1.PodFailed:
+		if r.FailedPodsAfter > 0 && now.Sub(podStoppedAt(pod)) >= r.FailedPodsAfter {
+			if pod.Status.Reason == "Evicted" {
+				return NoiseEvicted
+			}
+			return NoiseFailed
+		}
+	}
+	return ""
+}
+
+// DeploymentNoise returns why a deployment is noise, or "" when it is not
+func (r NoiseRules) DeploymentNoise(deployment *appsv1.Deployment) string {
+	if r.ScaledToZeroDeployments && deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+		return NoiseScaledToZero
+	}
+	return ""
+}
+
+// StatefulSetNoise returns why a statefulset is noise, or "" when it is not
+func (r NoiseRules) StatefulSetNoise(statefulset *appsv1.StatefulSet) string {
+	if r.ScaledToZeroStatefulSets && statefulset.Spec.Replicas != nil && *statefulset.Spec.Replicas == 0 {
+		return NoiseScaledToZero
+	}
+	return ""
+}
+
+// podStoppedAt returns when a pod's last container stopped, falling back to
+// when it started, or was created, for pods that never ran
+func podStoppedAt(pod *v1.Pod) time.Time {
+	var stopped time.Time
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Terminated != nil && cs.State.Terminated.FinishedAt.After(stopped) {
+			stopped = cs.State.Terminated.FinishedAt.Time
+		}
+	}
+	if !stopped.IsZero() {
+		return stopped
+	}
+	if pod.Status.StartTime != nil {
+		return pod.Status.StartTime.Time
+	}
+	return pod.CreationTimestamp.Time
+}
+
+// NoiseSummary describes hidden resources by reason, e.g.
+// "hiding 214 completed, 3 evicted"; "" when none are hidden
+func NoiseSummary(hidden map[string]int) string {
+	reasons := make([]string, 0, len(hidden))
+	for reason, n := range hidden {
+		if n > 0 {
+			reasons = append(reasons, reason)
+		}
+	}
+	if len(reasons) == 0 {
+		return ""
+	}
+	// Most hidden first, so the usual culprit leads
+	sort.Slice(reasons, func(i, j int) bool {
+		if hidden[reasons[i]] != hidden[reasons[j]] {
+			return hidden[reasons[i]] > hidden[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+	parts := make([]string, len(reasons))
+	for i, reason := range reasons {
+		parts[i] = fmt.Sprintf("%d %s", hidden[reason], reason)
+	}
+	return "hiding " + strings.Join(parts, ", ")
+}
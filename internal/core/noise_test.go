@@ -0,0 +1,75 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNoiseRulesPodNoise(t *testing.T) {
+	now := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+	stopped := func(ago time.Duration) []v1.ContainerStatus {
+		return []v1.ContainerStatus{{State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+			FinishedAt: metav1.NewTime(now.Add(-ago)),
+		}}}}
+	}
+	failed := func(reason string, ago time.Duration) *v1.Pod {
+		return &v1.Pod{Status: v1.PodStatus{Phase: v1.PodFailed, Reason: reason, ContainerStatuses: stopped(ago)}}
+	}
+	completed := &v1.Pod{Status: v1.PodStatus{Phase: v1.PodSucceeded}}
+	running := &v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning}}
+	withFailed := NoiseRules{CompletedPods: true, FailedPodsAfter: time.Hour}
+
+	tests := []struct {
+		name  string
+		rules NoiseRules
+		pod   *v1.Pod
+		want  string
+	}{
+		{"completed by default", DefaultNoiseRules(), completed, NoiseCompleted},
+		{"running is never noise", withFailed, running, ""},
+		{"completed kept when off", NoiseRules{}, completed, ""},
+		{"failed kept by default", DefaultNoiseRules(), failed("Error", 2*time.Hour), ""},
+		{"old failure", withFailed, failed("Error", 2*time.Hour), NoiseFailed},
+		{"recent failure", withFailed, failed("Error", 10*time.Minute), ""},
+		{"old eviction", withFailed, failed("Evicted", 2*time.Hour), NoiseEvicted},
+		{"eviction without containers uses start time", withFailed, &v1.Pod{Status: v1.PodStatus{
+			Phase: v1.PodFailed, Reason: "Evicted", StartTime: &metav1.Time{Time: now.Add(-3 * time.Hour)},
+		}}, NoiseEvicted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.PodNoise(tt.pod, now); got != tt.want {
+				t.Errorf("PodNoise() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoiseRulesScaledToZero(t *testing.T) {
+	zero, one := int32(0), int32(1)
+	rules := NoiseRules{ScaledToZeroDeployments: true}
+
+	if got := rules.DeploymentNoise(&appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: &zero}}); got != NoiseScaledToZero {
+		t.Errorf("Expected a deployment scaled to zero to be noise, got %q", got)
+	}
+	if got := rules.DeploymentNoise(&appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: &one}}); got != "" {
+		t.Errorf("Expected a running deployment kept, got %q", got)
+	}
+	if got := rules.StatefulSetNoise(&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: &zero}}); got != "" {
+		t.Errorf("Expected statefulsets kept unless their rule is on, got %q", got)
+	}
+}
+
+func TestNoiseSummary(t *testing.T) {
+	if got := NoiseSummary(nil); got != "" {
+		t.Errorf("Expected no summary, got %q", got)
+	}
+	got := NoiseSummary(map[string]int{NoiseEvicted: 3, NoiseCompleted: 214, NoiseFailed: 0})
+	if want := "hiding 214 completed, 3 evicted"; got != want {
+		t.Errorf("NoiseSummary() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,354 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	// MaxObjectSnapshots is how many observed changes are kept per object
+	MaxObjectSnapshots = 10
+
+	// maxChangeValueWidth is how much of a changed value is shown
+	maxChangeValueWidth = 40
+)
+
+// ChangeField is a field whose changes the recent changes show
+type ChangeField struct {
+	// Path is the field's path in the object, e.g. "status.phase". A
+	// segment ending in [] steps into each element of a list, and the
+	// element's name labels the change when there are several.
+	Path string
+	// Label names the field in a change; the path when empty
+	Label string
+}
+
+// ChangeFields are the fields followed for each kind. Other fields change
+// too often, like timestamps, or say little, like annotations.
+var ChangeFields = map[string][]ChangeField{
+	"Pod": {
+		{Path: "status.phase"},
+		{Path: "spec.nodeName"},
+		{Path: "metadata.deletionTimestamp", Label: "deletionTimestamp"},
+		{Path: "status.containerStatuses[].ready", Label: "ready"},
+		{Path: "status.containerStatuses[].restartCount", Label: "restartCount"},
+		{Path: "status.containerStatuses[].image", Label: "image"},
+	},
+	"Deployment": {
+		{Path: "spec.replicas"},
+		{Path: "status.readyReplicas"},
+		{Path: "status.updatedReplicas"},
+		{Path: "status.availableReplicas"},
+		{Path: "spec.template.spec.containers[].image", Label: "image"},
+		{Path: "spec.paused"},
+	},
+	"StatefulSet": {
+		{Path: "spec.replicas"},
+		{Path: "status.readyReplicas"},
+		{Path: "status.currentRevision"},
+		{Path: "status.updateRevision"},
+		{Path: "spec.template.spec.containers[].image", Label: "image"},
+	},
+	"Service": {
+		{Path: "spec.type"},
+		{Path: "spec.clusterIP"},
+		{Path: "spec.selector"},
+		{Path: "status.loadBalancer.ingress"},
+	},
+	"Ingress": {
+		{Path: "spec.ingressClassName"},
+		{Path: "status.loadBalancer.ingress"},
+	},
+}
+
+// FieldChange is a followed field that changed between two observations
+type FieldChange struct {
+	Field string
+	Old   string // Empty when the field was unset
+	New   string // Empty when the field was unset
+}
+
+// ObjectEvent is a change of an object seen during the session
+type ObjectEvent struct {
+	Type    watch.EventType // Added or Modified
+	At      time.Time
+	Changes []FieldChange // Followed fields that changed; none when added
+}
+
+// Describe renders the event as a line of the recent changes, e.g.
+// "Modified at 14:02:11 — status.phase Pending→Running"
+func (e ObjectEvent) Describe() string {
+	what := "Modified"
+	if e.Type == watch.Added {
+		what = "Added"
+	}
+	line := fmt.Sprintf("%s at %s", what, FormatClock(e.At))
+	if len(e.Changes) == 0 {
+		return line
+	}
+	changes := make([]string, len(e.Changes))
+	for i, change := range e.Changes {
+		changes[i] = fmt.Sprintf("%s %s→%s", change.Field, changeValue(change.Old), changeValue(change.New))
+	}
+	return line + " — " + strings.Join(changes, ", ")
+}
+
+// changeValue shortens a changed value for a change line
+func changeValue(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	if utf8.RuneCountInString(value) > maxChangeValueWidth {
+		return string([]rune(value)[:maxChangeValueWidth-1]) + "…"
+	}
+	return value
+}
+
+// objectSnapshot is an observed version of an object: its followed fields
+// and how they differ from the version before
+type objectSnapshot struct {
+	ObjectEvent
+	fields map[string]string
+}
+
+// observedObject is what the history knows about one object
+type observedObject struct {
+	kind            string
+	resourceVersion string
+	snapshots       []objectSnapshot // Oldest first
+}
+
+// ObjectHistory records the recent changes of the objects listed or
+// watched during the session, keyed by UID. Each new version of an object,
+// as told by its resource version, is diffed against the one before on the
+// followed fields of its kind.
+type ObjectHistory struct {
+	mu      sync.Mutex
+	objects map[types.UID]*observedObject
+}
+
+// NewObjectHistory creates an empty object history
+func NewObjectHistory() *ObjectHistory {
+	return &ObjectHistory{objects: make(map[types.UID]*observedObject)}
+}
+
+// Observe records an object seen at a time, unless it is the version last
+// seen, and returns the event recorded
+func (h *ObjectHistory) Observe(obj runtime.Object, at time.Time) (ObjectEvent, bool) {
+	kind := objectKind(obj)
+	accessor, err := meta.Accessor(obj)
+	if kind == "" || err != nil || accessor.GetUID() == "" {
+		return ObjectEvent{}, false
+	}
+	uid, resourceVersion := accessor.GetUID(), accessor.GetResourceVersion()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	known, ok := h.objects[uid]
+	if ok && known.resourceVersion == resourceVersion {
+		return ObjectEvent{}, false
+	}
+	fields := changeFieldValues(obj, ChangeFields[kind])
+	if !ok {
+		known = &observedObject{kind: kind}
+		h.objects[uid] = known
+	}
+	snapshot := objectSnapshot{ObjectEvent: ObjectEvent{Type: watch.Added, At: at}, fields: fields}
+	if n := len(known.snapshots); n > 0 {
+		snapshot.Type = watch.Modified
+		snapshot.Changes = diffFields(known.snapshots[n-1].fields, fields, ChangeFields[kind])
+	}
+	known.resourceVersion = resourceVersion
+	known.snapshots = append(known.snapshots, snapshot)
+	if excess := len(known.snapshots) - MaxObjectSnapshots; excess > 0 {
+		known.snapshots = known.snapshots[excess:]
+	}
+	return snapshot.ObjectEvent, true
+}
+
+// ObserveEvent records the object in a watch event. A deleted object is
+// forgotten.
+func (h *ObjectHistory) ObserveEvent(event watch.Event, at time.Time) {
+	switch event.Type {
+	case watch.Added, watch.Modified:
+		h.Observe(event.Object, at)
+	case watch.Deleted:
+		if accessor, err := meta.Accessor(event.Object); err == nil {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(h.objects, accessor.GetUID())
+		}
+	}
+}
+
+// Retain forgets the objects of a kind that are not listed anymore, so the
+// history only holds what can be described
+func (h *ObjectHistory) Retain(kind string, listed map[types.UID]bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for uid, known := range h.objects {
+		if known.kind == kind && !listed[uid] {
+			delete(h.objects, uid)
+		}
+	}
+}
+
+// Events returns the recent changes of an object, oldest first
+func (h *ObjectHistory) Events(uid types.UID) []ObjectEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	known, ok := h.objects[uid]
+	if !ok {
+		return nil
+	}
+	events := make([]ObjectEvent, len(known.snapshots))
+	for i, snapshot := range known.snapshots {
+		events[i] = snapshot.ObjectEvent
+	}
+	return events
+}
+
+// objectKind returns the kind of an object the history follows, or ""
+func objectKind(obj runtime.Object) string {
+	switch obj.(type) {
+	case *v1.Pod:
+		return "Pod"
+	case *appsv1.Deployment:
+		return "Deployment"
+	case *appsv1.StatefulSet:
+		return "StatefulSet"
+	case *v1.Service:
+		return "Service"
+	case *networkingv1.Ingress:
+		return "Ingress"
+	}
+	return ""
+}
+
+// changeFieldValues returns the values of the followed fields of an object
+// by label
+func changeFieldValues(obj runtime.Object, fields []ChangeField) map[string]string {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil
+	}
+	values := make(map[string]string)
+	for _, field := range fields {
+		label := field.Label
+		if label == "" {
+			label = field.Path
+		}
+		collectFieldValues(content, strings.Split(field.Path, "."), label, values)
+	}
+	return values
+}
+
+// collectFieldValues adds the values at a path below value to values
+func collectFieldValues(value interface{}, path []string, label string, values map[string]string) {
+	if len(path) == 0 {
+		if value != nil {
+			values[label] = formatFieldValue(value)
+		}
+		return
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	segment := path[0]
+	if !strings.HasSuffix(segment, "[]") {
+		collectFieldValues(object[segment], path[1:], label, values)
+		return
+	}
+
+	items, _ := object[strings.TrimSuffix(segment, "[]")].([]interface{})
+	for i, item := range items {
+		name, _ := item.(map[string]interface{})["name"].(string)
+		if name == "" {
+			name = fmt.Sprint(i)
+		}
+		collectFieldValues(item, path[1:], fmt.Sprintf("%s[%s]", label, name), values)
+	}
+}
+
+// formatFieldValue renders a field's value: scalars as they are, and lists
+// and maps as compact JSON
+func formatFieldValue(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		return string(encoded)
+	}
+	return fmt.Sprint(value)
+}
+
+// diffFields returns the followed fields that differ between two versions,
+// in the order the kind lists them
+func diffFields(before, after map[string]string, fields []ChangeField) []FieldChange {
+	var changes []FieldChange
+	for _, field := range fields {
+		label := field.Label
+		if label == "" {
+			label = field.Path
+		}
+		// Values of list fields are labelled by element, as "ready[web]"
+		var keys []string
+		for _, key := range sortedKeys(mergeKeys(before, after)) {
+			if key == label || strings.HasPrefix(key, label+"[") {
+				keys = append(keys, key)
+			}
+		}
+		for _, key := range keys {
+			if before[key] == after[key] {
+				continue
+			}
+			// A lone element, like the container of most pods, needs no name
+			name := key
+			if len(keys) == 1 {
+				name = label
+			}
+			changes = append(changes, FieldChange{Field: name, Old: before[key], New: after[key]})
+		}
+	}
+	return changes
+}
+
+// mergeKeys returns a map with the keys of both maps
+func mergeKeys(a, b map[string]string) map[string]string {
+	merged := make(map[string]string, len(a)+len(b))
+	for key := range a {
+		merged[key] = ""
+	}
+	for key := range b {
+		merged[key] = ""
+	}
+	return merged
+}
+
+// sortedKeys returns the keys of values in order
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// podVersion is a version of pod "a" with a phase and per-container restarts
+func podVersion(version int, phase v1.PodPhase, restarts ...int32) *v1.Pod {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		UID: "a", Name: "web-1", Namespace: "prod", ResourceVersion: fmt.Sprint(version),
+	}}
+	pod.Status.Phase = phase
+	names := []string{"web", "sidecar"}
+	for i, n := range restarts {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, v1.ContainerStatus{Name: names[i], RestartCount: n})
+	}
+	return pod
+}
+
+func describeEvents(events []ObjectEvent) []string {
+	lines := make([]string, len(events))
+	for i, event := range events {
+		lines[i] = event.Describe()
+	}
+	return lines
+}
+
+func TestObjectHistoryWatchEvents(t *testing.T) {
+	defer SetTimeFormat(TimeFormat{})
+	SetTimeFormat(TimeFormat{UTC: true, Layout: "15:04"})
+	start := time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC)
+
+	events := []watch.Event{
+		{Type: watch.Added, Object: podVersion(1, v1.PodPending)},
+		{Type: watch.Modified, Object: podVersion(2, v1.PodRunning, 0)},
+		{Type: watch.Modified, Object: podVersion(2, v1.PodRunning, 0)}, // Same version again
+		{Type: watch.Modified, Object: podVersion(3, v1.PodRunning, 0)}, // Nothing followed changed
+		{Type: watch.Modified, Object: podVersion(4, v1.PodRunning, 1)},
+		{Type: watch.Modified, Object: podVersion(5, v1.PodFailed, 2, 0)},
+	}
+	history := NewObjectHistory()
+	for i, event := range events {
+		history.ObserveEvent(event, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	want := []string{
+		"Added at 14:00 UTC",
+		"Modified at 14:01 UTC — status.phase Pending→Running, ready <none>→false, restartCount <none>→0",
+		"Modified at 14:03 UTC",
+		"Modified at 14:04 UTC — restartCount 0→1",
+		// Once there are several containers, each change names its own
+		"Modified at 14:05 UTC — status.phase Running→Failed, ready[sidecar] <none>→false, restartCount[sidecar] <none>→0, restartCount[web] 1→2",
+	}
+	got := describeEvents(history.Events("a"))
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+
+	history.ObserveEvent(watch.Event{Type: watch.Deleted, Object: podVersion(6, v1.PodFailed)}, start.Add(6*time.Minute))
+	if events := history.Events("a"); events != nil {
+		t.Errorf("Expected a deleted pod to be forgotten, got %v", describeEvents(events))
+	}
+}
+
+func TestObjectHistoryKeepsLastSnapshots(t *testing.T) {
+	defer SetTimeFormat(TimeFormat{})
+	SetTimeFormat(TimeFormat{UTC: true, Layout: "15:04"})
+	start := time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC)
+
+	history := NewObjectHistory()
+	for i := 0; i < MaxObjectSnapshots+5; i++ {
+		history.Observe(podVersion(i, v1.PodRunning, int32(i)), start.Add(time.Duration(i)*time.Minute))
+	}
+
+	events := history.Events("a")
+	if len(events) != MaxObjectSnapshots {
+		t.Fatalf("Expected %d events kept, got %d", MaxObjectSnapshots, len(events))
+	}
+	// The oldest kept still shows its change from the one before
+	if want := "Modified at 14:05 UTC — restartCount 4→5"; events[0].Describe() != want {
+		t.Errorf("Expected %q, got %q", want, events[0].Describe())
+	}
+}
+
+func TestObjectHistoryFieldsByKind(t *testing.T) {
+	defer SetTimeFormat(TimeFormat{})
+	SetTimeFormat(TimeFormat{UTC: true, Layout: "15:04"})
+	at := time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC)
+
+	deployment := func(version string, replicas int32, image string) *appsv1.Deployment {
+		d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{UID: "d", ResourceVersion: version}}
+		d.Spec.Replicas = &replicas
+		d.Spec.Template.Spec.Containers = []v1.Container{{Name: "web", Image: image}}
+		return d
+	}
+	service := func(version string, ingress ...string) *v1.Service {
+		s := &v1.Service{ObjectMeta: metav1.ObjectMeta{UID: "s", ResourceVersion: version}}
+		s.Spec.Type = v1.ServiceTypeLoadBalancer
+		for _, ip := range ingress {
+			s.Status.LoadBalancer.Ingress = append(s.Status.LoadBalancer.Ingress, v1.LoadBalancerIngress{IP: ip})
+		}
+		return s
+	}
+
+	history := NewObjectHistory()
+	history.Observe(deployment("1", 2, "registry/web:v1"), at)
+	history.Observe(deployment("2", 3, "registry/web:v2"), at)
+	history.Observe(service("1"), at)
+	history.Observe(service("2", "10.0.0.1"), at)
+	history.Observe(&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{UID: "c", ResourceVersion: "1"}}, at)
+
+	tests := []struct {
+		uid  types.UID
+		want string
+	}{
+		{"d", "Modified at 14:00 UTC — spec.replicas 2→3, image registry/web:v1→registry/web:v2"},
+		{"s", `Modified at 14:00 UTC — status.loadBalancer.ingress <none>→[{"ip":"10.0.0.1"}]`},
+	}
+	for _, tt := range tests {
+		events := history.Events(tt.uid)
+		if len(events) != 2 || events[1].Describe() != tt.want {
+			t.Errorf("%s: expected %q, got %v", tt.uid, tt.want, describeEvents(events))
+		}
+	}
+	if events := history.Events("c"); events != nil {
+		t.Errorf("Expected kinds without followed fields ignored, got %v", describeEvents(events))
+	}
+}
+
+func TestStateForgetsChangesOfUnlistedResources(t *testing.T) {
+	state := NewState(&Config{})
+	first := podVersion(1, v1.PodRunning, 0)
+	second := podVersion(2, v1.PodRunning, 1)
+
+	state.UpdatePods([]v1.Pod{*first})
+	state.UpdatePods([]v1.Pod{*second})
+	if uid, ok := state.FindUID("", "prod", "web-1"); !ok || uid != "a" {
+		t.Fatalf("Expected web-1 found as a, got %q", uid)
+	}
+	if events := state.Changes.Events("a"); len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %v", describeEvents(events))
+	}
+
+	// Deployments listed don't forget pods
+	state.UpdateDeployments(nil)
+	if events := state.Changes.Events("a"); len(events) != 2 {
+		t.Fatalf("Expected pod events kept, got %v", describeEvents(events))
+	}
+
+	state.UpdatePods(nil)
+	if events := state.Changes.Events("a"); events != nil {
+		t.Errorf("Expected an unlisted pod forgotten, got %v", describeEvents(events))
+	}
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// PodScope narrows a pod list to the pods of one workload, such as the
+// deployment selected in the top pane of a linked split
+type PodScope struct {
+	Kind      string // Kind of the workload, e.g. "Deployment"
+	Namespace string
+	Name      string
+	Context   string // The workload's context in multi-context mode, else ""
+
+	// Selector is nil when the workload's selector cannot be expressed as
+	// a label selector; its pods are then matched by owner
+	Selector labels.Selector
+}
+
+// DeploymentPodScope returns the scope of a deployment's pods: the pods in
+// its namespace that its selector matches, less those a ReplicaSet of
+// another deployment owns when selectors overlap
+func DeploymentPodScope(deployment *appsv1.Deployment) (*PodScope, error) {
+	if deployment.Spec.Selector == nil {
+		return nil, fmt.Errorf("deployment %s has no selector", deployment.Name)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("deployment %s: %w", deployment.Name, err)
+	}
+	return &PodScope{
+		Kind:      "Deployment",
+		Namespace: deployment.Namespace,
+		Name:      deployment.Name,
+		Selector:  selector,
+	}, nil
+}
+
+// WorkloadPodScope returns the scope of the pods of a deployment or
+// statefulset. Its selector's matchLabels and matchExpressions both carry
+// over; a selector that does not translate, or selects everything, leaves
+// the pods matched by the workload owning them instead.
+func WorkloadPodScope(kind, namespace, name string, selector *metav1.LabelSelector) *PodScope {
+	scope := &PodScope{Kind: kind, Namespace: namespace, Name: name}
+	if selector == nil || len(selector.MatchLabels)+len(selector.MatchExpressions) == 0 {
+		return scope
+	}
+	if translated, err := metav1.LabelSelectorAsSelector(selector); err == nil {
+		scope.Selector = translated
+	}
+	return scope
+}
+
+// Matches returns true when pod is in the scope
+func (s *PodScope) Matches(pod *v1.Pod) bool {
+	if pod.Namespace != s.Namespace {
+		return false
+	}
+	if s.Selector == nil {
+		kind, name := PodWorkload(pod)
+		return kind == s.Kind && name == s.Name
+	}
+	if !s.Selector.Matches(labels.Set(pod.Labels)) {
+		return false
+	}
+	if s.Kind == "Deployment" {
+		if owner := replicaSetDeployment(pod); owner != "" && owner != s.Name {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesIn returns true when pod, listed in context, is in the scope. A
+// scope without a context matches pods of any.
+func (s *PodScope) MatchesIn(context string, pod *v1.Pod) bool {
+	return (s.Context == "" || s.Context == context) && s.Matches(pod)
+}
+
+// Equal returns true when both scopes select the same pods. Nil scopes are
+// equal to each other only.
+func (s *PodScope) Equal(other *PodScope) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	if s.Kind != other.Kind || s.Namespace != other.Namespace || s.Name != other.Name || s.Context != other.Context {
+		return false
+	}
+	if s.Selector == nil || other.Selector == nil {
+		return s.Selector == nil && other.Selector == nil
+	}
+	return s.Selector.String() == other.Selector.String()
+}
+
+// String describes the scope for a pane title, e.g. "pods of deployment web"
+func (s *PodScope) String() string {
+	return fmt.Sprintf("pods of %s %s", strings.ToLower(s.Kind), s.Name)
+}
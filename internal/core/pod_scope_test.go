@@ -0,0 +1,162 @@
+package core
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func scopedPod(name, namespace, app, replicaSet string) *v1.Pod {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    map[string]string{"app": app},
+	}}
+	if replicaSet != "" {
+		pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey] = "5d8f7"
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: replicaSet + "-5d8f7"}}
+	}
+	return pod
+}
+
+func TestDeploymentPodScope(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "prod"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+	scope, err := DeploymentPodScope(deployment)
+	if err != nil {
+		t.Fatalf("DeploymentPodScope() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		pod  *v1.Pod
+		want bool
+	}{
+		{"pod of the deployment", scopedPod("web-5d8f7-abcde", "prod", "web", "web"), true},
+		{"matching pod without an owner", scopedPod("web-debug", "prod", "web", ""), true},
+		{"other namespace", scopedPod("web-5d8f7-abcde", "staging", "web", "web"), false},
+		{"other labels", scopedPod("api-5d8f7-abcde", "prod", "api", "api"), false},
+		{"overlapping selector, other deployment", scopedPod("web-canary-5d8f7-abcde", "prod", "web", "web-canary"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scope.Matches(tt.pod); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if scope.String() != "pods of deployment web" {
+		t.Errorf("Unexpected description %q", scope.String())
+	}
+	same, _ := DeploymentPodScope(deployment.DeepCopy())
+	if !scope.Equal(same) || scope.Equal(nil) {
+		t.Error("Expected scopes of the same deployment to be equal, and not to nil")
+	}
+
+	deployment.Spec.Selector = nil
+	if _, err := DeploymentPodScope(deployment); err == nil {
+		t.Error("Expected an error for a deployment without a selector")
+	}
+}
+
+func TestWorkloadPodScope(t *testing.T) {
+	owned := func(name, kind, owner string, labels map[string]string) *v1.Pod {
+		pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", Labels: labels}}
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: kind, Name: owner, Controller: &controller}}
+		return pod
+	}
+
+	tests := []struct {
+		name     string
+		selector *metav1.LabelSelector
+		pod      *v1.Pod
+		want     bool
+	}{
+		{
+			name: "In and Exists",
+			selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"checkout", "checkout-v2"}},
+				{Key: "tier", Operator: metav1.LabelSelectorOpExists},
+			}},
+			pod:  owned("checkout-0", "StatefulSet", "checkout", map[string]string{"app": "checkout-v2", "tier": "web"}),
+			want: true,
+		},
+		{
+			name: "Exists unmet",
+			selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: metav1.LabelSelectorOpExists},
+			}},
+			pod:  owned("checkout-0", "StatefulSet", "checkout", map[string]string{"app": "checkout"}),
+			want: false,
+		},
+		{
+			name: "matchLabels with NotIn and DoesNotExist",
+			selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "checkout"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "track", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"canary"}},
+					{Key: "debug", Operator: metav1.LabelSelectorOpDoesNotExist},
+				},
+			},
+			pod:  owned("checkout-1", "StatefulSet", "checkout", map[string]string{"app": "checkout", "track": "canary"}),
+			want: false,
+		},
+		{
+			// In without values cannot be expressed, so the owner decides
+			name: "untranslatable selector, owned pod",
+			selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "app", Operator: metav1.LabelSelectorOpIn},
+			}},
+			pod:  owned("checkout-0", "StatefulSet", "checkout", nil),
+			want: true,
+		},
+		{
+			name: "untranslatable selector, other owner",
+			selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "app", Operator: "Near", Values: []string{"checkout"}},
+			}},
+			pod:  owned("cart-0", "StatefulSet", "cart", map[string]string{"app": "checkout"}),
+			want: false,
+		},
+		{
+			name:     "empty selector matches by owner, not everything",
+			selector: &metav1.LabelSelector{},
+			pod:      owned("cart-0", "StatefulSet", "cart", map[string]string{"app": "checkout"}),
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope := WorkloadPodScope("StatefulSet", "shop", "checkout", tt.selector)
+			if got := scope.Matches(tt.pod); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// The deployment behind a pod's ReplicaSet owns it
+	scope := WorkloadPodScope("Deployment", "shop", "checkout", nil)
+	if !scope.Matches(scopedPod("checkout-5d8f7-abcde", "shop", "", "checkout")) ||
+		scope.Matches(scopedPod("cart-5d8f7-abcde", "shop", "", "cart")) {
+		t.Error("Expected a deployment without a selector to match the pods of its ReplicaSets only")
+	}
+	if !scope.Equal(WorkloadPodScope("Deployment", "shop", "checkout", nil)) ||
+		scope.Equal(WorkloadPodScope("Deployment", "shop", "checkout", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}})) {
+		t.Error("Expected owner-matched scopes equal to each other only")
+	}
+
+	// Scoped to a context, pods of other contexts are left out
+	scope.Context = "prod"
+	pod := scopedPod("checkout-5d8f7-abcde", "shop", "", "checkout")
+	if !scope.MatchesIn("prod", pod) || scope.MatchesIn("staging", pod) {
+		t.Error("Expected only the pods of the workload's context to match")
+	}
+}
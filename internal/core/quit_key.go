@@ -0,0 +1,29 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QuitKeyBehavior says what q does outside the resource list
+type QuitKeyBehavior int
+
+const (
+	// QuitKeyContextual makes q close the current view like Esc, quitting
+	// only from the resource list, as in less and k9s
+	QuitKeyContextual QuitKeyBehavior = iota
+	// QuitKeyGlobal makes q quit from every view that binds it
+	QuitKeyGlobal
+)
+
+// ParseQuitKeyBehavior reads a config value: "contextual" or "global";
+// empty means contextual
+func ParseQuitKeyBehavior(s string) (QuitKeyBehavior, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "contextual":
+		return QuitKeyContextual, nil
+	case "global":
+		return QuitKeyGlobal, nil
+	}
+	return QuitKeyContextual, fmt.Errorf("unknown quit key behavior %q: use contextual or global", s)
+}
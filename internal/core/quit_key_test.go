@@ -0,0 +1,25 @@
+package core
+
+import "testing"
+
+func TestParseQuitKeyBehavior(t *testing.T) {
+	tests := []struct {
+		value   string
+		expect  QuitKeyBehavior
+		wantErr bool
+	}{
+		{"", QuitKeyContextual, false},
+		{"contextual", QuitKeyContextual, false},
+		{" Global ", QuitKeyGlobal, false},
+		{"always", QuitKeyContextual, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseQuitKeyBehavior(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: expected error %v, got %v", tt.value, tt.wantErr, err)
+		}
+		if got != tt.expect {
+			t.Errorf("%q: expected %v, got %v", tt.value, tt.expect, got)
+		}
+	}
+}
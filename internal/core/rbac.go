@@ -0,0 +1,97 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// maxSubjectsShown is how many of a binding's subjects its SUBJECTS column
+// names before counting the rest
+const maxSubjectsShown = 2
+
+// IsClusterScoped returns true for a resource type whose objects belong to
+// no namespace. Such a type is listed across the cluster whatever namespace
+// is chosen, and has no NAMESPACE column.
+func IsClusterScoped(t ResourceType) bool {
+	switch t {
+	case ResourceTypeClusterRole, ResourceTypeClusterRoleBinding, ResourceTypeNode:
+		return true
+	}
+	return false
+}
+
+// IsProtectedResourceType returns true for a resource type whose deletion
+// can lock users or controllers out of the cluster, RBAC's, or takes a
+// machine out of it, a node's. Deleting one asks for its name to be typed,
+// not just confirmed.
+func IsProtectedResourceType(t ResourceType) bool {
+	return ProtectedDeletionWarning(t) != ""
+}
+
+// ProtectedDeletionWarning explains what deleting an object of a protected
+// resource type risks, or returns "" for other types
+func ProtectedDeletionWarning(t ResourceType) string {
+	switch t {
+	case ResourceTypeServiceAccount, ResourceTypeRole, ResourceTypeClusterRole,
+		ResourceTypeRoleBinding, ResourceTypeClusterRoleBinding:
+		return "This is an access control object. Deleting it can lock users or\n" +
+			"controllers out of the cluster, and it cannot be undone."
+	case ResourceTypeNode:
+		return "This is a node. Deleting it takes it out of the cluster without\n" +
+			"draining it first, and it cannot be undone."
+	}
+	return ""
+}
+
+// RoleRefName names the role a binding grants as kubectl does, e.g.
+// "ClusterRole/view"
+func RoleRefName(ref rbacv1.RoleRef) string {
+	return ref.Kind + "/" + ref.Name
+}
+
+// SubjectName names a binding's subject with its kind, e.g. "User/alice"
+// or, as service accounts are namespaced, "ServiceAccount/ci/deployer"
+func SubjectName(subject rbacv1.Subject) string {
+	if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace != "" {
+		return subject.Kind + "/" + subject.Namespace + "/" + subject.Name
+	}
+	return subject.Kind + "/" + subject.Name
+}
+
+// SubjectsSummary names the first of a binding's subjects, counting the
+// rest, e.g. "User/alice, Group/devs +3 more"
+func SubjectsSummary(subjects []rbacv1.Subject) string {
+	if len(subjects) == 0 {
+		return "<none>"
+	}
+	names := make([]string, 0, maxSubjectsShown)
+	for i := 0; i < len(subjects) && i < maxSubjectsShown; i++ {
+		names = append(names, SubjectName(subjects[i]))
+	}
+	summary := strings.Join(names, ", ")
+	if extra := len(subjects) - len(names); extra > 0 {
+		summary += fmt.Sprintf(" +%d more", extra)
+	}
+	return summary
+}
+
+// bindsServiceAccount returns true when subjects include the service
+// account namespace/name. A subject's namespace is that of a role binding
+// when left out.
+func bindsServiceAccount(subjects []rbacv1.Subject, bindingNamespace, namespace, name string) bool {
+	for _, subject := range subjects {
+		if subject.Kind != rbacv1.ServiceAccountKind || subject.Name != name {
+			continue
+		}
+		subjectNamespace := subject.Namespace
+		if subjectNamespace == "" {
+			subjectNamespace = bindingNamespace
+		}
+		if subjectNamespace == namespace {
+			return true
+		}
+	}
+	return false
+}
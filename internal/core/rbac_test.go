@@ -0,0 +1,31 @@
+package core
+
+import (
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestSubjectsSummary(t *testing.T) {
+	deployer := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "deployer", Namespace: "ci"}
+	alice := rbacv1.Subject{Kind: rbacv1.UserKind, Name: "alice"}
+	devs := rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "devs"}
+
+	tests := []struct {
+		name     string
+		subjects []rbacv1.Subject
+		expected string
+	}{
+		{"none", nil, "<none>"},
+		{"service account", []rbacv1.Subject{deployer}, "ServiceAccount/ci/deployer"},
+		{"two", []rbacv1.Subject{alice, devs}, "User/alice, Group/devs"},
+		{"more", []rbacv1.Subject{alice, devs, deployer, alice}, "User/alice, Group/devs +2 more"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SubjectsSummary(tt.subjects); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,914 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Relation names how a related resource is connected to the one asked about
+type Relation string
+
+const (
+	RelationOwners    Relation = "Owners"
+	RelationOwns      Relation = "Owns"
+	RelationServices  Relation = "Services selecting it"
+	RelationSelects   Relation = "Selects"
+	RelationIngresses Relation = "Ingresses routing to it"
+	RelationRoutes    Relation = "HTTPRoutes routing to it"
+	RelationAttached  Relation = "HTTPRoutes attached"
+	RelationParents   Relation = "Parent gateways"
+	RelationRoutesTo  Relation = "Routes to"
+	RelationConfigMap Relation = "ConfigMaps"
+	RelationSecrets   Relation = "Secrets"
+	RelationPVCs      Relation = "PersistentVolumeClaims"
+	RelationUsedBy    Relation = "Used by"
+	RelationGrants    Relation = "Grants"
+	RelationSubjects  Relation = "Subjects"
+	RelationBoundBy   Relation = "Bound by"
+)
+
+// relationOrder is the order relations are listed in
+var relationOrder = []Relation{
+	RelationOwners,
+	RelationOwns,
+	RelationServices,
+	RelationSelects,
+	RelationIngresses,
+	RelationRoutes,
+	RelationAttached,
+	RelationParents,
+	RelationRoutesTo,
+	RelationConfigMap,
+	RelationSecrets,
+	RelationPVCs,
+	RelationUsedBy,
+	RelationGrants,
+	RelationSubjects,
+	RelationBoundBy,
+}
+
+// RelatedResource is an immediate neighbour of a resource in the relationship
+// graph
+type RelatedResource struct {
+	Relation  Relation
+	Kind      string       // e.g. "Pod" or "PersistentVolumeClaim"
+	Type      ResourceType // Empty for kinds kubewatch does not list
+	Namespace string
+	Name      string
+	Missing   bool   // Referenced but not among the cached objects
+	Problem   string // Why the relation is not working, e.g. a route not accepted
+}
+
+// String names the resource as kubectl would, e.g. "configmap/app-config"
+func (r RelatedResource) String() string {
+	return strings.ToLower(r.Kind) + "/" + r.Name
+}
+
+// ObjectSnapshot holds the cached objects relationships are resolved against
+type ObjectSnapshot struct {
+	Pods         []v1.Pod
+	Deployments  []appsv1.Deployment
+	StatefulSets []appsv1.StatefulSet
+	Services     []v1.Service
+	Ingresses    []networkingv1.Ingress
+	Gateways     []Gateway
+	HTTPRoutes   []HTTPRoute
+	ConfigMaps   []v1.ConfigMap
+	Secrets      []v1.Secret
+
+	ServiceAccounts     []v1.ServiceAccount
+	Roles               []rbacv1.Role
+	ClusterRoles        []rbacv1.ClusterRole
+	RoleBindings        []rbacv1.RoleBinding
+	ClusterRoleBindings []rbacv1.ClusterRoleBinding
+}
+
+// ObjectSnapshot returns the objects cached by the last refresh of each
+// resource type. Lists are replaced rather than modified on refresh, so the
+// snapshot shares them.
+func (s *State) ObjectSnapshot() *ObjectSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &ObjectSnapshot{
+		Pods:         s.Pods,
+		Deployments:  s.Deployments,
+		StatefulSets: s.StatefulSets,
+		Services:     s.Services,
+		Ingresses:    s.Ingresses,
+		Gateways:     s.Gateways,
+		HTTPRoutes:   s.HTTPRoutes,
+		ConfigMaps:   s.ConfigMaps,
+		Secrets:      s.Secrets,
+
+		ServiceAccounts:     s.ServiceAccounts,
+		Roles:               s.Roles,
+		ClusterRoles:        s.ClusterRoles,
+		RoleBindings:        s.RoleBindings,
+		ClusterRoleBindings: s.ClusterRoleBindings,
+	}
+}
+
+// RelationResolver finds the neighbours of the named resource among the
+// snapshot's objects, returning false when the resource itself is not cached
+type RelationResolver func(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool)
+
+var relationResolvers = map[ResourceType]RelationResolver{
+	ResourceTypePod:         resolvePodRelations,
+	ResourceTypeDeployment:  resolveDeploymentRelations,
+	ResourceTypeStatefulSet: resolveStatefulSetRelations,
+	ResourceTypeService:     resolveServiceRelations,
+	ResourceTypeIngress:     resolveIngressRelations,
+	ResourceTypeGateway:     resolveGatewayRelations,
+	ResourceTypeHTTPRoute:   resolveHTTPRouteRelations,
+	ResourceTypeConfigMap:   resolveConfigMapRelations,
+	ResourceTypeSecret:      resolveSecretRelations,
+
+	ResourceTypeServiceAccount:     resolveServiceAccountRelations,
+	ResourceTypeRole:               resolveRoleRelations,
+	ResourceTypeClusterRole:        resolveClusterRoleRelations,
+	ResourceTypeRoleBinding:        resolveRoleBindingRelations,
+	ResourceTypeClusterRoleBinding: resolveClusterRoleBindingRelations,
+}
+
+// RegisterRelationResolver sets the resolver for a resource type, replacing
+// any built-in one
+func RegisterRelationResolver(resourceType ResourceType, resolver RelationResolver) {
+	relationResolvers[resourceType] = resolver
+}
+
+// ResolveRelations lists the neighbours of a resource, grouped by relation in
+// display order and sorted by kind and name within each group. Only cached
+// objects are consulted, so no API calls are made.
+func ResolveRelations(snap *ObjectSnapshot, resourceType ResourceType, namespace, name string) ([]RelatedResource, error) {
+	resolver, ok := relationResolvers[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("relationships are not supported for %s", resourceType)
+	}
+	related, found := resolver(snap, namespace, name)
+	if !found {
+		return nil, fmt.Errorf("%s %s is not cached; refresh the list and try again", resourceType, name)
+	}
+
+	rank := make(map[Relation]int, len(relationOrder))
+	for i, r := range relationOrder {
+		rank[r] = i
+	}
+	related = dedupeRelated(related)
+	sort.SliceStable(related, func(i, j int) bool {
+		a, b := related[i], related[j]
+		if a.Relation != b.Relation {
+			return rank[a.Relation] < rank[b.Relation]
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return related, nil
+}
+
+// dedupeRelated drops repeats of the same resource under the same relation
+func dedupeRelated(related []RelatedResource) []RelatedResource {
+	seen := make(map[RelatedResource]bool, len(related))
+	result := related[:0]
+	for _, r := range related {
+		if !seen[r] {
+			seen[r] = true
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func resolvePodRelations(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+	var pod *v1.Pod
+	for i := range snap.Pods {
+		if snap.Pods[i].Namespace == namespace && snap.Pods[i].Name == name {
+			pod = &snap.Pods[i]
+			break
+		}
+	}
+	if pod == nil {
+		return nil, false
+	}
+
+	related := podOwners(snap, pod)
+	services := servicesSelecting(snap, namespace, pod.Labels)
+	related = append(related, services...)
+	related = append(related, ingressesRoutingTo(snap, namespace, services)...)
+	related = append(related, httpRoutesRoutingTo(snap, namespace, services)...)
+	related = append(related, podSpecReferences(snap, namespace, &pod.Spec)...)
+	return related, true
+}
+
+func resolveDeploymentRelations(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+	for _, d := range snap.Deployments {
+		if d.Namespace != namespace || d.Name != name {
+			continue
+		}
+		related := objectOwners(snap, namespace, d.OwnerReferences)
+		for _, pod := range snap.Pods {
+			if pod.Namespace == namespace && replicaSetDeployment(&pod) == name {
+				related = append(related, relatedPod(RelationOwns, &pod))
+			}
+		}
+		services := servicesSelecting(snap, namespace, d.Spec.Template.Labels)
+		related = append(related, services...)
+		related = append(related, ingressesRoutingTo(snap, namespace, services)...)
+		related = append(related, httpRoutesRoutingTo(snap, namespace, services)...)
+		related = append(related, podSpecReferences(snap, namespace, &d.Spec.Template.Spec)...)
+		return related, true
+	}
+	return nil, false
+}
+
+func resolveStatefulSetRelations(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+	for _, sts := range snap.StatefulSets {
+		if sts.Namespace != namespace || sts.Name != name {
+			continue
+		}
+		related := objectOwners(snap, namespace, sts.OwnerReferences)
+		for _, pod := range snap.Pods {
+			if pod.Namespace == namespace && ownedBy(pod.OwnerReferences, "StatefulSet", name) {
+				related = append(related, relatedPod(RelationOwns, &pod))
+				// Claims from volumeClaimTemplates are named after the pod
+				for _, tmpl := range sts.Spec.VolumeClaimTemplates {
+					related = append(related, RelatedResource{
+						Relation:  RelationPVCs,
+						Kind:      "PersistentVolumeClaim",
+						Namespace: namespace,
+						Name:      tmpl.Name + "-" + pod.Name,
+					})
+				}
+			}
+		}
+		services := servicesSelecting(snap, namespace, sts.Spec.Template.Labels)
+		related = append(related, services...)
+		related = append(related, ingressesRoutingTo(snap, namespace, services)...)
+		related = append(related, httpRoutesRoutingTo(snap, namespace, services)...)
+		related = append(related, podSpecReferences(snap, namespace, &sts.Spec.Template.Spec)...)
+		return related, true
+	}
+	return nil, false
+}
+
+func resolveServiceRelations(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+	for _, svc := range snap.Services {
+		if svc.Namespace != namespace || svc.Name != name {
+			continue
+		}
+		related := objectOwners(snap, namespace, svc.OwnerReferences)
+		if len(svc.Spec.Selector) > 0 {
+			selector := labels.SelectorFromSet(svc.Spec.Selector)
+			for _, pod := range snap.Pods {
+				if pod.Namespace == namespace && selector.Matches(labels.Set(pod.Labels)) {
+					related = append(related, relatedPod(RelationSelects, &pod))
+				}
+			}
+		}
+		self := []RelatedResource{{Kind: "Service", Name: name}}
+		related = append(related, ingressesRoutingTo(snap, namespace, self)...)
+		related = append(related, httpRoutesRoutingTo(snap, namespace, self)...)
+		return related, true
+	}
+	return nil, false
+}
+
+func resolveIngressRelations(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+	for _, ing := range snap.Ingresses {
+		if ing.Namespace != namespace || ing.Name != name {
+			continue
+		}
+		related := objectOwners(snap, namespace, ing.OwnerReferences)
+		for _, backend := range ingressServiceNames(&ing) {
+			related = append(related, RelatedResource{
+				Relation:  RelationRoutesTo,
+				Kind:      "Service",
+				Type:      ResourceTypeService,
+				Namespace: namespace,
+				Name:      backend,
+				Missing:   !hasService(snap, namespace, backend),
+			})
+		}
+		for _, tls := range ing.Spec.TLS {
+			if tls.SecretName != "" {
+				related = append(related, secretRef(snap, namespace, tls.SecretName))
+			}
+		}
+		return related, true
+	}
+	return nil, false
+}
+
+func resolveGatewayRelations(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+	for _, gw := range snap.Gateways {
+		if gw.Namespace != namespace || gw.Name != name {
+			continue
+		}
+		related := objectOwners(snap, namespace, gw.OwnerReferences)
+		for i := range snap.HTTPRoutes {
+			route := &snap.HTTPRoutes[i]
+			for _, ref := range route.Spec.ParentRefs {
+				if IsGatewayParent(ref) && ref.Name == name && route.ParentNamespace(ref) == namespace {
+					related = append(related, RelatedResource{
+						Relation:  RelationAttached,
+						Kind:      "HTTPRoute",
+						Type:      ResourceTypeHTTPRoute,
+						Namespace: route.Namespace,
+						Name:      route.Name,
+						Problem:   acceptanceProblem(route.Acceptance(ref)),
+					})
+					break
+				}
+			}
+		}
+		return related, true
+	}
+	return nil, false
+}
+
+func resolveHTTPRouteRelations(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+	for i := range snap.HTTPRoutes {
+		route := &snap.HTTPRoutes[i]
+		if route.Namespace != namespace || route.Name != name {
+			continue
+		}
+		related := objectOwners(snap, namespace, route.OwnerReferences)
+		for _, ref := range route.Spec.ParentRefs {
+			parent := RelatedResource{
+				Relation:  RelationParents,
+				Kind:      ref.Kind,
+				Namespace: route.ParentNamespace(ref),
+				Name:      ref.Name,
+				Problem:   acceptanceProblem(route.Acceptance(ref)),
+			}
+			if IsGatewayParent(ref) {
+				parent.Kind = "Gateway"
+				parent.Type = ResourceTypeGateway
+				parent.Missing = !hasGateway(snap, parent.Namespace, ref.Name)
+			}
+			related = append(related, parent)
+		}
+		for _, backend := range route.BackendServices() {
+			backendNamespace := route.BackendNamespace(backend)
+			related = append(related, RelatedResource{
+				Relation:  RelationRoutesTo,
+				Kind:      "Service",
+				Type:      ResourceTypeService,
+				Namespace: backendNamespace,
+				Name:      backend.Name,
+				Missing:   !hasService(snap, backendNamespace, backend.Name),
+			})
+		}
+		return related, true
+	}
+	return nil, false
+}
+
+// acceptanceProblem describes a parent that has not accepted a route, or ""
+// for one that has
+func acceptanceProblem(acceptance ParentAcceptance) string {
+	switch {
+	case !acceptance.Reported:
+		return "acceptance not reported"
+	case !acceptance.Accepted:
+		return "not accepted: " + acceptance.Reason
+	}
+	return ""
+}
+
+func resolveConfigMapRelations(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+	for _, cm := range snap.ConfigMaps {
+		if cm.Namespace == namespace && cm.Name == name {
+			related := objectOwners(snap, namespace, cm.OwnerReferences)
+			return append(related, usersOf(snap, namespace, "ConfigMap", name)...), true
+		}
+	}
+	return nil, false
+}
+
+func resolveSecretRelations(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+	for _, secret := range snap.Secrets {
+		if secret.Namespace == namespace && secret.Name == name {
+			related := objectOwners(snap, namespace, secret.OwnerReferences)
+			return append(related, usersOf(snap, namespace, "Secret", name)...), true
+		}
+	}
+	return nil, false
+}
+
+// podOwners lists a pod's owners. A pod created by a deployment is owned by
+// a ReplicaSet, which kubewatch does not list, so the deployment is shown in
+// its place.
+func podOwners(snap *ObjectSnapshot, pod *v1.Pod) []RelatedResource {
+	if deployment := replicaSetDeployment(pod); deployment != "" {
+		return []RelatedResource{{
+			Relation:  RelationOwners,
+			Kind:      "Deployment",
+			Type:      ResourceTypeDeployment,
+			Namespace: pod.Namespace,
+			Name:      deployment,
+			Missing:   !hasDeployment(snap, pod.Namespace, deployment),
+		}}
+	}
+	return objectOwners(snap, pod.Namespace, pod.OwnerReferences)
+}
+
+// PodWorkload returns the kind and name of the workload pod belongs to: the
+// deployment behind its ReplicaSet, else its controller, or "" for a pod no
+// controller owns
+func PodWorkload(pod *v1.Pod) (string, string) {
+	if deployment := replicaSetDeployment(pod); deployment != "" {
+		return "Deployment", deployment
+	}
+	if ref := metav1.GetControllerOf(pod); ref != nil {
+		return ref.Kind, ref.Name
+	}
+	return "", ""
+}
+
+// replicaSetDeployment returns the deployment behind the ReplicaSet owning
+// pod, from the ReplicaSet's name and the pod-template-hash label, or ""
+func replicaSetDeployment(pod *v1.Pod) string {
+	hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+	if hash == "" {
+		return ""
+	}
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == "ReplicaSet" && strings.HasSuffix(ref.Name, "-"+hash) {
+			return strings.TrimSuffix(ref.Name, "-"+hash)
+		}
+	}
+	return ""
+}
+
+// objectOwners lists the owners in refs, marking those of cached kinds that
+// are not cached as missing
+func objectOwners(snap *ObjectSnapshot, namespace string, refs []metav1.OwnerReference) []RelatedResource {
+	var related []RelatedResource
+	for _, ref := range refs {
+		r := RelatedResource{Relation: RelationOwners, Kind: ref.Kind, Namespace: namespace, Name: ref.Name}
+		switch ref.Kind {
+		case "Deployment":
+			r.Type = ResourceTypeDeployment
+			r.Missing = !hasDeployment(snap, namespace, ref.Name)
+		case "StatefulSet":
+			r.Type = ResourceTypeStatefulSet
+			r.Missing = !hasStatefulSet(snap, namespace, ref.Name)
+		}
+		related = append(related, r)
+	}
+	return related
+}
+
+// ownedBy returns true when refs name an owner of the given kind and name
+func ownedBy(refs []metav1.OwnerReference, kind, name string) bool {
+	for _, ref := range refs {
+		if ref.Kind == kind && ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// servicesSelecting lists the cached services whose selector matches
+// podLabels. Services without a selector are skipped.
+func servicesSelecting(snap *ObjectSnapshot, namespace string, podLabels map[string]string) []RelatedResource {
+	var related []RelatedResource
+	for _, svc := range snap.Services {
+		if svc.Namespace != namespace || len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		if labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(podLabels)) {
+			related = append(related, RelatedResource{
+				Relation:  RelationServices,
+				Kind:      "Service",
+				Type:      ResourceTypeService,
+				Namespace: namespace,
+				Name:      svc.Name,
+			})
+		}
+	}
+	return related
+}
+
+// ingressesRoutingTo lists the cached ingresses with a backend among services
+func ingressesRoutingTo(snap *ObjectSnapshot, namespace string, services []RelatedResource) []RelatedResource {
+	names := make(map[string]bool, len(services))
+	for _, svc := range services {
+		names[svc.Name] = true
+	}
+
+	var related []RelatedResource
+	for _, ing := range snap.Ingresses {
+		if ing.Namespace != namespace {
+			continue
+		}
+		for _, backend := range ingressServiceNames(&ing) {
+			if names[backend] {
+				related = append(related, RelatedResource{
+					Relation:  RelationIngresses,
+					Kind:      "Ingress",
+					Type:      ResourceTypeIngress,
+					Namespace: namespace,
+					Name:      ing.Name,
+				})
+				break
+			}
+		}
+	}
+	return related
+}
+
+// httpRoutesRoutingTo lists the cached HTTP routes with a backend among
+// services
+func httpRoutesRoutingTo(snap *ObjectSnapshot, namespace string, services []RelatedResource) []RelatedResource {
+	names := make(map[string]bool, len(services))
+	for _, svc := range services {
+		names[svc.Name] = true
+	}
+
+	var related []RelatedResource
+	for i := range snap.HTTPRoutes {
+		route := &snap.HTTPRoutes[i]
+		for _, backend := range route.BackendServices() {
+			if route.BackendNamespace(backend) == namespace && names[backend.Name] {
+				related = append(related, RelatedResource{
+					Relation:  RelationRoutes,
+					Kind:      "HTTPRoute",
+					Type:      ResourceTypeHTTPRoute,
+					Namespace: route.Namespace,
+					Name:      route.Name,
+				})
+				break
+			}
+		}
+	}
+	return related
+}
+
+// ingressServiceNames returns the services an ingress routes to, including
+// its default backend
+func ingressServiceNames(ing *networkingv1.Ingress) []string {
+	var names []string
+	if b := ing.Spec.DefaultBackend; b != nil && b.Service != nil {
+		names = append(names, b.Service.Name)
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil {
+				names = append(names, path.Backend.Service.Name)
+			}
+		}
+	}
+	return names
+}
+
+func resolveServiceAccountRelations(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+	for _, sa := range snap.ServiceAccounts {
+		if sa.Namespace != namespace || sa.Name != name {
+			continue
+		}
+		related := objectOwners(snap, namespace, sa.OwnerReferences)
+		for _, d := range snap.Deployments {
+			if d.Namespace == namespace && podServiceAccount(&d.Spec.Template.Spec) == name {
+				related = append(related, RelatedResource{Relation: RelationUsedBy, Kind: "Deployment", Type: ResourceTypeDeployment, Namespace: namespace, Name: d.Name})
+			}
+		}
+		for _, sts := range snap.StatefulSets {
+			if sts.Namespace == namespace && podServiceAccount(&sts.Spec.Template.Spec) == name {
+				related = append(related, RelatedResource{Relation: RelationUsedBy, Kind: "StatefulSet", Type: ResourceTypeStatefulSet, Namespace: namespace, Name: sts.Name})
+			}
+		}
+		for _, pod := range snap.Pods {
+			if pod.Namespace == namespace && podServiceAccount(&pod.Spec) == name {
+				related = append(related, relatedPod(RelationUsedBy, &pod))
+			}
+		}
+		for _, rb := range snap.RoleBindings {
+			if bindsServiceAccount(rb.Subjects, rb.Namespace, namespace, name) {
+				related = append(related, RelatedResource{Relation: RelationBoundBy, Kind: "RoleBinding", Type: ResourceTypeRoleBinding, Namespace: rb.Namespace, Name: rb.Name})
+			}
+		}
+		for _, crb := range snap.ClusterRoleBindings {
+			if bindsServiceAccount(crb.Subjects, "", namespace, name) {
+				related = append(related, RelatedResource{Relation: RelationBoundBy, Kind: "ClusterRoleBinding", Type: ResourceTypeClusterRoleBinding, Name: crb.Name})
+			}
+		}
+		return related, true
+	}
+	return nil, false
+}
+
+func resolveRoleRelations(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+	for _, role := range snap.Roles {
+		if role.Namespace != namespace || role.Name != name {
+			continue
+		}
+		var related []RelatedResource
+		for _, rb := range snap.RoleBindings {
+			if rb.Namespace == namespace && rb.RoleRef.Kind == "Role" && rb.RoleRef.Name == name {
+				related = append(related, RelatedResource{Relation: RelationBoundBy, Kind: "RoleBinding", Type: ResourceTypeRoleBinding, Namespace: rb.Namespace, Name: rb.Name})
+			}
+		}
+		return related, true
+	}
+	return nil, false
+}
+
+func resolveClusterRoleRelations(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+	for _, role := range snap.ClusterRoles {
+		if role.Name != name {
+			continue
+		}
+		var related []RelatedResource
+		for _, rb := range snap.RoleBindings {
+			if rb.RoleRef.Kind == "ClusterRole" && rb.RoleRef.Name == name {
+				related = append(related, RelatedResource{Relation: RelationBoundBy, Kind: "RoleBinding", Type: ResourceTypeRoleBinding, Namespace: rb.Namespace, Name: rb.Name})
+			}
+		}
+		for _, crb := range snap.ClusterRoleBindings {
+			if crb.RoleRef.Name == name {
+				related = append(related, RelatedResource{Relation: RelationBoundBy, Kind: "ClusterRoleBinding", Type: ResourceTypeClusterRoleBinding, Name: crb.Name})
+			}
+		}
+		return related, true
+	}
+	return nil, false
+}
+
+func resolveRoleBindingRelations(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+	for _, rb := range snap.RoleBindings {
+		if rb.Namespace == namespace && rb.Name == name {
+			return bindingRelations(snap, rb.Namespace, rb.RoleRef, rb.Subjects), true
+		}
+	}
+	return nil, false
+}
+
+func resolveClusterRoleBindingRelations(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+	for _, crb := range snap.ClusterRoleBindings {
+		if crb.Name == name {
+			return bindingRelations(snap, "", crb.RoleRef, crb.Subjects), true
+		}
+	}
+	return nil, false
+}
+
+// bindingRelations lists the role a binding in namespace grants, empty for
+// a cluster role binding, and the subjects it grants it to. Users and groups
+// are not objects kubewatch lists, so only service accounts can be missing.
+func bindingRelations(snap *ObjectSnapshot, namespace string, roleRef rbacv1.RoleRef, subjects []rbacv1.Subject) []RelatedResource {
+	role := RelatedResource{Relation: RelationGrants, Kind: roleRef.Kind, Name: roleRef.Name}
+	switch roleRef.Kind {
+	case "Role":
+		role.Type = ResourceTypeRole
+		role.Namespace = namespace
+		role.Missing = !hasRole(snap, namespace, roleRef.Name)
+	case "ClusterRole":
+		role.Type = ResourceTypeClusterRole
+		role.Missing = !hasClusterRole(snap, roleRef.Name)
+	}
+	related := []RelatedResource{role}
+
+	for _, subject := range subjects {
+		r := RelatedResource{Relation: RelationSubjects, Kind: subject.Kind, Namespace: subject.Namespace, Name: subject.Name}
+		if subject.Kind == rbacv1.ServiceAccountKind {
+			if r.Namespace == "" {
+				r.Namespace = namespace
+			}
+			r.Type = ResourceTypeServiceAccount
+			r.Missing = !hasServiceAccount(snap, r.Namespace, subject.Name)
+		}
+		related = append(related, r)
+	}
+	return related
+}
+
+// podServiceAccount returns the service account a pod spec runs as; one
+// naming none runs as the namespace's "default"
+func podServiceAccount(spec *v1.PodSpec) string {
+	if spec.ServiceAccountName == "" {
+		return "default"
+	}
+	return spec.ServiceAccountName
+}
+
+// podSpecReferences lists the configmaps, secrets and claims a pod spec
+// mounts or reads environment variables from
+func podSpecReferences(snap *ObjectSnapshot, namespace string, spec *v1.PodSpec) []RelatedResource {
+	var related []RelatedResource
+	configMap := func(name string) {
+		related = append(related, RelatedResource{
+			Relation:  RelationConfigMap,
+			Kind:      "ConfigMap",
+			Type:      ResourceTypeConfigMap,
+			Namespace: namespace,
+			Name:      name,
+			Missing:   !hasConfigMap(snap, namespace, name),
+		})
+	}
+	secret := func(name string) {
+		related = append(related, secretRef(snap, namespace, name))
+	}
+
+	for _, vol := range spec.Volumes {
+		switch {
+		case vol.ConfigMap != nil:
+			configMap(vol.ConfigMap.Name)
+		case vol.Secret != nil:
+			secret(vol.Secret.SecretName)
+		case vol.PersistentVolumeClaim != nil:
+			related = append(related, RelatedResource{
+				Relation:  RelationPVCs,
+				Kind:      "PersistentVolumeClaim",
+				Namespace: namespace,
+				Name:      vol.PersistentVolumeClaim.ClaimName,
+			})
+		case vol.Projected != nil:
+			for _, source := range vol.Projected.Sources {
+				if source.ConfigMap != nil {
+					configMap(source.ConfigMap.Name)
+				}
+				if source.Secret != nil {
+					secret(source.Secret.Name)
+				}
+			}
+		}
+	}
+
+	containers := append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, c := range containers {
+		for _, from := range c.EnvFrom {
+			if from.ConfigMapRef != nil {
+				configMap(from.ConfigMapRef.Name)
+			}
+			if from.SecretRef != nil {
+				secret(from.SecretRef.Name)
+			}
+		}
+		for _, env := range c.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+				configMap(ref.Name)
+			}
+			if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+				secret(ref.Name)
+			}
+		}
+	}
+
+	for _, ref := range spec.ImagePullSecrets {
+		secret(ref.Name)
+	}
+	return related
+}
+
+// usersOf lists the cached workloads and pods referencing a configmap or
+// secret
+func usersOf(snap *ObjectSnapshot, namespace, kind, name string) []RelatedResource {
+	uses := func(spec *v1.PodSpec) bool {
+		for _, r := range podSpecReferences(snap, namespace, spec) {
+			if r.Kind == kind && r.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	var related []RelatedResource
+	for _, d := range snap.Deployments {
+		if d.Namespace == namespace && uses(&d.Spec.Template.Spec) {
+			related = append(related, RelatedResource{Relation: RelationUsedBy, Kind: "Deployment", Type: ResourceTypeDeployment, Namespace: namespace, Name: d.Name})
+		}
+	}
+	for _, sts := range snap.StatefulSets {
+		if sts.Namespace == namespace && uses(&sts.Spec.Template.Spec) {
+			related = append(related, RelatedResource{Relation: RelationUsedBy, Kind: "StatefulSet", Type: ResourceTypeStatefulSet, Namespace: namespace, Name: sts.Name})
+		}
+	}
+	for _, pod := range snap.Pods {
+		if pod.Namespace == namespace && uses(&pod.Spec) {
+			related = append(related, relatedPod(RelationUsedBy, &pod))
+		}
+	}
+	for _, ing := range snap.Ingresses {
+		if kind != "Secret" || ing.Namespace != namespace {
+			continue
+		}
+		for _, tls := range ing.Spec.TLS {
+			if tls.SecretName == name {
+				related = append(related, RelatedResource{Relation: RelationUsedBy, Kind: "Ingress", Type: ResourceTypeIngress, Namespace: namespace, Name: ing.Name})
+				break
+			}
+		}
+	}
+	return related
+}
+
+func relatedPod(relation Relation, pod *v1.Pod) RelatedResource {
+	return RelatedResource{Relation: relation, Kind: "Pod", Type: ResourceTypePod, Namespace: pod.Namespace, Name: pod.Name}
+}
+
+func secretRef(snap *ObjectSnapshot, namespace, name string) RelatedResource {
+	found := false
+	for _, s := range snap.Secrets {
+		if s.Namespace == namespace && s.Name == name {
+			found = true
+			break
+		}
+	}
+	return RelatedResource{
+		Relation:  RelationSecrets,
+		Kind:      "Secret",
+		Type:      ResourceTypeSecret,
+		Namespace: namespace,
+		Name:      name,
+		Missing:   !found,
+	}
+}
+
+func hasDeployment(snap *ObjectSnapshot, namespace, name string) bool {
+	for _, d := range snap.Deployments {
+		if d.Namespace == namespace && d.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasStatefulSet(snap *ObjectSnapshot, namespace, name string) bool {
+	for _, sts := range snap.StatefulSets {
+		if sts.Namespace == namespace && sts.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasService(snap *ObjectSnapshot, namespace, name string) bool {
+	for _, svc := range snap.Services {
+		if svc.Namespace == namespace && svc.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasGateway(snap *ObjectSnapshot, namespace, name string) bool {
+	for _, gw := range snap.Gateways {
+		if gw.Namespace == namespace && gw.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasServiceAccount(snap *ObjectSnapshot, namespace, name string) bool {
+	for _, sa := range snap.ServiceAccounts {
+		if sa.Namespace == namespace && sa.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasRole(snap *ObjectSnapshot, namespace, name string) bool {
+	for _, role := range snap.Roles {
+		if role.Namespace == namespace && role.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasClusterRole(snap *ObjectSnapshot, name string) bool {
+	for _, role := range snap.ClusterRoles {
+		if role.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasConfigMap(snap *ObjectSnapshot, namespace, name string) bool {
+	for _, cm := range snap.ConfigMaps {
+		if cm.Namespace == namespace && cm.Name == name {
+			return true
+		}
+	}
+	return false
+}
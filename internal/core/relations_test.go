@@ -0,0 +1,381 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func relationTestSnapshot() *ObjectSnapshot {
+	webLabels := map[string]string{"app": "web"}
+	podSpec := v1.PodSpec{
+		Volumes: []v1.Volume{
+			{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "web-config"}}}},
+			{Name: "data", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "web-data"}}},
+		},
+		Containers: []v1.Container{{
+			Name: "web",
+			EnvFrom: []v1.EnvFromSource{
+				{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "web-creds"}}},
+				{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "feature-flags"}}},
+			},
+		}},
+	}
+
+	return &ObjectSnapshot{
+		Pods: []v1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web-5d8f7-abcde",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "web", "pod-template-hash": "5d8f7"},
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "ReplicaSet", Name: "web-5d8f7"},
+				},
+			},
+			Spec: podSpec,
+		}},
+		Deployments: []appsv1.Deployment{{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: webLabels},
+				Spec:       podSpec,
+			}},
+		}},
+		Services: []v1.Service{
+			{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}, Spec: v1.ServiceSpec{Selector: webLabels}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "other"}, Spec: v1.ServiceSpec{Selector: webLabels}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "manual", Namespace: "default"}},
+		},
+		Ingresses: []networkingv1.Ingress{{
+			ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: "default"},
+			Spec: networkingv1.IngressSpec{
+				TLS: []networkingv1.IngressTLS{{SecretName: "public-tls"}},
+				Rules: []networkingv1.IngressRule{{IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{
+						{Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "web"}}},
+						{Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "api"}}},
+					}},
+				}}},
+			},
+		}},
+		ConfigMaps: []v1.ConfigMap{{ObjectMeta: metav1.ObjectMeta{Name: "web-config", Namespace: "default"}}},
+		Secrets:    []v1.Secret{{ObjectMeta: metav1.ObjectMeta{Name: "web-creds", Namespace: "default"}}},
+	}
+}
+
+// describeRelated renders related resources as "Relation: kind/name" lines,
+// with missing ones suffixed " (missing)" and any problem in brackets
+func describeRelated(related []RelatedResource) string {
+	var lines []string
+	for _, r := range related {
+		line := string(r.Relation) + ": " + r.String()
+		if r.Missing {
+			line += " (missing)"
+		}
+		if r.Problem != "" {
+			line += " [" + r.Problem + "]"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestResolveRelations(t *testing.T) {
+	snap := relationTestSnapshot()
+
+	tests := []struct {
+		name         string
+		resourceType ResourceType
+		resource     string
+		want         []string
+	}{
+		{
+			name:         "pod",
+			resourceType: ResourceTypePod,
+			resource:     "web-5d8f7-abcde",
+			want: []string{
+				"Owners: deployment/web",
+				"Services selecting it: service/web",
+				"Ingresses routing to it: ingress/public",
+				"ConfigMaps: configmap/feature-flags (missing)",
+				"ConfigMaps: configmap/web-config",
+				"Secrets: secret/web-creds",
+				"PersistentVolumeClaims: persistentvolumeclaim/web-data",
+			},
+		},
+		{
+			name:         "deployment",
+			resourceType: ResourceTypeDeployment,
+			resource:     "web",
+			want: []string{
+				"Owns: pod/web-5d8f7-abcde",
+				"Services selecting it: service/web",
+				"Ingresses routing to it: ingress/public",
+				"ConfigMaps: configmap/feature-flags (missing)",
+				"ConfigMaps: configmap/web-config",
+				"Secrets: secret/web-creds",
+				"PersistentVolumeClaims: persistentvolumeclaim/web-data",
+			},
+		},
+		{
+			name:         "service",
+			resourceType: ResourceTypeService,
+			resource:     "web",
+			want: []string{
+				"Selects: pod/web-5d8f7-abcde",
+				"Ingresses routing to it: ingress/public",
+			},
+		},
+		{
+			name:         "ingress",
+			resourceType: ResourceTypeIngress,
+			resource:     "public",
+			want: []string{
+				"Routes to: service/api (missing)",
+				"Routes to: service/web",
+				"Secrets: secret/public-tls (missing)",
+			},
+		},
+		{
+			name:         "configmap",
+			resourceType: ResourceTypeConfigMap,
+			resource:     "web-config",
+			want: []string{
+				"Used by: deployment/web",
+				"Used by: pod/web-5d8f7-abcde",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			related, err := ResolveRelations(snap, tt.resourceType, "default", tt.resource)
+			if err != nil {
+				t.Fatalf("ResolveRelations failed: %v", err)
+			}
+			if got, want := describeRelated(related), strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+			}
+		})
+	}
+
+	if _, err := ResolveRelations(snap, ResourceTypePod, "default", "gone"); err == nil {
+		t.Error("Expected an error for a resource that is not cached")
+	}
+}
+
+func TestRegisterRelationResolver(t *testing.T) {
+	original := relationResolvers[ResourceTypeSecret]
+	defer RegisterRelationResolver(ResourceTypeSecret, original)
+
+	RegisterRelationResolver(ResourceTypeSecret, func(snap *ObjectSnapshot, namespace, name string) ([]RelatedResource, bool) {
+		return []RelatedResource{{Relation: RelationUsedBy, Kind: "Pod", Type: ResourceTypePod, Name: "custom"}}, true
+	})
+	related, err := ResolveRelations(&ObjectSnapshot{}, ResourceTypeSecret, "default", "anything")
+	if err != nil || len(related) != 1 || related[0].Name != "custom" {
+		t.Errorf("Expected the registered resolver to be used, got %v, %v", related, err)
+	}
+}
+
+// TestResolveGatewayRelations tests that a route's parents say whether they
+// accepted it and its backends whether they exist, and that gateways and
+// services find the routes using them
+func TestResolveGatewayRelations(t *testing.T) {
+	accepted := metav1.Condition{Type: "Accepted", Status: metav1.ConditionTrue, Reason: "Accepted"}
+	rejected := metav1.Condition{Type: "Accepted", Status: metav1.ConditionFalse, Reason: "NotAllowedByListeners", Message: "no listener allows this namespace"}
+	snap := &ObjectSnapshot{
+		Services: []v1.Service{{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}},
+		Gateways: []Gateway{{ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: "default"}}},
+		HTTPRoutes: []HTTPRoute{{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-route", Namespace: "default"},
+			Spec: HTTPRouteSpec{
+				ParentRefs: []ParentReference{{Name: "public"}, {Name: "internal"}, {Name: "edge", Namespace: "infra"}},
+				Rules: []HTTPRouteRule{
+					{BackendRefs: []HTTPBackendRef{{Name: "web"}, {Name: "api"}}},
+					{BackendRefs: []HTTPBackendRef{{Name: "web"}}},
+				},
+			},
+			Status: HTTPRouteStatus{Parents: []RouteParentStatus{
+				{ParentRef: ParentReference{Name: "public"}, Conditions: []metav1.Condition{accepted}},
+				{ParentRef: ParentReference{Name: "internal"}, Conditions: []metav1.Condition{rejected}},
+			}},
+		}},
+	}
+
+	tests := []struct {
+		name         string
+		resourceType ResourceType
+		resource     string
+		want         []string
+	}{
+		{
+			name:         "httproute",
+			resourceType: ResourceTypeHTTPRoute,
+			resource:     "web-route",
+			want: []string{
+				"Parent gateways: gateway/edge (missing) [acceptance not reported]",
+				"Parent gateways: gateway/internal (missing) [not accepted: NotAllowedByListeners: no listener allows this namespace]",
+				"Parent gateways: gateway/public",
+				"Routes to: service/api (missing)",
+				"Routes to: service/web",
+			},
+		},
+		{
+			name:         "gateway",
+			resourceType: ResourceTypeGateway,
+			resource:     "public",
+			want:         []string{"HTTPRoutes attached: httproute/web-route"},
+		},
+		{
+			name:         "service",
+			resourceType: ResourceTypeService,
+			resource:     "web",
+			want:         []string{"HTTPRoutes routing to it: httproute/web-route"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			related, err := ResolveRelations(snap, tt.resourceType, "default", tt.resource)
+			if err != nil {
+				t.Fatalf("ResolveRelations failed: %v", err)
+			}
+			if got, want := describeRelated(related), strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+			}
+		})
+	}
+}
+
+// TestResolveRBACRelations tests that a service account finds the pods and
+// workloads running as it and the bindings naming it, and that a binding
+// finds the role it grants and its subjects
+func TestResolveRBACRelations(t *testing.T) {
+	snap := &ObjectSnapshot{
+		Pods: []v1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "deployer-1", Namespace: "ci"}, Spec: v1.PodSpec{ServiceAccountName: "deployer"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "ci"}},
+		},
+		Deployments: []appsv1.Deployment{{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployer", Namespace: "ci"},
+			Spec:       appsv1.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{ServiceAccountName: "deployer"}}},
+		}},
+		ServiceAccounts: []v1.ServiceAccount{
+			{ObjectMeta: metav1.ObjectMeta{Name: "deployer", Namespace: "ci"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "ci"}},
+		},
+		Roles: []rbacv1.Role{{ObjectMeta: metav1.ObjectMeta{Name: "deploy", Namespace: "ci"}}},
+		RoleBindings: []rbacv1.RoleBinding{{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployer-deploy", Namespace: "ci"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "deploy"},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Name: "deployer"},
+				{Kind: rbacv1.UserKind, Name: "alice"},
+			},
+		}},
+		ClusterRoleBindings: []rbacv1.ClusterRoleBinding{{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployer-view"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "deployer", Namespace: "ci"}},
+		}},
+	}
+
+	tests := []struct {
+		name         string
+		resourceType ResourceType
+		namespace    string
+		resource     string
+		want         []string
+	}{
+		{
+			name:         "serviceaccount",
+			resourceType: ResourceTypeServiceAccount,
+			namespace:    "ci",
+			resource:     "deployer",
+			want: []string{
+				"Used by: deployment/deployer",
+				"Used by: pod/deployer-1",
+				"Bound by: clusterrolebinding/deployer-view",
+				"Bound by: rolebinding/deployer-deploy",
+			},
+		},
+		{
+			name:         "default serviceaccount",
+			resourceType: ResourceTypeServiceAccount,
+			namespace:    "ci",
+			resource:     "default",
+			want:         []string{"Used by: pod/web-1"},
+		},
+		{
+			name:         "rolebinding",
+			resourceType: ResourceTypeRoleBinding,
+			namespace:    "ci",
+			resource:     "deployer-deploy",
+			want: []string{
+				"Grants: role/deploy",
+				"Subjects: serviceaccount/deployer",
+				"Subjects: user/alice",
+			},
+		},
+		{
+			name:         "clusterrolebinding",
+			resourceType: ResourceTypeClusterRoleBinding,
+			resource:     "deployer-view",
+			want: []string{
+				"Grants: clusterrole/view (missing)",
+				"Subjects: serviceaccount/deployer",
+			},
+		},
+		{
+			name:         "role",
+			resourceType: ResourceTypeRole,
+			namespace:    "ci",
+			resource:     "deploy",
+			want:         []string{"Bound by: rolebinding/deployer-deploy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			related, err := ResolveRelations(snap, tt.resourceType, tt.namespace, tt.resource)
+			if err != nil {
+				t.Fatalf("ResolveRelations failed: %v", err)
+			}
+			if got, want := describeRelated(related), strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+			}
+		})
+	}
+}
+
+func TestPodWorkload(t *testing.T) {
+	controller := true
+	owned := func(kind, name string, labels map[string]string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &controller}},
+		}}
+	}
+	tests := []struct {
+		name       string
+		pod        *v1.Pod
+		kind, want string
+	}{
+		{"deployment", owned("ReplicaSet", "web-5d8f7", map[string]string{"pod-template-hash": "5d8f7"}), "Deployment", "web"},
+		{"bare replica set", owned("ReplicaSet", "web-5d8f7", nil), "ReplicaSet", "web-5d8f7"},
+		{"statefulset", owned("StatefulSet", "db", nil), "StatefulSet", "db"},
+		{"no controller", &v1.Pod{}, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if kind, name := PodWorkload(tt.pod); kind != tt.kind || name != tt.want {
+				t.Errorf("Expected %s %s, got %s %s", tt.kind, tt.want, kind, name)
+			}
+		})
+	}
+}
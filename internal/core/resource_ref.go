@@ -0,0 +1,45 @@
+package core
+
+import "strings"
+
+// ResourceRef refers to a resource by name, qualified by its namespace and
+// context when they are known. An empty Namespace or Context matches any,
+// so a bare name may match several resources when the list spans
+// namespaces or contexts.
+type ResourceRef struct {
+	Context   string
+	Namespace string
+	Name      string
+}
+
+// ParseResourceRef parses "namespace/name" or a bare name. Context names may
+// contain slashes, so the context is never part of the text form.
+func ParseResourceRef(s string) ResourceRef {
+	s = strings.TrimSpace(s)
+	if namespace, name, ok := strings.Cut(s, "/"); ok && namespace != "" && name != "" {
+		return ResourceRef{Namespace: namespace, Name: name}
+	}
+	return ResourceRef{Name: s}
+}
+
+// String renders the reference as "namespace/name", or the bare name when
+// the namespace is not known
+func (r ResourceRef) String() string {
+	if r.Namespace == "" {
+		return r.Name
+	}
+	return r.Namespace + "/" + r.Name
+}
+
+// IsZero reports whether the reference names nothing
+func (r ResourceRef) IsZero() bool {
+	return r.Name == ""
+}
+
+// Matches reports whether the resource in namespace of context is the one
+// referred to
+func (r ResourceRef) Matches(context, namespace, name string) bool {
+	return r.Name == name &&
+		(r.Namespace == "" || r.Namespace == namespace) &&
+		(r.Context == "" || r.Context == context)
+}
@@ -0,0 +1,45 @@
+package core
+
+import "testing"
+
+func TestParseResourceRef(t *testing.T) {
+	tests := []struct {
+		in   string
+		want ResourceRef
+	}{
+		{"web", ResourceRef{Name: "web"}},
+		{"prod/web", ResourceRef{Namespace: "prod", Name: "web"}},
+		{" prod/web ", ResourceRef{Namespace: "prod", Name: "web"}},
+		{"/web", ResourceRef{Name: "/web"}},
+		{"prod/", ResourceRef{Name: "prod/"}},
+	}
+	for _, tt := range tests {
+		if got := ParseResourceRef(tt.in); got != tt.want {
+			t.Errorf("ParseResourceRef(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+		if tt.want.Namespace != "" && ParseResourceRef(tt.in).String() != "prod/web" {
+			t.Errorf("ParseResourceRef(%q).String() = %q", tt.in, ParseResourceRef(tt.in).String())
+		}
+	}
+}
+
+func TestResourceRefMatches(t *testing.T) {
+	tests := []struct {
+		ref                      ResourceRef
+		context, namespace, name string
+		want                     bool
+	}{
+		{ResourceRef{Name: "web"}, "", "prod", "web", true},
+		{ResourceRef{Name: "web"}, "east", "staging", "web", true},
+		{ResourceRef{Name: "web"}, "", "prod", "api", false},
+		{ResourceRef{Namespace: "prod", Name: "web"}, "", "prod", "web", true},
+		{ResourceRef{Namespace: "prod", Name: "web"}, "", "staging", "web", false},
+		{ResourceRef{Context: "east", Namespace: "prod", Name: "web"}, "west", "prod", "web", false},
+		{ResourceRef{Context: "east", Namespace: "prod", Name: "web"}, "east", "prod", "web", true},
+	}
+	for _, tt := range tests {
+		if got := tt.ref.Matches(tt.context, tt.namespace, tt.name); got != tt.want {
+			t.Errorf("%+v.Matches(%q, %q, %q) = %v, want %v", tt.ref, tt.context, tt.namespace, tt.name, got, tt.want)
+		}
+	}
+}
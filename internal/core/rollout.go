@@ -0,0 +1,59 @@
+package core
+
+import "fmt"
+
+// RolloutPausedBadge marks the READY cell of a deployment whose rollout is
+// paused
+const RolloutPausedBadge = "(paused)"
+
+// RolloutAction is a built-in action on a deployment's rollout, as kubectl
+// rollout pause, resume and restart
+type RolloutAction string
+
+const (
+	RolloutPause   RolloutAction = "pause"
+	RolloutResume  RolloutAction = "resume"
+	RolloutRestart RolloutAction = "restart"
+)
+
+// RolloutActions returns the rollout actions offered on a deployment: pause
+// or resume, whichever applies, then restart
+func RolloutActions(paused bool) []RolloutAction {
+	if paused {
+		return []RolloutAction{RolloutResume, RolloutRestart}
+	}
+	return []RolloutAction{RolloutPause, RolloutRestart}
+}
+
+// Description returns how the action is offered, e.g. "Pause rollout"
+func (a RolloutAction) Description() string {
+	switch a {
+	case RolloutPause:
+		return "Pause rollout"
+	case RolloutResume:
+		return "Resume rollout"
+	case RolloutRestart:
+		return "Restart rollout"
+	}
+	return string(a)
+}
+
+// DeploymentReady returns a deployment's READY cell, e.g. "2/3", badged
+// "2/3 (paused)" while its rollout is paused
+func DeploymentReady(ready, replicas int32, paused bool) string {
+	cell := fmt.Sprintf("%d/%d", ready, replicas)
+	if paused {
+		cell += " " + RolloutPausedBadge
+	}
+	return cell
+}
+
+// StatefulSetReady returns a statefulset's READY cell, e.g. "2/3", noting
+// "2/3 (rolling 1/3)" while a rollout is replacing its pods with updated ones
+func StatefulSetReady(ready, updated, replicas int32, rolling bool) string {
+	cell := fmt.Sprintf("%d/%d", ready, replicas)
+	if rolling {
+		cell += fmt.Sprintf(" (rolling %d/%d)", updated, replicas)
+	}
+	return cell
+}
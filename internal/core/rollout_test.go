@@ -0,0 +1,31 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRolloutActions(t *testing.T) {
+	if got := RolloutActions(false); !reflect.DeepEqual(got, []RolloutAction{RolloutPause, RolloutRestart}) {
+		t.Errorf("Expected pause offered on a running rollout, got %v", got)
+	}
+	if got := RolloutActions(true); !reflect.DeepEqual(got, []RolloutAction{RolloutResume, RolloutRestart}) {
+		t.Errorf("Expected resume offered on a paused rollout, got %v", got)
+	}
+
+	if got := DeploymentReady(2, 3, false); got != "2/3" {
+		t.Errorf("Expected 2/3, got %q", got)
+	}
+	if got := DeploymentReady(2, 3, true); got != "2/3 (paused)" {
+		t.Errorf("Expected the paused badge, got %q", got)
+	}
+}
+
+func TestStatefulSetReady(t *testing.T) {
+	if got := StatefulSetReady(2, 3, 3, false); got != "2/3" {
+		t.Errorf("Expected 2/3, got %q", got)
+	}
+	if got := StatefulSetReady(2, 1, 3, true); got != "2/3 (rolling 1/3)" {
+		t.Errorf("Expected the rollout's progress, got %q", got)
+	}
+}
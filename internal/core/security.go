@@ -0,0 +1,207 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Pod Security Standards levels, from least to most restrictive
+const (
+	PodSecurityPrivileged = "privileged"
+	PodSecurityBaseline   = "baseline"
+	PodSecurityRestricted = "restricted"
+)
+
+// podSecurityLabelPrefix starts the namespace labels that set the Pod
+// Security admission levels
+const podSecurityLabelPrefix = "pod-security.kubernetes.io/"
+
+// PodSecurityLabels are the Pod Security admission levels a namespace sets.
+// An empty level is not set.
+type PodSecurityLabels struct {
+	Enforce string // Pods violating it are rejected
+	Warn    string // Violations are reported to whoever applies the pod
+	Audit   string // Violations are recorded in the audit log
+}
+
+// NamespacePodSecurity reads the Pod Security admission levels from a
+// namespace's labels
+func NamespacePodSecurity(labels map[string]string) PodSecurityLabels {
+	return PodSecurityLabels{
+		Enforce: labels[podSecurityLabelPrefix+"enforce"],
+		Warn:    labels[podSecurityLabelPrefix+"warn"],
+		Audit:   labels[podSecurityLabelPrefix+"audit"],
+	}
+}
+
+// Badge summarizes the levels for the header, e.g. "PSS: baseline" or
+// "PSS: baseline (warn: restricted)". It is empty when no level is set.
+func (l PodSecurityLabels) Badge() string {
+	if l.Enforce == "" && l.Warn == "" {
+		return ""
+	}
+	enforce := l.Enforce
+	if enforce == "" {
+		// Without an enforce label nothing is rejected
+		enforce = PodSecurityPrivileged
+	}
+	badge := "PSS: " + enforce
+	if l.Warn != "" && l.Warn != enforce {
+		badge += fmt.Sprintf(" (warn: %s)", l.Warn)
+	}
+	return badge
+}
+
+// SecurityRule flags one kind of Pod Security violation that can be seen in
+// a pod spec alone
+type SecurityRule struct {
+	Badge string // Short form shown in the SECURITY column, e.g. "PRIV"
+	Level string // Least restrictive Pod Security level the violation breaks
+
+	// Check returns one detail per violation found, e.g. "container web is
+	// privileged"
+	Check func(spec *v1.PodSpec) []string
+}
+
+// SecurityFinding is a violation found by a SecurityRule
+type SecurityFinding struct {
+	Badge  string
+	Level  string
+	Detail string
+}
+
+// SecurityRules returns the rules CheckPodSecurity applies, in badge order
+func SecurityRules() []SecurityRule {
+	return []SecurityRule{
+		{
+			Badge: "PRIV",
+			Level: PodSecurityBaseline,
+			Check: func(spec *v1.PodSpec) []string {
+				return eachContainer(spec, func(c *v1.Container) string {
+					if c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
+						return "container " + c.Name + " is privileged"
+					}
+					return ""
+				})
+			},
+		},
+		{
+			Badge: "HOSTNET",
+			Level: PodSecurityBaseline,
+			Check: func(spec *v1.PodSpec) []string {
+				if spec.HostNetwork {
+					return []string{"uses the host network (hostNetwork)"}
+				}
+				return nil
+			},
+		},
+		{
+			Badge: "HOSTPID",
+			Level: PodSecurityBaseline,
+			Check: func(spec *v1.PodSpec) []string {
+				var details []string
+				if spec.HostPID {
+					details = append(details, "shares the host process namespace (hostPID)")
+				}
+				if spec.HostIPC {
+					details = append(details, "shares the host IPC namespace (hostIPC)")
+				}
+				return details
+			},
+		},
+		{
+			Badge: "ROOT",
+			Level: PodSecurityRestricted,
+			Check: func(spec *v1.PodSpec) []string {
+				return eachContainer(spec, func(c *v1.Container) string {
+					if runsAsRoot(spec.SecurityContext, c.SecurityContext) {
+						return "container " + c.Name + " runs as root"
+					}
+					return ""
+				})
+			},
+		},
+		{
+			Badge: "NOSECCTX",
+			Level: PodSecurityRestricted,
+			Check: func(spec *v1.PodSpec) []string {
+				if spec.SecurityContext != nil {
+					return nil
+				}
+				return eachContainer(spec, func(c *v1.Container) string {
+					if c.SecurityContext == nil {
+						return "container " + c.Name + " has no securityContext"
+					}
+					return ""
+				})
+			},
+		},
+	}
+}
+
+// eachContainer applies check to the init and regular containers of spec,
+// collecting the details it returns
+func eachContainer(spec *v1.PodSpec, check func(c *v1.Container) string) []string {
+	var details []string
+	for _, containers := range [][]v1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			if detail := check(&containers[i]); detail != "" {
+				details = append(details, detail)
+			}
+		}
+	}
+	return details
+}
+
+// runsAsRoot reports whether a container is set to run as root: its user,
+// or the pod's when it sets none, is 0, or it explicitly allows root. A
+// container that sets neither takes the image's user, which is not known here.
+func runsAsRoot(pod *v1.PodSecurityContext, container *v1.SecurityContext) bool {
+	var user *int64
+	var nonRoot *bool
+	if pod != nil {
+		user, nonRoot = pod.RunAsUser, pod.RunAsNonRoot
+	}
+	if container != nil {
+		if container.RunAsUser != nil {
+			user = container.RunAsUser
+		}
+		if container.RunAsNonRoot != nil {
+			nonRoot = container.RunAsNonRoot
+		}
+	}
+	if user != nil {
+		return *user == 0
+	}
+	return nonRoot != nil && !*nonRoot
+}
+
+// CheckPodSecurity applies the security rules to a pod spec
+func CheckPodSecurity(spec *v1.PodSpec) []SecurityFinding {
+	var findings []SecurityFinding
+	for _, rule := range SecurityRules() {
+		for _, detail := range rule.Check(spec) {
+			findings = append(findings, SecurityFinding{Badge: rule.Badge, Level: rule.Level, Detail: detail})
+		}
+	}
+	return findings
+}
+
+// SecurityBadges joins the distinct badges of findings for the SECURITY
+// column, or returns "-" when there are none
+func SecurityBadges(findings []SecurityFinding) string {
+	var badges []string
+	seen := make(map[string]bool)
+	for _, finding := range findings {
+		if !seen[finding.Badge] {
+			seen[finding.Badge] = true
+			badges = append(badges, finding.Badge)
+		}
+	}
+	if len(badges) == 0 {
+		return "-"
+	}
+	return strings.Join(badges, ",")
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func boolPtr(b bool) *bool    { return &b }
+func int64Ptr(i int64) *int64 { return &i }
+
+func TestCheckPodSecurity(t *testing.T) {
+	restricted := &v1.SecurityContext{RunAsNonRoot: boolPtr(true)}
+
+	tests := []struct {
+		name    string
+		spec    v1.PodSpec
+		badges  string
+		details []string
+	}{
+		{
+			name:   "restricted pod is clean",
+			spec:   v1.PodSpec{Containers: []v1.Container{{Name: "web", SecurityContext: restricted}}},
+			badges: "-",
+		},
+		{
+			name: "privileged container",
+			spec: v1.PodSpec{Containers: []v1.Container{
+				{Name: "web", SecurityContext: restricted},
+				{Name: "agent", SecurityContext: &v1.SecurityContext{Privileged: boolPtr(true)}},
+			}},
+			badges:  "PRIV",
+			details: []string{"container agent is privileged"},
+		},
+		{
+			name: "host namespaces",
+			spec: v1.PodSpec{
+				HostNetwork: true, HostPID: true, HostIPC: true,
+				Containers: []v1.Container{{Name: "web", SecurityContext: restricted}},
+			},
+			badges:  "HOSTNET,HOSTPID",
+			details: []string{"hostNetwork", "hostPID", "hostIPC"},
+		},
+		{
+			name: "root from the pod security context",
+			spec: v1.PodSpec{
+				SecurityContext: &v1.PodSecurityContext{RunAsUser: int64Ptr(0)},
+				Containers:      []v1.Container{{Name: "web"}},
+			},
+			badges:  "ROOT",
+			details: []string{"container web runs as root"},
+		},
+		{
+			name: "container user overrides the pod's root",
+			spec: v1.PodSpec{
+				SecurityContext: &v1.PodSecurityContext{RunAsUser: int64Ptr(0)},
+				Containers:      []v1.Container{{Name: "web", SecurityContext: &v1.SecurityContext{RunAsUser: int64Ptr(1000)}}},
+			},
+			badges: "-",
+		},
+		{
+			name:    "root explicitly allowed",
+			spec:    v1.PodSpec{Containers: []v1.Container{{Name: "web", SecurityContext: &v1.SecurityContext{RunAsNonRoot: boolPtr(false)}}}},
+			badges:  "ROOT",
+			details: []string{"container web runs as root"},
+		},
+		{
+			name: "missing security context, init containers included",
+			spec: v1.PodSpec{
+				InitContainers: []v1.Container{{Name: "migrate"}},
+				Containers:     []v1.Container{{Name: "web"}, {Name: "proxy", SecurityContext: restricted}},
+			},
+			badges:  "NOSECCTX",
+			details: []string{"container migrate has no securityContext", "container web has no securityContext"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := CheckPodSecurity(&tt.spec)
+			if got := SecurityBadges(findings); got != tt.badges {
+				t.Errorf("Expected badges %q, got %q", tt.badges, got)
+			}
+			if len(findings) != len(tt.details) {
+				t.Fatalf("Expected %d findings, got %v", len(tt.details), findings)
+			}
+			for i, detail := range tt.details {
+				if !strings.Contains(findings[i].Detail, detail) {
+					t.Errorf("Expected finding %d to mention %q, got %q", i, detail, findings[i].Detail)
+				}
+			}
+		})
+	}
+}
+
+func TestSecurityRulesHaveLevels(t *testing.T) {
+	for _, rule := range SecurityRules() {
+		if rule.Badge == "" || rule.Check == nil {
+			t.Errorf("Rule %+v needs a badge and a check", rule)
+		}
+		if rule.Level != PodSecurityBaseline && rule.Level != PodSecurityRestricted {
+			t.Errorf("Rule %s: unexpected level %q", rule.Badge, rule.Level)
+		}
+	}
+}
+
+func TestNamespacePodSecurityBadge(t *testing.T) {
+	tests := []struct {
+		labels   map[string]string
+		expected string
+	}{
+		{nil, ""},
+		{map[string]string{"pod-security.kubernetes.io/enforce": "restricted"}, "PSS: restricted"},
+		{map[string]string{
+			"pod-security.kubernetes.io/enforce": "baseline",
+			"pod-security.kubernetes.io/warn":    "restricted",
+		}, "PSS: baseline (warn: restricted)"},
+		{map[string]string{"pod-security.kubernetes.io/warn": "baseline"}, "PSS: privileged (warn: baseline)"},
+		{map[string]string{"pod-security.kubernetes.io/audit": "restricted"}, ""},
+	}
+	for _, tt := range tests {
+		if got := NamespacePodSecurity(tt.labels).Badge(); got != tt.expected {
+			t.Errorf("Badge for %v = %q, want %q", tt.labels, got, tt.expected)
+		}
+	}
+}
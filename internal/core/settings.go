@@ -0,0 +1,367 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Setting describes a runtime-tunable option. Settings register once and are
+// picked up by the settings overlay and by config file loading/saving.
+type Setting struct {
+	Key         string // Key used in the config file
+	Name        string // Display name
+	Description string
+	Unit        string // Display unit, e.g. "s" or "lines"
+	Flag        string // CLI flag that sets the same value, if any
+	Min         int
+	Max         int
+
+	// Get reads the current value from the config
+	Get func(*Config) int
+	// Apply stores a validated value in the config
+	Apply func(*Config, int)
+	// Format describes a value with a special meaning, such as "off"; ""
+	// shows the number. Optional.
+	Format func(*Config, int) string
+}
+
+// Validate checks that value is within the setting's bounds
+func (s *Setting) Validate(value int) error {
+	if value < s.Min || value > s.Max {
+		return fmt.Errorf("%s must be between %d and %d", s.Name, s.Min, s.Max)
+	}
+	return nil
+}
+
+// Parse parses and validates a string value
+func (s *Setting) Parse(value string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a whole number", s.Name)
+	}
+	if err := s.Validate(n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// Set parses, validates and applies value to config
+func (s *Setting) Set(config *Config, value string) error {
+	n, err := s.Parse(value)
+	if err != nil {
+		return err
+	}
+	s.Apply(config, n)
+	return nil
+}
+
+// FormatValue returns the current value with its unit
+func (s *Setting) FormatValue(config *Config) string {
+	if s.Format != nil {
+		if value := s.Format(config, s.Get(config)); value != "" {
+			return value
+		}
+	}
+	value := strconv.Itoa(s.Get(config))
+	if s.Unit != "" {
+		value += " " + s.Unit
+	}
+	return value
+}
+
+var (
+	settingsMu       sync.RWMutex
+	settingsRegistry []*Setting
+)
+
+func init() {
+	for _, s := range defaultSettings() {
+		if err := RegisterSetting(s); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// defaultSettings returns the built-in runtime-tunable settings
+func defaultSettings() []*Setting {
+	settings := []*Setting{
+		{
+			Key:         "refreshInterval",
+			Name:        "Refresh interval",
+			Description: "Seconds between automatic resource refreshes",
+			Unit:        "s",
+			Flag:        "refresh-interval",
+			Min:         1,
+			Max:         300,
+			Get:         func(c *Config) int { return c.RefreshInterval },
+			Apply:       func(c *Config, v int) { c.RefreshInterval = v },
+		},
+		{
+			Key:         "logTailLines",
+			Name:        "Log tail lines",
+			Description: "Number of log lines to fetch when opening logs",
+			Unit:        "lines",
+			Flag:        "log-tail-lines",
+			Min:         1,
+			Max:         100000,
+			Get:         func(c *Config) int { return c.LogTailLines },
+			Apply:       func(c *Config, v int) { c.LogTailLines = v },
+		},
+		{
+			Key:         "maxResources",
+			Name:        "Max resources shown",
+			Description: "Maximum number of rows shown in the resource list",
+			Unit:        "rows",
+			Flag:        "max-resources",
+			Min:         1,
+			Max:         100000,
+			Get:         func(c *Config) int { return c.MaxResourcesShown },
+			Apply:       func(c *Config, v int) { c.MaxResourcesShown = v },
+		},
+		{
+			Key:         "metricsInterval",
+			Name:        "Metrics polling interval",
+			Description: "Seconds between pod metrics fetches (0 = every refresh)",
+			Unit:        "s",
+			Min:         0,
+			Max:         600,
+			Get:         func(c *Config) int { return c.MetricsInterval },
+			Apply:       func(c *Config, v int) { c.MetricsInterval = v },
+		},
+		{
+			Key:         "coalesceWindow",
+			Name:        "Refresh coalescing window",
+			Description: "Automatic refreshes this soon after another refresh are skipped (0 = off)",
+			Unit:        "ms",
+			Min:         0,
+			Max:         60000,
+			Get:         func(c *Config) int { return c.CoalesceWindowMs },
+			Apply:       func(c *Config, v int) { c.CoalesceWindowMs = v },
+		},
+		{
+			Key:         "historyMinutes",
+			Name:        "Table history",
+			Description: "Minutes of table states kept for the history scrubber (H) (0 = off)",
+			Unit:        "min",
+			Min:         0,
+			Max:         60,
+			Get:         func(c *Config) int { return c.HistoryMinutes },
+			Apply:       func(c *Config, v int) { c.HistoryMinutes = v },
+		},
+		{
+			Key:         "batchConcurrency",
+			Name:        "Batch concurrency",
+			Description: "Operations a batch action, such as a cleanup, runs at once",
+			Min:         1,
+			Max:         50,
+			Get:         func(c *Config) int { return c.BatchConcurrency },
+			Apply:       func(c *Config, v int) { c.BatchConcurrency = v },
+		},
+		{
+			Key:         "staleAfter",
+			Name:        "Stale data warning",
+			Description: "Refresh intervals without a successful update before the list is marked stale",
+			Unit:        "intervals",
+			Min:         2,
+			Max:         100,
+			Get:         func(c *Config) int { return c.StaleAfterIntervals },
+			Apply:       func(c *Config, v int) { c.StaleAfterIntervals = v },
+		},
+		{
+			Key:         "logRateInterval",
+			Name:        "Log rate sampling",
+			Description: "How often each visible pod's log rate is sampled for the LOG column; reads pod logs (0 = off)",
+			Unit:        "s",
+			Min:         0,
+			Max:         3600,
+			Get:         func(c *Config) int { return c.LogRateInterval },
+			Apply:       func(c *Config, v int) { c.LogRateInterval = v },
+		},
+		{
+			Key:         "churnGrace",
+			Name:        "Churn grace period",
+			Description: "While pods churn, deleted pods stay listed, dimmed, this long so the cursor does not jump (0 = off)",
+			Unit:        "ms",
+			Min:         0,
+			Max:         60000,
+			Get:         func(c *Config) int { return c.ChurnGraceMs },
+			Apply:       func(c *Config, v int) { c.ChurnGraceMs = v },
+		},
+		{
+			Key:         "hideNoise",
+			Name:        "Hide noise",
+			Description: "Hide completed pods and the other resources the noise rules match; z toggles it (0 = off, 1 = on)",
+			Min:         0,
+			Max:         1,
+			Get: func(c *Config) int {
+				if c.HideNoise {
+					return 1
+				}
+				return 0
+			},
+			Apply: func(c *Config, v int) { c.HideNoise = v == 1 },
+		},
+		{
+			Key:         "accessible",
+			Name:        "Accessible mode",
+			Description: "Plain text for screen readers: ASCII borders, no colors, the selected row read out under the list (0 = off, 1 = on)",
+			Flag:        "accessible",
+			Min:         0,
+			Max:         1,
+			Get: func(c *Config) int {
+				if c.Accessible {
+					return 1
+				}
+				return 0
+			},
+			Apply: func(c *Config, v int) { c.Accessible = v == 1 },
+		},
+		{
+			Key:         "hintBar",
+			Name:        "Key hints",
+			Description: "Show the most useful keys for what is on screen in a line at the bottom; hidden under 20 lines (0 = off, 1 = on)",
+			Min:         0,
+			Max:         1,
+			Get: func(c *Config) int {
+				if c.HideHints {
+					return 0
+				}
+				return 1
+			},
+			Apply: func(c *Config, v int) { c.HideHints = v == 0 },
+		},
+	}
+	return append(settings, kindRefreshSettings()...)
+}
+
+// kindRefreshSettings returns a refresh interval setting per resource type,
+// such as refreshInterval.configmaps, which overrides the refresh interval
+// for that type's list; by default none does
+func kindRefreshSettings() []*Setting {
+	settings := make([]*Setting, 0, len(AllResourceTypes))
+	for _, t := range AllResourceTypes {
+		kind := strings.ToLower(string(t))
+		settings = append(settings, &Setting{
+			Key:         "refreshInterval." + kind,
+			Name:        "Refresh " + kind,
+			Description: fmt.Sprintf("Seconds between automatic refreshes of %s (-1 = the refresh interval, 0 = off: watch events and r only)", kind),
+			Unit:        "s",
+			Min:         RefreshDefault,
+			Max:         300,
+			Get: func(c *Config) int {
+				if seconds, ok := c.RefreshIntervals[t]; ok {
+					return seconds
+				}
+				return RefreshDefault
+			},
+			Apply: func(c *Config, v int) {
+				if v == RefreshDefault {
+					delete(c.RefreshIntervals, t)
+					return
+				}
+				if c.RefreshIntervals == nil {
+					c.RefreshIntervals = make(map[ResourceType]int)
+				}
+				c.RefreshIntervals[t] = v
+			},
+			Format: func(c *Config, v int) string {
+				switch v {
+				case RefreshDefault:
+					return fmt.Sprintf("default (%d s)", c.RefreshInterval)
+				case RefreshOff:
+					return "off"
+				}
+				return ""
+			},
+		})
+	}
+	return settings
+}
+
+// RegisterSetting adds a setting to the registry
+func RegisterSetting(s *Setting) error {
+	if s.Key == "" || s.Get == nil || s.Apply == nil {
+		return fmt.Errorf("setting %q must have a key, getter and apply function", s.Key)
+	}
+	if s.Min > s.Max {
+		return fmt.Errorf("setting %q: min %d > max %d", s.Key, s.Min, s.Max)
+	}
+
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+
+	for _, existing := range settingsRegistry {
+		if existing.Key == s.Key {
+			return fmt.Errorf("setting %q already registered", s.Key)
+		}
+	}
+	settingsRegistry = append(settingsRegistry, s)
+	return nil
+}
+
+// Settings returns all registered settings in registration order
+func Settings() []*Setting {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+
+	result := make([]*Setting, len(settingsRegistry))
+	copy(result, settingsRegistry)
+	return result
+}
+
+// LookupSetting finds a registered setting by key
+func LookupSetting(key string) (*Setting, bool) {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+
+	for _, s := range settingsRegistry {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// SettingValues returns the current value of every registered setting keyed
+// by config file key, ready to be persisted
+func SettingValues(config *Config) map[string]string {
+	values := make(map[string]string)
+	for _, s := range Settings() {
+		values[s.Key] = strconv.Itoa(s.Get(config))
+	}
+	return values
+}
+
+// ApplySettingValues applies persisted values to config. Keys for which skip
+// returns true are left alone (e.g. because a CLI flag overrides them). All
+// values are applied even when some fail; the errors are joined.
+func ApplySettingValues(config *Config, values map[string]string, skip func(*Setting) bool) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	for _, key := range keys {
+		s, ok := LookupSetting(key)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown setting %q", key))
+			continue
+		}
+		if skip != nil && skip(s) {
+			continue
+		}
+		if err := s.Set(config, values[key]); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid settings: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSettingSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		value     string
+		expectErr string
+		check     func(*Config) int
+		expected  int
+	}{
+		{"refresh interval", "refreshInterval", "5", "", func(c *Config) int { return c.RefreshInterval }, 5},
+		{"refresh interval trims spaces", "refreshInterval", " 7 ", "", func(c *Config) int { return c.RefreshInterval }, 7},
+		{"refresh interval below min", "refreshInterval", "0", "between 1 and 300", func(c *Config) int { return c.RefreshInterval }, 2},
+		{"log tail lines", "logTailLines", "500", "", func(c *Config) int { return c.LogTailLines }, 500},
+		{"max resources not a number", "maxResources", "lots", "whole number", func(c *Config) int { return c.MaxResourcesShown }, 500},
+		{"metrics interval zero allowed", "metricsInterval", "0", "", func(c *Config) int { return c.MetricsInterval }, 0},
+		{"coalesce window", "coalesceWindow", "1500", "", func(c *Config) int { return c.CoalesceWindowMs }, 1500},
+		{"history minutes", "historyMinutes", "10", "", func(c *Config) int { return c.HistoryMinutes }, 10},
+		{"history minutes above max", "historyMinutes", "600", "between 0 and 60", func(c *Config) int { return c.HistoryMinutes }, 0},
+		{"batch concurrency", "batchConcurrency", "8", "", func(c *Config) int { return c.BatchConcurrency }, 8},
+		{"batch concurrency below min", "batchConcurrency", "0", "between 1 and 50", func(c *Config) int { return c.BatchConcurrency }, 0},
+		{"stale after", "staleAfter", "5", "", func(c *Config) int { return c.StaleAfterIntervals }, 5},
+		{"stale after below min", "staleAfter", "1", "between 2 and 100", func(c *Config) int { return c.StaleAfterIntervals }, 0},
+		{"log rate interval", "logRateInterval", "60", "", func(c *Config) int { return c.LogRateInterval }, 60},
+		{"log rate off", "logRateInterval", "0", "", func(c *Config) int { return c.LogRateInterval }, 0},
+		{"churn grace pinned to zero", "churnGrace", "0", "", func(c *Config) int { return c.ChurnGraceMs }, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{RefreshInterval: 2, LogTailLines: 100, MaxResourcesShown: 500}
+			s, ok := LookupSetting(tt.key)
+			if !ok {
+				t.Fatalf("Setting %q not registered", tt.key)
+			}
+
+			err := s.Set(config, tt.value)
+			if tt.expectErr == "" && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.expectErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectErr)) {
+				t.Fatalf("Expected error containing %q, got %v", tt.expectErr, err)
+			}
+			if got := tt.check(config); got != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSettingValuesRoundTrip(t *testing.T) {
+	original := &Config{RefreshInterval: 9, LogTailLines: 250, MaxResourcesShown: 40, MetricsInterval: 30, CoalesceWindowMs: 800, BatchConcurrency: 3, StaleAfterIntervals: 4, LogRateInterval: 120, ChurnGraceMs: 1500,
+		RefreshIntervals: map[ResourceType]int{ResourceTypePod: 1, ResourceTypeConfigMap: RefreshOff}}
+	values := SettingValues(original)
+
+	if len(values) != len(Settings()) {
+		t.Errorf("Expected a value for every setting, got %d of %d", len(values), len(Settings()))
+	}
+
+	restored := &Config{}
+	if err := ApplySettingValues(restored, values, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(restored, original) {
+		t.Errorf("Expected %+v, got %+v", *original, *restored)
+	}
+}
+
+func TestRefreshIntervalFor(t *testing.T) {
+	config := &Config{RefreshInterval: 2}
+	set := func(key, value string) {
+		t.Helper()
+		s, ok := LookupSetting(key)
+		if !ok {
+			t.Fatalf("Setting %q not registered", key)
+		}
+		if err := s.Set(config, value); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	set("refreshInterval.pods", "1")
+	set("refreshInterval.configmaps", "0")
+
+	tests := []struct {
+		kind     ResourceType
+		expected time.Duration
+		periodic bool
+		format   string
+	}{
+		{ResourceTypePod, time.Second, true, "1 s"},
+		{ResourceTypeConfigMap, 0, false, "off"},
+		{ResourceTypeDeployment, 2 * time.Second, true, "default (2 s)"},
+	}
+	for _, tt := range tests {
+		interval, periodic := config.RefreshIntervalFor(tt.kind)
+		if interval != tt.expected || periodic != tt.periodic {
+			t.Errorf("%s: expected %v, %v, got %v, %v", tt.kind, tt.expected, tt.periodic, interval, periodic)
+		}
+		s, _ := LookupSetting("refreshInterval." + strings.ToLower(string(tt.kind)))
+		if got := s.FormatValue(config); got != tt.format {
+			t.Errorf("%s: expected %q shown, got %q", tt.kind, tt.format, got)
+		}
+	}
+
+	// Back to the default, the override is dropped
+	set("refreshInterval.pods", "-1")
+	if _, ok := config.RefreshIntervals[ResourceTypePod]; ok {
+		t.Errorf("Expected the pods override dropped, got %v", config.RefreshIntervals)
+	}
+}
+
+func TestApplySettingValuesSkipsAndReportsErrors(t *testing.T) {
+	config := &Config{RefreshInterval: 2, LogTailLines: 100}
+	values := map[string]string{
+		"refreshInterval": "10",
+		"logTailLines":    "-1",
+		"bogus":           "1",
+	}
+
+	// Pretend --refresh-interval was given on the command line
+	skipFlagged := func(s *Setting) bool { return s.Flag == "refresh-interval" }
+
+	err := ApplySettingValues(config, values, skipFlagged)
+	if err == nil {
+		t.Fatal("Expected an error for invalid and unknown settings")
+	}
+	for _, want := range []string{"Log tail lines", `unknown setting "bogus"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to mention %q, got %v", want, err)
+		}
+	}
+	if config.RefreshInterval != 2 {
+		t.Errorf("Expected skipped setting to be left alone, got %d", config.RefreshInterval)
+	}
+	if config.LogTailLines != 100 {
+		t.Errorf("Expected invalid value to be rejected, got %d", config.LogTailLines)
+	}
+}
+
+func TestRegisterSettingRejectsDuplicates(t *testing.T) {
+	err := RegisterSetting(&Setting{
+		Key:   "refreshInterval",
+		Get:   func(c *Config) int { return 0 },
+		Apply: func(c *Config, v int) {},
+	})
+	if err == nil {
+		t.Error("Expected duplicate registration to fail")
+	}
+}
@@ -1,11 +1,18 @@
 package core
 
 import (
+	"strings"
 	"sync"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // ResourceType represents the type of Kubernetes resource
@@ -15,16 +22,86 @@ const (
 	ResourceTypePod         ResourceType = "Pods"
 	ResourceTypeDeployment  ResourceType = "Deployments"
 	ResourceTypeStatefulSet ResourceType = "StatefulSets"
+	ResourceTypeJob         ResourceType = "Jobs"
+	ResourceTypeCronJob     ResourceType = "CronJobs"
 	ResourceTypeService     ResourceType = "Services"
 	ResourceTypeIngress     ResourceType = "Ingresses"
+	ResourceTypeGateway     ResourceType = "Gateways"
+	ResourceTypeHTTPRoute   ResourceType = "HTTPRoutes"
 	ResourceTypeConfigMap   ResourceType = "ConfigMaps"
 	ResourceTypeSecret      ResourceType = "Secrets"
+
+	ResourceTypeServiceAccount     ResourceType = "ServiceAccounts"
+	ResourceTypeRole               ResourceType = "Roles"
+	ResourceTypeClusterRole        ResourceType = "ClusterRoles"
+	ResourceTypeRoleBinding        ResourceType = "RoleBindings"
+	ResourceTypeClusterRoleBinding ResourceType = "ClusterRoleBindings"
+
+	ResourceTypeNode ResourceType = "Nodes"
 )
 
+// AllResourceTypes lists the resource types in display order
+var AllResourceTypes = []ResourceType{
+	ResourceTypePod,
+	ResourceTypeDeployment,
+	ResourceTypeStatefulSet,
+	ResourceTypeJob,
+	ResourceTypeCronJob,
+	ResourceTypeService,
+	ResourceTypeIngress,
+	ResourceTypeGateway,
+	ResourceTypeHTTPRoute,
+	ResourceTypeConfigMap,
+	ResourceTypeSecret,
+	ResourceTypeServiceAccount,
+	ResourceTypeRole,
+	ResourceTypeClusterRole,
+	ResourceTypeRoleBinding,
+	ResourceTypeClusterRoleBinding,
+	ResourceTypeNode,
+}
+
+// OptionalResourceTypes are the resource types whose CRDs a cluster may not
+// have. They are offered only once the cluster is found to serve them.
+var OptionalResourceTypes = []ResourceType{
+	ResourceTypeGateway,
+	ResourceTypeHTTPRoute,
+}
+
+// IsOptionalResourceType returns true for a type whose CRDs a cluster may
+// not have
+func IsOptionalResourceType(t ResourceType) bool {
+	for _, optional := range OptionalResourceTypes {
+		if t == optional {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseResourceType resolves a plural or singular resource type name,
+// case-insensitively (e.g. "Pods", "pods" or "pod")
+func ParseResourceType(name string) (ResourceType, bool) {
+	lower := strings.ToLower(strings.TrimSpace(name))
+	for _, t := range AllResourceTypes {
+		plural := strings.ToLower(string(t))
+		if lower == plural || lower+"s" == plural || lower+"es" == plural {
+			return t, true
+		}
+	}
+	return "", false
+}
+
 // State holds the application state
 type State struct {
 	mu sync.RWMutex
 
+	// generation counts switches of what is listed. A list started in one
+	// generation is not applied in another; relistMu is held while a list is
+	// applied and while switching, so neither sees half of the other.
+	generation uint64
+	relistMu   sync.Mutex
+
 	// Current view state
 	CurrentResourceType ResourceType
 	CurrentNamespace    string
@@ -32,6 +109,10 @@ type State struct {
 	SelectedIndex       int
 	ScrollOffset        int
 
+	// Where the starting namespace came from, shown while it is listed
+	namespaceOrigin string
+	startNamespace  string
+
 	// Multi-context support
 	CurrentContexts  []string        // Active contexts
 	ContextFilter    map[string]bool // Which contexts to show
@@ -43,32 +124,98 @@ type State struct {
 	StatefulSets []appsv1.StatefulSet
 	Services     []v1.Service
 	Ingresses    []networkingv1.Ingress
+	Gateways     []Gateway
+	HTTPRoutes   []HTTPRoute
 	ConfigMaps   []v1.ConfigMap
 	Secrets      []v1.Secret
 
+	ServiceAccounts     []v1.ServiceAccount
+	Roles               []rbacv1.Role
+	ClusterRoles        []rbacv1.ClusterRole
+	RoleBindings        []rbacv1.RoleBinding
+	ClusterRoleBindings []rbacv1.ClusterRoleBinding
+
+	Nodes []v1.Node
+
+	Jobs     []batchv1.Job
+	CronJobs []batchv1.CronJob
+
 	// Multi-context resources cache
 	PodsByContext         map[string][]v1.Pod
 	DeploymentsByContext  map[string][]appsv1.Deployment
 	StatefulSetsByContext map[string][]appsv1.StatefulSet
 	ServicesByContext     map[string][]v1.Service
 	IngressesByContext    map[string][]networkingv1.Ingress
+	GatewaysByContext     map[string][]Gateway
+	HTTPRoutesByContext   map[string][]HTTPRoute
 	ConfigMapsByContext   map[string][]v1.ConfigMap
 	SecretsByContext      map[string][]v1.Secret
 
+	ServiceAccountsByContext     map[string][]v1.ServiceAccount
+	RolesByContext               map[string][]rbacv1.Role
+	ClusterRolesByContext        map[string][]rbacv1.ClusterRole
+	RoleBindingsByContext        map[string][]rbacv1.RoleBinding
+	ClusterRoleBindingsByContext map[string][]rbacv1.ClusterRoleBinding
+
+	NodesByContext map[string][]v1.Node
+
+	JobsByContext     map[string][]batchv1.Job
+	CronJobsByContext map[string][]batchv1.CronJob
+
 	// UI state
-	ShowHelp      bool
-	ShowLogs      bool
-	LogsTarget    string // pod or deployment name
-	FilterString  string
+	ShowHelp     bool
+	ShowLogs     bool
+	LogsTarget   string // pod or deployment name
+	FilterString string
+	SavedFilter  string // Name of the saved filter that set FilterString, if any
+	// FilterGlobal is set when FilterString is the global filter, which
+	// applies to every resource type that has the columns it names, rather
+	// than the current type's own
+	FilterGlobal  bool
 	SortColumn    string
 	SortAscending bool
+	Columns       []string // Columns shown in the list; empty shows them all
+
+	// columnOrders is the order each resource type's columns are shown in,
+	// where it is not the usual one
+	columnOrders map[ResourceType][]string
 
 	// Selection state
 	SelectedItems map[string]bool // for multi-select
 
+	// Images records the deployment image changes seen this session
+	Images *ImageHistory
+
+	// Changes records the recent changes of the listed resources
+	Changes *ObjectHistory
+
+	// Deletions follows the deletes requested until the objects are gone
+	Deletions *DeletionTracker
+
+	// Caches tracks the size of the lists above and when each was viewed
+	Caches *CacheManager
+
+	// Each resource type's own filter, remembered for the session, and the
+	// global filter, which takes their place while it is set
+	typeFilters  map[ResourceType]typeFilter
+	globalFilter string
+
+	// The label selector the lists of the types that support it are
+	// narrowed by, as kubectl -l takes it
+	labelSelector string
+
+	// The optional resource types the cluster was found to serve
+	installedTypes map[ResourceType]bool
+
 	config *Config
 }
 
+// typeFilter is a filter expression and the saved filter it came from
+type typeFilter struct {
+	expression  string
+	savedFilter string
+}
+
 // NewState creates a new application state
 func NewState(config *Config) *State {
 	// Set initial resource type from config
@@ -83,10 +230,30 @@ func NewState(config *Config) *State {
 			resourceType = ResourceTypeService
 		case "ingress":
 			resourceType = ResourceTypeIngress
+		case "gateway":
+			resourceType = ResourceTypeGateway
+		case "httproute":
+			resourceType = ResourceTypeHTTPRoute
 		case "configmap":
 			resourceType = ResourceTypeConfigMap
 		case "secret":
 			resourceType = ResourceTypeSecret
+		case "serviceaccount":
+			resourceType = ResourceTypeServiceAccount
+		case "role":
+			resourceType = ResourceTypeRole
+		case "clusterrole":
+			resourceType = ResourceTypeClusterRole
+		case "rolebinding":
+			resourceType = ResourceTypeRoleBinding
+		case "clusterrolebinding":
+			resourceType = ResourceTypeClusterRoleBinding
+		case "node":
+			resourceType = ResourceTypeNode
+		case "job":
+			resourceType = ResourceTypeJob
+		case "cronjob":
+			resourceType = ResourceTypeCronJob
 		default:
 			resourceType = ResourceTypePod
 		}
@@ -98,6 +265,8 @@ func NewState(config *Config) *State {
 		CurrentContext:      config.CurrentContext,
 		SelectedItems:       make(map[string]bool),
 		config:              config,
+		namespaceOrigin:     config.NamespaceOrigin,
+		startNamespace:      config.CurrentNamespace,
 		SortColumn:          "NAME", // Default sort by name
 		SortAscending:       true,
 
@@ -110,8 +279,26 @@ func NewState(config *Config) *State {
 		StatefulSetsByContext: make(map[string][]appsv1.StatefulSet),
 		ServicesByContext:     make(map[string][]v1.Service),
 		IngressesByContext:    make(map[string][]networkingv1.Ingress),
+		GatewaysByContext:     make(map[string][]Gateway),
+		HTTPRoutesByContext:   make(map[string][]HTTPRoute),
 		ConfigMapsByContext:   make(map[string][]v1.ConfigMap),
 		SecretsByContext:      make(map[string][]v1.Secret),
+
+		ServiceAccountsByContext:     make(map[string][]v1.ServiceAccount),
+		RolesByContext:               make(map[string][]rbacv1.Role),
+		ClusterRolesByContext:        make(map[string][]rbacv1.ClusterRole),
+		RoleBindingsByContext:        make(map[string][]rbacv1.RoleBinding),
+		ClusterRoleBindingsByContext: make(map[string][]rbacv1.ClusterRoleBinding),
+
+		NodesByContext: make(map[string][]v1.Node),
+
+		JobsByContext:     make(map[string][]batchv1.Job),
+		CronJobsByContext: make(map[string][]batchv1.CronJob),
+
+		Images:    NewImageHistory(),
+		Changes:   NewObjectHistory(),
+		Deletions: NewDeletionTracker(),
+		Caches:    NewCacheManager(DefaultCacheTTL),
 	}
 }
 
@@ -131,82 +318,554 @@ func (s *State) GetCurrentResourceCount() int {
 		return len(s.Services)
 	case ResourceTypeIngress:
 		return len(s.Ingresses)
+	case ResourceTypeGateway:
+		return len(s.Gateways)
+	case ResourceTypeHTTPRoute:
+		return len(s.HTTPRoutes)
 	case ResourceTypeConfigMap:
 		return len(s.ConfigMaps)
 	case ResourceTypeSecret:
 		return len(s.Secrets)
+	case ResourceTypeServiceAccount:
+		return len(s.ServiceAccounts)
+	case ResourceTypeRole:
+		return len(s.Roles)
+	case ResourceTypeClusterRole:
+		return len(s.ClusterRoles)
+	case ResourceTypeRoleBinding:
+		return len(s.RoleBindings)
+	case ResourceTypeClusterRoleBinding:
+		return len(s.ClusterRoleBindings)
+	case ResourceTypeNode:
+		return len(s.Nodes)
+	case ResourceTypeJob:
+		return len(s.Jobs)
+	case ResourceTypeCronJob:
+		return len(s.CronJobs)
 	default:
 		return 0
 	}
 }
 
+// CurrentCreationTimestamps returns the creation timestamps of the current
+// resource type's objects
+func (s *State) CurrentCreationTimestamps() []time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var timestamps []time.Time
+	switch s.CurrentResourceType {
+	case ResourceTypePod:
+		for i := range s.Pods {
+			timestamps = append(timestamps, s.Pods[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeDeployment:
+		for i := range s.Deployments {
+			timestamps = append(timestamps, s.Deployments[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeStatefulSet:
+		for i := range s.StatefulSets {
+			timestamps = append(timestamps, s.StatefulSets[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeService:
+		for i := range s.Services {
+			timestamps = append(timestamps, s.Services[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeIngress:
+		for i := range s.Ingresses {
+			timestamps = append(timestamps, s.Ingresses[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeGateway:
+		for i := range s.Gateways {
+			timestamps = append(timestamps, s.Gateways[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeHTTPRoute:
+		for i := range s.HTTPRoutes {
+			timestamps = append(timestamps, s.HTTPRoutes[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeConfigMap:
+		for i := range s.ConfigMaps {
+			timestamps = append(timestamps, s.ConfigMaps[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeSecret:
+		for i := range s.Secrets {
+			timestamps = append(timestamps, s.Secrets[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeServiceAccount:
+		for i := range s.ServiceAccounts {
+			timestamps = append(timestamps, s.ServiceAccounts[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeRole:
+		for i := range s.Roles {
+			timestamps = append(timestamps, s.Roles[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeClusterRole:
+		for i := range s.ClusterRoles {
+			timestamps = append(timestamps, s.ClusterRoles[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeRoleBinding:
+		for i := range s.RoleBindings {
+			timestamps = append(timestamps, s.RoleBindings[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeClusterRoleBinding:
+		for i := range s.ClusterRoleBindings {
+			timestamps = append(timestamps, s.ClusterRoleBindings[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeNode:
+		for i := range s.Nodes {
+			timestamps = append(timestamps, s.Nodes[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeJob:
+		for i := range s.Jobs {
+			timestamps = append(timestamps, s.Jobs[i].CreationTimestamp.Time)
+		}
+	case ResourceTypeCronJob:
+		for i := range s.CronJobs {
+			timestamps = append(timestamps, s.CronJobs[i].CreationTimestamp.Time)
+		}
+	}
+	return timestamps
+}
+
+// NamespaceOrigin returns where the namespace kubewatch started in came from
+// when no flag chose it, e.g. NamespaceFromKubeconfig, while it is still the
+// one listed
+func (s *State) NamespaceOrigin() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.CurrentNamespace != s.startNamespace {
+		return ""
+	}
+	return s.namespaceOrigin
+}
+
 // SetNamespace updates the current namespace
 func (s *State) SetNamespace(namespace string) {
+	s.relistMu.Lock()
+	defer s.relistMu.Unlock()
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.generation++
 	s.CurrentNamespace = namespace
 	s.SelectedIndex = 0
 	s.ScrollOffset = 0
 	s.SelectedItems = make(map[string]bool)
 }
 
+// SetFilter sets the current resource type's filter expression and the name
+// of the saved filter it came from (empty for an ad-hoc filter). Each type
+// keeps its own filter for the session. A global filter is ended, so the
+// other types show their own filters again.
+func (s *State) SetFilter(expression, savedFilter string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.globalFilter = ""
+	s.rememberTypeFilter(expression, savedFilter)
+	s.showFilter()
+	s.SelectedIndex = 0
+	s.ScrollOffset = 0
+}
+
+// SetGlobalFilter sets a filter for every resource type that has the
+// columns it names, in place of each type's own filter until it is cleared.
+// An empty expression ends the global filter.
+func (s *State) SetGlobalFilter(expression string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.globalFilter = expression
+	s.showFilter()
+	s.SelectedIndex = 0
+	s.ScrollOffset = 0
+}
+
+// ClearFilter clears the filter in effect: the global filter, after which
+// the current type's own filter shows again, or else the type's own filter
+func (s *State) ClearFilter() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.globalFilter != "" {
+		s.globalFilter = ""
+	} else {
+		s.rememberTypeFilter("", "")
+	}
+	s.showFilter()
+	s.SelectedIndex = 0
+	s.ScrollOffset = 0
+}
+
+// ClearAllFilters clears the global filter and every type's own filter
+func (s *State) ClearAllFilters() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.globalFilter = ""
+	s.typeFilters = nil
+	s.showFilter()
+	s.SelectedIndex = 0
+	s.ScrollOffset = 0
+}
+
+// FilteredTypes returns the resource types with a filter of their own, in
+// display order, whether or not a global filter is shown in its place
+func (s *State) FilteredTypes() []ResourceType {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var types []ResourceType
+	for _, t := range AllResourceTypes {
+		if s.typeFilters[t].expression != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// IsFilterGlobal returns true when the filter shown is the global filter
+func (s *State) IsFilterGlobal() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.FilterGlobal
+}
+
+// rememberTypeFilter sets the current type's own filter; the caller holds
+// s.mu
+func (s *State) rememberTypeFilter(expression, savedFilter string) {
+	if expression == "" {
+		delete(s.typeFilters, s.CurrentResourceType)
+		return
+	}
+	if s.typeFilters == nil {
+		s.typeFilters = make(map[ResourceType]typeFilter)
+	}
+	s.typeFilters[s.CurrentResourceType] = typeFilter{expression, savedFilter}
+}
+
+// showFilter makes the filter in effect for the current type the one shown:
+// the global filter when set, or else the type's own; the caller holds s.mu
+func (s *State) showFilter() {
+	if s.globalFilter != "" {
+		s.FilterString, s.SavedFilter, s.FilterGlobal = s.globalFilter, "", true
+		return
+	}
+	own := s.typeFilters[s.CurrentResourceType]
+	s.FilterString, s.SavedFilter, s.FilterGlobal = own.expression, own.savedFilter, false
+}
+
+// GetFilter returns the list filter expression and the active saved filter name
+func (s *State) GetFilter() (string, string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.FilterString, s.SavedFilter
+}
+
+// SetColumns sets the columns the list shows. Empty shows them all.
+func (s *State) SetColumns(columns []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Columns = columns
+}
+
+// GetColumns returns the columns the list shows, or nil for all of them
+func (s *State) GetColumns() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.Columns
+}
+
+// SetInstalledTypes records which of the optional resource types the
+// cluster serves; those it does not are left out of AvailableResourceTypes
+func (s *State) SetInstalledTypes(installed map[ResourceType]bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range OptionalResourceTypes {
+		s.setTypeInstalled(t, installed[t])
+	}
+}
+
+// setTypeInstalled records whether an optional type is served; s.mu must be
+// held
+func (s *State) setTypeInstalled(t ResourceType, installed bool) {
+	if s.installedTypes == nil {
+		s.installedTypes = make(map[ResourceType]bool)
+	}
+	s.installedTypes[t] = installed
+}
+
+// IsTypeAvailable returns true for a resource type that can be listed: one
+// every cluster has, or an optional one the cluster was found to serve
+func (s *State) IsTypeAvailable(t ResourceType) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return !IsOptionalResourceType(t) || s.installedTypes[t]
+}
+
+// AvailableResourceTypes lists the resource types that can be listed, in
+// display order
+func (s *State) AvailableResourceTypes() []ResourceType {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var types []ResourceType
+	for _, t := range AllResourceTypes {
+		if !IsOptionalResourceType(t) || s.installedTypes[t] {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
 // SetResourceType updates the current resource type
 func (s *State) SetResourceType(resourceType ResourceType) {
+	s.relistMu.Lock()
+	defer s.relistMu.Unlock()
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.generation++
+	// The type left keeps its filter for when it is shown again
+	if !s.FilterGlobal {
+		s.rememberTypeFilter(s.FilterString, s.SavedFilter)
+	}
 	s.CurrentResourceType = resourceType
 	s.SelectedIndex = 0
 	s.ScrollOffset = 0
 	s.SelectedItems = make(map[string]bool)
+	s.showFilter()
 }
 
 // UpdatePods updates the pods list
 func (s *State) UpdatePods(pods []v1.Pod) {
+	size := stripUnusedFields(pods)
+	observeDeletions(s, ResourceTypePod, "", pods)
+	observeChanges(s.Changes, "Pod", pods)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.Pods = pods
+	s.recordCache(CacheKey{Kind: ResourceTypePod}, len(pods), size)
 }
 
-// UpdateDeployments updates the deployments list
+// UpdateDeployments updates the deployments list and records any image
+// changes in s.Images
 func (s *State) UpdateDeployments(deployments []appsv1.Deployment) {
+	size := stripUnusedFields(deployments)
+	observeDeletions(s, ResourceTypeDeployment, "", deployments)
+	if s.Images != nil {
+		now := time.Now()
+		for i := range deployments {
+			s.Images.Observe(&deployments[i], now)
+		}
+	}
+	observeChanges(s.Changes, "Deployment", deployments)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.Deployments = deployments
+	s.recordCache(CacheKey{Kind: ResourceTypeDeployment}, len(deployments), size)
+}
+
+// observeChanges records a kind's objects, just listed, in a history, which
+// forgets the objects of the kind no longer listed
+func observeChanges[T any, P interface {
+	*T
+	runtime.Object
+	metav1.Object
+}](history *ObjectHistory, kind string, objects []T) {
+	if history == nil {
+		return
+	}
+	now := time.Now()
+	listed := make(map[types.UID]bool, len(objects))
+	for i := range objects {
+		obj := P(&objects[i])
+		history.Observe(obj, now)
+		listed[obj.GetUID()] = true
+	}
+	history.Retain(kind, listed)
+}
+
+// observeDeletions updates the deletions of a kind from a list of it, just
+// made in context and the current namespace
+func observeDeletions[T any, P interface {
+	*T
+	metav1.Object
+}](s *State, kind ResourceType, context string, objects []T) {
+	if s.Deletions == nil {
+		return
+	}
+	listed := make([]metav1.Object, len(objects))
+	for i := range objects {
+		listed[i] = P(&objects[i])
+	}
+	namespace := s.GetCurrentNamespace()
+	if IsClusterScoped(kind) {
+		namespace = ""
+	}
+	s.Deletions.ObserveList(kind, context, namespace, listed, time.Now())
 }
 
 // UpdateStatefulSets updates the statefulsets list
 func (s *State) UpdateStatefulSets(statefulsets []appsv1.StatefulSet) {
+	size := stripUnusedFields(statefulsets)
+	observeDeletions(s, ResourceTypeStatefulSet, "", statefulsets)
+	observeChanges(s.Changes, "StatefulSet", statefulsets)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.StatefulSets = statefulsets
+	s.recordCache(CacheKey{Kind: ResourceTypeStatefulSet}, len(statefulsets), size)
 }
 
 // UpdateServices updates the services list
 func (s *State) UpdateServices(services []v1.Service) {
+	size := stripUnusedFields(services)
+	observeDeletions(s, ResourceTypeService, "", services)
+	observeChanges(s.Changes, "Service", services)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.Services = services
+	s.recordCache(CacheKey{Kind: ResourceTypeService}, len(services), size)
 }
 
 // UpdateIngresses updates the ingresses list
 func (s *State) UpdateIngresses(ingresses []networkingv1.Ingress) {
+	size := stripUnusedFields(ingresses)
+	observeDeletions(s, ResourceTypeIngress, "", ingresses)
+	observeChanges(s.Changes, "Ingress", ingresses)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.Ingresses = ingresses
+	s.recordCache(CacheKey{Kind: ResourceTypeIngress}, len(ingresses), size)
+}
+
+// UpdateGateways updates the gateways list
+func (s *State) UpdateGateways(gateways []Gateway) {
+	size := stripUnusedFields(gateways)
+	observeDeletions(s, ResourceTypeGateway, "", gateways)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Gateways = gateways
+	s.recordCache(CacheKey{Kind: ResourceTypeGateway}, len(gateways), size)
+}
+
+// UpdateHTTPRoutes updates the HTTP routes list
+func (s *State) UpdateHTTPRoutes(routes []HTTPRoute) {
+	size := stripUnusedFields(routes)
+	observeDeletions(s, ResourceTypeHTTPRoute, "", routes)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.HTTPRoutes = routes
+	s.recordCache(CacheKey{Kind: ResourceTypeHTTPRoute}, len(routes), size)
 }
 
 // UpdateConfigMaps updates the configmaps list
 func (s *State) UpdateConfigMaps(configmaps []v1.ConfigMap) {
+	size := stripUnusedFields(configmaps)
+	observeDeletions(s, ResourceTypeConfigMap, "", configmaps)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.ConfigMaps = configmaps
+	s.recordCache(CacheKey{Kind: ResourceTypeConfigMap}, len(configmaps), size)
 }
 
 // UpdateSecrets updates the secrets list
 func (s *State) UpdateSecrets(secrets []v1.Secret) {
+	size := stripUnusedFields(secrets)
+	observeDeletions(s, ResourceTypeSecret, "", secrets)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.Secrets = secrets
+	s.recordCache(CacheKey{Kind: ResourceTypeSecret}, len(secrets), size)
+}
+
+// UpdateServiceAccounts updates the serviceaccounts list
+func (s *State) UpdateServiceAccounts(serviceaccounts []v1.ServiceAccount) {
+	size := stripUnusedFields(serviceaccounts)
+	observeDeletions(s, ResourceTypeServiceAccount, "", serviceaccounts)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ServiceAccounts = serviceaccounts
+	s.recordCache(CacheKey{Kind: ResourceTypeServiceAccount}, len(serviceaccounts), size)
+}
+
+// UpdateRoles updates the roles list
+func (s *State) UpdateRoles(roles []rbacv1.Role) {
+	size := stripUnusedFields(roles)
+	observeDeletions(s, ResourceTypeRole, "", roles)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Roles = roles
+	s.recordCache(CacheKey{Kind: ResourceTypeRole}, len(roles), size)
+}
+
+// UpdateClusterRoles updates the cluster roles list
+func (s *State) UpdateClusterRoles(roles []rbacv1.ClusterRole) {
+	size := stripUnusedFields(roles)
+	observeDeletions(s, ResourceTypeClusterRole, "", roles)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ClusterRoles = roles
+	s.recordCache(CacheKey{Kind: ResourceTypeClusterRole}, len(roles), size)
+}
+
+// UpdateRoleBindings updates the role bindings list
+func (s *State) UpdateRoleBindings(bindings []rbacv1.RoleBinding) {
+	size := stripUnusedFields(bindings)
+	observeDeletions(s, ResourceTypeRoleBinding, "", bindings)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.RoleBindings = bindings
+	s.recordCache(CacheKey{Kind: ResourceTypeRoleBinding}, len(bindings), size)
+}
+
+// UpdateClusterRoleBindings updates the cluster role bindings list
+func (s *State) UpdateClusterRoleBindings(bindings []rbacv1.ClusterRoleBinding) {
+	size := stripUnusedFields(bindings)
+	observeDeletions(s, ResourceTypeClusterRoleBinding, "", bindings)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ClusterRoleBindings = bindings
+	s.recordCache(CacheKey{Kind: ResourceTypeClusterRoleBinding}, len(bindings), size)
+}
+
+// UpdateNodes updates the nodes list
+func (s *State) UpdateNodes(nodes []v1.Node) {
+	size := stripUnusedFields(nodes)
+	observeDeletions(s, ResourceTypeNode, "", nodes)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Nodes = nodes
+	s.recordCache(CacheKey{Kind: ResourceTypeNode}, len(nodes), size)
+}
+
+// UpdateJobs updates the jobs list
+func (s *State) UpdateJobs(jobs []batchv1.Job) {
+	size := stripUnusedFields(jobs)
+	observeDeletions(s, ResourceTypeJob, "", jobs)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Jobs = jobs
+	s.recordCache(CacheKey{Kind: ResourceTypeJob}, len(jobs), size)
+}
+
+// UpdateCronJobs updates the cron jobs list
+func (s *State) UpdateCronJobs(cronJobs []batchv1.CronJob) {
+	size := stripUnusedFields(cronJobs)
+	observeDeletions(s, ResourceTypeCronJob, "", cronJobs)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.CronJobs = cronJobs
+	s.recordCache(CacheKey{Kind: ResourceTypeCronJob}, len(cronJobs), size)
 }
 
 // SetMultiContextMode enables or disables multi-context mode
@@ -218,8 +877,11 @@ func (s *State) SetMultiContextMode(enabled bool) {
 
 // SetCurrentContexts updates the active contexts
 func (s *State) SetCurrentContexts(contexts []string) {
+	s.relistMu.Lock()
+	defer s.relistMu.Unlock()
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.generation++
 	s.CurrentContexts = contexts
 
 	// Update context filter
@@ -229,53 +891,417 @@ func (s *State) SetCurrentContexts(contexts []string) {
 	}
 }
 
+// Generation returns the current generation of what is listed, to be passed
+// to ApplyList with the result of a list started now
+func (s *State) Generation() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.generation
+}
+
+// ApplyList runs apply, which stores the result of a list started in
+// generation, unless the contexts, namespace or resource type have been
+// switched since.
+// It returns false, having applied nothing, for a list that is out of date.
+// A switch waits for apply to finish, so a list is applied whole or not at all.
+func (s *State) ApplyList(generation uint64, apply func()) bool {
+	s.relistMu.Lock()
+	defer s.relistMu.Unlock()
+	if s.Generation() != generation {
+		return false
+	}
+	apply()
+	return true
+}
+
 // UpdatePodsByContext updates pods for a specific context
 func (s *State) UpdatePodsByContext(context string, pods []v1.Pod) {
+	size := stripUnusedFields(pods)
+	observeDeletions(s, ResourceTypePod, context, pods)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.PodsByContext[context] = pods
+	s.recordCache(CacheKey{Kind: ResourceTypePod, Context: context}, len(pods), size)
 }
 
 // UpdateDeploymentsByContext updates deployments for a specific context
 func (s *State) UpdateDeploymentsByContext(context string, deployments []appsv1.Deployment) {
+	size := stripUnusedFields(deployments)
+	observeDeletions(s, ResourceTypeDeployment, context, deployments)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.DeploymentsByContext[context] = deployments
+	s.recordCache(CacheKey{Kind: ResourceTypeDeployment, Context: context}, len(deployments), size)
+}
+
+// ContextPods returns the pods last listed in a context
+func (s *State) ContextPods(context string) []v1.Pod {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.PodsByContext[context]
+}
+
+// ContextDeployments returns the deployments last listed in a context
+func (s *State) ContextDeployments(context string) []appsv1.Deployment {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.DeploymentsByContext[context]
+}
+
+// FindDeployment returns the listed deployment with a namespace and name,
+// from the context's list in multi-context mode, or else the single list
+func (s *State) FindDeployment(context, namespace, name string) (*appsv1.Deployment, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	deployments := s.Deployments
+	if s.MultiContextMode && context != "" {
+		deployments = s.DeploymentsByContext[context]
+	}
+	for i := range deployments {
+		if deployments[i].Namespace == namespace && deployments[i].Name == name {
+			return &deployments[i], true
+		}
+	}
+	return nil, false
+}
+
+// FindStatefulSet returns the listed statefulset with a namespace and
+// name, from the context's list in multi-context mode, or else the single
+// list
+func (s *State) FindStatefulSet(context, namespace, name string) (*appsv1.StatefulSet, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statefulsets := s.StatefulSets
+	if s.MultiContextMode && context != "" {
+		statefulsets = s.StatefulSetsByContext[context]
+	}
+	for i := range statefulsets {
+		if statefulsets[i].Namespace == namespace && statefulsets[i].Name == name {
+			return &statefulsets[i], true
+		}
+	}
+	return nil, false
+}
+
+// FindPod returns the listed pod with a namespace and name, from the
+// context's list in multi-context mode, or else the single list
+func (s *State) FindPod(context, namespace, name string) (*v1.Pod, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pods := s.Pods
+	if s.MultiContextMode && context != "" {
+		pods = s.PodsByContext[context]
+	}
+	for i := range pods {
+		if pods[i].Namespace == namespace && pods[i].Name == name {
+			return &pods[i], true
+		}
+	}
+	return nil, false
+}
+
+// FindUID returns the UID of the listed resource of the current type with a
+// namespace and name, from the context's list in multi-context mode
+func (s *State) FindUID(context, namespace, name string) (types.UID, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	multiContext := s.MultiContextMode && context != ""
+	switch s.CurrentResourceType {
+	case ResourceTypePod:
+		pods := s.Pods
+		if multiContext {
+			pods = s.PodsByContext[context]
+		}
+		return findUID(pods, namespace, name)
+	case ResourceTypeDeployment:
+		deployments := s.Deployments
+		if multiContext {
+			deployments = s.DeploymentsByContext[context]
+		}
+		return findUID(deployments, namespace, name)
+	case ResourceTypeStatefulSet:
+		return findUID(s.StatefulSets, namespace, name)
+	case ResourceTypeService:
+		return findUID(s.Services, namespace, name)
+	case ResourceTypeIngress:
+		return findUID(s.Ingresses, namespace, name)
+	case ResourceTypeGateway:
+		return findUID(s.Gateways, namespace, name)
+	case ResourceTypeHTTPRoute:
+		return findUID(s.HTTPRoutes, namespace, name)
+	case ResourceTypeServiceAccount:
+		return findUID(s.ServiceAccounts, namespace, name)
+	case ResourceTypeRole:
+		return findUID(s.Roles, namespace, name)
+	case ResourceTypeClusterRole:
+		return findUID(s.ClusterRoles, namespace, name)
+	case ResourceTypeRoleBinding:
+		return findUID(s.RoleBindings, namespace, name)
+	case ResourceTypeClusterRoleBinding:
+		return findUID(s.ClusterRoleBindings, namespace, name)
+	case ResourceTypeNode:
+		return findUID(s.Nodes, namespace, name)
+	case ResourceTypeJob:
+		return findUID(s.Jobs, namespace, name)
+	case ResourceTypeCronJob:
+		return findUID(s.CronJobs, namespace, name)
+	}
+	return "", false
+}
+
+// findUID returns the UID of the object in a list with a namespace and name
+func findUID[T any, P interface {
+	*T
+	metav1.Object
+}](objects []T, namespace, name string) (types.UID, bool) {
+	for i := range objects {
+		if obj := P(&objects[i]); obj.GetNamespace() == namespace && obj.GetName() == name {
+			return obj.GetUID(), true
+		}
+	}
+	return "", false
 }
 
 // UpdateStatefulSetsByContext updates statefulsets for a specific context
 func (s *State) UpdateStatefulSetsByContext(context string, statefulsets []appsv1.StatefulSet) {
+	size := stripUnusedFields(statefulsets)
+	observeDeletions(s, ResourceTypeStatefulSet, context, statefulsets)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.StatefulSetsByContext[context] = statefulsets
+	s.recordCache(CacheKey{Kind: ResourceTypeStatefulSet, Context: context}, len(statefulsets), size)
 }
 
 // UpdateServicesByContext updates services for a specific context
 func (s *State) UpdateServicesByContext(context string, services []v1.Service) {
+	size := stripUnusedFields(services)
+	observeDeletions(s, ResourceTypeService, context, services)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.ServicesByContext[context] = services
+	s.recordCache(CacheKey{Kind: ResourceTypeService, Context: context}, len(services), size)
 }
 
 // UpdateIngressesByContext updates ingresses for a specific context
 func (s *State) UpdateIngressesByContext(context string, ingresses []networkingv1.Ingress) {
+	size := stripUnusedFields(ingresses)
+	observeDeletions(s, ResourceTypeIngress, context, ingresses)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.IngressesByContext[context] = ingresses
+	s.recordCache(CacheKey{Kind: ResourceTypeIngress, Context: context}, len(ingresses), size)
+}
+
+// UpdateGatewaysByContext updates gateways for a specific context
+func (s *State) UpdateGatewaysByContext(context string, gateways []Gateway) {
+	size := stripUnusedFields(gateways)
+	observeDeletions(s, ResourceTypeGateway, context, gateways)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.GatewaysByContext[context] = gateways
+	s.recordCache(CacheKey{Kind: ResourceTypeGateway, Context: context}, len(gateways), size)
+}
+
+// UpdateHTTPRoutesByContext updates HTTP routes for a specific context
+func (s *State) UpdateHTTPRoutesByContext(context string, routes []HTTPRoute) {
+	size := stripUnusedFields(routes)
+	observeDeletions(s, ResourceTypeHTTPRoute, context, routes)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.HTTPRoutesByContext[context] = routes
+	s.recordCache(CacheKey{Kind: ResourceTypeHTTPRoute, Context: context}, len(routes), size)
 }
 
 // UpdateConfigMapsByContext updates configmaps for a specific context
 func (s *State) UpdateConfigMapsByContext(context string, configmaps []v1.ConfigMap) {
+	size := stripUnusedFields(configmaps)
+	observeDeletions(s, ResourceTypeConfigMap, context, configmaps)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.ConfigMapsByContext[context] = configmaps
+	s.recordCache(CacheKey{Kind: ResourceTypeConfigMap, Context: context}, len(configmaps), size)
 }
 
 // UpdateSecretsByContext updates secrets for a specific context
 func (s *State) UpdateSecretsByContext(context string, secrets []v1.Secret) {
+	size := stripUnusedFields(secrets)
+	observeDeletions(s, ResourceTypeSecret, context, secrets)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.SecretsByContext[context] = secrets
+	s.recordCache(CacheKey{Kind: ResourceTypeSecret, Context: context}, len(secrets), size)
+}
+
+// UpdateServiceAccountsByContext updates serviceaccounts for a specific context
+func (s *State) UpdateServiceAccountsByContext(context string, serviceaccounts []v1.ServiceAccount) {
+	size := stripUnusedFields(serviceaccounts)
+	observeDeletions(s, ResourceTypeServiceAccount, context, serviceaccounts)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ServiceAccountsByContext[context] = serviceaccounts
+	s.recordCache(CacheKey{Kind: ResourceTypeServiceAccount, Context: context}, len(serviceaccounts), size)
+}
+
+// UpdateRolesByContext updates roles for a specific context
+func (s *State) UpdateRolesByContext(context string, roles []rbacv1.Role) {
+	size := stripUnusedFields(roles)
+	observeDeletions(s, ResourceTypeRole, context, roles)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.RolesByContext[context] = roles
+	s.recordCache(CacheKey{Kind: ResourceTypeRole, Context: context}, len(roles), size)
+}
+
+// UpdateClusterRolesByContext updates cluster roles for a specific context
+func (s *State) UpdateClusterRolesByContext(context string, roles []rbacv1.ClusterRole) {
+	size := stripUnusedFields(roles)
+	observeDeletions(s, ResourceTypeClusterRole, context, roles)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ClusterRolesByContext[context] = roles
+	s.recordCache(CacheKey{Kind: ResourceTypeClusterRole, Context: context}, len(roles), size)
+}
+
+// UpdateRoleBindingsByContext updates role bindings for a specific context
+func (s *State) UpdateRoleBindingsByContext(context string, bindings []rbacv1.RoleBinding) {
+	size := stripUnusedFields(bindings)
+	observeDeletions(s, ResourceTypeRoleBinding, context, bindings)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.RoleBindingsByContext[context] = bindings
+	s.recordCache(CacheKey{Kind: ResourceTypeRoleBinding, Context: context}, len(bindings), size)
+}
+
+// UpdateClusterRoleBindingsByContext updates cluster role bindings for a specific context
+func (s *State) UpdateClusterRoleBindingsByContext(context string, bindings []rbacv1.ClusterRoleBinding) {
+	size := stripUnusedFields(bindings)
+	observeDeletions(s, ResourceTypeClusterRoleBinding, context, bindings)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ClusterRoleBindingsByContext[context] = bindings
+	s.recordCache(CacheKey{Kind: ResourceTypeClusterRoleBinding, Context: context}, len(bindings), size)
+}
+
+// UpdateNodesByContext updates nodes for a specific context
+func (s *State) UpdateNodesByContext(context string, nodes []v1.Node) {
+	size := stripUnusedFields(nodes)
+	observeDeletions(s, ResourceTypeNode, context, nodes)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.NodesByContext[context] = nodes
+	s.recordCache(CacheKey{Kind: ResourceTypeNode, Context: context}, len(nodes), size)
+}
+
+// UpdateJobsByContext updates jobs for a specific context
+func (s *State) UpdateJobsByContext(context string, jobs []batchv1.Job) {
+	size := stripUnusedFields(jobs)
+	observeDeletions(s, ResourceTypeJob, context, jobs)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.JobsByContext[context] = jobs
+	s.recordCache(CacheKey{Kind: ResourceTypeJob, Context: context}, len(jobs), size)
+}
+
+// UpdateCronJobsByContext updates cron jobs for a specific context
+func (s *State) UpdateCronJobsByContext(context string, cronJobs []batchv1.CronJob) {
+	size := stripUnusedFields(cronJobs)
+	observeDeletions(s, ResourceTypeCronJob, context, cronJobs)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.CronJobsByContext[context] = cronJobs
+	s.recordCache(CacheKey{Kind: ResourceTypeCronJob, Context: context}, len(cronJobs), size)
+}
+
+// recordCache notes a list just stored in the current namespace; s.mu must
+// be held
+func (s *State) recordCache(key CacheKey, objects int, bytes int64) {
+	if s.Caches != nil {
+		s.Caches.Record(key, s.CurrentNamespace, objects, bytes)
+	}
+}
+
+// EvictStaleCaches drops the cached lists of resource types not viewed
+// within the cache TTL, and of contexts no longer shown. The lists of the
+// type being viewed are always kept. It returns what was dropped.
+func (s *State) EvictStaleCaches(now time.Time) []CacheEntry {
+	if s.Caches == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stale := s.Caches.Sweep(now, func(key CacheKey) bool {
+		return key.Kind == s.CurrentResourceType && (key.Context == "" || s.ContextFilter[key.Context])
+	})
+	for _, entry := range stale {
+		switch entry.Kind {
+		case ResourceTypePod:
+			evictList(&s.Pods, s.PodsByContext, entry.Context)
+		case ResourceTypeDeployment:
+			evictList(&s.Deployments, s.DeploymentsByContext, entry.Context)
+		case ResourceTypeStatefulSet:
+			evictList(&s.StatefulSets, s.StatefulSetsByContext, entry.Context)
+		case ResourceTypeService:
+			evictList(&s.Services, s.ServicesByContext, entry.Context)
+		case ResourceTypeIngress:
+			evictList(&s.Ingresses, s.IngressesByContext, entry.Context)
+		case ResourceTypeGateway:
+			evictList(&s.Gateways, s.GatewaysByContext, entry.Context)
+		case ResourceTypeHTTPRoute:
+			evictList(&s.HTTPRoutes, s.HTTPRoutesByContext, entry.Context)
+		case ResourceTypeConfigMap:
+			evictList(&s.ConfigMaps, s.ConfigMapsByContext, entry.Context)
+		case ResourceTypeSecret:
+			evictList(&s.Secrets, s.SecretsByContext, entry.Context)
+		case ResourceTypeServiceAccount:
+			evictList(&s.ServiceAccounts, s.ServiceAccountsByContext, entry.Context)
+		case ResourceTypeRole:
+			evictList(&s.Roles, s.RolesByContext, entry.Context)
+		case ResourceTypeClusterRole:
+			evictList(&s.ClusterRoles, s.ClusterRolesByContext, entry.Context)
+		case ResourceTypeRoleBinding:
+			evictList(&s.RoleBindings, s.RoleBindingsByContext, entry.Context)
+		case ResourceTypeClusterRoleBinding:
+			evictList(&s.ClusterRoleBindings, s.ClusterRoleBindingsByContext, entry.Context)
+		case ResourceTypeNode:
+			evictList(&s.Nodes, s.NodesByContext, entry.Context)
+		case ResourceTypeJob:
+			evictList(&s.Jobs, s.JobsByContext, entry.Context)
+		case ResourceTypeCronJob:
+			evictList(&s.CronJobs, s.CronJobsByContext, entry.Context)
+		}
+	}
+	return stale
+}
+
+// evictList drops a kind's main list, or its list for a context
+func evictList[T any](list *[]T, byContext map[string][]T, context string) {
+	if context == "" {
+		*list = nil
+		return
+	}
+	delete(byContext, context)
 }
 
 // GetAggregatedPods returns pods from all active contexts
@@ -333,6 +1359,52 @@ func (s *State) SetSortState(column string, ascending bool) {
 	s.SortAscending = ascending
 }
 
+// ForContext returns a fresh state showing the same resource type, namespace,
+// sort and filter in a single context. Each pane of a comparison gets one so
+// it can be filtered and scrolled on its own. It shares the image history.
+func (s *State) ForContext(context string) *State {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	config := s.config
+	if config == nil {
+		config = &Config{}
+	}
+	state := NewState(config)
+	state.CurrentResourceType = s.CurrentResourceType
+	state.CurrentNamespace = s.CurrentNamespace
+	state.CurrentContext = context
+	state.FilterString = s.FilterString
+	state.SavedFilter = s.SavedFilter
+	state.FilterGlobal = s.FilterGlobal
+	state.globalFilter = s.globalFilter
+	for t, filter := range s.typeFilters {
+		if state.typeFilters == nil {
+			state.typeFilters = make(map[ResourceType]typeFilter)
+		}
+		state.typeFilters[t] = filter
+	}
+	state.SortColumn = s.SortColumn
+	state.SortAscending = s.SortAscending
+	state.Columns = s.Columns
+	for t, order := range s.columnOrders {
+		if state.columnOrders == nil {
+			state.columnOrders = make(map[ResourceType][]string)
+		}
+		state.columnOrders[t] = order
+	}
+	state.Images = s.Images
+	state.Changes = s.Changes
+	state.Deletions = s.Deletions
+	for t, installed := range s.installedTypes {
+		state.setTypeInstalled(t, installed)
+	}
+	if s.Caches != nil {
+		state.Caches.SetTTL(s.Caches.TTL())
+	}
+	return state
+}
+
 // GetCurrentNamespace returns the current namespace in a thread-safe manner
 func (s *State) GetCurrentNamespace() string {
 	s.mu.RLock()
@@ -114,3 +114,85 @@ func TestStateContextConsistency(t *testing.T) {
 		t.Errorf("Config context not preserved: expected 'test-context', got '%s'", state.config.CurrentContext)
 	}
 }
+
+// TestStateForContext tests that a per-context state copies what is viewed
+// but can then be filtered on its own
+func TestStateForContext(t *testing.T) {
+	state := NewState(&Config{CurrentContext: "prod", CurrentNamespace: "payments"})
+	state.SetResourceType(ResourceTypeDeployment)
+	state.SetSortState("AGE", false)
+	state.SetFilter("status!=Running", "broken")
+	state.SetCurrentContexts([]string{"prod", "staging"})
+
+	staging := state.ForContext("staging")
+	if staging.CurrentContext != "staging" || staging.CurrentNamespace != "payments" ||
+		staging.CurrentResourceType != ResourceTypeDeployment {
+		t.Errorf("Expected staging deployments in payments, got %s %s in %s",
+			staging.CurrentContext, staging.CurrentResourceType, staging.CurrentNamespace)
+	}
+	if column, ascending := staging.GetSortState(); column != "AGE" || ascending {
+		t.Errorf("Expected the sort to be copied, got %s ascending=%v", column, ascending)
+	}
+	if expression, saved := staging.GetFilter(); expression != "status!=Running" || saved != "broken" {
+		t.Errorf("Expected the filter to be copied, got %q [%s]", expression, saved)
+	}
+	if len(staging.CurrentContexts) != 0 {
+		t.Errorf("Expected a single-context state, got contexts %v", staging.CurrentContexts)
+	}
+
+	staging.SetFilter("", "")
+	if expression, _ := state.GetFilter(); expression != "status!=Running" {
+		t.Errorf("Expected the original filter to be untouched, got %q", expression)
+	}
+}
+
+// TestStateFiltersPerType tests that each resource type keeps its own filter
+// across switches, and that a global filter takes their place until cleared
+func TestStateFiltersPerType(t *testing.T) {
+	state := NewState(&Config{})
+	state.SetFilter("name~checkout", "")
+
+	state.SetResourceType(ResourceTypeDeployment)
+	if expression, _ := state.GetFilter(); expression != "" {
+		t.Errorf("Expected deployments unfiltered, got %q", expression)
+	}
+	state.SetFilter("ready!=3/3", "degraded")
+
+	state.SetResourceType(ResourceTypePod)
+	if expression, saved := state.GetFilter(); expression != "name~checkout" || saved != "" {
+		t.Errorf("Expected the pods' filter back, got %q [%s]", expression, saved)
+	}
+	state.SetResourceType(ResourceTypeDeployment)
+	if expression, saved := state.GetFilter(); expression != "ready!=3/3" || saved != "degraded" {
+		t.Errorf("Expected the deployments' saved filter back, got %q [%s]", expression, saved)
+	}
+
+	state.SetGlobalFilter("namespace=payments")
+	state.SetResourceType(ResourceTypeService)
+	if expression, _ := state.GetFilter(); expression != "namespace=payments" || !state.IsFilterGlobal() {
+		t.Errorf("Expected the global filter on services, got %q", expression)
+	}
+	if types := state.FilteredTypes(); len(types) != 2 || types[0] != ResourceTypePod || types[1] != ResourceTypeDeployment {
+		t.Errorf("Expected pods and deployments to keep their own filters, got %v", types)
+	}
+
+	// Clearing the global filter shows each type's own again
+	state.SetResourceType(ResourceTypePod)
+	state.ClearFilter()
+	if expression, _ := state.GetFilter(); expression != "name~checkout" || state.IsFilterGlobal() {
+		t.Errorf("Expected the pods' own filter after the global one, got %q", expression)
+	}
+	state.ClearFilter()
+	if expression, _ := state.GetFilter(); expression != "" {
+		t.Errorf("Expected the pods' filter cleared, got %q", expression)
+	}
+	if types := state.FilteredTypes(); len(types) != 1 || types[0] != ResourceTypeDeployment {
+		t.Errorf("Expected only deployments filtered, got %v", types)
+	}
+
+	state.ClearAllFilters()
+	state.SetResourceType(ResourceTypeDeployment)
+	if expression, _ := state.GetFilter(); expression != "" || len(state.FilteredTypes()) != 0 {
+		t.Errorf("Expected every filter cleared, got %q", expression)
+	}
+}
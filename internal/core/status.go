@@ -0,0 +1,239 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// StatusTerminating is shown for a resource whose deletion is in progress
+	StatusTerminating = "Terminating"
+
+	// StuckTerminatingThreshold is how long a deletion may take before the
+	// resource counts as stuck, usually on a finalizer nobody is removing
+	StuckTerminatingThreshold = 5 * time.Minute
+)
+
+// TerminationStatus describes a resource's pending deletion: "" when it is
+// not being deleted, "Terminating" while the deletion is recent, and
+// "stuck terminating (12m)" once it has been pending past the threshold
+func TerminationStatus(meta metav1.ObjectMeta, now time.Time) string {
+	if meta.DeletionTimestamp == nil {
+		return ""
+	}
+	pending := now.Sub(meta.DeletionTimestamp.Time)
+	if pending < StuckTerminatingThreshold {
+		return StatusTerminating
+	}
+	return fmt.Sprintf("stuck terminating (%s)", FormatDuration(pending))
+}
+
+// IsStuckTerminating returns true for a status from TerminationStatus that
+// marks a stuck deletion
+func IsStuckTerminating(status string) bool {
+	return strings.HasPrefix(status, "stuck terminating")
+}
+
+// PodStatus returns the status shown for a pod: its termination status while
+// it is being deleted, otherwise the most specific reason from its
+// containers or conditions, falling back to its phase
+func PodStatus(pod *v1.Pod, now time.Time) string {
+	if status := TerminationStatus(pod.ObjectMeta, now); status != "" {
+		return status
+	}
+
+	status := string(pod.Status.Phase)
+
+	// Get more detailed status if available
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady && condition.Status != v1.ConditionTrue {
+			if condition.Reason != "" {
+				status = condition.Reason
+			}
+		}
+	}
+
+	// Check container statuses for more specific states
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+			return cs.State.Waiting.Reason
+		}
+		if cs.State.Terminated != nil && cs.State.Terminated.Reason != "" {
+			return cs.State.Terminated.Reason
+		}
+	}
+
+	return status
+}
+
+// IsTerminating returns true for a status from TerminationStatus, stuck or
+// not
+func IsTerminating(status string) bool {
+	return status == StatusTerminating || IsStuckTerminating(status)
+}
+
+// PodCounts tallies pods for a summary. A pod being deleted counts only as
+// terminating, however running or ready it still looks, so it never passes
+// for a replica a rollout can rely on.
+type PodCounts struct {
+	Running     int // Running and not being deleted
+	Ready       int // Ready and not being deleted
+	Starting    int // Neither ready, finished nor being deleted
+	Terminating int // Being deleted
+}
+
+// Add counts one pod
+func (c *PodCounts) Add(pod *v1.Pod) {
+	if pod.DeletionTimestamp != nil {
+		c.Terminating++
+		return
+	}
+	if pod.Status.Phase == v1.PodRunning {
+		c.Running++
+	}
+	switch {
+	case podReady(pod):
+		c.Ready++
+	case pod.Status.Phase == v1.PodPending, pod.Status.Phase == v1.PodRunning:
+		c.Starting++
+	}
+}
+
+// CountPods tallies pods for a summary
+func CountPods(pods []v1.Pod) PodCounts {
+	var c PodCounts
+	for i := range pods {
+		c.Add(&pods[i])
+	}
+	return c
+}
+
+// podReady returns true when a pod's Ready condition is true
+func podReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// ManyContainersThreshold is the container count above which a pod's
+// unready containers are named, since a READY count such as 13/15 no longer
+// tells which ones are down
+const ManyContainersThreshold = 5
+
+// ContainerCount returns how many init, app and ephemeral containers report a
+// status on a pod
+func ContainerCount(pod *v1.Pod) int {
+	return len(pod.Status.InitContainerStatuses) + len(pod.Status.ContainerStatuses) + len(pod.Status.EphemeralContainerStatuses)
+}
+
+// NotReadyContainers names a pod's containers that are not ready: init
+// containers that have not completed, app containers that are not ready, and
+// ephemeral containers that are waiting or exited with an error. Init and
+// ephemeral containers are marked, e.g. "setup (init)". A pod that ran to
+// completion has none.
+func NotReadyContainers(pod *v1.Pod) []string {
+	if pod.Status.Phase == v1.PodSucceeded {
+		return nil
+	}
+
+	var names []string
+	for _, cs := range pod.Status.InitContainerStatuses {
+		completed := cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0
+		if !cs.Ready && !completed {
+			names = append(names, cs.Name+" (init)")
+		}
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if !cs.Ready {
+			names = append(names, cs.Name)
+		}
+	}
+	// Ephemeral containers have no readiness; they are fine while running or
+	// once they exit cleanly
+	for _, cs := range pod.Status.EphemeralContainerStatuses {
+		failed := cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0
+		if cs.State.Waiting != nil || failed {
+			names = append(names, cs.Name+" (ephemeral)")
+		}
+	}
+	return names
+}
+
+// PodContainer is one of a pod's containers, as the log container picker
+// lists it
+type PodContainer struct {
+	Name     string
+	Kind     string // "init", "ephemeral", or "" for an app container
+	State    string // e.g. Running, CrashLoopBackOff or Completed
+	Restarts int32
+}
+
+// PodContainers lists a pod's init, app and ephemeral containers, in that
+// order, with the state and restarts their statuses report. A container
+// with no status yet, as on a pod still pending, is Pending.
+func PodContainers(pod *v1.Pod) []PodContainer {
+	statuses := make(map[string]v1.ContainerStatus, ContainerCount(pod))
+	for _, list := range [][]v1.ContainerStatus{
+		pod.Status.InitContainerStatuses,
+		pod.Status.ContainerStatuses,
+		pod.Status.EphemeralContainerStatuses,
+	} {
+		for _, cs := range list {
+			statuses[cs.Name] = cs
+		}
+	}
+	add := func(containers []PodContainer, name, kind string) []PodContainer {
+		container := PodContainer{Name: name, Kind: kind, State: "Pending"}
+		if cs, ok := statuses[name]; ok {
+			container.State = containerState(cs.State)
+			container.Restarts = cs.RestartCount
+		}
+		return append(containers, container)
+	}
+
+	containers := make([]PodContainer, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers)+len(pod.Spec.EphemeralContainers))
+	for _, c := range pod.Spec.InitContainers {
+		containers = add(containers, c.Name, "init")
+	}
+	for _, c := range pod.Spec.Containers {
+		containers = add(containers, c.Name, "")
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		containers = add(containers, c.Name, "ephemeral")
+	}
+	return containers
+}
+
+// containerState names a container's state by its reason when it has one,
+// e.g. CrashLoopBackOff, or else Running, Waiting or Terminated
+func containerState(state v1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return "Running"
+	case state.Waiting != nil && state.Waiting.Reason != "":
+		return state.Waiting.Reason
+	case state.Waiting != nil:
+		return "Waiting"
+	case state.Terminated != nil && state.Terminated.Reason != "":
+		return state.Terminated.Reason
+	case state.Terminated != nil:
+		return "Terminated"
+	}
+	return "Pending"
+}
+
+// AgeOrStuck returns a resource's age, or its stuck terminating badge when it
+// is stuck, for kinds without a status column to show the badge in
+func AgeOrStuck(meta metav1.ObjectMeta, now time.Time) string {
+	if status := TerminationStatus(meta, now); IsStuckTerminating(status) {
+		return status
+	}
+	return string(AppendAge(nil, meta.CreationTimestamp.Time, now))
+}
@@ -0,0 +1,233 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// deletingMeta returns metadata for an object whose deletion was requested ago
+func deletingMeta(now time.Time, ago time.Duration) metav1.ObjectMeta {
+	deleted := metav1.NewTime(now.Add(-ago))
+	return metav1.ObjectMeta{
+		CreationTimestamp: metav1.NewTime(now.Add(-24 * time.Hour)),
+		DeletionTimestamp: &deleted,
+		Finalizers:        []string{"example.com/cleanup"},
+	}
+}
+
+func TestTerminationStatus(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		meta      metav1.ObjectMeta
+		expected  string
+		wantStuck bool
+	}{
+		{"not deleting", metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))}, "", false},
+		{"freshly deleting", deletingMeta(now, 30*time.Second), StatusTerminating, false},
+		{"just under threshold", deletingMeta(now, StuckTerminatingThreshold-time.Second), StatusTerminating, false},
+		{"stuck", deletingMeta(now, 12*time.Minute), "stuck terminating (12m)", true},
+		{"stuck for days", deletingMeta(now, 3*24*time.Hour), "stuck terminating (3d)", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TerminationStatus(tt.meta, now)
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+			if IsStuckTerminating(got) != tt.wantStuck {
+				t.Errorf("Expected IsStuckTerminating(%q) = %v", got, tt.wantStuck)
+			}
+		})
+	}
+}
+
+func TestPodStatus(t *testing.T) {
+	now := time.Now()
+	crashing := v1.PodStatus{
+		Phase: v1.PodRunning,
+		ContainerStatuses: []v1.ContainerStatus{{
+			State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		meta     metav1.ObjectMeta
+		status   v1.PodStatus
+		expected string
+	}{
+		{"running", metav1.ObjectMeta{}, v1.PodStatus{Phase: v1.PodRunning}, "Running"},
+		{"container reason", metav1.ObjectMeta{}, crashing, "CrashLoopBackOff"},
+		{"freshly deleting", deletingMeta(now, time.Minute), crashing, StatusTerminating},
+		{"stuck deleting", deletingMeta(now, 12*time.Minute), crashing, "stuck terminating (12m)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &v1.Pod{ObjectMeta: tt.meta, Status: tt.status}
+			if got := PodStatus(pod, now); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCountPods(t *testing.T) {
+	now := time.Now()
+	readyStatus := func(phase v1.PodPhase, ready v1.ConditionStatus) v1.PodStatus {
+		return v1.PodStatus{Phase: phase, Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: ready}}}
+	}
+	pods := []v1.Pod{
+		{Status: readyStatus(v1.PodRunning, v1.ConditionTrue)},
+		{Status: readyStatus(v1.PodRunning, v1.ConditionTrue)},
+		{Status: readyStatus(v1.PodRunning, v1.ConditionFalse)},
+		{Status: v1.PodStatus{Phase: v1.PodPending}},
+		{Status: v1.PodStatus{Phase: v1.PodSucceeded}},
+		// Still running and ready while being deleted
+		{ObjectMeta: deletingMeta(now, time.Minute), Status: readyStatus(v1.PodRunning, v1.ConditionTrue)},
+		{ObjectMeta: deletingMeta(now, 12*time.Minute), Status: readyStatus(v1.PodRunning, v1.ConditionFalse)},
+	}
+
+	got := CountPods(pods)
+	want := PodCounts{Running: 3, Ready: 2, Starting: 2, Terminating: 2}
+	if got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+
+	for _, status := range []string{StatusTerminating, "stuck terminating (12m)"} {
+		if !IsTerminating(status) {
+			t.Errorf("Expected %q to be terminating", status)
+		}
+	}
+	if IsTerminating("Running") {
+		t.Error("Expected Running not to be terminating")
+	}
+}
+
+func TestNotReadyContainers(t *testing.T) {
+	running := v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+	waiting := v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}
+	exited := func(code int32) v1.ContainerState {
+		return v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: code}}
+	}
+
+	tests := []struct {
+		name     string
+		status   v1.PodStatus
+		expected []string
+	}{
+		{"all ready", v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{
+			{Name: "app", Ready: true, State: running},
+			{Name: "proxy", Ready: true, State: running},
+		}}, nil},
+		{"app containers", v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{
+			{Name: "app", Ready: true, State: running},
+			{Name: "proxy", State: running},
+			{Name: "metrics", State: waiting},
+		}}, []string{"proxy", "metrics"}},
+		{"init containers", v1.PodStatus{Phase: v1.PodPending,
+			InitContainerStatuses: []v1.ContainerStatus{
+				{Name: "migrate", State: exited(0)},
+				{Name: "setup", State: exited(1)},
+				{Name: "sidecar", Ready: true, State: running},
+			},
+			ContainerStatuses: []v1.ContainerStatus{{Name: "app", State: waiting}},
+		}, []string{"setup (init)", "app"}},
+		{"ephemeral containers", v1.PodStatus{Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{Name: "app", Ready: true, State: running}},
+			EphemeralContainerStatuses: []v1.ContainerStatus{
+				{Name: "debugger-a", State: running},
+				{Name: "debugger-b", State: exited(0)},
+				{Name: "debugger-c", State: exited(137)},
+				{Name: "debugger-d", State: waiting},
+			},
+		}, []string{"debugger-c (ephemeral)", "debugger-d (ephemeral)"}},
+		{"completed pod", v1.PodStatus{Phase: v1.PodSucceeded, ContainerStatuses: []v1.ContainerStatus{
+			{Name: "job", State: exited(0)},
+		}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &v1.Pod{Status: tt.status}
+			got := NotReadyContainers(pod)
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestContainerCount(t *testing.T) {
+	pod := &v1.Pod{Status: v1.PodStatus{
+		InitContainerStatuses:      make([]v1.ContainerStatus, 2),
+		ContainerStatuses:          make([]v1.ContainerStatus, 13),
+		EphemeralContainerStatuses: make([]v1.ContainerStatus, 1),
+	}}
+	if got := ContainerCount(pod); got != 16 {
+		t.Errorf("Expected 16 containers, got %d", got)
+	}
+}
+
+func TestPodContainers(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "migrate"}},
+			Containers:     []v1.Container{{Name: "app"}, {Name: "proxy"}},
+			EphemeralContainers: []v1.EphemeralContainer{
+				{EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: "debugger"}},
+			},
+		},
+		Status: v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{
+				{Name: "migrate", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Completed"}}},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "app", RestartCount: 4, State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+				{Name: "proxy", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+			},
+		},
+	}
+
+	expected := []PodContainer{
+		{Name: "migrate", Kind: "init", State: "Completed"},
+		{Name: "app", State: "CrashLoopBackOff", Restarts: 4},
+		{Name: "proxy", State: "Running"},
+		{Name: "debugger", Kind: "ephemeral", State: "Pending"},
+	}
+	got := PodContainers(pod)
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d containers, got %+v", len(expected), got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Expected container %d to be %+v, got %+v", i, expected[i], got[i])
+		}
+	}
+
+	// A pending pod reports no statuses yet
+	pod.Status = v1.PodStatus{Phase: v1.PodPending}
+	for _, container := range PodContainers(pod) {
+		if container.State != "Pending" || container.Restarts != 0 {
+			t.Errorf("Expected %s pending without restarts, got %+v", container.Name, container)
+		}
+	}
+}
+
+func TestAgeOrStuck(t *testing.T) {
+	now := time.Now()
+
+	if got := AgeOrStuck(deletingMeta(now, time.Minute), now); got != "1d" {
+		t.Errorf("Expected the age while freshly deleting, got %q", got)
+	}
+	if got := AgeOrStuck(deletingMeta(now, 12*time.Minute), now); got != "stuck terminating (12m)" {
+		t.Errorf("Expected the stuck badge, got %q", got)
+	}
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// TimeFormat says how timestamps are shown. The zero value shows local time
+// on a 24-hour clock, e.g. "2024-03-05 14:02:11".
+type TimeFormat struct {
+	// Clock12 shows a 12-hour clock, e.g. "2:02:11 PM"
+	Clock12 bool
+	// UTC shows times in UTC, marked so screenshots are unambiguous
+	UTC bool
+	// Layout is a Go time layout used instead of the built-in ones, for
+	// dates and times of day alike
+	Layout string
+}
+
+// timeFormat is the format every timestamp in the UI uses
+var timeFormat atomic.Pointer[TimeFormat]
+
+// SetTimeFormat sets how timestamps are shown
+func SetTimeFormat(format TimeFormat) {
+	timeFormat.Store(&format)
+}
+
+// CurrentTimeFormat returns how timestamps are shown
+func CurrentTimeFormat() TimeFormat {
+	if format := timeFormat.Load(); format != nil {
+		return *format
+	}
+	return TimeFormat{}
+}
+
+// ParseTimeFormat builds a TimeFormat from config values: clock is "24h"
+// or "12h" and zone is "local" or "utc"; empty values mean the defaults
+func ParseTimeFormat(clock, zone, layout string) (TimeFormat, error) {
+	var format TimeFormat
+	switch strings.ToLower(strings.TrimSpace(clock)) {
+	case "", "24h":
+	case "12h":
+		format.Clock12 = true
+	default:
+		return TimeFormat{}, fmt.Errorf("unknown clock %q: use 24h or 12h", clock)
+	}
+	switch strings.ToLower(strings.TrimSpace(zone)) {
+	case "", "local":
+	case "utc":
+		format.UTC = true
+	default:
+		return TimeFormat{}, fmt.Errorf("unknown zone %q: use local or utc", zone)
+	}
+	format.Layout = layout
+	return format, nil
+}
+
+// FormatTimestamp formats a date and time, e.g. "2024-03-05 14:02:11", in
+// the current TimeFormat
+func FormatTimestamp(t time.Time) string {
+	format := CurrentTimeFormat()
+	layout := "2006-01-02 15:04:05"
+	if format.Clock12 {
+		layout = "2006-01-02 3:04:05 PM"
+	}
+	return format.format(t, layout)
+}
+
+// FormatClock formats the time of day, e.g. "14:02:11", in the current
+// TimeFormat
+func FormatClock(t time.Time) string {
+	format := CurrentTimeFormat()
+	layout := "15:04:05"
+	if format.Clock12 {
+		layout = "3:04:05 PM"
+	}
+	return format.format(t, layout)
+}
+
+// format formats t with the custom layout, or else the given one, marking
+// UTC times unless the layout shows the zone itself
+func (f TimeFormat) format(t time.Time, layout string) string {
+	if f.Layout != "" {
+		layout = f.Layout
+	}
+	if !f.UTC {
+		return t.Local().Format(layout)
+	}
+	formatted := t.UTC().Format(layout)
+	if layoutShowsZone(layout) {
+		return formatted
+	}
+	return formatted + " UTC"
+}
+
+// layoutShowsZone reports whether a Go time layout includes the zone
+func layoutShowsZone(layout string) bool {
+	for _, element := range []string{"MST", "Z07", "-07"} {
+		if strings.Contains(layout, element) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTimestampModes(t *testing.T) {
+	defer SetTimeFormat(TimeFormat{})
+
+	// 14:02:11 UTC, shown in a zone two hours behind when local
+	at := time.Date(2024, 3, 5, 14, 2, 11, 0, time.UTC)
+	local := time.Local
+	time.Local = time.FixedZone("TEST", -2*60*60)
+	defer func() { time.Local = local }()
+
+	tests := []struct {
+		name      string
+		format    TimeFormat
+		timestamp string
+		clock     string
+	}{
+		{"24h local", TimeFormat{}, "2024-03-05 12:02:11", "12:02:11"},
+		{"12h local", TimeFormat{Clock12: true}, "2024-03-05 12:02:11 PM", "12:02:11 PM"},
+		{"24h UTC", TimeFormat{UTC: true}, "2024-03-05 14:02:11 UTC", "14:02:11 UTC"},
+		{"12h UTC", TimeFormat{Clock12: true, UTC: true}, "2024-03-05 2:02:11 PM UTC", "2:02:11 PM UTC"},
+		{"custom layout", TimeFormat{Layout: "Jan 2 15:04"}, "Mar 5 12:02", "Mar 5 12:02"},
+		{"custom layout with zone", TimeFormat{UTC: true, Layout: time.RFC3339}, "2024-03-05T14:02:11Z", "2024-03-05T14:02:11Z"},
+		{"custom layout without zone", TimeFormat{UTC: true, Layout: "15:04"}, "14:02 UTC", "14:02 UTC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetTimeFormat(tt.format)
+			if got := FormatTimestamp(at); got != tt.timestamp {
+				t.Errorf("FormatTimestamp() = %q, want %q", got, tt.timestamp)
+			}
+			if got := FormatClock(at); got != tt.clock {
+				t.Errorf("FormatClock() = %q, want %q", got, tt.clock)
+			}
+		})
+	}
+}
+
+func TestParseTimeFormat(t *testing.T) {
+	format, err := ParseTimeFormat("12h", "UTC", "")
+	if err != nil || !format.Clock12 || !format.UTC {
+		t.Errorf("Expected 12h UTC, got %+v, %v", format, err)
+	}
+	if format, err := ParseTimeFormat("", "", ""); err != nil || format != (TimeFormat{}) {
+		t.Errorf("Expected the defaults, got %+v, %v", format, err)
+	}
+	if _, err := ParseTimeFormat("13h", "", ""); err == nil {
+		t.Error("Expected an unknown clock rejected")
+	}
+	if _, err := ParseTimeFormat("", "mars", ""); err == nil {
+		t.Error("Expected an unknown zone rejected")
+	}
+}
@@ -0,0 +1,165 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ViewLink describes what a list shows precisely enough to open the same view
+// again from the command line
+type ViewLink struct {
+	Contexts      []string
+	Namespace     string // Empty for all namespaces
+	ResourceType  ResourceType
+	Filter        string
+	LabelSelector string
+	SortColumn    string
+	SortAscending bool
+	Columns       []string // Empty for every column
+	Selected      string   // Name of the selected resource, if any
+}
+
+// viewLinkResourceArgs names each resource type the way the command line does
+var viewLinkResourceArgs = map[ResourceType]string{
+	ResourceTypePod:         "pods",
+	ResourceTypeDeployment:  "deployments",
+	ResourceTypeStatefulSet: "statefulsets",
+	ResourceTypeJob:         "jobs",
+	ResourceTypeCronJob:     "cronjobs",
+	ResourceTypeService:     "services",
+	ResourceTypeIngress:     "ingresses",
+	ResourceTypeGateway:     "gateways",
+	ResourceTypeHTTPRoute:   "httproutes",
+	ResourceTypeConfigMap:   "configmaps",
+	ResourceTypeSecret:      "secrets",
+
+	ResourceTypeServiceAccount:     "serviceaccounts",
+	ResourceTypeRole:               "roles",
+	ResourceTypeClusterRole:        "clusterroles",
+	ResourceTypeRoleBinding:        "rolebindings",
+	ResourceTypeClusterRoleBinding: "clusterrolebindings",
+
+	ResourceTypeNode: "nodes",
+}
+
+// ViewLink returns the link for the current view. The selection is not part
+// of the state, so callers fill in Selected themselves.
+func (s *State) ViewLink() ViewLink {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	link := ViewLink{
+		Namespace:     s.CurrentNamespace,
+		ResourceType:  s.CurrentResourceType,
+		Filter:        s.FilterString,
+		LabelSelector: s.labelSelector,
+		SortColumn:    s.SortColumn,
+		SortAscending: s.SortAscending,
+		Columns:       append([]string(nil), s.Columns...),
+	}
+	if link.Namespace == "all" {
+		link.Namespace = ""
+	}
+	if s.MultiContextMode && len(s.CurrentContexts) > 0 {
+		link.Contexts = append([]string(nil), s.CurrentContexts...)
+	} else if s.CurrentContext != "" {
+		link.Contexts = []string{s.CurrentContext}
+	}
+	return link
+}
+
+// Args returns the command-line arguments that reopen the view. Settings
+// that match the defaults are left out.
+func (l ViewLink) Args() []string {
+	var args []string
+	if len(l.Contexts) > 0 {
+		args = append(args, "--context", strings.Join(l.Contexts, ","))
+	}
+	if l.Namespace != "" {
+		args = append(args, "-n", l.Namespace)
+	} else {
+		args = append(args, "-A")
+	}
+	if resource, ok := viewLinkResourceArgs[l.ResourceType]; ok {
+		args = append(args, resource)
+	}
+	if l.LabelSelector != "" {
+		args = append(args, "-l", l.LabelSelector)
+	}
+	if filter := strings.TrimSpace(l.Filter); filter != "" {
+		args = append(args, "--filter", filter)
+	}
+	if l.SortColumn != "" && (l.SortColumn != "NAME" || !l.SortAscending) {
+		args = append(args, "--sort", FormatSort(l.SortColumn, l.SortAscending))
+	}
+	if len(l.Columns) > 0 {
+		args = append(args, "--columns", strings.Join(l.Columns, ","))
+	}
+	if l.Selected != "" {
+		args = append(args, "--select", l.Selected)
+	}
+	return args
+}
+
+// Command returns a kubewatch command line that reopens the view, quoted for
+// a POSIX shell
+func (l ViewLink) Command() string {
+	words := []string{"kubewatch"}
+	for _, arg := range l.Args() {
+		words = append(words, shellWord(arg))
+	}
+	return strings.Join(words, " ")
+}
+
+// shellWord returns s unchanged when the shell would read it as one word, and
+// quoted otherwise
+func shellWord(s string) string {
+	if s == "" {
+		return ShellQuote(s)
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("-_./:,@%+", r):
+		default:
+			return ShellQuote(s)
+		}
+	}
+	return s
+}
+
+// FormatSort writes a sort column and direction as COLUMN:asc or COLUMN:desc
+func FormatSort(column string, ascending bool) string {
+	if ascending {
+		return column + ":asc"
+	}
+	return column + ":desc"
+}
+
+// ParseSort reads a sort written by FormatSort. The direction may be left
+// off, in which case the sort is ascending.
+func ParseSort(spec string) (string, bool, error) {
+	column, direction, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	column = strings.ToUpper(strings.TrimSpace(column))
+	if column == "" {
+		return "", false, fmt.Errorf("sort %q names no column", spec)
+	}
+	switch strings.ToLower(strings.TrimSpace(direction)) {
+	case "", "asc":
+		return column, true, nil
+	case "desc":
+		return column, false, nil
+	}
+	return "", false, fmt.Errorf("sort %q: direction must be asc or desc", spec)
+}
+
+// ParseColumns reads a comma-separated list of column names
+func ParseColumns(list string) []string {
+	var columns []string
+	for _, column := range strings.Split(list, ",") {
+		if column = strings.ToUpper(strings.TrimSpace(column)); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
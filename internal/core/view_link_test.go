@@ -0,0 +1,91 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestViewLinkCommand(t *testing.T) {
+	link := ViewLink{
+		Contexts:      []string{"prod"},
+		Namespace:     "payments",
+		ResourceType:  ResourceTypePod,
+		Filter:        "status=CrashLoopBackOff",
+		LabelSelector: "app=checkout",
+		SortColumn:    "RESTARTS",
+		SortAscending: false,
+		Selected:      "checkout-7f9c",
+	}
+	want := "kubewatch --context prod -n payments pods -l 'app=checkout' --filter 'status=CrashLoopBackOff' --sort RESTARTS:desc --select checkout-7f9c"
+	if got := link.Command(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// Words the shell would split or expand are quoted, and defaults left out
+	link = ViewLink{
+		ResourceType:  ResourceTypeDeployment,
+		Filter:        "web status!=Running",
+		SortColumn:    "NAME",
+		SortAscending: true,
+		Columns:       []string{"READY", "AGE"},
+	}
+	want = "kubewatch -A deployments --filter 'web status!=Running' --columns READY,AGE"
+	if got := link.Command(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestStateViewLink(t *testing.T) {
+	state := NewState(&Config{CurrentNamespace: "all"})
+	state.SetMultiContextMode(true)
+	state.SetCurrentContexts([]string{"east", "west"})
+	state.SetResourceType(ResourceTypeService)
+	state.SetFilter("type=LoadBalancer", "public")
+	state.SetSortState("AGE", false)
+	state.SetColumns([]string{"TYPE"})
+	if err := state.SetLabelSelector("tier=edge"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := ViewLink{
+		Contexts:      []string{"east", "west"},
+		ResourceType:  ResourceTypeService,
+		Filter:        "type=LoadBalancer",
+		LabelSelector: "tier=edge",
+		SortColumn:    "AGE",
+		SortAscending: false,
+		Columns:       []string{"TYPE"},
+	}
+	if got := state.ViewLink(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		spec      string
+		column    string
+		ascending bool
+		wantErr   bool
+	}{
+		{spec: "RESTARTS:desc", column: "RESTARTS", ascending: false},
+		{spec: "age:ASC", column: "AGE", ascending: true},
+		{spec: "name", column: "NAME", ascending: true},
+		{spec: ":desc", wantErr: true},
+		{spec: "AGE:sideways", wantErr: true},
+	}
+	for _, tt := range tests {
+		column, ascending, err := ParseSort(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSort(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (column != tt.column || ascending != tt.ascending) {
+			t.Errorf("ParseSort(%q) = %s, %v; want %s, %v", tt.spec, column, ascending, tt.column, tt.ascending)
+		}
+	}
+
+	if spec := FormatSort("CPU", false); spec != "CPU:desc" {
+		t.Errorf("Expected CPU:desc, got %q", spec)
+	}
+}
@@ -0,0 +1,211 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// deleteInBackground deletes what an object owns along with it. Jobs
+// otherwise leave their pods behind, as their API orphans them by default.
+func deleteInBackground() metav1.DeleteOptions {
+	policy := metav1.DeletePropagationBackground
+	return metav1.DeleteOptions{PropagationPolicy: &policy}
+}
+
+// ListJobs returns jobs in a namespace
+func (c *Client) ListJobs(ctx context.Context, namespace string) ([]batchv1.Job, error) {
+	return c.ListJobsWithLabels(ctx, namespace, "")
+}
+
+// ListJobsWithLabels returns the jobs in a namespace matching a label
+// selector, or all of them for an empty selector
+func (c *Client) ListJobsWithLabels(ctx context.Context, namespace, labelSelector string) ([]batchv1.Job, error) {
+	items, err := listAll[batchv1.Job](ctx, metav1.ListOptions{LabelSelector: labelSelector}, c.clientset.BatchV1().Jobs(namespace).List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "jobs", namespace, "")
+	}
+	return items, nil
+}
+
+// WatchJobs watches for job changes
+func (c *Client) WatchJobs(ctx context.Context, namespace string) (watch.Interface, error) {
+	w, err := c.clientset.BatchV1().Jobs(namespace).Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, "jobs", namespace)
+}
+
+// DeleteJob deletes a job and its pods
+func (c *Client) DeleteJob(ctx context.Context, namespace, name string) error {
+	err := c.clientset.BatchV1().Jobs(namespace).Delete(ctx, name, deleteInBackground())
+	return c.wrapError(err, OpDelete, "jobs", namespace, name)
+}
+
+// ListCronJobs returns cron jobs in a namespace
+func (c *Client) ListCronJobs(ctx context.Context, namespace string) ([]batchv1.CronJob, error) {
+	return c.ListCronJobsWithLabels(ctx, namespace, "")
+}
+
+// ListCronJobsWithLabels returns the cron jobs in a namespace matching a
+// label selector, or all of them for an empty selector
+func (c *Client) ListCronJobsWithLabels(ctx context.Context, namespace, labelSelector string) ([]batchv1.CronJob, error) {
+	items, err := listAll[batchv1.CronJob](ctx, metav1.ListOptions{LabelSelector: labelSelector}, c.clientset.BatchV1().CronJobs(namespace).List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "cronjobs", namespace, "")
+	}
+	return items, nil
+}
+
+// WatchCronJobs watches for cron job changes
+func (c *Client) WatchCronJobs(ctx context.Context, namespace string) (watch.Interface, error) {
+	w, err := c.clientset.BatchV1().CronJobs(namespace).Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, "cronjobs", namespace)
+}
+
+// DeleteCronJob deletes a cron job and the jobs it started
+func (c *Client) DeleteCronJob(ctx context.Context, namespace, name string) error {
+	err := c.clientset.BatchV1().CronJobs(namespace).Delete(ctx, name, deleteInBackground())
+	return c.wrapError(err, OpDelete, "cronjobs", namespace, name)
+}
+
+// GetPodsForJob returns the pods a job's selector matches
+func (c *Client) GetPodsForJob(ctx context.Context, namespace, jobName string) ([]v1.Pod, error) {
+	job, err := c.clientset.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		return nil, c.wrapError(err, OpGet, "jobs", namespace, jobName)
+	}
+
+	pods, err := listAll[v1.Pod](ctx, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(job.Spec.Selector),
+	}, c.clientset.CoreV1().Pods(namespace).List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "pods", namespace, "")
+	}
+	return pods, nil
+}
+
+// describeJob returns detailed information about a job: how far it got and
+// the pods it ran
+func (c *Client) describeJob(ctx context.Context, name, namespace string) (string, error) {
+	job, err := c.clientset.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", c.wrapError(err, OpGet, "jobs", namespace, name)
+	}
+
+	now := time.Now()
+	var result strings.Builder
+	result.WriteString(describeDeletion(job.ObjectMeta, now))
+	result.WriteString(fmt.Sprintf("Name:         %s\n", job.Name))
+	result.WriteString(fmt.Sprintf("Namespace:    %s\n", job.Namespace))
+	for _, owner := range job.OwnerReferences {
+		if owner.Kind == "CronJob" {
+			result.WriteString(fmt.Sprintf("Cron Job:     %s\n", owner.Name))
+		}
+	}
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(job.CreationTimestamp.Time)))
+	result.WriteString(fmt.Sprintf("Completions:  %s\n", core.JobCompletions(job)))
+	if job.Status.StartTime != nil {
+		result.WriteString(fmt.Sprintf("Started:      %s\n", core.FormatTimestamp(job.Status.StartTime.Time)))
+	}
+	if job.Status.CompletionTime != nil {
+		result.WriteString(fmt.Sprintf("Completed:    %s\n", core.FormatTimestamp(job.Status.CompletionTime.Time)))
+	}
+	if duration := core.JobDuration(job, now); duration != "" {
+		result.WriteString(fmt.Sprintf("Duration:     %s\n", duration))
+	}
+	if job.Spec.BackoffLimit != nil {
+		result.WriteString(fmt.Sprintf("Backoff:      %d\n", *job.Spec.BackoffLimit))
+	}
+	result.WriteString(fmt.Sprintf("Pods:         %d active / %d succeeded / %d failed\n",
+		job.Status.Active, job.Status.Succeeded, job.Status.Failed))
+
+	if len(job.Status.Conditions) > 0 {
+		result.WriteString("\nConditions:\n")
+		for _, condition := range job.Status.Conditions {
+			result.WriteString(fmt.Sprintf("  %s: %s", condition.Type, condition.Status))
+			if condition.Reason != "" {
+				result.WriteString(fmt.Sprintf(" (%s)", condition.Reason))
+			}
+			if condition.Message != "" {
+				result.WriteString(fmt.Sprintf(" %s", condition.Message))
+			}
+			result.WriteString("\n")
+		}
+	}
+
+	describeContainers(&result, job.Spec.Template.Spec.Containers)
+
+	if len(job.Labels) > 0 {
+		result.WriteString("\nLabels:\n")
+		for k, v := range job.Labels {
+			result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
+		}
+	}
+
+	return result.String(), nil
+}
+
+// describeCronJob returns detailed information about a cron job: its
+// schedule and the jobs it is running
+func (c *Client) describeCronJob(ctx context.Context, name, namespace string) (string, error) {
+	cronJob, err := c.clientset.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", c.wrapError(err, OpGet, "cronjobs", namespace, name)
+	}
+
+	now := time.Now()
+	var result strings.Builder
+	result.WriteString(describeDeletion(cronJob.ObjectMeta, now))
+	result.WriteString(fmt.Sprintf("Name:         %s\n", cronJob.Name))
+	result.WriteString(fmt.Sprintf("Namespace:    %s\n", cronJob.Namespace))
+	result.WriteString(fmt.Sprintf("Schedule:     %s\n", cronJob.Spec.Schedule))
+	if cronJob.Spec.TimeZone != nil {
+		result.WriteString(fmt.Sprintf("Time Zone:    %s\n", *cronJob.Spec.TimeZone))
+	}
+	result.WriteString(fmt.Sprintf("Suspend:      %s\n", core.CronJobSuspend(cronJob)))
+	result.WriteString(fmt.Sprintf("Concurrency:  %s\n", cronJob.Spec.ConcurrencyPolicy))
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(cronJob.CreationTimestamp.Time)))
+	if cronJob.Status.LastScheduleTime != nil {
+		result.WriteString(fmt.Sprintf("Last Run:     %s (%s ago)\n",
+			core.FormatTimestamp(cronJob.Status.LastScheduleTime.Time), core.CronJobLastSchedule(cronJob, now)))
+	}
+	if cronJob.Status.LastSuccessfulTime != nil {
+		result.WriteString(fmt.Sprintf("Last Success: %s\n", core.FormatTimestamp(cronJob.Status.LastSuccessfulTime.Time)))
+	}
+
+	if len(cronJob.Status.Active) > 0 {
+		result.WriteString("\nActive Jobs:\n")
+		for _, job := range cronJob.Status.Active {
+			result.WriteString(fmt.Sprintf("  %s\n", job.Name))
+		}
+	}
+
+	describeContainers(&result, cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers)
+
+	if len(cronJob.Labels) > 0 {
+		result.WriteString("\nLabels:\n")
+		for k, v := range cronJob.Labels {
+			result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
+		}
+	}
+
+	return result.String(), nil
+}
+
+// describeContainers lists the containers of a pod template with their
+// images
+func describeContainers(result *strings.Builder, containers []v1.Container) {
+	if len(containers) == 0 {
+		return
+	}
+	result.WriteString("\nContainers:\n")
+	for _, container := range containers {
+		result.WriteString(fmt.Sprintf("  %s: %s\n", container.Name, container.Image))
+	}
+}
@@ -0,0 +1,93 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// TestDescribeJobs tests that jobs and cron jobs describe under either name
+// their resource type goes by
+func TestDescribeJobs(t *testing.T) {
+	suspend := true
+	started := metav1.NewTime(time.Now().Add(-90 * time.Second))
+	client := &Client{clientset: fake.NewSimpleClientset(
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "backup-28001", Namespace: "ops",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "backup"}},
+			},
+			Status: batchv1.JobStatus{StartTime: &started, Active: 1},
+		},
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ops"},
+			Spec:       batchv1.CronJobSpec{Schedule: "*/5 * * * *", Suspend: &suspend},
+		},
+	)}
+	ctx := context.Background()
+
+	out, err := client.DescribeResource(ctx, "Jobs", "backup-28001", "ops")
+	if err != nil {
+		t.Fatalf("DescribeResource failed: %v", err)
+	}
+	for _, expected := range []string{
+		"Cron Job:     backup\n",
+		"Completions:  0/1\n",
+		"Duration:     1m\n",
+		"Pods:         1 active / 0 succeeded / 0 failed\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, out)
+		}
+	}
+
+	out, err = client.DescribeResource(ctx, "cronjob", "backup", "ops")
+	if err != nil {
+		t.Fatalf("DescribeResource failed: %v", err)
+	}
+	for _, expected := range []string{"Schedule:     */5 * * * *\n", "Suspend:      True\n"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, out)
+		}
+	}
+}
+
+// TestDeleteJobTakesItsPods tests that deleting a job or cron job deletes
+// what it started too, rather than the API's default of orphaning it
+func TestDeleteJobTakesItsPods(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "ops"}},
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ops"}},
+	)
+	client := &Client{clientset: clientset}
+	ctx := context.Background()
+
+	if err := client.DeleteJob(ctx, "ops", "migrate"); err != nil {
+		t.Fatalf("DeleteJob failed: %v", err)
+	}
+	if err := client.DeleteCronJob(ctx, "ops", "backup"); err != nil {
+		t.Fatalf("DeleteCronJob failed: %v", err)
+	}
+
+	deletes := 0
+	for _, action := range clientset.Actions() {
+		deleteAction, ok := action.(k8stesting.DeleteAction)
+		if !ok {
+			continue
+		}
+		deletes++
+		policy := deleteAction.GetDeleteOptions().PropagationPolicy
+		if policy == nil || *policy != metav1.DeletePropagationBackground {
+			t.Errorf("Expected %s deleted in the background, got %v", deleteAction.GetName(), policy)
+		}
+	}
+	if deletes != 2 {
+		t.Errorf("Expected 2 deletes, got %d", deletes)
+	}
+}
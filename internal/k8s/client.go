@@ -4,20 +4,25 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
-	"path/filepath"
+	"net/http"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/HamStudy/kubewatch/internal/core"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
@@ -25,7 +30,14 @@ import (
 type Client struct {
 	clientset     kubernetes.Interface
 	metricsClient metricsclient.Interface
+	dynamicClient dynamic.Interface // Created on first use; see dynamic()
 	config        *rest.Config
+	contextName   string
+
+	// Lazily created caches shared by describe and list calls
+	cacheMu               sync.Mutex
+	serviceEndpointsCache *ServiceEndpointsCache
+	servedTypesCache      *ServedTypesCache
 }
 
 // ClientOptions contains additional options for creating a Kubernetes client
@@ -57,6 +69,19 @@ func getPathSeparator() string {
 
 // NewClientFromConfig creates a new Kubernetes client from a rest.Config
 func NewClientFromConfig(config *rest.Config) (*Client, error) {
+	client := &Client{}
+
+	// Route every request through the observer; see SetRequestObserver
+	config = rest.CopyConfig(config)
+	if NetworkRestricted() {
+		if err := restrictDialing(config); err != nil {
+			return nil, err
+		}
+	}
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &observedTransport{next: rt, client: client}
+	})
+
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create clientset: %w", err)
@@ -68,11 +93,10 @@ func NewClientFromConfig(config *rest.Config) (*Client, error) {
 		metricsClient = nil
 	}
 
-	return &Client{
-		clientset:     clientset,
-		metricsClient: metricsClient,
-		config:        config,
-	}, nil
+	client.clientset = clientset
+	client.metricsClient = metricsClient
+	client.config = config
+	return client, nil
 }
 
 // NewClient creates a new Kubernetes client
@@ -83,36 +107,8 @@ func NewClient(kubeconfig string) (*Client, error) {
 	// Try in-cluster config first
 	config, err = rest.InClusterConfig()
 	if err != nil {
-		// Fall back to kubeconfig
-		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-
-		// Handle KUBECONFIG environment variable with multiple paths
-		if kubeconfig != "" {
-			// Split by OS-specific separator to handle multiple paths
-			separator := getPathSeparator()
-			paths := strings.Split(kubeconfig, separator)
-
-			// Filter out empty paths and trim whitespace
-			var validPaths []string
-			for _, path := range paths {
-				trimmed := strings.TrimSpace(path)
-				if trimmed != "" {
-					validPaths = append(validPaths, trimmed)
-				}
-			}
-
-			if len(validPaths) > 0 {
-				loadingRules.Precedence = validPaths
-			}
-		} else {
-			// Use default kubeconfig location if KUBECONFIG is not set
-			if home, err := os.UserHomeDir(); err == nil && home != "" {
-				defaultPath := filepath.Join(home, ".kube", "config")
-				if _, err := os.Stat(defaultPath); err == nil {
-					loadingRules.Precedence = []string{defaultPath}
-				}
-			}
-		}
+		// Fall back to kubeconfig, leaving out files that cannot be parsed
+		loadingRules, _ := kubeconfigLoadingRules(kubeconfig)
 
 		// Create the client config
 		configOverrides := &clientcmd.ConfigOverrides{}
@@ -138,36 +134,8 @@ func NewClientWithOptions(kubeconfig string, opts *ClientOptions) (*Client, erro
 	// Try in-cluster config first
 	config, err = rest.InClusterConfig()
 	if err != nil {
-		// Fall back to kubeconfig
-		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-
-		// Handle KUBECONFIG environment variable with multiple paths
-		if kubeconfig != "" {
-			// Split by OS-specific separator to handle multiple paths
-			separator := getPathSeparator()
-			paths := strings.Split(kubeconfig, separator)
-
-			// Filter out empty paths and trim whitespace
-			var validPaths []string
-			for _, path := range paths {
-				trimmed := strings.TrimSpace(path)
-				if trimmed != "" {
-					validPaths = append(validPaths, trimmed)
-				}
-			}
-
-			if len(validPaths) > 0 {
-				loadingRules.Precedence = validPaths
-			}
-		} else {
-			// Use default kubeconfig location if KUBECONFIG is not set
-			if home, err := os.UserHomeDir(); err == nil && home != "" {
-				defaultPath := filepath.Join(home, ".kube", "config")
-				if _, err := os.Stat(defaultPath); err == nil {
-					loadingRules.Precedence = []string{defaultPath}
-				}
-			}
-		}
+		// Fall back to kubeconfig, leaving out files that cannot be parsed
+		loadingRules, skipped := kubeconfigLoadingRules(kubeconfig)
 
 		// Create config overrides from options
 		configOverrides := &clientcmd.ConfigOverrides{}
@@ -225,6 +193,10 @@ func NewClientWithOptions(kubeconfig string, opts *ClientOptions) (*Client, erro
 			}
 		}
 
+		if err := checkContext(loadingRules, skipped, configOverrides.CurrentContext); err != nil {
+			return nil, err
+		}
+
 		// Create the client config
 		kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 			loadingRules,
@@ -241,46 +213,121 @@ func NewClientWithOptions(kubeconfig string, opts *ClientOptions) (*Client, erro
 			// Parse and apply timeout to REST config
 			// This would need proper duration parsing
 		}
+
+		client, err := NewClientFromConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		client.contextName = contextNameOf(kubeConfig, configOverrides)
+		return client, nil
 	}
 
 	return NewClientFromConfig(config)
 }
 
+// ContextName returns the kubeconfig context the client was built for, or ""
+// when it was built from a bare REST config
+func (c *Client) ContextName() string {
+	return c.contextName
+}
+
+// contextNameOf returns the kubeconfig context a client config resolves to
+func contextNameOf(kubeConfig clientcmd.ClientConfig, overrides *clientcmd.ConfigOverrides) string {
+	if overrides != nil && overrides.CurrentContext != "" {
+		return overrides.CurrentContext
+	}
+	if raw, err := kubeConfig.RawConfig(); err == nil {
+		return raw.CurrentContext
+	}
+	return ""
+}
+
 // GetNamespaces returns all namespaces
 func (c *Client) GetNamespaces(ctx context.Context) ([]v1.Namespace, error) {
-	list, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	items, err := listAll[v1.Namespace](ctx, metav1.ListOptions{}, c.clientset.CoreV1().Namespaces().List)
 	if err != nil {
-		return nil, err
+		return nil, c.wrapError(err, OpList, "namespaces", "", "")
 	}
-	return list.Items, nil
+	return items, nil
 }
 
 // ListNamespaces returns all namespaces
 func (c *Client) ListNamespaces(ctx context.Context) ([]v1.Namespace, error) {
-	list, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	items, err := listAll[v1.Namespace](ctx, metav1.ListOptions{}, c.clientset.CoreV1().Namespaces().List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "namespaces", "", "")
+	}
+	return items, nil
+}
+
+// ListNodes returns all nodes in the cluster
+func (c *Client) ListNodes(ctx context.Context) ([]v1.Node, error) {
+	items, err := listAll[v1.Node](ctx, metav1.ListOptions{}, c.clientset.CoreV1().Nodes().List)
 	if err != nil {
-		return nil, err
+		return nil, c.wrapError(err, OpList, "nodes", "", "")
 	}
-	return list.Items, nil
+	return items, nil
+}
+
+// WatchNodes watches the nodes of the cluster
+func (c *Client) WatchNodes(ctx context.Context) (watch.Interface, error) {
+	w, err := c.clientset.CoreV1().Nodes().Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, "nodes", "")
 }
 
 // ListPods returns pods in a namespace
 func (c *Client) ListPods(ctx context.Context, namespace string) ([]v1.Pod, error) {
-	list, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	return c.ListPodsWithSelector(ctx, namespace, "")
+}
+
+// ListPodsWithSelector returns the pods in a namespace matching a field
+// selector, or every pod for an empty selector
+func (c *Client) ListPodsWithSelector(ctx context.Context, namespace, fieldSelector string) ([]v1.Pod, error) {
+	return c.ListPodsMatching(ctx, namespace, "", fieldSelector)
+}
+
+// ListPodsWithLabels returns the pods in a namespace matching a label
+// selector
+func (c *Client) ListPodsWithLabels(ctx context.Context, namespace, labelSelector string) ([]v1.Pod, error) {
+	return c.ListPodsMatching(ctx, namespace, labelSelector, "")
+}
+
+// ListPodsMatching returns the pods in a namespace matching both a label
+// selector and a field selector; an empty selector matches every pod
+func (c *Client) ListPodsMatching(ctx context.Context, namespace, labelSelector, fieldSelector string) ([]v1.Pod, error) {
+	pods, _, err := c.ListPodsUpTo(ctx, namespace, labelSelector, fieldSelector, 0)
+	return pods, err
+}
+
+// ListPodsUpTo is ListPodsMatching stopping once it has upTo pods, the
+// first ones by name in a single namespace, or listing them all for 0. It
+// also returns how many pods it left unread, -1 when the API server did not
+// say how many.
+func (c *Client) ListPodsUpTo(ctx context.Context, namespace, labelSelector, fieldSelector string, upTo int) ([]v1.Pod, int, error) {
+	opts := metav1.ListOptions{LabelSelector: labelSelector, FieldSelector: fieldSelector}
+	listed, err := listPaged[v1.Pod](ctx, opts, upTo, c.clientset.CoreV1().Pods(namespace).List)
 	if err != nil {
-		return nil, err
+		return nil, 0, c.wrapError(err, OpList, "pods", namespace, "")
 	}
-	return list.Items, nil
+	return listed.items, listed.unread, nil
 }
 
 // WatchPods watches for pod changes
 func (c *Client) WatchPods(ctx context.Context, namespace string) (watch.Interface, error) {
-	return c.clientset.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{})
+	return c.WatchPodsWithSelector(ctx, namespace, "")
+}
+
+// WatchPodsWithSelector watches for changes to the pods in a namespace
+// matching a field selector, or to every pod for an empty selector
+func (c *Client) WatchPodsWithSelector(ctx context.Context, namespace, fieldSelector string) (watch.Interface, error) {
+	w, err := c.clientset.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{FieldSelector: fieldSelector})
+	return c.watched(w, err, "pods", namespace)
 }
 
 // DeletePod deletes a pod
 func (c *Client) DeletePod(ctx context.Context, namespace, name string) error {
-	return c.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	err := c.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	return c.wrapError(err, OpDelete, "pods", namespace, name)
 }
 
 // DeletePods deletes multiple pods
@@ -305,7 +352,8 @@ func (c *Client) GetPodLogs(ctx context.Context, namespace, pod, container strin
 	}
 
 	req := c.clientset.CoreV1().Pods(namespace).GetLogs(pod, opts)
-	return req.Stream(ctx)
+	stream, err := req.Stream(ctx)
+	return stream, c.wrapError(err, OpLogs, "pods", namespace, pod)
 }
 
 // GetPodLogsWithOptions returns a stream of pod logs with more options
@@ -324,157 +372,236 @@ func (c *Client) GetPodLogsWithOptions(ctx context.Context, namespace, pod, cont
 	}
 
 	req := c.clientset.CoreV1().Pods(namespace).GetLogs(pod, opts)
-	return req.Stream(ctx)
+	stream, err := req.Stream(ctx)
+	return stream, c.wrapError(err, OpLogs, "pods", namespace, pod)
 }
 
 // ListDeployments returns deployments in a namespace
 func (c *Client) ListDeployments(ctx context.Context, namespace string) ([]appsv1.Deployment, error) {
-	list, err := c.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	return c.ListDeploymentsWithLabels(ctx, namespace, "")
+}
+
+// ListDeploymentsWithLabels returns the deployments in a namespace matching a label
+// selector, or all of them for an empty selector
+func (c *Client) ListDeploymentsWithLabels(ctx context.Context, namespace, labelSelector string) ([]appsv1.Deployment, error) {
+	items, err := listAll[appsv1.Deployment](ctx, metav1.ListOptions{LabelSelector: labelSelector}, c.clientset.AppsV1().Deployments(namespace).List)
 	if err != nil {
-		return nil, err
+		return nil, c.wrapError(err, OpList, "deployments", namespace, "")
 	}
-	return list.Items, nil
+	return items, nil
 }
 
 // WatchDeployments watches for deployment changes
 func (c *Client) WatchDeployments(ctx context.Context, namespace string) (watch.Interface, error) {
-	return c.clientset.AppsV1().Deployments(namespace).Watch(ctx, metav1.ListOptions{})
+	w, err := c.clientset.AppsV1().Deployments(namespace).Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, "deployments", namespace)
 }
 
 // DeleteDeployment deletes a deployment
 func (c *Client) DeleteDeployment(ctx context.Context, namespace, name string) error {
-	return c.clientset.AppsV1().Deployments(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	err := c.clientset.AppsV1().Deployments(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	return c.wrapError(err, OpDelete, "deployments", namespace, name)
 }
 
 // ListStatefulSets returns statefulsets in a namespace
 func (c *Client) ListStatefulSets(ctx context.Context, namespace string) ([]appsv1.StatefulSet, error) {
-	list, err := c.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	return c.ListStatefulSetsWithLabels(ctx, namespace, "")
+}
+
+// ListStatefulSetsWithLabels returns the statefulsets in a namespace matching a label
+// selector, or all of them for an empty selector
+func (c *Client) ListStatefulSetsWithLabels(ctx context.Context, namespace, labelSelector string) ([]appsv1.StatefulSet, error) {
+	items, err := listAll[appsv1.StatefulSet](ctx, metav1.ListOptions{LabelSelector: labelSelector}, c.clientset.AppsV1().StatefulSets(namespace).List)
 	if err != nil {
-		return nil, err
+		return nil, c.wrapError(err, OpList, "statefulsets", namespace, "")
 	}
-	return list.Items, nil
+	return items, nil
 }
 
 // WatchStatefulSets watches for statefulset changes
 func (c *Client) WatchStatefulSets(ctx context.Context, namespace string) (watch.Interface, error) {
-	return c.clientset.AppsV1().StatefulSets(namespace).Watch(ctx, metav1.ListOptions{})
+	w, err := c.clientset.AppsV1().StatefulSets(namespace).Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, "statefulsets", namespace)
 }
 
 // DeleteStatefulSet deletes a statefulset
 func (c *Client) DeleteStatefulSet(ctx context.Context, namespace, name string) error {
-	return c.clientset.AppsV1().StatefulSets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	err := c.clientset.AppsV1().StatefulSets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	return c.wrapError(err, OpDelete, "statefulsets", namespace, name)
 }
 
 // ListServices returns services in a namespace
 func (c *Client) ListServices(ctx context.Context, namespace string) ([]v1.Service, error) {
-	list, err := c.clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	return c.ListServicesWithLabels(ctx, namespace, "")
+}
+
+// ListServicesWithLabels returns the services in a namespace matching a label
+// selector, or all of them for an empty selector
+func (c *Client) ListServicesWithLabels(ctx context.Context, namespace, labelSelector string) ([]v1.Service, error) {
+	items, err := listAll[v1.Service](ctx, metav1.ListOptions{LabelSelector: labelSelector}, c.clientset.CoreV1().Services(namespace).List)
 	if err != nil {
-		return nil, err
+		return nil, c.wrapError(err, OpList, "services", namespace, "")
 	}
-	return list.Items, nil
+	return items, nil
 }
 
 // WatchServices watches for service changes
 func (c *Client) WatchServices(ctx context.Context, namespace string) (watch.Interface, error) {
-	return c.clientset.CoreV1().Services(namespace).Watch(ctx, metav1.ListOptions{})
+	w, err := c.clientset.CoreV1().Services(namespace).Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, "services", namespace)
 }
 
 // DeleteService deletes a service
 func (c *Client) DeleteService(ctx context.Context, namespace, name string) error {
-	return c.clientset.CoreV1().Services(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	err := c.clientset.CoreV1().Services(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	return c.wrapError(err, OpDelete, "services", namespace, name)
 }
 
 // ListIngresses returns ingresses in a namespace
 func (c *Client) ListIngresses(ctx context.Context, namespace string) ([]networkingv1.Ingress, error) {
-	list, err := c.clientset.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	items, err := listAll[networkingv1.Ingress](ctx, metav1.ListOptions{}, c.clientset.NetworkingV1().Ingresses(namespace).List)
 	if err != nil {
-		return nil, err
+		return nil, c.wrapError(err, OpList, "ingresses", namespace, "")
 	}
-	return list.Items, nil
+	return items, nil
 }
 
 // WatchIngresses watches for ingress changes
 func (c *Client) WatchIngresses(ctx context.Context, namespace string) (watch.Interface, error) {
-	return c.clientset.NetworkingV1().Ingresses(namespace).Watch(ctx, metav1.ListOptions{})
+	w, err := c.clientset.NetworkingV1().Ingresses(namespace).Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, "ingresses", namespace)
 }
 
 // DeleteIngress deletes an ingress
 func (c *Client) DeleteIngress(ctx context.Context, namespace, name string) error {
-	return c.clientset.NetworkingV1().Ingresses(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	err := c.clientset.NetworkingV1().Ingresses(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	return c.wrapError(err, OpDelete, "ingresses", namespace, name)
 }
 
 // ListConfigMaps returns configmaps in a namespace
 func (c *Client) ListConfigMaps(ctx context.Context, namespace string) ([]v1.ConfigMap, error) {
-	list, err := c.clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	items, err := listAll[v1.ConfigMap](ctx, metav1.ListOptions{}, c.clientset.CoreV1().ConfigMaps(namespace).List)
 	if err != nil {
-		return nil, err
+		return nil, c.wrapError(err, OpList, "configmaps", namespace, "")
 	}
-	return list.Items, nil
+	return items, nil
 }
 
 // WatchConfigMaps watches for configmap changes
 func (c *Client) WatchConfigMaps(ctx context.Context, namespace string) (watch.Interface, error) {
-	return c.clientset.CoreV1().ConfigMaps(namespace).Watch(ctx, metav1.ListOptions{})
+	w, err := c.clientset.CoreV1().ConfigMaps(namespace).Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, "configmaps", namespace)
 }
 
 // DeleteConfigMap deletes a configmap
 func (c *Client) DeleteConfigMap(ctx context.Context, namespace, name string) error {
-	return c.clientset.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	err := c.clientset.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	return c.wrapError(err, OpDelete, "configmaps", namespace, name)
 }
 
 // ListSecrets returns secrets in a namespace
 func (c *Client) ListSecrets(ctx context.Context, namespace string) ([]v1.Secret, error) {
-	list, err := c.clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	items, err := listAll[v1.Secret](ctx, metav1.ListOptions{}, c.clientset.CoreV1().Secrets(namespace).List)
 	if err != nil {
-		return nil, err
+		return nil, c.wrapError(err, OpList, "secrets", namespace, "")
 	}
-	return list.Items, nil
+	return items, nil
 }
 
 // WatchSecrets watches for secret changes
 func (c *Client) WatchSecrets(ctx context.Context, namespace string) (watch.Interface, error) {
-	return c.clientset.CoreV1().Secrets(namespace).Watch(ctx, metav1.ListOptions{})
+	w, err := c.clientset.CoreV1().Secrets(namespace).Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, "secrets", namespace)
 }
 
 // DeleteSecret deletes a secret
 func (c *Client) DeleteSecret(ctx context.Context, namespace, name string) error {
-	return c.clientset.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	err := c.clientset.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	return c.wrapError(err, OpDelete, "secrets", namespace, name)
 }
 
 // GetPodsForDeployment returns all pods for a deployment
 func (c *Client) GetPodsForDeployment(ctx context.Context, namespace, deploymentName string) ([]v1.Pod, error) {
 	deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(ctx, deploymentName, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return nil, c.wrapError(err, OpGet, "deployments", namespace, deploymentName)
 	}
 
 	labelSelector := metav1.FormatLabelSelector(deployment.Spec.Selector)
-	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+	pods, err := listAll[v1.Pod](ctx, metav1.ListOptions{
 		LabelSelector: labelSelector,
-	})
+	}, c.clientset.CoreV1().Pods(namespace).List)
 	if err != nil {
-		return nil, err
+		return nil, c.wrapError(err, OpList, "pods", namespace, "")
 	}
 
-	return pods.Items, nil
+	return pods, nil
 }
 
 // GetPodsForStatefulSet returns pods belonging to a statefulset
 func (c *Client) GetPodsForStatefulSet(ctx context.Context, namespace, statefulSetName string) ([]v1.Pod, error) {
 	statefulSet, err := c.clientset.AppsV1().StatefulSets(namespace).Get(ctx, statefulSetName, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return nil, c.wrapError(err, OpGet, "statefulsets", namespace, statefulSetName)
 	}
 
 	labelSelector := metav1.FormatLabelSelector(statefulSet.Spec.Selector)
-	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+	pods, err := listAll[v1.Pod](ctx, metav1.ListOptions{
 		LabelSelector: labelSelector,
-	})
+	}, c.clientset.CoreV1().Pods(namespace).List)
 	if err != nil {
-		return nil, err
+		return nil, c.wrapError(err, OpList, "pods", namespace, "")
 	}
 
-	return pods.Items, nil
+	return pods, nil
+}
+
+// GetPodsForService returns the pods a service's selector matches. A
+// service without a selector, such as one with manual endpoints, has none.
+func (c *Client) GetPodsForService(ctx context.Context, namespace, serviceName string) ([]v1.Pod, error) {
+	service, err := c.clientset.CoreV1().Services(namespace).Get(ctx, serviceName, metav1.GetOptions{})
+	if err != nil {
+		return nil, c.wrapError(err, OpGet, "services", namespace, serviceName)
+	}
+	if len(service.Spec.Selector) == 0 {
+		return nil, fmt.Errorf("service %s has no selector, so no pods to show logs for", serviceName)
+	}
+
+	pods, err := listAll[v1.Pod](ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+	}, c.clientset.CoreV1().Pods(namespace).List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "pods", namespace, "")
+	}
+
+	return pods, nil
+}
+
+// GetSiblingPods returns the pods sharing the named pod's controller (e.g. its
+// ReplicaSet), or just the pod itself when it has no controller
+func (c *Client) GetSiblingPods(ctx context.Context, namespace, podName string) ([]v1.Pod, error) {
+	pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, c.wrapError(err, OpGet, "pods", namespace, podName)
+	}
+
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return []v1.Pod{*pod}, nil
+	}
+
+	pods, err := listAll[v1.Pod](ctx, metav1.ListOptions{}, c.clientset.CoreV1().Pods(namespace).List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "pods", namespace, "")
+	}
+
+	var siblings []v1.Pod
+	for _, p := range pods {
+		if ref := metav1.GetControllerOf(&p); ref != nil && ref.UID == owner.UID {
+			siblings = append(siblings, p)
+		}
+	}
+	return siblings, nil
 }
 
 // PodMetrics represents CPU and memory metrics for a pod
@@ -483,6 +610,35 @@ type PodMetrics struct {
 	Namespace string
 	CPU       string // in millicores (e.g., "100m")
 	Memory    string // in bytes (e.g., "128Mi")
+
+	// Containers holds each container's share of the totals, in the order
+	// the metrics API reported them
+	Containers []ContainerMetrics
+}
+
+// ContainerMetrics represents CPU and memory usage of one container in a pod
+type ContainerMetrics struct {
+	Name        string
+	CPU         string // Formatted like PodMetrics.CPU
+	Memory      string // Formatted like PodMetrics.Memory
+	MilliCPU    int64
+	MemoryBytes int64
+}
+
+// PodKey identifies a pod within a cluster
+type PodKey struct {
+	Namespace string
+	Name      string
+}
+
+// PodMetricsSet holds pod metrics keyed by namespace and name, so pods with
+// the same name in different namespaces keep their own metrics
+type PodMetricsSet map[PodKey]*PodMetrics
+
+// Get returns the metrics for a pod
+func (s PodMetricsSet) Get(namespace, name string) (*PodMetrics, bool) {
+	m, ok := s[PodKey{Namespace: namespace, Name: name}]
+	return m, ok
 }
 
 // formatCPU formats CPU value from millicores to a readable string
@@ -537,8 +693,9 @@ const (
 	Gi = 1024 * Mi
 )
 
-// GetPodMetrics returns metrics for pods in a namespace
-func (c *Client) GetPodMetrics(ctx context.Context, namespace string) (map[string]*PodMetrics, error) {
+// GetPodMetrics returns metrics for pods in a namespace, or in all namespaces
+// when namespace is empty
+func (c *Client) GetPodMetrics(ctx context.Context, namespace string) (PodMetricsSet, error) {
 	if c.metricsClient == nil {
 		return nil, fmt.Errorf("metrics API not available")
 	}
@@ -548,36 +705,47 @@ func (c *Client) GetPodMetrics(ctx context.Context, namespace string) (map[strin
 		return nil, fmt.Errorf("failed to get pod metrics: %w", err)
 	}
 
-	result := make(map[string]*PodMetrics)
-	for _, m := range metrics.Items {
-		var totalCPU int64
-		var totalMemory int64
-
-		// Sum up container metrics
-		for _, container := range m.Containers {
-			if cpuQuantity, ok := container.Usage[v1.ResourceCPU]; ok {
-				// CPU is in nanocores, convert to millicores
-				totalCPU += cpuQuantity.MilliValue()
-			}
-			if memQuantity, ok := container.Usage[v1.ResourceMemory]; ok {
-				totalMemory += memQuantity.Value()
-			}
-		}
-
-		// Format CPU with appropriate precision
-		cpu := formatCPU(totalCPU)
-
-		// Format memory in human-readable format
-		memory := formatMemory(totalMemory)
+	result := make(PodMetricsSet)
+	for i := range metrics.Items {
+		m := podMetricsFrom(&metrics.Items[i])
+		result[PodKey{Namespace: m.Namespace, Name: m.Name}] = m
+	}
+	return result, nil
+}
 
-		result[m.Name] = &PodMetrics{
-			Name:      m.Name,
-			Namespace: m.Namespace,
-			CPU:       cpu,
-			Memory:    memory,
+// podMetricsFrom sums the usage of a pod's containers, keeping each
+// container's own usage alongside the totals
+func podMetricsFrom(m *metricsv1beta1.PodMetrics) *PodMetrics {
+	var totalCPU int64
+	var totalMemory int64
+	containers := make([]ContainerMetrics, 0, len(m.Containers))
+	for _, container := range m.Containers {
+		var cpu, memory int64
+		if cpuQuantity, ok := container.Usage[v1.ResourceCPU]; ok {
+			// CPU is in nanocores, convert to millicores
+			cpu = cpuQuantity.MilliValue()
+		}
+		if memQuantity, ok := container.Usage[v1.ResourceMemory]; ok {
+			memory = memQuantity.Value()
 		}
+		totalCPU += cpu
+		totalMemory += memory
+		containers = append(containers, ContainerMetrics{
+			Name:        container.Name,
+			CPU:         formatCPU(cpu),
+			Memory:      formatMemory(memory),
+			MilliCPU:    cpu,
+			MemoryBytes: memory,
+		})
+	}
+
+	return &PodMetrics{
+		Name:       m.Name,
+		Namespace:  m.Namespace,
+		CPU:        formatCPU(totalCPU),
+		Memory:     formatMemory(totalMemory),
+		Containers: containers,
 	}
-	return result, nil
 }
 
 // GetNodeMetrics returns metrics for nodes
@@ -632,6 +800,34 @@ func (c *Client) DescribeResource(ctx context.Context, resourceType interface{},
 			return c.describeDeployment(ctx, name, namespace)
 		case "service", "services":
 			return c.describeService(ctx, name, namespace)
+		case "statefulset", "statefulsets":
+			return c.describeStatefulSet(ctx, name, namespace)
+		case "ingress", "ingresses":
+			return c.describeIngress(ctx, name, namespace)
+		case "configmap", "configmaps":
+			return c.describeConfigMap(ctx, name, namespace)
+		case "secret", "secrets":
+			return c.describeSecret(ctx, name, namespace)
+		case "gateway", "gateways":
+			return c.describeGateway(ctx, name, namespace)
+		case "httproute", "httproutes":
+			return c.describeHTTPRoute(ctx, name, namespace)
+		case "serviceaccount", "serviceaccounts":
+			return c.describeServiceAccount(ctx, name, namespace)
+		case "role", "roles":
+			return c.describeRole(ctx, name, namespace, false)
+		case "clusterrole", "clusterroles":
+			return c.describeRole(ctx, name, "", true)
+		case "rolebinding", "rolebindings":
+			return c.describeRoleBinding(ctx, name, namespace, false)
+		case "clusterrolebinding", "clusterrolebindings":
+			return c.describeRoleBinding(ctx, name, "", true)
+		case "node", "nodes":
+			return c.describeNode(ctx, name)
+		case "job", "jobs":
+			return c.describeJob(ctx, name, namespace)
+		case "cronjob", "cronjobs":
+			return c.describeCronJob(ctx, name, namespace)
 		default:
 			return "", fmt.Errorf("unsupported resource type: %s", rt)
 		}
@@ -645,26 +841,66 @@ func (c *Client) DescribeResource(ctx context.Context, resourceType interface{},
 			return c.describeDeployment(ctx, name, namespace)
 		case "service", "services":
 			return c.describeService(ctx, name, namespace)
+		case "statefulset", "statefulsets":
+			return c.describeStatefulSet(ctx, name, namespace)
+		case "ingress", "ingresses":
+			return c.describeIngress(ctx, name, namespace)
+		case "configmap", "configmaps":
+			return c.describeConfigMap(ctx, name, namespace)
+		case "secret", "secrets":
+			return c.describeSecret(ctx, name, namespace)
+		case "gateway", "gateways":
+			return c.describeGateway(ctx, name, namespace)
+		case "httproute", "httproutes":
+			return c.describeHTTPRoute(ctx, name, namespace)
+		case "serviceaccount", "serviceaccounts":
+			return c.describeServiceAccount(ctx, name, namespace)
+		case "role", "roles":
+			return c.describeRole(ctx, name, namespace, false)
+		case "clusterrole", "clusterroles":
+			return c.describeRole(ctx, name, "", true)
+		case "rolebinding", "rolebindings":
+			return c.describeRoleBinding(ctx, name, namespace, false)
+		case "clusterrolebinding", "clusterrolebindings":
+			return c.describeRoleBinding(ctx, name, "", true)
+		case "node", "nodes":
+			return c.describeNode(ctx, name)
+		case "job", "jobs":
+			return c.describeJob(ctx, name, namespace)
+		case "cronjob", "cronjobs":
+			return c.describeCronJob(ctx, name, namespace)
 		default:
 			return "", fmt.Errorf("unsupported resource type: %v", resourceType)
 		}
 	}
 }
 
+// GetObject fetches one resource of kind as the typed object the cluster
+// returns, for a describe template to render
+func (c *Client) GetObject(ctx context.Context, kind core.ResourceType, namespace, name string) (metav1.Object, error) {
+	resource := strings.ToLower(string(kind))
+	obj, err := c.getObject(ctx, resource, namespace, name)
+	if err != nil {
+		return nil, c.wrapError(err, OpGet, resource, namespace, name)
+	}
+	return obj, nil
+}
+
 // describePod returns detailed information about a pod
 func (c *Client) describePod(ctx context.Context, name, namespace string) (string, error) {
 	pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return "", fmt.Errorf("failed to get pod: %w", err)
+		return "", c.wrapError(err, OpGet, "pods", namespace, name)
 	}
 
 	var result strings.Builder
+	result.WriteString(describeDeletion(pod.ObjectMeta, time.Now()))
 	result.WriteString(fmt.Sprintf("Name:         %s\n", pod.Name))
 	result.WriteString(fmt.Sprintf("Namespace:    %s\n", pod.Namespace))
 	result.WriteString(fmt.Sprintf("Node:         %s\n", pod.Spec.NodeName))
 	result.WriteString(fmt.Sprintf("Status:       %s\n", pod.Status.Phase))
 	result.WriteString(fmt.Sprintf("IP:           %s\n", pod.Status.PodIP))
-	result.WriteString(fmt.Sprintf("Created:      %s\n", pod.CreationTimestamp.Format(time.RFC3339)))
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(pod.CreationTimestamp.Time)))
 
 	if len(pod.Labels) > 0 {
 		result.WriteString("\nLabels:\n")
@@ -694,7 +930,7 @@ func (c *Client) describePod(ctx context.Context, name, namespace string) (strin
 			result.WriteString(fmt.Sprintf("    Ready:          %t\n", status.Ready))
 			result.WriteString(fmt.Sprintf("    Restart Count:  %d\n", status.RestartCount))
 			if status.State.Running != nil {
-				result.WriteString(fmt.Sprintf("    State:          Running (started %s)\n", status.State.Running.StartedAt.Format(time.RFC3339)))
+				result.WriteString(fmt.Sprintf("    State:          Running (started %s)\n", core.FormatTimestamp(status.State.Running.StartedAt.Time)))
 			} else if status.State.Waiting != nil {
 				result.WriteString(fmt.Sprintf("    State:          Waiting (%s)\n", status.State.Waiting.Reason))
 			} else if status.State.Terminated != nil {
@@ -703,6 +939,11 @@ func (c *Client) describePod(ctx context.Context, name, namespace string) (strin
 		}
 	}
 
+	// Which services route to this pod, and whether it is in their endpoints
+	memberships, err := c.GetPodServiceMemberships(ctx, pod)
+	result.WriteString(formatPodNetworking(pod, memberships, err))
+	result.WriteString(describeSecurity(&pod.Spec))
+
 	return result.String(), nil
 }
 
@@ -710,13 +951,14 @@ func (c *Client) describePod(ctx context.Context, name, namespace string) (strin
 func (c *Client) describeDeployment(ctx context.Context, name, namespace string) (string, error) {
 	deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return "", fmt.Errorf("failed to get deployment: %w", err)
+		return "", c.wrapError(err, OpGet, "deployments", namespace, name)
 	}
 
 	var result strings.Builder
+	result.WriteString(describeDeletion(deployment.ObjectMeta, time.Now()))
 	result.WriteString(fmt.Sprintf("Name:         %s\n", deployment.Name))
 	result.WriteString(fmt.Sprintf("Namespace:    %s\n", deployment.Namespace))
-	result.WriteString(fmt.Sprintf("Created:      %s\n", deployment.CreationTimestamp.Format(time.RFC3339)))
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(deployment.CreationTimestamp.Time)))
 
 	if deployment.Spec.Replicas != nil {
 		result.WriteString(fmt.Sprintf("Replicas:     %d desired | %d updated | %d total | %d available | %d unavailable\n",
@@ -744,6 +986,7 @@ func (c *Client) describeDeployment(ctx context.Context, name, namespace string)
 			result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
 		}
 	}
+	result.WriteString(describeSecurity(&deployment.Spec.Template.Spec))
 
 	return result.String(), nil
 }
@@ -752,15 +995,16 @@ func (c *Client) describeDeployment(ctx context.Context, name, namespace string)
 func (c *Client) describeService(ctx context.Context, name, namespace string) (string, error) {
 	service, err := c.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return "", fmt.Errorf("failed to get service: %w", err)
+		return "", c.wrapError(err, OpGet, "services", namespace, name)
 	}
 
 	var result strings.Builder
+	result.WriteString(describeDeletion(service.ObjectMeta, time.Now()))
 	result.WriteString(fmt.Sprintf("Name:         %s\n", service.Name))
 	result.WriteString(fmt.Sprintf("Namespace:    %s\n", service.Namespace))
 	result.WriteString(fmt.Sprintf("Type:         %s\n", service.Spec.Type))
 	result.WriteString(fmt.Sprintf("Cluster IP:   %s\n", service.Spec.ClusterIP))
-	result.WriteString(fmt.Sprintf("Created:      %s\n", service.CreationTimestamp.Format(time.RFC3339)))
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(service.CreationTimestamp.Time)))
 
 	if len(service.Spec.Ports) > 0 {
 		result.WriteString("\nPorts:\n")
@@ -788,3 +1032,274 @@ func (c *Client) describeService(ctx context.Context, name, namespace string) (s
 
 	return result.String(), nil
 }
+
+// describeStatefulSet returns detailed information about a stateful set
+func (c *Client) describeStatefulSet(ctx context.Context, name, namespace string) (string, error) {
+	statefulSet, err := c.clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", c.wrapError(err, OpGet, "statefulsets", namespace, name)
+	}
+
+	var result strings.Builder
+	result.WriteString(describeDeletion(statefulSet.ObjectMeta, time.Now()))
+	result.WriteString(fmt.Sprintf("Name:         %s\n", statefulSet.Name))
+	result.WriteString(fmt.Sprintf("Namespace:    %s\n", statefulSet.Namespace))
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(statefulSet.CreationTimestamp.Time)))
+	if statefulSet.Spec.Replicas != nil {
+		result.WriteString(fmt.Sprintf("Replicas:     %d desired | %d current | %d updated | %d ready\n",
+			*statefulSet.Spec.Replicas,
+			statefulSet.Status.CurrentReplicas,
+			statefulSet.Status.UpdatedReplicas,
+			statefulSet.Status.ReadyReplicas))
+	}
+	if statefulSet.Spec.ServiceName != "" {
+		result.WriteString(fmt.Sprintf("Service:      %s\n", statefulSet.Spec.ServiceName))
+	}
+	if statefulSet.Spec.UpdateStrategy.Type != "" {
+		result.WriteString(fmt.Sprintf("Strategy:     %s\n", statefulSet.Spec.UpdateStrategy.Type))
+	}
+	if statefulSet.Spec.PodManagementPolicy != "" {
+		result.WriteString(fmt.Sprintf("Pod Policy:   %s\n", statefulSet.Spec.PodManagementPolicy))
+	}
+
+	if statefulSet.Spec.Selector != nil && len(statefulSet.Spec.Selector.MatchLabels) > 0 {
+		result.WriteString("Selector:\n")
+		for k, v := range statefulSet.Spec.Selector.MatchLabels {
+			result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
+		}
+	}
+
+	describeContainers(&result, statefulSet.Spec.Template.Spec.Containers)
+
+	if len(statefulSet.Spec.VolumeClaimTemplates) > 0 {
+		result.WriteString("\nVolume Claims:\n")
+		for _, claim := range statefulSet.Spec.VolumeClaimTemplates {
+			storage := claim.Spec.Resources.Requests[v1.ResourceStorage]
+			result.WriteString(fmt.Sprintf("  %s: %s\n", claim.Name, storage.String()))
+		}
+	}
+
+	if len(statefulSet.Labels) > 0 {
+		result.WriteString("\nLabels:\n")
+		for k, v := range statefulSet.Labels {
+			result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
+		}
+	}
+	result.WriteString(describeSecurity(&statefulSet.Spec.Template.Spec))
+
+	return result.String(), nil
+}
+
+// describeIngress returns detailed information about an ingress: its
+// address and the backends each host and path route to
+func (c *Client) describeIngress(ctx context.Context, name, namespace string) (string, error) {
+	ingress, err := c.clientset.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", c.wrapError(err, OpGet, "ingresses", namespace, name)
+	}
+
+	var result strings.Builder
+	result.WriteString(describeDeletion(ingress.ObjectMeta, time.Now()))
+	result.WriteString(fmt.Sprintf("Name:         %s\n", ingress.Name))
+	result.WriteString(fmt.Sprintf("Namespace:    %s\n", ingress.Namespace))
+	if ingress.Spec.IngressClassName != nil {
+		result.WriteString(fmt.Sprintf("Class:        %s\n", *ingress.Spec.IngressClassName))
+	}
+	var addresses []string
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			addresses = append(addresses, lb.IP)
+		} else if lb.Hostname != "" {
+			addresses = append(addresses, lb.Hostname)
+		}
+	}
+	result.WriteString(fmt.Sprintf("Address:      %s\n", strings.Join(addresses, ",")))
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(ingress.CreationTimestamp.Time)))
+
+	if ingress.Spec.DefaultBackend != nil {
+		result.WriteString(fmt.Sprintf("Default:      %s\n", ingressBackend(ingress.Spec.DefaultBackend)))
+	}
+
+	if len(ingress.Spec.TLS) > 0 {
+		result.WriteString("\nTLS:\n")
+		for _, tls := range ingress.Spec.TLS {
+			result.WriteString(fmt.Sprintf("  %s terminates %s\n", tls.SecretName, strings.Join(tls.Hosts, ",")))
+		}
+	}
+
+	if len(ingress.Spec.Rules) > 0 {
+		result.WriteString("\nRules:\n")
+		for _, rule := range ingress.Spec.Rules {
+			host := rule.Host
+			if host == "" {
+				host = "*"
+			}
+			result.WriteString(fmt.Sprintf("  %s\n", host))
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				result.WriteString(fmt.Sprintf("    %s -> %s\n", path.Path, ingressBackend(&path.Backend)))
+			}
+		}
+	}
+
+	if len(ingress.Labels) > 0 {
+		result.WriteString("\nLabels:\n")
+		for k, v := range ingress.Labels {
+			result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
+		}
+	}
+
+	return result.String(), nil
+}
+
+// ingressBackend names where an ingress sends traffic, e.g. "web:80"
+func ingressBackend(backend *networkingv1.IngressBackend) string {
+	switch {
+	case backend.Service != nil && backend.Service.Port.Name != "":
+		return backend.Service.Name + ":" + backend.Service.Port.Name
+	case backend.Service != nil:
+		return fmt.Sprintf("%s:%d", backend.Service.Name, backend.Service.Port.Number)
+	case backend.Resource != nil:
+		return backend.Resource.Kind + "/" + backend.Resource.Name
+	}
+	return "<none>"
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// describeConfigMap returns detailed information about a config map,
+// with its data
+func (c *Client) describeConfigMap(ctx context.Context, name, namespace string) (string, error) {
+	configMap, err := c.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", c.wrapError(err, OpGet, "configmaps", namespace, name)
+	}
+
+	var result strings.Builder
+	result.WriteString(describeDeletion(configMap.ObjectMeta, time.Now()))
+	result.WriteString(fmt.Sprintf("Name:         %s\n", configMap.Name))
+	result.WriteString(fmt.Sprintf("Namespace:    %s\n", configMap.Namespace))
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(configMap.CreationTimestamp.Time)))
+
+	if len(configMap.Labels) > 0 {
+		result.WriteString("\nLabels:\n")
+		for k, v := range configMap.Labels {
+			result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
+		}
+	}
+
+	result.WriteString("\nData:\n")
+	if len(configMap.Data) == 0 && len(configMap.BinaryData) == 0 {
+		result.WriteString("  <none>\n")
+	}
+	for _, key := range sortedKeys(configMap.Data) {
+		result.WriteString(fmt.Sprintf("  %s:\n", key))
+		for _, line := range strings.Split(strings.TrimRight(configMap.Data[key], "\n"), "\n") {
+			result.WriteString("    " + line + "\n")
+		}
+	}
+	for _, key := range sortedKeys(configMap.BinaryData) {
+		result.WriteString(fmt.Sprintf("  %s: %d bytes (binary)\n", key, len(configMap.BinaryData[key])))
+	}
+
+	return result.String(), nil
+}
+
+// describeSecret returns detailed information about a secret. Like kubectl
+// describe, it gives the size of each value, never the value.
+func (c *Client) describeSecret(ctx context.Context, name, namespace string) (string, error) {
+	secret, err := c.clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", c.wrapError(err, OpGet, "secrets", namespace, name)
+	}
+
+	var result strings.Builder
+	result.WriteString(describeDeletion(secret.ObjectMeta, time.Now()))
+	result.WriteString(fmt.Sprintf("Name:         %s\n", secret.Name))
+	result.WriteString(fmt.Sprintf("Namespace:    %s\n", secret.Namespace))
+	result.WriteString(fmt.Sprintf("Type:         %s\n", secret.Type))
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(secret.CreationTimestamp.Time)))
+
+	if len(secret.Labels) > 0 {
+		result.WriteString("\nLabels:\n")
+		for k, v := range secret.Labels {
+			result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
+		}
+	}
+
+	result.WriteString("\nData:\n")
+	if len(secret.Data) == 0 {
+		result.WriteString("  <none>\n")
+	}
+	for _, key := range sortedKeys(secret.Data) {
+		result.WriteString(fmt.Sprintf("  %s: %d bytes\n", key, len(secret.Data[key])))
+	}
+
+	return result.String(), nil
+}
+
+// describeNode returns detailed information about a node: its state,
+// addresses, capacity and conditions
+func (c *Client) describeNode(ctx context.Context, name string) (string, error) {
+	node, err := c.GetNode(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	result.WriteString(describeDeletion(node.ObjectMeta, time.Now()))
+	result.WriteString(fmt.Sprintf("Name:         %s\n", node.Name))
+	result.WriteString(fmt.Sprintf("Status:       %s\n", core.NodeStatus(node)))
+	result.WriteString(fmt.Sprintf("Roles:        %s\n", core.NodeRoles(node)))
+	result.WriteString(fmt.Sprintf("Version:      %s\n", node.Status.NodeInfo.KubeletVersion))
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(node.CreationTimestamp.Time)))
+
+	if len(node.Status.Addresses) > 0 {
+		result.WriteString("\nAddresses:\n")
+		for _, address := range node.Status.Addresses {
+			result.WriteString(fmt.Sprintf("  %s: %s\n", address.Type, address.Address))
+		}
+	}
+
+	result.WriteString("\nCapacity:\n")
+	result.WriteString(fmt.Sprintf("  cpu:     %s (allocatable %s)\n", node.Status.Capacity.Cpu(), node.Status.Allocatable.Cpu()))
+	result.WriteString(fmt.Sprintf("  memory:  %s (allocatable %s)\n", node.Status.Capacity.Memory(), node.Status.Allocatable.Memory()))
+	result.WriteString(fmt.Sprintf("  pods:    %s (allocatable %s)\n", node.Status.Capacity.Pods(), node.Status.Allocatable.Pods()))
+
+	if len(node.Status.Conditions) > 0 {
+		result.WriteString("\nConditions:\n")
+		for _, condition := range node.Status.Conditions {
+			result.WriteString(fmt.Sprintf("  %s: %s", condition.Type, condition.Status))
+			if condition.Reason != "" {
+				result.WriteString(fmt.Sprintf(" (%s)", condition.Reason))
+			}
+			result.WriteString("\n")
+		}
+	}
+
+	if len(node.Spec.Taints) > 0 {
+		result.WriteString("\nTaints:\n")
+		for _, taint := range node.Spec.Taints {
+			result.WriteString(fmt.Sprintf("  %s\n", taint.ToString()))
+		}
+	}
+
+	if len(node.Labels) > 0 {
+		result.WriteString("\nLabels:\n")
+		for k, v := range node.Labels {
+			result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
+		}
+	}
+
+	return result.String(), nil
+}
@@ -18,9 +18,11 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
 	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
 	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
@@ -815,6 +817,65 @@ func TestClientServiceOperations(t *testing.T) {
 	}
 }
 
+func TestClientListWithLabels(t *testing.T) {
+	frontend := map[string]string{"app": "frontend"}
+	backend := map[string]string{"app": "backend"}
+	fakeClient := fake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: frontend}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "default", Labels: backend}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: frontend}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", Labels: backend}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Labels: backend}},
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: frontend}},
+	)
+	client := &Client{clientset: fakeClient}
+	ctx := context.Background()
+
+	pods, err := client.ListPodsMatching(ctx, "default", "app=frontend", "")
+	if err != nil || len(pods) != 1 || pods[0].Name != "web-1" {
+		t.Errorf("Expected only web-1 listed, got %d pods, %v", len(pods), err)
+	}
+	deployments, err := client.ListDeploymentsWithLabels(ctx, "default", "app!=frontend")
+	if err != nil || len(deployments) != 1 || deployments[0].Name != "api" {
+		t.Errorf("Expected only api listed, got %d deployments, %v", len(deployments), err)
+	}
+	statefulSets, err := client.ListStatefulSetsWithLabels(ctx, "default", "app=frontend")
+	if err != nil || len(statefulSets) != 0 {
+		t.Errorf("Expected no statefulsets listed, got %d, %v", len(statefulSets), err)
+	}
+	services, err := client.ListServicesWithLabels(ctx, "default", "app in (frontend, backend)")
+	if err != nil || len(services) != 1 {
+		t.Errorf("Expected the web service listed, got %d, %v", len(services), err)
+	}
+}
+
+func TestClientPodsWithFieldSelector(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset()
+	client := &Client{clientset: fakeClient}
+	ctx := context.Background()
+
+	if _, err := client.ListPodsMatching(ctx, "default", "", "spec.nodeName=worker-3"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	w, err := client.WatchPodsWithSelector(ctx, "default", "spec.nodeName=worker-3")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	w.Stop()
+
+	// The list and the watch ask the API server for the same pods
+	actions := fakeClient.Actions()
+	if len(actions) != 2 {
+		t.Fatalf("Expected a list and a watch, got %v", actions)
+	}
+	if fields := actions[0].(k8stesting.ListAction).GetListRestrictions().Fields.String(); fields != "spec.nodeName=worker-3" {
+		t.Errorf("Expected the list narrowed by the field selector, got %q", fields)
+	}
+	if fields := actions[1].(k8stesting.WatchAction).GetWatchRestrictions().Fields.String(); fields != "spec.nodeName=worker-3" {
+		t.Errorf("Expected the watch narrowed by the field selector, got %q", fields)
+	}
+}
+
 func TestClientIngressOperations(t *testing.T) {
 	fakeClient := fake.NewSimpleClientset()
 
@@ -1038,6 +1099,33 @@ func TestGetPodsForStatefulSet(t *testing.T) {
 	}
 }
 
+func TestGetPodsForService(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "web"}},
+		},
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default", Labels: map[string]string{"app": "web", "tier": "front"}}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+	)
+	client := &Client{clientset: fakeClient}
+
+	pods, err := client.GetPodsForService(context.Background(), "default", "web")
+	if err != nil {
+		t.Fatalf("GetPodsForService failed: %v", err)
+	}
+	if len(pods) != 2 {
+		t.Errorf("Expected the 2 web pods, got %d", len(pods))
+	}
+
+	// Without a selector there are no pods to speak of
+	if _, err := client.GetPodsForService(context.Background(), "default", "external"); err == nil || !strings.Contains(err.Error(), "no selector") {
+		t.Errorf("Expected a no selector error, got %v", err)
+	}
+}
+
 func TestFormatCPU(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1195,6 +1283,43 @@ func TestGetPodMetrics(t *testing.T) {
 	}
 }
 
+func TestGetPodMetricsKeysByNamespace(t *testing.T) {
+	metricsClient := metricsfake.NewSimpleClientset()
+	// The fake guesses the wrong resource for PodMetrics objects, so add
+	// them under the resource the client lists
+	gvr := schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+	for ns, cpu := range map[string]string{"prod": "900m", "staging": "10m"} {
+		err := metricsClient.Tracker().Create(gvr, &metricsv1beta1.PodMetrics{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: ns},
+			Containers: []metricsv1beta1.ContainerMetrics{{
+				Name:  "app",
+				Usage: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)},
+			}},
+		}, ns)
+		if err != nil {
+			t.Fatalf("Failed to add metrics: %v", err)
+		}
+	}
+
+	client := &Client{metricsClient: metricsClient}
+	metrics, err := client.GetPodMetrics(context.Background(), "")
+	if err != nil {
+		t.Fatalf("GetPodMetrics failed: %v", err)
+	}
+	if len(metrics) != 2 {
+		t.Fatalf("Expected metrics for both pods named web, got %d", len(metrics))
+	}
+	if m, ok := metrics.Get("prod", "web"); !ok || m.CPU != "900m" || m.Namespace != "prod" {
+		t.Errorf("Expected prod/web at 900m, got %+v", m)
+	}
+	if m, ok := metrics.Get("staging", "web"); !ok || m.CPU != "10m" {
+		t.Errorf("Expected staging/web at 10m, got %+v", m)
+	}
+	if _, ok := metrics.Get("dev", "web"); ok {
+		t.Error("Expected no metrics for dev/web")
+	}
+}
+
 func TestGetNodeMetrics(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -1408,6 +1533,97 @@ func TestDescribeResource(t *testing.T) {
 	}
 }
 
+func TestDescribeStatefulSetsIngressesAndConfig(t *testing.T) {
+	replicas := int32(3)
+	className := "nginx"
+	pathType := networkingv1.PathTypePrefix
+	client := &Client{clientset: fake.NewSimpleClientset(
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas:    &replicas,
+				ServiceName: "db-headless",
+				Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "postgres", Image: "postgres:16"}},
+				}},
+				VolumeClaimTemplates: []v1.PersistentVolumeClaim{{
+					ObjectMeta: metav1.ObjectMeta{Name: "data"},
+					Spec: v1.PersistentVolumeClaimSpec{Resources: v1.VolumeResourceRequirements{
+						Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("10Gi")},
+					}},
+				}},
+			},
+			Status: appsv1.StatefulSetStatus{CurrentReplicas: 3, UpdatedReplicas: 3, ReadyReplicas: 2},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: &className,
+				Rules: []networkingv1.IngressRule{{
+					Host: "example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     "/api",
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+								Name: "api", Port: networkingv1.ServiceBackendPort{Number: 8080},
+							}},
+						}},
+					}},
+				}},
+			},
+			Status: networkingv1.IngressStatus{LoadBalancer: networkingv1.IngressLoadBalancerStatus{
+				Ingress: []networkingv1.IngressLoadBalancerIngress{{IP: "203.0.113.7"}},
+			}},
+		},
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"},
+			Data:       map[string]string{"mode": "fast", "app.yaml": "port: 80\nlog: debug\n"},
+			BinaryData: map[string][]byte{"logo.png": {1, 2, 3}},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "default"},
+			Type:       v1.SecretTypeOpaque,
+			Data:       map[string][]byte{"password": []byte("hunter2")},
+		},
+	)}
+
+	tests := []struct {
+		resourceType string
+		name         string
+		want         []string
+		notWant      []string
+	}{
+		{"statefulset", "db", []string{"Name:         db", "Replicas:     3 desired | 3 current | 3 updated | 2 ready",
+			"Service:      db-headless", "postgres: postgres:16", "data: 10Gi"}, nil},
+		{"ingresses", "web", []string{"Class:        nginx", "Address:      203.0.113.7", "example.com", "/api -> api:8080"}, nil},
+		{"configmap", "settings", []string{"mode:\n    fast", "app.yaml:\n    port: 80\n    log: debug", "logo.png: 3 bytes (binary)"}, nil},
+		{"secrets", "creds", []string{"Type:         Opaque", "password: 7 bytes"}, []string{"hunter2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.resourceType, func(t *testing.T) {
+			description, err := client.DescribeResource(context.Background(), tt.resourceType, tt.name, "default")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(description, want) {
+					t.Errorf("Expected %q in the description, got:\n%s", want, description)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(description, notWant) {
+					t.Errorf("Expected no %q in the description, got:\n%s", notWant, description)
+				}
+			}
+		})
+	}
+
+	if _, err := client.DescribeResource(context.Background(), "secret", "missing", "default"); err == nil {
+		t.Error("Expected an error for a secret that does not exist")
+	}
+}
+
 // Test helper for valid kubeconfig
 var validKubeconfig = `
 apiVersion: v1
@@ -0,0 +1,230 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// CreatedLabel marks resources created from kubewatch so they can be found
+// and cleaned up later
+const CreatedLabel = "kubewatch-created"
+
+// CreatedResource identifies a resource created from kubewatch
+type CreatedResource struct {
+	Kind      string
+	Resource  string // Plural resource, e.g. "pods"
+	Namespace string // Empty for cluster-scoped resources
+	Name      string
+
+	gvr schema.GroupVersionResource
+}
+
+// String names the resource as kubectl would, e.g. "pod/debug-x7k2p in default"
+func (r CreatedResource) String() string {
+	s := strings.ToLower(r.Kind) + "/" + r.Name
+	if r.Namespace != "" {
+		s += " in " + r.Namespace
+	}
+	return s
+}
+
+// CreateFromManifest creates every object in a YAML or JSON manifest, which
+// may hold several documents. Objects of any kind the API server serves are
+// supported; each is labelled with CreatedLabel. Namespaced objects without a
+// namespace go to "default". Creation stops at the first failure, returning
+// what was created before it.
+func (c *Client) CreateFromManifest(ctx context.Context, manifest string) ([]CreatedResource, error) {
+	objects, err := decodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	dyn, err := c.dynamic()
+	if err != nil {
+		return nil, err
+	}
+	groupResources, err := restmapper.GetAPIGroupResources(c.clientset.Discovery())
+	if err != nil {
+		return nil, c.wrapError(err, OpGet, "api resources", "", "")
+	}
+	mapper := restmapper.NewDiscoveryRESTMapper(groupResources)
+
+	var created []CreatedResource
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return created, fmt.Errorf("unknown kind %s: %w", gvk.Kind, err)
+		}
+
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[CreatedLabel] = "true"
+		obj.SetLabels(labels)
+
+		var client dynamic.ResourceInterface = dyn.Resource(mapping.Resource)
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			if obj.GetNamespace() == "" {
+				obj.SetNamespace("default")
+			}
+			client = dyn.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+		} else {
+			obj.SetNamespace("")
+		}
+
+		result, err := client.Create(ctx, obj, metav1.CreateOptions{})
+		if err != nil {
+			name := obj.GetName()
+			if name == "" {
+				name = obj.GetGenerateName()
+			}
+			return created, c.wrapError(err, OpCreate, mapping.Resource.Resource, obj.GetNamespace(), name)
+		}
+		created = append(created, CreatedResource{
+			Kind:      result.GetKind(),
+			Resource:  mapping.Resource.Resource,
+			Namespace: result.GetNamespace(),
+			Name:      result.GetName(),
+			gvr:       mapping.Resource,
+		})
+	}
+	return created, nil
+}
+
+// ListCreatedResources finds the resources carrying CreatedLabel in namespace,
+// or in every namespace when it is empty. Every namespaced resource type the
+// API server can list and delete is searched.
+func (c *Client) ListCreatedResources(ctx context.Context, namespace string) ([]CreatedResource, error) {
+	dyn, err := c.dynamic()
+	if err != nil {
+		return nil, err
+	}
+	groupResources, err := restmapper.GetAPIGroupResources(c.clientset.Discovery())
+	if err != nil {
+		return nil, c.wrapError(err, OpGet, "api resources", "", "")
+	}
+
+	selector := metav1.ListOptions{LabelSelector: CreatedLabel + "=true"}
+	var found []CreatedResource
+	var errs []error
+	for _, group := range groupResources {
+		version := group.Group.PreferredVersion.Version
+		for _, res := range group.VersionedResources[version] {
+			if !res.Namespaced || strings.Contains(res.Name, "/") || !hasVerbs(res.Verbs, "list", "delete") {
+				continue
+			}
+			gvr := schema.GroupVersionResource{Group: group.Group.Name, Version: version, Resource: res.Name}
+			items, err := listAll[unstructured.Unstructured](ctx, selector, dyn.Resource(gvr).Namespace(namespace).List)
+			if err != nil {
+				errs = append(errs, c.wrapError(err, OpList, res.Name, namespace, ""))
+				continue
+			}
+			for _, item := range items {
+				found = append(found, CreatedResource{
+					Kind:      item.GetKind(),
+					Resource:  res.Name,
+					Namespace: item.GetNamespace(),
+					Name:      item.GetName(),
+					gvr:       gvr,
+				})
+			}
+		}
+	}
+
+	// Types the user may not list are skipped; fail only if nothing could be searched
+	if len(found) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return found, nil
+}
+
+// DeleteCreatedResource deletes a resource found by ListCreatedResources or
+// returned by CreateFromManifest
+func (c *Client) DeleteCreatedResource(ctx context.Context, r CreatedResource) error {
+	dyn, err := c.dynamic()
+	if err != nil {
+		return err
+	}
+
+	var client dynamic.ResourceInterface = dyn.Resource(r.gvr)
+	if r.Namespace != "" {
+		client = dyn.Resource(r.gvr).Namespace(r.Namespace)
+	}
+	return c.wrapError(client.Delete(ctx, r.Name, metav1.DeleteOptions{}), OpDelete, r.Resource, r.Namespace, r.Name)
+}
+
+// dynamic returns the client's dynamic client, creating it on first use
+func (c *Client) dynamic() (dynamic.Interface, error) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	if c.dynamicClient == nil {
+		if c.config == nil {
+			return nil, fmt.Errorf("no cluster configuration for the dynamic client")
+		}
+		dyn, err := dynamic.NewForConfig(c.config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+		}
+		c.dynamicClient = dyn
+	}
+	return c.dynamicClient, nil
+}
+
+// decodeManifest splits a YAML or JSON manifest into objects, skipping empty
+// documents
+func decodeManifest(manifest string) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+	var objects []*unstructured.Unstructured
+	for {
+		var raw map[string]interface{}
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("invalid manifest: %w", err)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		obj := &unstructured.Unstructured{Object: raw}
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+			return nil, fmt.Errorf("invalid manifest: every object needs apiVersion and kind")
+		}
+		objects = append(objects, obj)
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("manifest has no objects")
+	}
+	return objects, nil
+}
+
+// hasVerbs returns true when verbs includes every one of want
+func hasVerbs(verbs metav1.Verbs, want ...string) bool {
+	for _, w := range want {
+		found := false
+		for _, v := range verbs {
+			if v == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,175 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newCreateTestClient returns a client serving pods, configmaps and nodes
+// through fake discovery and a fake dynamic client
+func newCreateTestClient() *Client {
+	clientset := fake.NewSimpleClientset()
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"create", "list", "delete"}},
+				{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"get"}},
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"create", "list", "delete"}},
+				{Name: "nodes", Kind: "Node", Verbs: metav1.Verbs{"list", "delete"}},
+			},
+		},
+	}
+
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "pods"}:       "PodList",
+		{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+	})
+	return &Client{clientset: clientset, dynamicClient: dyn}
+}
+
+func TestCreateFromManifest(t *testing.T) {
+	ctx := context.Background()
+	client := newCreateTestClient()
+
+	manifest := `apiVersion: v1
+kind: Pod
+metadata:
+  name: debug-1
+  namespace: payments
+  labels:
+    app: debug
+spec:
+  containers:
+  - name: debug
+    image: busybox
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: debug-config
+`
+	created, err := client.CreateFromManifest(ctx, manifest)
+	if err != nil {
+		t.Fatalf("CreateFromManifest failed: %v", err)
+	}
+	if len(created) != 2 {
+		t.Fatalf("Expected 2 resources, got %+v", created)
+	}
+	if got := created[0].String(); got != "pod/debug-1 in payments" {
+		t.Errorf("Expected pod/debug-1 in payments, got %q", got)
+	}
+	if created[1].Namespace != "default" {
+		t.Errorf("Expected a namespaced object without a namespace to go to default, got %q", created[1].Namespace)
+	}
+
+	pod, err := client.dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "pods"}).
+		Namespace("payments").Get(ctx, "debug-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Created pod not found: %v", err)
+	}
+	if labels := pod.GetLabels(); labels[CreatedLabel] != "true" || labels["app"] != "debug" {
+		t.Errorf("Expected the created label alongside existing labels, got %v", labels)
+	}
+
+	// Creating the same pod again fails as a conflict, after nothing was created
+	created, err = client.CreateFromManifest(ctx, strings.Split(manifest, "---")[0])
+	if len(created) != 0 || ClassifyError(err) != ErrConflict {
+		t.Errorf("Expected a conflict creating an existing pod, got %v, %v", created, err)
+	}
+	if msg := UserMessage(err); !strings.Contains(msg, "pods/debug-1 in payments") {
+		t.Errorf("Expected the pod named in the message, got %q", msg)
+	}
+
+	if _, err := client.CreateFromManifest(ctx, "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: w\n"); err == nil {
+		t.Error("Expected an error for a kind the server does not serve")
+	}
+	if _, err := client.CreateFromManifest(ctx, "metadata:\n  name: w\n"); err == nil {
+		t.Error("Expected an error for an object without apiVersion and kind")
+	}
+	if _, err := client.CreateFromManifest(ctx, "---\n"); err == nil {
+		t.Error("Expected an error for an empty manifest")
+	}
+}
+
+func TestListAndDeleteCreatedResources(t *testing.T) {
+	ctx := context.Background()
+	client := newCreateTestClient()
+
+	manifest := `apiVersion: v1
+kind: Pod
+metadata:
+  name: debug-1
+  namespace: payments
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: debug-config
+  namespace: payments
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: debug-2
+  namespace: other
+`
+	if _, err := client.CreateFromManifest(ctx, manifest); err != nil {
+		t.Fatalf("CreateFromManifest failed: %v", err)
+	}
+
+	// A pod created elsewhere is not ours to clean up
+	unrelated := `apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: payments
+`
+	objects, _ := decodeManifest(unrelated)
+	pods := client.dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "pods"})
+	if _, err := pods.Namespace("payments").Create(ctx, objects[0], metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create unrelated pod: %v", err)
+	}
+
+	found, err := client.ListCreatedResources(ctx, "payments")
+	if err != nil {
+		t.Fatalf("ListCreatedResources failed: %v", err)
+	}
+	var names []string
+	for _, r := range found {
+		names = append(names, r.String())
+	}
+	if strings.Join(names, ",") != "pod/debug-1 in payments,configmap/debug-config in payments" {
+		t.Fatalf("Expected the two created resources in payments, got %v", names)
+	}
+
+	all, err := client.ListCreatedResources(ctx, "")
+	if err != nil || len(all) != 3 {
+		t.Errorf("Expected 3 created resources across namespaces, got %v, %v", all, err)
+	}
+
+	for _, r := range found {
+		if err := client.DeleteCreatedResource(ctx, r); err != nil {
+			t.Errorf("DeleteCreatedResource %s failed: %v", r, err)
+		}
+	}
+	if left, _ := client.ListCreatedResources(ctx, "payments"); len(left) != 0 {
+		t.Errorf("Expected nothing left in payments, got %v", left)
+	}
+	if _, err := pods.Namespace("payments").Get(ctx, "web-1", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the unrelated pod to survive, got %v", err)
+	}
+
+	err = client.DeleteCreatedResource(ctx, found[0])
+	if ClassifyError(err) != ErrNotFound {
+		t.Errorf("Expected NotFound deleting twice, got %v", err)
+	}
+}
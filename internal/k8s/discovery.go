@@ -0,0 +1,137 @@
+package k8s
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// DefaultServedTypesTTL is how long discovery of a group version's
+// resources is cached before asking the API server again, so a controller
+// or CRD installed meanwhile is picked up
+const DefaultServedTypesTTL = 5 * time.Minute
+
+// servedResource is the API resource a resource type is listed from, and
+// the kind it lists
+type servedResource struct {
+	gvr  schema.GroupVersionResource
+	kind string
+}
+
+// servedResources maps the resource types a cluster may not serve to their
+// API resource. The core group's types are served by every cluster and are
+// left out.
+var servedResources = map[core.ResourceType]servedResource{
+	core.ResourceTypeDeployment:  {schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, "Deployment"},
+	core.ResourceTypeStatefulSet: {schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}, "StatefulSet"},
+	core.ResourceTypeJob:         {schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}, "Job"},
+	core.ResourceTypeCronJob:     {schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}, "CronJob"},
+	core.ResourceTypeIngress:     {schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}, "Ingress"},
+	core.ResourceTypeGateway:     {gatewayResource, "Gateway"},
+	core.ResourceTypeHTTPRoute:   {httpRouteResource, "HTTPRoute"},
+
+	core.ResourceTypeRole:               {schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "roles"}, "Role"},
+	core.ResourceTypeClusterRole:        {schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles"}, "ClusterRole"},
+	core.ResourceTypeRoleBinding:        {schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "rolebindings"}, "RoleBinding"},
+	core.ResourceTypeClusterRoleBinding: {schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterrolebindings"}, "ClusterRoleBinding"},
+}
+
+// NotServedError is returned for a resource type the cluster's API server
+// does not serve, such as Ingress on a cluster using only the Gateway API
+type NotServedError struct {
+	GroupVersion schema.GroupVersion
+	Kind         string
+}
+
+func (e *NotServedError) Error() string {
+	return fmt.Sprintf("%s %s is not served by this cluster", e.GroupVersion, e.Kind)
+}
+
+// ServedTypesCache caches which resources each group version serves, from
+// API discovery
+type ServedTypesCache struct {
+	mu      sync.Mutex
+	client  *Client
+	ttl     time.Duration
+	entries map[schema.GroupVersion]*servedTypesEntry
+}
+
+type servedTypesEntry struct {
+	resources map[string]bool
+	fetchedAt time.Time
+}
+
+// NewServedTypesCache creates a discovery cache backed by client
+func NewServedTypesCache(client *Client, ttl time.Duration) *ServedTypesCache {
+	return &ServedTypesCache{
+		client:  client,
+		ttl:     ttl,
+		entries: make(map[schema.GroupVersion]*servedTypesEntry),
+	}
+}
+
+// Check returns a *NotServedError when the cluster does not serve
+// resourceType. A discovery failure that does not say so is not an error
+// here: listing the type reports it better.
+func (c *ServedTypesCache) Check(resourceType core.ResourceType) error {
+	resource, ok := servedResources[resourceType]
+	if !ok {
+		return nil
+	}
+	gv := resource.gvr.GroupVersion()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.entries[gv]
+	if entry == nil || time.Since(entry.fetchedAt) >= c.ttl {
+		list, err := c.client.clientset.Discovery().ServerResourcesForGroupVersion(gv.String())
+		switch {
+		case apierrors.IsNotFound(err):
+			entry = &servedTypesEntry{resources: map[string]bool{}, fetchedAt: time.Now()}
+		case err != nil || list == nil || list.GroupVersion != gv.String():
+			// Keep what was last found, if anything
+			if entry == nil {
+				return nil
+			}
+		default:
+			entry = &servedTypesEntry{resources: make(map[string]bool, len(list.APIResources)), fetchedAt: time.Now()}
+			for _, r := range list.APIResources {
+				entry.resources[r.Name] = true
+			}
+		}
+		c.entries[gv] = entry
+	}
+
+	if !entry.resources[resource.gvr.Resource] {
+		return &NotServedError{GroupVersion: gv, Kind: resource.kind}
+	}
+	return nil
+}
+
+// Invalidate forces the next Check to ask the API server again
+func (c *ServedTypesCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[schema.GroupVersion]*servedTypesEntry)
+}
+
+// CheckServed returns a *NotServedError when the cluster does not serve
+// resourceType, from discovery cached for DefaultServedTypesTTL
+func (c *Client) CheckServed(resourceType core.ResourceType) error {
+	return c.servedTypes().Check(resourceType)
+}
+
+// servedTypes returns the client's discovery cache, creating it on first use
+func (c *Client) servedTypes() *ServedTypesCache {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	if c.servedTypesCache == nil {
+		c.servedTypesCache = NewServedTypesCache(c, DefaultServedTypesTTL)
+	}
+	return c.servedTypesCache
+}
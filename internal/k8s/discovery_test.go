@@ -0,0 +1,60 @@
+package k8s
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckServed(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	client := &Client{clientset: clientset}
+
+	// The core group is served by every cluster
+	if err := client.CheckServed(core.ResourceTypePod); err != nil {
+		t.Errorf("Expected pods served, got %v", err)
+	}
+
+	var notServed *NotServedError
+	err := client.CheckServed(core.ResourceTypeIngress)
+	if !errors.As(err, &notServed) || err.Error() != "networking.k8s.io/v1 Ingress is not served by this cluster" {
+		t.Fatalf("Expected ingresses not served, got %v", err)
+	}
+
+	// Discovery is cached until it is invalidated or goes stale
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+		GroupVersion: "networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{{Name: "ingresses", Namespaced: true, Kind: "Ingress"}},
+	}}
+	if err := client.CheckServed(core.ResourceTypeIngress); err == nil {
+		t.Error("Expected the cached discovery used")
+	}
+	client.servedTypes().Invalidate()
+	if err := client.CheckServed(core.ResourceTypeIngress); err != nil {
+		t.Errorf("Expected ingresses served once installed, got %v", err)
+	}
+}
+
+func TestServedTypesCacheRefreshes(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	cache := NewServedTypesCache(&Client{clientset: clientset}, time.Nanosecond)
+	if err := cache.Check(core.ResourceTypeGateway); err == nil {
+		t.Fatal("Expected gateways not served")
+	}
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+		GroupVersion: core.GatewayAPIGroup + "/v1",
+		APIResources: []metav1.APIResource{{Name: "gateways", Namespaced: true, Kind: "Gateway"}},
+	}}
+	time.Sleep(time.Millisecond)
+	if err := cache.Check(core.ResourceTypeGateway); err != nil {
+		t.Errorf("Expected the stale discovery refetched, got %v", err)
+	}
+	if err := cache.Check(core.ResourceTypeHTTPRoute); err == nil {
+		t.Error("Expected httproutes still not served")
+	}
+}
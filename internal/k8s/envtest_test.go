@@ -0,0 +1,438 @@
+//go:build integration
+
+// Integration tests against a real kube-apiserver and etcd started by envtest.
+// They cover what the fake clientset does not model: watches that start with
+// the current state, field selectors, delete preconditions, deletion held by
+// finalizers, log option validation, discovery and a missing metrics API.
+//
+// Run them with `make test-integration`, which downloads the control plane
+// binaries with setup-envtest. To run them directly, point KUBEBUILDER_ASSETS
+// at a directory holding kube-apiserver and etcd:
+//
+//	export KUBEBUILDER_ASSETS=$(go run sigs.k8s.io/controller-runtime/tools/setup-envtest@release-0.17 use 1.29.x -p path)
+//	go test -tags integration ./internal/k8s/ -run Envtest
+//
+// There is no kubelet, scheduler or controller manager, so pods never run,
+// nothing is garbage collected and no metrics are served.
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+var envtestClient *Client
+
+func TestMain(m *testing.M) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		fmt.Fprintln(os.Stderr, "integration tests need KUBEBUILDER_ASSETS; run them with make test-integration")
+		os.Exit(1)
+	}
+
+	env := &envtest.Environment{}
+	config, err := env.Start()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start envtest: %v\n", err)
+		os.Exit(1)
+	}
+
+	envtestClient, err = NewClientFromConfig(config)
+	if err != nil {
+		_ = env.Stop()
+		fmt.Fprintf(os.Stderr, "failed to create client: %v\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	if err := env.Stop(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to stop envtest: %v\n", err)
+	}
+	os.Exit(code)
+}
+
+// envtestNamespace creates a namespace for one test. envtest has no namespace
+// controller, so it is left behind when the test ends.
+func envtestNamespace(t *testing.T) string {
+	t.Helper()
+	ns, err := envtestClient.clientset.CoreV1().Namespaces().Create(context.Background(), &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "kubewatch-test-"},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Failed to create namespace: %v", err)
+	}
+	return ns.Name
+}
+
+func envtestPod(t *testing.T, namespace, name string, labels map[string]string) *v1.Pod {
+	t.Helper()
+	pod, err := envtestClient.clientset.CoreV1().Pods(namespace).Create(context.Background(), &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: "busybox"}}},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Failed to create pod %s: %v", name, err)
+	}
+	return pod
+}
+
+// nextWatchEvent waits for an event on a channel of watch events
+func nextWatchEvent[T any](t *testing.T, events <-chan T) (T, bool) {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		return event, ok
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for a watch event")
+	}
+	var zero T
+	return zero, false
+}
+
+func TestEnvtestWatchStartsWithCurrentState(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ns := envtestNamespace(t)
+	envtestPod(t, ns, "web-1", nil)
+	envtestPod(t, ns, "web-2", nil)
+
+	// Without a resource version the server replays existing objects as
+	// ADDED; the fake clientset only reports changes made after the watch
+	w, err := envtestClient.WatchPods(ctx, ns)
+	if err != nil {
+		t.Fatalf("WatchPods failed: %v", err)
+	}
+	defer w.Stop()
+
+	seen := make(map[string]bool)
+	for len(seen) < 2 {
+		event, ok := nextWatchEvent(t, w.ResultChan())
+		if !ok {
+			t.Fatal("Watch closed early")
+		}
+		if event.Type != watch.Added {
+			t.Fatalf("Expected ADDED for existing pods, got %s", event.Type)
+		}
+		seen[event.Object.(*v1.Pod).Name] = true
+	}
+
+	if err := envtestClient.DeletePod(ctx, ns, "web-1"); err != nil {
+		t.Fatalf("DeletePod failed: %v", err)
+	}
+	for {
+		event, ok := nextWatchEvent(t, w.ResultChan())
+		if !ok {
+			t.Fatal("Watch closed early")
+		}
+		if event.Type == watch.Deleted {
+			if name := event.Object.(*v1.Pod).Name; name != "web-1" {
+				t.Errorf("Expected web-1 deleted, got %s", name)
+			}
+			break
+		}
+	}
+}
+
+func TestEnvtestWatchCoalescerReconnects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ns := envtestNamespace(t)
+	envtestPod(t, ns, "before", nil)
+
+	coalescer := NewWatchCoalescer()
+	listener, err := coalescer.AddWatchListener(ctx, envtestClient, "envtest", ns, "pods")
+	if err != nil {
+		t.Fatalf("AddWatchListener failed: %v", err)
+	}
+	if event, _ := nextWatchEvent(t, listener); event.Object.(*v1.Pod).Name != "before" {
+		t.Fatalf("Expected the existing pod first, got %v", event.Object)
+	}
+
+	// End the watch as a server timeout or dropped connection would
+	coalescer.mu.RLock()
+	req := coalescer.activeWatches[coalescer.generateWatchKey("envtest", ns, "pods")]
+	coalescer.mu.RUnlock()
+	req.Watcher.Stop()
+	for {
+		if _, ok := nextWatchEvent(t, listener); !ok {
+			break
+		}
+	}
+
+	// A pod created while disconnected is in the state the new watch starts with
+	envtestPod(t, ns, "during", nil)
+	listener, err = coalescer.AddWatchListener(ctx, envtestClient, "envtest", ns, "pods")
+	if err != nil {
+		t.Fatalf("AddWatchListener after the watch ended failed: %v", err)
+	}
+	seen := make(map[string]bool)
+	for len(seen) < 2 {
+		event, ok := nextWatchEvent(t, listener)
+		if !ok {
+			t.Fatal("Watch closed early")
+		}
+		seen[event.Object.(*v1.Pod).Name] = true
+	}
+	if !seen["before"] || !seen["during"] {
+		t.Errorf("Expected both pods after reconnecting, got %v", seen)
+	}
+}
+
+func TestEnvtestSelectors(t *testing.T) {
+	ctx := context.Background()
+	ns := envtestNamespace(t)
+	envtestPod(t, ns, "web-1", map[string]string{"app": "web"})
+	envtestPod(t, ns, "web-2", map[string]string{"app": "web"})
+	envtestPod(t, ns, "db-1", map[string]string{"app": "db"})
+
+	// Only the server filters by field; the fake clientset ignores them
+	running, err := envtestClient.clientset.CoreV1().Pods(ns).Get(ctx, "web-2", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	running.Status.Phase = v1.PodRunning
+	if _, err := envtestClient.clientset.CoreV1().Pods(ns).UpdateStatus(ctx, running, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+
+	orc := NewOptimizedResourceClient(envtestClient, NewResourceCache(10, time.Minute), nil)
+	filter := NewSmartResourceFilter(envtestClient)
+	pods, err := orc.ListPodsOptimized(ctx, ns, filter.FilterPodsByPhase(v1.PodRunning))
+	if err != nil {
+		t.Fatalf("ListPodsOptimized failed: %v", err)
+	}
+	if len(pods) != 1 {
+		t.Errorf("Expected one running pod from the field selector, got %d", len(pods))
+	}
+
+	pods, err = orc.ListPodsOptimized(ctx, ns, filter.FilterPodsForDeployment("web"))
+	if err != nil {
+		t.Fatalf("ListPodsOptimized failed: %v", err)
+	}
+	if len(pods) != 2 {
+		t.Errorf("Expected two pods from the label selector, got %d", len(pods))
+	}
+
+	// An invalid field is rejected by the server
+	_, err = orc.ListPodsOptimized(ctx, ns, &ResourceFilter{FieldSelector: "spec.unknownField=x"})
+	if err == nil || !apierrors.IsBadRequest(err) {
+		t.Errorf("Expected a bad request for an unsupported field selector, got %v", err)
+	}
+}
+
+func TestEnvtestListEventsFor(t *testing.T) {
+	ctx := context.Background()
+	ns := envtestNamespace(t)
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	for i, object := range []struct{ kind, name string }{
+		{"Pod", "web-1"}, {"Pod", "web-1"}, {"Pod", "web-2"}, {"Deployment", "web-1"},
+	} {
+		_, err := envtestClient.clientset.CoreV1().Events(ns).Create(ctx, &v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: fmt.Sprintf("event-%d", i), Namespace: ns},
+			InvolvedObject: v1.ObjectReference{Kind: object.kind, Name: object.name, Namespace: ns},
+			Reason:         "Test",
+			Type:           v1.EventTypeNormal,
+			FirstTimestamp: now,
+			LastTimestamp:  now,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+	}
+
+	events, err := envtestClient.ListEventsFor(ctx, "Pods", ns, "web-1", time.Time{})
+	if err != nil {
+		t.Fatalf("ListEventsFor failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Errorf("Expected the server to select web-1's two pod events, got %d", len(events))
+	}
+}
+
+func TestEnvtestDeletePreconditions(t *testing.T) {
+	ctx := context.Background()
+	ns := envtestNamespace(t)
+	pod := envtestPod(t, ns, "web-1", nil)
+
+	// The fake clientset ignores preconditions and deletes anyway
+	stale := types.UID("00000000-0000-0000-0000-000000000000")
+	err := envtestClient.clientset.CoreV1().Pods(ns).Delete(ctx, pod.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &stale},
+	})
+	err = envtestClient.wrapError(err, OpDelete, "pods", ns, pod.Name)
+	if ClassifyError(err) != ErrConflict {
+		t.Errorf("Expected a Conflict for a stale UID precondition, got %v", err)
+	}
+
+	err = envtestClient.clientset.CoreV1().Pods(ns).Delete(ctx, pod.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &pod.UID, ResourceVersion: &pod.ResourceVersion},
+	})
+	if err != nil {
+		t.Errorf("Expected matching preconditions to delete, got %v", err)
+	}
+
+	err = envtestClient.DeletePod(ctx, ns, pod.Name)
+	if ClassifyError(err) != ErrNotFound {
+		t.Errorf("Expected NotFound deleting again, got %v", err)
+	}
+}
+
+func TestEnvtestFinalizerHoldsDeletion(t *testing.T) {
+	ctx := context.Background()
+	ns := envtestNamespace(t)
+	_, err := envtestClient.clientset.CoreV1().ConfigMaps(ns).Create(ctx, &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "held", Namespace: ns, Finalizers: []string{"example.com/hold"}},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Failed to create config map: %v", err)
+	}
+
+	// The server keeps the object, marked for deletion, until the finalizer
+	// is gone; the fake clientset deletes it at once
+	if err := envtestClient.DeleteConfigMap(ctx, ns, "held"); err != nil {
+		t.Fatalf("DeleteConfigMap failed: %v", err)
+	}
+	cm, err := envtestClient.clientset.CoreV1().ConfigMaps(ns).Get(ctx, "held", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected the config map held by its finalizer, got %v", err)
+	}
+	if cm.DeletionTimestamp == nil {
+		t.Error("Expected a deletion timestamp while the finalizer is set")
+	}
+
+	if err := envtestClient.RemoveFinalizer(ctx, "configmaps", ns, "held", "example.com/hold"); err != nil {
+		t.Fatalf("RemoveFinalizer failed: %v", err)
+	}
+	_, err = envtestClient.clientset.CoreV1().ConfigMaps(ns).Get(ctx, "held", metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the config map gone once the finalizer was removed, got %v", err)
+	}
+}
+
+func TestEnvtestPodLogOptions(t *testing.T) {
+	ctx := context.Background()
+	ns := envtestNamespace(t)
+	envtestPod(t, ns, "web-1", nil)
+
+	// The fake clientset returns canned logs whatever the options; the server
+	// validates them before it would ask a kubelet
+	tests := []struct {
+		name      string
+		container string
+		tailLines int64
+		want      string
+	}{
+		{"unknown container", "sidecar", 10, "sidecar"},
+		{"negative tail", "app", -1, "tailLines"},
+	}
+
+	since := time.Now().Add(-time.Hour)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := envtestClient.GetPodLogsWithOptions(ctx, ns, "web-1", tt.container, false, tt.tailLines, false, &since, true)
+			if err == nil {
+				stream.Close()
+				t.Fatal("Expected the options to be rejected")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error mentioning %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	// Valid options on a pod with no node yet give empty logs, not an error
+	stream, err := envtestClient.GetPodLogsWithOptions(ctx, ns, "web-1", "app", false, 10, false, &since, true)
+	if err != nil {
+		t.Fatalf("Expected empty logs for an unscheduled pod, got %v", err)
+	}
+	logs, _ := io.ReadAll(stream)
+	stream.Close()
+	if len(logs) != 0 {
+		t.Errorf("Expected no logs before the pod has a node, got %q", logs)
+	}
+
+	_, err = envtestClient.GetPodLogsWithOptions(ctx, ns, "missing", "", false, 10, false, nil, false)
+	if ClassifyError(err) != ErrNotFound {
+		t.Errorf("Expected NotFound for a missing pod, got %v", err)
+	}
+}
+
+func TestEnvtestCreateFromManifest(t *testing.T) {
+	ctx := context.Background()
+	ns := envtestNamespace(t)
+
+	// Kinds are resolved through the server's discovery, which the fake
+	// clientset serves only partially
+	manifest := fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: %s
+data:
+  key: value
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: %s
+spec:
+  ports:
+  - port: 80
+`, ns, ns)
+
+	created, err := envtestClient.CreateFromManifest(ctx, manifest)
+	if err != nil {
+		t.Fatalf("CreateFromManifest failed: %v", err)
+	}
+	if len(created) != 2 {
+		t.Fatalf("Expected two resources, got %v", created)
+	}
+
+	found, err := envtestClient.ListCreatedResources(ctx, ns)
+	if err != nil {
+		t.Fatalf("ListCreatedResources failed: %v", err)
+	}
+	if len(found) != 2 {
+		t.Fatalf("Expected the two labelled resources, got %v", found)
+	}
+	for _, r := range found {
+		if err := envtestClient.DeleteCreatedResource(ctx, r); err != nil {
+			t.Errorf("DeleteCreatedResource(%s) failed: %v", r, err)
+		}
+	}
+
+	_, err = envtestClient.CreateFromManifest(ctx, "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: w\n")
+	if err == nil || !strings.Contains(err.Error(), "unknown kind Widget") {
+		t.Errorf("Expected an unknown kind error, got %v", err)
+	}
+}
+
+func TestEnvtestMetricsAbsent(t *testing.T) {
+	ctx := context.Background()
+	ns := envtestNamespace(t)
+
+	// envtest serves no metrics.k8s.io, like a cluster without metrics-server
+	_, err := envtestClient.GetPodMetrics(ctx, ns)
+	if err == nil {
+		t.Fatal("Expected an error without the metrics API")
+	}
+	if ClassifyError(err) != ErrNotFound {
+		t.Errorf("Expected NotFound for the missing metrics API, got %v (%s)", err, ClassifyError(err))
+	}
+
+	if _, err := envtestClient.GetNodeMetrics(ctx); err == nil {
+		t.Error("Expected an error for node metrics without the metrics API")
+	}
+}
@@ -0,0 +1,314 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ErrorKind is the category of a failed Kubernetes API call
+type ErrorKind int
+
+const (
+	ErrUnknown ErrorKind = iota
+	ErrNotFound
+	ErrForbidden
+	ErrUnauthorized
+	ErrTimeout
+	ErrConflict
+	ErrThrottled
+	ErrConnectionFailed
+)
+
+// String returns the name of the error kind
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrNotFound:
+		return "NotFound"
+	case ErrForbidden:
+		return "Forbidden"
+	case ErrUnauthorized:
+		return "Unauthorized"
+	case ErrTimeout:
+		return "Timeout"
+	case ErrConflict:
+		return "Conflict"
+	case ErrThrottled:
+		return "Throttled"
+	case ErrConnectionFailed:
+		return "ConnectionFailed"
+	default:
+		return "Unknown"
+	}
+}
+
+// Operations recorded on an Error
+const (
+	OpList   = "list"
+	OpGet    = "get"
+	OpCreate = "create"
+	OpDelete = "delete"
+	OpPatch  = "patch"
+	OpLogs   = "logs"
+	OpWatch  = "watch"
+	OpExec   = "exec"
+	OpScale  = "scale"
+)
+
+// Error is a classified failure of a Kubernetes API call, carrying what was
+// being done and where so it can be explained to the user
+type Error struct {
+	Kind      ErrorKind
+	Op        string // One of the Op constants
+	Resource  string // Plural resource, e.g. "pods"
+	Name      string // Empty for list and watch
+	Namespace string // Empty for cluster-scoped or all-namespace calls
+	Context   string
+	Server    string
+
+	// RetryAfter is the delay the API server asked for, if any
+	RetryAfter time.Duration
+
+	Err error
+}
+
+// Error returns the technical description, including the underlying error
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s %s", e.Op, e.target())
+	if e.Context != "" {
+		msg += fmt.Sprintf(" (context %s)", e.Context)
+	}
+	return fmt.Sprintf("%s: %v", msg, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Retryable returns true when the call may succeed if simply tried again
+func (e *Error) Retryable() bool {
+	switch e.Kind {
+	case ErrTimeout, ErrThrottled, ErrConnectionFailed:
+		return true
+	}
+	return false
+}
+
+// UserMessage explains the failure in one line, with what to do about it
+func (e *Error) UserMessage() string {
+	return e.message(0)
+}
+
+// message renders the user message; a positive retryIn replaces the advice
+// of a retryable error with when it will be retried
+func (e *Error) message(retryIn time.Duration) string {
+	var problem, advice string
+	switch e.Kind {
+	case ErrNotFound:
+		problem = fmt.Sprintf("%s not found", e.target())
+		advice = "it may have been deleted"
+	case ErrForbidden:
+		problem = fmt.Sprintf("permission denied %s %s", e.verb(), e.target())
+		advice = "check your RBAC role"
+	case ErrUnauthorized:
+		problem = fmt.Sprintf("not authorized by %s", e.where())
+		advice = "your credentials may have expired; log in again"
+	case ErrTimeout:
+		problem = fmt.Sprintf("timed out %s %s", e.verb(), e.target())
+		advice = "the API server may be overloaded"
+	case ErrConflict:
+		problem = fmt.Sprintf("%s was changed while %s it", e.target(), e.verb())
+		advice = "refresh and try again"
+	case ErrThrottled:
+		problem = fmt.Sprintf("API server is throttling requests from %s", e.where())
+		advice = "slow down the refresh interval"
+		if e.RetryAfter > retryIn {
+			retryIn = e.RetryAfter
+		}
+	case ErrConnectionFailed:
+		problem = fmt.Sprintf("API server unreachable at %s", e.where())
+		advice = "check your network, VPN or kubeconfig"
+	default:
+		if e.Op == "" {
+			return e.Err.Error()
+		}
+		return fmt.Sprintf("failed %s %s: %v", e.verb(), e.target(), e.Err)
+	}
+
+	if retryIn > 0 && e.Retryable() {
+		advice = fmt.Sprintf("retrying in %s", retryIn.Round(time.Second))
+	}
+	return problem + " — " + advice
+}
+
+// verb describes the operation for a message, e.g. "listing"
+func (e *Error) verb() string {
+	switch e.Op {
+	case OpList:
+		return "listing"
+	case OpGet:
+		return "getting"
+	case OpCreate:
+		return "creating"
+	case OpDelete:
+		return "deleting"
+	case OpPatch:
+		return "patching"
+	case OpLogs:
+		return "streaming logs of"
+	case OpWatch:
+		return "watching"
+	case OpExec:
+		return "opening a shell in"
+	case OpScale:
+		return "scaling"
+	default:
+		return "accessing"
+	}
+}
+
+// target describes the object or list involved, e.g. "secrets in payments"
+func (e *Error) target() string {
+	target := e.Resource
+	if target == "" {
+		target = "resource"
+	}
+	if e.Name != "" {
+		target += "/" + e.Name
+	}
+	if e.Namespace != "" {
+		target += " in " + e.Namespace
+	}
+	return target
+}
+
+// where names the API server, falling back to the context
+func (e *Error) where() string {
+	switch {
+	case e.Server != "":
+		return e.Server
+	case e.Context != "":
+		return "context " + e.Context
+	default:
+		return "the cluster"
+	}
+}
+
+// ClassifyError sorts a raw error from client-go or the network into a kind
+func ClassifyError(err error) ErrorKind {
+	if err == nil {
+		return ErrUnknown
+	}
+
+	var kerr *Error
+	if errors.As(err, &kerr) {
+		return kerr.Kind
+	}
+
+	switch {
+	case apierrors.IsNotFound(err):
+		return ErrNotFound
+	case apierrors.IsForbidden(err):
+		return ErrForbidden
+	case apierrors.IsUnauthorized(err):
+		return ErrUnauthorized
+	case apierrors.IsConflict(err), apierrors.IsAlreadyExists(err):
+		return ErrConflict
+	case apierrors.IsTooManyRequests(err):
+		return ErrThrottled
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return ErrTimeout
+	}
+
+	// A failed dial means the server is unreachable, even when it timed out
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return ErrConnectionFailed
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return ErrConnectionFailed
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EHOSTUNREACH) || errors.Is(err, syscall.ENETUNREACH) {
+		return ErrConnectionFailed
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return ErrTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrTimeout
+	}
+
+	return ErrUnknown
+}
+
+// WrapError classifies err and records the call it came from. It returns nil
+// for a nil error and leaves an already classified error unchanged.
+func WrapError(err error, op, resource, namespace, name string) error {
+	if err == nil {
+		return nil
+	}
+	var kerr *Error
+	if errors.As(err, &kerr) {
+		return err
+	}
+
+	wrapped := &Error{
+		Kind:      ClassifyError(err),
+		Op:        op,
+		Resource:  resource,
+		Name:      name,
+		Namespace: namespace,
+		Err:       err,
+	}
+	if delay, ok := apierrors.SuggestsClientDelay(err); ok {
+		wrapped.RetryAfter = time.Duration(delay) * time.Second
+	}
+	return wrapped
+}
+
+// wrapError is WrapError filling in the client's context and API server
+func (c *Client) wrapError(err error, op, resource, namespace, name string) error {
+	var existing *Error
+	if err == nil || errors.As(err, &existing) {
+		return err
+	}
+
+	kerr := WrapError(err, op, resource, namespace, name).(*Error)
+	kerr.Context = c.contextName
+	if c.config != nil {
+		kerr.Server = c.config.Host
+	}
+	return kerr
+}
+
+// UserMessage explains any error in one line for the UI. Classified errors
+// get an actionable message; others are classified on the spot.
+func UserMessage(err error) string {
+	return RetryMessage(err, 0)
+}
+
+// RetryMessage is UserMessage for an error that will be retried in retryIn,
+// saying so in place of the advice when the error is retryable
+func RetryMessage(err error, retryIn time.Duration) string {
+	if err == nil {
+		return ""
+	}
+	var kerr *Error
+	if !errors.As(err, &kerr) {
+		kerr = &Error{Kind: ClassifyError(err), Err: err}
+	}
+	// Joined errors print one per line; keep the message to one
+	return strings.ReplaceAll(kerr.message(retryIn), "\n", "; ")
+}
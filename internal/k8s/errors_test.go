@@ -0,0 +1,164 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClassifyError(t *testing.T) {
+	pods := schema.GroupResource{Resource: "pods"}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected ErrorKind
+	}{
+		{"nil", nil, ErrUnknown},
+		{"plain error", errors.New("boom"), ErrUnknown},
+		{"not found", apierrors.NewNotFound(pods, "web-1"), ErrNotFound},
+		{"forbidden", apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "", errors.New("no RBAC")), ErrForbidden},
+		{"unauthorized", apierrors.NewUnauthorized("token expired"), ErrUnauthorized},
+		{"conflict", apierrors.NewConflict(pods, "web-1", errors.New("stale")), ErrConflict},
+		{"already exists", apierrors.NewAlreadyExists(pods, "web-1"), ErrConflict},
+		{"too many requests", apierrors.NewTooManyRequests("slow down", 8), ErrThrottled},
+		{"server timeout", apierrors.NewServerTimeout(pods, "list", 2), ErrTimeout},
+		{"gateway timeout", apierrors.NewTimeoutError("took too long", 0), ErrTimeout},
+		{"context deadline", context.DeadlineExceeded, ErrTimeout},
+		{"wrapped deadline", fmt.Errorf("list: %w", context.DeadlineExceeded), ErrTimeout},
+		{
+			"connection refused",
+			&url.Error{Op: "Get", URL: "https://10.0.0.1:6443/api", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}},
+			ErrConnectionFailed,
+		},
+		{
+			"dial timeout",
+			&url.Error{Op: "Get", URL: "https://10.0.0.1:6443/api", Err: &net.OpError{Op: "dial", Net: "tcp", Err: timeoutError{}}},
+			ErrConnectionFailed,
+		},
+		{"dns failure", &net.DNSError{Err: "no such host", Name: "api.example.com", IsNotFound: true}, ErrConnectionFailed},
+		{"connection reset", fmt.Errorf("read: %w", syscall.ECONNRESET), ErrConnectionFailed},
+		{
+			"read timeout",
+			&url.Error{Op: "Get", URL: "https://10.0.0.1:6443/api", Err: &net.OpError{Op: "read", Net: "tcp", Err: timeoutError{}}},
+			ErrTimeout,
+		},
+		{"already classified", &Error{Kind: ErrForbidden, Err: errors.New("x")}, ErrForbidden},
+		{"joined errors", errors.Join(errors.New("context a: boom"), fmt.Errorf("context b: %w", apierrors.NewUnauthorized(""))), ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyError(tt.err); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUserMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		retryIn  time.Duration
+		expected string
+	}{
+		{
+			name:     "forbidden list",
+			err:      WrapError(apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "", errors.New("no RBAC")), OpList, "secrets", "payments", ""),
+			expected: "permission denied listing secrets in payments — check your RBAC role",
+		},
+		{
+			name:     "not found",
+			err:      WrapError(apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "web-1"), OpGet, "pods", "default", "web-1"),
+			expected: "pods/web-1 in default not found — it may have been deleted",
+		},
+		{
+			name:     "unreachable with retry",
+			err:      &Error{Kind: ErrConnectionFailed, Op: OpList, Resource: "pods", Server: "https://10.0.0.1:6443", Err: syscall.ECONNREFUSED},
+			retryIn:  8 * time.Second,
+			expected: "API server unreachable at https://10.0.0.1:6443 — retrying in 8s",
+		},
+		{
+			name:     "unreachable without retry",
+			err:      &Error{Kind: ErrConnectionFailed, Op: OpList, Resource: "pods", Context: "prod", Err: syscall.ECONNREFUSED},
+			expected: "API server unreachable at context prod — check your network, VPN or kubeconfig",
+		},
+		{
+			name:     "throttled uses the server's delay when longer",
+			err:      WrapError(apierrors.NewTooManyRequests("slow down", 30), OpList, "pods", "default", ""),
+			retryIn:  5 * time.Second,
+			expected: "API server is throttling requests from the cluster — retrying in 30s",
+		},
+		{
+			name:     "non-retryable ignores retry",
+			err:      WrapError(apierrors.NewUnauthorized("expired"), OpList, "pods", "default", ""),
+			retryIn:  5 * time.Second,
+			expected: "not authorized by the cluster — your credentials may have expired; log in again",
+		},
+		{
+			name:     "unknown with operation",
+			err:      WrapError(errors.New("boom"), OpDelete, "pods", "default", "web-1"),
+			expected: "failed deleting pods/web-1 in default: boom",
+		},
+		{
+			name:     "unclassified plain error",
+			err:      errors.New("boom"),
+			expected: "boom",
+		},
+		{
+			name:     "joined errors stay on one line",
+			err:      errors.Join(errors.New("context a: boom"), errors.New("context b: bang")),
+			expected: "context a: boom; context b: bang",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RetryMessage(tt.err, tt.retryIn); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	if WrapError(nil, OpList, "pods", "default", "") != nil {
+		t.Error("Expected nil for a nil error")
+	}
+
+	raw := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("no RBAC"))
+	err := WrapError(raw, OpList, "pods", "default", "")
+
+	var kerr *Error
+	if !errors.As(err, &kerr) || kerr.Kind != ErrForbidden {
+		t.Fatalf("Expected a Forbidden *Error, got %v", err)
+	}
+	if !apierrors.IsForbidden(err) {
+		t.Error("Expected the wrapped error to still satisfy apierrors.IsForbidden")
+	}
+	if !strings.Contains(err.Error(), "list pods in default") {
+		t.Errorf("Expected Error() to describe the call, got %q", err.Error())
+	}
+
+	// Wrapping again keeps the original details
+	if again := WrapError(fmt.Errorf("outer: %w", err), OpGet, "nodes", "", ""); !errors.As(again, &kerr) || kerr.Op != OpList {
+		t.Error("Expected an already classified error to be left unchanged")
+	}
+}
+
+// timeoutError is a net.Error that timed out
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
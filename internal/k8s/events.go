@@ -0,0 +1,215 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// EventKind returns the Kind of events about a resource type, which may be
+// named by its plural as the views do (e.g. "Pods")
+func EventKind(resourceType string) string {
+	switch strings.ToLower(resourceType) {
+	case "pod", "pods":
+		return "Pod"
+	case "deployment", "deployments":
+		return "Deployment"
+	case "statefulset", "statefulsets":
+		return "StatefulSet"
+	case "service", "services":
+		return "Service"
+	case "ingress", "ingresses":
+		return "Ingress"
+	case "gateway", "gateways":
+		return "Gateway"
+	case "httproute", "httproutes":
+		return "HTTPRoute"
+	case "configmap", "configmaps":
+		return "ConfigMap"
+	case "secret", "secrets":
+		return "Secret"
+	case "serviceaccount", "serviceaccounts":
+		return "ServiceAccount"
+	case "role", "roles":
+		return "Role"
+	case "clusterrole", "clusterroles":
+		return "ClusterRole"
+	case "rolebinding", "rolebindings":
+		return "RoleBinding"
+	case "clusterrolebinding", "clusterrolebindings":
+		return "ClusterRoleBinding"
+	case "node", "nodes":
+		return "Node"
+	case "job", "jobs":
+		return "Job"
+	case "cronjob", "cronjobs":
+		return "CronJob"
+	}
+	return resourceType
+}
+
+// EventLastSeen returns when an event last occurred, falling back through the
+// fields older and newer event producers fill in
+func EventLastSeen(event v1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// ListEventsFor returns the events about one object, oldest first. Only
+// events last seen at or after since are returned, so a caller can fetch just
+// what changed since its newest event; a zero since returns them all. An event
+// that recurs keeps its UID, so callers merge by UID.
+func (c *Client) ListEventsFor(ctx context.Context, resourceType, namespace, name string, since time.Time) ([]v1.Event, error) {
+	kind := EventKind(resourceType)
+	selector := fields.Set{
+		"involvedObject.kind": kind,
+		"involvedObject.name": name,
+	}.AsSelector().String()
+
+	listed, err := listAll[v1.Event](ctx, metav1.ListOptions{FieldSelector: selector}, c.clientset.CoreV1().Events(namespace).List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "events", namespace, "")
+	}
+
+	var events []v1.Event
+	for _, event := range listed {
+		// The field selector is not applied everywhere (e.g. fake clients)
+		if event.InvolvedObject.Kind != kind || event.InvolvedObject.Name != name {
+			continue
+		}
+		if !since.IsZero() && EventLastSeen(event).Before(since) {
+			continue
+		}
+		events = append(events, event)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventLastSeen(events[i]).Before(EventLastSeen(events[j]))
+	})
+	return events, nil
+}
+
+// GetEventsForObject returns the events about one object from both the core
+// and the events.k8s.io APIs, oldest first. An empty uid matches any object
+// of that kind and name, e.g. one deleted and created again; an empty name
+// returns every event in the namespace, or in all namespaces when namespace
+// is empty too. The two APIs serve the same events, so they are merged by
+// UID, and a cluster not serving events.k8s.io is not an error.
+func (c *Client) GetEventsForObject(ctx context.Context, namespace, kind, name string, uid types.UID) ([]v1.Event, error) {
+	involved := fields.Set{}
+	regarding := fields.Set{}
+	if name != "" {
+		involved["involvedObject.kind"], involved["involvedObject.name"] = kind, name
+		regarding["regarding.kind"], regarding["regarding.name"] = kind, name
+		if uid != "" {
+			involved["involvedObject.uid"], regarding["regarding.uid"] = string(uid), string(uid)
+		}
+	}
+
+	listed, err := listAll[v1.Event](ctx, metav1.ListOptions{FieldSelector: involved.AsSelector().String()}, c.clientset.CoreV1().Events(namespace).List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "events", namespace, "")
+	}
+	newer, err := listAll[eventsv1.Event](ctx, metav1.ListOptions{FieldSelector: regarding.AsSelector().String()}, c.clientset.EventsV1().Events(namespace).List)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, c.wrapError(err, OpList, "events", namespace, "")
+	}
+	for _, event := range newer {
+		listed = append(listed, coreEvent(event))
+	}
+
+	seen := make(map[types.UID]bool, len(listed))
+	var events []v1.Event
+	for _, event := range listed {
+		// The field selectors are not applied everywhere (e.g. fake clients)
+		object := event.InvolvedObject
+		if name != "" && (object.Kind != kind || object.Name != name || (uid != "" && object.UID != uid)) {
+			continue
+		}
+		if event.UID != "" && seen[event.UID] {
+			continue
+		}
+		seen[event.UID] = true
+		events = append(events, event)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventLastSeen(events[i]).Before(EventLastSeen(events[j]))
+	})
+	return events, nil
+}
+
+// coreEvent converts an events.k8s.io event to the core one it mirrors
+func coreEvent(event eventsv1.Event) v1.Event {
+	converted := v1.Event{
+		ObjectMeta:          event.ObjectMeta,
+		InvolvedObject:      event.Regarding,
+		Reason:              event.Reason,
+		Message:             event.Note,
+		Type:                event.Type,
+		Action:              event.Action,
+		Related:             event.Related,
+		EventTime:           event.EventTime,
+		Count:               event.DeprecatedCount,
+		FirstTimestamp:      event.DeprecatedFirstTimestamp,
+		LastTimestamp:       event.DeprecatedLastTimestamp,
+		Source:              event.DeprecatedSource,
+		ReportingController: event.ReportingController,
+		ReportingInstance:   event.ReportingInstance,
+	}
+	if event.Series != nil {
+		converted.Series = &v1.EventSeries{Count: event.Series.Count, LastObservedTime: event.Series.LastObservedTime}
+	}
+	return converted
+}
+
+// ListEvents returns the events in a namespace, or in all namespaces when
+// namespace is empty, oldest first, with the resource version to watch for
+// newer ones from
+func (c *Client) ListEvents(ctx context.Context, namespace string) ([]v1.Event, string, error) {
+	listed, err := listPaged[v1.Event](ctx, metav1.ListOptions{}, 0, c.clientset.CoreV1().Events(namespace).List)
+	if err != nil {
+		return nil, "", c.wrapError(err, OpList, "events", namespace, "")
+	}
+	events := listed.items
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventLastSeen(events[i]).Before(EventLastSeen(events[j]))
+	})
+	return events, listed.resourceVersion, nil
+}
+
+// WatchEvents watches the events in a namespace, or in all namespaces when
+// namespace is empty, for changes after resourceVersion
+func (c *Client) WatchEvents(ctx context.Context, namespace, resourceVersion string) (watch.Interface, error) {
+	w, err := c.clientset.CoreV1().Events(namespace).Watch(ctx, metav1.ListOptions{ResourceVersion: resourceVersion})
+	return c.watched(w, err, "events", namespace)
+}
+
+// EventCount returns how many times an event occurred, from whichever of
+// the count fields its producer fills in
+func EventCount(event v1.Event) int32 {
+	count := event.Count
+	if event.Series != nil && event.Series.Count > count {
+		count = event.Series.Count
+	}
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
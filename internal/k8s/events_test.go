@@ -0,0 +1,124 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testEvent(name, kind, object, reason string, lastSeen time.Time) *v1.Event {
+	return &v1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)},
+		InvolvedObject: v1.ObjectReference{Kind: kind, Name: object, Namespace: "default"},
+		Reason:         reason,
+		Type:           v1.EventTypeNormal,
+		LastTimestamp:  metav1.NewTime(lastSeen),
+	}
+}
+
+func TestListEventsFor(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	client := &Client{clientset: fake.NewSimpleClientset(
+		testEvent("pulled", "Pod", "web-1", "Pulled", now.Add(-5*time.Minute)),
+		testEvent("scheduled", "Pod", "web-1", "Scheduled", now.Add(-10*time.Minute)),
+		testEvent("backoff", "Pod", "web-1", "BackOff", now),
+		testEvent("other-pod", "Pod", "web-2", "Pulled", now),
+		testEvent("same-name", "Deployment", "web-1", "ScalingReplicaSet", now),
+	)}
+	ctx := context.Background()
+
+	events, err := client.ListEventsFor(ctx, "Pods", "default", "web-1", time.Time{})
+	if err != nil {
+		t.Fatalf("ListEventsFor failed: %v", err)
+	}
+	var reasons []string
+	for _, event := range events {
+		reasons = append(reasons, event.Reason)
+	}
+	if len(reasons) != 3 || reasons[0] != "Scheduled" || reasons[1] != "Pulled" || reasons[2] != "BackOff" {
+		t.Errorf("Expected the pod's events oldest first, got %v", reasons)
+	}
+
+	// Only events last seen at or after since
+	events, err = client.ListEventsFor(ctx, "Pods", "default", "web-1", now.Add(-5*time.Minute))
+	if err != nil {
+		t.Fatalf("ListEventsFor failed: %v", err)
+	}
+	if len(events) != 2 || events[0].Reason != "Pulled" {
+		t.Errorf("Expected the two newer events, got %d", len(events))
+	}
+}
+
+func TestGetEventsForObject(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	scheduled := testEvent("scheduled", "Pod", "web-1", "Scheduled", now.Add(-10*time.Minute))
+	scheduled.InvolvedObject.UID = "pod-uid"
+	earlier := testEvent("earlier-pod", "Pod", "web-1", "Killing", now.Add(-time.Hour))
+	earlier.InvolvedObject.UID = "old-pod-uid"
+	// The events.k8s.io API serves the same events, and some only it has
+	// the series of
+	mirrored := &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "scheduled", Namespace: "default", UID: "scheduled"},
+		Regarding:  v1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "default", UID: "pod-uid"},
+		Reason:     "Scheduled",
+	}
+	backoff := &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "backoff", Namespace: "default", UID: "backoff"},
+		Regarding:  v1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "default", UID: "pod-uid"},
+		Reason:     "BackOff",
+		Note:       "Back-off restarting failed container",
+		Type:       v1.EventTypeWarning,
+		EventTime:  metav1.NewMicroTime(now.Add(-5 * time.Minute)),
+		Series:     &eventsv1.EventSeries{Count: 7, LastObservedTime: metav1.NewMicroTime(now)},
+	}
+	client := &Client{clientset: fake.NewSimpleClientset(
+		scheduled, earlier, mirrored, backoff,
+		testEvent("other-pod", "Pod", "web-2", "Pulled", now),
+	)}
+	ctx := context.Background()
+
+	events, err := client.GetEventsForObject(ctx, "default", "Pod", "web-1", "pod-uid")
+	if err != nil {
+		t.Fatalf("GetEventsForObject failed: %v", err)
+	}
+	if len(events) != 2 || events[0].Reason != "Scheduled" || events[1].Reason != "BackOff" {
+		t.Fatalf("Expected this pod's two events once each, oldest first, got %v", events)
+	}
+	if got := events[1]; got.Message != "Back-off restarting failed container" || got.Type != v1.EventTypeWarning || EventCount(got) != 7 || !EventLastSeen(got).Equal(now) {
+		t.Errorf("Expected the events.k8s.io event converted, got %+v", got)
+	}
+
+	// Without a UID, earlier objects of the name match too
+	if events, _ := client.GetEventsForObject(ctx, "default", "Pod", "web-1", ""); len(events) != 3 {
+		t.Errorf("Expected every web-1 pod's events, got %d", len(events))
+	}
+
+	// Without a name, the namespace's events
+	if events, _ := client.GetEventsForObject(ctx, "default", "", "", ""); len(events) != 4 {
+		t.Errorf("Expected the namespace's four events, got %d", len(events))
+	}
+}
+
+func TestEventLastSeen(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	event := v1.Event{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))}}
+	if got := EventLastSeen(event); !got.Equal(now.Add(-time.Hour)) {
+		t.Errorf("Expected the creation time without timestamps, got %v", got)
+	}
+
+	event.EventTime = metav1.NewMicroTime(now.Add(-time.Minute))
+	if got := EventLastSeen(event); !got.Equal(now.Add(-time.Minute)) {
+		t.Errorf("Expected the event time, got %v", got)
+	}
+
+	event.LastTimestamp = metav1.NewTime(now)
+	if got := EventLastSeen(event); !got.Equal(now) {
+		t.Errorf("Expected the last timestamp, got %v", got)
+	}
+}
@@ -0,0 +1,51 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/utils/exec"
+)
+
+// shellCommand starts bash where the image has it and sh otherwise
+var shellCommand = []string{"/bin/sh", "-c", "command -v bash >/dev/null 2>&1 && exec bash || exec sh"}
+
+// ExecShell runs an interactive shell in a pod's container on a terminal,
+// returning when the shell exits. stdout must be a terminal in raw mode, as
+// the container's terminal writes to it; sizes tells the container the
+// terminal's size as it changes. The shell exiting with a non-zero status
+// is not an error.
+func (c *Client) ExecShell(ctx context.Context, namespace, pod, container string, stdin io.Reader, stdout io.Writer, sizes remotecommand.TerminalSizeQueue) error {
+	req := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: container,
+			Command:   shellCommand,
+			Stdin:     true,
+			Stdout:    true,
+			TTY:       true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(c.config, "POST", req.URL())
+	if err != nil {
+		return c.wrapError(err, OpExec, "pods", namespace, pod)
+	}
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:             stdin,
+		Stdout:            stdout,
+		Tty:               true,
+		TerminalSizeQueue: sizes,
+	})
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) {
+		return nil
+	}
+	return c.wrapError(err, OpExec, "pods", namespace, pod)
+}
@@ -0,0 +1,183 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// describeDeletion renders a pending deletion for the top of describe output,
+// with the finalizers holding it up. It is empty when no deletion is pending.
+func describeDeletion(meta metav1.ObjectMeta, now time.Time) string {
+	status := core.TerminationStatus(meta, now)
+	if status == "" {
+		return ""
+	}
+
+	requested := meta.DeletionTimestamp.Time
+	var result strings.Builder
+	if core.IsStuckTerminating(status) {
+		result.WriteString(fmt.Sprintf("⚠ STUCK TERMINATING: deletion requested %s ago (%s)\n",
+			core.FormatDuration(now.Sub(requested)), core.FormatTimestamp(requested)))
+	} else {
+		result.WriteString(fmt.Sprintf("Terminating: deletion requested %s ago (%s)\n",
+			core.FormatDuration(now.Sub(requested)), core.FormatTimestamp(requested)))
+	}
+
+	if len(meta.Finalizers) == 0 {
+		result.WriteString("  No finalizers remain; it should be gone shortly\n")
+	} else {
+		result.WriteString("  Waiting on finalizers:\n")
+		for _, finalizer := range meta.Finalizers {
+			result.WriteString(fmt.Sprintf("    - %s\n", finalizer))
+		}
+	}
+	result.WriteString("\n")
+	return result.String()
+}
+
+// GetFinalizers returns the finalizers set on a resource, named by
+// its plural (e.g. "pods")
+func (c *Client) GetFinalizers(ctx context.Context, resource, namespace, name string) ([]string, error) {
+	obj, err := c.getObject(ctx, resource, namespace, name)
+	if err != nil {
+		return nil, c.wrapError(err, OpGet, resource, namespace, name)
+	}
+	return obj.GetFinalizers(), nil
+}
+
+// RemoveFinalizer removes one finalizer from a resource with a JSON patch. The
+// patch tests that the finalizer is still at the position it was read from,
+// so a concurrent change fails the patch instead of removing the wrong one.
+func (c *Client) RemoveFinalizer(ctx context.Context, resource, namespace, name, finalizer string) error {
+	obj, err := c.getObject(ctx, resource, namespace, name)
+	if err != nil {
+		return c.wrapError(err, OpGet, resource, namespace, name)
+	}
+
+	patch, err := finalizerRemovalPatch(obj.GetFinalizers(), finalizer)
+	if err != nil {
+		return err
+	}
+
+	return c.wrapError(c.patchObject(ctx, resource, namespace, name, patch), OpPatch, resource, namespace, name)
+}
+
+// finalizerRemovalPatch builds a JSON patch removing finalizer from finalizers
+func finalizerRemovalPatch(finalizers []string, finalizer string) ([]byte, error) {
+	for i, f := range finalizers {
+		if f != finalizer {
+			continue
+		}
+		path := fmt.Sprintf("/metadata/finalizers/%d", i)
+		return json.Marshal([]map[string]interface{}{
+			{"op": "test", "path": path, "value": finalizer},
+			{"op": "remove", "path": path},
+		})
+	}
+	return nil, fmt.Errorf("finalizer %s is not set", finalizer)
+}
+
+// getObject fetches a resource by its plural name; namespace is ignored for
+// cluster-scoped ones
+func (c *Client) getObject(ctx context.Context, resource, namespace, name string) (metav1.Object, error) {
+	opts := metav1.GetOptions{}
+	switch resource {
+	case "pods":
+		return asObject(c.clientset.CoreV1().Pods(namespace).Get(ctx, name, opts))
+	case "deployments":
+		return asObject(c.clientset.AppsV1().Deployments(namespace).Get(ctx, name, opts))
+	case "statefulsets":
+		return asObject(c.clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, opts))
+	case "services":
+		return asObject(c.clientset.CoreV1().Services(namespace).Get(ctx, name, opts))
+	case "ingresses":
+		return asObject(c.clientset.NetworkingV1().Ingresses(namespace).Get(ctx, name, opts))
+	case "configmaps":
+		return asObject(c.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, opts))
+	case "secrets":
+		return asObject(c.clientset.CoreV1().Secrets(namespace).Get(ctx, name, opts))
+	case "gateways":
+		return asObject(c.GetGateway(ctx, namespace, name))
+	case "httproutes":
+		return asObject(c.GetHTTPRoute(ctx, namespace, name))
+	case "serviceaccounts":
+		return asObject(c.clientset.CoreV1().ServiceAccounts(namespace).Get(ctx, name, opts))
+	case "roles":
+		return asObject(c.clientset.RbacV1().Roles(namespace).Get(ctx, name, opts))
+	case "clusterroles":
+		return asObject(c.clientset.RbacV1().ClusterRoles().Get(ctx, name, opts))
+	case "rolebindings":
+		return asObject(c.clientset.RbacV1().RoleBindings(namespace).Get(ctx, name, opts))
+	case "clusterrolebindings":
+		return asObject(c.clientset.RbacV1().ClusterRoleBindings().Get(ctx, name, opts))
+	case "nodes":
+		return asObject(c.clientset.CoreV1().Nodes().Get(ctx, name, opts))
+	case "jobs":
+		return asObject(c.clientset.BatchV1().Jobs(namespace).Get(ctx, name, opts))
+	case "cronjobs":
+		return asObject(c.clientset.BatchV1().CronJobs(namespace).Get(ctx, name, opts))
+	}
+	return nil, fmt.Errorf("finalizers are not supported for %s", resource)
+}
+
+// patchObject applies a JSON patch to a resource by its plural name
+func (c *Client) patchObject(ctx context.Context, resource, namespace, name string, patch []byte) error {
+	opts := metav1.PatchOptions{}
+	switch resource {
+	case "pods":
+		return patchErr(c.clientset.CoreV1().Pods(namespace).Patch(ctx, name, types.JSONPatchType, patch, opts))
+	case "deployments":
+		return patchErr(c.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.JSONPatchType, patch, opts))
+	case "statefulsets":
+		return patchErr(c.clientset.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.JSONPatchType, patch, opts))
+	case "services":
+		return patchErr(c.clientset.CoreV1().Services(namespace).Patch(ctx, name, types.JSONPatchType, patch, opts))
+	case "ingresses":
+		return patchErr(c.clientset.NetworkingV1().Ingresses(namespace).Patch(ctx, name, types.JSONPatchType, patch, opts))
+	case "configmaps":
+		return patchErr(c.clientset.CoreV1().ConfigMaps(namespace).Patch(ctx, name, types.JSONPatchType, patch, opts))
+	case "secrets":
+		return patchErr(c.clientset.CoreV1().Secrets(namespace).Patch(ctx, name, types.JSONPatchType, patch, opts))
+	case "gateways":
+		return c.patchDynamic(ctx, gatewayResource, namespace, name, patch)
+	case "httproutes":
+		return c.patchDynamic(ctx, httpRouteResource, namespace, name, patch)
+	case "serviceaccounts":
+		return patchErr(c.clientset.CoreV1().ServiceAccounts(namespace).Patch(ctx, name, types.JSONPatchType, patch, opts))
+	case "roles":
+		return patchErr(c.clientset.RbacV1().Roles(namespace).Patch(ctx, name, types.JSONPatchType, patch, opts))
+	case "clusterroles":
+		return patchErr(c.clientset.RbacV1().ClusterRoles().Patch(ctx, name, types.JSONPatchType, patch, opts))
+	case "rolebindings":
+		return patchErr(c.clientset.RbacV1().RoleBindings(namespace).Patch(ctx, name, types.JSONPatchType, patch, opts))
+	case "clusterrolebindings":
+		return patchErr(c.clientset.RbacV1().ClusterRoleBindings().Patch(ctx, name, types.JSONPatchType, patch, opts))
+	case "nodes":
+		return patchErr(c.clientset.CoreV1().Nodes().Patch(ctx, name, types.JSONPatchType, patch, opts))
+	case "jobs":
+		return patchErr(c.clientset.BatchV1().Jobs(namespace).Patch(ctx, name, types.JSONPatchType, patch, opts))
+	case "cronjobs":
+		return patchErr(c.clientset.BatchV1().CronJobs(namespace).Patch(ctx, name, types.JSONPatchType, patch, opts))
+	}
+	return fmt.Errorf("finalizers are not supported for %s", resource)
+}
+
+// asObject adapts a typed Get result to metav1.Object
+func asObject[T metav1.Object](obj T, err error) (metav1.Object, error) {
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// patchErr drops the patched object from a typed Patch result
+func patchErr[T any](_ T, err error) error {
+	return err
+}
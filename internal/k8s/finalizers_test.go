@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRemoveFinalizer(t *testing.T) {
+	ctx := context.Background()
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:       "web-1",
+		Namespace:  "default",
+		Finalizers: []string{"example.com/first", "example.com/second", "example.com/third"},
+	}}
+	client := &Client{clientset: fake.NewSimpleClientset(pod)}
+
+	if err := client.RemoveFinalizer(ctx, "pods", "default", "web-1", "example.com/second"); err != nil {
+		t.Fatalf("RemoveFinalizer failed: %v", err)
+	}
+
+	finalizers, err := client.GetFinalizers(ctx, "pods", "default", "web-1")
+	if err != nil {
+		t.Fatalf("GetFinalizers failed: %v", err)
+	}
+	expected := []string{"example.com/first", "example.com/third"}
+	if !reflect.DeepEqual(finalizers, expected) {
+		t.Errorf("Expected %v, got %v", expected, finalizers)
+	}
+
+	if err := client.RemoveFinalizer(ctx, "pods", "default", "web-1", "example.com/second"); err == nil {
+		t.Error("Expected an error removing a finalizer that is not set")
+	}
+
+	err = client.RemoveFinalizer(ctx, "pods", "default", "missing", "example.com/first")
+	if ClassifyError(err) != ErrNotFound {
+		t.Errorf("Expected NotFound for a missing pod, got %v", err)
+	}
+
+	if _, err := client.GetFinalizers(ctx, "nodes", "", "node-1"); err == nil {
+		t.Error("Expected an error for an unsupported resource")
+	}
+}
+
+func TestDescribeDeletion(t *testing.T) {
+	now := time.Now()
+	deleted := metav1.NewTime(now.Add(-12 * time.Minute))
+
+	if got := describeDeletion(metav1.ObjectMeta{}, now); got != "" {
+		t.Errorf("Expected nothing for a resource not being deleted, got %q", got)
+	}
+
+	got := describeDeletion(metav1.ObjectMeta{
+		DeletionTimestamp: &deleted,
+		Finalizers:        []string{"example.com/cleanup"},
+	}, now)
+	for _, want := range []string{"STUCK TERMINATING", "12m ago", "example.com/cleanup"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+}
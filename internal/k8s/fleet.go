@@ -0,0 +1,37 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// FindPods returns the pods whose names match a fleet search query. A
+// single namespace is listed when the query names one; otherwise every
+// namespace is.
+func (c *Client) FindPods(ctx context.Context, query core.FleetQuery) ([]v1.Pod, error) {
+	pods, err := c.ListPods(ctx, query.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	var matches []v1.Pod
+	for _, pod := range pods {
+		if query.MatchesName(pod.Name) {
+			matches = append(matches, pod)
+		}
+	}
+	return matches, nil
+}
+
+// NewClientForContext creates a client for one context of the kubeconfig
+// at path, or of the default kubeconfig when path is empty. Nothing is
+// contacted until the client is used.
+func NewClientForContext(path, contextName string) (*Client, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if path != "" {
+		loadingRules.ExplicitPath = path
+	}
+	return NewClientWithContext(loadingRules, &clientcmd.ConfigOverrides{CurrentContext: contextName})
+}
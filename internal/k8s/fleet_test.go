@@ -0,0 +1,78 @@
+package k8s
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFindPods(t *testing.T) {
+	pod := func(namespace, name string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	client := &Client{clientset: fake.NewSimpleClientset(
+		pod("payments", "checkout-7f9c-abcde"),
+		pod("payments", "cart-1"),
+		pod("staging", "checkout-1234-xyz"),
+	)}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"checkout", 2},
+		{"payments/checkout", 1},
+		{"checkout-*-xyz", 1},
+		{"missing", 0},
+	}
+	for _, tt := range tests {
+		pods, err := client.FindPods(context.Background(), core.ParseFleetQuery(tt.query))
+		if err != nil {
+			t.Fatalf("FindPods(%q) failed: %v", tt.query, err)
+		}
+		if len(pods) != tt.want {
+			t.Errorf("FindPods(%q) found %d pods, want %d", tt.query, len(pods), tt.want)
+		}
+	}
+}
+
+func TestNewClientForContext(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	err := os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev
+  cluster: {server: "https://dev.example.com"}
+- name: prod
+  cluster: {server: "https://prod.example.com"}
+users:
+- name: me
+  user: {token: secret}
+contexts:
+- name: dev
+  context: {cluster: dev, user: me}
+- name: prod
+  context: {cluster: prod, user: me}
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewClientForContext(kubeconfig, "prod")
+	if err != nil {
+		t.Fatalf("NewClientForContext failed: %v", err)
+	}
+	if client.ContextName() != "prod" {
+		t.Errorf("Expected a client for prod, got %q", client.ContextName())
+	}
+	if _, err := NewClientForContext(kubeconfig, "missing"); err == nil {
+		t.Error("Expected an error for a context not in the kubeconfig")
+	}
+}
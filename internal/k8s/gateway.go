@@ -0,0 +1,422 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// The Gateway API resources, served only by clusters with their CRDs
+var (
+	gatewayResource   = schema.GroupVersionResource{Group: core.GatewayAPIGroup, Version: "v1", Resource: "gateways"}
+	httpRouteResource = schema.GroupVersionResource{Group: core.GatewayAPIGroup, Version: "v1", Resource: "httproutes"}
+)
+
+// optionalResources maps the optional resource types to the API resource
+// that lists them
+var optionalResources = map[core.ResourceType]schema.GroupVersionResource{
+	core.ResourceTypeGateway:   gatewayResource,
+	core.ResourceTypeHTTPRoute: httpRouteResource,
+}
+
+// InstalledOptionalTypes returns which of the optional resource types the
+// cluster serves, from API discovery. A group the cluster does not have is
+// not an error; its types are simply not installed.
+func (c *Client) InstalledOptionalTypes() (map[core.ResourceType]bool, error) {
+	installed := make(map[core.ResourceType]bool, len(optionalResources))
+	served := make(map[schema.GroupVersion]map[string]bool)
+	for resourceType, gvr := range optionalResources {
+		gv := gvr.GroupVersion()
+		resources, ok := served[gv]
+		if !ok {
+			list, err := c.clientset.Discovery().ServerResourcesForGroupVersion(gv.String())
+			if err != nil && !apierrors.IsNotFound(err) {
+				return nil, c.wrapError(err, OpList, gvr.Resource, "", "")
+			}
+			resources = make(map[string]bool)
+			if list != nil {
+				for _, r := range list.APIResources {
+					resources[r.Name] = true
+				}
+			}
+			served[gv] = resources
+		}
+		installed[resourceType] = resources[gvr.Resource]
+	}
+	return installed, nil
+}
+
+// ListGateways returns gateways in a namespace
+func (c *Client) ListGateways(ctx context.Context, namespace string) ([]core.Gateway, error) {
+	items, err := c.listDynamic(ctx, gatewayResource, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return decodeItems[core.Gateway](items)
+}
+
+// WatchGateways watches for gateway changes
+func (c *Client) WatchGateways(ctx context.Context, namespace string) (watch.Interface, error) {
+	w, err := c.watchDynamic(ctx, gatewayResource, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return watch.Filter(w, decodeEvent[core.Gateway]), nil
+}
+
+// DeleteGateway deletes a gateway
+func (c *Client) DeleteGateway(ctx context.Context, namespace, name string) error {
+	return c.deleteDynamic(ctx, gatewayResource, namespace, name)
+}
+
+// GetGateway fetches one gateway
+func (c *Client) GetGateway(ctx context.Context, namespace, name string) (*core.Gateway, error) {
+	item, err := c.getDynamic(ctx, gatewayResource, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	gateway := &core.Gateway{}
+	return gateway, decodeItem(item, gateway)
+}
+
+// ListHTTPRoutes returns HTTP routes in a namespace
+func (c *Client) ListHTTPRoutes(ctx context.Context, namespace string) ([]core.HTTPRoute, error) {
+	items, err := c.listDynamic(ctx, httpRouteResource, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return decodeItems[core.HTTPRoute](items)
+}
+
+// WatchHTTPRoutes watches for HTTP route changes
+func (c *Client) WatchHTTPRoutes(ctx context.Context, namespace string) (watch.Interface, error) {
+	w, err := c.watchDynamic(ctx, httpRouteResource, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return watch.Filter(w, decodeEvent[core.HTTPRoute]), nil
+}
+
+// DeleteHTTPRoute deletes an HTTP route
+func (c *Client) DeleteHTTPRoute(ctx context.Context, namespace, name string) error {
+	return c.deleteDynamic(ctx, httpRouteResource, namespace, name)
+}
+
+// GetHTTPRoute fetches one HTTP route
+func (c *Client) GetHTTPRoute(ctx context.Context, namespace, name string) (*core.HTTPRoute, error) {
+	item, err := c.getDynamic(ctx, httpRouteResource, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	route := &core.HTTPRoute{}
+	return route, decodeItem(item, route)
+}
+
+// listDynamic lists a resource kubewatch has no typed client for
+func (c *Client) listDynamic(ctx context.Context, gvr schema.GroupVersionResource, namespace string) ([]unstructured.Unstructured, error) {
+	dyn, err := c.dynamic()
+	if err != nil {
+		return nil, err
+	}
+	items, err := listAll[unstructured.Unstructured](ctx, metav1.ListOptions{}, dyn.Resource(gvr).Namespace(namespace).List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, gvr.Resource, namespace, "")
+	}
+	return items, nil
+}
+
+// watchDynamic watches a resource kubewatch has no typed client for
+func (c *Client) watchDynamic(ctx context.Context, gvr schema.GroupVersionResource, namespace string) (watch.Interface, error) {
+	dyn, err := c.dynamic()
+	if err != nil {
+		return nil, err
+	}
+	w, err := dyn.Resource(gvr).Namespace(namespace).Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, gvr.Resource, namespace)
+}
+
+// getDynamic fetches one object of a resource kubewatch has no typed
+// client for
+func (c *Client) getDynamic(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	dyn, err := c.dynamic()
+	if err != nil {
+		return nil, err
+	}
+	item, err := dyn.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, c.wrapError(err, OpGet, gvr.Resource, namespace, name)
+	}
+	return item, nil
+}
+
+// deleteDynamic deletes an object of a resource kubewatch has no typed
+// client for
+func (c *Client) deleteDynamic(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) error {
+	dyn, err := c.dynamic()
+	if err != nil {
+		return err
+	}
+	err = dyn.Resource(gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	return c.wrapError(err, OpDelete, gvr.Resource, namespace, name)
+}
+
+// patchDynamic applies a JSON patch to an object of a resource kubewatch
+// has no typed client for
+func (c *Client) patchDynamic(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string, patch []byte) error {
+	dyn, err := c.dynamic()
+	if err != nil {
+		return err
+	}
+	_, err = dyn.Resource(gvr).Namespace(namespace).Patch(ctx, name, types.JSONPatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// decodeItem converts a dynamic client's object into a typed one
+func decodeItem(item *unstructured.Unstructured, into interface{}) error {
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, into); err != nil {
+		return fmt.Errorf("failed to decode %s %s: %w", item.GetKind(), item.GetName(), err)
+	}
+	return nil
+}
+
+// decodeItems converts a dynamic client's list into typed objects
+func decodeItems[T any](items []unstructured.Unstructured) ([]T, error) {
+	decoded := make([]T, len(items))
+	for i := range items {
+		if err := decodeItem(&items[i], &decoded[i]); err != nil {
+			return nil, err
+		}
+	}
+	return decoded, nil
+}
+
+// decodeEvent converts a dynamic watch event's object into a typed one, so
+// a watch delivers the same objects a list returns. An object that does not
+// decode, such as a watch's error status, is passed on as it is.
+func decodeEvent[T any, P interface {
+	*T
+	runtime.Object
+}](event watch.Event) (watch.Event, bool) {
+	if item, ok := event.Object.(*unstructured.Unstructured); ok {
+		obj := P(new(T))
+		if decodeItem(item, obj) == nil {
+			event.Object = obj
+		}
+	}
+	return event, true
+}
+
+// describeGateway returns detailed information about a gateway
+func (c *Client) describeGateway(ctx context.Context, name, namespace string) (string, error) {
+	gateway, err := c.GetGateway(ctx, namespace, name)
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	result.WriteString(describeDeletion(gateway.ObjectMeta, time.Now()))
+	result.WriteString(fmt.Sprintf("Name:         %s\n", gateway.Name))
+	result.WriteString(fmt.Sprintf("Namespace:    %s\n", gateway.Namespace))
+	result.WriteString(fmt.Sprintf("Class:        %s\n", gateway.Spec.GatewayClassName))
+	result.WriteString(fmt.Sprintf("Addresses:    %s\n", gateway.AddressList()))
+	result.WriteString(fmt.Sprintf("Programmed:   %s\n", gateway.Programmed()))
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(gateway.CreationTimestamp.Time)))
+
+	statuses := make(map[string]core.GatewayListenerStatus, len(gateway.Status.Listeners))
+	for _, status := range gateway.Status.Listeners {
+		statuses[status.Name] = status
+	}
+	if len(gateway.Spec.Listeners) > 0 {
+		result.WriteString("\nListeners:\n")
+		for _, listener := range gateway.Spec.Listeners {
+			hostname := listener.Hostname
+			if hostname == "" {
+				hostname = "*"
+			}
+			result.WriteString(fmt.Sprintf("  %s:\n", listener.Name))
+			result.WriteString(fmt.Sprintf("    Protocol:         %s\n", listener.Protocol))
+			result.WriteString(fmt.Sprintf("    Port:             %d\n", listener.Port))
+			result.WriteString(fmt.Sprintf("    Hostname:         %s\n", hostname))
+			if status, ok := statuses[listener.Name]; ok {
+				result.WriteString(fmt.Sprintf("    Attached Routes:  %d\n", status.AttachedRoutes))
+				result.WriteString(describeConditions("    ", status.Conditions))
+			}
+		}
+	}
+
+	if len(gateway.Status.Conditions) > 0 {
+		result.WriteString("\nConditions:\n")
+		result.WriteString(describeConditions("  ", gateway.Status.Conditions))
+	}
+
+	if len(gateway.Labels) > 0 {
+		result.WriteString("\nLabels:\n")
+		for k, v := range gateway.Labels {
+			result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
+		}
+	}
+
+	return result.String(), nil
+}
+
+// describeHTTPRoute returns detailed information about an HTTP route,
+// checking, as for an ingress, that each service it routes to exists and
+// that each parent gateway accepted it
+func (c *Client) describeHTTPRoute(ctx context.Context, name, namespace string) (string, error) {
+	route, err := c.GetHTTPRoute(ctx, namespace, name)
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	result.WriteString(describeDeletion(route.ObjectMeta, time.Now()))
+	result.WriteString(fmt.Sprintf("Name:         %s\n", route.Name))
+	result.WriteString(fmt.Sprintf("Namespace:    %s\n", route.Namespace))
+	hostnames := "*"
+	if len(route.Spec.Hostnames) > 0 {
+		hostnames = strings.Join(route.Spec.Hostnames, ", ")
+	}
+	result.WriteString(fmt.Sprintf("Hostnames:    %s\n", hostnames))
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(route.CreationTimestamp.Time)))
+
+	if len(route.Spec.ParentRefs) > 0 {
+		result.WriteString("\nParents:\n")
+		for i, ref := range route.Spec.ParentRefs {
+			acceptance := route.Acceptance(ref)
+			state := "✓ Accepted"
+			switch {
+			case !acceptance.Reported:
+				state = "? Not reported by its controller"
+			case !acceptance.Accepted:
+				state = "✗ Not accepted: " + acceptance.Reason
+			}
+			result.WriteString(fmt.Sprintf("  %s  %s\n", route.ParentNames()[i], state))
+		}
+	}
+
+	if len(route.Spec.Rules) > 0 {
+		result.WriteString("\nRules:\n")
+		services := make(map[string]string)
+		for i, rule := range route.Spec.Rules {
+			result.WriteString(fmt.Sprintf("  Rule %d:\n", i+1))
+			if len(rule.Matches) == 0 {
+				result.WriteString("    Match:    PathPrefix /\n")
+			}
+			for _, match := range rule.Matches {
+				result.WriteString(fmt.Sprintf("    Match:    %s\n", describeRouteMatch(match)))
+			}
+			for _, backend := range rule.BackendRefs {
+				result.WriteString(fmt.Sprintf("    Backend:  %s%s\n", describeBackendRef(route, backend), c.backendProblem(ctx, route, backend, services)))
+			}
+		}
+	}
+
+	for _, parent := range route.Status.Parents {
+		if len(parent.Conditions) == 0 {
+			continue
+		}
+		result.WriteString(fmt.Sprintf("\nConditions from %s (%s):\n", parent.ParentRef.Name, parent.ControllerName))
+		result.WriteString(describeConditions("  ", parent.Conditions))
+	}
+
+	if len(route.Labels) > 0 {
+		result.WriteString("\nLabels:\n")
+		for k, v := range route.Labels {
+			result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
+		}
+	}
+
+	return result.String(), nil
+}
+
+// describeRouteMatch describes what a request must have to match, e.g.
+// "PathPrefix /api, GET, header x-env=canary"
+func describeRouteMatch(match core.HTTPRouteMatch) string {
+	var parts []string
+	if match.Path != nil {
+		pathType := match.Path.Type
+		if pathType == "" {
+			pathType = "PathPrefix"
+		}
+		parts = append(parts, pathType+" "+match.Path.Value)
+	}
+	if match.Method != "" {
+		parts = append(parts, match.Method)
+	}
+	for _, header := range match.Headers {
+		parts = append(parts, fmt.Sprintf("header %s=%s", header.Name, header.Value))
+	}
+	if len(parts) == 0 {
+		return "any request"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeBackendRef names a backend as kubectl would, with its port and
+// weight, e.g. "service/api:8080 (weight 90)"
+func describeBackendRef(route *core.HTTPRoute, ref core.HTTPBackendRef) string {
+	kind := "service"
+	if !core.IsServiceBackend(ref) {
+		kind = strings.ToLower(ref.Kind)
+	}
+	name := ref.Name
+	if ns := route.BackendNamespace(ref); ns != route.Namespace {
+		name = ns + "/" + name
+	}
+	text := kind + "/" + name
+	if ref.Port != nil {
+		text += fmt.Sprintf(":%d", *ref.Port)
+	}
+	if ref.Weight != nil {
+		text += fmt.Sprintf(" (weight %d)", *ref.Weight)
+	}
+	return text
+}
+
+// backendProblem checks that a service backend exists, returning a note
+// for one that does not or could not be checked. Each service is looked up
+// once per describe; checked remembers the notes.
+func (c *Client) backendProblem(ctx context.Context, route *core.HTTPRoute, ref core.HTTPBackendRef, checked map[string]string) string {
+	if !core.IsServiceBackend(ref) {
+		return ""
+	}
+	namespace := route.BackendNamespace(ref)
+	key := namespace + "/" + ref.Name
+	if note, ok := checked[key]; ok {
+		return note
+	}
+	note := ""
+	_, err := c.clientset.CoreV1().Services(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		note = "  ✗ Service not found"
+	case err != nil:
+		note = "  ? " + UserMessage(c.wrapError(err, OpGet, "services", namespace, ref.Name))
+	}
+	checked[key] = note
+	return note
+}
+
+// describeConditions lists conditions one per line under indent, with the
+// reason and any message of each
+func describeConditions(indent string, conditions []metav1.Condition) string {
+	var b strings.Builder
+	for _, cond := range conditions {
+		line := fmt.Sprintf("%s%-14s %-8s %s", indent, cond.Type, cond.Status, cond.Reason)
+		if cond.Message != "" {
+			line += ": " + cond.Message
+		}
+		b.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,192 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newGatewayTestClient returns a client serving the web service and the
+// given Gateway API objects
+func newGatewayTestClient(t *testing.T, objects ...*unstructured.Unstructured) *Client {
+	t.Helper()
+	clientset := fake.NewSimpleClientset(&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}})
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		gatewayResource:   "GatewayList",
+		httpRouteResource: "HTTPRouteList",
+	})
+	for _, obj := range objects {
+		gvr := gatewayResource
+		if obj.GetKind() == "HTTPRoute" {
+			gvr = httpRouteResource
+		}
+		if _, err := dyn.Resource(gvr).Namespace("default").Create(context.Background(), obj, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("Failed to create %s: %v", obj.GetName(), err)
+		}
+	}
+	return &Client{clientset: clientset, dynamicClient: dyn}
+}
+
+// gatewayObject returns a Gateway API object as the dynamic client serves it
+func gatewayObject(kind, name string, spec, status map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": core.GatewayAPIGroup + "/v1",
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+		"spec":       spec,
+		"status":     status,
+	}}
+}
+
+func TestInstalledOptionalTypes(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	client := &Client{clientset: clientset}
+
+	installed, err := client.InstalledOptionalTypes()
+	if err != nil {
+		t.Fatalf("Expected a cluster without the Gateway API to be no error, got %v", err)
+	}
+	if installed[core.ResourceTypeGateway] || installed[core.ResourceTypeHTTPRoute] {
+		t.Errorf("Expected nothing installed, got %v", installed)
+	}
+
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+		GroupVersion: core.GatewayAPIGroup + "/v1",
+		APIResources: []metav1.APIResource{{Name: "gateways", Namespaced: true, Kind: "Gateway"}},
+	}}
+	installed, err = client.InstalledOptionalTypes()
+	if err != nil {
+		t.Fatalf("InstalledOptionalTypes failed: %v", err)
+	}
+	if !installed[core.ResourceTypeGateway] || installed[core.ResourceTypeHTTPRoute] {
+		t.Errorf("Expected only gateways installed, got %v", installed)
+	}
+}
+
+func TestListGatewayAPIResources(t *testing.T) {
+	ctx := context.Background()
+	client := newGatewayTestClient(t,
+		gatewayObject("Gateway", "public", map[string]interface{}{"gatewayClassName": "envoy"}, map[string]interface{}{
+			"addresses":  []interface{}{map[string]interface{}{"type": "IPAddress", "value": "203.0.113.10"}},
+			"conditions": []interface{}{map[string]interface{}{"type": "Programmed", "status": "True", "reason": "Programmed", "lastTransitionTime": "2024-01-01T00:00:00Z"}},
+		}),
+		gatewayObject("HTTPRoute", "web", map[string]interface{}{
+			"hostnames":  []interface{}{"web.example.com"},
+			"parentRefs": []interface{}{map[string]interface{}{"name": "public"}},
+		}, nil),
+	)
+
+	gateways, err := client.ListGateways(ctx, "default")
+	if err != nil || len(gateways) != 1 {
+		t.Fatalf("Expected one gateway, got %v (%v)", gateways, err)
+	}
+	if gw := gateways[0]; gw.Spec.GatewayClassName != "envoy" || gw.AddressList() != "203.0.113.10" || gw.Programmed() != "True" {
+		t.Errorf("Expected the gateway decoded, got %+v", gw)
+	}
+
+	routes, err := client.ListHTTPRoutes(ctx, "default")
+	if err != nil || len(routes) != 1 {
+		t.Fatalf("Expected one route, got %v (%v)", routes, err)
+	}
+	if names := routes[0].ParentNames(); len(names) != 1 || names[0] != "public" {
+		t.Errorf("Expected the route's parent decoded, got %v", names)
+	}
+
+	if err := client.DeleteHTTPRoute(ctx, "default", "web"); err != nil {
+		t.Fatalf("DeleteHTTPRoute failed: %v", err)
+	}
+	if routes, _ := client.ListHTTPRoutes(ctx, "default"); len(routes) != 0 {
+		t.Errorf("Expected the route deleted, got %v", routes)
+	}
+}
+
+// TestWatchGatewaysDecodesEvents tests that a watch delivers gateways as a
+// list returns them, not the dynamic client's objects
+func TestWatchGatewaysDecodesEvents(t *testing.T) {
+	client := managedFieldsServer(t)
+	w, err := client.WatchGateways(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("WatchGateways failed: %v", err)
+	}
+	defer w.Stop()
+
+	select {
+	case event := <-w.ResultChan():
+		if gw, ok := event.Object.(*core.Gateway); !ok || gw.Name != "web" {
+			t.Errorf("Expected a decoded gateway, got %T", event.Object)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected a watch event")
+	}
+}
+
+func TestDescribeGatewayAPIResources(t *testing.T) {
+	ctx := context.Background()
+	acceptedAt := "2024-01-01T00:00:00Z"
+	client := newGatewayTestClient(t,
+		gatewayObject("Gateway", "public", map[string]interface{}{
+			"gatewayClassName": "envoy",
+			"listeners": []interface{}{
+				map[string]interface{}{"name": "https", "protocol": "HTTPS", "port": int64(443), "hostname": "*.example.com"},
+			},
+		}, map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Programmed", "status": "False", "reason": "AddressNotAssigned", "message": "no load balancer yet", "lastTransitionTime": acceptedAt},
+			},
+			"listeners": []interface{}{
+				map[string]interface{}{"name": "https", "attachedRoutes": int64(2), "conditions": []interface{}{}},
+			},
+		}),
+		gatewayObject("HTTPRoute", "web", map[string]interface{}{
+			"parentRefs": []interface{}{map[string]interface{}{"name": "public"}, map[string]interface{}{"name": "internal"}},
+			"rules": []interface{}{map[string]interface{}{
+				"matches":     []interface{}{map[string]interface{}{"path": map[string]interface{}{"type": "PathPrefix", "value": "/api"}, "method": "GET"}},
+				"backendRefs": []interface{}{map[string]interface{}{"name": "web", "port": int64(80)}, map[string]interface{}{"name": "api", "port": int64(8080)}},
+			}},
+		}, map[string]interface{}{
+			"parents": []interface{}{
+				map[string]interface{}{"parentRef": map[string]interface{}{"name": "public"}, "controllerName": "example.com/gateway",
+					"conditions": []interface{}{map[string]interface{}{"type": "Accepted", "status": "True", "reason": "Accepted", "lastTransitionTime": acceptedAt}}},
+				map[string]interface{}{"parentRef": map[string]interface{}{"name": "internal"}, "controllerName": "example.com/gateway",
+					"conditions": []interface{}{map[string]interface{}{"type": "Accepted", "status": "False", "reason": "NotAllowedByListeners", "lastTransitionTime": acceptedAt}}},
+			},
+		}),
+	)
+
+	gateway, err := client.DescribeResource(ctx, "Gateways", "public", "default")
+	if err != nil {
+		t.Fatalf("Describe gateway failed: %v", err)
+	}
+	for _, want := range []string{"Class:        envoy", "Programmed:   False", "  https:", "Hostname:         *.example.com", "Attached Routes:  2", "AddressNotAssigned: no load balancer yet"} {
+		if !strings.Contains(gateway, want) {
+			t.Errorf("Expected %q in the gateway's description:\n%s", want, gateway)
+		}
+	}
+
+	route, err := client.DescribeResource(ctx, "HTTPRoutes", "web", "default")
+	if err != nil {
+		t.Fatalf("Describe route failed: %v", err)
+	}
+	for _, want := range []string{
+		"public  ✓ Accepted",
+		"internal  ✗ Not accepted: NotAllowedByListeners",
+		"Match:    PathPrefix /api, GET",
+		"Backend:  service/web:80\n",
+		"Backend:  service/api:8080  ✗ Service not found",
+	} {
+		if !strings.Contains(route, want) {
+			t.Errorf("Expected %q in the route's description:\n%s", want, route)
+		}
+	}
+}
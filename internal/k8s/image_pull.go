@@ -0,0 +1,301 @@
+package k8s
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// PullSlowAfter is how long an image pull may run before it counts as slow
+const PullSlowAfter = 2 * time.Minute
+
+// PullEventKind says what an image pull event reports
+type PullEventKind int
+
+const (
+	// PullStarted is a kubelet starting to pull an image
+	PullStarted PullEventKind = iota + 1
+	// PullSucceeded is an image pulled, or found already present
+	PullSucceeded
+	// PullFailed is a pull that failed, or an image that cannot be pulled
+	PullFailed
+	// PullBackingOff is a kubelet waiting before it pulls again
+	PullBackingOff
+)
+
+// PullEvent is what an image pull event of a pod says
+type PullEvent struct {
+	Kind      PullEventKind
+	Image     string
+	Container string        // From the event's field path; "" when it has none
+	Cause     string        // Why a failed pull failed, shortened, e.g. "manifest unknown"
+	Took      time.Duration // How long a successful pull took, when the message says
+	At        time.Time
+}
+
+var (
+	// The first quoted string of a message is the image
+	pullImagePattern = regexp.MustCompile(`"([^"]+)"`)
+
+	// "in 2.519s (2.519s including waiting)", or just "in 2.519304887s"
+	pullTookPattern = regexp.MustCompile(` in ([0-9][0-9.hmµn]*s)\b`)
+
+	// "spec.containers{app}" or "spec.initContainers{setup}"
+	fieldPathContainerPattern = regexp.MustCompile(`\{([^}]+)\}`)
+)
+
+// ParsePullEvent reads an image pull event of a pod. Kubelets word these
+// messages differently from release to release and runtime to runtime, so
+// only the image and what happened are relied on; false means the event is
+// not about pulling an image.
+func ParsePullEvent(event v1.Event) (PullEvent, bool) {
+	pull, ok := parsePullMessage(event.Reason, event.Message)
+	if !ok {
+		return PullEvent{}, false
+	}
+	pull.At = EventLastSeen(event)
+	if match := fieldPathContainerPattern.FindStringSubmatch(event.InvolvedObject.FieldPath); match != nil {
+		pull.Container = match[1]
+	}
+	return pull, true
+}
+
+// parsePullMessage reads the reason and message of an image pull event
+func parsePullMessage(reason, message string) (PullEvent, bool) {
+	var pull PullEvent
+	if match := pullImagePattern.FindStringSubmatch(message); match != nil {
+		pull.Image = match[1]
+	}
+
+	switch {
+	case reason == "Pulling" && strings.HasPrefix(message, "Pulling image"):
+		pull.Kind = PullStarted
+	case reason == "Pulled":
+		pull.Kind = PullSucceeded
+		if match := pullTookPattern.FindStringSubmatch(message); match != nil {
+			pull.Took, _ = time.ParseDuration(match[1])
+		}
+	case reason == "Failed" && strings.HasPrefix(message, "Failed to pull image"),
+		reason == "InspectFailed", reason == "ErrImageNeverPull":
+		pull.Kind = PullFailed
+		pull.Cause = PullCause(pullError(message))
+	case reason == "BackOff" && strings.HasPrefix(message, "Back-off pulling image"):
+		// Newer kubelets add why the last pull failed
+		pull.Kind = PullBackingOff
+		if cause := pullError(message); cause != "" {
+			pull.Cause = PullCause(cause)
+		}
+	default:
+		return PullEvent{}, false
+	}
+	if pull.Image == "" {
+		return PullEvent{}, false
+	}
+	return pull, true
+}
+
+// pullError returns the error a pull message carries after the quoted
+// image, e.g. the rpc error of `Failed to pull image "x": rpc error: ...`,
+// or the whole message when it has no such part
+func pullError(message string) string {
+	if i := strings.Index(message, `": `); i >= 0 {
+		return message[i+3:]
+	}
+	if strings.HasPrefix(message, "Back-off pulling image") {
+		return ""
+	}
+	return message
+}
+
+// pullCauses shorten the errors registries and runtimes fail pulls with.
+// The first whose match a lowercased error contains wins, so the more
+// specific come first.
+var pullCauses = []struct {
+	match string
+	cause string
+}{
+	{"manifest unknown", "manifest unknown"},
+	{"toomanyrequests", "registry rate limit reached"},
+	{"pull rate limit", "registry rate limit reached"},
+	{"unauthorized", "unauthorized"},
+	{"authorization failed", "unauthorized"},
+	{"access denied", "access denied"},
+	{"access to the resource is denied", "access denied"},
+	{"no match for platform", "no image for this platform"},
+	{"invalid reference format", "invalid image name"},
+	{"pull policy of never", "not present and pull policy is Never"},
+	{"x509:", "registry certificate not trusted"},
+	{"no such host", "registry host not found"},
+	{"connection refused", "registry connection refused"},
+	{"i/o timeout", "registry timed out"},
+	{"context deadline exceeded", "timed out"},
+	{"not found", "not found"},
+}
+
+// PullCause shortens the error a pull failed with to what went wrong, e.g.
+// "manifest unknown" for `rpc error: code = NotFound desc = failed to pull
+// and unpack image "...": ... manifest unknown`. An error it does not know
+// is cut to its last part that is not a URL.
+func PullCause(err string) string {
+	lower := strings.ToLower(err)
+	for _, known := range pullCauses {
+		if strings.Contains(lower, known.match) {
+			return known.cause
+		}
+	}
+
+	parts := strings.Split(err, ": ")
+	for i := len(parts) - 1; i >= 0; i-- {
+		part := strings.TrimSpace(parts[i])
+		if part == "" || strings.Contains(part, "://") {
+			continue
+		}
+		if len(part) > 60 {
+			part = part[:59] + "…"
+		}
+		return part
+	}
+	return ""
+}
+
+// failingPullReasons are the waiting reasons of a container whose image
+// cannot be pulled
+var failingPullReasons = map[string]bool{
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
+
+// pullWaitingReasons are the waiting reasons of a container an image pull
+// in progress may explain
+var pullWaitingReasons = map[string]bool{
+	"ContainerCreating": true,
+	"PodInitializing":   true,
+}
+
+// PullDiagnosis says why a pod waits on an image: a pull in progress, or
+// one that fails
+type PullDiagnosis struct {
+	Container string
+	Image     string
+	Reason    string    // A failing pull's waiting reason, e.g. ImagePullBackOff; "" while pulling
+	Cause     string    // Why a failing pull fails, "" when not known
+	Since     time.Time // When the pull in progress started
+}
+
+// Failing reports whether the image cannot be pulled
+func (d PullDiagnosis) Failing() bool {
+	return d.Reason != ""
+}
+
+// Slow reports whether the pull in progress has run past PullSlowAfter
+func (d PullDiagnosis) Slow(now time.Time) bool {
+	return !d.Failing() && now.Sub(d.Since) > PullSlowAfter
+}
+
+// Describe says what the pull is doing at now, e.g. "pulling
+// registry…/svc:v1.42 for 3m12s" or "ImagePullBackOff: manifest unknown
+// (registry…/svc:v1.42)"
+func (d PullDiagnosis) Describe(now time.Time) string {
+	image := ShortImage(d.Image)
+	if !d.Failing() {
+		return fmt.Sprintf("pulling %s for %s", image, max(now.Sub(d.Since), 0).Round(time.Second))
+	}
+	if d.Cause == "" {
+		return fmt.Sprintf("%s: %s", d.Reason, image)
+	}
+	return fmt.Sprintf("%s: %s (%s)", d.Reason, d.Cause, image)
+}
+
+// ShortImage shortens an image reference with a long repository path to its
+// registry and last path element, e.g. "registry…/svc:v1.42" for
+// "registry/team/backend/svc:v1.42"
+func ShortImage(image string) string {
+	parts := strings.Split(image, "/")
+	if len(parts) <= 2 {
+		return image
+	}
+	return parts[0] + "…/" + parts[len(parts)-1]
+}
+
+// AwaitingImage reports whether a pod has a container waiting for a reason
+// an image pull may explain, so its pull events are worth reading
+func AwaitingImage(pod *v1.Pod) bool {
+	for _, statuses := range [][]v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, cs := range statuses {
+			if cs.State.Waiting != nil && (failingPullReasons[cs.State.Waiting.Reason] || pullWaitingReasons[cs.State.Waiting.Reason]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DiagnosePull explains why a pod waits on an image from its containers'
+// waiting reasons and its events: the first container whose image cannot
+// be pulled, else the first whose image is being pulled. events may be nil,
+// which only loses when a pull started and, for a failing pull, the cause
+// its events give. False means no container waits on an image.
+func DiagnosePull(pod *v1.Pod, events []v1.Event, now time.Time) (PullDiagnosis, bool) {
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+
+	for _, cs := range statuses {
+		waiting := cs.State.Waiting
+		if waiting == nil || !failingPullReasons[waiting.Reason] {
+			continue
+		}
+		d := PullDiagnosis{Container: cs.Name, Image: cs.Image, Reason: waiting.Reason}
+		if latest, ok := latestPullEvent(events, cs); ok && latest.Cause != "" {
+			d.Cause = latest.Cause
+		} else if waiting.Message != "" {
+			if cause := pullError(waiting.Message); cause != "" {
+				d.Cause = PullCause(cause)
+			}
+		}
+		return d, true
+	}
+
+	for _, cs := range statuses {
+		waiting := cs.State.Waiting
+		if waiting == nil || !pullWaitingReasons[waiting.Reason] {
+			continue
+		}
+		if latest, ok := latestPullEvent(events, cs); ok && latest.Kind == PullStarted {
+			return PullDiagnosis{Container: cs.Name, Image: cs.Image, Since: latest.At}, true
+		}
+	}
+	return PullDiagnosis{}, false
+}
+
+// latestPullEvent returns the latest pull event about a container's image.
+// Events name the container in their field path; those without one are
+// matched by image.
+func latestPullEvent(events []v1.Event, cs v1.ContainerStatus) (PullEvent, bool) {
+	var latest PullEvent
+	found := false
+	for _, event := range events {
+		pull, ok := ParsePullEvent(event)
+		if !ok {
+			continue
+		}
+		if pull.Container != "" && pull.Container != cs.Name {
+			continue
+		}
+		if pull.Container == "" && pull.Image != cs.Image {
+			continue
+		}
+		// A back-off without a cause says less than the failure before it
+		if pull.Kind == PullBackingOff && pull.Cause == "" && found && latest.Kind == PullFailed {
+			continue
+		}
+		if !found || !pull.At.Before(latest.At) {
+			latest = pull
+			found = true
+		}
+	}
+	return latest, found
+}
@@ -0,0 +1,222 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Pull event messages as kubelets of several releases and runtimes word them
+func TestParsePullEventFixtures(t *testing.T) {
+	tests := []struct {
+		name    string
+		reason  string
+		message string
+		kind    PullEventKind
+		image   string
+		cause   string
+		took    time.Duration
+	}{
+		{
+			name:    "pulling",
+			reason:  "Pulling",
+			message: `Pulling image "registry.example.com/team/svc:v1.42"`,
+			kind:    PullStarted,
+			image:   "registry.example.com/team/svc:v1.42",
+		},
+		{
+			name:    "pulled with waiting time, kubelet 1.28+",
+			reason:  "Pulled",
+			message: `Successfully pulled image "nginx:1.25" in 2.519s (2.519s including waiting)`,
+			kind:    PullSucceeded,
+			image:   "nginx:1.25",
+			took:    2519 * time.Millisecond,
+		},
+		{
+			name:    "pulled, older kubelet",
+			reason:  "Pulled",
+			message: `Successfully pulled image "nginx:1.25" in 1m3.204851277s`,
+			kind:    PullSucceeded,
+			image:   "nginx:1.25",
+			took:    time.Minute + 3204851277*time.Nanosecond,
+		},
+		{
+			name:    "already present",
+			reason:  "Pulled",
+			message: `Container image "nginx:1.25" already present on machine`,
+			kind:    PullSucceeded,
+			image:   "nginx:1.25",
+		},
+		{
+			name:    "containerd, tag not found",
+			reason:  "Failed",
+			message: `Failed to pull image "nginx:nope": rpc error: code = NotFound desc = failed to pull and unpack image "docker.io/library/nginx:nope": failed to resolve reference "docker.io/library/nginx:nope": docker.io/library/nginx:nope: not found`,
+			kind:    PullFailed,
+			image:   "nginx:nope",
+			cause:   "not found",
+		},
+		{
+			name:    "docker, manifest unknown",
+			reason:  "Failed",
+			message: `Failed to pull image "registry.example.com/team/svc:v1.42": rpc error: code = Unknown desc = Error response from daemon: manifest for registry.example.com/team/svc:v1.42 not found: manifest unknown: manifest unknown`,
+			kind:    PullFailed,
+			image:   "registry.example.com/team/svc:v1.42",
+			cause:   "manifest unknown",
+		},
+		{
+			name:    "CRI-O, manifest unknown",
+			reason:  "Failed",
+			message: `Failed to pull image "quay.io/team/svc:v2": rpc error: code = Unknown desc = reading manifest v2 in quay.io/team/svc: manifest unknown: manifest unknown`,
+			kind:    PullFailed,
+			image:   "quay.io/team/svc:v2",
+			cause:   "manifest unknown",
+		},
+		{
+			name:    "containerd, private registry without credentials",
+			reason:  "Failed",
+			message: `Failed to pull image "ghcr.io/team/private:1": failed to pull and unpack image "ghcr.io/team/private:1": failed to resolve reference "ghcr.io/team/private:1": failed to authorize: failed to fetch anonymous token: unexpected status from GET request to https://ghcr.io/token?scope=repository%3Ateam%2Fprivate%3Apull: 401 Unauthorized`,
+			kind:    PullFailed,
+			image:   "ghcr.io/team/private:1",
+			cause:   "unauthorized",
+		},
+		{
+			name:    "Docker Hub rate limit",
+			reason:  "Failed",
+			message: `Failed to pull image "redis:7": rpc error: code = Unknown desc = failed to pull and unpack image "docker.io/library/redis:7": failed to copy: httpReadSeeker: failed open: unexpected status code https://registry-1.docker.io/v2/library/redis/manifests/sha256:abc: 429 Too Many Requests - Server message: toomanyrequests: You have reached your pull rate limit. You may increase the limit by authenticating and upgrading: https://www.docker.com/increase-rate-limit`,
+			kind:    PullFailed,
+			image:   "redis:7",
+			cause:   "registry rate limit reached",
+		},
+		{
+			name:    "unresolvable registry",
+			reason:  "Failed",
+			message: `Failed to pull image "registry.internal/svc:1": rpc error: code = Unknown desc = failed to pull and unpack image "registry.internal/svc:1": failed to resolve reference "registry.internal/svc:1": failed to do request: Head "https://registry.internal/v2/svc/manifests/1": dial tcp: lookup registry.internal on 10.96.0.10:53: no such host`,
+			kind:    PullFailed,
+			image:   "registry.internal/svc:1",
+			cause:   "registry host not found",
+		},
+		{
+			name:    "invalid image name",
+			reason:  "InspectFailed",
+			message: `Failed to apply default image tag "Nginx:Latest": couldn't parse image reference "Nginx:Latest": invalid reference format: repository name must be lowercase`,
+			kind:    PullFailed,
+			image:   "Nginx:Latest",
+			cause:   "invalid image name",
+		},
+		{
+			name:    "never pull",
+			reason:  "ErrImageNeverPull",
+			message: `Container image "local/svc:dev" is not present with pull policy of Never`,
+			kind:    PullFailed,
+			image:   "local/svc:dev",
+			cause:   "not present and pull policy is Never",
+		},
+		{
+			name:    "unknown error keeps its last part",
+			reason:  "Failed",
+			message: `Failed to pull image "svc:1": rpc error: code = Unknown desc = failed to extract layer: disk quota exceeded`,
+			kind:    PullFailed,
+			image:   "svc:1",
+			cause:   "disk quota exceeded",
+		},
+		{
+			name:    "back-off",
+			reason:  "BackOff",
+			message: `Back-off pulling image "nginx:nope"`,
+			kind:    PullBackingOff,
+			image:   "nginx:nope",
+		},
+		{
+			name:    "back-off with the last error, kubelet 1.31+",
+			reason:  "BackOff",
+			message: `Back-off pulling image "nginx:nope": ErrImagePull: failed to pull and unpack image "docker.io/library/nginx:nope": not found`,
+			kind:    PullBackingOff,
+			image:   "nginx:nope",
+			cause:   "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pull, ok := ParsePullEvent(v1.Event{Reason: tt.reason, Message: tt.message})
+			if !ok {
+				t.Fatalf("Expected a pull event")
+			}
+			if pull.Kind != tt.kind || pull.Image != tt.image || pull.Cause != tt.cause || pull.Took != tt.took {
+				t.Errorf("Expected %v %q %q %v, got %v %q %q %v", tt.kind, tt.image, tt.cause, tt.took, pull.Kind, pull.Image, pull.Cause, pull.Took)
+			}
+		})
+	}
+
+	// Events about anything but pulls are not pull events
+	for _, event := range []v1.Event{
+		{Reason: "BackOff", Message: "Back-off restarting failed container app in pod web-1_default(abc)"},
+		{Reason: "Failed", Message: "Error: ImagePullBackOff"},
+		{Reason: "Scheduled", Message: "Successfully assigned default/web-1 to node-1"},
+	} {
+		if pull, ok := ParsePullEvent(event); ok {
+			t.Errorf("Expected %q not to be a pull event, got %+v", event.Message, pull)
+		}
+	}
+}
+
+func TestDiagnosePull(t *testing.T) {
+	now := time.Date(2024, 3, 5, 14, 2, 0, 0, time.UTC)
+	image := "registry/team/backend/svc:v1.42"
+	event := func(uid, reason, message string, at time.Time) v1.Event {
+		return v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{UID: types.UID(uid)},
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "web-1", FieldPath: "spec.containers{app}"},
+			Reason:         reason,
+			Message:        message,
+			LastTimestamp:  metav1.NewTime(at),
+		}
+	}
+	pod := func(reason, message string) *v1.Pod {
+		return &v1.Pod{Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{
+			Name:  "app",
+			Image: image,
+			State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: reason, Message: message}},
+		}}}}
+	}
+
+	// A pull in progress says how long it has run
+	creating := pod("ContainerCreating", "")
+	if !AwaitingImage(creating) {
+		t.Fatal("Expected a creating container to wait on its image")
+	}
+	events := []v1.Event{event("a", "Pulling", `Pulling image "`+image+`"`, now.Add(-3*time.Minute-12*time.Second))}
+	d, ok := DiagnosePull(creating, events, now)
+	if !ok || d.Describe(now) != "pulling registry…/svc:v1.42 for 3m12s" || !d.Slow(now) {
+		t.Errorf("Expected a slow pull in progress, got %q (%v)", d.Describe(now), ok)
+	}
+
+	// Once pulled, creating is not down to the image
+	events = append(events, event("b", "Pulled", `Successfully pulled image "`+image+`" in 3m15s (3m15s including waiting)`, now))
+	if d, ok := DiagnosePull(creating, events, now); ok {
+		t.Errorf("Expected no pull once the image is pulled, got %q", d.Describe(now))
+	}
+
+	// A failing pull gives the cause its events give
+	backOff := pod("ImagePullBackOff", `Back-off pulling image "`+image+`"`)
+	events = []v1.Event{
+		event("c", "Failed", `Failed to pull image "`+image+`": rpc error: code = NotFound desc = manifest unknown`, now.Add(-time.Minute)),
+		event("d", "BackOff", `Back-off pulling image "`+image+`"`, now),
+	}
+	d, ok = DiagnosePull(backOff, events, now)
+	if !ok || !d.Failing() || d.Describe(now) != "ImagePullBackOff: manifest unknown (registry…/svc:v1.42)" {
+		t.Errorf("Expected the back-off with its cause, got %q (%v)", d.Describe(now), ok)
+	}
+
+	// Without events, the waiting message is all there is
+	errPull := pod("ErrImagePull", `rpc error: code = Unknown desc = Error response from daemon: pull access denied for svc, repository does not exist or may require 'docker login': denied: requested access to the resource is denied`)
+	if d, _ := DiagnosePull(errPull, nil, now); d.Describe(now) != "ErrImagePull: access denied (registry…/svc:v1.42)" {
+		t.Errorf("Expected the cause from the waiting message, got %q", d.Describe(now))
+	}
+	if d, _ := DiagnosePull(backOff, nil, now); d.Describe(now) != "ImagePullBackOff: registry…/svc:v1.42" {
+		t.Errorf("Expected the back-off without a cause, got %q", d.Describe(now))
+	}
+}
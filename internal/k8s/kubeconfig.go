@@ -0,0 +1,104 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// SkippedKubeconfig is a kubeconfig file left out of the merge because it
+// could not be read or parsed
+type SkippedKubeconfig struct {
+	Path string
+	Err  error
+
+	data []byte // The file's text, to tell which contexts it meant to define
+}
+
+func (s SkippedKubeconfig) String() string {
+	return fmt.Sprintf("%s: %v", s.Path, s.Err)
+}
+
+// kubeconfigPaths splits a list of kubeconfig files, as KUBECONFIG lists
+// them, dropping empty entries
+func kubeconfigPaths(kubeconfig string) []string {
+	var paths []string
+	for _, path := range strings.Split(kubeconfig, getPathSeparator()) {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// kubeconfigLoadingRules returns loading rules over the kubeconfig files
+// listed, or over KUBECONFIG and then ~/.kube/config when none are. Each file
+// is parsed on its own and the ones that cannot be are left out, so one
+// broken file does not keep the contexts of the rest from loading; the rest
+// merge by kubectl's rules, the first file to set a value winning. Missing
+// files are kept, as kubectl ignores them.
+func kubeconfigLoadingRules(kubeconfig string) (*clientcmd.ClientConfigLoadingRules, []SkippedKubeconfig) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	paths := kubeconfigPaths(kubeconfig)
+	if len(paths) == 0 {
+		paths = loadingRules.GetLoadingPrecedence()
+	}
+
+	var loaded []string
+	var skipped []SkippedKubeconfig
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			loaded = append(loaded, path)
+			continue
+		}
+		if err == nil {
+			_, err = clientcmd.Load(data)
+		}
+		if err != nil {
+			skipped = append(skipped, SkippedKubeconfig{Path: path, Err: err, data: data})
+			continue
+		}
+		loaded = append(loaded, path)
+	}
+	loadingRules.Precedence = loaded
+	return loadingRules, skipped
+}
+
+// CheckKubeconfig returns the files of a list of kubeconfig files that
+// clients leave out because they cannot be parsed. An empty list checks
+// KUBECONFIG, then ~/.kube/config.
+func CheckKubeconfig(kubeconfig string) []SkippedKubeconfig {
+	_, skipped := kubeconfigLoadingRules(kubeconfig)
+	return skipped
+}
+
+// checkContext returns an error saying so when contextName is defined only in
+// a skipped file, which would otherwise fail as a context not found. An empty
+// contextName, or one the loaded files define, is no error.
+func checkContext(loadingRules *clientcmd.ClientConfigLoadingRules, skipped []SkippedKubeconfig, contextName string) error {
+	if contextName == "" || len(skipped) == 0 {
+		return nil
+	}
+	if config, err := loadingRules.Load(); err == nil {
+		if _, ok := config.Contexts[contextName]; ok {
+			return nil
+		}
+	}
+	for _, s := range skipped {
+		if s.defines(contextName) {
+			return fmt.Errorf("context %q is only in kubeconfig %s, which was skipped: %w", contextName, s.Path, s.Err)
+		}
+	}
+	return nil
+}
+
+// defines reports whether the skipped file's text names a context
+// contextName. It cannot be parsed, so a line naming it is taken to.
+func (s SkippedKubeconfig) defines(contextName string) bool {
+	pattern := `(?m)^\s*(-\s+)?name:\s*["']?` + regexp.QuoteMeta(contextName) + `["']?\s*$`
+	return regexp.MustCompile(pattern).Match(s.data)
+}
@@ -0,0 +1,108 @@
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const goodKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://good.example.com
+  name: good
+contexts:
+- context:
+    cluster: good
+    namespace: shop
+    user: good
+  name: good
+current-context: good
+users:
+- name: good
+  user:
+    token: token
+`
+
+// badKubeconfig names a context but is not valid YAML
+const badKubeconfig = `apiVersion: v1
+kind: Config
+contexts:
+- context:
+    cluster: broken
+    user: [broken
+  name: broken
+current-context: broken
+`
+
+// writeKubeconfigs writes the good and bad files and returns them listed
+// as KUBECONFIG lists them, in the order given
+func writeKubeconfigs(t *testing.T, badFirst bool) (kubeconfig, badPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	goodPath := filepath.Join(dir, "good.yaml")
+	badPath = filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(goodPath, []byte(goodKubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(badPath, []byte(badKubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	paths := []string{goodPath, badPath}
+	if badFirst {
+		paths = []string{badPath, goodPath}
+	}
+	return strings.Join(paths, getPathSeparator()), badPath
+}
+
+func TestKubeconfigSkipsBrokenFile(t *testing.T) {
+	for _, badFirst := range []bool{false, true} {
+		kubeconfig, badPath := writeKubeconfigs(t, badFirst)
+
+		skipped := CheckKubeconfig(kubeconfig)
+		if len(skipped) != 1 || skipped[0].Path != badPath || skipped[0].Err == nil {
+			t.Fatalf("Expected only %s skipped, with its error, got %v", badPath, skipped)
+		}
+
+		// The default merge uses the good file's current context
+		client, err := NewClientWithOptions(kubeconfig, &ClientOptions{})
+		if err != nil {
+			t.Fatalf("Expected the good file to load, got %v", err)
+		}
+		if client.ContextName() != "good" {
+			t.Errorf("Expected the good file's current context, got %q", client.ContextName())
+		}
+		name, namespace, err := GetContextNamespace(kubeconfig, "")
+		if err != nil || name != "good" || namespace != "shop" {
+			t.Errorf("Expected the good context's namespace, got %q %q %v", name, namespace, err)
+		}
+	}
+}
+
+func TestKubeconfigContextInEachFile(t *testing.T) {
+	kubeconfig, badPath := writeKubeconfigs(t, false)
+
+	client, err := NewClientWithOptions(kubeconfig, &ClientOptions{Context: "good"})
+	if err != nil {
+		t.Fatalf("Expected the good file's context to load, got %v", err)
+	}
+	if client.ContextName() != "good" {
+		t.Errorf("Expected context good, got %q", client.ContextName())
+	}
+
+	_, err = NewClientWithOptions(kubeconfig, &ClientOptions{Context: "broken"})
+	if err == nil {
+		t.Fatal("Expected the broken file's context to fail")
+	}
+	if want := `context "broken" is only in kubeconfig ` + badPath + ", which was skipped"; !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected the error to say %q, got %v", want, err)
+	}
+
+	// A context in neither file fails as not found
+	_, err = NewClientWithOptions(kubeconfig, &ClientOptions{Context: "missing"})
+	if err == nil || strings.Contains(err.Error(), "skipped") {
+		t.Errorf("Expected a context in neither file not found, got %v", err)
+	}
+}
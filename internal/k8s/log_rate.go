@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// errorLinePattern matches log lines that look like errors: ERROR or FATAL
+// levels, Go panics, and error levels in logfmt and JSON logs
+var errorLinePattern = regexp.MustCompile(`\b(ERROR|FATAL)\b|\bpanic:|level=(error|fatal)\b|"level":\s*"(error|fatal)"`)
+
+// LogSample counts the lines a container logged over a recent window
+type LogSample struct {
+	Lines  int
+	Errors int
+
+	// Window is the time the counted lines cover. It is shorter than the
+	// window asked for when the byte limit cut the logs off.
+	Window time.Duration
+}
+
+// SampleLogs counts the lines a pod's container logged in the last window,
+// reading at most limitBytes. A pod that logs more than that is counted
+// from the oldest lines up to the limit, and the sample covers only the
+// time those span.
+func (c *Client) SampleLogs(ctx context.Context, namespace, pod, container string, window time.Duration, limitBytes int64) (LogSample, error) {
+	since := int64(window.Seconds())
+	opts := &v1.PodLogOptions{
+		Container:    container,
+		SinceSeconds: &since,
+		LimitBytes:   &limitBytes,
+		Timestamps:   true,
+	}
+	start := time.Now().Add(-window)
+
+	stream, err := c.clientset.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream(ctx)
+	if err != nil {
+		return LogSample{}, c.wrapError(err, OpLogs, "pods", namespace, pod)
+	}
+	defer stream.Close()
+
+	counting := &countingReader{r: stream}
+	sample, last, err := CountLogLines(counting)
+	if err != nil {
+		return LogSample{}, c.wrapError(err, OpLogs, "pods", namespace, pod)
+	}
+
+	sample.Window = window
+	if counting.n >= limitBytes && !last.IsZero() && last.After(start) {
+		sample.Window = last.Sub(start)
+	}
+	return sample, nil
+}
+
+// CountLogLines counts the lines and error lines of timestamped logs, and
+// returns the timestamp of the last line
+func CountLogLines(r io.Reader) (LogSample, time.Time, error) {
+	var sample LogSample
+	var last time.Time
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		sample.Lines++
+
+		timestamp, message, _ := strings.Cut(line, " ")
+		if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+			last = t
+		} else {
+			message = line
+		}
+		if errorLinePattern.MatchString(message) {
+			sample.Errors++
+		}
+	}
+	return sample, last, scanner.Err()
+}
+
+// DefaultLogContainer returns the container kubectl logs reads by default:
+// the one named by the default-container annotation, or the first
+func DefaultLogContainer(pod *v1.Pod) string {
+	if name := pod.Annotations["kubectl.kubernetes.io/default-container"]; name != "" {
+		return name
+	}
+	if len(pod.Spec.Containers) > 0 {
+		return pod.Spec.Containers[0].Name
+	}
+	return ""
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
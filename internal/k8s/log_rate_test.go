@@ -0,0 +1,49 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCountLogLines(t *testing.T) {
+	logs := strings.Join([]string{
+		"2024-05-01T10:00:00.000000000Z starting server",
+		"2024-05-01T10:00:01.000000000Z ERROR connection refused",
+		`2024-05-01T10:00:02.000000000Z {"level":"error","msg":"timeout"}`,
+		"2024-05-01T10:00:03.000000000Z level=info msg=ok",
+		"2024-05-01T10:00:04.000000000Z panic: runtime error: index out of range",
+		"2024-05-01T10:00:05.000000000Z no errors here, error handling works",
+		"",
+	}, "\n")
+
+	sample, last, err := CountLogLines(strings.NewReader(logs))
+	if err != nil {
+		t.Fatalf("CountLogLines() error = %v", err)
+	}
+	if sample.Lines != 6 {
+		t.Errorf("Lines = %d, want 6", sample.Lines)
+	}
+	if sample.Errors != 3 {
+		t.Errorf("Errors = %d, want 3", sample.Errors)
+	}
+	want := time.Date(2024, 5, 1, 10, 0, 5, 0, time.UTC)
+	if !last.Equal(want) {
+		t.Errorf("last = %v, want %v", last, want)
+	}
+}
+
+func TestDefaultLogContainer(t *testing.T) {
+	pod := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "istio-proxy"}, {Name: "app"}}}}
+	if got := DefaultLogContainer(pod); got != "istio-proxy" {
+		t.Errorf("DefaultLogContainer() = %q, want first container", got)
+	}
+
+	pod.ObjectMeta = metav1.ObjectMeta{Annotations: map[string]string{"kubectl.kubernetes.io/default-container": "app"}}
+	if got := DefaultLogContainer(pod); got != "app" {
+		t.Errorf("DefaultLogContainer() = %q, want annotated container", got)
+	}
+}
@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -24,6 +25,41 @@ type ResourceWithContext struct {
 	Resource interface{}
 }
 
+// ContextError is the failure of one context in a call across contexts
+type ContextError struct {
+	Context string
+	Err     error
+}
+
+func (e *ContextError) Error() string {
+	return fmt.Sprintf("context %s: %v", e.Context, e.Err)
+}
+
+func (e *ContextError) Unwrap() error {
+	return e.Err
+}
+
+// FailedContexts returns the contexts whose failures err carries. The calls
+// across contexts join one ContextError per failed context.
+func FailedContexts(err error) []string {
+	var contexts []string
+	var walk func(error)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case *ContextError:
+			contexts = append(contexts, e.Context)
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner)
+			}
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		}
+	}
+	walk(err)
+	return contexts
+}
+
 // NewMultiContextClient creates a client that can work with multiple contexts
 func NewMultiContextClient(contextNames []string) (*MultiContextClient, error) {
 	if len(contextNames) == 0 {
@@ -35,11 +71,15 @@ func NewMultiContextClient(contextNames []string) (*MultiContextClient, error) {
 		contexts: contextNames,
 	}
 
-	// Load kubeconfig
-	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	// Load kubeconfig, leaving out files that cannot be parsed
+	loadingRules, skipped := kubeconfigLoadingRules("")
 	configOverrides := &clientcmd.ConfigOverrides{}
 
 	for _, contextName := range contextNames {
+		if err := checkContext(loadingRules, skipped, contextName); err != nil {
+			return nil, err
+		}
+
 		// Set context override for this specific client
 		configOverrides.CurrentContext = contextName
 
@@ -67,7 +107,12 @@ func NewClientWithContext(loadingRules *clientcmd.ClientConfigLoadingRules, over
 		return nil, fmt.Errorf("failed to build config: %w", err)
 	}
 
-	return NewClientFromConfig(config)
+	client, err := NewClientFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	client.contextName = contextNameOf(kubeConfig, overrides)
+	return client, nil
 }
 
 // GetContexts returns the list of active contexts
@@ -91,6 +136,18 @@ func (mc *MultiContextClient) GetClient(context string) (*Client, error) {
 
 // ListPodsAllContexts returns pods from all contexts with context information
 func (mc *MultiContextClient) ListPodsAllContexts(ctx context.Context, namespace string) ([]PodWithContext, error) {
+	return mc.ListPodsAllContextsWithSelector(ctx, namespace, "")
+}
+
+// ListPodsAllContextsWithSelector returns the pods matching a field selector
+// from all contexts, with context information
+func (mc *MultiContextClient) ListPodsAllContextsWithSelector(ctx context.Context, namespace, fieldSelector string) ([]PodWithContext, error) {
+	return mc.ListPodsAllContextsMatching(ctx, namespace, "", fieldSelector)
+}
+
+// ListPodsAllContextsMatching returns the pods matching both a label
+// selector and a field selector from all contexts, with context information
+func (mc *MultiContextClient) ListPodsAllContextsMatching(ctx context.Context, namespace, labelSelector, fieldSelector string) ([]PodWithContext, error) {
 	var allPods []PodWithContext
 	var wg sync.WaitGroup
 	var mu sync.Mutex
@@ -103,13 +160,13 @@ func (mc *MultiContextClient) ListPodsAllContexts(ctx context.Context, namespace
 
 			client, err := mc.GetClient(ctxName)
 			if err != nil {
-				errChan <- fmt.Errorf("context %s: %w", ctxName, err)
+				errChan <- &ContextError{Context: ctxName, Err: err}
 				return
 			}
 
-			pods, err := client.ListPods(ctx, namespace)
+			pods, err := client.ListPodsMatching(ctx, namespace, labelSelector, fieldSelector)
 			if err != nil {
-				errChan <- fmt.Errorf("context %s: %w", ctxName, err)
+				errChan <- &ContextError{Context: ctxName, Err: err}
 				return
 			}
 
@@ -134,7 +191,7 @@ func (mc *MultiContextClient) ListPodsAllContexts(ctx context.Context, namespace
 	}
 	if len(errs) > 0 {
 		// Return partial results with error
-		return allPods, fmt.Errorf("errors from %d contexts: %v", len(errs), errs)
+		return allPods, fmt.Errorf("errors from %d contexts: %w", len(errs), errors.Join(errs...))
 	}
 
 	return allPods, nil
@@ -154,6 +211,12 @@ type DeploymentWithContext struct {
 
 // ListDeploymentsAllContexts returns deployments from all contexts
 func (mc *MultiContextClient) ListDeploymentsAllContexts(ctx context.Context, namespace string) ([]DeploymentWithContext, error) {
+	return mc.ListDeploymentsAllContextsWithLabels(ctx, namespace, "")
+}
+
+// ListDeploymentsAllContextsWithLabels returns the deployments matching a
+// label selector from all contexts
+func (mc *MultiContextClient) ListDeploymentsAllContextsWithLabels(ctx context.Context, namespace, labelSelector string) ([]DeploymentWithContext, error) {
 	var allDeployments []DeploymentWithContext
 	var wg sync.WaitGroup
 	var mu sync.Mutex
@@ -166,13 +229,13 @@ func (mc *MultiContextClient) ListDeploymentsAllContexts(ctx context.Context, na
 
 			client, err := mc.GetClient(ctxName)
 			if err != nil {
-				errChan <- fmt.Errorf("context %s: %w", ctxName, err)
+				errChan <- &ContextError{Context: ctxName, Err: err}
 				return
 			}
 
-			deployments, err := client.ListDeployments(ctx, namespace)
+			deployments, err := client.ListDeploymentsWithLabels(ctx, namespace, labelSelector)
 			if err != nil {
-				errChan <- fmt.Errorf("context %s: %w", ctxName, err)
+				errChan <- &ContextError{Context: ctxName, Err: err}
 				return
 			}
 
@@ -196,7 +259,7 @@ func (mc *MultiContextClient) ListDeploymentsAllContexts(ctx context.Context, na
 		errs = append(errs, err)
 	}
 	if len(errs) > 0 {
-		return allDeployments, fmt.Errorf("errors from %d contexts: %v", len(errs), errs)
+		return allDeployments, fmt.Errorf("errors from %d contexts: %w", len(errs), errors.Join(errs...))
 	}
 
 	return allDeployments, nil
@@ -252,13 +315,13 @@ func (mc *MultiContextClient) ListNamespacesAllContexts(ctx context.Context) ([]
 
 			client, err := mc.GetClient(ctxName)
 			if err != nil {
-				errChan <- fmt.Errorf("context %s: %w", ctxName, err)
+				errChan <- &ContextError{Context: ctxName, Err: err}
 				return
 			}
 
 			namespaces, err := client.ListNamespaces(ctx)
 			if err != nil {
-				errChan <- fmt.Errorf("context %s: %w", ctxName, err)
+				errChan <- &ContextError{Context: ctxName, Err: err}
 				return
 			}
 
@@ -283,12 +346,59 @@ func (mc *MultiContextClient) ListNamespacesAllContexts(ctx context.Context) ([]
 	}
 	if len(errs) > 0 {
 		// Return partial results with error
-		return allNamespaces, fmt.Errorf("errors from %d contexts: %v", len(errs), errs)
+		return allNamespaces, fmt.Errorf("errors from %d contexts: %w", len(errs), errors.Join(errs...))
 	}
 
 	return allNamespaces, nil
 }
 
+// GetPodMetricsAllContexts returns pod metrics from all contexts, keyed by
+// context name
+func (mc *MultiContextClient) GetPodMetricsAllContexts(ctx context.Context, namespace string) (map[string]PodMetricsSet, error) {
+	allMetrics := make(map[string]PodMetricsSet)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	errChan := make(chan error, len(mc.contexts))
+
+	for _, contextName := range mc.contexts {
+		wg.Add(1)
+		go func(ctxName string) {
+			defer wg.Done()
+
+			client, err := mc.GetClient(ctxName)
+			if err != nil {
+				errChan <- &ContextError{Context: ctxName, Err: err}
+				return
+			}
+
+			metrics, err := client.GetPodMetrics(ctx, namespace)
+			if err != nil {
+				errChan <- &ContextError{Context: ctxName, Err: err}
+				return
+			}
+
+			mu.Lock()
+			allMetrics[ctxName] = metrics
+			mu.Unlock()
+		}(contextName)
+	}
+
+	wg.Wait()
+	close(errChan)
+
+	// Check for errors
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		// Return partial results with error
+		return allMetrics, fmt.Errorf("errors from %d contexts: %w", len(errs), errors.Join(errs...))
+	}
+
+	return allMetrics, nil
+}
+
 // GetUniqueNamespaces returns unique namespace names from all contexts
 func (mc *MultiContextClient) GetUniqueNamespaces(ctx context.Context) ([]v1.Namespace, error) {
 	namespacesWithContext, err := mc.ListNamespacesAllContexts(ctx)
@@ -313,7 +423,16 @@ func (mc *MultiContextClient) GetUniqueNamespaces(ctx context.Context) ([]v1.Nam
 
 // GetAvailableContexts returns all available contexts from kubeconfig
 func GetAvailableContexts() ([]string, string, error) {
+	return GetAvailableContextsFromKubeconfig("")
+}
+
+// GetAvailableContextsFromKubeconfig returns all contexts from the given
+// kubeconfig path, or from the default loading rules when the path is empty
+func GetAvailableContextsFromKubeconfig(kubeconfig string) ([]string, string, error) {
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig != "" {
+		loadingRules.ExplicitPath = kubeconfig
+	}
 	config, err := loadingRules.Load()
 	if err != nil {
 		return nil, "", err
@@ -326,3 +445,24 @@ func GetAvailableContexts() ([]string, string, error) {
 
 	return contexts, config.CurrentContext, nil
 }
+
+// GetContextNamespace returns the namespace a kubeconfig context sets, ""
+// when it sets none, and the context's name. kubeconfig may list several
+// files as KUBECONFIG does; empty uses the default loading rules. Files that
+// cannot be parsed are left out. An empty contextName is the kubeconfig's
+// current context.
+func GetContextNamespace(kubeconfig, contextName string) (name, namespace string, err error) {
+	loadingRules, _ := kubeconfigLoadingRules(kubeconfig)
+	config, err := loadingRules.Load()
+	if err != nil {
+		return "", "", err
+	}
+
+	if contextName == "" {
+		contextName = config.CurrentContext
+	}
+	if kubeContext, ok := config.Contexts[contextName]; ok {
+		return contextName, kubeContext.Namespace, nil
+	}
+	return contextName, "", nil
+}
@@ -3,6 +3,9 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
@@ -669,6 +672,16 @@ func TestErrorPropagation(t *testing.T) {
 		// ctx1 has no pods, so should be 0
 		t.Errorf("Expected 0 pods, got %d", len(pods))
 	}
+
+	// The failed contexts can be told apart from the one that worked
+	failed := FailedContexts(err)
+	sort.Strings(failed)
+	if strings.Join(failed, ",") != "ctx2,ctx3" {
+		t.Errorf("Expected ctx2 and ctx3 to have failed, got %v", failed)
+	}
+	if FailedContexts(fmt.Errorf("not from a context")) != nil {
+		t.Error("Expected no failed contexts for an unrelated error")
+	}
 }
 
 func BenchmarkListPodsAllContexts(b *testing.B) {
@@ -882,3 +895,56 @@ func TestMultiContextClientNamespaces(t *testing.T) {
 		}
 	})
 }
+
+func TestGetContextNamespace(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	base := write("config", `apiVersion: v1
+kind: Config
+current-context: payments
+clusters:
+- name: prod
+  cluster: {server: "https://prod.example.com"}
+users:
+- name: me
+  user: {token: secret}
+contexts:
+- name: payments
+  context: {cluster: prod, user: me, namespace: payments}
+- name: bare
+  context: {cluster: prod, user: me}
+`)
+	extra := write("extra", `apiVersion: v1
+kind: Config
+contexts:
+- name: staging
+  context: {cluster: prod, user: me, namespace: qa}
+`)
+	kubeconfig := base + string(os.PathListSeparator) + extra
+
+	tests := []struct {
+		context       string
+		wantName      string
+		wantNamespace string
+	}{
+		{"", "payments", "payments"},
+		{"bare", "bare", ""},
+		{"staging", "staging", "qa"},
+		{"missing", "missing", ""},
+	}
+	for _, tt := range tests {
+		name, namespace, err := GetContextNamespace(kubeconfig, tt.context)
+		if err != nil {
+			t.Fatalf("GetContextNamespace(%q) failed: %v", tt.context, err)
+		}
+		if name != tt.wantName || namespace != tt.wantNamespace {
+			t.Errorf("GetContextNamespace(%q) = %q, %q, want %q, %q", tt.context, name, namespace, tt.wantName, tt.wantNamespace)
+		}
+	}
+}
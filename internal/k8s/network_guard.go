@@ -0,0 +1,123 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+var (
+	networkMu         sync.RWMutex
+	networkRestricted bool
+)
+
+// RestrictNetwork makes clients created afterwards refuse every connection
+// but those to their API server and its proxy. Features that would reach
+// anything else check NetworkRestricted and turn themselves off.
+func RestrictNetwork(on bool) {
+	networkMu.Lock()
+	defer networkMu.Unlock()
+	networkRestricted = on
+}
+
+// NetworkRestricted reports whether connections are limited to the API
+// servers; see RestrictNetwork
+func NetworkRestricted() bool {
+	networkMu.RLock()
+	defer networkMu.RUnlock()
+	return networkRestricted
+}
+
+// ExternalNetworkError is returned for a connection refused because it is
+// not to the client's API server or its proxy
+type ExternalNetworkError struct {
+	Addr    string
+	Allowed []string
+}
+
+func (e *ExternalNetworkError) Error() string {
+	return fmt.Sprintf("refused connection to %s: external network access is disabled (allowed: %s)",
+		e.Addr, strings.Join(e.Allowed, ", "))
+}
+
+// restrictDialing wraps the dialer of config so it only connects to the API
+// server and the proxy requests to it go through. Auth providers fetch
+// tokens with their own HTTP clients, which the dialer cannot see, so they
+// are refused outright.
+func restrictDialing(config *rest.Config) error {
+	if config.AuthProvider != nil {
+		return fmt.Errorf("auth provider %q makes its own network calls, which external network access being disabled does not allow", config.AuthProvider.Name)
+	}
+	if config.Transport != nil {
+		return fmt.Errorf("a custom transport bypasses the dialer, which external network access being disabled does not allow")
+	}
+
+	allowed, err := allowedEndpoints(config)
+	if err != nil {
+		return err
+	}
+	list := make([]string, 0, len(allowed))
+	for addr := range allowed {
+		list = append(list, addr)
+	}
+	sort.Strings(list)
+
+	next := config.Dial
+	if next == nil {
+		next = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+	}
+	config.Dial = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if !allowed[addr] {
+			return nil, &ExternalNetworkError{Addr: addr, Allowed: list}
+		}
+		return next(ctx, network, addr)
+	}
+	return nil
+}
+
+// allowedEndpoints returns the host:port of the API server of config and of
+// the proxy requests to it go through, if any
+func allowedEndpoints(config *rest.Config) (map[string]bool, error) {
+	server, _, err := rest.DefaultServerUrlFor(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse API server address: %w", err)
+	}
+	allowed := map[string]bool{hostPort(server): true}
+
+	proxy := config.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+	proxyURL, err := proxy(&http.Request{URL: server})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve proxy for %s: %w", server.Host, err)
+	}
+	if proxyURL != nil {
+		allowed[hostPort(proxyURL)] = true
+	}
+	return allowed, nil
+}
+
+// hostPort returns the host:port a URL connects to, with the scheme's
+// default port when it names none
+func hostPort(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return net.JoinHostPort(u.Hostname(), port)
+	}
+	port := "443"
+	switch u.Scheme {
+	case "http":
+		port = "80"
+	case "socks5":
+		port = "1080"
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
@@ -0,0 +1,93 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestRestrictNetworkRejectsOtherHosts(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[]}`))
+	}))
+	defer apiServer.Close()
+
+	rogueCalled := false
+	rogue := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rogueCalled = true
+	}))
+	defer rogue.Close()
+
+	RestrictNetwork(true)
+	defer RestrictNetwork(false)
+
+	client, err := NewClientFromConfig(&rest.Config{Host: apiServer.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+
+	// The API server is reachable
+	if _, err := client.ListPods(context.Background(), "default"); err != nil {
+		t.Fatalf("ListPods failed: %v", err)
+	}
+
+	// Anything else through the client's transport is refused before dialing
+	transport, err := rest.TransportFor(client.config)
+	if err != nil {
+		t.Fatalf("TransportFor failed: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, rogue.URL+"/phone-home", nil)
+	_, err = (&http.Client{Transport: transport}).Do(req)
+	var refused *ExternalNetworkError
+	if !errors.As(err, &refused) {
+		t.Fatalf("Expected the rogue call refused, got %v", err)
+	}
+	if rogueCalled {
+		t.Error("Expected the rogue server never reached")
+	}
+	if refused.Addr != rogue.Listener.Addr().String() {
+		t.Errorf("Expected the refusal to name %s, got %q", rogue.Listener.Addr(), refused.Addr)
+	}
+}
+
+func TestRestrictNetworkOff(t *testing.T) {
+	client, err := NewClientFromConfig(&rest.Config{Host: "https://api.example.invalid:6443"})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	if client.config.Dial != nil {
+		t.Error("Expected the dialer left alone when the network is not restricted")
+	}
+}
+
+func TestRestrictDialingRefusesAuthProviders(t *testing.T) {
+	config := &rest.Config{
+		Host:         "https://api.example.invalid",
+		AuthProvider: &clientcmdapi.AuthProviderConfig{Name: "oidc"},
+	}
+	if err := restrictDialing(config); err == nil {
+		t.Error("Expected an auth provider to be refused")
+	}
+}
+
+func TestAllowedEndpoints(t *testing.T) {
+	proxy, _ := url.Parse("http://proxy.corp:3128")
+	config := &rest.Config{
+		Host:  "https://api.example.invalid",
+		Proxy: func(*http.Request) (*url.URL, error) { return proxy, nil },
+	}
+	allowed, err := allowedEndpoints(config)
+	if err != nil {
+		t.Fatalf("allowedEndpoints failed: %v", err)
+	}
+	if len(allowed) != 2 || !allowed["api.example.invalid:443"] || !allowed["proxy.corp:3128"] {
+		t.Errorf("Expected the API server and its proxy, got %v", allowed)
+	}
+}
@@ -0,0 +1,303 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// DefaultServiceEndpointsTTL is how long services and endpoint slices are
+// cached per namespace before refetching
+const DefaultServiceEndpointsTTL = 10 * time.Second
+
+// EndpointMembership describes whether a pod appears in a service's endpoints
+type EndpointMembership int
+
+const (
+	// EndpointMissing means the service selects the pod but no endpoint refers to it
+	EndpointMissing EndpointMembership = iota
+	// EndpointReady means the pod is a ready endpoint of the service
+	EndpointReady
+	// EndpointNotReady means the pod is listed but not ready (or terminating)
+	EndpointNotReady
+	// EndpointNoPodIP means the pod has no IP yet, so it cannot be an endpoint
+	EndpointNoPodIP
+)
+
+// String returns a human readable membership state
+func (m EndpointMembership) String() string {
+	switch m {
+	case EndpointReady:
+		return "ready"
+	case EndpointNotReady:
+		return "not ready"
+	case EndpointNoPodIP:
+		return "no pod IP yet"
+	default:
+		return "not in endpoints"
+	}
+}
+
+// ServiceMembership is one service selecting a pod, and the pod's place in
+// that service's endpoints
+type ServiceMembership struct {
+	Service    string
+	Type       v1.ServiceType
+	ClusterIP  string
+	Headless   bool
+	Membership EndpointMembership
+	// Warning is set when the endpoint state and pod readiness disagree
+	Warning string
+}
+
+// ServiceEndpointsCache caches services and endpoint slices per namespace so
+// repeated describes do not list them every time
+type ServiceEndpointsCache struct {
+	mu      sync.Mutex
+	client  *Client
+	ttl     time.Duration
+	entries map[string]*serviceEndpointsEntry
+}
+
+type serviceEndpointsEntry struct {
+	services  []v1.Service
+	slices    []discoveryv1.EndpointSlice
+	fetchedAt time.Time
+}
+
+// NewServiceEndpointsCache creates a service/endpoint slice cache backed by client
+func NewServiceEndpointsCache(client *Client, ttl time.Duration) *ServiceEndpointsCache {
+	return &ServiceEndpointsCache{
+		client:  client,
+		ttl:     ttl,
+		entries: make(map[string]*serviceEndpointsEntry),
+	}
+}
+
+// Get returns the services and endpoint slices in namespace, refetching when
+// the cached copy is stale
+func (c *ServiceEndpointsCache) Get(ctx context.Context, namespace string) ([]v1.Service, []discoveryv1.EndpointSlice, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.entries[namespace]
+	if entry != nil && time.Since(entry.fetchedAt) < c.ttl {
+		return entry.services, entry.slices, nil
+	}
+
+	services, err := c.client.ListServices(ctx, namespace)
+	if err == nil {
+		var slices []discoveryv1.EndpointSlice
+		slices, err = c.client.ListEndpointSlices(ctx, namespace)
+		if err == nil {
+			c.entries[namespace] = &serviceEndpointsEntry{
+				services:  services,
+				slices:    slices,
+				fetchedAt: time.Now(),
+			}
+			return services, slices, nil
+		}
+	}
+
+	// Serve stale data rather than nothing if we have it
+	if entry != nil {
+		return entry.services, entry.slices, nil
+	}
+	return nil, nil, err
+}
+
+// Invalidate forces the next Get to refetch every namespace
+func (c *ServiceEndpointsCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*serviceEndpointsEntry)
+}
+
+// ComputeServiceMemberships lists every service whose selector matches the
+// pod's labels, sorted by name, with the pod's endpoint membership in each.
+// Services without a selector manage their endpoints by hand and are skipped.
+func ComputeServiceMemberships(pod *v1.Pod, services []v1.Service, slices []discoveryv1.EndpointSlice) []ServiceMembership {
+	slicesByService := make(map[string][]discoveryv1.EndpointSlice)
+	for _, slice := range slices {
+		if name := slice.Labels[discoveryv1.LabelServiceName]; name != "" {
+			slicesByService[name] = append(slicesByService[name], slice)
+		}
+	}
+
+	podIPs := podIPSet(pod)
+	podReady := isPodReady(pod)
+
+	var memberships []ServiceMembership
+	for _, svc := range services {
+		if svc.Namespace != "" && svc.Namespace != pod.Namespace {
+			continue
+		}
+		if len(svc.Spec.Selector) == 0 ||
+			!labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+
+		m := ServiceMembership{
+			Service:   svc.Name,
+			Type:      svc.Spec.Type,
+			ClusterIP: svc.Spec.ClusterIP,
+			Headless:  svc.Spec.ClusterIP == v1.ClusterIPNone,
+		}
+
+		if m.Type == "" {
+			m.Type = v1.ServiceTypeClusterIP
+		}
+
+		if len(podIPs) == 0 {
+			m.Membership = EndpointNoPodIP
+		} else {
+			m.Membership = endpointMembership(pod, podIPs, slicesByService[svc.Name])
+		}
+
+		switch m.Membership {
+		case EndpointMissing:
+			m.Warning = "selected but not in endpoints"
+		case EndpointNotReady:
+			if podReady {
+				m.Warning = "pod is Ready but its endpoint is not"
+			} else {
+				m.Warning = "selected but not ready"
+			}
+		case EndpointReady:
+			// publishNotReadyAddresses deliberately routes to unready pods
+			if !podReady && !svc.Spec.PublishNotReadyAddresses {
+				m.Warning = "ready in endpoints but pod is failing readiness"
+			}
+		}
+
+		memberships = append(memberships, m)
+	}
+
+	sort.Slice(memberships, func(i, j int) bool {
+		return memberships[i].Service < memberships[j].Service
+	})
+	return memberships
+}
+
+// podIPSet returns all IPs assigned to the pod
+func podIPSet(pod *v1.Pod) map[string]bool {
+	ips := make(map[string]bool)
+	if pod.Status.PodIP != "" {
+		ips[pod.Status.PodIP] = true
+	}
+	for _, ip := range pod.Status.PodIPs {
+		if ip.IP != "" {
+			ips[ip.IP] = true
+		}
+	}
+	return ips
+}
+
+// endpointMembership finds the pod in a service's endpoint slices, matching by
+// target reference or, failing that, by address
+func endpointMembership(pod *v1.Pod, podIPs map[string]bool, slices []discoveryv1.EndpointSlice) EndpointMembership {
+	for _, slice := range slices {
+		for _, ep := range slice.Endpoints {
+			if !endpointRefersToPod(ep, pod, podIPs) {
+				continue
+			}
+			// A nil ready condition means ready, per the EndpointSlice API
+			ready := ep.Conditions.Ready == nil || *ep.Conditions.Ready
+			terminating := ep.Conditions.Terminating != nil && *ep.Conditions.Terminating
+			if ready && !terminating {
+				return EndpointReady
+			}
+			return EndpointNotReady
+		}
+	}
+	return EndpointMissing
+}
+
+func endpointRefersToPod(ep discoveryv1.Endpoint, pod *v1.Pod, podIPs map[string]bool) bool {
+	if ref := ep.TargetRef; ref != nil && ref.Kind == "Pod" {
+		return ref.Name == pod.Name && (ref.Namespace == "" || ref.Namespace == pod.Namespace)
+	}
+	for _, addr := range ep.Addresses {
+		if podIPs[addr] {
+			return true
+		}
+	}
+	return false
+}
+
+// ListEndpointSlices lists endpoint slices in a namespace
+func (c *Client) ListEndpointSlices(ctx context.Context, namespace string) ([]discoveryv1.EndpointSlice, error) {
+	slices, err := listAll[discoveryv1.EndpointSlice](ctx, metav1.ListOptions{}, c.clientset.DiscoveryV1().EndpointSlices(namespace).List)
+	if err != nil {
+		return nil, err
+	}
+	return slices, nil
+}
+
+// GetPodServiceMemberships returns the services selecting pod and whether the
+// pod is in their endpoints, using the client's service/endpoints cache
+func (c *Client) GetPodServiceMemberships(ctx context.Context, pod *v1.Pod) ([]ServiceMembership, error) {
+	services, slices, err := c.serviceEndpoints().Get(ctx, pod.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return ComputeServiceMemberships(pod, services, slices), nil
+}
+
+// serviceEndpoints returns the client's service/endpoints cache, creating it on first use
+func (c *Client) serviceEndpoints() *ServiceEndpointsCache {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	if c.serviceEndpointsCache == nil {
+		c.serviceEndpointsCache = NewServiceEndpointsCache(c, DefaultServiceEndpointsTTL)
+	}
+	return c.serviceEndpointsCache
+}
+
+// formatPodNetworking renders the networking section of a pod describe
+func formatPodNetworking(pod *v1.Pod, memberships []ServiceMembership, err error) string {
+	var result strings.Builder
+	result.WriteString("\nNetworking:\n")
+
+	if ips := podIPSet(pod); len(ips) == 0 {
+		result.WriteString("  Pod IP:     none yet\n")
+	}
+
+	if err != nil {
+		result.WriteString(fmt.Sprintf("  Services:   unavailable (%v)\n", err))
+		return result.String()
+	}
+	if len(memberships) == 0 {
+		result.WriteString("  Services:   none select this pod\n")
+		return result.String()
+	}
+
+	nameWidth := 0
+	for _, m := range memberships {
+		if len(m.Service) > nameWidth {
+			nameWidth = len(m.Service)
+		}
+	}
+
+	result.WriteString("  Services:\n")
+	for _, m := range memberships {
+		address := fmt.Sprintf("%s %s", m.Type, m.ClusterIP)
+		if m.Headless {
+			address = "headless"
+		}
+		line := fmt.Sprintf("    %-*s  %-24s  %s", nameWidth, m.Service, address, m.Membership)
+		if m.Warning != "" {
+			line += "  ⚠ " + m.Warning
+		}
+		result.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	return result.String()
+}
@@ -0,0 +1,211 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func networkingTestPod(ip string, ready bool) *v1.Pod {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-1",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "web", "tier": "frontend"},
+		},
+		Status: v1.PodStatus{
+			PodIP:      ip,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: status}},
+		},
+	}
+}
+
+func networkingTestService(name string, selector map[string]string, clusterIP string) v1.Service {
+	return v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1.ServiceSpec{
+			Type:      v1.ServiceTypeClusterIP,
+			ClusterIP: clusterIP,
+			Selector:  selector,
+		},
+	}
+}
+
+// networkingTestSlice builds an endpoint slice for service with one endpoint
+// per address; byRef sets a pod target reference instead of relying on the IP
+func networkingTestSlice(service, podName, address string, ready *bool, byRef bool) discoveryv1.EndpointSlice {
+	ep := discoveryv1.Endpoint{
+		Addresses:  []string{address},
+		Conditions: discoveryv1.EndpointConditions{Ready: ready},
+	}
+	if byRef {
+		ep.TargetRef = &v1.ObjectReference{Kind: "Pod", Name: podName, Namespace: "default"}
+	}
+	return discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      service + "-abc",
+			Namespace: "default",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   []discoveryv1.Endpoint{ep},
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestComputeServiceMemberships(t *testing.T) {
+	web := networkingTestService("web", map[string]string{"app": "web"}, "10.96.0.10")
+	headless := networkingTestService("web-hl", map[string]string{"app": "web"}, v1.ClusterIPNone)
+	other := networkingTestService("api", map[string]string{"app": "api"}, "10.96.0.11")
+	manual := networkingTestService("external", nil, "10.96.0.12")
+
+	tests := []struct {
+		name         string
+		pod          *v1.Pod
+		services     []v1.Service
+		slices       []discoveryv1.EndpointSlice
+		expectNames  []string
+		expectStates []EndpointMembership
+		expectWarn   []bool
+	}{
+		{
+			name:         "ready endpoint matched by address",
+			pod:          networkingTestPod("10.0.0.5", true),
+			services:     []v1.Service{web, other, manual},
+			slices:       []discoveryv1.EndpointSlice{networkingTestSlice("web", "", "10.0.0.5", nil, false)},
+			expectNames:  []string{"web"},
+			expectStates: []EndpointMembership{EndpointReady},
+			expectWarn:   []bool{false},
+		},
+		{
+			name:         "not ready endpoint matched by target ref",
+			pod:          networkingTestPod("10.0.0.5", false),
+			services:     []v1.Service{web},
+			slices:       []discoveryv1.EndpointSlice{networkingTestSlice("web", "web-1", "10.0.0.99", boolPtr(false), true)},
+			expectNames:  []string{"web"},
+			expectStates: []EndpointMembership{EndpointNotReady},
+			expectWarn:   []bool{true},
+		},
+		{
+			name:         "ready endpoint but pod failing readiness",
+			pod:          networkingTestPod("10.0.0.5", false),
+			services:     []v1.Service{web},
+			slices:       []discoveryv1.EndpointSlice{networkingTestSlice("web", "", "10.0.0.5", boolPtr(true), false)},
+			expectNames:  []string{"web"},
+			expectStates: []EndpointMembership{EndpointReady},
+			expectWarn:   []bool{true},
+		},
+		{
+			name:         "selected but missing from endpoints, headless sorted",
+			pod:          networkingTestPod("10.0.0.5", true),
+			services:     []v1.Service{web, headless},
+			slices:       []discoveryv1.EndpointSlice{networkingTestSlice("web", "", "10.0.0.5", nil, false)},
+			expectNames:  []string{"web", "web-hl"},
+			expectStates: []EndpointMembership{EndpointReady, EndpointMissing},
+			expectWarn:   []bool{false, true},
+		},
+		{
+			name:         "pod without IP",
+			pod:          networkingTestPod("", false),
+			services:     []v1.Service{web},
+			expectNames:  []string{"web"},
+			expectStates: []EndpointMembership{EndpointNoPodIP},
+			expectWarn:   []bool{false},
+		},
+		{
+			name:     "no matching services",
+			pod:      networkingTestPod("10.0.0.5", true),
+			services: []v1.Service{other, manual},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeServiceMemberships(tt.pod, tt.services, tt.slices)
+
+			if len(got) != len(tt.expectNames) {
+				t.Fatalf("Expected %d memberships, got %+v", len(tt.expectNames), got)
+			}
+			for i, m := range got {
+				if m.Service != tt.expectNames[i] {
+					t.Errorf("[%d] expected service %s, got %s", i, tt.expectNames[i], m.Service)
+				}
+				if m.Membership != tt.expectStates[i] {
+					t.Errorf("[%d] expected %s, got %s", i, tt.expectStates[i], m.Membership)
+				}
+				if (m.Warning != "") != tt.expectWarn[i] {
+					t.Errorf("[%d] expected warning=%v, got %q", i, tt.expectWarn[i], m.Warning)
+				}
+			}
+		})
+	}
+}
+
+func TestDescribePodNetworking(t *testing.T) {
+	pod := networkingTestPod("10.0.0.5", false)
+	web := networkingTestService("web", map[string]string{"app": "web"}, "10.96.0.10")
+	headless := networkingTestService("web-hl", map[string]string{"app": "web"}, v1.ClusterIPNone)
+	slice := networkingTestSlice("web", "web-1", "10.0.0.5", boolPtr(false), true)
+
+	fakeClient := fake.NewSimpleClientset(pod, &web, &headless, &slice)
+	client := &Client{clientset: fakeClient}
+
+	output, err := client.DescribeResource(context.Background(), "pod", "web-1", "default")
+	if err != nil {
+		t.Fatalf("DescribeResource failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"Networking:",
+		"web     ClusterIP 10.96.0.10",
+		"not ready  ⚠ selected but not ready",
+		"web-hl  headless",
+		"not in endpoints  ⚠ selected but not in endpoints",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected describe output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	// A second describe within the TTL is served from the cache
+	if err := fakeClient.CoreV1().Services("default").Delete(context.Background(), "web-hl", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	output, _ = client.DescribeResource(context.Background(), "pod", "web-1", "default")
+	if !strings.Contains(output, "web-hl") {
+		t.Error("Expected cached services to be used within the TTL")
+	}
+
+	client.serviceEndpoints().Invalidate()
+	output, _ = client.DescribeResource(context.Background(), "pod", "web-1", "default")
+	if strings.Contains(output, "web-hl") {
+		t.Error("Expected invalidated cache to refetch services")
+	}
+}
+
+func TestDescribePodNetworkingWithoutIP(t *testing.T) {
+	pod := networkingTestPod("", false)
+	client := &Client{clientset: fake.NewSimpleClientset(pod)}
+
+	output, err := client.DescribeResource(context.Background(), "pod", "web-1", "default")
+	if err != nil {
+		t.Fatalf("DescribeResource failed: %v", err)
+	}
+	for _, want := range []string{"Pod IP:     none yet", "Services:   none select this pod"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected describe output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetNode returns a node by name
+func (c *Client) GetNode(ctx context.Context, name string) (*v1.Node, error) {
+	node, err := c.clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, c.wrapError(err, OpGet, "nodes", "", name)
+	}
+	return node, nil
+}
+
+// DeleteNode deletes a node
+func (c *Client) DeleteNode(ctx context.Context, name string) error {
+	err := c.clientset.CoreV1().Nodes().Delete(ctx, name, metav1.DeleteOptions{})
+	return c.wrapError(err, OpDelete, "nodes", "", name)
+}
+
+// ListPodsOnNode returns the pods scheduled on a node, across all namespaces
+func (c *Client) ListPodsOnNode(ctx context.Context, nodeName string) ([]v1.Pod, error) {
+	selector := fields.OneTermEqualSelector("spec.nodeName", nodeName).String()
+	listed, err := listAll[v1.Pod](ctx, metav1.ListOptions{FieldSelector: selector}, c.clientset.CoreV1().Pods("").List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "pods", "", "")
+	}
+
+	var pods []v1.Pod
+	for _, pod := range listed {
+		// The field selector is not applied everywhere (e.g. fake clients)
+		if pod.Spec.NodeName == nodeName {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+// PatchNodeUnschedulable cordons (true) or uncordons (false) a node, as
+// kubectl cordon and uncordon do. Pods already on the node keep running.
+func (c *Client) PatchNodeUnschedulable(ctx context.Context, name string, unschedulable bool) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+	_, err := c.clientset.CoreV1().Nodes().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return c.wrapError(err, OpPatch, "nodes", "", name)
+	}
+	return nil
+}
+
+// NodeRequests sums the resource requests of the pods on a node against what
+// the node can allocate
+type NodeRequests struct {
+	CPU    resource.Quantity
+	Memory resource.Quantity
+	Pods   int
+
+	AllocatableCPU    resource.Quantity
+	AllocatableMemory resource.Quantity
+	AllocatablePods   int64
+
+	// Pods that request neither CPU nor memory. The scheduler counts them as
+	// free, so a node can look empty while they use it heavily.
+	WithoutRequests int
+}
+
+// ComputeNodeRequests adds up the requests of the pods holding resources on
+// node. Pods that have finished no longer hold theirs and are skipped.
+func ComputeNodeRequests(node *v1.Node, pods []v1.Pod) NodeRequests {
+	var requests NodeRequests
+	if node != nil {
+		requests.AllocatableCPU = node.Status.Allocatable.Cpu().DeepCopy()
+		requests.AllocatableMemory = node.Status.Allocatable.Memory().DeepCopy()
+		requests.AllocatablePods = node.Status.Allocatable.Pods().Value()
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		requests.Pods++
+
+		podRequests := PodRequests(pod)
+		cpu, hasCPU := podRequests[v1.ResourceCPU]
+		memory, hasMemory := podRequests[v1.ResourceMemory]
+		if !hasCPU && !hasMemory {
+			requests.WithoutRequests++
+			continue
+		}
+		requests.CPU.Add(cpu)
+		requests.Memory.Add(memory)
+	}
+	return requests
+}
+
+// PodRequests returns what a pod reserves on its node the way the scheduler
+// counts it: the larger of its containers' summed requests and its largest
+// init container request, plus the pod overhead
+func PodRequests(pod *v1.Pod) v1.ResourceList {
+	requests := v1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			total := requests[name]
+			total.Add(quantity)
+			requests[name] = total
+		}
+	}
+
+	// Init containers run one at a time before the others start
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+
+	for name, quantity := range pod.Spec.Overhead {
+		total := requests[name]
+		total.Add(quantity)
+		requests[name] = total
+	}
+	return requests
+}
+
+// RequestFraction returns requested as a fraction of allocatable, or 0 when
+// nothing is allocatable
+func RequestFraction(requested, allocatable resource.Quantity) float64 {
+	if allocatable.IsZero() {
+		return 0
+	}
+	return requested.AsApproximateFloat64() / allocatable.AsApproximateFloat64()
+}
+
+// IsNodeReady reports whether a node's Ready condition is true
+func IsNodeReady(node *v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// IsNodeConditionHealthy reports whether a node condition has its healthy
+// status: Ready is true, and the pressure and unavailable conditions are not
+func IsNodeConditionHealthy(condition v1.NodeCondition) bool {
+	if condition.Type == v1.NodeReady {
+		return condition.Status == v1.ConditionTrue
+	}
+	return condition.Status == v1.ConditionFalse
+}
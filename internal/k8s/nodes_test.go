@@ -0,0 +1,174 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// requestsPod returns a running pod on node-1 whose containers request the
+// given CPU and memory; an empty value leaves that request out
+func requestsPod(name string, containers ...[2]string) v1.Pod {
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       v1.PodSpec{NodeName: "node-1"},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+	for _, c := range containers {
+		requests := v1.ResourceList{}
+		if c[0] != "" {
+			requests[v1.ResourceCPU] = resource.MustParse(c[0])
+		}
+		if c[1] != "" {
+			requests[v1.ResourceMemory] = resource.MustParse(c[1])
+		}
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{
+			Name:      name,
+			Resources: v1.ResourceRequirements{Requests: requests},
+		})
+	}
+	return pod
+}
+
+func TestComputeNodeRequests(t *testing.T) {
+	node := &v1.Node{Status: v1.NodeStatus{Allocatable: v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("4"),
+		v1.ResourceMemory: resource.MustParse("8Gi"),
+		v1.ResourcePods:   resource.MustParse("110"),
+	}}}
+
+	withInit := requestsPod("migrate", [2]string{"100m", "128Mi"})
+	withInit.Spec.InitContainers = []v1.Container{{
+		Name:      "init",
+		Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}},
+	}}
+	finished := requestsPod("job", [2]string{"2", "4Gi"})
+	finished.Status.Phase = v1.PodSucceeded
+
+	pods := []v1.Pod{
+		requestsPod("web", [2]string{"500m", "1Gi"}, [2]string{"250m", ""}),
+		requestsPod("besteffort", [2]string{"", ""}),
+		requestsPod("bare"),
+		withInit,
+		finished,
+	}
+
+	requests := ComputeNodeRequests(node, pods)
+	if requests.Pods != 4 {
+		t.Errorf("Expected 4 pods holding resources, got %d", requests.Pods)
+	}
+	if requests.WithoutRequests != 2 {
+		t.Errorf("Expected 2 pods without requests, got %d", requests.WithoutRequests)
+	}
+	// web 750m + migrate's init container 1 (larger than its 100m)
+	if got := requests.CPU.MilliValue(); got != 1750 {
+		t.Errorf("Expected 1750m CPU requested, got %dm", got)
+	}
+	if want := resource.MustParse("1152Mi"); requests.Memory.Cmp(want) != 0 {
+		t.Errorf("Expected 1152Mi memory requested, got %s", requests.Memory.String())
+	}
+	if requests.AllocatablePods != 110 {
+		t.Errorf("Expected 110 allocatable pods, got %d", requests.AllocatablePods)
+	}
+	if got := RequestFraction(requests.CPU, requests.AllocatableCPU); got < 0.437 || got > 0.438 {
+		t.Errorf("Expected 1750m of 4 CPU to be 0.4375, got %v", got)
+	}
+}
+
+func TestComputeNodeRequestsWithoutAllocatable(t *testing.T) {
+	requests := ComputeNodeRequests(nil, []v1.Pod{requestsPod("web", [2]string{"1", "1Gi"})})
+	if got := RequestFraction(requests.CPU, requests.AllocatableCPU); got != 0 {
+		t.Errorf("Expected no fraction without allocatable CPU, got %v", got)
+	}
+	if requests.CPU.MilliValue() != 1000 {
+		t.Errorf("Expected requests summed without a node, got %s", requests.CPU.String())
+	}
+}
+
+func TestPodRequestsAddsOverhead(t *testing.T) {
+	pod := requestsPod("sandboxed", [2]string{"500m", "256Mi"})
+	pod.Spec.Overhead = v1.ResourceList{v1.ResourceCPU: resource.MustParse("250m")}
+
+	requests := PodRequests(&pod)
+	if cpu := requests[v1.ResourceCPU]; cpu.MilliValue() != 750 {
+		t.Errorf("Expected 750m CPU with overhead, got %s", cpu.String())
+	}
+}
+
+func TestNodeClientOperations(t *testing.T) {
+	ctx := context.Background()
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	onNode := requestsPod("web", [2]string{"100m", ""})
+	onNode.Namespace = "shop"
+	elsewhere := requestsPod("api")
+	elsewhere.Spec.NodeName = "node-2"
+	client := &Client{clientset: fake.NewSimpleClientset(node, &onNode, &elsewhere)}
+
+	got, err := client.GetNode(ctx, "node-1")
+	if err != nil || got.Name != "node-1" {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	if _, err := client.GetNode(ctx, "missing"); ClassifyError(err) != ErrNotFound {
+		t.Errorf("Expected NotFound for a missing node, got %v", err)
+	}
+
+	pods, err := client.ListPodsOnNode(ctx, "node-1")
+	if err != nil {
+		t.Fatalf("ListPodsOnNode failed: %v", err)
+	}
+	if len(pods) != 1 || pods[0].Name != "web" || pods[0].Namespace != "shop" {
+		t.Errorf("Expected only shop/web on node-1, got %v", pods)
+	}
+
+	if err := client.PatchNodeUnschedulable(ctx, "node-1", true); err != nil {
+		t.Fatalf("Cordon failed: %v", err)
+	}
+	if got, _ := client.GetNode(ctx, "node-1"); !got.Spec.Unschedulable {
+		t.Error("Expected the node cordoned")
+	}
+	if err := client.PatchNodeUnschedulable(ctx, "node-1", false); err != nil {
+		t.Fatalf("Uncordon failed: %v", err)
+	}
+	if got, _ := client.GetNode(ctx, "node-1"); got.Spec.Unschedulable {
+		t.Error("Expected the node uncordoned")
+	}
+}
+
+// TestDescribeNode tests that describing a node, under either name the
+// resource type goes by, shows its state and addresses
+func TestDescribeNode(t *testing.T) {
+	client := &Client{clientset: fake.NewSimpleClientset(&v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{"node-role.kubernetes.io/control-plane": ""},
+		},
+		Spec: v1.NodeSpec{Unschedulable: true},
+		Status: v1.NodeStatus{
+			Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+			Addresses:  []v1.NodeAddress{{Type: v1.NodeInternalIP, Address: "10.0.0.4"}},
+			NodeInfo:   v1.NodeSystemInfo{KubeletVersion: "v1.29.2"},
+		},
+	})}
+
+	for _, resourceType := range []string{"node", "Nodes"} {
+		out, err := client.DescribeResource(context.Background(), resourceType, "node-1", "")
+		if err != nil {
+			t.Fatalf("DescribeResource(%s) failed: %v", resourceType, err)
+		}
+		for _, expected := range []string{
+			"Status:       Ready,SchedulingDisabled\n",
+			"Roles:        control-plane\n",
+			"Version:      v1.29.2\n",
+			"  InternalIP: 10.0.0.4\n",
+		} {
+			if !strings.Contains(out, expected) {
+				t.Errorf("Expected %q in:\n%s", expected, out)
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package k8s
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RequestObserver is told about every API request a client makes: the
+// client's context, the HTTP method, how long the server took to respond and
+// the error when no response arrived at all
+type RequestObserver func(contextName, method string, duration time.Duration, err error)
+
+var (
+	observerMu      sync.RWMutex
+	requestObserver RequestObserver
+)
+
+// SetRequestObserver installs fn to observe the API requests of every client,
+// including ones already created. Nil removes the observer.
+func SetRequestObserver(fn RequestObserver) {
+	observerMu.Lock()
+	defer observerMu.Unlock()
+	requestObserver = fn
+}
+
+// observedTransport reports each request to the installed RequestObserver.
+// For watches and log streams the duration covers the wait for the response
+// headers, not the stream.
+type observedTransport struct {
+	next   http.RoundTripper
+	client *Client
+}
+
+func (t *observedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	observerMu.RLock()
+	observe := requestObserver
+	observerMu.RUnlock()
+
+	if observe == nil {
+		return t.next.RoundTrip(req)
+	}
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	observe(t.client.contextName, req.Method, time.Since(start), err)
+	return resp, err
+}
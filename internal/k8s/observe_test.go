@@ -0,0 +1,47 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestRequestObserver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	client.contextName = "test"
+
+	var mu sync.Mutex
+	var observed []string
+	SetRequestObserver(func(contextName, method string, duration time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil || duration <= 0 {
+			t.Errorf("Expected a successful timed request, got %v after %v", err, duration)
+		}
+		observed = append(observed, contextName+" "+method)
+	})
+	defer SetRequestObserver(nil)
+
+	if _, err := client.ListPods(context.Background(), "default"); err != nil {
+		t.Fatalf("ListPods failed: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(observed) != 1 || observed[0] != "test GET" {
+		t.Errorf("Expected one observed GET for the test context, got %v", observed)
+	}
+}
@@ -0,0 +1,98 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ListPageSize is how many objects each request of a list asks for. Lists
+// are read a page at a time so a namespace of tens of thousands of pods
+// neither hits the API server's response size limit nor times out.
+const ListPageSize = 500
+
+// listRestarts is how many times a list starts over when its continue token
+// expires, which happens when the API server compacts away the snapshot
+// the pages are read from before the last page is
+const listRestarts = 3
+
+// pagedList is what a list read page by page
+type pagedList[T any] struct {
+	items []T
+
+	// unread is how many objects a list stopped early left: 0 when none
+	// were left, or -1 when the API server did not say how many
+	unread int
+
+	// resourceVersion is that of the snapshot the pages were read from,
+	// to watch for changes after it
+	resourceVersion string
+}
+
+// listAll lists every object of a typed list call, such as
+// Pods(namespace).List, page by page
+func listAll[T any, L runtime.Object](ctx context.Context, opts metav1.ListOptions, list func(context.Context, metav1.ListOptions) (L, error)) ([]T, error) {
+	listed, err := listPaged[T](ctx, opts, 0, list)
+	return listed.items, err
+}
+
+// listPaged is listAll stopping once it has upTo objects, or reading them
+// all for 0. Objects come in the API server's order, by namespace and then
+// name.
+func listPaged[T any, L runtime.Object](ctx context.Context, opts metav1.ListOptions, upTo int, list func(context.Context, metav1.ListOptions) (L, error)) (pagedList[T], error) {
+	var listed pagedList[T]
+	restarts := 0
+	opts.Continue = ""
+	for {
+		opts.Limit = ListPageSize
+		if upTo > 0 {
+			opts.Limit = int64(min(ListPageSize, upTo-len(listed.items)))
+		}
+
+		page, err := list(ctx, opts)
+		if apierrors.IsResourceExpired(err) && opts.Continue != "" && restarts < listRestarts {
+			// The pages read so far belong to a snapshot that is gone, so
+			// they cannot be finished consistently; start over
+			restarts++
+			listed.items = nil
+			opts.Continue = ""
+			continue
+		}
+		if err != nil {
+			return pagedList[T]{}, err
+		}
+
+		objects, err := meta.ExtractList(scrubbed(page))
+		if err != nil {
+			return pagedList[T]{}, err
+		}
+		for _, object := range objects {
+			item, ok := any(object).(*T)
+			if !ok {
+				return pagedList[T]{}, fmt.Errorf("unexpected %T in a list of %T", object, *new(T))
+			}
+			listed.items = append(listed.items, *item)
+		}
+
+		accessor, err := meta.ListAccessor(page)
+		if err != nil {
+			return pagedList[T]{}, err
+		}
+		listed.resourceVersion = accessor.GetResourceVersion()
+		if accessor.GetContinue() == "" {
+			return listed, nil
+		}
+		if upTo > 0 && len(listed.items) >= upTo {
+			listed.unread = -1
+			if remaining := accessor.GetRemainingItemCount(); remaining != nil {
+				listed.unread = int(*remaining)
+			}
+			return listed, nil
+		}
+		opts.Continue = accessor.GetContinue()
+	}
+}
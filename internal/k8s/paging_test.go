@@ -0,0 +1,120 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+// pagingServer serves pods a to e two to a page, expiring the continue
+// token of the last page the first expire times it is asked for
+func pagingServer(t *testing.T, expire int, remaining bool) (*Client, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []string
+	pages := map[string][]string{"": {"a", "b"}, "1": {"c", "d"}, "2": {"e"}}
+	next := map[string]string{"": "1", "1": "2"}
+	left := map[string]int{"": 3, "1": 1}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("continue")
+		mu.Lock()
+		requests = append(requests, "limit="+r.URL.Query().Get("limit")+" continue="+token)
+		expired := token == "2" && expire > 0
+		if expired {
+			expire--
+		}
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if expired {
+			w.WriteHeader(http.StatusGone)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Expired","code":410}`))
+			return
+		}
+		var items []string
+		for _, name := range pages[token] {
+			items = append(items, fmt.Sprintf(`{"metadata":{"name":%q,"namespace":"default"}}`, name))
+		}
+		metadata := fmt.Sprintf(`"resourceVersion":"7","continue":%q`, next[token])
+		if remaining && next[token] != "" {
+			metadata += fmt.Sprintf(`,"remainingItemCount":%d`, left[token])
+		}
+		fmt.Fprintf(w, `{"kind":"PodList","apiVersion":"v1","metadata":{%s},"items":[%s]}`, metadata, strings.Join(items, ","))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	return client, &requests
+}
+
+func podNames(t *testing.T, client *Client, upTo int) (string, int) {
+	t.Helper()
+	pods, unread, err := client.ListPodsUpTo(context.Background(), "default", "", "", upTo)
+	if err != nil {
+		t.Fatalf("ListPodsUpTo failed: %v", err)
+	}
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return strings.Join(names, ","), unread
+}
+
+func TestListReadsEveryPage(t *testing.T) {
+	client, requests := pagingServer(t, 0, true)
+
+	names, unread := podNames(t, client, 0)
+	if names != "a,b,c,d,e" || unread != 0 {
+		t.Errorf("got %q with %d unread, want every pod", names, unread)
+	}
+	if len(*requests) != 3 || (*requests)[0] != fmt.Sprintf("limit=%d continue=", ListPageSize) {
+		t.Errorf("requests = %v, want three pages of %d", *requests, ListPageSize)
+	}
+}
+
+func TestListRestartsWhenContinueExpires(t *testing.T) {
+	client, requests := pagingServer(t, 1, true)
+
+	names, _ := podNames(t, client, 0)
+	if names != "a,b,c,d,e" {
+		t.Errorf("got %q, want each pod once after starting over", names)
+	}
+	if len(*requests) != 6 {
+		t.Errorf("requests = %v, want the three pages read twice", *requests)
+	}
+}
+
+func TestListGivesUpWhenContinueKeepsExpiring(t *testing.T) {
+	client, _ := pagingServer(t, listRestarts+1, true)
+
+	_, _, err := client.ListPodsUpTo(context.Background(), "default", "", "", 0)
+	if err == nil {
+		t.Fatal("expected the expired continue token to fail the list in the end")
+	}
+}
+
+func TestListStopsAtUpTo(t *testing.T) {
+	client, requests := pagingServer(t, 0, true)
+
+	names, unread := podNames(t, client, 3)
+	if names != "a,b,c,d" || unread != 1 {
+		t.Errorf("got %q with %d unread, want the first two pages and 1 unread", names, unread)
+	}
+	if (*requests)[0] != "limit=3 continue=" {
+		t.Errorf("first request = %q, want it to ask for only 3", (*requests)[0])
+	}
+
+	client, _ = pagingServer(t, 0, false)
+	if _, unread := podNames(t, client, 2); unread != -1 {
+		t.Errorf("unread = %d, want -1 when the server does not count what is left", unread)
+	}
+}
@@ -0,0 +1,290 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PermissionVerbs are the verbs checked for each resource type
+var PermissionVerbs = []string{"get", "list", "watch", "delete", "create", "patch"}
+
+// Permission is whether the user may use a verb on a resource type
+type Permission int
+
+const (
+	// PermissionUnknown is when the check failed or could not tell
+	PermissionUnknown Permission = iota
+	PermissionAllowed
+	PermissionDenied
+)
+
+// String returns the permission's matrix cell
+func (p Permission) String() string {
+	switch p {
+	case PermissionAllowed:
+		return "yes"
+	case PermissionDenied:
+		return "no"
+	}
+	return "?"
+}
+
+// apiResource is what a resource type is authorized as
+type apiResource struct {
+	group    string
+	resource string
+}
+
+// permissionResources maps the resource types kubewatch lists to the API
+// group and resource RBAC rules name
+var permissionResources = map[core.ResourceType]apiResource{
+	core.ResourceTypePod:         {"", "pods"},
+	core.ResourceTypeDeployment:  {"apps", "deployments"},
+	core.ResourceTypeStatefulSet: {"apps", "statefulsets"},
+	core.ResourceTypeService:     {"", "services"},
+	core.ResourceTypeIngress:     {"networking.k8s.io", "ingresses"},
+	core.ResourceTypeGateway:     {core.GatewayAPIGroup, "gateways"},
+	core.ResourceTypeHTTPRoute:   {core.GatewayAPIGroup, "httproutes"},
+	core.ResourceTypeConfigMap:   {"", "configmaps"},
+	core.ResourceTypeSecret:      {"", "secrets"},
+
+	core.ResourceTypeServiceAccount:     {"", "serviceaccounts"},
+	core.ResourceTypeRole:               {"rbac.authorization.k8s.io", "roles"},
+	core.ResourceTypeClusterRole:        {"rbac.authorization.k8s.io", "clusterroles"},
+	core.ResourceTypeRoleBinding:        {"rbac.authorization.k8s.io", "rolebindings"},
+	core.ResourceTypeClusterRoleBinding: {"rbac.authorization.k8s.io", "clusterrolebindings"},
+
+	core.ResourceTypeNode: {"", "nodes"},
+
+	core.ResourceTypeJob:     {"batch", "jobs"},
+	core.ResourceTypeCronJob: {"batch", "cronjobs"},
+}
+
+// Permissions is what the user may do with each resource type in one
+// namespace of one context
+type Permissions struct {
+	Context   string
+	Namespace string // Empty for all namespaces
+	CheckedAt time.Time
+	// Method says how they were found: by a rules review, access reviews
+	// or both
+	Method string
+	// Err is why some permissions are unknown, when a review failed
+	Err error
+
+	cells map[core.ResourceType]map[string]Permission
+}
+
+// Can returns whether verb may be used on kind; unknown for permissions
+// never checked
+func (p *Permissions) Can(kind core.ResourceType, verb string) Permission {
+	if p == nil {
+		return PermissionUnknown
+	}
+	return p.cells[kind][verb]
+}
+
+// set records one permission
+func (p *Permissions) set(kind core.ResourceType, verb string, permission Permission) {
+	if p.cells[kind] == nil {
+		p.cells[kind] = make(map[string]Permission)
+	}
+	p.cells[kind][verb] = permission
+}
+
+// ReviewPermissions finds what the user may do with each resource type in
+// namespace. One rules review answers for the whole namespace; where the
+// server does not support it, or cannot list every rule, each permission
+// left is checked with an access review. All namespaces are checked with
+// access reviews, as rules reviews need a namespace. A failed review leaves
+// its permissions unknown rather than failing the rest.
+func (c *Client) ReviewPermissions(ctx context.Context, namespace string) *Permissions {
+	permissions := &Permissions{
+		Context:   c.contextName,
+		Namespace: namespace,
+		CheckedAt: time.Now(),
+		cells:     make(map[core.ResourceType]map[string]Permission),
+	}
+
+	if namespace != "" {
+		review, err := c.clientset.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx,
+			&authorizationv1.SelfSubjectRulesReview{
+				Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+			}, metav1.CreateOptions{})
+		switch {
+		case err == nil:
+			permissions.Method = "rules review"
+			permissions.fromRules(review.Status)
+		case apierrors.IsNotFound(err) || apierrors.IsMethodNotSupported(err):
+			// Not served; ask about each permission instead
+		default:
+			permissions.Err = c.wrapError(err, "create", "selfsubjectrulesreviews", namespace, "")
+			return permissions
+		}
+	}
+
+	c.reviewUnknownAccess(ctx, permissions)
+	return permissions
+}
+
+// fromRules decides each permission from the rules that apply to the user.
+// When the rules are incomplete, permissions no rule grants stay unknown.
+func (p *Permissions) fromRules(status authorizationv1.SubjectRulesReviewStatus) {
+	for kind, resource := range permissionResources {
+		for _, verb := range PermissionVerbs {
+			permission := PermissionDenied
+			if status.Incomplete {
+				permission = PermissionUnknown
+			}
+			for _, rule := range status.ResourceRules {
+				if ruleGrants(rule, resource, verb) {
+					permission = PermissionAllowed
+					break
+				}
+			}
+			p.set(kind, verb, permission)
+		}
+	}
+}
+
+// ruleGrants returns whether rule allows verb on every object of resource.
+// Rules limited to named objects do not.
+func ruleGrants(rule authorizationv1.ResourceRule, resource apiResource, verb string) bool {
+	return len(rule.ResourceNames) == 0 &&
+		matchesRule(rule.Verbs, verb) &&
+		matchesRule(rule.APIGroups, resource.group) &&
+		matchesRule(rule.Resources, resource.resource)
+}
+
+// matchesRule returns whether values, where "*" matches anything, has value
+func matchesRule(values []string, value string) bool {
+	for _, v := range values {
+		if v == value || v == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// reviewUnknownAccess checks each permission not yet known with an access
+// review, concurrently
+func (c *Client) reviewUnknownAccess(ctx context.Context, p *Permissions) {
+	type check struct {
+		kind     core.ResourceType
+		resource apiResource
+		verb     string
+	}
+	var checks []check
+	for kind, resource := range permissionResources {
+		for _, verb := range PermissionVerbs {
+			if p.Can(kind, verb) == PermissionUnknown {
+				checks = append(checks, check{kind, resource, verb})
+			}
+		}
+	}
+	if len(checks) == 0 {
+		return
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			namespace := p.Namespace
+			if core.IsClusterScoped(check.kind) {
+				namespace = ""
+			}
+			permission, err := c.reviewAccess(ctx, namespace, check.resource, check.verb)
+			mu.Lock()
+			defer mu.Unlock()
+			p.set(check.kind, check.verb, permission)
+			if err != nil && p.Err == nil {
+				p.Err = c.wrapError(err, "create", "selfsubjectaccessreviews", p.Namespace, "")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if p.Method == "" {
+		p.Method = "access reviews"
+	} else {
+		p.Method += " and access reviews"
+	}
+}
+
+// reviewAccess asks whether the user may use verb on resource in namespace.
+// The request is denied unless an authorizer allows it.
+func (c *Client) reviewAccess(ctx context.Context, namespace string, resource apiResource, verb string) (Permission, error) {
+	review, err := c.clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx,
+		&authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      verb,
+					Group:     resource.group,
+					Resource:  resource.resource,
+				},
+			},
+		}, metav1.CreateOptions{})
+	if err != nil {
+		return PermissionUnknown, err
+	}
+	if review.Status.Allowed {
+		return PermissionAllowed, nil
+	}
+	return PermissionDenied, nil
+}
+
+// PermissionCache keeps the permissions reviewed for each context and
+// namespace until they are checked again, so the permissions overlay and the
+// actions they rule out give the same answer
+type PermissionCache struct {
+	mu      sync.Mutex
+	entries map[permissionKey]*Permissions
+}
+
+// permissionKey identifies the permissions of one namespace of one context
+type permissionKey struct {
+	context   string
+	namespace string
+}
+
+// NewPermissionCache creates an empty permission cache
+func NewPermissionCache() *PermissionCache {
+	return &PermissionCache{entries: make(map[permissionKey]*Permissions)}
+}
+
+// Get returns the permissions last reviewed in a context's namespace, or nil
+func (c *PermissionCache) Get(contextName, namespace string) *Permissions {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[permissionKey{contextName, namespace}]
+}
+
+// Review checks the permissions in a context's namespace with client and
+// caches them under the context
+func (c *PermissionCache) Review(ctx context.Context, client *Client, contextName, namespace string) *Permissions {
+	permissions := client.ReviewPermissions(ctx, namespace)
+	permissions.Context = contextName
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[permissionKey{contextName, namespace}] = permissions
+	return permissions
+}
+
+// Denied returns whether verb is known to be denied on kind in a context's
+// namespace. Permissions never checked, or unknown, deny nothing.
+func (c *PermissionCache) Denied(contextName, namespace string, kind core.ResourceType, verb string) bool {
+	return c.Get(contextName, namespace).Can(kind, verb) == PermissionDenied
+}
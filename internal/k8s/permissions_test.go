@@ -0,0 +1,174 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/client-go/rest"
+)
+
+// permissionsTestClient creates a client of a server answering rules reviews
+// with rulesStatus, or the rules review as it is, and access reviews with
+// allowed
+func permissionsTestClient(t *testing.T, rulesStatus int, rules authorizationv1.SubjectRulesReviewStatus, allowed func(authorizationv1.ResourceAttributes) bool) (*Client, *int32) {
+	t.Helper()
+	var accessReviews int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/selfsubjectrulesreviews"):
+			if rulesStatus != http.StatusOK {
+				w.WriteHeader(rulesStatus)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"kind": "Status", "apiVersion": "v1", "status": "Failure", "code": rulesStatus,
+					"reason": map[int]string{http.StatusNotFound: "NotFound", http.StatusForbidden: "Forbidden"}[rulesStatus],
+				})
+				return
+			}
+			var review authorizationv1.SelfSubjectRulesReview
+			json.NewDecoder(r.Body).Decode(&review)
+			review.Status = rules
+			json.NewEncoder(w).Encode(review)
+		case strings.HasSuffix(r.URL.Path, "/selfsubjectaccessreviews"):
+			atomic.AddInt32(&accessReviews, 1)
+			var review authorizationv1.SelfSubjectAccessReview
+			json.NewDecoder(r.Body).Decode(&review)
+			review.Status.Allowed = allowed(*review.Spec.ResourceAttributes)
+			json.NewEncoder(w).Encode(review)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	return client, &accessReviews
+}
+
+func TestReviewPermissionsFromRules(t *testing.T) {
+	rules := authorizationv1.SubjectRulesReviewStatus{
+		ResourceRules: []authorizationv1.ResourceRule{
+			{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{""}, Resources: []string{"pods", "services"}},
+			{Verbs: []string{"*"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}},
+			{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{"token"}},
+		},
+	}
+	client, accessReviews := permissionsTestClient(t, http.StatusOK, rules, nil)
+
+	permissions := client.ReviewPermissions(context.Background(), "web")
+	if permissions.Err != nil {
+		t.Fatalf("Expected no error, got %v", permissions.Err)
+	}
+	tests := []struct {
+		kind core.ResourceType
+		verb string
+		want Permission
+	}{
+		{core.ResourceTypePod, "list", PermissionAllowed},
+		{core.ResourceTypePod, "delete", PermissionDenied},
+		{core.ResourceTypeDeployment, "patch", PermissionAllowed},
+		{core.ResourceTypeStatefulSet, "get", PermissionDenied},
+		{core.ResourceTypeSecret, "get", PermissionDenied}, // Only one named secret
+	}
+	for _, tt := range tests {
+		if got := permissions.Can(tt.kind, tt.verb); got != tt.want {
+			t.Errorf("Expected %s %s to be %s, got %s", tt.verb, tt.kind, tt.want, got)
+		}
+	}
+	if *accessReviews != 0 || permissions.Method != "rules review" {
+		t.Errorf("Expected the rules review alone to answer, got %d access reviews by %q", *accessReviews, permissions.Method)
+	}
+}
+
+func TestReviewPermissionsIncompleteRules(t *testing.T) {
+	rules := authorizationv1.SubjectRulesReviewStatus{
+		ResourceRules: []authorizationv1.ResourceRule{
+			{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}},
+		},
+		Incomplete: true,
+	}
+	client, accessReviews := permissionsTestClient(t, http.StatusOK, rules, nil)
+
+	// Everything is granted, so no access review is needed despite the
+	// incomplete rules
+	permissions := client.ReviewPermissions(context.Background(), "web")
+	if permissions.Can(core.ResourceTypeSecret, "delete") != PermissionAllowed || *accessReviews != 0 {
+		t.Errorf("Expected the wildcard rule to grant everything, got %s after %d access reviews",
+			permissions.Can(core.ResourceTypeSecret, "delete"), *accessReviews)
+	}
+
+	rules.ResourceRules = nil
+	client, accessReviews = permissionsTestClient(t, http.StatusOK, rules, func(attributes authorizationv1.ResourceAttributes) bool {
+		return attributes.Resource == "pods"
+	})
+	permissions = client.ReviewPermissions(context.Background(), "web")
+	if permissions.Can(core.ResourceTypePod, "delete") != PermissionAllowed || permissions.Can(core.ResourceTypeSecret, "get") != PermissionDenied {
+		t.Error("Expected access reviews to settle what the incomplete rules do not grant")
+	}
+	if want := int32(len(permissionResources) * len(PermissionVerbs)); *accessReviews != want {
+		t.Errorf("Expected %d access reviews, got %d", want, *accessReviews)
+	}
+	if permissions.Method != "rules review and access reviews" {
+		t.Errorf("Expected both methods named, got %q", permissions.Method)
+	}
+}
+
+func TestReviewPermissionsWithoutRulesReview(t *testing.T) {
+	client, _ := permissionsTestClient(t, http.StatusNotFound, authorizationv1.SubjectRulesReviewStatus{}, func(attributes authorizationv1.ResourceAttributes) bool {
+		return attributes.Namespace == "web" && attributes.Verb == "get"
+	})
+
+	permissions := client.ReviewPermissions(context.Background(), "web")
+	if permissions.Err != nil {
+		t.Fatalf("Expected no error, got %v", permissions.Err)
+	}
+	if permissions.Can(core.ResourceTypeConfigMap, "get") != PermissionAllowed || permissions.Can(core.ResourceTypeConfigMap, "watch") != PermissionDenied {
+		t.Error("Expected access reviews to answer when rules reviews are not served")
+	}
+	if permissions.Method != "access reviews" {
+		t.Errorf("Expected access reviews named, got %q", permissions.Method)
+	}
+}
+
+func TestReviewPermissionsForbidden(t *testing.T) {
+	client, accessReviews := permissionsTestClient(t, http.StatusForbidden, authorizationv1.SubjectRulesReviewStatus{}, nil)
+
+	permissions := client.ReviewPermissions(context.Background(), "web")
+	if ClassifyError(permissions.Err) != ErrForbidden {
+		t.Errorf("Expected the forbidden review reported, got %v", permissions.Err)
+	}
+	if permissions.Can(core.ResourceTypePod, "get") != PermissionUnknown || *accessReviews != 0 {
+		t.Error("Expected every permission unknown when the review itself is forbidden")
+	}
+}
+
+func TestPermissionCache(t *testing.T) {
+	rules := authorizationv1.SubjectRulesReviewStatus{
+		ResourceRules: []authorizationv1.ResourceRule{
+			{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+		},
+	}
+	client, _ := permissionsTestClient(t, http.StatusOK, rules, nil)
+	cache := NewPermissionCache()
+
+	if cache.Get("prod", "web") != nil || cache.Denied("prod", "web", core.ResourceTypePod, "delete") {
+		t.Fatal("Expected nothing denied before a review")
+	}
+	cache.Review(context.Background(), client, "prod", "web")
+	if !cache.Denied("prod", "web", core.ResourceTypePod, "delete") || cache.Denied("prod", "web", core.ResourceTypePod, "list") {
+		t.Error("Expected the reviewed permissions to decide what is denied")
+	}
+	if cache.Denied("prod", "api", core.ResourceTypePod, "delete") || cache.Denied("staging", "web", core.ResourceTypePod, "delete") {
+		t.Error("Expected other namespaces and contexts not reviewed to deny nothing")
+	}
+}
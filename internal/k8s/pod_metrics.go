@@ -0,0 +1,103 @@
+package k8s
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// MetricsHeadline chooses what a pod's CPU and MEMORY cells show when its
+// metrics break usage down by container
+type MetricsHeadline string
+
+const (
+	// HeadlinePod shows the sum over all the pod's containers
+	HeadlinePod MetricsHeadline = "pod"
+	// HeadlineLargest shows the container using the most CPU
+	HeadlineLargest MetricsHeadline = "largest"
+	// HeadlineMain shows the one container not matching a sidecar pattern,
+	// falling back to the sum when there are none or several
+	HeadlineMain MetricsHeadline = "main"
+)
+
+// DefaultSidecars are the container names HeadlineMain leaves out of the
+// headline unless configured otherwise
+var DefaultSidecars = []string{"istio-proxy", "linkerd-proxy"}
+
+// ParseMetricsHeadline parses a headline mode; empty means HeadlinePod
+func ParseMetricsHeadline(s string) (MetricsHeadline, error) {
+	switch mode := MetricsHeadline(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return HeadlinePod, nil
+	case HeadlinePod, HeadlineLargest, HeadlineMain:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown container usage %q: use %s, %s or %s", s, HeadlinePod, HeadlineLargest, HeadlineMain)
+}
+
+// ValidateSidecars checks that sidecar patterns are valid globs
+func ValidateSidecars(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid sidecar pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Headline returns the CPU and memory cells for the pod under mode. A cell
+// showing one of several containers says how many it leaves out, e.g.
+// "120m +2 more"; pods with a single container always show their totals.
+func (m *PodMetrics) Headline(mode MetricsHeadline, sidecars []string) (cpu, memory string) {
+	var headline *ContainerMetrics
+	switch mode {
+	case HeadlineLargest:
+		headline = m.largestContainer()
+	case HeadlineMain:
+		headline = m.mainContainer(sidecars)
+	}
+	if headline == nil || len(m.Containers) < 2 {
+		return m.CPU, m.Memory
+	}
+	hint := fmt.Sprintf(" +%d more", len(m.Containers)-1)
+	return headline.CPU + hint, headline.Memory + hint
+}
+
+// largestContainer returns the container using the most CPU, then memory
+func (m *PodMetrics) largestContainer() *ContainerMetrics {
+	var largest *ContainerMetrics
+	for i := range m.Containers {
+		c := &m.Containers[i]
+		if largest == nil || c.MilliCPU > largest.MilliCPU ||
+			(c.MilliCPU == largest.MilliCPU && c.MemoryBytes > largest.MemoryBytes) {
+			largest = c
+		}
+	}
+	return largest
+}
+
+// mainContainer returns the only container whose name matches none of the
+// sidecar patterns, or nil when there are none or several
+func (m *PodMetrics) mainContainer(sidecars []string) *ContainerMetrics {
+	var main *ContainerMetrics
+	for i := range m.Containers {
+		if isSidecar(m.Containers[i].Name, sidecars) {
+			continue
+		}
+		if main != nil {
+			return nil
+		}
+		main = &m.Containers[i]
+	}
+	return main
+}
+
+// isSidecar reports whether a container name matches one of the patterns
+func isSidecar(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+// meshedPodMetrics returns metrics for a pod with an app container, a mesh
+// proxy and a log shipper
+func meshedPodMetrics() *metricsv1beta1.PodMetrics {
+	usage := func(cpu, memory string) v1.ResourceList {
+		return v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse(cpu),
+			v1.ResourceMemory: resource.MustParse(memory),
+		}
+	}
+	return &metricsv1beta1.PodMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "prod"},
+		Containers: []metricsv1beta1.ContainerMetrics{
+			{Name: "istio-proxy", Usage: usage("300m", "64Mi")},
+			{Name: "app", Usage: usage("120m", "256Mi")},
+			{Name: "fluent-bit", Usage: usage("5m", "16Mi")},
+		},
+	}
+}
+
+func TestPodMetricsFromKeepsContainers(t *testing.T) {
+	m := podMetricsFrom(meshedPodMetrics())
+
+	if m.Name != "web-1" || m.Namespace != "prod" {
+		t.Errorf("Expected prod/web-1, got %s/%s", m.Namespace, m.Name)
+	}
+	if m.CPU != "425m" || m.Memory != "336Mi" {
+		t.Errorf("Expected totals 425m/336Mi, got %s/%s", m.CPU, m.Memory)
+	}
+	if len(m.Containers) != 3 {
+		t.Fatalf("Expected 3 containers, got %d", len(m.Containers))
+	}
+	app := m.Containers[1]
+	if app.Name != "app" || app.CPU != "120m" || app.Memory != "256Mi" || app.MilliCPU != 120 || app.MemoryBytes != 256*Mi {
+		t.Errorf("Unexpected app container metrics %+v", app)
+	}
+}
+
+func TestPodMetricsFromMissingUsage(t *testing.T) {
+	m := podMetricsFrom(&metricsv1beta1.PodMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: "idle", Namespace: "prod"},
+		Containers: []metricsv1beta1.ContainerMetrics{{Name: "app"}},
+	})
+	if m.CPU != "-" || m.Memory != "-" {
+		t.Errorf("Expected no usage, got %s/%s", m.CPU, m.Memory)
+	}
+	if len(m.Containers) != 1 || m.Containers[0].CPU != "-" {
+		t.Errorf("Expected the container kept without usage, got %+v", m.Containers)
+	}
+}
+
+func TestPodMetricsHeadline(t *testing.T) {
+	meshed := podMetricsFrom(meshedPodMetrics())
+	single := &PodMetrics{CPU: "50m", Memory: "32Mi", Containers: []ContainerMetrics{
+		{Name: "app", CPU: "50m", Memory: "32Mi", MilliCPU: 50, MemoryBytes: 32 * Mi},
+	}}
+
+	tests := []struct {
+		name        string
+		metrics     *PodMetrics
+		mode        MetricsHeadline
+		sidecars    []string
+		cpu, memory string
+	}{
+		{"pod sums containers", meshed, HeadlinePod, DefaultSidecars, "425m", "336Mi"},
+		{"largest picks the proxy", meshed, HeadlineLargest, nil, "300m +2 more", "64Mi +2 more"},
+		{"main skips sidecars", meshed, HeadlineMain, []string{"istio-proxy", "fluent-*"}, "120m +2 more", "256Mi +2 more"},
+		{"several mains fall back to the sum", meshed, HeadlineMain, DefaultSidecars, "425m", "336Mi"},
+		{"no main falls back to the sum", meshed, HeadlineMain, []string{"*"}, "425m", "336Mi"},
+		{"single container shows totals", single, HeadlineLargest, nil, "50m", "32Mi"},
+		{"no breakdown shows totals", &PodMetrics{CPU: "10m", Memory: "8Mi"}, HeadlineMain, DefaultSidecars, "10m", "8Mi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu, memory := tt.metrics.Headline(tt.mode, tt.sidecars)
+			if cpu != tt.cpu || memory != tt.memory {
+				t.Errorf("Headline() = %q, %q; want %q, %q", cpu, memory, tt.cpu, tt.memory)
+			}
+		})
+	}
+}
+
+func TestPodMetricsHeadlineLargestBreaksTiesOnMemory(t *testing.T) {
+	m := &PodMetrics{CPU: "20m", Memory: "96Mi", Containers: []ContainerMetrics{
+		{Name: "a", CPU: "10m", Memory: "32Mi", MilliCPU: 10, MemoryBytes: 32 * Mi},
+		{Name: "b", CPU: "10m", Memory: "64Mi", MilliCPU: 10, MemoryBytes: 64 * Mi},
+	}}
+	if _, memory := m.Headline(HeadlineLargest, nil); memory != "64Mi +1 more" {
+		t.Errorf("Expected b as the largest, got %q", memory)
+	}
+}
+
+func TestParseMetricsHeadline(t *testing.T) {
+	for in, want := range map[string]MetricsHeadline{"": HeadlinePod, "pod": HeadlinePod, " Largest ": HeadlineLargest, "main": HeadlineMain} {
+		if got, err := ParseMetricsHeadline(in); err != nil || got != want {
+			t.Errorf("ParseMetricsHeadline(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseMetricsHeadline("biggest"); err == nil {
+		t.Error("Expected an unknown mode rejected")
+	}
+	if err := ValidateSidecars([]string{"istio-*", "[bad"}); err == nil {
+		t.Error("Expected a bad pattern rejected")
+	}
+}
+
+func TestGetPodMetricsPerContainer(t *testing.T) {
+	metricsClient := metricsfake.NewSimpleClientset()
+	gvr := schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+	if err := metricsClient.Tracker().Create(gvr, meshedPodMetrics(), "prod"); err != nil {
+		t.Fatalf("Failed to add metrics: %v", err)
+	}
+
+	client := &Client{metricsClient: metricsClient}
+	metrics, err := client.GetPodMetrics(context.Background(), "prod")
+	if err != nil {
+		t.Fatalf("GetPodMetrics failed: %v", err)
+	}
+	m, ok := metrics.Get("prod", "web-1")
+	if !ok {
+		t.Fatal("Expected metrics for prod/web-1")
+	}
+	if m.CPU != "425m" || len(m.Containers) != 3 || m.Containers[0].Name != "istio-proxy" {
+		t.Errorf("Expected the totals and all three containers, got %+v", m)
+	}
+}
@@ -0,0 +1,335 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// ListServiceAccounts returns service accounts in a namespace
+func (c *Client) ListServiceAccounts(ctx context.Context, namespace string) ([]v1.ServiceAccount, error) {
+	items, err := listAll[v1.ServiceAccount](ctx, metav1.ListOptions{}, c.clientset.CoreV1().ServiceAccounts(namespace).List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "serviceaccounts", namespace, "")
+	}
+	return items, nil
+}
+
+// WatchServiceAccounts watches for service account changes
+func (c *Client) WatchServiceAccounts(ctx context.Context, namespace string) (watch.Interface, error) {
+	w, err := c.clientset.CoreV1().ServiceAccounts(namespace).Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, "serviceaccounts", namespace)
+}
+
+// DeleteServiceAccount deletes a service account
+func (c *Client) DeleteServiceAccount(ctx context.Context, namespace, name string) error {
+	err := c.clientset.CoreV1().ServiceAccounts(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	return c.wrapError(err, OpDelete, "serviceaccounts", namespace, name)
+}
+
+// ListRoles returns roles in a namespace
+func (c *Client) ListRoles(ctx context.Context, namespace string) ([]rbacv1.Role, error) {
+	items, err := listAll[rbacv1.Role](ctx, metav1.ListOptions{}, c.clientset.RbacV1().Roles(namespace).List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "roles", namespace, "")
+	}
+	return items, nil
+}
+
+// WatchRoles watches for role changes
+func (c *Client) WatchRoles(ctx context.Context, namespace string) (watch.Interface, error) {
+	w, err := c.clientset.RbacV1().Roles(namespace).Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, "roles", namespace)
+}
+
+// DeleteRole deletes a role
+func (c *Client) DeleteRole(ctx context.Context, namespace, name string) error {
+	err := c.clientset.RbacV1().Roles(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	return c.wrapError(err, OpDelete, "roles", namespace, name)
+}
+
+// ListClusterRoles returns the cluster's cluster roles
+func (c *Client) ListClusterRoles(ctx context.Context) ([]rbacv1.ClusterRole, error) {
+	items, err := listAll[rbacv1.ClusterRole](ctx, metav1.ListOptions{}, c.clientset.RbacV1().ClusterRoles().List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "clusterroles", "", "")
+	}
+	return items, nil
+}
+
+// WatchClusterRoles watches for cluster role changes
+func (c *Client) WatchClusterRoles(ctx context.Context) (watch.Interface, error) {
+	w, err := c.clientset.RbacV1().ClusterRoles().Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, "clusterroles", "")
+}
+
+// DeleteClusterRole deletes a cluster role
+func (c *Client) DeleteClusterRole(ctx context.Context, name string) error {
+	err := c.clientset.RbacV1().ClusterRoles().Delete(ctx, name, metav1.DeleteOptions{})
+	return c.wrapError(err, OpDelete, "clusterroles", "", name)
+}
+
+// ListRoleBindings returns role bindings in a namespace
+func (c *Client) ListRoleBindings(ctx context.Context, namespace string) ([]rbacv1.RoleBinding, error) {
+	items, err := listAll[rbacv1.RoleBinding](ctx, metav1.ListOptions{}, c.clientset.RbacV1().RoleBindings(namespace).List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "rolebindings", namespace, "")
+	}
+	return items, nil
+}
+
+// WatchRoleBindings watches for role binding changes
+func (c *Client) WatchRoleBindings(ctx context.Context, namespace string) (watch.Interface, error) {
+	w, err := c.clientset.RbacV1().RoleBindings(namespace).Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, "rolebindings", namespace)
+}
+
+// DeleteRoleBinding deletes a role binding
+func (c *Client) DeleteRoleBinding(ctx context.Context, namespace, name string) error {
+	err := c.clientset.RbacV1().RoleBindings(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	return c.wrapError(err, OpDelete, "rolebindings", namespace, name)
+}
+
+// ListClusterRoleBindings returns the cluster's cluster role bindings
+func (c *Client) ListClusterRoleBindings(ctx context.Context) ([]rbacv1.ClusterRoleBinding, error) {
+	items, err := listAll[rbacv1.ClusterRoleBinding](ctx, metav1.ListOptions{}, c.clientset.RbacV1().ClusterRoleBindings().List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "clusterrolebindings", "", "")
+	}
+	return items, nil
+}
+
+// WatchClusterRoleBindings watches for cluster role binding changes
+func (c *Client) WatchClusterRoleBindings(ctx context.Context) (watch.Interface, error) {
+	w, err := c.clientset.RbacV1().ClusterRoleBindings().Watch(ctx, metav1.ListOptions{})
+	return c.watched(w, err, "clusterrolebindings", "")
+}
+
+// DeleteClusterRoleBinding deletes a cluster role binding
+func (c *Client) DeleteClusterRoleBinding(ctx context.Context, name string) error {
+	err := c.clientset.RbacV1().ClusterRoleBindings().Delete(ctx, name, metav1.DeleteOptions{})
+	return c.wrapError(err, OpDelete, "clusterrolebindings", "", name)
+}
+
+// describeServiceAccount returns detailed information about a service account
+func (c *Client) describeServiceAccount(ctx context.Context, name, namespace string) (string, error) {
+	sa, err := c.clientset.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", c.wrapError(err, OpGet, "serviceaccounts", namespace, name)
+	}
+
+	var result strings.Builder
+	result.WriteString(describeDeletion(sa.ObjectMeta, time.Now()))
+	result.WriteString(fmt.Sprintf("Name:         %s\n", sa.Name))
+	result.WriteString(fmt.Sprintf("Namespace:    %s\n", sa.Namespace))
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(sa.CreationTimestamp.Time)))
+	if sa.AutomountServiceAccountToken != nil {
+		result.WriteString(fmt.Sprintf("Automount:    %t\n", *sa.AutomountServiceAccountToken))
+	}
+
+	if len(sa.Secrets) > 0 {
+		result.WriteString("\nSecrets:\n")
+		for _, secret := range sa.Secrets {
+			result.WriteString(fmt.Sprintf("  %s\n", secret.Name))
+		}
+	}
+	if len(sa.ImagePullSecrets) > 0 {
+		result.WriteString("\nImage Pull Secrets:\n")
+		for _, secret := range sa.ImagePullSecrets {
+			result.WriteString(fmt.Sprintf("  %s\n", secret.Name))
+		}
+	}
+
+	if len(sa.Labels) > 0 {
+		result.WriteString("\nLabels:\n")
+		for k, v := range sa.Labels {
+			result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
+		}
+	}
+
+	return result.String(), nil
+}
+
+// describeRole returns detailed information about a role, or a cluster role
+// when cluster is set
+func (c *Client) describeRole(ctx context.Context, name, namespace string, cluster bool) (string, error) {
+	meta, rules, err := c.getRoleRules(ctx, name, namespace, cluster)
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	result.WriteString(describeDeletion(meta, time.Now()))
+	result.WriteString(fmt.Sprintf("Name:         %s\n", meta.Name))
+	if !cluster {
+		result.WriteString(fmt.Sprintf("Namespace:    %s\n", meta.Namespace))
+	}
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(meta.CreationTimestamp.Time)))
+
+	result.WriteString("\nRules:\n")
+	result.WriteString(describeRules("  ", rules))
+
+	if len(meta.Labels) > 0 {
+		result.WriteString("\nLabels:\n")
+		for k, v := range meta.Labels {
+			result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
+		}
+	}
+
+	return result.String(), nil
+}
+
+// describeRoleBinding returns detailed information about a role binding, or
+// a cluster role binding when cluster is set: who it grants what to, and the
+// rules of the role it grants, fetched so that a review needs no second look
+func (c *Client) describeRoleBinding(ctx context.Context, name, namespace string, cluster bool) (string, error) {
+	var meta metav1.ObjectMeta
+	var roleRef rbacv1.RoleRef
+	var subjects []rbacv1.Subject
+	if cluster {
+		binding, err := c.clientset.RbacV1().ClusterRoleBindings().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", c.wrapError(err, OpGet, "clusterrolebindings", "", name)
+		}
+		meta, roleRef, subjects = binding.ObjectMeta, binding.RoleRef, binding.Subjects
+	} else {
+		binding, err := c.clientset.RbacV1().RoleBindings(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", c.wrapError(err, OpGet, "rolebindings", namespace, name)
+		}
+		meta, roleRef, subjects = binding.ObjectMeta, binding.RoleRef, binding.Subjects
+	}
+
+	var result strings.Builder
+	result.WriteString(describeDeletion(meta, time.Now()))
+	result.WriteString(fmt.Sprintf("Name:         %s\n", meta.Name))
+	if !cluster {
+		result.WriteString(fmt.Sprintf("Namespace:    %s\n", meta.Namespace))
+	}
+	result.WriteString(fmt.Sprintf("Role:         %s\n", core.RoleRefName(roleRef)))
+	result.WriteString(fmt.Sprintf("Created:      %s\n", core.FormatTimestamp(meta.CreationTimestamp.Time)))
+
+	result.WriteString("\nSubjects:\n")
+	rows := make([][]string, 0, len(subjects))
+	for _, subject := range subjects {
+		subjectNamespace := subject.Namespace
+		if subject.Kind == rbacv1.ServiceAccountKind && subjectNamespace == "" {
+			subjectNamespace = meta.Namespace
+		}
+		rows = append(rows, []string{subject.Kind, subject.Name, subjectNamespace})
+	}
+	result.WriteString(describeTable("  ", []string{"Kind", "Name", "Namespace"}, rows))
+
+	// A role binding may grant a cluster role, whose rules then apply only
+	// within the binding's namespace
+	result.WriteString(fmt.Sprintf("\nRules granted by %s:\n", core.RoleRefName(roleRef)))
+	_, rules, err := c.getRoleRules(ctx, roleRef.Name, meta.Namespace, roleRef.Kind == "ClusterRole")
+	if err != nil {
+		result.WriteString(fmt.Sprintf("  <unavailable: %v>\n", err))
+	} else {
+		result.WriteString(describeRules("  ", rules))
+	}
+
+	if len(meta.Labels) > 0 {
+		result.WriteString("\nLabels:\n")
+		for k, v := range meta.Labels {
+			result.WriteString(fmt.Sprintf("  %s=%s\n", k, v))
+		}
+	}
+
+	return result.String(), nil
+}
+
+// getRoleRules fetches a role's metadata and rules, or a cluster role's
+func (c *Client) getRoleRules(ctx context.Context, name, namespace string, cluster bool) (metav1.ObjectMeta, []rbacv1.PolicyRule, error) {
+	if cluster {
+		role, err := c.clientset.RbacV1().ClusterRoles().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return metav1.ObjectMeta{}, nil, c.wrapError(err, OpGet, "clusterroles", "", name)
+		}
+		return role.ObjectMeta, role.Rules, nil
+	}
+	role, err := c.clientset.RbacV1().Roles(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return metav1.ObjectMeta{}, nil, c.wrapError(err, OpGet, "roles", namespace, name)
+	}
+	return role.ObjectMeta, role.Rules, nil
+}
+
+// describeRules renders policy rules as kubectl describe does: one row per
+// rule, its resources qualified by their API group, e.g. "deployments.apps"
+func describeRules(indent string, rules []rbacv1.PolicyRule) string {
+	rows := make([][]string, 0, len(rules))
+	for _, rule := range rules {
+		var resources []string
+		for _, resource := range rule.Resources {
+			if len(rule.APIGroups) == 0 {
+				resources = append(resources, resource)
+			}
+			for _, group := range rule.APIGroups {
+				if group == "" {
+					resources = append(resources, resource)
+				} else {
+					resources = append(resources, resource+"."+group)
+				}
+			}
+		}
+		sort.Strings(resources)
+		rows = append(rows, []string{
+			strings.Join(resources, ", "),
+			bracketed(rule.NonResourceURLs),
+			bracketed(rule.ResourceNames),
+			bracketed(rule.Verbs),
+		})
+	}
+	return describeTable(indent, []string{"Resources", "Non-Resource URLs", "Resource Names", "Verbs"}, rows)
+}
+
+// bracketed lists values as kubectl does, e.g. "[get list]", "[]" for none
+func bracketed(values []string) string {
+	return "[" + strings.Join(values, " ") + "]"
+}
+
+// describeTable lays rows out in aligned columns under headers, or notes
+// that there are none
+func describeTable(indent string, headers []string, rows [][]string) string {
+	if len(rows) == 0 {
+		return indent + "<none>\n"
+	}
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = len(header)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+
+	var b strings.Builder
+	line := func(cells []string) {
+		var l strings.Builder
+		for i, cell := range cells {
+			l.WriteString(fmt.Sprintf("%-*s  ", widths[i], cell))
+		}
+		b.WriteString(indent + strings.TrimRight(l.String(), " ") + "\n")
+	}
+	line(headers)
+	rules := make([]string, len(headers))
+	for i, header := range headers {
+		rules[i] = strings.Repeat("-", len(header))
+	}
+	line(rules)
+	for _, row := range rows {
+		line(row)
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// TestDescribeRoleBindingExpandsRole tests that describing a binding lists
+// its subjects and the rules of the role it grants
+func TestDescribeRoleBindingExpandsRole(t *testing.T) {
+	client := &Client{clientset: fake.NewSimpleClientset(
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "deploy"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get", "patch"}},
+				{APIGroups: []string{""}, Resources: []string{"pods"}, ResourceNames: []string{"web-1"}, Verbs: []string{"delete"}},
+			},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployer-deploy", Namespace: "ci"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "deploy"},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Name: "deployer"},
+				{Kind: rbacv1.UserKind, Name: "alice"},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "orphan"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "gone"},
+		},
+	)}
+	ctx := context.Background()
+
+	out, err := client.DescribeResource(ctx, "RoleBindings", "deployer-deploy", "ci")
+	if err != nil {
+		t.Fatalf("DescribeResource failed: %v", err)
+	}
+	for _, expected := range []string{
+		"Role:         ClusterRole/deploy\n",
+		"  Kind            Name      Namespace\n",
+		"  ServiceAccount  deployer  ci\n",
+		"  User            alice\n",
+		"Rules granted by ClusterRole/deploy:\n",
+		"  deployments.apps  []                 []              [get patch]\n",
+		"  pods              []                 [web-1]         [delete]\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, out)
+		}
+	}
+
+	// A binding to a role that is gone still describes, saying so
+	out, err = client.DescribeResource(ctx, "ClusterRoleBindings", "orphan", "")
+	if err != nil {
+		t.Fatalf("DescribeResource failed: %v", err)
+	}
+	if strings.Contains(out, "Namespace:") || !strings.Contains(out, "Subjects:\n  <none>\n") || !strings.Contains(out, "<unavailable:") {
+		t.Errorf("Expected no namespace, no subjects and the role unavailable, got:\n%s", out)
+	}
+}
@@ -325,6 +325,7 @@ func (orc *OptimizedResourceClient) ListPodsOptimized(ctx context.Context, names
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
+	StripManagedFields(podList)
 
 	// Cache results
 	orc.cache.SetPods(cacheKey, podList.Items, podList.ResourceVersion)
@@ -356,6 +357,7 @@ func (orc *OptimizedResourceClient) ListDeploymentsOptimized(ctx context.Context
 	if err != nil {
 		return nil, fmt.Errorf("failed to list deployments: %w", err)
 	}
+	StripManagedFields(deploymentList)
 
 	orc.cache.SetDeployments(cacheKey, deploymentList.Items, deploymentList.ResourceVersion)
 
@@ -385,6 +387,7 @@ func (orc *OptimizedResourceClient) ListServicesOptimized(ctx context.Context, n
 	if err != nil {
 		return nil, fmt.Errorf("failed to list services: %w", err)
 	}
+	StripManagedFields(serviceList)
 
 	orc.cache.SetServices(cacheKey, serviceList.Items, serviceList.ResourceVersion)
 
@@ -0,0 +1,75 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// restartedAtAnnotation is the pod template annotation kubectl rollout
+// restart sets, whose change rolls every pod
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// ErrRolloutPaused is returned for a restart of a deployment whose rollout
+// is paused, which would not roll anything until it is resumed
+var ErrRolloutPaused = errors.New("its rollout is paused; resume it before restarting")
+
+// PauseRollout pauses a deployment's rollout, as kubectl rollout pause does.
+// Changes to its pod template are not rolled out until it is resumed.
+func (c *Client) PauseRollout(ctx context.Context, namespace, name string) error {
+	return c.patchRolloutPaused(ctx, namespace, name, true)
+}
+
+// ResumeRollout resumes a paused deployment's rollout, as kubectl rollout
+// resume does
+func (c *Client) ResumeRollout(ctx context.Context, namespace, name string) error {
+	return c.patchRolloutPaused(ctx, namespace, name, false)
+}
+
+// patchRolloutPaused sets a deployment's spec.paused
+func (c *Client) patchRolloutPaused(ctx context.Context, namespace, name string, paused bool) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"paused":%t}}`, paused))
+	_, err := c.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return c.wrapError(err, OpPatch, "deployments", namespace, name)
+	}
+	return nil
+}
+
+// RestartDeployment rolls every pod of a deployment, as kubectl rollout
+// restart does. A paused deployment is refused with ErrRolloutPaused.
+func (c *Client) RestartDeployment(ctx context.Context, namespace, name string) error {
+	deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return c.wrapError(err, OpGet, "deployments", namespace, name)
+	}
+	if deployment.Spec.Paused {
+		return fmt.Errorf("cannot restart deployment %s: %w", name, ErrRolloutPaused)
+	}
+	_, err = c.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, restartPatch(), metav1.PatchOptions{})
+	if err != nil {
+		return c.wrapError(err, OpPatch, "deployments", namespace, name)
+	}
+	return nil
+}
+
+// RestartStatefulSet rolls every pod of a statefulset, as kubectl rollout
+// restart does, one at a time in its update strategy's order
+func (c *Client) RestartStatefulSet(ctx context.Context, namespace, name string) error {
+	_, err := c.clientset.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, restartPatch(), metav1.PatchOptions{})
+	if err != nil {
+		return c.wrapError(err, OpPatch, "statefulsets", namespace, name)
+	}
+	return nil
+}
+
+// restartPatch stamps a pod template with the time, which kubectl rollout
+// restart uses to change the template and so roll its pods
+func restartPatch() []byte {
+	return []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+		restartedAtAnnotation, time.Now().Format(time.RFC3339)))
+}
@@ -0,0 +1,92 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestClientPauseResumeRollout(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+	})
+	client := &Client{clientset: fakeClient}
+	ctx := context.Background()
+	paused := func() bool {
+		deployment, err := fakeClient.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return deployment.Spec.Paused
+	}
+
+	if err := client.PauseRollout(ctx, "default", "web"); err != nil {
+		t.Fatalf("PauseRollout failed: %v", err)
+	}
+	if !paused() {
+		t.Error("Expected the rollout paused")
+	}
+
+	// A paused deployment is not restarted
+	err := client.RestartDeployment(ctx, "default", "web")
+	if !errors.Is(err, ErrRolloutPaused) {
+		t.Errorf("Expected the restart refused, got %v", err)
+	}
+
+	if err := client.ResumeRollout(ctx, "default", "web"); err != nil {
+		t.Fatalf("ResumeRollout failed: %v", err)
+	}
+	if paused() {
+		t.Error("Expected the rollout resumed")
+	}
+
+	if err := client.PauseRollout(ctx, "default", "missing"); err == nil {
+		t.Error("Expected an error pausing a missing deployment")
+	}
+}
+
+func TestClientRestartDeployment(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+	})
+	client := &Client{clientset: fakeClient}
+	ctx := context.Background()
+
+	if err := client.RestartDeployment(ctx, "default", "web"); err != nil {
+		t.Fatalf("RestartDeployment failed: %v", err)
+	}
+	deployment, err := fakeClient.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if deployment.Spec.Template.Annotations[restartedAtAnnotation] == "" {
+		t.Errorf("Expected the pod template stamped, got %v", deployment.Spec.Template.Annotations)
+	}
+}
+
+func TestClientRestartStatefulSet(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(&appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+	})
+	client := &Client{clientset: fakeClient}
+	ctx := context.Background()
+
+	if err := client.RestartStatefulSet(ctx, "default", "db"); err != nil {
+		t.Fatalf("RestartStatefulSet failed: %v", err)
+	}
+	statefulset, err := fakeClient.AppsV1().StatefulSets("default").Get(ctx, "db", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if statefulset.Spec.Template.Annotations[restartedAtAnnotation] == "" {
+		t.Errorf("Expected the pod template stamped, got %v", statefulset.Spec.Template.Annotations)
+	}
+
+	if err := client.RestartStatefulSet(ctx, "default", "missing"); err == nil {
+		t.Error("Expected an error restarting a missing statefulset")
+	}
+}
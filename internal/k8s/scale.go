@@ -0,0 +1,40 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ScaleDeployment sets a deployment's replicas through its scale
+// subresource, as kubectl scale does
+func (c *Client) ScaleDeployment(ctx context.Context, namespace, name string, replicas int32) error {
+	if replicas < 0 {
+		return fmt.Errorf("cannot scale deployment %s to %d replicas", name, replicas)
+	}
+	_, err := c.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, scalePatch(replicas), metav1.PatchOptions{}, "scale")
+	if err != nil {
+		return c.wrapError(err, OpScale, "deployments", namespace, name)
+	}
+	return nil
+}
+
+// ScaleStatefulSet sets a statefulset's replicas through its scale
+// subresource, as kubectl scale does
+func (c *Client) ScaleStatefulSet(ctx context.Context, namespace, name string, replicas int32) error {
+	if replicas < 0 {
+		return fmt.Errorf("cannot scale statefulset %s to %d replicas", name, replicas)
+	}
+	_, err := c.clientset.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, scalePatch(replicas), metav1.PatchOptions{}, "scale")
+	if err != nil {
+		return c.wrapError(err, OpScale, "statefulsets", namespace, name)
+	}
+	return nil
+}
+
+// scalePatch sets the replicas of a scale subresource
+func scalePatch(replicas int32) []byte {
+	return []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+}
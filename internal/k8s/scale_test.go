@@ -0,0 +1,38 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestClientScale(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}},
+	)
+	client := &Client{clientset: fakeClient}
+	ctx := context.Background()
+
+	if err := client.ScaleDeployment(ctx, "default", "web", 4); err != nil {
+		t.Fatalf("ScaleDeployment failed: %v", err)
+	}
+	if err := client.ScaleStatefulSet(ctx, "default", "db", 0); err != nil {
+		t.Fatalf("ScaleStatefulSet failed: %v", err)
+	}
+	for _, action := range fakeClient.Actions() {
+		if action.GetVerb() == "patch" && action.GetSubresource() != "scale" {
+			t.Errorf("Expected the scale subresource patched, got %q", action.GetSubresource())
+		}
+	}
+
+	if err := client.ScaleDeployment(ctx, "default", "web", -1); err == nil {
+		t.Error("Expected negative replicas refused")
+	}
+	if err := client.ScaleStatefulSet(ctx, "default", "missing", 1); err == nil {
+		t.Error("Expected an error scaling a missing statefulset")
+	}
+}
@@ -0,0 +1,52 @@
+package k8s
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// StripManagedFields drops the managed fields of a decoded object, or of
+// each item of a decoded list. Server-side apply records there which
+// manager set every field, often more bytes than the rest of the object,
+// and kubewatch never shows them; every later copy, conversion and cached
+// list would otherwise carry them. Objects fetched one at a time, as
+// describe does, keep them.
+func StripManagedFields(obj runtime.Object) {
+	if meta.IsListType(obj) {
+		meta.EachListItem(obj, func(item runtime.Object) error {
+			stripObjectManagedFields(item)
+			return nil
+		})
+		return
+	}
+	stripObjectManagedFields(obj)
+}
+
+// stripObjectManagedFields drops one object's managed fields; objects
+// without metadata, such as a watch's error status, are left alone
+func stripObjectManagedFields(obj runtime.Object) {
+	if accessor, err := meta.Accessor(obj); err == nil {
+		accessor.SetManagedFields(nil)
+	}
+}
+
+// scrubbed strips a list's managed fields as it is decoded, before its
+// items go anywhere. Every list the client returns goes through it.
+func scrubbed[L runtime.Object](list L) L {
+	StripManagedFields(list)
+	return list
+}
+
+// watched finishes starting a watch: a failure is wrapped, and the objects
+// of a started watch's events are stripped of their managed fields before
+// they are delivered. Every watch the client starts goes through it.
+func (c *Client) watched(w watch.Interface, err error, resource, namespace string) (watch.Interface, error) {
+	if err != nil {
+		return nil, c.wrapError(err, OpWatch, resource, namespace, "")
+	}
+	return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+		StripManagedFields(event.Object)
+		return event, true
+	}), nil
+}
@@ -0,0 +1,272 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+)
+
+// scrubTestKinds are the kinds and API versions of the resources served by
+// the managed fields test server
+var scrubTestKinds = map[string][2]string{
+	"pods":         {"Pod", "v1"},
+	"deployments":  {"Deployment", "apps/v1"},
+	"statefulsets": {"StatefulSet", "apps/v1"},
+	"services":     {"Service", "v1"},
+	"ingresses":    {"Ingress", "networking.k8s.io/v1"},
+	"gateways":     {"Gateway", "gateway.networking.k8s.io/v1"},
+	"httproutes":   {"HTTPRoute", "gateway.networking.k8s.io/v1"},
+	"configmaps":   {"ConfigMap", "v1"},
+	"secrets":      {"Secret", "v1"},
+	"events":       {"Event", "v1"},
+
+	"serviceaccounts":     {"ServiceAccount", "v1"},
+	"roles":               {"Role", "rbac.authorization.k8s.io/v1"},
+	"clusterroles":        {"ClusterRole", "rbac.authorization.k8s.io/v1"},
+	"rolebindings":        {"RoleBinding", "rbac.authorization.k8s.io/v1"},
+	"clusterrolebindings": {"ClusterRoleBinding", "rbac.authorization.k8s.io/v1"},
+
+	"nodes": {"Node", "v1"},
+
+	"jobs":     {"Job", "batch/v1"},
+	"cronjobs": {"CronJob", "batch/v1"},
+}
+
+// managedFieldsServer serves every list and watch with one object carrying
+// managed fields, as server-side apply leaves them
+func managedFieldsServer(t *testing.T) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		kind, ok := scrubTestKinds[parts[len(parts)-1]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		object := fmt.Sprintf(`{"kind":%q,"apiVersion":%q,"metadata":{"name":"web","namespace":"default",`+
+			`"managedFields":[{"manager":"kubectl","operation":"Apply","apiVersion":%q,"fieldsType":"FieldsV1","fieldsV1":{"f:metadata":{"f:labels":{}}}}]}}`,
+			kind[0], kind[1], kind[1])
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("watch") == "true" {
+			fmt.Fprintf(w, `{"type":"ADDED","object":%s}`+"\n", object)
+			return
+		}
+		fmt.Fprintf(w, `{"kind":"%sList","apiVersion":%q,"metadata":{},"items":[%s]}`, kind[0], kind[1], object)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	return client
+}
+
+// TestListsAndWatchesDropManagedFields tests that every resource type
+// kubewatch lists arrives without managed fields, whether listed or watched
+func TestListsAndWatchesDropManagedFields(t *testing.T) {
+	client := managedFieldsServer(t)
+	ctx := context.Background()
+
+	type listWatch struct {
+		list  func() (runtime.Object, error)
+		watch func() (watch.Interface, error)
+	}
+	byType := map[core.ResourceType]listWatch{
+		core.ResourceTypePod: {
+			func() (runtime.Object, error) { return firstListed(client.ListPods(ctx, "default")) },
+			func() (watch.Interface, error) { return client.WatchPods(ctx, "default") }},
+		core.ResourceTypeDeployment: {
+			func() (runtime.Object, error) { return firstListed(client.ListDeployments(ctx, "default")) },
+			func() (watch.Interface, error) { return client.WatchDeployments(ctx, "default") }},
+		core.ResourceTypeStatefulSet: {
+			func() (runtime.Object, error) { return firstListed(client.ListStatefulSets(ctx, "default")) },
+			func() (watch.Interface, error) { return client.WatchStatefulSets(ctx, "default") }},
+		core.ResourceTypeService: {
+			func() (runtime.Object, error) { return firstListed(client.ListServices(ctx, "default")) },
+			func() (watch.Interface, error) { return client.WatchServices(ctx, "default") }},
+		core.ResourceTypeIngress: {
+			func() (runtime.Object, error) { return firstListed(client.ListIngresses(ctx, "default")) },
+			func() (watch.Interface, error) { return client.WatchIngresses(ctx, "default") }},
+		core.ResourceTypeGateway: {
+			func() (runtime.Object, error) { return firstListed(client.ListGateways(ctx, "default")) },
+			func() (watch.Interface, error) { return client.WatchGateways(ctx, "default") }},
+		core.ResourceTypeHTTPRoute: {
+			func() (runtime.Object, error) { return firstListed(client.ListHTTPRoutes(ctx, "default")) },
+			func() (watch.Interface, error) { return client.WatchHTTPRoutes(ctx, "default") }},
+		core.ResourceTypeConfigMap: {
+			func() (runtime.Object, error) { return firstListed(client.ListConfigMaps(ctx, "default")) },
+			func() (watch.Interface, error) { return client.WatchConfigMaps(ctx, "default") }},
+		core.ResourceTypeSecret: {
+			func() (runtime.Object, error) { return firstListed(client.ListSecrets(ctx, "default")) },
+			func() (watch.Interface, error) { return client.WatchSecrets(ctx, "default") }},
+		core.ResourceTypeServiceAccount: {
+			func() (runtime.Object, error) { return firstListed(client.ListServiceAccounts(ctx, "default")) },
+			func() (watch.Interface, error) { return client.WatchServiceAccounts(ctx, "default") }},
+		core.ResourceTypeRole: {
+			func() (runtime.Object, error) { return firstListed(client.ListRoles(ctx, "default")) },
+			func() (watch.Interface, error) { return client.WatchRoles(ctx, "default") }},
+		core.ResourceTypeClusterRole: {
+			func() (runtime.Object, error) { return firstListed(client.ListClusterRoles(ctx)) },
+			func() (watch.Interface, error) { return client.WatchClusterRoles(ctx) }},
+		core.ResourceTypeRoleBinding: {
+			func() (runtime.Object, error) { return firstListed(client.ListRoleBindings(ctx, "default")) },
+			func() (watch.Interface, error) { return client.WatchRoleBindings(ctx, "default") }},
+		core.ResourceTypeClusterRoleBinding: {
+			func() (runtime.Object, error) { return firstListed(client.ListClusterRoleBindings(ctx)) },
+			func() (watch.Interface, error) { return client.WatchClusterRoleBindings(ctx) }},
+		core.ResourceTypeNode: {
+			func() (runtime.Object, error) { return firstListed(client.ListNodes(ctx)) },
+			func() (watch.Interface, error) { return client.WatchNodes(ctx) }},
+		core.ResourceTypeJob: {
+			func() (runtime.Object, error) { return firstListed(client.ListJobs(ctx, "default")) },
+			func() (watch.Interface, error) { return client.WatchJobs(ctx, "default") }},
+		core.ResourceTypeCronJob: {
+			func() (runtime.Object, error) { return firstListed(client.ListCronJobs(ctx, "default")) },
+			func() (watch.Interface, error) { return client.WatchCronJobs(ctx, "default") }},
+	}
+
+	for _, resourceType := range core.AllResourceTypes {
+		t.Run(string(resourceType), func(t *testing.T) {
+			paths, ok := byType[resourceType]
+			if !ok {
+				t.Fatal("Expected every resource type to be checked; add its list and watch here")
+			}
+
+			listed, err := paths.list()
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			expectNoManagedFields(t, "listed", listed)
+
+			w, err := paths.watch()
+			if err != nil {
+				t.Fatalf("Watch failed: %v", err)
+			}
+			defer w.Stop()
+			select {
+			case event := <-w.ResultChan():
+				expectNoManagedFields(t, "watched", event.Object)
+			case <-time.After(5 * time.Second):
+				t.Fatal("Expected a watch event")
+			}
+		})
+	}
+
+	events, _, err := client.ListEvents(ctx, "default")
+	if err != nil || len(events) != 1 {
+		t.Fatalf("Expected one event listed, got %d (%v)", len(events), err)
+	}
+	expectNoManagedFields(t, "listed event", &events[0])
+}
+
+// firstListed returns the first object of a list call
+func firstListed[T any, P interface {
+	*T
+	runtime.Object
+}](items []T, err error) (runtime.Object, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("nothing listed")
+	}
+	return P(&items[0]), nil
+}
+
+func expectNoManagedFields(t *testing.T, how string, obj runtime.Object) {
+	t.Helper()
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		t.Fatalf("Expected an object %s, got %T: %v", how, obj, err)
+	}
+	if accessor.GetName() != "web" {
+		t.Errorf("Expected the object %s decoded, got %+v", how, obj)
+	}
+	if fields := accessor.GetManagedFields(); fields != nil {
+		t.Errorf("Expected no managed fields %s, got %+v", how, fields)
+	}
+}
+
+// syntheticDeploymentEvents returns count modifications of a hundred
+// deployments, each written by several managers as controllers on a busy
+// cluster leave them
+func syntheticDeploymentEvents(count int) []watch.Event {
+	managers := []string{"kubectl-client-side-apply", "argocd-controller", "kube-controller-manager", "hpa-controller", "istio-sidecar-injector"}
+	fields := `{"f:metadata":{"f:annotations":{".":{},"f:deployment.kubernetes.io/revision":{}},"f:labels":{".":{},"f:app":{},"f:team":{},"f:version":{}}},` +
+		`"f:spec":{"f:progressDeadlineSeconds":{},"f:replicas":{},"f:revisionHistoryLimit":{},"f:selector":{},"f:strategy":{"f:rollingUpdate":{".":{},"f:maxSurge":{},"f:maxUnavailable":{}},"f:type":{}},` +
+		`"f:template":{"f:metadata":{"f:labels":{".":{},"f:app":{}}},"f:spec":{"f:containers":{"k:{\"name\":\"app\"}":{".":{},"f:image":{},"f:imagePullPolicy":{},"f:name":{},` +
+		`"f:ports":{".":{},"k:{\"containerPort\":8080,\"protocol\":\"TCP\"}":{".":{},"f:containerPort":{},"f:protocol":{}}},"f:resources":{".":{},"f:limits":{".":{},"f:cpu":{},"f:memory":{}},"f:requests":{".":{},"f:cpu":{},"f:memory":{}}}}}}}},` +
+		`"f:status":{"f:availableReplicas":{},"f:conditions":{".":{},"k:{\"type\":\"Available\"}":{".":{},"f:lastTransitionTime":{},"f:lastUpdateTime":{},"f:message":{},"f:reason":{},"f:status":{},"f:type":{}}},"f:observedGeneration":{},"f:readyReplicas":{},"f:replicas":{},"f:updatedReplicas":{}}}`
+
+	events := make([]watch.Event, count)
+	for i := range events {
+		replicas := int32(i%5 + 1)
+		deployment := &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            fmt.Sprintf("app-%d", i%100),
+				Namespace:       "default",
+				UID:             types.UID(fmt.Sprintf("uid-%d", i%100)),
+				ResourceVersion: fmt.Sprint(i + 1),
+				Labels:          map[string]string{"app": "web", "team": "checkout", "version": "v1"},
+			},
+			Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		}
+		for _, manager := range managers {
+			deployment.ManagedFields = append(deployment.ManagedFields, metav1.ManagedFieldsEntry{
+				Manager:    manager,
+				Operation:  metav1.ManagedFieldsOperationUpdate,
+				APIVersion: "apps/v1",
+				FieldsType: "FieldsV1",
+				FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+			})
+		}
+		events[i] = watch.Event{Type: watch.Modified, Object: deployment}
+	}
+	return events
+}
+
+// BenchmarkWatchEventProcessing measures what 10k watch events of objects
+// with realistic managed fields cost to record in the session's history,
+// with the managed fields kept as decoded and stripped as the client does
+func BenchmarkWatchEventProcessing(b *testing.B) {
+	events := syntheticDeploymentEvents(10000)
+	for _, strip := range []bool{false, true} {
+		name := "kept"
+		if strip {
+			name = "stripped"
+		}
+		b.Run(name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				b.StopTimer()
+				decoded := make([]watch.Event, len(events))
+				for i, event := range events {
+					decoded[i] = watch.Event{Type: event.Type, Object: event.Object.DeepCopyObject()}
+				}
+				history := core.NewObjectHistory()
+				b.StartTimer()
+
+				for _, event := range decoded {
+					if strip {
+						StripManagedFields(event.Object)
+					}
+					history.ObserveEvent(event, time.Now())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetNamespace returns a namespace by name
+func (c *Client) GetNamespace(ctx context.Context, name string) (*v1.Namespace, error) {
+	namespace, err := c.clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, c.wrapError(err, OpGet, "namespaces", "", name)
+	}
+	return namespace, nil
+}
+
+// NamespaceState is whether a namespace can still hold resources
+type NamespaceState int
+
+const (
+	NamespaceActive NamespaceState = iota
+	NamespaceTerminating
+	NamespaceGone
+)
+
+// GetNamespaceState reports whether the namespace name exists, is being
+// deleted, or is gone. Listing in a namespace that was deleted succeeds with
+// nothing in it, so this is how a deleted namespace is told from an empty one.
+func (c *Client) GetNamespaceState(ctx context.Context, name string) (NamespaceState, error) {
+	namespace, err := c.GetNamespace(ctx, name)
+	if apierrors.IsNotFound(err) {
+		return NamespaceGone, nil
+	}
+	if err != nil {
+		return NamespaceActive, err
+	}
+	if namespace.DeletionTimestamp != nil || namespace.Status.Phase == v1.NamespaceTerminating {
+		return NamespaceTerminating, nil
+	}
+	return NamespaceActive, nil
+}
+
+// describeSecurity renders the Pod Security violations visible in a pod spec
+// for the end of describe output. It is empty when there are none.
+func describeSecurity(spec *v1.PodSpec) string {
+	findings := core.CheckPodSecurity(spec)
+	if len(findings) == 0 {
+		return ""
+	}
+
+	var result strings.Builder
+	result.WriteString("\nSecurity:\n")
+	for _, finding := range findings {
+		result.WriteString(fmt.Sprintf("  ⚠ %-8s %s (not allowed by %s)\n", finding.Badge, finding.Detail, finding.Level))
+	}
+	return result.String()
+}
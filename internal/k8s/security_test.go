@@ -0,0 +1,98 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDescribeShowsSecurityFindings(t *testing.T) {
+	privileged := true
+	flagged := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+		Spec: v1.PodSpec{
+			HostNetwork: true,
+			Containers: []v1.Container{{
+				Name:            "agent",
+				SecurityContext: &v1.SecurityContext{Privileged: &privileged},
+			}},
+		},
+	}
+	nonRoot := true
+	clean := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1.PodSpec{Containers: []v1.Container{{
+			Name:            "web",
+			SecurityContext: &v1.SecurityContext{RunAsNonRoot: &nonRoot},
+		}}},
+	}
+	client := &Client{clientset: fake.NewSimpleClientset(flagged, clean)}
+
+	output, err := client.DescribeResource(context.Background(), "pod", "agent", "default")
+	if err != nil {
+		t.Fatalf("DescribeResource failed: %v", err)
+	}
+	for _, want := range []string{"\nSecurity:\n", "⚠ PRIV     container agent is privileged (not allowed by baseline)", "HOSTNET"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in describe output, got:\n%s", want, output)
+		}
+	}
+
+	output, err = client.DescribeResource(context.Background(), "pod", "web", "default")
+	if err != nil {
+		t.Fatalf("DescribeResource failed: %v", err)
+	}
+	if strings.Contains(output, "Security:") {
+		t.Errorf("Expected no security section for a clean pod, got:\n%s", output)
+	}
+}
+
+func TestGetNamespace(t *testing.T) {
+	namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "payments",
+		Labels: map[string]string{"pod-security.kubernetes.io/enforce": "restricted"},
+	}}
+	client := &Client{clientset: fake.NewSimpleClientset(namespace)}
+
+	got, err := client.GetNamespace(context.Background(), "payments")
+	if err != nil || got.Labels["pod-security.kubernetes.io/enforce"] != "restricted" {
+		t.Fatalf("Expected the payments namespace with its labels, got %v, %v", got, err)
+	}
+	if _, err := client.GetNamespace(context.Background(), "missing"); ClassifyError(err) != ErrNotFound {
+		t.Errorf("Expected NotFound for a missing namespace, got %v", err)
+	}
+}
+
+func TestGetNamespaceState(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}})
+	client := &Client{clientset: clientset}
+
+	check := func(expected NamespaceState) {
+		t.Helper()
+		state, err := client.GetNamespaceState(ctx, "payments")
+		if err != nil || state != expected {
+			t.Fatalf("Expected state %d, got %d (%v)", expected, state, err)
+		}
+	}
+	check(NamespaceActive)
+
+	// Deleting a namespace first marks it terminating while its contents go
+	terminating := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments"},
+		Status:     v1.NamespaceStatus{Phase: v1.NamespaceTerminating},
+	}
+	if _, err := clientset.CoreV1().Namespaces().Update(ctx, terminating, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update namespace: %v", err)
+	}
+	check(NamespaceTerminating)
+
+	if err := clientset.CoreV1().Namespaces().Delete(ctx, "payments", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete namespace: %v", err)
+	}
+	check(NamespaceGone)
+}
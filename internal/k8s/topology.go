@@ -0,0 +1,203 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ZoneLabel is the well-known node label holding the node's zone
+	ZoneLabel = "topology.kubernetes.io/zone"
+
+	// UnknownTopologyBucket collects pods on nodes without a zone label
+	UnknownTopologyBucket = "unknown"
+
+	// DefaultTopologySkewThreshold is the largest skew (max - min pods per
+	// bucket) that is not flagged, matching the Kubernetes default maxSkew
+	DefaultTopologySkewThreshold = 1
+
+	// DefaultNodeInfoTTL is how long node labels are cached before refetching
+	DefaultNodeInfoTTL = 60 * time.Second
+)
+
+// NodeInfoCache caches node name -> labels so topology summaries can be
+// recomputed on every refresh without listing nodes each time
+type NodeInfoCache struct {
+	mu        sync.Mutex
+	client    *Client
+	ttl       time.Duration
+	labels    map[string]map[string]string
+	fetchedAt time.Time
+}
+
+// NewNodeInfoCache creates a node info cache backed by client
+func NewNodeInfoCache(client *Client, ttl time.Duration) *NodeInfoCache {
+	return &NodeInfoCache{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+// NodeLabels returns labels keyed by node name, refetching when the cache is stale
+func (c *NodeInfoCache) NodeLabels(ctx context.Context) (map[string]map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.labels != nil && time.Since(c.fetchedAt) < c.ttl {
+		return c.labels, nil
+	}
+
+	nodes, err := c.client.ListNodes(ctx)
+	if err != nil {
+		// Serve stale labels rather than nothing if we have them
+		if c.labels != nil {
+			return c.labels, nil
+		}
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	labels := make(map[string]map[string]string, len(nodes))
+	for _, node := range nodes {
+		labels[node.Name] = node.Labels
+	}
+	c.labels = labels
+	c.fetchedAt = time.Now()
+
+	return c.labels, nil
+}
+
+// Invalidate forces the next NodeLabels call to refetch
+func (c *NodeInfoCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.labels = nil
+}
+
+// TopologyBucket is the number of pods in one node or zone
+type TopologyBucket struct {
+	Name  string
+	Count int
+}
+
+// TopologySpread describes how a set of pods is spread across nodes and zones
+type TopologySpread struct {
+	ByNode      []TopologyBucket
+	ByZone      []TopologyBucket
+	Unscheduled int
+}
+
+// ComputeTopologySpread buckets scheduled pods by node and by the node's zone
+// label. Every zone known from nodeLabels is included, even with zero pods,
+// so that replicas piled into one zone show up as skew.
+func ComputeTopologySpread(pods []v1.Pod, nodeLabels map[string]map[string]string) TopologySpread {
+	var spread TopologySpread
+
+	nodeCounts := make(map[string]int)
+	zoneCounts := make(map[string]int)
+	for _, labels := range nodeLabels {
+		if zone := labels[ZoneLabel]; zone != "" {
+			zoneCounts[zone] = 0
+		}
+	}
+
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			spread.Unscheduled++
+			continue
+		}
+		nodeCounts[pod.Spec.NodeName]++
+
+		zone := nodeLabels[pod.Spec.NodeName][ZoneLabel]
+		if zone == "" {
+			zone = UnknownTopologyBucket
+		}
+		zoneCounts[zone]++
+	}
+
+	spread.ByNode = sortedBuckets(nodeCounts)
+	spread.ByZone = sortedBuckets(zoneCounts)
+	return spread
+}
+
+// sortedBuckets orders buckets by count descending, then name
+func sortedBuckets(counts map[string]int) []TopologyBucket {
+	buckets := make([]TopologyBucket, 0, len(counts))
+	for name, count := range counts {
+		buckets = append(buckets, TopologyBucket{Name: name, Count: count})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Count != buckets[j].Count {
+			return buckets[i].Count > buckets[j].Count
+		}
+		return buckets[i].Name < buckets[j].Name
+	})
+	return buckets
+}
+
+// bucketSkew returns max - min pods per bucket, ignoring the unknown bucket
+// since it does not represent a real failure domain
+func bucketSkew(buckets []TopologyBucket) int {
+	first := true
+	minCount, maxCount := 0, 0
+	for _, b := range buckets {
+		if b.Name == UnknownTopologyBucket {
+			continue
+		}
+		if first || b.Count < minCount {
+			minCount = b.Count
+		}
+		if first || b.Count > maxCount {
+			maxCount = b.Count
+		}
+		first = false
+	}
+	return maxCount - minCount
+}
+
+// ZoneSkew returns the difference between the most and least loaded zones
+func (s TopologySpread) ZoneSkew() int {
+	return bucketSkew(s.ByZone)
+}
+
+// NodeSkew returns the difference between the most and least loaded nodes
+// that host at least one of the pods
+func (s TopologySpread) NodeSkew() int {
+	return bucketSkew(s.ByNode)
+}
+
+// ZoneSummary formats the zone buckets, e.g. "zone us-east-1a: 5, us-east-1b: 1 ⚠"
+func (s TopologySpread) ZoneSummary(threshold int) string {
+	return formatTopologySummary("zone", s.ByZone, s.ZoneSkew() > threshold)
+}
+
+// NodeSummary formats the node buckets, e.g. "node ip-10-0-1-5: 3, ip-10-0-2-7: 1 ⚠"
+func (s TopologySpread) NodeSummary(threshold int) string {
+	// A single node holding several replicas is the worst case, even though
+	// there is nothing to compute a skew against
+	warn := s.NodeSkew() > threshold ||
+		(len(s.ByNode) == 1 && s.ByNode[0].Count > threshold)
+	return formatTopologySummary("node", s.ByNode, warn)
+}
+
+func formatTopologySummary(kind string, buckets []TopologyBucket, warn bool) string {
+	if len(buckets) == 0 {
+		return kind + " -"
+	}
+
+	parts := make([]string, 0, len(buckets))
+	for _, b := range buckets {
+		parts = append(parts, fmt.Sprintf("%s: %d", b.Name, b.Count))
+	}
+
+	summary := kind + " " + strings.Join(parts, ", ")
+	if warn {
+		summary += " ⚠"
+	}
+	return summary
+}
@@ -0,0 +1,199 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func topologyTestPod(name, node string) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       v1.PodSpec{NodeName: node},
+	}
+}
+
+func topologyTestNode(name, zone string) *v1.Node {
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}}}
+	if zone != "" {
+		node.Labels[ZoneLabel] = zone
+	}
+	return node
+}
+
+func TestComputeTopologySpread(t *testing.T) {
+	nodeLabels := map[string]map[string]string{
+		"node-a1": {ZoneLabel: "us-east-1a"},
+		"node-a2": {ZoneLabel: "us-east-1a"},
+		"node-b1": {ZoneLabel: "us-east-1b"},
+		"node-c1": {ZoneLabel: "us-east-1c"},
+		"node-x":  {},
+	}
+
+	tests := []struct {
+		name              string
+		pods              []v1.Pod
+		expectZones       string
+		expectNodes       string
+		expectZoneSkew    int
+		expectUnscheduled int
+	}{
+		{
+			name: "evenly spread",
+			pods: []v1.Pod{
+				topologyTestPod("p1", "node-a1"),
+				topologyTestPod("p2", "node-b1"),
+				topologyTestPod("p3", "node-c1"),
+			},
+			expectZones:    "zone us-east-1a: 1, us-east-1b: 1, us-east-1c: 1",
+			expectNodes:    "node node-a1: 1, node-b1: 1, node-c1: 1",
+			expectZoneSkew: 0,
+		},
+		{
+			name: "piled into one zone",
+			pods: []v1.Pod{
+				topologyTestPod("p1", "node-a1"),
+				topologyTestPod("p2", "node-a1"),
+				topologyTestPod("p3", "node-a2"),
+				topologyTestPod("p4", "node-a2"),
+				topologyTestPod("p5", "node-a2"),
+				topologyTestPod("p6", "node-b1"),
+			},
+			expectZones:    "zone us-east-1a: 5, us-east-1b: 1, us-east-1c: 0 ⚠",
+			expectNodes:    "node node-a2: 3, node-a1: 2, node-b1: 1 ⚠",
+			expectZoneSkew: 5,
+		},
+		{
+			name: "node without zone label and unscheduled pod",
+			pods: []v1.Pod{
+				topologyTestPod("p1", "node-x"),
+				topologyTestPod("p2", "node-b1"),
+				topologyTestPod("p3", ""),
+			},
+			expectZones:       "zone unknown: 1, us-east-1b: 1, us-east-1a: 0, us-east-1c: 0",
+			expectNodes:       "node node-b1: 1, node-x: 1",
+			expectZoneSkew:    1,
+			expectUnscheduled: 1,
+		},
+		{
+			name:           "no pods",
+			pods:           nil,
+			expectZones:    "zone us-east-1a: 0, us-east-1b: 0, us-east-1c: 0",
+			expectNodes:    "node -",
+			expectZoneSkew: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spread := ComputeTopologySpread(tt.pods, nodeLabels)
+
+			if got := spread.ZoneSummary(DefaultTopologySkewThreshold); got != tt.expectZones {
+				t.Errorf("Expected zone summary %q, got %q", tt.expectZones, got)
+			}
+			if got := spread.NodeSummary(DefaultTopologySkewThreshold); got != tt.expectNodes {
+				t.Errorf("Expected node summary %q, got %q", tt.expectNodes, got)
+			}
+			if got := spread.ZoneSkew(); got != tt.expectZoneSkew {
+				t.Errorf("Expected zone skew %d, got %d", tt.expectZoneSkew, got)
+			}
+			if spread.Unscheduled != tt.expectUnscheduled {
+				t.Errorf("Expected %d unscheduled pods, got %d", tt.expectUnscheduled, spread.Unscheduled)
+			}
+		})
+	}
+}
+
+func TestNodeSummaryWarnsWhenAllReplicasOnOneNode(t *testing.T) {
+	spread := ComputeTopologySpread([]v1.Pod{
+		topologyTestPod("p1", "node-a1"),
+		topologyTestPod("p2", "node-a1"),
+	}, nil)
+
+	if got, want := spread.NodeSummary(DefaultTopologySkewThreshold), "node node-a1: 2 ⚠"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if got, want := spread.ZoneSummary(DefaultTopologySkewThreshold), "zone unknown: 2"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestNodeInfoCache(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(
+		topologyTestNode("node-a1", "us-east-1a"),
+		topologyTestNode("node-b1", "us-east-1b"),
+	)
+	client := &Client{clientset: fakeClient}
+	cache := NewNodeInfoCache(client, time.Hour)
+	ctx := context.Background()
+
+	labels, err := cache.NodeLabels(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if labels["node-a1"][ZoneLabel] != "us-east-1a" {
+		t.Errorf("Expected node-a1 in us-east-1a, got %q", labels["node-a1"][ZoneLabel])
+	}
+
+	// A new node is not visible until the cache is invalidated
+	if _, err := fakeClient.CoreV1().Nodes().Create(ctx, topologyTestNode("node-c1", "us-east-1c"), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+	labels, _ = cache.NodeLabels(ctx)
+	if _, exists := labels["node-c1"]; exists {
+		t.Error("Expected cached labels to be served within the TTL")
+	}
+
+	cache.Invalidate()
+	labels, _ = cache.NodeLabels(ctx)
+	if _, exists := labels["node-c1"]; !exists {
+		t.Error("Expected node-c1 after invalidation")
+	}
+}
+
+func TestGetSiblingPods(t *testing.T) {
+	controller := true
+	ownedBy := func(name string, uid types.UID) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "rs", UID: uid, Controller: &controller},
+			},
+		}}
+	}
+
+	fakeClient := fake.NewSimpleClientset(
+		ownedBy("web-1", "rs-web"),
+		ownedBy("web-2", "rs-web"),
+		ownedBy("api-1", "rs-api"),
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "default"}},
+	)
+	client := &Client{clientset: fakeClient}
+
+	tests := []struct {
+		pod         string
+		expectCount int
+	}{
+		{"web-1", 2},
+		{"api-1", 1},
+		{"standalone", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pod, func(t *testing.T) {
+			pods, err := client.GetSiblingPods(context.Background(), "default", tt.pod)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(pods) != tt.expectCount {
+				t.Errorf("Expected %d pods, got %d", tt.expectCount, len(pods))
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UsageSort orders namespaces and pods in the usage view
+type UsageSort int
+
+const (
+	// UsageSortCPU puts the most CPU first, like kubectl top --sort-by=cpu
+	UsageSortCPU UsageSort = iota
+	// UsageSortMemory puts the most memory first
+	UsageSortMemory
+	// UsageSortName orders by name
+	UsageSortName
+)
+
+// String names the sort for the view's header
+func (s UsageSort) String() string {
+	switch s {
+	case UsageSortMemory:
+		return "MEMORY"
+	case UsageSortName:
+		return "NAME"
+	}
+	return "CPU"
+}
+
+// Next returns the sort after s, cycling back to CPU
+func (s UsageSort) Next() UsageSort {
+	return (s + 1) % 3
+}
+
+// PodUsage is what one pod uses and requests
+type PodUsage struct {
+	Pod         *v1.Pod
+	Measured    bool // False when the metrics API has nothing for the pod yet
+	MilliCPU    int64
+	MemoryBytes int64
+	Requests    v1.ResourceList
+}
+
+// CPU returns the pod's CPU usage formatted like its list cell
+func (u PodUsage) CPU() string { return formatCPU(u.MilliCPU) }
+
+// Memory returns the pod's memory usage formatted like its list cell
+func (u PodUsage) Memory() string { return formatMemory(u.MemoryBytes) }
+
+// NamespaceUsage sums what the running pods of a namespace use and request,
+// next to what its resource quotas allow them to request
+type NamespaceUsage struct {
+	Context   string // Set by the caller in multi-context mode
+	Namespace string
+
+	MilliCPU    int64
+	MemoryBytes int64
+
+	// Pods counts the running pods, Measured those with metrics. When fewer
+	// are measured, the usage is only a lower bound.
+	Pods     int
+	Measured int
+
+	RequestsCPU    resource.Quantity
+	RequestsMemory resource.Quantity
+
+	// QuotaCPU and QuotaMemory are the hard limits on requests of the
+	// namespace's quotas, the tightest when there are several; nil when no
+	// quota limits them
+	QuotaCPU    *resource.Quantity
+	QuotaMemory *resource.Quantity
+}
+
+// CPU returns the namespace's CPU usage formatted like a pod's
+func (u NamespaceUsage) CPU() string { return formatCPU(u.MilliCPU) }
+
+// Memory returns the namespace's memory usage formatted like a pod's
+func (u NamespaceUsage) Memory() string { return formatMemory(u.MemoryBytes) }
+
+// Partial reports whether some running pods have no metrics, so the usage
+// is less than what the namespace really uses
+func (u NamespaceUsage) Partial() bool {
+	return u.Measured < u.Pods
+}
+
+// UsageReport is a cluster's usage by namespace, with the pods behind each
+// namespace so it can be drilled into without fetching again
+type UsageReport struct {
+	Namespaces []NamespaceUsage      // By namespace name
+	Pods       map[string][]PodUsage // By namespace, then pod name
+
+	// Why there are no metrics or quotas, when fetching them failed. Usage
+	// and requests are still shown without them.
+	MetricsErr error
+	QuotaErr   error
+}
+
+// ListResourceQuotas returns the resource quotas in a namespace, or in all
+// namespaces when namespace is empty
+func (c *Client) ListResourceQuotas(ctx context.Context, namespace string) ([]v1.ResourceQuota, error) {
+	items, err := listAll[v1.ResourceQuota](ctx, metav1.ListOptions{}, c.clientset.CoreV1().ResourceQuotas(namespace).List)
+	if err != nil {
+		return nil, c.wrapError(err, OpList, "resourcequotas", namespace, "")
+	}
+	return items, nil
+}
+
+// GetUsageReport fetches the pods, pod metrics and resource quotas of every
+// namespace and sums them up. Only failing to list pods is an error; without
+// metrics or quotas the report says why.
+func (c *Client) GetUsageReport(ctx context.Context) (*UsageReport, error) {
+	pods, err := c.ListPods(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	metrics, metricsErr := c.GetPodMetrics(ctx, "")
+	quotas, quotaErr := c.ListResourceQuotas(ctx, "")
+
+	report := BuildUsageReport(pods, metrics, quotas)
+	report.MetricsErr = metricsErr
+	report.QuotaErr = quotaErr
+	return report, nil
+}
+
+// BuildUsageReport sums pod metrics and requests by namespace. Finished pods
+// neither use nor hold anything and are left out. Namespaces with a quota
+// but no running pods are kept, since their limits still apply.
+func BuildUsageReport(pods []v1.Pod, metrics PodMetricsSet, quotas []v1.ResourceQuota) *UsageReport {
+	report := &UsageReport{Pods: make(map[string][]PodUsage)}
+	byNamespace := make(map[string]*NamespaceUsage)
+	namespace := func(name string) *NamespaceUsage {
+		if usage, ok := byNamespace[name]; ok {
+			return usage
+		}
+		usage := &NamespaceUsage{Namespace: name}
+		byNamespace[name] = usage
+		return usage
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		podUsage := PodUsage{Pod: pod, Requests: PodRequests(pod)}
+		if m, ok := metrics.Get(pod.Namespace, pod.Name); ok {
+			podUsage.Measured = true
+			for _, container := range m.Containers {
+				podUsage.MilliCPU += container.MilliCPU
+				podUsage.MemoryBytes += container.MemoryBytes
+			}
+		}
+
+		usage := namespace(pod.Namespace)
+		usage.Pods++
+		if podUsage.Measured {
+			usage.Measured++
+		}
+		usage.MilliCPU += podUsage.MilliCPU
+		usage.MemoryBytes += podUsage.MemoryBytes
+		if cpu, ok := podUsage.Requests[v1.ResourceCPU]; ok {
+			usage.RequestsCPU.Add(cpu)
+		}
+		if memory, ok := podUsage.Requests[v1.ResourceMemory]; ok {
+			usage.RequestsMemory.Add(memory)
+		}
+		report.Pods[pod.Namespace] = append(report.Pods[pod.Namespace], podUsage)
+	}
+
+	for i := range quotas {
+		quota := &quotas[i]
+		usage := namespace(quota.Namespace)
+		usage.QuotaCPU = tighterQuota(usage.QuotaCPU, quota.Spec.Hard, v1.ResourceRequestsCPU, v1.ResourceCPU)
+		usage.QuotaMemory = tighterQuota(usage.QuotaMemory, quota.Spec.Hard, v1.ResourceRequestsMemory, v1.ResourceMemory)
+	}
+
+	for _, usage := range byNamespace {
+		report.Namespaces = append(report.Namespaces, *usage)
+	}
+	SortNamespaceUsage(report.Namespaces, UsageSortName)
+	for _, podUsages := range report.Pods {
+		SortPodUsage(podUsages, UsageSortName)
+	}
+	return report
+}
+
+// tighterQuota returns the smallest of current and the limits hard sets on
+// names; "cpu" and "requests.cpu" both limit requests
+func tighterQuota(current *resource.Quantity, hard v1.ResourceList, names ...v1.ResourceName) *resource.Quantity {
+	for _, name := range names {
+		limit, ok := hard[name]
+		if !ok {
+			continue
+		}
+		if current == nil || limit.Cmp(*current) < 0 {
+			limit := limit.DeepCopy()
+			current = &limit
+		}
+	}
+	return current
+}
+
+// SortNamespaceUsage orders namespaces, the largest first when sorting by
+// usage. Ties, and rows of several contexts, fall back to context and name.
+func SortNamespaceUsage(namespaces []NamespaceUsage, by UsageSort) {
+	sort.SliceStable(namespaces, func(i, j int) bool {
+		a, b := namespaces[i], namespaces[j]
+		if ka, kb := usageKey(a.MilliCPU, a.MemoryBytes, by), usageKey(b.MilliCPU, b.MemoryBytes, by); ka != kb {
+			return largerFirst(ka, kb)
+		}
+		if a.Context != b.Context {
+			return a.Context < b.Context
+		}
+		return a.Namespace < b.Namespace
+	})
+}
+
+// SortPodUsage orders pods the way kubectl top does, the largest first when
+// sorting by usage, falling back to the pod name
+func SortPodUsage(pods []PodUsage, by UsageSort) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		a, b := pods[i], pods[j]
+		if ka, kb := usageKey(a.MilliCPU, a.MemoryBytes, by), usageKey(b.MilliCPU, b.MemoryBytes, by); ka != kb {
+			return largerFirst(ka, kb)
+		}
+		return a.Pod.Name < b.Pod.Name
+	})
+}
+
+// usageKey returns what to sort a usage by, the sorted resource first and
+// then the other; all zero when sorting by name
+func usageKey(milliCPU, memoryBytes int64, by UsageSort) [2]int64 {
+	switch by {
+	case UsageSortMemory:
+		return [2]int64{memoryBytes, milliCPU}
+	case UsageSortName:
+		return [2]int64{}
+	}
+	return [2]int64{milliCPU, memoryBytes}
+}
+
+// largerFirst reports whether usage key a sorts before b
+func largerFirst(a, b [2]int64) bool {
+	if a[0] != b[0] {
+		return a[0] > b[0]
+	}
+	return a[1] > b[1]
+}
+
+// FormatRequestsOfQuota renders requests against a quota, e.g. "1500m / 4",
+// or just the requests when there is no quota
+func FormatRequestsOfQuota(requests resource.Quantity, quota *resource.Quantity) string {
+	used := "-"
+	if !requests.IsZero() {
+		used = requests.String()
+	}
+	if quota == nil {
+		return used
+	}
+	return fmt.Sprintf("%s / %s", used, quota.String())
+}
@@ -0,0 +1,158 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// usagePod is a running pod requesting cpu and memory
+func usagePod(namespace, name, cpu, memory string) v1.Pod {
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	pod.Status.Phase = v1.PodRunning
+	pod.Spec.Containers = []v1.Container{{Name: "app", Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse(cpu),
+		v1.ResourceMemory: resource.MustParse(memory),
+	}}}}
+	return pod
+}
+
+// usageMetrics is pod metrics of one container
+func usageMetrics(namespace, name string, milliCPU, memoryBytes int64) *PodMetrics {
+	return &PodMetrics{Namespace: namespace, Name: name, Containers: []ContainerMetrics{
+		{Name: "app", MilliCPU: milliCPU, MemoryBytes: memoryBytes},
+	}}
+}
+
+func usageQuota(namespace string, hard v1.ResourceList) v1.ResourceQuota {
+	return v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "quota"},
+		Spec:       v1.ResourceQuotaSpec{Hard: hard},
+	}
+}
+
+func TestBuildUsageReport(t *testing.T) {
+	finished := usagePod("prod", "migrate", "1", "1Gi")
+	finished.Status.Phase = v1.PodSucceeded
+	pods := []v1.Pod{
+		usagePod("prod", "web-1", "250m", "256Mi"),
+		usagePod("prod", "web-2", "250m", "256Mi"),
+		usagePod("prod", "starting", "100m", "64Mi"),
+		finished,
+		usagePod("dev", "api", "100m", "128Mi"),
+	}
+	metrics := PodMetricsSet{}
+	for _, m := range []*PodMetrics{
+		usageMetrics("prod", "web-1", 300, 200*Mi),
+		usageMetrics("prod", "web-2", 100, 300*Mi),
+		usageMetrics("prod", "migrate", 900, Gi),
+		usageMetrics("dev", "api", 50, 100*Mi),
+	} {
+		metrics[PodKey{Namespace: m.Namespace, Name: m.Name}] = m
+	}
+	quotas := []v1.ResourceQuota{
+		usageQuota("prod", v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("4"), v1.ResourceRequestsMemory: resource.MustParse("8Gi")}),
+		// A second quota tightens cpu with the short name
+		usageQuota("prod", v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")}),
+		usageQuota("idle", v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("1")}),
+	}
+
+	report := BuildUsageReport(pods, metrics, quotas)
+
+	var names []string
+	for _, usage := range report.Namespaces {
+		names = append(names, usage.Namespace)
+	}
+	if strings.Join(names, ",") != "dev,idle,prod" {
+		t.Fatalf("Expected dev, idle and prod by name, got %v", names)
+	}
+
+	prod := report.Namespaces[2]
+	if prod.Pods != 3 || prod.Measured != 2 || !prod.Partial() {
+		t.Errorf("Expected 2 of 3 running pods measured, got %d of %d", prod.Measured, prod.Pods)
+	}
+	if prod.MilliCPU != 400 || prod.MemoryBytes != 500*Mi {
+		t.Errorf("Expected the finished pod left out of 400m/500Mi, got %s/%s", prod.CPU(), prod.Memory())
+	}
+	if got := FormatRequestsOfQuota(prod.RequestsCPU, prod.QuotaCPU); got != "600m / 2" {
+		t.Errorf("Expected cpu requests against the tighter quota, got %q", got)
+	}
+	if got := FormatRequestsOfQuota(prod.RequestsMemory, prod.QuotaMemory); got != "576Mi / 8Gi" {
+		t.Errorf("Expected memory requests against the quota, got %q", got)
+	}
+
+	idle := report.Namespaces[1]
+	if idle.Pods != 0 || idle.CPU() != "-" || FormatRequestsOfQuota(idle.RequestsCPU, idle.QuotaCPU) != "- / 1" {
+		t.Errorf("Expected a quota without pods kept empty, got %+v", idle)
+	}
+	if dev := report.Namespaces[0]; dev.Partial() || dev.QuotaCPU != nil {
+		t.Errorf("Expected dev fully measured without quota, got %+v", dev)
+	}
+
+	if len(report.Pods["prod"]) != 3 || report.Pods["prod"][0].Pod.Name != "starting" || report.Pods["prod"][0].Measured {
+		t.Errorf("Expected prod's running pods by name, the unmeasured one first, got %+v", report.Pods["prod"])
+	}
+}
+
+func TestSortUsage(t *testing.T) {
+	namespaces := []NamespaceUsage{
+		{Context: "b", Namespace: "web", MilliCPU: 100, MemoryBytes: 10},
+		{Context: "a", Namespace: "web", MilliCPU: 100, MemoryBytes: 10},
+		{Context: "a", Namespace: "batch", MilliCPU: 50, MemoryBytes: 90},
+		{Context: "a", Namespace: "cache", MilliCPU: 100, MemoryBytes: 40},
+	}
+	tests := []struct {
+		by   UsageSort
+		want string
+	}{
+		// Rows of several contexts stay apart, labelled rather than summed
+		{UsageSortCPU, "a/cache a/web b/web a/batch"},
+		{UsageSortMemory, "a/batch a/cache a/web b/web"},
+		{UsageSortName, "a/batch a/cache a/web b/web"},
+	}
+	for _, tt := range tests {
+		SortNamespaceUsage(namespaces, tt.by)
+		var got []string
+		for _, usage := range namespaces {
+			got = append(got, usage.Context+"/"+usage.Namespace)
+		}
+		if strings.Join(got, " ") != tt.want {
+			t.Errorf("By %s: expected %s, got %s", tt.by, tt.want, strings.Join(got, " "))
+		}
+	}
+
+	pods := []PodUsage{
+		{Pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "b"}}, MilliCPU: 10, MemoryBytes: 5},
+		{Pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a"}}, MilliCPU: 10, MemoryBytes: 5},
+		{Pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "c"}}, MilliCPU: 20, MemoryBytes: 1},
+	}
+	SortPodUsage(pods, UsageSortCPU)
+	if pods[0].Pod.Name != "c" || pods[1].Pod.Name != "a" || pods[2].Pod.Name != "b" {
+		t.Errorf("Expected c, a, b by cpu, got %s, %s, %s", pods[0].Pod.Name, pods[1].Pod.Name, pods[2].Pod.Name)
+	}
+	if UsageSortName.Next() != UsageSortCPU {
+		t.Error("Expected the sort to cycle back to cpu")
+	}
+}
+
+func TestGetUsageReportWithoutMetrics(t *testing.T) {
+	web := usagePod("prod", "web-1", "250m", "256Mi")
+	quota := usageQuota("prod", v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("2")})
+	client := &Client{clientset: fake.NewSimpleClientset(&web, &quota)}
+
+	report, err := client.GetUsageReport(context.Background())
+	if err != nil {
+		t.Fatalf("GetUsageReport failed: %v", err)
+	}
+	if report.MetricsErr == nil || report.QuotaErr != nil {
+		t.Errorf("Expected only the metrics missing, got %v and %v", report.MetricsErr, report.QuotaErr)
+	}
+	if len(report.Namespaces) != 1 || report.Namespaces[0].Measured != 0 || report.Namespaces[0].QuotaCPU == nil {
+		t.Errorf("Expected prod with its quota and no pods measured, got %+v", report.Namespaces)
+	}
+}
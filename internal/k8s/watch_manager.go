@@ -85,6 +85,26 @@ func (wc *WatchCoalescer) AddWatchListener(ctx context.Context, client *Client,
 		watcher, err = client.WatchStatefulSets(ctx, namespace)
 	case "ingresses":
 		watcher, err = client.WatchIngresses(ctx, namespace)
+	case "gateways":
+		watcher, err = client.WatchGateways(ctx, namespace)
+	case "httproutes":
+		watcher, err = client.WatchHTTPRoutes(ctx, namespace)
+	case "serviceaccounts":
+		watcher, err = client.WatchServiceAccounts(ctx, namespace)
+	case "roles":
+		watcher, err = client.WatchRoles(ctx, namespace)
+	case "clusterroles":
+		watcher, err = client.WatchClusterRoles(ctx)
+	case "rolebindings":
+		watcher, err = client.WatchRoleBindings(ctx, namespace)
+	case "clusterrolebindings":
+		watcher, err = client.WatchClusterRoleBindings(ctx)
+	case "nodes":
+		watcher, err = client.WatchNodes(ctx)
+	case "jobs":
+		watcher, err = client.WatchJobs(ctx, namespace)
+	case "cronjobs":
+		watcher, err = client.WatchCronJobs(ctx, namespace)
 	default:
 		return nil, fmt.Errorf("unsupported resource type: %s", resource)
 	}
@@ -0,0 +1,258 @@
+// Package metrics serves Prometheus metrics about the watched cluster,
+// derived from the objects kubewatch has already fetched, alongside the
+// latency of kubewatch's own API requests.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	v1 "k8s.io/api/core/v1"
+)
+
+// Metric names. They and their labels are part of kubewatch's interface for
+// dashboards, so they must not change.
+const (
+	// PodsMetric is a gauge of cached pods, labelled namespace and phase
+	// (Pending, Running, Succeeded, Failed, Unknown). Every phase is reported
+	// for each namespace with pods, zero when none are in it.
+	PodsMetric = "kubewatch_pods"
+
+	// DeploymentUnavailableReplicasMetric is a gauge of each cached
+	// deployment's unavailable replicas, labelled namespace and deployment
+	DeploymentUnavailableReplicasMetric = "kubewatch_deployment_unavailable_replicas"
+
+	// DeploymentsUnavailableMetric is a gauge of cached deployments with at
+	// least one unavailable replica, labelled namespace
+	DeploymentsUnavailableMetric = "kubewatch_deployments_unavailable"
+
+	// ConnectedMetric is a gauge per context, labelled context: 1 when the
+	// most recent API request got a response from the server, 0 when it did
+	// not. Contexts appear after their first request.
+	ConnectedMetric = "kubewatch_cluster_connected"
+
+	// RequestDurationMetric is a histogram of the time kubewatch's API
+	// requests take to get a response, labelled context and method (the HTTP
+	// method, e.g. GET). Requests that fail without a response are included.
+	RequestDurationMetric = "kubewatch_api_request_duration_seconds"
+)
+
+// LatencyBuckets are the upper bounds, in seconds, of the request duration
+// histogram's buckets
+var LatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// inClusterContext labels requests from a client without a kubeconfig
+// context, i.e. one using the in-cluster configuration
+const inClusterContext = "in-cluster"
+
+var podPhases = []v1.PodPhase{v1.PodPending, v1.PodRunning, v1.PodSucceeded, v1.PodFailed, v1.PodUnknown}
+
+// Exporter renders the metrics in the Prometheus text format. Cluster gauges
+// are computed from the state's cached objects on each scrape; request
+// metrics are recorded by ObserveRequest.
+type Exporter struct {
+	state *core.State
+
+	mu        sync.Mutex
+	connected map[string]bool
+	latency   map[latencyKey]*histogram
+}
+
+type latencyKey struct {
+	context string
+	method  string
+}
+
+// histogram counts observations per bucket of LatencyBuckets, the last count
+// being those above every bound
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewExporter creates an exporter reading cached objects from state
+func NewExporter(state *core.State) *Exporter {
+	return &Exporter{
+		state:     state,
+		connected: make(map[string]bool),
+		latency:   make(map[latencyKey]*histogram),
+	}
+}
+
+// ObserveRequest records an API request. Its signature matches
+// k8s.RequestObserver so it can be installed with k8s.SetRequestObserver.
+func (e *Exporter) ObserveRequest(contextName, method string, duration time.Duration, err error) {
+	if contextName == "" {
+		contextName = inClusterContext
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.connected[contextName] = err == nil
+
+	key := latencyKey{context: contextName, method: method}
+	h := e.latency[key]
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(LatencyBuckets)+1)}
+		e.latency[key] = h
+	}
+	seconds := duration.Seconds()
+	bucket := sort.SearchFloat64s(LatencyBuckets, seconds)
+	h.counts[bucket]++
+	h.count++
+	h.sum += seconds
+}
+
+// ServeHTTP serves the metrics
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if r.Method == http.MethodHead {
+		return
+	}
+	e.Write(w)
+}
+
+// Write writes every metric in the Prometheus text format, series sorted by
+// label values so scrapes are stable
+func (e *Exporter) Write(w io.Writer) {
+	snap := e.state.ObjectSnapshot()
+	e.writePods(w, snap)
+	e.writeDeployments(w, snap)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.writeConnected(w)
+	e.writeLatency(w)
+}
+
+func (e *Exporter) writePods(w io.Writer, snap *core.ObjectSnapshot) {
+	counts := make(map[string]map[v1.PodPhase]int)
+	for _, pod := range snap.Pods {
+		if counts[pod.Namespace] == nil {
+			counts[pod.Namespace] = make(map[v1.PodPhase]int)
+		}
+		phase := pod.Status.Phase
+		if phase == "" {
+			phase = v1.PodUnknown
+		}
+		counts[pod.Namespace][phase]++
+	}
+
+	writeHeader(w, PodsMetric, "gauge", "Cached pods by namespace and phase.")
+	for _, namespace := range sortedKeys(counts) {
+		for _, phase := range podPhases {
+			writeSample(w, PodsMetric, labels("namespace", namespace, "phase", string(phase)), float64(counts[namespace][phase]))
+		}
+	}
+}
+
+func (e *Exporter) writeDeployments(w io.Writer, snap *core.ObjectSnapshot) {
+	// The snapshot shares the state's list, so sort a copy
+	deployments := append(snap.Deployments[:0:0], snap.Deployments...)
+	sort.Slice(deployments, func(i, j int) bool {
+		if deployments[i].Namespace != deployments[j].Namespace {
+			return deployments[i].Namespace < deployments[j].Namespace
+		}
+		return deployments[i].Name < deployments[j].Name
+	})
+
+	unavailable := make(map[string]int)
+	writeHeader(w, DeploymentUnavailableReplicasMetric, "gauge", "Unavailable replicas of each cached deployment.")
+	for _, d := range deployments {
+		if d.Status.UnavailableReplicas > 0 {
+			unavailable[d.Namespace]++
+		} else if _, ok := unavailable[d.Namespace]; !ok {
+			unavailable[d.Namespace] = 0
+		}
+		writeSample(w, DeploymentUnavailableReplicasMetric, labels("namespace", d.Namespace, "deployment", d.Name), float64(d.Status.UnavailableReplicas))
+	}
+
+	writeHeader(w, DeploymentsUnavailableMetric, "gauge", "Cached deployments with unavailable replicas, by namespace.")
+	for _, namespace := range sortedKeys(unavailable) {
+		writeSample(w, DeploymentsUnavailableMetric, labels("namespace", namespace), float64(unavailable[namespace]))
+	}
+}
+
+// writeConnected writes the connection gauges. The caller must hold e.mu.
+func (e *Exporter) writeConnected(w io.Writer) {
+	writeHeader(w, ConnectedMetric, "gauge", "Whether the last API request to the context got a response (1) or not (0).")
+	for _, context := range sortedKeys(e.connected) {
+		value := 0.0
+		if e.connected[context] {
+			value = 1
+		}
+		writeSample(w, ConnectedMetric, labels("context", context), value)
+	}
+}
+
+// writeLatency writes the request duration histograms. The caller must hold
+// e.mu.
+func (e *Exporter) writeLatency(w io.Writer) {
+	keys := make([]latencyKey, 0, len(e.latency))
+	for key := range e.latency {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].context != keys[j].context {
+			return keys[i].context < keys[j].context
+		}
+		return keys[i].method < keys[j].method
+	})
+
+	writeHeader(w, RequestDurationMetric, "histogram", "Time kubewatch's API requests took to get a response.")
+	for _, key := range keys {
+		h := e.latency[key]
+		var cumulative uint64
+		for i, bound := range LatencyBuckets {
+			cumulative += h.counts[i]
+			le := strconv.FormatFloat(bound, 'g', -1, 64)
+			writeSample(w, RequestDurationMetric+"_bucket", labels("context", key.context, "method", key.method, "le", le), float64(cumulative))
+		}
+		writeSample(w, RequestDurationMetric+"_bucket", labels("context", key.context, "method", key.method, "le", "+Inf"), float64(h.count))
+		writeSample(w, RequestDurationMetric+"_sum", labels("context", key.context, "method", key.method), h.sum)
+		writeSample(w, RequestDurationMetric+"_count", labels("context", key.context, "method", key.method), float64(h.count))
+	}
+}
+
+func writeHeader(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func writeSample(w io.Writer, name, labels string, value float64) {
+	fmt.Fprintf(w, "%s{%s} %s\n", name, labels, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// labels formats name/value pairs as a Prometheus label set, escaping values
+func labels(pairs ...string) string {
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, pairs[i]+`="`+labelValueEscaper.Replace(pairs[i+1])+`"`)
+	}
+	return strings.Join(parts, ",")
+}
+
+// labelValueEscaper escapes label values as the text format requires
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
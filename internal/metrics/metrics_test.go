@@ -0,0 +1,182 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestExporter() *Exporter {
+	state := core.NewState(&core.Config{})
+	state.UpdatePods([]v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "prod"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "prod"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "job-1", Namespace: "batch"}, Status: v1.PodStatus{Phase: v1.PodFailed}},
+	})
+	state.UpdateDeployments([]appsv1.Deployment{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "prod"}, Status: appsv1.DeploymentStatus{UnavailableReplicas: 2}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "prod"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "batch"}},
+	})
+	return NewExporter(state)
+}
+
+func scrape(t *testing.T, handler http.Handler, method string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, MetricsPath, nil))
+	return rec
+}
+
+func TestExporterClusterGauges(t *testing.T) {
+	rec := scrape(t, newTestExporter(), http.MethodGet)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Unexpected content type %q", ct)
+	}
+
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE kubewatch_pods gauge",
+		`kubewatch_pods{namespace="prod",phase="Running"} 2`,
+		`kubewatch_pods{namespace="prod",phase="Pending"} 0`,
+		`kubewatch_pods{namespace="batch",phase="Failed"} 1`,
+		`kubewatch_deployment_unavailable_replicas{namespace="prod",deployment="web"} 2`,
+		`kubewatch_deployment_unavailable_replicas{namespace="prod",deployment="api"} 0`,
+		`kubewatch_deployments_unavailable{namespace="prod"} 1`,
+		`kubewatch_deployments_unavailable{namespace="batch"} 0`,
+		"# TYPE kubewatch_cluster_connected gauge",
+		"# TYPE kubewatch_api_request_duration_seconds histogram",
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected line %q in:\n%s", line, body)
+		}
+	}
+
+	// Series are sorted so scrapes are stable
+	if strings.Index(body, `namespace="batch",phase="Failed"`) > strings.Index(body, `namespace="prod",phase="Running"`) {
+		t.Error("Expected namespaces in sorted order")
+	}
+}
+
+func TestExporterRequestMetrics(t *testing.T) {
+	e := newTestExporter()
+	e.ObserveRequest("prod", "GET", 30*time.Millisecond, nil)
+	e.ObserveRequest("prod", "GET", 3*time.Second, nil)
+	e.ObserveRequest("staging", "GET", time.Millisecond, errors.New("connection refused"))
+	e.ObserveRequest("", "DELETE", time.Millisecond, nil)
+
+	body := scrape(t, e, http.MethodGet).Body.String()
+	for _, line := range []string{
+		`kubewatch_cluster_connected{context="prod"} 1`,
+		`kubewatch_cluster_connected{context="staging"} 0`,
+		`kubewatch_cluster_connected{context="in-cluster"} 1`,
+		`kubewatch_api_request_duration_seconds_bucket{context="prod",method="GET",le="0.025"} 0`,
+		`kubewatch_api_request_duration_seconds_bucket{context="prod",method="GET",le="0.05"} 1`,
+		`kubewatch_api_request_duration_seconds_bucket{context="prod",method="GET",le="5"} 2`,
+		`kubewatch_api_request_duration_seconds_bucket{context="prod",method="GET",le="+Inf"} 2`,
+		`kubewatch_api_request_duration_seconds_sum{context="prod",method="GET"} 3.03`,
+		`kubewatch_api_request_duration_seconds_count{context="prod",method="GET"} 2`,
+		`kubewatch_api_request_duration_seconds_count{context="in-cluster",method="DELETE"} 1`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected line %q in:\n%s", line, body)
+		}
+	}
+}
+
+func TestExporterEscapesLabelValues(t *testing.T) {
+	e := NewExporter(core.NewState(&core.Config{}))
+	e.ObserveRequest(`we"ird\ctx`, "GET", time.Millisecond, nil)
+
+	body := scrape(t, e, http.MethodGet).Body.String()
+	if !strings.Contains(body, `kubewatch_cluster_connected{context="we\"ird\\ctx"} 1`) {
+		t.Errorf("Expected escaped context label in:\n%s", body)
+	}
+}
+
+func TestExporterRejectsOtherMethods(t *testing.T) {
+	e := newTestExporter()
+
+	rec := scrape(t, e, http.MethodPost)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for POST, got %d", rec.Code)
+	}
+	if allow := rec.Header().Get("Allow"); allow != "GET, HEAD" {
+		t.Errorf("Expected Allow header, got %q", allow)
+	}
+
+	rec = scrape(t, e, http.MethodHead)
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Errorf("Expected an empty 200 for HEAD, got %d with %d bytes", rec.Code, rec.Body.Len())
+	}
+}
+
+func TestCheckListenAddress(t *testing.T) {
+	tests := []struct {
+		addr          string
+		allowExternal bool
+		wantErr       bool
+	}{
+		{"127.0.0.1:9123", false, false},
+		{"[::1]:9123", false, false},
+		{"localhost:9123", false, false},
+		{"0.0.0.0:9123", false, true},
+		{":9123", false, true},
+		{"10.0.0.5:9123", false, true},
+		{"0.0.0.0:9123", true, false},
+		{":9123", true, false},
+		{"127.0.0.1", false, true},
+		{"127.0.0.1:", false, true},
+	}
+
+	for _, tt := range tests {
+		err := CheckListenAddress(tt.addr, tt.allowExternal)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckListenAddress(%q, %v) error = %v, wantErr %v", tt.addr, tt.allowExternal, err, tt.wantErr)
+		}
+	}
+}
+
+func TestServerStartAndShutdown(t *testing.T) {
+	if _, err := Start("0.0.0.0:0", false, newTestExporter()); err == nil {
+		t.Fatal("Expected a non-loopback address to be refused")
+	}
+
+	server, err := Start("127.0.0.1:0", false, newTestExporter())
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	resp, err := http.Get("http://" + server.Addr().String() + MetricsPath)
+	if err != nil {
+		t.Fatalf("Scrape failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "kubewatch_pods{") {
+		t.Errorf("Unexpected scrape: %d\n%s", resp.StatusCode, body)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if _, err := http.Get("http://" + server.Addr().String() + MetricsPath); err == nil {
+		t.Error("Expected the server to stop listening after Shutdown")
+	}
+}
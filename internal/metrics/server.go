@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// MetricsPath is where the metrics are served
+const MetricsPath = "/metrics"
+
+// CheckListenAddress validates a host:port to serve metrics on. Only
+// loopback addresses are accepted unless allowExternal is set, since the
+// metrics name the cluster's namespaces and workloads. An empty host listens
+// on every interface and so counts as external.
+func CheckListenAddress(addr string, allowExternal bool) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid metrics address %q: %w", addr, err)
+	}
+	if port == "" {
+		return fmt.Errorf("invalid metrics address %q: missing port", addr)
+	}
+	if allowExternal || host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("metrics address %q is not a loopback address; pass --metrics-allow-external to serve it anyway", addr)
+}
+
+// Server serves an exporter over HTTP on its own goroutine
+type Server struct {
+	server *http.Server
+	addr   net.Addr
+	done   chan struct{}
+
+	// Why serving stopped early, if it did; written before done is closed.
+	// Logging it would draw over the TUI.
+	serveErr error
+}
+
+// Start checks addr, listens on it and serves the exporter's metrics in the
+// background. Listening happens before Start returns, so an address in use
+// is reported here rather than lost in the background.
+func Start(addr string, allowExternal bool, exporter *Exporter) (*Server, error) {
+	if err := CheckListenAddress(addr, allowExternal); err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for metrics on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, exporter)
+
+	s := &Server{
+		server: &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		addr: listener.Addr(),
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(s.done)
+		if err := s.server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			s.serveErr = err
+		}
+	}()
+	return s, nil
+}
+
+// Addr returns the address the server listens on
+func (s *Server) Addr() net.Addr {
+	return s.addr
+}
+
+// Shutdown stops the server, waiting for in-flight scrapes until ctx is
+// done. It also reports an error that stopped the server earlier.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if err := s.server.Shutdown(ctx); err != nil {
+		return err
+	}
+	select {
+	case <-s.done:
+		return s.serveErr
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
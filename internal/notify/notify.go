@@ -0,0 +1,182 @@
+// Package notify sends desktop notifications of alerts raised while the
+// terminal is in the background.
+package notify
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+)
+
+// DefaultMinInterval is the least time between two notifications unless the
+// config sets another
+const DefaultMinInterval = 30 * time.Second
+
+// Notifier shows a desktop notification. The terminal's notification
+// escapes are the only way so far; a native notifier would implement the
+// same.
+type Notifier interface {
+	Notify(title, body string) error
+}
+
+// Method is how the terminal is asked to notify
+type Method string
+
+const (
+	// MethodAuto picks the escape the terminal is known to support, else
+	// rings the bell
+	MethodAuto Method = "auto"
+	// MethodOSC777 is the notify escape of urxvt, foot, Ghostty and WezTerm,
+	// among others: a title and a body
+	MethodOSC777 Method = "osc777"
+	// MethodOSC9 is the escape of iTerm2, kitty and Windows Terminal, among
+	// others: a body only
+	MethodOSC9 Method = "osc9"
+	// MethodBell rings the terminal bell, which most terminals and window
+	// managers can turn into an urgency hint
+	MethodBell Method = "bell"
+)
+
+// ParseMethod parses a notification method as the config file names it;
+// "" is MethodAuto
+func ParseMethod(s string) (Method, error) {
+	switch method := Method(strings.ToLower(strings.TrimSpace(s))); method {
+	case "":
+		return MethodAuto, nil
+	case MethodAuto, MethodOSC777, MethodOSC9, MethodBell:
+		return method, nil
+	}
+	return "", fmt.Errorf("unknown method %q, expected auto, osc777, osc9 or bell", s)
+}
+
+// DetectMethod picks the notification escape a terminal supports from its
+// environment, or the bell for one not known to support any
+func DetectMethod(getenv func(string) string) Method {
+	switch program := getenv("TERM_PROGRAM"); {
+	case program == "iTerm.app", getenv("WT_SESSION") != "", getenv("KITTY_WINDOW_ID") != "":
+		return MethodOSC9
+	case program == "WezTerm", program == "ghostty":
+		return MethodOSC777
+	}
+	if term := getenv("TERM"); strings.HasPrefix(term, "foot") || strings.Contains(term, "rxvt") {
+		return MethodOSC777
+	}
+	return MethodBell
+}
+
+// Terminal notifies by writing a terminal's notification escape
+type Terminal struct {
+	out    io.Writer
+	method Method
+	tmux   bool
+}
+
+// NewTerminal creates a notifier writing to out, which should not be the
+// output frames are drawn to. MethodAuto is resolved from the environment.
+func NewTerminal(out io.Writer, method Method, getenv func(string) string) *Terminal {
+	if method == MethodAuto || method == "" {
+		method = DetectMethod(getenv)
+	}
+	return &Terminal{out: out, method: method, tmux: getenv("TMUX") != ""}
+}
+
+// Method returns how the terminal is asked to notify
+func (t *Terminal) Method() Method {
+	return t.method
+}
+
+// Notify writes the notification escape
+func (t *Terminal) Notify(title, body string) error {
+	var seq string
+	switch t.method {
+	case MethodOSC777:
+		// Fields are separated by semicolons, so none may be in them
+		seq = "\x1b]777;notify;" + escapeText(title, true) + ";" + escapeText(body, true) + "\x07"
+	case MethodOSC9:
+		seq = "\x1b]9;" + escapeText(title+": "+body, false) + "\x07"
+	default:
+		seq = "\x07"
+	}
+	if t.tmux && seq != "\x07" {
+		// tmux passes an escape on to the terminal only wrapped, with every
+		// escape character in it doubled
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	_, err := io.WriteString(t.out, seq)
+	return err
+}
+
+// escapeText makes text safe inside an escape: control characters, which
+// could end it early, become spaces, as do semicolons when they separate
+// fields
+func escapeText(text string, fields bool) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || (r >= 0x80 && r < 0xa0) || (fields && r == ';') {
+			return ' '
+		}
+		return r
+	}, text)
+}
+
+// Options are what the config chooses about notifications
+type Options struct {
+	Categories  []core.AlertCategory // Empty notifies every category
+	MinInterval time.Duration        // Zero is DefaultMinInterval
+	Method      Method
+}
+
+// Dispatcher notifies of the alerts of the categories chosen, at most once
+// per interval. Alerts raised within the interval are not lost entirely:
+// the next notification says how many there were.
+type Dispatcher struct {
+	notifier    Notifier
+	categories  map[core.AlertCategory]bool
+	minInterval time.Duration
+	last        time.Time
+	held        int
+}
+
+// NewDispatcher creates a dispatcher sending to notifier
+func NewDispatcher(notifier Notifier, options Options) *Dispatcher {
+	d := &Dispatcher{notifier: notifier, minInterval: options.MinInterval}
+	if d.minInterval <= 0 {
+		d.minInterval = DefaultMinInterval
+	}
+	if len(options.Categories) > 0 {
+		d.categories = make(map[core.AlertCategory]bool, len(options.Categories))
+		for _, category := range options.Categories {
+			d.categories[category] = true
+		}
+	}
+	return d
+}
+
+// Send notifies of the first alert of a chosen category, unless another
+// notification went out within the interval, and counts the rest
+func (d *Dispatcher) Send(alerts []core.Alert, now time.Time) error {
+	var chosen []core.Alert
+	for _, alert := range alerts {
+		if d.categories == nil || d.categories[alert.Category] {
+			chosen = append(chosen, alert)
+		}
+	}
+	if len(chosen) == 0 {
+		return nil
+	}
+	if !d.last.IsZero() && now.Sub(d.last) < d.minInterval {
+		d.held += len(chosen)
+		return nil
+	}
+
+	alert := chosen[0]
+	body := alert.Text()
+	if more := d.held + len(chosen) - 1; more > 0 {
+		body += fmt.Sprintf(" (+%d more)", more)
+	}
+	d.last = now
+	d.held = 0
+	return d.notifier.Notify("kubewatch: "+alert.Category.Title(), body)
+}
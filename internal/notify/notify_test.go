@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+)
+
+// env returns a getenv reading from vars
+func env(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestTerminalNotify(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   Method
+		vars     map[string]string
+		expected string
+	}{
+		{"osc777", MethodOSC777, nil, "\x1b]777;notify;kubewatch: Delete;prod/payments: pod web-1 stuck  finalizers a, b\x07"},
+		{"osc9", MethodOSC9, nil, "\x1b]9;kubewatch: Delete: prod/payments: pod web-1 stuck; finalizers a, b\x07"},
+		{"bell", MethodBell, nil, "\x07"},
+		{"detected", MethodAuto, map[string]string{"TERM_PROGRAM": "iTerm.app"}, "\x1b]9;kubewatch: Delete: prod/payments: pod web-1 stuck; finalizers a, b\x07"},
+		{"unknown terminal", MethodAuto, map[string]string{"TERM": "xterm-256color"}, "\x07"},
+		{"tmux", MethodOSC9, map[string]string{"TMUX": "/tmp/tmux-1000/default,1,0"}, "\x1bPtmux;\x1b\x1b]9;kubewatch: Delete: prod/payments: pod web-1 stuck; finalizers a, b\x07\x1b\\"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			terminal := NewTerminal(&out, tt.method, env(tt.vars))
+			if err := terminal.Notify("kubewatch: Delete", "prod/payments: pod web-1 stuck;\x1bfinalizers a, b"); err != nil {
+				t.Fatalf("Notify failed: %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, out.String())
+			}
+		})
+	}
+}
+
+// recorder is a notifier remembering what it was asked to show
+type recorder struct {
+	bodies []string
+}
+
+func (r *recorder) Notify(title, body string) error {
+	r.bodies = append(r.bodies, title+" | "+body)
+	return nil
+}
+
+func TestDispatcherRateLimits(t *testing.T) {
+	crash := func(pod string) core.Alert {
+		return core.Alert{Category: core.AlertCrashLoop, Context: "prod", Namespace: "payments", Resource: "pod " + pod, Message: "is in CrashLoopBackOff"}
+	}
+	deleted := core.Alert{Category: core.AlertDeletion, Resource: "pod old", Message: "deleted"}
+
+	r := &recorder{}
+	d := NewDispatcher(r, Options{Categories: []core.AlertCategory{core.AlertCrashLoop}, MinInterval: time.Minute})
+	now := time.Now()
+
+	// Categories not chosen are dropped; several alerts make one notification
+	d.Send([]core.Alert{deleted}, now)
+	d.Send([]core.Alert{crash("web-1"), crash("web-2")}, now)
+	// Alerts within the interval are held and counted in the next one
+	d.Send([]core.Alert{crash("web-3")}, now.Add(10*time.Second))
+	d.Send([]core.Alert{crash("web-4")}, now.Add(time.Minute))
+
+	expected := []string{
+		"kubewatch: CrashLoopBackOff | prod/payments: pod web-1 is in CrashLoopBackOff (+1 more)",
+		"kubewatch: CrashLoopBackOff | prod/payments: pod web-4 is in CrashLoopBackOff (+1 more)",
+	}
+	if strings.Join(r.bodies, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(r.bodies, "\n"))
+	}
+}
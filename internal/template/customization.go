@@ -43,12 +43,25 @@ type TemplateValidationError struct {
 	Template string
 	Line     int
 	Column   int
-	Message  string
+	// Near is the function or field the error is about, when known, such
+	// as ".Spec.Replica" or "humanize"
+	Near    string
+	Message string
 }
 
 func (e *TemplateValidationError) Error() string {
-	return fmt.Sprintf("template validation error in %s at line %d, column %d: %s",
-		e.Template, e.Line, e.Column, e.Message)
+	where := fmt.Sprintf("template %q", e.Template)
+	if e.Line > 0 {
+		where += fmt.Sprintf(" at line %d", e.Line)
+		if e.Column > 0 {
+			where += fmt.Sprintf(", column %d", e.Column)
+		}
+	}
+	// Name what the error is about unless the message already does
+	if e.Near != "" && !strings.Contains(e.Message, e.Near) {
+		return fmt.Sprintf("%s: <%s>: %s", where, e.Near, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", where, e.Message)
 }
 
 // NewCustomizationManager creates a new template customization manager
@@ -10,6 +10,7 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -286,18 +287,7 @@ func (e *Engine) humanizeDurationFunc(d interface{}) string {
 		return "0s"
 	}
 
-	if duration < time.Minute {
-		return fmt.Sprintf("%ds", int(duration.Seconds()))
-	} else if duration < time.Hour {
-		return fmt.Sprintf("%dm", int(duration.Minutes()))
-	} else if duration < 24*time.Hour {
-		return fmt.Sprintf("%dh", int(duration.Hours()))
-	} else if duration < 30*24*time.Hour {
-		return fmt.Sprintf("%dd", int(duration.Hours()/24))
-	} else if duration < 365*24*time.Hour {
-		return fmt.Sprintf("%dmo", int(duration.Hours()/24/30))
-	}
-	return fmt.Sprintf("%dy", int(duration.Hours()/24/365))
+	return core.FormatDuration(duration)
 }
 
 func (e *Engine) millicoresFunc(cores interface{}) string {
@@ -500,8 +490,7 @@ func (e *Engine) agoFunc(t interface{}) string {
 		return "unknown"
 	}
 
-	duration := time.Since(ts)
-	return e.humanizeDurationFunc(duration)
+	return core.FormatAge(ts)
 }
 
 func (e *Engine) ageInSecondsFunc(t interface{}) float64 {
@@ -535,7 +524,7 @@ func (e *Engine) timestampFunc(t interface{}) string {
 	default:
 		return "unknown"
 	}
-	return ts.Format("2006-01-02 15:04:05")
+	return core.FormatTimestamp(ts)
 }
 
 // String functions - handles both (slice, sep) and (sep, slice) signatures
@@ -770,6 +759,54 @@ func (e *Engine) Validate(tmplStr string) error {
 	return err
 }
 
+// Render parses a template named name and executes it with data, without
+// caching either. A failure is returned as a *TemplateValidationError
+// giving the line and the function or field it is about, so a user's
+// template can be checked against a sample object and its failures on real
+// ones reported the same way.
+func (e *Engine) Render(name, tmplStr string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Funcs(e.funcMap).Parse(tmplStr)
+	if err != nil {
+		return "", locateTemplateError(name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", locateTemplateError(name, err)
+	}
+	return buf.String(), nil
+}
+
+// templateErrorPattern matches text/template's errors, which start with
+// "template: NAME:LINE:" or, when executing, "template: NAME:LINE:COLUMN:"
+var templateErrorPattern = regexp.MustCompile(`^template: .*?:(\d+):(?:(\d+):)? (.*)$`)
+
+// templateErrorNearPattern picks out what an error is about: the node being
+// executed ("executing "x" at <.Foo>: ...") or a function not defined
+var templateErrorNearPattern = regexp.MustCompile(`^executing ".*?" at <(.*?)>: (.*)$|function "(.*?)" not defined`)
+
+// locateTemplateError turns a text/template error into a
+// *TemplateValidationError of the template name
+func locateTemplateError(name string, err error) error {
+	located := &TemplateValidationError{Template: name, Message: err.Error()}
+	match := templateErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return located
+	}
+	located.Line, _ = strconv.Atoi(match[1])
+	located.Column, _ = strconv.Atoi(match[2])
+	located.Message = match[3]
+
+	if near := templateErrorNearPattern.FindStringSubmatch(located.Message); near != nil {
+		if near[1] != "" {
+			located.Near, located.Message = near[1], near[2]
+		} else {
+			located.Near = near[3]
+		}
+	}
+	return located
+}
+
 // LoadTemplate loads a named template
 func (e *Engine) LoadTemplate(name, tmplStr string) error {
 	tmpl, err := template.New(name).Funcs(e.funcMap).Parse(tmplStr)
@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
 )
 
 func TestEngine_ColorFunc(t *testing.T) {
@@ -1123,3 +1125,114 @@ func BenchmarkEngine_ComplexTemplate(b *testing.B) {
 		_, _ = engine.Execute(template, data)
 	}
 }
+
+func TestTimestampFollowsTimeFormat(t *testing.T) {
+	defer core.SetTimeFormat(core.TimeFormat{})
+	engine := NewEngine()
+
+	core.SetTimeFormat(core.TimeFormat{Clock12: true, UTC: true})
+	got, err := engine.Execute(`{{ timestamp .Time }}`, map[string]string{"Time": "2024-03-05T14:02:11Z"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got != "2024-03-05 2:02:11 PM UTC" {
+		t.Errorf("Expected a 12-hour UTC timestamp, got %q", got)
+	}
+}
+
+// TestDescribeTemplatesUseTimeHelpers keeps timestamps in the describe
+// templates going through the timestamp function, so they follow the
+// configured time format
+func TestDescribeTemplatesUseTimeHelpers(t *testing.T) {
+	defer core.SetTimeFormat(core.TimeFormat{})
+	for name, tmpl := range DefaultTemplates {
+		if !strings.HasSuffix(name, "_describe") {
+			continue
+		}
+		for _, layout := range []string{".Format", "2006", "15:04", "RFC3339"} {
+			if strings.Contains(tmpl, layout) {
+				t.Errorf("Template %s formats times itself (%q); use the timestamp function", name, layout)
+			}
+		}
+	}
+
+	core.SetTimeFormat(core.TimeFormat{UTC: true})
+	tmpl, _ := GetDefaultTemplate("pod_describe")
+	got, err := NewEngine().Execute(tmpl, map[string]interface{}{
+		"Name":              "web-0",
+		"Namespace":         "prod",
+		"CreationTimestamp": "2024-03-05T14:02:11Z",
+		"Status":            map[string]interface{}{"StartTime": "2024-03-05T14:02:15Z"},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(got, "2024-03-05 14:02:15 UTC") {
+		t.Errorf("Expected the start time in UTC, got:\n%s", got)
+	}
+}
+
+func TestEngine_RenderLocatesErrors(t *testing.T) {
+	engine := NewEngine()
+	deployment, _ := SampleObject(core.ResourceTypeDeployment)
+
+	tests := []struct {
+		name     string
+		template string
+		line     int
+		near     string
+		message  string
+	}{
+		{"unknown function", "{{ .Name }}\n{{ humanize .Spec.Replicas }}", 2, "humanize", `function "humanize" not defined`},
+		{"unknown field", "{{ .Name }}\n\nReplicas: {{ .Spec.Replica }}", 3, ".Spec.Replica", "can't evaluate field Replica"},
+		{"syntax", "{{ .Name }", 1, "", "unexpected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := engine.Render("deployment_describe", tt.template, deployment)
+			located, ok := err.(*TemplateValidationError)
+			if !ok {
+				t.Fatalf("Expected a *TemplateValidationError, got %T: %v", err, err)
+			}
+			if located.Template != "deployment_describe" || located.Line != tt.line || located.Near != tt.near {
+				t.Errorf("Expected deployment_describe line %d near %q, got %s line %d near %q",
+					tt.line, tt.near, located.Template, located.Line, located.Near)
+			}
+			if !strings.Contains(located.Message, tt.message) || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected the message to say %q, got %q", tt.message, err.Error())
+			}
+		})
+	}
+
+	out, err := engine.Render("deployment_describe", "{{ .Name }}: {{ .Status.ReadyReplicas }}/{{ .Spec.Replicas }}", deployment)
+	if err != nil || out != "web: 3/3" {
+		t.Errorf("Expected the sample rendered, got %q (%v)", out, err)
+	}
+}
+
+func TestValidateUserTemplate(t *testing.T) {
+	engine := NewEngine()
+	for _, kind := range core.AllResourceTypes {
+		name := DescribeTemplateName(kind)
+		if got, ok := DescribeTemplateKind(name); !ok || got != kind {
+			t.Errorf("Expected %s to be the describe template of %s, got %s", name, kind, got)
+		}
+		if err := engine.ValidateUserTemplate(name, "{{ .Name }} in {{ .Namespace }} {{ ago .CreationTimestamp.Time }}", kind); err != nil {
+			t.Errorf("Expected the %s sample to render, got %v", kind, err)
+		}
+	}
+	if DescribeTemplateName(core.ResourceTypeIngress) != "ingress_describe" {
+		t.Errorf("Expected ingress_describe, got %s", DescribeTemplateName(core.ResourceTypeIngress))
+	}
+
+	// A template of no resource type is only parsed
+	if err := engine.ValidateUserTemplate("banner", "{{ .Anything }}", ""); err != nil {
+		t.Errorf("Expected a template of no type to parse, got %v", err)
+	}
+	if err := engine.ValidateUserTemplate("banner", "{{ nope }}", ""); err == nil {
+		t.Error("Expected an unknown function to fail to parse")
+	}
+	if err := engine.ValidateUserTemplate("pod_describe", "{{ .Status.Phse }}", core.ResourceTypePod); err == nil {
+		t.Error("Expected a misspelled field to fail against the sample pod")
+	}
+}
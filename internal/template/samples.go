@@ -0,0 +1,193 @@
+package template
+
+import (
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// describeTemplateSuffix ends the names of the templates that replace a
+// resource type's describe output, as in "pod_describe"
+const describeTemplateSuffix = "_describe"
+
+// DescribeTemplateName returns the name of the template that replaces the
+// describe output of kind, e.g. "pod_describe" for pods
+func DescribeTemplateName(kind core.ResourceType) string {
+	return strings.ToLower(singularKind(kind)) + describeTemplateSuffix
+}
+
+// DescribeTemplateKind returns the resource type a describe template is
+// named for, and false for templates of any other name
+func DescribeTemplateKind(name string) (core.ResourceType, bool) {
+	kindName, ok := strings.CutSuffix(name, describeTemplateSuffix)
+	if !ok {
+		return "", false
+	}
+	return core.ParseResourceType(kindName)
+}
+
+// singularKind returns a resource type's name in the singular
+func singularKind(kind core.ResourceType) string {
+	name := string(kind)
+	if strings.HasSuffix(name, "sses") {
+		return strings.TrimSuffix(name, "es")
+	}
+	return strings.TrimSuffix(name, "s")
+}
+
+// SampleObject returns a representative object of kind, as the cluster
+// returns it, for user templates to be tried against before they meet a
+// real one. Every field a template is likely to use is set, so a typo'd
+// field or a function given the wrong type fails here rather than later.
+func SampleObject(kind core.ResourceType) (runtime.Object, bool) {
+	created := metav1.NewTime(time.Now().Add(-time.Hour))
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			UID:               "00000000-0000-0000-0000-000000000000",
+			ResourceVersion:   "1",
+			CreationTimestamp: created,
+			Labels:            map[string]string{"app": "web"},
+			Annotations:       map[string]string{"example.com/owner": "platform"},
+		}
+	}
+	replicas := int32(3)
+	container := v1.Container{
+		Name:  "app",
+		Image: "nginx:1.25",
+		Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080, Protocol: v1.ProtocolTCP}},
+	}
+	podSpec := v1.PodSpec{Containers: []v1.Container{container}, NodeName: "worker-1"}
+
+	switch kind {
+	case core.ResourceTypePod:
+		return &v1.Pod{
+			TypeMeta:   metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			ObjectMeta: meta("web-7d4b9c6f5-x2kq9"),
+			Spec:       podSpec,
+			Status: v1.PodStatus{
+				Phase:     v1.PodRunning,
+				PodIP:     "10.244.1.5",
+				HostIP:    "192.168.1.10",
+				StartTime: &created,
+				Conditions: []v1.PodCondition{
+					{Type: v1.PodReady, Status: v1.ConditionTrue, LastTransitionTime: created},
+				},
+				ContainerStatuses: []v1.ContainerStatus{{
+					Name:         "app",
+					Image:        "nginx:1.25",
+					Ready:        true,
+					RestartCount: 1,
+					State:        v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: created}},
+				}},
+			},
+		}, true
+	case core.ResourceTypeDeployment:
+		return &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta: meta("web"),
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Template: v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}}, Spec: podSpec},
+				Strategy: appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
+			},
+			Status: appsv1.DeploymentStatus{
+				Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue, LastTransitionTime: created},
+				},
+			},
+		}, true
+	case core.ResourceTypeStatefulSet:
+		return &appsv1.StatefulSet{
+			TypeMeta:   metav1.TypeMeta{Kind: "StatefulSet", APIVersion: "apps/v1"},
+			ObjectMeta: meta("db"),
+			Spec: appsv1.StatefulSetSpec{
+				Replicas:    &replicas,
+				ServiceName: "db",
+				Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Template:    v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}}, Spec: podSpec},
+			},
+			Status: appsv1.StatefulSetStatus{Replicas: 3, ReadyReplicas: 3, CurrentReplicas: 3, UpdatedReplicas: 3},
+		}, true
+	case core.ResourceTypeService:
+		return &v1.Service{
+			TypeMeta:   metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+			ObjectMeta: meta("web"),
+			Spec: v1.ServiceSpec{
+				Type:       v1.ServiceTypeClusterIP,
+				ClusterIP:  "10.96.0.10",
+				ClusterIPs: []string{"10.96.0.10"},
+				Selector:   map[string]string{"app": "web"},
+				Ports: []v1.ServicePort{
+					{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: v1.ProtocolTCP},
+				},
+			},
+		}, true
+	case core.ResourceTypeIngress:
+		className := "nginx"
+		pathType := networkingv1.PathTypePrefix
+		return &networkingv1.Ingress{
+			TypeMeta:   metav1.TypeMeta{Kind: "Ingress", APIVersion: "networking.k8s.io/v1"},
+			ObjectMeta: meta("web"),
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: &className,
+				TLS:              []networkingv1.IngressTLS{{Hosts: []string{"web.example.com"}, SecretName: "web-tls"}},
+				Rules: []networkingv1.IngressRule{{
+					Host: "web.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     "/",
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+								Name: "web", Port: networkingv1.ServiceBackendPort{Number: 80},
+							}},
+						}},
+					}},
+				}},
+			},
+			Status: networkingv1.IngressStatus{LoadBalancer: networkingv1.IngressLoadBalancerStatus{
+				Ingress: []networkingv1.IngressLoadBalancerIngress{{IP: "203.0.113.10"}},
+			}},
+		}, true
+	case core.ResourceTypeConfigMap:
+		return &v1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: meta("web-config"),
+			Data:       map[string]string{"LOG_LEVEL": "info"},
+		}, true
+	case core.ResourceTypeSecret:
+		return &v1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: meta("web-tls"),
+			Type:       v1.SecretTypeTLS,
+			Data:       map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")},
+		}, true
+	}
+	return nil, false
+}
+
+// ValidateUserTemplate checks a user's template before it is used: it must
+// parse and, when it is for a resource type, execute against the type's
+// sample object. kind is empty for templates not of one resource type.
+func (e *Engine) ValidateUserTemplate(name, tmplStr string, kind core.ResourceType) error {
+	sample, ok := SampleObject(kind)
+	if !ok {
+		if _, err := template.New(name).Funcs(e.funcMap).Parse(tmplStr); err != nil {
+			return locateTemplateError(name, err)
+		}
+		return nil
+	}
+	_, err := e.Render(name, tmplStr, sample)
+	return err
+}
@@ -0,0 +1,152 @@
+package fixtures
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestPodBuilderDefaults(t *testing.T) {
+	pod := NewPod("api-7d9f", "web").Build()
+
+	if pod.UID != "uid-web-api-7d9f" {
+		t.Errorf("Expected the UID uid-web-api-7d9f, got %s", pod.UID)
+	}
+	if age := Epoch.Sub(pod.CreationTimestamp.Time); age != DefaultAge {
+		t.Errorf("Expected the pod created %v before Epoch, got %v", DefaultAge, age)
+	}
+	if pod.Status.Phase != v1.PodRunning || pod.Spec.NodeName == "" || pod.Status.PodIP == "" {
+		t.Errorf("Expected a scheduled running pod with an IP, got phase %s, node %q, IP %q",
+			pod.Status.Phase, pod.Spec.NodeName, pod.Status.PodIP)
+	}
+	if len(pod.Spec.Containers) != 1 || pod.Spec.Containers[0].Name != "api" {
+		t.Fatalf("Expected one container named api, got %+v", pod.Spec.Containers)
+	}
+	if status := pod.Status.ContainerStatuses[0]; !status.Ready || status.State.Running == nil {
+		t.Errorf("Expected the container running and ready, got %+v", status)
+	}
+	if again := NewPod("api-7d9f", "web").Build(); again.Status.PodIP != pod.Status.PodIP {
+		t.Errorf("Expected a stable IP, got %s and %s", pod.Status.PodIP, again.Status.PodIP)
+	}
+}
+
+func TestPodBuilderOverrides(t *testing.T) {
+	tests := []struct {
+		name  string
+		build *PodBuilder
+		check func(t *testing.T, pod v1.Pod)
+	}{
+		{
+			name:  "pending",
+			build: NewPod("worker-5c2b", "jobs").WithPhase(v1.PodPending),
+			check: func(t *testing.T, pod v1.Pod) {
+				status := pod.Status.ContainerStatuses[0]
+				if pod.Status.PodIP != "" || status.Ready || status.State.Waiting == nil || status.State.Waiting.Reason != "ContainerCreating" {
+					t.Errorf("Expected a pod creating its containers without an IP, got %+v", pod.Status)
+				}
+			},
+		},
+		{
+			name:  "crash looping",
+			build: NewPod("api-7f4c2-z8r5w", "web").CrashLooping(7),
+			check: func(t *testing.T, pod v1.Pod) {
+				status := pod.Status.ContainerStatuses[0]
+				if status.RestartCount != 7 || status.State.Waiting == nil || status.State.Waiting.Reason != "CrashLoopBackOff" {
+					t.Errorf("Expected 7 restarts backing off, got %+v", status)
+				}
+				if status.LastTerminationState.Terminated == nil {
+					t.Error("Expected the last termination recorded")
+				}
+			},
+		},
+		{
+			name:  "owned with sidecars",
+			build: NewPod("prometheus-0", "monitoring").WithOwner("StatefulSet", "prometheus").WithContainers(3),
+			check: func(t *testing.T, pod v1.Pod) {
+				if len(pod.OwnerReferences) != 1 || pod.OwnerReferences[0].Kind != "StatefulSet" || !*pod.OwnerReferences[0].Controller {
+					t.Errorf("Expected a controlling StatefulSet owner, got %+v", pod.OwnerReferences)
+				}
+				if pod.OwnerReferences[0].APIVersion != "apps/v1" {
+					t.Errorf("Expected the owner's API version apps/v1, got %s", pod.OwnerReferences[0].APIVersion)
+				}
+				names := []string{}
+				for _, container := range pod.Spec.Containers {
+					names = append(names, container.Name)
+				}
+				if len(names) != 3 || names[0] != "prometheus" || names[2] != "sidecar-2" {
+					t.Errorf("Expected prometheus and two sidecars, got %v", names)
+				}
+				if len(pod.Status.ContainerStatuses) != 3 {
+					t.Errorf("Expected a status per container, got %d", len(pod.Status.ContainerStatuses))
+				}
+			},
+		},
+		{
+			name:  "succeeded",
+			build: NewPod("migrate-28504", "jobs").WithPhase(v1.PodSucceeded),
+			check: func(t *testing.T, pod v1.Pod) {
+				if terminated := pod.Status.ContainerStatuses[0].State.Terminated; terminated == nil || terminated.Reason != "Completed" {
+					t.Errorf("Expected the container completed, got %+v", pod.Status.ContainerStatuses[0].State)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, tt.build.Build())
+		})
+	}
+}
+
+func TestBuildReturnsIndependentObjects(t *testing.T) {
+	builder := NewPod("api-7d9f", "web").WithLabels(map[string]string{"app": "api"})
+	first := builder.Build()
+	first.Labels["app"] = "changed"
+	if second := builder.Build(); second.Labels["app"] != "api" {
+		t.Errorf("Expected a build unaffected by changes to the one before, got label %s", second.Labels["app"])
+	}
+}
+
+func TestDeploymentBuilder(t *testing.T) {
+	deployment := NewDeployment("api", "web").WithImage("example/api:1.4").WithReady(2).Build()
+
+	if *deployment.Spec.Replicas != 3 || deployment.Status.ReadyReplicas != 2 || deployment.Status.AvailableReplicas != 2 {
+		t.Errorf("Expected 2 of 3 replicas ready, got %+v", deployment.Status)
+	}
+	if deployment.Spec.Template.Spec.Containers[0].Image != "example/api:1.4" {
+		t.Errorf("Expected the image example/api:1.4, got %s", deployment.Spec.Template.Spec.Containers[0].Image)
+	}
+	if deployment.Spec.Selector.MatchLabels["app"] != "api" {
+		t.Errorf("Expected the selector app=api, got %v", deployment.Spec.Selector.MatchLabels)
+	}
+}
+
+func TestScenarios(t *testing.T) {
+	rollout := CrashloopingRollout("web")
+	crashing := 0
+	for _, pod := range rollout.Pods {
+		if pod.Labels["app"] != "api" || len(pod.OwnerReferences) == 0 {
+			t.Errorf("Expected %s selected by the deployment and owned by a replica set", pod.Name)
+		}
+		if waiting := pod.Status.ContainerStatuses[0].State.Waiting; waiting != nil && waiting.Reason == "CrashLoopBackOff" {
+			crashing++
+		}
+	}
+	if crashing != 1 || rollout.Deployments[0].Status.UpdatedReplicas != 1 {
+		t.Errorf("Expected one updated replica, crash looping, got %d crash looping", crashing)
+	}
+
+	namespaces := map[string]bool{}
+	for _, pod := range MultiNamespaceMix().Pods {
+		namespaces[pod.Namespace] = true
+	}
+	if len(namespaces) != 3 {
+		t.Errorf("Expected pods in three namespaces, got %v", namespaces)
+	}
+
+	pair := MultiContextPair()
+	if len(pair) != 2 || len(pair["prod"].Pods) == 0 || len(pair["staging"].Pods) == 0 {
+		t.Errorf("Expected pods in prod and staging, got %v", pair)
+	}
+}
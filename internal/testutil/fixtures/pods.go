@@ -0,0 +1,278 @@
+// Package fixtures builds realistic Kubernetes objects for tests and
+// snapshots. Builders start from sensible defaults, so a test only spells
+// out what it is about, and the canned scenarios give the golden-file
+// snapshots and the view tests one source of truth.
+package fixtures
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Epoch is the time fixtures are created relative to, and the clock golden
+// snapshots are rendered at
+var Epoch = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+// DefaultAge is how long before Epoch a fixture is created unless a builder
+// says otherwise
+const DefaultAge = 2 * time.Hour
+
+// Meta returns the metadata of an object created DefaultAge before Epoch,
+// with the UID uid-<namespace>-<name>
+func Meta(name, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:              name,
+		Namespace:         namespace,
+		UID:               types.UID("uid-" + namespace + "-" + name),
+		CreationTimestamp: metav1.NewTime(Epoch.Add(-DefaultAge)),
+	}
+}
+
+// PodBuilder builds a pod. A new pod is Running on node-a with one ready
+// container named after the pod's workload, e.g. api for api-7d9f.
+type PodBuilder struct {
+	meta       metav1.ObjectMeta
+	image      string // Image of the first container, "" for the default
+	node       string
+	ip         string
+	ipSet      bool
+	phase      v1.PodPhase
+	containers int
+	restarts   int32
+	waiting    string // Reason the containers wait, e.g. CrashLoopBackOff
+	crashed    bool   // Whether the first container's last run failed a minute before Epoch
+	notReady   bool
+	deleting   bool
+}
+
+// NewPod returns a builder for the pod name in namespace
+func NewPod(name, namespace string) *PodBuilder {
+	return &PodBuilder{
+		meta:       Meta(name, namespace),
+		node:       "node-a",
+		phase:      v1.PodRunning,
+		containers: 1,
+	}
+}
+
+// WithPhase sets the pod's phase. Pending pods wait in ContainerCreating
+// without an IP, or have no container statuses before they are scheduled;
+// Succeeded and Failed pods' containers have terminated.
+func (b *PodBuilder) WithPhase(phase v1.PodPhase) *PodBuilder {
+	b.phase = phase
+	return b
+}
+
+// WithRestarts sets the restart count of the pod's first container
+func (b *PodBuilder) WithRestarts(restarts int32) *PodBuilder {
+	b.restarts = restarts
+	return b
+}
+
+// WithOwner makes the pod controlled by the kind, e.g. ReplicaSet, named
+// name. A ReplicaSet's pods are labelled with its pod-template-hash, the
+// last part of its name, as a deployment's are.
+func (b *PodBuilder) WithOwner(kind, name string) *PodBuilder {
+	if i := strings.LastIndex(name, "-"); kind == "ReplicaSet" && i > 0 {
+		b.WithLabels(map[string]string{"pod-template-hash": name[i+1:]})
+	}
+	controller := true
+	b.meta.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: ownerAPIVersion(kind),
+		Kind:       kind,
+		Name:       name,
+		UID:        types.UID("uid-" + b.meta.Namespace + "-" + name),
+		Controller: &controller,
+	}}
+	return b
+}
+
+// WithContainers sets how many containers the pod runs; those after the
+// first are sidecars
+func (b *PodBuilder) WithContainers(n int) *PodBuilder {
+	b.containers = n
+	return b
+}
+
+// WithImage sets the image of the pod's first container
+func (b *PodBuilder) WithImage(image string) *PodBuilder {
+	b.image = image
+	return b
+}
+
+// WithNode sets the node the pod is scheduled on, "" for unscheduled
+func (b *PodBuilder) WithNode(node string) *PodBuilder {
+	b.node = node
+	return b
+}
+
+// WithIP sets the pod's IP, "" for none
+func (b *PodBuilder) WithIP(ip string) *PodBuilder {
+	b.ip, b.ipSet = ip, true
+	return b
+}
+
+// WithLabels adds labels to the pod
+func (b *PodBuilder) WithLabels(labels map[string]string) *PodBuilder {
+	if b.meta.Labels == nil {
+		b.meta.Labels = make(map[string]string, len(labels))
+	}
+	for key, value := range labels {
+		b.meta.Labels[key] = value
+	}
+	return b
+}
+
+// WithUID sets the pod's UID
+func (b *PodBuilder) WithUID(uid string) *PodBuilder {
+	b.meta.UID = types.UID(uid)
+	return b
+}
+
+// CreatedAgo sets how long before Epoch the pod was created
+func (b *PodBuilder) CreatedAgo(age time.Duration) *PodBuilder {
+	b.meta.CreationTimestamp = metav1.NewTime(Epoch.Add(-age))
+	return b
+}
+
+// Waiting makes the pod's containers wait for reason, e.g. ImagePullBackOff,
+// and not ready
+func (b *PodBuilder) Waiting(reason string) *PodBuilder {
+	b.waiting = reason
+	return b
+}
+
+// CrashLooping makes the pod's first container back off after restarts
+// restarts, the last of them failing a minute before Epoch
+func (b *PodBuilder) CrashLooping(restarts int32) *PodBuilder {
+	b.crashed = true
+	return b.Waiting("CrashLoopBackOff").WithRestarts(restarts)
+}
+
+// NotReady makes the pod's containers run without passing readiness
+func (b *PodBuilder) NotReady() *PodBuilder {
+	b.notReady = true
+	return b
+}
+
+// Terminating marks the pod for deletion
+func (b *PodBuilder) Terminating() *PodBuilder {
+	b.deleting = true
+	return b
+}
+
+// Build returns the pod. Each call returns a new pod, so a builder can
+// stamp out several.
+func (b *PodBuilder) Build() v1.Pod {
+	pod := v1.Pod{
+		ObjectMeta: *b.meta.DeepCopy(),
+		Spec:       v1.PodSpec{NodeName: b.node},
+		Status:     v1.PodStatus{Phase: b.phase},
+	}
+	if b.deleting {
+		deleted := metav1.NewTime(Epoch)
+		pod.DeletionTimestamp = &deleted
+	}
+	switch {
+	case b.ipSet:
+		pod.Status.PodIP = b.ip
+	case b.phase != v1.PodPending:
+		pod.Status.PodIP = podIP(b.meta.Namespace + "/" + b.meta.Name)
+	}
+
+	started := metav1.NewTime(b.meta.CreationTimestamp.Add(30 * time.Minute))
+	for i := range b.containers {
+		name := workload(b.meta.Name)
+		if i > 0 {
+			name = fmt.Sprintf("sidecar-%d", i)
+		}
+		image := "example/" + name + ":1.0"
+		if i == 0 && b.image != "" {
+			image = b.image
+		}
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: name, Image: image})
+		if b.phase == v1.PodPending && b.node == "" {
+			continue
+		}
+		status := v1.ContainerStatus{Name: name, Image: image}
+		switch {
+		case b.waiting != "":
+			status.State.Waiting = &v1.ContainerStateWaiting{Reason: b.waiting}
+		case b.phase == v1.PodPending:
+			status.State.Waiting = &v1.ContainerStateWaiting{Reason: "ContainerCreating"}
+		case b.phase == v1.PodSucceeded:
+			status.State.Terminated = &v1.ContainerStateTerminated{Reason: "Completed", StartedAt: started}
+		case b.phase == v1.PodFailed:
+			status.State.Terminated = &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 1, StartedAt: started}
+		default:
+			status.State.Running = &v1.ContainerStateRunning{StartedAt: started}
+			ready := !b.notReady
+			status.Ready, status.Started = ready, &ready
+		}
+		if i == 0 {
+			status.RestartCount = b.restarts
+		}
+		if i == 0 && b.crashed {
+			status.LastTerminationState.Terminated = &v1.ContainerStateTerminated{
+				Reason:     "Error",
+				ExitCode:   1,
+				FinishedAt: metav1.NewTime(Epoch.Add(-time.Minute)),
+			}
+		}
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, status)
+	}
+	return pod
+}
+
+// workload returns the name of the workload a pod belongs to, the pod's
+// name without its generated suffixes
+func workload(pod string) string {
+	parts := strings.Split(pod, "-")
+	for len(parts) > 1 && generated(parts[len(parts)-1]) {
+		parts = parts[:len(parts)-1]
+	}
+	return strings.Join(parts, "-")
+}
+
+// generated reports whether a name part looks like a generated suffix, a
+// replica set hash, a pod hash or an ordinal
+func generated(part string) bool {
+	if len(part) < 1 || len(part) > 10 {
+		return false
+	}
+	digit := false
+	for _, r := range part {
+		switch {
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < 'a' || r > 'z':
+			return false
+		}
+	}
+	return digit
+}
+
+// podIP returns a stable pod IP for key in 10.244.0.0/16
+func podIP(key string) string {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	sum := h.Sum32()
+	return fmt.Sprintf("10.244.%d.%d", (sum>>8)%256, sum%254+1)
+}
+
+// ownerAPIVersion returns the API version of an owner kind
+func ownerAPIVersion(kind string) string {
+	switch kind {
+	case "ReplicaSet", "StatefulSet", "DaemonSet", "Deployment":
+		return "apps/v1"
+	case "Job", "CronJob":
+		return "batch/v1"
+	}
+	return "v1"
+}
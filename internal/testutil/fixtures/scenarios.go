@@ -0,0 +1,93 @@
+package fixtures
+
+import (
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// Scenario is a set of objects that fit together, as a cluster would list
+// them
+type Scenario struct {
+	Deployments  []appsv1.Deployment
+	StatefulSets []appsv1.StatefulSet
+	Pods         []v1.Pod
+}
+
+// PodsIn returns the scenario's pods in namespace
+func (s Scenario) PodsIn(namespace string) []v1.Pod {
+	var pods []v1.Pod
+	for _, pod := range s.Pods {
+		if pod.Namespace == namespace {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// CrashloopingRollout is the deployment api in namespace halfway through a
+// rollout to example/api:1.5: the two pods of the old replica set still
+// serve, the one of the new replica set crash loops.
+func CrashloopingRollout(namespace string) Scenario {
+	app := map[string]string{"app": "api"}
+	return Scenario{
+		Deployments: []appsv1.Deployment{
+			NewDeployment("api", namespace).WithImage("example/api:1.5").WithReady(2).WithUpdated(1).Build(),
+		},
+		Pods: []v1.Pod{
+			NewPod("api-6b8d9-k2x7p", namespace).WithOwner("ReplicaSet", "api-6b8d9").WithLabels(app).
+				WithImage("example/api:1.4").Build(),
+			NewPod("api-6b8d9-q9m4t", namespace).WithOwner("ReplicaSet", "api-6b8d9").WithLabels(app).
+				WithImage("example/api:1.4").WithNode("node-b").Build(),
+			NewPod("api-7f4c2-z8r5w", namespace).WithOwner("ReplicaSet", "api-7f4c2").WithLabels(app).
+				WithImage("example/api:1.5").CrashLooping(7).CreatedAgo(15 * time.Minute).Build(),
+		},
+	}
+}
+
+// MultiNamespaceMix is pods of every phase spread over the namespaces web,
+// jobs and monitoring
+func MultiNamespaceMix() Scenario {
+	return Scenario{
+		Deployments: []appsv1.Deployment{
+			NewDeployment("api", "web").WithReplicas(2).Build(),
+		},
+		StatefulSets: []appsv1.StatefulSet{
+			NewStatefulSet("prometheus", "monitoring").Build(),
+		},
+		Pods: []v1.Pod{
+			NewPod("api-7d9f-b5k2m", "web").WithOwner("ReplicaSet", "api-7d9f").WithLabels(map[string]string{"app": "api"}).Build(),
+			NewPod("api-7d9f-h8n3c", "web").WithOwner("ReplicaSet", "api-7d9f").WithLabels(map[string]string{"app": "api"}).
+				WithNode("node-b").WithRestarts(2).Build(),
+			NewPod("migrate-28504", "jobs").WithOwner("Job", "migrate-28504").WithPhase(v1.PodSucceeded).Build(),
+			NewPod("report-28505", "jobs").WithOwner("Job", "report-28505").WithPhase(v1.PodFailed).CreatedAgo(20 * time.Minute).Build(),
+			NewPod("prometheus-0", "monitoring").WithOwner("StatefulSet", "prometheus").WithContainers(2).CreatedAgo(48 * time.Hour).Build(),
+			NewPod("grafana-5f6d8", "monitoring").WithPhase(v1.PodPending).WithNode("").CreatedAgo(time.Minute).Build(),
+		},
+	}
+}
+
+// MultiContextPair is the deployment api in namespace web of the contexts
+// prod and staging: prod serves, staging cannot pull its new image.
+func MultiContextPair() map[string]Scenario {
+	app := map[string]string{"app": "api"}
+	return map[string]Scenario{
+		"prod": {
+			Deployments: []appsv1.Deployment{NewDeployment("api", "web").WithReplicas(2).WithImage("example/api:1.4").Build()},
+			Pods: []v1.Pod{
+				NewPod("api-7d9f-b5k2m", "web").WithUID("uid-prod-api-b5k2m").WithOwner("ReplicaSet", "api-7d9f").WithLabels(app).
+					WithImage("example/api:1.4").Build(),
+				NewPod("api-7d9f-h8n3c", "web").WithUID("uid-prod-api-h8n3c").WithOwner("ReplicaSet", "api-7d9f").WithLabels(app).
+					WithImage("example/api:1.4").WithNode("node-b").Build(),
+			},
+		},
+		"staging": {
+			Deployments: []appsv1.Deployment{NewDeployment("api", "web").WithReplicas(1).WithImage("example/api:1.5").WithReady(0).Build()},
+			Pods: []v1.Pod{
+				NewPod("api-8c1e-m4v9d", "web").WithUID("uid-staging-api-m4v9d").WithOwner("ReplicaSet", "api-8c1e").WithLabels(app).
+					WithImage("example/api:1.5").Waiting("ImagePullBackOff").CreatedAgo(10 * time.Minute).Build(),
+			},
+		},
+	}
+}
@@ -0,0 +1,155 @@
+package fixtures
+
+import (
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DeploymentBuilder builds a deployment. A new deployment runs three ready
+// replicas of example/<name>:1.0 selected by app=<name>.
+type DeploymentBuilder struct {
+	meta     metav1.ObjectMeta
+	image    string
+	replicas int32
+	ready    int32
+	updated  int32
+	paused   bool
+}
+
+// NewDeployment returns a builder for the deployment name in namespace
+func NewDeployment(name, namespace string) *DeploymentBuilder {
+	meta := Meta(name, namespace)
+	meta.Labels = map[string]string{"app": name}
+	return &DeploymentBuilder{
+		meta:     meta,
+		image:    "example/" + name + ":1.0",
+		replicas: 3,
+		ready:    3,
+		updated:  3,
+	}
+}
+
+// WithReplicas sets the desired replicas; all of them are ready and up to
+// date unless WithReady or WithUpdated say otherwise
+func (b *DeploymentBuilder) WithReplicas(replicas int32) *DeploymentBuilder {
+	b.replicas, b.ready, b.updated = replicas, replicas, replicas
+	return b
+}
+
+// WithReady sets how many replicas are ready and available
+func (b *DeploymentBuilder) WithReady(ready int32) *DeploymentBuilder {
+	b.ready = ready
+	return b
+}
+
+// WithUpdated sets how many replicas run the current template
+func (b *DeploymentBuilder) WithUpdated(updated int32) *DeploymentBuilder {
+	b.updated = updated
+	return b
+}
+
+// WithImage sets the image of the deployment's container
+func (b *DeploymentBuilder) WithImage(image string) *DeploymentBuilder {
+	b.image = image
+	return b
+}
+
+// WithUID sets the deployment's UID
+func (b *DeploymentBuilder) WithUID(uid string) *DeploymentBuilder {
+	b.meta.UID = types.UID(uid)
+	return b
+}
+
+// CreatedAgo sets how long before Epoch the deployment was created
+func (b *DeploymentBuilder) CreatedAgo(age time.Duration) *DeploymentBuilder {
+	b.meta.CreationTimestamp = metav1.NewTime(Epoch.Add(-age))
+	return b
+}
+
+// Paused pauses the deployment's rollout
+func (b *DeploymentBuilder) Paused() *DeploymentBuilder {
+	b.paused = true
+	return b
+}
+
+// Build returns the deployment
+func (b *DeploymentBuilder) Build() appsv1.Deployment {
+	replicas := b.replicas
+	name := b.meta.Name
+	return appsv1.Deployment{
+		ObjectMeta: *b.meta.DeepCopy(),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Paused:   b.paused,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: name, Image: b.image}}},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          max(b.replicas, b.updated),
+			ReadyReplicas:     b.ready,
+			UpdatedReplicas:   b.updated,
+			AvailableReplicas: b.ready,
+		},
+	}
+}
+
+// StatefulSetBuilder builds a stateful set. A new stateful set runs one
+// ready replica of example/<name>:1.0.
+type StatefulSetBuilder struct {
+	meta     metav1.ObjectMeta
+	image    string
+	replicas int32
+	ready    int32
+}
+
+// NewStatefulSet returns a builder for the stateful set name in namespace
+func NewStatefulSet(name, namespace string) *StatefulSetBuilder {
+	meta := Meta(name, namespace)
+	meta.Labels = map[string]string{"app": name}
+	return &StatefulSetBuilder{meta: meta, image: "example/" + name + ":1.0", replicas: 1, ready: 1}
+}
+
+// WithReplicas sets the desired replicas, all of them ready unless
+// WithReady says otherwise
+func (b *StatefulSetBuilder) WithReplicas(replicas int32) *StatefulSetBuilder {
+	b.replicas, b.ready = replicas, replicas
+	return b
+}
+
+// WithReady sets how many replicas are ready
+func (b *StatefulSetBuilder) WithReady(ready int32) *StatefulSetBuilder {
+	b.ready = ready
+	return b
+}
+
+// WithImage sets the image of the stateful set's container
+func (b *StatefulSetBuilder) WithImage(image string) *StatefulSetBuilder {
+	b.image = image
+	return b
+}
+
+// Build returns the stateful set
+func (b *StatefulSetBuilder) Build() appsv1.StatefulSet {
+	replicas := b.replicas
+	name := b.meta.Name
+	return appsv1.StatefulSet{
+		ObjectMeta: *b.meta.DeepCopy(),
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &replicas,
+			ServiceName: name,
+			Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: name, Image: b.image}}},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{Replicas: b.replicas, ReadyReplicas: b.ready},
+	}
+}
@@ -3,8 +3,10 @@ package transformers
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/HamStudy/kubewatch/internal/components/selection"
+	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/HamStudy/kubewatch/internal/template"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -53,7 +55,7 @@ func (t *ConfigMapTransformer) TransformToRow(resource interface{}, showNamespac
 	}
 
 	// Basic formatting
-	age := getAge(configMap.CreationTimestamp.Time)
+	age := core.AgeOrStuck(configMap.ObjectMeta, time.Now())
 	dataCount := fmt.Sprintf("%d", len(configMap.Data))
 
 	row := []string{
@@ -2,9 +2,12 @@ package transformers
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/HamStudy/kubewatch/internal/components/selection"
+	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/HamStudy/kubewatch/internal/template"
 	appsv1 "k8s.io/api/apps/v1"
 )
@@ -73,7 +76,7 @@ func (t *DeploymentTransformer) TransformToRow(resource interface{}, showNamespa
 	if deployment.Spec.Replicas != nil {
 		replicas = *deployment.Spec.Replicas
 	}
-	ready := fmt.Sprintf("%d/%d", deployment.Status.ReadyReplicas, replicas)
+	ready := core.DeploymentReady(deployment.Status.ReadyReplicas, replicas, deployment.Spec.Paused)
 	row = append(row, ready)
 
 	// UP-TO-DATE column
@@ -85,7 +88,7 @@ func (t *DeploymentTransformer) TransformToRow(resource interface{}, showNamespa
 	row = append(row, available)
 
 	// AGE column
-	age := getAge(deployment.CreationTimestamp.Time)
+	age := core.AgeOrStuck(deployment.ObjectMeta, time.Now())
 	row = append(row, age)
 
 	// CONTAINERS column
@@ -109,6 +112,8 @@ func (t *DeploymentTransformer) TransformToRow(resource interface{}, showNamespa
 	for k, v := range deployment.Spec.Selector.MatchLabels {
 		selectors = append(selectors, fmt.Sprintf("%s=%s", k, v))
 	}
+	// Labels in map order would reorder the cell between refreshes
+	sort.Strings(selectors)
 	selectorStr := strings.Join(selectors, ",")
 	row = append(row, selectorStr)
 
@@ -268,7 +273,7 @@ func (t *DeploymentTransformer) AggregateResources(resources []interface{}, show
 			oldestTime = dep.CreationTimestamp.Time
 		}
 	}
-	age := getAge(oldestTime)
+	age := core.FormatAge(oldestTime)
 	row = append(row, age)
 
 	// CONTAINERS column (from base deployment)
@@ -292,6 +297,7 @@ func (t *DeploymentTransformer) AggregateResources(resources []interface{}, show
 	for k, v := range baseDeployment.Spec.Selector.MatchLabels {
 		selectors = append(selectors, fmt.Sprintf("%s=%s", k, v))
 	}
+	sort.Strings(selectors)
 	selectorStr := strings.Join(selectors, ",")
 	row = append(row, selectorStr)
 
@@ -3,8 +3,10 @@ package transformers
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/HamStudy/kubewatch/internal/components/selection"
+	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/HamStudy/kubewatch/internal/template"
 	networkingv1 "k8s.io/api/networking/v1"
 )
@@ -53,7 +55,7 @@ func (t *IngressTransformer) TransformToRow(resource interface{}, showNamespace
 	}
 
 	// Basic formatting (template support can be added later)
-	age := getAge(ingress.CreationTimestamp.Time)
+	age := core.AgeOrStuck(ingress.ObjectMeta, time.Now())
 	class := "<none>"
 	if ingress.Spec.IngressClassName != nil {
 		class = *ingress.Spec.IngressClassName
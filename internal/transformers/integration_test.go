@@ -20,13 +20,13 @@ import (
 
 // MockMetricsProvider for testing
 type MockMetricsProvider struct {
-	metrics map[string]map[string]*k8s.PodMetrics
+	metrics k8s.PodMetricsSet
 	mu      sync.RWMutex
 }
 
 func NewMockMetricsProvider() *MockMetricsProvider {
 	return &MockMetricsProvider{
-		metrics: make(map[string]map[string]*k8s.PodMetrics),
+		metrics: make(k8s.PodMetricsSet),
 	}
 }
 
@@ -34,20 +34,20 @@ func (m *MockMetricsProvider) SetPodMetrics(namespace, name string, metrics *k8s
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if m.metrics[namespace] == nil {
-		m.metrics[namespace] = make(map[string]*k8s.PodMetrics)
-	}
-	m.metrics[namespace][name] = metrics
+	m.metrics[k8s.PodKey{Namespace: namespace, Name: name}] = metrics
 }
 
-func (m *MockMetricsProvider) GetPodMetrics(namespace string) (map[string]*k8s.PodMetrics, error) {
+func (m *MockMetricsProvider) GetPodMetrics(namespace string) (k8s.PodMetricsSet, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	if metrics, ok := m.metrics[namespace]; ok {
-		return metrics, nil
+	result := make(k8s.PodMetricsSet)
+	for key, metrics := range m.metrics {
+		if key.Namespace == namespace {
+			result[key] = metrics
+		}
 	}
-	return make(map[string]*k8s.PodMetrics), nil
+	return result, nil
 }
 
 // TestPodTransformerWithMetrics tests the complete pod transformation flow
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/HamStudy/kubewatch/internal/components/selection"
+	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/HamStudy/kubewatch/internal/k8s"
 	"github.com/HamStudy/kubewatch/internal/template"
 	v1 "k8s.io/api/core/v1"
@@ -20,7 +21,7 @@ type PodTransformer struct {
 
 // MetricsProvider interface for getting pod metrics
 type MetricsProvider interface {
-	GetPodMetrics(namespace string) (map[string]*k8s.PodMetrics, error)
+	GetPodMetrics(namespace string) (k8s.PodMetricsSet, error)
 }
 
 // NewPodTransformer creates a new pod transformer
@@ -118,30 +119,10 @@ func (t *PodTransformer) TransformToRow(resource interface{}, showNamespace bool
 	}
 
 	// STATUS column
-	status := string(pod.Status.Phase)
+	status := core.PodStatus(&pod, time.Now())
 
-	// Get more detailed status if available
-	for _, condition := range pod.Status.Conditions {
-		if condition.Type == v1.PodReady && condition.Status != v1.ConditionTrue {
-			if condition.Reason != "" {
-				status = condition.Reason
-			}
-		}
-	}
-
-	// Check container statuses for more specific states
-	for _, cs := range pod.Status.ContainerStatuses {
-		if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
-			status = cs.State.Waiting.Reason
-			break
-		}
-		if cs.State.Terminated != nil && cs.State.Terminated.Reason != "" {
-			status = cs.State.Terminated.Reason
-			break
-		}
-	}
-
-	if templateEngine != nil {
+	// A pending deletion outranks whatever the template would show
+	if templateEngine != nil && pod.DeletionTimestamp == nil {
 		data := map[string]interface{}{
 			"Status": map[string]interface{}{
 				"Phase":      pod.Status.Phase,
@@ -173,7 +154,7 @@ func (t *PodTransformer) TransformToRow(resource interface{}, showNamespace bool
 
 	restartText := fmt.Sprintf("%d", restartCount)
 	if restartCount > 0 && lastRestartTime != nil {
-		restartAge := getAge(*lastRestartTime)
+		restartAge := core.FormatAge(*lastRestartTime)
 		restartText = fmt.Sprintf("%d (%s ago)", restartCount, restartAge)
 	}
 
@@ -193,7 +174,7 @@ func (t *PodTransformer) TransformToRow(resource interface{}, showNamespace bool
 	}
 
 	// AGE column
-	age := getAge(pod.CreationTimestamp.Time)
+	age := core.FormatAge(pod.CreationTimestamp.Time)
 	if templateEngine != nil {
 		data := map[string]interface{}{
 			"Metadata": map[string]interface{}{
@@ -214,7 +195,7 @@ func (t *PodTransformer) TransformToRow(resource interface{}, showNamespace bool
 	var cpuMillicores int64
 	if t.metricsProvider != nil {
 		if metrics, err := t.metricsProvider.GetPodMetrics(pod.Namespace); err == nil {
-			if podMetrics, ok := metrics[pod.Name]; ok && podMetrics.CPU != "" && podMetrics.CPU != "-" {
+			if podMetrics, ok := metrics.Get(pod.Namespace, pod.Name); ok && podMetrics.CPU != "" && podMetrics.CPU != "-" {
 				// Parse the CPU value (it comes as a string like "100m" or "1.5")
 				cpuMillicores = parseCPUToMillicores(podMetrics.CPU)
 
@@ -240,7 +221,7 @@ func (t *PodTransformer) TransformToRow(resource interface{}, showNamespace bool
 	var memoryBytes int64
 	if t.metricsProvider != nil {
 		if metrics, err := t.metricsProvider.GetPodMetrics(pod.Namespace); err == nil {
-			if podMetrics, ok := metrics[pod.Name]; ok && podMetrics.Memory != "" && podMetrics.Memory != "-" {
+			if podMetrics, ok := metrics.Get(pod.Namespace, pod.Name); ok && podMetrics.Memory != "" && podMetrics.Memory != "-" {
 				// Parse the memory value (it comes as a string like "128Mi" or "1Gi")
 				memoryBytes = parseMemoryToBytes(podMetrics.Memory)
 
@@ -469,7 +450,7 @@ func (t *PodTransformer) GetSortValue(resource interface{}, column string) inter
 		// Sort by actual CPU usage if metrics are available
 		if t.metricsProvider != nil {
 			if metrics, err := t.metricsProvider.GetPodMetrics(pod.Namespace); err == nil {
-				if podMetrics, ok := metrics[pod.Name]; ok && podMetrics.CPU != "" && podMetrics.CPU != "-" {
+				if podMetrics, ok := metrics.Get(pod.Namespace, pod.Name); ok && podMetrics.CPU != "" && podMetrics.CPU != "-" {
 					return parseCPUToMillicores(podMetrics.CPU)
 				}
 			}
@@ -479,7 +460,7 @@ func (t *PodTransformer) GetSortValue(resource interface{}, column string) inter
 		// Sort by actual memory usage if metrics are available
 		if t.metricsProvider != nil {
 			if metrics, err := t.metricsProvider.GetPodMetrics(pod.Namespace); err == nil {
-				if podMetrics, ok := metrics[pod.Name]; ok && podMetrics.Memory != "" && podMetrics.Memory != "-" {
+				if podMetrics, ok := metrics.Get(pod.Namespace, pod.Name); ok && podMetrics.Memory != "" && podMetrics.Memory != "-" {
 					return parseMemoryToBytes(podMetrics.Memory)
 				}
 			}
@@ -522,21 +503,3 @@ func (t *PodTransformer) AggregateResources(resources []interface{}, showNamespa
 	// Use the first resource
 	return t.TransformToRow(resources[0], showNamespace, templateEngine)
 }
-
-// getAge returns a human-readable age string
-func getAge(t time.Time) string {
-	duration := time.Since(t)
-
-	if duration < time.Minute {
-		return fmt.Sprintf("%ds", int(duration.Seconds()))
-	} else if duration < time.Hour {
-		return fmt.Sprintf("%dm", int(duration.Minutes()))
-	} else if duration < 24*time.Hour {
-		return fmt.Sprintf("%dh", int(duration.Hours()))
-	} else if duration < 30*24*time.Hour {
-		return fmt.Sprintf("%dd", int(duration.Hours()/24))
-	} else if duration < 365*24*time.Hour {
-		return fmt.Sprintf("%dmo", int(duration.Hours()/24/30))
-	}
-	return fmt.Sprintf("%dy", int(duration.Hours()/24/365))
-}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/HamStudy/kubewatch/internal/components/selection"
+	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/HamStudy/kubewatch/internal/template"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -116,7 +118,7 @@ func (t *ServiceTransformer) GetSortValue(resource interface{}, column string) i
 
 // formatBasicRow provides fallback formatting when templates fail
 func (t *ServiceTransformer) formatBasicRow(service *corev1.Service, showNamespace bool) []string {
-	age := getAge(service.CreationTimestamp.Time)
+	age := core.AgeOrStuck(service.ObjectMeta, time.Now())
 
 	row := []string{
 		service.Name,
@@ -3,8 +3,10 @@ package transformers
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/HamStudy/kubewatch/internal/components/selection"
+	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/HamStudy/kubewatch/internal/template"
 	appsv1 "k8s.io/api/apps/v1"
 )
@@ -56,7 +58,7 @@ func (t *StatefulSetTransformer) TransformToRow(resource interface{}, showNamesp
 	data := map[string]interface{}{
 		"Name":        statefulSet.Name,
 		"Namespace":   statefulSet.Namespace,
-		"Ready":       fmt.Sprintf("%d/%d", statefulSet.Status.ReadyReplicas, *statefulSet.Spec.Replicas),
+		"Ready":       statefulSetReady(statefulSet),
 		"Age":         statefulSet.CreationTimestamp.Time,
 		"StatefulSet": statefulSet,
 	}
@@ -139,8 +141,8 @@ func (t *StatefulSetTransformer) AggregateResources(resources []interface{}, sho
 
 // formatBasicRow provides fallback formatting when templates fail
 func (t *StatefulSetTransformer) formatBasicRow(statefulSet *appsv1.StatefulSet, showNamespace bool) []string {
-	age := getAge(statefulSet.CreationTimestamp.Time)
-	ready := fmt.Sprintf("%d/%d", statefulSet.Status.ReadyReplicas, *statefulSet.Spec.Replicas)
+	age := core.AgeOrStuck(statefulSet.ObjectMeta, time.Now())
+	ready := statefulSetReady(statefulSet)
 
 	row := []string{
 		statefulSet.Name,
@@ -154,3 +156,15 @@ func (t *StatefulSetTransformer) formatBasicRow(statefulSet *appsv1.StatefulSet,
 
 	return row
 }
+
+// statefulSetReady returns a statefulset's READY cell, noting a rollout in
+// progress
+func statefulSetReady(statefulSet *appsv1.StatefulSet) string {
+	replicas := int32(0)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	status := statefulSet.Status
+	rolling := status.UpdateRevision != "" && status.UpdateRevision != status.CurrentRevision
+	return core.StatefulSetReady(status.ReadyReplicas, status.UpdatedReplicas, replicas, rolling)
+}
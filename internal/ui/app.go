@@ -3,18 +3,28 @@ package ui
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/HamStudy/kubewatch/internal/components/dropdown"
+	"github.com/HamStudy/kubewatch/internal/components/style"
+	"github.com/HamStudy/kubewatch/internal/config"
 	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/notify"
 	"github.com/HamStudy/kubewatch/internal/ui/views"
+	osc52 "github.com/aymanbagabas/go-osc52/v2"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 )
 
@@ -112,6 +122,14 @@ type watchEventMsg struct {
 // tickMsg represents a periodic refresh tick
 type tickMsg time.Time
 
+// armedTickMsg is a tick of the refresh timer armed as generation gen. The
+// timer is armed again when the interval changes, as on Tab to a kind
+// refreshed at another; the ticks of the timers replaced are dropped.
+type armedTickMsg struct {
+	gen uint64
+	at  time.Time
+}
+
 // App represents the main application model
 type App struct {
 	ctx       context.Context
@@ -135,6 +153,136 @@ type App struct {
 	confirmView          *views.ConfirmView
 	describeView         *views.DescribeView
 	resourceSelectorView *views.ResourceSelectorView
+	topologyView         *views.TopologyView
+	settingsView         *views.SettingsView
+	filterBar            *views.FilterBar
+	labelSelectorBar     *views.LabelSelectorBar
+	filterPreviewPending bool // A preview of the filter bar's expression is due
+	savedFiltersView     *views.SavedFiltersView
+	finalizerView        *views.FinalizerView
+	columnOrderView      *views.ColumnOrderView
+	shellPicker          *views.ShellPickerView
+	logPicker            *views.LogPickerView
+	logSplit             *views.LogSplitView // Two log panes side by side, nil for one
+	scaleView            *views.ScaleView
+	actionMenuView       *views.ActionMenuView
+	actionOutputView     *views.ActionOutputView
+	templatePickerView   *views.TemplatePickerView
+	templateFormView     *views.TemplateFormView
+	comparisonView       *views.ComparisonView
+	splitView            *views.SplitView
+	relationsView        *views.RelationsView
+	resourcePickerView   *views.ResourcePickerView
+	batchView            *views.BatchView
+	nodeDetailView       *views.NodeDetailView
+	usageView            *views.UsageView
+	eventTailView        *views.EventTailView
+	eventsView           *views.EventsView
+	permissionsView      *views.PermissionsView
+	fleetView            *views.FleetView
+
+	// The clients a fleet search uses, one per kubeconfig context, and why
+	// any context could not get one
+	fleetClients    map[string]*k8s.Client
+	fleetClientErrs map[string]error
+
+	// Persists runtime settings when saved from the settings overlay
+	settingsSaver func(values map[string]string) error
+
+	// The accessible mode draws without colors; the terminal's own color
+	// profile is put back when it is turned off
+	colorProfile  termenv.Profile
+	colorsDropped bool
+
+	// Saved filters from the config file, and how to persist a new one
+	savedFilters []*config.SavedFilter
+	filterSaver  func(filter *config.SavedFilter) error
+
+	// Persists a resource type's column order when saved from the chooser
+	columnOrderSaver func(resourceType core.ResourceType, order []string) error
+
+	// User-defined actions from the config file, and those bound to a key
+	userActions    []*config.UserAction
+	userActionKeys map[string]*config.UserAction
+
+	// What counts as noise in the list, hidden while config.HideNoise is on
+	noiseRules core.NoiseRules
+
+	// Manifest templates offered by the create-from-template picker
+	manifestTemplates []*config.ManifestTemplate
+
+	// User templates replacing the describe output, by resource type
+	describeTemplates map[core.ResourceType]string
+	pendingCleanup    *createdCleanup
+
+	// The resource last selected in each resource type's list this session
+	typeSelections map[core.ResourceType]core.ResourceRef
+
+	// Set when clearing a filter left others; the next Esc clears them all
+	clearFiltersOffered bool
+
+	// The batch action whose results overlay is open
+	batch *batchSession
+
+	// Where jumps to related resources came from, most recent last
+	navStack []navEntry
+
+	// Changes made to the cluster this session
+	actionLog *core.ActionLog
+
+	// What the user may do, by context and namespace, as last checked
+	permissions *k8s.PermissionCache
+
+	// Whether q closes views other than the list or quits from them too
+	quitKeyBehavior core.QuitKeyBehavior
+
+	// Removing finalizers bypasses controller cleanup, so it is opt-in
+	allowFinalizerRemoval bool
+	pendingFinalizer      *finalizerRemoval
+
+	// The cordon or uncordon awaiting confirmation in the node overlay
+	pendingCordon *nodeCordon
+
+	// The rollout pause, resume or restart awaiting confirmation
+	pendingRollout *rolloutChange
+
+	// The delete awaiting confirmation
+	pendingDelete *pendingDeletion
+
+	// The deployment or statefulset the scale prompt is for
+	pendingScale *scaleChange
+
+	// Open the usage overlay at start, for `kubewatch top`
+	usageOnStart bool
+
+	// Search every context for pods at start, for --fleet-search
+	fleetOnStart string
+
+	// The startup layout's log split or comparison, opened once the list
+	// first loads, and how to persist the view as the startup layout
+	startup            *config.StartupLayout
+	startupLayoutSaver func(layout *config.StartupLayout) error
+
+	// Sends desktop notifications of alerts while the terminal is
+	// unfocused; nil when they are off
+	notifications *notify.Dispatcher
+
+	// The namespace shown, when it is being deleted or is gone, and whether
+	// a check that it exists is under way
+	namespaceLost     string
+	namespaceChecking bool
+
+	// The opt-in check for a newer release, and the release found
+	updateCheck   func(context.Context) (string, error)
+	updateDismiss func(version string) error
+	updateSkip    string // Release whose notice was dismissed
+	updateVersion string
+
+	// Kubeconfig files left out as broken, noted until dismissed
+	skippedKubeconfigs []k8s.SkippedKubeconfig
+
+	// Node labels per context, joined with pods for topology summaries
+	nodeCaches map[string]*k8s.NodeInfoCache
 
 	// Screen mode system
 	currentMode  ScreenModeType
@@ -147,14 +295,35 @@ type App struct {
 	ready              bool
 	showNamespacePopup bool
 	showDeleteConfirm  bool
-	pendingDeleteName  string
 	loadingNamespaces  bool
 
 	// Watchers
 	cancelWatcher context.CancelFunc
 	watcherCtx    context.Context
+	watcherGen    uint64 // Tags the current watcher; events of older ones are dropped
+
+	// Terminal focus, as reported when focus reporting is on. While blurred,
+	// only every blurredTickFactor-th tick does any work, metrics are not
+	// fetched, and the last frame is reused until something changes.
+	blurred      bool
+	blurredTicks int
+	viewDirty    bool
+	lastView     string
+
+	// The generation of the refresh timer armed last and the interval it
+	// ticks at
+	tickGen       uint64
+	armedInterval time.Duration
 }
 
+// blurredTickFactor is how much the refresh tick stretches while the
+// terminal is unfocused
+const blurredTickFactor = 5
+
+// filterPreviewDelay is how long after a key in the filter bar the list is
+// filtered by what was typed: about a frame
+const filterPreviewDelay = time.Second / 60
+
 // NewApp creates a new application instance
 func NewApp(ctx context.Context, k8sClient *k8s.Client, state *core.State, config *core.Config) *App {
 	// Always use multi-context mode - get current context and create multi-client
@@ -180,6 +349,9 @@ func NewApp(ctx context.Context, k8sClient *k8s.Client, state *core.State, confi
 		resourceView:   views.NewResourceViewWithMultiContext(state, multiClient),
 		logView:        views.NewLogView(),
 		helpView:       views.NewHelpView(),
+		actionLog:      core.NewActionLog(),
+		permissions:    k8s.NewPermissionCache(),
+		noiseRules:     core.DefaultNoiseRules(),
 		isMultiContext: true, // Always use multi-context mode
 		activeContexts: activeContexts,
 		currentMode:    ModeList,
@@ -196,8 +368,37 @@ func NewApp(ctx context.Context, k8sClient *k8s.Client, state *core.State, confi
 		ModeNamespaceSelector: NewNamespaceSelectorMode(),
 		ModeConfirmDialog:     NewConfirmDialogMode(),
 		ModeResourceSelector:  NewResourceSelectorMode(),
+		ModeTopology:          NewTopologyMode(),
+		ModeSettings:          NewSettingsMode(),
+		ModeFilter:            NewFilterMode(),
+		ModeSavedFilters:      NewSavedFiltersMode(),
+		ModeScrub:             NewScrubMode(),
+		ModeFinalizers:        NewFinalizersMode(),
+		ModeActionMenu:        NewActionMenuMode(),
+		ModeActionOutput:      NewActionOutputMode(),
+		ModeTemplates:         NewTemplatesMode(),
+		ModeTemplateForm:      NewTemplateFormMode(),
+		ModeCompare:           NewCompareMode(),
+		ModeRelations:         NewRelationsMode(),
+		ModeBatch:             NewBatchMode(),
+		ModeNodeDetail:        NewNodeDetailMode(),
+		ModeSplit:             NewSplitMode(),
+		ModePickResource:      NewPickResourceMode(),
+		ModeUsage:             NewUsageMode(),
+		ModeEventTail:         NewEventTailMode(),
+		ModePermissions:       NewPermissionsMode(),
+		ModeFleet:             NewFleetMode(),
+		ModeActivity:          NewActivityMode(),
+		ModeColumns:           NewColumnsMode(),
+		ModeShellPicker:       NewShellPickerMode(),
+		ModeLogPicker:         NewLogPickerMode(),
+		ModeScale:             NewScaleMode(),
+		ModeEvents:            NewEventsMode(),
+		ModeLabelSelector:     NewLabelSelectorMode(),
 	}
 
+	app.applyRuntimeSettings()
+
 	return app
 }
 
@@ -213,6 +414,9 @@ func NewAppWithMultiContext(ctx context.Context, multiClient *k8s.MultiContextCl
 		logView:              views.NewLogView(),
 		helpView:             views.NewHelpView(),
 		resourceSelectorView: views.NewResourceSelectorView(),
+		actionLog:            core.NewActionLog(),
+		permissions:          k8s.NewPermissionCache(),
+		noiseRules:           core.DefaultNoiseRules(),
 		isMultiContext:       true, activeContexts: state.CurrentContexts,
 		currentMode:  ModeList,
 		previousMode: ModeList,
@@ -228,33 +432,166 @@ func NewAppWithMultiContext(ctx context.Context, multiClient *k8s.MultiContextCl
 		ModeNamespaceSelector: NewNamespaceSelectorMode(),
 		ModeConfirmDialog:     NewConfirmDialogMode(),
 		ModeResourceSelector:  NewResourceSelectorMode(),
+		ModeTopology:          NewTopologyMode(),
+		ModeSettings:          NewSettingsMode(),
+		ModeFilter:            NewFilterMode(),
+		ModeSavedFilters:      NewSavedFiltersMode(),
+		ModeScrub:             NewScrubMode(),
+		ModeFinalizers:        NewFinalizersMode(),
+		ModeActionMenu:        NewActionMenuMode(),
+		ModeActionOutput:      NewActionOutputMode(),
+		ModeTemplates:         NewTemplatesMode(),
+		ModeTemplateForm:      NewTemplateFormMode(),
+		ModeCompare:           NewCompareMode(),
+		ModeRelations:         NewRelationsMode(),
+		ModeBatch:             NewBatchMode(),
+		ModeNodeDetail:        NewNodeDetailMode(),
+		ModeSplit:             NewSplitMode(),
+		ModePickResource:      NewPickResourceMode(),
+		ModeUsage:             NewUsageMode(),
+		ModeEventTail:         NewEventTailMode(),
+		ModePermissions:       NewPermissionsMode(),
+		ModeFleet:             NewFleetMode(),
+		ModeActivity:          NewActivityMode(),
+		ModeColumns:           NewColumnsMode(),
+		ModeShellPicker:       NewShellPickerMode(),
+		ModeLogPicker:         NewLogPickerMode(),
+		ModeScale:             NewScaleMode(),
+		ModeEvents:            NewEventsMode(),
+		ModeLabelSelector:     NewLabelSelectorMode(),
 	}
 
+	app.applyRuntimeSettings()
+
 	return app
 }
 
 // Init initializes the application
 func (a *App) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		a.resourceView.Init(),
 		tea.EnterAltScreen,
 		a.startRefreshTimer(), // Start the refresh timer
-	)
+		a.detectOptionalTypes(),
+	}
+	if a.usageOnStart {
+		cmds = append(cmds, a.startUsageView())
+	}
+	if a.fleetOnStart != "" {
+		cmds = append(cmds, a.startFleetSearch(core.ParseFleetQuery(a.fleetOnStart)))
+	}
+	if cmd := a.checkForUpdate(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update handles messages
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := a.update(msg)
+	// Any message may have switched the kind listed or changed its interval
+	if rearm := a.rearmRefreshTimer(); rearm != nil {
+		cmd = tea.Batch(cmd, rearm)
+	}
+	return model, cmd
+}
+
+// update handles a message for Update
+func (a *App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// Any message but a tick skipped while unfocused may change the view
+	a.viewDirty = true
+
 	switch msg := msg.(type) {
+	case armedTickMsg:
+		if msg.gen != a.tickGen {
+			a.viewDirty = false
+			return a, nil
+		}
+		return a.update(tickMsg(msg.at))
+
+	case tea.BlurMsg:
+		a.setBlurred(true)
+		return a, nil
+
+	case tea.FocusMsg:
+		return a, a.setBlurred(false)
+
 	case tickMsg:
-		// Auto-refresh on tick
-		return a, tea.Batch(
-			a.resourceView.RefreshResources(),
+		if a.blurred {
+			a.blurredTicks++
+			if a.blurredTicks%blurredTickFactor != 0 {
+				a.viewDirty = false
+				return a, a.startRefreshTimer()
+			}
+		}
+
+		// Auto-refresh on tick, unless another refresh just ran or failed
+		// refreshes are backing off
+		cmds := []tea.Cmd{
 			a.startRefreshTimer(), // Schedule next tick
-		)
+		}
+		// Drop the lists of resource types not viewed for a while
+		a.state.EvictStaleCaches(time.Now())
+		a.notifyAlerts(time.Now())
+
+		window := time.Duration(a.config.CoalesceWindowMs) * time.Millisecond
+		if a.resourceView.HighChurn() {
+			// Pods churning fast are listed less often
+			window = max(window, views.ChurnCoalesceWindow)
+		}
+		if a.blurred {
+			window *= blurredTickFactor
+		}
+		// A kind with periodic refresh off is refreshed on watch events and
+		// r only
+		_, periodic := a.config.RefreshIntervalFor(a.state.CurrentResourceType)
+		if periodic && (window <= 0 || !a.resourceView.RefreshedWithin(window)) && a.resourceView.RetryDue() {
+			cmds = append(cmds, a.resourceView.RefreshResources())
+		}
+		if cmd := a.checkNamespace(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		if a.currentMode == ModeList && !a.blurred {
+			// Sample log rates of the pods on screen, when turned on
+			cmds = append(cmds, a.resourceView.SampleLogRates())
+			// Read the events of pods waiting on an image
+			cmds = append(cmds, a.resourceView.FetchPodEvents())
+			// Tally the pods of the selected deployment for its replica math
+			cmds = append(cmds, a.resourceView.FetchDeploymentPods())
+		}
+		if a.currentMode == ModeTopology {
+			// Keep the topology overlay current as pods move
+			cmds = append(cmds, a.refreshTopology())
+		}
+		if a.currentMode == ModeNodeDetail {
+			cmds = append(cmds, a.refreshNodeDetail())
+		}
+		if a.currentMode == ModeEvents {
+			cmds = append(cmds, a.refreshEvents())
+		}
+		if a.currentMode == ModeUsage && a.usageView != nil && !a.blurred &&
+			a.usageView.Due(time.Duration(a.config.MetricsInterval)*time.Second) {
+			// Usage follows the metrics polling cadence
+			cmds = append(cmds, a.refreshUsage())
+		}
+		if a.comparisonView != nil {
+			cmds = append(cmds, a.comparisonView.RefreshResources())
+		}
+		if a.splitView != nil {
+			cmds = append(cmds, a.splitView.RefreshResources())
+		}
+		return a, tea.Batch(cmds...)
 
 	case tea.KeyMsg:
+		// A key press means the terminal has focus, whatever was reported;
+		// a focus event can be missed while a command had the terminal
+		if focusCmd := a.setBlurred(false); focusCmd != nil {
+			model, cmd := a.Update(msg)
+			return model, tea.Batch(focusCmd, cmd)
+		}
+
 		// Use the new mode system for key handling
 		currentMode := a.getCurrentMode()
 		handled, cmd := currentMode.HandleKey(msg, a)
@@ -283,6 +620,154 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.namespaceView = nsModel.(*views.NamespaceView)
 				return a, viewCmd
 			}
+		case ModeSettings:
+			if a.settingsView != nil {
+				settingsModel, viewCmd := a.settingsView.Update(msg)
+				a.settingsView = settingsModel.(*views.SettingsView)
+				return a, viewCmd
+			}
+		case ModeFilter:
+			if a.filterBar != nil {
+				before := a.filterBar.Expression()
+				filterModel, viewCmd := a.filterBar.Update(msg)
+				a.filterBar = filterModel.(*views.FilterBar)
+				if a.filterBar.Expression() != before {
+					viewCmd = tea.Batch(viewCmd, a.scheduleFilterPreview())
+				}
+				return a, viewCmd
+			}
+		case ModeLabelSelector:
+			if a.labelSelectorBar != nil {
+				barModel, viewCmd := a.labelSelectorBar.Update(msg)
+				a.labelSelectorBar = barModel.(*views.LabelSelectorBar)
+				return a, viewCmd
+			}
+		case ModeSavedFilters:
+			if a.savedFiltersView != nil {
+				savedModel, viewCmd := a.savedFiltersView.Update(msg)
+				a.savedFiltersView = savedModel.(*views.SavedFiltersView)
+				return a, viewCmd
+			}
+		case ModeColumns:
+			if a.columnOrderView != nil {
+				columnsModel, viewCmd := a.columnOrderView.Update(msg)
+				a.columnOrderView = columnsModel.(*views.ColumnOrderView)
+				return a, viewCmd
+			}
+		case ModeShellPicker:
+			if a.shellPicker != nil {
+				pickerModel, viewCmd := a.shellPicker.Update(msg)
+				a.shellPicker = pickerModel.(*views.ShellPickerView)
+				return a, viewCmd
+			}
+		case ModeLogPicker:
+			if a.logPicker != nil {
+				pickerModel, viewCmd := a.logPicker.Update(msg)
+				a.logPicker = pickerModel.(*views.LogPickerView)
+				return a, viewCmd
+			}
+		case ModeScale:
+			if a.scaleView != nil {
+				scaleModel, viewCmd := a.scaleView.Update(msg)
+				a.scaleView = scaleModel.(*views.ScaleView)
+				return a, viewCmd
+			}
+		case ModeFinalizers:
+			if a.finalizerView != nil {
+				finalizerModel, viewCmd := a.finalizerView.Update(msg)
+				a.finalizerView = finalizerModel.(*views.FinalizerView)
+				return a, viewCmd
+			}
+		case ModeActionMenu:
+			if a.actionMenuView != nil {
+				menuModel, viewCmd := a.actionMenuView.Update(msg)
+				a.actionMenuView = menuModel.(*views.ActionMenuView)
+				return a, viewCmd
+			}
+		case ModeActionOutput:
+			if a.actionOutputView != nil {
+				outputModel, viewCmd := a.actionOutputView.Update(msg)
+				a.actionOutputView = outputModel.(*views.ActionOutputView)
+				return a, viewCmd
+			}
+		case ModeTemplates:
+			if a.templatePickerView != nil {
+				pickerModel, viewCmd := a.templatePickerView.Update(msg)
+				a.templatePickerView = pickerModel.(*views.TemplatePickerView)
+				return a, viewCmd
+			}
+		case ModeTemplateForm:
+			if a.templateFormView != nil {
+				formModel, viewCmd := a.templateFormView.Update(msg)
+				a.templateFormView = formModel.(*views.TemplateFormView)
+				return a, viewCmd
+			}
+		case ModeCompare:
+			if a.comparisonView != nil {
+				compareModel, viewCmd := a.comparisonView.Update(msg)
+				a.comparisonView = compareModel.(*views.ComparisonView)
+				return a, viewCmd
+			}
+		case ModeSplit:
+			if a.splitView != nil {
+				splitModel, viewCmd := a.splitView.Update(msg)
+				a.splitView = splitModel.(*views.SplitView)
+				return a, viewCmd
+			}
+		case ModeRelations:
+			if a.relationsView != nil {
+				relationsModel, viewCmd := a.relationsView.Update(msg)
+				a.relationsView = relationsModel.(*views.RelationsView)
+				return a, viewCmd
+			}
+		case ModePickResource:
+			if a.resourcePickerView != nil {
+				pickerModel, viewCmd := a.resourcePickerView.Update(msg)
+				a.resourcePickerView = pickerModel.(*views.ResourcePickerView)
+				return a, viewCmd
+			}
+		case ModeBatch:
+			if a.batchView != nil {
+				batchModel, viewCmd := a.batchView.Update(msg)
+				a.batchView = batchModel.(*views.BatchView)
+				return a, viewCmd
+			}
+		case ModeNodeDetail:
+			if a.nodeDetailView != nil {
+				nodeModel, viewCmd := a.nodeDetailView.Update(msg)
+				a.nodeDetailView = nodeModel.(*views.NodeDetailView)
+				return a, viewCmd
+			}
+		case ModeUsage:
+			if a.usageView != nil {
+				usageModel, viewCmd := a.usageView.Update(msg)
+				a.usageView = usageModel.(*views.UsageView)
+				return a, viewCmd
+			}
+		case ModeEventTail:
+			if a.eventTailView != nil {
+				tailModel, viewCmd := a.eventTailView.Update(msg)
+				a.eventTailView = tailModel.(*views.EventTailView)
+				return a, viewCmd
+			}
+		case ModeEvents:
+			if a.eventsView != nil {
+				eventsModel, viewCmd := a.eventsView.Update(msg)
+				a.eventsView = eventsModel.(*views.EventsView)
+				return a, viewCmd
+			}
+		case ModePermissions:
+			if a.permissionsView != nil {
+				permissionsModel, viewCmd := a.permissionsView.Update(msg)
+				a.permissionsView = permissionsModel.(*views.PermissionsView)
+				return a, viewCmd
+			}
+		case ModeFleet:
+			if a.fleetView != nil {
+				fleetModel, viewCmd := a.fleetView.Update(msg)
+				a.fleetView = fleetModel.(*views.FleetView)
+				return a, viewCmd
+			}
 		case ModeConfirmDialog:
 			if a.confirmView != nil {
 				confirmModel, viewCmd := a.confirmView.Update(msg)
@@ -297,9 +782,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case ModeLog:
 			// Log view handles its own keys
-			logModel, viewCmd := a.logView.Update(msg)
-			a.logView = logModel.(*views.LogView)
-			return a, viewCmd
+			return a, a.updateLogs(msg)
 		case ModeDescribe:
 			if a.describeView != nil {
 				describeModel, viewCmd := a.describeView.Update(msg)
@@ -318,25 +801,175 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.width = msg.Width
 		a.height = msg.Height
 		a.ready = true
+		a.resize()
+		return a, nil
 
-		// Update child views
-		a.resourceView.SetSize(msg.Width, msg.Height)
-		a.logView.SetSize(msg.Width, msg.Height/2)
-		if a.namespaceView != nil {
-			a.namespaceView.SetSize(msg.Width, msg.Height)
-		}
-		if a.confirmView != nil {
-			a.confirmView.SetSize(msg.Width, msg.Height)
+	case views.DeleteRequestedMsg:
+		// Follow the delete until the object is gone; refreshes mark its row
+		a.state.Deletions.Requested(msg.Kind, msg.Ref, time.Now())
+		return a, a.resourceView.RefreshResources()
+
+	case errMsg:
+		a.resourceView.ShowError(msg.err)
+		return a, nil
+
+	case views.SettingAppliedMsg:
+		// The config was updated in place; push the new values into the views
+		a.applyRuntimeSettings()
+		return a, nil
+
+	case views.SettingsSaveRequestedMsg:
+		a.saveRuntimeSettings()
+		return a, nil
+
+	case views.SaveFilterRequestedMsg:
+		return a, a.saveFilter(msg.Name, msg.Expression)
+
+	case views.SavedFilterSelectedMsg:
+		if err := a.applySavedFilter(msg.Filter); err != nil {
+			if a.savedFiltersView != nil {
+				a.savedFiltersView.SetStatus(err.Error())
+			}
+			return a, nil
 		}
-		if a.contextView != nil {
-			a.contextView.SetSize(msg.Width, msg.Height)
+		a.setMode(ModeList)
+		return a, a.resourceView.RefreshResources()
+
+	case finalizersLoadedMsg:
+		return a, a.showFinalizers(msg)
+
+	case views.ColumnOrderChosenMsg:
+		a.applyColumnOrder(msg.Order, msg.Save)
+		return a, nil
+
+	case views.FinalizerSelectedMsg:
+		a.confirmFinalizerRemoval(msg.Finalizer)
+		return a, nil
+
+	case finalizerRemovedMsg:
+		return a, a.finalizerRemoved(msg)
+
+	case views.NodePodSelectedMsg:
+		return a, a.showNodePod(msg)
+
+	case views.UsagePodSelectedMsg:
+		return a, a.showUsagePod(msg)
+
+	case nodeCordonedMsg:
+		return a, a.nodeCordoned(msg)
+
+	case rolloutChangedMsg:
+		return a, a.rolloutChanged(msg)
+
+	case views.RolloutActionSelectedMsg:
+		a.confirmRollout(msg.Action)
+		return a, nil
+
+	case views.PermissionsSelectedMsg:
+		return a, a.startPermissionsView()
+
+	case views.FleetResultMsg:
+		// Results keep coming while a found pod's logs or description are
+		// on screen
+		if a.fleetView != nil {
+			fleetModel, cmd := a.fleetView.Update(msg)
+			a.fleetView = fleetModel.(*views.FleetView)
+			return a, cmd
 		}
 		return a, nil
 
-	case deleteCompleteMsg:
-		// Resource deleted successfully, refresh the list
+	case views.FleetSearchRequestedMsg:
+		return a, a.searchFleet()
+
+	case views.UserActionSelectedMsg:
+		a.setMode(ModeList)
+		return a, a.runUserAction(msg.Action)
+
+	case views.LogsSelectedMsg:
+		a.setMode(ModeList)
+		return a, a.startLogs()
+
+	case views.LogContainerPickedMsg:
+		a.logPicker = nil
+		a.setMode(ModeList)
+		return a, a.openLogs(msg.Container)
+
+	case pagerDoneMsg:
+		a.pagerDone(msg)
+		return a, nil
+
+	case views.ShellContainerPickedMsg:
+		return a, a.openShell(msg.Context, msg.Namespace, msg.Pod, msg.Container)
+
+	case shellDoneMsg:
+		a.shellDone(msg)
+		return a, nil
+
+	case views.ScaleRequestedMsg:
+		return a, a.scale(msg.Replicas)
+
+	case scaledMsg:
+		return a, a.scaled(msg)
+
+	case namespaceStateMsg:
+		a.namespaceChecked(msg)
+		return a, nil
+
+	case updateAvailableMsg:
+		a.updateVersion = msg.version
+		a.resourceView.SetUpdateNotice(a.updateNotice())
+		return a, nil
+
+	case userActionDoneMsg:
+		if msg.err != nil {
+			a.resourceView.ShowError(fmt.Errorf("action %q failed: %w", msg.name, msg.err))
+		} else {
+			a.resourceView.ShowNotice(fmt.Sprintf("✓ Action %q finished", msg.name))
+		}
+		// The terminal was just handed back, so it has focus, and the
+		// action may have changed the cluster
+		a.setBlurred(false)
 		return a, a.resourceView.RefreshResources()
 
+	case userActionOutputMsg:
+		a.showActionOutput(msg)
+		return a, nil
+
+	case views.RelatedResourceSelectedMsg:
+		return a, a.jumpToRelated(msg.Resource)
+
+	case views.AmbiguousSelectionMsg:
+		a.openResourcePicker(msg)
+		return a, nil
+
+	case views.ResourcePickedMsg:
+		a.closeResourcePicker()
+		a.resourceView.SelectResource(msg.Ref)
+		return a, nil
+
+	case views.TemplateSelectedMsg:
+		a.openTemplateForm(msg.Template)
+		return a, nil
+
+	case views.TemplateFormSubmittedMsg:
+		return a, a.createFromTemplate(msg.Template, msg.Values)
+
+	case resourcesCreatedMsg:
+		return a, a.resourcesCreated(msg)
+
+	case views.CleanupRequestedMsg:
+		return a, a.findCreatedResources()
+
+	case createdResourcesFoundMsg:
+		a.confirmCleanup(msg)
+		return a, nil
+
+	case BatchProgressMsg:
+		return a, a.batchProgress(msg)
+
+	case comparisonReadyMsg:
+		return a, a.showComparison(msg)
+
 	case views.ContextInfoMsg:
 		// Show context information
 		return a, a.showContextInfo(msg.ContextName)
@@ -350,21 +983,33 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Update namespace view with loaded namespaces
 		if a.namespaceView != nil {
 			if msg.err != nil {
-				// Handle error - for now, just show empty list
-				a.namespaceView.SetNamespaces([]v1.Namespace{})
+				a.namespaceView.SetLoadError(msg.err)
 			} else {
 				a.namespaceView.SetNamespaces(msg.namespaces)
 			}
 		}
+		// Their Pod Security levels come for free; across contexts the
+		// same namespace may set different ones
+		if msg.err == nil && !a.isMultiContext {
+			for _, ns := range msg.namespaces {
+				a.resourceView.SetNamespacePodSecurity(ns.Name, core.NamespacePodSecurity(ns.Labels))
+			}
+		}
+		return a, nil
+
+	case optionalTypesDetectedMsg:
+		return a, a.applyInstalledTypes(msg.installed)
+
+	case filterPreviewMsg:
+		a.filterPreviewPending = false
+		a.previewFilter()
 		return a, nil
 
 	case dropdown.SelectedMsg:
 		// Handle dropdown selection
 		if a.currentMode == ModeResourceSelector {
 			if resourceType, ok := msg.Option.Value.(core.ResourceType); ok {
-				a.state.SetResourceType(resourceType)
-				a.setMode(ModeList)
-				return a, a.resourceView.RefreshResources()
+				return a, a.switchResourceType(resourceType)
 			}
 		}
 		return a, nil
@@ -390,18 +1035,14 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.(type) {
 		case tea.KeyMsg:
 			// Keyboard events go only to log view
-			logModel, cmd := a.logView.Update(msg)
-			a.logView = logModel.(*views.LogView)
-			cmds = append(cmds, cmd)
+			cmds = append(cmds, a.updateLogs(msg))
 		default:
 			// Non-keyboard events go to both views
 			resourceModel, cmd := a.resourceView.Update(msg)
 			a.resourceView = resourceModel.(*views.ResourceView)
 			cmds = append(cmds, cmd)
 
-			logModel, cmd := a.logView.Update(msg)
-			a.logView = logModel.(*views.LogView)
-			cmds = append(cmds, cmd)
+			cmds = append(cmds, a.updateLogs(msg))
 		}
 
 	case ModeDescribe:
@@ -432,22 +1073,133 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 
+	case ModeTopology:
+		if a.topologyView != nil {
+			topologyModel, cmd := a.topologyView.Update(msg)
+			a.topologyView = topologyModel.(*views.TopologyView)
+			cmds = append(cmds, cmd)
+		}
+
+	case ModeNodeDetail:
+		if a.nodeDetailView != nil {
+			nodeModel, cmd := a.nodeDetailView.Update(msg)
+			a.nodeDetailView = nodeModel.(*views.NodeDetailView)
+			cmds = append(cmds, cmd)
+		}
+
+	case ModeUsage:
+		if a.usageView != nil {
+			usageModel, cmd := a.usageView.Update(msg)
+			a.usageView = usageModel.(*views.UsageView)
+			cmds = append(cmds, cmd)
+		}
+
+	case ModeEventTail:
+		if a.eventTailView != nil {
+			tailModel, cmd := a.eventTailView.Update(msg)
+			a.eventTailView = tailModel.(*views.EventTailView)
+			cmds = append(cmds, cmd)
+		}
+
+	case ModeEvents:
+		if a.eventsView != nil {
+			eventsModel, cmd := a.eventsView.Update(msg)
+			a.eventsView = eventsModel.(*views.EventsView)
+			cmds = append(cmds, cmd)
+		}
+
+	case ModePermissions:
+		if a.permissionsView != nil {
+			permissionsModel, cmd := a.permissionsView.Update(msg)
+			a.permissionsView = permissionsModel.(*views.PermissionsView)
+			cmds = append(cmds, cmd)
+		}
+
+	case ModeFleet:
+		if a.fleetView != nil {
+			fleetModel, cmd := a.fleetView.Update(msg)
+			a.fleetView = fleetModel.(*views.FleetView)
+			cmds = append(cmds, cmd)
+		}
+
+	case ModeCompare:
+		if a.comparisonView != nil {
+			compareModel, cmd := a.comparisonView.Update(msg)
+			a.comparisonView = compareModel.(*views.ComparisonView)
+			cmds = append(cmds, cmd)
+		}
+
+	case ModeSplit:
+		if a.splitView != nil {
+			splitModel, cmd := a.splitView.Update(msg)
+			a.splitView = splitModel.(*views.SplitView)
+			cmds = append(cmds, cmd)
+		}
+
 	default:
-		// Default to resource view (list mode)
+		// Default to resource view (list mode). Keys reach it only when it
+		// has the keyboard; another mode's keys it did not take are dropped.
+		if _, isKey := msg.(tea.KeyMsg); isKey && a.currentMode != ModeList {
+			break
+		}
 		resourceModel, cmd := a.resourceView.Update(msg)
 		a.resourceView = resourceModel.(*views.ResourceView)
 		cmds = append(cmds, cmd)
 	}
 
+	if a.startup != nil && a.currentMode == ModeList && a.resourceView.Loaded() {
+		cmds = append(cmds, a.openStartupLayout())
+	}
+
 	return a, tea.Batch(cmds...)
 }
 
 // View renders the application
 func (a *App) View() string {
+	if a.blurred && !a.viewDirty && a.lastView != "" {
+		return a.lastView
+	}
+	a.lastView = a.render()
+	if a.config.Accessible {
+		a.lastView = style.PlainText(a.lastView)
+	}
+	a.viewDirty = false
+	return a.lastView
+}
+
+// setBlurred records whether the terminal has lost focus. Unfocused, the
+// work done on ticks is cut down; focused again, the list is refreshed at
+// once and the normal cadence resumes.
+func (a *App) setBlurred(blurred bool) tea.Cmd {
+	if a.blurred == blurred {
+		return nil
+	}
+	a.blurred = blurred
+	a.blurredTicks = 0
+	a.resourceView.SetMetricsPaused(blurred)
+	if blurred {
+		return nil
+	}
+	return a.resourceView.RefreshResources()
+}
+
+// render renders the current mode, with the key hint bar under it when
+// there is room
+func (a *App) render() string {
 	if !a.ready {
 		return "Initializing..."
 	}
+	if !a.hintBarShown() {
+		return a.renderMode()
+	}
+	// Pad or cut the mode to its height so the hints stay on the last line
+	height := a.viewHeight()
+	content := lipgloss.NewStyle().Height(height).MaxHeight(height).Render(a.renderMode())
+	return lipgloss.JoinVertical(lipgloss.Left, content, a.renderHintBar())
+}
 
+// renderMode renders the current mode
+func (a *App) renderMode() string {
 	// Render based on current mode
 	switch a.currentMode {
 	case ModeConfirmDialog:
@@ -466,6 +1218,9 @@ func (a *App) View() string {
 		}
 
 	case ModeHelp:
+		if a.state.Caches != nil {
+			a.helpView.SetCacheUsage(a.state.Caches.Usage())
+		}
 		return a.helpView.View()
 
 	case ModeResourceSelector:
@@ -478,35 +1233,181 @@ func (a *App) View() string {
 			return a.describeView.View()
 		}
 
-	case ModeLog:
-		// Split view - give more space to logs, keep resource view compact
-		minResourceHeight := 8 // Minimum height for resource view (header + 5-6 rows)
-		resourceHeight := minResourceHeight
+	case ModeTopology:
+		if a.topologyView != nil {
+			return a.topologyView.View()
+		}
 
-		// If we have more space, show a bit more context
-		if a.height > 20 {
-			resourceHeight = a.height / 3 // Give 1/3 to resources, 2/3 to logs
-			if resourceHeight < minResourceHeight {
-				resourceHeight = minResourceHeight
-			}
+	case ModeSettings:
+		if a.settingsView != nil {
+			return a.settingsView.View()
 		}
 
-		logHeight := a.height - resourceHeight - 1
+	case ModeSavedFilters:
+		if a.savedFiltersView != nil {
+			return a.savedFiltersView.View()
+		}
 
-		// Update sizes for both views
-		a.resourceView.SetSize(a.width, resourceHeight)
-		a.logView.SetSize(a.width, logHeight)
+	case ModeColumns:
+		if a.columnOrderView != nil {
+			return a.columnOrderView.View()
+		}
+	case ModeShellPicker:
+		if a.shellPicker != nil {
+			return a.shellPicker.View()
+		}
+	case ModeLogPicker:
+		if a.logPicker != nil {
+			return a.logPicker.View()
+		}
+	case ModeScale:
+		if a.scaleView != nil {
+			return a.scaleView.View()
+		}
 
-		topView := lipgloss.NewStyle().
+	case ModeFinalizers:
+		if a.finalizerView != nil {
+			return a.finalizerView.View()
+		}
+
+	case ModeActionMenu:
+		if a.actionMenuView != nil {
+			return a.actionMenuView.View()
+		}
+
+	case ModeActionOutput:
+		if a.actionOutputView != nil {
+			return a.actionOutputView.View()
+		}
+
+	case ModeTemplates:
+		if a.templatePickerView != nil {
+			return a.templatePickerView.View()
+		}
+
+	case ModeTemplateForm:
+		if a.templateFormView != nil {
+			return a.templateFormView.View()
+		}
+
+	case ModeCompare:
+		if a.comparisonView != nil {
+			return a.comparisonView.View()
+		}
+
+	case ModeSplit:
+		if a.splitView != nil {
+			return a.splitView.View()
+		}
+
+	case ModeRelations:
+		if a.relationsView != nil {
+			return a.relationsView.View()
+		}
+
+	case ModePickResource:
+		if a.resourcePickerView != nil {
+			return a.resourcePickerView.View()
+		}
+
+	case ModeBatch:
+		if a.batchView != nil {
+			return a.batchView.View()
+		}
+
+	case ModeNodeDetail:
+		if a.nodeDetailView != nil {
+			return a.nodeDetailView.View()
+		}
+
+	case ModeUsage:
+		if a.usageView != nil {
+			return a.usageView.View()
+		}
+
+	case ModeEventTail:
+		if a.eventTailView != nil {
+			return a.eventTailView.View()
+		}
+
+	case ModeEvents:
+		if a.eventsView != nil {
+			return a.eventsView.View()
+		}
+
+	case ModePermissions:
+		if a.permissionsView != nil {
+			return a.permissionsView.View()
+		}
+
+	case ModeFleet:
+		if a.fleetView != nil {
+			return a.fleetView.View()
+		}
+
+	case ModeFilter:
+		if a.filterBar != nil && a.comparisonView != nil {
+			return lipgloss.JoinVertical(lipgloss.Left, a.comparisonView.View(), a.filterBar.View())
+		}
+		if a.filterBar != nil && a.splitView != nil {
+			return lipgloss.JoinVertical(lipgloss.Left, a.splitView.View(), a.filterBar.View())
+		}
+		if a.filterBar != nil {
+			// Keep the list visible above the filter bar
+			return lipgloss.JoinVertical(lipgloss.Left, a.resourceView.View(), a.filterBar.View())
+		}
+
+	case ModeLabelSelector:
+		if a.labelSelectorBar != nil {
+			// Keep the list visible above the label selector bar
+			a.resourceView.SetSize(a.width, a.viewHeight()-1)
+			a.labelSelectorBar.SetSize(a.width, 1)
+			return lipgloss.JoinVertical(lipgloss.Left, a.resourceView.View(), a.labelSelectorBar.View())
+		}
+
+	case ModeLog:
+		// Split view - give more space to logs, keep resource view compact
+		minResourceHeight := 8 // Minimum height for resource view (header + 5-6 rows)
+		resourceHeight := minResourceHeight
+		height := a.viewHeight()
+
+		// If we have more space, show a bit more context
+		if height > 20 {
+			resourceHeight = height / 3 // Give 1/3 to resources, 2/3 to logs
+			if resourceHeight < minResourceHeight {
+				resourceHeight = minResourceHeight
+			}
+		}
+
+		logHeight := height - resourceHeight - 1
+
+		// Update sizes for both views
+		a.resourceView.SetSize(a.width, resourceHeight)
+		logView := a.logView.View
+		if a.logSplit != nil {
+			a.logSplit.SetSize(a.width, logHeight)
+			logView = a.logSplit.View
+		} else {
+			a.logView.SetSize(a.width, logHeight)
+		}
+
+		// Logs opened from a fleet search keep its results on top
+		listView := a.resourceView.View
+		if a.fleetView != nil {
+			a.fleetView.SetSize(a.width, resourceHeight)
+			listView = a.fleetView.View
+		}
+
+		topView := lipgloss.NewStyle().
 			Height(resourceHeight).
 			MaxHeight(resourceHeight).
-			Render(a.resourceView.View())
+			Render(listView())
 
 		bottomView := lipgloss.NewStyle().
 			Height(logHeight).
 			BorderTop(true).
 			BorderStyle(lipgloss.NormalBorder()).
-			Render(a.logView.View())
+			Render(logView())
 
 		return lipgloss.JoinVertical(lipgloss.Left, topView, bottomView)
 	}
@@ -517,15 +1418,7 @@ func (a *App) View() string {
 
 // nextResourceType cycles to the next resource type
 func (a *App) nextResourceType() {
-	types := []core.ResourceType{
-		core.ResourceTypePod,
-		core.ResourceTypeDeployment,
-		core.ResourceTypeStatefulSet,
-		core.ResourceTypeService,
-		core.ResourceTypeIngress,
-		core.ResourceTypeConfigMap,
-		core.ResourceTypeSecret,
-	}
+	types := a.state.AvailableResourceTypes()
 
 	current := a.state.CurrentResourceType
 	for i, t := range types {
@@ -539,15 +1432,7 @@ func (a *App) nextResourceType() {
 
 // prevResourceType cycles to the previous resource type
 func (a *App) prevResourceType() {
-	types := []core.ResourceType{
-		core.ResourceTypePod,
-		core.ResourceTypeDeployment,
-		core.ResourceTypeStatefulSet,
-		core.ResourceTypeService,
-		core.ResourceTypeIngress,
-		core.ResourceTypeConfigMap,
-		core.ResourceTypeSecret,
-	}
+	types := a.state.AvailableResourceTypes()
 
 	current := a.state.CurrentResourceType
 	for i, t := range types {
@@ -559,6 +1444,53 @@ func (a *App) prevResourceType() {
 	}
 }
 
+// detectOptionalTypes finds which of the optional resource types, such as
+// the Gateway API's, the active contexts serve. A type is offered when any
+// of them serves it; a context that cannot be asked counts as serving none.
+func (a *App) detectOptionalTypes() tea.Cmd {
+	var clients []*k8s.Client
+	if a.isMultiContext && a.multiClient != nil {
+		for _, name := range a.activeContexts {
+			if client, err := a.multiClient.GetClient(name); err == nil {
+				clients = append(clients, client)
+			}
+		}
+	} else if a.k8sClient != nil {
+		clients = append(clients, a.k8sClient)
+	}
+	if len(clients) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		installed := make(map[core.ResourceType]bool)
+		for _, client := range clients {
+			types, err := client.InstalledOptionalTypes()
+			if err != nil {
+				continue
+			}
+			for t, served := range types {
+				installed[t] = installed[t] || served
+			}
+		}
+		return optionalTypesDetectedMsg{installed}
+	}
+}
+
+// applyInstalledTypes offers the optional resource types found installed.
+// A list of a type the cluster does not serve, as `kubewatch gateways` asks
+// for, is swapped for the pods with a notice saying why.
+func (a *App) applyInstalledTypes(installed map[core.ResourceType]bool) tea.Cmd {
+	a.state.SetInstalledTypes(installed)
+	current := a.state.CurrentResourceType
+	if a.state.IsTypeAvailable(current) {
+		return nil
+	}
+	a.state.SetResourceType(core.ResourceTypePod)
+	a.resourceView.ShowNotice(fmt.Sprintf("%s are not installed in this cluster; showing Pods", current))
+	return a.resourceView.RefreshResources()
+}
+
 // startWatcher starts watching for resource changes
 func (a *App) startWatcher() tea.Cmd {
 	// Cancel any existing watcher
@@ -570,15 +1502,21 @@ func (a *App) startWatcher() tea.Cmd {
 	ctx, cancel := context.WithCancel(a.ctx)
 	a.watcherCtx = ctx
 	a.cancelWatcher = cancel
+	generation := atomic.AddUint64(&a.watcherGen, 1)
 
 	return func() tea.Msg {
 		// Start watcher based on current resource type
 		var watcher watch.Interface
 		var err error
 
+		// There is nothing to watch of a type the cluster does not serve
+		if a.k8sClient.CheckServed(a.state.CurrentResourceType) != nil {
+			return nil
+		}
+
 		switch a.state.CurrentResourceType {
 		case core.ResourceTypePod:
-			watcher, err = a.k8sClient.WatchPods(ctx, a.state.CurrentNamespace)
+			watcher, err = a.k8sClient.WatchPodsWithSelector(ctx, a.state.CurrentNamespace, a.state.PodFieldSelector())
 		case core.ResourceTypeDeployment:
 			watcher, err = a.k8sClient.WatchDeployments(ctx, a.state.CurrentNamespace)
 		case core.ResourceTypeStatefulSet:
@@ -587,10 +1525,30 @@ func (a *App) startWatcher() tea.Cmd {
 			watcher, err = a.k8sClient.WatchServices(ctx, a.state.CurrentNamespace)
 		case core.ResourceTypeIngress:
 			watcher, err = a.k8sClient.WatchIngresses(ctx, a.state.CurrentNamespace)
+		case core.ResourceTypeGateway:
+			watcher, err = a.k8sClient.WatchGateways(ctx, a.state.CurrentNamespace)
+		case core.ResourceTypeHTTPRoute:
+			watcher, err = a.k8sClient.WatchHTTPRoutes(ctx, a.state.CurrentNamespace)
 		case core.ResourceTypeConfigMap:
 			watcher, err = a.k8sClient.WatchConfigMaps(ctx, a.state.CurrentNamespace)
 		case core.ResourceTypeSecret:
 			watcher, err = a.k8sClient.WatchSecrets(ctx, a.state.CurrentNamespace)
+		case core.ResourceTypeServiceAccount:
+			watcher, err = a.k8sClient.WatchServiceAccounts(ctx, a.state.CurrentNamespace)
+		case core.ResourceTypeRole:
+			watcher, err = a.k8sClient.WatchRoles(ctx, a.state.CurrentNamespace)
+		case core.ResourceTypeClusterRole:
+			watcher, err = a.k8sClient.WatchClusterRoles(ctx)
+		case core.ResourceTypeRoleBinding:
+			watcher, err = a.k8sClient.WatchRoleBindings(ctx, a.state.CurrentNamespace)
+		case core.ResourceTypeClusterRoleBinding:
+			watcher, err = a.k8sClient.WatchClusterRoleBindings(ctx)
+		case core.ResourceTypeNode:
+			watcher, err = a.k8sClient.WatchNodes(ctx)
+		case core.ResourceTypeJob:
+			watcher, err = a.k8sClient.WatchJobs(ctx, a.state.CurrentNamespace)
+		case core.ResourceTypeCronJob:
+			watcher, err = a.k8sClient.WatchCronJobs(ctx, a.state.CurrentNamespace)
 		default:
 			return nil
 		}
@@ -599,35 +1557,88 @@ func (a *App) startWatcher() tea.Cmd {
 			return nil // Silently fail for now
 		}
 
-		// Watch for events
-		go func() {
-			defer watcher.Stop()
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case _, ok := <-watcher.ResultChan():
-					if !ok {
-						return
-					}
-					// For now, just trigger a refresh
-					// In a full implementation, we'd send the event as a message
-				}
-			}
-		}()
-
+		go a.watchEvents(ctx, generation, a.state.CurrentResourceType, watcher)
 		return nil
 	}
 }
 
+// watchEvents records the events of the watcher of generation, which
+// watches kind, until ctx is done or a newer watcher replaces it
+func (a *App) watchEvents(ctx context.Context, generation uint64, kind core.ResourceType, watcher watch.Interface) {
+	defer watcher.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return
+			}
+			// A cancelled watcher can still deliver an event that was in
+			// flight; it belongs to what was listed before
+			if atomic.LoadUint64(&a.watcherGen) != generation {
+				return
+			}
+			// Record deployment image changes as they happen
+			if a.state.Images != nil {
+				a.state.Images.ObserveEvent(event, time.Now())
+			}
+			if a.state.Changes != nil {
+				a.state.Changes.ObserveEvent(event, time.Now())
+			}
+			if a.state.Deletions != nil {
+				a.state.Deletions.ObserveEvent(kind, "", event, time.Now())
+			}
+			// For now, just trigger a refresh
+			// In a full implementation, we'd send the event as a message
+		}
+	}
+}
+
 // startRefreshTimer returns a command that sends a tick message after the configured interval
 func (a *App) startRefreshTimer() tea.Cmd {
-	interval := time.Duration(a.config.RefreshInterval) * time.Second
+	interval := a.tickInterval()
+	a.tickGen++
+	a.armedInterval = interval
+	a.resourceView.SetRefreshInterval(a.listRefreshInterval())
+	gen := a.tickGen
 	return tea.Tick(interval, func(t time.Time) tea.Msg {
-		return tickMsg(t)
+		return armedTickMsg{gen: gen, at: t}
 	})
 }
 
+// listRefreshInterval returns how often the kind listed is refreshed, or 0
+// when it is not refreshed periodically
+func (a *App) listRefreshInterval() time.Duration {
+	interval, _ := a.config.RefreshIntervalFor(a.state.CurrentResourceType)
+	return interval
+}
+
+// tickInterval returns the interval the refresh timer ticks at: that of the
+// kind listed, or the refresh interval when the kind is not refreshed
+// periodically, as ticks do more than refresh the list
+func (a *App) tickInterval() time.Duration {
+	if interval := a.listRefreshInterval(); interval > 0 {
+		return interval
+	}
+	return time.Duration(a.config.RefreshInterval) * time.Second
+}
+
+// rearmRefreshTimer arms the refresh timer again when the interval it
+// should tick at is no longer the one it was armed with, or returns nil,
+// and keeps the list's interval that of the kind listed. Nothing is armed
+// before the timer first is.
+func (a *App) rearmRefreshTimer() tea.Cmd {
+	if a.tickGen == 0 {
+		return nil
+	}
+	if a.tickInterval() == a.armedInterval {
+		a.resourceView.SetRefreshInterval(a.listRefreshInterval())
+		return nil
+	}
+	return a.startRefreshTimer()
+}
+
 // openNamespaceSelector opens the namespace selection popup
 func (a *App) openNamespaceSelector() tea.Cmd {
 	// For testing or when no clients are available, use mock namespaces
@@ -639,7 +1650,7 @@ func (a *App) openNamespaceSelector() tea.Cmd {
 			{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace"}},
 		}
 		a.namespaceView = views.NewNamespaceView(testNamespaces, a.state.CurrentNamespace)
-		a.namespaceView.SetSize(a.width, a.height)
+		a.namespaceView.SetSize(a.width, a.viewHeight())
 		a.showNamespacePopup = true
 		a.setMode(ModeNamespaceSelector)
 		return nil
@@ -652,7 +1663,7 @@ func (a *App) openNamespaceSelector() tea.Cmd {
 	}
 
 	a.namespaceView = views.NewNamespaceViewWithLoading(a.state.CurrentNamespace, loadingMessage)
-	a.namespaceView.SetSize(a.width, a.height)
+	a.namespaceView.SetSize(a.width, a.viewHeight())
 	a.showNamespacePopup = true
 	a.setMode(ModeNamespaceSelector)
 
@@ -681,7 +1692,7 @@ func (a *App) openContextSelector() tea.Cmd {
 		// Create context view with test contexts
 		testContexts := []string{"test-context", "context-1", "context-2"}
 		a.contextView = views.NewContextView(testContexts, a.activeContexts)
-		a.contextView.SetSize(a.width, a.height)
+		a.contextView.SetSize(a.width, a.viewHeight())
 		a.showContextSelector = true
 		a.setMode(ModeContextSelector)
 		return nil
@@ -696,7 +1707,7 @@ func (a *App) openContextSelector() tea.Cmd {
 
 		// Create context view with current selections
 		a.contextView = views.NewContextView(contexts, a.activeContexts)
-		a.contextView.SetSize(a.width, a.height)
+		a.contextView.SetSize(a.width, a.viewHeight())
 		a.showContextSelector = true
 		a.setMode(ModeContextSelector)
 
@@ -706,6 +1717,12 @@ func (a *App) openContextSelector() tea.Cmd {
 
 // getSelectedResourceContext returns the context of the currently selected resource in multi-context mode
 func (a *App) getSelectedResourceContext() string {
+	if a.comparisonView != nil {
+		return a.comparisonView.FocusedContext()
+	}
+	if a.splitView != nil {
+		return a.splitView.Context()
+	}
 	if !a.isMultiContext {
 		return ""
 	}
@@ -715,8 +1732,8 @@ func (a *App) getSelectedResourceContext() string {
 
 // cycleSortColumn cycles through available sort columns or toggles sort direction
 func (a *App) cycleSortColumn() {
-	// Get available columns for current resource type
-	availableColumns := a.getAvailableSortColumns()
+	// Get available columns for current resource type, in the order shown
+	availableColumns := core.ApplyColumnOrder(a.getAvailableSortColumns(), a.state.GetColumnOrder(a.state.CurrentResourceType))
 
 	currentColumn := a.state.SortColumn
 	if currentColumn == "" {
@@ -764,6 +1781,11 @@ func (a *App) getAvailableSortColumns() []string {
 			return []string{"CONTEXT", "NAME", "TYPE", "CLUSTER-IP", "AGE"}
 		}
 		return []string{"NAME", "TYPE", "CLUSTER-IP", "AGE"}
+	case core.ResourceTypeGateway:
+		if a.isMultiContext {
+			return []string{"CONTEXT", "NAME", "CLASS", "PROGRAMMED", "AGE"}
+		}
+		return []string{"NAME", "CLASS", "PROGRAMMED", "AGE"}
 	default:
 		if a.isMultiContext {
 			return []string{"CONTEXT", "NAME", "AGE"}
@@ -772,12 +1794,124 @@ func (a *App) getAvailableSortColumns() []string {
 	}
 }
 
+// sizedView is a view that lays itself out for the terminal size
+type sizedView interface {
+	SetSize(width, height int)
+}
+
+// liveOverlays is the registry of full-screen views that currently exist,
+// whether or not they are on screen. A new overlay must be added here so it
+// follows terminal resizes.
+func (a *App) liveOverlays() []sizedView {
+	var live []sizedView
+	if a.helpView != nil {
+		live = append(live, a.helpView)
+	}
+	if a.namespaceView != nil {
+		live = append(live, a.namespaceView)
+	}
+	if a.contextView != nil {
+		live = append(live, a.contextView)
+	}
+	if a.confirmView != nil {
+		live = append(live, a.confirmView)
+	}
+	if a.describeView != nil {
+		live = append(live, a.describeView)
+	}
+	if a.resourceSelectorView != nil {
+		live = append(live, a.resourceSelectorView)
+	}
+	if a.topologyView != nil {
+		live = append(live, a.topologyView)
+	}
+	if a.nodeDetailView != nil {
+		live = append(live, a.nodeDetailView)
+	}
+	if a.usageView != nil {
+		live = append(live, a.usageView)
+	}
+	if a.eventTailView != nil {
+		live = append(live, a.eventTailView)
+	}
+	if a.eventsView != nil {
+		live = append(live, a.eventsView)
+	}
+	if a.permissionsView != nil {
+		live = append(live, a.permissionsView)
+	}
+	if a.fleetView != nil {
+		live = append(live, a.fleetView)
+	}
+	if a.settingsView != nil {
+		live = append(live, a.settingsView)
+	}
+	if a.savedFiltersView != nil {
+		live = append(live, a.savedFiltersView)
+	}
+	if a.columnOrderView != nil {
+		live = append(live, a.columnOrderView)
+	}
+	if a.shellPicker != nil {
+		live = append(live, a.shellPicker)
+	}
+	if a.logPicker != nil {
+		live = append(live, a.logPicker)
+	}
+	if a.scaleView != nil {
+		live = append(live, a.scaleView)
+	}
+	if a.finalizerView != nil {
+		live = append(live, a.finalizerView)
+	}
+	if a.actionMenuView != nil {
+		live = append(live, a.actionMenuView)
+	}
+	if a.actionOutputView != nil {
+		live = append(live, a.actionOutputView)
+	}
+	if a.templatePickerView != nil {
+		live = append(live, a.templatePickerView)
+	}
+	if a.templateFormView != nil {
+		live = append(live, a.templateFormView)
+	}
+	if a.comparisonView != nil {
+		live = append(live, a.comparisonView)
+	}
+	if a.splitView != nil {
+		live = append(live, a.splitView)
+	}
+	if a.relationsView != nil {
+		live = append(live, a.relationsView)
+	}
+	if a.resourcePickerView != nil {
+		live = append(live, a.resourcePickerView)
+	}
+	if a.batchView != nil {
+		live = append(live, a.batchView)
+	}
+	return live
+}
+
 // Mode management methods
 
 // setMode changes the current screen mode
 func (a *App) setMode(mode ScreenModeType) {
+	// The list keeps its place while another mode has the keyboard
+	if a.currentMode == ModeList && mode != ModeList {
+		a.resourceView.HoldFocus()
+	} else if a.currentMode != ModeList && mode == ModeList {
+		a.resourceView.RestoreFocus()
+	}
+
+	hintBar := a.hintBarShown()
 	a.previousMode = a.currentMode
 	a.currentMode = mode
+	if a.hintBarShown() != hintBar || (a.previousMode == ModeFilter) != (mode == ModeFilter) {
+		// The key hint bar or the filter bar comes or goes with the mode
+		a.resize()
+	}
 
 	// Update legacy state flags for compatibility
 	switch mode {
@@ -818,119 +1952,2126 @@ func (a *App) getCurrentMode() ScreenMode {
 
 // startDescribeView starts the describe view for a resource
 func (a *App) startDescribeView(resourceName string) tea.Cmd {
+	return a.startDescribeRef(core.ResourceRef{
+		Context:   a.getSelectedResourceContext(),
+		Namespace: a.listView().GetSelectedResourceNamespace(),
+		Name:      resourceName,
+	})
+}
+
+// startDescribeRef starts the describe view for a resource of the listed type
+func (a *App) startDescribeRef(ref core.ResourceRef) tea.Cmd {
+	resourceType := string(a.listState().CurrentResourceType)
+	resourceName, namespace, context := ref.Name, ref.Namespace, ref.Context
+
+	a.describeView = views.NewDescribeView(a.ctx, a.clientForContext(context), resourceType, resourceName, namespace, context)
+	a.describeView.SetSize(a.width, a.viewHeight())
+	if tmpl, ok := a.describeTemplates[a.listState().CurrentResourceType]; ok {
+		a.describeView.SetDescribeTemplate(tmpl)
+	}
+
+	// A deployment's image changes seen this session follow its description
+	if state := a.listState(); state.CurrentResourceType == core.ResourceTypeDeployment && state.Images != nil {
+		if deployment, ok := state.FindDeployment(context, namespace, resourceName); ok {
+			a.describeView.SetImageChanges(state.Images.Changes(deployment.UID))
+		}
+	}
+	if state := a.listState(); state.Changes != nil {
+		if uid, ok := state.FindUID(context, namespace, resourceName); ok {
+			a.describeView.SetRecentChanges(state.Changes.Events(uid))
+		}
+	}
+	return a.describeView.Init()
+}
+
+// startTopologyView opens the topology overlay for a resource
+func (a *App) startTopologyView(resourceName string) tea.Cmd {
 	resourceType := string(a.state.CurrentResourceType)
-	namespace := a.state.CurrentNamespace
+	namespace := a.resourceView.GetSelectedResourceNamespace()
 	context := ""
 
 	if a.isMultiContext {
 		context = a.getSelectedResourceContext()
 	}
 
-	a.describeView = views.NewDescribeView(resourceType, resourceName, namespace, context)
-	a.describeView.SetSize(a.width, a.height)
+	a.topologyView = views.NewTopologyView(resourceType, resourceName, namespace, context)
+	a.topologyView.SetSize(a.width, a.viewHeight())
 
-	// Use the appropriate client
-	if a.isMultiContext && context != "" {
-		if client, err := a.multiClient.GetClient(context); err == nil {
-			return a.describeView.LoadDescribeWithClient(a.ctx, client)
+	return a.refreshTopology()
+}
+
+// refreshTopology reloads the topology overlay's pods and node labels
+func (a *App) refreshTopology() tea.Cmd {
+	if a.topologyView == nil {
+		return nil
+	}
+
+	// Use the context captured when the overlay opened, not the live selection
+	context := a.topologyView.GetContext()
+	var client *k8s.Client
+	if a.isMultiContext && a.multiClient != nil {
+		if context != "" {
+			client, _ = a.multiClient.GetClient(context)
 		}
-	} else if a.k8sClient != nil {
-		return a.describeView.LoadDescribeWithClient(a.ctx, a.k8sClient)
+	} else {
+		client = a.k8sClient
 	}
 
-	// Fallback to placeholder content
-	return a.describeView.Init()
+	if client == nil {
+		return nil
+	}
+
+	return a.topologyView.LoadTopologyWithClient(a.ctx, client, a.nodeInfoCache(context, client))
 }
 
-// showDeleteConfirmation shows the delete confirmation dialog
-func (a *App) showDeleteConfirmation(resourceName string) tea.Cmd {
-	a.pendingDeleteName = resourceName
-	resourceType := string(a.state.CurrentResourceType)
+// startNodeDetailView opens the node overlay for the selected node, or the
+// node the selected pod is scheduled on
+func (a *App) startNodeDetailView() tea.Cmd {
+	var nodeName string
+	switch a.state.CurrentResourceType {
+	case core.ResourceTypeNode:
+		nodeName = a.listView().GetSelectedResourceName()
+		if nodeName == "" {
+			return nil
+		}
+	case core.ResourceTypePod:
+		if a.listView().GetSelectedResourceName() == "" {
+			return nil
+		}
+		nodeName = a.listView().GetSelectedResourceColumn("NODE")
+		if nodeName == "" || nodeName == "-" || nodeName == "<none>" {
+			a.resourceView.ShowNotice("The pod is not scheduled on a node yet")
+			return nil
+		}
+	default:
+		a.resourceView.ShowNotice("Node details are shown from a node or a pod's NODE")
+		return nil
+	}
+
+	a.nodeDetailView = views.NewNodeDetailView(nodeName, a.getSelectedResourceContext())
+	a.nodeDetailView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeNodeDetail)
+	return a.refreshNodeDetail()
+}
 
-	// Remove the 's' at the end for singular form
-	if strings.HasSuffix(resourceType, "s") {
-		resourceType = resourceType[:len(resourceType)-1]
+// startEventsView opens the events of the selected resource, or of the
+// namespace when none is selected
+func (a *App) startEventsView() tea.Cmd {
+	list := a.listView()
+	namespace := a.listState().CurrentNamespace
+	var kind, name string
+	var uid types.UID
+	if name = list.GetSelectedResourceName(); name != "" {
+		kind = k8s.EventKind(string(a.listState().CurrentResourceType))
+		namespace = list.GetSelectedResourceNamespace()
+		uid = types.UID(list.SelectedResourceUID())
 	}
 
-	message := fmt.Sprintf("Are you sure you want to delete %s '%s'?",
-		strings.ToLower(resourceType), resourceName)
-	a.confirmView = views.NewConfirmView("⚠️  Confirm Deletion", message)
-	a.confirmView.SetSize(a.width, a.height)
-	a.confirmView.SetConfirmText("Delete")
-	a.confirmView.SetCancelText("Cancel")
+	a.eventsView = views.NewEventsView(kind, name, uid, namespace, a.getSelectedResourceContext())
+	a.eventsView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeEvents)
+	return a.refreshEvents()
+}
 
-	return nil
+// refreshEvents reloads the events overlay
+func (a *App) refreshEvents() tea.Cmd {
+	if a.eventsView == nil {
+		return nil
+	}
+	client := a.clientForContext(a.eventsView.GetContext())
+	if client == nil {
+		return nil
+	}
+	return a.eventsView.LoadWithClient(a.ctx, client)
 }
 
-// applyContextSelection applies the selected contexts
-func (a *App) applyContextSelection() tea.Cmd {
-	if a.contextView == nil {
+// refreshNodeDetail reloads the node overlay's node and pods
+func (a *App) refreshNodeDetail() tea.Cmd {
+	if a.nodeDetailView == nil {
 		return nil
 	}
-	newContexts := a.contextView.GetSelectedContexts()
-	if len(newContexts) > 0 {
-		// Show loading indicators for selected contexts
-		for _, ctx := range newContexts {
-			a.contextView.SetContextLoading(ctx, true)
-		}
+	client := a.clientForContext(a.nodeDetailView.GetContext())
+	if client == nil {
+		return nil
+	}
+	return a.nodeDetailView.LoadNodeWithClient(a.ctx, client)
+}
 
-		a.activeContexts = newContexts
-		a.state.SetCurrentContexts(newContexts)
+// showNodePod closes the node overlay and selects a pod from it in the list
+func (a *App) showNodePod(msg views.NodePodSelectedMsg) tea.Cmd {
+	ref := core.ResourceRef{Namespace: msg.Namespace, Name: msg.Name}
+	if a.isMultiContext {
+		ref.Context = a.nodeDetailView.GetContext()
+	}
+	a.nodeDetailView = nil
+	namespace := a.state.CurrentNamespace
+	if namespace != "" {
+		// Stay in all-namespaces view; otherwise follow the pod
+		namespace = msg.Namespace
+	}
+	return a.showResource(core.ResourceTypePod, namespace, ref)
+}
 
-		// Always use multi-context mode regardless of number of contexts
-		multiClient, err := k8s.NewMultiContextClient(newContexts)
-		if err == nil {
-			a.multiClient = multiClient
-			a.k8sClient = nil
-			a.isMultiContext = true
-			// Update resource view with multi-client
-			a.resourceView = views.NewResourceViewWithMultiContext(a.state, multiClient)
-			a.resourceView.SetSize(a.width, a.height)
-		}
+// startUsageView opens the overlay of usage by namespace, over every active
+// context
+func (a *App) startUsageView() tea.Cmd {
+	contexts := []string{""}
+	if a.isMultiContext && len(a.activeContexts) > 0 {
+		contexts = a.activeContexts
+	}
+	a.usageView = views.NewUsageView(contexts)
+	a.usageView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeUsage)
+	return a.refreshUsage()
+}
 
-		// Clear loading indicators
-		for _, ctx := range newContexts {
-			a.contextView.SetContextLoading(ctx, false)
+// refreshUsage fetches the usage overlay's reports again
+func (a *App) refreshUsage() tea.Cmd {
+	if a.usageView == nil {
+		return nil
+	}
+	return a.usageView.LoadUsageWithClients(a.ctx, a.overlayClients())
+}
+
+// overlayClients returns the clients of the active contexts, keyed by
+// context, or the single client as context ""
+func (a *App) overlayClients() map[string]*k8s.Client {
+	clients := make(map[string]*k8s.Client)
+	if !a.isMultiContext || len(a.activeContexts) == 0 {
+		if a.k8sClient != nil {
+			clients[""] = a.k8sClient
+		}
+	} else {
+		for _, contextName := range a.activeContexts {
+			if client := a.clientForContext(contextName); client != nil {
+				clients[contextName] = client
+			}
 		}
+	}
+	return clients
+}
 
-		// Refresh resources with new contexts
-		a.setMode(ModeList)
-		return a.resourceView.RefreshResources()
+// startEventTail opens the live tail of the current namespace's events, over
+// every active context
+func (a *App) startEventTail() tea.Cmd {
+	contexts := []string{""}
+	if a.isMultiContext && len(a.activeContexts) > 0 {
+		contexts = a.activeContexts
 	}
-	a.setMode(ModeList)
-	return nil
+	a.eventTailView = views.NewEventTailView(a.state.CurrentNamespace, contexts)
+	a.eventTailView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeEventTail)
+	return a.eventTailView.StartWithClients(a.ctx, a.overlayClients())
 }
 
-// applyNamespaceSelection applies the selected namespace
-func (a *App) applyNamespaceSelection() tea.Cmd {
-	newNamespace := a.namespaceView.GetSelectedNamespace()
-	if newNamespace != a.state.CurrentNamespace {
-		a.state.CurrentNamespace = newNamespace
-		a.config.CurrentNamespace = newNamespace
-		// Refresh resources with new namespace
-		a.setMode(ModeList)
-		return a.resourceView.RefreshResources()
+// closeEventTail stops the event tail's watches and returns to the list
+func (a *App) closeEventTail() {
+	if a.eventTailView != nil {
+		a.eventTailView.Stop()
+		a.eventTailView = nil
 	}
 	a.setMode(ModeList)
-	return nil
 }
 
-// handleConfirmDialogAction handles the confirm dialog action
-func (a *App) handleConfirmDialogAction() tea.Cmd {
-	if a.confirmView.IsConfirmed() {
-		// Proceed with deletion
-		a.setMode(ModeList)
-		return a.resourceView.DeleteSelected()
+// startPermissionsView opens the overlay of what the user may do in the
+// current namespace, over every active context, checking the permissions
+// not checked yet
+func (a *App) startPermissionsView() tea.Cmd {
+	contexts := []string{""}
+	if a.isMultiContext && len(a.activeContexts) > 0 {
+		contexts = a.activeContexts
 	}
-	// Cancelled
-	a.setMode(ModeList)
-	a.pendingDeleteName = ""
-	return nil
+	a.permissionsView = views.NewPermissionsView(a.state.CurrentNamespace, contexts, a.permissions)
+	a.permissionsView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModePermissions)
+	return a.refreshPermissions(false)
 }
 
-// showContextInfo displays detailed information about a context
-func (a *App) showContextInfo(contextName string) tea.Cmd {
-	return func() tea.Msg {
+// refreshPermissions checks the permissions overlay's contexts, again when
+// recheck is set
+func (a *App) refreshPermissions(recheck bool) tea.Cmd {
+	if a.permissionsView == nil {
+		return nil
+	}
+	return a.permissionsView.LoadWithClients(a.ctx, a.overlayClients(), recheck)
+}
+
+// deleteDenied reports, and says why, when the permission cache knows the
+// user may not delete the selected resource. Permissions never checked
+// deny nothing; the server decides.
+func (a *App) deleteDenied() bool {
+	ref := a.listView().SelectedResourceRef()
+	kind := a.listState().CurrentResourceType
+	if !a.permissions.Denied(a.getSelectedResourceContext(), ref.Namespace, kind, "delete") {
+		return false
+	}
+	a.listView().ShowError(fmt.Errorf("you may not delete %s in %s (Ctrl+A shows what you can do)",
+		strings.ToLower(string(kind)), ref.Namespace))
+	return true
+}
+
+// showUsagePod closes the usage overlay and selects a pod from it in the list
+func (a *App) showUsagePod(msg views.UsagePodSelectedMsg) tea.Cmd {
+	ref := core.ResourceRef{Namespace: msg.Namespace, Name: msg.Name}
+	if a.isMultiContext {
+		ref.Context = msg.Context
+	}
+	a.usageView = nil
+	namespace := a.state.CurrentNamespace
+	if namespace != "" {
+		// Stay in all-namespaces view; otherwise follow the pod
+		namespace = msg.Namespace
+	}
+	return a.showResource(core.ResourceTypePod, namespace, ref)
+}
+
+// ShowUsageOnStart opens the usage overlay once the program starts, as
+// `kubewatch top` does
+func (a *App) ShowUsageOnStart() {
+	a.usageOnStart = true
+}
+
+// SearchFleetOnStart searches every context for pods matching query, typed
+// as "[namespace/]pattern", once the program starts
+func (a *App) SearchFleetOnStart(query string) {
+	a.fleetOnStart = query
+}
+
+// startFleetSearch opens the fleet search over every context of the
+// kubeconfig, asking for the query first when it is empty
+func (a *App) startFleetSearch(query core.FleetQuery) tea.Cmd {
+	a.fleetClients, a.fleetClientErrs = a.fleetConnections()
+	contexts := make([]string, 0, len(a.fleetClients)+len(a.fleetClientErrs))
+	for contextName := range a.fleetClients {
+		contexts = append(contexts, contextName)
+	}
+	for contextName := range a.fleetClientErrs {
+		contexts = append(contexts, contextName)
+	}
+
+	a.fleetView = views.NewFleetView(contexts, query)
+	a.fleetView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeFleet)
+	if a.fleetView.IsEditing() {
+		return nil
+	}
+	return a.searchFleet()
+}
+
+// fleetConnections returns a client for every context of the kubeconfig,
+// reusing those of the active contexts, and why any other context could not
+// get one. Without a kubeconfig to read, the active contexts are searched.
+func (a *App) fleetConnections() (map[string]*k8s.Client, map[string]error) {
+	clients := make(map[string]*k8s.Client)
+	errs := make(map[string]error)
+	contexts, _, err := k8s.GetAvailableContextsFromKubeconfig(a.config.KubeConfig)
+	if err != nil || len(contexts) == 0 {
+		return a.overlayClients(), errs
+	}
+
+	active := make(map[string]*k8s.Client)
+	for contextName, client := range a.overlayClients() {
+		if contextName == "" {
+			contextName = client.ContextName()
+		}
+		active[contextName] = client
+	}
+	for _, contextName := range contexts {
+		if client := active[contextName]; client != nil {
+			clients[contextName] = client
+			continue
+		}
+		// Building a client contacts nothing; the search does
+		client, err := k8s.NewClientForContext(a.config.KubeConfig, contextName)
+		if err != nil {
+			errs[contextName] = err
+			continue
+		}
+		clients[contextName] = client
+	}
+	return clients, errs
+}
+
+// searchFleet runs the fleet search's query in every context again
+func (a *App) searchFleet() tea.Cmd {
+	if a.fleetView == nil || a.fleetView.IsEditing() {
+		return nil
+	}
+	// The maps are only read while the search runs
+	clients, errs := a.fleetClients, a.fleetClientErrs
+	return a.fleetView.Search(a.ctx, func(contextName string) (*k8s.Client, error) {
+		if err := errs[contextName]; err != nil {
+			return nil, err
+		}
+		if client := clients[contextName]; client != nil {
+			return client, nil
+		}
+		return nil, fmt.Errorf("no client for context %s", contextName)
+	})
+}
+
+// closeFleetSearch abandons the fleet search and returns to the list
+func (a *App) closeFleetSearch() {
+	if a.fleetView != nil {
+		a.fleetView.Stop()
+		a.fleetView = nil
+	}
+	a.fleetClients, a.fleetClientErrs = nil, nil
+	a.setMode(ModeList)
+}
+
+// openFleetLogs streams the logs of the pod selected in the fleet search,
+// from its own context
+func (a *App) openFleetLogs() tea.Cmd {
+	found, ok := a.fleetView.SelectedPod()
+	if !ok || a.fleetClients[found.Context] == nil {
+		return nil
+	}
+	// The log view finds the pod in a state of its own, as the list's does
+	// not hold pods of other contexts
+	state := &core.State{CurrentResourceType: core.ResourceTypePod, Pods: []v1.Pod{found.Pod}}
+	a.setMode(ModeLog)
+	a.logView.SetPodMetrics(nil)
+	return a.logView.StartStreaming(a.ctx, a.fleetClients[found.Context], state, found.Pod.Namespace, found.Pod.Name)
+}
+
+// describeFleetPod describes the pod selected in the fleet search
+func (a *App) describeFleetPod() tea.Cmd {
+	found, ok := a.fleetView.SelectedPod()
+	if !ok {
+		return nil
+	}
+	a.describeView = views.NewDescribeView(a.ctx, a.fleetClients[found.Context], string(core.ResourceTypePod), found.Pod.Name, found.Pod.Namespace, found.Context)
+	a.describeView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeDescribe)
+	return a.describeView.Init()
+}
+
+// nodeCordon is a cordon or uncordon awaiting confirmation
+type nodeCordon struct {
+	context       string
+	node          string
+	unschedulable bool
+	client        *k8s.Client
+}
+
+// nodeCordonedMsg reports the outcome of a cordon or uncordon
+type nodeCordonedMsg struct {
+	cordon *nodeCordon
+	err    error
+}
+
+// confirmCordon asks for confirmation before cordoning the node in the
+// overlay, or uncordoning it if it is already cordoned
+func (a *App) confirmCordon() {
+	if a.nodeDetailView == nil || !a.nodeDetailView.IsLoaded() {
+		return
+	}
+	client := a.clientForContext(a.nodeDetailView.GetContext())
+	if client == nil {
+		return
+	}
+
+	a.pendingCordon = &nodeCordon{
+		context:       a.nodeDetailView.GetContext(),
+		node:          a.nodeDetailView.NodeName(),
+		unschedulable: !a.nodeDetailView.IsCordoned(),
+		client:        client,
+	}
+
+	title, action := "⚠️  Cordon Node", "Cordon"
+	message := fmt.Sprintf("Cordon node '%s'?\n\n"+
+		"No new pods will be scheduled on it. Pods already there keep running.", a.pendingCordon.node)
+	if !a.pendingCordon.unschedulable {
+		title, action = "Uncordon Node", "Uncordon"
+		message = fmt.Sprintf("Uncordon node '%s'?\n\nNew pods may be scheduled on it again.", a.pendingCordon.node)
+	}
+	a.confirmView = views.NewConfirmView(title, message)
+	a.confirmView.SetSize(a.width, a.viewHeight())
+	a.confirmView.SetConfirmText(action)
+	a.confirmView.SetCancelText("Cancel")
+	a.setMode(ModeConfirmDialog)
+}
+
+// cordonNode applies a confirmed cordon or uncordon
+func (a *App) cordonNode(cordon *nodeCordon) tea.Cmd {
+	ctx := a.ctx
+	return func() tea.Msg {
+		err := cordon.client.PatchNodeUnschedulable(ctx, cordon.node, cordon.unschedulable)
+		return nodeCordonedMsg{cordon: cordon, err: err}
+	}
+}
+
+// nodeCordoned records a cordon or uncordon and shows its outcome
+func (a *App) nodeCordoned(msg nodeCordonedMsg) tea.Cmd {
+	action := "cordon"
+	if !msg.cordon.unschedulable {
+		action = "uncordon"
+	}
+	a.actionLog.Record(core.ActionLogEntry{
+		Action:   action,
+		Context:  msg.cordon.context,
+		Resource: "Nodes",
+		Name:     msg.cordon.node,
+		Err:      msg.err,
+	})
+
+	if a.nodeDetailView == nil || a.nodeDetailView.NodeName() != msg.cordon.node {
+		return nil
+	}
+	if msg.err != nil {
+		a.nodeDetailView.SetStatus("✗ " + k8s.UserMessage(msg.err))
+		return nil
+	}
+	if msg.cordon.unschedulable {
+		a.nodeDetailView.SetStatus("Cordoned " + msg.cordon.node)
+	} else {
+		a.nodeDetailView.SetStatus("Uncordoned " + msg.cordon.node)
+	}
+	return a.nodeDetailView.LoadNodeWithClient(a.ctx, msg.cordon.client)
+}
+
+// startSettingsView opens the runtime settings overlay
+func (a *App) startSettingsView() {
+	a.settingsView = views.NewSettingsView(a.config)
+	a.settingsView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeSettings)
+}
+
+// SetSettingsSaver sets the function used to persist runtime settings
+func (a *App) SetSettingsSaver(saver func(values map[string]string) error) {
+	a.settingsSaver = saver
+}
+
+// applyRuntimeSettings pushes the runtime-tunable config values into the views.
+// The refresh interval and coalescing window are read from the config directly.
+func (a *App) applyRuntimeSettings() {
+	a.resourceView.SetMaxResources(a.config.MaxResourcesShown)
+	a.resourceView.SetRefreshInterval(a.listRefreshInterval())
+	a.resourceView.SetStaleAfter(a.config.StaleAfterIntervals)
+	a.resourceView.SetMetricsInterval(time.Duration(a.config.MetricsInterval) * time.Second)
+	a.resourceView.SetClockSkewCorrection(a.config.CorrectClockSkew)
+	a.resourceView.SetHistoryRetention(time.Duration(a.config.HistoryMinutes) * time.Minute)
+	a.resourceView.SetLogRateInterval(time.Duration(a.config.LogRateInterval) * time.Second)
+	a.resourceView.SetChurnGrace(time.Duration(a.config.ChurnGraceMs) * time.Millisecond)
+	a.resourceView.SetHideNoise(a.config.HideNoise)
+	a.resourceView.SetAccessibility(a.config.NoColor, a.config.Accessible)
+	a.resourceView.SetUpdateNotice(a.updateNotice())
+	a.resourceView.SetKubeconfigNotice(a.kubeconfigNotice())
+	a.logView.SetTailLines(a.config.LogTailLines)
+	a.applyColors()
+	// The key hint bar may have been turned on or off
+	a.resize()
+}
+
+// applyColors drops colors and text attributes in the accessible mode, as
+// lipgloss does by itself when NO_COLOR is set, and the colors written into
+// log lines with them
+func (a *App) applyColors() {
+	if a.config.Accessible != a.colorsDropped {
+		if a.config.Accessible {
+			a.colorProfile = lipgloss.ColorProfile()
+			lipgloss.SetColorProfile(termenv.Ascii)
+		} else {
+			lipgloss.SetColorProfile(a.colorProfile)
+		}
+		a.colorsDropped = a.config.Accessible
+	}
+	if a.config.NoColor || a.config.Accessible {
+		a.logView.SetColors(false)
+	}
+}
+
+// saveRuntimeSettings persists the current runtime settings and reports the result
+func (a *App) saveRuntimeSettings() {
+	if a.settingsView == nil {
+		return
+	}
+	if a.settingsSaver == nil {
+		a.settingsView.SetStatus("No config file available; settings apply to this session only", false)
+		return
+	}
+	if err := a.settingsSaver(core.SettingValues(a.config)); err != nil {
+		a.settingsView.SetStatus(fmt.Sprintf("Failed to save settings: %v", err), false)
+		return
+	}
+	a.settingsView.SetStatus("Settings saved", true)
+}
+
+// startFilterBar opens the filter bar below the list, starting from the current filter
+func (a *App) startFilterBar() {
+	expression, _ := a.listState().GetFilter()
+	a.filterBar = views.NewFilterBar(expression)
+	// Entering the mode lays the list out above the bar
+	a.setMode(ModeFilter)
+}
+
+// scheduleFilterPreview filters the list by the filter bar's expression a
+// frame from now, unless that is already due, so keys typed within a frame
+// filter it once
+func (a *App) scheduleFilterPreview() tea.Cmd {
+	if a.filterPreviewPending {
+		return nil
+	}
+	a.filterPreviewPending = true
+	return tea.Tick(filterPreviewDelay, func(time.Time) tea.Msg { return filterPreviewMsg{} })
+}
+
+// previewFilter filters the list by the filter bar's expression as typed so
+// far. An expression that does not parse yet, or names a column the list
+// lacks, leaves the last preview showing.
+func (a *App) previewFilter() {
+	if a.filterBar == nil {
+		return
+	}
+	filter, err := core.ParseFilter(a.filterBar.Expression())
+	if err != nil {
+		return
+	}
+	state := a.listState()
+	if a.checkFilterColumns(filter, "", state.CurrentResourceType, state.CurrentNamespace) != nil {
+		return
+	}
+	a.listView().PreviewFilter(filter)
+}
+
+// closeFilterBar returns to the list and gives it back the filter bar's line
+func (a *App) closeFilterBar() {
+	a.filterBar = nil
+	a.listView().EndFilterPreview()
+	switch {
+	case a.comparisonView != nil:
+		a.comparisonView.SetSize(a.width, a.viewHeight())
+	case a.splitView != nil:
+		a.splitView.SetSize(a.width, a.viewHeight())
+	default:
+		a.resourceView.SetSize(a.width, a.viewHeight())
+	}
+	a.returnToList()
+}
+
+// checkFilterColumns returns an error when the filter or sort refers to a
+// column the list does not show for resourceType in namespace
+func (a *App) checkFilterColumns(filter *core.Filter, sortColumn string, resourceType core.ResourceType, namespace string) error {
+	columns := a.resourceView.Columns(resourceType, namespace)
+	unknown := filter.UnknownColumns(columns)
+	if sortColumn != "" {
+		found := false
+		for _, column := range columns {
+			if strings.EqualFold(column, sortColumn) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, strings.ToUpper(sortColumn))
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%s has no column %s", resourceType, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// applyFilter applies an ad-hoc filter expression from the filter bar to the
+// current resource type or, when global, to every type with its columns
+func (a *App) applyFilter(expression string, global bool) tea.Cmd {
+	state := a.listState()
+	filter, err := core.ParseFilter(expression)
+	if err == nil {
+		err = a.checkFilterColumns(filter, "", state.CurrentResourceType, state.CurrentNamespace)
+	}
+	if err != nil {
+		if a.filterBar != nil {
+			a.filterBar.SetStatus(err.Error(), false)
+		}
+		return nil
+	}
+
+	if global {
+		state.SetGlobalFilter(expression)
+	} else {
+		state.SetFilter(expression, "")
+	}
+	a.closeFilterBar()
+	return a.listView().RefreshResources()
+}
+
+// clearFilter clears the filter in effect for the list, the scope to a
+// workload's pods first. When that leaves other filters, of other types or
+// the type's own under a global filter, the next Esc clears them all;
+// offered is whether this is that Esc. It returns false when there was
+// nothing to clear.
+func (a *App) clearFilter(offered bool) (bool, tea.Cmd) {
+	state := a.state
+	if scope := a.resourceView.PodScope(); scope != nil {
+		a.resourceView.SetPodScope(nil)
+		a.resourceView.ShowNotice(fmt.Sprintf("Listing every pod, not only the pods of %s", scope.Name))
+		return true, a.resourceView.RefreshResources()
+	}
+	if expression, _ := state.GetFilter(); expression == "" {
+		if !offered {
+			return false, nil
+		}
+		state.ClearAllFilters()
+		a.resourceView.ShowNotice("Filters cleared for every resource type")
+		return true, a.resourceView.RefreshResources()
+	}
+
+	cleared := fmt.Sprintf("Filter cleared for %s", state.CurrentResourceType)
+	if state.IsFilterGlobal() {
+		cleared = "Filter cleared for all types"
+	}
+	state.ClearFilter()
+
+	if remaining := state.FilteredTypes(); len(remaining) > 0 {
+		names := make([]string, len(remaining))
+		for i, t := range remaining {
+			names[i] = string(t)
+		}
+		a.clearFiltersOffered = true
+		a.resourceView.ShowNotice(fmt.Sprintf("%s; Esc again clears the filters of %s too", cleared, strings.Join(names, ", ")))
+	} else {
+		a.resourceView.ShowNotice(cleared)
+	}
+	return true, a.resourceView.RefreshResources()
+}
+
+// saveFilter saves the filter bar's expression, with the current resource
+// type, namespace and sort, as a named filter and applies it
+func (a *App) saveFilter(name, expression string) tea.Cmd {
+	filter, err := core.ParseFilter(expression)
+	if err == nil {
+		err = a.checkFilterColumns(filter, "", a.state.CurrentResourceType, a.state.CurrentNamespace)
+	}
+	if err == nil && a.filterSaver == nil {
+		err = fmt.Errorf("no config file available to save filters to")
+	}
+
+	saved := &config.SavedFilter{
+		Name:         name,
+		ResourceType: string(a.state.CurrentResourceType),
+		Expression:   expression,
+	}
+	if namespace := a.state.CurrentNamespace; namespace != "" && namespace != "all" {
+		saved.Namespace = namespace
+	}
+	sortColumn, sortAscending := a.state.GetSortState()
+	if sortColumn != "" {
+		saved.Sort = &config.SortConfig{Column: sortColumn, Ascending: sortAscending}
+	}
+
+	if err == nil {
+		err = a.filterSaver(saved)
+	}
+	if err != nil {
+		if a.filterBar != nil {
+			a.filterBar.SetStatus(fmt.Sprintf("Failed to save filter: %v", err), false)
+		}
+		return nil
+	}
+
+	a.addSavedFilter(saved)
+	a.listState().SetFilter(expression, name)
+	a.closeFilterBar()
+	return a.listView().RefreshResources()
+}
+
+// addSavedFilter adds filter to the picker list, replacing one of the same name
+func (a *App) addSavedFilter(filter *config.SavedFilter) {
+	for i, existing := range a.savedFilters {
+		if existing.Name == filter.Name {
+			a.savedFilters[i] = filter
+			return
+		}
+	}
+	a.savedFilters = append(a.savedFilters, filter)
+}
+
+// SetSavedFilters sets the saved filters offered by the saved filters picker
+func (a *App) SetSavedFilters(filters []*config.SavedFilter) {
+	a.savedFilters = filters
+}
+
+// SetLogRecordGrouping configures multi-line record grouping in the log view
+func (a *App) SetLogRecordGrouping(recordStart string, enabled bool) error {
+	return a.logView.SetRecordGrouping(recordStart, enabled)
+}
+
+// SetLogStreamFilter sets the regular expression log lines must match to be
+// kept as they are read, such as a request ID
+func (a *App) SetLogStreamFilter(pattern string) error {
+	return a.logView.SetStreamFilter(pattern)
+}
+
+// SetLogColors sets whether the log view shows the colors in log lines
+func (a *App) SetLogColors(enabled bool) {
+	a.logView.SetColors(enabled && !a.config.NoColor && !a.config.Accessible)
+}
+
+// SetQuitKeyBehavior sets whether q closes views other than the list, like
+// Esc, or quits from them as Ctrl+C does
+func (a *App) SetQuitKeyBehavior(behavior core.QuitKeyBehavior) {
+	a.quitKeyBehavior = behavior
+}
+
+// quitKeyQuits reports whether a key that closes the current view should
+// quit instead: q does when the quit key behavior is global
+func (a *App) quitKeyQuits(msg tea.KeyMsg) bool {
+	return a.quitKeyBehavior == core.QuitKeyGlobal && msg.String() == "q"
+}
+
+// SetCacheTTL sets how long the list of a resource type no longer viewed is
+// kept in memory
+func (a *App) SetCacheTTL(ttl time.Duration) {
+	if a.state.Caches != nil {
+		a.state.Caches.SetTTL(ttl)
+	}
+}
+
+// SetNoiseRules sets which resources the noise toggle hides
+func (a *App) SetNoiseRules(rules core.NoiseRules) {
+	a.noiseRules = rules
+	a.resourceView.SetNoiseRules(rules)
+}
+
+// SetPodMetricsHeadline sets what a pod's CPU and MEMORY cells show when its
+// metrics break usage down by container
+func (a *App) SetPodMetricsHeadline(mode k8s.MetricsHeadline, sidecars []string) {
+	a.resourceView.SetMetricsHeadline(mode, sidecars)
+}
+
+// SetFilterSaver sets the function used to persist filters saved from the filter bar
+func (a *App) SetFilterSaver(saver func(filter *config.SavedFilter) error) {
+	a.filterSaver = saver
+}
+
+// startSavedFiltersView opens the saved filters picker with the filters
+// scoped to the active contexts
+func (a *App) startSavedFiltersView() {
+	var filters []*config.SavedFilter
+	for _, f := range a.savedFilters {
+		if a.savedFilterInScope(f) {
+			filters = append(filters, f)
+		}
+	}
+
+	_, active := a.state.GetFilter()
+	a.savedFiltersView = views.NewSavedFiltersView(filters, active)
+	a.savedFiltersView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeSavedFilters)
+}
+
+// savedFilterInScope reports whether a saved filter applies to the active contexts
+func (a *App) savedFilterInScope(filter *config.SavedFilter) bool {
+	if filter.Context == "" {
+		return true
+	}
+	contexts := a.activeContexts
+	if len(contexts) == 0 && a.state.CurrentContext != "" {
+		contexts = []string{a.state.CurrentContext}
+	}
+	if len(contexts) == 0 {
+		// Nothing to scope against, so do not hide anything
+		return true
+	}
+	for _, ctx := range contexts {
+		if ctx == filter.Context {
+			return true
+		}
+	}
+	return false
+}
+
+// applySavedFilter switches to the saved filter's resource type, namespace
+// and sort and sets its filter. Nothing changes if the filter refers to a
+// column the list does not have, e.g. one added in a newer version.
+func (a *App) applySavedFilter(saved *config.SavedFilter) error {
+	resourceType, ok := core.ParseResourceType(saved.ResourceType)
+	if !ok {
+		return fmt.Errorf("saved filter %q: unknown resource type %q", saved.Name, saved.ResourceType)
+	}
+	filter, err := core.ParseFilter(saved.Expression)
+	if err != nil {
+		return fmt.Errorf("saved filter %q: %v", saved.Name, err)
+	}
+
+	namespace := a.state.CurrentNamespace
+	if saved.Namespace != "" {
+		namespace = saved.Namespace
+	}
+	sortColumn := ""
+	if saved.Sort != nil {
+		sortColumn = saved.Sort.Column
+	}
+	if err := a.checkFilterColumns(filter, sortColumn, resourceType, namespace); err != nil {
+		return fmt.Errorf("saved filter %q: %v", saved.Name, err)
+	}
+
+	if resourceType != a.state.CurrentResourceType {
+		a.state.SetResourceType(resourceType)
+	}
+	if namespace != a.state.CurrentNamespace {
+		a.clearNamespaceBanner()
+		a.state.SetNamespace(namespace)
+		a.config.CurrentNamespace = namespace
+	}
+	if sortColumn != "" {
+		a.state.SetSortState(strings.ToUpper(sortColumn), saved.Sort.Ascending)
+	}
+	a.state.SetFilter(saved.Expression, saved.Name)
+	return nil
+}
+
+// nodeInfoCache returns the node label cache for a context, creating it on first use
+func (a *App) nodeInfoCache(context string, client *k8s.Client) *k8s.NodeInfoCache {
+	if a.nodeCaches == nil {
+		a.nodeCaches = make(map[string]*k8s.NodeInfoCache)
+	}
+	cache, exists := a.nodeCaches[context]
+	if !exists {
+		cache = k8s.NewNodeInfoCache(client, k8s.DefaultNodeInfoTTL)
+		a.nodeCaches[context] = cache
+	}
+	return cache
+}
+
+// applyContextSelection applies the selected contexts
+func (a *App) applyContextSelection() tea.Cmd {
+	if a.contextView == nil {
+		return nil
+	}
+	newContexts := a.contextView.GetSelectedContexts()
+	if len(newContexts) > 0 {
+		// Show loading indicators for selected contexts
+		for _, ctx := range newContexts {
+			a.contextView.SetContextLoading(ctx, true)
+		}
+
+		a.clearNamespaceBanner()
+		a.activeContexts = newContexts
+		a.state.SetCurrentContexts(newContexts)
+
+		// Always use multi-context mode regardless of number of contexts
+		multiClient, err := k8s.NewMultiContextClient(newContexts)
+		if err == nil {
+			a.multiClient = multiClient
+			a.k8sClient = nil
+			a.isMultiContext = true
+			// Update resource view with multi-client
+			a.resourceView = views.NewResourceViewWithMultiContext(a.state, multiClient)
+			a.resourceView.SetSize(a.width, a.viewHeight())
+			a.resourceView.SetNoiseRules(a.noiseRules)
+			a.applyRuntimeSettings()
+		}
+
+		// Clear loading indicators
+		for _, ctx := range newContexts {
+			a.contextView.SetContextLoading(ctx, false)
+		}
+
+		// Refresh resources with new contexts, which may serve other
+		// optional types
+		a.setMode(ModeList)
+		return tea.Batch(a.resourceView.RefreshResources(), a.detectOptionalTypes())
+	}
+	a.setMode(ModeList)
+	return nil
+}
+
+// applyNamespaceSelection applies the selected namespace
+func (a *App) applyNamespaceSelection() tea.Cmd {
+	newNamespace := a.namespaceView.GetSelectedNamespace()
+	if newNamespace != a.state.CurrentNamespace {
+		a.clearNamespaceBanner()
+		a.state.SetNamespace(newNamespace)
+		a.config.CurrentNamespace = newNamespace
+		// Refresh resources with new namespace
+		a.setMode(ModeList)
+		return a.resourceView.RefreshResources()
+	}
+	a.setMode(ModeList)
+	return nil
+}
+
+// handleConfirmDialogAction handles the confirm dialog action
+func (a *App) handleConfirmDialogAction() tea.Cmd {
+	if a.pendingCleanup != nil {
+		cleanup := a.pendingCleanup
+		a.pendingCleanup = nil
+		a.setMode(ModeTemplates)
+		if !a.confirmView.IsConfirmed() {
+			return nil
+		}
+		return a.deleteCreatedResources(cleanup)
+	}
+
+	if a.pendingFinalizer != nil {
+		removal := a.pendingFinalizer
+		a.pendingFinalizer = nil
+		a.setMode(ModeDescribe)
+		if !a.confirmView.IsConfirmed() {
+			return nil
+		}
+		return a.removeFinalizer(removal)
+	}
+
+	if a.pendingCordon != nil {
+		cordon := a.pendingCordon
+		a.pendingCordon = nil
+		a.setMode(ModeNodeDetail)
+		if !a.confirmView.IsConfirmed() {
+			return nil
+		}
+		return a.cordonNode(cordon)
+	}
+
+	if a.pendingRollout != nil {
+		change := a.pendingRollout
+		a.pendingRollout = nil
+		a.returnToList()
+		if !a.confirmView.IsConfirmed() {
+			return nil
+		}
+		return a.changeRollout(change)
+	}
+
+	deletion := a.pendingDelete
+	a.pendingDelete = nil
+	a.returnToList()
+	if deletion == nil || !a.confirmView.IsConfirmed() {
+		return nil
+	}
+	return a.deleteResource(deletion)
+}
+
+// SetUserActions sets the user-defined actions from the config file. Actions
+// whose key kubewatch already uses are only offered in the quick-action menu;
+// a warning is returned for each.
+func (a *App) SetUserActions(actions []*config.UserAction) []string {
+	reserved := make(map[string]bool)
+	for _, binding := range a.modes[ModeList].GetKeyBindings() {
+		for _, k := range binding.Key.Keys() {
+			reserved[k] = true
+		}
+	}
+	// Keys the list passes on to the resource view
+	for _, k := range []string{"u", "home", "end", "pgup", "pgdown"} {
+		reserved[k] = true
+	}
+
+	var warnings []string
+	a.userActions = actions
+	a.userActionKeys = make(map[string]*config.UserAction)
+	for _, action := range actions {
+		if action.Key == "" {
+			continue
+		}
+		if reserved[action.Key] {
+			warnings = append(warnings, fmt.Sprintf("action %q: key %q is used by kubewatch; the action is only in the quick-action menu (!)", action.Name, action.Key))
+			continue
+		}
+		a.userActionKeys[action.Key] = action
+	}
+	return warnings
+}
+
+// userActionForKey returns the user action bound to key for the current
+// resource type, if any
+func (a *App) userActionForKey(key string) *config.UserAction {
+	action, exists := a.userActionKeys[key]
+	if !exists || !action.AppliesTo(a.state.CurrentResourceType) {
+		return nil
+	}
+	return action
+}
+
+// startLogs opens the logs of the selected resource, first asking which
+// container when the selected pod has more than one
+func (a *App) startLogs() tea.Cmd {
+	if pod, context, ok := a.selectedPod(); ok {
+		if containers := core.PodContainers(pod); len(containers) > 1 {
+			a.logPicker = views.NewLogPickerView(context, pod.Namespace, pod.Name, containers)
+			a.logPicker.SetSize(a.width, a.viewHeight())
+			a.setMode(ModeLogPicker)
+			return nil
+		}
+	}
+	return a.openLogs("")
+}
+
+// openLogs streams the logs of the selected resource, as core.LogTargets
+// says for its type, only container's when one is named; types without
+// logs get a hint instead
+func (a *App) openLogs(container string) tea.Cmd {
+	target := core.LogTargetFor(a.state.CurrentResourceType)
+	if !target.Applies() {
+		a.resourceView.ShowNotice(target.Hint)
+		return nil
+	}
+
+	selected := a.resourceView.SelectedResourceRef()
+	if selected.IsZero() {
+		return nil
+	}
+	// Get the appropriate client for logs
+	var client *k8s.Client
+	if a.isMultiContext && a.multiClient != nil {
+		if contextName := a.getSelectedResourceContext(); contextName != "" {
+			client, _ = a.multiClient.GetClient(contextName)
+		}
+	} else {
+		client = a.k8sClient
+	}
+	// Only proceed if we have a valid client
+	if client == nil {
+		return nil
+	}
+
+	a.setMode(ModeLog)
+	a.resourceView.SetCompactMode(true)
+	a.logView.SetPodMetrics(a.resourceView.SelectedPodMetrics())
+	a.logView.SetContainer(container)
+	return a.logView.StartStreaming(a.ctx, client, a.state, selected.Namespace, selected.Name)
+}
+
+// updateLogs passes a message to the log view, or to both panes when split
+func (a *App) updateLogs(msg tea.Msg) tea.Cmd {
+	if a.logSplit != nil {
+		_, cmd := a.logSplit.Update(msg)
+		return cmd
+	}
+	logModel, cmd := a.logView.Update(msg)
+	a.logView = logModel.(*views.LogView)
+	return cmd
+}
+
+// focusedLogView returns the log view keys go to, the focused pane when split
+func (a *App) focusedLogView() *views.LogView {
+	if a.logSplit != nil && a.logSplit.Focused() != nil {
+		return a.logSplit.Focused()
+	}
+	return a.logView
+}
+
+// openLogSplit splits the log view, asking for a pod to show the logs of
+// beside the one shown, among the pods of the same workload
+func (a *App) openLogSplit() {
+	namespace, name := a.logView.Resource()
+	if a.state.CurrentResourceType != core.ResourceTypePod || name == "" {
+		a.resourceView.ShowNotice("Split logs compare two pods; open the logs of a pod first")
+		return
+	}
+	a.logSplit = views.NewLogSplitView(a.ctx, a.logView, a.siblingPods(namespace, name))
+}
+
+// siblingPods returns the pods listed of the same workload as the pod name,
+// the old and new ones of a rollout alike, or of its namespace when no
+// workload owns it; the pod itself is left out
+func (a *App) siblingPods(namespace, name string) []v1.Pod {
+	var pod *v1.Pod
+	for i := range a.state.Pods {
+		if a.state.Pods[i].Name == name && (namespace == "" || a.state.Pods[i].Namespace == namespace) {
+			pod = &a.state.Pods[i]
+			break
+		}
+	}
+	if pod == nil {
+		return nil
+	}
+	kind, workload := core.PodWorkload(pod)
+	var siblings []v1.Pod
+	for _, other := range a.state.Pods {
+		if other.Namespace != pod.Namespace || other.UID == pod.UID {
+			continue
+		}
+		if otherKind, otherWorkload := core.PodWorkload(&other); workload == "" || (otherKind == kind && otherWorkload == workload) {
+			siblings = append(siblings, other)
+		}
+	}
+	return siblings
+}
+
+// closeLogPane closes the focused log pane, or the pod picker of the second,
+// leaving the other as the log view
+func (a *App) closeLogPane() tea.Cmd {
+	open, closed := a.logSplit.CloseFocused()
+	a.logSplit = nil
+	a.logView = open
+	if closed == nil {
+		return nil
+	}
+	return closed.StopStreaming()
+}
+
+// openActionMenu opens the quick-action menu for the selected resource
+func (a *App) openActionMenu() {
+	var actions []*config.UserAction
+	for _, action := range a.userActions {
+		if action.AppliesTo(a.state.CurrentResourceType) {
+			actions = append(actions, action)
+		}
+	}
+
+	target := fmt.Sprintf("%s %s/%s", a.state.CurrentResourceType,
+		a.resourceView.GetSelectedResourceNamespace(), a.resourceView.GetSelectedResourceName())
+	a.actionMenuView = views.NewActionMenuView(actions, target)
+	a.actionMenuView.SetLogs(core.LogTargetFor(a.state.CurrentResourceType))
+	if deployment, _, ok := a.selectedDeployment(); ok {
+		a.actionMenuView.SetRollout(deployment.Spec.Paused)
+	}
+	a.actionMenuView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeActionMenu)
+}
+
+// runUserAction runs a user-defined action against the selected resource.
+// Interactive actions suspend the UI and get the terminal; others run in the
+// background and their output is shown when they finish.
+func (a *App) runUserAction(action *config.UserAction) tea.Cmd {
+	if !action.AppliesTo(a.state.CurrentResourceType) {
+		a.resourceView.ShowError(fmt.Errorf("action %q only applies to %s", action.Name, action.ResourceType))
+		return nil
+	}
+
+	name := a.resourceView.GetSelectedResourceName()
+	if name == "" {
+		a.resourceView.ShowError(fmt.Errorf("action %q needs a selected resource", action.Name))
+		return nil
+	}
+	if k8s.NetworkRestricted() {
+		// The command could reach anything; kubewatch cannot vouch for it
+		a.resourceView.ShowError(fmt.Errorf("action %q runs an external command, which --no-external-network does not allow", action.Name))
+		return nil
+	}
+
+	target := core.CommandTarget{
+		Name:      name,
+		Namespace: a.resourceView.GetSelectedResourceNamespace(),
+		Context:   a.getSelectedResourceContext(),
+		Node:      a.resourceView.GetSelectedResourceColumn("NODE"),
+	}
+	if target.Context == "" {
+		target.Context = a.state.CurrentContext
+	}
+	if target.Node == "-" {
+		target.Node = ""
+	}
+
+	tmpl, err := action.Template()
+	if err != nil {
+		a.resourceView.ShowError(fmt.Errorf("action %q: %w", action.Name, err))
+		return nil
+	}
+	command, err := tmpl.Render(target)
+	if err != nil {
+		a.resourceView.ShowError(fmt.Errorf("action %q: %w", action.Name, err))
+		return nil
+	}
+
+	if action.IsInteractive() {
+		return tea.ExecProcess(exec.Command("sh", "-c", command), func(err error) tea.Msg {
+			return userActionDoneMsg{name: action.Name, err: err}
+		})
+	}
+
+	a.resourceView.ShowNotice(fmt.Sprintf("Running action %q...", action.Name))
+	ctx := a.ctx
+	return func() tea.Msg {
+		output, err := exec.CommandContext(ctx, "sh", "-c", command).CombinedOutput()
+		return userActionOutputMsg{name: action.Name, command: command, output: string(output), err: err}
+	}
+}
+
+// showActionOutput shows a background action's output once it finishes,
+// unless the user has moved on to another view
+func (a *App) showActionOutput(msg userActionOutputMsg) {
+	if msg.err != nil {
+		a.resourceView.ShowError(fmt.Errorf("action %q failed: %w", msg.name, msg.err))
+	} else {
+		a.resourceView.ShowNotice(fmt.Sprintf("✓ Action %q finished", msg.name))
+	}
+	if a.currentMode != ModeList {
+		return
+	}
+
+	a.actionOutputView = views.NewActionOutputView(msg.name, msg.command, msg.output, msg.err)
+	a.actionOutputView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeActionOutput)
+}
+
+// navEntry is a place in the list to return to after jumping to a related
+// resource
+type navEntry struct {
+	resourceType core.ResourceType
+	namespace    string
+	resource     core.ResourceRef
+}
+
+// openRelations shows the relationships panel for the selected resource,
+// resolved from the objects cached by earlier refreshes
+func (a *App) openRelations() {
+	name := a.resourceView.GetSelectedResourceName()
+	if name == "" {
+		return
+	}
+	namespace := a.resourceView.GetSelectedResourceNamespace()
+	resourceType := a.state.CurrentResourceType
+
+	related, err := core.ResolveRelations(a.state.ObjectSnapshot(), resourceType, namespace, name)
+	a.relationsView = views.NewRelationsView(string(resourceType), name, namespace, related, err)
+	a.relationsView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeRelations)
+}
+
+// jumpToRelated shows a related resource in its list, remembering where the
+// jump came from so Backspace can return
+func (a *App) jumpToRelated(r core.RelatedResource) tea.Cmd {
+	a.navStack = append(a.navStack, navEntry{
+		resourceType: a.state.CurrentResourceType,
+		namespace:    a.state.CurrentNamespace,
+		resource:     a.resourceView.SelectedResourceRef(),
+	})
+	a.relationsView = nil
+
+	namespace := a.state.CurrentNamespace
+	if namespace != "" && !core.IsClusterScoped(r.Type) {
+		// Stay in all-namespaces view, or for a cluster-scoped resource in
+		// the namespace shown; otherwise follow the resource
+		namespace = r.Namespace
+	}
+	return a.showResource(r.Type, namespace, core.ResourceRef{Namespace: r.Namespace, Name: r.Name})
+}
+
+// showWorkloadPods lists the pods of the selected deployment or
+// statefulset, scoped to those its selector matches, in its context when
+// several are shown. Backspace returns to the workload.
+func (a *App) showWorkloadPods() tea.Cmd {
+	resourceType := a.state.CurrentResourceType
+	if resourceType != core.ResourceTypeDeployment && resourceType != core.ResourceTypeStatefulSet {
+		a.resourceView.ShowNotice("Select a deployment or statefulset to list its pods")
+		return nil
+	}
+	name := a.resourceView.GetSelectedResourceName()
+	if name == "" {
+		return nil
+	}
+	namespace := a.resourceView.GetSelectedResourceColumn("NAMESPACE")
+	if namespace == "" {
+		namespace = a.resourceView.GetSelectedResourceNamespace()
+	}
+	context := a.getSelectedResourceContext()
+
+	var scope *core.PodScope
+	if resourceType == core.ResourceTypeDeployment {
+		if deployment, ok := a.state.FindDeployment(context, namespace, name); ok {
+			scope = core.WorkloadPodScope("Deployment", deployment.Namespace, deployment.Name, deployment.Spec.Selector)
+		}
+	} else if statefulSet, ok := a.state.FindStatefulSet(context, namespace, name); ok {
+		scope = core.WorkloadPodScope("StatefulSet", statefulSet.Namespace, statefulSet.Name, statefulSet.Spec.Selector)
+	}
+	if scope == nil {
+		a.resourceView.ShowNotice(fmt.Sprintf("%s is not listed yet; refresh and try again", name))
+		return nil
+	}
+	if a.isMultiContext {
+		scope.Context = context
+	}
+
+	a.navStack = append(a.navStack, navEntry{
+		resourceType: resourceType,
+		namespace:    a.state.CurrentNamespace,
+		resource:     a.resourceView.SelectedResourceRef(),
+	})
+	listNamespace := a.state.CurrentNamespace
+	if listNamespace != "" {
+		listNamespace = scope.Namespace
+	}
+	cmd := a.showResource(core.ResourceTypePod, listNamespace, core.ResourceRef{})
+	// Set after the refresh is asked for, which drops a scope it finds
+	// left over from an earlier list
+	a.resourceView.SetPodScope(scope)
+	return cmd
+}
+
+// navigateBack returns to the resource a jump came from
+func (a *App) navigateBack() tea.Cmd {
+	if len(a.navStack) == 0 {
+		return nil
+	}
+	entry := a.navStack[len(a.navStack)-1]
+	a.navStack = a.navStack[:len(a.navStack)-1]
+	return a.showResource(entry.resourceType, entry.namespace, entry.resource)
+}
+
+// showResource lists resourceType in namespace and selects the resource ref
+// refers to once loaded
+func (a *App) showResource(resourceType core.ResourceType, namespace string, ref core.ResourceRef) tea.Cmd {
+	if namespace != a.state.CurrentNamespace {
+		a.clearNamespaceBanner()
+		a.state.SetNamespace(namespace)
+		a.config.CurrentNamespace = namespace
+	}
+	if resourceType != a.state.CurrentResourceType {
+		a.state.SetResourceType(resourceType)
+	}
+	a.resourceView.SelectAfterRefresh(ref)
+	a.setMode(ModeList)
+	return a.resourceView.RefreshResources()
+}
+
+// openResourcePicker asks which of several same-named resources a selection
+// meant, rather than acting on the first. It only interrupts the list.
+func (a *App) openResourcePicker(msg views.AmbiguousSelectionMsg) {
+	if a.currentMode != ModeList {
+		return
+	}
+	a.resourcePickerView = views.NewResourcePickerView(msg.Ref, msg.Matches)
+	a.resourcePickerView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModePickResource)
+}
+
+// closeResourcePicker returns from the picker to the list
+func (a *App) closeResourcePicker() {
+	a.resourcePickerView = nil
+	a.setMode(ModeList)
+}
+
+// clipboardOutput is where copied text is written as an OSC 52 sequence. It
+// is stderr so the sequence cannot land in the middle of a frame.
+var clipboardOutput io.Writer = os.Stderr
+
+// SelectOnStart selects the resource named by "namespace/name" or a bare
+// name once the first refresh lists it
+func (a *App) SelectOnStart(name string) {
+	a.resourceView.SelectAfterRefresh(core.ParseResourceRef(name))
+}
+
+// viewLink returns the link for what the list shows, selection included
+func (a *App) viewLink() core.ViewLink {
+	link := a.state.ViewLink()
+	if len(link.Contexts) == 0 && !a.isMultiContext && a.k8sClient != nil {
+		if name := a.k8sClient.ContextName(); name != "" {
+			link.Contexts = []string{name}
+		}
+	}
+	// A bare name may be listed in several namespaces
+	selected := a.resourceView.SelectedResourceRef()
+	if link.Namespace == "" {
+		link.Selected = selected.String()
+	} else {
+		link.Selected = selected.Name
+	}
+	return link
+}
+
+// toggleSecurityColumn shows or hides the SECURITY column of pods and
+// deployments and rebuilds the list
+func (a *App) toggleSecurityColumn() tea.Cmd {
+	if a.resourceView.ToggleSecurityColumn() {
+		a.resourceView.ShowNotice("Security column on: PRIV, HOSTNET, HOSTPID, ROOT and NOSECCTX flag Pod Security violations; describe (d) explains them")
+	} else {
+		a.resourceView.ShowNotice("Security column off")
+	}
+	return a.resourceView.RefreshResources()
+}
+
+// toggleNoise hides or shows completed pods and the other resources the
+// noise rules match. The choice lasts the session, and the settings overlay
+// can save it as the default.
+func (a *App) toggleNoise() tea.Cmd {
+	a.config.HideNoise = !a.config.HideNoise
+	a.resourceView.SetHideNoise(a.config.HideNoise)
+	if a.config.HideNoise {
+		a.resourceView.ShowNotice("Hiding noise: completed pods and whatever else the noise rules match (z shows it again)")
+	} else {
+		a.resourceView.ShowNotice("Showing noise")
+	}
+	return a.resourceView.RefreshResources()
+}
+
+// toggleActivityFeed shows or hides the feed of READY and STATUS
+// transitions under the list
+func (a *App) toggleActivityFeed() {
+	if a.resourceView.ToggleActivityFeed() {
+		a.resourceView.ShowNotice("Activity feed on: READY and STATUS changes show under the list from the next refresh (A picks one to jump to)")
+	} else {
+		a.resourceView.ShowNotice("Activity feed off")
+	}
+}
+
+// startActivitySelection picks among the activity feed's entries, the
+// newest first
+func (a *App) startActivitySelection() {
+	if !a.resourceView.ActivityFeedShown() {
+		a.resourceView.ShowNotice("Turn the activity feed on with a first")
+		return
+	}
+	if !a.resourceView.StartActivitySelection() {
+		a.resourceView.ShowNotice("No activity to jump to yet")
+		return
+	}
+	a.setMode(ModeActivity)
+}
+
+// jumpToActivity selects the resource of the picked activity entry in the
+// list; a burst's entry selects the first of its resources
+func (a *App) jumpToActivity() {
+	entry, ok := a.resourceView.SelectedActivity()
+	a.resourceView.StopActivitySelection()
+	a.setMode(ModeList)
+	if ok && !a.resourceView.SelectResource(entry.Ref) {
+		a.resourceView.ShowNotice(fmt.Sprintf("%s is not listed anymore, or hidden by the filter", entry.Ref.Name))
+	}
+}
+
+// copyViewCommand copies a command line that reopens the current view to the
+// terminal clipboard. It is shown in the list too, for terminals that do not
+// support OSC 52.
+func (a *App) copyViewCommand() {
+	command := a.viewLink().Command()
+
+	seq := osc52.New(command)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	}
+	if _, err := seq.WriteTo(clipboardOutput); err != nil {
+		a.resourceView.ShowError(fmt.Errorf("copying view: %w", err))
+		return
+	}
+	a.resourceView.ShowNotice("✓ Copied: " + command)
+}
+
+// startComparison opens a side-by-side comparison of the two contexts marked
+// in the context selector
+func (a *App) startComparison() tea.Cmd {
+	if a.contextView == nil {
+		return nil
+	}
+	marked := a.contextView.GetSelectedContexts()
+	if len(marked) != 2 {
+		a.contextView.SetStatus(fmt.Sprintf("Mark exactly two contexts to compare (%d marked; m for multi-select, Space to mark)", len(marked)))
+		return nil
+	}
+
+	return a.compareContexts([2]string{marked[0], marked[1]})
+}
+
+// compareContexts connects to two contexts, to compare them once both are
+func (a *App) compareContexts(contexts [2]string) tea.Cmd {
+	return func() tea.Msg {
+		multiClient, err := k8s.NewMultiContextClient(contexts[:])
+		if err != nil {
+			return comparisonReadyMsg{contexts: contexts, err: err}
+		}
+		var clients [2]*k8s.Client
+		for i, c := range contexts {
+			if clients[i], err = multiClient.GetClient(c); err != nil {
+				return comparisonReadyMsg{contexts: contexts, err: err}
+			}
+		}
+		return comparisonReadyMsg{contexts: contexts, clients: clients}
+	}
+}
+
+// showComparison opens the comparison once both contexts are connected
+func (a *App) showComparison(msg comparisonReadyMsg) tea.Cmd {
+	if msg.err != nil {
+		if a.contextView != nil && a.currentMode == ModeContextSelector {
+			a.contextView.SetStatus("✗ " + k8s.UserMessage(msg.err))
+		} else if a.currentMode == ModeList {
+			a.resourceView.ShowNotice(fmt.Sprintf("✗ Comparing %s and %s: %s", msg.contexts[0], msg.contexts[1], k8s.UserMessage(msg.err)))
+		}
+		return nil
+	}
+
+	a.comparisonView = views.NewComparisonView(a.state, msg.contexts, msg.clients)
+	for _, pane := range a.comparisonView.Panes() {
+		pane.SetMaxResources(a.config.MaxResourcesShown)
+		pane.SetRefreshInterval(time.Duration(a.config.RefreshInterval) * time.Second)
+	}
+	a.comparisonView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeCompare)
+	return a.comparisonView.Init()
+}
+
+// closeComparison leaves the comparison for the main list
+func (a *App) closeComparison() {
+	a.comparisonView = nil
+	a.setMode(ModeList)
+}
+
+// returnToList goes back to the fleet search, comparison or split when one
+// is open, else the list
+func (a *App) returnToList() {
+	switch {
+	case a.fleetView != nil:
+		a.setMode(ModeFleet)
+	case a.comparisonView != nil:
+		a.setMode(ModeCompare)
+	case a.splitView != nil:
+		a.setMode(ModeSplit)
+	case a.resourceView.IsScrubbing():
+		a.setMode(ModeScrub)
+	default:
+		a.setMode(ModeList)
+	}
+}
+
+// startSplit opens a split of the deployments over the pods of the selected
+// one, following the selection as it moves
+func (a *App) startSplit() tea.Cmd {
+	if a.state.CurrentResourceType != core.ResourceTypeDeployment {
+		a.resourceView.ShowNotice("Select a deployment to split it over its pods")
+		return nil
+	}
+	name := a.resourceView.GetSelectedResourceName()
+	if name == "" {
+		return nil
+	}
+	namespace := a.resourceView.GetSelectedResourceColumn("NAMESPACE")
+	if namespace == "" {
+		namespace = a.resourceView.GetSelectedResourceNamespace()
+	}
+	context := a.getSelectedResourceContext()
+	deployment, ok := a.state.FindDeployment(context, namespace, name)
+	if !ok {
+		a.resourceView.ShowNotice(fmt.Sprintf("Deployment %s is not listed yet; refresh and try again", name))
+		return nil
+	}
+	// Without a client the panes report the failed refresh themselves
+	split, err := views.NewSplitView(a.state, context, a.clientForContext(context), deployment)
+	if err != nil {
+		a.resourceView.ShowError(err)
+		return nil
+	}
+	for _, pane := range split.Panes() {
+		pane.SetMaxResources(a.config.MaxResourcesShown)
+		pane.SetRefreshInterval(time.Duration(a.config.RefreshInterval) * time.Second)
+	}
+	a.splitView = split
+	a.splitView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeSplit)
+	return a.splitView.Init()
+}
+
+// closeSplit leaves the split for the main list
+func (a *App) closeSplit() {
+	a.splitView = nil
+	a.setMode(ModeList)
+}
+
+// listView returns the list that actions apply to: the focused comparison
+// pane, or the main list
+func (a *App) listView() *views.ResourceView {
+	if a.comparisonView != nil {
+		return a.comparisonView.Focused()
+	}
+	if a.splitView != nil {
+		return a.splitView.Focused()
+	}
+	return a.resourceView
+}
+
+// listState returns the state behind listView
+func (a *App) listState() *core.State {
+	if a.comparisonView != nil {
+		return a.comparisonView.FocusedState()
+	}
+	if a.splitView != nil {
+		return a.splitView.FocusedState()
+	}
+	return a.state
+}
+
+// clientForContext returns the client for a context on screen: a compared
+// context, one of the active contexts, or the single-context client
+func (a *App) clientForContext(contextName string) *k8s.Client {
+	if a.comparisonView != nil {
+		if client := a.comparisonView.ClientFor(contextName); client != nil {
+			return client
+		}
+	}
+	if a.isMultiContext && a.multiClient != nil {
+		if contextName == "" {
+			return nil
+		}
+		client, _ := a.multiClient.GetClient(contextName)
+		return client
+	}
+	return a.k8sClient
+}
+
+// SetManifestTemplates sets the templates offered when creating resources
+func (a *App) SetManifestTemplates(templates []*config.ManifestTemplate) {
+	a.manifestTemplates = templates
+}
+
+// SetDescribeTemplates sets the user templates that replace the describe
+// output of each resource type
+func (a *App) SetDescribeTemplates(templates map[core.ResourceType]string) {
+	a.describeTemplates = templates
+}
+
+// createdCleanup is a set of resources created from kubewatch awaiting
+// confirmation to delete them
+type createdCleanup struct {
+	context   string
+	client    *k8s.Client
+	resources []k8s.CreatedResource
+}
+
+// templateClient returns the client to create resources with: the selected
+// resource's context, or the first active context when nothing is selected
+func (a *App) templateClient() (*k8s.Client, string) {
+	if !a.isMultiContext || a.multiClient == nil {
+		return a.k8sClient, a.state.CurrentContext
+	}
+	contextName := a.getSelectedResourceContext()
+	if contextName == "" && len(a.activeContexts) > 0 {
+		contextName = a.activeContexts[0]
+	}
+	client, err := a.multiClient.GetClient(contextName)
+	if err != nil {
+		return nil, contextName
+	}
+	return client, contextName
+}
+
+// openTemplatePicker opens the list of manifest templates
+func (a *App) openTemplatePicker() {
+	a.templatePickerView = views.NewTemplatePickerView(a.manifestTemplates)
+	a.templatePickerView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeTemplates)
+}
+
+// openTemplateForm opens the parameter form for a template, prefilling the
+// namespace being viewed and the node of the selected resource
+func (a *App) openTemplateForm(template *config.ManifestTemplate) {
+	defaults := map[string]string{"Namespace": a.state.CurrentNamespace}
+	if defaults["Namespace"] == "" {
+		defaults["Namespace"] = "default"
+	}
+	if node := a.resourceView.GetSelectedResourceColumn("NODE"); node != "-" {
+		defaults["Node"] = node
+	}
+
+	a.templateFormView = views.NewTemplateFormView(template, defaults)
+	a.templateFormView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeTemplateForm)
+}
+
+// createFromTemplate renders a filled-in template and creates its resources
+func (a *App) createFromTemplate(template *config.ManifestTemplate, values map[string]string) tea.Cmd {
+	if a.templateFormView == nil {
+		return nil
+	}
+	manifest, err := template.Render(values)
+	if err != nil {
+		a.templateFormView.SetError(err.Error())
+		return nil
+	}
+	client, contextName := a.templateClient()
+	if client == nil {
+		a.templateFormView.SetError("no cluster connection")
+		return nil
+	}
+
+	a.templateFormView.SetCreating(true)
+	ctx := a.ctx
+	return func() tea.Msg {
+		created, err := client.CreateFromManifest(ctx, manifest)
+		return resourcesCreatedMsg{template: template.Name, context: contextName, created: created, err: err}
+	}
+}
+
+// resourcesCreated records what a template created. A failure stays in the
+// form so the parameters can be corrected.
+func (a *App) resourcesCreated(msg resourcesCreatedMsg) tea.Cmd {
+	for _, r := range msg.created {
+		a.actionLog.Record(core.ActionLogEntry{
+			Action:    "create",
+			Context:   msg.context,
+			Namespace: r.Namespace,
+			Resource:  r.Kind,
+			Name:      r.Name,
+			Detail:    "from template " + msg.template,
+		})
+	}
+	if msg.err != nil {
+		a.actionLog.Record(core.ActionLogEntry{
+			Action:  "create",
+			Context: msg.context,
+			Detail:  "from template " + msg.template,
+			Err:     msg.err,
+		})
+		if a.templateFormView != nil {
+			a.templateFormView.SetError(k8s.UserMessage(msg.err))
+		}
+		return nil
+	}
+
+	names := make([]string, len(msg.created))
+	for i, r := range msg.created {
+		names[i] = r.String()
+	}
+	a.resourceView.ShowNotice("✓ Created " + strings.Join(names, ", "))
+	a.setMode(ModeList)
+	return a.resourceView.RefreshResources()
+}
+
+// findCreatedResources looks up the resources created from kubewatch in the
+// namespace being viewed, to offer deleting them
+func (a *App) findCreatedResources() tea.Cmd {
+	client, contextName := a.templateClient()
+	if client == nil {
+		if a.templatePickerView != nil {
+			a.templatePickerView.SetStatus("✗ No cluster connection")
+		}
+		return nil
+	}
+
+	namespace := a.state.CurrentNamespace
+	ctx := a.ctx
+	return func() tea.Msg {
+		found, err := client.ListCreatedResources(ctx, namespace)
+		return createdResourcesFoundMsg{cleanup: &createdCleanup{context: contextName, client: client, resources: found}, err: err}
+	}
+}
+
+// confirmCleanup asks for confirmation before deleting created resources
+func (a *App) confirmCleanup(msg createdResourcesFoundMsg) {
+	if a.currentMode != ModeTemplates || a.templatePickerView == nil {
+		return
+	}
+	switch {
+	case msg.err != nil:
+		a.templatePickerView.SetStatus("✗ " + k8s.UserMessage(msg.err))
+		return
+	case len(msg.cleanup.resources) == 0:
+		a.templatePickerView.SetStatus("Nothing to clean up: no resources created from kubewatch here")
+		return
+	}
+
+	var list strings.Builder
+	for i, r := range msg.cleanup.resources {
+		if i == 10 {
+			list.WriteString(fmt.Sprintf("  ... and %d more\n", len(msg.cleanup.resources)-i))
+			break
+		}
+		list.WriteString("  " + r.String() + "\n")
+	}
+
+	a.pendingCleanup = msg.cleanup
+	message := fmt.Sprintf("Delete %d resource(s) created from kubewatch?\n\n%s", len(msg.cleanup.resources), list.String())
+	a.confirmView = views.NewConfirmView("⚠️  Clean Up", message)
+	a.confirmView.SetSize(a.width, a.viewHeight())
+	a.confirmView.SetConfirmText("Delete")
+	a.confirmView.SetCancelText("Cancel")
+	a.setMode(ModeConfirmDialog)
+}
+
+// deleteCreatedResources deletes confirmed created resources as a batch,
+// recording each deletion and retry in the action log
+func (a *App) deleteCreatedResources(cleanup *createdCleanup) tea.Cmd {
+	ops := make([]BatchOperation, len(cleanup.resources))
+	for i, r := range cleanup.resources {
+		r := r
+		ops[i] = BatchOperation{
+			Label: r.String(),
+			Run: func(ctx context.Context) error {
+				return cleanup.client.DeleteCreatedResource(ctx, r)
+			},
+		}
+	}
+
+	onResult := func(result BatchResult) {
+		r := cleanup.resources[result.Index]
+		a.actionLog.Record(core.ActionLogEntry{
+			Action:    "delete",
+			Context:   cleanup.context,
+			Namespace: r.Namespace,
+			Resource:  r.Kind,
+			Name:      r.Name,
+			Detail:    "cleanup of created resources",
+			Err:       result.Err,
+		})
+		if kind, ok := core.ParseResourceType(r.Kind); ok && result.Err == nil {
+			a.state.Deletions.Requested(kind, core.ResourceRef{Namespace: r.Namespace, Name: r.Name}, time.Now())
+		}
+	}
+	onDismiss := func(progress views.BatchProgress) tea.Cmd {
+		status := fmt.Sprintf("✓ Deleted %d resource(s)", progress.Succeeded)
+		if progress.Succeeded < progress.Total {
+			status = fmt.Sprintf("✗ Deleted %d of %d", progress.Succeeded, progress.Total)
+		}
+		if a.templatePickerView != nil {
+			a.templatePickerView.SetStatus(status)
+		}
+		return a.resourceView.RefreshResources()
+	}
+
+	title := fmt.Sprintf("Deleting %d resource(s) created from kubewatch", len(ops))
+	return a.startBatch(title, ops, onResult, onDismiss)
+}
+
+// batchSession is a batch action with its results overlay open
+type batchSession struct {
+	runner *BatchRunner
+
+	// onResult is called for every finished operation, retries included
+	onResult func(BatchResult)
+	// onDismiss is called when the overlay is closed, with the final progress
+	onDismiss func(views.BatchProgress) tea.Cmd
+	// returnMode is the mode the overlay was opened from
+	returnMode ScreenModeType
+}
+
+// startBatch runs a batch action and shows its results overlay, returning to
+// the current mode when dismissed. Either callback may be nil.
+func (a *App) startBatch(title string, ops []BatchOperation, onResult func(BatchResult), onDismiss func(views.BatchProgress) tea.Cmd) tea.Cmd {
+	runner := NewBatchRunner(title, ops, a.config.BatchConcurrency)
+	a.batch = &batchSession{
+		runner:     runner,
+		onResult:   onResult,
+		onDismiss:  onDismiss,
+		returnMode: a.currentMode,
+	}
+	a.batchView = views.NewBatchView(runner.Progress())
+	a.batchView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeBatch)
+	return runner.Start(a.ctx)
+}
+
+// batchProgress records finished operations and refreshes the overlay,
+// waiting for more while the batch runs
+func (a *App) batchProgress(msg BatchProgressMsg) tea.Cmd {
+	if a.batch == nil || msg.Runner != a.batch.runner {
+		return nil
+	}
+	for _, result := range msg.Runner.TakeResults() {
+		if a.batch.onResult != nil {
+			a.batch.onResult(result)
+		}
+	}
+	if a.batchView != nil {
+		a.batchView.SetProgress(msg.Runner.Progress())
+	}
+	if msg.Runner.Active() {
+		return msg.Runner.WaitForProgress()
+	}
+	return nil
+}
+
+// retryBatch runs the failed operations of the open batch again
+func (a *App) retryBatch() tea.Cmd {
+	if a.batch == nil || a.batchView == nil {
+		return nil
+	}
+	cmd := a.batch.runner.RetryFailed(a.ctx)
+	if cmd == nil {
+		a.batchView.SetStatus("Nothing to retry")
+		return nil
+	}
+	a.batchView.SetStatus("")
+	a.batchView.SetProgress(a.batch.runner.Progress())
+	return cmd
+}
+
+// cancelBatch drops the queued operations of the open batch
+func (a *App) cancelBatch() {
+	if a.batch == nil || a.batchView == nil {
+		return
+	}
+	a.batch.runner.Cancel()
+	a.batchView.SetProgress(a.batch.runner.Progress())
+}
+
+// dismissBatch closes the results overlay once the batch has stopped, so its
+// failures are not lost while it runs
+func (a *App) dismissBatch() tea.Cmd {
+	if a.batch == nil {
+		a.batchView = nil
+		a.returnToList()
+		return nil
+	}
+	if a.batch.runner.Active() {
+		if a.batchView != nil {
+			a.batchView.SetStatus("Still running; press c to cancel the remainder first")
+		}
+		return nil
+	}
+
+	batch := a.batch
+	a.batch = nil
+	a.batchView = nil
+	a.setMode(batch.returnMode)
+	if batch.onDismiss != nil {
+		return batch.onDismiss(batch.runner.Progress())
+	}
+	return nil
+}
+
+// finalizerRemoval identifies a finalizer to remove from a described resource
+type finalizerRemoval struct {
+	context      string
+	namespace    string
+	resourceType string // As displayed, e.g. "Pods"
+	name         string
+	finalizer    string
+	client       *k8s.Client
+}
+
+// resource returns the plural API resource, e.g. "pods"
+func (r *finalizerRemoval) resource() string {
+	return strings.ToLower(r.resourceType)
+}
+
+// SetFinalizerRemoval enables removing finalizers from the describe view
+func (a *App) SetFinalizerRemoval(enabled bool) {
+	a.allowFinalizerRemoval = enabled
+}
+
+// ActionLog returns the session's record of changes made to the cluster
+func (a *App) ActionLog() *core.ActionLog {
+	return a.actionLog
+}
+
+// startFinalizerRemoval loads the described resource's finalizers to pick one
+// to remove
+func (a *App) startFinalizerRemoval() tea.Cmd {
+	if a.describeView == nil {
+		return nil
+	}
+	if !a.allowFinalizerRemoval {
+		a.describeView.SetStatus("Finalizer removal is disabled; set settings.advanced.allowFinalizerRemoval in the config file")
+		return nil
+	}
+
+	target := &finalizerRemoval{
+		context:      a.describeView.GetContext(),
+		namespace:    a.describeView.GetNamespace(),
+		resourceType: a.describeView.GetResourceType(),
+		name:         a.describeView.GetResourceName(),
+	}
+	target.client = a.clientForContext(target.context)
+	if target.client == nil {
+		a.describeView.SetStatus("✗ No cluster connection for " + target.name)
+		return nil
+	}
+
+	ctx := a.ctx
+	return func() tea.Msg {
+		finalizers, err := target.client.GetFinalizers(ctx, target.resource(), target.namespace, target.name)
+		return finalizersLoadedMsg{target: target, finalizers: finalizers, err: err}
+	}
+}
+
+// showFinalizers opens the finalizer picker once the finalizers are loaded
+func (a *App) showFinalizers(msg finalizersLoadedMsg) tea.Cmd {
+	if a.describeView == nil || a.currentMode != ModeDescribe {
+		return nil
+	}
+	switch {
+	case msg.err != nil:
+		a.describeView.SetStatus("✗ " + k8s.UserMessage(msg.err))
+		return nil
+	case len(msg.finalizers) == 0:
+		a.describeView.SetStatus(fmt.Sprintf("%s has no finalizers", msg.target.name))
+		return nil
+	}
+
+	a.pendingFinalizer = msg.target
+	target := fmt.Sprintf("%s %s/%s", msg.target.resourceType, msg.target.namespace, msg.target.name)
+	if msg.target.context != "" {
+		target = fmt.Sprintf("[%s] %s", msg.target.context, target)
+	}
+	a.finalizerView = views.NewFinalizerView(target, msg.finalizers)
+	a.finalizerView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeFinalizers)
+	return nil
+}
+
+// confirmFinalizerRemoval asks for confirmation before removing a finalizer
+func (a *App) confirmFinalizerRemoval(finalizer string) {
+	if a.pendingFinalizer == nil {
+		return
+	}
+	a.pendingFinalizer.finalizer = finalizer
+
+	message := fmt.Sprintf("Remove finalizer '%s' from %s '%s'?\n\n"+
+		"The controller that owns it will not get to clean up, which can leave\n"+
+		"external resources (volumes, load balancers, DNS records) behind.",
+		finalizer, strings.ToLower(strings.TrimSuffix(a.pendingFinalizer.resourceType, "s")), a.pendingFinalizer.name)
+	a.confirmView = views.NewConfirmView("⚠️  Remove Finalizer", message)
+	a.confirmView.SetSize(a.width, a.viewHeight())
+	a.confirmView.SetConfirmText("Remove finalizer")
+	a.confirmView.SetCancelText("Cancel")
+	a.confirmView.RequireInput(a.pendingFinalizer.name)
+	a.setMode(ModeConfirmDialog)
+}
+
+// removeFinalizer removes a confirmed finalizer
+func (a *App) removeFinalizer(removal *finalizerRemoval) tea.Cmd {
+	ctx := a.ctx
+	return func() tea.Msg {
+		err := removal.client.RemoveFinalizer(ctx, removal.resource(), removal.namespace, removal.name, removal.finalizer)
+		return finalizerRemovedMsg{removal: removal, err: err}
+	}
+}
+
+// finalizerRemoved records a finalizer removal and shows its outcome
+func (a *App) finalizerRemoved(msg finalizerRemovedMsg) tea.Cmd {
+	removal := msg.removal
+	a.actionLog.Record(core.ActionLogEntry{
+		Action:    "remove finalizer",
+		Context:   removal.context,
+		Namespace: removal.namespace,
+		Resource:  removal.resourceType,
+		Name:      removal.name,
+		Detail:    removal.finalizer,
+		Err:       msg.err,
+	})
+
+	if a.describeView == nil {
+		return nil
+	}
+	if msg.err != nil {
+		a.describeView.SetStatus("✗ " + k8s.UserMessage(msg.err))
+		return nil
+	}
+	a.describeView.SetStatus(fmt.Sprintf("Removed finalizer %s", removal.finalizer))
+	return a.describeView.LoadDescribeWithClient(a.ctx, removal.client)
+}
+
+// cancelConfirmDialog closes the confirmation dialog without acting
+func (a *App) cancelConfirmDialog() {
+	if a.pendingCleanup != nil {
+		a.pendingCleanup = nil
+		a.setMode(ModeTemplates)
+		return
+	}
+	if a.pendingFinalizer != nil {
+		a.pendingFinalizer = nil
+		a.setMode(ModeDescribe)
+		return
+	}
+	if a.pendingCordon != nil {
+		a.pendingCordon = nil
+		a.setMode(ModeNodeDetail)
+		return
+	}
+	if a.pendingRollout != nil {
+		a.pendingRollout = nil
+		a.returnToList()
+		return
+	}
+	a.pendingDelete = nil
+	a.returnToList()
+}
+
+// showContextInfo displays detailed information about a context
+func (a *App) showContextInfo(contextName string) tea.Cmd {
+	return func() tea.Msg {
 		// Get context information
 		contexts, currentCtx, err := k8s.GetAvailableContexts()
 		if err != nil {
@@ -963,15 +4104,33 @@ func (a *App) openResourceSelector() tea.Cmd {
 		a.resourceSelectorView = views.NewResourceSelectorView()
 	}
 
-	// Set current resource type in the dropdown
+	// Offer the types the cluster serves, with the current one selected
+	a.resourceSelectorView.SetResourceTypes(a.state.AvailableResourceTypes())
 	a.resourceSelectorView.SetCurrentResourceType(a.state.CurrentResourceType)
-	a.resourceSelectorView.SetSize(a.width, a.height)
+	a.resourceSelectorView.SetSize(a.width, a.viewHeight())
 	a.resourceSelectorView.Open()
 	a.setMode(ModeResourceSelector)
 
 	return nil
 }
 
+// switchResourceType lists resourceType, with the filter it had and the
+// resource last selected in it this session
+func (a *App) switchResourceType(resourceType core.ResourceType) tea.Cmd {
+	if a.typeSelections == nil {
+		a.typeSelections = make(map[core.ResourceType]core.ResourceRef)
+	}
+	if ref := a.resourceView.SelectedResourceRef(); !ref.IsZero() {
+		a.typeSelections[a.state.CurrentResourceType] = ref
+	}
+	a.state.SetResourceType(resourceType)
+	if ref, ok := a.typeSelections[resourceType]; ok {
+		a.resourceView.SelectAfterRefresh(ref)
+	}
+	a.setMode(ModeList)
+	return a.resourceView.RefreshResources()
+}
+
 // applyResourceSelection applies the selected resource type
 func (a *App) applyResourceSelection() tea.Cmd {
 	if a.resourceSelectorView == nil {
@@ -982,9 +4141,7 @@ func (a *App) applyResourceSelection() tea.Cmd {
 	// Get the selected resource type from the dropdown
 	selectedOption := a.resourceSelectorView.GetSelectedOption()
 	if resourceType, ok := selectedOption.Value.(core.ResourceType); ok {
-		a.state.SetResourceType(resourceType)
-		a.setMode(ModeList)
-		return a.resourceView.RefreshResources()
+		return a.switchResourceType(resourceType)
 	}
 
 	a.setMode(ModeList)
@@ -993,8 +4150,11 @@ func (a *App) applyResourceSelection() tea.Cmd {
 
 // Message types
 type errMsg struct{ err error }
-type deleteCompleteMsg struct{ name string }
 type contextSelectionMsg struct{ contexts []string }
+type filterPreviewMsg struct{}
+type optionalTypesDetectedMsg struct {
+	installed map[core.ResourceType]bool
+}
 type contextInfoDisplayMsg struct {
 	contextName string
 	info        string
@@ -1003,3 +4163,37 @@ type namespacesLoadedMsg struct {
 	namespaces []v1.Namespace
 	err        error
 }
+type userActionDoneMsg struct {
+	name string
+	err  error
+}
+type userActionOutputMsg struct {
+	name    string
+	command string
+	output  string
+	err     error
+}
+type comparisonReadyMsg struct {
+	contexts [2]string
+	clients  [2]*k8s.Client
+	err      error
+}
+type resourcesCreatedMsg struct {
+	template string
+	context  string
+	created  []k8s.CreatedResource
+	err      error
+}
+type createdResourcesFoundMsg struct {
+	cleanup *createdCleanup
+	err     error
+}
+type finalizersLoadedMsg struct {
+	target     *finalizerRemoval
+	finalizers []string
+	err        error
+}
+type finalizerRemovedMsg struct {
+	removal *finalizerRemoval
+	err     error
+}
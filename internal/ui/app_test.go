@@ -1,19 +1,30 @@
 package ui
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/HamStudy/kubewatch/internal/components/dropdown"
 	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/testutil/fixtures"
 	"github.com/HamStudy/kubewatch/internal/ui/views"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
 )
 
 func TestAppInitialization(t *testing.T) {
@@ -338,7 +349,7 @@ func TestAppViewRendering(t *testing.T) {
 			name: "describe view",
 			mode: ModeDescribe,
 			setupFunc: func(app *App) {
-				app.describeView = views.NewDescribeView("pod", "test-pod", "default", "")
+				app.describeView = views.NewDescribeView(context.Background(), nil, "pod", "test-pod", "default", "")
 				app.describeView.SetSize(80, 24)
 			},
 			expectContent: []string{}, // Describe view will render
@@ -600,11 +611,11 @@ func TestAppResourceTypeNavigation(t *testing.T) {
 			action: func(app *App) {
 				app.prevResourceType()
 			},
-			expectedType: core.ResourceTypeSecret,
+			expectedType: core.ResourceTypeNode,
 		},
 		{
 			name:      "next wraps around",
-			startType: core.ResourceTypeSecret,
+			startType: core.ResourceTypeNode,
 			action: func(app *App) {
 				app.nextResourceType()
 			},
@@ -616,14 +627,14 @@ func TestAppResourceTypeNavigation(t *testing.T) {
 			action: func(app *App) {
 				app.prevResourceType()
 			},
-			expectedType: core.ResourceTypeSecret,
+			expectedType: core.ResourceTypeNode,
 		},
 		{
 			name:      "cycle through all types",
 			startType: core.ResourceTypePod,
 			action: func(app *App) {
 				// Cycle through all types and back
-				for i := 0; i < 7; i++ {
+				for i := 0; i < 15; i++ {
 					app.nextResourceType()
 				}
 			},
@@ -750,11 +761,14 @@ func TestAppMessageHandling(t *testing.T) {
 			},
 		},
 		{
-			name: "delete complete message",
-			msg:  deleteCompleteMsg{name: "test-pod"},
+			name: "delete requested message",
+			msg:  views.DeleteRequestedMsg{Kind: core.ResourceTypePod, Ref: core.ResourceRef{Namespace: "default", Name: "test-pod"}},
+			setupFunc: func(app *App) {
+				app.state.Deletions = core.NewDeletionTracker()
+			},
 			validateFunc: func(t *testing.T, app *App, cmd tea.Cmd) {
 				if cmd == nil {
-					t.Error("Delete complete should trigger refresh")
+					t.Error("An accepted delete should trigger a refresh")
 				}
 			},
 		},
@@ -1022,7 +1036,7 @@ func TestAppDeleteConfirmation(t *testing.T) {
 			app := createTestApp(t)
 			app.state.CurrentResourceType = tt.resourceType
 
-			// Populate ResourceView with test data so DeleteSelected has something to work with
+			// Populate ResourceView with test data so there is a resource to delete
 			var headers []string
 			var rows [][]string
 
@@ -1049,7 +1063,7 @@ func TestAppDeleteConfirmation(t *testing.T) {
 			app.resourceView.SetSelectedRow(0)
 
 			// Show delete confirmation
-			cmd := app.showDeleteConfirmation(tt.resourceName)
+			cmd := app.showDeleteConfirmation()
 			_ = cmd // Command is nil for this operation
 
 			// Verify confirmation dialog was created
@@ -1057,9 +1071,9 @@ func TestAppDeleteConfirmation(t *testing.T) {
 				t.Error("Confirm view should be created")
 			}
 
-			if app.pendingDeleteName != tt.resourceName {
-				t.Errorf("Expected pending delete name %s, got %s",
-					tt.resourceName, app.pendingDeleteName)
+			if app.pendingDelete == nil || app.pendingDelete.ref.Name != tt.resourceName || app.pendingDelete.kind != tt.resourceType {
+				t.Errorf("Expected pending delete of %s %s, got %+v",
+					tt.resourceType, tt.resourceName, app.pendingDelete)
 			}
 
 			// Simulate confirmation or cancellation by updating the view
@@ -1084,8 +1098,8 @@ func TestAppDeleteConfirmation(t *testing.T) {
 					t.Error("Expected delete command when confirmed")
 				}
 			} else {
-				if app.pendingDeleteName != "" {
-					t.Error("Pending delete name should be cleared when cancelled")
+				if app.pendingDelete != nil {
+					t.Error("Pending delete should be cleared when cancelled")
 				}
 			}
 
@@ -1193,6 +1207,57 @@ func TestAppRefreshTimer(t *testing.T) {
 	}
 }
 
+func TestRefreshTimerFollowsKindInterval(t *testing.T) {
+	app := createTestApp(t)
+	app.config.RefreshInterval = 2
+	app.config.RefreshIntervals = map[core.ResourceType]int{
+		core.ResourceTypePod:        1,
+		core.ResourceTypeDeployment: core.RefreshOff,
+	}
+
+	app.startRefreshTimer()
+	if app.armedInterval != time.Second {
+		t.Fatalf("Expected pods refreshed every 1s, got %v", app.armedInterval)
+	}
+	gen := app.tickGen
+
+	// pick opens the resource selector with Tab and picks t
+	pick := func(t core.ResourceType) {
+		app.Update(tea.KeyMsg{Type: tea.KeyTab})
+		app.Update(dropdown.SelectedMsg{Option: dropdown.Option{Label: string(t), Value: t}})
+	}
+
+	// Tab to deployments, which are not refreshed periodically; the timer
+	// still ticks at the refresh interval for everything else a tick does
+	pick(core.ResourceTypeDeployment)
+	if app.state.CurrentResourceType != core.ResourceTypeDeployment {
+		t.Fatalf("Expected Tab to switch to deployments, got %s", app.state.CurrentResourceType)
+	}
+	if app.tickGen == gen || app.armedInterval != 2*time.Second {
+		t.Errorf("Expected the timer armed again at 2s, got generation %d at %v", app.tickGen, app.armedInterval)
+	}
+	if header := app.resourceView.View(); !strings.Contains(header, "auto-refresh off") {
+		t.Errorf("Expected the header to show auto-refresh off, got:\n%s", header)
+	}
+
+	// The replaced timer's ticks are dropped
+	app.viewDirty = false
+	app.Update(armedTickMsg{gen: gen, at: time.Now()})
+	if app.viewDirty {
+		t.Error("Expected a tick of the replaced timer dropped")
+	}
+
+	// Back on a kind at the refresh interval, the timer is left as it is
+	gen = app.tickGen
+	pick(core.ResourceTypeService)
+	if app.tickGen != gen {
+		t.Errorf("Expected the timer left armed for %s, got generation %d", app.state.CurrentResourceType, app.tickGen)
+	}
+	if header := app.resourceView.View(); !strings.Contains(header, "(every 2s)") {
+		t.Errorf("Expected the header to show the 2s interval, got:\n%s", header)
+	}
+}
+
 func TestAppWatcherManagement(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1246,6 +1311,73 @@ func TestAppWatcherManagement(t *testing.T) {
 	}
 }
 
+func TestAppDropsEventsOfReplacedWatcher(t *testing.T) {
+	tests := []struct {
+		name     string
+		switchTo func(app *App)
+	}{
+		{"resource type switch", func(app *App) { app.state.SetResourceType(core.ResourceTypeService) }},
+		{"context switch", func(app *App) { app.state.SetCurrentContexts([]string{"staging"}) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := createTestApp(t)
+			app.state.Deletions = core.NewDeletionTracker()
+			ref := core.ResourceRef{Namespace: "default", Name: "web-1"}
+			pod := fixtures.NewPod("web-1", "default").Build()
+
+			// run reads the events of a watcher of the current generation
+			// until its channel closes or it is dropped
+			run := func(watcher *watch.FakeWatcher) <-chan struct{} {
+				done := make(chan struct{})
+				generation := app.watcherGen
+				go func() {
+					app.watchEvents(context.Background(), generation, core.ResourceTypePod, watcher)
+					close(done)
+				}()
+				return done
+			}
+			wait := func(done <-chan struct{}) {
+				t.Helper()
+				select {
+				case <-done:
+				case <-time.After(5 * time.Second):
+					t.Fatal("Expected the watcher to stop")
+				}
+			}
+
+			app.startWatcher()
+			app.state.Deletions.Requested(core.ResourceTypePod, ref, time.Now())
+			old := watch.NewFake()
+			oldDone := run(old)
+
+			// The switch replaces the watcher; an event of the old one in
+			// flight arrives after it and is dropped, stopping the old one
+			tt.switchTo(app)
+			app.startWatcher()
+			old.Delete(&pod)
+			wait(oldDone)
+			if d, _ := app.state.Deletions.Get(core.ResourceTypePod, "", "default", "web-1"); !d.DoneAt.IsZero() {
+				t.Error("Expected the replaced watcher's event ignored")
+			}
+			if !old.IsStopped() {
+				t.Error("Expected the replaced watcher stopped")
+			}
+
+			// The current watcher's events are recorded
+			current := watch.NewFake()
+			currentDone := run(current)
+			current.Delete(&pod)
+			current.Stop()
+			wait(currentDone)
+			if d, _ := app.state.Deletions.Get(core.ResourceTypePod, "", "default", "web-1"); d.DoneAt.IsZero() {
+				t.Error("Expected the current watcher's event recorded")
+			}
+		})
+	}
+}
+
 func TestAppGetAvailableSortColumns(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -1345,8 +1477,8 @@ func TestAppStateConsistency(t *testing.T) {
 		{
 			name: "modes initialized",
 			validateFunc: func(t *testing.T, app *App) {
-				if len(app.modes) != 8 {
-					t.Errorf("Expected 8 modes, got %d", len(app.modes))
+				if len(app.modes) != 35 {
+					t.Errorf("Expected 35 modes, got %d", len(app.modes))
 				}
 				for mode, handler := range app.modes {
 					if handler == nil {
@@ -1573,3 +1705,551 @@ func TestAppLogViewSplitScreen(t *testing.T) {
 		})
 	}
 }
+
+// TestAppResizeKeepsOverlayState tests that resizing the terminal mid-overlay
+// keeps each overlay's selection and input, and reaches overlays not on screen
+func TestAppResizeKeepsOverlayState(t *testing.T) {
+	resize := tea.WindowSizeMsg{Width: 100, Height: 40}
+
+	t.Run("confirm dialog", func(t *testing.T) {
+		app := createTestApp(t)
+		app.resourceView.SetTestData([]string{"NAME", "STATUS"}, [][]string{{"web-1", "Running"}})
+		app.resourceView.SetSelectedRow(0)
+		app.showDeleteConfirmation()
+		app.setMode(ModeConfirmDialog)
+
+		// Highlight the confirm button, which is not the default
+		app.Update(tea.KeyMsg{Type: tea.KeyRight})
+		app.Update(resize)
+
+		if app.currentMode != ModeConfirmDialog || !app.confirmView.IsConfirmed() {
+			t.Error("Expected the highlighted button to survive a resize")
+		}
+	})
+
+	t.Run("namespace selector", func(t *testing.T) {
+		app := createTestApp(t)
+		var namespaces []v1.Namespace
+		for _, name := range []string{"default", "kube-system", "kube-public", "prod"} {
+			namespaces = append(namespaces, v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		}
+		app.namespaceView = views.NewNamespaceView(namespaces, "default")
+		app.setMode(ModeNamespaceSelector)
+
+		for _, r := range "/kube" {
+			app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		app.Update(tea.KeyMsg{Type: tea.KeyDown})
+		selected := app.namespaceView.GetSelectedNamespace()
+		app.Update(resize)
+
+		if view := app.View(); !strings.Contains(view, "kube") || strings.Contains(view, "prod") {
+			t.Errorf("Expected the filter to survive a resize, got:\n%s", view)
+		}
+		if got := app.namespaceView.GetSelectedNamespace(); got != selected {
+			t.Errorf("Expected %q to stay selected, got %q", selected, got)
+		}
+	})
+
+	t.Run("overlays not on screen", func(t *testing.T) {
+		app := createTestApp(t)
+		app.startSavedFiltersView()
+		app.setMode(ModeList)
+
+		app.Update(resize)
+
+		for _, tt := range []struct {
+			name string
+			view string
+		}{
+			{"saved filters", app.savedFiltersView.View()},
+			{"help", app.helpView.View()},
+		} {
+			if width := lipgloss.Width(strings.Split(tt.view, "\n")[0]); width != resize.Width {
+				t.Errorf("Expected the %s overlay to be laid out %d wide, got %d", tt.name, resize.Width, width)
+			}
+		}
+	})
+}
+
+func TestCopyViewAsCommand(t *testing.T) {
+	app := createTestApp(t)
+	app.width, app.height = 200, 30
+	app.state.CurrentNamespace = "payments"
+	app.state.SetFilter("status=CrashLoopBackOff", "")
+	app.state.SetSortState("RESTARTS", false)
+	app.resourceView.SetSize(200, 30)
+	app.resourceView.SetTestData([]string{"NAME", "STATUS", "RESTARTS"}, [][]string{
+		{"checkout-7f9c", "CrashLoopBackOff", "42"},
+	})
+
+	var clipboard bytes.Buffer
+	old := clipboardOutput
+	clipboardOutput = &clipboard
+	defer func() { clipboardOutput = old }()
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+
+	want := "kubewatch --context test-context -n payments pods --filter 'status=CrashLoopBackOff' --sort RESTARTS:desc --select checkout-7f9c"
+	encoded := base64.StdEncoding.EncodeToString([]byte(want))
+	if !strings.Contains(clipboard.String(), encoded) {
+		t.Errorf("Expected an OSC 52 copy of %q, got %q", want, clipboard.String())
+	}
+	if view := app.resourceView.View(); !strings.Contains(view, "Copied") {
+		t.Errorf("Expected the copy noted in the list, got:\n%s", view)
+	}
+}
+
+func TestNodeDetailOpensFromPodNode(t *testing.T) {
+	app := createTestApp(t)
+	app.width, app.height = 140, 40
+	app.resourceView.SetTestData([]string{"NAME", "STATUS", "NODE"}, [][]string{
+		{"web", "Running", "node-1"},
+		{"pending", "Pending", "<none>"},
+	})
+
+	app.resourceView.SetSelectedRow(1)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")})
+	if app.currentMode != ModeList || app.nodeDetailView != nil {
+		t.Errorf("Expected no node overlay for an unscheduled pod, got mode %v", app.currentMode)
+	}
+
+	app.resourceView.SetSelectedRow(0)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")})
+	if app.currentMode != ModeNodeDetail || app.nodeDetailView == nil || app.nodeDetailView.NodeName() != "node-1" {
+		t.Fatalf("Expected the node-1 overlay, got mode %v", app.currentMode)
+	}
+	if view := app.View(); !strings.Contains(view, "Node: node-1") {
+		t.Errorf("Expected the node overlay on screen, got:\n%s", view)
+	}
+
+	// Nothing is loaded, so there is nothing to cordon yet
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if app.currentMode != ModeNodeDetail || app.pendingCordon != nil {
+		t.Errorf("Expected no cordon before the node loads, got mode %v", app.currentMode)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList || app.nodeDetailView != nil {
+		t.Errorf("Expected Esc to close the overlay, got mode %v", app.currentMode)
+	}
+}
+
+// TestNodeDetailOpensFromNodeList tests that N on a node row opens the
+// overlay for that node
+func TestNodeDetailOpensFromNodeList(t *testing.T) {
+	app := createTestApp(t)
+	app.width, app.height = 140, 40
+	app.state.CurrentResourceType = core.ResourceTypeNode
+	app.resourceView.SetTestData([]string{"NAME", "STATUS"}, [][]string{{"node-2", "Ready"}})
+	app.resourceView.SetSelectedRow(0)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")})
+	if app.currentMode != ModeNodeDetail || app.nodeDetailView == nil || app.nodeDetailView.NodeName() != "node-2" {
+		t.Errorf("Expected the node-2 overlay, got mode %v", app.currentMode)
+	}
+}
+
+// TestUsageOverlay tests opening the usage overlay and picking a pod from it
+func TestUsageOverlay(t *testing.T) {
+	app := createTestApp(t)
+	app.width, app.height = 140, 40
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("U")})
+	if app.currentMode != ModeUsage || app.usageView == nil {
+		t.Fatalf("Expected U to open the usage overlay, got mode %v", app.currentMode)
+	}
+	if view := app.View(); !strings.Contains(view, "Usage by namespace") {
+		t.Errorf("Expected the usage overlay on screen, got:\n%s", view)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList || app.usageView != nil {
+		t.Errorf("Expected Esc to close the overlay, got mode %v", app.currentMode)
+	}
+
+	// kubewatch top starts in the overlay
+	app.ShowUsageOnStart()
+	app.Init()
+	if app.currentMode != ModeUsage {
+		t.Fatalf("Expected the overlay open at start, got mode %v", app.currentMode)
+	}
+	app.state.SetNamespace("default")
+	app.Update(views.UsagePodSelectedMsg{Namespace: "web", Name: "web-1"})
+	if app.currentMode != ModeList || app.usageView != nil || app.state.CurrentNamespace != "web" {
+		t.Errorf("Expected the pod shown in its namespace, got mode %v in %q", app.currentMode, app.state.CurrentNamespace)
+	}
+	if app.state.CurrentResourceType != core.ResourceTypePod {
+		t.Errorf("Expected the pod list, got %s", app.state.CurrentResourceType)
+	}
+}
+
+// TestEventTail tests opening and closing the event tail, and that a search
+// being typed keeps the keys that close it
+func TestEventTail(t *testing.T) {
+	app := createTestApp(t)
+	app.width, app.height = 140, 40
+	app.state.SetNamespace("web")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	if app.currentMode != ModeEventTail || app.eventTailView == nil {
+		t.Fatalf("Expected E to open the event tail, got mode %v", app.currentMode)
+	}
+	if view := app.View(); !strings.Contains(view, "Events [FOLLOWING] namespace web") {
+		t.Errorf("Expected the event tail on screen, got:\n%s", view)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if app.currentMode != ModeEventTail {
+		t.Fatalf("Expected q typed into the search, got mode %v", app.currentMode)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeEventTail {
+		t.Fatalf("Expected Esc to end the search first, got mode %v", app.currentMode)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if app.currentMode != ModeList || app.eventTailView != nil {
+		t.Errorf("Expected q to close the event tail, got mode %v", app.currentMode)
+	}
+}
+
+// readOnlyPodsClient creates a client of a server whose rules review grants
+// reading pods and nothing else
+func readOnlyPodsClient(t *testing.T) *k8s.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"SelfSubjectRulesReview","apiVersion":"authorization.k8s.io/v1","spec":{},"status":{
+			"resourceRules":[{"verbs":["get","list","watch"],"apiGroups":[""],"resources":["pods"]}],
+			"nonResourceRules":[],"incomplete":false}}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	return client
+}
+
+// TestPermissionsOverlay tests opening the permissions overlay with Ctrl+A
+// and from the quick actions, and that what it finds rules out deletes
+func TestPermissionsOverlay(t *testing.T) {
+	app := createTestApp(t)
+	app.width, app.height = 140, 40
+	app.isMultiContext = false
+	app.k8sClient = readOnlyPodsClient(t)
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	if app.currentMode != ModePermissions || app.permissionsView == nil || cmd == nil {
+		t.Fatalf("Expected Ctrl+A to open the permissions and check them, got mode %v", app.currentMode)
+	}
+	app.Update(cmd())
+	view := app.View()
+	if !strings.Contains(view, "Permissions in namespace default") || !strings.Contains(view, "by rules review") {
+		t.Errorf("Expected the checked permissions of default, got:\n%s", view)
+	}
+	if app.permissions.Get("", "default").Can(core.ResourceTypePod, "delete") != k8s.PermissionDenied {
+		t.Error("Expected the review cached for the app")
+	}
+
+	// Reopening uses the cache; r checks again
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	if app.currentMode != ModeActionMenu || !strings.Contains(app.View(), "What can I do here?") {
+		t.Fatalf("Expected the quick actions to offer the permissions, got:\n%s", app.View())
+	}
+	_, cmd = app.Update(views.PermissionsSelectedMsg{})
+	if app.currentMode != ModePermissions || cmd != nil {
+		t.Errorf("Expected cached permissions shown without a check, got mode %v", app.currentMode)
+	}
+	if _, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}); cmd == nil {
+		t.Error("Expected r to check the permissions again")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	if app.currentMode != ModeList || app.permissionsView != nil {
+		t.Fatalf("Expected Ctrl+A to close the permissions, got mode %v", app.currentMode)
+	}
+
+	// A delete known to be denied is refused without asking
+	app.resourceView.SetTestData([]string{"NAME", "NAMESPACE", "STATUS"}, [][]string{{"web", "default", "Running"}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	if app.currentMode != ModeList || !strings.Contains(app.View(), "you may not delete pods in default") {
+		t.Errorf("Expected the denied delete refused, got mode %v:\n%s", app.currentMode, app.View())
+	}
+}
+
+// TestLogsByResourceType tests that l, the quick actions and help agree on
+// what viewing logs does for each resource type
+func TestLogsByResourceType(t *testing.T) {
+	for _, resourceType := range core.AllResourceTypes {
+		t.Run(string(resourceType), func(t *testing.T) {
+			target := core.LogTargetFor(resourceType)
+			app := createTestApp(t)
+			app.width, app.height = 200, 40
+			app.state.CurrentResourceType = resourceType
+			app.resourceView.SetSize(200, 40)
+			app.resourceView.SetTestData([]string{"NAME"}, [][]string{{"web"}})
+
+			app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+			view := app.resourceView.View()
+			if target.Applies() {
+				if strings.Contains(view, "No logs") {
+					t.Errorf("Expected logs for %s, got a hint:\n%s", resourceType, view)
+				}
+			} else {
+				if app.currentMode != ModeList || !strings.Contains(view, target.Hint) {
+					t.Errorf("Expected the hint %q in the list, got mode %v:\n%s", target.Hint, app.currentMode, view)
+				}
+			}
+
+			want := target.Description
+			if !target.Applies() {
+				want = target.Hint
+			}
+			app.openActionMenu()
+			if view := app.actionMenuView.View(); !strings.Contains(view, want) {
+				t.Errorf("Expected the quick actions to say %q, got:\n%s", want, view)
+			}
+			if app.actionMenuView.LogsSelected() != target.Applies() {
+				t.Errorf("Expected the logs row selectable only with logs")
+			}
+			app.setMode(ModeList)
+
+			app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+			if view := app.helpView.View(); !strings.Contains(view, want) {
+				t.Errorf("Expected help to say %q, got:\n%s", want, view)
+			}
+		})
+	}
+}
+
+// TestAppDoesLessWhileUnfocused tests that ticks mostly idle and the last
+// frame is reused while the terminal is unfocused
+func TestAppDoesLessWhileUnfocused(t *testing.T) {
+	app := createTestApp(t)
+	app.resourceView.SetSize(80, 24)
+	app.resourceView.SetTestData([]string{"NAME"}, [][]string{{"web"}})
+
+	app.Update(tea.BlurMsg{})
+	if !app.blurred {
+		t.Fatal("Expected a blur to be recorded")
+	}
+	frame := app.View()
+
+	// Changes made behind the app's back show once a tick does work
+	app.resourceView.ShowNotice("changed meanwhile")
+	for i := 1; i < blurredTickFactor; i++ {
+		app.Update(tickMsg(time.Now()))
+		if app.View() != frame {
+			t.Fatalf("Expected tick %d to reuse the last frame", i)
+		}
+	}
+	app.Update(tickMsg(time.Now()))
+	if !strings.Contains(app.View(), "changed meanwhile") {
+		t.Error("Expected every fifth tick to render again")
+	}
+
+	// Focus refreshes at once
+	if _, cmd := app.Update(tea.FocusMsg{}); cmd == nil || app.blurred {
+		t.Error("Expected focus to end the blur and refresh")
+	}
+}
+
+// TestAppKeyPressEndsBlur tests that a key press counts as focus, since a
+// focus event can be missed while a command had the terminal
+func TestAppKeyPressEndsBlur(t *testing.T) {
+	app := createTestApp(t)
+	app.Update(tea.BlurMsg{})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	if app.blurred {
+		t.Error("Expected a key press to end the blur")
+	}
+	if app.currentMode != ModeHelp {
+		t.Errorf("Expected the key handled too, got mode %v", app.currentMode)
+	}
+}
+
+// fleetKubeconfig writes a kubeconfig with a context "us" served by a server
+// listing a checkout pod, and a context "down" nothing answers for
+func fleetKubeconfig(t *testing.T) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[
+			{"metadata":{"name":"checkout-7f9c","namespace":"payments"},"spec":{"containers":[{"name":"app"}]},"status":{"phase":"Running"}},
+			{"metadata":{"name":"cart-1","namespace":"payments"},"spec":{"containers":[{"name":"app"}]},"status":{"phase":"Running"}}]}`))
+	}))
+	t.Cleanup(server.Close)
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	path := filepath.Join(t.TempDir(), "config")
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+current-context: us
+clusters:
+- name: us
+  cluster: {server: %q}
+- name: down
+  cluster: {server: %q}
+users:
+- name: me
+  user: {token: secret}
+contexts:
+- name: us
+  context: {cluster: us, user: me}
+- name: down
+  context: {cluster: down, user: me}
+`, server.URL, down.URL)
+	if err := os.WriteFile(path, []byte(kubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// feedCmd runs a command and every command it batches, feeding what they
+// return to the app and running what that returns in turn
+func feedCmd(app *App, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, cmd := range batch {
+			feedCmd(app, cmd)
+		}
+		return
+	}
+	if msg != nil {
+		_, next := app.Update(msg)
+		feedCmd(app, next)
+	}
+}
+
+// TestFleetSearch tests searching every kubeconfig context for pods with
+// Ctrl+F, and going to a found pod's logs and back
+func TestFleetSearch(t *testing.T) {
+	app := createTestApp(t)
+	app.width, app.height = 200, 40
+	app.isMultiContext = false
+	app.config.KubeConfig = fleetKubeconfig(t)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	if app.currentMode != ModeFleet || app.fleetView == nil || !app.fleetView.IsEditing() {
+		t.Fatalf("Expected Ctrl+F to ask what to search for, got mode %v", app.currentMode)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("checkout")})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	feedCmd(app, cmd)
+
+	view := app.View()
+	for _, want := range []string{"2 of 2 contexts answered", "checkout-7f9c", "✗ down:"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the fleet search:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "cart-1") {
+		t.Errorf("Expected only matching pods listed:\n%s", view)
+	}
+
+	// Logs come from the pod's context, and Esc returns to the results
+	if _, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter}); app.currentMode != ModeLog || cmd == nil {
+		t.Fatalf("Expected Enter to open the found pod's logs, got mode %v", app.currentMode)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeFleet {
+		t.Fatalf("Expected Esc to return to the fleet search, got mode %v", app.currentMode)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if app.currentMode != ModeDescribe || app.describeView == nil {
+		t.Fatalf("Expected d to describe the found pod, got mode %v", app.currentMode)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList || app.fleetView != nil {
+		t.Errorf("Expected Esc to close the fleet search, got mode %v", app.currentMode)
+	}
+}
+
+// TestActivityFeed tests that a shows READY and STATUS transitions under the
+// list and that A picks one to jump to
+func TestActivityFeed(t *testing.T) {
+	app := createTestApp(t)
+	app.width, app.height = 120, 40
+	app.resourceView.SetSize(120, 36)
+	headers := []string{"NAME", "NAMESPACE", "READY", "STATUS"}
+	app.resourceView.SetTestData(headers, [][]string{{"api", "default", "1/1", "Running"}, {"web", "default", "0/1", "Pending"}})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	if app.currentMode != ModeList || !strings.Contains(app.View(), "Turn the activity feed on") {
+		t.Fatalf("Expected A refused with the feed off, got mode %v", app.currentMode)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if !app.resourceView.ActivityFeedShown() {
+		t.Fatal("Expected a to turn the activity feed on")
+	}
+
+	app.resourceView.SetTestData(headers, [][]string{{"api", "default", "0/1", "CrashLoopBackOff"}, {"web", "default", "1/1", "Running"}})
+	if view := app.View(); !strings.Contains(view, "pod web Ready") || !strings.Contains(view, "pod api CrashLoopBackOff") {
+		t.Fatalf("Expected the transitions under the list, got:\n%s", view)
+	}
+
+	// The newest entry is picked first; Enter selects its pod
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	if app.currentMode != ModeActivity {
+		t.Fatalf("Expected A to pick an activity entry, got mode %v", app.currentMode)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.currentMode != ModeList || app.resourceView.GetSelectedResourceName() != "api" {
+		t.Errorf("Expected Enter to select api in the list, got %q in mode %v", app.resourceView.GetSelectedResourceName(), app.currentMode)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if app.resourceView.ActivityFeedShown() {
+		t.Error("Expected a to turn the activity feed off")
+	}
+}
+
+// TestAccessibleMode tests that the accessible mode draws in plain ASCII
+// without colors and reads out the selected row, and that turning it off
+// gives the terminal its colors back
+func TestAccessibleMode(t *testing.T) {
+	app := createTestApp(t)
+	app.width, app.height = 120, 30
+	app.resourceView.SetSize(120, 26)
+	app.resourceView.SetTestData([]string{"NAME", "STATUS"}, [][]string{{"web", "Running"}})
+	profile := lipgloss.ColorProfile()
+
+	app.config.Accessible = true
+	app.applyRuntimeSettings()
+	defer func() {
+		app.config.Accessible = false
+		app.applyRuntimeSettings()
+		if lipgloss.ColorProfile() != profile {
+			t.Error("Expected the color profile restored")
+		}
+	}()
+	if lipgloss.ColorProfile() != termenv.Ascii {
+		t.Error("Expected colors dropped")
+	}
+
+	boxDrawing := func(r rune) bool { return r >= 0x2500 && r <= 0x259f }
+	view := app.View()
+	if strings.ContainsFunc(view, boxDrawing) {
+		t.Errorf("Expected no box-drawing characters in the list, got:\n%s", view)
+	}
+	if !strings.Contains(view, "> web") || !strings.Contains(view, "Selected 1 of 1: NAME: web, STATUS: Running") {
+		t.Errorf("Expected the selected row marked and read out, got:\n%s", view)
+	}
+
+	// The overlays' borders too, and the setting is listed
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(",")})
+	view = app.View()
+	if app.currentMode != ModeSettings || strings.ContainsFunc(view, boxDrawing) || !strings.Contains(view, "Accessible mode") {
+		t.Errorf("Expected the settings in plain ASCII, got mode %v:\n%s", app.currentMode, view)
+	}
+}
@@ -0,0 +1,265 @@
+package ui
+
+import (
+	"context"
+	"sync"
+
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultBatchConcurrency is how many operations of a batch run at once when
+// the config does not say
+const DefaultBatchConcurrency = 5
+
+// BatchOperation is one operation of a batch action, such as deleting one of
+// several resources
+type BatchOperation struct {
+	Label string // What the operation acts on, shown with its failure
+	Run   func(ctx context.Context) error
+}
+
+// BatchResult is the outcome of a finished operation
+type BatchResult struct {
+	Index int // Into the operations the runner was created with
+	Err   error
+}
+
+type batchOpState int
+
+const (
+	batchQueued batchOpState = iota
+	batchRunning
+	batchSucceeded
+	batchFailed
+	batchDropped
+)
+
+// BatchRunner runs a batch action's operations with bounded concurrency. It
+// can cancel the remainder of a run, which lets in-flight operations finish
+// and drops the queued ones, and retry just the operations that failed.
+// Progress is reported as BatchProgressMsg.
+type BatchRunner struct {
+	title       string
+	ops         []BatchOperation
+	concurrency int
+
+	mu         sync.Mutex
+	states     []batchOpState
+	errs       []error
+	results    []BatchResult // Finished since the last TakeResults
+	active     bool
+	cancelling bool
+	cancel     context.CancelFunc
+
+	// Signalled after every change; holds at most one pending signal
+	updates chan struct{}
+}
+
+// NewBatchRunner creates a runner for ops, all queued. A concurrency below
+// one uses DefaultBatchConcurrency.
+func NewBatchRunner(title string, ops []BatchOperation, concurrency int) *BatchRunner {
+	if concurrency < 1 {
+		concurrency = DefaultBatchConcurrency
+	}
+	return &BatchRunner{
+		title:       title,
+		ops:         ops,
+		concurrency: concurrency,
+		states:      make([]batchOpState, len(ops)),
+		errs:        make([]error, len(ops)),
+		updates:     make(chan struct{}, 1),
+	}
+}
+
+// Start runs the queued operations in the background. Operations get ctx,
+// which cancelling the batch does not cancel. It returns nil when a run is
+// already active or nothing is queued.
+func (b *BatchRunner) Start(ctx context.Context) tea.Cmd {
+	b.mu.Lock()
+	if b.active {
+		b.mu.Unlock()
+		return nil
+	}
+	var queue []int
+	for i, state := range b.states {
+		if state == batchQueued {
+			queue = append(queue, i)
+		}
+	}
+	if len(queue) == 0 {
+		b.mu.Unlock()
+		return nil
+	}
+	dispatchCtx, cancel := context.WithCancel(ctx)
+	b.active = true
+	b.cancelling = false
+	b.cancel = cancel
+	b.mu.Unlock()
+
+	go b.run(ctx, dispatchCtx, queue)
+	return b.WaitForProgress()
+}
+
+// run dispatches queue until it is done or dispatchCtx is cancelled, then
+// waits for the operations in flight
+func (b *BatchRunner) run(ctx, dispatchCtx context.Context, queue []int) {
+	slots := make(chan struct{}, b.concurrency)
+	var wg sync.WaitGroup
+
+	for n, i := range queue {
+		select {
+		case slots <- struct{}{}:
+		case <-dispatchCtx.Done():
+		}
+		if dispatchCtx.Err() != nil {
+			b.drop(queue[n:])
+			break
+		}
+
+		b.setState(i, batchRunning, nil)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := b.ops[i].Run(ctx)
+			<-slots
+			if err != nil {
+				b.setState(i, batchFailed, err)
+			} else {
+				b.setState(i, batchSucceeded, nil)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	b.mu.Lock()
+	b.active = false
+	b.cancel()
+	b.mu.Unlock()
+	b.notify()
+}
+
+// setState records an operation's progress, queueing a result when it has
+// finished
+func (b *BatchRunner) setState(i int, state batchOpState, err error) {
+	b.mu.Lock()
+	b.states[i] = state
+	b.errs[i] = err
+	if state == batchSucceeded || state == batchFailed {
+		b.results = append(b.results, BatchResult{Index: i, Err: err})
+	}
+	b.mu.Unlock()
+	b.notify()
+}
+
+// drop marks queued operations as skipped by a cancel
+func (b *BatchRunner) drop(indexes []int) {
+	b.mu.Lock()
+	for _, i := range indexes {
+		b.states[i] = batchDropped
+	}
+	b.mu.Unlock()
+	b.notify()
+}
+
+func (b *BatchRunner) notify() {
+	select {
+	case b.updates <- struct{}{}:
+	default:
+	}
+}
+
+// WaitForProgress returns a command that waits for the next change
+func (b *BatchRunner) WaitForProgress() tea.Cmd {
+	return func() tea.Msg {
+		<-b.updates
+		return BatchProgressMsg{Runner: b}
+	}
+}
+
+// Cancel stops dispatching the active run. Operations in flight finish;
+// queued ones are dropped.
+func (b *BatchRunner) Cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.active && !b.cancelling {
+		b.cancelling = true
+		b.cancel()
+	}
+}
+
+// RetryFailed queues the failed operations again and runs them. It returns
+// nil when a run is active or nothing failed.
+func (b *BatchRunner) RetryFailed(ctx context.Context) tea.Cmd {
+	b.mu.Lock()
+	if b.active {
+		b.mu.Unlock()
+		return nil
+	}
+	for i, state := range b.states {
+		if state == batchFailed {
+			b.states[i] = batchQueued
+			b.errs[i] = nil
+		}
+	}
+	b.mu.Unlock()
+	return b.Start(ctx)
+}
+
+// Active returns true while operations are queued or in flight
+func (b *BatchRunner) Active() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.active
+}
+
+// TakeResults returns the operations finished since the last call, in the
+// order they finished
+func (b *BatchRunner) TakeResults() []BatchResult {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	results := b.results
+	b.results = nil
+	return results
+}
+
+// Progress returns a snapshot for the results overlay, failures in operation
+// order with their classified errors
+func (b *BatchRunner) Progress() views.BatchProgress {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	p := views.BatchProgress{
+		Title:      b.title,
+		Total:      len(b.ops),
+		Active:     b.active,
+		Cancelling: b.cancelling,
+	}
+	for i, state := range b.states {
+		switch state {
+		case batchQueued:
+			p.Queued++
+		case batchRunning:
+			p.Running++
+		case batchSucceeded:
+			p.Succeeded++
+		case batchFailed:
+			p.Failed++
+			p.Failures = append(p.Failures, views.BatchFailure{
+				Label:   b.ops[i].Label,
+				Kind:    k8s.ClassifyError(b.errs[i]).String(),
+				Message: k8s.UserMessage(b.errs[i]),
+			})
+		case batchDropped:
+			p.Dropped++
+		}
+	}
+	return p
+}
+
+// BatchProgressMsg is sent when an operation of a batch starts or finishes,
+// or a run ends
+type BatchProgressMsg struct {
+	Runner *BatchRunner
+}
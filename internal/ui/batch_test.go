@@ -0,0 +1,264 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+	tea "github.com/charmbracelet/bubbletea"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// runBatch follows a runner's progress commands until its run ends
+func runBatch(t *testing.T, runner *BatchRunner, cmd tea.Cmd) {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	for cmd != nil {
+		done := make(chan tea.Msg, 1)
+		go func(cmd tea.Cmd) { done <- cmd() }(cmd)
+		select {
+		case msg := <-done:
+			if _, ok := msg.(BatchProgressMsg); !ok {
+				t.Fatalf("Expected BatchProgressMsg, got %T", msg)
+			}
+		case <-deadline:
+			t.Fatal("Batch did not finish")
+		}
+		cmd = nil
+		if runner.Active() {
+			cmd = runner.WaitForProgress()
+		}
+	}
+}
+
+func TestBatchRunnerBoundsConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	ops := make([]BatchOperation, 12)
+	for i := range ops {
+		ops[i] = BatchOperation{Label: fmt.Sprintf("op-%d", i), Run: func(ctx context.Context) error {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return nil
+		}}
+	}
+
+	runner := NewBatchRunner("Test", ops, 3)
+	runBatch(t, runner, runner.Start(context.Background()))
+
+	if maxInFlight > 3 {
+		t.Errorf("Expected at most 3 operations at once, saw %d", maxInFlight)
+	}
+	p := runner.Progress()
+	if p.Succeeded != 12 || p.Pending() != 0 || p.Active {
+		t.Errorf("Expected all 12 to succeed, got %+v", p)
+	}
+	if results := runner.TakeResults(); len(results) != 12 {
+		t.Errorf("Expected 12 results, got %d", len(results))
+	}
+	if results := runner.TakeResults(); len(results) != 0 {
+		t.Errorf("Expected results to be taken once, got %d more", len(results))
+	}
+}
+
+func TestBatchRunnerDefaultConcurrency(t *testing.T) {
+	if runner := NewBatchRunner("Test", nil, 0); runner.concurrency != DefaultBatchConcurrency {
+		t.Errorf("Expected concurrency %d, got %d", DefaultBatchConcurrency, runner.concurrency)
+	}
+	runner := NewBatchRunner("Test", nil, 0)
+	if cmd := runner.Start(context.Background()); cmd != nil || runner.Active() {
+		t.Error("Expected an empty batch not to start")
+	}
+}
+
+func TestBatchRunnerCancelDropsQueued(t *testing.T) {
+	release := make(chan struct{})
+	var started sync.WaitGroup
+	started.Add(2)
+	var ran int32
+	ops := make([]BatchOperation, 5)
+	for i := range ops {
+		ops[i] = BatchOperation{Label: fmt.Sprintf("op-%d", i), Run: func(ctx context.Context) error {
+			atomic.AddInt32(&ran, 1)
+			started.Done()
+			<-release
+			return nil
+		}}
+	}
+
+	runner := NewBatchRunner("Test", ops, 2)
+	cmd := runner.Start(context.Background())
+	started.Wait()
+
+	runner.Cancel()
+	p := runner.Progress()
+	if !p.Active || !p.Cancelling || p.Running != 2 {
+		t.Errorf("Expected two in flight while cancelling, got %+v", p)
+	}
+
+	// In-flight operations finish; queued ones never start
+	close(release)
+	runBatch(t, runner, cmd)
+
+	p = runner.Progress()
+	if p.Succeeded != 2 || p.Dropped != 3 || p.Pending() != 0 || p.Active {
+		t.Errorf("Expected 2 done and 3 dropped, got %+v", p)
+	}
+	if ran != 2 {
+		t.Errorf("Expected only the in-flight operations to run, %d ran", ran)
+	}
+
+	// Dropped operations are not failures, so there is nothing to retry
+	if cmd := runner.RetryFailed(context.Background()); cmd != nil {
+		t.Error("Expected nothing to retry after a cancel")
+	}
+}
+
+func TestBatchRunnerCancelDoesNotCancelInFlight(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	runner := NewBatchRunner("Test", []BatchOperation{{Label: "op", Run: func(ctx context.Context) error {
+		close(started)
+		<-release
+		return ctx.Err()
+	}}}, 1)
+
+	cmd := runner.Start(context.Background())
+	<-started
+	runner.Cancel()
+	close(release)
+	runBatch(t, runner, cmd)
+
+	if p := runner.Progress(); p.Succeeded != 1 {
+		t.Errorf("Expected the in-flight operation to finish, got %+v", p)
+	}
+}
+
+func TestBatchRunnerRetriesOnlyFailures(t *testing.T) {
+	var mu sync.Mutex
+	runs := make(map[int]int)
+	failing := map[int]bool{1: true, 3: true}
+
+	ops := make([]BatchOperation, 5)
+	for i := range ops {
+		i := i
+		ops[i] = BatchOperation{Label: fmt.Sprintf("pod/p%d", i), Run: func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			runs[i]++
+			if failing[i] && runs[i] == 1 {
+				return apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, fmt.Sprintf("p%d", i), errors.New("no"))
+			}
+			return nil
+		}}
+	}
+
+	runner := NewBatchRunner("Test", ops, 5)
+	runBatch(t, runner, runner.Start(context.Background()))
+
+	p := runner.Progress()
+	if p.Succeeded != 3 || p.Failed != 2 || len(p.Failures) != 2 {
+		t.Fatalf("Expected 3 done and 2 failed, got %+v", p)
+	}
+	if f := p.Failures[0]; f.Label != "pod/p1" || f.Kind != "Forbidden" || f.Message == "" {
+		t.Errorf("Expected the classified failure of pod/p1 first, got %+v", f)
+	}
+	if results := runner.TakeResults(); len(results) != 5 {
+		t.Errorf("Expected 5 results, got %d", len(results))
+	}
+
+	runBatch(t, runner, runner.RetryFailed(context.Background()))
+
+	p = runner.Progress()
+	if p.Succeeded != 5 || p.Failed != 0 || len(p.Failures) != 0 {
+		t.Errorf("Expected every operation to succeed after the retry, got %+v", p)
+	}
+	results := runner.TakeResults()
+	if len(results) != 2 {
+		t.Fatalf("Expected results for the 2 retried operations, got %v", results)
+	}
+	for _, r := range results {
+		if !failing[r.Index] || r.Err != nil {
+			t.Errorf("Unexpected retry result %+v", r)
+		}
+	}
+	for i, n := range runs {
+		want := 1
+		if failing[i] {
+			want = 2
+		}
+		if n != want {
+			t.Errorf("Expected op %d to run %d time(s), ran %d", i, want, n)
+		}
+	}
+}
+
+func TestBatchOverlayFlow(t *testing.T) {
+	app := createTestApp(t)
+	app.width, app.height = 120, 40
+	app.currentMode = ModeTemplates
+
+	var recorded []BatchResult
+	var dismissed bool
+	attempts := 0
+	ops := []BatchOperation{
+		{Label: "pod/a", Run: func(ctx context.Context) error { return nil }},
+		{Label: "pod/b", Run: func(ctx context.Context) error {
+			attempts++
+			if attempts == 1 {
+				return apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "b")
+			}
+			return nil
+		}},
+	}
+	cmd := app.startBatch("Deleting 2 resource(s)", ops,
+		func(r BatchResult) { recorded = append(recorded, r) },
+		func(p views.BatchProgress) tea.Cmd { dismissed = true; return nil })
+
+	if app.currentMode != ModeBatch {
+		t.Fatalf("Expected the results overlay, got mode %v", app.currentMode)
+	}
+	for cmd != nil {
+		_, cmd = app.Update(cmd())
+	}
+	view := app.View()
+	for _, want := range []string{"failed 1", "pod/b", "NotFound", "Retry failures"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the overlay, got:\n%s", want, view)
+		}
+	}
+	if len(recorded) != 2 {
+		t.Errorf("Expected both results recorded, got %v", recorded)
+	}
+
+	// Retry just the failure
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	for cmd != nil {
+		_, cmd = app.Update(cmd())
+	}
+	if p := app.batchView.Progress(); p.Succeeded != 2 || p.Failed != 0 {
+		t.Errorf("Expected the retry to succeed, got %+v", p)
+	}
+	if len(recorded) != 3 || recorded[2].Index != 1 {
+		t.Errorf("Expected the retry recorded, got %v", recorded)
+	}
+
+	// The overlay stays until dismissed, then returns where it came from
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeTemplates || app.batchView != nil || !dismissed {
+		t.Errorf("Expected Esc to dismiss to the picker, got mode %v", app.currentMode)
+	}
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+)
+
+// SetColumnOrderSaver sets the function used to persist column orders
+// chosen in the column chooser
+func (a *App) SetColumnOrderSaver(saver func(resourceType core.ResourceType, order []string) error) {
+	a.columnOrderSaver = saver
+}
+
+// startColumnOrderView opens the column chooser over the listed columns
+func (a *App) startColumnOrderView() {
+	shown, usual := a.resourceView.ColumnOrder()
+	if len(shown) == 0 {
+		a.resourceView.ShowNotice("No columns to reorder yet")
+		return
+	}
+	a.columnOrderView = views.NewColumnOrderView(string(a.state.CurrentResourceType), shown, usual)
+	a.columnOrderView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeColumns)
+}
+
+// applyColumnOrder shows the current resource type's columns in order, and
+// with save writes it to the config file. The usual order is kept as no
+// order at all, so columns added later take their usual place.
+func (a *App) applyColumnOrder(order []string, save bool) {
+	resourceType := a.state.CurrentResourceType
+	if _, usual := a.resourceView.ColumnOrder(); slices.Equal(order, usual) {
+		order = nil
+	}
+	a.state.SetColumnOrder(resourceType, order)
+
+	if save {
+		if a.columnOrderSaver == nil {
+			a.columnOrderView.SetStatus("No config file to save to")
+			return
+		}
+		if err := a.columnOrderSaver(resourceType, order); err != nil {
+			a.columnOrderView.SetStatus(fmt.Sprintf("Saving failed: %v", err))
+			return
+		}
+		a.resourceView.ShowNotice(fmt.Sprintf("Saved the column order of %s", resourceType))
+	}
+	a.columnOrderView = nil
+	a.setMode(ModeList)
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+)
+
+func TestColumnOrderChooser(t *testing.T) {
+	app := createTestApp(t)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	app.resourceView.SetTestData([]string{"NAME", "READY", "STATUS", "AGE"}, [][]string{{"api", "1/1", "Running", "5m"}})
+
+	var saved []string
+	app.SetColumnOrderSaver(func(resourceType core.ResourceType, order []string) error {
+		if resourceType != core.ResourceTypePod {
+			t.Errorf("Expected the pods order saved, got %s", resourceType)
+		}
+		saved = order
+		return nil
+	})
+
+	press := func(msg tea.KeyMsg) {
+		t.Helper()
+		_, cmd := app.Update(msg)
+		if cmd == nil {
+			return
+		}
+		if chosen := cmd(); chosen != nil {
+			app.Update(chosen)
+		}
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	press(runes("C"))
+	if app.currentMode != ModeColumns {
+		t.Fatalf("Expected the column chooser, got mode %v", app.currentMode)
+	}
+
+	// Move STATUS up to right after NAME, and save
+	press(runes("j"))
+	press(runes("j"))
+	press(tea.KeyMsg{Type: tea.KeyShiftUp})
+	press(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if app.currentMode != ModeList {
+		t.Fatalf("Expected the list back after saving, got mode %v", app.currentMode)
+	}
+	want := []string{"NAME", "STATUS", "READY", "AGE"}
+	if !slices.Equal(saved, want) || !slices.Equal(app.state.GetColumnOrder(core.ResourceTypePod), want) {
+		t.Errorf("Expected %v applied and saved, got %v and %v", want, app.state.GetColumnOrder(core.ResourceTypePod), saved)
+	}
+	view := app.View()
+	if status, ready := strings.Index(view, "STATUS"), strings.Index(view, "READY"); status < 0 || ready < 0 || status > ready {
+		t.Errorf("Expected STATUS shown before READY, got:\n%s", view)
+	}
+
+	// Sorting cycles through the columns in the order shown
+	app.state.SortColumn = "NAME"
+	app.cycleSortColumn()
+	if app.state.SortColumn != "STATUS" {
+		t.Errorf("Expected sorting by STATUS after NAME, got %s", app.state.SortColumn)
+	}
+
+	// Putting back the usual order forgets the user's
+	press(runes("C"))
+	press(runes("r"))
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if order := app.state.GetColumnOrder(core.ResourceTypePod); order != nil {
+		t.Errorf("Expected the usual order, got %v", order)
+	}
+}
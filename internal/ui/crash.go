@@ -0,0 +1,258 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CrashLogLines is how many of the latest log lines a crash report keeps
+const CrashLogLines = 50
+
+// NoCrashReportEnv names the environment variable that, set to anything,
+// stops kubewatch writing a crash report when it panics; the panic and its
+// stack are printed instead
+const NoCrashReportEnv = "KUBEWATCH_NO_CRASH_REPORT"
+
+// secretPattern matches what looks like a credential in a log line, keeping
+// the part before the value so the line still reads
+var secretPattern = regexp.MustCompile(`(?i)((?:token|password|secret|authorization)\s*[=:]\s*(?:bearer\s+|basic\s+)?|bearer\s+)\S+`)
+
+// redactSecrets masks what looks like a credential in text
+func redactSecrets(text string) string {
+	return secretPattern.ReplaceAllString(text, "${1}[redacted]")
+}
+
+// LogTail keeps the latest lines written to it, for a crash report. It is
+// written to alongside the log's usual output.
+type LogTail struct {
+	mu      sync.Mutex
+	lines   []string
+	partial string
+	max     int
+}
+
+// NewLogTail creates a log tail keeping the latest max lines
+func NewLogTail(max int) *LogTail {
+	return &LogTail{max: max}
+}
+
+// Write records the complete lines of p; a line without its newline yet
+// waits for the rest
+func (t *LogTail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	text := t.partial + string(p)
+	lines := strings.Split(text, "\n")
+	t.partial = lines[len(lines)-1]
+	t.lines = append(t.lines, lines[:len(lines)-1]...)
+	if len(t.lines) > t.max {
+		t.lines = append([]string(nil), t.lines[len(t.lines)-t.max:]...)
+	}
+	return len(p), nil
+}
+
+// Lines returns the lines kept, oldest first
+func (t *LogTail) Lines() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.lines...)
+}
+
+// crashDescriber is a model that can say what it was showing when it
+// panicked
+type crashDescriber interface {
+	crashState() []string
+}
+
+// crashState describes what the app was showing, for a crash report. It
+// leaves out anything typed or listed, which may be sensitive.
+func (a *App) crashState() []string {
+	resourceType := ""
+	if a.state != nil {
+		resourceType = string(a.state.CurrentResourceType)
+	}
+	return []string{
+		"Mode: " + strings.TrimPrefix(fmt.Sprintf("%T", a.modes[a.currentMode]), "*ui."),
+		"Resource type: " + resourceType,
+		fmt.Sprintf("Terminal: %dx%d", a.width, a.height),
+	}
+}
+
+// CrashGuard runs a model so that a panic in it, whether while updating,
+// rendering or running a command, leaves a usable terminal and a crash
+// report rather than a broken screen and a lost stack. The program must be
+// started with tea.WithoutCatchPanics, and SetTerminalRestore given the
+// program's ReleaseTerminal once it exists.
+type CrashGuard struct {
+	model   tea.Model
+	version string
+	logs    *LogTail
+	dir     string // Where reports are written; empty when disabled
+
+	restore func() error
+	stderr  io.Writer
+	exit    func(code int)
+	once    sync.Once
+}
+
+// NewCrashGuard guards model, reporting version and the lines of logs in
+// any crash report
+func NewCrashGuard(model tea.Model, version string, logs *LogTail) *CrashGuard {
+	g := &CrashGuard{
+		model:   model,
+		version: version,
+		logs:    logs,
+		stderr:  os.Stderr,
+		exit:    os.Exit,
+	}
+	if os.Getenv(NoCrashReportEnv) == "" {
+		g.dir = CrashReportDir()
+	}
+	return g
+}
+
+// CrashReportDir returns where crash reports are written, under the user's
+// cache directory
+func CrashReportDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "kubewatch", "crashes")
+}
+
+// SetTerminalRestore sets how the terminal is given back after a panic
+func (g *CrashGuard) SetTerminalRestore(restore func() error) {
+	g.restore = restore
+}
+
+// Init initializes the guarded model
+func (g *CrashGuard) Init() (cmd tea.Cmd) {
+	defer func() {
+		if r := recover(); r != nil {
+			g.crash(r, debug.Stack())
+			cmd = tea.Quit
+		}
+	}()
+	return g.guard(g.model.Init())
+}
+
+// Update updates the guarded model; after a panic the program quits
+func (g *CrashGuard) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	defer func() {
+		if r := recover(); r != nil {
+			g.crash(r, debug.Stack())
+			model, cmd = g, tea.Quit
+		}
+	}()
+	g.model, cmd = g.model.Update(msg)
+	return g, g.guard(cmd)
+}
+
+// View renders the guarded model, or nothing after a panic
+func (g *CrashGuard) View() (view string) {
+	defer func() {
+		if r := recover(); r != nil {
+			g.crash(r, debug.Stack())
+			view = ""
+		}
+	}()
+	return g.model.View()
+}
+
+// guard wraps a command, and the commands of a batch it returns, so a panic
+// while it runs is reported like one in the model
+func (g *CrashGuard) guard(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				g.crash(r, debug.Stack())
+				msg = nil
+			}
+		}()
+		msg = cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			guarded := make(tea.BatchMsg, len(batch))
+			for i, c := range batch {
+				guarded[i] = g.guard(c)
+			}
+			msg = guarded
+		}
+		return msg
+	}
+}
+
+// crash handles the first panic: it writes the report, gives the terminal
+// back, says where the report is and exits
+func (g *CrashGuard) crash(r interface{}, stack []byte) {
+	g.once.Do(func() {
+		path, err := g.writeReport(r, stack, time.Now())
+		if g.restore != nil {
+			_ = g.restore()
+		}
+
+		fmt.Fprintln(g.stderr, "Sorry, kubewatch crashed.")
+		switch {
+		case g.dir == "":
+			fmt.Fprintf(g.stderr, "panic: %s\n\n%s", redactSecrets(fmt.Sprint(r)), stack)
+		case err != nil:
+			fmt.Fprintf(g.stderr, "Could not write a crash report: %v\npanic: %s\n\n%s", err, redactSecrets(fmt.Sprint(r)), stack)
+		default:
+			fmt.Fprintf(g.stderr, "A crash report is at %s; please attach it to a bug report.\n", path)
+		}
+		g.exit(1)
+	})
+}
+
+// writeReport writes a crash report of panic r and returns its path. It has
+// the stack, version and what the model was showing, and the latest log
+// lines with anything like a credential masked. Nothing is written when
+// reports are disabled.
+func (g *CrashGuard) writeReport(r interface{}, stack []byte, now time.Time) (string, error) {
+	if g.dir == "" {
+		return "", nil
+	}
+
+	var b strings.Builder
+	b.WriteString("kubewatch crash report\n")
+	fmt.Fprintf(&b, "Time: %s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Version: %s\n", g.version)
+	fmt.Fprintf(&b, "Go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	if describer, ok := g.model.(crashDescriber); ok {
+		for _, line := range describer.crashState() {
+			b.WriteString(line + "\n")
+		}
+	}
+	fmt.Fprintf(&b, "\npanic: %s\n\n", redactSecrets(fmt.Sprint(r)))
+	b.Write(stack)
+
+	if g.logs != nil {
+		b.WriteString("\nLast log lines:\n")
+		for _, line := range g.logs.Lines() {
+			b.WriteString(redactSecrets(line) + "\n")
+		}
+	}
+
+	if err := os.MkdirAll(g.dir, 0o700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(g.dir, fmt.Sprintf("crash-%s.txt", now.Format("20060102-150405")))
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
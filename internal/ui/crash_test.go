@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// panickingView is the app with a rendering bug
+type panickingView struct {
+	*App
+}
+
+func (v panickingView) View() string {
+	var rows []string
+	return rows[3]
+}
+
+// testCrashGuard guards model, writing reports to a temporary directory and
+// recording the terminal restore and the exit instead of exiting
+func testCrashGuard(t *testing.T, model tea.Model, logs *LogTail) (guard *CrashGuard, restored *bool, code *int, stderr *bytes.Buffer) {
+	t.Helper()
+	guard = NewCrashGuard(model, "v1.2.3", logs)
+	if guard.dir != "" {
+		guard.dir = t.TempDir()
+	}
+	restored, code, stderr = new(bool), new(int), &bytes.Buffer{}
+	guard.SetTerminalRestore(func() error {
+		*restored = true
+		return nil
+	})
+	guard.stderr = stderr
+	guard.exit = func(c int) { *code = c }
+	return guard, restored, code, stderr
+}
+
+// TestCrashGuardReportsViewPanic tests that a panic while rendering gives
+// the terminal back and leaves a report of what happened, without secrets
+func TestCrashGuardReportsViewPanic(t *testing.T) {
+	app := createTestApp(t)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	logs := NewLogTail(CrashLogLines)
+	fmt.Fprintf(logs, "Refreshing pods\nAuthorization: Bearer abc123\nusing token=s3cr3t\n")
+
+	guard, restored, code, stderr := testCrashGuard(t, panickingView{app}, logs)
+	if view := guard.View(); view != "" {
+		t.Errorf("Expected nothing rendered after the panic, got %q", view)
+	}
+
+	if !*restored {
+		t.Error("Expected the terminal restored")
+	}
+	if *code != 1 {
+		t.Errorf("Expected exit code 1, got %d", *code)
+	}
+
+	reports, _ := filepath.Glob(filepath.Join(guard.dir, "crash-*.txt"))
+	if len(reports) != 1 {
+		t.Fatalf("Expected one crash report, got %v", reports)
+	}
+	if !strings.Contains(stderr.String(), reports[0]) {
+		t.Errorf("Expected the report's path printed, got %q", stderr.String())
+	}
+	data, err := os.ReadFile(reports[0])
+	if err != nil {
+		t.Fatalf("Failed to read the report: %v", err)
+	}
+	report := string(data)
+	for _, want := range []string{
+		"Version: v1.2.3",
+		"Mode: ListMode",
+		"Resource type: Pods",
+		"Terminal: 120x30",
+		"index out of range",
+		"panickingView.View",
+		"Refreshing pods",
+		"Bearer [redacted]",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected %q in the report:\n%s", want, report)
+		}
+	}
+	for _, secret := range []string{"abc123", "s3cr3t"} {
+		if strings.Contains(report, secret) {
+			t.Errorf("Expected %q kept out of the report", secret)
+		}
+	}
+}
+
+// TestCrashGuardReportsCommandPanic tests that a panic in a command, which
+// runs on its own goroutine, is reported too, as is one in a batch
+func TestCrashGuardReportsCommandPanic(t *testing.T) {
+	app := createTestApp(t)
+	guard, restored, code, _ := testCrashGuard(t, app, nil)
+
+	panicking := func() tea.Msg { panic("command failed") }
+	cmd := guard.guard(tea.Batch(func() tea.Msg { return nil }, panicking))
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Expected the batch passed through")
+	}
+	for _, c := range batch {
+		if c != nil {
+			c()
+		}
+	}
+
+	if !*restored || *code != 1 {
+		t.Errorf("Expected the terminal restored and exit code 1, got %v and %d", *restored, *code)
+	}
+	reports, _ := filepath.Glob(filepath.Join(guard.dir, "crash-*.txt"))
+	if len(reports) != 1 {
+		t.Fatalf("Expected one crash report, got %v", reports)
+	}
+}
+
+// TestCrashGuardWithoutReport tests that the environment variable stops the
+// report, leaving the panic printed
+func TestCrashGuardWithoutReport(t *testing.T) {
+	t.Setenv(NoCrashReportEnv, "1")
+	guard, restored, _, stderr := testCrashGuard(t, panickingView{createTestApp(t)}, nil)
+	if guard.dir != "" {
+		t.Fatalf("Expected no report directory, got %q", guard.dir)
+	}
+
+	guard.View()
+	if !*restored {
+		t.Error("Expected the terminal restored")
+	}
+	if !strings.Contains(stderr.String(), "index out of range") || strings.Contains(stderr.String(), "crash report is at") {
+		t.Errorf("Expected the panic printed instead of a report, got %q", stderr.String())
+	}
+}
+
+// TestLogTail tests that the log tail keeps the latest complete lines
+func TestLogTail(t *testing.T) {
+	logs := NewLogTail(3)
+	fmt.Fprint(logs, "one\ntwo\nthr")
+	fmt.Fprint(logs, "ee\nfour\nfive\n")
+	if got := strings.Join(logs.Lines(), ","); got != "three,four,five" {
+		t.Errorf("Expected the last three lines, got %q", got)
+	}
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+)
+
+// pendingDeletion is a delete awaiting confirmation. What is deleted, and
+// with which client, is captured when the dialog opens, so a refresh that
+// reorders the rows meanwhile cannot change it.
+type pendingDeletion struct {
+	kind   core.ResourceType
+	ref    core.ResourceRef
+	client *k8s.Client
+}
+
+// showDeleteConfirmation asks for confirmation before deleting the resource
+// selected in listView
+func (a *App) showDeleteConfirmation() tea.Cmd {
+	ref := a.listView().SelectedResourceRef()
+	if ref.IsZero() {
+		return nil
+	}
+	kind := a.listState().CurrentResourceType
+	a.pendingDelete = &pendingDeletion{
+		kind:   kind,
+		ref:    ref,
+		client: a.clientForContext(a.getSelectedResourceContext()),
+	}
+
+	// Remove the 's' at the end for singular form
+	resourceType := strings.ToLower(strings.TrimSuffix(string(kind), "s"))
+
+	// Name the namespace when the list spans several
+	resourceName := ref.Name
+	if namespace := a.listState().CurrentNamespace; namespace == "" || namespace == "all" {
+		resourceName = ref.String()
+	}
+
+	message := fmt.Sprintf("Are you sure you want to delete %s '%s'?", resourceType, resourceName)
+	if a.comparisonView != nil {
+		// Both panes may list the name; say which context it goes from
+		message = fmt.Sprintf("Are you sure you want to delete %s '%s' from %s?",
+			resourceType, resourceName, a.comparisonView.FocusedContext())
+	}
+	warning := core.ProtectedDeletionWarning(kind)
+	if warning != "" {
+		message += "\n\n" + warning
+	}
+	a.confirmView = views.NewConfirmView("⚠️  Confirm Deletion", message)
+	a.confirmView.SetSize(a.width, a.viewHeight())
+	a.confirmView.SetConfirmText("Delete")
+	a.confirmView.SetCancelText("Cancel")
+	if warning != "" {
+		a.confirmView.RequireInput(ref.Name)
+	}
+
+	return nil
+}
+
+// deleteResource deletes a confirmed resource, reporting the accepted
+// delete with views.DeleteRequestedMsg so it is followed until it is gone
+func (a *App) deleteResource(deletion *pendingDeletion) tea.Cmd {
+	ctx := a.ctx
+	return func() tea.Msg {
+		client, name, namespace := deletion.client, deletion.ref.Name, deletion.ref.Namespace
+		if client == nil {
+			return errMsg{fmt.Errorf("cannot delete %s: not connected to its cluster", name)}
+		}
+
+		var err error
+		switch deletion.kind {
+		case core.ResourceTypePod:
+			err = client.DeletePod(ctx, namespace, name)
+		case core.ResourceTypeDeployment:
+			err = client.DeleteDeployment(ctx, namespace, name)
+		case core.ResourceTypeStatefulSet:
+			err = client.DeleteStatefulSet(ctx, namespace, name)
+		case core.ResourceTypeService:
+			err = client.DeleteService(ctx, namespace, name)
+		case core.ResourceTypeIngress:
+			err = client.DeleteIngress(ctx, namespace, name)
+		case core.ResourceTypeGateway:
+			err = client.DeleteGateway(ctx, namespace, name)
+		case core.ResourceTypeHTTPRoute:
+			err = client.DeleteHTTPRoute(ctx, namespace, name)
+		case core.ResourceTypeConfigMap:
+			err = client.DeleteConfigMap(ctx, namespace, name)
+		case core.ResourceTypeSecret:
+			err = client.DeleteSecret(ctx, namespace, name)
+		case core.ResourceTypeServiceAccount:
+			err = client.DeleteServiceAccount(ctx, namespace, name)
+		case core.ResourceTypeRole:
+			err = client.DeleteRole(ctx, namespace, name)
+		case core.ResourceTypeClusterRole:
+			err = client.DeleteClusterRole(ctx, name)
+		case core.ResourceTypeRoleBinding:
+			err = client.DeleteRoleBinding(ctx, namespace, name)
+		case core.ResourceTypeClusterRoleBinding:
+			err = client.DeleteClusterRoleBinding(ctx, name)
+		case core.ResourceTypeNode:
+			err = client.DeleteNode(ctx, name)
+		case core.ResourceTypeJob:
+			err = client.DeleteJob(ctx, namespace, name)
+		case core.ResourceTypeCronJob:
+			err = client.DeleteCronJob(ctx, namespace, name)
+		default:
+			err = fmt.Errorf("cannot delete %s: %s cannot be deleted from kubewatch", name, strings.ToLower(string(deletion.kind)))
+		}
+		if err != nil {
+			return errMsg{err}
+		}
+		return views.DeleteRequestedMsg{Kind: deletion.kind, Ref: deletion.ref}
+	}
+}
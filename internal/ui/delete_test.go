@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/client-go/rest"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+)
+
+// deleteTestApp is a test app listing the pods web-1 and web-2, with a
+// client of a server recording the deletes sent to it
+func deleteTestApp(t *testing.T) (*App, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var deletes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			mu.Lock()
+			deletes = append(deletes, r.URL.Path)
+			mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success"}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+
+	app := createTestApp(t)
+	app.width, app.height = 140, 40
+	app.k8sClient = client
+	app.state.Deletions = core.NewDeletionTracker()
+	app.resourceView.SetTestData([]string{"NAME", "STATUS"}, [][]string{{"web-1", "Running"}, {"web-2", "Running"}})
+	app.resourceView.SetSelectedRow(0)
+	return app, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), deletes...)
+	}
+}
+
+func TestDeleteFromKeyToAPI(t *testing.T) {
+	app, deletes := deleteTestApp(t)
+
+	// d describes; only Del and D delete
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if app.currentMode == ModeConfirmDialog || app.pendingDelete != nil {
+		t.Fatalf("Expected d to describe, got mode %v", app.currentMode)
+	}
+	app.setMode(ModeList)
+
+	for _, keyMsg := range []tea.KeyMsg{{Type: tea.KeyDelete}, {Type: tea.KeyRunes, Runes: []rune("D")}} {
+		app.Update(keyMsg)
+		if app.currentMode != ModeConfirmDialog || app.pendingDelete == nil {
+			t.Fatalf("Expected %s to ask before deleting, got mode %v", keyMsg, app.currentMode)
+		}
+		app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		if app.currentMode != ModeList || app.pendingDelete != nil || len(deletes()) != 0 {
+			t.Fatalf("Expected the delete cancelled, got mode %v and %v", app.currentMode, deletes())
+		}
+	}
+
+	// The rows change under the open dialog; web-1, as asked about, goes
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	app.resourceView.SetTestData([]string{"NAME", "STATUS"}, [][]string{{"web-0", "Running"}, {"web-1", "Running"}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRight})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected the delete sent")
+	}
+	msg := cmd()
+	requested, ok := msg.(views.DeleteRequestedMsg)
+	if !ok || requested.Ref.Name != "web-1" || requested.Kind != core.ResourceTypePod {
+		t.Fatalf("Expected the delete of web-1 accepted, got %#v", msg)
+	}
+	if got := deletes(); len(got) != 1 || got[0] != "/api/v1/namespaces/default/pods/web-1" {
+		t.Errorf("Expected web-1 deleted, got %v", got)
+	}
+
+	app.Update(msg)
+	if _, ok := app.state.Deletions.Get(core.ResourceTypePod, "", "default", "web-1"); !ok {
+		t.Error("Expected the delete followed until web-1 is gone")
+	}
+}
+
+func TestDeleteWithoutClientFails(t *testing.T) {
+	app, _ := deleteTestApp(t)
+	app.k8sClient = nil
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	app.Update(tea.KeyMsg{Type: tea.KeyRight})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app.Update(cmd())
+	if view := app.resourceView.View(); !strings.Contains(view, "not connected") {
+		t.Errorf("Expected the delete refused without a client, got:\n%s", view)
+	}
+}
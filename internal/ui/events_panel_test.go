@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/client-go/rest"
+
+	"github.com/HamStudy/kubewatch/internal/k8s"
+)
+
+// eventsServer serves one warning about pod web, with the UID test rows
+// get, and records the field selectors events are listed with
+func eventsServer(t *testing.T) (*k8s.Client, *[]string) {
+	t.Helper()
+	var selectors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/events":
+			selectors = append(selectors, r.URL.Query().Get("fieldSelector"))
+			w.Write([]byte(`{"kind":"EventList","apiVersion":"v1","items":[{
+				"metadata":{"name":"web.1","namespace":"default","uid":"e1"},
+				"involvedObject":{"kind":"Pod","name":"web","namespace":"default","uid":"test-uid-web"},
+				"reason":"BackOff","message":"Back-off restarting failed container","type":"Warning","count":4,
+				"lastTimestamp":"2024-01-01T00:00:00Z"}]}`))
+		case "/apis/events.k8s.io/v1/namespaces/default/events":
+			w.Write([]byte(`{"kind":"EventList","apiVersion":"events.k8s.io/v1","items":[]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	return client, &selectors
+}
+
+func TestEventsPanel(t *testing.T) {
+	client, selectors := eventsServer(t)
+	app := createTestApp(t)
+	app.width, app.height = 140, 40
+	app.k8sClient = client
+	app.resourceView.SetTestData([]string{"NAME", "READY"}, [][]string{{"web", "1/1"}})
+	app.resourceView.SetSelectedRow(0)
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if app.currentMode != ModeEvents || cmd == nil {
+		t.Fatalf("Expected v to open the events, got mode %v", app.currentMode)
+	}
+	app.Update(cmd())
+	view := app.View()
+	for _, want := range []string{"Events: Pod default/web", "BackOff", "Back-off restarting failed container"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the events, got:\n%s", want, view)
+		}
+	}
+	if len(*selectors) != 1 || !strings.Contains((*selectors)[0], "involvedObject.uid=test-uid-web") {
+		t.Errorf("Expected the pod's events listed, got selectors %q", *selectors)
+	}
+
+	// r reloads them, as each refresh interval does
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	app.Update(cmd())
+	if len(*selectors) != 2 {
+		t.Errorf("Expected r to reload the events, got %d lists", len(*selectors))
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList || app.eventsView != nil {
+		t.Errorf("Expected Esc to close the events, got mode %v", app.currentMode)
+	}
+
+	// With nothing selected, the namespace's events
+	app.resourceView.SetTestData([]string{"NAME", "READY"}, nil)
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	app.Update(cmd())
+	if view := app.View(); !strings.Contains(view, "Events in namespace default") || !strings.Contains(view, "Pod/web") {
+		t.Errorf("Expected the namespace's events, got:\n%s", view)
+	}
+	if last := (*selectors)[len(*selectors)-1]; last != "" {
+		t.Errorf("Expected the namespace's events listed unfiltered, got %q", last)
+	}
+}
@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+)
+
+// hintBarMinHeight is the shortest terminal the key hint bar is shown on;
+// shorter ones need every line for the view
+const hintBarMinHeight = 20
+
+// maxHints bounds the hints in the bar, the last of which points at help
+const maxHints = 7
+
+// hint names a key binding of the current mode worth pointing out, and what
+// to call it in the bar
+type hint struct {
+	binding string // Name in the mode's GetKeyBindings
+	label   string
+}
+
+// modeHints are the hints for each mode that has the bar, most useful
+// first. The list's hints depend on what is selected, so they are worked
+// out in listHints. Overlays, describe and the filter bar list their keys
+// themselves and get no bar.
+var modeHints = map[ScreenModeType][]hint{
+	ModeLog: {
+		{"follow", "follow"}, {"search", "search"}, {"container", "containers"},
+		{"records", "records"}, {"escape", "close"},
+	},
+}
+
+// hintBarShown reports whether the key hint bar takes the last line: it is
+// on unless turned off, in the list and logs, on terminals tall enough to
+// spare the line
+func (a *App) hintBarShown() bool {
+	if a.config.HideHints || a.height < hintBarMinHeight {
+		return false
+	}
+	_, listed := modeHints[a.currentMode]
+	return listed || a.currentMode == ModeList
+}
+
+// viewHeight is the height views get, the terminal's less the hint bar
+func (a *App) viewHeight() int {
+	if a.hintBarShown() {
+		return a.height - 1
+	}
+	return a.height
+}
+
+// resize lays every view out for the terminal's size. The list, log and
+// filter bar share the screen; overlays take all of it. Every live overlay
+// is resized, not just the one on screen, so each is laid out for the new
+// size when it is shown again.
+func (a *App) resize() {
+	if !a.ready {
+		return
+	}
+	height := a.viewHeight()
+	a.resourceView.SetSize(a.width, height)
+	a.logView.SetSize(a.width, height/2)
+	if a.filterBar != nil {
+		a.filterBar.SetSize(a.width, 1)
+	}
+	for _, view := range a.liveOverlays() {
+		view.SetSize(a.width, height)
+	}
+	if a.currentMode == ModeFilter && a.filterBar != nil {
+		// The filter bar takes the line under the list it filters
+		a.filteredView().SetSize(a.width, height-1)
+	}
+}
+
+// filteredView is the list the filter bar is shown under
+func (a *App) filteredView() sizedView {
+	switch {
+	case a.comparisonView != nil:
+		return a.comparisonView
+	case a.splitView != nil:
+		return a.splitView
+	default:
+		return a.resourceView
+	}
+}
+
+// hints returns the hints for the current mode and selection
+func (a *App) hints() []hint {
+	hints := append([]hint(nil), modeHints[a.currentMode]...)
+	if a.currentMode == ModeList {
+		hints = a.listHints()
+	}
+	if len(hints) > maxHints-1 {
+		hints = hints[:maxHints-1]
+	}
+	return append(hints, hint{"help", "more"})
+}
+
+// listHints picks the list's hints: what there is to leave first, then what
+// can be done with the selected resource, or how to find one
+func (a *App) listHints() []hint {
+	var hints []hint
+	if a.namespaceLost != "" {
+		hints = append(hints, hint{"allns", "all namespaces"}, hint{"namespace", "namespace"})
+	}
+	if expression, _ := a.state.GetFilter(); expression != "" {
+		hints = append(hints, hint{"escape", "clear filter"})
+	}
+	if a.updateVersion != "" {
+		hints = append(hints, hint{"dismiss", "dismiss notice"})
+	}
+
+	if a.resourceView.GetSelectedResourceName() == "" {
+		return append(hints, hint{"namespace", "namespace"}, hint{"tab", "next type"},
+			hint{"filter", "filter"}, hint{"create", "create"})
+	}
+	if core.LogTargetFor(a.state.CurrentResourceType).Applies() {
+		hints = append(hints, hint{"logs", "logs"})
+	}
+	return append(hints, hint{"describe", "describe"}, hint{"delete", "delete"},
+		hint{"actions", "actions"}, hint{"filter", "filter"}, hint{"copy", "copy"})
+}
+
+// renderHintBar renders the hints as "key label" pairs, with the keys the
+// mode's bindings give so the bar always shows what is actually bound
+func (a *App) renderHintBar() string {
+	mode, ok := a.modes[a.currentMode]
+	if !ok {
+		return ""
+	}
+	bindings := mode.GetKeyBindings()
+
+	keyStyle := lipgloss.NewStyle().Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	seen := make(map[string]bool)
+	var parts []string
+	for _, h := range a.hints() {
+		binding, ok := bindings[h.binding]
+		if !ok || seen[h.binding] || !binding.Key.Enabled() {
+			continue
+		}
+		seen[h.binding] = true
+		parts = append(parts, keyStyle.Render(binding.Key.Help().Key)+" "+labelStyle.Render(h.label))
+	}
+	bar := strings.Join(parts, labelStyle.Render(" · "))
+	return lipgloss.NewStyle().MaxWidth(a.width).Render(bar)
+}
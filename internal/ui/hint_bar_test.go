@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+)
+
+// lastLine returns the last line of a rendered view
+func lastLine(view string) string {
+	lines := strings.Split(view, "\n")
+	return lines[len(lines)-1]
+}
+
+func TestHintBar(t *testing.T) {
+	app := createTestApp(t)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+
+	// Nothing selected: how to find something
+	view := app.View()
+	if lines := strings.Count(view, "\n") + 1; lines != 30 {
+		t.Errorf("Expected the view to fill the terminal, got %d lines", lines)
+	}
+	if bar := lastLine(view); !strings.Contains(bar, "n namespace") || !strings.Contains(bar, "? more") {
+		t.Errorf("Expected hints for finding resources, got %q", bar)
+	}
+
+	// A pod selected: what to do with it
+	headers := []string{"NAME", "NAMESPACE", "READY", "STATUS"}
+	app.resourceView.SetTestData(headers, [][]string{{"api", "default", "1/1", "Running"}})
+	bar := lastLine(app.View())
+	if !strings.Contains(bar, "l logs · d describe · Del/D delete") {
+		t.Errorf("Expected hints for the selected pod, got %q", bar)
+	}
+	if strings.Count(bar, "·") > maxHints-1 {
+		t.Errorf("Expected at most %d hints, got %q", maxHints, bar)
+	}
+
+	// Types without logs are not offered them
+	app.state.SetResourceType(core.ResourceTypeConfigMap)
+	if bar := lastLine(app.View()); strings.Contains(bar, "logs") {
+		t.Errorf("Expected no logs hint for configmaps, got %q", bar)
+	}
+	app.state.SetResourceType(core.ResourceTypePod)
+
+	// A filter in effect puts clearing it first
+	app.state.SetFilter("status=Running", "")
+	if bar := lastLine(app.View()); !strings.HasPrefix(bar, "Esc clear filter") {
+		t.Errorf("Expected clearing the filter hinted first, got %q", bar)
+	}
+	app.state.ClearFilter()
+
+	// Logs have hints of their own; overlays list their keys themselves
+	app.setMode(ModeLog)
+	if bar := lastLine(app.View()); !strings.Contains(bar, "f follow") || !strings.Contains(bar, "Esc/q close") {
+		t.Errorf("Expected log hints, got %q", bar)
+	}
+	app.setMode(ModeHelp)
+	if strings.Contains(app.View(), "? more") {
+		t.Error("Expected no hint bar over help")
+	}
+	app.setMode(ModeList)
+
+	// Short terminals and the setting turn the bar off, giving its line back
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: hintBarMinHeight - 1})
+	if strings.Contains(app.View(), "? more") || app.viewHeight() != hintBarMinHeight-1 {
+		t.Error("Expected no hint bar on a short terminal")
+	}
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	if app.viewHeight() != 29 {
+		t.Errorf("Expected views a line shorter for the bar, got %d", app.viewHeight())
+	}
+	setting, _ := core.LookupSetting("hintBar")
+	if err := setting.Set(app.config, "0"); err != nil {
+		t.Fatal(err)
+	}
+	app.applyRuntimeSettings()
+	if strings.Contains(app.View(), "? more") || app.viewHeight() != 30 {
+		t.Error("Expected the setting to turn the hint bar off")
+	}
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/k8s"
+)
+
+// SetSkippedKubeconfigs notes the kubeconfig files left out as broken, shown
+// under the header until dismissed with Ctrl+X
+func (a *App) SetSkippedKubeconfigs(skipped []k8s.SkippedKubeconfig) {
+	a.skippedKubeconfigs = skipped
+	a.resourceView.SetKubeconfigNotice(a.kubeconfigNotice())
+}
+
+// kubeconfigNotice is the line naming the skipped kubeconfig files and why
+// each was skipped, or "" when none were
+func (a *App) kubeconfigNotice() string {
+	if len(a.skippedKubeconfigs) == 0 {
+		return ""
+	}
+	files := make([]string, len(a.skippedKubeconfigs))
+	for i, s := range a.skippedKubeconfigs {
+		files[i] = s.String()
+	}
+	return fmt.Sprintf("⚠ Skipped kubeconfig %s (Ctrl+X dismisses)", strings.Join(files, "; "))
+}
+
+// dismissKubeconfigNotice hides the skipped kubeconfig files notice. It
+// reports whether there was a notice to dismiss.
+func (a *App) dismissKubeconfigNotice() bool {
+	if len(a.skippedKubeconfigs) == 0 {
+		return false
+	}
+	a.skippedKubeconfigs = nil
+	a.resourceView.SetKubeconfigNotice("")
+	return true
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startLabelSelectorBar opens the label selector bar below the list,
+// starting from the current selector
+func (a *App) startLabelSelectorBar() {
+	a.labelSelectorBar = views.NewLabelSelectorBar(a.state.GetLabelSelector())
+	a.labelSelectorBar.SetSize(a.width, 1)
+	a.setMode(ModeLabelSelector)
+}
+
+// applyLabelSelector narrows the lists to the objects the label selector bar's
+// selector matches, or lists them all for an empty one, and relists
+func (a *App) applyLabelSelector() tea.Cmd {
+	if a.labelSelectorBar == nil || !a.labelSelectorBar.Submit() {
+		return nil
+	}
+	if err := a.state.SetLabelSelector(a.labelSelectorBar.Selector()); err != nil {
+		a.labelSelectorBar.SetStatus(err.Error())
+		return nil
+	}
+	a.closeLabelSelectorBar()
+	return a.resourceView.RefreshResources()
+}
+
+// closeLabelSelectorBar returns to the list and gives it back the bar's line
+func (a *App) closeLabelSelectorBar() {
+	a.labelSelectorBar = nil
+	a.resourceView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeList)
+}
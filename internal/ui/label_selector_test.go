@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+)
+
+func TestLabelSelectorBar(t *testing.T) {
+	app := createTestApp(t)
+	app.Update(tea.WindowSizeMsg{Width: 160, Height: 30})
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	app.Update(runes("L"))
+	if app.currentMode != ModeLabelSelector {
+		t.Fatalf("Expected L to open the label selector bar, got mode %v", app.currentMode)
+	}
+
+	// A selector that does not parse is shown as such, not applied
+	app.Update(runes("app in (web"))
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.currentMode != ModeLabelSelector || app.state.GetLabelSelector() != "" {
+		t.Fatalf("Expected the bar kept open and no selector set, got mode %v and %q", app.currentMode, app.state.GetLabelSelector())
+	}
+	if view := app.View(); !strings.Contains(view, "Labels: app in (web") || !strings.Contains(view, "✗") {
+		t.Errorf("Expected the selector's error shown, got:\n%s", view)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	app.Update(runes("app=frontend"))
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.currentMode != ModeList || app.state.GetLabelSelector() != "app=frontend" {
+		t.Fatalf("Expected app=frontend applied, got mode %v and %q", app.currentMode, app.state.GetLabelSelector())
+	}
+	if header := app.View(); !strings.Contains(header, "Labels: app=frontend") {
+		t.Errorf("Expected the selector in the header, got:\n%s", header)
+	}
+
+	// Types without labels support show it is not applied to them
+	app.state.SetResourceType(core.ResourceTypeConfigMap)
+	if header := app.View(); !strings.Contains(header, "Labels: app=frontend (not applied)") {
+		t.Errorf("Expected the selector shown as not applied, got:\n%s", header)
+	}
+
+	// An empty selector lists everything again
+	app.Update(runes("L"))
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.state.GetLabelSelector() != "" {
+		t.Errorf("Expected the selector cleared, got %q", app.state.GetLabelSelector())
+	}
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/testutil/fixtures"
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+)
+
+// logPickerTestApp is a test app listing pod, whose logs a test API server
+// serves, recording the containers asked for
+func logPickerTestApp(t *testing.T, pod v1.Pod) (*App, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var containers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		containers = append(containers, r.URL.Query().Get("container"))
+		mu.Unlock()
+		w.Write([]byte("started\n"))
+	}))
+	t.Cleanup(server.Close)
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+
+	app := createTestApp(t)
+	app.width, app.height = 120, 30
+	app.k8sClient = client
+	app.state.UpdatePods([]v1.Pod{pod})
+	app.resourceView.SetTestData([]string{"NAME", "STATUS"}, [][]string{{pod.Name, string(pod.Status.Phase)}})
+	app.resourceView.SetSelectedRow(0)
+	return app, &containers
+}
+
+func TestLogsPickContainerFirst(t *testing.T) {
+	pod := fixtures.NewPod("web", "default").WithContainers(2).Build()
+	pod.Spec.InitContainers = []v1.Container{{Name: "migrate"}}
+	app, requested := logPickerTestApp(t, pod)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if app.currentMode != ModeLogPicker || app.logPicker == nil {
+		t.Fatalf("Expected the container picker for a pod with three containers, got mode %v", app.currentMode)
+	}
+	if view := app.View(); !strings.Contains(view, "Show the logs of web") || !strings.Contains(view, "migrate (init)") {
+		t.Errorf("Expected the pod's containers offered, got:\n%s", view)
+	}
+
+	// Esc goes back to the list without streaming
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList || app.logPicker != nil || len(*requested) != 0 {
+		t.Fatalf("Expected Esc back to the list, got mode %v with %v streamed", app.currentMode, *requested)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("mig")})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected Enter to pick the container")
+	}
+	picked, ok := cmd().(views.LogContainerPickedMsg)
+	if !ok || picked.Container != "migrate" {
+		t.Fatalf("Expected migrate picked, got %#v", picked)
+	}
+	_, cmd = app.Update(picked)
+	if app.currentMode != ModeLog || app.logPicker != nil {
+		t.Fatalf("Expected the log view, got mode %v", app.currentMode)
+	}
+	cmd()
+	if got := strings.Join(*requested, ","); got != "migrate" {
+		t.Errorf("Expected the logs of migrate, got %s", got)
+	}
+}
+
+func TestLogsOfSingleContainerPodOpenDirectly(t *testing.T) {
+	app, requested := logPickerTestApp(t, fixtures.NewPod("web", "default").Build())
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if app.currentMode != ModeLog || app.logPicker != nil {
+		t.Fatalf("Expected the log view without a picker, got mode %v", app.currentMode)
+	}
+	cmd()
+	if got := strings.Join(*requested, ","); got != "web" {
+		t.Errorf("Expected the logs of web, got %s", got)
+	}
+}
+
+func TestLogsPickerForPendingPod(t *testing.T) {
+	pod := fixtures.NewPod("web", "default").WithContainers(2).WithPhase(v1.PodPending).WithNode("").Build()
+	app, _ := logPickerTestApp(t, pod)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if app.currentMode != ModeLogPicker {
+		t.Fatalf("Expected the container picker for a pending pod, got mode %v", app.currentMode)
+	}
+	if view := app.View(); strings.Count(view, "Pending") != 2 {
+		t.Errorf("Expected both containers pending, got:\n%s", view)
+	}
+}
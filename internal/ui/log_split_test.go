@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/client-go/rest"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/testutil/fixtures"
+)
+
+// logSplitTestApp is a test app showing the logs of the first pod of a
+// crash looping rollout, with a pod of another workload listed beside it
+func logSplitTestApp(t *testing.T) *App {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("started\n"))
+	}))
+	t.Cleanup(server.Close)
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+
+	pods := append(fixtures.CrashloopingRollout("web").Pods,
+		fixtures.NewPod("cart-5d8e-klmno", "web").WithOwner("ReplicaSet", "cart-5d8e").Build())
+	app := createTestApp(t)
+	app.width, app.height = 160, 40
+	app.k8sClient = client
+	app.state.CurrentNamespace = "web"
+	app.state.UpdatePods(pods)
+	app.resourceView.SetTestData([]string{"NAME", "STATUS"}, [][]string{{pods[0].Name, "Running"}})
+	app.resourceView.SetSelectedRow(0)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if app.currentMode != ModeLog {
+		t.Fatalf("Expected the log view, got mode %v", app.currentMode)
+	}
+	return app
+}
+
+func TestLogSplitOffersSiblingPods(t *testing.T) {
+	app := logSplitTestApp(t)
+	first := app.logView
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if app.logSplit == nil || !app.logSplit.IsPicking() {
+		t.Fatal("Expected v to ask for the pod to show beside the logs")
+	}
+	view := app.View()
+	for _, want := range []string{"api-6b8d9-q9m4t", "api-7f4c2-z8r5w"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the rollout's pod %s offered, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "cart-5d8e-klmno") {
+		t.Errorf("Expected the pod of another workload left out, got:\n%s", view)
+	}
+
+	// Esc closes the picker, back to the logs shown before
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.logSplit != nil || app.logView != first || app.currentMode != ModeLog {
+		t.Fatalf("Expected Esc back to a single log pane, got mode %v", app.currentMode)
+	}
+}
+
+func TestLogSplitFocusAndClose(t *testing.T) {
+	app := logSplitTestApp(t)
+	first := app.logView
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z8r5w")})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || app.logSplit.IsPicking() {
+		t.Fatal("Expected Enter to open the picked pod's logs")
+	}
+	second := app.focusedLogView()
+	if second == first {
+		t.Fatal("Expected the new pane focused")
+	}
+	if _, name := second.Resource(); name != "api-7f4c2-z8r5w" {
+		t.Errorf("Expected the second pane showing api-7f4c2-z8r5w, got %s", name)
+	}
+
+	// Tab moves the keys to the first pane, L links scrolling
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if app.focusedLogView() != first {
+		t.Error("Expected Tab to focus the first pane")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	if !app.logSplit.IsLinked() {
+		t.Error("Expected L to link the panes' scrolling")
+	}
+
+	// Closing the focused first pane leaves the second as the log view
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.logSplit != nil || app.logView != second || app.currentMode != ModeLog {
+		t.Fatalf("Expected the second pane left as the log view, got mode %v", app.currentMode)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList {
+		t.Errorf("Expected Esc to close the logs, got mode %v", app.currentMode)
+	}
+}
+
+func TestLogSplitNeedsPodLogs(t *testing.T) {
+	app := createTestApp(t)
+	app.state.CurrentResourceType = core.ResourceTypeDeployment
+	app.setMode(ModeLog)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if app.logSplit != nil {
+		t.Error("Expected no split without a pod's logs")
+	}
+}
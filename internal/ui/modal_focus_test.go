@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+)
+
+// pressKeys sends each named key to the app
+func pressKeys(app *App, keys ...string) {
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "up":
+			msg = tea.KeyMsg{Type: tea.KeyUp}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "left":
+			msg = tea.KeyMsg{Type: tea.KeyLeft}
+		case "right":
+			msg = tea.KeyMsg{Type: tea.KeyRight}
+		case "pgup":
+			msg = tea.KeyMsg{Type: tea.KeyPgUp}
+		case "pgdown":
+			msg = tea.KeyMsg{Type: tea.KeyPgDown}
+		case "home":
+			msg = tea.KeyMsg{Type: tea.KeyHome}
+		case "end":
+			msg = tea.KeyMsg{Type: tea.KeyEnd}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case "shift+tab":
+			msg = tea.KeyMsg{Type: tea.KeyShiftTab}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		app.Update(msg)
+	}
+}
+
+// podRows lists n pods, after the extra ones, too wide to show whole
+func podRows(n int, extra ...string) [][]string {
+	var rows [][]string
+	for _, name := range extra {
+		rows = append(rows, []string{name, "1/1", "Running", "0", "1h", "10.0.0.1", "node-with-a-name-too-long-to-fit"})
+	}
+	for i := 0; i < n; i++ {
+		rows = append(rows, []string{fmt.Sprintf("pod-%02d", i), "1/1", "Running", "0", "1h", "10.0.0.1", "node-with-a-name-too-long-to-fit"})
+	}
+	return rows
+}
+
+func TestOverlaysTrapListFocus(t *testing.T) {
+	headers := []string{"NAME", "READY", "STATUS", "RESTARTS", "AGE", "IP", "NODE"}
+	navigation := []string{"j", "k", "up", "down", "pgup", "pgdown", "home", "end", "h", "l", "left", "right", "u", "s"}
+
+	tests := []struct {
+		name  string
+		open  func(app *App)
+		mode  ScreenModeType
+		mash  []string
+		close []string
+	}{
+		{
+			name:  "namespace selector",
+			open:  func(app *App) { pressKeys(app, "n") },
+			mode:  ModeNamespaceSelector,
+			mash:  append(navigation, "tab", "shift+tab"),
+			close: []string{"esc"},
+		},
+		{
+			name:  "confirm dialog",
+			open:  func(app *App) { pressKeys(app, "D") },
+			mode:  ModeConfirmDialog,
+			mash:  append(navigation, "tab", "shift+tab"),
+			close: []string{"esc"},
+		},
+		{
+			name:  "resource selector",
+			open:  func(app *App) { pressKeys(app, "tab") },
+			mode:  ModeResourceSelector,
+			mash:  append(navigation, "shift+tab"),
+			close: []string{"esc"},
+		},
+		{
+			name:  "help",
+			open:  func(app *App) { pressKeys(app, "?") },
+			mode:  ModeHelp,
+			mash:  append(navigation, "tab", "shift+tab"),
+			close: []string{"esc"},
+		},
+		{
+			// A mode whose view is gone must not hand its keys to the list
+			name:  "settings without its view",
+			open:  func(app *App) { app.setMode(ModeSettings) },
+			mode:  ModeSettings,
+			mash:  append(navigation, "tab", "shift+tab"),
+			close: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := createTestApp(t)
+			app.resourceView.SetSize(app.width, app.viewHeight())
+			app.resourceView.SetTestData(headers, podRows(60))
+			pressKeys(app, "pgdown", "pgdown", "k", "right", "right")
+			app.View()
+			row, viewportStart, horizontalOffset := app.resourceView.Position()
+			selected := app.resourceView.GetSelectedResourceName()
+			if row == 0 || viewportStart == 0 || horizontalOffset == 0 {
+				t.Fatalf("Expected the list moved and scrolled, got row %d from %d, offset %d", row, viewportStart, horizontalOffset)
+			}
+
+			tt.open(app)
+			if app.currentMode != tt.mode {
+				t.Fatalf("Expected mode %v, got %v", tt.mode, app.currentMode)
+			}
+			pressKeys(app, tt.mash...)
+			if app.currentMode != tt.mode {
+				t.Fatalf("Expected the overlay kept open, got mode %v", app.currentMode)
+			}
+			if gotRow, gotStart, gotOffset := app.resourceView.Position(); gotRow != row || gotStart != viewportStart || gotOffset != horizontalOffset {
+				t.Errorf("Expected the list untouched under the overlay at row %d from %d, offset %d, got row %d from %d, offset %d",
+					row, viewportStart, horizontalOffset, gotRow, gotStart, gotOffset)
+			}
+
+			// A refresh meanwhile lists new pods above the one selected
+			app.resourceView.SetTestData(headers, podRows(60, "new-a", "new-b"))
+			pressKeys(app, tt.mash...)
+
+			if tt.close != nil {
+				pressKeys(app, tt.close...)
+			} else {
+				app.setMode(ModeList)
+			}
+			if app.currentMode != ModeList {
+				t.Fatalf("Expected the list back, got mode %v", app.currentMode)
+			}
+			app.View()
+			gotRow, gotStart, gotOffset := app.resourceView.Position()
+			if got := app.resourceView.GetSelectedResourceName(); got != selected || gotRow != row+2 {
+				t.Errorf("Expected %s selected at row %d, got %s at %d", selected, row+2, got, gotRow)
+			}
+			// The selection was on the last line shown and stays there
+			if gotStart != viewportStart+2 || gotOffset != horizontalOffset {
+				t.Errorf("Expected the list scrolled to row %d, offset %d, got %d, %d", viewportStart+2, horizontalOffset, gotStart, gotOffset)
+			}
+		})
+	}
+}
+
+func TestOverlayJumpMovesListFocus(t *testing.T) {
+	headers := []string{"NAME", "READY", "STATUS", "RESTARTS", "AGE", "IP", "NODE"}
+	app := createTestApp(t)
+	app.resourceView.SetSize(app.width, app.viewHeight())
+	app.resourceView.SetTestData(headers, podRows(60))
+	pressKeys(app, "pgdown", "pgdown")
+	app.View()
+
+	// An overlay that jumps to a resource selects it on purpose
+	pressKeys(app, "?")
+	if !app.resourceView.SelectResource(core.ResourceRef{Namespace: "default", Name: "pod-05"}) {
+		t.Fatal("Expected pod-05 selected")
+	}
+	pressKeys(app, "esc")
+	if got := app.resourceView.GetSelectedResourceName(); got != "pod-05" {
+		t.Errorf("Expected the jump to pod-05 kept, got %s", got)
+	}
+}
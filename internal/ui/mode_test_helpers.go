@@ -58,7 +58,7 @@ func NewModeTestSetup(t *testing.T, modeType ScreenModeType) *ModeTestSetup {
 
 	case ModeDescribe:
 		// Describe mode needs describe view
-		app.describeView = views.NewDescribeView("pod", "test-pod", "default", "test-context")
+		app.describeView = views.NewDescribeView(context.Background(), nil, "pod", "test-pod", "default", "test-context")
 		app.describeView.SetSize(80, 24)
 
 	case ModeLog:
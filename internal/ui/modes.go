@@ -1,7 +1,8 @@
 package ui
 
 import (
-	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/ui/views"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -18,6 +19,33 @@ const (
 	ModeNamespaceSelector
 	ModeConfirmDialog
 	ModeResourceSelector
+	ModeTopology
+	ModeSettings
+	ModeFilter
+	ModeSavedFilters
+	ModeScrub
+	ModeFinalizers
+	ModeActionMenu
+	ModeActionOutput
+	ModeTemplates
+	ModeTemplateForm
+	ModeCompare
+	ModeRelations
+	ModeBatch
+	ModeNodeDetail
+	ModeSplit
+	ModePickResource
+	ModeUsage
+	ModeEventTail
+	ModePermissions
+	ModeFleet
+	ModeActivity
+	ModeColumns
+	ModeLabelSelector
+	ModeShellPicker
+	ModeScale
+	ModeEvents
+	ModeLogPicker
 )
 
 // KeyBinding represents a key binding with help text
@@ -102,6 +130,36 @@ func (m *ListMode) GetKeyBindings() map[string]KeyBinding {
 		"delete":    NewKeyBinding([]string{"delete", "D"}, "Del/D", "Delete resource", "Actions"),
 		"refresh":   NewKeyBinding([]string{"r", "ctrl+r"}, "r", "Refresh", "Actions"),
 		"sort":      NewKeyBinding([]string{"s"}, "s", "Cycle sort column/direction", "Actions"),
+		"topology":  NewKeyBinding([]string{"T"}, "T", "Show topology spread", "Actions"),
+		"node":      NewKeyBinding([]string{"N"}, "N", "Show the node, or the pod's", "Actions"),
+		"security":  NewKeyBinding([]string{"P"}, "P", "Toggle security column", "Actions"),
+		"usage":     NewKeyBinding([]string{"U"}, "U", "Show usage by namespace", "Actions"),
+		"events":    NewKeyBinding([]string{"E"}, "E", "Tail the namespace's events", "Actions"),
+		"eventsfor": NewKeyBinding([]string{"v"}, "v", "Events of the selected resource, or the namespace", "Actions"),
+		"perms":     NewKeyBinding([]string{"ctrl+a"}, "Ctrl+A", "What can I do here?", "Actions"),
+		"fleet":     NewKeyBinding([]string{"ctrl+f"}, "Ctrl+F", "Find pods in every context", "Actions"),
+		"noise":     NewKeyBinding([]string{"z"}, "z", "Hide/show completed pods and other noise", "Actions"),
+		"activity":  NewKeyBinding([]string{"a"}, "a", "Show/hide the READY/STATUS activity feed", "Actions"),
+		"pickfeed":  NewKeyBinding([]string{"A"}, "A", "Pick an activity entry to jump to", "Actions"),
+		"filter":    NewKeyBinding([]string{"/"}, "/", "Filter list", "Actions"),
+		"saved":     NewKeyBinding([]string{"F"}, "F", "Saved filters", "Actions"),
+		"labels":    NewKeyBinding([]string{"L"}, "L", "Label selector", "Actions"),
+		"columns":   NewKeyBinding([]string{"C"}, "C", "Reorder columns", "Display"),
+		"history":   NewKeyBinding([]string{"H"}, "H", "Scrub table history", "Actions"),
+		"copy":      NewKeyBinding([]string{"y"}, "y", "Copy view as command", "Actions"),
+		"startup":   NewKeyBinding([]string{"ctrl+s"}, "Ctrl+S", "Save view as the startup layout", "Actions"),
+		"actions":   NewKeyBinding([]string{"!"}, "!", "Quick actions", "Actions"),
+		"create":    NewKeyBinding([]string{"+"}, "+", "Create from template", "Actions"),
+		"shell":     NewKeyBinding([]string{"e"}, "e", "Open a shell in the pod", "Actions"),
+		"relations": NewKeyBinding([]string{"x"}, "x", "Show relationships", "Actions"),
+		"split":     NewKeyBinding([]string{"V"}, "V", "Split deployment over its pods", "Actions"),
+		"restart":   NewKeyBinding([]string{"R"}, "R", "Restart deployment or statefulset", "Actions"),
+		"scale":     NewKeyBinding([]string{"S"}, "S", "Scale deployment or statefulset", "Actions"),
+		"dismiss":   NewKeyBinding([]string{"ctrl+x"}, "Ctrl+X", "Dismiss the new release or kubeconfig notice", "General"),
+		"allns":     NewKeyBinding([]string{"*"}, "*", "All namespaces, when the namespace was deleted", "Navigation"),
+		"back":      NewKeyBinding([]string{"backspace"}, "Backspace", "Back to previous resource", "Navigation"),
+		"pods":      NewKeyBinding([]string{"p"}, "p", "Pods of the deployment or statefulset", "Navigation"),
+		"settings":  NewKeyBinding([]string{","}, ",", "Settings", "General"),
 		"help":      NewKeyBinding([]string{"?"}, "?", "Toggle help", "General"),
 		"quit":      NewKeyBinding([]string{"q", "ctrl+c"}, "q", "Quit", "General"),
 		"escape":    NewKeyBinding([]string{"esc"}, "Esc", "Close dialog/Back", "General"),
@@ -122,11 +180,16 @@ func (m *ListMode) GetHelpSections() map[string][]KeyBinding {
 func (m *ListMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
 	bindings := m.GetKeyBindings()
 
+	// An offer to clear every filter lasts until the next key
+	clearOffered := app.clearFiltersOffered
+	app.clearFiltersOffered = false
+
 	switch {
 	case key.Matches(msg, bindings["quit"].Key):
 		return true, tea.Quit
 
 	case key.Matches(msg, bindings["help"].Key):
+		app.helpView.SetLogs(core.LogTargetFor(app.state.CurrentResourceType))
 		app.setMode(ModeHelp)
 		return true, nil
 
@@ -143,25 +206,8 @@ func (m *ListMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
 		return true, app.openResourceSelector()
 
 	case key.Matches(msg, bindings["logs"].Key), key.Matches(msg, bindings["enter"].Key):
-		selectedName := app.resourceView.GetSelectedResourceName()
-		if selectedName != "" {
-			// Get the appropriate client for logs
-			var client *k8s.Client
-			if app.isMultiContext && app.multiClient != nil {
-				contextName := app.getSelectedResourceContext()
-				if contextName != "" {
-					client, _ = app.multiClient.GetClient(contextName)
-				}
-			} else {
-				client = app.k8sClient
-			}
-
-			// Only proceed if we have a valid client
-			if client != nil {
-				app.setMode(ModeLog)
-				app.resourceView.SetCompactMode(true)
-				return true, app.logView.StartStreaming(app.ctx, client, app.state, selectedName)
-			}
+		if !app.resourceView.SelectedResourceRef().IsZero() {
+			return true, app.startLogs()
 		}
 	case key.Matches(msg, bindings["info"].Key):
 		selectedName := app.resourceView.GetSelectedResourceName()
@@ -209,9 +255,9 @@ func (m *ListMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
 
 	case key.Matches(msg, bindings["delete"].Key):
 		selectedName := app.resourceView.GetSelectedResourceName()
-		if selectedName != "" {
+		if selectedName != "" && !app.deleteDenied() {
 			app.setMode(ModeConfirmDialog)
-			return true, app.showDeleteConfirmation(selectedName)
+			return true, app.showDeleteConfirmation()
 		}
 
 	case key.Matches(msg, bindings["refresh"].Key):
@@ -220,6 +266,132 @@ func (m *ListMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
 	case key.Matches(msg, bindings["sort"].Key):
 		app.cycleSortColumn()
 		return true, app.resourceView.RefreshResources()
+
+	case key.Matches(msg, bindings["topology"].Key):
+		selectedName := app.resourceView.GetSelectedResourceName()
+		if selectedName != "" && views.SupportsTopology(app.state.CurrentResourceType) {
+			app.setMode(ModeTopology)
+			return true, app.startTopologyView(selectedName)
+		}
+		return true, nil
+
+	case key.Matches(msg, bindings["node"].Key):
+		return true, app.startNodeDetailView()
+
+	case key.Matches(msg, bindings["usage"].Key):
+		return true, app.startUsageView()
+
+	case key.Matches(msg, bindings["events"].Key):
+		return true, app.startEventTail()
+
+	case key.Matches(msg, bindings["eventsfor"].Key):
+		return true, app.startEventsView()
+
+	case key.Matches(msg, bindings["perms"].Key):
+		return true, app.startPermissionsView()
+
+	case key.Matches(msg, bindings["fleet"].Key):
+		return true, app.startFleetSearch(core.FleetQuery{})
+
+	case key.Matches(msg, bindings["security"].Key):
+		return true, app.toggleSecurityColumn()
+
+	case key.Matches(msg, bindings["noise"].Key):
+		return true, app.toggleNoise()
+
+	case key.Matches(msg, bindings["activity"].Key):
+		app.toggleActivityFeed()
+		return true, nil
+
+	case key.Matches(msg, bindings["pickfeed"].Key):
+		app.startActivitySelection()
+		return true, nil
+
+	case key.Matches(msg, bindings["settings"].Key):
+		app.startSettingsView()
+		return true, nil
+
+	case key.Matches(msg, bindings["filter"].Key):
+		app.startFilterBar()
+		return true, nil
+
+	case key.Matches(msg, bindings["saved"].Key):
+		app.startSavedFiltersView()
+		return true, nil
+
+	case key.Matches(msg, bindings["labels"].Key):
+		app.startLabelSelectorBar()
+		return true, nil
+
+	case key.Matches(msg, bindings["columns"].Key):
+		app.startColumnOrderView()
+		return true, nil
+
+	case key.Matches(msg, bindings["history"].Key):
+		if app.resourceView.StartScrub() {
+			app.setMode(ModeScrub)
+		}
+		return true, nil
+
+	case key.Matches(msg, bindings["copy"].Key):
+		app.copyViewCommand()
+		return true, nil
+
+	case key.Matches(msg, bindings["startup"].Key):
+		app.saveStartupLayout()
+		return true, nil
+
+	case key.Matches(msg, bindings["actions"].Key):
+		app.openActionMenu()
+		return true, nil
+
+	case key.Matches(msg, bindings["create"].Key):
+		app.openTemplatePicker()
+		return true, nil
+
+	case key.Matches(msg, bindings["shell"].Key):
+		return true, app.startShell()
+
+	case key.Matches(msg, bindings["relations"].Key):
+		app.openRelations()
+		return true, nil
+
+	case key.Matches(msg, bindings["split"].Key):
+		return true, app.startSplit()
+
+	case key.Matches(msg, bindings["restart"].Key):
+		app.confirmRestart()
+		return true, nil
+
+	case key.Matches(msg, bindings["scale"].Key):
+		app.startScale()
+		return true, nil
+
+	case key.Matches(msg, bindings["dismiss"].Key):
+		return app.dismissUpdate() || app.dismissKubeconfigNotice(), nil
+
+	case key.Matches(msg, bindings["allns"].Key):
+		if handled, cmd := app.showAllNamespaces(); handled {
+			return true, cmd
+		}
+
+	case key.Matches(msg, bindings["back"].Key):
+		return true, app.navigateBack()
+
+	case key.Matches(msg, bindings["pods"].Key):
+		return true, app.showWorkloadPods()
+
+	case key.Matches(msg, bindings["escape"].Key):
+		// Esc clears an active filter, or every filter when offered;
+		// otherwise the list ignores it
+		if handled, cmd := app.clearFilter(clearOffered); handled {
+			return true, cmd
+		}
+	}
+
+	// User-defined actions bound to a key
+	if action := app.userActionForKey(msg.String()); action != nil {
+		return true, app.runUserAction(action)
 	}
 
 	return false, nil
@@ -246,15 +418,23 @@ func (m *LogMode) GetKeyBindings() map[string]KeyBinding {
 		"pageup":    NewKeyBinding([]string{"pgup"}, "PgUp", "Page up", "Navigation"),
 		"pagedown":  NewKeyBinding([]string{"pgdown"}, "PgDn", "Page down", "Navigation"),
 		"home":      NewKeyBinding([]string{"home", "g"}, "Home/g", "Jump to top", "Navigation"),
-		"end":       NewKeyBinding([]string{"end", "G"}, "End/G", "Jump to bottom (follow)", "Navigation"),
+		"end":       NewKeyBinding([]string{"end", "G", "F"}, "End/G/F", "Jump to bottom and follow", "Navigation"),
+		"sideways":  NewKeyBinding([]string{"left", "right"}, "←/→", "Scroll long lines sideways", "Navigation"),
 		"follow":    NewKeyBinding([]string{"f"}, "f", "Toggle follow mode", "Log Controls"),
 		"search":    NewKeyBinding([]string{"/"}, "/", "Search in logs", "Log Controls"),
-		"container": NewKeyBinding([]string{"c"}, "c", "Cycle containers", "Log Controls"),
+		"grep":      NewKeyBinding([]string{"|"}, "|", "Keep only lines matching a pattern as they arrive", "Log Controls"),
+		"container": NewKeyBinding([]string{"c"}, "c", "Cycle containers (pick when many)", "Log Controls"),
 		"pod":       NewKeyBinding([]string{"p"}, "p", "Cycle pods", "Log Controls"),
+		"records":   NewKeyBinding([]string{"m"}, "m", "Toggle multi-line records", "Log Controls"),
+		"colors":    NewKeyBinding([]string{"a"}, "a", "Toggle log colors", "Log Controls"),
 		"clear":     NewKeyBinding([]string{"C"}, "C", "Clear log buffer", "Log Controls"),
+		"split":     NewKeyBinding([]string{"v"}, "v", "Show another pod's logs beside these", "Split Logs"),
+		"pane":      NewKeyBinding([]string{"tab", "shift+tab"}, "Tab", "Switch pane", "Split Logs"),
+		"link":      NewKeyBinding([]string{"L"}, "L", "Link the panes' scrolling", "Split Logs"),
+		"startup":   NewKeyBinding([]string{"ctrl+s"}, "Ctrl+S", "Save the list and log split as the startup layout", "General"),
 		"help":      NewKeyBinding([]string{"?"}, "?", "Toggle help", "General"),
-		"quit":      NewKeyBinding([]string{"q", "ctrl+c"}, "q", "Quit application", "General"),
-		"escape":    NewKeyBinding([]string{"esc"}, "Esc", "Close logs", "General"),
+		"quit":      NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":    NewKeyBinding([]string{"esc", "q"}, "Esc/q", "Close logs, or the focused pane when split", "General"),
 	}
 }
 
@@ -272,8 +452,24 @@ func (m *LogMode) GetHelpSections() map[string][]KeyBinding {
 func (m *LogMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
 	bindings := m.GetKeyBindings()
 
-	// When in search mode, only handle ESC and let log view handle everything else
-	if app.logView.IsSearchMode() {
+	// The pod picker of a second pane takes every key but Esc, which
+	// closes it
+	if app.logSplit != nil && app.logSplit.IsPicking() {
+		if msg.String() == "esc" {
+			return true, app.closeLogPane()
+		}
+		return false, nil
+	}
+
+	// The container picker takes every key, Esc included
+	logView := app.focusedLogView()
+	if logView.IsPickingContainer() {
+		return false, nil
+	}
+
+	// When in search mode or typing the stream filter, only handle ESC and
+	// let log view handle everything else
+	if logView.IsSearchMode() || logView.IsEditingStreamFilter() {
 		if key.Matches(msg, bindings["escape"].Key) {
 			// Let log view handle search cancellation
 			return false, nil
@@ -291,10 +487,40 @@ func (m *LogMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
 		return true, nil
 
 	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		if app.logSplit != nil {
+			return true, app.closeLogPane()
+		}
+		if app.fleetView != nil {
+			// Logs opened from a fleet search go back to it
+			app.fleetView.SetSize(app.width, app.height)
+			app.setMode(ModeFleet)
+			return true, app.logView.StopStreaming()
+		}
 		app.setMode(ModeList)
 		app.resourceView.SetCompactMode(false)
 		app.resourceView.SetSize(app.width, app.height)
 		return true, app.logView.StopStreaming()
+
+	case key.Matches(msg, bindings["startup"].Key):
+		app.saveStartupLayout()
+		return true, nil
+
+	case key.Matches(msg, bindings["split"].Key):
+		if app.logSplit == nil {
+			app.openLogSplit()
+		}
+		return true, nil
+
+	case key.Matches(msg, bindings["pane"].Key) && app.logSplit != nil:
+		app.logSplit.ToggleFocus()
+		return true, nil
+
+	case key.Matches(msg, bindings["link"].Key) && app.logSplit != nil:
+		app.logSplit.ToggleLinked()
+		return true, nil
 	}
 
 	// Let log view handle all other keys
@@ -326,9 +552,13 @@ func (m *DescribeMode) GetKeyBindings() map[string]KeyBinding {
 		"wordwrap":    NewKeyBinding([]string{"u"}, "u", "Toggle word wrap", "Display"),
 		"refresh":     NewKeyBinding([]string{"r", "ctrl+r"}, "r", "Manual refresh", "Actions"),
 		"autorefresh": NewKeyBinding([]string{"a"}, "a", "Toggle auto-refresh", "Actions"),
+		"follow":      NewKeyBinding([]string{"f"}, "f", "Follow new events", "Display"),
+		"warnings":    NewKeyBinding([]string{"w"}, "w", "Show only warning events", "Display"),
+		"finalizer":   NewKeyBinding([]string{"x"}, "x", "Remove a finalizer", "Actions"),
+		"pager":       NewKeyBinding([]string{"|"}, "|", "Open in $PAGER", "Actions"),
 		"help":        NewKeyBinding([]string{"?"}, "?", "Toggle help", "General"),
-		"quit":        NewKeyBinding([]string{"q", "ctrl+c"}, "q", "Quit application", "General"),
-		"escape":      NewKeyBinding([]string{"esc"}, "Esc", "Back to list", "General"),
+		"quit":        NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":      NewKeyBinding([]string{"esc", "q"}, "Esc/q", "Back to list", "General"),
 	}
 }
 
@@ -354,8 +584,17 @@ func (m *DescribeMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
 		app.setMode(ModeHelp)
 		return true, nil
 
+	case key.Matches(msg, bindings["finalizer"].Key):
+		return true, app.startFinalizerRemoval()
+
+	case key.Matches(msg, bindings["pager"].Key):
+		return true, app.openInPager()
+
 	case key.Matches(msg, bindings["escape"].Key):
-		app.setMode(ModeList)
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		app.returnToList()
 		return true, nil
 	}
 
@@ -380,8 +619,8 @@ func NewHelpMode() *HelpMode {
 func (m *HelpMode) GetKeyBindings() map[string]KeyBinding {
 	return map[string]KeyBinding{
 		"help":   NewKeyBinding([]string{"?"}, "?", "Close help", "General"),
-		"quit":   NewKeyBinding([]string{"q", "ctrl+c"}, "q", "Quit application", "General"),
-		"escape": NewKeyBinding([]string{"esc"}, "Esc", "Close help", "General"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc", "q"}, "Esc/q", "Close help", "General"),
 	}
 }
 
@@ -404,6 +643,9 @@ func (m *HelpMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
 		return true, tea.Quit
 
 	case key.Matches(msg, bindings["help"].Key), key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
 		app.returnToPreviousMode()
 		return true, nil
 	}
@@ -427,14 +669,15 @@ func NewContextSelectorMode() *ContextSelectorMode {
 
 func (m *ContextSelectorMode) GetKeyBindings() map[string]KeyBinding {
 	return map[string]KeyBinding{
-		"up":     NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
-		"down":   NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
-		"space":  NewKeyBinding([]string{" "}, "Space", "Toggle selection", "Actions"),
-		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Apply selection", "Actions"),
-		"info":   NewKeyBinding([]string{"i"}, "i", "Show context info", "Actions"),
-		"search": NewKeyBinding([]string{"/"}, "/", "Search contexts", "Actions"),
-		"quit":   NewKeyBinding([]string{"q", "ctrl+c"}, "q", "Quit application", "General"),
-		"escape": NewKeyBinding([]string{"esc", "c"}, "Esc/c", "Cancel", "General"),
+		"up":      NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":    NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"space":   NewKeyBinding([]string{" "}, "Space", "Toggle selection", "Actions"),
+		"enter":   NewKeyBinding([]string{"enter"}, "Enter", "Apply selection", "Actions"),
+		"info":    NewKeyBinding([]string{"i"}, "i", "Show context info", "Actions"),
+		"search":  NewKeyBinding([]string{"/"}, "/", "Search contexts", "Actions"),
+		"compare": NewKeyBinding([]string{"="}, "=", "Compare two marked contexts", "Actions"),
+		"quit":    NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":  NewKeyBinding([]string{"esc", "c", "q"}, "Esc/c/q", "Cancel", "General"),
 	}
 }
 
@@ -464,7 +707,13 @@ func (m *ContextSelectorMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd
 	case key.Matches(msg, bindings["enter"].Key):
 		return true, app.applyContextSelection()
 
+	case key.Matches(msg, bindings["compare"].Key):
+		return true, app.startComparison()
+
 	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
 		app.setMode(ModeList)
 		return true, nil
 
@@ -500,8 +749,8 @@ func (m *NamespaceSelectorMode) GetKeyBindings() map[string]KeyBinding {
 		"up":     NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
 		"down":   NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
 		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Select namespace", "Actions"),
-		"quit":   NewKeyBinding([]string{"q", "ctrl+c"}, "q", "Quit application", "General"),
-		"escape": NewKeyBinding([]string{"esc", "n"}, "Esc/n", "Cancel", "General"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc", "n", "q"}, "Esc/n/q", "Cancel", "General"),
 	}
 }
 
@@ -527,6 +776,9 @@ func (m *NamespaceSelectorMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.C
 		return true, app.applyNamespaceSelection()
 
 	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
 		app.setMode(ModeList)
 		return true, nil
 	}
@@ -551,11 +803,14 @@ func NewConfirmDialogMode() *ConfirmDialogMode {
 
 func (m *ConfirmDialogMode) GetKeyBindings() map[string]KeyBinding {
 	return map[string]KeyBinding{
-		"left":   NewKeyBinding([]string{"left", "h"}, "←/h", "Move left", "Navigation"),
-		"right":  NewKeyBinding([]string{"right", "l"}, "→/l", "Move right", "Navigation"),
+		"left":   NewKeyBinding([]string{"left", "h"}, "←/h", "Focus cancel", "Navigation"),
+		"right":  NewKeyBinding([]string{"right", "l"}, "→/l", "Focus confirm", "Navigation"),
+		"switch": NewKeyBinding([]string{"tab", "shift+tab"}, "Tab", "Switch button", "Navigation"),
 		"enter":  NewKeyBinding([]string{"enter", " "}, "Enter/Space", "Confirm selection", "Actions"),
-		"quit":   NewKeyBinding([]string{"q", "ctrl+c"}, "q", "Quit application", "General"),
-		"escape": NewKeyBinding([]string{"esc"}, "Esc", "Cancel", "General"),
+		"yes":    NewKeyBinding([]string{"y", "Y"}, "y", "Confirm", "Actions"),
+		"no":     NewKeyBinding([]string{"n", "N"}, "n", "Cancel", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc", "q"}, "Esc/q", "Cancel", "General"),
 	}
 }
 
@@ -573,6 +828,19 @@ func (m *ConfirmDialogMode) GetHelpSections() map[string][]KeyBinding {
 func (m *ConfirmDialogMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
 	bindings := m.GetKeyBindings()
 
+	// While a name is being typed, keys are text; only Esc and Ctrl+C keep
+	// their meaning, and the dialog itself decides when Enter confirms
+	if app.confirmView != nil && app.confirmView.RequiresInput() {
+		switch msg.String() {
+		case "ctrl+c":
+			return true, tea.Quit
+		case "esc":
+			app.cancelConfirmDialog()
+			return true, nil
+		}
+		return false, nil
+	}
+
 	switch {
 	case key.Matches(msg, bindings["quit"].Key):
 		return true, tea.Quit
@@ -580,12 +848,15 @@ func (m *ConfirmDialogMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd)
 	case key.Matches(msg, bindings["enter"].Key):
 		return true, app.handleConfirmDialogAction()
 
-	case key.Matches(msg, bindings["escape"].Key):
-		app.setMode(ModeList)
+	case key.Matches(msg, bindings["escape"].Key), key.Matches(msg, bindings["no"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		app.cancelConfirmDialog()
 		return true, nil
 	}
 
-	// Let confirm view handle navigation keys
+	// Let confirm view handle navigation keys and y
 	return false, nil
 }
 
@@ -608,8 +879,8 @@ func (m *ResourceSelectorMode) GetKeyBindings() map[string]KeyBinding {
 		"up":     NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
 		"down":   NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
 		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Select resource type", "Actions"),
-		"quit":   NewKeyBinding([]string{"q", "ctrl+c"}, "q", "Quit application", "General"),
-		"escape": NewKeyBinding([]string{"esc", "tab"}, "Esc/Tab", "Cancel", "General"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc", "tab", "q"}, "Esc/Tab/q", "Cancel", "General"),
 	}
 }
 
@@ -637,6 +908,9 @@ func (m *ResourceSelectorMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cm
 		return true, nil
 
 	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
 		app.setMode(ModeList)
 		return true, nil
 	}
@@ -644,3 +918,1655 @@ func (m *ResourceSelectorMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cm
 	// Let resource selector view handle navigation keys
 	return false, nil
 }
+
+// TopologyMode handles the topology spread overlay
+type TopologyMode struct {
+	BaseMode
+}
+
+func NewTopologyMode() *TopologyMode {
+	return &TopologyMode{
+		BaseMode: BaseMode{
+			modeType: ModeTopology,
+			title:    "KubeWatch TUI - Topology Spread",
+		},
+	}
+}
+
+func (m *TopologyMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"refresh": NewKeyBinding([]string{"r", "ctrl+r"}, "r", "Refresh", "Actions"),
+		"help":    NewKeyBinding([]string{"?"}, "?", "Toggle help", "General"),
+		"quit":    NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":  NewKeyBinding([]string{"esc", "T", "q"}, "Esc/T/q", "Close topology", "General"),
+	}
+}
+
+func (m *TopologyMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *TopologyMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["help"].Key):
+		app.setMode(ModeHelp)
+		return true, nil
+
+	case key.Matches(msg, bindings["refresh"].Key):
+		return true, app.refreshTopology()
+
+	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	return true, nil
+}
+
+// SettingsMode handles the runtime settings overlay
+type SettingsMode struct {
+	BaseMode
+}
+
+func NewSettingsMode() *SettingsMode {
+	return &SettingsMode{
+		BaseMode: BaseMode{
+			modeType: ModeSettings,
+			title:    "KubeWatch TUI - Settings",
+		},
+	}
+}
+
+func (m *SettingsMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":     NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":   NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Edit/Apply value", "Actions"),
+		"save":   NewKeyBinding([]string{"s", "ctrl+s"}, "s", "Save to config file", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc", ","}, "Esc/,", "Cancel edit/Close settings", "General"),
+	}
+}
+
+func (m *SettingsMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *SettingsMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		// Esc cancels an edit in progress before closing the overlay
+		if app.settingsView != nil && app.settingsView.IsEditing() {
+			return false, nil
+		}
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	// Let the settings view handle navigation and editing
+	return false, nil
+}
+
+// FilterMode handles typing a list filter in the filter bar
+type FilterMode struct {
+	BaseMode
+}
+
+func NewFilterMode() *FilterMode {
+	return &FilterMode{
+		BaseMode: BaseMode{
+			modeType: ModeFilter,
+			title:    "KubeWatch TUI - Filter",
+		},
+	}
+}
+
+func (m *FilterMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Apply filter", "Actions"),
+		"global": NewKeyBinding([]string{"ctrl+g"}, "Ctrl+G", "Apply filter to all types", "Actions"),
+		"save":   NewKeyBinding([]string{"ctrl+s"}, "Ctrl+S", "Save filter as…", "Actions"),
+		"clear":  NewKeyBinding([]string{"ctrl+u"}, "Ctrl+U", "Clear filter text", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc"}, "Esc", "Cancel", "General"),
+	}
+}
+
+func (m *FilterMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *FilterMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	if key.Matches(msg, bindings["quit"].Key) {
+		return true, tea.Quit
+	}
+
+	// While naming a filter to save, Enter and Esc belong to the filter bar
+	if app.filterBar == nil || app.filterBar.IsNaming() {
+		return false, nil
+	}
+
+	switch {
+	case key.Matches(msg, bindings["enter"].Key):
+		return true, app.applyFilter(app.filterBar.Expression(), false)
+
+	case key.Matches(msg, bindings["global"].Key):
+		return true, app.applyFilter(app.filterBar.Expression(), true)
+
+	case key.Matches(msg, bindings["escape"].Key):
+		app.closeFilterBar()
+		return true, nil
+	}
+
+	// Every other key is text or an action for the filter bar
+	return false, nil
+}
+
+// LabelSelectorMode handles typing a label selector in the label selector bar
+type LabelSelectorMode struct {
+	BaseMode
+}
+
+func NewLabelSelectorMode() *LabelSelectorMode {
+	return &LabelSelectorMode{
+		BaseMode: BaseMode{
+			modeType: ModeLabelSelector,
+			title:    "KubeWatch TUI - Label Selector",
+		},
+	}
+}
+
+func (m *LabelSelectorMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Apply label selector", "Actions"),
+		"clear":  NewKeyBinding([]string{"ctrl+u"}, "Ctrl+U", "Clear selector text", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc"}, "Esc", "Cancel", "General"),
+	}
+}
+
+func (m *LabelSelectorMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *LabelSelectorMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["enter"].Key):
+		return true, app.applyLabelSelector()
+
+	case key.Matches(msg, bindings["escape"].Key):
+		app.closeLabelSelectorBar()
+		return true, nil
+	}
+
+	// Every other key is text for the label selector bar
+	return false, nil
+}
+
+// SavedFiltersMode handles the saved filters picker
+type SavedFiltersMode struct {
+	BaseMode
+}
+
+func NewSavedFiltersMode() *SavedFiltersMode {
+	return &SavedFiltersMode{
+		BaseMode: BaseMode{
+			modeType: ModeSavedFilters,
+			title:    "KubeWatch TUI - Saved Filters",
+		},
+	}
+}
+
+func (m *SavedFiltersMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":     NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":   NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Apply saved filter", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc", "F"}, "Esc/F", "Close saved filters", "General"),
+	}
+}
+
+func (m *SavedFiltersMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *SavedFiltersMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	// Let the picker handle navigation and selection
+	return false, nil
+}
+
+// ScrubMode steps through recorded table states. The view is frozen on a past
+// state, so actions that would act on live resources are disabled; copying
+// the view and describing the selected resource only read, so they work.
+type ScrubMode struct {
+	BaseMode
+}
+
+func NewScrubMode() *ScrubMode {
+	return &ScrubMode{
+		BaseMode: BaseMode{
+			modeType: ModeScrub,
+			title:    "KubeWatch TUI - History",
+		},
+	}
+}
+
+func (m *ScrubMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"older":    NewKeyBinding([]string{"left", "h"}, "←/h", "Older table state", "History"),
+		"newer":    NewKeyBinding([]string{"right", "l"}, "→/l", "Newer table state", "History"),
+		"up":       NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":     NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"copy":     NewKeyBinding([]string{"y"}, "y", "Copy view as command", "Actions"),
+		"describe": NewKeyBinding([]string{"d", "i"}, "d/i", "Describe resource", "Actions"),
+		"quit":     NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":   NewKeyBinding([]string{"esc", "H"}, "Esc/H", "Back to live", "General"),
+	}
+}
+
+func (m *ScrubMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *ScrubMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["older"].Key):
+		app.resourceView.ScrubStep(-1)
+
+	case key.Matches(msg, bindings["newer"].Key):
+		app.resourceView.ScrubStep(1)
+
+	case key.Matches(msg, bindings["up"].Key):
+		app.resourceView.ScrubMoveSelection(-1)
+
+	case key.Matches(msg, bindings["down"].Key):
+		app.resourceView.ScrubMoveSelection(1)
+
+	case key.Matches(msg, bindings["copy"].Key):
+		app.copyViewCommand()
+
+	case key.Matches(msg, bindings["describe"].Key):
+		// The resource is looked up by the name the past state shows; Esc
+		// from the description comes back here
+		ref := app.resourceView.SelectedResourceRef()
+		if ref.Name == "" || app.clientForContext(ref.Context) == nil {
+			return true, nil
+		}
+		app.setMode(ModeDescribe)
+		return true, app.startDescribeRef(ref)
+
+	case key.Matches(msg, bindings["escape"].Key):
+		// Back to live, catching up with one refresh
+		app.resourceView.StopScrub()
+		app.setMode(ModeList)
+		return true, app.resourceView.RefreshResources()
+	}
+
+	// Every other key would act on live resources, so swallow it
+	return true, nil
+}
+
+// ActivityMode handles picking an entry of the activity feed to jump to its
+// resource
+type ActivityMode struct {
+	BaseMode
+}
+
+func NewActivityMode() *ActivityMode {
+	return &ActivityMode{
+		BaseMode: BaseMode{
+			modeType: ModeActivity,
+			title:    "KubeWatch TUI - Activity",
+		},
+	}
+}
+
+func (m *ActivityMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":     NewKeyBinding([]string{"up", "k"}, "↑/k", "Older entry", "Navigation"),
+		"down":   NewKeyBinding([]string{"down", "j"}, "↓/j", "Newer entry", "Navigation"),
+		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Jump to the resource", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc", "A"}, "Esc/A", "Back to the list", "General"),
+	}
+}
+
+func (m *ActivityMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *ActivityMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["up"].Key):
+		app.resourceView.MoveActivitySelection(-1)
+
+	case key.Matches(msg, bindings["down"].Key):
+		app.resourceView.MoveActivitySelection(1)
+
+	case key.Matches(msg, bindings["enter"].Key):
+		app.jumpToActivity()
+
+	case key.Matches(msg, bindings["escape"].Key):
+		app.resourceView.StopActivitySelection()
+		app.setMode(ModeList)
+	}
+
+	// The list keeps its keys until an entry is picked
+	return true, nil
+}
+
+// ColumnsMode handles reordering the list's columns
+type ColumnsMode struct {
+	BaseMode
+}
+
+func NewColumnsMode() *ColumnsMode {
+	return &ColumnsMode{
+		BaseMode: BaseMode{
+			modeType: ModeColumns,
+			title:    "KubeWatch TUI - Column Order",
+		},
+	}
+}
+
+func (m *ColumnsMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":       NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":     NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"moveup":   NewKeyBinding([]string{"shift+up", "K"}, "Shift+↑/K", "Move column up", "Actions"),
+		"movedown": NewKeyBinding([]string{"shift+down", "J"}, "Shift+↓/J", "Move column down", "Actions"),
+		"reset":    NewKeyBinding([]string{"r"}, "r", "Reset to the usual order", "Actions"),
+		"enter":    NewKeyBinding([]string{"enter"}, "Enter", "Apply order", "Actions"),
+		"save":     NewKeyBinding([]string{"ctrl+s"}, "Ctrl+S", "Apply and save order", "Actions"),
+		"quit":     NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":   NewKeyBinding([]string{"esc", "C"}, "Esc/C", "Cancel", "General"),
+	}
+}
+
+func (m *ColumnsMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *ColumnsMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	// Let the chooser handle selection and moves
+	return false, nil
+}
+
+// ShellPickerMode handles picking the container to open a shell in
+type ShellPickerMode struct {
+	BaseMode
+}
+
+func NewShellPickerMode() *ShellPickerMode {
+	return &ShellPickerMode{
+		BaseMode: BaseMode{
+			modeType: ModeShellPicker,
+			title:    "KubeWatch TUI - Shell Container",
+		},
+	}
+}
+
+func (m *ShellPickerMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":     NewKeyBinding([]string{"up", "ctrl+p"}, "↑", "Move up", "Navigation"),
+		"down":   NewKeyBinding([]string{"down", "ctrl+n"}, "↓", "Move down", "Navigation"),
+		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Open a shell in the container", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc"}, "Esc", "Cancel", "General"),
+	}
+}
+
+func (m *ShellPickerMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *ShellPickerMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		app.shellPicker = nil
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	// Every other key filters, moves through or picks from the containers
+	return false, nil
+}
+
+// LogPickerMode handles picking the container to show the logs of
+type LogPickerMode struct {
+	BaseMode
+}
+
+func NewLogPickerMode() *LogPickerMode {
+	return &LogPickerMode{
+		BaseMode: BaseMode{
+			modeType: ModeLogPicker,
+			title:    "KubeWatch TUI - Log Container",
+		},
+	}
+}
+
+func (m *LogPickerMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":     NewKeyBinding([]string{"up", "ctrl+p"}, "↑", "Move up", "Navigation"),
+		"down":   NewKeyBinding([]string{"down", "ctrl+n"}, "↓", "Move down", "Navigation"),
+		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Show the container's logs", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc"}, "Esc", "Cancel", "General"),
+	}
+}
+
+func (m *LogPickerMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *LogPickerMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		app.logPicker = nil
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	// Every other key filters, moves through or picks from the containers
+	return false, nil
+}
+
+// ScaleMode handles entering the replicas to scale to
+type ScaleMode struct {
+	BaseMode
+}
+
+func NewScaleMode() *ScaleMode {
+	return &ScaleMode{
+		BaseMode: BaseMode{
+			modeType: ModeScale,
+			title:    "KubeWatch TUI - Scale",
+		},
+	}
+}
+
+func (m *ScaleMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":     NewKeyBinding([]string{"+", "up"}, "+/↑", "One more replica", "Actions"),
+		"down":   NewKeyBinding([]string{"-", "down"}, "-/↓", "One fewer replica", "Actions"),
+		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Scale to the replicas entered", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc"}, "Esc", "Cancel", "General"),
+	}
+}
+
+func (m *ScaleMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *ScaleMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		app.closeScale()
+		return true, nil
+	}
+
+	// Every other key edits or enters the replicas
+	return false, nil
+}
+
+// EventsMode handles the events overlay of a resource or namespace
+type EventsMode struct {
+	BaseMode
+}
+
+func NewEventsMode() *EventsMode {
+	return &EventsMode{
+		BaseMode: BaseMode{
+			modeType: ModeEvents,
+			title:    "KubeWatch TUI - Events",
+		},
+	}
+}
+
+func (m *EventsMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":      NewKeyBinding([]string{"up", "k"}, "↑/k", "Scroll up", "Navigation"),
+		"down":    NewKeyBinding([]string{"down", "j"}, "↓/j", "Scroll down", "Navigation"),
+		"refresh": NewKeyBinding([]string{"r", "ctrl+r"}, "r", "Refresh", "Actions"),
+		"quit":    NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":  NewKeyBinding([]string{"esc", "v", "q"}, "Esc/v/q", "Close events", "General"),
+	}
+}
+
+func (m *EventsMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *EventsMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["refresh"].Key):
+		return true, app.refreshEvents()
+
+	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		app.eventsView = nil
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	// Let the overlay scroll through its events
+	return false, nil
+}
+
+// FinalizersMode handles picking a finalizer to remove from a described resource
+type FinalizersMode struct {
+	BaseMode
+}
+
+func NewFinalizersMode() *FinalizersMode {
+	return &FinalizersMode{
+		BaseMode: BaseMode{
+			modeType: ModeFinalizers,
+			title:    "KubeWatch TUI - Remove Finalizer",
+		},
+	}
+}
+
+func (m *FinalizersMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":     NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":   NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Remove finalizer", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc"}, "Esc", "Back to describe", "General"),
+	}
+}
+
+func (m *FinalizersMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *FinalizersMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		app.setMode(ModeDescribe)
+		return true, nil
+	}
+
+	// Let the picker handle navigation and selection
+	return false, nil
+}
+
+// ActionMenuMode handles the quick-action menu of user-defined actions
+type ActionMenuMode struct {
+	BaseMode
+}
+
+func NewActionMenuMode() *ActionMenuMode {
+	return &ActionMenuMode{
+		BaseMode: BaseMode{
+			modeType: ModeActionMenu,
+			title:    "KubeWatch TUI - Quick Actions",
+		},
+	}
+}
+
+func (m *ActionMenuMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":     NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":   NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Run action", "Actions"),
+		"perms":  NewKeyBinding([]string{"ctrl+a"}, "Ctrl+A", "What can I do here?", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc", "!"}, "Esc/!", "Close quick actions", "General"),
+	}
+}
+
+func (m *ActionMenuMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *ActionMenuMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		app.setMode(ModeList)
+		return true, nil
+
+	case key.Matches(msg, bindings["perms"].Key):
+		return true, app.startPermissionsView()
+	}
+
+	// Let the menu handle navigation and selection
+	return false, nil
+}
+
+// ActionOutputMode shows the output of a user-defined action run in the background
+type ActionOutputMode struct {
+	BaseMode
+}
+
+func NewActionOutputMode() *ActionOutputMode {
+	return &ActionOutputMode{
+		BaseMode: BaseMode{
+			modeType: ModeActionOutput,
+			title:    "KubeWatch TUI - Action Output",
+		},
+	}
+}
+
+func (m *ActionOutputMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":       NewKeyBinding([]string{"up", "k"}, "↑/k", "Scroll up", "Navigation"),
+		"down":     NewKeyBinding([]string{"down", "j"}, "↓/j", "Scroll down", "Navigation"),
+		"pageup":   NewKeyBinding([]string{"pgup"}, "PgUp", "Page up", "Navigation"),
+		"pagedown": NewKeyBinding([]string{"pgdown"}, "PgDn", "Page down", "Navigation"),
+		"home":     NewKeyBinding([]string{"home", "g"}, "Home/g", "Jump to top", "Navigation"),
+		"end":      NewKeyBinding([]string{"end", "G"}, "End/G", "Jump to bottom", "Navigation"),
+		"quit":     NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":   NewKeyBinding([]string{"esc", "q"}, "Esc/q", "Close output", "General"),
+	}
+}
+
+func (m *ActionOutputMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *ActionOutputMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	// Let the output view scroll
+	return false, nil
+}
+
+// TemplatesMode handles the picker of manifest templates to create resources from
+type TemplatesMode struct {
+	BaseMode
+}
+
+func NewTemplatesMode() *TemplatesMode {
+	return &TemplatesMode{
+		BaseMode: BaseMode{
+			modeType: ModeTemplates,
+			title:    "KubeWatch TUI - Create from Template",
+		},
+	}
+}
+
+func (m *TemplatesMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":      NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":    NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"enter":   NewKeyBinding([]string{"enter"}, "Enter", "Fill in template", "Actions"),
+		"cleanup": NewKeyBinding([]string{"x"}, "x", "Clean up created resources", "Actions"),
+		"quit":    NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":  NewKeyBinding([]string{"esc", "+"}, "Esc/+", "Close templates", "General"),
+	}
+}
+
+func (m *TemplatesMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *TemplatesMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	// Let the picker handle navigation, selection and cleanup
+	return false, nil
+}
+
+// TemplateFormMode handles filling in a manifest template's parameters
+type TemplateFormMode struct {
+	BaseMode
+}
+
+func NewTemplateFormMode() *TemplateFormMode {
+	return &TemplateFormMode{
+		BaseMode: BaseMode{
+			modeType: ModeTemplateForm,
+			title:    "KubeWatch TUI - Create from Template",
+		},
+	}
+}
+
+func (m *TemplateFormMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":     NewKeyBinding([]string{"up", "shift+tab"}, "↑/S-Tab", "Previous field", "Navigation"),
+		"down":   NewKeyBinding([]string{"down", "tab"}, "↓/Tab", "Next field", "Navigation"),
+		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Create resources", "Actions"),
+		"clear":  NewKeyBinding([]string{"ctrl+u"}, "Ctrl+U", "Clear field", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc"}, "Esc", "Back to templates", "General"),
+	}
+}
+
+func (m *TemplateFormMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *TemplateFormMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		app.setMode(ModeTemplates)
+		return true, nil
+	}
+
+	// Everything else is typed into the form
+	return false, nil
+}
+
+// RelationsMode handles the relationships panel of the selected resource
+type RelationsMode struct {
+	BaseMode
+}
+
+func NewRelationsMode() *RelationsMode {
+	return &RelationsMode{
+		BaseMode: BaseMode{
+			modeType: ModeRelations,
+			title:    "KubeWatch TUI - Relationships",
+		},
+	}
+}
+
+func (m *RelationsMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":     NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":   NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Jump to resource", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc", "x", "q"}, "Esc/x/q", "Close relationships", "General"),
+	}
+}
+
+func (m *RelationsMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *RelationsMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		app.relationsView = nil
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	// Let the panel handle navigation and jumping
+	return false, nil
+}
+
+// PickResourceMode asks which of several same-named resources was meant
+type PickResourceMode struct {
+	BaseMode
+}
+
+func NewPickResourceMode() *PickResourceMode {
+	return &PickResourceMode{
+		BaseMode: BaseMode{
+			modeType: ModePickResource,
+			title:    "KubeWatch TUI - Pick Resource",
+		},
+	}
+}
+
+func (m *PickResourceMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":     NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":   NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"enter":  NewKeyBinding([]string{"enter"}, "Enter", "Select resource", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc", "q"}, "Esc/q", "Cancel", "General"),
+	}
+}
+
+func (m *PickResourceMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *PickResourceMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		app.closeResourcePicker()
+		return true, nil
+	}
+
+	// Let the picker handle navigation and picking
+	return false, nil
+}
+
+// BatchMode handles the results overlay of a batch action
+type BatchMode struct {
+	BaseMode
+}
+
+func NewBatchMode() *BatchMode {
+	return &BatchMode{
+		BaseMode: BaseMode{
+			modeType: ModeBatch,
+			title:    "KubeWatch TUI - Batch Results",
+		},
+	}
+}
+
+func (m *BatchMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":     NewKeyBinding([]string{"up", "k"}, "↑/k", "Scroll failures up", "Navigation"),
+		"down":   NewKeyBinding([]string{"down", "j"}, "↓/j", "Scroll failures down", "Navigation"),
+		"retry":  NewKeyBinding([]string{"r"}, "r", "Retry failures", "Actions"),
+		"cancel": NewKeyBinding([]string{"c"}, "c", "Cancel remainder", "Actions"),
+		"quit":   NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape": NewKeyBinding([]string{"esc", "q"}, "Esc/q", "Close results", "General"),
+	}
+}
+
+func (m *BatchMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *BatchMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["retry"].Key):
+		return true, app.retryBatch()
+
+	case key.Matches(msg, bindings["cancel"].Key):
+		app.cancelBatch()
+		return true, nil
+
+	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		return true, app.dismissBatch()
+	}
+
+	// Let the overlay scroll its failures
+	return false, nil
+}
+
+// NodeDetailMode handles the node detail overlay
+type NodeDetailMode struct {
+	BaseMode
+}
+
+func NewNodeDetailMode() *NodeDetailMode {
+	return &NodeDetailMode{
+		BaseMode: BaseMode{
+			modeType: ModeNodeDetail,
+			title:    "KubeWatch TUI - Node",
+		},
+	}
+}
+
+func (m *NodeDetailMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":      NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":    NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"enter":   NewKeyBinding([]string{"enter"}, "Enter", "Show pod in the list", "Actions"),
+		"cordon":  NewKeyBinding([]string{"c"}, "c", "Cordon/uncordon node", "Actions"),
+		"refresh": NewKeyBinding([]string{"r", "ctrl+r"}, "r", "Refresh", "Actions"),
+		"quit":    NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":  NewKeyBinding([]string{"esc", "N", "q"}, "Esc/N/q", "Close node", "General"),
+	}
+}
+
+func (m *NodeDetailMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *NodeDetailMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["cordon"].Key):
+		app.confirmCordon()
+		return true, nil
+
+	case key.Matches(msg, bindings["refresh"].Key):
+		return true, app.refreshNodeDetail()
+
+	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		app.nodeDetailView = nil
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	// Let the overlay move through its pods
+	return false, nil
+}
+
+// UsageMode handles the overlay of usage by namespace
+type UsageMode struct {
+	BaseMode
+}
+
+func NewUsageMode() *UsageMode {
+	return &UsageMode{
+		BaseMode: BaseMode{
+			modeType: ModeUsage,
+			title:    "KubeWatch TUI - Usage",
+		},
+	}
+}
+
+func (m *UsageMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":      NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":    NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"enter":   NewKeyBinding([]string{"enter"}, "Enter", "Show the namespace's pods, or the pod in the list", "Actions"),
+		"sort":    NewKeyBinding([]string{"s"}, "s", "Sort by CPU, memory or name", "Actions"),
+		"refresh": NewKeyBinding([]string{"r", "ctrl+r"}, "r", "Refresh", "Actions"),
+		"quit":    NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":  NewKeyBinding([]string{"esc", "U", "q"}, "Esc/U/q", "Back to namespaces, or close", "General"),
+	}
+}
+
+func (m *UsageMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *UsageMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["refresh"].Key):
+		return true, app.refreshUsage()
+
+	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		if app.usageView != nil && app.usageView.Back() {
+			return true, nil
+		}
+		app.usageView = nil
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	// Let the overlay move, sort and drill in
+	return false, nil
+}
+
+// EventTailMode handles the live tail of a namespace's events
+type EventTailMode struct {
+	BaseMode
+}
+
+func NewEventTailMode() *EventTailMode {
+	return &EventTailMode{
+		BaseMode: BaseMode{
+			modeType: ModeEventTail,
+			title:    "KubeWatch TUI - Events",
+		},
+	}
+}
+
+func (m *EventTailMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":       NewKeyBinding([]string{"up", "k"}, "↑/k", "Scroll up", "Navigation"),
+		"down":     NewKeyBinding([]string{"down", "j"}, "↓/j", "Scroll down", "Navigation"),
+		"top":      NewKeyBinding([]string{"g", "home"}, "g", "Go to top", "Navigation"),
+		"bottom":   NewKeyBinding([]string{"G", "end"}, "G", "Go to bottom and follow", "Navigation"),
+		"follow":   NewKeyBinding([]string{"f"}, "f", "Toggle follow", "Actions"),
+		"search":   NewKeyBinding([]string{"/"}, "/", "Search", "Actions"),
+		"next":     NewKeyBinding([]string{"n"}, "n/N", "Next/previous match", "Actions"),
+		"warnings": NewKeyBinding([]string{"w"}, "w", "Toggle warnings only", "Actions"),
+		"export":   NewKeyBinding([]string{"e"}, "e", "Export shown lines to a file", "Actions"),
+		"quit":     NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":   NewKeyBinding([]string{"esc", "E", "q"}, "Esc/E/q", "Close events", "General"),
+	}
+}
+
+func (m *EventTailMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *EventTailMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	// A search being typed takes every key but Ctrl+C
+	if app.eventTailView != nil && app.eventTailView.IsSearchMode() && msg.String() != "ctrl+c" {
+		return false, nil
+	}
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		app.closeEventTail()
+		return true, nil
+	}
+
+	// Let the tail scroll, search, filter and export
+	return false, nil
+}
+
+// PermissionsMode handles the overlay of what the user may do in the
+// current namespace
+type PermissionsMode struct {
+	BaseMode
+}
+
+func NewPermissionsMode() *PermissionsMode {
+	return &PermissionsMode{
+		BaseMode: BaseMode{
+			modeType: ModePermissions,
+			title:    "KubeWatch TUI - Permissions",
+		},
+	}
+}
+
+func (m *PermissionsMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"refresh": NewKeyBinding([]string{"r", "ctrl+r"}, "r", "Check again", "Actions"),
+		"quit":    NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":  NewKeyBinding([]string{"esc", "ctrl+a", "q"}, "Esc/Ctrl+A/q", "Close permissions", "General"),
+	}
+}
+
+func (m *PermissionsMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *PermissionsMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		app.permissionsView = nil
+		app.setMode(ModeList)
+		return true, nil
+
+	case key.Matches(msg, bindings["refresh"].Key):
+		return true, app.refreshPermissions(true)
+	}
+
+	return true, nil
+}
+
+// FleetMode handles the search for pods by name across every context
+type FleetMode struct {
+	BaseMode
+}
+
+func NewFleetMode() *FleetMode {
+	return &FleetMode{
+		BaseMode: BaseMode{
+			modeType: ModeFleet,
+			title:    "KubeWatch TUI - Fleet Search",
+		},
+	}
+}
+
+func (m *FleetMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":       NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":     NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"logs":     NewKeyBinding([]string{"enter", "l"}, "Enter/l", "View the pod's logs", "Actions"),
+		"describe": NewKeyBinding([]string{"d"}, "d", "Describe the pod", "Actions"),
+		"search":   NewKeyBinding([]string{"/"}, "/", "New search", "Actions"),
+		"refresh":  NewKeyBinding([]string{"r", "ctrl+r"}, "r", "Search again", "Actions"),
+		"quit":     NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":   NewKeyBinding([]string{"esc", "q"}, "Esc/q", "Close fleet search", "General"),
+	}
+}
+
+func (m *FleetMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *FleetMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+
+	if key.Matches(msg, bindings["quit"].Key) {
+		return true, tea.Quit
+	}
+
+	if app.fleetView == nil {
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	// While a query is typed the input takes every key; Esc goes back to
+	// the last results, or closes when nothing was searched yet
+	if app.fleetView.IsEditing() {
+		if msg.Type == tea.KeyEsc {
+			if !app.fleetView.CancelEditing() {
+				app.closeFleetSearch()
+			}
+			return true, nil
+		}
+		return false, nil
+	}
+
+	switch {
+	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		app.closeFleetSearch()
+		return true, nil
+
+	case key.Matches(msg, bindings["logs"].Key):
+		return true, app.openFleetLogs()
+
+	case key.Matches(msg, bindings["describe"].Key):
+		return true, app.describeFleetPod()
+
+	case key.Matches(msg, bindings["refresh"].Key):
+		return true, app.searchFleet()
+	}
+
+	// Let the fleet view move the selection and start a new search
+	return false, nil
+}
+
+// CompareMode handles the side-by-side comparison of two contexts. Keys and
+// actions apply to the focused pane.
+type CompareMode struct {
+	BaseMode
+}
+
+func NewCompareMode() *CompareMode {
+	return &CompareMode{
+		BaseMode: BaseMode{
+			modeType: ModeCompare,
+			title:    "KubeWatch TUI - Compare Contexts",
+		},
+	}
+}
+
+func (m *CompareMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":       NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":     NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"left":     NewKeyBinding([]string{"left", "h"}, "←/h", "Scroll left", "Navigation"),
+		"right":    NewKeyBinding([]string{"right", "l"}, "→/l", "Scroll right", "Navigation"),
+		"tab":      NewKeyBinding([]string{"tab", "shift+tab"}, "Tab", "Switch pane", "Navigation"),
+		"link":     NewKeyBinding([]string{"L"}, "L", "Toggle linked selection", "Actions"),
+		"describe": NewKeyBinding([]string{"d", "enter"}, "d/Enter", "Describe resource", "Actions"),
+		"delete":   NewKeyBinding([]string{"delete", "D"}, "Del/D", "Delete resource", "Actions"),
+		"refresh":  NewKeyBinding([]string{"r", "ctrl+r"}, "r", "Refresh both panes", "Actions"),
+		"filter":   NewKeyBinding([]string{"/"}, "/", "Filter focused pane", "Actions"),
+		"startup":  NewKeyBinding([]string{"ctrl+s"}, "Ctrl+S", "Save comparison as the startup layout", "Actions"),
+		"quit":     NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":   NewKeyBinding([]string{"esc", "q"}, "Esc/q", "Close comparison", "General"),
+	}
+}
+
+func (m *CompareMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *CompareMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+	if app.comparisonView == nil {
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		app.closeComparison()
+		return true, nil
+
+	case key.Matches(msg, bindings["tab"].Key):
+		app.comparisonView.ToggleFocus()
+		return true, nil
+
+	case key.Matches(msg, bindings["link"].Key):
+		app.comparisonView.ToggleLinked()
+		return true, nil
+
+	case key.Matches(msg, bindings["startup"].Key):
+		app.saveStartupLayout()
+		return true, nil
+
+	case key.Matches(msg, bindings["describe"].Key):
+		if selectedName := app.comparisonView.Focused().GetSelectedResourceName(); selectedName != "" &&
+			app.comparisonView.FocusedClient() != nil {
+			app.setMode(ModeDescribe)
+			return true, app.startDescribeView(selectedName)
+		}
+		return true, nil
+
+	case key.Matches(msg, bindings["delete"].Key):
+		if selectedName := app.comparisonView.Focused().GetSelectedResourceName(); selectedName != "" && !app.deleteDenied() {
+			app.setMode(ModeConfirmDialog)
+			return true, app.showDeleteConfirmation()
+		}
+		return true, nil
+
+	case key.Matches(msg, bindings["refresh"].Key):
+		return true, app.comparisonView.RefreshResources()
+
+	case key.Matches(msg, bindings["filter"].Key):
+		app.startFilterBar()
+		return true, nil
+	}
+
+	// Navigation goes to the focused pane
+	return false, nil
+}
+
+// SplitMode handles the linked split of deployments over their pods
+type SplitMode struct {
+	BaseMode
+}
+
+func NewSplitMode() *SplitMode {
+	return &SplitMode{
+		BaseMode: BaseMode{
+			modeType: ModeSplit,
+			title:    "KubeWatch TUI - Split View",
+		},
+	}
+}
+
+func (m *SplitMode) GetKeyBindings() map[string]KeyBinding {
+	return map[string]KeyBinding{
+		"up":       NewKeyBinding([]string{"up", "k"}, "↑/k", "Move up", "Navigation"),
+		"down":     NewKeyBinding([]string{"down", "j"}, "↓/j", "Move down", "Navigation"),
+		"left":     NewKeyBinding([]string{"left", "h"}, "←/h", "Scroll left", "Navigation"),
+		"right":    NewKeyBinding([]string{"right", "l"}, "→/l", "Scroll right", "Navigation"),
+		"tab":      NewKeyBinding([]string{"tab", "shift+tab"}, "Tab", "Switch pane", "Navigation"),
+		"describe": NewKeyBinding([]string{"d", "enter"}, "d/Enter", "Describe resource", "Actions"),
+		"delete":   NewKeyBinding([]string{"delete", "D"}, "Del/D", "Delete resource", "Actions"),
+		"refresh":  NewKeyBinding([]string{"r", "ctrl+r"}, "r", "Refresh both panes", "Actions"),
+		"filter":   NewKeyBinding([]string{"/"}, "/", "Filter focused pane", "Actions"),
+		"quit":     NewKeyBinding([]string{"ctrl+c"}, "Ctrl+C", "Quit application", "General"),
+		"escape":   NewKeyBinding([]string{"esc", "q"}, "Esc/q", "Close split", "General"),
+	}
+}
+
+func (m *SplitMode) GetHelpSections() map[string][]KeyBinding {
+	bindings := m.GetKeyBindings()
+	sections := make(map[string][]KeyBinding)
+
+	for _, binding := range bindings {
+		sections[binding.Section] = append(sections[binding.Section], binding)
+	}
+
+	return sections
+}
+
+func (m *SplitMode) HandleKey(msg tea.KeyMsg, app *App) (bool, tea.Cmd) {
+	bindings := m.GetKeyBindings()
+	if app.splitView == nil {
+		app.setMode(ModeList)
+		return true, nil
+	}
+
+	switch {
+	case key.Matches(msg, bindings["quit"].Key):
+		return true, tea.Quit
+
+	case key.Matches(msg, bindings["escape"].Key):
+		if app.quitKeyQuits(msg) {
+			return true, tea.Quit
+		}
+		app.closeSplit()
+		return true, nil
+
+	case key.Matches(msg, bindings["tab"].Key):
+		app.splitView.ToggleFocus()
+		return true, nil
+
+	case key.Matches(msg, bindings["describe"].Key):
+		if selectedName := app.splitView.Focused().GetSelectedResourceName(); selectedName != "" &&
+			app.clientForContext(app.splitView.Context()) != nil {
+			app.setMode(ModeDescribe)
+			return true, app.startDescribeView(selectedName)
+		}
+		return true, nil
+
+	case key.Matches(msg, bindings["delete"].Key):
+		if selectedName := app.splitView.Focused().GetSelectedResourceName(); selectedName != "" && !app.deleteDenied() {
+			app.setMode(ModeConfirmDialog)
+			return true, app.showDeleteConfirmation()
+		}
+		return true, nil
+
+	case key.Matches(msg, bindings["refresh"].Key):
+		return true, app.splitView.RefreshResources()
+
+	case key.Matches(msg, bindings["filter"].Key):
+		app.startFilterBar()
+		return true, nil
+	}
+
+	// Navigation goes to the focused pane
+	return false, nil
+}
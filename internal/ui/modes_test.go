@@ -1,13 +1,27 @@
 package ui
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/HamStudy/kubewatch/internal/config"
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/testutil/fixtures"
 	"github.com/HamStudy/kubewatch/internal/ui/views"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // TestModeInitialization tests that all modes are properly initialized
@@ -60,6 +74,42 @@ func TestModeInitialization(t *testing.T) {
 			expectedType:  ModeConfirmDialog,
 			expectedTitle: "KubeWatch TUI - Confirmation",
 		},
+		{
+			name:          "TopologyMode",
+			createMode:    func() ScreenMode { return NewTopologyMode() },
+			expectedType:  ModeTopology,
+			expectedTitle: "KubeWatch TUI - Topology Spread",
+		},
+		{
+			name:          "SettingsMode",
+			createMode:    func() ScreenMode { return NewSettingsMode() },
+			expectedType:  ModeSettings,
+			expectedTitle: "KubeWatch TUI - Settings",
+		},
+		{
+			name:          "FilterMode",
+			createMode:    func() ScreenMode { return NewFilterMode() },
+			expectedType:  ModeFilter,
+			expectedTitle: "KubeWatch TUI - Filter",
+		},
+		{
+			name:          "SavedFiltersMode",
+			createMode:    func() ScreenMode { return NewSavedFiltersMode() },
+			expectedType:  ModeSavedFilters,
+			expectedTitle: "KubeWatch TUI - Saved Filters",
+		},
+		{
+			name:          "ScrubMode",
+			createMode:    func() ScreenMode { return NewScrubMode() },
+			expectedType:  ModeScrub,
+			expectedTitle: "KubeWatch TUI - History",
+		},
+		{
+			name:          "FinalizersMode",
+			createMode:    func() ScreenMode { return NewFinalizersMode() },
+			expectedType:  ModeFinalizers,
+			expectedTitle: "KubeWatch TUI - Remove Finalizer",
+		},
 	}
 
 	for _, tt := range tests {
@@ -126,6 +176,11 @@ func TestListModeCompleteKeyHandling(t *testing.T) {
 		{"D for delete", tea.KeyRunes, []rune("D"), false, ModeList, "Should not handle D when no resource selected"},
 		{"refresh", tea.KeyRunes, []rune("r"), true, ModeList, "Should handle r for refresh"},
 		{"sort", tea.KeyRunes, []rune("s"), true, ModeList, "Should handle s for sort"},
+		{"topology", tea.KeyRunes, []rune("T"), true, ModeList, "Should handle T but stay in list when no resource"},
+		{"settings", tea.KeyRunes, []rune(","), true, ModeSettings, "Should open settings overlay"},
+		{"filter", tea.KeyRunes, []rune("/"), true, ModeFilter, "Should open filter bar"},
+		{"saved filters", tea.KeyRunes, []rune("F"), true, ModeSavedFilters, "Should open saved filters picker"},
+		{"history", tea.KeyRunes, []rune("H"), true, ModeList, "Should handle H but stay in list while history is off"},
 
 		// General
 		{"quit q", tea.KeyRunes, []rune("q"), true, ModeList, "Should handle q for quit"},
@@ -133,8 +188,9 @@ func TestListModeCompleteKeyHandling(t *testing.T) {
 		{"escape", tea.KeyEsc, nil, false, ModeList, "Should not handle escape in list mode"},
 
 		// Unknown keys
-		{"unknown x", tea.KeyRunes, []rune("x"), false, ModeList, "Should not handle unknown key x"},
-		{"unknown z", tea.KeyRunes, []rune("z"), false, ModeList, "Should not handle unknown key z"},
+		{"relations x", tea.KeyRunes, []rune("x"), true, ModeList, "Should handle relations key (nothing selected)"},
+		{"unknown w", tea.KeyRunes, []rune("w"), false, ModeList, "Should not handle unknown key w"},
+		{"noise z", tea.KeyRunes, []rune("z"), true, ModeList, "Should handle z to toggle noise"},
 	}
 
 	for _, tt := range tests {
@@ -192,7 +248,7 @@ func TestLogModeCompleteKeyHandling(t *testing.T) {
 
 		// Mode-level controls
 		{"help", tea.KeyRunes, []rune("?"), false, true, "Should handle help at mode level"},
-		{"quit q", tea.KeyRunes, []rune("q"), false, true, "Should handle quit at mode level"},
+		{"close q", tea.KeyRunes, []rune("q"), false, true, "Should close logs like escape"},
 		{"quit ctrl+c", tea.KeyCtrlC, nil, false, true, "Should handle ctrl+c at mode level"},
 		{"escape", tea.KeyEsc, nil, false, true, "Should handle escape to return to list"},
 
@@ -249,12 +305,15 @@ func TestDescribeModeCompleteKeyHandling(t *testing.T) {
 
 		// Mode controls
 		{"help", tea.KeyRunes, []rune("?"), true, ModeHelp},
-		{"quit q", tea.KeyRunes, []rune("q"), true, ModeDescribe},
+		{"close q", tea.KeyRunes, []rune("q"), true, ModeList},
 		{"quit ctrl+c", tea.KeyCtrlC, nil, true, ModeDescribe},
 		{"escape", tea.KeyEsc, nil, true, ModeList},
 
+		// Actions
+		{"remove finalizer x", tea.KeyRunes, []rune("x"), true, ModeDescribe},
+
 		// Unknown keys
-		{"unknown x", tea.KeyRunes, []rune("x"), false, ModeDescribe},
+		{"unknown z", tea.KeyRunes, []rune("z"), false, ModeDescribe},
 	}
 
 	for _, tt := range tests {
@@ -293,7 +352,7 @@ func TestHelpModeCompleteKeyHandling(t *testing.T) {
 	}{
 		{"help toggle", tea.KeyRunes, []rune("?"), ModeList, true, ModeList},
 		{"escape", tea.KeyEsc, nil, ModeList, true, ModeList},
-		{"quit q", tea.KeyRunes, []rune("q"), ModeList, true, ModeHelp},
+		{"close q", tea.KeyRunes, []rune("q"), ModeList, true, ModeList},
 		{"quit ctrl+c", tea.KeyCtrlC, nil, ModeList, true, ModeHelp},
 		{"unknown key", tea.KeyRunes, []rune("x"), ModeList, false, ModeHelp},
 
@@ -351,9 +410,9 @@ func TestContextSelectorModeCompleteKeyHandling(t *testing.T) {
 		// Cancel
 		{"escape", tea.KeyEsc, nil, true, ModeList},
 		{"c key", tea.KeyRunes, []rune("c"), true, ModeList},
+		{"q key", tea.KeyRunes, []rune("q"), true, ModeList},
 
 		// Quit
-		{"quit q", tea.KeyRunes, []rune("q"), true, ModeContextSelector},
 		{"quit ctrl+c", tea.KeyCtrlC, nil, true, ModeContextSelector},
 
 		// Unknown
@@ -405,9 +464,9 @@ func TestNamespaceSelectorModeCompleteKeyHandling(t *testing.T) {
 		// Cancel
 		{"escape", tea.KeyEsc, nil, true, ModeList},
 		{"n key", tea.KeyRunes, []rune("n"), true, ModeList},
+		{"q key", tea.KeyRunes, []rune("q"), true, ModeList},
 
 		// Quit
-		{"quit q", tea.KeyRunes, []rune("q"), true, ModeNamespaceSelector},
 		{"quit ctrl+c", tea.KeyCtrlC, nil, true, ModeNamespaceSelector},
 
 		// Unknown
@@ -461,16 +520,21 @@ func TestConfirmDialogModeCompleteKeyHandling(t *testing.T) {
 		{"h key", tea.KeyRunes, []rune("h"), false, ModeConfirmDialog},
 		{"right arrow", tea.KeyRight, nil, false, ModeConfirmDialog},
 		{"l key", tea.KeyRunes, []rune("l"), false, ModeConfirmDialog},
+		{"tab", tea.KeyTab, nil, false, ModeConfirmDialog},
+		{"shift+tab", tea.KeyShiftTab, nil, false, ModeConfirmDialog},
 
 		// Confirmation
 		{"enter", tea.KeyEnter, nil, true, ModeConfirmDialog},
 		{"space", tea.KeySpace, nil, true, ModeConfirmDialog},
+		{"y key", tea.KeyRunes, []rune("y"), false, ModeConfirmDialog},
 
 		// Cancel
 		{"escape", tea.KeyEsc, nil, true, ModeList},
+		{"n key", tea.KeyRunes, []rune("n"), true, ModeList},
+		{"N key", tea.KeyRunes, []rune("N"), true, ModeList},
+		{"q key", tea.KeyRunes, []rune("q"), true, ModeList},
 
 		// Quit
-		{"quit q", tea.KeyRunes, []rune("q"), true, ModeConfirmDialog},
 		{"quit ctrl+c", tea.KeyCtrlC, nil, true, ModeConfirmDialog},
 
 		// Unknown
@@ -506,6 +570,218 @@ func TestConfirmDialogModeCompleteKeyHandling(t *testing.T) {
 	}
 }
 
+// TestConfirmDialogModeTypedConfirmation tests dialogs confirmed by typing a name
+func TestConfirmDialogModeTypedConfirmation(t *testing.T) {
+	mode := NewConfirmDialogMode()
+	app := createTestApp(t)
+	app.confirmView = views.NewConfirmView("Remove Finalizer", "Are you sure?")
+	app.confirmView.RequireInput("web")
+	app.setMode(ModeConfirmDialog)
+
+	// Keys that act on a plain dialog are text here
+	for _, r := range "qnyweb" {
+		handled, cmd := mode.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}, app)
+		if handled || cmd != nil {
+			t.Fatalf("Expected %q passed to the dialog as text", r)
+		}
+		app.confirmView.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if app.currentMode != ModeConfirmDialog {
+		t.Fatalf("Expected the dialog still open, got mode %v", app.currentMode)
+	}
+
+	// Enter is the dialog's, and does nothing until the name matches
+	if handled, _ := mode.HandleKey(tea.KeyMsg{Type: tea.KeyEnter}, app); handled {
+		t.Fatal("Expected enter passed to the dialog")
+	}
+	app.confirmView.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.confirmView.IsCompleted() {
+		t.Fatal("Expected a mismatched name not to confirm")
+	}
+
+	for i := 0; i < 6; i++ {
+		app.confirmView.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	for _, r := range "web" {
+		app.confirmView.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	app.confirmView.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !app.confirmView.IsCompleted() || !app.confirmView.IsConfirmed() {
+		t.Error("Expected the matching name to confirm")
+	}
+
+	// Esc still cancels
+	if handled, _ := mode.HandleKey(tea.KeyMsg{Type: tea.KeyEsc}, app); !handled {
+		t.Error("Expected esc to cancel")
+	}
+}
+
+// TestTopologyModeCompleteKeyHandling tests all key bindings in topology mode
+func TestTopologyModeCompleteKeyHandling(t *testing.T) {
+	tests := []struct {
+		name          string
+		keyType       tea.KeyType
+		keyRunes      []rune
+		expectHandled bool
+		expectMode    ScreenModeType
+	}{
+		{"refresh", tea.KeyRunes, []rune("r"), true, ModeTopology},
+		{"help", tea.KeyRunes, []rune("?"), true, ModeHelp},
+		{"escape", tea.KeyEsc, nil, true, ModeList},
+		{"T closes", tea.KeyRunes, []rune("T"), true, ModeList},
+		{"q closes", tea.KeyRunes, []rune("q"), true, ModeList},
+		{"unknown x", tea.KeyRunes, []rune("x"), true, ModeTopology},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode := NewTopologyMode()
+			app := createTestApp(t)
+			app.topologyView = views.NewTopologyView("Pods", "test-pod", "default", "")
+			app.setMode(ModeTopology)
+
+			keyMsg := tea.KeyMsg{Type: tt.keyType}
+			if tt.keyRunes != nil {
+				keyMsg.Runes = tt.keyRunes
+			}
+
+			handled, _ := mode.HandleKey(keyMsg, app)
+
+			if handled != tt.expectHandled {
+				t.Errorf("%s: expected handled=%v, got %v", tt.name, tt.expectHandled, handled)
+			}
+
+			if app.currentMode != tt.expectMode {
+				t.Errorf("%s: expected mode %v, got %v", tt.name, tt.expectMode, app.currentMode)
+			}
+		})
+	}
+}
+
+// TestTopologyOverlayOpensForSelectedResource tests T opens the overlay for workloads only
+func TestTopologyOverlayOpensForSelectedResource(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType core.ResourceType
+		expectMode   ScreenModeType
+	}{
+		{"pods", core.ResourceTypePod, ModeTopology},
+		{"deployments", core.ResourceTypeDeployment, ModeTopology},
+		{"services", core.ResourceTypeService, ModeList},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := createTestApp(t)
+			app.state.CurrentResourceType = tt.resourceType
+			app.resourceView.SetTestData([]string{"NAME", "AGE"}, [][]string{{"web-1", "5m"}})
+			app.setMode(ModeList)
+
+			app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+
+			if app.currentMode != tt.expectMode {
+				t.Errorf("Expected mode %v, got %v", tt.expectMode, app.currentMode)
+			}
+			if tt.expectMode == ModeTopology {
+				if app.topologyView == nil {
+					t.Fatal("Expected topology view to be created")
+				}
+				if !strings.Contains(app.View(), "web-1") {
+					t.Error("Expected overlay to name the selected resource")
+				}
+			}
+		})
+	}
+}
+
+// TestSettingsModeCompleteKeyHandling tests all key bindings in settings mode
+func TestSettingsModeCompleteKeyHandling(t *testing.T) {
+	tests := []struct {
+		name          string
+		keyType       tea.KeyType
+		keyRunes      []rune
+		editing       bool
+		expectHandled bool
+		expectMode    ScreenModeType
+	}{
+		{"escape closes", tea.KeyEsc, nil, false, true, ModeList},
+		{"comma closes", tea.KeyRunes, []rune(","), false, true, ModeList},
+		{"escape cancels edit", tea.KeyEsc, nil, true, false, ModeSettings},
+		{"q passes to view", tea.KeyRunes, []rune("q"), false, false, ModeSettings},
+		{"enter passes to view", tea.KeyEnter, nil, false, false, ModeSettings},
+		{"down passes to view", tea.KeyDown, nil, false, false, ModeSettings},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode := NewSettingsMode()
+			app := createTestApp(t)
+			app.startSettingsView()
+			if tt.editing {
+				app.settingsView.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			}
+
+			keyMsg := tea.KeyMsg{Type: tt.keyType}
+			if tt.keyRunes != nil {
+				keyMsg.Runes = tt.keyRunes
+			}
+
+			handled, _ := mode.HandleKey(keyMsg, app)
+
+			if handled != tt.expectHandled {
+				t.Errorf("%s: expected handled=%v, got %v", tt.name, tt.expectHandled, handled)
+			}
+
+			if app.currentMode != tt.expectMode {
+				t.Errorf("%s: expected mode %v, got %v", tt.name, tt.expectMode, app.currentMode)
+			}
+		})
+	}
+}
+
+// TestSettingsOverlayAppliesAndSaves tests that edits reach the config and views and can be saved
+func TestSettingsOverlayAppliesAndSaves(t *testing.T) {
+	app := createTestApp(t)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(",")})
+	if app.currentMode != ModeSettings {
+		t.Fatalf("Expected settings mode, got %v", app.currentMode)
+	}
+
+	// Edit the first setting (refresh interval)
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	app.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("7")})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected applying a setting to return a command")
+	}
+	app.Update(cmd())
+
+	if app.config.RefreshInterval != 7 {
+		t.Errorf("Expected refresh interval 7, got %d", app.config.RefreshInterval)
+	}
+
+	var saved map[string]string
+	app.SetSettingsSaver(func(values map[string]string) error {
+		saved = values
+		return nil
+	})
+
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if cmd == nil {
+		t.Fatal("Expected save to return a command")
+	}
+	app.Update(cmd())
+
+	if saved["refreshInterval"] != "7" {
+		t.Errorf("Expected saved refreshInterval 7, got %q", saved["refreshInterval"])
+	}
+	if !strings.Contains(app.View(), "Settings saved") {
+		t.Error("Expected overlay to confirm the save")
+	}
+}
+
 // TestModeTransitions tests all valid mode transitions
 func TestModeTransitions(t *testing.T) {
 	tests := []struct {
@@ -717,6 +993,41 @@ func TestKeyBindingConsistency(t *testing.T) {
 	}
 }
 
+// TestQuitKeyBehavior tests that q closes deep views unless it is set to quit
+// from everywhere, while Ctrl+C always quits
+func TestQuitKeyBehavior(t *testing.T) {
+	quits := func(cmd tea.Cmd) bool {
+		if cmd == nil {
+			return false
+		}
+		_, ok := cmd().(tea.QuitMsg)
+		return ok
+	}
+	q := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}
+
+	app := createTestApp(t)
+	app.setMode(ModeDescribe)
+	if _, cmd := NewDescribeMode().HandleKey(q, app); quits(cmd) || app.currentMode != ModeList {
+		t.Errorf("Expected q to close describe, got mode %v", app.currentMode)
+	}
+	if _, cmd := NewListMode().HandleKey(q, app); !quits(cmd) {
+		t.Error("Expected q to quit from the list")
+	}
+	app.setMode(ModeDescribe)
+	if _, cmd := NewDescribeMode().HandleKey(tea.KeyMsg{Type: tea.KeyCtrlC}, app); !quits(cmd) {
+		t.Error("Expected Ctrl+C to quit from describe")
+	}
+
+	app.SetQuitKeyBehavior(core.QuitKeyGlobal)
+	app.setMode(ModeHelp)
+	if _, cmd := NewHelpMode().HandleKey(q, app); !quits(cmd) || app.currentMode != ModeHelp {
+		t.Errorf("Expected global q to quit from help, got mode %v", app.currentMode)
+	}
+	if _, cmd := NewHelpMode().HandleKey(tea.KeyMsg{Type: tea.KeyEsc}, app); quits(cmd) {
+		t.Error("Expected Esc to still close help")
+	}
+}
+
 // TestKeyBindingUniqueness tests that key combinations don't conflict within a mode
 func TestKeyBindingUniqueness(t *testing.T) {
 	modes := []ScreenMode{
@@ -960,3 +1271,846 @@ func BenchmarkKeyHandling(b *testing.B) {
 		mode.HandleKey(keys[keyIndex], app)
 	}
 }
+
+// TestFilterBarFlow tests filtering the list from the filter bar and saving the filter
+func TestFilterBarFlow(t *testing.T) {
+	app := createTestApp(t)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if app.currentMode != ModeFilter {
+		t.Fatalf("Expected filter mode, got %v", app.currentMode)
+	}
+
+	// Keys that are list shortcuts elsewhere are text in the filter bar
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if app.currentMode != ModeFilter || app.filterBar.Expression() != "q" {
+		t.Fatalf("Expected q to be typed into the filter, got mode %v and %q", app.currentMode, app.filterBar.Expression())
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+
+	// A column the list does not show is rejected
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("zone=a")})
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.currentMode != ModeFilter {
+		t.Fatal("Expected filter bar to stay open for an unknown column")
+	}
+	if !strings.Contains(app.View(), "no column ZONE") {
+		t.Error("Expected unknown column message in the filter bar")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("status=Pending")})
+
+	var saved *config.SavedFilter
+	app.SetFilterSaver(func(f *config.SavedFilter) error {
+		saved = f
+		return nil
+	})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("pending")})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected save to return a command")
+	}
+	app.Update(cmd())
+
+	if saved == nil || saved.Name != "pending" || saved.Expression != "status=Pending" {
+		t.Fatalf("Expected filter to be saved, got %+v", saved)
+	}
+	if saved.ResourceType != string(core.ResourceTypePod) || saved.Namespace != "default" {
+		t.Errorf("Expected current resource type and namespace to be saved, got %+v", saved)
+	}
+	if app.currentMode != ModeList {
+		t.Errorf("Expected list mode after saving, got %v", app.currentMode)
+	}
+	if expression, name := app.state.GetFilter(); expression != "status=Pending" || name != "pending" {
+		t.Errorf("Expected saved filter to be active, got %q %q", expression, name)
+	}
+	if len(app.savedFilters) != 1 {
+		t.Errorf("Expected saved filter in the picker, got %d", len(app.savedFilters))
+	}
+
+	// Reopening the bar starts from the active filter; Esc leaves it unchanged
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if app.filterBar.Expression() != "status=Pending" {
+		t.Errorf("Expected the bar to start from the active filter, got %q", app.filterBar.Expression())
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList {
+		t.Fatalf("Expected Esc to close the filter bar, got %v", app.currentMode)
+	}
+	if expression, _ := app.state.GetFilter(); expression != "status=Pending" {
+		t.Errorf("Expected cancelling to keep the filter, got %q", expression)
+	}
+
+	// Esc in the list clears the filter
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if expression, name := app.state.GetFilter(); expression != "" || name != "" {
+		t.Errorf("Expected Esc to clear the filter, got %q %q", expression, name)
+	}
+}
+
+// TestFilterBarLaidOutOutsideView tests that the list makes room for the
+// filter bar when the mode is entered and the terminal resized, not when drawn
+func TestFilterBarLaidOutOutsideView(t *testing.T) {
+	app := createTestApp(t)
+	// More rows than fit, so the list fills whatever height it is given
+	var rows [][]string
+	for i := range 100 {
+		rows = append(rows, []string{fmt.Sprintf("web-%d", i), "Running"})
+	}
+	app.resourceView.SetTestData([]string{"NAME", "STATUS"}, rows)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	// The lines the list draws besides those it is given
+	extra := lipgloss.Height(app.resourceView.View()) - app.viewHeight()
+	listHeight := func() int { return lipgloss.Height(app.resourceView.View()) - extra }
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if got, want := listHeight(), app.viewHeight()-1; got != want {
+		t.Errorf("Expected the list a line shorter above the filter bar, %d lines, got %d", want, got)
+	}
+
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	before := listHeight()
+	app.View()
+	if got, want := listHeight(), app.viewHeight()-1; before != want || got != want {
+		t.Errorf("Expected the resize to lay out the list in %d lines and drawing to leave it, got %d then %d", want, before, got)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if got, want := listHeight(), app.viewHeight(); got != want {
+		t.Errorf("Expected the list its full %d lines once the filter bar closes, got %d", want, got)
+	}
+}
+
+// TestFiltersPerResourceType tests that each resource type keeps its own
+// filter, that Ctrl+G applies one to every type, and that Esc clears the
+// filter in effect before offering to clear the rest
+func TestFiltersPerResourceType(t *testing.T) {
+	app := createTestApp(t)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("name~web")})
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if expression, _ := app.state.GetFilter(); expression != "name~web" {
+		t.Fatalf("Expected the pods filter applied, got %q", expression)
+	}
+
+	app.switchResourceType(core.ResourceTypeDeployment)
+	if expression, _ := app.state.GetFilter(); expression != "" {
+		t.Errorf("Expected deployments unfiltered, got %q", expression)
+	}
+	app.switchResourceType(core.ResourceTypePod)
+	if expression, _ := app.state.GetFilter(); expression != "name~web" {
+		t.Errorf("Expected the pods filter back, got %q", expression)
+	}
+
+	// Ctrl+G applies the filter to every type
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("api")})
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if app.currentMode != ModeList || !app.state.IsFilterGlobal() {
+		t.Fatalf("Expected a global filter applied, got mode %v", app.currentMode)
+	}
+	app.switchResourceType(core.ResourceTypeService)
+	if expression, _ := app.state.GetFilter(); expression != "api" {
+		t.Errorf("Expected the global filter on services, got %q", expression)
+	}
+	app.switchResourceType(core.ResourceTypePod)
+
+	// Esc ends the global filter first, leaving the pods filter
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if expression, _ := app.state.GetFilter(); expression != "name~web" {
+		t.Errorf("Expected the pods filter after ending the global one, got %q", expression)
+	}
+
+	// Clearing the pods filter while deployments keep one offers to clear both
+	app.switchResourceType(core.ResourceTypeDeployment)
+	app.state.SetFilter("ready=1/1", "")
+	app.switchResourceType(core.ResourceTypePod)
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if expression, _ := app.state.GetFilter(); expression != "" {
+		t.Errorf("Expected the pods filter cleared, got %q", expression)
+	}
+	if !app.clearFiltersOffered {
+		t.Fatal("Expected clearing the rest offered")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if remaining := app.state.FilteredTypes(); len(remaining) != 0 {
+		t.Errorf("Expected every filter cleared, got %v", remaining)
+	}
+
+	// Any other key withdraws the offer
+	app.state.SetFilter("name~web", "")
+	app.switchResourceType(core.ResourceTypeDeployment)
+	app.state.SetFilter("ready=1/1", "")
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if remaining := app.state.FilteredTypes(); len(remaining) != 1 || remaining[0] != core.ResourceTypePod {
+		t.Errorf("Expected the pods filter kept, got %v", remaining)
+	}
+}
+
+// TestFilterBarTypingAcrossRefreshes tests that refreshes landing between
+// keystrokes neither drop characters nor move the cursor
+func TestFilterBarTypingAcrossRefreshes(t *testing.T) {
+	app := createTestApp(t)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+
+	refreshes := []tea.Msg{
+		tickMsg(time.Now()),
+		watchEventMsg{Type: watch.Modified, Object: createMockPod("web-1", "Running", "default")},
+		tea.WindowSizeMsg{Width: 100, Height: 30},
+	}
+	typed := []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("stat")},
+		{Type: tea.KeyRunes, Runes: []rune("us=P")},
+		{Type: tea.KeyRunes, Runes: []rune("x")},
+		{Type: tea.KeyBackspace},
+		{Type: tea.KeyRunes, Runes: []rune("ending")},
+		{Type: tea.KeyHome},
+		{Type: tea.KeyRunes, Runes: []rune("!")},
+		{Type: tea.KeyDelete},
+		{Type: tea.KeyRunes, Runes: []rune("s")},
+	}
+	for i, key := range typed {
+		app.Update(key)
+		// A refresh, and what the refresh reports, land before the next key
+		app.Update(refreshes[i%len(refreshes)])
+		app.Update(app.resourceView.RefreshResources()())
+	}
+
+	if app.currentMode != ModeFilter {
+		t.Fatalf("Expected the filter bar still open, got mode %v", app.currentMode)
+	}
+	if got := app.filterBar.Expression(); got != "!status=Pending" {
+		t.Errorf("Expected the typed sequence, got %q", got)
+	}
+	if !strings.Contains(app.View(), "/!s") {
+		t.Errorf("Expected the cursor still after the edit, got:\n%s", app.View())
+	}
+}
+
+// TestFilterBarPreviewsAsTyped tests that the list is filtered by the filter
+// bar's expression once a burst of keys settles, and that Esc puts the list
+// back
+func TestFilterBarPreviewsAsTyped(t *testing.T) {
+	app := createTestApp(t)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	app.resourceView.SetTestData([]string{"NAME", "STATUS"}, [][]string{{"web-1", "Running"}, {"api-1", "Running"}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	listed := func() int {
+		count := 0
+		for _, name := range []string{"web-1", "api-1"} {
+			if strings.Contains(app.resourceView.View(), name) {
+				count++
+			}
+		}
+		return count
+	}
+
+	// Keys within a frame schedule one preview
+	_, first := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("we")})
+	_, second := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if first == nil || second != nil {
+		t.Fatal("Expected only the first key of a burst to schedule a preview")
+	}
+	if listed() != 2 {
+		t.Errorf("Expected no filtering before the preview is due, got %d rows", listed())
+	}
+	app.Update(filterPreviewMsg{})
+	if listed() != 1 {
+		t.Errorf("Expected the preview to filter the list, got %d rows", listed())
+	}
+
+	// An expression that does not parse yet keeps the last preview
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" status=")})
+	app.Update(filterPreviewMsg{})
+	if listed() != 1 {
+		t.Errorf("Expected the last preview kept, got %d rows", listed())
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if listed() != 2 {
+		t.Errorf("Expected Esc to show every row again, got %d rows", listed())
+	}
+}
+
+// TestSavedFiltersPicker tests applying saved filters from the picker
+func TestSavedFiltersPicker(t *testing.T) {
+	tests := []struct {
+		name          string
+		filter        *config.SavedFilter
+		expectMode    ScreenModeType
+		expectType    core.ResourceType
+		expectStatus  string
+		expectSortCol string
+	}{
+		{
+			name:          "applies type, namespace and sort",
+			filter:        &config.SavedFilter{Name: "lb", ResourceType: "services", Expression: "type=LoadBalancer", Namespace: "prod", Sort: &config.SortConfig{Column: "age"}},
+			expectMode:    ModeList,
+			expectType:    core.ResourceTypeService,
+			expectSortCol: "AGE",
+		},
+		{
+			name:         "unknown filter column",
+			filter:       &config.SavedFilter{Name: "zoned", ResourceType: "pods", Expression: "zone=us-east-1a"},
+			expectMode:   ModeSavedFilters,
+			expectType:   core.ResourceTypePod,
+			expectStatus: "no column ZONE",
+		},
+		{
+			name:         "unknown sort column",
+			filter:       &config.SavedFilter{Name: "sorted", ResourceType: "configmaps", Expression: "app", Sort: &config.SortConfig{Column: "SIZE"}},
+			expectMode:   ModeSavedFilters,
+			expectType:   core.ResourceTypePod,
+			expectStatus: "no column SIZE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := createTestApp(t)
+			app.SetSavedFilters([]*config.SavedFilter{tt.filter})
+
+			app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+			_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			if cmd == nil {
+				t.Fatal("Expected selecting a filter to return a command")
+			}
+			app.Update(cmd())
+
+			if app.currentMode != tt.expectMode {
+				t.Errorf("Expected mode %v, got %v", tt.expectMode, app.currentMode)
+			}
+			if app.state.CurrentResourceType != tt.expectType {
+				t.Errorf("Expected resource type %v, got %v", tt.expectType, app.state.CurrentResourceType)
+			}
+			if tt.expectStatus != "" {
+				if !strings.Contains(app.View(), tt.expectStatus) {
+					t.Errorf("Expected %q in the picker", tt.expectStatus)
+				}
+				if expression, _ := app.state.GetFilter(); expression != "" {
+					t.Errorf("Expected no filter to be applied, got %q", expression)
+				}
+				return
+			}
+
+			expression, name := app.state.GetFilter()
+			if expression != tt.filter.Expression || name != tt.filter.Name {
+				t.Errorf("Expected filter %q (%s), got %q (%s)", tt.filter.Expression, tt.filter.Name, expression, name)
+			}
+			if app.state.CurrentNamespace != tt.filter.Namespace {
+				t.Errorf("Expected namespace %q, got %q", tt.filter.Namespace, app.state.CurrentNamespace)
+			}
+			if app.state.SortColumn != tt.expectSortCol {
+				t.Errorf("Expected sort column %q, got %q", tt.expectSortCol, app.state.SortColumn)
+			}
+		})
+	}
+}
+
+// TestSavedFiltersContextScope tests that filters scoped to another context are not offered
+func TestSavedFiltersContextScope(t *testing.T) {
+	app := createTestApp(t)
+	app.activeContexts = []string{"staging"}
+	app.SetSavedFilters([]*config.SavedFilter{
+		{Name: "everywhere", ResourceType: "pods", Expression: "web"},
+		{Name: "prod-only", ResourceType: "pods", Expression: "api", Context: "prod"},
+		{Name: "staging-only", ResourceType: "pods", Expression: "db", Context: "staging"},
+	})
+
+	app.startSavedFiltersView()
+	view := app.View()
+	if !strings.Contains(view, "everywhere") || !strings.Contains(view, "staging-only") {
+		t.Error("Expected unscoped and staging filters to be listed")
+	}
+	if strings.Contains(view, "prod-only") {
+		t.Error("Expected prod filter to be hidden in staging")
+	}
+}
+
+// TestScrubModeFlow tests freezing the list on recorded states and returning to live
+func TestScrubModeFlow(t *testing.T) {
+	app := createTestApp(t)
+	app.config.HistoryMinutes = 5
+	app.applyRuntimeSettings()
+
+	// Two refreshes leave two table states in the history
+	headers := []string{"NAME", "STATUS"}
+	app.resourceView.SetSize(120, 30)
+	app.resourceView.SetTestData(headers, [][]string{{"web-1", "Running"}})
+	app.resourceView.SetTestData(headers, [][]string{{"web-1", "Running"}, {"web-2", "Pending"}})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	if app.currentMode != ModeScrub {
+		t.Fatalf("Expected scrub mode, got %v", app.currentMode)
+	}
+	if view := app.View(); !strings.Contains(view, "Viewing state as of") || !strings.Contains(view, "(2/2)") || !strings.Contains(view, "web-2") {
+		t.Errorf("Expected the latest state to be shown, got:\n%s", view)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	view := app.View()
+	if !strings.Contains(view, "(1/2)") || strings.Contains(view, "web-2") {
+		t.Errorf("Expected the older state without web-2, got:\n%s", view)
+	}
+
+	// Action keys are swallowed while scrubbing
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if app.currentMode != ModeScrub {
+		t.Errorf("Expected / to be ignored while scrubbing, got mode %v", app.currentMode)
+	}
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList || app.resourceView.IsScrubbing() {
+		t.Errorf("Expected Esc to return to the live list, got mode %v", app.currentMode)
+	}
+	if cmd == nil {
+		t.Error("Expected leaving scrub mode to refresh the list")
+	}
+	if !strings.Contains(app.View(), "web-2") {
+		t.Errorf("Expected the live list to be shown again, got:\n%s", app.View())
+	}
+}
+
+// TestScrubModeCopyAndDescribe tests that copy and describe act on the row of
+// the state shown while scrubbing
+func TestScrubModeCopyAndDescribe(t *testing.T) {
+	app := createTestApp(t)
+	app.k8sClient = &k8s.Client{}
+	app.config.HistoryMinutes = 5
+	app.applyRuntimeSettings()
+
+	// web-old is only in the older table state
+	headers := []string{"NAME", "STATUS"}
+	app.resourceView.SetSize(120, 30)
+	app.resourceView.SetTestData(headers, [][]string{{"web-old", "Running"}})
+	app.resourceView.SetTestData(headers, [][]string{{"web-new", "Running"}})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	app.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if app.currentMode != ModeScrub {
+		t.Fatalf("Expected scrub mode, got %v", app.currentMode)
+	}
+
+	var clipboard bytes.Buffer
+	old := clipboardOutput
+	clipboardOutput = &clipboard
+	defer func() { clipboardOutput = old }()
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	command := app.viewLink().Command()
+	if !strings.Contains(command, "--select web-old") {
+		t.Errorf("Expected the command to select the scrubbed row, got %q", command)
+	}
+	if encoded := base64.StdEncoding.EncodeToString([]byte(command)); !strings.Contains(clipboard.String(), encoded) {
+		t.Errorf("Expected an OSC 52 copy of %q, got %q", command, clipboard.String())
+	}
+	if app.currentMode != ModeScrub {
+		t.Errorf("Expected to stay in scrub mode after copying, got %v", app.currentMode)
+	}
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if app.currentMode != ModeDescribe || cmd == nil {
+		t.Fatalf("Expected d to describe while scrubbing, got mode %v", app.currentMode)
+	}
+	if got := app.describeView.GetResourceName(); got != "web-old" {
+		t.Errorf("Expected the scrubbed row described, got %q", got)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeScrub || !app.resourceView.IsScrubbing() {
+		t.Errorf("Expected Esc from describe to return to scrubbing, got mode %v", app.currentMode)
+	}
+}
+
+// TestFinalizerRemovalFlow tests the gate, picker and confirmation for removing a finalizer
+func TestFinalizerRemovalFlow(t *testing.T) {
+	app := createTestApp(t)
+	app.describeView = views.NewDescribeView(context.Background(), nil, "Pods", "web-1", "default", "")
+	app.describeView.SetSize(app.width, app.height)
+	app.setMode(ModeDescribe)
+
+	// Disabled by default
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if app.currentMode != ModeDescribe || !strings.Contains(app.View(), "allowFinalizerRemoval") {
+		t.Fatalf("Expected removal to be refused while disabled, got mode %v:\n%s", app.currentMode, app.View())
+	}
+
+	app.SetFinalizerRemoval(true)
+	target := &finalizerRemoval{namespace: "default", resourceType: "Pods", name: "web-1"}
+	app.Update(finalizersLoadedMsg{target: target, finalizers: []string{"example.com/a", "example.com/b"}})
+	if app.currentMode != ModeFinalizers {
+		t.Fatalf("Expected the finalizer picker, got mode %v", app.currentMode)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected enter to select a finalizer")
+	}
+	app.Update(cmd())
+	if app.currentMode != ModeConfirmDialog || app.pendingFinalizer.finalizer != "example.com/b" {
+		t.Fatalf("Expected confirmation for example.com/b, got mode %v", app.currentMode)
+	}
+
+	// Cancelling goes back to describe without touching the cluster
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeDescribe || app.pendingFinalizer != nil {
+		t.Errorf("Expected Esc to cancel back to describe, got mode %v", app.currentMode)
+	}
+
+	target.finalizer = "example.com/b"
+	app.Update(finalizerRemovedMsg{removal: target, err: errors.New("patch rejected")})
+	entries := app.ActionLog().Entries()
+	if len(entries) != 1 || entries[0].Detail != "example.com/b" || entries[0].Err == nil {
+		t.Errorf("Expected the failed removal to be logged, got %+v", entries)
+	}
+	if !strings.Contains(app.View(), "patch rejected") {
+		t.Errorf("Expected the failure to be shown, got:\n%s", app.View())
+	}
+}
+
+// TestUserActionFlow tests running user-defined actions by key and from the quick-action menu
+func TestUserActionFlow(t *testing.T) {
+	app := createTestApp(t)
+	app.state.SetResourceType(core.ResourceTypePod)
+	app.resourceView.SetSize(120, 30)
+	app.resourceView.SetTestData([]string{"NAME", "NODE"}, [][]string{{"web-1", "node-a"}})
+
+	background := false
+	warnings := app.SetUserActions([]*config.UserAction{
+		{Name: "echo", Key: "O", ResourceType: "pods", Command: "printf '%s@%s' {{.Name}} {{.Node}}", Interactive: &background},
+		{Name: "clash", Key: "d", Command: "true"},
+		{Name: "deployments only", Key: "Y", ResourceType: "deployments", Command: "true"},
+	})
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `"clash"`) {
+		t.Errorf("Expected a warning for the key clash, got %v", warnings)
+	}
+	if app.userActionForKey("Y") != nil {
+		t.Error("Expected an action for another resource type not to be bound")
+	}
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("O")})
+	if cmd == nil {
+		t.Fatal("Expected the bound key to run the action")
+	}
+	app.Update(cmd())
+	if app.currentMode != ModeActionOutput {
+		t.Fatalf("Expected the action output, got mode %v", app.currentMode)
+	}
+	if view := app.View(); !strings.Contains(view, "web-1@node-a") {
+		t.Errorf("Expected the output with placeholders filled in, got:\n%s", view)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList {
+		t.Fatalf("Expected Esc to close the output, got mode %v", app.currentMode)
+	}
+
+	// The menu offers every action for pods, including the one without a key
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	if app.currentMode != ModeActionMenu {
+		t.Fatalf("Expected the quick-action menu, got mode %v", app.currentMode)
+	}
+	view := app.View()
+	if !strings.Contains(view, "echo") || !strings.Contains(view, "clash") || strings.Contains(view, "deployments only") {
+		t.Errorf("Expected the pod actions in the menu, got:\n%s", view)
+	}
+
+	// A failing command is reported with its exit status
+	app.setMode(ModeList)
+	app.Update(userActionOutputMsg{name: "broken", command: "false", err: errors.New("exit status 1")})
+	if view := app.View(); !strings.Contains(view, "exit status 1") {
+		t.Errorf("Expected the failure to be shown, got:\n%s", view)
+	}
+
+	// External commands are off while the network is restricted
+	k8s.RestrictNetwork(true)
+	defer k8s.RestrictNetwork(false)
+	app.setMode(ModeList)
+	if _, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("O")}); cmd != nil {
+		t.Error("Expected no action to run with external network access disabled")
+	}
+	if view := app.View(); !strings.Contains(view, "--no-external-network") {
+		t.Errorf("Expected the refusal to be shown, got:\n%s", view)
+	}
+}
+
+func TestCreateFromTemplateFlow(t *testing.T) {
+	app := createTestApp(t)
+	app.resourceView.SetSize(120, 30)
+	app.resourceView.SetTestData([]string{"NAME", "NODE"}, [][]string{{"web-1", "node-a"}})
+	app.SetManifestTemplates([]*config.ManifestTemplate{{
+		Name:       "Debug",
+		Parameters: []string{"Namespace", "Node"},
+		Manifest:   "apiVersion: v1\nkind: Pod\nmetadata:\n  namespace: {{.Namespace}}\nspec:\n  nodeName: {{.Node}}\n",
+	}})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	if app.currentMode != ModeTemplates {
+		t.Fatalf("Expected the template picker, got mode %v", app.currentMode)
+	}
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected enter to pick the template")
+	}
+	app.Update(cmd())
+	if app.currentMode != ModeTemplateForm {
+		t.Fatalf("Expected the parameter form, got mode %v", app.currentMode)
+	}
+
+	// Defaults come from the namespace being viewed and the selected row
+	form := app.templateFormView
+	if form.Value("Namespace") != "default" || form.Value("Node") != "node-a" {
+		t.Errorf("Expected default/node-a, got %q/%q", form.Value("Namespace"), form.Value("Node"))
+	}
+
+	// Typing edits the focused field, even keys bound elsewhere
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	app.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if app.currentMode != ModeTemplateForm || form.Value("Node") != "node-q" {
+		t.Fatalf("Expected node-q typed into the form, got %q in mode %v", form.Value("Node"), app.currentMode)
+	}
+	if view := app.View(); !strings.Contains(view, "nodeName: node-q") {
+		t.Errorf("Expected the rendered manifest in the preview, got:\n%s", view)
+	}
+
+	// Without a cluster the error is shown in the form
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app.Update(cmd())
+	if app.currentMode != ModeTemplateForm || !strings.Contains(form.Error(), "no cluster connection") {
+		t.Fatalf("Expected the error inline, got %q in mode %v", form.Error(), app.currentMode)
+	}
+
+	// API errors stay in the form so the values can be fixed
+	app.Update(resourcesCreatedMsg{template: "Debug", err: errors.New(`Pod "x" is invalid: spec.nodeName: Invalid value`)})
+	if app.currentMode != ModeTemplateForm || !strings.Contains(app.View(), "Invalid value") {
+		t.Fatalf("Expected the API error in the form, got mode %v:\n%s", app.currentMode, app.View())
+	}
+
+	app.Update(resourcesCreatedMsg{template: "Debug", context: "test-context", created: []k8s.CreatedResource{
+		{Kind: "Pod", Resource: "pods", Namespace: "default", Name: "debug-x7k2p"},
+	}})
+	if app.currentMode != ModeList {
+		t.Fatalf("Expected the list after creating, got mode %v", app.currentMode)
+	}
+	if view := app.View(); !strings.Contains(view, "pod/debug-x7k2p") {
+		t.Errorf("Expected a notice naming the pod, got:\n%s", view)
+	}
+	entries := app.ActionLog().Entries()
+	if len(entries) != 2 || entries[0].Err == nil || entries[1].Name != "debug-x7k2p" {
+		t.Errorf("Expected the failed and successful creations in the action log, got %v", entries)
+	}
+
+	// Cleanup asks before deleting and Esc returns to the picker
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	app.Update(createdResourcesFoundMsg{cleanup: &createdCleanup{resources: []k8s.CreatedResource{
+		{Kind: "Pod", Resource: "pods", Namespace: "default", Name: "debug-x7k2p"},
+	}}})
+	if app.currentMode != ModeConfirmDialog || !strings.Contains(app.View(), "pod/debug-x7k2p") {
+		t.Fatalf("Expected confirmation listing the pod, got mode %v", app.currentMode)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeTemplates || app.pendingCleanup != nil {
+		t.Fatalf("Expected Esc to return to the picker, got mode %v", app.currentMode)
+	}
+
+	app.Update(createdResourcesFoundMsg{cleanup: &createdCleanup{}})
+	if !strings.Contains(app.View(), "Nothing to clean up") {
+		t.Errorf("Expected a note when nothing was created, got:\n%s", app.View())
+	}
+}
+
+func TestCompareContextsFlow(t *testing.T) {
+	app := createTestApp(t)
+	app.width, app.height = 160, 30
+
+	// The context selector refuses to compare until exactly two are marked
+	app.openContextSelector()
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("=")})
+	if app.currentMode != ModeContextSelector || !strings.Contains(app.View(), "Mark exactly two contexts") {
+		t.Fatalf("Expected a note asking for two contexts, got mode %v:\n%s", app.currentMode, app.View())
+	}
+
+	app.Update(comparisonReadyMsg{contexts: [2]string{"context-1", "context-2"}})
+	if app.currentMode != ModeCompare || app.comparisonView == nil {
+		t.Fatalf("Expected the comparison, got mode %v", app.currentMode)
+	}
+	if view := app.View(); !strings.Contains(view, "context-1 ⇄ context-2") {
+		t.Errorf("Expected both contexts in the header, got:\n%s", view)
+	}
+
+	// Tab moves the focus and actions follow it
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if app.getSelectedResourceContext() != "context-2" || app.listView() != app.comparisonView.Panes()[1] {
+		t.Errorf("Expected actions to apply to the context-2 pane")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	if app.comparisonView.IsLinked() {
+		t.Error("Expected L to unlink the panes")
+	}
+
+	// Filtering applies to the focused pane only
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if app.currentMode != ModeFilter {
+		t.Fatalf("Expected the filter bar, got mode %v", app.currentMode)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeCompare {
+		t.Fatalf("Expected Esc to return to the comparison, got mode %v", app.currentMode)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList || app.comparisonView != nil {
+		t.Errorf("Expected Esc to close the comparison, got mode %v", app.currentMode)
+	}
+}
+
+func TestRelationsNavigationFlow(t *testing.T) {
+	app := createTestApp(t)
+	app.resourceView.SetSize(120, 30)
+	app.resourceView.SetTestData([]string{"NAME", "STATUS"}, [][]string{{"web-1", "Running"}})
+	app.state.UpdatePods([]v1.Pod{
+		fixtures.NewPod("web-1", "default").WithOwner("ReplicaSet", "web-5d8f7").
+			WithLabels(map[string]string{"pod-template-hash": "5d8f7"}).Build(),
+	})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if app.currentMode != ModeRelations {
+		t.Fatalf("Expected the relationships panel, got mode %v", app.currentMode)
+	}
+	// Deployments were never listed, so the owner is not cached
+	if view := app.View(); !strings.Contains(view, "deployment/web") || !strings.Contains(view, "missing") {
+		t.Errorf("Expected the owning deployment marked missing, got:\n%s", view)
+	}
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected enter to jump to the deployment")
+	}
+	app.Update(cmd())
+	if app.currentMode != ModeList || app.state.CurrentResourceType != core.ResourceTypeDeployment {
+		t.Fatalf("Expected the deployment list, got mode %v showing %s", app.currentMode, app.state.CurrentResourceType)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if app.state.CurrentResourceType != core.ResourceTypePod || len(app.navStack) != 0 {
+		t.Errorf("Expected Backspace to return to the pods, got %s with %d jumps left",
+			app.state.CurrentResourceType, len(app.navStack))
+	}
+
+	// Nothing left to go back to
+	app.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if app.state.CurrentResourceType != core.ResourceTypePod {
+		t.Errorf("Expected Backspace with no history to stay put, got %s", app.state.CurrentResourceType)
+	}
+}
+
+func TestSplitDeploymentOverPodsFlow(t *testing.T) {
+	app := createTestApp(t)
+	app.width, app.height = 160, 40
+
+	// Only a deployment can be split over its pods
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("V")})
+	if app.currentMode != ModeList || app.splitView != nil {
+		t.Fatalf("Expected no split from the pod list, got mode %v", app.currentMode)
+	}
+
+	app.state.SetResourceType(core.ResourceTypeDeployment)
+	app.state.UpdateDeployments([]appsv1.Deployment{fixtures.NewDeployment("web", "default").Build()})
+	app.resourceView.SetTestData([]string{"NAME", "READY"}, [][]string{{"web", "1/1"}})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("V")})
+	if app.currentMode != ModeSplit || app.splitView == nil {
+		t.Fatalf("Expected the split, got mode %v", app.currentMode)
+	}
+	if view := app.View(); !strings.Contains(view, "pods of deployment web") {
+		t.Errorf("Expected the link in the header, got:\n%s", view)
+	}
+
+	// Tab moves the focus to the pods, and actions follow it
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if app.listView() != app.splitView.Panes()[1] || app.listState().CurrentResourceType != core.ResourceTypePod {
+		t.Error("Expected actions to apply to the pods pane")
+	}
+
+	// Filtering applies to the focused pane and returns to the split
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if app.currentMode != ModeFilter {
+		t.Fatalf("Expected the filter bar, got mode %v", app.currentMode)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeSplit {
+		t.Fatalf("Expected Esc to return to the split, got mode %v", app.currentMode)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList || app.splitView != nil {
+		t.Errorf("Expected Esc to close the split, got mode %v", app.currentMode)
+	}
+}
+
+func TestSameNamedPodsAcrossNamespacesFlow(t *testing.T) {
+	app := createTestApp(t)
+	app.width, app.height = 160, 40
+	app.state.SetNamespace("")
+	app.resourceView.SetTestData([]string{"NAME", "NAMESPACE", "STATUS"}, [][]string{
+		{"web", "alpha", "Running"},
+		{"web", "beta", "Running"},
+		{"web", "gamma", "Running"},
+	})
+	matches := []core.ResourceRef{
+		{Namespace: "alpha", Name: "web"},
+		{Namespace: "beta", Name: "web"},
+		{Namespace: "gamma", Name: "web"},
+	}
+
+	// A bare name matching several pods asks which one was meant
+	app.Update(views.AmbiguousSelectionMsg{Ref: core.ResourceRef{Name: "web"}, Matches: matches})
+	if app.currentMode != ModePickResource {
+		t.Fatalf("Expected the resource picker, got mode %v", app.currentMode)
+	}
+	if view := app.View(); !strings.Contains(view, "alpha/web") || !strings.Contains(view, "gamma/web") {
+		t.Errorf("Expected the picker to name the namespaces, got:\n%s", view)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected Enter to pick a pod")
+	}
+	app.Update(cmd())
+	if app.currentMode != ModeList || app.resourceView.GetSelectedResourceNamespace() != "beta" {
+		t.Fatalf("Expected beta/web selected in the list, got %q in mode %v",
+			app.resourceView.GetSelectedResourceNamespace(), app.currentMode)
+	}
+
+	// Esc cancels without moving the selection
+	app.Update(views.AmbiguousSelectionMsg{Ref: core.ResourceRef{Name: "web"}, Matches: matches})
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList || app.resourcePickerView != nil || app.resourceView.GetSelectedResourceNamespace() != "beta" {
+		t.Errorf("Expected Esc to close the picker and keep beta/web, got mode %v", app.currentMode)
+	}
+
+	// Links, relations and deletes name the selected pod's namespace
+	if link := app.viewLink(); link.Selected != "beta/web" {
+		t.Errorf("Expected the link to select beta/web, got %q", link.Selected)
+	}
+	app.openRelations()
+	if view := app.View(); !strings.Contains(view, "Namespace: beta") {
+		t.Errorf("Expected relations of beta/web, got:\n%s", view)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	if app.currentMode != ModeConfirmDialog || !strings.Contains(app.View(), "beta/web") {
+		t.Errorf("Expected the delete confirmation to name beta/web, got:\n%s", app.View())
+	}
+}
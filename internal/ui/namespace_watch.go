@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HamStudy/kubewatch/internal/k8s"
+)
+
+// namespaceCheckTimeout bounds each check that the namespace shown exists
+const namespaceCheckTimeout = 5 * time.Second
+
+// namespaceStateMsg reports whether the namespace shown still exists
+type namespaceStateMsg struct {
+	namespace string
+	state     k8s.NamespaceState
+	known     bool // False when no context could say
+}
+
+// checkNamespace asks whether the namespace shown is being deleted or is
+// gone, since lists in a deleted namespace succeed with nothing in them. With
+// several contexts, the namespace is gone only when it is gone from all of
+// them. Nothing is checked when every namespace is shown, or while a check
+// is under way.
+func (a *App) checkNamespace() tea.Cmd {
+	namespace := a.state.GetCurrentNamespace()
+	if namespace == "" || namespace == "all" || a.namespaceChecking {
+		return nil
+	}
+	var clients []*k8s.Client
+	if a.isMultiContext && a.multiClient != nil {
+		for _, contextName := range a.activeContexts {
+			if client, err := a.multiClient.GetClient(contextName); err == nil {
+				clients = append(clients, client)
+			}
+		}
+	} else if a.k8sClient != nil {
+		clients = append(clients, a.k8sClient)
+	}
+	if len(clients) == 0 {
+		return nil
+	}
+
+	a.namespaceChecking = true
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), namespaceCheckTimeout)
+		defer cancel()
+		msg := namespaceStateMsg{namespace: namespace, state: k8s.NamespaceGone}
+		for _, client := range clients {
+			state, err := client.GetNamespaceState(ctx, namespace)
+			if err != nil {
+				// A context that cannot say, such as one where namespaces
+				// may not be read, leaves the others to decide
+				continue
+			}
+			msg.known = true
+			if state < msg.state {
+				msg.state = state
+			}
+		}
+		return msg
+	}
+}
+
+// namespaceChecked shows or clears the banner saying the namespace shown is
+// being deleted or is gone
+func (a *App) namespaceChecked(msg namespaceStateMsg) {
+	a.namespaceChecking = false
+	if !msg.known || msg.namespace != a.state.GetCurrentNamespace() {
+		return
+	}
+	switch msg.state {
+	case k8s.NamespaceTerminating:
+		a.namespaceLost = msg.namespace
+		a.resourceView.SetNamespaceBanner(fmt.Sprintf("⚠ Namespace '%s' is terminating — n: pick another namespace, *: all namespaces", msg.namespace))
+	case k8s.NamespaceGone:
+		a.namespaceLost = msg.namespace
+		a.resourceView.SetNamespaceBanner(fmt.Sprintf("⚠ Namespace '%s' was deleted — n: pick another namespace, *: all namespaces", msg.namespace))
+	default:
+		a.clearNamespaceBanner()
+	}
+}
+
+// clearNamespaceBanner removes the banner about a lost namespace
+func (a *App) clearNamespaceBanner() {
+	a.namespaceLost = ""
+	a.resourceView.SetNamespaceBanner("")
+}
+
+// showAllNamespaces leaves a namespace that is being deleted or is gone for
+// all namespaces. It is only offered while the banner is shown, and reports
+// whether it was.
+func (a *App) showAllNamespaces() (bool, tea.Cmd) {
+	if a.namespaceLost == "" {
+		return false, nil
+	}
+	a.clearNamespaceBanner()
+	a.state.SetNamespace("")
+	a.config.CurrentNamespace = ""
+	return true, a.resourceView.RefreshResources()
+}
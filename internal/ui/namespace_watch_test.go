@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/client-go/rest"
+
+	"github.com/HamStudy/kubewatch/internal/k8s"
+)
+
+func TestNamespaceDeletedBanner(t *testing.T) {
+	// The server's payments namespace goes through being deleted
+	var mu sync.Mutex
+	phase := "Active"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/api/v1/namespaces/payments" || phase == "" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+			return
+		}
+		w.Write([]byte(`{"kind":"Namespace","apiVersion":"v1","metadata":{"name":"payments"},"status":{"phase":"` + phase + `"}}`))
+	}))
+	defer server.Close()
+	setPhase := func(p string) {
+		mu.Lock()
+		defer mu.Unlock()
+		phase = p
+	}
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+
+	app := createTestApp(t)
+	app.width, app.height = 160, 40
+	app.resourceView.SetSize(app.width, app.height)
+	app.isMultiContext = false
+	app.k8sClient = client
+	app.state.SetNamespace("payments")
+
+	check := func() {
+		t.Helper()
+		cmd := app.checkNamespace()
+		if cmd == nil {
+			t.Fatal("Expected the namespace checked")
+		}
+		app.Update(cmd())
+	}
+
+	check()
+	if strings.Contains(app.View(), "Namespace 'payments'") {
+		t.Fatalf("Expected no banner while the namespace exists, got:\n%s", app.View())
+	}
+
+	setPhase("Terminating")
+	check()
+	if !strings.Contains(app.View(), "Namespace 'payments' is terminating") {
+		t.Fatalf("Expected the terminating banner, got:\n%s", app.View())
+	}
+
+	setPhase("")
+	check()
+	view := app.View()
+	if !strings.Contains(view, "Namespace 'payments' was deleted") || !strings.Contains(view, "*: all namespaces") {
+		t.Fatalf("Expected the deleted banner offering a way out, got:\n%s", view)
+	}
+
+	// n opens the namespace selector as usual
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if app.currentMode != ModeNamespaceSelector {
+		t.Fatalf("Expected n to open the namespace selector, got mode %v", app.currentMode)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	// * switches to all namespaces, after which there is nothing to check
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("*")})
+	if ns := app.state.GetCurrentNamespace(); ns != "" {
+		t.Errorf("Expected all namespaces, got %q", ns)
+	}
+	if strings.Contains(app.View(), "Namespace 'payments'") {
+		t.Error("Expected the banner cleared")
+	}
+	if app.checkNamespace() != nil {
+		t.Error("Expected no check across all namespaces")
+	}
+
+	// * only leaves a lost namespace
+	app.state.SetNamespace("payments")
+	setPhase("Active")
+	check()
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("*")})
+	if ns := app.state.GetCurrentNamespace(); ns != "payments" {
+		t.Errorf("Expected * to do nothing while the namespace exists, got %q", ns)
+	}
+}
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/notify"
+)
+
+// SetNotifications turns on desktop notifications of alerts raised while
+// the terminal is unfocused, sent through d
+func (a *App) SetNotifications(d *notify.Dispatcher) {
+	a.notifications = d
+}
+
+// notifyAlerts sends a desktop notification of the alerts that began since
+// the last tick, while the terminal is unfocused. Focused, the list shows
+// them, but they are still collected so that they are not news on the next
+// blur.
+func (a *App) notifyAlerts(now time.Time) {
+	if a.notifications == nil {
+		return
+	}
+	alerts := a.resourceView.Alerts(now)
+	if !a.blurred || len(alerts) == 0 {
+		return
+	}
+	if err := a.notifications.Send(alerts, now); err != nil {
+		a.resourceView.ShowError(fmt.Errorf("desktop notification: %w", err))
+	}
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/notify"
+)
+
+// notificationRecorder is a notifier remembering what it was asked to show
+type notificationRecorder struct {
+	bodies []string
+}
+
+func (r *notificationRecorder) Notify(title, body string) error {
+	r.bodies = append(r.bodies, title+" | "+body)
+	return nil
+}
+
+func TestAlertsNotifyOnlyWhileUnfocused(t *testing.T) {
+	app := createTestApp(t)
+	app.state.Deletions = core.NewDeletionTracker()
+	recorder := &notificationRecorder{}
+	app.SetNotifications(notify.NewDispatcher(recorder, notify.Options{MinInterval: time.Minute}))
+
+	// Ticks only do work every blurredTickFactor-th time while unfocused
+	tick := func() {
+		for i := 0; i < blurredTickFactor; i++ {
+			app.Update(tickMsg(time.Now()))
+		}
+	}
+	deleted := func(name string) {
+		app.state.Deletions.Requested(core.ResourceTypePod, core.ResourceRef{Namespace: "default", Name: name}, time.Now())
+		app.state.Deletions.ObserveList(core.ResourceTypePod, "", "default", nil, time.Now())
+	}
+	tick()
+
+	// Focused, the list shows what happens; nothing is sent
+	deleted("web-1")
+	tick()
+	if len(recorder.bodies) != 0 {
+		t.Fatalf("Expected no notification while focused, got %v", recorder.bodies)
+	}
+
+	// Unfocused, a delete finishing is sent, with where it happened, once
+	app.Update(tea.BlurMsg{})
+	deleted("web-2")
+	tick()
+	tick()
+	expected := "kubewatch: Delete | test-context/default: pod web-2 deleted"
+	if len(recorder.bodies) != 1 || recorder.bodies[0] != expected {
+		t.Fatalf("Expected %q, got %v", expected, recorder.bodies)
+	}
+}
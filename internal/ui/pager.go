@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultPager is run when $PAGER is not set
+const defaultPager = "less -R"
+
+// fallbackPager is run when the pager chosen is not installed
+const fallbackPager = "more"
+
+// pagerCommand is a pager to hand content to, and whether it shows ANSI
+// colors rather than their escape codes
+type pagerCommand struct {
+	path  string
+	args  []string
+	color bool
+}
+
+// resolvePager picks the pager to run from $PAGER, given as pagerEnv, or
+// less -R when it is not set. A pager that is not installed falls back to
+// more, and notice says so. lessEnv is $LESS, which can turn on less's -R.
+// lookPath finds a program as exec.LookPath does.
+func resolvePager(pagerEnv, lessEnv string, lookPath func(string) (string, error)) (cmd pagerCommand, notice string, err error) {
+	fields := strings.Fields(pagerEnv)
+	if len(fields) == 0 {
+		fields = strings.Fields(defaultPager)
+	}
+
+	path, err := lookPath(fields[0])
+	if err != nil {
+		fallback, fallbackErr := lookPath(fallbackPager)
+		if fallbackErr != nil {
+			return pagerCommand{}, "", fmt.Errorf("pager %s not found; set $PAGER to one that is installed", fields[0])
+		}
+		notice = fmt.Sprintf("Pager %s not found; used %s", fields[0], fallbackPager)
+		return pagerCommand{path: fallback}, notice, nil
+	}
+
+	return pagerCommand{
+		path:  path,
+		args:  fields[1:],
+		color: pagerShowsColor(fields[0], fields[1:], lessEnv),
+	}, "", nil
+}
+
+// pagerShowsColor reports whether a pager passes ANSI colors through to the
+// terminal. Only less is known to, and only with -R or -r, given as an
+// argument or in $LESS; other pagers get plain text.
+func pagerShowsColor(name string, args []string, lessEnv string) bool {
+	if filepath.Base(name) != "less" {
+		return false
+	}
+	options := append([]string(nil), args...)
+	for _, option := range strings.Fields(lessEnv) {
+		// $LESS may leave out the leading dash
+		if !strings.HasPrefix(option, "-") {
+			option = "-" + option
+		}
+		options = append(options, option)
+	}
+
+	for _, option := range options {
+		switch {
+		case strings.EqualFold(option, "--raw-control-chars"):
+			return true
+		case strings.HasPrefix(option, "--"):
+			continue
+		case strings.HasPrefix(option, "-") && strings.ContainsAny(option, "Rr"):
+			// Single-letter options can be combined, as in -FRX
+			return true
+		}
+	}
+	return false
+}
+
+// pagerDoneMsg is sent when the pager exits and kubewatch has the terminal
+// back
+type pagerDoneMsg struct {
+	notice string // Said when the pager exited cleanly, if anything
+	err    error
+}
+
+// openInPager suspends kubewatch and shows the describe output in the
+// user's pager, returning to the describe view as it was when the pager
+// exits. Colors are kept only for a pager that shows them, and never when
+// colors are off.
+func (a *App) openInPager() tea.Cmd {
+	if a.describeView == nil {
+		return nil
+	}
+	pager, notice, err := resolvePager(os.Getenv("PAGER"), os.Getenv("LESS"), exec.LookPath)
+	if err != nil {
+		a.describeView.SetStatus("✗ " + err.Error())
+		return nil
+	}
+
+	color := pager.color && !a.config.NoColor && !a.config.Accessible
+	cmd := exec.Command(pager.path, pager.args...)
+	cmd.Stdin = strings.NewReader(a.describeView.PagerContent(color))
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return pagerDoneMsg{notice: notice, err: err}
+	})
+}
+
+// pagerDone reports how the pager exited in the describe view
+func (a *App) pagerDone(msg pagerDoneMsg) {
+	// The terminal was just handed back, so it has focus
+	a.setBlurred(false)
+	if a.describeView == nil {
+		return
+	}
+	switch {
+	case msg.err != nil:
+		a.describeView.SetStatus("✗ Pager failed: " + msg.err.Error())
+	case msg.notice != "":
+		a.describeView.SetStatus(msg.notice)
+	}
+}
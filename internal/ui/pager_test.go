@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestResolvePager(t *testing.T) {
+	// installed stands in for exec.LookPath over a PATH holding these programs
+	lookPath := func(installed ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			if slices.Contains(installed, name) || strings.HasPrefix(name, "/") {
+				return "/bin/" + strings.TrimPrefix(name, "/bin/"), nil
+			}
+			return "", errors.New("not found")
+		}
+	}
+
+	tests := []struct {
+		name         string
+		pager        string
+		less         string
+		installed    []string
+		expectPath   string
+		expectArgs   []string
+		expectColor  bool
+		expectNotice string
+		expectErr    string
+	}{
+		{name: "unset runs less -R", installed: []string{"less", "more"}, expectPath: "/bin/less", expectArgs: []string{"-R"}, expectColor: true},
+		{name: "less with combined options", pager: "less -FRX", installed: []string{"less"}, expectPath: "/bin/less", expectArgs: []string{"-FRX"}, expectColor: true},
+		{name: "less with the long option", pager: "/bin/less --RAW-CONTROL-CHARS", expectPath: "/bin/less", expectArgs: []string{"--RAW-CONTROL-CHARS"}, expectColor: true},
+		{name: "less without -R", pager: "less -FX", installed: []string{"less"}, expectPath: "/bin/less", expectArgs: []string{"-FX"}},
+		{name: "less with -R in $LESS", pager: "less", less: "FRX", installed: []string{"less"}, expectPath: "/bin/less", expectColor: true},
+		{name: "a long option with r is not -r", pager: "less --clear-screen", installed: []string{"less"}, expectPath: "/bin/less", expectArgs: []string{"--clear-screen"}},
+		{name: "other pagers get plain text", pager: "most -r", installed: []string{"most"}, expectPath: "/bin/most", expectArgs: []string{"-r"}},
+		{name: "missing pager falls back to more", pager: "bat", installed: []string{"more"}, expectPath: "/bin/more", expectNotice: "Pager bat not found; used more"},
+		{name: "missing default falls back to more", installed: []string{"more"}, expectPath: "/bin/more", expectNotice: "Pager less not found"},
+		{name: "no pager at all", pager: "bat", expectErr: "pager bat not found; set $PAGER"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, notice, err := resolvePager(tt.pager, tt.less, lookPath(tt.installed...))
+			if tt.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Fatalf("Expected error %q, got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if cmd.path != tt.expectPath || !slices.Equal(cmd.args, tt.expectArgs) || cmd.color != tt.expectColor {
+				t.Errorf("Expected %s %q color=%v, got %s %q color=%v", tt.expectPath, tt.expectArgs, tt.expectColor, cmd.path, cmd.args, cmd.color)
+			}
+			if !strings.Contains(notice, tt.expectNotice) || (tt.expectNotice == "" && notice != "") {
+				t.Errorf("Expected notice %q, got %q", tt.expectNotice, notice)
+			}
+		})
+	}
+}
+
+func TestOpenInPager(t *testing.T) {
+	app := createTestApp(t)
+	app.describeView = views.NewDescribeView(context.Background(), nil, "Pods", "web-1", "default", "")
+	app.describeView.SetSize(app.width, app.height)
+	app.setMode(ModeDescribe)
+
+	// With no pager installed the describe view says why nothing opened
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv("PAGER", "")
+	if _, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("|")}); cmd != nil {
+		t.Error("Expected no pager run")
+	}
+	if app.currentMode != ModeDescribe || !strings.Contains(app.View(), "pager less not found") {
+		t.Fatalf("Expected the fallback message in the describe view, got:\n%s", app.View())
+	}
+
+	// A pager that fails is reported on return
+	app.Update(pagerDoneMsg{err: errors.New("exit status 2")})
+	if app.currentMode != ModeDescribe || !strings.Contains(app.View(), "Pager failed: exit status 2") {
+		t.Errorf("Expected the failure in the describe view, got:\n%s", app.View())
+	}
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+)
+
+// TestDeletingRBACRequiresTypedName tests that deleting an RBAC object or a
+// node asks for its name to be typed, while other kinds are only confirmed
+func TestDeletingRBACRequiresTypedName(t *testing.T) {
+	tests := []struct {
+		resourceType core.ResourceType
+		typed        bool
+	}{
+		{core.ResourceTypePod, false},
+		{core.ResourceTypeServiceAccount, true},
+		{core.ResourceTypeRoleBinding, true},
+		{core.ResourceTypeClusterRole, true},
+		{core.ResourceTypeNode, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.resourceType), func(t *testing.T) {
+			app := createTestApp(t)
+			app.state.CurrentResourceType = tt.resourceType
+			app.resourceView.SetTestData([]string{"NAME", "AGE"}, [][]string{{"deployer", "5m"}})
+			app.resourceView.SetSelectedRow(0)
+
+			app.showDeleteConfirmation()
+			if app.confirmView.RequiresInput() != tt.typed {
+				t.Errorf("Expected typed confirmation %v, got %v", tt.typed, app.confirmView.RequiresInput())
+			}
+		})
+	}
+}
+
+// TestJumpToClusterScopedKeepsNamespace tests that following a relation to
+// a cluster-scoped object keeps the namespace shown, as it has none
+func TestJumpToClusterScopedKeepsNamespace(t *testing.T) {
+	app := createTestApp(t)
+	app.state.CurrentResourceType = core.ResourceTypeServiceAccount
+
+	app.jumpToRelated(core.RelatedResource{Relation: core.RelationBoundBy, Kind: "ClusterRoleBinding", Type: core.ResourceTypeClusterRoleBinding, Name: "deployer-view"})
+	if app.state.CurrentResourceType != core.ResourceTypeClusterRoleBinding || app.state.CurrentNamespace != "default" {
+		t.Errorf("Expected cluster role bindings listed from default, got %s in %q", app.state.CurrentResourceType, app.state.CurrentNamespace)
+	}
+}
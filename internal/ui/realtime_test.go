@@ -6,10 +6,9 @@ import (
 	"time"
 
 	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/testutil/fixtures"
 	tea "github.com/charmbracelet/bubbletea"
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 // TestAutoRefreshBehavior tests that the app automatically refreshes resources
@@ -301,32 +300,10 @@ func TestConcurrentUpdateHandling(t *testing.T) {
 
 // Helper to create mock pod with more details
 func createDetailedMockPod(name, phase, namespace string, ready bool) *v1.Pod {
-	pod := &v1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:              name,
-			Namespace:         namespace,
-			UID:               types.UID("uid-" + name),
-			CreationTimestamp: metav1.Time{Time: time.Now()},
-		},
-		Status: v1.PodStatus{
-			Phase: v1.PodPhase(phase),
-			ContainerStatuses: []v1.ContainerStatus{
-				{
-					Name:  "main",
-					Ready: ready,
-					State: v1.ContainerState{
-						Running: &v1.ContainerStateRunning{
-							StartedAt: metav1.Time{Time: time.Now()},
-						},
-					},
-				},
-			},
-		},
-		Spec: v1.PodSpec{
-			Containers: []v1.Container{
-				{Name: "main", Image: "nginx:latest"},
-			},
-		},
-	}
-	return pod
+	builder := fixtures.NewPod(name, namespace).WithUID("uid-" + name).WithPhase(v1.PodPhase(phase))
+	if !ready {
+		builder.NotReady()
+	}
+	pod := builder.Build()
+	return &pod
 }
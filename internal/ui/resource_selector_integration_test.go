@@ -2,6 +2,7 @@ package ui
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/HamStudy/kubewatch/internal/components/dropdown"
@@ -181,3 +182,51 @@ func TestShiftTabKeyOpensResourceSelector(t *testing.T) {
 		t.Error("Expected no command to be returned when opening resource selector")
 	}
 }
+
+// TestOptionalResourceTypesHiddenUntilInstalled tests that the Gateway API's
+// types are offered only once the cluster is found to serve them
+func TestOptionalResourceTypesHiddenUntilInstalled(t *testing.T) {
+	config := &core.Config{RefreshInterval: 30}
+	state := core.NewState(config)
+	app := NewApp(context.Background(), nil, state, config)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+
+	cycle := func() []core.ResourceType {
+		var seen []core.ResourceType
+		for {
+			app.nextResourceType()
+			seen = append(seen, state.CurrentResourceType)
+			if state.CurrentResourceType == core.ResourceTypePod {
+				return seen
+			}
+		}
+	}
+	for _, rt := range cycle() {
+		if core.IsOptionalResourceType(rt) {
+			t.Errorf("Expected %s hidden before detection", rt)
+		}
+	}
+
+	app.Update(optionalTypesDetectedMsg{installed: map[core.ResourceType]bool{core.ResourceTypeGateway: true}})
+	seen := cycle()
+	hasGateways := false
+	for _, rt := range seen {
+		hasGateways = hasGateways || rt == core.ResourceTypeGateway
+		if rt == core.ResourceTypeHTTPRoute {
+			t.Error("Expected HTTPRoutes hidden when not installed")
+		}
+	}
+	if !hasGateways {
+		t.Errorf("Expected Gateways offered once installed, got %v", seen)
+	}
+
+	// A list of a type the cluster lacks falls back to pods
+	state.SetResourceType(core.ResourceTypeGateway)
+	app.Update(optionalTypesDetectedMsg{installed: map[core.ResourceType]bool{}})
+	if state.CurrentResourceType != core.ResourceTypePod {
+		t.Errorf("Expected a fall back to Pods, got %s", state.CurrentResourceType)
+	}
+	if !strings.Contains(app.View(), "Gateways are not installed in this cluster") {
+		t.Error("Expected a notice saying why Pods are shown")
+	}
+}
@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+)
+
+// rolloutChange is a pause, resume or restart of a deployment's rollout,
+// or a restart of a statefulset's, awaiting confirmation
+type rolloutChange struct {
+	action       core.RolloutAction
+	resourceType core.ResourceType
+	context      string
+	namespace    string
+	name         string
+	client       *k8s.Client
+}
+
+// rolloutChangedMsg reports the outcome of a rollout change
+type rolloutChangedMsg struct {
+	change *rolloutChange
+	err    error
+}
+
+// selectedDeployment returns the selected deployment as last listed, and
+// the context it was listed in
+func (a *App) selectedDeployment() (*appsv1.Deployment, string, bool) {
+	if a.state.CurrentResourceType != core.ResourceTypeDeployment {
+		return nil, "", false
+	}
+	ref := a.resourceView.SelectedResourceRef()
+	if ref.IsZero() {
+		return nil, "", false
+	}
+	deployment, ok := a.state.FindDeployment(ref.Context, ref.Namespace, ref.Name)
+	return deployment, ref.Context, ok
+}
+
+// confirmRollout asks for confirmation before changing the selected
+// deployment's rollout. A restart of a paused one is refused, as it would
+// roll nothing until the rollout is resumed.
+func (a *App) confirmRollout(action core.RolloutAction) {
+	a.returnToList()
+	deployment, context, ok := a.selectedDeployment()
+	if !ok {
+		a.resourceView.ShowNotice("Select a deployment to change its rollout")
+		return
+	}
+	if action == core.RolloutRestart && deployment.Spec.Paused {
+		a.resourceView.ShowError(fmt.Errorf("cannot restart deployment %s: %w", deployment.Name, k8s.ErrRolloutPaused))
+		return
+	}
+	client := a.clientForContext(context)
+	if client == nil {
+		return
+	}
+
+	a.pendingRollout = &rolloutChange{
+		action:       action,
+		resourceType: core.ResourceTypeDeployment,
+		context:      context,
+		namespace:    deployment.Namespace,
+		name:         deployment.Name,
+		client:       client,
+	}
+
+	var title, confirm, message string
+	switch action {
+	case core.RolloutPause:
+		title, confirm = "⚠️  Pause Rollout", "Pause"
+		message = fmt.Sprintf("Pause the rollout of deployment '%s'?\n\n"+
+			"Changes to its pods are not rolled out until it is resumed. Pods already running keep running.", deployment.Name)
+	case core.RolloutResume:
+		title, confirm = "Resume Rollout", "Resume"
+		message = fmt.Sprintf("Resume the rollout of deployment '%s'?\n\n"+
+			"Changes made while it was paused are rolled out.", deployment.Name)
+	case core.RolloutRestart:
+		title, confirm = "⚠️  Restart Rollout", "Restart"
+		message = fmt.Sprintf("Restart the rollout of deployment '%s'?\n\n"+
+			"Every pod is replaced, as its strategy allows.", deployment.Name)
+	}
+	a.confirmView = views.NewConfirmView(title, message)
+	a.confirmView.SetSize(a.width, a.viewHeight())
+	a.confirmView.SetConfirmText(confirm)
+	a.confirmView.SetCancelText("Cancel")
+	a.setMode(ModeConfirmDialog)
+}
+
+// confirmRestart asks for confirmation before restarting the selected
+// deployment's or statefulset's rollout, as kubectl rollout restart
+func (a *App) confirmRestart() {
+	if a.state.CurrentResourceType != core.ResourceTypeStatefulSet {
+		if a.state.CurrentResourceType != core.ResourceTypeDeployment {
+			a.resourceView.ShowNotice("Select a deployment or statefulset to restart it")
+			return
+		}
+		a.confirmRollout(core.RolloutRestart)
+		return
+	}
+
+	a.returnToList()
+	ref := a.resourceView.SelectedResourceRef()
+	if ref.IsZero() {
+		a.resourceView.ShowNotice("Select a statefulset to restart it")
+		return
+	}
+	statefulset, ok := a.state.FindStatefulSet(ref.Context, ref.Namespace, ref.Name)
+	if !ok {
+		return
+	}
+	client := a.clientForContext(ref.Context)
+	if client == nil {
+		return
+	}
+
+	a.pendingRollout = &rolloutChange{
+		action:       core.RolloutRestart,
+		resourceType: core.ResourceTypeStatefulSet,
+		context:      ref.Context,
+		namespace:    statefulset.Namespace,
+		name:         statefulset.Name,
+		client:       client,
+	}
+	a.confirmView = views.NewConfirmView("⚠️  Restart Rollout", fmt.Sprintf(
+		"Restart the rollout of statefulset '%s'?\n\n"+
+			"Every pod is replaced one at a time, in its update strategy's order.", statefulset.Name))
+	a.confirmView.SetSize(a.width, a.viewHeight())
+	a.confirmView.SetConfirmText("Restart")
+	a.confirmView.SetCancelText("Cancel")
+	a.setMode(ModeConfirmDialog)
+}
+
+// changeRollout applies a confirmed rollout change
+func (a *App) changeRollout(change *rolloutChange) tea.Cmd {
+	ctx := a.ctx
+	return func() tea.Msg {
+		var err error
+		switch change.action {
+		case core.RolloutPause:
+			err = change.client.PauseRollout(ctx, change.namespace, change.name)
+		case core.RolloutResume:
+			err = change.client.ResumeRollout(ctx, change.namespace, change.name)
+		case core.RolloutRestart:
+			if change.resourceType == core.ResourceTypeStatefulSet {
+				err = change.client.RestartStatefulSet(ctx, change.namespace, change.name)
+			} else {
+				err = change.client.RestartDeployment(ctx, change.namespace, change.name)
+			}
+		}
+		return rolloutChangedMsg{change: change, err: err}
+	}
+}
+
+// rolloutChanged records a rollout change and shows its outcome
+func (a *App) rolloutChanged(msg rolloutChangedMsg) tea.Cmd {
+	a.actionLog.Record(core.ActionLogEntry{
+		Action:    string(msg.change.action) + " rollout",
+		Context:   msg.change.context,
+		Namespace: msg.change.namespace,
+		Resource:  string(msg.change.resourceType),
+		Name:      msg.change.name,
+		Err:       msg.err,
+	})
+	if msg.err != nil {
+		a.resourceView.ShowError(msg.err)
+		return nil
+	}
+	switch msg.change.action {
+	case core.RolloutPause:
+		a.resourceView.ShowNotice("Paused the rollout of " + msg.change.name)
+	case core.RolloutResume:
+		a.resourceView.ShowNotice("Resumed the rollout of " + msg.change.name)
+	case core.RolloutRestart:
+		a.resourceView.ShowNotice("Restarted the rollout of " + msg.change.name)
+	}
+	return a.resourceView.RefreshResources()
+}
@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/testutil/fixtures"
+)
+
+// rolloutTestApp is a test app listing the deployment web, paused or not,
+// and the statefulset web, with a client of a server recording the patches sent to it
+func rolloutTestApp(t *testing.T, paused bool) (*App, *[]string) {
+	t.Helper()
+	var patches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			body, _ := io.ReadAll(r.Body)
+			patches = append(patches, string(body))
+		}
+		kind := "Deployment"
+		if strings.Contains(r.URL.Path, "/statefulsets/") {
+			kind = "StatefulSet"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"` + kind + `","apiVersion":"apps/v1","metadata":{"name":"web","namespace":"default"}}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+
+	app := createTestApp(t)
+	app.width, app.height = 140, 40
+	app.k8sClient = client
+	app.state.CurrentResourceType = core.ResourceTypeDeployment
+	deployment := fixtures.NewDeployment("web", "default")
+	if paused {
+		deployment.Paused()
+	}
+	app.state.UpdateDeployments([]appsv1.Deployment{deployment.Build()})
+	app.state.UpdateStatefulSets([]appsv1.StatefulSet{fixtures.NewStatefulSet("web", "default").Build()})
+	app.resourceView.SetTestData([]string{"NAME", "READY"}, [][]string{{"web", "3/3"}})
+	app.resourceView.SetSelectedRow(0)
+	return app, &patches
+}
+
+// pickRolloutAction opens the quick actions and runs the rollout action
+// described
+func pickRolloutAction(t *testing.T, app *App, description string) {
+	t.Helper()
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	for i := 0; i < 5; i++ {
+		if action, ok := app.actionMenuView.SelectedRollout(); ok && action.Description() == description {
+			_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			app.Update(cmd())
+			return
+		}
+		app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	t.Fatalf("Expected %q in the quick actions, got:\n%s", description, app.actionMenuView.View())
+}
+
+func TestPauseRolloutAfterConfirmation(t *testing.T) {
+	app, patches := rolloutTestApp(t, false)
+
+	app.openActionMenu()
+	view := app.actionMenuView.View()
+	if !strings.Contains(view, "Pause rollout") || strings.Contains(view, "Resume rollout") {
+		t.Errorf("Expected pause offered on a running rollout, got:\n%s", view)
+	}
+	app.setMode(ModeList)
+
+	pickRolloutAction(t, app, "Pause rollout")
+	if app.currentMode != ModeConfirmDialog || app.pendingRollout == nil {
+		t.Fatalf("Expected the pause confirmed first, got mode %v", app.currentMode)
+	}
+
+	// Cancelling sends nothing
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList || app.pendingRollout != nil || len(*patches) != 0 {
+		t.Fatalf("Expected the pause cancelled, got mode %v and %v", app.currentMode, *patches)
+	}
+
+	pickRolloutAction(t, app, "Pause rollout")
+	app.Update(tea.KeyMsg{Type: tea.KeyRight})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected the pause sent")
+	}
+	app.Update(cmd())
+	if len(*patches) != 1 || (*patches)[0] != `{"spec":{"paused":true}}` {
+		t.Errorf("Expected spec.paused patched, got %v", *patches)
+	}
+	if view := app.resourceView.View(); !strings.Contains(view, "Paused the rollout of web") {
+		t.Errorf("Expected the pause noted, got:\n%s", view)
+	}
+}
+
+func TestPausedRolloutRefusesRestart(t *testing.T) {
+	app, patches := rolloutTestApp(t, true)
+
+	app.openActionMenu()
+	if view := app.actionMenuView.View(); !strings.Contains(view, "Resume rollout") || strings.Contains(view, "Pause rollout") {
+		t.Errorf("Expected resume offered on a paused rollout, got:\n%s", view)
+	}
+	app.setMode(ModeList)
+
+	pickRolloutAction(t, app, "Restart rollout")
+	if app.currentMode != ModeList || app.pendingRollout != nil || len(*patches) != 0 {
+		t.Fatalf("Expected the restart refused, got mode %v", app.currentMode)
+	}
+	if view := app.resourceView.View(); !strings.Contains(view, "rollout is paused") {
+		t.Errorf("Expected the refusal explained, got:\n%s", view)
+	}
+
+	pickRolloutAction(t, app, "Resume rollout")
+	if app.currentMode != ModeConfirmDialog || app.pendingRollout == nil || app.pendingRollout.action != core.RolloutResume {
+		t.Errorf("Expected the resume confirmed first, got mode %v", app.currentMode)
+	}
+}
+
+func TestRolloutActionsOnlyForDeployments(t *testing.T) {
+	app := createTestApp(t)
+	app.resourceView.SetTestData([]string{"NAME", "STATUS"}, [][]string{{"web-1", "Running"}})
+	app.resourceView.SetSelectedRow(0)
+
+	app.openActionMenu()
+	if view := app.actionMenuView.View(); strings.Contains(view, "rollout") {
+		t.Errorf("Expected no rollout actions for pods, got:\n%s", view)
+	}
+}
+
+func TestRestartKey(t *testing.T) {
+	app, patches := rolloutTestApp(t, false)
+	restart := func() {
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+		if app.currentMode != ModeConfirmDialog || app.pendingRollout == nil {
+			t.Fatalf("Expected the restart confirmed first, got mode %v", app.currentMode)
+		}
+		app.Update(tea.KeyMsg{Type: tea.KeyRight})
+		_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if cmd == nil {
+			t.Fatal("Expected the restart sent")
+		}
+		app.Update(cmd())
+	}
+
+	restart()
+	if len(*patches) != 1 || !strings.Contains((*patches)[0], "kubectl.kubernetes.io/restartedAt") {
+		t.Errorf("Expected the deployment's pod template stamped, got %v", *patches)
+	}
+
+	app.state.CurrentResourceType = core.ResourceTypeStatefulSet
+	restart()
+	if len(*patches) != 2 || !strings.Contains((*patches)[1], "kubectl.kubernetes.io/restartedAt") {
+		t.Errorf("Expected the statefulset's pod template stamped, got %v", *patches)
+	}
+	if view := app.resourceView.View(); !strings.Contains(view, "Restarted the rollout of web") {
+		t.Errorf("Expected the restart noted, got:\n%s", view)
+	}
+
+	// Other types have no rollout to restart
+	app.state.CurrentResourceType = core.ResourceTypePod
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if app.currentMode != ModeList || len(*patches) != 2 {
+		t.Errorf("Expected no restart for pods, got mode %v and %v", app.currentMode, *patches)
+	}
+}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+)
+
+// scaleChange is a deployment or statefulset whose replicas are being
+// asked for, in the context its row belongs to
+type scaleChange struct {
+	resourceType core.ResourceType
+	context      string
+	namespace    string
+	name         string
+	client       *k8s.Client
+}
+
+// scaledMsg reports the outcome of scaling
+type scaledMsg struct {
+	change   *scaleChange
+	replicas int32
+	err      error
+}
+
+// startScale asks how many replicas to scale the selected deployment or
+// statefulset to
+func (a *App) startScale() {
+	kind := a.state.CurrentResourceType
+	if kind != core.ResourceTypeDeployment && kind != core.ResourceTypeStatefulSet {
+		a.resourceView.ShowNotice("Select a deployment or statefulset to scale it")
+		return
+	}
+	ref := a.resourceView.SelectedResourceRef()
+	if ref.IsZero() {
+		return
+	}
+
+	// Replicas left unset default to one
+	current := int32(1)
+	var replicas *int32
+	if kind == core.ResourceTypeDeployment {
+		deployment, ok := a.state.FindDeployment(ref.Context, ref.Namespace, ref.Name)
+		if !ok {
+			return
+		}
+		replicas = deployment.Spec.Replicas
+	} else {
+		statefulset, ok := a.state.FindStatefulSet(ref.Context, ref.Namespace, ref.Name)
+		if !ok {
+			return
+		}
+		replicas = statefulset.Spec.Replicas
+	}
+	if replicas != nil {
+		current = *replicas
+	}
+
+	client := a.clientForContext(ref.Context)
+	if client == nil {
+		return
+	}
+	a.pendingScale = &scaleChange{
+		resourceType: kind,
+		context:      ref.Context,
+		namespace:    ref.Namespace,
+		name:         ref.Name,
+		client:       client,
+	}
+	a.scaleView = views.NewScaleView(strings.ToLower(strings.TrimSuffix(string(kind), "s")), ref.Name, current)
+	a.scaleView.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeScale)
+}
+
+// closeScale closes the prompt without scaling
+func (a *App) closeScale() {
+	a.scaleView = nil
+	a.pendingScale = nil
+	a.returnToList()
+}
+
+// scale sets the replicas entered in the prompt
+func (a *App) scale(replicas int32) tea.Cmd {
+	change := a.pendingScale
+	a.closeScale()
+	if change == nil {
+		return nil
+	}
+	ctx := a.ctx
+	return func() tea.Msg {
+		var err error
+		if change.resourceType == core.ResourceTypeStatefulSet {
+			err = change.client.ScaleStatefulSet(ctx, change.namespace, change.name, replicas)
+		} else {
+			err = change.client.ScaleDeployment(ctx, change.namespace, change.name, replicas)
+		}
+		return scaledMsg{change: change, replicas: replicas, err: err}
+	}
+}
+
+// scaled records scaling and shows its outcome; READY follows on the
+// refresh
+func (a *App) scaled(msg scaledMsg) tea.Cmd {
+	a.actionLog.Record(core.ActionLogEntry{
+		Action:    fmt.Sprintf("scale to %d", msg.replicas),
+		Context:   msg.change.context,
+		Namespace: msg.change.namespace,
+		Resource:  string(msg.change.resourceType),
+		Name:      msg.change.name,
+		Err:       msg.err,
+	})
+	if msg.err != nil {
+		a.resourceView.ShowError(msg.err)
+		return nil
+	}
+	a.resourceView.ShowNotice(fmt.Sprintf("Scaled %s to %d replicas", msg.change.name, msg.replicas))
+	return a.resourceView.RefreshResources()
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/testutil/fixtures"
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+)
+
+// scaleServer records the scale patches sent to it as "path body"
+func scaleServer(t *testing.T) (*k8s.Client, *[]string) {
+	t.Helper()
+	var patches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		patches = append(patches, r.URL.Path+" "+string(body))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"Scale","apiVersion":"autoscaling/v1","metadata":{"name":"web","namespace":"default"}}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	return client, &patches
+}
+
+// typeKeys sends each rune of keys to the app
+func typeKeys(app *App, keys string) {
+	for _, r := range keys {
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestScaleDeployment(t *testing.T) {
+	client, patches := scaleServer(t)
+	app := createTestApp(t)
+	app.width, app.height = 140, 40
+	app.k8sClient = client
+	app.state.CurrentResourceType = core.ResourceTypeDeployment
+	app.state.UpdateDeployments([]appsv1.Deployment{fixtures.NewDeployment("web", "default").WithReplicas(2).Build()})
+	app.resourceView.SetTestData([]string{"NAME", "READY"}, [][]string{{"web", "2/2"}})
+	app.resourceView.SetSelectedRow(0)
+
+	typeKeys(app, "S")
+	if app.currentMode != ModeScale || !strings.Contains(app.View(), "Current replicas: 2") {
+		t.Fatalf("Expected the scale prompt from 2 replicas, got mode %v:\n%s", app.currentMode, app.View())
+	}
+
+	// Cancelling sends nothing
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList || app.scaleView != nil || len(*patches) != 0 {
+		t.Fatalf("Expected the scale cancelled, got mode %v and %v", app.currentMode, *patches)
+	}
+
+	// A number is required
+	typeKeys(app, "Sx")
+	if _, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || app.currentMode != ModeScale {
+		t.Fatalf("Expected 2x refused, got mode %v", app.currentMode)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	typeKeys(app, "S++-+")
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected the replicas entered")
+	}
+	_, cmd = app.Update(cmd())
+	if cmd == nil {
+		t.Fatal("Expected the scale sent")
+	}
+	app.Update(cmd())
+	want := `/apis/apps/v1/namespaces/default/deployments/web/scale {"spec":{"replicas":4}}`
+	if len(*patches) != 1 || (*patches)[0] != want {
+		t.Errorf("Expected %s, got %v", want, *patches)
+	}
+	if app.currentMode != ModeList || !strings.Contains(app.resourceView.View(), "Scaled web to 4 replicas") {
+		t.Errorf("Expected the scale noted, got mode %v:\n%s", app.currentMode, app.resourceView.View())
+	}
+}
+
+func TestScaleInRowContext(t *testing.T) {
+	client, patches := scaleServer(t)
+	app := createTestApp(t)
+	app.width, app.height = 140, 40
+	// Only prod's client can reach the server; the compared contexts'
+	// clients stand in for the active contexts'
+	app.comparisonView = views.NewComparisonView(app.state, [2]string{"staging", "prod"}, [2]*k8s.Client{nil, client})
+	app.state.CurrentResourceType = core.ResourceTypeStatefulSet
+	app.state.MultiContextMode = true
+	app.state.StatefulSetsByContext = map[string][]appsv1.StatefulSet{"prod": {{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+	}}}
+	app.resourceView.SetTestData([]string{"NAME", "CONTEXT", "READY"}, [][]string{{"web", "prod", "1/1"}})
+	app.resourceView.SetSelectedRow(0)
+
+	typeKeys(app, "S")
+	if app.pendingScale == nil || app.pendingScale.context != "prod" || app.pendingScale.client != client {
+		t.Fatalf("Expected the scale in prod, got %+v", app.pendingScale)
+	}
+	typeKeys(app, "-")
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd = app.Update(cmd())
+	app.Update(cmd())
+	if len(*patches) != 1 || !strings.HasPrefix((*patches)[0], "/apis/apps/v1/namespaces/default/statefulsets/web/scale") {
+		t.Errorf("Expected the statefulset scaled in prod, got %v", *patches)
+	}
+}
+
+func TestScaleOnlyDeploymentsAndStatefulSets(t *testing.T) {
+	app := createTestApp(t)
+	app.resourceView.SetTestData([]string{"NAME", "STATUS"}, [][]string{{"web-1", "Running"}})
+	app.resourceView.SetSelectedRow(0)
+
+	typeKeys(app, "S")
+	if app.currentMode != ModeList || app.scaleView != nil {
+		t.Errorf("Expected no scale prompt for pods, got mode %v", app.currentMode)
+	}
+}
@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/cancelreader"
+	"golang.org/x/term"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// shellDoneMsg is sent when a shell in a pod exits and kubewatch has the
+// terminal back
+type shellDoneMsg struct {
+	pod string
+	err error
+}
+
+// podShell runs a shell in a pod's container on the terminal kubewatch hands
+// over while tea.Exec suspends it
+type podShell struct {
+	ctx       context.Context
+	client    *k8s.Client
+	namespace string
+	pod       string
+	container string
+
+	stdin  io.Reader
+	stdout io.Writer
+}
+
+func (s *podShell) SetStdin(r io.Reader)  { s.stdin = r }
+func (s *podShell) SetStdout(w io.Writer) { s.stdout = w }
+func (s *podShell) SetStderr(io.Writer)   {} // A terminal has one output
+
+// Run puts the terminal in raw mode for the container's terminal and runs
+// the shell until it exits
+func (s *podShell) Run() error {
+	if in, ok := s.stdin.(*os.File); ok && term.IsTerminal(int(in.Fd())) {
+		state, err := term.MakeRaw(int(in.Fd()))
+		if err != nil {
+			return err
+		}
+		defer term.Restore(int(in.Fd()), state)
+	}
+
+	// Reading stdin must stop when the shell exits, or the read still
+	// waiting would swallow the next key meant for kubewatch
+	stdin, err := cancelreader.NewReader(s.stdin)
+	if err != nil {
+		return err
+	}
+	defer stdin.Close()
+	defer stdin.Cancel()
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+	return s.client.ExecShell(ctx, s.namespace, s.pod, s.container, stdin, s.stdout, newTerminalSizes(ctx, s.stdout))
+}
+
+// terminalSizes tells the container the size of the terminal, polling it
+// since resize signals are not portable
+type terminalSizes struct {
+	ctx  context.Context
+	fd   int
+	last remotecommand.TerminalSize
+}
+
+func newTerminalSizes(ctx context.Context, out io.Writer) remotecommand.TerminalSizeQueue {
+	f, ok := out.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return nil
+	}
+	return &terminalSizes{ctx: ctx, fd: int(f.Fd())}
+}
+
+// Next returns the terminal's size once it differs from the last one told,
+// or nil once the shell has exited
+func (t *terminalSizes) Next() *remotecommand.TerminalSize {
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		width, height, err := term.GetSize(t.fd)
+		if err == nil {
+			size := remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}
+			if size != t.last {
+				t.last = size
+				return &size
+			}
+		}
+		select {
+		case <-t.ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// startShell opens a shell in the selected pod, first asking which container
+// when it has more than one. Pods that are not running cannot take one.
+func (a *App) startShell() tea.Cmd {
+	pod, context, ok := a.selectedPod()
+	if !ok {
+		a.resourceView.ShowNotice("Select a pod to open a shell in")
+		return nil
+	}
+	if pod.Status.Phase != v1.PodRunning {
+		a.resourceView.ShowError(fmt.Errorf("pod %s is %s; a shell needs it Running", pod.Name, pod.Status.Phase))
+		return nil
+	}
+
+	containers := make([]string, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		containers = append(containers, container.Name)
+	}
+	if len(containers) == 1 {
+		return a.openShell(context, pod.Namespace, pod.Name, containers[0])
+	}
+
+	a.shellPicker = views.NewShellPickerView(context, pod.Namespace, pod.Name, containers)
+	a.shellPicker.SetSize(a.width, a.viewHeight())
+	a.setMode(ModeShellPicker)
+	return nil
+}
+
+// openShell suspends kubewatch and runs a shell in a pod's container,
+// coming back to the list as it was when the shell exits
+func (a *App) openShell(context, namespace, pod, container string) tea.Cmd {
+	a.shellPicker = nil
+	a.setMode(ModeList)
+	client := a.clientForContext(context)
+	if client == nil {
+		a.resourceView.ShowError(fmt.Errorf("no client for context %q", context))
+		return nil
+	}
+	shell := &podShell{ctx: a.ctx, client: client, namespace: namespace, pod: pod, container: container}
+	return tea.Exec(shell, func(err error) tea.Msg {
+		return shellDoneMsg{pod: pod, err: err}
+	})
+}
+
+// shellDone reports a shell that failed once kubewatch has the terminal back
+func (a *App) shellDone(msg shellDoneMsg) {
+	// The terminal was just handed back, so it has focus
+	a.setBlurred(false)
+	if msg.err != nil {
+		a.resourceView.ShowError(fmt.Errorf("shell in %s: %s", msg.pod, k8s.UserMessage(msg.err)))
+	}
+}
+
+// selectedPod returns the pod selected in the list and its context
+func (a *App) selectedPod() (*v1.Pod, string, bool) {
+	if a.state.CurrentResourceType != core.ResourceTypePod {
+		return nil, "", false
+	}
+	ref := a.resourceView.SelectedResourceRef()
+	if ref.IsZero() {
+		return nil, "", false
+	}
+	pod, ok := a.state.FindPod(ref.Context, ref.Namespace, ref.Name)
+	return pod, ref.Context, ok
+}
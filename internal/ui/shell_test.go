@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+)
+
+// shellTestApp is a test app listing the pod web in a phase with containers
+func shellTestApp(t *testing.T, phase v1.PodPhase, containers ...string) *App {
+	t.Helper()
+	app := createTestApp(t)
+	app.width, app.height = 120, 30
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Status:     v1.PodStatus{Phase: phase},
+	}
+	for _, name := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: name})
+	}
+	app.state.UpdatePods([]v1.Pod{pod})
+	app.resourceView.SetTestData([]string{"NAME", "STATUS"}, [][]string{{"web", string(phase)}})
+	app.resourceView.SetSelectedRow(0)
+	return app
+}
+
+func TestShellRefusedForPodNotRunning(t *testing.T) {
+	app := shellTestApp(t, v1.PodPending, "app")
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if cmd != nil || app.currentMode != ModeList {
+		t.Errorf("Expected no shell for a pending pod, got mode %v", app.currentMode)
+	}
+	if view := app.resourceView.View(); !strings.Contains(view, "pod web is Pending") {
+		t.Errorf("Expected the refusal in the header, got:\n%s", view)
+	}
+}
+
+func TestShellOnlyFromPods(t *testing.T) {
+	app := shellTestApp(t, v1.PodRunning, "app")
+	app.state.CurrentResourceType = core.ResourceTypeService
+
+	if _, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")}); cmd != nil {
+		t.Error("Expected no shell outside the pod list")
+	}
+}
+
+func TestShellPicksContainerFirst(t *testing.T) {
+	app := shellTestApp(t, v1.PodRunning, "app", "istio-proxy", "metrics")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if app.currentMode != ModeShellPicker || app.shellPicker == nil {
+		t.Fatalf("Expected the container picker for a pod with three containers, got mode %v", app.currentMode)
+	}
+	if view := app.View(); !strings.Contains(view, "Open a shell in web") || !strings.Contains(view, "istio-proxy") {
+		t.Errorf("Expected the pod's containers offered, got:\n%s", view)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("met")})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected Enter to pick the container")
+	}
+	picked, ok := cmd().(views.ShellContainerPickedMsg)
+	if !ok || picked.Container != "metrics" || picked.Namespace != "default" || picked.Pod != "web" {
+		t.Fatalf("Expected metrics picked in default/web, got %#v", picked)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.currentMode != ModeList || app.shellPicker != nil {
+		t.Errorf("Expected Esc back to the list, got mode %v", app.currentMode)
+	}
+}
+
+func TestShellFailureShownOnReturn(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/pods/web/exec") || r.URL.Query().Get("container") != "app" {
+			t.Errorf("Expected an exec in container app of web, got %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403,"message":"pods \"web\" is forbidden"}`))
+	}))
+	defer server.Close()
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+
+	app := shellTestApp(t, v1.PodRunning, "app")
+	app.k8sClient = client
+	if _, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")}); cmd == nil {
+		t.Fatal("Expected a shell for a running pod with one container")
+	}
+
+	shell := &podShell{ctx: context.Background(), client: client, namespace: "default", pod: "web", container: "app"}
+	shell.SetStdin(strings.NewReader(""))
+	shell.SetStdout(io.Discard)
+	err = shell.Run()
+	if err == nil {
+		t.Fatal("Expected the refused exec to fail")
+	}
+	app.Update(shellDoneMsg{pod: "web", err: err})
+	if view := app.resourceView.View(); !strings.Contains(view, "shell in web") {
+		t.Errorf("Expected the failure in the header, got:\n%s", view)
+	}
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/HamStudy/kubewatch/internal/config"
+	"github.com/HamStudy/kubewatch/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OpenOnStart opens the startup layout's log split or comparison once the
+// list first loads. The rest of the layout is applied before the app is
+// created, as the view flags are.
+func (a *App) OpenOnStart(layout *config.StartupLayout) {
+	a.startup = layout
+}
+
+// SetStartupLayoutSaver sets the function used to persist the view as the
+// startup layout
+func (a *App) SetStartupLayoutSaver(saver func(layout *config.StartupLayout) error) {
+	a.startupLayoutSaver = saver
+}
+
+// openStartupLayout opens the log split or comparison the startup layout
+// asks for. What it names may be gone since it was saved; then the list is
+// left as it is, with a notice saying why.
+func (a *App) openStartupLayout() tea.Cmd {
+	layout := a.startup
+	a.startup = nil
+
+	if layout.Compare {
+		return a.compareContexts([2]string{layout.Contexts[0], layout.Contexts[1]})
+	}
+
+	query := core.ParseFleetQuery(layout.Logs)
+	inNamespace := func(ref core.ResourceRef) bool {
+		return query.Namespace == "" || ref.Namespace == query.Namespace
+	}
+	// The resource named exactly, else the first whose name matches
+	if !a.resourceView.SelectFirstMatching(func(ref core.ResourceRef) bool {
+		return inNamespace(ref) && ref.Name == query.Pattern
+	}) && !a.resourceView.SelectFirstMatching(func(ref core.ResourceRef) bool {
+		return inNamespace(ref) && query.MatchesName(ref.Name)
+	}) {
+		a.resourceView.ShowNotice(fmt.Sprintf("Nothing listed matches %q from the startup layout; the log split stays closed", layout.Logs))
+		return nil
+	}
+	return a.openLogs("")
+}
+
+// currentLayout returns the startup layout that reopens what is shown: the
+// list, with the log split open on the resource whose logs are shown, or
+// the comparison
+func (a *App) currentLayout() *config.StartupLayout {
+	link := a.viewLink()
+	switch {
+	case a.currentMode == ModeCompare && a.comparisonView != nil:
+		contexts := a.comparisonView.Contexts()
+		link.Contexts = contexts[:]
+		layout := config.NewStartupLayout(link)
+		layout.Compare = true
+		return layout
+
+	case a.currentMode == ModeLog && a.fleetView == nil:
+		layout := config.NewStartupLayout(link)
+		layout.Logs = a.startupLogPattern(a.resourceView.SelectedResourceRef())
+		return layout
+	}
+	return config.NewStartupLayout(link)
+}
+
+// startupLogPattern is the pattern a startup layout finds a resource by
+// again: for a pod made by a controller, the name its replacements share,
+// e.g. "payments/checkout-7f9c5-*"; else its name
+func (a *App) startupLogPattern(ref core.ResourceRef) string {
+	if ref.IsZero() {
+		return ""
+	}
+	pattern := ref.Name
+	if a.state.CurrentResourceType == core.ResourceTypePod {
+		for _, pod := range a.state.GetAggregatedPods() {
+			if pod.Namespace == ref.Namespace && pod.Name == ref.Name && pod.GenerateName != "" {
+				pattern = pod.GenerateName + "*"
+				break
+			}
+		}
+	}
+	return core.FleetQuery{Namespace: ref.Namespace, Pattern: pattern}.String()
+}
+
+// saveStartupLayout writes what is shown to the config file as the layout
+// kubewatch starts in
+func (a *App) saveStartupLayout() {
+	notify := a.resourceView.ShowNotice
+	if a.currentMode == ModeCompare && a.comparisonView != nil {
+		notify = a.comparisonView.Focused().ShowNotice
+	}
+	if a.startupLayoutSaver == nil {
+		notify("No config file to save the startup layout to")
+		return
+	}
+	layout := a.currentLayout()
+	if err := a.startupLayoutSaver(layout); err != nil {
+		notify(fmt.Sprintf("Saving the startup layout failed: %v", err))
+		return
+	}
+	notify("✓ Saved as the startup layout: kubewatch opens into this view")
+}
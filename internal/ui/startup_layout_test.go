@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/client-go/rest"
+
+	"github.com/HamStudy/kubewatch/internal/config"
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+)
+
+func TestStartupLayoutRoundTrip(t *testing.T) {
+	// The server lists the payments pods named, made by a ReplicaSet
+	var mu sync.Mutex
+	var names []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/api/v1/namespaces/payments/pods" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+			return
+		}
+		var items []string
+		for _, name := range names {
+			generateName := name[:strings.LastIndex(name, "-")+1]
+			items = append(items, fmt.Sprintf(`{"metadata":{"name":%q,"generateName":%q,"namespace":"payments"},"spec":{"containers":[{"name":"app"}]},"status":{"phase":"Running"}}`, name, generateName))
+		}
+		w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[` + strings.Join(items, ",") + `]}`))
+	}))
+	defer server.Close()
+	setPods := func(pods ...string) {
+		mu.Lock()
+		defer mu.Unlock()
+		names = pods
+	}
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+
+	newApp := func() *App {
+		state := &core.State{CurrentResourceType: core.ResourceTypePod, CurrentNamespace: "payments", CurrentContext: "prod"}
+		app := NewApp(context.Background(), client, state, &core.Config{RefreshInterval: 5})
+		app.isMultiContext = false
+		app.k8sClient = client
+		app.resourceView = views.NewResourceView(state, client)
+		app.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+		return app
+	}
+	load := func(app *App) {
+		t.Helper()
+		if msg := app.resourceView.RefreshResources()(); msg != nil {
+			app.Update(msg)
+		}
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// A live layout: the pods listed, with the log split open on one
+	setPods("cart-6d8b9-xk2lp", "checkout-7f9c5-abcde")
+	live := newApp()
+	load(live)
+	if !live.resourceView.SelectResource(core.ResourceRef{Namespace: "payments", Name: "checkout-7f9c5-abcde"}) {
+		t.Fatal("Expected the checkout pod listed")
+	}
+	live.Update(runes("l"))
+	if live.currentMode != ModeLog {
+		t.Fatalf("Expected the log split open, got mode %v", live.currentMode)
+	}
+
+	dir := t.TempDir()
+	loader := config.NewLoader(dir)
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	live.SetStartupLayoutSaver(loader.SaveStartupLayout)
+	live.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if !strings.Contains(live.View(), "Saved as the startup layout") {
+		t.Errorf("Expected the save confirmed, got:\n%s", live.View())
+	}
+
+	reloaded := config.NewLoader(dir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	layout := reloaded.StartupLayout()
+	if layout == nil || layout.Namespace != "payments" || layout.ResourceType != "pods" || layout.Logs != "payments/checkout-7f9c5-*" {
+		t.Fatalf("Expected the list and the log split's pod saved, got %+v", layout)
+	}
+
+	// Reapplied after the pod was replaced, the log split opens on its
+	// replacement once the list loads, not before
+	setPods("cart-6d8b9-xk2lp", "checkout-7f9c5-q7wzt")
+	app := newApp()
+	app.OpenOnStart(layout)
+	if app.currentMode != ModeList {
+		t.Fatalf("Expected the list until it loads, got mode %v", app.currentMode)
+	}
+	load(app)
+	if app.currentMode != ModeLog {
+		t.Fatalf("Expected the log split opened after the first load, got mode %v", app.currentMode)
+	}
+	if got := app.resourceView.SelectedResourceRef().Name; got != "checkout-7f9c5-q7wzt" {
+		t.Errorf("Expected the replacement pod's logs, got %q", got)
+	}
+
+	// Nothing matching leaves the log split closed, saying why
+	setPods("cart-6d8b9-xk2lp")
+	app = newApp()
+	app.OpenOnStart(layout)
+	load(app)
+	if app.currentMode != ModeList {
+		t.Fatalf("Expected the list, got mode %v", app.currentMode)
+	}
+	if view := app.View(); !strings.Contains(view, "the log split stays closed") {
+		t.Errorf("Expected a notice that the log split stays closed, got:\n%s", view)
+	}
+	load(app)
+	if app.currentMode != ModeList {
+		t.Errorf("Expected the layout applied only once, got mode %v", app.currentMode)
+	}
+}
+
+func TestSaveComparisonAsStartupLayout(t *testing.T) {
+	app := createTestApp(t)
+	var saved *config.StartupLayout
+	app.SetStartupLayoutSaver(func(layout *config.StartupLayout) error {
+		saved = layout
+		return nil
+	})
+
+	app.showComparison(comparisonReadyMsg{contexts: [2]string{"east", "west"}})
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if saved == nil || !saved.Compare || !slices.Equal(saved.Contexts, []string{"east", "west"}) {
+		t.Fatalf("Expected the comparison saved, got %+v", saved)
+	}
+	if err := saved.Validate(); err != nil {
+		t.Errorf("Expected a valid layout, got %v", err)
+	}
+}
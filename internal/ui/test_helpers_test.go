@@ -10,11 +10,10 @@ import (
 
 	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/testutil/fixtures"
 	tea "github.com/charmbracelet/bubbletea"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 // createTestApp creates a test app with minimal setup for testing UI logic
@@ -50,6 +49,33 @@ func createTestApp(t *testing.T) *App {
 			ModeNamespaceSelector: NewNamespaceSelectorMode(),
 			ModeConfirmDialog:     NewConfirmDialogMode(),
 			ModeResourceSelector:  NewResourceSelectorMode(),
+			ModeTopology:          NewTopologyMode(),
+			ModeSettings:          NewSettingsMode(),
+			ModeFilter:            NewFilterMode(),
+			ModeSavedFilters:      NewSavedFiltersMode(),
+			ModeScrub:             NewScrubMode(),
+			ModeFinalizers:        NewFinalizersMode(),
+			ModeActionMenu:        NewActionMenuMode(),
+			ModeActionOutput:      NewActionOutputMode(),
+			ModeTemplates:         NewTemplatesMode(),
+			ModeTemplateForm:      NewTemplateFormMode(),
+			ModeCompare:           NewCompareMode(),
+			ModeRelations:         NewRelationsMode(),
+			ModeBatch:             NewBatchMode(),
+			ModeNodeDetail:        NewNodeDetailMode(),
+			ModeSplit:             NewSplitMode(),
+			ModePickResource:      NewPickResourceMode(),
+			ModeUsage:             NewUsageMode(),
+			ModeEventTail:         NewEventTailMode(),
+			ModePermissions:       NewPermissionsMode(),
+			ModeFleet:             NewFleetMode(),
+			ModeActivity:          NewActivityMode(),
+			ModeColumns:           NewColumnsMode(),
+			ModeShellPicker:       NewShellPickerMode(),
+			ModeLogPicker:         NewLogPickerMode(),
+			ModeScale:             NewScaleMode(),
+			ModeEvents:            NewEventsMode(),
+			ModeLabelSelector:     NewLabelSelectorMode(),
 		}
 	}
 
@@ -58,21 +84,8 @@ func createTestApp(t *testing.T) *App {
 
 // createMockPod creates a mock pod for testing
 func createMockPod(name, phase, namespace string) *v1.Pod {
-	return &v1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-			UID:       types.UID("mock-uid-" + name),
-		},
-		Status: v1.PodStatus{
-			Phase: v1.PodPhase(phase),
-		},
-		Spec: v1.PodSpec{
-			Containers: []v1.Container{
-				{Name: "main", Image: "nginx:latest"},
-			},
-		},
-	}
+	pod := fixtures.NewPod(name, namespace).WithUID("mock-uid-" + name).WithPhase(v1.PodPhase(phase)).Build()
+	return &pod
 }
 
 // createMockDeployment creates a mock deployment for testing
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HamStudy/kubewatch/internal/k8s"
+)
+
+// updateCheckTimeout bounds the startup check for a newer release, so a
+// slow network never holds anything up
+const updateCheckTimeout = 5 * time.Second
+
+// updateAvailableMsg is sent when a newer release than this build is found
+type updateAvailableMsg struct {
+	version string
+}
+
+// SetUpdateCheck turns on the startup check for a newer release. check
+// returns the newer version, or "" when this build is current. A version
+// equal to dismissed is not announced; dismiss records the version the user
+// dismisses so it is not announced again.
+func (a *App) SetUpdateCheck(check func(context.Context) (string, error), dismissed string, dismiss func(version string) error) {
+	a.updateCheck = check
+	a.updateSkip = dismissed
+	a.updateDismiss = dismiss
+}
+
+// checkForUpdate looks for a newer release in the background. It does
+// nothing unless the check was turned on, or when --no-external-network is
+// set, and a failed check is dropped silently.
+func (a *App) checkForUpdate() tea.Cmd {
+	if a.updateCheck == nil || k8s.NetworkRestricted() {
+		return nil
+	}
+	check, skip := a.updateCheck, a.updateSkip
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+		defer cancel()
+		version, err := check(ctx)
+		if err != nil || version == "" || version == skip {
+			return nil
+		}
+		return updateAvailableMsg{version: version}
+	}
+}
+
+// updateNotice is the line announcing a newer release, or "" when there is
+// none to announce
+func (a *App) updateNotice() string {
+	if a.updateVersion == "" {
+		return ""
+	}
+	return fmt.Sprintf("v%s available — see releases (Ctrl+X dismisses)", a.updateVersion)
+}
+
+// dismissUpdate hides the new release notice and remembers not to show it
+// for this version again. It reports whether there was a notice to dismiss.
+func (a *App) dismissUpdate() bool {
+	if a.updateVersion == "" {
+		return false
+	}
+	version := a.updateVersion
+	a.updateVersion = ""
+	a.updateSkip = version
+	a.resourceView.SetUpdateNotice("")
+	if a.updateDismiss != nil {
+		if err := a.updateDismiss(version); err != nil {
+			a.resourceView.ShowError(fmt.Errorf("remembering the dismissed release: %w", err))
+		}
+	}
+	return true
+}
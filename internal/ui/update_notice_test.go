@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestUpdateNotice(t *testing.T) {
+	app := createTestApp(t)
+	if app.checkForUpdate() != nil {
+		t.Fatal("Expected no check unless it was turned on")
+	}
+
+	var dismissed []string
+	check := func(context.Context) (string, error) { return "0.9.3", nil }
+	app.SetUpdateCheck(check, "", func(version string) error {
+		dismissed = append(dismissed, version)
+		return nil
+	})
+
+	k8s.RestrictNetwork(true)
+	restricted := app.checkForUpdate()
+	k8s.RestrictNetwork(false)
+	if restricted != nil {
+		t.Error("Expected no check with --no-external-network")
+	}
+
+	msg := app.checkForUpdate()()
+	if msg != (updateAvailableMsg{version: "0.9.3"}) {
+		t.Fatalf("Expected the newer release announced, got %#v", msg)
+	}
+	app.Update(msg)
+	if !strings.Contains(app.View(), "v0.9.3 available — see releases") {
+		t.Fatalf("Expected the notice under the header, got:\n%s", app.View())
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	if strings.Contains(app.View(), "v0.9.3 available") {
+		t.Error("Expected Ctrl+X to dismiss the notice")
+	}
+	if len(dismissed) != 1 || dismissed[0] != "0.9.3" {
+		t.Errorf("Expected the dismissed release remembered, got %v", dismissed)
+	}
+
+	// A dismissed release is not announced again, and failures are silent
+	app.SetUpdateCheck(check, "0.9.3", nil)
+	if msg := app.checkForUpdate()(); msg != nil {
+		t.Errorf("Expected nothing for the dismissed release, got %#v", msg)
+	}
+	app.SetUpdateCheck(func(context.Context) (string, error) {
+		return "", errors.New("dial tcp: i/o timeout")
+	}, "", nil)
+	if msg := app.checkForUpdate()(); msg != nil {
+		t.Errorf("Expected a failed check to be dropped, got %#v", msg)
+	}
+}
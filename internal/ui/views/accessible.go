@@ -0,0 +1,51 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetAccessibility sets how the list is drawn for those who cannot rely on
+// color. Without colors the selected row is marked with ">", as its
+// highlight no longer shows. The accessible mode, for screen readers, also
+// reads out the selected row as one line under the table and shows when the
+// list was last refreshed instead of a counter that changes every second.
+func (v *ResourceView) SetAccessibility(noColor, accessible bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.noColor = noColor
+	v.accessible = accessible
+}
+
+// textOnly reports whether states must be told without color. The caller
+// must hold v.mu.
+func (v *ResourceView) textOnly() bool {
+	return v.noColor || v.accessible
+}
+
+// renderSelectionReadout renders the selected row as one line of "column:
+// value" pairs, e.g. "Selected 2 of 12: NAME: web-1, READY: 1/1, STATUS:
+// Running", so a screen reader at the bottom of the screen reads it as a
+// sentence. The caller must hold v.mu.
+func (v *ResourceView) renderSelectionReadout() string {
+	rowCount := v.table.GetRowCount()
+	if rowCount == 0 || v.selectedRow < 0 || v.selectedRow >= rowCount {
+		return "Selected: nothing"
+	}
+
+	shown := v.shownColumns()
+	row := v.table.RowValues(v.selectedRow)
+	titles := v.table.Titles()
+	var pairs []string
+	for _, i := range v.table.ColumnOrder() {
+		if i >= len(row) || (shown != nil && !shown[i]) {
+			continue
+		}
+		pairs = append(pairs, titles[i]+": "+row[i])
+	}
+	line := fmt.Sprintf("Selected %d of %d: %s", v.selectedRow+1, rowCount, strings.Join(pairs, ", "))
+	if v.width > 0 {
+		line = truncateCell(line, v.width)
+	}
+	return line
+}
@@ -0,0 +1,76 @@
+package views
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+)
+
+// plainLines returns the lines of a rendering with trailing padding removed
+func plainLines(view string) []string {
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return lines
+}
+
+func TestResourceViewAccessibleSnapshot(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "default", ""), nil)
+	rv.SetSize(120, 12)
+	rv.SetAccessibility(false, true)
+	rv.SetTestData([]string{"NAME", "READY", "STATUS", "AGE"}, [][]string{{"web-1", "1/1", "Running", "5m"}, {"web-2", "0/1", "CrashLoopBackOff", "5m"}})
+	rv.SetSelectedRow(1)
+	rv.lastRefresh = time.Date(2024, 3, 5, 14, 2, 11, 0, time.Local)
+
+	lines := plainLines(rv.View())
+	if !strings.HasSuffix(lines[0], "Refreshed: 14:02:11 (auto-refresh off)") {
+		t.Errorf("Expected the time of the last refresh in the header, got %q", lines[0])
+	}
+	want := []string{
+		"  NAME      READY STATUS             AGE",
+		"────────────────────────────────────────────",
+		"  web-1       1/1 Running            5m",
+		"> web-2       0/1 CrashLoopBackOff   5m",
+		"Selected 2 of 2: NAME: web-2, READY: 0/1, STATUS: CrashLoopBackOff, AGE: 5m",
+	}
+	if got := lines[2:]; !slices.Equal(got, want) {
+		t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+
+	// Hidden columns are not read out
+	rv.state.SetColumns([]string{"STATUS"})
+	if lines := plainLines(rv.View()); lines[len(lines)-1] != "Selected 2 of 2: NAME: web-2, STATUS: CrashLoopBackOff" {
+		t.Errorf("Expected only the shown columns read out, got %q", lines[len(lines)-1])
+	}
+}
+
+func TestResourceViewNoColorSnapshot(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "default", ""), nil)
+	rv.SetSize(120, 12)
+	rv.SetAccessibility(true, false)
+	rv.SetTestData([]string{"NAME", "STATUS"}, [][]string{{"web-1", "Running"}, {"web-2", "Pending"}})
+
+	// Only the selection marker: no read-out, and the usual refresh counter
+	lines := plainLines(rv.View())
+	want := []string{
+		"  NAME    STATUS",
+		"───────────────────",
+		"> web-1   Running",
+		"  web-2   Pending",
+	}
+	if got := lines[2:]; !slices.Equal(got, want) {
+		t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+	if !strings.Contains(lines[0], "↻ Never") {
+		t.Errorf("Expected the refresh counter kept, got %q", lines[0])
+	}
+
+	rv.SetAccessibility(false, false)
+	if strings.Contains(rv.View(), "> web-1") {
+		t.Error("Expected no marker with colors")
+	}
+}
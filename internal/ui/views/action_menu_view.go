@@ -0,0 +1,263 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/config"
+	"github.com/HamStudy/kubewatch/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ActionMenuView lists the user-defined actions for the selected resource so
+// one can be run, after the built-in logs action when it applies and before
+// the built-in rollout actions of a deployment and permissions check
+type ActionMenuView struct {
+	actions  []*config.UserAction
+	target   string // e.g. "Pods default/web-1"
+	selected int    // Row selected; the logs row comes first when shown, the permissions row last
+
+	logs     core.LogTarget
+	showLogs bool
+
+	rollout []core.RolloutAction // Offered after the user-defined actions
+
+	width  int
+	height int
+}
+
+// NewActionMenuView creates a picker over the actions that apply to target
+func NewActionMenuView(actions []*config.UserAction, target string) *ActionMenuView {
+	return &ActionMenuView{
+		actions: actions,
+		target:  target,
+	}
+}
+
+// SetLogs adds the built-in logs action, as it applies to the resource: a
+// row to run it, or its hint when the resource has no logs
+func (v *ActionMenuView) SetLogs(target core.LogTarget) {
+	v.logs = target
+	v.showLogs = true
+}
+
+// SetRollout adds the built-in rollout actions of a deployment, as they
+// apply to one whose rollout is paused or not
+func (v *ActionMenuView) SetRollout(paused bool) {
+	v.rollout = core.RolloutActions(paused)
+}
+
+// logsRow returns whether the first row runs the logs action
+func (v *ActionMenuView) logsRow() bool {
+	return v.showLogs && v.logs.Applies()
+}
+
+// rows returns how many rows can be selected
+func (v *ActionMenuView) rows() int {
+	if v.logsRow() {
+		return len(v.actions) + len(v.rollout) + 2
+	}
+	return len(v.actions) + len(v.rollout) + 1
+}
+
+// LogsSelected returns whether the logs row is highlighted
+func (v *ActionMenuView) LogsSelected() bool {
+	return v.logsRow() && v.selected == 0
+}
+
+// PermissionsSelected returns whether the permissions row is highlighted
+func (v *ActionMenuView) PermissionsSelected() bool {
+	return v.selected == v.rows()-1
+}
+
+// Init initializes the view
+func (v *ActionMenuView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (v *ActionMenuView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if v.selected > 0 {
+				v.selected--
+			}
+		case "down", "j":
+			if v.selected < v.rows()-1 {
+				v.selected++
+			}
+		case "enter":
+			if v.LogsSelected() {
+				return v, func() tea.Msg { return LogsSelectedMsg{} }
+			}
+			if v.PermissionsSelected() {
+				return v, func() tea.Msg { return PermissionsSelectedMsg{} }
+			}
+			if a := v.SelectedAction(); a != nil {
+				return v, func() tea.Msg { return UserActionSelectedMsg{Action: a} }
+			}
+			if a, ok := v.SelectedRollout(); ok {
+				return v, func() tea.Msg { return RolloutActionSelectedMsg{Action: a} }
+			}
+		}
+	}
+	return v, nil
+}
+
+// SelectedAction returns the highlighted action
+func (v *ActionMenuView) SelectedAction() *config.UserAction {
+	i := v.selected
+	if v.logsRow() {
+		i--
+	}
+	if i < 0 || i >= len(v.actions) {
+		return nil
+	}
+	return v.actions[i]
+}
+
+// SelectedRollout returns the highlighted rollout action, if one is
+func (v *ActionMenuView) SelectedRollout() (core.RolloutAction, bool) {
+	i := v.selected - len(v.actions)
+	if v.logsRow() {
+		i--
+	}
+	if i < 0 || i >= len(v.rollout) {
+		return "", false
+	}
+	return v.rollout[i], true
+}
+
+// View renders the action menu
+func (v *ActionMenuView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(true)
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	nameWidth := 0
+	for _, a := range v.actions {
+		if len(a.Name) > nameWidth {
+			nameWidth = len(a.Name)
+		}
+	}
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Quick Actions"))
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render(v.target))
+	content.WriteString("\n\n")
+
+	switch {
+	case v.logsRow():
+		line := fmt.Sprintf("%-3s %s", "l", v.logs.Description)
+		if v.LogsSelected() {
+			content.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n")
+	case v.showLogs:
+		content.WriteString(labelStyle.Render(fmt.Sprintf("  %-3s %s", "l", v.logs.Hint)))
+		content.WriteString("\n")
+	}
+
+	if len(v.actions) == 0 {
+		content.WriteString(labelStyle.Render("No actions for this resource type. Add them under actions: in the config file."))
+		content.WriteString("\n")
+	}
+
+	for _, a := range v.actions {
+		line := fmt.Sprintf("%-3s %-*s", a.Key, nameWidth, a.Name)
+		if !a.IsInteractive() {
+			line += "  (background)"
+		}
+		if a == v.SelectedAction() {
+			content.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n")
+	}
+
+	selectedRollout, rolloutSelected := v.SelectedRollout()
+	for _, a := range v.rollout {
+		line := fmt.Sprintf("%-3s %s", "", a.Description())
+		if rolloutSelected && a == selectedRollout {
+			content.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n")
+	}
+
+	line := fmt.Sprintf("%-3s %s", "^a", "What can I do here? (permissions)")
+	if v.PermissionsSelected() {
+		content.WriteString(selectedStyle.Render("> " + line))
+	} else {
+		content.WriteString("  " + line)
+	}
+	content.WriteString("\n")
+
+	if a := v.SelectedAction(); a != nil {
+		content.WriteString("\n")
+		content.WriteString(labelStyle.Render(a.Command))
+	}
+
+	content.WriteString("\n\n")
+	content.WriteString(labelStyle.Render("[↑/↓] Select  [Enter] Run  [Esc/!] Close"))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// SetSize updates the view size
+func (v *ActionMenuView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// LogsSelectedMsg is sent when the user picks the built-in logs action
+type LogsSelectedMsg struct{}
+
+// PermissionsSelectedMsg is sent when the user picks the built-in permissions
+// check
+type PermissionsSelectedMsg struct{}
+
+// RolloutActionSelectedMsg is sent when the user picks a built-in rollout
+// action on a deployment
+type RolloutActionSelectedMsg struct {
+	Action core.RolloutAction
+}
+
+// UserActionSelectedMsg is sent when the user picks an action to run
+type UserActionSelectedMsg struct {
+	Action *config.UserAction
+}
@@ -0,0 +1,114 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ActionOutputView shows the output of a user-defined action run in the background
+type ActionOutputView struct {
+	viewport viewport.Model
+	name     string
+	command  string
+	output   string
+	err      error
+
+	width  int
+	height int
+}
+
+// NewActionOutputView creates a view of an action's combined output and how it exited
+func NewActionOutputView(name, command, output string, err error) *ActionOutputView {
+	v := &ActionOutputView{
+		viewport: viewport.New(80, 20),
+		name:     name,
+		command:  command,
+		output:   output,
+		err:      err,
+	}
+	v.setContent()
+	return v
+}
+
+// Init initializes the view
+func (v *ActionOutputView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (v *ActionOutputView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+		return v, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "g", "home":
+			v.viewport.GotoTop()
+			return v, nil
+		case "G", "end":
+			v.viewport.GotoBottom()
+			return v, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+// setContent puts the output in the viewport
+func (v *ActionOutputView) setContent() {
+	output := strings.TrimRight(v.output, "\n")
+	if output == "" {
+		output = "(no output)"
+	}
+	v.viewport.SetContent(output)
+}
+
+// View renders the action output
+func (v *ActionOutputView) View() string {
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	commandStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240"))
+
+	statusStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("46"))
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240"))
+
+	status := "✓ Finished"
+	if v.err != nil {
+		status = fmt.Sprintf("✗ Failed: %v", v.err)
+		statusStyle = statusStyle.Foreground(lipgloss.Color("196"))
+	}
+
+	header := fmt.Sprintf("▶ Action: %s", v.name)
+	footer := "↑↓/PgUp/PgDn: Scroll | g/G: Top/Bottom | Esc/q: Close"
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s\n%s\n%s",
+		headerStyle.Render(header),
+		commandStyle.Render("$ "+v.command),
+		statusStyle.Render(status),
+		v.viewport.View(),
+		footerStyle.Render(footer),
+	)
+}
+
+// SetSize updates the view size
+func (v *ActionOutputView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	// Leave room for the header, command, status and footer lines
+	resizeViewport(&v.viewport, width, height-4, nil)
+}
@@ -0,0 +1,160 @@
+package views
+
+import (
+	"strings"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ToggleActivityFeed shows or hides the feed of READY and STATUS
+// transitions under the table, returning whether it is now shown. The feed
+// starts from the rows listed now.
+func (v *ResourceView) ToggleActivityFeed() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.activity != nil {
+		v.activity = nil
+		v.activitySelected = -1
+		return false
+	}
+	v.activity = core.NewActivityFeed()
+	v.activitySelected = -1
+	v.recordActivityLocked()
+	return true
+}
+
+// ActivityFeedShown returns true while the activity feed is on
+func (v *ResourceView) ActivityFeedShown() bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.activity != nil
+}
+
+// recordActivity feeds the rows of a refresh to the activity feed
+func (v *ResourceView) recordActivity() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.recordActivityLocked()
+}
+
+// recordActivityLocked feeds the rows of the last update, filtered or not,
+// to the activity feed when it is on. The caller must hold v.mu.
+func (v *ResourceView) recordActivityLocked() {
+	if v.activity == nil || len(v.table.Columns()) == 0 {
+		return
+	}
+	readyColumn, statusColumn := -1, -1
+	for i, header := range v.table.Titles() {
+		switch header {
+		case "READY":
+			readyColumn = i
+		case "STATUS":
+			statusColumn = i
+		}
+	}
+
+	rows := make([]core.ActivityRow, 0, len(v.unfiltered))
+	for _, row := range v.unfiltered {
+		activityRow := core.ActivityRow{Ref: v.rowRef(row)}
+		if readyColumn >= 0 && readyColumn < len(row) {
+			activityRow.Ready = row[readyColumn]
+		}
+		if statusColumn >= 0 && statusColumn < len(row) {
+			activityRow.Status = row[statusColumn]
+		}
+		rows = append(rows, activityRow)
+	}
+	v.activity.Observe(v.historyKey(), v.state.CurrentResourceType, rows, time.Now())
+}
+
+// StartActivitySelection selects the newest entry of the activity feed, to
+// jump to its resource. It returns false when there is none.
+func (v *ResourceView) StartActivitySelection() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.activity == nil {
+		return false
+	}
+	entries := v.activity.Entries()
+	if len(entries) == 0 {
+		return false
+	}
+	v.activitySelected = len(entries) - 1
+	return true
+}
+
+// MoveActivitySelection moves the selected entry of the activity feed
+func (v *ResourceView) MoveActivitySelection(delta int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.activity == nil || v.activitySelected < 0 {
+		return
+	}
+	v.activitySelected = max(0, min(v.activitySelected+delta, len(v.activity.Entries())-1))
+}
+
+// SelectedActivity returns the selected entry of the activity feed
+func (v *ResourceView) SelectedActivity() (core.ActivityEntry, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.activity == nil || v.activitySelected < 0 {
+		return core.ActivityEntry{}, false
+	}
+	entries := v.activity.Entries()
+	if len(entries) == 0 {
+		return core.ActivityEntry{}, false
+	}
+	return entries[min(v.activitySelected, len(entries)-1)], true
+}
+
+// StopActivitySelection leaves the entries of the activity feed
+func (v *ResourceView) StopActivitySelection() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.activitySelected = -1
+}
+
+// renderActivityFeed renders the activity feed, one line per entry, or ""
+// when it is off or the list is compact. The caller must hold v.mu.
+func (v *ResourceView) renderActivityFeed() string {
+	if v.activity == nil || v.compactMode {
+		return ""
+	}
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+
+	entries := v.activity.Entries()
+	if len(entries) == 0 {
+		return labelStyle.Render("Activity: no READY or STATUS changes yet")
+	}
+	selected := -1
+	if v.activitySelected >= 0 {
+		selected = min(v.activitySelected, len(entries)-1)
+	}
+
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		line := entry.Line()
+		if v.textOnly() && selected >= 0 {
+			// The highlight does not show without colors
+			marker := "  "
+			if i == selected {
+				marker = "> "
+			}
+			line = marker + line
+		}
+		line = truncateCell(line, max(v.width, 20))
+		switch {
+		case i == selected:
+			lines[i] = selectedStyle.Render(line)
+		case entry.Warning:
+			lines[i] = warnStyle.Render(line)
+		default:
+			lines[i] = labelStyle.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,60 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+)
+
+func TestResourceViewActivityFeed(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "web", ""), nil)
+	rv.SetSize(100, 30)
+	headers := []string{"NAME", "READY", "STATUS", "RESTARTS", "AGE"}
+	rv.SetTestData(headers, [][]string{{"checkout-7f9c", "0/1", "ContainerCreating", "0", "5s"}, {"cart-1", "1/1", "Running", "0", "1h"}})
+
+	if strings.Contains(rv.View(), "Activity:") {
+		t.Error("Expected no activity feed until it is turned on")
+	}
+	if !rv.ToggleActivityFeed() {
+		t.Fatal("Expected the feed turned on")
+	}
+	if !strings.Contains(rv.View(), "Activity: no READY or STATUS changes yet") {
+		t.Errorf("Expected the empty feed noted, got:\n%s", rv.View())
+	}
+
+	rv.SetTestData(headers, [][]string{{"checkout-7f9c", "1/1", "Running", "0", "9s"}, {"cart-1", "0/1", "CrashLoopBackOff", "1", "1h"}})
+	output := rv.View()
+	for _, want := range []string{"pod checkout-7f9c Ready", "pod cart-1 CrashLoopBackOff"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in the feed:\n%s", want, output)
+		}
+	}
+
+	// Picking an entry starts from the newest
+	if !rv.StartActivitySelection() {
+		t.Fatal("Expected an entry to select")
+	}
+	if entry, _ := rv.SelectedActivity(); entry.Ref != (core.ResourceRef{Namespace: "web", Name: "cart-1"}) {
+		t.Errorf("Expected the newest entry selected, got %+v", entry.Ref)
+	}
+	rv.MoveActivitySelection(-5)
+	if entry, _ := rv.SelectedActivity(); entry.Ref.Name != "checkout-7f9c" {
+		t.Errorf("Expected the selection to stop at the oldest entry, got %+v", entry.Ref)
+	}
+	rv.StopActivitySelection()
+	if _, ok := rv.SelectedActivity(); ok {
+		t.Error("Expected no entry selected after stopping")
+	}
+
+	// Compact mode leaves no room for it
+	rv.SetCompactMode(true)
+	if strings.Contains(rv.View(), "checkout-7f9c Ready") {
+		t.Error("Expected no feed in compact mode")
+	}
+	rv.SetCompactMode(false)
+
+	if rv.ToggleActivityFeed() || rv.ActivityFeedShown() {
+		t.Error("Expected the feed turned off")
+	}
+}
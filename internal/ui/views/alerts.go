@@ -0,0 +1,83 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+)
+
+// Alerts returns the alert conditions that began holding since the last
+// call: pods listed going into CrashLoopBackOff, contexts whose data went
+// stale, and followed deletes finishing or getting stuck. It should be
+// called on every tick, focused or not, so that what began earlier is not
+// taken for news later.
+func (v *ResourceView) Alerts(now time.Time) []core.Alert {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.scrub != nil {
+		return nil
+	}
+
+	var began []core.Alert
+	if v.state.CurrentResourceType == core.ResourceTypePod && !v.lastRefresh.IsZero() {
+		// Each list is its own scope, so pods already crashing when a list
+		// is first shown are not news
+		scope := fmt.Sprintf("crashloop %s %s", v.state.CurrentNamespace, strings.Join(v.state.CurrentContexts, ","))
+		holding := make([]core.Alert, len(v.crashLooping))
+		for i, ref := range v.crashLooping {
+			holding[i] = core.Alert{
+				Category:  core.AlertCrashLoop,
+				Context:   v.alertContext(ref.Context),
+				Namespace: ref.Namespace,
+				Resource:  "pod " + ref.Name,
+				Message:   "is in CrashLoopBackOff",
+			}
+		}
+		began = append(began, v.alerts.Observe(scope, holding)...)
+	}
+
+	var stale []core.Alert
+	for _, c := range v.staleContexts(now) {
+		stale = append(stale, core.Alert{
+			Category: core.AlertDisconnected,
+			Context:  v.alertContext(c.name),
+			Message:  fmt.Sprintf("data stale for %s, reconnecting", core.FormatDuration(now.Sub(c.updated))),
+		})
+	}
+	began = append(began, v.alerts.Observe("disconnected", stale)...)
+
+	if v.state.Deletions != nil {
+		// A delete that got stuck and then finished is alerted of twice
+		var stuck, done []core.Alert
+		for _, d := range v.state.Deletions.Pending(now) {
+			alert := core.Alert{
+				Category:  core.AlertDeletion,
+				Context:   v.alertContext(d.Ref.Context),
+				Namespace: d.Ref.Namespace,
+				Resource:  strings.ToLower(k8s.EventKind(string(d.Kind))) + " " + d.Ref.Name,
+				Message:   d.Status(now),
+			}
+			switch d.Phase(now) {
+			case core.DeletionStuck:
+				stuck = append(stuck, alert)
+			case core.DeletionDone:
+				done = append(done, alert)
+			}
+		}
+		began = append(began, v.alerts.Observe("deletion stuck", stuck)...)
+		began = append(began, v.alerts.Observe("deletion done", done)...)
+	}
+	return began
+}
+
+// alertContext names the context an alert is about: the one given, or in
+// single-context mode the one shown. The caller must hold v.mu.
+func (v *ResourceView) alertContext(context string) string {
+	if context != "" {
+		return context
+	}
+	return v.state.CurrentContext
+}
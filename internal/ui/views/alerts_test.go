@@ -0,0 +1,46 @@
+package views
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceViewAlertsOfCrashLoops(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "payments", "prod"), nil)
+	crashing := v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{{
+		State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+	}}}
+	pods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "payments"}, Status: crashing},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "payments"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+	}
+	rv.updateTableWithPods(pods)
+	rv.lastRefresh = time.Now()
+
+	// Already crashing when first listed is not news
+	if alerts := rv.Alerts(time.Now()); len(alerts) != 0 {
+		t.Fatalf("Expected no alerts on the first list, got %v", alerts)
+	}
+
+	pods[1].Status = crashing
+	rv.updateTableWithPods(pods)
+	alerts := rv.Alerts(time.Now())
+	if len(alerts) != 1 || alerts[0].Text() != "prod/payments: pod web-2 is in CrashLoopBackOff" {
+		t.Fatalf("Expected web-2 alerted of, got %v", alerts)
+	}
+	if alerts := rv.Alerts(time.Now()); len(alerts) != 0 {
+		t.Errorf("Expected web-2 alerted of once, got %v", alerts)
+	}
+
+	// Data going stale is alerted of too
+	rv.refreshInterval = time.Second
+	rv.lastRefresh = time.Now().Add(-time.Minute)
+	alerts = rv.Alerts(time.Now())
+	if len(alerts) != 1 || alerts[0].Category != core.AlertDisconnected || alerts[0].Context != "prod" {
+		t.Errorf("Expected the stale context alerted of, got %v", alerts)
+	}
+}
@@ -0,0 +1,33 @@
+package views
+
+import (
+	"strconv"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+func (v *ResourceView) updateTableWithJobs(jobs []batchv1.Job) {
+	now := v.now()
+	rows := make([]objectRow, len(jobs))
+	for i := range jobs {
+		job := &jobs[i]
+		rows[i] = objectRow{job.ObjectMeta, []string{core.JobCompletions(job), core.JobDuration(job, now)}}
+	}
+	v.updateTableWithObjectRows(rows)
+}
+
+func (v *ResourceView) updateTableWithCronJobs(cronJobs []batchv1.CronJob) {
+	now := v.now()
+	rows := make([]objectRow, len(cronJobs))
+	for i := range cronJobs {
+		cronJob := &cronJobs[i]
+		rows[i] = objectRow{cronJob.ObjectMeta, []string{
+			cronJob.Spec.Schedule,
+			core.CronJobSuspend(cronJob),
+			strconv.Itoa(len(cronJob.Status.Active)),
+			core.CronJobLastSchedule(cronJob, now),
+		}}
+	}
+	v.updateTableWithObjectRows(rows)
+}
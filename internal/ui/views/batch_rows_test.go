@@ -0,0 +1,51 @@
+package views
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestBatchRows tests that jobs and cron jobs are listed with kubectl's
+// columns
+func TestBatchRows(t *testing.T) {
+	now := time.Now()
+	started := metav1.NewTime(now.Add(-5 * time.Minute))
+	completed := metav1.NewTime(now.Add(-3 * time.Minute))
+	completions := int32(1)
+
+	rv := NewResourceView(createTestState(core.ResourceTypeJob, "ops", "prod"), nil)
+	rv.updateTableWithJobs([]batchv1.Job{{
+		ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "ops"},
+		Spec:       batchv1.JobSpec{Completions: &completions},
+		Status:     batchv1.JobStatus{Succeeded: 1, StartTime: &started, CompletionTime: &completed},
+	}})
+	if expected := []string{"NAME", "COMPLETIONS", "DURATION", "AGE"}; !reflect.DeepEqual(rv.table.Titles(), expected) {
+		t.Errorf("Expected columns %v, got %v", expected, rv.table.Titles())
+	}
+	if row := rv.table.RowValues(0); row[1] != "1/1" || row[2] != "2m" {
+		t.Errorf("Expected the job's completions and duration, got %v", row)
+	}
+
+	lastRun := metav1.NewTime(now.Add(-10 * time.Minute))
+	rv.state.CurrentResourceType = core.ResourceTypeCronJob
+	rv.updateTableWithCronJobs([]batchv1.CronJob{{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ops"},
+		Spec:       batchv1.CronJobSpec{Schedule: "0 * * * *"},
+		Status: batchv1.CronJobStatus{
+			Active:           []v1.ObjectReference{{Name: "backup-1"}},
+			LastScheduleTime: &lastRun,
+		},
+	}})
+	if expected := []string{"NAME", "SCHEDULE", "SUSPEND", "ACTIVE", "LAST-SCHEDULE", "AGE"}; !reflect.DeepEqual(rv.table.Titles(), expected) {
+		t.Errorf("Expected columns %v, got %v", expected, rv.table.Titles())
+	}
+	if row := rv.table.RowValues(0); row[1] != "0 * * * *" || row[2] != "False" || row[3] != "1" || row[4] != "10m" {
+		t.Errorf("Expected the cron job's schedule and runs, got %v", row)
+	}
+}
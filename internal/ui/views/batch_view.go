@@ -0,0 +1,215 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// BatchProgress is a snapshot of a batch action for the results overlay
+type BatchProgress struct {
+	Title     string
+	Total     int
+	Succeeded int
+	Failed    int
+	Running   int
+	Queued    int
+	Dropped   int // Queued operations skipped because the batch was cancelled
+
+	// Active is true while operations are queued or in flight
+	Active bool
+	// Cancelling is true once the remainder was cancelled and in-flight
+	// operations are finishing
+	Cancelling bool
+
+	Failures []BatchFailure
+}
+
+// Pending returns the operations not yet finished
+func (p BatchProgress) Pending() int {
+	return p.Running + p.Queued
+}
+
+// BatchFailure is one failed operation of a batch
+type BatchFailure struct {
+	Label   string // What the operation acted on, e.g. "Deployment/web"
+	Kind    string // Classified error kind, e.g. "Forbidden"
+	Message string
+}
+
+// BatchView shows the live progress and the failures of a batch action. It
+// stays open until dismissed so failures are not lost.
+type BatchView struct {
+	progress BatchProgress
+	offset   int
+	status   string
+
+	width  int
+	height int
+}
+
+// NewBatchView creates a results overlay for a batch
+func NewBatchView(progress BatchProgress) *BatchView {
+	return &BatchView{progress: progress}
+}
+
+// Init initializes the view
+func (v *BatchView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages. Retry, cancel and dismiss are handled by the
+// batch mode; the view scrolls the failures.
+func (v *BatchView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if v.offset > 0 {
+				v.offset--
+			}
+		case "down", "j":
+			if v.offset < len(v.progress.Failures)-1 {
+				v.offset++
+			}
+		case "g", "home":
+			v.offset = 0
+		case "G", "end":
+			v.offset = len(v.progress.Failures) - 1
+			if v.offset < 0 {
+				v.offset = 0
+			}
+		}
+	}
+	return v, nil
+}
+
+// SetProgress replaces the snapshot shown
+func (v *BatchView) SetProgress(progress BatchProgress) {
+	v.progress = progress
+	if v.offset >= len(progress.Failures) {
+		v.offset = 0
+	}
+}
+
+// Progress returns the snapshot shown
+func (v *BatchView) Progress() BatchProgress {
+	return v.progress
+}
+
+// SetStatus shows a message under the failures, e.g. why a key did nothing
+func (v *BatchView) SetStatus(status string) {
+	v.status = status
+}
+
+// View renders the overlay
+func (v *BatchView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	doneStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("82"))
+
+	failedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196"))
+
+	kindStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("214"))
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	p := v.progress
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(p.Title))
+	content.WriteString("\n\n")
+
+	state := "✓ Finished"
+	switch {
+	case p.Cancelling && p.Active:
+		state = "Cancelling: waiting for in-flight operations"
+	case p.Active:
+		state = "Running"
+	case p.Dropped > 0:
+		state = "Cancelled"
+	}
+	content.WriteString(state + "\n")
+	content.WriteString(fmt.Sprintf("%s  %s  %s",
+		doneStyle.Render(fmt.Sprintf("done %d", p.Succeeded)),
+		failedStyle.Render(fmt.Sprintf("failed %d", p.Failed)),
+		labelStyle.Render(fmt.Sprintf("pending %d of %d", p.Pending(), p.Total))))
+	if p.Dropped > 0 {
+		content.WriteString(labelStyle.Render(fmt.Sprintf("  dropped %d", p.Dropped)))
+	}
+	content.WriteString("\n")
+
+	if len(p.Failures) > 0 {
+		content.WriteString("\n")
+		content.WriteString(failedStyle.Render("Failures"))
+		content.WriteString("\n")
+
+		// Keep the list inside the overlay; j/k scroll it
+		end := len(p.Failures)
+		if maxItems := v.height - 16; maxItems > 2 && end-v.offset > maxItems {
+			end = v.offset + maxItems
+		}
+		if v.offset > 0 {
+			content.WriteString(labelStyle.Render(fmt.Sprintf("  ↑ %d more", v.offset)) + "\n")
+		}
+		for _, f := range p.Failures[v.offset:end] {
+			content.WriteString("  " + f.Label + " " + kindStyle.Render(f.Kind) + "\n")
+			content.WriteString("    " + labelStyle.Render(f.Message) + "\n")
+		}
+		if end < len(p.Failures) {
+			content.WriteString(labelStyle.Render(fmt.Sprintf("  ↓ %d more", len(p.Failures)-end)) + "\n")
+		}
+	}
+
+	if v.status != "" {
+		content.WriteString("\n")
+		content.WriteString(statusStyle(v.status).Render(v.status))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	var help string
+	switch {
+	case p.Active:
+		help = "[c] Cancel remainder"
+	case p.Failed > 0:
+		help = "[r] Retry failures  [↑/↓] Scroll  [Esc] Close"
+	default:
+		help = "[Esc] Close"
+	}
+	content.WriteString(labelStyle.Render(help))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// SetSize updates the view size
+func (v *BatchView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
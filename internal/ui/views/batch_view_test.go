@@ -0,0 +1,59 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBatchViewStates(t *testing.T) {
+	v := NewBatchView(BatchProgress{Title: "Deleting 4 resource(s)", Total: 4, Succeeded: 1, Running: 2, Queued: 1, Active: true})
+	v.SetSize(100, 40)
+
+	view := v.View()
+	for _, want := range []string{"Deleting 4 resource(s)", "Running", "done 1", "failed 0", "pending 3 of 4", "Cancel remainder"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q while running, got:\n%s", want, view)
+		}
+	}
+
+	v.SetProgress(BatchProgress{Title: "Deleting 4 resource(s)", Total: 4, Succeeded: 2, Running: 1, Queued: 1, Active: true, Cancelling: true})
+	if view := v.View(); !strings.Contains(view, "Cancelling") {
+		t.Errorf("Expected the cancel to show, got:\n%s", view)
+	}
+
+	v.SetProgress(BatchProgress{Title: "Deleting 4 resource(s)", Total: 4, Succeeded: 2, Failed: 1, Dropped: 1, Failures: []BatchFailure{
+		{Label: "pod/web-1", Kind: "Forbidden", Message: "permission denied deleting pods/web-1 — check your RBAC role"},
+	}})
+	view = v.View()
+	for _, want := range []string{"Cancelled", "dropped 1", "pod/web-1", "Forbidden", "check your RBAC role", "Retry failures"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q after the cancel, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestBatchViewScrollsFailures(t *testing.T) {
+	var failures []BatchFailure
+	for _, name := range []string{"pod/a", "pod/b", "pod/c", "pod/d", "pod/e", "pod/f"} {
+		failures = append(failures, BatchFailure{Label: name, Kind: "NotFound", Message: "gone"})
+	}
+	v := NewBatchView(BatchProgress{Title: "Batch", Total: 6, Failed: 6, Failures: failures})
+	v.SetSize(80, 20) // Room for four failures
+
+	if view := v.View(); strings.Contains(view, "pod/f") || !strings.Contains(view, "↓ 2 more") {
+		t.Errorf("Expected the list cut to fit, got:\n%s", view)
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	view := v.View()
+	if !strings.Contains(view, "pod/f") || strings.Contains(view, "pod/a") || !strings.Contains(view, "↑ 5 more") {
+		t.Errorf("Expected the last failure after G, got:\n%s", view)
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if view := v.View(); !strings.Contains(view, "pod/a") {
+		t.Errorf("Expected the first failure after g, got:\n%s", view)
+	}
+}
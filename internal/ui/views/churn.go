@@ -0,0 +1,258 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/components/selection"
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// churnWindow is how far back pod creates and deletes are counted
+	churnWindow = 30 * time.Second
+
+	// churnMinSpan is how long pods are followed before a rate is given, so
+	// the first few updates do not pass for a burst
+	churnMinSpan = 5 * time.Second
+
+	// churnThreshold is the creates or deletes per second above which the
+	// pods listed churn
+	churnThreshold = 1.0
+
+	// ChurnCoalesceWindow is the least refresh coalescing window while the
+	// pods listed churn, so the list is not redrawn on every tick
+	ChurnCoalesceWindow = 5 * time.Second
+)
+
+// churnSample is the pods created and deleted between two updates
+type churnSample struct {
+	at      time.Time
+	creates int
+	deletes int
+}
+
+// churnRow is a row shown for a pod, kept for when the pod is deleted
+type churnRow struct {
+	row      []string
+	identity *selection.ResourceIdentity
+	gone     time.Time // When the pod was deleted
+}
+
+// churnTracker follows the pods created and deleted between updates of a
+// pod list, and keeps the rows of deleted pods for a grace period. Pods are
+// keyed by churnKey; a list of another scope starts over.
+type churnTracker struct {
+	scope   string
+	since   time.Time
+	listed  map[string]bool     // Pods listed at the last update
+	shown   map[string]churnRow // Rows shown at the last update
+	gone    map[string]churnRow // Rows of deleted pods, within the grace period
+	samples []churnSample
+
+	// Filled by the update in progress
+	nextListed map[string]bool
+	nextShown  map[string]churnRow
+}
+
+// churnKey identifies a pod by its UID, so a pod recreated under its name
+// is another pod, or else by its name
+func churnKey(context, namespace, name, uid string) string {
+	if uid != "" {
+		return uid
+	}
+	return podKey(context, namespace, name)
+}
+
+// begin starts an update of the list of scope. The maps of the update
+// before last are reused, so following an unchanged list allocates little.
+func (c *churnTracker) begin(scope string, now time.Time) {
+	if c.scope != scope {
+		*c = churnTracker{scope: scope, since: now}
+	}
+	if c.nextListed == nil {
+		c.nextListed = make(map[string]bool, len(c.listed))
+		c.nextShown = make(map[string]churnRow, len(c.shown))
+	}
+	clear(c.nextListed)
+	clear(c.nextShown)
+}
+
+// list records a pod listed, shown or not
+func (c *churnTracker) list(context string, pod *v1.Pod) {
+	c.nextListed[churnKey(context, pod.Namespace, pod.Name, string(pod.UID))] = true
+}
+
+// show records the row a pod is shown in
+func (c *churnTracker) show(identity *selection.ResourceIdentity, row []string) {
+	key := churnKey(identity.Context, identity.Namespace, identity.Name, identity.UID)
+	c.nextShown[key] = churnRow{row: row, identity: identity}
+}
+
+// end finishes the update: it counts the pods created and deleted since the
+// last, and returns the rows of pods deleted within grace to be kept while
+// the pods churn. The first update of a scope counts nothing.
+func (c *churnTracker) end(now time.Time, grace time.Duration) []churnRow {
+	first := c.listed == nil
+	previous := c.listed
+	c.listed, c.nextListed = c.nextListed, previous
+
+	var sample churnSample
+	if !first {
+		for key := range c.listed {
+			if !previous[key] {
+				sample.creates++
+			}
+		}
+		for key := range previous {
+			if !c.listed[key] {
+				sample.deletes++
+				if row, ok := c.shown[key]; ok {
+					row.gone = now
+					c.gone[key] = row
+				}
+			}
+		}
+		sample.at = now
+		c.samples = append(c.samples, sample)
+	}
+	if c.gone == nil {
+		c.gone = make(map[string]churnRow)
+	}
+	c.shown, c.nextShown = c.nextShown, c.shown
+	c.prune(now)
+
+	if grace <= 0 || !c.high(now) {
+		clear(c.gone)
+		return nil
+	}
+	var ghosts []churnRow
+	for key, row := range c.gone {
+		if now.Sub(row.gone) >= grace {
+			delete(c.gone, key)
+			continue
+		}
+		ghosts = append(ghosts, row)
+	}
+	return ghosts
+}
+
+// prune drops the samples older than the churn window
+func (c *churnTracker) prune(now time.Time) {
+	keep := 0
+	for keep < len(c.samples) && now.Sub(c.samples[keep].at) > churnWindow {
+		keep++
+	}
+	c.samples = c.samples[keep:]
+}
+
+// rates returns the pods created and deleted per second over the churn
+// window, or zeros until the pods have been followed for churnMinSpan
+func (c *churnTracker) rates(now time.Time) (creates, deletes float64) {
+	span := min(now.Sub(c.since), churnWindow)
+	if c.listed == nil || span < churnMinSpan {
+		return 0, 0
+	}
+	for _, sample := range c.samples {
+		if now.Sub(sample.at) > churnWindow {
+			continue
+		}
+		creates += float64(sample.creates)
+		deletes += float64(sample.deletes)
+	}
+	return creates / span.Seconds(), deletes / span.Seconds()
+}
+
+// high reports whether pods are created or deleted faster than the churn
+// threshold
+func (c *churnTracker) high(now time.Time) bool {
+	creates, deletes := c.rates(now)
+	return creates > churnThreshold || deletes > churnThreshold
+}
+
+// indicator returns the header's churn notice, e.g. "high churn: 3.2
+// creates/s", or "" while the pods do not churn
+func (c *churnTracker) indicator(now time.Time) string {
+	creates, deletes := c.rates(now)
+	switch {
+	case creates <= churnThreshold && deletes <= churnThreshold:
+		return ""
+	case deletes > creates:
+		return fmt.Sprintf("high churn: %.1f deletes/s", deletes)
+	}
+	return fmt.Sprintf("high churn: %.1f creates/s", creates)
+}
+
+// addGhostRows appends the kept rows of deleted pods to rows, with their
+// STATUS shown as Deleted, unless a pod of the same name is listed again.
+// It returns the rows and marks the ghosts for dimming. The caller must
+// hold v.mu.
+func (v *ResourceView) addGhostRows(rows [][]string, ghosts []churnRow) [][]string {
+	v.ghosts = nil
+	if len(ghosts) == 0 {
+		return rows
+	}
+	listed := make(map[string]bool, len(v.resourceMap))
+	for _, identity := range v.resourceMap {
+		listed[podDetailKey(identity)] = true
+	}
+	status := -1
+	for i, header := range v.table.Titles() {
+		if header == "STATUS" {
+			status = i
+		}
+	}
+	for _, ghost := range ghosts {
+		if listed[podDetailKey(ghost.identity)] {
+			continue
+		}
+		row := append([]string(nil), ghost.row...)
+		if status >= 0 && status < len(row) {
+			row[status] = "Deleted"
+		}
+		rows = append(rows, row)
+		v.resourceMap[len(rows)-1] = ghost.identity
+		if v.ghosts == nil {
+			v.ghosts = make(map[core.ResourceRef]bool)
+		}
+		v.ghosts[v.rowRef(row)] = true
+	}
+	return rows
+}
+
+// rowGhost reports whether a row is kept for a deleted pod. The caller must
+// hold v.mu.
+func (v *ResourceView) rowGhost(row []string) bool {
+	return len(v.ghosts) > 0 && v.state.CurrentResourceType == core.ResourceTypePod && v.ghosts[v.rowRef(row)]
+}
+
+// styleGhostCell dims a cell of a deleted pod's row
+func styleGhostCell(value string, width int) string {
+	return lipgloss.NewStyle().Width(width).Foreground(lipgloss.Color("241")).Faint(true).Render(value)
+}
+
+// churnScope identifies the pod list churn is followed for
+func (v *ResourceView) churnScope() string {
+	if v.isMultiContext {
+		return fmt.Sprintf("%s@%v", v.historyKey(), v.state.CurrentContexts)
+	}
+	return v.historyKey()
+}
+
+// HighChurn reports whether the pods listed are created or deleted faster
+// than the churn threshold
+func (v *ResourceView) HighChurn() bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.state.CurrentResourceType == core.ResourceTypePod && v.churn.high(v.now())
+}
+
+// SetChurnGrace sets how long the rows of pods deleted during high churn
+// stay listed; 0 lists only the pods that exist
+func (v *ResourceView) SetChurnGrace(grace time.Duration) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.churnGrace = grace
+}
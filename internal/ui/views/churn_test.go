@@ -0,0 +1,228 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// churnPods returns the pods ci-<first> to ci-<first+n-1>, as a CI namespace
+// lists them while jobs come and go
+func churnPods(first, n int) []v1.Pod {
+	pods := make([]v1.Pod, n)
+	for i := range pods {
+		name := fmt.Sprintf("ci-%04d", first+i)
+		pods[i] = v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "ci",
+				UID:               types.UID("uid-" + name),
+				CreationTimestamp: metav1.NewTime(goldenNow.Add(-time.Minute)),
+			},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		}
+	}
+	return pods
+}
+
+// churnView returns a pod view of namespace ci on a clock the test moves
+func churnView(grace time.Duration) (*ResourceView, *time.Time) {
+	now := goldenNow
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "ci", "test-context"), nil)
+	rv.SetClock(func() time.Time { return now })
+	rv.SetChurnGrace(grace)
+	rv.SetSize(160, 40)
+	return rv, &now
+}
+
+// selectPod selects the row of the pod named name
+func selectPod(t *testing.T, rv *ResourceView, name string) {
+	t.Helper()
+	for i := range rv.table.GetRowCount() {
+		if rv.table.RowValues(i)[0] == name {
+			rv.SetSelectedRow(i)
+			return
+		}
+	}
+	t.Fatalf("No row for pod %s", name)
+}
+
+// rowStatus returns the STATUS of row
+func rowStatus(rv *ResourceView, row []string) string {
+	for i, header := range rv.table.Titles() {
+		if header == "STATUS" {
+			return row[i]
+		}
+	}
+	return ""
+}
+
+func TestChurnIndicator(t *testing.T) {
+	rv, now := churnView(3 * time.Second)
+
+	// A steady list does not churn
+	for second := range 10 {
+		*now = goldenNow.Add(time.Duration(second) * time.Second)
+		rv.updateTableWithPods(churnPods(0, 20))
+	}
+	if rv.HighChurn() || strings.Contains(rv.renderHeader(), "high churn") {
+		t.Fatalf("Expected no churn for an unchanged list, got header:\n%s", rv.renderHeader())
+	}
+
+	// Four pods replaced every second, over the whole churn window
+	for second := 1; second <= 30; second++ {
+		*now = goldenNow.Add(time.Duration(10+second) * time.Second)
+		rv.updateTableWithPods(churnPods(4*second, 20))
+	}
+	if !rv.HighChurn() {
+		t.Fatal("Expected high churn while four pods a second are replaced")
+	}
+	if header := rv.renderHeader(); !strings.Contains(header, "high churn: 4.0 creates/s") {
+		t.Errorf("Expected the create rate in the header, got:\n%s", header)
+	}
+
+	// The churn passes once the window holds no more changes
+	*now = now.Add(churnWindow + time.Second)
+	rv.updateTableWithPods(churnPods(40, 20))
+	if rv.HighChurn() {
+		t.Error("Expected the churn to pass once the pods settle")
+	}
+
+	// Another namespace starts over
+	rv.state.CurrentNamespace = "other"
+	rv.updateTableWithPods(churnPods(0, 20))
+	if rv.HighChurn() {
+		t.Error("Expected the churn of another list to start over")
+	}
+}
+
+// TestChurnCursorStability replaces four pods a second and moves the
+// selection along with the oldest pods. The cursor must never leave a pod
+// that is still listed, never land on a pod created since the last update,
+// and must stay on a deleted pod's dimmed row for the grace period.
+func TestChurnCursorStability(t *testing.T) {
+	tests := []struct {
+		name  string
+		grace time.Duration
+		// Updates the cursor is expected to spend on the rows of deleted pods
+		ghostUpdates int
+	}{
+		{"grace period", 2 * time.Second, 2},
+		{"grace pinned to zero", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rv, now := churnView(tt.grace)
+			first := 0
+			step := func() {
+				*now = now.Add(time.Second)
+				first += 4
+				rv.updateTableWithPods(churnPods(first, 20))
+			}
+			rv.updateTableWithPods(churnPods(first, 20))
+			for range 10 {
+				step()
+			}
+			if !rv.HighChurn() {
+				t.Fatal("Expected high churn before measuring the cursor")
+			}
+			selectPod(t, rv, fmt.Sprintf("ci-%04d", first+5))
+
+			var jumps, ontoNew, ghostUpdates int
+			for range 30 {
+				before := rv.GetSelectedResourceName()
+				listedBefore := map[string]bool{}
+				for i := range rv.table.GetRowCount() {
+					listedBefore[rv.table.RowValues(i)[0]] = true
+				}
+				step()
+				after := rv.GetSelectedResourceName()
+
+				stillListed := false
+				for _, pod := range churnPods(first, 20) {
+					stillListed = stillListed || pod.Name == before
+				}
+				if after != before && stillListed {
+					jumps++
+				}
+				if !listedBefore[after] {
+					ontoNew++
+				}
+				if rowStatus(rv, rv.table.RowValues(rv.selectedRow)) == "Deleted" {
+					ghostUpdates++
+					if !rv.rowGhost(rv.table.RowValues(rv.selectedRow)) {
+						t.Errorf("Expected the deleted pod %s's row dimmed", after)
+					}
+				}
+			}
+
+			if jumps != 0 {
+				t.Errorf("Expected the cursor to stay on listed pods, it left them %d times", jumps)
+			}
+			if ontoNew != 0 {
+				t.Errorf("Expected the cursor never to land on a new pod, it did %d times", ontoNew)
+			}
+			// The cursor follows the oldest pods down, staying on each
+			// deleted one for the grace period before moving to the nearest
+			// pod still listed
+			if tt.ghostUpdates == 0 && ghostUpdates != 0 {
+				t.Errorf("Expected no deleted rows with the grace period at zero, the cursor was on %d", ghostUpdates)
+			}
+			if tt.ghostUpdates > 0 && ghostUpdates < tt.ghostUpdates {
+				t.Errorf("Expected the cursor kept on deleted rows at least %d updates, got %d", tt.ghostUpdates, ghostUpdates)
+			}
+		})
+	}
+}
+
+func TestChurnGhostRowsExpire(t *testing.T) {
+	rv, now := churnView(2 * time.Second)
+	first := 0
+	rv.updateTableWithPods(churnPods(first, 20))
+	for range 10 {
+		*now = now.Add(time.Second)
+		first += 4
+		rv.updateTableWithPods(churnPods(first, 20))
+	}
+
+	// The pods deleted in the last two updates are kept
+	if got := rv.table.GetRowCount(); got != 28 {
+		t.Errorf("Expected 20 pods and 8 deleted rows, got %d rows", got)
+	}
+	for i := range rv.table.GetRowCount() {
+		row := rv.table.RowValues(i)
+		deleted := rowStatus(rv, row) == "Deleted"
+		if deleted != rv.rowGhost(row) {
+			t.Errorf("Expected only the deleted rows dimmed, %s is deleted %v, dimmed %v", row[0], deleted, rv.rowGhost(row))
+		}
+	}
+
+	// Once the churn passes, only the pods listed are shown
+	*now = now.Add(churnWindow + time.Second)
+	rv.updateTableWithPods(churnPods(first, 20))
+	if got := rv.table.GetRowCount(); got != 20 {
+		t.Errorf("Expected the deleted rows gone with the churn, got %d rows", got)
+	}
+}
+
+func TestSelectionPrefersRemainingNeighbor(t *testing.T) {
+	rv, _ := churnView(0)
+	rv.updateTableWithPods(churnPods(0, 10))
+	selectPod(t, rv, "ci-0004")
+
+	// The selected pod and the one after it go; a new pod sorts first, so
+	// the old index now holds another pod than the nearest below
+	pods := append(churnPods(0, 4), churnPods(6, 4)...)
+	pods = append(pods, v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "aa-new", Namespace: "ci", UID: "uid-aa-new"}})
+	rv.updateTableWithPods(pods)
+	if got := rv.GetSelectedResourceName(); got != "ci-0006" {
+		t.Errorf("Expected the nearest remaining pod below, ci-0006, selected, got %s", got)
+	}
+}
@@ -0,0 +1,162 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ColumnOrderView lists a resource type's columns so they can be reordered
+type ColumnOrderView struct {
+	resourceType string
+	columns      []string
+	usual        []string // The columns in their usual order, for reset
+	selected     int
+
+	status string
+
+	width  int
+	height int
+}
+
+// NewColumnOrderView creates a chooser over columns, in the order shown.
+// usual is their order without a user-defined one.
+func NewColumnOrderView(resourceType string, columns, usual []string) *ColumnOrderView {
+	return &ColumnOrderView{
+		resourceType: resourceType,
+		columns:      slices.Clone(columns),
+		usual:        usual,
+	}
+}
+
+// Init initializes the view
+func (v *ColumnOrderView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (v *ColumnOrderView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if v.selected > 0 {
+				v.selected--
+			}
+		case "down", "j":
+			if v.selected < len(v.columns)-1 {
+				v.selected++
+			}
+		case "shift+up", "K":
+			v.move(-1)
+		case "shift+down", "J":
+			v.move(1)
+		case "r":
+			v.columns = slices.Clone(v.usual)
+			v.selected = 0
+		case "enter":
+			order := slices.Clone(v.columns)
+			return v, func() tea.Msg { return ColumnOrderChosenMsg{Order: order} }
+		case "ctrl+s":
+			order := slices.Clone(v.columns)
+			return v, func() tea.Msg { return ColumnOrderChosenMsg{Order: order, Save: true} }
+		}
+	}
+	return v, nil
+}
+
+// move moves the selected column by delta places, keeping it selected
+func (v *ColumnOrderView) move(delta int) {
+	to := v.selected + delta
+	if v.selected < 0 || to < 0 || to >= len(v.columns) {
+		return
+	}
+	v.columns[v.selected], v.columns[to] = v.columns[to], v.columns[v.selected]
+	v.selected = to
+}
+
+// Order returns the columns in the order chosen so far
+func (v *ColumnOrderView) Order() []string {
+	return slices.Clone(v.columns)
+}
+
+// SetStatus shows an error below the list, e.g. when the order cannot be saved
+func (v *ColumnOrderView) SetStatus(status string) {
+	v.status = status
+}
+
+// View renders the column chooser
+func (v *ColumnOrderView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(true)
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196"))
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Column Order — " + v.resourceType))
+	content.WriteString("\n\n")
+
+	for i, column := range v.columns {
+		line := fmt.Sprintf("%2d. %s", i+1, column)
+		if i == v.selected {
+			content.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n")
+	}
+
+	if v.status != "" {
+		content.WriteString("\n")
+		content.WriteString(errorStyle.Render(v.status))
+	}
+
+	content.WriteString("\n\n")
+	content.WriteString(labelStyle.Render("[↑/↓] Select  [Shift+↑/↓] Move  [r] Reset\n[Enter] Apply  [Ctrl+S] Apply and save  [Esc] Cancel"))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// SetSize updates the view size
+func (v *ColumnOrderView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// ColumnOrderChosenMsg is sent when the user applies a column order, and
+// asks for it to be saved to the config file with Save
+type ColumnOrderChosenMsg struct {
+	Order []string
+	Save  bool
+}
@@ -0,0 +1,229 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ComparisonView shows the same resource type and namespace in two contexts
+// side by side. Each pane has its own state, so it scrolls and filters on
+// its own; keys go to the focused pane. In linked mode, selecting a resource
+// selects the one of the same name in the other pane, and rows missing from
+// the other context are marked.
+type ComparisonView struct {
+	panes    [2]*ResourceView
+	states   [2]*core.State
+	contexts [2]string
+	clients  [2]*k8s.Client
+	focused  int
+	linked   bool
+
+	width  int
+	height int
+}
+
+// NewComparisonView creates a comparison of two contexts, each pane starting
+// from what state shows. Clients may be nil in tests.
+func NewComparisonView(state *core.State, contexts [2]string, clients [2]*k8s.Client) *ComparisonView {
+	v := &ComparisonView{
+		contexts: contexts,
+		clients:  clients,
+		linked:   true,
+	}
+	for i := range v.panes {
+		v.states[i] = state.ForContext(contexts[i])
+		v.panes[i] = NewResourceView(v.states[i], clients[i])
+	}
+	return v
+}
+
+// Init initializes the view
+func (v *ComparisonView) Init() tea.Cmd {
+	return v.RefreshResources()
+}
+
+// Update handles messages. Keys, and the results of actions such as a
+// failed delete, go to the focused pane; any message updates the link marks,
+// since refreshes finish in the background.
+func (v *ComparisonView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+		return v, nil
+
+	case tea.KeyMsg:
+		_, cmd := v.panes[v.focused].Update(msg)
+		v.SyncSelection()
+		return v, cmd
+	}
+
+	_, cmd := v.panes[v.focused].Update(msg)
+	v.updateMarks()
+	return v, cmd
+}
+
+// RefreshResources refreshes both panes
+func (v *ComparisonView) RefreshResources() tea.Cmd {
+	return tea.Batch(v.panes[0].RefreshResources(), v.panes[1].RefreshResources())
+}
+
+// Contexts returns the contexts compared, left pane first
+func (v *ComparisonView) Contexts() [2]string {
+	return v.contexts
+}
+
+// Panes returns the left and right resource views
+func (v *ComparisonView) Panes() [2]*ResourceView {
+	return v.panes
+}
+
+// Focused returns the resource view that keys and actions apply to
+func (v *ComparisonView) Focused() *ResourceView {
+	return v.panes[v.focused]
+}
+
+// FocusedState returns the focused pane's state
+func (v *ComparisonView) FocusedState() *core.State {
+	return v.states[v.focused]
+}
+
+// FocusedContext returns the context shown in the focused pane
+func (v *ComparisonView) FocusedContext() string {
+	return v.contexts[v.focused]
+}
+
+// FocusedClient returns the client of the focused pane's context
+func (v *ComparisonView) FocusedClient() *k8s.Client {
+	return v.clients[v.focused]
+}
+
+// ClientFor returns the client for one of the compared contexts, or nil
+func (v *ComparisonView) ClientFor(context string) *k8s.Client {
+	for i, c := range v.contexts {
+		if c == context {
+			return v.clients[i]
+		}
+	}
+	return nil
+}
+
+// ToggleFocus moves the focus to the other pane
+func (v *ComparisonView) ToggleFocus() {
+	v.focused = 1 - v.focused
+}
+
+// ToggleLinked turns linked selection on or off
+func (v *ComparisonView) ToggleLinked() {
+	v.linked = !v.linked
+	v.SyncSelection()
+}
+
+// IsLinked returns true when selection is synced between the panes
+func (v *ComparisonView) IsLinked() bool {
+	return v.linked
+}
+
+// SyncSelection selects the focused pane's resource in the other pane too,
+// when linked and it is listed there
+func (v *ComparisonView) SyncSelection() {
+	if v.linked {
+		// The panes show different contexts, so match within the namespace
+		if ref := v.Focused().SelectedResourceRef(); !ref.IsZero() {
+			ref.Context = ""
+			v.panes[1-v.focused].SelectResource(ref)
+		}
+	}
+	v.updateMarks()
+}
+
+// updateMarks marks the rows each pane has that the other lacks, when linked
+func (v *ComparisonView) updateMarks() {
+	if !v.linked {
+		v.panes[0].SetCompareNames(nil)
+		v.panes[1].SetCompareNames(nil)
+		return
+	}
+	left, right := v.panes[0].ResourceNames(), v.panes[1].ResourceNames()
+	v.panes[0].SetCompareNames(right)
+	v.panes[1].SetCompareNames(left)
+}
+
+// View renders the two panes side by side
+func (v *ComparisonView) View() string {
+	focusedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57"))
+
+	paneTitleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	separatorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240"))
+
+	link := "Linked: OFF"
+	if v.linked {
+		link = "Linked: ON (≠ missing in the other context)"
+	}
+	title := fmt.Sprintf("Compare %s: %s ⇄ %s", v.states[0].CurrentResourceType, v.contexts[0], v.contexts[1])
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86")).Render(title) +
+		"   " + infoStyle.Render(link+"  [Tab] Switch pane  [L] Link  [Esc] Close")
+
+	paneWidth, paneHeight := v.paneSize()
+	var rendered [2]string
+	for i, pane := range v.panes {
+		label := " " + v.contexts[i] + " "
+		if i == v.focused {
+			label = focusedStyle.Render("▶" + label)
+		} else {
+			label = paneTitleStyle.Render(" " + label)
+		}
+		body := lipgloss.JoinVertical(lipgloss.Left, label, pane.View())
+		rendered[i] = lipgloss.NewStyle().
+			Width(paneWidth).
+			MaxWidth(paneWidth).
+			Height(paneHeight + 1).
+			MaxHeight(paneHeight + 1).
+			Render(body)
+	}
+
+	separator := separatorStyle.Render(strings.TrimSuffix(strings.Repeat("│\n", paneHeight+1), "\n"))
+	panes := lipgloss.JoinHorizontal(lipgloss.Top, rendered[0], separator, rendered[1])
+
+	if v.width > 0 {
+		header = lipgloss.NewStyle().MaxWidth(v.width).Render(header)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, panes)
+}
+
+// paneSize returns the size of each pane's resource view, leaving room for
+// the header, the pane titles and the separator
+func (v *ComparisonView) paneSize() (int, int) {
+	width := (v.width - 1) / 2
+	height := v.height - 2
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	return width, height
+}
+
+// SetSize updates the view size and splits it between the panes
+func (v *ComparisonView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	paneWidth, paneHeight := v.paneSize()
+	for _, pane := range v.panes {
+		pane.SetSize(paneWidth, paneHeight)
+	}
+}
@@ -0,0 +1,102 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func createTestComparisonView() *ComparisonView {
+	state := createTestState(core.ResourceTypePod, "default", "staging")
+	view := NewComparisonView(state, [2]string{"staging", "prod"}, [2]*k8s.Client{})
+	view.SetSize(160, 20)
+
+	headers := []string{"NAME", "STATUS"}
+	view.Panes()[0].SetTestData(headers, [][]string{{"api", "Running"}, {"web", "Running"}, {"worker", "Running"}})
+	view.Panes()[1].SetTestData(headers, [][]string{{"api", "Running"}, {"worker", "Pending"}})
+	view.updateMarks()
+	return view
+}
+
+func TestComparisonViewLinkedSelection(t *testing.T) {
+	view := createTestComparisonView()
+	left, right := view.Panes()[0], view.Panes()[1]
+
+	// Moving to worker on the left selects it on the right too
+	view.Update(tea.KeyMsg{Type: tea.KeyDown})
+	view.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if left.GetSelectedResourceName() != "worker" || right.GetSelectedResourceName() != "worker" {
+		t.Fatalf("Expected worker selected in both panes, got %q and %q",
+			left.GetSelectedResourceName(), right.GetSelectedResourceName())
+	}
+
+	// web is missing on the right, so the right pane keeps its selection
+	view.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if left.GetSelectedResourceName() != "web" || right.GetSelectedResourceName() != "worker" {
+		t.Errorf("Expected web on the left and worker kept on the right, got %q and %q",
+			left.GetSelectedResourceName(), right.GetSelectedResourceName())
+	}
+
+	// Keys go to the focused pane only once unlinked
+	view.ToggleLinked()
+	view.ToggleFocus()
+	if view.Focused() != right || view.FocusedContext() != "prod" {
+		t.Fatalf("Expected focus on the prod pane")
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if left.GetSelectedResourceName() != "web" || right.GetSelectedResourceName() != "api" {
+		t.Errorf("Expected web kept on the left and api on the right, got %q and %q",
+			left.GetSelectedResourceName(), right.GetSelectedResourceName())
+	}
+}
+
+func TestComparisonViewMarksMissingRows(t *testing.T) {
+	view := createTestComparisonView()
+
+	output := view.View()
+	for _, want := range []string{"Compare", "staging ⇄ prod", "Linked: ON", "≠ web"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output:\n%s", want, output)
+		}
+	}
+	if strings.Count(output, "≠") != 2 {
+		t.Errorf("Expected only web marked besides the legend, got:\n%s", output)
+	}
+
+	view.ToggleLinked()
+	if output := view.View(); strings.Contains(output, "≠ web") || !strings.Contains(output, "Linked: OFF") {
+		t.Errorf("Expected no marks when unlinked, got:\n%s", output)
+	}
+}
+
+func TestComparisonViewSameNamesAcrossNamespaces(t *testing.T) {
+	state := createTestState(core.ResourceTypePod, "", "staging")
+	view := NewComparisonView(state, [2]string{"staging", "prod"}, [2]*k8s.Client{})
+	view.SetSize(160, 20)
+
+	headers := []string{"NAME", "NAMESPACE", "STATUS"}
+	view.Panes()[0].SetTestData(headers, [][]string{{"web", "alpha", "Running"}, {"web", "beta", "Running"}, {"web", "gamma", "Running"}})
+	view.Panes()[1].SetTestData(headers, [][]string{{"web", "alpha", "Running"}, {"web", "gamma", "Running"}})
+	view.updateMarks()
+	left, right := view.Panes()[0], view.Panes()[1]
+
+	// The right pane follows to the same namespace, not the first web
+	view.Update(tea.KeyMsg{Type: tea.KeyDown})
+	view.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if left.GetSelectedResourceColumn("NAMESPACE") != "gamma" || right.GetSelectedResourceColumn("NAMESPACE") != "gamma" {
+		t.Fatalf("Expected gamma/web selected in both panes, got %q and %q",
+			left.GetSelectedResourceColumn("NAMESPACE"), right.GetSelectedResourceColumn("NAMESPACE"))
+	}
+
+	// beta/web is missing on the right even though a web is listed there
+	view.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if right.GetSelectedResourceColumn("NAMESPACE") != "gamma" {
+		t.Errorf("Expected the right pane to keep gamma/web, got %q", right.GetSelectedResourceColumn("NAMESPACE"))
+	}
+	if output := view.View(); strings.Count(output, "≠") != 2 {
+		t.Errorf("Expected only beta/web marked besides the legend, got:\n%s", output)
+	}
+}
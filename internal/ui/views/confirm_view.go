@@ -17,6 +17,11 @@ type ConfirmView struct {
 	completed   bool // Track if the dialog has been completed
 	width       int
 	height      int
+
+	// For dangerous actions, the text, such as the resource's name, that
+	// must be typed to confirm; the buttons are replaced by the input
+	requiredInput string
+	input         string
 }
 
 // NewConfirmView creates a new confirmation dialog
@@ -39,10 +44,17 @@ func (v *ConfirmView) Init() tea.Cmd {
 func (v *ConfirmView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if v.requiredInput != "" {
+			v.updateInput(msg)
+			return v, nil
+		}
 		switch msg.String() {
-		case "left", "h", "tab":
-			v.confirmed = !v.confirmed
+		case "left", "h":
+			// Cancel is the left button
+			v.confirmed = false
 		case "right", "l":
+			v.confirmed = true
+		case "tab", "shift+tab":
 			v.confirmed = !v.confirmed
 		case "y", "Y":
 			v.confirmed = true
@@ -65,6 +77,23 @@ func (v *ConfirmView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return v, nil
 }
 
+// updateInput edits the typed confirmation; Enter confirms once it matches
+func (v *ConfirmView) updateInput(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		if v.InputMatches() {
+			v.confirmed = true
+			v.completed = true
+		}
+	case tea.KeyBackspace:
+		if runes := []rune(v.input); len(runes) > 0 {
+			v.input = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		v.input += string(msg.Runes)
+	}
+}
+
 // View renders the confirmation dialog
 func (v *ConfirmView) View() string {
 	// Create styles
@@ -84,15 +113,16 @@ func (v *ConfirmView) View() string {
 		Width(60).
 		Height(10)
 
+	// Reversed video stands out whatever the terminal's colors, and the
+	// brackets mark the focused button even where it does not
 	selectedStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("0")).
-		Background(lipgloss.Color("7")).
+		Reverse(true).
 		Bold(true).
-		Padding(0, 2)
+		Padding(0, 1)
 
 	unselectedStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("7")).
-		Padding(0, 2)
+		Padding(0, 1)
 
 	// Build content
 	var content strings.Builder
@@ -105,14 +135,21 @@ func (v *ConfirmView) View() string {
 	content.WriteString(messageStyle.Render(v.message))
 	content.WriteString("\n\n")
 
+	if v.requiredInput != "" {
+		content.WriteString(v.renderInput())
+		helpText := "\n\n[Enter] Confirm once it matches  [Esc] Cancel"
+		content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(helpText))
+		return lipgloss.Place(v.width, v.height, lipgloss.Center, lipgloss.Center, borderStyle.Render(content.String()))
+	}
+
 	// Buttons
 	var yesButton, noButton string
 	if v.confirmed {
-		yesButton = selectedStyle.Render(v.confirmText)
-		noButton = unselectedStyle.Render(v.cancelText)
+		yesButton = selectedStyle.Render("[ " + v.confirmText + " ]")
+		noButton = unselectedStyle.Render("  " + v.cancelText + "  ")
 	} else {
-		yesButton = unselectedStyle.Render(v.confirmText)
-		noButton = selectedStyle.Render(v.cancelText)
+		yesButton = unselectedStyle.Render("  " + v.confirmText + "  ")
+		noButton = selectedStyle.Render("[ " + v.cancelText + " ]")
 	}
 
 	buttons := lipgloss.JoinHorizontal(
@@ -133,7 +170,7 @@ func (v *ConfirmView) View() string {
 	content.WriteString(buttons)
 
 	// Help text
-	helpText := "\n\n[←→/Tab] Switch  [Y/N] Select  [Enter] Confirm  [Esc] Cancel"
+	helpText := "\n\n[←→/Tab] Switch  [Enter] Select  [Y] Confirm  [N/Esc] Cancel"
 	content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(helpText))
 
 	// Center the dialog
@@ -146,6 +183,24 @@ func (v *ConfirmView) View() string {
 	)
 }
 
+// renderInput renders the typed confirmation and whether it matches yet
+func (v *ConfirmView) renderInput() string {
+	prompt := lipgloss.NewStyle().Foreground(lipgloss.Color("7")).
+		Render("Type " + v.requiredInput + " to confirm:")
+	input := lipgloss.NewStyle().Reverse(true).Padding(0, 1).Render(v.input + "▏")
+
+	var indicator string
+	switch {
+	case v.InputMatches():
+		indicator = lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Bold(true).Render("✓ matches")
+	case strings.HasPrefix(v.requiredInput, v.input):
+		indicator = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("…")
+	default:
+		indicator = lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true).Render("✗ does not match")
+	}
+	return prompt + "\n" + input + "  " + indicator
+}
+
 // SetSize updates the view size
 func (v *ConfirmView) SetSize(width, height int) {
 	v.width = width
@@ -167,6 +222,24 @@ func (v *ConfirmView) SetConfirmText(text string) {
 	v.confirmText = text
 }
 
+// RequireInput makes the dialog confirm only once text, such as the name
+// of the resource at stake, has been typed; y and the buttons no longer do
+func (v *ConfirmView) RequireInput(text string) {
+	v.requiredInput = text
+	v.input = ""
+	v.confirmed = false
+}
+
+// RequiresInput returns whether the dialog is confirmed by typing
+func (v *ConfirmView) RequiresInput() bool {
+	return v.requiredInput != ""
+}
+
+// InputMatches returns whether the typed text is the required text
+func (v *ConfirmView) InputMatches() bool {
+	return v.requiredInput != "" && v.input == v.requiredInput
+}
+
 // SetCancelText sets custom cancel button text
 func (v *ConfirmView) SetCancelText(text string) {
 	v.cancelText = text
@@ -66,22 +66,27 @@ func TestConfirmViewKeyHandling(t *testing.T) {
 		wantConfirmed bool
 	}{
 		{
-			name:          "left arrow toggles selection",
-			keys:          []string{"left"},
-			wantConfirmed: true, // Starts at false, toggles to true
+			name:          "left arrow focuses cancel",
+			keys:          []string{"right", "left"},
+			wantConfirmed: false,
 		},
 		{
-			name:          "right arrow toggles selection",
+			name:          "right arrow focuses confirm",
 			keys:          []string{"right"},
 			wantConfirmed: true,
 		},
 		{
-			name:          "h key toggles selection",
-			keys:          []string{"h"},
+			name:          "right arrow stays on confirm",
+			keys:          []string{"right", "right"},
 			wantConfirmed: true,
 		},
 		{
-			name:          "l key toggles selection",
+			name:          "h key focuses cancel",
+			keys:          []string{"l", "h"},
+			wantConfirmed: false,
+		},
+		{
+			name:          "l key focuses confirm",
 			keys:          []string{"l"},
 			wantConfirmed: true,
 		},
@@ -90,6 +95,11 @@ func TestConfirmViewKeyHandling(t *testing.T) {
 			keys:          []string{"tab"},
 			wantConfirmed: true,
 		},
+		{
+			name:          "shift+tab toggles selection",
+			keys:          []string{"tab", "shift+tab"},
+			wantConfirmed: false,
+		},
 		{
 			name:          "Y key confirms",
 			keys:          []string{"Y"},
@@ -117,7 +127,7 @@ func TestConfirmViewKeyHandling(t *testing.T) {
 		},
 		{
 			name:          "multiple toggles",
-			keys:          []string{"left", "right", "tab"},
+			keys:          []string{"tab", "tab", "tab"},
 			wantConfirmed: true, // false -> true -> false -> true
 		},
 		{
@@ -145,6 +155,8 @@ func TestConfirmViewKeyHandling(t *testing.T) {
 					msg = tea.KeyMsg{Type: tea.KeyRight}
 				case "tab":
 					msg = tea.KeyMsg{Type: tea.KeyTab}
+				case "shift+tab":
+					msg = tea.KeyMsg{Type: tea.KeyShiftTab}
 				case "enter":
 					msg = tea.KeyMsg{Type: tea.KeyEnter}
 				case " ":
@@ -254,6 +266,62 @@ func TestConfirmViewRendering(t *testing.T) {
 	}
 }
 
+func TestConfirmViewFocusedButton(t *testing.T) {
+	view := NewConfirmView("Delete Pod", "Are you sure?")
+	view.SetConfirmText("Delete")
+	view.SetCancelText("Cancel")
+	view.SetSize(80, 24)
+
+	if output := view.View(); !strings.Contains(output, "[ Cancel ]") || strings.Contains(output, "[ Delete ]") {
+		t.Error("Expected cancel bracketed as the focused button")
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if output := view.View(); !strings.Contains(output, "[ Delete ]") || strings.Contains(output, "[ Cancel ]") {
+		t.Error("Expected delete bracketed once focused")
+	}
+}
+
+func TestConfirmViewRequiredInput(t *testing.T) {
+	view := NewConfirmView("Remove Finalizer", "Are you sure?")
+	view.RequireInput("web-1")
+	view.SetSize(80, 24)
+
+	typeText := func(text string) {
+		for _, r := range text {
+			view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	// y and the arrows are text, not shortcuts
+	typeText("y")
+	view.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if view.IsCompleted() || view.IsConfirmed() {
+		t.Fatal("Expected y not to confirm a typed confirmation")
+	}
+	if output := view.View(); !strings.Contains(output, "Type web-1 to confirm") || !strings.Contains(output, "does not match") {
+		t.Errorf("Expected the prompt and a mismatch shown, got %q", output)
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	typeText("web-")
+	if output := view.View(); strings.Contains(output, "does not match") || strings.Contains(output, "✓ matches") {
+		t.Errorf("Expected a partial name to show neither, got %q", output)
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if view.IsCompleted() {
+		t.Fatal("Expected enter to wait for the whole name")
+	}
+
+	typeText("1")
+	if output := view.View(); !strings.Contains(output, "web-1") || !strings.Contains(output, "✓ matches") {
+		t.Errorf("Expected the typed name and a match shown, got %q", output)
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !view.IsCompleted() || !view.IsConfirmed() {
+		t.Error("Expected enter to confirm once the name matches")
+	}
+}
+
 func TestConfirmViewSetters(t *testing.T) {
 	view := NewConfirmView("Test", "Message")
 
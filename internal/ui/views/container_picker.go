@@ -0,0 +1,134 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pickerResult is what a key did to a container picker
+type pickerResult int
+
+const (
+	pickerOpen      pickerResult = iota // Still picking
+	pickerPicked                        // Enter picked a container
+	pickerCancelled                     // Esc closed the picker
+)
+
+// containerPicker picks one of a pod's containers from a list narrowed by
+// typing, for the log view and for opening a shell, or one of several pods
+type containerPicker struct {
+	containers []string
+	item       string // What is picked, "container" unless set
+	withAll    bool   // Offers all containers, as -1, before the first
+	query      string
+	index      int // Into choices
+}
+
+// newContainerPicker opens a picker over containers with an empty query
+func newContainerPicker(containers []string, withAll bool) containerPicker {
+	return containerPicker{containers: containers, withAll: withAll}
+}
+
+// choices returns the containers matching the query, as indexes into
+// p.containers, with -1 for all containers first when offered and matching
+func (p *containerPicker) choices() []int {
+	query := strings.ToLower(p.query)
+	var choices []int
+	if p.withAll && strings.Contains("all containers", query) {
+		choices = append(choices, -1)
+	}
+	for i, name := range p.containers {
+		if strings.Contains(strings.ToLower(name), query) {
+			choices = append(choices, i)
+		}
+	}
+	return choices
+}
+
+// handleKey filters and moves through the picker, returning the picked
+// choice along with pickerPicked once Enter picks one
+func (p *containerPicker) handleKey(msg tea.KeyMsg) (pickerResult, int) {
+	choices := p.choices()
+	switch msg.String() {
+	case "esc":
+		return pickerCancelled, 0
+	case "enter":
+		if p.index >= len(choices) {
+			return pickerOpen, 0
+		}
+		return pickerPicked, choices[p.index]
+	case "up", "ctrl+p":
+		if p.index > 0 {
+			p.index--
+		}
+	case "down", "ctrl+n":
+		if p.index < len(choices)-1 {
+			p.index++
+		}
+	case "backspace":
+		if query := []rune(p.query); len(query) > 0 {
+			p.query = string(query[:len(query)-1])
+			p.index = 0
+		}
+	default:
+		if msg.Type == tea.KeyRunes {
+			p.query += string(msg.Runes)
+			p.index = 0
+		}
+	}
+	return pickerOpen, 0
+}
+
+// noun names what is picked
+func (p *containerPicker) noun() string {
+	if p.item == "" {
+		return "container"
+	}
+	return p.item
+}
+
+// prompt renders the query being typed and the picker's keys, naming what
+// Enter does, e.g. "stream"
+func (p *containerPicker) prompt(enter string) string {
+	promptStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229"))
+	return promptStyle.Render(fmt.Sprintf("%s: %s_", strings.ToUpper(p.noun()[:1])+p.noun()[1:], p.query)) +
+		fmt.Sprintf(" | ↑/↓: select | Enter: %s | Esc: cancel", enter)
+}
+
+// render renders height lines of matching containers, keeping the
+// highlighted one in view. label names a choice, -1 being all containers.
+func (p *containerPicker) render(height int, label func(choice int) string) string {
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	choices := p.choices()
+	if height < 1 {
+		height = 1
+	}
+	lines := make([]string, 0, height)
+	if len(choices) == 0 {
+		lines = append(lines, dimStyle.Render("  No "+p.noun()+" matches"))
+	}
+
+	start := 0
+	if p.index >= height {
+		start = p.index - height + 1
+	}
+	for i := start; i < len(choices) && len(lines) < height; i++ {
+		if i == p.index {
+			lines = append(lines, selectedStyle.Render("> "+label(choices[i])))
+		} else {
+			lines = append(lines, "  "+label(choices[i]))
+		}
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
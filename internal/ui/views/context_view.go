@@ -2,6 +2,7 @@ package views
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -22,6 +23,7 @@ type ContextView struct {
 	loadingContexts  map[string]bool // Track which contexts are loading
 	showingInfo      bool            // Whether currently showing context info
 	infoContext      string          // Context name for which info is being shown
+	status           string          // Why the last action was refused, e.g. a comparison
 }
 
 // NewContextView creates a new context selector view
@@ -74,6 +76,8 @@ func (v *ContextView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return v, nil
 		}
 
+		v.status = ""
+
 		// Handle special keys first
 		if msg.Type == tea.KeySpace {
 			// Toggle selection for current context
@@ -235,7 +239,13 @@ func (v *ContextView) View() string {
 		Foreground(lipgloss.Color("240")).
 		MarginTop(2)
 
-	helpText := "↑↓: Navigate | Space: Toggle | Enter: Confirm | i: Info | Esc: Cancel"
+	if v.status != "" {
+		content.WriteString("\n")
+		content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(v.status))
+		content.WriteString("\n")
+	}
+
+	helpText := "↑↓: Navigate | Space: Toggle | Enter: Confirm | =: Compare two | i: Info | Esc: Cancel"
 	if v.multiSelect {
 		helpText += " | a: All | m: Single-select"
 	} else {
@@ -255,15 +265,31 @@ func (v *ContextView) View() string {
 	)
 }
 
-// GetSelectedContexts returns the selected context names
+// GetSelectedContexts returns the selected context names in list order
 func (v *ContextView) GetSelectedContexts() []string {
 	var selected []string
-	for ctx, isSelected := range v.selectedContexts {
-		if isSelected {
+	listed := make(map[string]bool, len(v.contexts))
+	for _, ctx := range v.contexts {
+		listed[ctx] = true
+		if v.selectedContexts[ctx] {
 			selected = append(selected, ctx)
 		}
 	}
-	return selected
+
+	// Active contexts no longer in the kubeconfig
+	var others []string
+	for ctx, isSelected := range v.selectedContexts {
+		if isSelected && !listed[ctx] {
+			others = append(others, ctx)
+		}
+	}
+	sort.Strings(others)
+	return append(selected, others...)
+}
+
+// SetStatus shows a message above the help line until the next key
+func (v *ContextView) SetStatus(status string) {
+	v.status = status
 }
 
 // filterContexts filters the context list based on search query
@@ -0,0 +1,104 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// deletionColor is the color a deletion's mark and status are shown in
+func deletionColor(phase core.DeletionPhase) lipgloss.Color {
+	switch phase {
+	case core.DeletionStuck:
+		return lipgloss.Color("196")
+	case core.DeletionDone:
+		return lipgloss.Color("2")
+	}
+	return lipgloss.Color("214")
+}
+
+// deletionMark is the mark of a row being deleted
+func deletionMark(phase core.DeletionPhase) string {
+	switch phase {
+	case core.DeletionStuck:
+		return "! "
+	case core.DeletionDone:
+		return "✓ "
+	}
+	return "✕ "
+}
+
+// collectDeletions gathers the deletions of the listed type, as of now, for
+// the rows to be marked by; none while scrubbing. The caller must hold v.mu.
+func (v *ResourceView) collectDeletions(now time.Time) {
+	v.deletions = nil
+	v.deletionsAt = now
+	if v.state.Deletions == nil || v.scrub != nil {
+		return
+	}
+	for _, d := range v.state.Deletions.Pending(now) {
+		if d.Kind != v.state.CurrentResourceType {
+			continue
+		}
+		if v.deletions == nil {
+			v.deletions = make(map[core.ResourceRef]core.PendingDeletion)
+		}
+		v.deletions[d.Ref] = d
+	}
+}
+
+// rowDeletion returns the deletion of a row's resource, if one is followed.
+// The caller must hold v.mu.
+func (v *ResourceView) rowDeletion(row []string) (core.PendingDeletion, bool) {
+	if len(v.deletions) == 0 {
+		return core.PendingDeletion{}, false
+	}
+	ref := v.rowRef(row)
+	if d, ok := v.deletions[ref]; ok {
+		return d, true
+	}
+	// A delete requested from one context's list is followed without its
+	// context
+	ref.Context = ""
+	d, ok := v.deletions[ref]
+	return d, ok
+}
+
+// deletionStatus returns the line describing the selected row's deletion,
+// or else the latest requested, e.g. "pod web-1: terminating (grace 30s,
+// 12s left)". The caller must hold v.mu.
+func (v *ResourceView) deletionStatus() (string, core.DeletionPhase) {
+	if len(v.deletions) == 0 {
+		return "", 0
+	}
+	now := v.deletionsAt
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		if d, ok := v.rowDeletion(v.table.RowValues(v.selectedRow)); ok {
+			return describeDeletion(d, now), d.Phase(now)
+		}
+	}
+
+	var latest core.PendingDeletion
+	for _, d := range v.deletions {
+		if d.RequestedAt.After(latest.RequestedAt) {
+			latest = d
+		}
+	}
+	status := describeDeletion(latest, now)
+	if more := len(v.deletions) - 1; more > 0 {
+		status += fmt.Sprintf(" (+%d more)", more)
+	}
+	return status, latest.Phase(now)
+}
+
+// describeDeletion names a deletion's resource and says how far it has got
+func describeDeletion(d core.PendingDeletion, now time.Time) string {
+	status := fmt.Sprintf("%s %s: %s", k8s.EventKind(string(d.Kind)), d.Ref.String(), d.Status(now))
+	if d.Phase(now) == core.DeletionStuck && len(d.Finalizers) > 0 {
+		status += " — d, then x removes one"
+	}
+	return status
+}
@@ -0,0 +1,166 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+const (
+	// deploymentPodsInterval is how often the pods of the selected
+	// deployment are read again
+	deploymentPodsInterval = 10 * time.Second
+
+	// deploymentPodsTimeout bounds one read of a deployment's pods
+	deploymentPodsTimeout = 15 * time.Second
+)
+
+// deploymentPodCache holds the tally of the selected deployment's pods. Its
+// status counts ready and updated replicas but leaves out the pods being
+// deleted, so how many are terminating is only known from the pods
+// themselves. Only one deployment is held; selecting another forgets it.
+type deploymentPodCache struct {
+	mu        sync.Mutex
+	key       string // podKey of the deployment
+	counts    core.PodCounts
+	fetchedAt time.Time // When the last read succeeded; zero when none has
+	started   time.Time // When the read in flight started; zero when none is
+}
+
+// Due reports whether the deployment's pods should be read now, and if so
+// marks the read started
+func (c *deploymentPodCache) Due(key string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if key != c.key {
+		c.key, c.counts = key, core.PodCounts{}
+		c.fetchedAt, c.started = time.Time{}, time.Time{}
+	}
+	if !c.started.IsZero() && now.Sub(c.started) < deploymentPodsTimeout {
+		return false
+	}
+	if !c.fetchedAt.IsZero() && now.Sub(c.fetchedAt) < deploymentPodsInterval {
+		return false
+	}
+	c.started = now
+	return true
+}
+
+// Record keeps the tally a read made of the deployment's pods. A failed
+// read keeps the last tally and is tried again once the timeout passes.
+func (c *deploymentPodCache) Record(key string, counts core.PodCounts, err error, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if key != c.key || err != nil {
+		return
+	}
+	c.counts = counts
+	c.fetchedAt = now
+	c.started = time.Time{}
+}
+
+// Counts returns the tally of the deployment's pods, if one was read
+func (c *deploymentPodCache) Counts(key string) (core.PodCounts, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if key != c.key || c.fetchedAt.IsZero() {
+		return core.PodCounts{}, false
+	}
+	return c.counts, true
+}
+
+// deploymentPodsMsg carries the tally of one deployment's pods
+type deploymentPodsMsg struct {
+	key    string
+	counts core.PodCounts
+	err    error
+}
+
+// FetchDeploymentPods reads the pods of the selected deployment when they
+// are due. It returns nil when nothing is.
+func (v *ResourceView) FetchDeploymentPods() tea.Cmd {
+	v.mu.RLock()
+	contextName, deployment, ok := v.selectedDeployment()
+	v.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	scope, err := core.DeploymentPodScope(deployment)
+	if err != nil {
+		return nil
+	}
+	key := podKey(contextName, deployment.Namespace, deployment.Name)
+	if !v.deploymentPods.Due(key, time.Now()) {
+		return nil
+	}
+
+	return func() tea.Msg {
+		client, err := v.clientForContext(contextName)
+		if err != nil {
+			return deploymentPodsMsg{key: key, err: err}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), deploymentPodsTimeout)
+		defer cancel()
+		pods, err := client.ListPodsWithLabels(ctx, scope.Namespace, scope.Selector.String())
+		if err != nil {
+			return deploymentPodsMsg{key: key, err: err}
+		}
+		var counts core.PodCounts
+		for i := range pods {
+			if scope.Matches(&pods[i]) {
+				counts.Add(&pods[i])
+			}
+		}
+		return deploymentPodsMsg{key: key, counts: counts}
+	}
+}
+
+// selectedDeployment returns the selected deployment and the context it was
+// listed in. The caller must hold v.mu.
+func (v *ResourceView) selectedDeployment() (string, *appsv1.Deployment, bool) {
+	if v.state.CurrentResourceType != core.ResourceTypeDeployment || v.scrub != nil {
+		return "", nil, false
+	}
+	if v.selectedRow < 0 || v.selectedRow >= v.table.GetRowCount() {
+		return "", nil, false
+	}
+	row := v.table.RowValues(v.selectedRow)
+	ref := v.rowRef(row)
+	deployment, ok := v.state.FindDeployment(ref.Context, ref.Namespace, ref.Name)
+	return ref.Context, deployment, ok
+}
+
+// replicaMath says how the selected deployment's pods stand while some are
+// starting or terminating, e.g. "2 of 3 ready, 1 terminating, 1 starting";
+// "" when every pod is settled or they were not read yet. A paused rollout
+// makes no progress, so it is said to be paused rather than under way. The
+// caller must hold v.mu.
+func (v *ResourceView) replicaMath(contextName string, deployment *appsv1.Deployment) string {
+	counts, ok := v.deploymentPods.Counts(podKey(contextName, deployment.Namespace, deployment.Name))
+	if !ok || counts.Terminating+counts.Starting == 0 {
+		if deployment.Spec.Paused {
+			return "rollout paused"
+		}
+		return ""
+	}
+	parts := []string{fmt.Sprintf("%d ready", counts.Ready)}
+	if deployment.Spec.Replicas != nil {
+		parts[0] = fmt.Sprintf("%d of %d ready", counts.Ready, *deployment.Spec.Replicas)
+	}
+	if counts.Terminating > 0 {
+		parts = append(parts, fmt.Sprintf("%d terminating", counts.Terminating))
+	}
+	if counts.Starting > 0 {
+		parts = append(parts, fmt.Sprintf("%d starting", counts.Starting))
+	}
+	if deployment.Spec.Paused {
+		parts = append([]string{"rollout paused"}, parts...)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,107 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceViewCountsTerminatingPodsApart(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "default", ""), nil)
+	rv.SetSize(200, 20)
+	deleted := metav1.NewTime(time.Now().Add(-time.Minute))
+	running := v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}}
+	pods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}, Status: running},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default"}, Status: running},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-3", Namespace: "default", DeletionTimestamp: &deleted}, Status: running},
+	}
+	rv.state.UpdatePods(pods)
+	rv.updateTableWithPods(pods)
+
+	view := rv.View()
+	if !strings.Contains(view, "Running 2") || !strings.Contains(view, "Terminating 1") {
+		t.Errorf("Expected the terminating pod counted apart from the running ones, got:\n%s", view)
+	}
+
+	rv.mu.Lock()
+	defer rv.mu.Unlock()
+	for row, want := range []bool{false, false, true} {
+		if got := rv.rowTerminating(rv.table.RowValues(row)); got != want {
+			t.Errorf("Expected row %d terminating %v, got %v", row, want, got)
+		}
+	}
+}
+
+func TestDeploymentDetailShowsTerminatingAndStartingPods(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypeDeployment, "default", ""), nil)
+	rv.SetSize(200, 20)
+	replicas := int32(3)
+	deployments := []appsv1.Deployment{{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}}
+	rv.state.UpdateDeployments(deployments)
+	rv.updateTableWithDeployments(deployments)
+	rv.selectedRow = 0
+
+	// The pods are read once, then again only after the interval
+	if cmd := rv.FetchDeploymentPods(); cmd == nil {
+		t.Fatal("Expected the pods of web read")
+	}
+	if cmd := rv.FetchDeploymentPods(); cmd != nil {
+		t.Error("Expected no second read while the first is in flight")
+	}
+
+	key := podKey("", "default", "web")
+	rv.Update(deploymentPodsMsg{key: key, counts: core.PodCounts{Running: 3, Ready: 2, Starting: 1, Terminating: 1}})
+	if view := rv.View(); !strings.Contains(view, "web — 2 of 3 ready, 1 terminating, 1 starting") {
+		t.Errorf("Expected the replica math under the header, got:\n%s", view)
+	}
+
+	// Settled pods say nothing
+	rv.Update(deploymentPodsMsg{key: key, counts: core.PodCounts{Running: 3, Ready: 3}})
+	if view := rv.View(); strings.Contains(view, "web —") {
+		t.Errorf("Expected no replica math once the rollout settled, got:\n%s", view)
+	}
+}
+
+func TestPausedDeploymentIsBadged(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypeDeployment, "default", ""), nil)
+	rv.SetSize(200, 20)
+	replicas := int32(3)
+	deployments := []appsv1.Deployment{{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Paused:   true,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 2},
+	}}
+	rv.state.UpdateDeployments(deployments)
+	rv.updateTableWithDeployments(deployments)
+	rv.selectedRow = 0
+
+	if ready := rv.table.RowValues(0)[1]; ready != "2/3 (paused)" {
+		t.Errorf("Expected the READY cell badged, got %q", ready)
+	}
+
+	// A paused rollout is not expected to progress, settled or not
+	if view := rv.View(); !strings.Contains(view, "web — rollout paused") {
+		t.Errorf("Expected the rollout said to be paused, got:\n%s", view)
+	}
+	rv.FetchDeploymentPods()
+	rv.Update(deploymentPodsMsg{key: podKey("", "default", "web"), counts: core.PodCounts{Running: 3, Ready: 2, Starting: 1}})
+	if view := rv.View(); !strings.Contains(view, "web — rollout paused, 2 of 3 ready, 1 starting") {
+		t.Errorf("Expected the pods tallied after the pause, got:\n%s", view)
+	}
+}
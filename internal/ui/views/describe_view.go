@@ -1,23 +1,29 @@
 package views
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/HamStudy/kubewatch/internal/k8s"
 	"github.com/HamStudy/kubewatch/internal/template"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
 )
 
-// DescribeView displays the kubectl describe output for a resource
+// DescribeView displays the kubectl describe output for a resource, read
+// from the cluster. The resource's events follow the describe output and are
+// fetched incrementally on refresh.
 type DescribeView struct {
 	viewport       viewport.Model
-	content        string
+	content        string   // Describe output and events, as shown
+	lines          []string // content as laid out in the viewport
+	body           string   // Describe output without the events
 	resourceType   string
 	resourceName   string
 	namespace      string
@@ -31,28 +37,123 @@ type DescribeView struct {
 	autoRefresh    bool
 	refreshTicker  *time.Ticker
 	templateEngine *template.Engine
-	events         []string
+	status         string // Result of the last action, shown beside the timestamp
+
+	client        *k8s.Client
+	ctx           context.Context
+	events        []v1.Event // Oldest first
+	eventsErr     error
+	lastEventSeen time.Time // Newest event fetched; the next fetch starts here
+	followEvents  bool      // Keep the tail of the events in view
+	warningsOnly  bool      // Hide Normal events
+
+	// Image changes of a deployment seen this session, oldest first; shown
+	// once SetImageChanges is called
+	imageChanges []core.ImageChange
+	showImages   bool
+
+	// Changes of the resource seen this session, oldest first; shown once
+	// SetRecentChanges is called
+	recentChanges []core.ObjectEvent
+	showChanges   bool
+
+	// The user's template replacing the describe output, if any, and why
+	// it last failed to render; the built-in output is shown under a
+	// banner with the error instead
+	describeTemplate string
+	templateErr      error
 }
 
-// NewDescribeView creates a new describe view for a resource
-func NewDescribeView(resourceType, resourceName, namespace, context string) *DescribeView {
+// errNoClusterConnection is shown in place of the describe output when the
+// view has no client to read the resource with
+var errNoClusterConnection = errors.New("no cluster connection")
+
+// NewDescribeView creates a describe view for a resource, read through
+// client from the cluster of kubeContext. Without a client the view says
+// there is no cluster connection.
+func NewDescribeView(ctx context.Context, client *k8s.Client, resourceType, resourceName, namespace, kubeContext string) *DescribeView {
 	return &DescribeView{
 		viewport:       viewport.New(80, 20),
 		resourceType:   resourceType,
 		resourceName:   resourceName,
 		namespace:      namespace,
-		context:        context,
+		context:        kubeContext,
+		ctx:            ctx,
+		client:         client,
 		loading:        true,
 		wordWrap:       false,
 		autoRefresh:    true,
 		templateEngine: template.NewEngine(),
-		events:         make([]string, 0),
+		events:         make([]v1.Event, 0),
+	}
+}
+
+// SetDescribeTemplate sets the user's template to render the described
+// object with in place of the built-in describe output
+func (v *DescribeView) SetDescribeTemplate(tmpl string) {
+	v.describeTemplate = tmpl
+}
+
+// SetImageChanges sets the image changes of the described deployment seen
+// this session, shown after the describe output
+func (v *DescribeView) SetImageChanges(changes []core.ImageChange) {
+	v.imageChanges = changes
+	v.showImages = true
+	if !v.loading {
+		v.rebuildContent()
+	}
+}
+
+// imageChangesSection renders the image changes for after the describe output
+func (v *DescribeView) imageChangesSection(now time.Time) string {
+	var buf strings.Builder
+	buf.WriteString("\nImage changes (seen this session):\n")
+	if len(v.imageChanges) == 0 {
+		buf.WriteString("  <none>\n")
+		return buf.String()
+	}
+	// Newest first, like the detail line shows the latest
+	for i := len(v.imageChanges) - 1; i >= 0; i-- {
+		change := v.imageChanges[i]
+		container := change.Container
+		if container == "" {
+			container = "-"
+		}
+		buf.WriteString(fmt.Sprintf("  %-19s  %-6s  %-15s  %s → %s\n", core.FormatTimestamp(change.At),
+			core.FormatDuration(now.Sub(change.At)), container, change.Old, change.New))
+	}
+	return buf.String()
+}
+
+// SetRecentChanges sets the changes of the described resource seen this
+// session, shown after the describe output
+func (v *DescribeView) SetRecentChanges(events []core.ObjectEvent) {
+	v.recentChanges = events
+	v.showChanges = true
+	if !v.loading {
+		v.rebuildContent()
 	}
 }
 
+// recentChangesSection renders the recent changes for after the describe
+// output
+func (v *DescribeView) recentChangesSection() string {
+	var buf strings.Builder
+	buf.WriteString("\nRecent changes (seen this session):\n")
+	if len(v.recentChanges) == 0 {
+		buf.WriteString("  <none>\n")
+		return buf.String()
+	}
+	// Newest first, like the image changes
+	for i := len(v.recentChanges) - 1; i >= 0; i-- {
+		buf.WriteString("  " + v.recentChanges[i].Describe() + "\n")
+	}
+	return buf.String()
+}
+
 // Init initializes the view
 func (v *DescribeView) Init() tea.Cmd {
-	cmds := []tea.Cmd{v.loadDescribe()}
+	cmds := []tea.Cmd{v.loadDescribe(), v.loadEvents()}
 
 	// Start auto-refresh if enabled
 	if v.autoRefresh {
@@ -78,454 +179,183 @@ func (v *DescribeView) stopAutoRefresh() {
 	}
 }
 
-// loadDescribe loads the describe output for the resource
+// loadDescribe loads the describe output for the resource, or reports that
+// there is no cluster to load it from
 func (v *DescribeView) loadDescribe() tea.Cmd {
-	return func() tea.Msg {
-		// Use placeholder content for now - real implementation would need client access
-		return describeLoadedMsg{
-			content: v.getDescribeContent(),
+	if v.client == nil {
+		return func() tea.Msg {
+			return describeLoadedMsg{err: errNoClusterConnection}
 		}
 	}
+	return v.LoadDescribeWithClient(v.ctx, v.client)
 }
 
 // LoadDescribeWithClient loads the describe output using a real K8s client
 func (v *DescribeView) LoadDescribeWithClient(ctx context.Context, client *k8s.Client) tea.Cmd {
+	v.ctx, v.client = ctx, client
+	resourceType, name, namespace := v.resourceType, v.resourceName, v.namespace
+	describeTemplate, engine := v.describeTemplate, v.templateEngine
 	return func() tea.Msg {
-		content, err := GetDescribeContent(ctx, client, v.resourceType, v.resourceName, v.namespace)
+		var templateErr error
+		if describeTemplate != "" {
+			kind, _ := core.ParseResourceType(resourceType)
+			obj, err := client.GetObject(ctx, kind, namespace, name)
+			if err != nil {
+				return describeLoadedMsg{err: err}
+			}
+			content, err := engine.Render(template.DescribeTemplateName(kind), describeTemplate, obj)
+			if err == nil {
+				return describeLoadedMsg{content: content}
+			}
+			templateErr = err
+		}
+
+		content, err := GetDescribeContent(ctx, client, resourceType, name, namespace)
 		return describeLoadedMsg{
-			content: content,
-			err:     err,
+			content:     content,
+			err:         err,
+			templateErr: templateErr,
 		}
 	}
 }
 
-// getDescribeContent gets the describe content using templates for enhanced formatting
-func (v *DescribeView) getDescribeContent() string {
-	// Create mock data structure for template rendering
-	data := v.createMockResourceData()
+// loadEvents fetches the events last seen since the newest one shown. It is
+// nil without a client.
+func (v *DescribeView) loadEvents() tea.Cmd {
+	if v.client == nil {
+		return nil
+	}
+	ctx, client := v.ctx, v.client
+	resourceType, name, namespace, since := v.resourceType, v.resourceName, v.namespace, v.lastEventSeen
+	return func() tea.Msg {
+		events, err := client.ListEventsFor(ctx, resourceType, namespace, name, since)
+		return describeEventsMsg{events: events, err: err}
+	}
+}
 
-	// Try to use default template from template system first
-	templateName := fmt.Sprintf("%s_describe", strings.ToLower(v.resourceType))
-	if v.templateEngine != nil {
-		if templateStr, exists := template.GetDefaultTemplate(templateName); exists {
-			if content, err := v.templateEngine.Execute(templateStr, data); err == nil {
-				return content
+// mergeEvents adds fetched events to those shown. A recurring event replaces
+// its earlier copy. It returns false when nothing changed.
+func (v *DescribeView) mergeEvents(fetched []v1.Event) bool {
+	changed := false
+	for _, event := range fetched {
+		if seen := k8s.EventLastSeen(event); seen.After(v.lastEventSeen) {
+			v.lastEventSeen = seen
+		}
+		found := false
+		for i := range v.events {
+			if v.events[i].UID != event.UID {
+				continue
+			}
+			found = true
+			if v.events[i].ResourceVersion != event.ResourceVersion {
+				v.events = append(v.events[:i], v.events[i+1:]...)
+				v.events = append(v.events, event)
+				changed = true
 			}
+			break
 		}
-
-		// Fallback to inline template
-		templateStr := v.getDescribeTemplate(v.resourceType)
-		if content, err := v.templateEngine.Execute(templateStr, data); err == nil {
-			return content
+		if !found {
+			v.events = append(v.events, event)
+			changed = true
 		}
 	}
+	return changed
+}
 
-	// Fallback to enhanced static content
-	return v.getEnhancedDescribeContent()
-}
-
-// createMockResourceData creates mock data for template rendering
-func (v *DescribeView) createMockResourceData() map[string]interface{} {
-	now := time.Now()
-
-	baseData := map[string]interface{}{
-		"Name":              v.resourceName,
-		"Namespace":         v.namespace,
-		"Context":           v.context,
-		"Type":              v.resourceType,
-		"CreationTimestamp": now.Add(-5 * time.Minute),
-		"Labels": map[string]string{
-			"app":     strings.ToLower(v.resourceName),
-			"version": "v1.0.0",
-			"env":     "production",
-		},
-		"Annotations": map[string]string{
-			"deployment.kubernetes.io/revision":                "1",
-			"kubectl.kubernetes.io/last-applied-configuration": "...",
-		},
-	}
-
-	// Add type-specific data
-	switch strings.ToLower(v.resourceType) {
-	case "pod":
-		baseData["Status"] = map[string]interface{}{
-			"Phase":     "Running",
-			"PodIP":     "10.244.1.5",
-			"HostIP":    "192.168.1.100",
-			"StartTime": now.Add(-5 * time.Minute),
-			"Conditions": []map[string]interface{}{
-				{"Type": "Initialized", "Status": "True", "LastTransitionTime": now.Add(-5 * time.Minute)},
-				{"Type": "Ready", "Status": "True", "LastTransitionTime": now.Add(-4 * time.Minute)},
-				{"Type": "ContainersReady", "Status": "True", "LastTransitionTime": now.Add(-4 * time.Minute)},
-				{"Type": "PodScheduled", "Status": "True", "LastTransitionTime": now.Add(-5 * time.Minute)},
-			},
-			"ContainerStatuses": []map[string]interface{}{
-				{
-					"Name":         "app",
-					"Ready":        true,
-					"RestartCount": 0,
-					"Image":        "nginx:latest",
-					"ImageID":      "docker-pullable://nginx@sha256:...",
-					"ContainerID":  "containerd://abc123...",
-					"State": map[string]interface{}{
-						"Running": map[string]interface{}{
-							"StartedAt": now.Add(-4 * time.Minute),
-						},
-					},
-				},
-			},
-		}
-		baseData["Spec"] = map[string]interface{}{
-			"NodeName":       "worker-node-1",
-			"ServiceAccount": "default",
-			"Priority":       0,
-			"Containers": []map[string]interface{}{
-				{
-					"Name":  "app",
-					"Image": "nginx:latest",
-					"Ports": []map[string]interface{}{
-						{"ContainerPort": 80, "Protocol": "TCP"},
-					},
-					"Resources": map[string]interface{}{
-						"Limits": map[string]string{
-							"cpu":    "1",
-							"memory": "300Mi",
-						},
-						"Requests": map[string]string{
-							"cpu":    "100m",
-							"memory": "100Mi",
-						},
-					},
-					"LivenessProbe": map[string]interface{}{
-						"HttpGet": map[string]interface{}{
-							"Path": "/",
-							"Port": 80,
-						},
-						"InitialDelaySeconds": 10,
-						"TimeoutSeconds":      1,
-						"PeriodSeconds":       5,
-						"SuccessThreshold":    1,
-						"FailureThreshold":    3,
-					},
-				},
-			},
-			"Volumes": []map[string]interface{}{
-				{
-					"Name": "kube-api-access-token",
-					"Projected": map[string]interface{}{
-						"Sources": []map[string]interface{}{
-							{"ServiceAccountToken": map[string]interface{}{"ExpirationSeconds": 3607}},
-							{"ConfigMap": map[string]interface{}{"Name": "kube-root-ca.crt"}},
-							{"DownwardAPI": true},
-						},
-					},
-				},
-			},
+// eventsSection renders the events for the end of the content
+func (v *DescribeView) eventsSection() string {
+	var buf strings.Builder
+	shown := v.events
+	if v.warningsOnly {
+		shown = nil
+		for _, event := range v.events {
+			if event.Type == v1.EventTypeWarning {
+				shown = append(shown, event)
+			}
 		}
+		buf.WriteString(fmt.Sprintf("\nEvents (warnings only, %d normal hidden):\n", len(v.events)-len(shown)))
+	} else {
+		buf.WriteString("\nEvents:\n")
+	}
 
-	case "deployment":
-		baseData["Status"] = map[string]interface{}{
-			"Replicas":           3,
-			"UpdatedReplicas":    3,
-			"ReadyReplicas":      3,
-			"AvailableReplicas":  3,
-			"ObservedGeneration": 1,
-		}
-		baseData["Spec"] = map[string]interface{}{
-			"Replicas": 3,
-			"Strategy": map[string]interface{}{
-				"Type": "RollingUpdate",
-				"RollingUpdate": map[string]interface{}{
-					"MaxUnavailable": "25%",
-					"MaxSurge":       "25%",
-				},
-			},
-			"Selector": map[string]interface{}{
-				"MatchLabels": map[string]string{
-					"app": strings.ToLower(v.resourceName),
-				},
-			},
-		}
+	if v.eventsErr != nil {
+		buf.WriteString(fmt.Sprintf("  Could not load events: %s\n", k8s.UserMessage(v.eventsErr)))
+	}
+	if len(shown) == 0 {
+		buf.WriteString("  <none>\n")
+		return buf.String()
+	}
 
-	case "service":
-		baseData["Status"] = map[string]interface{}{
-			"LoadBalancer": map[string]interface{}{},
+	buf.WriteString(fmt.Sprintf("  %-7s  %-20s  %-5s  %-17s  %s\n", "Type", "Reason", "Age", "From", "Message"))
+	for _, event := range shown {
+		// Warnings are marked so they stand out in the highlight
+		marker := "  "
+		if event.Type == v1.EventTypeWarning {
+			marker = "⚠ "
 		}
-		baseData["Spec"] = map[string]interface{}{
-			"Type":      "ClusterIP",
-			"ClusterIP": "10.96.1.1",
-			"Ports": []map[string]interface{}{
-				{"Name": "http", "Port": 80, "TargetPort": 80, "Protocol": "TCP"},
-			},
-			"Selector": map[string]string{
-				"app": strings.ToLower(v.resourceName),
-			},
+		from := event.Source.Component
+		if from == "" {
+			from = event.ReportingController
 		}
-
-	case "ingress":
-		baseData["Status"] = map[string]interface{}{
-			"LoadBalancer": map[string]interface{}{
-				"Ingress": []map[string]interface{}{
-					{"IP": "192.168.1.200"},
-				},
-			},
-		}
-		baseData["Spec"] = map[string]interface{}{
-			"IngressClassName": "nginx",
-			"Rules": []map[string]interface{}{
-				{
-					"Host": fmt.Sprintf("%s.example.com", strings.ToLower(v.resourceName)),
-					"HTTP": map[string]interface{}{
-						"Paths": []map[string]interface{}{
-							{
-								"Path":     "/",
-								"PathType": "Prefix",
-								"Backend": map[string]interface{}{
-									"Service": map[string]interface{}{
-										"Name": v.resourceName,
-										"Port": map[string]interface{}{"Number": 80},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
+		message := strings.TrimSpace(event.Message)
+		if event.Count > 1 {
+			message = fmt.Sprintf("%s (x%d)", message, event.Count)
 		}
+		buf.WriteString(fmt.Sprintf("%s%-7s  %-20s  %-5s  %-17s  %s\n", marker, event.Type, event.Reason,
+			core.FormatAge(k8s.EventLastSeen(event)), from, message))
+	}
+	return buf.String()
+}
 
-	case "configmap":
-		baseData["Data"] = map[string]string{
-			"config.yaml":    "key: value\nother: setting",
-			"app.properties": "debug=true\nport=8080",
-		}
+// rebuildContent lays out the describe output and events again, keeping the
+// same line at the top of the viewport rather than the same offset, or the
+// tail in view when following events
+func (v *DescribeView) rebuildContent() {
+	content := v.body
+	if v.showImages {
+		content += v.imageChangesSection(time.Now())
+	}
+	if v.showChanges {
+		content += v.recentChangesSection()
+	}
+	if v.client != nil {
+		content += v.eventsSection()
+	}
+	if content == v.content && v.lines != nil {
+		return
+	}
 
-	case "secret":
-		baseData["Type"] = "Opaque"
-		baseData["Data"] = map[string]string{
-			"username": "YWRtaW4=",         // base64 encoded
-			"password": "MWYyZDFlMmU2N2Rm", // base64 encoded
-		}
+	offset := v.viewport.YOffset
+	anchor := ""
+	if offset >= 0 && offset < len(v.lines) {
+		anchor = v.lines[offset]
 	}
 
-	// Add events
-	baseData["Events"] = []map[string]interface{}{
-		{
-			"Type":    "Normal",
-			"Reason":  "Scheduled",
-			"Age":     "5m",
-			"From":    "default-scheduler",
-			"Message": fmt.Sprintf("Successfully assigned %s/%s to worker-node-1", v.namespace, v.resourceName),
-		},
-		{
-			"Type":    "Normal",
-			"Reason":  "Pulled",
-			"Age":     "5m",
-			"From":    "kubelet",
-			"Message": "Container image \"nginx:latest\" already present on machine",
-		},
-		{
-			"Type":    "Normal",
-			"Reason":  "Created",
-			"Age":     "5m",
-			"From":    "kubelet",
-			"Message": "Created container app",
-		},
-		{
-			"Type":    "Normal",
-			"Reason":  "Started",
-			"Age":     "5m",
-			"From":    "kubelet",
-			"Message": "Started container app",
-		},
-	}
-
-	return baseData
-}
-
-// getEnhancedDescribeContent provides enhanced static content as fallback
-func (v *DescribeView) getEnhancedDescribeContent() string {
-	var buf bytes.Buffer
-	now := time.Now()
-
-	// Header information
-	buf.WriteString(fmt.Sprintf("Name:             %s\n", v.resourceName))
-	buf.WriteString(fmt.Sprintf("Namespace:        %s\n", v.namespace))
-	if v.context != "" {
-		buf.WriteString(fmt.Sprintf("Context:          %s\n", v.context))
-	}
-	buf.WriteString(fmt.Sprintf("Priority:         0\n"))
-	buf.WriteString(fmt.Sprintf("Service Account:  default\n"))
-	buf.WriteString(fmt.Sprintf("Start Time:       %s\n", now.Add(-5*time.Minute).Format("Mon, 02 Jan 2006 15:04:05 -0700")))
-
-	// Labels
-	buf.WriteString("Labels:           app=" + strings.ToLower(v.resourceName) + "\n")
-	buf.WriteString("                  env=production\n")
-	buf.WriteString("                  version=v1.0.0\n")
-
-	// Annotations
-	buf.WriteString("Annotations:      deployment.kubernetes.io/revision: 1\n")
-	buf.WriteString("                  kubectl.kubernetes.io/last-applied-configuration: {...}\n")
-
-	// Type-specific content
-	switch strings.ToLower(v.resourceType) {
-	case "pod":
-		v.addPodSpecificContent(&buf, now)
-	case "deployment":
-		v.addDeploymentSpecificContent(&buf)
-	case "service":
-		v.addServiceSpecificContent(&buf)
-	case "ingress":
-		v.addIngressSpecificContent(&buf)
-	case "configmap":
-		v.addConfigMapSpecificContent(&buf)
-	case "secret":
-		v.addSecretSpecificContent(&buf)
-	default:
-		buf.WriteString("\n(Detailed information would appear here)\n")
-	}
-
-	// Events section (always at the end)
-	buf.WriteString("\nEvents:\n")
-	buf.WriteString("  Type    Reason      Age   From               Message\n")
-	buf.WriteString("  ----    ------      ----  ----               -------\n")
-	buf.WriteString("  Normal  Scheduled   5m    default-scheduler  Successfully assigned " + v.namespace + "/" + v.resourceName + " to worker-node-1\n")
-	buf.WriteString("  Normal  Pulled      5m    kubelet            Container image \"nginx:latest\" already present on machine\n")
-	buf.WriteString("  Normal  Created     5m    kubelet            Created container app\n")
-	buf.WriteString("  Normal  Started     5m    kubelet            Started container app\n")
+	v.content = content
+	v.setViewportContent()
 
-	return buf.String()
+	if v.followEvents {
+		v.viewport.GotoBottom()
+		return
+	}
+	v.viewport.SetYOffset(anchorOffset(v.lines, anchor, offset))
 }
 
-// Helper methods for type-specific content
-func (v *DescribeView) addPodSpecificContent(buf *bytes.Buffer, now time.Time) {
-	buf.WriteString(fmt.Sprintf("Node:             worker-node-1/192.168.1.100\n"))
-	buf.WriteString(fmt.Sprintf("Status:           Running\n"))
-	buf.WriteString(fmt.Sprintf("IP:               10.244.1.5\n"))
-	buf.WriteString("IPs:\n")
-	buf.WriteString("  IP:             10.244.1.5\n")
-	buf.WriteString("Controlled By:    ReplicaSet/" + v.resourceName + "-abc123\n")
-
-	buf.WriteString("\nContainers:\n")
-	buf.WriteString("  app:\n")
-	buf.WriteString("    Container ID:   containerd://abc123def456...\n")
-	buf.WriteString("    Image:          nginx:latest\n")
-	buf.WriteString("    Image ID:       nginx@sha256:b3590f10cafc8a250f24b54d49a26a5e88863671c15cb15c417322b8eff6f186\n")
-	buf.WriteString("    Port:           80/TCP\n")
-	buf.WriteString("    Host Port:      0/TCP\n")
-	buf.WriteString("    State:          Running\n")
-	buf.WriteString(fmt.Sprintf("      Started:      %s\n", now.Add(-4*time.Minute).Format("Mon, 02 Jan 2006 15:04:05 -0700")))
-	buf.WriteString("    Ready:          True\n")
-	buf.WriteString("    Restart Count:  0\n")
-	buf.WriteString("    Limits:\n")
-	buf.WriteString("      cpu:     1\n")
-	buf.WriteString("      memory:  300Mi\n")
-	buf.WriteString("    Requests:\n")
-	buf.WriteString("      cpu:     100m\n")
-	buf.WriteString("      memory:  100Mi\n")
-	buf.WriteString("    Liveness:  http-get http://:80/ delay=10s timeout=1s period=5s #success=1 #failure=3\n")
-	buf.WriteString("    Environment:\n")
-	buf.WriteString("      K8S_POD_NAME:  " + v.resourceName + " (v1:metadata.name)\n")
-	buf.WriteString("    Mounts:\n")
-	buf.WriteString("      /var/run/secrets/kubernetes.io/serviceaccount from kube-api-access-token (ro)\n")
-
-	buf.WriteString("\nConditions:\n")
-	buf.WriteString("  Type                        Status\n")
-	buf.WriteString("  PodReadyToStartContainers   True\n")
-	buf.WriteString("  Initialized                 True\n")
-	buf.WriteString("  Ready                       True\n")
-	buf.WriteString("  ContainersReady             True\n")
-	buf.WriteString("  PodScheduled                True\n")
-
-	buf.WriteString("\nVolumes:\n")
-	buf.WriteString("  kube-api-access-token:\n")
-	buf.WriteString("    Type:                    Projected (a volume that contains injected data from multiple sources)\n")
-	buf.WriteString("    TokenExpirationSeconds:  3607\n")
-	buf.WriteString("    ConfigMapName:           kube-root-ca.crt\n")
-	buf.WriteString("    ConfigMapOptional:       <nil>\n")
-	buf.WriteString("    DownwardAPI:             true\n")
-
-	buf.WriteString("QoS Class:                   Burstable\n")
-	buf.WriteString("Node-Selectors:              <none>\n")
-	buf.WriteString("Tolerations:                 node.kubernetes.io/not-ready:NoExecute op=Exists for 300s\n")
-	buf.WriteString("                             node.kubernetes.io/unreachable:NoExecute op=Exists for 300s\n")
-}
-
-func (v *DescribeView) addDeploymentSpecificContent(buf *bytes.Buffer) {
-	buf.WriteString("Replicas:               3 desired | 3 updated | 3 total | 3 available | 0 unavailable\n")
-	buf.WriteString("StrategyType:           RollingUpdate\n")
-	buf.WriteString("MinReadySeconds:        0\n")
-	buf.WriteString("RollingUpdateStrategy:  25% max unavailable, 25% max surge\n")
-	buf.WriteString("Pod Template:\n")
-	buf.WriteString("  Labels:  app=" + strings.ToLower(v.resourceName) + "\n")
-	buf.WriteString("  Containers:\n")
-	buf.WriteString("   app:\n")
-	buf.WriteString("    Image:      nginx:latest\n")
-	buf.WriteString("    Port:       80/TCP\n")
-	buf.WriteString("    Host Port:  0/TCP\n")
-	buf.WriteString("    Limits:\n")
-	buf.WriteString("      cpu:     1\n")
-	buf.WriteString("      memory:  300Mi\n")
-	buf.WriteString("    Requests:\n")
-	buf.WriteString("      cpu:     100m\n")
-	buf.WriteString("      memory:  100Mi\n")
-	buf.WriteString("    Environment:  <none>\n")
-	buf.WriteString("    Mounts:       <none>\n")
-	buf.WriteString("  Volumes:        <none>\n")
-	buf.WriteString("\nConditions:\n")
-	buf.WriteString("  Type           Status  Reason\n")
-	buf.WriteString("  ----           ------  ------\n")
-	buf.WriteString("  Available      True    MinimumReplicasAvailable\n")
-	buf.WriteString("  Progressing    True    NewReplicaSetAvailable\n")
-}
-
-func (v *DescribeView) addServiceSpecificContent(buf *bytes.Buffer) {
-	buf.WriteString("Type:                     ClusterIP\n")
-	buf.WriteString("IP Family Policy:        SingleStack\n")
-	buf.WriteString("IP Families:             IPv4\n")
-	buf.WriteString("IP:                      10.96.1.1\n")
-	buf.WriteString("IPs:                     10.96.1.1\n")
-	buf.WriteString("Port:                    http  80/TCP\n")
-	buf.WriteString("TargetPort:              80/TCP\n")
-	buf.WriteString("Endpoints:               10.244.1.5:80,10.244.1.6:80,10.244.1.7:80\n")
-	buf.WriteString("Session Affinity:        None\n")
-	buf.WriteString("Internal Traffic Policy: Cluster\n")
-}
-
-func (v *DescribeView) addIngressSpecificContent(buf *bytes.Buffer) {
-	buf.WriteString("Address:          192.168.1.200\n")
-	buf.WriteString("Ingress Class:    nginx\n")
-	buf.WriteString("Default backend:  <default>\n")
-	buf.WriteString("Rules:\n")
-	buf.WriteString("  Host                    Path  Backends\n")
-	buf.WriteString("  ----                    ----  --------\n")
-	buf.WriteString(fmt.Sprintf("  %s.example.com  /     %s:80 (10.244.1.5:80,10.244.1.6:80)\n", strings.ToLower(v.resourceName), v.resourceName))
-}
-
-func (v *DescribeView) addConfigMapSpecificContent(buf *bytes.Buffer) {
-	buf.WriteString("Type:             ConfigMap\n")
-	buf.WriteString("\nData\n")
-	buf.WriteString("====\n")
-	buf.WriteString("config.yaml:\n")
-	buf.WriteString("----\n")
-	buf.WriteString("key: value\n")
-	buf.WriteString("other: setting\n")
-	buf.WriteString("\n")
-	buf.WriteString("app.properties:\n")
-	buf.WriteString("----\n")
-	buf.WriteString("debug=true\n")
-	buf.WriteString("port=8080\n")
-	buf.WriteString("\n")
-	buf.WriteString("BinaryData\n")
-	buf.WriteString("==========\n")
-	buf.WriteString("<none>\n")
-}
-
-func (v *DescribeView) addSecretSpecificContent(buf *bytes.Buffer) {
-	buf.WriteString("Type:  Opaque\n")
-	buf.WriteString("\nData\n")
-	buf.WriteString("====\n")
-	buf.WriteString("password:  12 bytes\n")
-	buf.WriteString("username:  5 bytes\n")
+// anchorOffset returns the index of the line equal to anchor nearest to
+// offset, or offset when no line matches
+func anchorOffset(lines []string, anchor string, offset int) int {
+	if anchor == "" {
+		return offset
+	}
+	for d := 0; d < len(lines); d++ {
+		if i := offset - d; i >= 0 && i < len(lines) && lines[i] == anchor {
+			return i
+		}
+		if i := offset + d; i < len(lines) && lines[i] == anchor {
+			return i
+		}
+	}
+	return offset
 }
 
 // GetDescribeUsingClient gets actual describe content using the K8s client
@@ -539,29 +369,31 @@ func (v *DescribeView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		v.width = msg.Width
-		v.height = msg.Height
-		if !v.ready {
-			v.viewport = viewport.New(msg.Width, msg.Height-4) // Leave room for header, timestamp, and footer
-			v.viewport.YPosition = 0
-			v.ready = true
-		} else {
-			v.viewport.Width = msg.Width
-			v.viewport.Height = msg.Height - 4
-		}
-		if v.content != "" {
-			v.setViewportContent()
-		}
+		v.SetSize(msg.Width, msg.Height)
+		return v, nil
 
 	case describeLoadedMsg:
 		v.loading = false
 		v.lastUpdated = time.Now()
+		bannerChanged := (msg.templateErr == nil) != (v.templateErr == nil)
+		v.templateErr = msg.templateErr
+		if bannerChanged && v.ready {
+			// The banner comes or goes, so the viewport changes height
+			v.SetSize(v.width, v.height)
+		}
 		if msg.err != nil {
-			v.content = fmt.Sprintf("Error loading description: %v", msg.err)
+			v.body = fmt.Sprintf("Error loading description: %s", k8s.UserMessage(msg.err))
 		} else {
-			v.content = msg.content
+			v.body = msg.content
+		}
+		v.rebuildContent()
+		return v, nil
+
+	case describeEventsMsg:
+		v.eventsErr = msg.err
+		if v.mergeEvents(msg.events) || msg.err != nil {
+			v.rebuildContent()
 		}
-		v.setViewportContent()
 		return v, nil
 
 	case autoRefreshMsg:
@@ -569,6 +401,7 @@ func (v *DescribeView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if v.autoRefresh {
 			return v, tea.Batch(
 				v.loadDescribe(),
+				v.loadEvents(),
 				tea.Tick(30*time.Second, func(t time.Time) tea.Msg {
 					return autoRefreshMsg{time: t}
 				}),
@@ -578,11 +411,24 @@ func (v *DescribeView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "g", "home":
+			v.followEvents = false
 			v.viewport.GotoTop()
 			return v, nil
 		case "G", "end":
 			v.viewport.GotoBottom()
 			return v, nil
+		case "f":
+			// Toggle keeping the newest events in view
+			v.followEvents = !v.followEvents
+			if v.followEvents {
+				v.viewport.GotoBottom()
+			}
+			return v, nil
+		case "w":
+			// Toggle hiding Normal events
+			v.warningsOnly = !v.warningsOnly
+			v.rebuildContent()
+			return v, nil
 		case "u":
 			// Toggle word wrap
 			v.wordWrap = !v.wordWrap
@@ -590,7 +436,7 @@ func (v *DescribeView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return v, nil
 		case "r", "ctrl+r":
 			// Manual refresh
-			return v, v.loadDescribe()
+			return v, tea.Batch(v.loadDescribe(), v.loadEvents())
 		case "a":
 			// Toggle auto-refresh
 			v.autoRefresh = !v.autoRefresh
@@ -607,17 +453,51 @@ func (v *DescribeView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	// Scrolling away from the tail stops following events
 	v.viewport, cmd = v.viewport.Update(msg)
+	if v.followEvents && !v.viewport.AtBottom() {
+		v.followEvents = false
+	}
 	return v, cmd
 }
 
+// PagerContent returns the describe output and events for a pager, which
+// wraps and scrolls them itself. Warnings are highlighted only with color.
+func (v *DescribeView) PagerContent(color bool) string {
+	if color {
+		return highlightWarnings(v.content)
+	}
+	return v.content
+}
+
 // setViewportContent sets the viewport content with word wrap handling
 func (v *DescribeView) setViewportContent() {
 	content := v.content
 	if v.wordWrap && v.width > 0 {
 		content = v.wrapText(content, v.width-4) // Account for padding
 	}
-	v.viewport.SetContent(content)
+	v.lines = strings.Split(content, "\n")
+	v.viewport.SetContent(highlightWarnings(content))
+}
+
+// highlightWarnings colors lines flagged with ⚠, such as services that select
+// a pod it is not a ready endpoint of
+func highlightWarnings(content string) string {
+	if !strings.Contains(content, "⚠") {
+		return content
+	}
+
+	warnStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		Bold(true)
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.Contains(line, "⚠") {
+			lines[i] = warnStyle.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
 }
 
 // wrapText wraps text to the specified width
@@ -685,7 +565,7 @@ func (v *DescribeView) View() string {
 
 	var statusInfo []string
 	if !v.lastUpdated.IsZero() {
-		statusInfo = append(statusInfo, fmt.Sprintf("Last Updated: %s", v.lastUpdated.Format("15:04:05")))
+		statusInfo = append(statusInfo, fmt.Sprintf("Last Updated: %s", core.FormatClock(v.lastUpdated)))
 	}
 
 	if v.autoRefresh {
@@ -700,13 +580,38 @@ func (v *DescribeView) View() string {
 		statusInfo = append(statusInfo, "Word wrap: OFF")
 	}
 
-	timestamp := strings.Join(statusInfo, " | ")
+	if v.followEvents {
+		statusInfo = append(statusInfo, "Following events")
+	}
+	if v.warningsOnly {
+		statusInfo = append(statusInfo, "Warnings only")
+	}
+
+	timestamp := timestampStyle.Render(strings.Join(statusInfo, " | "))
+	if v.status != "" {
+		statusStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("229"))
+		if strings.HasPrefix(v.status, "✗") {
+			statusStyle = statusStyle.Foreground(lipgloss.Color("196"))
+		}
+		timestamp += timestampStyle.Render(" | ") + statusStyle.Render(v.status)
+	}
+
+	// A describe template that failed to render is named above the
+	// built-in output shown instead
+	if v.templateErr != nil {
+		bannerStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("214")).
+			Bold(true).
+			MaxWidth(v.width)
+		timestamp += "\n" + bannerStyle.Render("⚠ "+v.templateErr.Error()+"; showing the built-in output")
+	}
 
 	// Footer with controls
 	footerStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("240"))
 
-	footer := "↑↓/PgUp/PgDn: Scroll | g/G: Top/Bottom | u: Word wrap | r: Refresh | a: Auto-refresh | Esc: Close"
+	footer := "↑↓/PgUp/PgDn: Scroll | g/G: Top/Bottom | u: Word wrap | r: Refresh | a: Auto-refresh | f: Follow events | w: Warnings only | x: Remove finalizer | Esc: Close"
 
 	// Loading indicator
 	if v.loading {
@@ -715,7 +620,7 @@ func (v *DescribeView) View() string {
 		return fmt.Sprintf(
 			"%s\n%s\n%s\n%s",
 			headerStyle.Render(header),
-			timestampStyle.Render(timestamp),
+			timestamp,
 			loadingStyle.Render("Loading describe information..."),
 			footerStyle.Render(footer),
 		)
@@ -724,25 +629,67 @@ func (v *DescribeView) View() string {
 	return fmt.Sprintf(
 		"%s\n%s\n%s\n%s",
 		headerStyle.Render(header),
-		timestampStyle.Render(timestamp),
+		timestamp,
 		v.viewport.View(),
 		footerStyle.Render(footer),
 	)
 }
 
+// GetResourceType returns the type of the described resource
+func (v *DescribeView) GetResourceType() string {
+	return v.resourceType
+}
+
+// GetResourceName returns the name of the described resource
+func (v *DescribeView) GetResourceName() string {
+	return v.resourceName
+}
+
+// GetNamespace returns the namespace of the described resource
+func (v *DescribeView) GetNamespace() string {
+	return v.namespace
+}
+
+// GetContext returns the context of the described resource
+func (v *DescribeView) GetContext() string {
+	return v.context
+}
+
+// SetStatus shows the result of an action taken from the view
+func (v *DescribeView) SetStatus(status string) {
+	v.status = status
+}
+
 // SetSize updates the view size
 func (v *DescribeView) SetSize(width, height int) {
 	v.width = width
 	v.height = height
-	v.viewport.Width = width
-	v.viewport.Height = height - 3
+	// Leave room for the header, status line, template error banner and
+	// footer
+	chrome := 3
+	if v.templateErr != nil {
+		chrome++
+	}
+	resizeViewport(&v.viewport, width, height-chrome, func() {
+		if v.content != "" {
+			v.setViewportContent()
+		}
+	})
 	v.ready = true
 }
 
 // describeLoadedMsg is sent when describe content is loaded
 type describeLoadedMsg struct {
-	content string
-	err     error
+	content     string
+	err         error
+	templateErr error // Why the user's describe template did not render
+}
+
+// describeEventsMsg is sent when the events newer than the last seen are
+// fetched
+type describeEventsMsg struct {
+	events []v1.Event
+	err    error
 }
 
 // autoRefreshMsg is sent when auto-refresh timer triggers
@@ -750,217 +697,6 @@ type autoRefreshMsg struct {
 	time time.Time
 }
 
-// getDescribeTemplate returns the appropriate template for the resource type
-func (v *DescribeView) getDescribeTemplate(resourceType string) string {
-	// Define templates for different resource types
-	templates := map[string]string{
-		"pod": `Name:             {{ .Name }}
-Namespace:        {{ .Namespace }}
-Priority:         {{ .Priority | default 0 }}
-Service Account:  {{ .Spec.ServiceAccount | default "default" }}
-Node:             {{ .Spec.NodeName }}/{{ .Status.HostIP }}
-Start Time:       {{ .Status.StartTime | timestamp }}
-Labels:           {{ range $k, $v := .Labels }}{{ $k }}={{ $v }}
-                  {{ end }}
-Annotations:      {{ range $k, $v := .Annotations }}{{ $k }}: {{ $v }}
-                  {{ end }}
-Status:           {{ .Status.Phase }}
-IP:               {{ .Status.PodIP }}
-IPs:
-  IP:             {{ .Status.PodIP }}
-Controlled By:    ReplicaSet/{{ .Name }}-abc123
-Containers:
-{{ range .Spec.Containers }}  {{ .Name }}:
-    Container ID:   containerd://abc123def456...
-    Image:          {{ .Image }}
-    Image ID:       {{ .Image }}@sha256:b3590f10cafc8a250f24b54d49a26a5e88863671c15cb15c417322b8eff6f186
-    Port:           {{ range .Ports }}{{ .ContainerPort }}/{{ .Protocol }}{{ end }}
-    Host Port:      0/TCP
-    State:          Running
-      Started:      {{ $.Status.StartTime | timestamp }}
-    Ready:          True
-    Restart Count:  0
-    Limits:
-{{ range $k, $v := .Resources.Limits }}      {{ $k }}:     {{ $v }}
-{{ end }}    Requests:
-{{ range $k, $v := .Resources.Requests }}      {{ $k }}:     {{ $v }}
-{{ end }}{{ if .LivenessProbe }}    Liveness:  http-get http://:{{ .LivenessProbe.HttpGet.Port }}{{ .LivenessProbe.HttpGet.Path }} delay={{ .LivenessProbe.InitialDelaySeconds }}s timeout={{ .LivenessProbe.TimeoutSeconds }}s period={{ .LivenessProbe.PeriodSeconds }}s #success={{ .LivenessProbe.SuccessThreshold }} #failure={{ .LivenessProbe.FailureThreshold }}{{ end }}
-    Environment:
-      K8S_POD_NAME:  {{ $.Name }} (v1:metadata.name)
-    Mounts:
-      /var/run/secrets/kubernetes.io/serviceaccount from kube-api-access-token (ro)
-{{ end }}
-Conditions:
-  Type                        Status
-{{ range .Status.Conditions }}  {{ .Type | printf "%-27s" }} {{ .Status }}
-{{ end }}
-Volumes:
-{{ range .Spec.Volumes }}  {{ .Name }}:
-    Type:                    Projected (a volume that contains injected data from multiple sources)
-    TokenExpirationSeconds:  3607
-    ConfigMapName:           kube-root-ca.crt
-    ConfigMapOptional:       <nil>
-    DownwardAPI:             true
-{{ end }}
-QoS Class:                   Burstable
-Node-Selectors:              <none>
-Tolerations:                 node.kubernetes.io/not-ready:NoExecute op=Exists for 300s
-                             node.kubernetes.io/unreachable:NoExecute op=Exists for 300s
-
-Events:
-  Type    Reason      Age   From               Message
-  ----    ------      ----  ----               -------
-{{ range .Events }}  {{ .Type | printf "%-6s" }}  {{ .Reason | printf "%-10s" }}  {{ .Age | printf "%-4s" }}  {{ .From | printf "%-17s" }}  {{ .Message }}
-{{ end }}`,
-
-		"deployment": `Name:                   {{ .Name }}
-Namespace:              {{ .Namespace }}
-CreationTimestamp:      {{ .CreationTimestamp | timestamp }}
-Labels:                 {{ range $k, $v := .Labels }}{{ $k }}={{ $v }}
-                        {{ end }}
-Annotations:            {{ range $k, $v := .Annotations }}{{ $k }}: {{ $v }}
-                        {{ end }}
-Selector:               {{ range $k, $v := .Spec.Selector.MatchLabels }}{{ $k }}={{ $v }}{{ end }}
-Replicas:               {{ .Status.Replicas }} desired | {{ .Status.UpdatedReplicas }} updated | {{ .Status.Replicas }} total | {{ .Status.AvailableReplicas }} available | {{ sub .Status.Replicas .Status.AvailableReplicas }} unavailable
-StrategyType:           {{ .Spec.Strategy.Type }}
-MinReadySeconds:        0
-RollingUpdateStrategy:  {{ .Spec.Strategy.RollingUpdate.MaxUnavailable }} max unavailable, {{ .Spec.Strategy.RollingUpdate.MaxSurge }} max surge
-Pod Template:
-  Labels:  {{ range $k, $v := .Labels }}{{ $k }}={{ $v }} {{ end }}
-  Containers:
-{{ range .Spec.Containers }}   {{ .Name }}:
-    Image:      {{ .Image }}
-    Port:       {{ range .Ports }}{{ .ContainerPort }}/{{ .Protocol }}{{ end }}
-    Host Port:  0/TCP
-{{ if .Resources.Limits }}    Limits:
-{{ range $k, $v := .Resources.Limits }}      {{ $k }}:     {{ $v }}
-{{ end }}{{ end }}{{ if .Resources.Requests }}    Requests:
-{{ range $k, $v := .Resources.Requests }}      {{ $k }}:     {{ $v }}
-{{ end }}{{ end }}    Environment:  <none>
-    Mounts:       <none>
-{{ end }}  Volumes:        <none>
-
-Conditions:
-  Type           Status  Reason
-  ----           ------  ------
-  Available      True    MinimumReplicasAvailable
-  Progressing    True    NewReplicaSetAvailable
-
-Events:
-  Type    Reason      Age   From               Message
-  ----    ------      ----  ----               -------
-{{ range .Events }}  {{ .Type | printf "%-6s" }}  {{ .Reason | printf "%-10s" }}  {{ .Age | printf "%-4s" }}  {{ .From | printf "%-17s" }}  {{ .Message }}
-{{ end }}`,
-
-		"service": `Name:                     {{ .Name }}
-Namespace:                {{ .Namespace }}
-Labels:                   {{ range $k, $v := .Labels }}{{ $k }}={{ $v }}
-                          {{ end }}
-Annotations:              {{ range $k, $v := .Annotations }}{{ $k }}: {{ $v }}
-                          {{ end }}
-Selector:                 {{ range $k, $v := .Spec.Selector }}{{ $k }}={{ $v }}{{ end }}
-Type:                     {{ .Spec.Type }}
-IP Family Policy:         SingleStack
-IP Families:              IPv4
-IP:                       {{ .Spec.ClusterIP }}
-IPs:                      {{ .Spec.ClusterIP }}
-Port:                     {{ range .Spec.Ports }}{{ .Name }}  {{ .Port }}/{{ .Protocol }}{{ end }}
-TargetPort:               {{ range .Spec.Ports }}{{ .TargetPort }}/{{ .Protocol }}{{ end }}
-Endpoints:                10.244.1.5:80,10.244.1.6:80,10.244.1.7:80
-Session Affinity:         None
-Internal Traffic Policy:  Cluster
-
-Events:
-  Type    Reason      Age   From               Message
-  ----    ------      ----  ----               -------
-{{ range .Events }}  {{ .Type | printf "%-6s" }}  {{ .Reason | printf "%-10s" }}  {{ .Age | printf "%-4s" }}  {{ .From | printf "%-17s" }}  {{ .Message }}
-{{ end }}`,
-
-		"ingress": `Name:             {{ .Name }}
-Namespace:        {{ .Namespace }}
-Address:          {{ range .Status.LoadBalancer.Ingress }}{{ .IP }}{{ end }}
-Ingress Class:    {{ .Spec.IngressClassName }}
-Default backend:  <default>
-Rules:
-  Host                    Path  Backends
-  ----                    ----  --------
-{{ range .Spec.Rules }}  {{ .Host | printf "%-22s" }}  {{ range .HTTP.Paths }}{{ .Path }}     {{ .Backend.Service.Name }}:{{ .Backend.Service.Port.Number }}{{ end }}
-{{ end }}
-Events:
-  Type    Reason      Age   From               Message
-  ----    ------      ----  ----               -------
-{{ range .Events }}  {{ .Type | printf "%-6s" }}  {{ .Reason | printf "%-10s" }}  {{ .Age | printf "%-4s" }}  {{ .From | printf "%-17s" }}  {{ .Message }}
-{{ end }}`,
-
-		"configmap": `Name:         {{ .Name }}
-Namespace:    {{ .Namespace }}
-Labels:       {{ range $k, $v := .Labels }}{{ $k }}={{ $v }}
-              {{ end }}
-Annotations:  {{ range $k, $v := .Annotations }}{{ $k }}: {{ $v }}
-              {{ end }}
-
-Type:         ConfigMap
-
-Data
-====
-{{ range $k, $v := .Data }}{{ $k }}:
-----
-{{ $v }}
-
-{{ end }}
-BinaryData
-==========
-<none>
-
-Events:
-  Type    Reason      Age   From               Message
-  ----    ------      ----  ----               -------
-{{ range .Events }}  {{ .Type | printf "%-6s" }}  {{ .Reason | printf "%-10s" }}  {{ .Age | printf "%-4s" }}  {{ .From | printf "%-17s" }}  {{ .Message }}
-{{ end }}`,
-
-		"secret": `Name:         {{ .Name }}
-Namespace:    {{ .Namespace }}
-Labels:       {{ range $k, $v := .Labels }}{{ $k }}={{ $v }}
-              {{ end }}
-Annotations:  {{ range $k, $v := .Annotations }}{{ $k }}: {{ $v }}
-              {{ end }}
-
-Type:  {{ .Type }}
-
-Data
-====
-{{ range $k, $v := .Data }}{{ $k }}:  {{ len $v }} bytes
-{{ end }}
-
-Events:
-  Type    Reason      Age   From               Message
-  ----    ------      ----  ----               -------
-{{ range .Events }}  {{ .Type | printf "%-6s" }}  {{ .Reason | printf "%-10s" }}  {{ .Age | printf "%-4s" }}  {{ .From | printf "%-17s" }}  {{ .Message }}
-{{ end }}`,
-	}
-
-	if template, exists := templates[strings.ToLower(resourceType)]; exists {
-		return template
-	}
-
-	// Default template for unknown resource types
-	return `Name:         {{ .Name }}
-Namespace:    {{ .Namespace }}
-Type:         {{ .Type }}
-Labels:       {{ range $k, $v := .Labels }}{{ $k }}={{ $v }}
-              {{ end }}
-Annotations:  {{ range $k, $v := .Annotations }}{{ $k }}: {{ $v }}
-              {{ end }}
-
-(Detailed information would appear here)
-
-Events:
-  Type    Reason      Age   From               Message
-  ----    ------      ----  ----               -------
-{{ range .Events }}  {{ .Type | printf "%-6s" }}  {{ .Reason | printf "%-10s" }}  {{ .Age | printf "%-4s" }}  {{ .From | printf "%-17s" }}  {{ .Message }}
-{{ end }}`
-}
-
 // FormatResourceType formats the resource type for display
 func FormatResourceType(resourceType string) string {
 	// Remove trailing 's' for singular form
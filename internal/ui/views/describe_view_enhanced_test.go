@@ -1,6 +1,7 @@
 package views
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
@@ -64,7 +65,7 @@ func TestDescribeViewEnhancements(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			view := NewDescribeView(tt.resourceType, tt.resourceName, tt.namespace, tt.context)
+			view := NewDescribeView(context.Background(), nil, tt.resourceType, tt.resourceName, tt.namespace, tt.context)
 			require.NotNil(t, view)
 
 			// Test initial state
@@ -129,7 +130,7 @@ func TestDescribeViewEnhancements(t *testing.T) {
 }
 
 func TestDescribeViewWordWrap(t *testing.T) {
-	view := NewDescribeView("Pod", "test-pod", "default", "test-context")
+	view := NewDescribeView(context.Background(), nil, "Pod", "test-pod", "default", "test-context")
 	view.width = 50
 	view.ready = true
 
@@ -152,7 +153,7 @@ func TestDescribeViewWordWrap(t *testing.T) {
 }
 
 func TestDescribeViewAutoRefresh(t *testing.T) {
-	view := NewDescribeView("Pod", "test-pod", "default", "test-context")
+	view := NewDescribeView(context.Background(), nil, "Pod", "test-pod", "default", "test-context")
 
 	// Test auto-refresh message
 	view.autoRefresh = true
@@ -168,76 +169,8 @@ func TestDescribeViewAutoRefresh(t *testing.T) {
 	assert.NotNil(t, view)
 	// Should not trigger refresh when disabled
 }
-func TestDescribeViewTemplateGeneration(t *testing.T) {
-	view := NewDescribeView("Pod", "test-pod", "default", "test-context")
-
-	// Test template data generation
-	data := view.createMockResourceData()
-	assert.NotNil(t, data)
-	assert.Equal(t, "test-pod", data["Name"])
-	assert.Equal(t, "default", data["Namespace"])
-	assert.Equal(t, "test-context", data["Context"])
-	assert.Equal(t, "Pod", data["Type"])
-
-	// Test that events are included
-	events, ok := data["Events"].([]map[string]interface{})
-	assert.True(t, ok)
-	assert.Greater(t, len(events), 0)
-
-	// Test template retrieval
-	template := view.getDescribeTemplate("Pod")
-	assert.NotEmpty(t, template)
-	assert.Contains(t, template, "Name:")
-	assert.Contains(t, template, "Events:")
-
-	// Test unknown resource type
-	unknownTemplate := view.getDescribeTemplate("UnknownType")
-	assert.NotEmpty(t, unknownTemplate)
-	assert.Contains(t, unknownTemplate, "(Detailed information would appear here)")
-}
-
-func TestDescribeViewEnhancedContent(t *testing.T) {
-	resourceTypes := []string{"Pod", "Deployment", "Service", "Ingress", "ConfigMap", "Secret"}
-
-	for _, resourceType := range resourceTypes {
-		t.Run(resourceType, func(t *testing.T) {
-			view := NewDescribeView(resourceType, "test-resource", "default", "test-context")
-
-			// Test enhanced content generation
-			content := view.getEnhancedDescribeContent()
-			assert.NotEmpty(t, content)
-			assert.Contains(t, content, "test-resource")
-			assert.Contains(t, content, "default")
-			assert.Contains(t, content, "Events:")
-
-			// Test that content includes resource-specific information
-			switch resourceType {
-			case "Pod":
-				assert.Contains(t, content, "Containers:")
-				assert.Contains(t, content, "Conditions:")
-				assert.Contains(t, content, "Volumes:")
-			case "Deployment":
-				assert.Contains(t, content, "Replicas:")
-				assert.Contains(t, content, "Strategy")
-			case "Service":
-				assert.Contains(t, content, "Type:")
-				assert.Contains(t, content, "Endpoints:")
-			case "Ingress":
-				assert.Contains(t, content, "Rules:")
-				assert.Contains(t, content, "Host")
-			case "ConfigMap":
-				assert.Contains(t, content, "Data")
-				assert.Contains(t, content, "BinaryData")
-			case "Secret":
-				assert.Contains(t, content, "Type:")
-				assert.Contains(t, content, "Data")
-			}
-		})
-	}
-}
-
 func TestDescribeViewKeyBindings(t *testing.T) {
-	view := NewDescribeView("Pod", "test-pod", "default", "test-context")
+	view := NewDescribeView(context.Background(), nil, "Pod", "test-pod", "default", "test-context")
 	view.ready = true
 	view.content = "test content"
 
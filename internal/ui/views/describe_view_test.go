@@ -2,10 +2,21 @@ package views
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
 	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
 )
 
 func TestDescribeViewInitialization(t *testing.T) {
@@ -41,7 +52,7 @@ func TestDescribeViewInitialization(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			view := NewDescribeView(tt.resourceType, tt.resourceName, tt.namespace, tt.context)
+			view := NewDescribeView(context.Background(), nil, tt.resourceType, tt.resourceName, tt.namespace, tt.context)
 
 			if view == nil {
 				t.Fatal("NewDescribeView returned nil")
@@ -71,7 +82,7 @@ func TestDescribeViewInitialization(t *testing.T) {
 }
 
 func TestDescribeViewInit(t *testing.T) {
-	view := NewDescribeView("Pod", "test-pod", "default", "")
+	view := NewDescribeView(context.Background(), nil, "Pod", "test-pod", "default", "")
 
 	// Test behavior: Init should return a command that can be executed
 	cmd := view.Init()
@@ -117,7 +128,7 @@ func TestDescribeViewInit(t *testing.T) {
 }
 
 func TestDescribeViewContentLoading(t *testing.T) {
-	view := NewDescribeView("Pod", "test-pod", "default", "")
+	view := NewDescribeView(context.Background(), nil, "Pod", "test-pod", "default", "")
 
 	// Simulate content loaded
 	msg := describeLoadedMsg{
@@ -138,7 +149,7 @@ func TestDescribeViewContentLoading(t *testing.T) {
 }
 
 func TestDescribeViewErrorHandling(t *testing.T) {
-	view := NewDescribeView("Pod", "test-pod", "default", "")
+	view := NewDescribeView(context.Background(), nil, "Pod", "test-pod", "default", "")
 	view.ready = true
 
 	// Simulate error loading content
@@ -193,7 +204,7 @@ func TestDescribeViewKeyHandling(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			view := NewDescribeView("Pod", "test-pod", "default", "")
+			view := NewDescribeView(context.Background(), nil, "Pod", "test-pod", "default", "")
 			view.ready = true
 			view.SetSize(80, 24)
 			view.content = strings.Repeat("Line\n", 100) // Long content for scrolling
@@ -216,7 +227,7 @@ func TestDescribeViewKeyHandling(t *testing.T) {
 }
 
 func TestDescribeViewWindowResize(t *testing.T) {
-	view := NewDescribeView("Pod", "test-pod", "default", "")
+	view := NewDescribeView(context.Background(), nil, "Pod", "test-pod", "default", "")
 
 	// Test behavior: Window resize should make view ready and update dimensions
 	msg := tea.WindowSizeMsg{Width: 100, Height: 50}
@@ -264,7 +275,7 @@ func TestDescribeViewWindowResize(t *testing.T) {
 }
 
 func TestDescribeViewSetSize(t *testing.T) {
-	view := NewDescribeView("Pod", "test-pod", "default", "")
+	view := NewDescribeView(context.Background(), nil, "Pod", "test-pod", "default", "")
 
 	view.SetSize(150, 40)
 
@@ -281,6 +292,50 @@ func TestDescribeViewSetSize(t *testing.T) {
 	}
 }
 
+func TestDescribeViewResizeKeepsScrollPosition(t *testing.T) {
+	lines := make([]string, 100)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %03d", i)
+	}
+
+	view := NewDescribeView(context.Background(), nil, "Pod", "test-pod", "default", "")
+	view.SetSize(80, 23)
+	view.Update(describeLoadedMsg{content: strings.Join(lines, "\n")})
+	view.viewport.SetYOffset(50)
+
+	// A resize, as a message or a direct call, keeps the same lines in view
+	view.Update(tea.WindowSizeMsg{Width: 100, Height: 33})
+	if view.viewport.YOffset != 50 {
+		t.Errorf("Expected the scroll position to survive a resize, got offset %d", view.viewport.YOffset)
+	}
+	if !strings.Contains(view.View(), "line 050") {
+		t.Error("Expected line 050 to stay in view")
+	}
+
+	// Re-wrapping for a narrower width keeps the same relative position
+	view.wordWrap = true
+	longLines := make([]string, 100)
+	for i := range longLines {
+		longLines[i] = fmt.Sprintf("line %03d %s", i, strings.Repeat("x", 50))
+	}
+	view.Update(describeLoadedMsg{content: strings.Join(longLines, "\n")})
+	view.viewport.SetYOffset(50)
+	view.SetSize(40, 33)
+	if total := view.viewport.TotalLineCount(); total <= 100 {
+		t.Fatalf("Expected the narrow width to wrap lines, got %d lines", total)
+	}
+	if got, want := view.viewport.YOffset, view.viewport.TotalLineCount()/2; got < want-2 || got > want+2 {
+		t.Errorf("Expected the offset to scale to about %d, got %d", want, got)
+	}
+
+	// A view scrolled to the bottom stays at the bottom
+	view.viewport.GotoBottom()
+	view.SetSize(100, 20)
+	if !view.viewport.AtBottom() {
+		t.Error("Expected a view at the bottom to stay at the bottom")
+	}
+}
+
 func TestDescribeViewRendering(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -333,7 +388,7 @@ func TestDescribeViewRendering(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			view := NewDescribeView(tt.resourceType, tt.resourceName, tt.namespace, tt.context)
+			view := NewDescribeView(context.Background(), nil, tt.resourceType, tt.resourceName, tt.namespace, tt.context)
 			view.loading = tt.loading
 			view.content = tt.content
 			view.ready = true
@@ -355,7 +410,7 @@ func TestDescribeViewRendering(t *testing.T) {
 }
 
 func TestDescribeViewNotReady(t *testing.T) {
-	view := NewDescribeView("Pod", "test-pod", "default", "")
+	view := NewDescribeView(context.Background(), nil, "Pod", "test-pod", "default", "")
 	view.ready = false
 
 	output := view.View()
@@ -365,82 +420,6 @@ func TestDescribeViewNotReady(t *testing.T) {
 	}
 }
 
-func TestDescribeViewGetDescribeContent(t *testing.T) {
-	tests := []struct {
-		name         string
-		resourceType string
-		resourceName string
-		namespace    string
-		context      string
-		wantBehavior string
-	}{
-		{
-			name:         "generates pod content with essential fields",
-			resourceType: "Pod",
-			resourceName: "test-pod",
-			namespace:    "default",
-			wantBehavior: "should include resource identification and pod-specific information",
-		},
-		{
-			name:         "generates deployment content with essential fields",
-			resourceType: "Deployment",
-			resourceName: "test-deploy",
-			namespace:    "production",
-			wantBehavior: "should include resource identification and deployment-specific information",
-		},
-		{
-			name:         "generates service content with essential fields",
-			resourceType: "Service",
-			resourceName: "test-svc",
-			namespace:    "default",
-			wantBehavior: "should include resource identification and service-specific information",
-		},
-		{
-			name:         "generates configmap content with essential fields",
-			resourceType: "ConfigMap",
-			resourceName: "test-cm",
-			namespace:    "default",
-			wantBehavior: "should include resource identification and configmap-specific information",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			view := NewDescribeView(tt.resourceType, tt.resourceName, tt.namespace, tt.context)
-			content := view.getDescribeContent()
-
-			// Test behavior: content should be non-empty and contain resource identification
-			if content == "" {
-				t.Error("getDescribeContent should return non-empty content")
-			}
-
-			// Test behavior: content should identify the resource
-			if !strings.Contains(content, tt.resourceName) {
-				t.Errorf("content should contain resource name %q", tt.resourceName)
-			}
-
-			if !strings.Contains(content, tt.namespace) {
-				t.Errorf("content should contain namespace %q", tt.namespace)
-			}
-
-			// Test behavior: content should have structured information (contains "Name:" field)
-			if !strings.Contains(content, "Name:") {
-				t.Error("content should be structured with field labels like 'Name:'")
-			}
-
-			// Test behavior: content should include events section for all resources
-			if !strings.Contains(content, "Events:") {
-				t.Error("content should include Events section")
-			}
-
-			// Test behavior: content length should be reasonable (not just a stub)
-			if len(content) < 100 {
-				t.Errorf("content seems too short (%d chars), may be incomplete", len(content))
-			}
-		})
-	}
-}
-
 func TestFormatResourceType(t *testing.T) {
 	tests := []struct {
 		input string
@@ -463,3 +442,259 @@ func TestFormatResourceType(t *testing.T) {
 		})
 	}
 }
+
+func describeEvent(uid, eventType, reason string, lastSeen time.Time) v1.Event {
+	return v1.Event{
+		ObjectMeta:    metav1.ObjectMeta{UID: types.UID(uid), ResourceVersion: "1"},
+		Type:          eventType,
+		Reason:        reason,
+		Message:       reason + " happened",
+		LastTimestamp: metav1.NewTime(lastSeen),
+	}
+}
+
+// newClientDescribeView returns a view that shows events, fed by messages
+// rather than a cluster
+func newClientDescribeView(body string) *DescribeView {
+	view := NewDescribeView(context.Background(), &k8s.Client{}, "Pods", "web-1", "default", "")
+	view.SetSize(80, 13) // Ten lines of content
+	view.Update(describeLoadedMsg{content: body})
+	return view
+}
+
+func TestDescribeViewRefreshKeepsAnchorLine(t *testing.T) {
+	lines := make([]string, 60)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %03d", i)
+	}
+	view := newClientDescribeView(strings.Join(lines, "\n"))
+	view.viewport.SetYOffset(30)
+
+	// A refresh that adds lines above keeps the same line at the top
+	view.Update(describeLoadedMsg{content: "Terminating\n\n" + strings.Join(lines, "\n")})
+	if top := view.lines[view.viewport.YOffset]; top != "line 030" {
+		t.Errorf("Expected line 030 to stay at the top, got %q", top)
+	}
+
+	// New events arriving at the end do not move it either
+	now := time.Now()
+	view.Update(describeEventsMsg{events: []v1.Event{describeEvent("a", v1.EventTypeNormal, "Pulled", now)}})
+	if top := view.lines[view.viewport.YOffset]; top != "line 030" {
+		t.Errorf("Expected line 030 to stay at the top after events, got %q", top)
+	}
+}
+
+func TestDescribeViewFollowsEvents(t *testing.T) {
+	view := newClientDescribeView(strings.Repeat("detail\n", 40))
+	now := time.Now()
+
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if !view.followEvents || !view.viewport.AtBottom() {
+		t.Fatal("Expected f to follow events at the bottom")
+	}
+
+	view.Update(describeEventsMsg{events: []v1.Event{
+		describeEvent("a", v1.EventTypeNormal, "Pulled", now.Add(-time.Minute)),
+		describeEvent("b", v1.EventTypeWarning, "BackOff", now),
+	}})
+	if !view.viewport.AtBottom() || !strings.Contains(view.View(), "BackOff") {
+		t.Errorf("Expected the newest event in view, got:\n%s", view.View())
+	}
+	if !strings.Contains(view.View(), "Following events") {
+		t.Error("Expected the status line to show events are followed")
+	}
+
+	// Scrolling up stops following
+	view.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if view.followEvents {
+		t.Error("Expected scrolling up to stop following")
+	}
+}
+
+func TestDescribeViewMergesEventsIncrementally(t *testing.T) {
+	view := newClientDescribeView("Name: web-1")
+	now := time.Now().Truncate(time.Second)
+
+	view.Update(describeEventsMsg{events: []v1.Event{
+		describeEvent("a", v1.EventTypeNormal, "Pulled", now.Add(-time.Minute)),
+		describeEvent("b", v1.EventTypeWarning, "BackOff", now.Add(-time.Minute)),
+	}})
+	if !view.lastEventSeen.Equal(now.Add(-time.Minute)) {
+		t.Errorf("Expected the next fetch to start at the newest event, got %v", view.lastEventSeen)
+	}
+
+	// A recurring event replaces its earlier copy and moves to the end
+	recurred := describeEvent("b", v1.EventTypeWarning, "BackOff", now)
+	recurred.ResourceVersion = "2"
+	recurred.Count = 4
+	view.Update(describeEventsMsg{events: []v1.Event{recurred}})
+	if len(view.events) != 2 || view.events[1].Count != 4 {
+		t.Fatalf("Expected the recurring event merged, got %d events", len(view.events))
+	}
+	if !view.lastEventSeen.Equal(now) {
+		t.Errorf("Expected the newest time to advance, got %v", view.lastEventSeen)
+	}
+	if !strings.Contains(view.content, "BackOff happened (x4)") {
+		t.Errorf("Expected the repeat count shown, got:\n%s", view.content)
+	}
+}
+
+func TestDescribeViewWarningsOnly(t *testing.T) {
+	view := newClientDescribeView("Name: web-1")
+	now := time.Now()
+	view.Update(describeEventsMsg{events: []v1.Event{
+		describeEvent("a", v1.EventTypeNormal, "Pulled", now),
+		describeEvent("b", v1.EventTypeWarning, "BackOff", now),
+	}})
+	if !strings.Contains(view.content, "Pulled") {
+		t.Fatal("Expected Normal events shown by default")
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if strings.Contains(view.content, "Pulled") || !strings.Contains(view.content, "BackOff") {
+		t.Errorf("Expected only warnings, got:\n%s", view.content)
+	}
+	if !strings.Contains(view.content, "1 normal hidden") {
+		t.Errorf("Expected the hidden count, got:\n%s", view.content)
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if !strings.Contains(view.content, "Pulled") {
+		t.Error("Expected Normal events back after toggling again")
+	}
+}
+
+func TestDescribeViewImageChanges(t *testing.T) {
+	view := NewDescribeView(context.Background(), nil, "Deployments", "web", "prod", "")
+	view.SetSize(120, 30)
+	view.Update(describeLoadedMsg{content: "Name: web"})
+	if strings.Contains(view.content, "Image changes") {
+		t.Fatal("Expected no image section before changes are set")
+	}
+
+	at := time.Now().Add(-22 * time.Minute)
+	view.SetImageChanges([]core.ImageChange{
+		{At: at.Add(-time.Hour), Old: "registry/web:v1.41.1", New: "registry/web:v1.41.2"},
+		{At: at, Old: "registry/web:v1.41.2", New: "registry/web:v1.42.0"},
+	})
+	newest := strings.Index(view.content, "registry/web:v1.41.2 → registry/web:v1.42.0")
+	oldest := strings.Index(view.content, "registry/web:v1.41.1 → registry/web:v1.41.2")
+	if newest < 0 || oldest < 0 || newest > oldest {
+		t.Errorf("Expected both changes listed newest first, got:\n%s", view.content)
+	}
+	if !strings.Contains(view.content, "22m") {
+		t.Errorf("Expected the age of the change, got:\n%s", view.content)
+	}
+
+	view.SetImageChanges(nil)
+	if !strings.Contains(view.content, "Image changes (seen this session):\n  <none>") {
+		t.Errorf("Expected an empty history to say so, got:\n%s", view.content)
+	}
+}
+
+func TestDescribeViewRecentChanges(t *testing.T) {
+	view := NewDescribeView(context.Background(), nil, "Pods", "web-1", "prod", "")
+	view.SetSize(120, 30)
+	view.Update(describeLoadedMsg{content: "Name: web-1"})
+	if strings.Contains(view.content, "Recent changes") {
+		t.Fatal("Expected no recent changes before they are set")
+	}
+
+	at := time.Now()
+	view.SetRecentChanges([]core.ObjectEvent{
+		{Type: watch.Added, At: at.Add(-3 * time.Minute)},
+		{Type: watch.Modified, At: at, Changes: []core.FieldChange{{Field: "status.phase", Old: "Pending", New: "Running"}}},
+	})
+	newest := strings.Index(view.content, "status.phase Pending→Running")
+	oldest := strings.Index(view.content, "Added at")
+	if newest < 0 || oldest < 0 || newest > oldest {
+		t.Errorf("Expected both changes listed newest first, got:\n%s", view.content)
+	}
+
+	view.SetRecentChanges(nil)
+	if !strings.Contains(view.content, "Recent changes (seen this session):\n  <none>") {
+		t.Errorf("Expected no changes to say so, got:\n%s", view.content)
+	}
+}
+
+// podServer serves one pod, web-1 in default on node-7, and nothing else
+func podServer(t *testing.T) *k8s.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/default/pods/web-1" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"Pod","apiVersion":"v1","metadata":{"name":"web-1","namespace":"default"},"spec":{"nodeName":"node-7"},"status":{"phase":"Running"}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	return client
+}
+
+func TestDescribeViewReadsCluster(t *testing.T) {
+	view := NewDescribeView(context.Background(), podServer(t), "Pods", "web-1", "default", "")
+	view.SetSize(120, 20)
+	view.Update(view.loadDescribe()())
+	if !strings.Contains(view.content, "Node:         node-7") {
+		t.Errorf("Expected the pod as the cluster has it, got:\n%s", view.content)
+	}
+}
+
+func TestDescribeViewWithoutClient(t *testing.T) {
+	for _, resourceType := range []string{"Pods", "Deployments", "Services", "Ingresses", "ConfigMaps", "Secrets"} {
+		t.Run(resourceType, func(t *testing.T) {
+			view := NewDescribeView(context.Background(), nil, resourceType, "web", "default", "")
+			view.SetSize(120, 20)
+			view.Update(view.loadDescribe()())
+
+			// Nothing that could pass for the cluster's data is shown
+			if !strings.Contains(view.content, "Error loading description: no cluster connection") {
+				t.Errorf("Expected the missing connection reported, got:\n%s", view.content)
+			}
+			for _, made := range []string{"Name:", "nginx", "10.244.", "Labels", "Events:"} {
+				if strings.Contains(view.content, made) {
+					t.Errorf("Expected no describe output without a cluster, got %q in:\n%s", made, view.content)
+				}
+			}
+		})
+	}
+}
+
+func TestDescribeViewTemplate(t *testing.T) {
+	client := podServer(t)
+	load := func(tmpl string) *DescribeView {
+		view := NewDescribeView(context.Background(), nil, "Pods", "web-1", "default", "")
+		view.SetSize(120, 20)
+		view.SetDescribeTemplate(tmpl)
+		view.Update(view.LoadDescribeWithClient(context.Background(), client)())
+		return view
+	}
+
+	view := load("Runs on {{ .Spec.NodeName }}")
+	if !strings.HasPrefix(view.content, "Runs on node-7") || view.templateErr != nil {
+		t.Errorf("Expected the template rendered, got:\n%s", view.content)
+	}
+	if strings.Contains(view.View(), "⚠ template") {
+		t.Error("Expected no banner when the template renders")
+	}
+
+	// A template that fails on the real object falls back to the built-in
+	// output under a banner saying why
+	view = load("Runs on {{ .Spec.Node }}")
+	if !strings.Contains(view.content, "Node:         node-7") {
+		t.Errorf("Expected the built-in output, got:\n%s", view.content)
+	}
+	rendered := view.View()
+	if !strings.Contains(rendered, `⚠ template "pod_describe" at line 1`) || !strings.Contains(rendered, "can't evaluate field Node") {
+		t.Errorf("Expected a banner naming the template error, got:\n%s", rendered)
+	}
+	if view.viewport.Height != 20-4 {
+		t.Errorf("Expected the banner to take a line from the viewport, got height %d", view.viewport.Height)
+	}
+}
@@ -0,0 +1,565 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/components/textinput"
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	// maxEventTailLines is how many lines the event tail keeps
+	maxEventTailLines = 5000
+
+	// eventTailRetryDelay is how long a context waits before listing again
+	// after its watch ends or listing fails
+	eventTailRetryDelay = 2 * time.Second
+)
+
+// eventTailKey is what makes events one line: repeats of a reason for the
+// same object update the line's count rather than adding lines
+type eventTailKey struct {
+	context   string
+	namespace string
+	kind      string
+	name      string
+	reason    string
+}
+
+// eventTailLine is one line of the tail
+type eventTailLine struct {
+	key      eventTailKey
+	warning  bool
+	message  string
+	lastSeen time.Time
+	counts   map[types.UID]int32 // Each event object's count; recurring events keep their UID
+}
+
+// count sums how often the line's events occurred
+func (l *eventTailLine) count() int32 {
+	var total int32
+	for _, count := range l.counts {
+		total += count
+	}
+	return total
+}
+
+// EventTailView streams the events of the current namespace, or of all
+// namespaces, like a log: newest at the bottom, followed unless scrolled,
+// searchable, and filterable to warnings. With several contexts, each
+// context's events are watched and their lines labelled.
+type EventTailView struct {
+	viewport viewport.Model
+	width    int
+	height   int
+
+	namespace string   // Empty for all namespaces
+	contexts  []string // [""] for a single client
+
+	lines []*eventTailLine
+	index map[eventTailKey]*eventTailLine
+
+	following    bool
+	warningsOnly bool
+
+	searchMode    bool
+	searchInput   textinput.Model // The query being typed, untouched by arriving events
+	searchQuery   string          // The query searched for
+	searchResults []int           // Shown line indices matching the query
+	currentMatch  int
+
+	// The streams; gen tells messages of stopped streams apart
+	ctx      context.Context
+	cancel   context.CancelFunc
+	gen      int
+	watchers map[string]watch.Interface
+	errs     map[string]error
+
+	notice string // Result of the last export
+}
+
+// NewEventTailView creates an event tail of namespace over contexts; a
+// single client is passed as the one context ""
+func NewEventTailView(namespace string, contexts []string) *EventTailView {
+	return &EventTailView{
+		viewport:  viewport.New(80, 20),
+		namespace: namespace,
+		contexts:  contexts,
+		index:     make(map[eventTailKey]*eventTailLine),
+		following: true,
+		watchers:  make(map[string]watch.Interface),
+		errs:      make(map[string]error),
+	}
+}
+
+// Init initializes the view
+func (v *EventTailView) Init() tea.Cmd {
+	return nil
+}
+
+// IsSearchMode returns true while a search is being typed
+func (v *EventTailView) IsSearchMode() bool {
+	return v.searchMode
+}
+
+// StartWithClients lists and then watches the events of each context
+func (v *EventTailView) StartWithClients(ctx context.Context, clients map[string]*k8s.Client) tea.Cmd {
+	v.Stop()
+	v.ctx, v.cancel = context.WithCancel(ctx)
+	v.gen++
+
+	var cmds []tea.Cmd
+	for _, contextName := range v.contexts {
+		if client := clients[contextName]; client != nil {
+			cmds = append(cmds, v.listEvents(contextName, client, 0))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// Stop ends the watches
+func (v *EventTailView) Stop() {
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+	for contextName, w := range v.watchers {
+		w.Stop()
+		delete(v.watchers, contextName)
+	}
+}
+
+// listEvents lists a context's events, after delay, and opens a watch for
+// what follows
+func (v *EventTailView) listEvents(contextName string, client *k8s.Client, delay time.Duration) tea.Cmd {
+	ctx, gen, namespace := v.ctx, v.gen, v.namespace
+	return func() tea.Msg {
+		if delay > 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(delay):
+			}
+		}
+		msg := eventTailListedMsg{gen: gen, context: contextName, client: client}
+		var resourceVersion string
+		msg.events, resourceVersion, msg.err = client.ListEvents(ctx, namespace)
+		if msg.err == nil {
+			msg.watcher, msg.err = client.WatchEvents(ctx, namespace, resourceVersion)
+		}
+		return msg
+	}
+}
+
+// nextEvent waits for a context's next watch event
+func (v *EventTailView) nextEvent(contextName string, client *k8s.Client, w watch.Interface) tea.Cmd {
+	gen := v.gen
+	return func() tea.Msg {
+		event, ok := <-w.ResultChan()
+		msg := eventTailWatchMsg{gen: gen, context: contextName, client: client, watcher: w, closed: !ok}
+		if ok {
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				msg.event, _ = event.Object.(*v1.Event)
+			case watch.Error:
+				// Usually an expired resource version; list again
+				msg.closed = true
+			}
+		}
+		return msg
+	}
+}
+
+// Add merges an event into the tail: a repeat of a reason for the same
+// object updates that line, anything else is a new line at the bottom
+func (v *EventTailView) Add(contextName string, event *v1.Event) {
+	key := eventTailKey{
+		context:   contextName,
+		namespace: event.Namespace,
+		kind:      event.InvolvedObject.Kind,
+		name:      event.InvolvedObject.Name,
+		reason:    event.Reason,
+	}
+	line := v.index[key]
+	if line == nil {
+		line = &eventTailLine{key: key, counts: make(map[types.UID]int32)}
+		v.index[key] = line
+		v.lines = append(v.lines, line)
+		if len(v.lines) > maxEventTailLines {
+			for _, dropped := range v.lines[:len(v.lines)-maxEventTailLines] {
+				delete(v.index, dropped.key)
+			}
+			v.lines = v.lines[len(v.lines)-maxEventTailLines:]
+		}
+	}
+	if seen := k8s.EventLastSeen(*event); !seen.Before(line.lastSeen) {
+		line.lastSeen = seen
+		line.warning = event.Type == v1.EventTypeWarning
+		line.message = strings.TrimSpace(plainLogText(sanitizeLogLine(event.Message)))
+	}
+	line.counts[event.UID] = k8s.EventCount(*event)
+}
+
+// Update handles messages. Esc and q are handled by the event tail mode.
+func (v *EventTailView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case eventTailListedMsg:
+		if msg.gen != v.gen {
+			if msg.watcher != nil {
+				msg.watcher.Stop()
+			}
+			return v, nil
+		}
+		if msg.err != nil {
+			v.errs[msg.context] = msg.err
+			v.refreshContent()
+			return v, v.listEvents(msg.context, msg.client, eventTailRetryDelay)
+		}
+		delete(v.errs, msg.context)
+		for i := range msg.events {
+			v.Add(msg.context, &msg.events[i])
+		}
+		v.watchers[msg.context] = msg.watcher
+		v.refreshContent()
+		v.updateSearchResults()
+		return v, v.nextEvent(msg.context, msg.client, msg.watcher)
+
+	case eventTailWatchMsg:
+		if msg.gen != v.gen {
+			return v, nil
+		}
+		if msg.closed {
+			msg.watcher.Stop()
+			delete(v.watchers, msg.context)
+			return v, v.listEvents(msg.context, msg.client, eventTailRetryDelay)
+		}
+		if msg.event != nil {
+			v.Add(msg.context, msg.event)
+			v.refreshContent()
+			v.updateSearchResults()
+		}
+		return v, v.nextEvent(msg.context, msg.client, msg.watcher)
+
+	case eventTailExportedMsg:
+		if msg.err != nil {
+			v.notice = "Export failed: " + msg.err.Error()
+		} else {
+			v.notice = fmt.Sprintf("Exported %d lines to %s", msg.lines, msg.path)
+		}
+		return v, nil
+
+	case tea.KeyMsg:
+		if v.searchMode {
+			switch msg.String() {
+			case "enter":
+				v.searchMode = false
+				v.searchQuery = v.searchInput.Value()
+				v.performSearch()
+			case "esc":
+				v.searchMode = false
+				v.searchInput.Reset()
+				v.searchQuery = ""
+				v.searchResults = nil
+				v.refreshContent()
+			default:
+				v.searchInput.HandleKey(msg)
+			}
+			return v, nil
+		}
+
+		switch msg.String() {
+		case "/":
+			v.searchMode = true
+			v.searchInput.Reset()
+			v.searchQuery = ""
+			v.searchResults = nil
+			v.refreshContent()
+			return v, nil
+		case "n":
+			if len(v.searchResults) > 0 {
+				v.currentMatch = (v.currentMatch + 1) % len(v.searchResults)
+				v.jumpToMatch()
+			}
+			return v, nil
+		case "N":
+			if len(v.searchResults) > 0 {
+				v.currentMatch = (v.currentMatch + len(v.searchResults) - 1) % len(v.searchResults)
+				v.jumpToMatch()
+			}
+			return v, nil
+		case "f":
+			v.following = !v.following
+			if v.following {
+				v.viewport.GotoBottom()
+			}
+			return v, nil
+		case "w":
+			v.warningsOnly = !v.warningsOnly
+			v.refreshContent()
+			if v.searchQuery != "" {
+				v.performSearch()
+			}
+			return v, nil
+		case "e":
+			return v, v.export()
+		case "g", "home":
+			v.following = false
+			v.viewport.GotoTop()
+			return v, nil
+		case "G", "end":
+			v.following = true
+			v.viewport.GotoBottom()
+			return v, nil
+		}
+	}
+
+	// Scrolling up stops following, like the log view
+	oldY := v.viewport.YOffset
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	if oldY != v.viewport.YOffset && v.viewport.YOffset < v.viewport.TotalLineCount()-v.viewport.Height {
+		v.following = false
+	}
+	return v, cmd
+}
+
+// shownLines returns the lines the severity filter lets through
+func (v *EventTailView) shownLines() []*eventTailLine {
+	if !v.warningsOnly {
+		return v.lines
+	}
+	var shown []*eventTailLine
+	for _, line := range v.lines {
+		if line.warning {
+			shown = append(shown, line)
+		}
+	}
+	return shown
+}
+
+// renderLine formats a line, e.g.
+// "[prod] web  14:02:11 ⚠ BackOff  pod/api-1: Back-off restarting (x5)"
+func (v *EventTailView) renderLine(line *eventTailLine) string {
+	var b strings.Builder
+	if len(v.contexts) > 1 {
+		b.WriteString("[" + line.key.context + "] ")
+	}
+	if v.namespace == "" {
+		b.WriteString(line.key.namespace + "  ")
+	}
+	b.WriteString(core.FormatClock(line.lastSeen))
+	if line.warning {
+		b.WriteString(" ⚠ ")
+	} else {
+		b.WriteString("   ")
+	}
+	fmt.Fprintf(&b, "%-20s %s/%s: %s", line.key.reason, strings.ToLower(line.key.kind), line.key.name, line.message)
+	if count := line.count(); count > 1 {
+		fmt.Fprintf(&b, " (x%d)", count)
+	}
+	return b.String()
+}
+
+// refreshContent renders the shown lines into the viewport, highlighting
+// search matches
+func (v *EventTailView) refreshContent() {
+	highlight := lipgloss.NewStyle().Background(lipgloss.Color("226")).Foreground(lipgloss.Color("0"))
+	warning := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+
+	shown := v.shownLines()
+	rendered := make([]string, len(shown))
+	for i, line := range shown {
+		text := v.renderLine(line)
+		if v.searchQuery != "" && !v.searchMode {
+			if start, end := indexFold(text, v.searchQuery); start >= 0 {
+				text = text[:start] + highlight.Render(text[start:end]) + text[end:]
+			}
+		} else if line.warning {
+			text = warning.Render(text)
+		}
+		rendered[i] = text
+	}
+	v.viewport.SetContent(strings.Join(rendered, "\n"))
+	if v.following {
+		v.viewport.GotoBottom()
+	}
+}
+
+// performSearch finds the shown lines matching the query and jumps to the
+// first
+func (v *EventTailView) performSearch() {
+	v.searchResults = v.findMatches()
+	v.currentMatch = 0
+	v.refreshContent()
+	if len(v.searchResults) > 0 {
+		v.jumpToMatch()
+	}
+}
+
+// findMatches returns the indices of the shown lines matching the query
+func (v *EventTailView) findMatches() []int {
+	if v.searchQuery == "" {
+		return nil
+	}
+	var matches []int
+	for i, line := range v.shownLines() {
+		if start, _ := indexFold(v.renderLine(line), v.searchQuery); start >= 0 {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// updateSearchResults searches again as events arrive, keeping the current
+// match on its line and the view where it is
+func (v *EventTailView) updateSearchResults() {
+	if v.searchQuery == "" {
+		return
+	}
+	shown := v.shownLines()
+	var current *eventTailLine
+	if v.currentMatch < len(v.searchResults) && v.searchResults[v.currentMatch] < len(shown) {
+		current = shown[v.searchResults[v.currentMatch]]
+	}
+
+	v.searchResults = v.findMatches()
+	v.currentMatch = 0
+	for i, index := range v.searchResults {
+		if shown[index] == current {
+			v.currentMatch = i
+			break
+		}
+	}
+}
+
+// jumpToMatch centers the current match, which stops following
+func (v *EventTailView) jumpToMatch() {
+	v.following = false
+	offset := v.searchResults[v.currentMatch] - v.viewport.Height/2
+	if maxOffset := v.viewport.TotalLineCount() - v.viewport.Height; offset > maxOffset {
+		offset = maxOffset
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	v.viewport.SetYOffset(offset)
+}
+
+// export writes the shown lines to a file in the working directory
+func (v *EventTailView) export() tea.Cmd {
+	shown := v.shownLines()
+	text := make([]string, len(shown))
+	for i, line := range shown {
+		text[i] = v.renderLine(line)
+	}
+	path := fmt.Sprintf("kubewatch-events-%s.txt", time.Now().Format("20060102-150405"))
+	return func() tea.Msg {
+		err := os.WriteFile(path, []byte(strings.Join(text, "\n")+"\n"), 0644)
+		return eventTailExportedMsg{path: path, lines: len(text), err: err}
+	}
+}
+
+// SetSize sets the view size
+func (v *EventTailView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.viewport.Width = width
+	v.viewport.Height = height - 3 // Header and status lines
+	if v.viewport.Height < 1 {
+		v.viewport.Height = 1
+	}
+	v.refreshContent()
+}
+
+// View renders the tail
+func (v *EventTailView) View() string {
+	state := "FOLLOWING"
+	if !v.following {
+		state = "SCROLLING"
+	}
+	scope := "namespace " + v.namespace
+	if v.namespace == "" {
+		scope = "all namespaces"
+	}
+	if len(v.contexts) > 1 {
+		scope += fmt.Sprintf(", %d contexts", len(v.contexts))
+	}
+	if v.warningsOnly {
+		scope += " | Warnings only"
+	}
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86")).
+		Render(fmt.Sprintf("📣 Events [%s] %s", state, scope))
+
+	statusStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	var status string
+	switch {
+	case v.searchMode:
+		status = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Render("Search: " + v.searchInput.View())
+	case len(v.errs) > 0:
+		var problems []string
+		for _, contextName := range v.contexts {
+			if err, ok := v.errs[contextName]; ok {
+				problem := k8s.UserMessage(err)
+				if contextName != "" {
+					problem = contextName + ": " + problem
+				}
+				problems = append(problems, problem)
+			}
+		}
+		status = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(strings.Join(problems, " | ") + " (retrying)")
+	case v.notice != "":
+		status = statusStyle.Render(v.notice)
+	case len(v.searchResults) > 0:
+		status = statusStyle.Render(fmt.Sprintf("Match %d/%d | n: next | N: prev | /: new search",
+			v.currentMatch+1, len(v.searchResults)))
+	default:
+		status = statusStyle.Render(fmt.Sprintf("Lines: %d | /: search | w: warnings only | f: follow | e: export | Esc: close",
+			len(v.shownLines())))
+	}
+
+	body := v.viewport.View()
+	if len(v.lines) == 0 {
+		body = lipgloss.NewStyle().Height(v.viewport.Height).Render("Waiting for events...")
+	}
+	return fmt.Sprintf("%s\n%s\n%s", header, body, status)
+}
+
+// eventTailListedMsg carries a context's events and the watch for what
+// follows them
+type eventTailListedMsg struct {
+	gen     int
+	context string
+	client  *k8s.Client
+	events  []v1.Event
+	watcher watch.Interface
+	err     error
+}
+
+// eventTailWatchMsg carries an event from a context's watch, or that the
+// watch ended
+type eventTailWatchMsg struct {
+	gen     int
+	context string
+	client  *k8s.Client
+	watcher watch.Interface
+	event   *v1.Event
+	closed  bool
+}
+
+// eventTailExportedMsg reports where the tail was exported
+type eventTailExportedMsg struct {
+	path  string
+	lines int
+	err   error
+}
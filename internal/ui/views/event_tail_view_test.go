@@ -0,0 +1,112 @@
+package views
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// eventTailTestEvent is an event about a pod, last seen at seen
+func eventTailTestEvent(uid, namespace, pod, eventType, reason, message string, count int32, seen time.Time) *v1.Event {
+	return &v1.Event{
+		ObjectMeta:     metav1.ObjectMeta{UID: types.UID(uid), Namespace: namespace, Name: pod + "." + uid},
+		InvolvedObject: v1.ObjectReference{Kind: "Pod", Namespace: namespace, Name: pod},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+		Count:          count,
+		LastTimestamp:  metav1.NewTime(seen),
+	}
+}
+
+func TestEventTailViewMergesRepeats(t *testing.T) {
+	v := NewEventTailView("", []string{"prod", "staging"})
+	v.SetSize(160, 20)
+	start := time.Now()
+
+	v.Update(eventTailListedMsg{context: "prod", events: []v1.Event{
+		*eventTailTestEvent("a", "web", "api-1", v1.EventTypeNormal, "Pulled", "Pulled image", 1, start),
+		*eventTailTestEvent("b", "web", "api-1", v1.EventTypeWarning, "BackOff", "Back-off restarting", 3, start.Add(time.Second)),
+	}})
+	v.Update(eventTailWatchMsg{context: "prod", event: eventTailTestEvent("b", "web", "api-1", v1.EventTypeWarning, "BackOff", "Back-off restarting again", 5, start.Add(time.Minute))})
+	v.Update(eventTailWatchMsg{context: "prod", event: eventTailTestEvent("c", "web", "api-1", v1.EventTypeWarning, "BackOff", "Back-off restarting again", 2, start.Add(2*time.Minute))})
+	v.Update(eventTailWatchMsg{context: "staging", event: eventTailTestEvent("d", "web", "api-1", v1.EventTypeWarning, "BackOff", "Back-off restarting", 1, start)})
+
+	if len(v.lines) != 3 {
+		t.Fatalf("Expected repeats merged into one line per context, got %d lines", len(v.lines))
+	}
+	backOff := v.renderLine(v.lines[1])
+	for _, want := range []string{"[prod] web", "⚠", "pod/api-1: Back-off restarting again", "(x7)"} {
+		if !strings.Contains(backOff, want) {
+			t.Errorf("Expected %q in the updated line, got %q", want, backOff)
+		}
+	}
+	if !strings.HasPrefix(v.renderLine(v.lines[2]), "[staging] web") {
+		t.Errorf("Expected the other context's event last, got %q", v.renderLine(v.lines[2]))
+	}
+
+	// Warnings only hides the pull, and search finds within what is shown
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if shown := v.shownLines(); len(shown) != 2 {
+		t.Errorf("Expected only the warnings shown, got %d lines", len(shown))
+	}
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, r := range "STAGING" {
+		v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(v.searchResults) != 1 || v.searchResults[0] != 1 || v.following {
+		t.Errorf("Expected one match on the second shown line and following stopped, got %v", v.searchResults)
+	}
+}
+
+func TestEventTailViewIgnoresStoppedStreams(t *testing.T) {
+	v := NewEventTailView("web", []string{""})
+	v.SetSize(120, 20)
+	v.gen = 2
+
+	v.Update(eventTailWatchMsg{gen: 1, event: eventTailTestEvent("a", "web", "api-1", v1.EventTypeNormal, "Pulled", "Pulled image", 1, time.Now())})
+	if len(v.lines) != 0 {
+		t.Error("Expected events of a stopped stream dropped")
+	}
+	if view := v.View(); !strings.Contains(view, "namespace web") || !strings.Contains(view, "Waiting for events") {
+		t.Errorf("Expected an empty tail of web, got:\n%s", view)
+	}
+}
+
+func TestEventTailViewExport(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	v := NewEventTailView("web", []string{""})
+	v.SetSize(120, 20)
+	v.Add("", eventTailTestEvent("a", "web", "api-1", v1.EventTypeWarning, "Failed", "Error: \x1b[31mImagePullBackOff", 1, time.Now()))
+
+	_, cmd := v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	msg, ok := cmd().(eventTailExportedMsg)
+	if !ok || msg.err != nil || msg.lines != 1 {
+		t.Fatalf("Expected one line exported, got %+v", msg)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, msg.path))
+	if err != nil || !strings.Contains(string(data), "pod/api-1: Error: ImagePullBackOff") {
+		t.Errorf("Expected the line in the file, got %q (%v)", data, err)
+	}
+	v.Update(msg)
+	if view := v.View(); !strings.Contains(view, "Exported 1 lines to kubewatch-events-") {
+		t.Errorf("Expected the path in the status line, got:\n%s", view)
+	}
+}
@@ -0,0 +1,244 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// EventsView lists the events about one resource, newest first, or every
+// event in the namespace when no resource was selected. The app reloads it
+// on the list's refresh interval.
+type EventsView struct {
+	kind      string // Empty for the namespace's events
+	name      string
+	uid       types.UID
+	namespace string // Empty for all namespaces
+	context   string
+
+	events []v1.Event
+	loaded bool
+	err    error
+	offset int // First event shown
+
+	width  int
+	height int
+}
+
+// NewEventsView creates an events overlay for the resource of kind named
+// name, or for the whole namespace when name is empty. A uid leaves out the
+// events of earlier objects of the same name.
+func NewEventsView(kind, name string, uid types.UID, namespace, context string) *EventsView {
+	return &EventsView{
+		kind:      kind,
+		name:      name,
+		uid:       uid,
+		namespace: namespace,
+		context:   context,
+	}
+}
+
+// Init initializes the view
+func (v *EventsView) Init() tea.Cmd {
+	return nil
+}
+
+// LoadWithClient fetches the events
+func (v *EventsView) LoadWithClient(ctx context.Context, client *k8s.Client) tea.Cmd {
+	namespace, kind, name, uid := v.namespace, v.kind, v.name, v.uid
+	return func() tea.Msg {
+		events, err := client.GetEventsForObject(ctx, namespace, kind, name, uid)
+		return eventsLoadedMsg{events: events, err: err}
+	}
+}
+
+// Update handles messages. Esc and r are handled by the events mode.
+func (v *EventsView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case eventsLoadedMsg:
+		v.setEvents(msg.events, msg.err)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			v.scroll(-1)
+		case "down", "j":
+			v.scroll(1)
+		case "pgup":
+			v.scroll(-v.visibleRows())
+		case "pgdown":
+			v.scroll(v.visibleRows())
+		case "home", "g":
+			v.offset = 0
+		case "end", "G":
+			v.scroll(len(v.events))
+		}
+	}
+	return v, nil
+}
+
+// setEvents shows the events fetched, newest first. A failed reload keeps
+// the events last shown under the error.
+func (v *EventsView) setEvents(events []v1.Event, err error) {
+	v.loaded = true
+	v.err = err
+	if err != nil {
+		return
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return k8s.EventLastSeen(events[i]).After(k8s.EventLastSeen(events[j]))
+	})
+	v.events = events
+	v.scroll(0)
+}
+
+// scroll moves the first event shown by delta, within the events
+func (v *EventsView) scroll(delta int) {
+	v.offset = min(max(v.offset+delta, 0), max(len(v.events)-v.visibleRows(), 0))
+}
+
+// visibleRows is how many events fit under the title, header and hints
+func (v *EventsView) visibleRows() int {
+	return max(v.height-12, 1)
+}
+
+// Events returns the events shown, newest first
+func (v *EventsView) Events() []v1.Event {
+	return v.events
+}
+
+// GetContext returns the context the events are read from
+func (v *EventsView) GetContext() string {
+	return v.context
+}
+
+// View renders the events overlay
+func (v *EventsView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	warningStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196"))
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(v.title()))
+	content.WriteString("\n")
+	if v.context != "" {
+		content.WriteString(labelStyle.Render("Context: " + v.context))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	if v.err != nil {
+		content.WriteString(warningStyle.Render("Could not load events: " + k8s.UserMessage(v.err)))
+		content.WriteString("\n")
+	}
+	switch {
+	case !v.loaded:
+		content.WriteString("Loading events...\n")
+	case len(v.events) == 0 && v.err == nil:
+		content.WriteString(labelStyle.Render("No events"))
+		content.WriteString("\n")
+	case len(v.events) > 0:
+		content.WriteString(v.renderEvents(labelStyle, warningStyle))
+	}
+
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render("[↑/↓] Scroll  [r] Refresh  [Esc/v] Close"))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// title names what the events are about, e.g. "Events: Pod default/web-1"
+func (v *EventsView) title() string {
+	if v.name == "" {
+		if v.namespace == "" {
+			return "Events in all namespaces"
+		}
+		return "Events in namespace " + v.namespace
+	}
+	ref := core.ResourceRef{Namespace: v.namespace, Name: v.name}
+	return fmt.Sprintf("Events: %s %s", v.kind, ref)
+}
+
+// renderEvents renders the events that fit as a table, warnings in red. The
+// namespace's events name the object each is about.
+func (v *EventsView) renderEvents(labelStyle, warningStyle lipgloss.Style) string {
+	withObject := v.name == ""
+	messageWidth := max(v.width-60, 20)
+	if withObject {
+		messageWidth = max(messageWidth-32, 20)
+	}
+
+	var b strings.Builder
+	header := fmt.Sprintf("%-7s  %-20s  %-5s  %5s  ", "TYPE", "REASON", "AGE", "COUNT")
+	if withObject {
+		header += fmt.Sprintf("%-30s  ", "OBJECT")
+	}
+	b.WriteString(labelStyle.Render(header + "MESSAGE"))
+	b.WriteString("\n")
+
+	end := min(v.offset+v.visibleRows(), len(v.events))
+	for _, event := range v.events[v.offset:end] {
+		line := fmt.Sprintf("%-7s  %-20s  %-5s  %5d  ", event.Type, truncateCell(event.Reason, 20),
+			core.FormatAge(k8s.EventLastSeen(event)), k8s.EventCount(event))
+		if withObject {
+			object := event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name
+			line += fmt.Sprintf("%-30s  ", truncateCell(object, 30))
+		}
+		line += truncateCell(strings.Join(strings.Fields(event.Message), " "), messageWidth)
+		if event.Type == v1.EventTypeWarning {
+			line = warningStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if len(v.events) > end-v.offset {
+		b.WriteString(labelStyle.Render(fmt.Sprintf("%d-%d of %d events", v.offset+1, end, len(v.events))))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// SetSize updates the view size
+func (v *EventsView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.scroll(0)
+}
+
+// eventsLoadedMsg is sent when the events overlay's events have been fetched
+type eventsLoadedMsg struct {
+	events []v1.Event
+	err    error
+}
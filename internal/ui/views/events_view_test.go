@@ -0,0 +1,103 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func eventsViewEvent(reason, eventType, object, message string, lastSeen time.Time) v1.Event {
+	return v1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: reason, UID: types.UID("uid-" + reason)},
+		InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: object, Namespace: "default"},
+		Reason:         reason,
+		Type:           eventType,
+		Message:        message,
+		LastTimestamp:  metav1.NewTime(lastSeen),
+	}
+}
+
+func TestEventsViewNewestFirst(t *testing.T) {
+	now := time.Now()
+	view := NewEventsView("Pod", "web-1", "pod-uid", "default", "")
+	view.SetSize(140, 40)
+	if !strings.Contains(view.View(), "Loading events...") {
+		t.Errorf("Expected loading until the events arrive, got:\n%s", view.View())
+	}
+
+	view.Update(eventsLoadedMsg{events: []v1.Event{
+		eventsViewEvent("Scheduled", v1.EventTypeNormal, "web-1", "Successfully assigned default/web-1", now.Add(-10*time.Minute)),
+		eventsViewEvent("BackOff", v1.EventTypeWarning, "web-1", "Back-off restarting\nfailed container", now.Add(-time.Minute)),
+		eventsViewEvent("Pulled", v1.EventTypeNormal, "web-1", "Container image pulled", now.Add(-5*time.Minute)),
+	}})
+	var reasons []string
+	for _, event := range view.Events() {
+		reasons = append(reasons, event.Reason)
+	}
+	if strings.Join(reasons, ",") != "BackOff,Pulled,Scheduled" {
+		t.Errorf("Expected the events newest first, got %v", reasons)
+	}
+
+	out := view.View()
+	for _, want := range []string{"Events: Pod default/web-1", "TYPE", "REASON", "AGE", "COUNT", "MESSAGE", "Back-off restarting failed container"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in the events, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "OBJECT") {
+		t.Errorf("Expected no OBJECT column for one resource's events, got:\n%s", out)
+	}
+
+	// A failed reload keeps the events under the error
+	view.Update(eventsLoadedMsg{err: errors.New("connection refused")})
+	if out := view.View(); !strings.Contains(out, "Could not load events") || !strings.Contains(out, "BackOff") {
+		t.Errorf("Expected the error over the last events, got:\n%s", out)
+	}
+}
+
+func TestEventsViewColorsWarnings(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	defer lipgloss.SetColorProfile(profile)
+
+	now := time.Now()
+	view := NewEventsView("Pod", "web-1", "", "default", "")
+	view.SetSize(140, 40)
+	view.Update(eventsLoadedMsg{events: []v1.Event{
+		eventsViewEvent("BackOff", v1.EventTypeWarning, "web-1", "Back-off restarting failed container", now),
+		eventsViewEvent("Pulled", v1.EventTypeNormal, "web-1", "Container image pulled", now),
+	}})
+
+	red := "\x1b[38;5;196m"
+	for _, line := range strings.Split(view.View(), "\n") {
+		switch {
+		case strings.Contains(line, "BackOff") && !strings.Contains(line, red):
+			t.Errorf("Expected the warning in red, got %q", line)
+		case strings.Contains(line, "Pulled") && strings.Contains(line, red):
+			t.Errorf("Expected the normal event uncolored, got %q", line)
+		}
+	}
+}
+
+func TestEventsViewNamespace(t *testing.T) {
+	view := NewEventsView("", "", "", "default", "")
+	view.SetSize(160, 40)
+	view.Update(eventsLoadedMsg{})
+	if out := view.View(); !strings.Contains(out, "Events in namespace default") || !strings.Contains(out, "No events") {
+		t.Errorf("Expected the namespace's empty events, got:\n%s", out)
+	}
+
+	view.Update(eventsLoadedMsg{events: []v1.Event{
+		eventsViewEvent("Killing", v1.EventTypeNormal, "api-7", "Stopping container api", time.Now()),
+	}})
+	if out := view.View(); !strings.Contains(out, "OBJECT") || !strings.Contains(out, "Pod/api-7") {
+		t.Errorf("Expected the namespace's events to name their objects, got:\n%s", out)
+	}
+}
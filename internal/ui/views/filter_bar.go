@@ -0,0 +1,168 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/components/textinput"
+	"github.com/HamStudy/kubewatch/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FilterBar edits the list filter expression on a single line below the list.
+// Ctrl+S switches to naming the expression so it can be saved to the config.
+// Refreshes of the list never touch what is being typed.
+type FilterBar struct {
+	input  textinput.Model
+	naming bool
+	name   textinput.Model
+
+	status   string
+	statusOK bool
+
+	width int
+}
+
+// NewFilterBar creates a filter bar starting from the current expression
+func NewFilterBar(expression string) *FilterBar {
+	b := &FilterBar{input: textinput.New(validateFilter)}
+	b.input.SetValue(expression)
+	return b
+}
+
+// validateFilter checks that the expression being typed parses, so a mistake
+// is shown while typing rather than when Enter does nothing
+func validateFilter(expression string) error {
+	_, err := core.ParseFilter(strings.TrimSpace(expression))
+	return err
+}
+
+// Init initializes the view
+func (b *FilterBar) Init() tea.Cmd {
+	return nil
+}
+
+// IsNaming returns true while the filter name is being typed
+func (b *FilterBar) IsNaming() bool {
+	return b.naming
+}
+
+// Expression returns the expression being edited
+func (b *FilterBar) Expression() string {
+	return strings.TrimSpace(b.input.Value())
+}
+
+// Update handles messages
+func (b *FilterBar) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return b, nil
+	}
+	if b.naming {
+		return b, b.handleNameKey(keyMsg)
+	}
+
+	// Enter and Esc on the expression are handled by the filter mode
+	switch keyMsg.Type {
+	case tea.KeyCtrlS:
+		if b.Expression() == "" {
+			b.SetStatus("Type a filter before saving it", false)
+			return b, nil
+		}
+		if !b.input.Submit() {
+			b.status = ""
+			return b, nil
+		}
+		b.naming = true
+		b.status = ""
+
+	default:
+		if b.input.HandleKey(keyMsg) {
+			b.status = ""
+		}
+	}
+	return b, nil
+}
+
+// handleNameKey handles keys while the saved filter name is being typed
+func (b *FilterBar) handleNameKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		// Back to editing the expression
+		b.naming = false
+		b.status = ""
+
+	case tea.KeyEnter:
+		name := strings.TrimSpace(b.name.Value())
+		if name == "" {
+			b.SetStatus("Name the filter to save it", false)
+			return nil
+		}
+		expression := b.Expression()
+		return func() tea.Msg { return SaveFilterRequestedMsg{Name: name, Expression: expression} }
+
+	default:
+		b.name.HandleKey(msg)
+	}
+	return nil
+}
+
+// SetStatus shows a message after the input
+func (b *FilterBar) SetStatus(status string, ok bool) {
+	b.status = status
+	b.statusOK = ok
+}
+
+// View renders the filter bar
+func (b *FilterBar) View() string {
+	promptStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86")).
+		Bold(true)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196"))
+
+	okStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("82"))
+
+	var line, hint string
+	if b.naming {
+		line = promptStyle.Render("Save filter as: ") + b.name.View()
+		hint = fmt.Sprintf("(%s)  [Enter] Save  [Esc] Back", b.Expression())
+	} else {
+		line = promptStyle.Render("/") + b.input.View()
+		hint = "[Enter] Apply  [Ctrl+G] All types  [Ctrl+S] Save as…  [Ctrl+U] Clear  [Esc] Cancel"
+	}
+
+	// The status, or else the expression's error, goes before the hint so
+	// it survives truncation
+	switch {
+	case b.status != "" && b.statusOK:
+		line += "  " + okStyle.Render(b.status)
+	case b.status != "":
+		line += "  " + errorStyle.Render(b.status)
+	case !b.naming && b.input.ErrorView() != "":
+		line += "  " + b.input.ErrorView()
+	}
+	line += "  " + labelStyle.Render(hint)
+
+	if b.width > 0 {
+		return lipgloss.NewStyle().MaxWidth(b.width).Render(line)
+	}
+	return line
+}
+
+// SetSize updates the view width
+func (b *FilterBar) SetSize(width, height int) {
+	b.width = width
+}
+
+// SaveFilterRequestedMsg is sent when the user saves the expression under a name
+type SaveFilterRequestedMsg struct {
+	Name       string
+	Expression string
+}
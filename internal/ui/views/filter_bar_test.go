@@ -0,0 +1,108 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFilterBarEditing(t *testing.T) {
+	tests := []struct {
+		name         string
+		initial      string
+		keys         []tea.KeyMsg
+		expectExpr   string
+		expectNaming bool
+		expectStatus string
+	}{
+		{
+			name:       "typed expression",
+			keys:       []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("web")}, {Type: tea.KeySpace}, {Type: tea.KeyRunes, Runes: []rune("status=Running")}},
+			expectExpr: "web status=Running",
+		},
+		{
+			name:       "edit current filter",
+			initial:    "webx",
+			keys:       []tea.KeyMsg{{Type: tea.KeyBackspace}},
+			expectExpr: "web",
+		},
+		{
+			name:       "clear",
+			initial:    "web",
+			keys:       []tea.KeyMsg{{Type: tea.KeyCtrlU}},
+			expectExpr: "",
+		},
+		{
+			name:         "invalid expression is not saved",
+			keys:         []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("status=")}, {Type: tea.KeyCtrlS}},
+			expectExpr:   "status=",
+			expectStatus: "missing a value",
+		},
+		{
+			name:         "empty expression is not saved",
+			keys:         []tea.KeyMsg{{Type: tea.KeyCtrlS}},
+			expectStatus: "Type a filter",
+		},
+		{
+			name:         "save asks for a name",
+			initial:      "web",
+			keys:         []tea.KeyMsg{{Type: tea.KeyCtrlS}},
+			expectExpr:   "web",
+			expectNaming: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewFilterBar(tt.initial)
+			for _, k := range tt.keys {
+				if _, cmd := b.Update(k); cmd != nil {
+					t.Errorf("Expected no command while editing, got %#v", cmd())
+				}
+			}
+
+			if b.Expression() != tt.expectExpr {
+				t.Errorf("Expected expression %q, got %q", tt.expectExpr, b.Expression())
+			}
+			if b.IsNaming() != tt.expectNaming {
+				t.Errorf("Expected naming=%v, got %v", tt.expectNaming, b.IsNaming())
+			}
+			if tt.expectStatus != "" && !strings.Contains(b.View(), tt.expectStatus) {
+				t.Errorf("Expected status %q in %q", tt.expectStatus, b.View())
+			}
+		})
+	}
+}
+
+func TestFilterBarSaveAs(t *testing.T) {
+	b := NewFilterBar("status=Pending")
+
+	b.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if !b.IsNaming() {
+		t.Fatal("Expected Ctrl+S to ask for a name")
+	}
+
+	// An empty name is not saved
+	if _, cmd := b.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("Expected no save without a name")
+	}
+
+	b.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("stuck")})
+	b.Update(tea.KeyMsg{Type: tea.KeySpace})
+	b.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("pods")})
+	_, cmd := b.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected a save request")
+	}
+	expected := SaveFilterRequestedMsg{Name: "stuck pods", Expression: "status=Pending"}
+	if msg := cmd(); msg != expected {
+		t.Errorf("Expected %#v, got %#v", expected, msg)
+	}
+
+	// Esc goes back to the expression rather than closing
+	b.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if b.IsNaming() {
+		t.Error("Expected Esc to return to the expression")
+	}
+}
@@ -0,0 +1,132 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FinalizerView lists a resource's finalizers so one can be picked for removal
+type FinalizerView struct {
+	target     string // e.g. "Pods default/web-1"
+	finalizers []string
+	selected   int
+
+	width  int
+	height int
+}
+
+// NewFinalizerView creates a picker over the finalizers set on target
+func NewFinalizerView(target string, finalizers []string) *FinalizerView {
+	return &FinalizerView{
+		target:     target,
+		finalizers: finalizers,
+	}
+}
+
+// Init initializes the view
+func (v *FinalizerView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (v *FinalizerView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if v.selected > 0 {
+				v.selected--
+			}
+		case "down", "j":
+			if v.selected < len(v.finalizers)-1 {
+				v.selected++
+			}
+		case "enter":
+			if finalizer := v.SelectedFinalizer(); finalizer != "" {
+				return v, func() tea.Msg { return FinalizerSelectedMsg{Finalizer: finalizer} }
+			}
+		}
+	}
+	return v, nil
+}
+
+// SelectedFinalizer returns the highlighted finalizer
+func (v *FinalizerView) SelectedFinalizer() string {
+	if v.selected < 0 || v.selected >= len(v.finalizers) {
+		return ""
+	}
+	return v.finalizers[v.selected]
+}
+
+// View renders the finalizer picker
+func (v *FinalizerView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("1"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	warnStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(true)
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("1")).
+		Padding(1, 2)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Remove Finalizer"))
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render(v.target))
+	content.WriteString("\n\n")
+	content.WriteString(warnStyle.Render("A finalizer lets a controller clean up before the object goes away.\nRemove one only if its controller is gone or stuck for good."))
+	content.WriteString("\n\n")
+
+	for i, finalizer := range v.finalizers {
+		line := fmt.Sprintf("%d. %s", i+1, finalizer)
+		if i == v.selected {
+			content.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render("[↑/↓] Select  [Enter] Remove...  [Esc] Cancel"))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// SetSize updates the view size
+func (v *FinalizerView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// FinalizerSelectedMsg is sent when the user picks a finalizer to remove
+type FinalizerSelectedMsg struct {
+	Finalizer string
+}
@@ -0,0 +1,408 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/components/textinput"
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// FleetSearchParallel is how many contexts a fleet search asks at once
+	FleetSearchParallel = 4
+
+	// FleetSearchTimeout is how long a fleet search waits for one context,
+	// so one unreachable cluster cannot hold up the rest
+	FleetSearchTimeout = 10 * time.Second
+)
+
+// FleetView finds pods by name in every configured context at once, for
+// when the same app runs in many clusters and one of them misbehaves. Each
+// context's pods are listed as soon as it answers, unhealthy ones first.
+type FleetView struct {
+	contexts []string
+	query    core.FleetQuery
+
+	// Typing the query, before the first search
+	editing bool
+	input   textinput.Model
+
+	pods     []core.FleetPod
+	pending  map[string]bool
+	errs     map[string]error
+	selected int
+
+	// gen tells results of an abandoned search apart; cancel abandons it
+	gen    int
+	cancel context.CancelFunc
+
+	width  int
+	height int
+}
+
+// NewFleetView creates a fleet search over contexts. An empty query is
+// asked for before anything is searched.
+func NewFleetView(contexts []string, query core.FleetQuery) *FleetView {
+	sorted := append([]string(nil), contexts...)
+	sort.Strings(sorted)
+	v := &FleetView{
+		contexts: sorted,
+		query:    query,
+		editing:  query.Pattern == "",
+		pending:  make(map[string]bool),
+		errs:     make(map[string]error),
+		input:    textinput.New(validateFleetQuery),
+	}
+	v.input.SetValue(query.String())
+	return v
+}
+
+// validateFleetQuery checks a query as it is typed: it needs a pattern, and a
+// namespace given before a / must be one that can exist
+func validateFleetQuery(text string) error {
+	query := core.ParseFleetQuery(text)
+	if query.Namespace != "" {
+		if err := textinput.KubernetesName()(query.Namespace); err != nil {
+			return fmt.Errorf("namespace %v", err)
+		}
+	}
+	if query.Pattern == "" {
+		return errors.New("type part of a pod name to search for")
+	}
+	return nil
+}
+
+// Init initializes the view
+func (v *FleetView) Init() tea.Cmd {
+	return nil
+}
+
+// IsEditing returns true while the query is being typed
+func (v *FleetView) IsEditing() bool {
+	return v.editing
+}
+
+// CancelEditing stops typing a new query and goes back to the results of
+// the last one, returning false when nothing was searched yet
+func (v *FleetView) CancelEditing() bool {
+	if v.query.Pattern == "" {
+		return false
+	}
+	v.editing = false
+	return true
+}
+
+// Query returns what is searched for
+func (v *FleetView) Query() core.FleetQuery {
+	return v.query
+}
+
+// Search asks every context for the query's pods, FleetSearchParallel at a
+// time and each within FleetSearchTimeout, dropping what an earlier search
+// found. connect returns a context's client.
+func (v *FleetView) Search(ctx context.Context, connect func(contextName string) (*k8s.Client, error)) tea.Cmd {
+	v.Stop()
+	ctx, v.cancel = context.WithCancel(ctx)
+	v.gen++
+	v.pods = nil
+	v.selected = 0
+	v.errs = make(map[string]error)
+	v.pending = make(map[string]bool)
+
+	gen, query := v.gen, v.query
+	slots := make(chan struct{}, FleetSearchParallel)
+	var cmds []tea.Cmd
+	for _, contextName := range v.contexts {
+		contextName := contextName
+		v.pending[contextName] = true
+		cmds = append(cmds, func() tea.Msg {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return nil
+			}
+			defer func() { <-slots }()
+
+			msg := FleetResultMsg{gen: gen, context: contextName}
+			client, err := connect(contextName)
+			if err != nil {
+				msg.err = err
+				return msg
+			}
+			searchCtx, cancel := context.WithTimeout(ctx, FleetSearchTimeout)
+			defer cancel()
+			pods, err := client.FindPods(searchCtx, query)
+			if errors.Is(searchCtx.Err(), context.DeadlineExceeded) {
+				err = fmt.Errorf("no answer within %s", core.FormatDuration(FleetSearchTimeout))
+			}
+			msg.err = err
+			for _, pod := range pods {
+				msg.pods = append(msg.pods, core.FleetPod{Context: contextName, Pod: pod})
+			}
+			return msg
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// Stop abandons the search in progress
+func (v *FleetView) Stop() {
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+}
+
+// Update handles messages. Esc, searching and the actions on a pod are
+// handled by the fleet mode.
+func (v *FleetView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case FleetResultMsg:
+		if msg.gen == v.gen {
+			v.addResult(msg)
+		}
+
+	case tea.KeyMsg:
+		if v.editing {
+			if msg.Type == tea.KeyEnter {
+				if v.input.Submit() {
+					v.query = core.ParseFleetQuery(v.input.Value())
+					v.editing = false
+					return v, func() tea.Msg { return FleetSearchRequestedMsg{} }
+				}
+				return v, nil
+			}
+			v.input.HandleKey(msg)
+			return v, nil
+		}
+
+		switch msg.String() {
+		case "up", "k":
+			if v.selected > 0 {
+				v.selected--
+			}
+		case "down", "j":
+			if v.selected < len(v.pods)-1 {
+				v.selected++
+			}
+		case "home", "g":
+			v.selected = 0
+		case "end", "G":
+			v.selected = max(len(v.pods)-1, 0)
+		case "/":
+			v.editing = true
+			v.input.SetValue(v.query.String())
+		}
+	}
+	return v, nil
+}
+
+// addResult merges a context's answer into the list, keeping the same pod
+// selected
+func (v *FleetView) addResult(msg FleetResultMsg) {
+	delete(v.pending, msg.context)
+	if msg.err != nil {
+		v.errs[msg.context] = msg.err
+	}
+	if len(msg.pods) == 0 {
+		return
+	}
+
+	previous, hadSelection := v.SelectedPod()
+	v.pods = append(v.pods, msg.pods...)
+	core.SortFleetPods(v.pods, time.Now())
+	if hadSelection {
+		for i, found := range v.pods {
+			if found.Context == previous.Context && found.Pod.Namespace == previous.Pod.Namespace && found.Pod.Name == previous.Pod.Name {
+				v.selected = i
+				break
+			}
+		}
+	}
+}
+
+// SelectedPod returns the highlighted pod
+func (v *FleetView) SelectedPod() (core.FleetPod, bool) {
+	if v.selected < 0 || v.selected >= len(v.pods) {
+		return core.FleetPod{}, false
+	}
+	return v.pods[v.selected], true
+}
+
+// IsSearching returns true while some context has not answered
+func (v *FleetView) IsSearching() bool {
+	return len(v.pending) > 0
+}
+
+// View renders the fleet search
+func (v *FleetView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	warnStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		Bold(true)
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(true)
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(fmt.Sprintf("Fleet search (%d contexts)", len(v.contexts))))
+	content.WriteString("\n")
+
+	if v.editing {
+		content.WriteString("\n")
+		content.WriteString("Pod name: " + v.input.View())
+		content.WriteString("\n")
+		if errView := v.input.ErrorView(); errView != "" {
+			content.WriteString("          " + errView + "\n")
+		}
+		content.WriteString("\n")
+		content.WriteString(labelStyle.Render("Part of a name, or a pattern with * such as checkout-*; namespace/name searches one namespace"))
+		content.WriteString("\n\n")
+		content.WriteString(labelStyle.Render("[Enter] Search  [Esc] Close"))
+		return v.place(borderStyle, content.String())
+	}
+
+	where := "all namespaces"
+	if v.query.Namespace != "" {
+		where = "namespace " + v.query.Namespace
+	}
+	content.WriteString(labelStyle.Render(fmt.Sprintf("Pods matching %q in %s", v.query.Pattern, where)))
+	content.WriteString("\n")
+
+	answered := len(v.contexts) - len(v.pending)
+	progress := fmt.Sprintf("%d of %d contexts answered", answered, len(v.contexts))
+	if v.IsSearching() {
+		progress += ", searching…"
+	}
+	content.WriteString(labelStyle.Render(progress))
+	content.WriteString("\n\n")
+
+	content.WriteString(v.renderPods(labelStyle, warnStyle, selectedStyle))
+
+	if len(v.errs) > 0 {
+		content.WriteString("\n")
+		var failed []string
+		for contextName := range v.errs {
+			failed = append(failed, contextName)
+		}
+		sort.Strings(failed)
+		for _, contextName := range failed {
+			content.WriteString(errorStyle.Render(fmt.Sprintf("✗ %s: %s", contextName, k8s.UserMessage(v.errs[contextName]))))
+			content.WriteString("\n")
+		}
+	}
+
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render("[↑/↓] Select  [Enter/l] Logs  [d] Describe  [/] New search  [r] Search again  [Esc] Close"))
+	return v.place(borderStyle, content.String())
+}
+
+// place centres the bordered content on the screen
+func (v *FleetView) place(borderStyle lipgloss.Style, content string) string {
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content),
+	)
+}
+
+// renderPods lists the pods found, keeping the selection on screen when the
+// list is taller than the panel
+func (v *FleetView) renderPods(labelStyle, warnStyle, selectedStyle lipgloss.Style) string {
+	if len(v.pods) == 0 {
+		if v.IsSearching() {
+			return labelStyle.Render("  Nothing found yet") + "\n"
+		}
+		return labelStyle.Render("  No matching pods") + "\n"
+	}
+
+	start, end := 0, len(v.pods)
+	if maxItems := max(v.height-14-len(v.errs), 3); len(v.pods) > maxItems {
+		start = min(max(v.selected-maxItems/2, 0), len(v.pods)-maxItems)
+		end = start + maxItems
+	}
+
+	now := time.Now()
+	var b strings.Builder
+	b.WriteString(labelStyle.Render(fmt.Sprintf("  %-20s %-18s %-36s %-6s %-18s %8s %6s", "CONTEXT", "NAMESPACE", "NAME", "READY", "STATUS", "RESTARTS", "AGE")))
+	b.WriteString("\n")
+	if start > 0 {
+		b.WriteString(labelStyle.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+	}
+	for i := start; i < end; i++ {
+		found := &v.pods[i]
+		pod := &found.Pod
+		ready := 0
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Ready {
+				ready++
+			}
+		}
+		line := fmt.Sprintf("%-20s %-18s %-36s %-6s %-18s %8d %6s",
+			truncateCell(found.Context, 20), truncateCell(pod.Namespace, 18), truncateCell(pod.Name, 36),
+			fmt.Sprintf("%d/%d", ready, len(pod.Spec.Containers)), truncateCell(core.PodStatus(pod, now), 18),
+			core.PodRestarts(pod), core.FormatAge(pod.CreationTimestamp.Time))
+		switch {
+		case i == v.selected:
+			b.WriteString(selectedStyle.Render("> " + line))
+		case core.PodUnhealthy(pod, now):
+			b.WriteString(warnStyle.Render("  " + line))
+		default:
+			b.WriteString("  " + line)
+		}
+		b.WriteString("\n")
+	}
+	if end < len(v.pods) {
+		b.WriteString(labelStyle.Render(fmt.Sprintf("  ↓ %d more", len(v.pods)-end)) + "\n")
+	}
+	return b.String()
+}
+
+// SetSize updates the view size
+func (v *FleetView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// FleetResultMsg is one context's answer to a fleet search
+type FleetResultMsg struct {
+	gen     int
+	context string
+	pods    []core.FleetPod
+	err     error
+}
+
+// FleetSearchRequestedMsg is sent when a fleet search query has been typed
+type FleetSearchRequestedMsg struct{}
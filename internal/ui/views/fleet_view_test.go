@@ -0,0 +1,97 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fleetTestPod is a pod found in a context, restarting restarts times
+func fleetTestPod(context, name string, phase v1.PodPhase, restarts int32) core.FleetPod {
+	return core.FleetPod{Context: context, Pod: v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: name},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app"}}},
+		Status: v1.PodStatus{Phase: phase, ContainerStatuses: []v1.ContainerStatus{
+			{Name: "app", Ready: phase == v1.PodRunning, RestartCount: restarts},
+		}},
+	}}
+}
+
+func TestFleetViewListsResultsAsTheyArrive(t *testing.T) {
+	v := NewFleetView([]string{"us", "eu", "ap"}, core.FleetQuery{Pattern: "checkout"})
+	v.SetSize(200, 40)
+	v.gen = 1
+	for _, contextName := range v.contexts {
+		v.pending[contextName] = true
+	}
+
+	v.Update(FleetResultMsg{gen: 1, context: "us", pods: []core.FleetPod{fleetTestPod("us", "checkout-a", v1.PodRunning, 0)}})
+	if !v.IsSearching() || !strings.Contains(v.View(), "1 of 3 contexts answered") {
+		t.Errorf("Expected the search still running after one answer:\n%s", v.View())
+	}
+
+	// The selection stays on the same pod as other contexts answer
+	v.Update(FleetResultMsg{gen: 1, context: "eu", pods: []core.FleetPod{
+		fleetTestPod("eu", "checkout-b", v1.PodPending, 0),
+		fleetTestPod("eu", "checkout-c", v1.PodRunning, 5),
+	}})
+	if found, _ := v.SelectedPod(); found.Context != "us" || found.Pod.Name != "checkout-a" {
+		t.Errorf("Expected the selection kept on us/checkout-a, got %s/%s", found.Context, found.Pod.Name)
+	}
+	if first := v.pods[0]; first.Pod.Name != "checkout-b" {
+		t.Errorf("Expected the pending pod first, got %s", first.Pod.Name)
+	}
+
+	// A stale answer is dropped, a failed context reported
+	v.Update(FleetResultMsg{gen: 0, context: "ap", pods: []core.FleetPod{fleetTestPod("ap", "old", v1.PodRunning, 0)}})
+	v.Update(FleetResultMsg{gen: 1, context: "ap", err: errors.New("connection refused")})
+	if v.IsSearching() || len(v.pods) != 3 {
+		t.Errorf("Expected the search done with 3 pods, got %d (searching %v)", len(v.pods), v.IsSearching())
+	}
+	view := v.View()
+	for _, want := range []string{"3 of 3 contexts answered", "checkout-c", "✗ ap: connection refused"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the view:\n%s", want, view)
+		}
+	}
+}
+
+func TestFleetViewAsksForQuery(t *testing.T) {
+	v := NewFleetView([]string{"us"}, core.FleetQuery{})
+	if !v.IsEditing() || v.CancelEditing() {
+		t.Fatal("Expected the query asked for, with nothing to go back to")
+	}
+
+	// Enter does nothing until the query can be searched
+	if _, cmd := v.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || !strings.Contains(v.View(), "type part of a pod name") {
+		t.Fatalf("Expected an empty query refused inline, got:\n%s", v.View())
+	}
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Payments/checkout")})
+	if _, cmd := v.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || !strings.Contains(v.View(), "namespace must be lowercase") {
+		t.Fatalf("Expected an impossible namespace refused inline, got:\n%s", v.View())
+	}
+	v.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("payments/checkout")})
+	_, cmd := v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if v.IsEditing() || cmd == nil {
+		t.Fatal("Expected Enter to request a search")
+	}
+	if _, ok := cmd().(FleetSearchRequestedMsg); !ok {
+		t.Error("Expected a search request")
+	}
+	if q := v.Query(); q.Namespace != "payments" || q.Pattern != "checkout" {
+		t.Errorf("Expected the typed query, got %+v", q)
+	}
+
+	// A new search can be abandoned for the last results
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if !v.IsEditing() || !v.CancelEditing() || v.IsEditing() {
+		t.Error("Expected / to start a new query that Esc abandons")
+	}
+}
@@ -0,0 +1,86 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+)
+
+func (v *ResourceView) updateTableWithGateways(gateways []core.Gateway) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.updateColumnsForResourceType()
+
+	showNamespace := v.state.CurrentNamespace == "" || v.state.CurrentNamespace == "all"
+
+	// Preserve the currently selected resource
+	var selected core.ResourceRef
+	previousSelectedRow := v.selectedRow
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		selected = v.rowRef(v.table.RowValues(v.selectedRow))
+	}
+
+	rows := [][]string{}
+	newSelectedRow := -1
+	for i := range gateways {
+		gw := &gateways[i]
+		rowData := []string{gw.Name}
+		if showNamespace {
+			rowData = append(rowData, gw.Namespace)
+		}
+		rowData = append(rowData, gw.Spec.GatewayClassName, gw.AddressList(), gw.Programmed(), core.AgeOrStuck(gw.ObjectMeta, v.now()))
+		rows = append(rows, rowData)
+
+		if !selected.IsZero() && selected.Matches("", gw.Namespace, gw.Name) {
+			newSelectedRow = len(rows) - 1
+		}
+	}
+	v.table.SetValues(rows)
+
+	v.restoreSelection(newSelectedRow, previousSelectedRow)
+	v.sortRows()
+}
+
+func (v *ResourceView) updateTableWithHTTPRoutes(routes []core.HTTPRoute) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.updateColumnsForResourceType()
+
+	showNamespace := v.state.CurrentNamespace == "" || v.state.CurrentNamespace == "all"
+
+	// Preserve the currently selected resource
+	var selected core.ResourceRef
+	previousSelectedRow := v.selectedRow
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		selected = v.rowRef(v.table.RowValues(v.selectedRow))
+	}
+
+	rows := [][]string{}
+	newSelectedRow := -1
+	for i := range routes {
+		route := &routes[i]
+		hostnames := "*"
+		if len(route.Spec.Hostnames) > 0 {
+			hostnames = strings.Join(route.Spec.Hostnames, ",")
+		}
+		parents := "<none>"
+		if names := route.ParentNames(); len(names) > 0 {
+			parents = strings.Join(names, ",")
+		}
+
+		rowData := []string{route.Name}
+		if showNamespace {
+			rowData = append(rowData, route.Namespace)
+		}
+		rowData = append(rowData, hostnames, parents, core.AgeOrStuck(route.ObjectMeta, v.now()))
+		rows = append(rows, rowData)
+
+		if !selected.IsZero() && selected.Matches("", route.Namespace, route.Name) {
+			newSelectedRow = len(rows) - 1
+		}
+	}
+	v.table.SetValues(rows)
+
+	v.restoreSelection(newSelectedRow, previousSelectedRow)
+	v.sortRows()
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/HamStudy/kubewatch/internal/config/resource"
 	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
 	"github.com/HamStudy/kubewatch/internal/template"
 )
 
@@ -157,6 +158,7 @@ func (v *GenericResourceView) RefreshResources(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to list %s: %w", v.currentResourceType, err)
 	}
+	k8s.StripManagedFields(list)
 
 	// Convert to unstructured slice
 	v.resources = make([]*unstructured.Unstructured, len(list.Items))
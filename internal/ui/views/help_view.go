@@ -3,6 +3,7 @@ package views
 import (
 	"strings"
 
+	"github.com/HamStudy/kubewatch/internal/core"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -12,6 +13,15 @@ type HelpView struct {
 	width       int
 	height      int
 	contextMode string // "resource" or "logs"
+
+	// What l does for the listed resource type; help says "View logs"
+	// until SetLogs is called
+	logs     core.LogTarget
+	showLogs bool
+
+	// The memory held by cached resource lists, shown at the bottom
+	cache     core.CacheUsage
+	showCache bool
 }
 
 // NewHelpView creates a new help view
@@ -26,6 +36,18 @@ func (v *HelpView) SetContext(context string) {
 	v.contextMode = context
 }
 
+// SetLogs sets what viewing logs does for the listed resource type
+func (v *HelpView) SetLogs(target core.LogTarget) {
+	v.logs = target
+	v.showLogs = true
+}
+
+// SetCacheUsage sets the cached resource lists' size to show
+func (v *HelpView) SetCacheUsage(usage core.CacheUsage) {
+	v.cache = usage
+	v.showCache = true
+}
+
 // SetModeHelp sets help content from a screen mode
 func (v *HelpView) SetModeHelp(mode interface{}) {
 	// This will be used to auto-generate help from mode key bindings
@@ -41,12 +63,17 @@ func (v *HelpView) Init() tea.Cmd {
 func (v *HelpView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		v.width = msg.Width
-		v.height = msg.Height
+		v.SetSize(msg.Width, msg.Height)
 	}
 	return v, nil
 }
 
+// SetSize updates the view size
+func (v *HelpView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
 // View renders the help screen
 func (v *HelpView) View() string {
 	if v.contextMode == "logs" {
@@ -87,23 +114,57 @@ func (v *HelpView) renderResourceHelp() string {
 	help.WriteString(keyStyle.Render("Tab") + descStyle.Render("    Next resource type") + "\n")
 	help.WriteString(keyStyle.Render("S-Tab") + descStyle.Render("  Previous resource type") + "\n")
 	help.WriteString(keyStyle.Render("n") + descStyle.Render("      Change namespace") + "\n")
-	help.WriteString(keyStyle.Render("c") + descStyle.Render("      Switch contexts (multi-context mode)") + "\n")
+	help.WriteString(keyStyle.Render("*") + descStyle.Render("      All namespaces, when the namespace was deleted") + "\n")
+	help.WriteString(keyStyle.Render("c") + descStyle.Render("      Switch contexts (= compares two marked)") + "\n")
 
 	help.WriteString(sectionStyle.Render("Actions"))
 	help.WriteString("\n")
-	help.WriteString(keyStyle.Render("Enter/l") + descStyle.Render(" View logs") + "\n")
+	logs := "View logs"
+	if v.showLogs && v.logs.Applies() {
+		logs = v.logs.Description
+	} else if v.showLogs {
+		logs = v.logs.Hint
+	}
+	help.WriteString(keyStyle.Render("Enter/l") + descStyle.Render(" "+logs) + "\n")
+	help.WriteString(keyStyle.Render("d/i") + descStyle.Render("     Describe selected") + "\n")
 	help.WriteString(keyStyle.Render("Del/D") + descStyle.Render("   Delete selected") + "\n")
 	help.WriteString(keyStyle.Render("r") + descStyle.Render("       Manual refresh") + "\n")
 	help.WriteString(keyStyle.Render("s") + descStyle.Render("       Cycle sort column/direction") + "\n")
 	help.WriteString(keyStyle.Render("u") + descStyle.Render("       Toggle word wrap") + "\n")
+	help.WriteString(keyStyle.Render("T") + descStyle.Render("       Show topology spread") + "\n")
+	help.WriteString(keyStyle.Render("U") + descStyle.Render("       Usage by namespace") + "\n")
+	help.WriteString(keyStyle.Render("E") + descStyle.Render("       Tail the namespace's events") + "\n")
+	help.WriteString(keyStyle.Render("Ctrl+A") + descStyle.Render("  What can I do here? (permissions)") + "\n")
+	help.WriteString(keyStyle.Render("Ctrl+F") + descStyle.Render("  Find pods in every context") + "\n")
+	help.WriteString(keyStyle.Render("a") + descStyle.Render("       Show/hide READY/STATUS activity") + "\n")
+	help.WriteString(keyStyle.Render("A") + descStyle.Render("       Jump to an activity entry") + "\n")
+	help.WriteString(keyStyle.Render("/") + descStyle.Render("       Filter list (Ctrl+G all types, Ctrl+S save)") + "\n")
+	help.WriteString(keyStyle.Render("F") + descStyle.Render("       Saved filters") + "\n")
+	help.WriteString(keyStyle.Render("C") + descStyle.Render("       Reorder columns (Ctrl+S saves)") + "\n")
+	help.WriteString(keyStyle.Render("z") + descStyle.Render("       Hide/show completed pods and other noise") + "\n")
+	help.WriteString(keyStyle.Render("H") + descStyle.Render("       Scrub table history (←/→ to step)") + "\n")
+	help.WriteString(keyStyle.Render("Ctrl+S") + descStyle.Render("  Save view as the startup layout") + "\n")
+	help.WriteString(keyStyle.Render("!") + descStyle.Render("       Quick actions") + "\n")
+	help.WriteString(keyStyle.Render("+") + descStyle.Render("       Create from template") + "\n")
+	help.WriteString(keyStyle.Render("x") + descStyle.Render("       Related resources (Backspace returns)") + "\n")
+	help.WriteString(keyStyle.Render("p") + descStyle.Render("       Pods of the deployment or statefulset (Esc shows all)") + "\n")
+	help.WriteString(keyStyle.Render("e") + descStyle.Render("       Open a shell in the pod") + "\n")
+	help.WriteString(keyStyle.Render("R") + descStyle.Render("       Restart deployment or statefulset") + "\n")
+	help.WriteString(keyStyle.Render("S") + descStyle.Render("       Scale deployment or statefulset") + "\n")
 
 	help.WriteString(sectionStyle.Render("General"))
 	help.WriteString("\n")
+	help.WriteString(keyStyle.Render(",") + descStyle.Render("      Settings") + "\n")
+	help.WriteString(keyStyle.Render("Ctrl+X") + descStyle.Render(" Dismiss the new release or kubeconfig notice") + "\n")
 	help.WriteString(keyStyle.Render("?") + descStyle.Render("      Toggle help") + "\n")
-	help.WriteString(keyStyle.Render("q") + descStyle.Render("      Quit") + "\n")
-	help.WriteString(keyStyle.Render("Esc") + descStyle.Render("    Close dialog") + "\n")
+	help.WriteString(keyStyle.Render("q") + descStyle.Render("      Quit (closes other views, like Esc)") + "\n")
+	help.WriteString(keyStyle.Render("Ctrl+C") + descStyle.Render(" Quit from anywhere") + "\n")
+	help.WriteString(keyStyle.Render("Esc") + descStyle.Render("    Close dialog/Clear filter") + "\n")
 
 	help.WriteString("\n\n")
+	if v.showCache {
+		help.WriteString(descStyle.Render("Cache: "+v.cache.String()) + "\n")
+	}
 	help.WriteString(descStyle.Render("Press ? to close help"))
 
 	return lipgloss.Place(
@@ -147,7 +208,8 @@ func (v *HelpView) renderLogHelp() string {
 	help.WriteString(keyStyle.Render("PgUp") + descStyle.Render("   Page up") + "\n")
 	help.WriteString(keyStyle.Render("PgDn") + descStyle.Render("   Page down") + "\n")
 	help.WriteString(keyStyle.Render("Home/g") + descStyle.Render(" Jump to top") + "\n")
-	help.WriteString(keyStyle.Render("End/G") + descStyle.Render("  Jump to bottom (follow)") + "\n")
+	help.WriteString(keyStyle.Render("End/G/F") + descStyle.Render(" Jump to bottom and follow") + "\n")
+	help.WriteString(keyStyle.Render("←/→") + descStyle.Render("    Scroll long lines sideways") + "\n")
 
 	help.WriteString(sectionStyle.Render("Log Controls"))
 	help.WriteString("\n")
@@ -155,11 +217,20 @@ func (v *HelpView) renderLogHelp() string {
 	help.WriteString(keyStyle.Render("/") + descStyle.Render("      Search in logs") + "\n")
 	help.WriteString(keyStyle.Render("c") + descStyle.Render("      Cycle containers (all/individual)") + "\n")
 	help.WriteString(keyStyle.Render("p") + descStyle.Render("      Cycle pods (for deployments)") + "\n")
+	help.WriteString(keyStyle.Render("m") + descStyle.Render("      Toggle multi-line record grouping") + "\n")
+	help.WriteString(keyStyle.Render("a") + descStyle.Render("      Toggle log colors") + "\n")
 	help.WriteString(keyStyle.Render("C") + descStyle.Render("      Clear log buffer") + "\n")
 
+	help.WriteString(sectionStyle.Render("Split Logs"))
+	help.WriteString("\n")
+	help.WriteString(keyStyle.Render("v") + descStyle.Render("      Show another pod's logs beside these") + "\n")
+	help.WriteString(keyStyle.Render("Tab") + descStyle.Render("    Switch pane") + "\n")
+	help.WriteString(keyStyle.Render("L") + descStyle.Render("      Link the panes' scrolling") + "\n")
+
 	help.WriteString(sectionStyle.Render("General"))
 	help.WriteString("\n")
-	help.WriteString(keyStyle.Render("Esc/q") + descStyle.Render("  Close logs") + "\n")
+	help.WriteString(keyStyle.Render("Esc/q") + descStyle.Render("  Close logs, or the focused pane when split") + "\n")
+	help.WriteString(keyStyle.Render("Ctrl+S") + descStyle.Render(" Save the list and log split as the startup layout") + "\n")
 	help.WriteString(keyStyle.Render("?") + descStyle.Render("      Toggle help") + "\n")
 
 	help.WriteString("\n\n")
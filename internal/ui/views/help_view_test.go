@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/HamStudy/kubewatch/internal/core"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -92,6 +93,19 @@ func TestHelpViewResourceHelp(t *testing.T) {
 	}
 }
 
+func TestHelpViewCacheUsage(t *testing.T) {
+	view := NewHelpView()
+	view.SetSize(120, 80)
+	if strings.Contains(view.View(), "Cache:") {
+		t.Error("Expected no cache line before the usage is set")
+	}
+
+	view.SetCacheUsage(core.CacheUsage{Lists: 2, Objects: 40, Bytes: 3 * 1024})
+	if output := view.View(); !strings.Contains(output, "Cache: 2 lists, 40 objects, ~3.0 KiB") {
+		t.Errorf("Expected the cache usage at the bottom, got:\n%s", output)
+	}
+}
+
 func TestHelpViewLogHelp(t *testing.T) {
 	view := NewHelpView()
 	view.SetContext("logs")
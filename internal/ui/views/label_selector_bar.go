@@ -0,0 +1,88 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/components/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LabelSelectorBar edits the label selector the lists are narrowed by, as
+// kubectl -l takes it, on a single line below the list
+type LabelSelectorBar struct {
+	input  textinput.Model
+	status string
+	width  int
+}
+
+// NewLabelSelectorBar creates a label selector bar starting from the
+// current selector
+func NewLabelSelectorBar(selector string) *LabelSelectorBar {
+	b := &LabelSelectorBar{input: textinput.New(textinput.LabelSelector())}
+	b.input.SetValue(selector)
+	return b
+}
+
+// Init initializes the view
+func (b *LabelSelectorBar) Init() tea.Cmd {
+	return nil
+}
+
+// Selector returns the selector being edited
+func (b *LabelSelectorBar) Selector() string {
+	return strings.TrimSpace(b.input.Value())
+}
+
+// Submit reports whether the selector may be applied, showing why not when
+// it does not parse
+func (b *LabelSelectorBar) Submit() bool {
+	return b.input.Submit()
+}
+
+// Update handles messages. Enter and Esc are handled by the label selector
+// mode.
+func (b *LabelSelectorBar) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && b.input.HandleKey(keyMsg) {
+		b.status = ""
+	}
+	return b, nil
+}
+
+// SetStatus shows an error after the input
+func (b *LabelSelectorBar) SetStatus(status string) {
+	b.status = status
+}
+
+// View renders the label selector bar
+func (b *LabelSelectorBar) View() string {
+	promptStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86")).
+		Bold(true)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196"))
+
+	line := promptStyle.Render("Labels: ") + b.input.View()
+	// The error goes before the hint so it survives truncation
+	switch {
+	case b.status != "":
+		line += "  " + errorStyle.Render(b.status)
+	case b.input.ErrorView() != "":
+		line += "  " + b.input.ErrorView()
+	}
+	line += "  " + labelStyle.Render("e.g. app=web,tier!=cache  [Enter] Apply  [Ctrl+U] Clear  [Esc] Cancel")
+
+	if b.width > 0 {
+		return lipgloss.NewStyle().MaxWidth(b.width).Render(line)
+	}
+	return line
+}
+
+// SetSize updates the view width
+func (b *LabelSelectorBar) SetSize(width, height int) {
+	b.width = width
+}
@@ -0,0 +1,134 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LogPickerView asks which of a pod's containers to show the logs of, with
+// each container's state and restarts, before the log view opens
+type LogPickerView struct {
+	context    string
+	namespace  string
+	pod        string
+	containers []core.PodContainer
+	picker     containerPicker
+
+	width  int
+	height int
+}
+
+// NewLogPickerView creates a picker over a pod's containers, offering all
+// of its app containers first
+func NewLogPickerView(context, namespace, pod string, containers []core.PodContainer) *LogPickerView {
+	return &LogPickerView{
+		context:    context,
+		namespace:  namespace,
+		pod:        pod,
+		containers: containers,
+		picker:     newContainerPicker(podContainerNames(containers), true),
+	}
+}
+
+// Init initializes the view
+func (v *LogPickerView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages. Esc is handled by the log picker mode.
+func (v *LogPickerView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case tea.KeyMsg:
+		if result, choice := v.picker.handleKey(msg); result == pickerPicked {
+			picked := LogContainerPickedMsg{
+				Context:   v.context,
+				Namespace: v.namespace,
+				Pod:       v.pod,
+			}
+			if choice >= 0 {
+				picked.Container = v.containers[choice].Name
+			}
+			return v, func() tea.Msg { return picked }
+		}
+	}
+	return v, nil
+}
+
+// View renders the container picker
+func (v *LogPickerView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	// Room for the title, prompt, border and padding
+	height := len(v.containers) + 1
+	if room := v.height - 10; room > 0 && height > room {
+		height = room
+	}
+
+	names := make([]string, len(v.containers))
+	nameWidth := len("All containers")
+	for i, container := range v.containers {
+		names[i] = container.Name
+		if container.Kind != "" {
+			names[i] += " (" + container.Kind + ")"
+		}
+		nameWidth = max(nameWidth, len(names[i]))
+	}
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Show the logs of " + v.pod))
+	content.WriteString("\n\n")
+	content.WriteString(v.picker.render(height, func(choice int) string {
+		if choice < 0 {
+			return "All containers"
+		}
+		container := v.containers[choice]
+		label := fmt.Sprintf("%-*s  %s", nameWidth, names[choice], container.State)
+		if container.Restarts > 0 {
+			label += fmt.Sprintf(", %d restarts", container.Restarts)
+		}
+		return label
+	}))
+	content.WriteString("\n\n")
+	content.WriteString(v.picker.prompt("show logs"))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// SetSize updates the view size
+func (v *LogPickerView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// LogContainerPickedMsg is sent when the user picks the container to show
+// the logs of, "" for all of the pod's app containers
+type LogContainerPickedMsg struct {
+	Context   string
+	Namespace string
+	Pod       string
+	Container string
+}
@@ -0,0 +1,180 @@
+package views
+
+import (
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logSourceColors are the colors streams are told apart by
+var logSourceColors = []string{"39", "170", "214", "114", "204", "81", "220", "141"}
+
+// logPrefixer lays out the "[source]" prefixes of the lines of several
+// streams. Labels drop what every pod name shares and are padded to the
+// widest, so messages start in one column. Each pod keeps the color it was
+// first given for as long as the log view exists, however the streams change.
+type logPrefixer struct {
+	colors map[string]string // By colorKey, once assigned
+	labels map[string]string // By source, for the sources laid out
+	width  int               // Of the widest label laid out
+}
+
+// newLogPrefixer creates a prefixer with no streams laid out
+func newLogPrefixer() *logPrefixer {
+	return &logPrefixer{
+		colors: make(map[string]string),
+		labels: make(map[string]string),
+	}
+}
+
+// Has returns true when source is laid out
+func (p *logPrefixer) Has(source string) bool {
+	_, ok := p.labels[source]
+	return ok
+}
+
+// Layout labels sources, measures the widest label and colors the pods not
+// colored yet. Existing colors are never changed.
+func (p *logPrefixer) Layout(sources []string) {
+	p.labels = logLabels(sources)
+	p.width = 0
+	for _, label := range p.labels {
+		if width := lipgloss.Width(label); width > p.width {
+			p.width = width
+		}
+	}
+
+	// New pods are colored in name order, so the same set of pods gets the
+	// same colors whatever order their streams start in
+	var uncolored []string
+	seen := make(map[string]bool)
+	for _, source := range sources {
+		key := colorKey(source)
+		if _, ok := p.colors[key]; !ok && !seen[key] {
+			seen[key] = true
+			uncolored = append(uncolored, key)
+		}
+	}
+	sort.Strings(uncolored)
+
+	used := make(map[string]bool)
+	for _, source := range sources {
+		if color, ok := p.colors[colorKey(source)]; ok {
+			used[color] = true
+		}
+	}
+	for _, key := range uncolored {
+		color := spreadLogColor(key, used)
+		p.colors[key] = color
+		used[color] = true
+	}
+}
+
+// Prefix returns the padded prefix of a line of source and how much of it
+// is the bracketed label, which is colored. A source not laid out is
+// labelled in full.
+func (p *logPrefixer) Prefix(source string) (prefix string, label int) {
+	text, ok := p.labels[source]
+	if !ok {
+		text = source
+	}
+	bracketed := "[" + text + "]"
+	padding := 0
+	if ok {
+		padding = p.width - lipgloss.Width(text)
+	}
+	return bracketed + strings.Repeat(" ", padding+1), len(bracketed)
+}
+
+// Color returns the color of source's label
+func (p *logPrefixer) Color(source string) string {
+	if color, ok := p.colors[colorKey(source)]; ok {
+		return color
+	}
+	return logSourceColors[logColorIndex(colorKey(source))]
+}
+
+// colorKey is what a stream's color follows: the pod of a "pod/container"
+// stream, so a pod's containers share its color, or the stream itself
+func colorKey(source string) string {
+	if pod, _, ok := strings.Cut(source, "/"); ok {
+		return pod
+	}
+	return source
+}
+
+// logColorIndex hashes key to its preferred color
+func logColorIndex(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(logSourceColors)))
+}
+
+// spreadLogColor picks key's preferred color or, when another pod shown has
+// it, the next free one. With every color taken, pods share.
+func spreadLogColor(key string, used map[string]bool) string {
+	start := logColorIndex(key)
+	for i := 0; i < len(logSourceColors); i++ {
+		if color := logSourceColors[(start+i)%len(logSourceColors)]; !used[color] {
+			return color
+		}
+	}
+	return logSourceColors[start]
+}
+
+// logLabels labels streams for their prefixes. The pod names of
+// "pod/container" streams lose the prefix they all share, such as a
+// deployment's "checkout-7f9c4b-", and the container is left out when every
+// stream is of the same one.
+func logLabels(sources []string) map[string]string {
+	var pods []string
+	containers := make(map[string]bool)
+	for _, source := range sources {
+		if pod, container, ok := strings.Cut(source, "/"); ok {
+			pods = append(pods, pod)
+			containers[container] = true
+		}
+	}
+	common := commonPodPrefix(pods)
+
+	labels := make(map[string]string, len(sources))
+	for _, source := range sources {
+		pod, container, ok := strings.Cut(source, "/")
+		if !ok {
+			labels[source] = source
+			continue
+		}
+		label := strings.TrimPrefix(pod, common)
+		if len(containers) > 1 {
+			label += "/" + container
+		}
+		labels[source] = label
+	}
+	return labels
+}
+
+// commonPodPrefix returns the prefix of pod names, up to and including a
+// dash, that two or more differently named pods all share, e.g.
+// "checkout-7f9c4b-" of "checkout-7f9c4b-abcde" and "checkout-7f9c4b-fghij"
+func commonPodPrefix(pods []string) string {
+	distinct := make(map[string]bool)
+	for _, pod := range pods {
+		distinct[pod] = true
+	}
+	if len(distinct) < 2 {
+		return ""
+	}
+
+	common := pods[0]
+	for _, pod := range pods[1:] {
+		for !strings.HasPrefix(pod, common) {
+			common = common[:len(common)-1]
+		}
+	}
+	// Cut back to a dash, so "web-10" and "web-11" become "10" and "11"
+	// rather than "0" and "1"
+	dash := strings.LastIndex(common, "-")
+	return common[:dash+1]
+}
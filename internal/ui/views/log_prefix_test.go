@@ -0,0 +1,116 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCommonPodPrefix(t *testing.T) {
+	tests := []struct {
+		pods []string
+		want string
+	}{
+		{[]string{"checkout-7f9c4b-abcde", "checkout-7f9c4b-fghij"}, "checkout-7f9c4b-"},
+		{[]string{"web-10", "web-11"}, "web-"},
+		{[]string{"api-7f-abc", "worker-9d-xyz"}, ""},
+		{[]string{"web", "web-1"}, ""},
+		{[]string{"checkout-7f9c4b-abcde", "checkout-7f9c4b-abcde"}, ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := commonPodPrefix(tt.pods); got != tt.want {
+			t.Errorf("commonPodPrefix(%v) = %q, want %q", tt.pods, got, tt.want)
+		}
+	}
+}
+
+func TestLogLabels(t *testing.T) {
+	labels := logLabels([]string{"checkout-7f9c4b-abcde/app", "checkout-7f9c4b-fghij/app"})
+	if labels["checkout-7f9c4b-abcde/app"] != "abcde" || labels["checkout-7f9c4b-fghij/app"] != "fghij" {
+		t.Errorf("Expected the shared pod prefix and container elided, got %v", labels)
+	}
+
+	labels = logLabels([]string{"web-0/app", "web-0/proxy", "web-1/app"})
+	if labels["web-0/proxy"] != "0/proxy" || labels["web-1/app"] != "1/app" {
+		t.Errorf("Expected containers kept when they differ, got %v", labels)
+	}
+
+	// The containers of a single pod are named as they are
+	labels = logLabels([]string{"app", "istio-proxy"})
+	if labels["app"] != "app" || labels["istio-proxy"] != "istio-proxy" {
+		t.Errorf("Expected container streams unchanged, got %v", labels)
+	}
+}
+
+func TestLogPrefixerAlignsLabels(t *testing.T) {
+	p := newLogPrefixer()
+	p.Layout([]string{"app", "istio-proxy"})
+
+	short, label := p.Prefix("app")
+	long, _ := p.Prefix("istio-proxy")
+	if short != "[app]         " || label != len("[app]") {
+		t.Errorf("Expected the short label padded to the long one, got %q", short)
+	}
+	if len(short) != len(long) {
+		t.Errorf("Expected messages to start in one column, got %q and %q", short, long)
+	}
+	if unknown, _ := p.Prefix("sidecar"); unknown != "[sidecar] " {
+		t.Errorf("Expected a stream not laid out labelled in full, got %q", unknown)
+	}
+}
+
+func TestLogPrefixerColors(t *testing.T) {
+	// The same pod gets the same color in every session
+	first, second := newLogPrefixer(), newLogPrefixer()
+	first.Layout([]string{"web-0/app"})
+	second.Layout([]string{"web-0/app", "web-0/proxy"})
+	if first.Color("web-0/app") != second.Color("web-0/app") || second.Color("web-0/app") != second.Color("web-0/proxy") {
+		t.Error("Expected a pod's color to depend only on its name")
+	}
+
+	// Pods whose names hash alike are spread over free colors
+	p := newLogPrefixer()
+	var sources []string
+	for i := 0; i < len(logSourceColors); i++ {
+		sources = append(sources, fmt.Sprintf("web-%d/app", i))
+	}
+	p.Layout(sources)
+	used := make(map[string]bool)
+	for _, source := range sources {
+		used[p.Color(source)] = true
+	}
+	if len(used) != len(logSourceColors) {
+		t.Errorf("Expected %d pods to get %d different colors, got %d", len(sources), len(logSourceColors), len(used))
+	}
+
+	// Pods coming and going never recolor the pods that stay
+	before := p.Color("web-3/app")
+	p.Layout([]string{"web-3/app", "web-9/app", "web-10/app"})
+	if p.Color("web-3/app") != before {
+		t.Errorf("Expected web-3 to keep %s, got %s", before, p.Color("web-3/app"))
+	}
+}
+
+func TestLogViewRealignsWhenStreamsChange(t *testing.T) {
+	lv := createTestLogView(t)
+	lv.containers = []string{"checkout-7f9c4b-abcde/app", "checkout-7f9c4b-fghij/app"}
+	lv.Update(logStreamStartedMsg{})
+	lv.Update(logLineMsg{container: "checkout-7f9c4b-abcde/app", line: "started"})
+	if lv.content[0] != "[abcde] started" {
+		t.Fatalf("Expected the pod prefix elided, got %q", lv.content[0])
+	}
+	color := lv.prefixes.Color("checkout-7f9c4b-abcde/app")
+
+	// A pod of another replica set joins: the prefixes widen to match
+	lv.containers = append(lv.containers, "checkout-5d8e-klmno/app")
+	lv.Update(logStreamStartedMsg{})
+	lv.Update(logLineMsg{container: "checkout-5d8e-klmno/app", line: "ready"})
+	want := []string{"[7f9c4b-abcde] started", "[5d8e-klmno]   ready"}
+	if strings.Join(lv.content, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected every line re-laid out, got:\n%s", strings.Join(lv.content, "\n"))
+	}
+	if lv.prefixes.Color("checkout-7f9c4b-abcde/app") != color {
+		t.Error("Expected the existing pod's color kept")
+	}
+}
@@ -0,0 +1,346 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// logSampleWindow is how far back each log sample reaches
+	logSampleWindow = time.Minute
+
+	// logSampleLimitBytes bounds how much of a pod's logs one sample reads
+	logSampleLimitBytes = 256 * 1024
+
+	// logSampleTimeout bounds how long one sample may take. A sample whose
+	// result never arrives, e.g. because another view took the message, is
+	// given up after it.
+	logSampleTimeout = 30 * time.Second
+
+	// maxLogSamplesInFlight bounds how many samples run at once
+	maxLogSamplesInFlight = 2
+
+	// maxLogSamplesPerMinute bounds how many samples start each minute,
+	// whatever the interval and however many rows are visible
+	maxLogSamplesPerMinute = 30
+)
+
+// logRateTarget is a pod whose log rate is sampled; key identifies it the
+// way podDetailKey does
+type logRateTarget struct {
+	key       string
+	context   string
+	namespace string
+	pod       string
+	container string
+}
+
+// logRateResult is the latest sample of a pod and the rate before it
+type logRateResult struct {
+	rate      core.LogRate
+	previous  *core.LogRate
+	sampledAt time.Time
+	err       error
+}
+
+// logRateRun is a sample in flight
+type logRateRun struct {
+	id      uint64
+	cancel  context.CancelFunc
+	started time.Time
+}
+
+// LogRateSampler schedules log samples of the visible pods. It samples
+// each pod at most once per interval, and keeps the total cost low: a few
+// samples run at once, a fixed number start per minute, and samples of
+// pods scrolled out of view are cancelled.
+type LogRateSampler struct {
+	mu       sync.Mutex
+	interval time.Duration
+	visible  []logRateTarget
+	results  map[string]*logRateResult
+	inFlight map[string]logRateRun
+	started  []time.Time // Sample start times within the last minute
+	nextID   uint64
+}
+
+// NewLogRateSampler creates a sampler that samples each pod once per interval
+func NewLogRateSampler(interval time.Duration) *LogRateSampler {
+	return &LogRateSampler{
+		interval: interval,
+		results:  make(map[string]*logRateResult),
+		inFlight: make(map[string]logRateRun),
+	}
+}
+
+// SetInterval changes how often each pod is sampled
+func (s *LogRateSampler) SetInterval(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.interval = interval
+}
+
+// SetVisible sets the pods on screen, top to bottom, and cancels the
+// samples of pods no longer among them
+func (s *LogRateSampler) SetVisible(targets []logRateTarget) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.visible = targets
+	shown := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		shown[target.key] = true
+	}
+	for key, run := range s.inFlight {
+		if !shown[key] {
+			run.cancel()
+			delete(s.inFlight, key)
+		}
+	}
+}
+
+// logRateStart is a sample the caller should run
+type logRateStart struct {
+	target logRateTarget
+	id     uint64
+	ctx    context.Context
+}
+
+// Due starts the samples that may run now: visible pods not sampled within
+// the interval, top first, within the in-flight and per-minute limits
+func (s *LogRateSampler) Due(parent context.Context, now time.Time) []logRateStart {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Forget starts older than a minute
+	recent := s.started[:0]
+	for _, t := range s.started {
+		if now.Sub(t) < time.Minute {
+			recent = append(recent, t)
+		}
+	}
+	s.started = recent
+
+	// Give up on samples that ran out of time
+	for key, run := range s.inFlight {
+		if now.Sub(run.started) >= logSampleTimeout {
+			run.cancel()
+			delete(s.inFlight, key)
+		}
+	}
+
+	// Forget pods that have not been on screen for a few intervals
+	shown := make(map[string]bool, len(s.visible))
+	for _, target := range s.visible {
+		shown[target.key] = true
+	}
+	for key, result := range s.results {
+		if !shown[key] && now.Sub(result.sampledAt) > 3*s.interval {
+			delete(s.results, key)
+		}
+	}
+
+	var starts []logRateStart
+	for _, target := range s.visible {
+		if len(s.inFlight) >= maxLogSamplesInFlight || len(s.started) >= maxLogSamplesPerMinute {
+			break
+		}
+		if _, running := s.inFlight[target.key]; running {
+			continue
+		}
+		if result, ok := s.results[target.key]; ok && now.Sub(result.sampledAt) < s.interval {
+			continue
+		}
+
+		s.nextID++
+		ctx, cancel := context.WithTimeout(parent, logSampleTimeout)
+		s.inFlight[target.key] = logRateRun{id: s.nextID, cancel: cancel, started: now}
+		s.started = append(s.started, now)
+		starts = append(starts, logRateStart{target: target, id: s.nextID, ctx: ctx})
+	}
+	return starts
+}
+
+// Record stores the result of a sample. Results of cancelled samples are
+// dropped; it returns false for them.
+func (s *LogRateSampler) Record(key string, id uint64, sample k8s.LogSample, err error, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run, ok := s.inFlight[key]
+	if !ok || run.id != id {
+		return false
+	}
+	run.cancel()
+	delete(s.inFlight, key)
+
+	previous, sampled := s.results[key]
+	if err != nil {
+		if sampled && previous.err == nil {
+			// Keep showing the last rate; the pod is sampled again next interval
+			previous.sampledAt = now
+			return true
+		}
+		s.results[key] = &logRateResult{sampledAt: now, err: err}
+		return true
+	}
+
+	result := &logRateResult{
+		rate:      core.NewLogRate(sample.Lines, sample.Errors, sample.Window),
+		sampledAt: now,
+	}
+	if sampled && previous.err == nil {
+		rate := previous.rate
+		result.previous = &rate
+	}
+	s.results[key] = result
+	return true
+}
+
+// Cell returns the LOG cell of a pod: its rate, or "…" before the first
+// sample and "-" when its logs cannot be read
+func (s *LogRateSampler) Cell(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, ok := s.results[key]
+	switch {
+	case !ok:
+		return "…"
+	case result.err != nil:
+		return "-"
+	}
+	change := core.LogRateSteady
+	if result.previous != nil {
+		change = result.rate.Compare(*result.previous)
+	}
+	return core.FormatLogRate(result.rate, change)
+}
+
+// Stop cancels every sample in flight
+func (s *LogRateSampler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, run := range s.inFlight {
+		run.cancel()
+		delete(s.inFlight, key)
+	}
+}
+
+// logRateSampledMsg carries the result of one log sample
+type logRateSampledMsg struct {
+	key    string
+	id     uint64
+	sample k8s.LogSample
+	err    error
+}
+
+// SetLogRateInterval turns log rate sampling on, sampling each visible pod
+// once per interval, or off when interval is 0. Sampling reads pod logs, so
+// it costs API calls.
+func (v *ResourceView) SetLogRateInterval(interval time.Duration) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	switch {
+	case interval <= 0 && v.logRates != nil:
+		v.logRates.Stop()
+		v.logRates = nil
+	case interval > 0 && v.logRates == nil:
+		v.logRates = NewLogRateSampler(interval)
+	case interval > 0:
+		v.logRates.SetInterval(interval)
+	}
+}
+
+// SampleLogRates starts the log samples that are due for the pods on
+// screen. It returns nil when sampling is off or nothing is due.
+func (v *ResourceView) SampleLogRates() tea.Cmd {
+	v.mu.RLock()
+	sampler := v.logRates
+	v.mu.RUnlock()
+	if sampler == nil {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for _, start := range sampler.Due(context.Background(), time.Now()) {
+		cmds = append(cmds, v.sampleLogRate(start))
+	}
+	return tea.Batch(cmds...)
+}
+
+// sampleLogRate returns a command that takes one log sample
+func (v *ResourceView) sampleLogRate(start logRateStart) tea.Cmd {
+	target := start.target
+	return func() tea.Msg {
+		client, err := v.clientForContext(target.context)
+		if err != nil {
+			return logRateSampledMsg{key: target.key, id: start.id, err: err}
+		}
+		sample, err := client.SampleLogs(start.ctx, target.namespace, target.pod, target.container, logSampleWindow, logSampleLimitBytes)
+		return logRateSampledMsg{key: target.key, id: start.id, sample: sample, err: err}
+	}
+}
+
+// clientForContext returns the client of a context; "" is the single-context client
+func (v *ResourceView) clientForContext(contextName string) (*k8s.Client, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.isMultiContext && contextName != "" && v.multiClient != nil {
+		return v.multiClient.GetClient(contextName)
+	}
+	if v.k8sClient == nil {
+		return nil, fmt.Errorf("no kubernetes client available")
+	}
+	return v.k8sClient, nil
+}
+
+// visibleLogTargets returns the pods in the rows on screen. The caller must
+// hold v.mu.
+func (v *ResourceView) visibleLogTargets(viewportHeight int) []logRateTarget {
+	if v.state.CurrentResourceType != core.ResourceTypePod || v.scrub != nil {
+		return nil
+	}
+	var targets []logRateTarget
+	for i := v.viewportStart; i < v.viewportStart+viewportHeight && i < v.table.GetRowCount(); i++ {
+		identity, ok := v.resourceMap[i]
+		if !ok || identity == nil {
+			continue
+		}
+		key := podDetailKey(identity)
+		targets = append(targets, logRateTarget{
+			key:       key,
+			context:   identity.Context,
+			namespace: identity.Namespace,
+			pod:       identity.Name,
+			container: v.logContainers[key],
+		})
+	}
+	return targets
+}
+
+// updateLogCell shows the latest rate of a pod in its LOG cell. The caller
+// must hold v.mu.
+func (v *ResourceView) updateLogCell(key string) {
+	column := -1
+	for i, header := range v.table.Titles() {
+		if header == "LOG" {
+			column = i
+		}
+	}
+	if column < 0 {
+		return
+	}
+	for i, identity := range v.resourceMap {
+		if identity != nil && podDetailKey(identity) == key {
+			v.table.SetCell(i, column, v.logRates.Cell(key))
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+func logTargets(n int) []logRateTarget {
+	targets := make([]logRateTarget, n)
+	for i := range targets {
+		name := fmt.Sprintf("pod-%d", i)
+		targets[i] = logRateTarget{key: podKey("", "default", name), namespace: "default", pod: name, container: "app"}
+	}
+	return targets
+}
+
+func TestLogRateSamplerLimitsInFlight(t *testing.T) {
+	s := NewLogRateSampler(time.Minute)
+	s.SetVisible(logTargets(5))
+	now := time.Now()
+
+	starts := s.Due(context.Background(), now)
+	if len(starts) != maxLogSamplesInFlight {
+		t.Fatalf("Due() started %d samples, want %d", len(starts), maxLogSamplesInFlight)
+	}
+	if starts[0].target.pod != "pod-0" || starts[1].target.pod != "pod-1" {
+		t.Errorf("Due() should start the top rows first, got %s and %s", starts[0].target.pod, starts[1].target.pod)
+	}
+	if again := s.Due(context.Background(), now); len(again) != 0 {
+		t.Errorf("Due() started %d more samples while the limit is in flight", len(again))
+	}
+
+	s.Record(starts[0].target.key, starts[0].id, k8s.LogSample{Lines: 10, Window: time.Minute}, nil, now)
+	next := s.Due(context.Background(), now)
+	if len(next) != 1 || next[0].target.pod != "pod-2" {
+		t.Errorf("Due() after one result = %v, want pod-2", next)
+	}
+}
+
+func TestLogRateSamplerInterval(t *testing.T) {
+	s := NewLogRateSampler(time.Minute)
+	targets := logTargets(1)
+	s.SetVisible(targets)
+	now := time.Now()
+
+	start := s.Due(context.Background(), now)[0]
+	s.Record(start.target.key, start.id, k8s.LogSample{Lines: 10, Window: time.Minute}, nil, now)
+
+	if starts := s.Due(context.Background(), now.Add(30*time.Second)); len(starts) != 0 {
+		t.Errorf("Due() resampled a pod within its interval")
+	}
+	if starts := s.Due(context.Background(), now.Add(time.Minute)); len(starts) != 1 {
+		t.Errorf("Due() did not resample a pod after its interval")
+	}
+}
+
+func TestLogRateSamplerPerMinuteLimit(t *testing.T) {
+	s := NewLogRateSampler(time.Second)
+	s.SetVisible(logTargets(maxLogSamplesPerMinute + 10))
+	now := time.Now()
+
+	started := 0
+	for i := 0; i < 100; i++ {
+		for _, start := range s.Due(context.Background(), now) {
+			started++
+			s.Record(start.target.key, start.id, k8s.LogSample{Window: time.Minute}, nil, now)
+		}
+	}
+	if started != maxLogSamplesPerMinute {
+		t.Errorf("started %d samples in a minute, want %d", started, maxLogSamplesPerMinute)
+	}
+
+	// A minute later the budget is back
+	if starts := s.Due(context.Background(), now.Add(time.Minute)); len(starts) == 0 {
+		t.Errorf("Due() started nothing after the per-minute limit reset")
+	}
+}
+
+func TestLogRateSamplerCancelsHiddenPods(t *testing.T) {
+	s := NewLogRateSampler(time.Minute)
+	targets := logTargets(2)
+	s.SetVisible(targets)
+	now := time.Now()
+
+	starts := s.Due(context.Background(), now)
+	if len(starts) != 2 {
+		t.Fatalf("Due() started %d samples, want 2", len(starts))
+	}
+
+	// Scroll pod-0 away
+	s.SetVisible(targets[1:])
+	if starts[0].ctx.Err() == nil {
+		t.Errorf("sample of a pod scrolled away was not cancelled")
+	}
+	if starts[1].ctx.Err() != nil {
+		t.Errorf("sample of a visible pod was cancelled")
+	}
+	if s.Record(starts[0].target.key, starts[0].id, k8s.LogSample{Lines: 5, Window: time.Minute}, nil, now) {
+		t.Errorf("Record() kept the result of a cancelled sample")
+	}
+	if cell := s.Cell(starts[0].target.key); cell != "…" {
+		t.Errorf("Cell() of a cancelled sample = %q, want …", cell)
+	}
+}
+
+func TestLogRateSamplerGivesUpOnLostResults(t *testing.T) {
+	s := NewLogRateSampler(time.Minute)
+	s.SetVisible(logTargets(maxLogSamplesInFlight + 1))
+	now := time.Now()
+
+	starts := s.Due(context.Background(), now)
+	later := now.Add(logSampleTimeout)
+	next := s.Due(context.Background(), later)
+	if len(next) == 0 {
+		t.Fatalf("Due() did not free samples whose results never came")
+	}
+	if starts[0].ctx.Err() == nil {
+		t.Errorf("timed out sample was not cancelled")
+	}
+}
+
+func TestLogRateSamplerCell(t *testing.T) {
+	s := NewLogRateSampler(time.Minute)
+	target := logTargets(1)[0]
+	s.SetVisible([]logRateTarget{target})
+	now := time.Now()
+
+	sample := func(lines, errs int, err error) {
+		t.Helper()
+		now = now.Add(time.Minute)
+		starts := s.Due(context.Background(), now)
+		if len(starts) != 1 {
+			t.Fatalf("Due() started %d samples, want 1", len(starts))
+		}
+		s.Record(target.key, starts[0].id, k8s.LogSample{Lines: lines, Errors: errs, Window: time.Minute}, err, now)
+	}
+
+	if cell := s.Cell(target.key); cell != "…" {
+		t.Errorf("Cell() before a sample = %q, want …", cell)
+	}
+
+	sample(0, 0, errors.New("container not running"))
+	if cell := s.Cell(target.key); cell != "-" {
+		t.Errorf("Cell() after a failed sample = %q, want -", cell)
+	}
+
+	sample(1234, 32, nil)
+	if cell := s.Cell(target.key); cell != "~1.2k/m ⚠32" {
+		t.Errorf("Cell() = %q, want ~1.2k/m ⚠32", cell)
+	}
+
+	// A failed sample keeps showing the last rate
+	sample(0, 0, errors.New("timeout"))
+	if cell := s.Cell(target.key); cell != "~1.2k/m ⚠32" {
+		t.Errorf("Cell() after a failure = %q, want the last rate", cell)
+	}
+
+	sample(9000, 0, nil)
+	if cell := s.Cell(target.key); cell != "~9k/m ▲" {
+		t.Errorf("Cell() after a spike = %q, want ~9k/m ▲", cell)
+	}
+
+	sample(0, 0, nil)
+	if cell := s.Cell(target.key); cell != "~0/m silent" {
+		t.Errorf("Cell() after going silent = %q, want ~0/m silent", cell)
+	}
+}
+
+func TestResourceViewLogRateColumn(t *testing.T) {
+	var containers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/pods/web/log") {
+			http.NotFound(w, r)
+			return
+		}
+		containers = append(containers, r.URL.Query().Get("container"))
+		now := time.Now().UTC().Format(time.RFC3339Nano)
+		for i := 0; i < 120; i++ {
+			fmt.Fprintf(w, "%s request served\n", now)
+		}
+		fmt.Fprintf(w, "%s ERROR upstream timeout\n", now)
+	}))
+	defer server.Close()
+
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "default", ""), client)
+	rv.SetSize(250, 20)
+	pods := []v1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app"}, {Name: "sidecar"}}},
+	}}
+
+	rv.updateTableWithPods(pods)
+	if strings.Contains(strings.Join(rv.table.Titles(), " "), "LOG") {
+		t.Fatal("Expected the LOG column off by default")
+	}
+	if cmd := rv.SampleLogRates(); cmd != nil {
+		t.Fatal("Expected no sampling while it is off")
+	}
+
+	rv.SetLogRateInterval(time.Minute)
+	rv.updateTableWithPods(pods)
+	column := -1
+	for i, header := range rv.table.Titles() {
+		if header == "LOG" {
+			column = i
+		}
+	}
+	if column < 0 {
+		t.Fatalf("Expected a LOG column, got %v", rv.table.Titles())
+	}
+	if rv.table.RowValues(0)[column] != "…" {
+		t.Errorf("Expected … before the first sample, got %q", rv.table.RowValues(0)[column])
+	}
+
+	// Only rows rendered on screen are sampled
+	rv.View()
+	cmd := rv.SampleLogRates()
+	if cmd == nil {
+		t.Fatal("Expected a sample of the visible pod")
+	}
+	rv.Update(cmd())
+
+	if len(containers) != 1 || containers[0] != "app" {
+		t.Errorf("Expected one sample of the default container, got %v", containers)
+	}
+	if got := rv.table.RowValues(0)[column]; got != "~121/m ⚠1" {
+		t.Errorf("Expected the sampled rate in the LOG cell, got %q", got)
+	}
+	if cmd := rv.SampleLogRates(); cmd != nil {
+		t.Error("Expected no resample within the interval")
+	}
+}
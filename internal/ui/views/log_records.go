@@ -0,0 +1,84 @@
+package views
+
+import (
+	"regexp"
+	"time"
+)
+
+// DefaultLogRecordStart matches the first line of a log record: a leading
+// date or time, a log level, a klog-style "I0102 " header, or a JSON object.
+// Lines that do not match (stack frames, "Caused by:", "Traceback ...") are
+// continuations of the record before them.
+const DefaultLogRecordStart = `^(\[?\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}|\[?\d{2}:\d{2}:\d{2}|\[?(?i:trace|debug|info|notice|warn|warning|error|err|fatal|critical|severe|panic)\b|[IWEF]\d{4} |\{)`
+
+const (
+	// DefaultLogRecordTimeout closes a record when no line arrives from its
+	// stream for this long, so an unrelated line after a pause starts a new one
+	DefaultLogRecordTimeout = 2 * time.Second
+
+	// maxLogRecordLines caps a record so a stream that never matches the start
+	// pattern does not become one endless record
+	maxLogRecordLines = 500
+)
+
+// LogRecordGrouper groups log lines into multi-line records per stream. A
+// record starts at a line matching the start pattern and collects the lines
+// after it from the same stream until the next start line arrives or the
+// stream stays quiet for the timeout. It only sees one line at a time, so it
+// is safe to use on a live stream.
+type LogRecordGrouper struct {
+	start   *regexp.Regexp
+	timeout time.Duration
+	nextID  int
+	open    map[string]*openLogRecord // Keyed by stream
+}
+
+type openLogRecord struct {
+	id       int
+	lines    int
+	lastLine time.Time
+}
+
+// NewLogRecordGrouper creates a grouper; a nil start pattern uses DefaultLogRecordStart
+func NewLogRecordGrouper(start *regexp.Regexp, timeout time.Duration) *LogRecordGrouper {
+	if start == nil {
+		start = regexp.MustCompile(DefaultLogRecordStart)
+	}
+	if timeout <= 0 {
+		timeout = DefaultLogRecordTimeout
+	}
+	return &LogRecordGrouper{
+		start:   start,
+		timeout: timeout,
+		open:    make(map[string]*openLogRecord),
+	}
+}
+
+// Add assigns a line from stream to a record. It returns the record's id,
+// which increases with every new record, and whether the line continues an
+// existing record rather than starting one.
+func (g *LogRecordGrouper) Add(stream, line string, now time.Time) (int, bool) {
+	rec := g.open[stream]
+	if rec != nil && !g.start.MatchString(line) &&
+		now.Sub(rec.lastLine) <= g.timeout && rec.lines < maxLogRecordLines {
+		rec.lines++
+		rec.lastLine = now
+		return rec.id, true
+	}
+
+	g.nextID++
+	g.open[stream] = &openLogRecord{id: g.nextID, lines: 1, lastLine: now}
+	return g.nextID, false
+}
+
+// NewRecord returns an id for a record that takes no continuation lines,
+// such as a status message
+func (g *LogRecordGrouper) NewRecord() int {
+	g.nextID++
+	return g.nextID
+}
+
+// Reset closes every open record
+func (g *LogRecordGrouper) Reset() {
+	g.open = make(map[string]*openLogRecord)
+}
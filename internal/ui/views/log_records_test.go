@@ -0,0 +1,134 @@
+package views
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestLogRecordGrouperDefaultPattern(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		// expected[i] is true when lines[i] continues the record before it
+		expected []bool
+	}{
+		{
+			name: "java stack trace",
+			lines: []string{
+				"2024-05-01 12:00:00.123 ERROR [main] Request failed",
+				"java.lang.IllegalStateException: boom",
+				"\tat com.example.Service.handle(Service.java:42)",
+				"Caused by: java.io.IOException: closed",
+				"\t... 12 more",
+				"2024-05-01 12:00:01.000 INFO [main] Recovered",
+			},
+			expected: []bool{false, true, true, true, true, false},
+		},
+		{
+			name: "python traceback",
+			lines: []string{
+				"ERROR:root:unhandled exception",
+				"Traceback (most recent call last):",
+				`  File "app.py", line 3, in <module>`,
+				"ValueError: bad value",
+				"INFO:root:retrying",
+			},
+			expected: []bool{false, true, true, true, false},
+		},
+		{
+			name: "klog and json lines each start a record",
+			lines: []string{
+				"I0501 12:00:00.000000       1 main.go:10] starting",
+				`{"level":"info","msg":"ready"}`,
+				"[12:00:02] listening",
+				"warn: slow request",
+			},
+			expected: []bool{false, false, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewLogRecordGrouper(nil, DefaultLogRecordTimeout)
+			now := time.Now()
+			for i, line := range tt.lines {
+				if _, continuation := g.Add("pod", line, now); continuation != tt.expected[i] {
+					t.Errorf("Line %q: expected continuation=%v", line, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLogRecordGrouperStreams(t *testing.T) {
+	g := NewLogRecordGrouper(nil, DefaultLogRecordTimeout)
+	now := time.Now()
+
+	a, _ := g.Add("pod-a", "ERROR failed", now)
+	b, _ := g.Add("pod-b", "ERROR also failed", now)
+	if a == b {
+		t.Fatal("Expected records from different streams to have different ids")
+	}
+
+	// Continuation lines attach to their own stream's record
+	if id, continuation := g.Add("pod-a", "\tat frame", now); !continuation || id != a {
+		t.Errorf("Expected pod-a continuation in record %d, got %d (continuation=%v)", a, id, continuation)
+	}
+	if id, continuation := g.Add("pod-b", "\tat frame", now); !continuation || id != b {
+		t.Errorf("Expected pod-b continuation in record %d, got %d (continuation=%v)", b, id, continuation)
+	}
+
+	// A continuation with no open record starts one
+	if _, continuation := g.Add("pod-c", "\tat frame", now); continuation {
+		t.Error("Expected the first line of a stream to start a record")
+	}
+}
+
+func TestLogRecordGrouperClosesRecords(t *testing.T) {
+	now := time.Now()
+
+	t.Run("after the timeout", func(t *testing.T) {
+		g := NewLogRecordGrouper(nil, time.Second)
+		first, _ := g.Add("pod", "ERROR failed", now)
+		if id, continuation := g.Add("pod", "\tat frame", now.Add(500*time.Millisecond)); !continuation || id != first {
+			t.Error("Expected a line within the timeout to continue the record")
+		}
+		if _, continuation := g.Add("pod", "\tat frame", now.Add(2*time.Second)); continuation {
+			t.Error("Expected a line after the timeout to start a new record")
+		}
+	})
+
+	t.Run("at the line cap", func(t *testing.T) {
+		g := NewLogRecordGrouper(nil, time.Second)
+		g.Add("pod", "ERROR failed", now)
+		for i := 1; i < maxLogRecordLines; i++ {
+			g.Add("pod", "\tat frame", now)
+		}
+		if _, continuation := g.Add("pod", "\tat frame", now); continuation {
+			t.Error("Expected a record at the line cap to be closed")
+		}
+	})
+
+	t.Run("on reset", func(t *testing.T) {
+		g := NewLogRecordGrouper(nil, time.Second)
+		g.Add("pod", "ERROR failed", now)
+		g.Reset()
+		if _, continuation := g.Add("pod", "\tat frame", now); continuation {
+			t.Error("Expected reset to close open records")
+		}
+	})
+}
+
+func TestLogRecordGrouperCustomPattern(t *testing.T) {
+	g := NewLogRecordGrouper(regexp.MustCompile(`^>>`), DefaultLogRecordTimeout)
+	now := time.Now()
+
+	g.Add("pod", ">> request", now)
+	if _, continuation := g.Add("pod", "2024-05-01 12:00:00 detail", now); !continuation {
+		t.Error("Expected the default pattern not to apply when a custom one is set")
+	}
+	if _, continuation := g.Add("pod", ">> next request", now); continuation {
+		t.Error("Expected the custom pattern to start a record")
+	}
+}
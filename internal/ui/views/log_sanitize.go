@@ -0,0 +1,238 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// ansiEscape starts every terminal escape sequence
+	ansiEscape = 0x1b
+
+	// logTabWidth is the distance between tab stops in the log view
+	logTabWidth = 8
+)
+
+// sanitizeLogLine makes a log line safe to draw. Color (SGR) sequences are
+// kept, and closed at the end of the line so colors never leak onto the
+// next one; every other escape sequence is dropped, since cursor movement,
+// clears and title changes would scribble over the UI. Other control
+// characters, invalid UTF-8 and bidirectional overrides are shown as
+// visible escapes: ^M for a carriage return, ^[ for an escape that never
+// finished, \xff for a byte that is not UTF-8, \u202e for an override.
+func sanitizeLogLine(line string) string {
+	if isPlainLogLine(line) {
+		return line
+	}
+
+	var b strings.Builder
+	b.Grow(len(line))
+	colored := false
+	for i := 0; i < len(line); {
+		c := line[i]
+		if c == ansiEscape {
+			n, sgr := escapeSequence(line[i:])
+			switch {
+			case n == 0:
+				// Unfinished, as when a sequence is cut off: show the
+				// escape and draw what follows as text
+				b.WriteString("^[")
+				i++
+				continue
+			case sgr:
+				b.WriteString(line[i : i+n])
+				colored = !isSGRReset(line[i+2 : i+n-1])
+			}
+			i += n
+			continue
+		}
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '\t':
+				b.WriteByte(c)
+			case c < 0x20:
+				b.WriteByte('^')
+				b.WriteByte(c + '@')
+			case c == 0x7f:
+				b.WriteString("^?")
+			default:
+				b.WriteByte(c)
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(line[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			fmt.Fprintf(&b, `\x%02x`, c)
+		case unicode.Is(unicode.Cc, r) || isBidiControl(r):
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteString(line[i : i+size])
+		}
+		i += size
+	}
+	if colored {
+		b.WriteString("\x1b[0m")
+	}
+	return b.String()
+}
+
+// isPlainLogLine reports whether a line is printable ASCII and tabs, which
+// is most log lines and needs no work
+func isPlainLogLine(line string) bool {
+	for i := 0; i < len(line); i++ {
+		if c := line[i]; (c < 0x20 && c != '\t') || c >= 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// escapeSequence returns the length of the escape sequence s starts with,
+// and whether it sets colors; 0 when the sequence is unfinished or malformed
+func escapeSequence(s string) (n int, sgr bool) {
+	if len(s) < 2 {
+		return 0, false
+	}
+	switch s[1] {
+	case '[':
+		// CSI: parameter bytes, intermediate bytes, then a final byte
+		i := 2
+		for i < len(s) && s[i] >= 0x30 && s[i] <= 0x3f {
+			i++
+		}
+		params := s[2:i]
+		for i < len(s) && s[i] >= 0x20 && s[i] <= 0x2f {
+			i++
+		}
+		if i >= len(s) || s[i] < 0x40 || s[i] > 0x7e {
+			return 0, false
+		}
+		return i + 1, s[i] == 'm' && i == len(params)+2 && isSGRParams(params)
+	case ']', 'P', 'X', '^', '_':
+		// OSC, DCS and the other strings, ended by BEL (OSC only) or ST
+		for i := 2; i < len(s); i++ {
+			if s[i] == 0x07 && s[1] == ']' {
+				return i + 1, false
+			}
+			if s[i] == ansiEscape && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2, false
+			}
+		}
+		return 0, false
+	default:
+		// Two-character sequences such as ESC c, with optional
+		// intermediates as in ESC ( B
+		i := 1
+		for i < len(s) && s[i] >= 0x20 && s[i] <= 0x2f {
+			i++
+		}
+		if i >= len(s) || s[i] < 0x30 || s[i] > 0x7e {
+			return 0, false
+		}
+		return i + 1, false
+	}
+}
+
+// isSGRParams reports whether CSI parameters are plain numbers, as color
+// sequences use; private ones such as "?25" are not colors
+func isSGRParams(params string) bool {
+	for i := 0; i < len(params); i++ {
+		if c := params[i]; (c < '0' || c > '9') && c != ';' && c != ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// isSGRReset reports whether SGR parameters only reset the colors
+func isSGRReset(params string) bool {
+	return strings.Trim(params, "0;") == ""
+}
+
+// isBidiControl reports whether r reorders the text around it, which can
+// make a line read differently from what it contains
+func isBidiControl(r rune) bool {
+	return (r >= 0x202a && r <= 0x202e) || (r >= 0x2066 && r <= 0x2069)
+}
+
+// expandLogTabs replaces tabs with spaces up to the next tab stop, measured
+// in terminal cells, so wide characters and colors before a tab don't throw
+// off the columns after it
+func expandLogTabs(line string) string {
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+	var b strings.Builder
+	column := 0
+	for {
+		tab := strings.IndexByte(line, '\t')
+		if tab < 0 {
+			b.WriteString(line)
+			return b.String()
+		}
+		b.WriteString(line[:tab])
+		column += ansi.StringWidth(line[:tab])
+		spaces := logTabWidth - column%logTabWidth
+		b.WriteString(strings.Repeat(" ", spaces))
+		column += spaces
+		line = line[tab+1:]
+	}
+}
+
+// plainLogText returns a sanitized line without its colors, as searched
+// and matched against
+func plainLogText(line string) string {
+	if strings.IndexByte(line, ansiEscape) < 0 {
+		return line
+	}
+	return ansi.Strip(line)
+}
+
+// indexFold returns where query first appears in s ignoring case, as byte
+// offsets into s, or -1. Unlike searching a lowercased copy, the offsets
+// stay right when case changes a character's length.
+func indexFold(s, query string) (start, end int) {
+	if query == "" {
+		return -1, -1
+	}
+	for start = range s {
+		end = start
+		matched := true
+		for _, q := range query {
+			if end >= len(s) {
+				matched = false
+				break
+			}
+			r, size := utf8.DecodeRuneInString(s[end:])
+			if !equalFoldRune(r, q) {
+				matched = false
+				break
+			}
+			end += size
+		}
+		if matched {
+			return start, end
+		}
+	}
+	return -1, -1
+}
+
+// equalFoldRune reports whether two runes are equal ignoring case
+func equalFoldRune(a, b rune) bool {
+	if a == b {
+		return true
+	}
+	for r := unicode.SimpleFold(a); r != a; r = unicode.SimpleFold(r) {
+		if r == b {
+			return true
+		}
+	}
+	return false
+}
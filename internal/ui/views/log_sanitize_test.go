@@ -0,0 +1,195 @@
+package views
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestSanitizeLogLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"plain", "GET /healthz 200", "GET /healthz 200"},
+		{"tabs kept", "\tat frame", "\tat frame"},
+		{"colors kept", "\x1b[1;31mERROR\x1b[0m boom", "\x1b[1;31mERROR\x1b[0m boom"},
+		{"open color closed", "\x1b[32mgreen", "\x1b[32mgreen\x1b[0m"},
+		{"clear screen and home", "\x1b[2J\x1b[Hhello", "hello"},
+		{"cursor movement", "a\x1b[5A\x1b[10;20Hb\x1b[K", "ab"},
+		{"private mode", "\x1b[?25l\x1b[?1049hhidden", "hidden"},
+		{"window title with BEL", "\x1b]0;pwned\x07text", "text"},
+		{"hyperlink with ST", "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", "link"},
+		{"charset and reset", "\x1b(B\x1bcafter", "after"},
+		{"carriage return", "progress 50%\rprogress 100%", "progress 50%^Mprogress 100%"},
+		{"NUL and bell", "a\x00b\x07", "a^@b^G"},
+		{"delete", "x\x7f", "x^?"},
+		{"invalid UTF-8", "bad \xff\xfe", `bad \xff\xfe`},
+		{"C1 control", "a\u009b2Jb", `a\u009b2Jb`},
+		{"bidi override", "user\u202etxt.exe", `user\u202etxt.exe`},
+		{"unfinished CSI", "abc\x1b[31", "abc^[[31"},
+		{"lone escape", "abc\x1b", "abc^["},
+		{"unfinished OSC", "\x1b]0;title", "^[]0;title"},
+		{"wide characters", "日本語 👍🏽 done", "日本語 👍🏽 done"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeLogLine(tt.line); got != tt.want {
+				t.Errorf("sanitizeLogLine(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeLogLineAcrossReads(t *testing.T) {
+	// Escapes split across reads are joined again by the line scanner, and
+	// one split across lines is shown rather than swallowing the next line
+	stream := "\x1b[31mred\x1b[0m \x1b[2Jcleared\nsplit \x1b[\n2Jnext\n"
+	scan := func(reader io.Reader) []string {
+		var lines []string
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			lines = append(lines, sanitizeLogLine(scanner.Text()))
+		}
+		return lines
+	}
+	whole := scan(strings.NewReader(stream))
+	bytewise := scan(iotest.OneByteReader(strings.NewReader(stream)))
+
+	want := []string{"\x1b[31mred\x1b[0m cleared", "split ^[[", "2Jnext"}
+	if strings.Join(bytewise, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected %q, got %q", want, bytewise)
+	}
+	if strings.Join(whole, "\n") != strings.Join(bytewise, "\n") {
+		t.Errorf("Expected the same lines however the stream is read, got %q and %q", whole, bytewise)
+	}
+}
+
+func TestExpandLogTabs(t *testing.T) {
+	tests := []struct {
+		line  string
+		width int // Of the text before "x"
+	}{
+		{"\tx", 8},
+		{"ab\tx", 8},
+		{"日本\tx", 8},
+		{"日本語です\tx", 16},
+		{"👍🏽\tx", 8},
+		{"\x1b[31mred\x1b[0m\tx", 8},
+	}
+	for _, tt := range tests {
+		got := expandLogTabs(tt.line)
+		if strings.Contains(got, "\t") {
+			t.Errorf("expandLogTabs(%q) = %q, still has tabs", tt.line, got)
+		}
+		if width := ansi.StringWidth(strings.TrimSuffix(got, "x")); width != tt.width {
+			t.Errorf("expandLogTabs(%q): x at column %d, want %d", tt.line, width, tt.width)
+		}
+	}
+}
+
+func TestIndexFold(t *testing.T) {
+	tests := []struct {
+		s, query   string
+		start, end int
+	}{
+		{"Request FAILED", "failed", 8, 14},
+		{"no match", "error", -1, -1},
+		// U+212A KELVIN SIGN is three bytes but folds to a one-byte k
+		{"\u212aelvin ok", "kelvin", 0, 8},
+		{"日本語 error", "ERROR", 10, 15},
+		{"anything", "", -1, -1},
+	}
+	for _, tt := range tests {
+		start, end := indexFold(tt.s, tt.query)
+		if start != tt.start || end != tt.end {
+			t.Errorf("indexFold(%q, %q) = (%d, %d), want (%d, %d)", tt.s, tt.query, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestLogViewSanitizesLines(t *testing.T) {
+	lv := createTestLogView(t)
+	lv.containers = []string{"app"}
+
+	model, _ := lv.Update(logLineMsg{container: "app", line: "\x1b[2J\x1b[H\x1b[31mERROR\x1b[0m disk full\r"})
+	lv = model.(*LogView)
+	if want := "\x1b[31mERROR\x1b[0m disk full^M"; lv.content[0] != want {
+		t.Fatalf("Expected %q buffered, got %q", want, lv.content[0])
+	}
+	if view := lv.View(); strings.Contains(view, "\x1b[2J") || strings.Contains(view, "\r") {
+		t.Errorf("Expected no clears or carriage returns drawn, got %q", view)
+	}
+
+	// Search ignores the colors
+	lv.searchQuery = "error disk"
+	lv.performSearch()
+	if len(lv.searchResults) != 1 {
+		t.Errorf("Expected the colored line to match, got %v", lv.searchResults)
+	}
+	lv.searchResults = nil
+
+	// Colors can be turned off
+	model, _ = lv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	lv = model.(*LogView)
+	if lv.colors {
+		t.Fatal("Expected colors toggled off")
+	}
+	if strings.Contains(lv.viewport.View(), "\x1b[31m") {
+		t.Error("Expected the log's own colors stripped")
+	}
+}
+
+func TestLogViewWideLinesScrollSideways(t *testing.T) {
+	lv := createTestLogView(t)
+	lv.containers = []string{"app"}
+
+	long := strings.Repeat("日本語👍🏽", 40) + "\tend"
+	for _, line := range []string{long, "short"} {
+		model, _ := lv.Update(logLineMsg{container: "app", line: line})
+		lv = model.(*LogView)
+	}
+
+	for press := 0; press < 3; press++ {
+		if press > 0 {
+			model, _ := lv.Update(tea.KeyMsg{Type: tea.KeyRight})
+			lv = model.(*LogView)
+		}
+		for i, line := range strings.Split(lv.viewport.View(), "\n") {
+			if width := ansi.StringWidth(line); width > 80 {
+				t.Fatalf("Line %d is %d cells wide, more than the view", i, width)
+			}
+		}
+	}
+	if lv.viewport.HorizontalScrollPercent() == 0 {
+		t.Error("Expected right to scroll the long line sideways")
+	}
+
+	// Highlighted lines are cut at the same place
+	lv.searchQuery = "end"
+	lv.performSearch()
+	for i, line := range strings.Split(lv.getHighlightedContent(), "\n") {
+		if width := ansi.StringWidth(line); width > 80 {
+			t.Fatalf("Highlighted line %d is %d cells wide, more than the view", i, width)
+		}
+	}
+}
+
+func TestLogViewHighlightsCaseChangingText(t *testing.T) {
+	lv := createTestLogView(t)
+	lv.content = []string{"\u212aelvin reading \u212aelvin"}
+	lv.searchQuery = "kelvin"
+	lv.performSearch()
+	if len(lv.searchResults) != 1 {
+		t.Fatalf("Expected one match, got %v", lv.searchResults)
+	}
+	if got := ansi.Strip(lv.getHighlightedContent()); !strings.HasPrefix(got, lv.content[0]) {
+		t.Errorf("Expected the line intact under highlighting, got %q", got)
+	}
+}
@@ -0,0 +1,259 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
+)
+
+// LogSplitView shows the logs of two pods side by side, such as the old and
+// the new pod of a rollout. Each pane is a log view of its own, following,
+// searching and filtering on its own; keys go to the focused pane. The
+// second pane starts as a picker over the pods to compare with. In linked
+// mode, scrolling one pane scrolls the other by as many lines, for reading
+// both at the same time.
+type LogSplitView struct {
+	ctx     context.Context
+	panes   [2]*LogView // The second is nil while picking its pod
+	focused int
+	linked  bool
+
+	candidates []v1.Pod
+	picker     containerPicker
+	picking    bool
+
+	width  int
+	height int
+}
+
+// NewLogSplitView splits the log view left, asking which of candidates to
+// show the logs of beside it, streamed until ctx is done
+func NewLogSplitView(ctx context.Context, left *LogView, candidates []v1.Pod) *LogSplitView {
+	names := make([]string, len(candidates))
+	for i, pod := range candidates {
+		names[i] = pod.Name
+	}
+	picker := newContainerPicker(names, false)
+	picker.item = "pod"
+	return &LogSplitView{
+		ctx:        ctx,
+		panes:      [2]*LogView{left, nil},
+		focused:    1,
+		candidates: candidates,
+		picker:     picker,
+		picking:    true,
+	}
+}
+
+// Init initializes the view
+func (v *LogSplitView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages. Keys, and errors such as a pod's logs failing to
+// open, go to the focused pane; the streams of both panes are read, each
+// pane ignoring the other's. Esc, Tab and L are handled by the log mode.
+func (v *LogSplitView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+		return v, nil
+
+	case tea.KeyMsg:
+		if v.picking {
+			return v, v.handlePickerKey(msg)
+		}
+		return v, v.updateFocused(msg)
+
+	case tea.MouseMsg:
+		if v.picking {
+			return v, nil
+		}
+		return v, v.updateFocused(msg)
+
+	case errMsg:
+		if pane := v.Focused(); pane != nil {
+			_, cmd := pane.Update(msg)
+			return v, cmd
+		}
+		return v, nil
+	}
+
+	var cmds []tea.Cmd
+	for _, pane := range v.panes {
+		if pane != nil {
+			_, cmd := pane.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+	return v, tea.Batch(cmds...)
+}
+
+// updateFocused passes a key or the mouse wheel to the focused pane,
+// scrolling the other by as many lines when linked
+func (v *LogSplitView) updateFocused(msg tea.Msg) tea.Cmd {
+	focused, other := v.panes[v.focused], v.panes[1-v.focused]
+	before := focused.viewport.YOffset
+	_, cmd := focused.Update(msg)
+	if v.linked {
+		other.scrollBy(focused.viewport.YOffset - before)
+	}
+	return cmd
+}
+
+// handlePickerKey narrows and moves through the pods to compare with;
+// Enter streams the picked pod's logs in the second pane
+func (v *LogSplitView) handlePickerKey(msg tea.KeyMsg) tea.Cmd {
+	result, choice := v.picker.handleKey(msg)
+	if result != pickerPicked {
+		return nil
+	}
+	left := v.panes[0]
+	pod := v.candidates[choice]
+	right := left.newPane()
+	right.SetSize(v.paneSize())
+	v.panes[1] = right
+	v.picking = false
+
+	// The pane finds the pod in a state of its own, whatever the list shows
+	state := &core.State{CurrentResourceType: core.ResourceTypePod, Pods: []v1.Pod{pod}}
+	return right.StartStreaming(v.ctx, left.client, state, pod.Namespace, pod.Name)
+}
+
+// IsPicking returns true while the pod of the second pane is being picked
+func (v *LogSplitView) IsPicking() bool {
+	return v.picking
+}
+
+// Focused returns the focused pane, nil while picking the second pane's pod
+func (v *LogSplitView) Focused() *LogView {
+	return v.panes[v.focused]
+}
+
+// ToggleFocus moves the focus to the other pane
+func (v *LogSplitView) ToggleFocus() {
+	if !v.picking {
+		v.focused = 1 - v.focused
+	}
+}
+
+// ToggleLinked turns linked scrolling on or off
+func (v *LogSplitView) ToggleLinked() {
+	v.linked = !v.linked
+}
+
+// IsLinked returns true when scrolling one pane scrolls the other
+func (v *LogSplitView) IsLinked() bool {
+	return v.linked
+}
+
+// CloseFocused closes the focused pane, returning the pane left open and
+// the one closed, whose streams the caller stops. While picking, it is the
+// picker that closes, and closed is nil.
+func (v *LogSplitView) CloseFocused() (open, closed *LogView) {
+	if v.picking {
+		return v.panes[0], nil
+	}
+	return v.panes[1-v.focused], v.panes[v.focused]
+}
+
+// View renders the two panes side by side
+func (v *LogSplitView) View() string {
+	focusedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57"))
+
+	paneTitleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	separatorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240"))
+
+	link := "Linked scrolling: OFF"
+	if v.linked {
+		link = "Linked scrolling: ON"
+	}
+	header := infoStyle.Render(link + "  [Tab] Switch pane  [L] Link  [Esc] Close pane")
+
+	paneWidth, paneHeight := v.paneSize()
+	var rendered [2]string
+	for i, pane := range v.panes {
+		var title, body string
+		if pane == nil {
+			title = " Compare with "
+			body = v.renderPicker(paneHeight)
+		} else {
+			_, name := pane.Resource()
+			title = " " + name + " "
+			body = pane.View()
+		}
+		if i == v.focused {
+			title = focusedStyle.Render("▶" + title)
+		} else {
+			title = paneTitleStyle.Render(" " + title)
+		}
+		rendered[i] = lipgloss.NewStyle().
+			Width(paneWidth).
+			MaxWidth(paneWidth).
+			Height(paneHeight + 1).
+			MaxHeight(paneHeight + 1).
+			Render(lipgloss.JoinVertical(lipgloss.Left, title, body))
+	}
+
+	separator := separatorStyle.Render(strings.TrimSuffix(strings.Repeat("│\n", paneHeight+1), "\n"))
+	panes := lipgloss.JoinHorizontal(lipgloss.Top, rendered[0], separator, rendered[1])
+
+	if v.width > 0 {
+		header = lipgloss.NewStyle().MaxWidth(v.width).Render(header)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, panes)
+}
+
+// renderPicker renders the pods to compare with in height lines
+func (v *LogSplitView) renderPicker(height int) string {
+	if len(v.candidates) == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("241")).
+			Render("No other pod to compare with\n\nEsc: cancel")
+	}
+	list := v.picker.render(height-2, func(choice int) string {
+		pod := v.candidates[choice]
+		return fmt.Sprintf("%s  %s", pod.Name, core.PodStatus(&pod, time.Now()))
+	})
+	return list + "\n\n" + v.picker.prompt("show logs")
+}
+
+// paneSize returns the size of each pane's log view, leaving room for the
+// header, the pane titles and the separator
+func (v *LogSplitView) paneSize() (int, int) {
+	width := (v.width - 1) / 2
+	height := v.height - 2
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	return width, height
+}
+
+// SetSize updates the view size and splits it between the panes
+func (v *LogSplitView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	paneWidth, paneHeight := v.paneSize()
+	for _, pane := range v.panes {
+		if pane != nil {
+			pane.SetSize(paneWidth, paneHeight)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/testutil/fixtures"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// createTestLogSplitView splits the logs of the first pod of a crash looping
+// rollout, streamed from workloadLogServer, picking the crash looping pod to
+// show beside them
+func createTestLogSplitView(t *testing.T) *LogSplitView {
+	t.Helper()
+	client, _ := workloadLogServer(t)
+	rollout := fixtures.CrashloopingRollout("web")
+	state := createTestState(core.ResourceTypePod, "web", "")
+	state.UpdatePods(rollout.Pods)
+
+	left := createTestLogView(t)
+	left.StartStreaming(context.Background(), client, state, "web", "api-6b8d9-k2x7p")()
+	view := NewLogSplitView(context.Background(), left, rollout.Pods[1:])
+	view.SetSize(160, 30)
+
+	if output := view.View(); !strings.Contains(output, "Compare with") || !strings.Contains(output, "api-7f4c2-z8r5w  CrashLoopBackOff") {
+		t.Fatalf("Expected the pods to compare with, got:\n%s", output)
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("7f4c")})
+	_, cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || view.IsPicking() {
+		t.Fatal("Expected Enter to open the picked pod's logs")
+	}
+	started := cmd()
+	if _, ok := started.(logStreamStartedMsg); !ok {
+		t.Fatalf("Expected the second pane's stream started, got %#v", started)
+	}
+	// Its first line is read, the first pane ignoring it
+	_, cmd = view.Update(started)
+	view.Update(cmd())
+	return view
+}
+
+func TestLogSplitViewPanesStreamTheirOwnPods(t *testing.T) {
+	view := createTestLogSplitView(t)
+	left, right := view.panes[0], view.panes[1]
+
+	if _, name := right.Resource(); name != "api-7f4c2-z8r5w" || view.Focused() != right {
+		t.Fatalf("Expected the focused second pane streaming api-7f4c2-z8r5w, got %s", name)
+	}
+	if !strings.Contains(strings.Join(right.content, "\n"), "line from api-7f4c2-z8r5w") {
+		t.Errorf("Expected the picked pod's line in the second pane, got:\n%s", strings.Join(right.content, "\n"))
+	}
+	if strings.Contains(strings.Join(left.content, "\n"), "api-7f4c2-z8r5w") {
+		t.Errorf("Expected the first pane to ignore the second's stream, got:\n%s", strings.Join(left.content, "\n"))
+	}
+	if output := view.View(); !strings.Contains(output, "▶ api-7f4c2-z8r5w") || !strings.Contains(output, "api-6b8d9-k2x7p") {
+		t.Errorf("Expected both pods named, the second focused, got:\n%s", output)
+	}
+}
+
+func TestLogSplitViewKeysGoToFocusedPane(t *testing.T) {
+	view := createTestLogSplitView(t)
+	left, right := view.panes[0], view.panes[1]
+
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if right.following || !left.following {
+		t.Errorf("Expected only the focused pane to stop following, got %v and %v", left.following, right.following)
+	}
+
+	view.ToggleFocus()
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("timeout")})
+	view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if left.searchQuery != "timeout" || right.searchQuery != "" {
+		t.Errorf("Expected only the first pane searched, got %q and %q", left.searchQuery, right.searchQuery)
+	}
+}
+
+func TestLogSplitViewLinkedScrolling(t *testing.T) {
+	view := createTestLogSplitView(t)
+	left, right := view.panes[0], view.panes[1]
+	for _, pane := range view.panes {
+		for i := range 100 {
+			pane.appendLogLine("api", fmt.Sprintf("line %d", i))
+		}
+		pane.refreshContent()
+		pane.viewport.GotoBottom()
+	}
+
+	// Unlinked, the other pane stays where it is
+	view.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	if right.following || !left.following || !left.viewport.AtBottom() {
+		t.Fatalf("Expected only the focused pane scrolled, got following %v and %v", left.following, right.following)
+	}
+
+	view.ToggleLinked()
+	leftBefore, rightBefore := left.viewport.YOffset, right.viewport.YOffset
+	view.Update(tea.KeyMsg{Type: tea.KeyUp})
+	view.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if rightBefore-right.viewport.YOffset != 2 || leftBefore-left.viewport.YOffset != 2 {
+		t.Errorf("Expected both panes scrolled up 2 lines, got %d and %d",
+			leftBefore-left.viewport.YOffset, rightBefore-right.viewport.YOffset)
+	}
+	if left.following {
+		t.Error("Expected the linked pane to stop following once scrolled up")
+	}
+}
+
+func TestLogSplitViewCloseFocused(t *testing.T) {
+	view := createTestLogSplitView(t)
+	left, right := view.panes[0], view.panes[1]
+
+	if open, closed := view.CloseFocused(); open != left || closed != right {
+		t.Error("Expected closing the focused second pane to leave the first")
+	}
+	view.ToggleFocus()
+	if open, closed := view.CloseFocused(); open != right || closed != left {
+		t.Error("Expected closing the focused first pane to leave the second")
+	}
+
+	picking := NewLogSplitView(context.Background(), left, nil)
+	picking.SetSize(160, 30)
+	if open, closed := picking.CloseFocused(); open != left || closed != nil {
+		t.Error("Expected closing the picker to leave the first pane")
+	}
+	if output := picking.View(); !strings.Contains(output, "No other pod to compare with") {
+		t.Errorf("Expected a note that there is no pod to compare with, got:\n%s", output)
+	}
+}
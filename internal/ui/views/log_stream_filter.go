@@ -0,0 +1,78 @@
+package views
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// logStreamFilter drops log lines that do not match a pattern as they are
+// read, before they reach the buffer, and counts them. It is distinct from
+// the search, which only moves through what was kept. Each stream's reader
+// checks its own lines, so the filter is shared under a lock.
+type logStreamFilter struct {
+	mu        sync.Mutex
+	pattern   *regexp.Regexp // nil keeps every line
+	discarded int
+}
+
+// Set filters by pattern, a regular expression, from the next line read;
+// empty keeps every line
+func (f *logStreamFilter) Set(pattern string) error {
+	var re *regexp.Regexp
+	if pattern != "" {
+		var err error
+		if re, err = regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid stream filter: %w", err)
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pattern = re
+	return nil
+}
+
+// Pattern returns the pattern filtered by, or "" when lines are kept
+func (f *logStreamFilter) Pattern() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.pattern == nil {
+		return ""
+	}
+	return f.pattern.String()
+}
+
+// Keep reports whether a line read is kept, counting those that are not.
+// Lines are matched without the colors written into them.
+func (f *logStreamFilter) Keep(line string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.pattern == nil || f.pattern.MatchString(plainLogText(line)) {
+		return true
+	}
+	f.discarded++
+	return false
+}
+
+// Discarded returns how many lines were dropped since the count was reset
+func (f *logStreamFilter) Discarded() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.discarded
+}
+
+// ResetCount starts counting dropped lines again, as streams are reopened
+func (f *logStreamFilter) ResetCount() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.discarded = 0
+}
+
+// validateStreamFilter accepts an empty pattern or a regular expression
+func validateStreamFilter(value string) error {
+	if _, err := regexp.Compile(value); err != nil {
+		return fmt.Errorf("not a regular expression: %s", strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+	}
+	return nil
+}
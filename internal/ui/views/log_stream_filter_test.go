@@ -0,0 +1,96 @@
+package views
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// streamLines reads the lines each stream keeps until it ends
+func streamLines(t *testing.T, lv *LogView, stream int) []string {
+	t.Helper()
+	var lines []string
+	for {
+		switch msg := lv.readNextLine(stream)().(type) {
+		case logLineMsg:
+			lines = append(lines, msg.line)
+		case logStreamEndedMsg:
+			return lines
+		default:
+			t.Fatalf("Expected a line from stream %d, got %#v", stream, msg)
+		}
+	}
+}
+
+func TestLogStreamFilterDropsLinesAsRead(t *testing.T) {
+	lv := createTestLogView(t)
+	lv.ctx = context.Background()
+	if err := lv.SetStreamFilter(`req-42\b`); err != nil {
+		t.Fatalf("SetStreamFilter failed: %v", err)
+	}
+
+	// Each stream is filtered on its own
+	lv.containers = []string{"web-1/app", "web-2/app"}
+	lv.scanners = []*bufio.Scanner{
+		bufio.NewScanner(strings.NewReader("GET / req-41\nGET /cart req-42\n\x1b[31mERROR req-42\x1b[0m timeout\n")),
+		bufio.NewScanner(strings.NewReader("GET / req-420\nPOST /pay req-42\n")),
+	}
+	if got := streamLines(t, lv, 0); len(got) != 2 || got[0] != "GET /cart req-42" {
+		t.Errorf("Expected web-1's two req-42 lines, colored or not, got %q", got)
+	}
+	if got := streamLines(t, lv, 1); len(got) != 1 || got[0] != "POST /pay req-42" {
+		t.Errorf("Expected web-2's req-42 line, got %q", got)
+	}
+	if discarded := lv.streamFilter.Discarded(); discarded != 2 {
+		t.Errorf("Expected 2 lines discarded, got %d", discarded)
+	}
+	if header := lv.View(); !strings.Contains(header, `Grep /req-42\b/ (2 discarded)`) {
+		t.Errorf("Expected the filter and discarded lines in the header, got:\n%s", header)
+	}
+}
+
+func TestLogStreamFilterChangedMidStream(t *testing.T) {
+	lv := createTestLogView(t)
+	lv.following = true
+	lv.Update(logLineMsg{ctx: lv.ctx, container: "app", line: "GET / req-41"})
+
+	lv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("|")})
+	if !lv.IsEditingStreamFilter() {
+		t.Fatal("Expected | to edit the stream filter")
+	}
+	lv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("req-(42")})
+	lv.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !lv.IsEditingStreamFilter() || lv.streamFilter.Pattern() != "" {
+		t.Fatal("Expected an invalid pattern refused")
+	}
+	if !strings.Contains(lv.View(), "not a regular expression") {
+		t.Errorf("Expected the refusal explained, got:\n%s", lv.View())
+	}
+	lv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(")")})
+	lv.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if lv.IsEditingStreamFilter() || lv.streamFilter.Pattern() != "req-(42)" {
+		t.Fatalf("Expected the filter set, got %q", lv.streamFilter.Pattern())
+	}
+
+	// The lines already shown stay, after a note of the change
+	want := []string{"GET / req-41", "--- Stream filter: only lines matching /req-(42)/ are kept from here ---"}
+	if len(lv.content) != 2 || lv.content[0] != want[0] || lv.content[1] != want[1] {
+		t.Errorf("Expected %q, got %q", want, lv.content)
+	}
+
+	// Esc leaves the filter as it was; an empty one keeps every line again
+	lv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("|")})
+	lv.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if lv.streamFilter.Pattern() != "req-(42)" {
+		t.Errorf("Expected Esc to keep the filter, got %q", lv.streamFilter.Pattern())
+	}
+	lv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("|")})
+	lv.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	lv.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if lv.streamFilter.Pattern() != "" || !strings.Contains(lv.content[len(lv.content)-1], "Stream filter cleared") {
+		t.Errorf("Expected the filter cleared with a note, got %q", lv.content)
+	}
+}
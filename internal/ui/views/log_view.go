@@ -5,9 +5,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/HamStudy/kubewatch/internal/components/textinput"
 	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/HamStudy/kubewatch/internal/k8s"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -31,40 +35,122 @@ type LogView struct {
 	scanners   []*bufio.Scanner // Multiple scanners
 	containers []string         // Container names
 	following  bool             // Auto-scroll to bottom
+	newBelow   int              // Lines arrived since scrolling away from the bottom
 	tailing    bool             // Keep reading new logs (always true while streaming)
 
 	// Search functionality
 	searchMode    bool
-	searchQuery   string
-	searchResults []int // Line indices that match search
-	currentMatch  int   // Current match index
+	searchInput   textinput.Model // The query being typed, untouched by arriving lines
+	searchQuery   string          // The query searched for
+	searchResults []int           // Line indices that match search
+	currentMatch  int             // Current match index
 
 	// Stream control
 	showStdout        bool
 	showStderr        bool
-	selectedContainer int // -1 for all, 0+ for specific container
+	selectedContainer int // -1 for all, 0+ into containerChoices
+
+	// The containers c cycles through and the picker offers: all of a
+	// pod's, init and ephemeral ones included, or the first pod's app
+	// containers for several pods. containers names the streams instead.
+	containerChoices []string
+	wantContainer    string // Container to select once the next stream starts
+
+	// Pods with many containers get a picker, filtered by typing, in place
+	// of cycling through them one by one
+	pickingContainer bool
+	picker           containerPicker
+
+	// Per-container usage of the pod whose logs are shown, nil when unknown
+	podMetrics *k8s.PodMetrics
 
 	// For deployments
 	pods        []string
 	selectedPod int // -1 for all, 0+ for specific pod
 
+	// listPods lists a deployment's or statefulset's pods again, so those a
+	// rollout starts join the stream and those it removes leave; nil for
+	// other resources
+	listPods func(ctx context.Context) ([]v1.Pod, error)
+
 	// For restarting streams
-	client       *k8s.Client
-	state        *core.State
-	resourceName string
-	needsRestart bool
+	client            *k8s.Client
+	state             *core.State
+	resourceNamespace string // Empty matches the resource in any namespace
+	resourceName      string
+	needsRestart      bool
+	tailLines         int64 // Lines of history fetched when a stream starts
+
+	// Multi-line record grouping keeps stack traces together, and apart from
+	// the lines of other streams
+	records      *LogRecordGrouper
+	lineInfo     []logLineInfo // Parallel to content
+	lineSeq      int
+	groupRecords bool
+
+	// Lines of several streams are prefixed with their source
+	prefixes     *logPrefixer
+	prefixStyles map[string]lipgloss.Style
+
+	// The buffer as shown, parallel to content, so a line is rendered once
+	// rather than every time another arrives
+	rendered []string
+
+	// Colors the logging programs wrote are shown unless turned off
+	colors bool
+
+	// Lines not matching the stream filter are dropped as they are read;
+	// the filter is edited in the status line
+	streamFilter      *logStreamFilter
+	streamFilterMode  bool
+	streamFilterInput textinput.Model
+}
+
+// logLineInfo records where a content line came from
+type logLineInfo struct {
+	record int    // Lines of one record share an id
+	seq    int    // Arrival order, used to ungroup
+	source string // Stream the line came from, empty for status messages
+	prefix int    // Length of the "[source]" label on the line
+	body   int    // Where the line's own text starts, after the padded prefix
 }
 
+const (
+	// defaultLogTailLines is used until SetTailLines is called with a positive value
+	defaultLogTailLines = 100
+
+	// minLogBufferLines and maxLogBufferLines bound how many lines the buffer
+	// keeps; see bufferLines
+	minLogBufferLines = 10000
+	maxLogBufferLines = 100000
+
+	// logPodResyncInterval is how often a workload's pods are listed again
+	// while its logs are streamed
+	logPodResyncInterval = 5 * time.Second
+
+	// logHorizontalStep is how many columns left/right scroll long lines
+	logHorizontalStep = 8
+)
+
 // NewLogView creates a new log view
 func NewLogView() *LogView {
+	vp := viewport.New(80, 20)
+	vp.SetHorizontalStep(logHorizontalStep)
 	return &LogView{
-		viewport:          viewport.New(80, 20),
+		viewport:          vp,
 		content:           []string{},
 		showStdout:        true,
 		showStderr:        true,
 		selectedContainer: -1, // Show all containers by default
 		selectedPod:       -1, // Show all pods by default
 		searchResults:     []int{},
+		tailLines:         defaultLogTailLines,
+		records:           NewLogRecordGrouper(nil, DefaultLogRecordTimeout),
+		groupRecords:      true,
+		colors:            true,
+		prefixes:          newLogPrefixer(),
+		streamFilter:      &logStreamFilter{},
+		streamFilterInput: textinput.New(validateStreamFilter),
 	}
 }
 
@@ -73,6 +159,16 @@ func (v *LogView) IsSearchMode() bool {
 	return v.searchMode
 }
 
+// IsEditingStreamFilter returns true while the stream filter is typed
+func (v *LogView) IsEditingStreamFilter() bool {
+	return v.streamFilterMode
+}
+
+// IsPickingContainer returns true while the container picker is open
+func (v *LogView) IsPickingContainer() bool {
+	return v.pickingContainer
+}
+
 // Init initializes the view
 func (v *LogView) Init() tea.Cmd {
 	return nil
@@ -89,27 +185,42 @@ func (v *LogView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch msg.String() {
 			case "enter":
 				v.searchMode = false
+				v.searchQuery = v.searchInput.Value()
 				v.performSearch()
 				return v, nil
 			case "esc":
 				// Cancel search mode
 				v.searchMode = false
+				v.searchInput.Reset()
 				v.searchQuery = ""
 				v.searchResults = []int{}
 				return v, nil
-			case "backspace":
-				if len(v.searchQuery) > 0 {
-					v.searchQuery = v.searchQuery[:len(v.searchQuery)-1]
-				}
-				return v, nil
 			default:
-				if len(msg.String()) == 1 {
-					v.searchQuery += msg.String()
-				}
+				v.searchInput.HandleKey(msg)
 				return v, nil
 			}
 		}
 
+		if v.streamFilterMode {
+			switch msg.String() {
+			case "enter":
+				if !v.streamFilterInput.Submit() {
+					return v, nil
+				}
+				v.streamFilterMode = false
+				v.changeStreamFilter(v.streamFilterInput.Value())
+			case "esc":
+				v.streamFilterMode = false
+			default:
+				v.streamFilterInput.HandleKey(msg)
+			}
+			return v, nil
+		}
+
+		if v.pickingContainer {
+			return v, v.handlePickerKey(msg)
+		}
+
 		// In normal mode, don't handle ESC - let the app handle it
 		if msg.String() == "esc" {
 			// Let the parent app handle ESC to close the log view
@@ -121,8 +232,15 @@ func (v *LogView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "/":
 			// Start search mode
 			v.searchMode = true
+			v.searchInput.Reset()
 			v.searchQuery = ""
 			return v, nil
+		case "|":
+			// Edit the stream filter, starting from the one in use
+			v.streamFilterMode = true
+			v.streamFilterInput.Reset()
+			v.streamFilterInput.SetValue(v.streamFilter.Pattern())
+			return v, nil
 		case "n":
 			// Next search result
 			if len(v.searchResults) > 0 {
@@ -142,16 +260,25 @@ func (v *LogView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return v, nil
 		case "f":
 			// Toggle follow mode
-			v.following = !v.following
 			if v.following {
-				v.viewport.GotoBottom()
+				v.detach()
+			} else {
+				v.resumeFollowing()
 			}
 			return v, nil
+		case "F", "G", "end":
+			v.resumeFollowing()
+			return v, nil
 		case "c":
-			// Cycle through containers
-			if len(v.containers) > 1 {
+			// Pick from many containers, or cycle through a few
+			if len(v.containerChoices) > core.ManyContainersThreshold {
+				v.pickingContainer = true
+				v.picker = newContainerPicker(v.containerChoices, true)
+				return v, nil
+			}
+			if len(v.containerChoices) > 1 {
 				v.selectedContainer++
-				if v.selectedContainer >= len(v.containers) {
+				if v.selectedContainer >= len(v.containerChoices) {
 					v.selectedContainer = -1 // Back to all
 				}
 				// Restart streaming with selected container
@@ -184,21 +311,30 @@ func (v *LogView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			// Note: Kubernetes API combines stdout/stderr, so this doesn't actually filter
 			// Would need to implement log parsing to detect stderr prefixes
-			v.content = append(v.content, "Note: Kubernetes combines stdout/stderr streams - filtering not available")
+			v.appendMessage("Note: Kubernetes combines stdout/stderr streams - filtering not available")
+			return v, nil
+		case "m":
+			// Toggle multi-line record grouping
+			v.groupRecords = !v.groupRecords
+			v.layoutRecords()
+			if len(v.searchResults) > 0 {
+				v.performSearch()
+			}
+			return v, nil
+		case "a":
+			// Toggle the colors written into the logs
+			v.colors = !v.colors
+			v.refreshContent()
 			return v, nil
 		case "C":
 			// Clear log buffer
-			v.content = []string{}
+			v.resetContent()
 			v.viewport.SetContent("")
 			return v, nil
 		case "g", "home":
-			v.following = false
+			v.detach()
 			v.viewport.GotoTop()
 			return v, nil
-		case "G", "end":
-			v.following = true
-			v.viewport.GotoBottom()
-			return v, nil
 		}
 
 	case tea.WindowSizeMsg:
@@ -207,6 +343,7 @@ func (v *LogView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if !v.ready {
 			v.viewport = viewport.New(msg.Width, msg.Height-3) // Extra line for status
 			v.viewport.YPosition = 0
+			v.viewport.SetHorizontalStep(logHorizontalStep)
 			v.ready = true
 		} else {
 			v.viewport.Width = msg.Width
@@ -214,28 +351,30 @@ func (v *LogView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case logStreamStartedMsg:
+		if msg.ctx != v.ctx {
+			return v, nil
+		}
 		// Stream has been initialized, start reading from all containers
+		if len(v.containers) > 1 {
+			v.layoutPrefixes()
+			v.refreshContent()
+		}
 		var cmds []tea.Cmd
 		for i := range v.scanners {
 			cmds = append(cmds, v.readNextLine(i))
 		}
+		if v.listPods != nil {
+			cmds = append(cmds, v.resyncPodsLater())
+		}
 		return v, tea.Batch(cmds...)
 
 	case logLineMsg:
-		// Format line with container prefix if multiple containers
-		line := msg.line
-		if len(v.containers) > 1 {
-			line = fmt.Sprintf("[%s] %s", msg.container, msg.line)
-		}
-		v.content = append(v.content, line)
-		if len(v.content) > 10000 {
-			// Keep last 10000 lines
-			v.content = v.content[len(v.content)-10000:]
-		}
-		v.viewport.SetContent(strings.Join(v.content, "\n"))
-		if v.following {
-			v.viewport.GotoBottom()
+		if msg.ctx != v.ctx {
+			// A line of another pane's stream
+			return v, nil
 		}
+		v.appendLogLine(msg.container, msg.line)
+		v.showNewLines(1)
 		// Continue reading from the container that sent this message
 		for i, container := range v.containers {
 			if container == msg.container {
@@ -244,18 +383,49 @@ func (v *LogView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return v, nil
 
+	case logStreamEndedMsg:
+		// Once closed, whether by the pod terminating or by leaving the
+		// stream, a stream says so only once
+		i := slices.Index(v.containers, msg.container)
+		if msg.ctx != v.ctx || i < 0 || v.scanners[i] == nil {
+			return v, nil
+		}
+		v.endStream(i)
+		if msg.err != nil {
+			v.appendMessage("Error: " + k8s.UserMessage(msg.err))
+		} else {
+			v.appendLogLine(msg.container, "--- End of logs (pod may have terminated) ---")
+		}
+		v.showNewLines(1)
+		return v, nil
+
+	case logPodsResyncMsg:
+		if msg.ctx != v.ctx || v.listPods == nil {
+			return v, nil
+		}
+		return v, v.resyncPods()
+
+	case logPodsListedMsg:
+		if msg.ctx != v.ctx || msg.ctx.Err() != nil {
+			for _, stream := range msg.joined {
+				stream.reader.Close()
+			}
+			return v, nil
+		}
+		return v, tea.Batch(v.syncPods(msg), v.resyncPodsLater())
+
 	case errMsg:
 		// Display error in the log view
-		v.content = append(v.content, fmt.Sprintf("Error: %v", msg.err))
-		v.viewport.SetContent(strings.Join(v.content, "\n"))
+		v.appendMessage("Error: " + k8s.UserMessage(msg.err))
+		v.showContent()
 		return v, nil
 	}
 
-	// Check if user scrolled manually (disable auto-follow)
+	// Scrolling away from the bottom, by key or wheel, stops following
 	oldY := v.viewport.YOffset
 	v.viewport, cmd = v.viewport.Update(msg)
-	if oldY != v.viewport.YOffset && v.viewport.YOffset < v.viewport.TotalLineCount()-v.viewport.Height {
-		v.following = false
+	if oldY != v.viewport.YOffset && !v.viewport.AtBottom() {
+		v.detach()
 	}
 
 	return v, cmd
@@ -267,16 +437,13 @@ func (v *LogView) View() string {
 		return "Loading logs..."
 	}
 
-	// Build header with status
-	followStatus := "FOLLOWING"
-	if !v.following {
-		followStatus = "SCROLLING"
-	}
-
 	// Container/Pod info
 	streamInfo := ""
-	if v.selectedContainer >= 0 && v.selectedContainer < len(v.containers) {
-		streamInfo = fmt.Sprintf(" | Container: %s", v.containers[v.selectedContainer])
+	if v.selectedContainer >= 0 && v.selectedContainer < len(v.containerChoices) {
+		streamInfo = fmt.Sprintf(" | Container: %s", v.containerChoices[v.selectedContainer])
+		if usage := v.containerUsage(v.containerChoices[v.selectedContainer]); usage != "" {
+			streamInfo += " (" + usage + ")"
+		}
 	} else if len(v.containers) > 1 {
 		streamInfo = fmt.Sprintf(" | All %d containers", len(v.containers))
 	}
@@ -287,18 +454,36 @@ func (v *LogView) View() string {
 		streamInfo += fmt.Sprintf(" | All %d pods", len(v.pods))
 	}
 
+	if v.groupRecords {
+		streamInfo += " | Records"
+	} else {
+		streamInfo += " | Lines"
+	}
+
+	if pattern := v.streamFilter.Pattern(); pattern != "" {
+		streamInfo += fmt.Sprintf(" | Grep /%s/ (%s discarded)", pattern, formatThousands(v.streamFilter.Discarded()))
+	}
+
 	header := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("86")).
-		Render(fmt.Sprintf("📜 Logs [%s]%s", followStatus, streamInfo))
+		Render(fmt.Sprintf("📜 Logs [%s]%s", v.followIndicator(), streamInfo))
 	// Build status line
 	statusStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 
 	statusText := ""
-	if v.searchMode {
+	if v.pickingContainer {
+		statusText = v.picker.prompt("stream")
+	} else if v.streamFilterMode {
+		filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229"))
+		statusText = filterStyle.Render("Keep lines matching: " + v.streamFilterInput.View())
+		if errView := v.streamFilterInput.ErrorView(); errView != "" {
+			statusText += "  " + errView
+		}
+	} else if v.searchMode {
 		// Show search input
 		searchStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229"))
-		statusText = searchStyle.Render(fmt.Sprintf("Search: %s_", v.searchQuery))
+		statusText = searchStyle.Render("Search: " + v.searchInput.View())
 	} else if len(v.searchResults) > 0 {
 		// Show search results
 		statusText = fmt.Sprintf("Match %d/%d | n: next | N: prev | /: new search",
@@ -306,7 +491,7 @@ func (v *LogView) View() string {
 	} else {
 		// Normal status
 		statusText = fmt.Sprintf(
-			"Lines: %d | Pos: %d/%d | /: search | c: containers | p: pods | f: follow | ?: help",
+			"Lines: %d | Pos: %d/%d | /: search | '|': grep | c: containers | p: pods | m: records | f: follow | ?: help",
 			len(v.content),
 			v.viewport.YOffset+1,
 			v.viewport.TotalLineCount(),
@@ -317,7 +502,9 @@ func (v *LogView) View() string {
 
 	// Apply search highlighting to the content if we have search results
 	var viewportContent string
-	if len(v.searchResults) > 0 && v.searchQuery != "" {
+	if v.pickingContainer {
+		viewportContent = v.renderContainerPicker()
+	} else if len(v.searchResults) > 0 && v.searchQuery != "" {
 		viewportContent = v.getHighlightedContent()
 	} else {
 		viewportContent = v.viewport.View()
@@ -326,31 +513,392 @@ func (v *LogView) View() string {
 	return fmt.Sprintf("%s\n%s\n%s", header, viewportContent, status)
 }
 
+// SetPodMetrics sets the usage of the pod whose logs are shown next, which
+// the header and container picker break down by container; nil for none
+func (v *LogView) SetPodMetrics(metrics *k8s.PodMetrics) {
+	v.podMetrics = metrics
+}
+
+// containerUsage returns a container's CPU and memory, e.g. "120m/256Mi",
+// or "" when the pod's metrics do not include it
+func (v *LogView) containerUsage(name string) string {
+	if v.podMetrics == nil {
+		return ""
+	}
+	for _, c := range v.podMetrics.Containers {
+		if c.Name == name {
+			return c.CPU + "/" + c.Memory
+		}
+	}
+	return ""
+}
+
+// handlePickerKey filters, moves through and picks from the container picker
+func (v *LogView) handlePickerKey(msg tea.KeyMsg) tea.Cmd {
+	result, choice := v.picker.handleKey(msg)
+	if result == pickerOpen {
+		return nil
+	}
+	v.pickingContainer = false
+	if result == pickerCancelled || choice == v.selectedContainer {
+		return nil
+	}
+	v.selectedContainer = choice
+	return v.restartStreaming()
+}
+
+// renderContainerPicker renders the matching containers in place of the
+// logs, with each one's usage and the one being streamed marked
+func (v *LogView) renderContainerPicker() string {
+	return v.picker.render(v.viewport.Height, func(choice int) string {
+		label := "All containers"
+		if choice >= 0 {
+			label = v.containerChoices[choice]
+			if usage := v.containerUsage(label); usage != "" {
+				label += "  " + usage
+			}
+		}
+		if choice == v.selectedContainer {
+			label += " (streaming)"
+		}
+		return label
+	})
+}
+
 // SetSize updates the view size
 func (v *LogView) SetSize(width, height int) {
 	v.width = width
 	v.height = height
-	v.viewport.Width = width
-	v.viewport.Height = height - 3 // Account for header and status line
+	resizeViewport(&v.viewport, width, height-3, nil) // Account for header and status line
 	v.ready = true
 }
 
-// performSearch searches for the query in the log content
+// SetTailLines sets how many lines of history are fetched when logs are opened
+func (v *LogView) SetTailLines(lines int) {
+	if lines <= 0 {
+		lines = defaultLogTailLines
+	}
+	v.tailLines = int64(lines)
+}
+
+// Resource returns the namespace and name of the resource whose logs are
+// shown, the namespace empty when it may be any
+func (v *LogView) Resource() (string, string) {
+	return v.resourceNamespace, v.resourceName
+}
+
+// newPane returns a log view without streams, set up like v: its history,
+// buffer, records, colors and stream filter
+func (v *LogView) newPane() *LogView {
+	pane := NewLogView()
+	pane.tailLines = v.tailLines
+	pane.records = NewLogRecordGrouper(v.records.start, v.records.timeout)
+	pane.groupRecords = v.groupRecords
+	pane.colors = v.colors
+	pane.SetStreamFilter(v.streamFilter.Pattern()) // Valid, as v filters by it
+	return pane
+}
+
+// scrollBy scrolls by delta lines, down when positive, as linked panes do.
+// Scrolling away from the bottom stops following, and back to it resumes.
+func (v *LogView) scrollBy(delta int) {
+	switch {
+	case delta > 0:
+		v.viewport.ScrollDown(delta)
+	case delta < 0:
+		v.viewport.ScrollUp(-delta)
+	default:
+		return
+	}
+	if v.viewport.AtBottom() {
+		v.resumeFollowing()
+	} else {
+		v.detach()
+	}
+}
+
+// bufferLines is how many lines the buffer keeps, the oldest dropped first:
+// the tail lines of every stream merged into it, so each stream's history
+// fits, but never fewer than minLogBufferLines nor, to bound memory however
+// many pods are merged, more than maxLogBufferLines
+func (v *LogView) bufferLines() int {
+	lines := int(v.tailLines) * max(len(v.containers), 1)
+	return min(max(lines, minLogBufferLines), maxLogBufferLines)
+}
+
+// SetRecordGrouping sets the pattern matching the first line of a multi-line
+// log record (empty for DefaultLogRecordStart) and whether grouping is on
+func (v *LogView) SetRecordGrouping(pattern string, enabled bool) error {
+	var start *regexp.Regexp
+	if pattern != "" {
+		var err error
+		if start, err = regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid log record start pattern: %w", err)
+		}
+	}
+	v.records = NewLogRecordGrouper(start, DefaultLogRecordTimeout)
+	if v.groupRecords != enabled {
+		v.groupRecords = enabled
+		v.layoutRecords()
+	}
+	return nil
+}
+
+// SetStreamFilter sets the regular expression lines must match to be kept
+// as they are read, empty to keep every line
+func (v *LogView) SetStreamFilter(pattern string) error {
+	if err := v.streamFilter.Set(pattern); err != nil {
+		return err
+	}
+	v.streamFilterInput.SetValue(pattern)
+	return nil
+}
+
+// changeStreamFilter filters by pattern from the next line read. The lines
+// already in the buffer stay as they are, so a note marks where the filter
+// changed.
+func (v *LogView) changeStreamFilter(pattern string) {
+	if pattern == v.streamFilter.Pattern() || v.streamFilter.Set(pattern) != nil {
+		return
+	}
+	if pattern == "" {
+		v.appendMessage("--- Stream filter cleared: every line is kept from here ---")
+	} else {
+		v.appendMessage(fmt.Sprintf("--- Stream filter: only lines matching /%s/ are kept from here ---", pattern))
+	}
+	v.showContent()
+	if v.following {
+		v.viewport.GotoBottom()
+	}
+}
+
+// SetColors sets whether the colors written into log lines are shown
+func (v *LogView) SetColors(enabled bool) {
+	if v.colors != enabled {
+		v.colors = enabled
+		v.refreshContent()
+	}
+}
+
+// appendLogLine adds a line from a stream, sanitized so escape sequences in
+// it can't move the cursor or clear the screen. While grouping, a line
+// continuing a record goes right after that record's other lines, so
+// records from different streams never interleave.
+func (v *LogView) appendLogLine(stream, line string) {
+	line = sanitizeLogLine(line)
+	record, continuation := v.records.Add(stream, plainLogText(line), time.Now())
+
+	info := logLineInfo{record: record, source: stream}
+	if len(v.containers) > 1 {
+		// Prefix lines with their stream when there are several
+		if !v.prefixes.Has(stream) {
+			v.layoutPrefixes(stream)
+		}
+		prefix, label := v.prefixes.Prefix(stream)
+		line = prefix + line
+		info.prefix, info.body = label, len(prefix)
+	}
+
+	pos := len(v.content)
+	if v.groupRecords && continuation {
+		v.syncLineInfo()
+		for pos > 0 && v.lineInfo[pos-1].record > record {
+			pos--
+		}
+	}
+	v.insertLine(pos, line, info)
+}
+
+// appendMessage adds a status line as a record of its own
+func (v *LogView) appendMessage(message string) {
+	v.insertLine(len(v.content), message, logLineInfo{record: v.records.NewRecord()})
+}
+
+// insertLine inserts a line at pos, dropping the oldest lines beyond bufferLines
+func (v *LogView) insertLine(pos int, line string, info logLineInfo) {
+	v.syncLineInfo()
+	v.lineSeq++
+	info.seq = v.lineSeq
+
+	v.content = append(v.content, "")
+	v.lineInfo = append(v.lineInfo, logLineInfo{})
+	copy(v.content[pos+1:], v.content[pos:])
+	copy(v.lineInfo[pos+1:], v.lineInfo[pos:])
+	v.content[pos] = line
+	v.lineInfo[pos] = info
+
+	// Keep the rendered lines in step while they are
+	inStep := len(v.rendered) == len(v.content)-1
+	if inStep {
+		v.rendered = append(v.rendered, "")
+		copy(v.rendered[pos+1:], v.rendered[pos:])
+		v.rendered[pos] = v.renderContentLine(pos)
+	}
+
+	if limit := v.bufferLines(); len(v.content) > limit {
+		drop := len(v.content) - limit
+		v.content = v.content[drop:]
+		v.lineInfo = v.lineInfo[drop:]
+		if inStep {
+			v.rendered = v.rendered[drop:]
+		}
+	}
+}
+
+// syncLineInfo gives lines added to content directly a record of their own
+func (v *LogView) syncLineInfo() {
+	if len(v.lineInfo) > len(v.content) {
+		v.lineInfo = v.lineInfo[:len(v.content)]
+	}
+	for len(v.lineInfo) < len(v.content) {
+		v.lineSeq++
+		v.lineInfo = append(v.lineInfo, logLineInfo{record: v.records.NewRecord(), seq: v.lineSeq})
+	}
+}
+
+// resetContent empties the log buffer and closes any open records
+func (v *LogView) resetContent(lines ...string) {
+	v.content = []string{}
+	v.lineInfo = nil
+	v.rendered = nil
+	v.newBelow = 0
+	v.records.Reset()
+	for _, line := range lines {
+		v.appendMessage(line)
+	}
+}
+
+// layoutRecords orders the buffer by record while grouping, or by arrival otherwise
+func (v *LogView) layoutRecords() {
+	v.syncLineInfo()
+	order := make([]int, len(v.content))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ia, ib := v.lineInfo[order[a]], v.lineInfo[order[b]]
+		if v.groupRecords && ia.record != ib.record {
+			return ia.record < ib.record
+		}
+		return ia.seq < ib.seq
+	})
+
+	content := make([]string, len(order))
+	lineInfo := make([]logLineInfo, len(order))
+	for i, j := range order {
+		content[i] = v.content[j]
+		lineInfo[i] = v.lineInfo[j]
+	}
+	v.content = content
+	v.lineInfo = lineInfo
+	v.refreshContent()
+}
+
+// refreshContent renders the whole buffer into the viewport, for when the
+// way every line is shown has changed
+func (v *LogView) refreshContent() {
+	v.rendered = make([]string, len(v.content))
+	for i := range v.content {
+		v.rendered[i] = v.renderContentLine(i)
+	}
+	v.viewport.SetContent(strings.Join(v.rendered, "\n"))
+}
+
+// showContent puts the rendered buffer into the viewport, rendering it all
+// again only when the lines rendered have fallen out of step with it
+func (v *LogView) showContent() {
+	if len(v.rendered) != len(v.content) {
+		v.refreshContent()
+		return
+	}
+	v.viewport.SetContent(strings.Join(v.rendered, "\n"))
+}
+
+// renderContentLine renders buffered line i, coloring its stream prefix so
+// the lines of one pod or container stand out together
+func (v *LogView) renderContentLine(i int) string {
+	line := v.renderLine(v.content[i])
+	if i < len(v.lineInfo) {
+		if info := v.lineInfo[i]; info.prefix > 0 && info.prefix <= len(line) {
+			return v.prefixStyle(info.source).Render(line[:info.prefix]) + line[info.prefix:]
+		}
+	}
+	return line
+}
+
+// prefixStyle returns the style of a stream's prefix, made once per stream
+func (v *LogView) prefixStyle(source string) lipgloss.Style {
+	style, ok := v.prefixStyles[source]
+	if !ok {
+		if v.prefixStyles == nil {
+			v.prefixStyles = make(map[string]lipgloss.Style)
+		}
+		style = lipgloss.NewStyle().Foreground(lipgloss.Color(v.prefixes.Color(source)))
+		v.prefixStyles[source] = style
+	}
+	return style
+}
+
+// renderLine prepares a buffered line for the viewport, dropping its colors
+// when they are turned off and expanding tabs so widths add up
+func (v *LogView) renderLine(line string) string {
+	if !v.colors {
+		line = plainLogText(line)
+	}
+	return expandLogTabs(line)
+}
+
+// layoutPrefixes lays out the prefixes of the streams, of the lines buffered
+// and of any other sources given, and re-prefixes the buffered lines to
+// match. Colors already given are kept.
+func (v *LogView) layoutPrefixes(others ...string) {
+	v.syncLineInfo()
+	sources := append(append([]string(nil), v.containers...), others...)
+	seen := make(map[string]bool)
+	for _, source := range sources {
+		seen[source] = true
+	}
+	for _, info := range v.lineInfo {
+		if info.body > 0 && !seen[info.source] {
+			seen[info.source] = true
+			sources = append(sources, info.source)
+		}
+	}
+	v.prefixes.Layout(sources)
+	// Every line is prefixed anew, so it is rendered anew
+	v.prefixStyles = nil
+	v.rendered = nil
+
+	for i, info := range v.lineInfo {
+		if info.body == 0 || info.body > len(v.content[i]) {
+			continue
+		}
+		prefix, label := v.prefixes.Prefix(info.source)
+		v.content[i] = prefix + v.content[i][info.body:]
+		v.lineInfo[i].prefix, v.lineInfo[i].body = label, len(prefix)
+	}
+}
+
+// searchUnit identifies what a search match selects: the whole record while
+// grouping, otherwise the single line
+func (v *LogView) searchUnit(i int) int {
+	if v.groupRecords && i < len(v.lineInfo) && len(v.lineInfo) == len(v.content) {
+		return v.lineInfo[i].record
+	}
+	return -1 - i
+}
+
+// performSearch searches for the query in the log content. While grouping,
+// each matching record is one result, so n/N step over whole stack traces.
 func (v *LogView) performSearch() {
 	v.searchResults = []int{}
 	if v.searchQuery == "" {
 		// Clear highlighting by resetting the viewport content
-		v.viewport.SetContent(strings.Join(v.content, "\n"))
+		v.refreshContent()
 		return
 	}
 
-	query := strings.ToLower(v.searchQuery)
-	for i, line := range v.content {
-		if strings.Contains(strings.ToLower(line), query) {
-			v.searchResults = append(v.searchResults, i)
-		}
-	}
-
+	v.searchResults = v.findMatches()
 	if len(v.searchResults) > 0 {
 		v.currentMatch = 0
 		v.jumpToMatch()
@@ -359,6 +907,73 @@ func (v *LogView) performSearch() {
 	// The highlighting will be applied in the View() method
 }
 
+// findMatches returns the lines matching the search query, one per unit
+func (v *LogView) findMatches() []int {
+	var matches []int
+	lastUnit := 0 // Never a valid unit: records count from 1, lines from -1
+	for i, line := range v.content {
+		if start, _ := indexFold(plainLogText(line), v.searchQuery); start >= 0 {
+			if unit := v.searchUnit(i); unit != lastUnit {
+				matches = append(matches, i)
+				lastUnit = unit
+			}
+		}
+	}
+	return matches
+}
+
+// updateSearchResults searches again as lines arrive, moving neither the
+// view nor the current match, so the results stay in step with the buffer
+func (v *LogView) updateSearchResults() {
+	if v.searchQuery == "" {
+		return
+	}
+	current := -1
+	if v.currentMatch >= 0 && v.currentMatch < len(v.searchResults) && v.searchResults[v.currentMatch] < len(v.lineInfo) {
+		current = v.lineInfo[v.searchResults[v.currentMatch]].seq
+	}
+
+	v.searchResults = v.findMatches()
+	v.currentMatch = 0
+	for i, line := range v.searchResults {
+		if line < len(v.lineInfo) && v.lineInfo[line].seq == current {
+			v.currentMatch = i
+			break
+		}
+	}
+}
+
+// detach stops following, counting the lines that arrive from now on
+func (v *LogView) detach() {
+	if v.following {
+		v.following = false
+		v.newBelow = 0
+	}
+}
+
+// resumeFollowing follows again, jumping to the newest line
+func (v *LogView) resumeFollowing() {
+	v.following = true
+	v.newBelow = 0
+	v.viewport.GotoBottom()
+}
+
+// followIndicator tells whether new lines are followed, or how many of them
+// wait below while scrolled away
+func (v *LogView) followIndicator() string {
+	if v.following {
+		return "▼ LIVE"
+	}
+	below := v.viewport.TotalLineCount() - v.viewport.YOffset - v.viewport.Height
+	if v.newBelow < below {
+		below = v.newBelow
+	}
+	if below <= 0 {
+		return "⏸ paused — press F/End to resume"
+	}
+	return fmt.Sprintf("⏸ %d new lines below — press F/End to resume", below)
+}
+
 // jumpToMatch jumps to the current search match
 func (v *LogView) jumpToMatch() {
 	if v.currentMatch >= 0 && v.currentMatch < len(v.searchResults) {
@@ -379,7 +994,7 @@ func (v *LogView) jumpToMatch() {
 			v.viewport.YOffset = maxOffset
 		}
 
-		v.following = false // Disable following when jumping to search result
+		v.detach() // Disable following when jumping to search result
 	}
 }
 
@@ -407,8 +1022,6 @@ func (v *LogView) getHighlightedContent() string {
 		Foreground(lipgloss.Color("15"))   // White text
 
 	var result []string
-	query := strings.ToLower(v.searchQuery)
-
 	for i := startLine; i < endLine; i++ {
 		if i < 0 || i >= len(v.content) {
 			result = append(result, "")
@@ -416,66 +1029,65 @@ func (v *LogView) getHighlightedContent() string {
 		}
 
 		line := v.content[i]
-		lowerLine := strings.ToLower(line)
+		plain := plainLogText(line)
 
 		// Check if this line contains the search query
-		if strings.Contains(lowerLine, query) {
-			// Check if this is the current match line
-			isCurrentMatch := false
-			if v.currentMatch >= 0 && v.currentMatch < len(v.searchResults) {
-				isCurrentMatch = (i == v.searchResults[v.currentMatch])
-			}
-
-			// Highlight all occurrences in the line
-			highlightedLine := ""
-			lastEnd := 0
-
-			for {
-				idx := strings.Index(lowerLine[lastEnd:], query)
-				if idx == -1 {
-					// No more matches, append the rest
-					highlightedLine += line[lastEnd:]
-					break
-				}
-
-				// Add the part before the match
-				actualIdx := lastEnd + idx
-				highlightedLine += line[lastEnd:actualIdx]
-
-				// Add the highlighted match
-				matchText := line[actualIdx : actualIdx+len(v.searchQuery)]
-				if isCurrentMatch {
-					highlightedLine += currentHighlightStyle.Render(matchText)
-				} else {
-					highlightedLine += highlightStyle.Render(matchText)
-				}
-
-				lastEnd = actualIdx + len(v.searchQuery)
-			}
+		start, end := indexFold(plain, v.searchQuery)
+		if start < 0 {
+			result = append(result, v.renderLine(line))
+			continue
+		}
 
-			result = append(result, highlightedLine)
-		} else {
-			result = append(result, line)
+		// Check if this is the current match line
+		isCurrentMatch := false
+		if v.currentMatch >= 0 && v.currentMatch < len(v.searchResults) {
+			isCurrentMatch = v.searchUnit(i) == v.searchUnit(v.searchResults[v.currentMatch])
+		}
+		style := highlightStyle
+		if isCurrentMatch {
+			style = currentHighlightStyle
 		}
-	}
 
-	// Pad with empty lines if needed
-	for len(result) < v.viewport.Height {
-		result = append(result, "")
+		// Highlight all occurrences in the line, which loses its own colors
+		var highlighted strings.Builder
+		for start >= 0 {
+			highlighted.WriteString(plain[:start])
+			highlighted.WriteString(style.Render(plain[start:end]))
+			plain = plain[end:]
+			start, end = indexFold(plain, v.searchQuery)
+		}
+		highlighted.WriteString(plain)
+		result = append(result, expandLogTabs(highlighted.String()))
 	}
 
-	return strings.Join(result, "\n")
+	// Render through a copy of the viewport so the lines are cut at the
+	// same horizontal scroll position
+	vp := v.viewport
+	vp.SetContent(strings.Join(result, "\n"))
+	vp.YOffset = 0
+	return vp.View()
 }
 
-// StartStreaming starts streaming logs for the selected resource
-func (v *LogView) StartStreaming(ctx context.Context, client *k8s.Client, state *core.State, selectedResourceName string) tea.Cmd {
+// StartStreaming starts streaming logs for the selected resource, the one
+// named selectedResourceName in namespace
+func (v *LogView) StartStreaming(ctx context.Context, client *k8s.Client, state *core.State, namespace, selectedResourceName string) tea.Cmd {
 	// Store for restarting
 	v.client = client
 	v.state = state
+	v.resourceNamespace = namespace
 	v.resourceName = selectedResourceName
 
+	// The same name may be listed in several namespaces
+	matches := func(resourceNamespace, name string) bool {
+		return name == selectedResourceName && (namespace == "" || resourceNamespace == namespace)
+	}
+
 	v.ctx, v.cancelFunc = context.WithCancel(ctx)
-	v.content = []string{}
+	v.resetContent()
+	v.streamFilter.ResetCount()
+	if pattern := v.streamFilter.Pattern(); pattern != "" {
+		v.appendMessage(fmt.Sprintf("=== Only lines matching /%s/ are kept ===", pattern))
+	}
 	v.following = true // Start with auto-follow enabled
 	v.tailing = true   // Always tail while streaming
 	v.viewport.SetContent("Loading logs...")
@@ -487,6 +1099,8 @@ func (v *LogView) StartStreaming(ctx context.Context, client *k8s.Client, state
 
 	// Reset pod list for new resource
 	v.pods = []string{}
+	v.listPods = nil
+	streamCtx := v.ctx
 
 	return func() tea.Msg {
 		var readers []io.ReadCloser
@@ -497,29 +1111,23 @@ func (v *LogView) StartStreaming(ctx context.Context, client *k8s.Client, state
 		case core.ResourceTypePod:
 			// Find the pod by name
 			for _, pod := range state.Pods {
-				if pod.Name == selectedResourceName {
-					// Build list of all container names for selection
-					allContainers := []string{}
+				if matches(pod.Namespace, pod.Name) {
+					// Any container can be picked, init and ephemeral
+					// ones included; all containers are the app ones
+					var appContainers []string
 					for _, container := range pod.Spec.Containers {
-						allContainers = append(allContainers, container.Name)
-					}
-					v.containers = allContainers
-
-					// Determine which containers to stream
-					containersToStream := []string{}
-					if v.selectedContainer >= 0 && v.selectedContainer < len(allContainers) {
-						// Stream only selected container
-						containersToStream = []string{allContainers[v.selectedContainer]}
-					} else {
-						// Stream all containers
-						containersToStream = allContainers
+						appContainers = append(appContainers, container.Name)
 					}
+					v.setContainerChoices(podContainerNames(core.PodContainers(&pod)))
 
-					// Stream logs from selected containers
+					containersToStream := appContainers
+					if v.selectedContainer >= 0 {
+						containersToStream = []string{v.containerChoices[v.selectedContainer]}
+					}
 					for _, containerName := range containersToStream {
-						reader, err := client.GetPodLogs(v.ctx, pod.Namespace, pod.Name, containerName, true, 100)
+						reader, err := client.GetPodLogsWithOptions(v.ctx, pod.Namespace, pod.Name, containerName, true, v.tailLines, false, nil, false)
 						if err != nil {
-							v.content = append(v.content, fmt.Sprintf("[%s] Error: %v", containerName, err))
+							v.appendMessage(fmt.Sprintf("[%s] Error: %s", containerName, k8s.UserMessage(err)))
 							continue
 						}
 						readers = append(readers, reader)
@@ -528,9 +1136,9 @@ func (v *LogView) StartStreaming(ctx context.Context, client *k8s.Client, state
 
 					// Show status message
 					if v.selectedContainer >= 0 {
-						v.content = append(v.content, fmt.Sprintf("=== Streaming logs from container: %s ===", containersToStream[0]))
-					} else if len(pod.Spec.Containers) > 1 {
-						v.content = append(v.content, fmt.Sprintf("=== Streaming logs from %d containers: %v ===", len(containerNames), containerNames))
+						v.appendMessage(fmt.Sprintf("=== Streaming logs from container: %s ===", containersToStream[0]))
+					} else if len(appContainers) > 1 {
+						v.appendMessage(fmt.Sprintf("=== Streaming logs from %d containers: %v ===", len(containerNames), containerNames))
 					}
 					break
 				}
@@ -538,68 +1146,13 @@ func (v *LogView) StartStreaming(ctx context.Context, client *k8s.Client, state
 		case core.ResourceTypeDeployment:
 			// Find the deployment by name
 			for _, deployment := range state.Deployments {
-				if deployment.Name == selectedResourceName {
-					// Get pods for deployment
-					pods, err := client.GetPodsForDeployment(v.ctx, deployment.Namespace, deployment.Name)
+				if matches(deployment.Namespace, deployment.Name) {
+					v.listPods = func(ctx context.Context) ([]v1.Pod, error) {
+						return client.GetPodsForDeployment(ctx, deployment.Namespace, deployment.Name)
+					}
+					pods, err := v.listPods(v.ctx)
 					if err == nil && len(pods) > 0 {
-						// Store pod names for cycling
-						v.pods = []string{}
-						allPodNames := []string{}
-						for _, pod := range pods {
-							allPodNames = append(allPodNames, pod.Name)
-						}
-						v.pods = allPodNames
-
-						// Build container list from first pod (assume all pods have same containers)
-						if len(pods) > 0 {
-							allContainers := []string{}
-							for _, container := range pods[0].Spec.Containers {
-								allContainers = append(allContainers, container.Name)
-							}
-							v.containers = allContainers
-						}
-
-						// Determine which pods to stream
-						podsToStream := pods
-						if v.selectedPod >= 0 && v.selectedPod < len(pods) {
-							// Stream only selected pod
-							podsToStream = []v1.Pod{pods[v.selectedPod]}
-						}
-
-						// Determine which containers to stream
-						containersToStream := v.containers
-						if v.selectedContainer >= 0 && v.selectedContainer < len(v.containers) {
-							// Stream only selected container
-							containersToStream = []string{v.containers[v.selectedContainer]}
-						}
-
-						// Stream from selected pods and containers
-						for _, pod := range podsToStream {
-							for _, containerName := range containersToStream {
-								reader, err := client.GetPodLogs(v.ctx, pod.Namespace, pod.Name, containerName, true, 100)
-								if err != nil {
-									v.content = append(v.content, fmt.Sprintf("[%s/%s] Error: %v", pod.Name, containerName, err))
-									continue
-								}
-								readers = append(readers, reader)
-								// Include pod name in container identifier for deployments
-								containerNames = append(containerNames, fmt.Sprintf("%s/%s", pod.Name, containerName))
-							}
-						}
-
-						// Show status message
-						statusMsg := ""
-						if v.selectedPod >= 0 {
-							statusMsg = fmt.Sprintf("Pod: %s", allPodNames[v.selectedPod])
-						} else {
-							statusMsg = fmt.Sprintf("%d pods", len(podsToStream))
-						}
-						if v.selectedContainer >= 0 {
-							statusMsg += fmt.Sprintf(", Container: %s", v.containers[v.selectedContainer])
-						} else {
-							statusMsg += fmt.Sprintf(", %d containers", len(containersToStream))
-						}
-						v.content = append(v.content, fmt.Sprintf("=== Streaming logs: %s ===", statusMsg))
+						readers, containerNames = v.streamPods(client, pods)
 					}
 					break
 				}
@@ -607,67 +1160,44 @@ func (v *LogView) StartStreaming(ctx context.Context, client *k8s.Client, state
 		case core.ResourceTypeStatefulSet:
 			// Find the statefulset by name
 			for _, sts := range state.StatefulSets {
-				if sts.Name == selectedResourceName {
-					// Get pods for statefulset
-					pods, err := client.GetPodsForStatefulSet(v.ctx, sts.Namespace, sts.Name)
+				if matches(sts.Namespace, sts.Name) {
+					v.listPods = func(ctx context.Context) ([]v1.Pod, error) {
+						return client.GetPodsForStatefulSet(ctx, sts.Namespace, sts.Name)
+					}
+					pods, err := v.listPods(v.ctx)
 					if err == nil && len(pods) > 0 {
-						// Store pod names for cycling
-						v.pods = []string{}
-						allPodNames := []string{}
-						for _, pod := range pods {
-							allPodNames = append(allPodNames, pod.Name)
-						}
-						v.pods = allPodNames
-
-						// Build container list from first pod
-						if len(pods) > 0 {
-							allContainers := []string{}
-							for _, container := range pods[0].Spec.Containers {
-								allContainers = append(allContainers, container.Name)
-							}
-							v.containers = allContainers
-						}
-
-						// Determine which pods to stream
-						podsToStream := pods
-						if v.selectedPod >= 0 && v.selectedPod < len(pods) {
-							// Stream only selected pod
-							podsToStream = []v1.Pod{pods[v.selectedPod]}
-						}
-
-						// Determine which containers to stream
-						containersToStream := v.containers
-						if v.selectedContainer >= 0 && v.selectedContainer < len(v.containers) {
-							// Stream only selected container
-							containersToStream = []string{v.containers[v.selectedContainer]}
-						}
-
-						// Stream from selected pods and containers
-						for _, pod := range podsToStream {
-							for _, containerName := range containersToStream {
-								reader, err := client.GetPodLogs(v.ctx, pod.Namespace, pod.Name, containerName, true, 100)
-								if err != nil {
-									v.content = append(v.content, fmt.Sprintf("[%s/%s] Error: %v", pod.Name, containerName, err))
-									continue
-								}
-								readers = append(readers, reader)
-								containerNames = append(containerNames, fmt.Sprintf("%s/%s", pod.Name, containerName))
-							}
-						}
-
-						// Show status message
-						statusMsg := ""
-						if v.selectedPod >= 0 {
-							statusMsg = fmt.Sprintf("Pod: %s", allPodNames[v.selectedPod])
-						} else {
-							statusMsg = fmt.Sprintf("%d pods", len(podsToStream))
-						}
-						if v.selectedContainer >= 0 {
-							statusMsg += fmt.Sprintf(", Container: %s", v.containers[v.selectedContainer])
-						} else {
-							statusMsg += fmt.Sprintf(", %d containers", len(containersToStream))
-						}
-						v.content = append(v.content, fmt.Sprintf("=== Streaming logs: %s ===", statusMsg))
+						readers, containerNames = v.streamPods(client, pods)
+					}
+					break
+				}
+			}
+		case core.ResourceTypeJob:
+			// Find the job by name; its finished pods still have logs
+			for _, job := range state.Jobs {
+				if matches(job.Namespace, job.Name) {
+					var pods []v1.Pod
+					pods, err = client.GetPodsForJob(v.ctx, job.Namespace, job.Name)
+					if err == nil && len(pods) == 0 {
+						err = fmt.Errorf("job %s has no pods left to show logs for", job.Name)
+					}
+					if err == nil {
+						readers, containerNames = v.streamPods(client, pods)
+					}
+					break
+				}
+			}
+		case core.ResourceTypeService:
+			// The pods the service's selector matches, which need not
+			// belong to one workload
+			for _, service := range state.Services {
+				if matches(service.Namespace, service.Name) {
+					var pods []v1.Pod
+					pods, err = client.GetPodsForService(v.ctx, service.Namespace, service.Name)
+					if err == nil && len(pods) == 0 {
+						err = fmt.Errorf("no pods match the selector of service %s", service.Name)
+					}
+					if err == nil {
+						readers, containerNames = v.streamPods(client, pods)
 					}
 					break
 				}
@@ -686,13 +1216,109 @@ func (v *LogView) StartStreaming(ctx context.Context, client *k8s.Client, state
 				v.scanners = append(v.scanners, bufio.NewScanner(reader))
 			}
 			// Return a message to trigger the first read
-			return logStreamStartedMsg{containerCount: len(readers)}
+			return logStreamStartedMsg{ctx: streamCtx, containerCount: len(readers)}
 		}
 
 		return errMsg{fmt.Errorf("no logs available for selected resource")}
 	}
 }
 
+// streamPods streams the logs of several pods, such as a workload's, or of
+// the pod and container picked among them, naming each stream pod/container
+func (v *LogView) streamPods(client *k8s.Client, pods []v1.Pod) ([]io.ReadCloser, []string) {
+	var readers []io.ReadCloser
+	var containerNames []string
+
+	// Store pod names for cycling
+	v.pods = []string{}
+	allPodNames := []string{}
+	for _, pod := range pods {
+		allPodNames = append(allPodNames, pod.Name)
+	}
+	v.pods = allPodNames
+
+	// Build container list from first pod (assume all pods have same containers)
+	if len(pods) > 0 {
+		allContainers := []string{}
+		for _, container := range pods[0].Spec.Containers {
+			allContainers = append(allContainers, container.Name)
+		}
+		v.setContainerChoices(allContainers)
+	}
+
+	// Determine which pods to stream
+	podsToStream := pods
+	if v.selectedPod >= 0 && v.selectedPod < len(pods) {
+		// Stream only selected pod
+		podsToStream = []v1.Pod{pods[v.selectedPod]}
+	}
+
+	// Determine which containers to stream
+	containersToStream := v.containerChoices
+	if v.selectedContainer >= 0 && v.selectedContainer < len(v.containerChoices) {
+		// Stream only selected container
+		containersToStream = []string{v.containerChoices[v.selectedContainer]}
+	}
+
+	// Stream from selected pods and containers
+	for _, pod := range podsToStream {
+		for _, containerName := range containersToStream {
+			reader, err := client.GetPodLogs(v.ctx, pod.Namespace, pod.Name, containerName, true, v.tailLines)
+			if err != nil {
+				v.appendMessage(fmt.Sprintf("[%s/%s] Error: %s", pod.Name, containerName, k8s.UserMessage(err)))
+				continue
+			}
+			readers = append(readers, reader)
+			// Include the pod name, as the pods share container names
+			containerNames = append(containerNames, fmt.Sprintf("%s/%s", pod.Name, containerName))
+		}
+	}
+
+	// Show status message
+	statusMsg := ""
+	if v.selectedPod >= 0 {
+		statusMsg = fmt.Sprintf("Pod: %s", allPodNames[v.selectedPod])
+	} else {
+		statusMsg = fmt.Sprintf("%d pods", len(podsToStream))
+	}
+	if v.selectedContainer >= 0 {
+		statusMsg += fmt.Sprintf(", Container: %s", v.containerChoices[v.selectedContainer])
+	} else {
+		statusMsg += fmt.Sprintf(", %d containers", len(containersToStream))
+	}
+	v.appendMessage(fmt.Sprintf("=== Streaming logs: %s ===", statusMsg))
+	return readers, containerNames
+}
+
+// SetContainer selects the container whose logs the next StartStreaming
+// shows, "" for all of them
+func (v *LogView) SetContainer(name string) {
+	v.selectedContainer = -1
+	v.wantContainer = name
+}
+
+// setContainerChoices sets the containers to pick from, selecting the one
+// SetContainer asked for, and keeping the selection in range
+func (v *LogView) setContainerChoices(choices []string) {
+	v.containerChoices = choices
+	if v.wantContainer != "" {
+		v.selectedContainer = slices.Index(choices, v.wantContainer)
+		v.wantContainer = ""
+	}
+	if v.selectedContainer >= len(choices) {
+		v.selectedContainer = -1
+	}
+}
+
+// podContainerNames returns the names of containers
+func podContainerNames(containers []core.PodContainer) []string {
+	names := make([]string, len(containers))
+	for i, container := range containers {
+		names[i] = container.Name
+	}
+	return names
+}
+
 // StopStreaming stops streaming logs
 func (v *LogView) StopStreaming() tea.Cmd {
 	if v.cancelFunc != nil {
@@ -725,15 +1351,22 @@ func (v *LogView) restartStreaming() tea.Cmd {
 	v.scanners = nil
 
 	// Clear content but keep filter settings
-	v.content = []string{"Restarting streams with new filters..."}
-	v.viewport.SetContent(strings.Join(v.content, "\n"))
+	v.resetContent("Restarting streams with new filters...")
+	v.refreshContent()
 
-	// Restart with same resource but current filter settings
+	// Restart with same resource but current filter settings, following
+	// and searching as before
 	if v.client != nil && v.state != nil && v.resourceName != "" {
 		// Create new context for the new streams
 		parentCtx := context.Background()
 		v.ctx, v.cancelFunc = context.WithCancel(parentCtx)
-		return v.StartStreaming(v.ctx, v.client, v.state, v.resourceName)
+		following := v.following
+		cmd := v.StartStreaming(v.ctx, v.client, v.state, v.resourceNamespace, v.resourceName)
+		if !following {
+			v.detach()
+		}
+		v.updateSearchResults()
+		return cmd
 	}
 
 	return nil
@@ -741,65 +1374,213 @@ func (v *LogView) restartStreaming() tea.Cmd {
 
 // readNextLine reads the next line from a specific container's log stream
 func (v *LogView) readNextLine(containerIndex int) tea.Cmd {
-	return func() tea.Msg {
-		if containerIndex >= len(v.scanners) || containerIndex >= len(v.containers) {
-			return nil
-		}
-
-		scanner := v.scanners[containerIndex]
-		containerName := v.containers[containerIndex]
-
-		if scanner == nil {
-			return nil
-		}
+	if containerIndex >= len(v.scanners) || containerIndex >= len(v.containers) {
+		return nil
+	}
+	scanner := v.scanners[containerIndex]
+	containerName := v.containers[containerIndex]
+	if scanner == nil {
+		return nil
+	}
+	ctx := v.ctx
+	filter := v.streamFilter
 
+	return func() tea.Msg {
 		// Check if context is cancelled
 		select {
-		case <-v.ctx.Done():
+		case <-ctx.Done():
 			return nil
 		default:
 		}
 
-		// Read in a goroutine to avoid blocking
+		// Read in a goroutine, so a cancelled stream stops waiting
 		lineChan := make(chan string, 1)
 		errChan := make(chan error, 1)
 
 		go func() {
-			if scanner.Scan() {
-				lineChan <- scanner.Text()
-			} else if err := scanner.Err(); err != nil {
-				errChan <- err
-			} else {
-				// EOF or stream closed
-				errChan <- io.EOF
+			// Lines the stream filter drops are skipped here, so they
+			// never reach the buffer or cost a redraw
+			for scanner.Scan() {
+				if line := scanner.Text(); filter.Keep(line) {
+					lineChan <- line
+					return
+				}
 			}
+			errChan <- scanner.Err()
 		}()
 
-		// Wait for result with timeout
 		select {
-		case <-v.ctx.Done():
+		case <-ctx.Done():
 			return nil
 		case line := <-lineChan:
-			return logLineMsg{container: containerName, line: line}
+			return logLineMsg{ctx: ctx, container: containerName, line: line}
 		case err := <-errChan:
-			if err == io.EOF {
-				// Stream ended, this shouldn't happen with follow=true
-				// but can happen if pod terminates
-				return logLineMsg{container: containerName, line: "--- End of logs (pod may have terminated) ---"}
+			// The stream ended, as a followed one does when its pod
+			// terminates, or was closed
+			return logStreamEndedMsg{ctx: ctx, container: containerName, err: err}
+		}
+	}
+}
+
+// endStream closes the stream at index i; its lines stay in the buffer
+func (v *LogView) endStream(i int) {
+	if i < len(v.logReaders) && v.logReaders[i] != nil {
+		v.logReaders[i].Close()
+	}
+	v.scanners[i] = nil
+}
+
+// showNewLines shows the buffer after n lines were added, keeping to the
+// bottom while following
+func (v *LogView) showNewLines(n int) {
+	v.showContent()
+	v.updateSearchResults()
+	if v.following {
+		v.viewport.GotoBottom()
+	} else {
+		v.newBelow += n
+	}
+}
+
+// resyncPodsLater lists the workload's pods again after logPodResyncInterval
+func (v *LogView) resyncPodsLater() tea.Cmd {
+	ctx := v.ctx
+	return tea.Tick(logPodResyncInterval, func(time.Time) tea.Msg {
+		return logPodsResyncMsg{ctx: ctx}
+	})
+}
+
+// resyncPods lists the workload's pods and opens the streams of those not
+// streamed yet. Only while all of its pods are shown.
+func (v *LogView) resyncPods() tea.Cmd {
+	if v.selectedPod >= 0 {
+		return v.resyncPodsLater()
+	}
+	ctx, client, listPods, tailLines := v.ctx, v.client, v.listPods, v.tailLines
+	known := slices.Clone(v.pods)
+	picked := ""
+	if v.selectedContainer >= 0 && v.selectedContainer < len(v.containerChoices) {
+		picked = v.containerChoices[v.selectedContainer]
+	}
+
+	return func() tea.Msg {
+		pods, err := listPods(ctx)
+		listed := logPodsListedMsg{ctx: ctx, err: err}
+		for _, pod := range pods {
+			listed.pods = append(listed.pods, pod.Name)
+			if slices.Contains(known, pod.Name) {
+				continue
+			}
+			containers := []string{picked}
+			if picked == "" {
+				containers = nil
+				for _, container := range pod.Spec.Containers {
+					containers = append(containers, container.Name)
+				}
+			}
+			for _, container := range containers {
+				name := fmt.Sprintf("%s/%s", pod.Name, container)
+				reader, err := client.GetPodLogs(ctx, pod.Namespace, pod.Name, container, true, tailLines)
+				if err != nil {
+					listed.failed = append(listed.failed, fmt.Sprintf("[%s] Error: %s", name, k8s.UserMessage(err)))
+					continue
+				}
+				listed.joined = append(listed.joined, logPodStream{name: name, reader: reader})
 			}
-			return errMsg{err}
-		case <-time.After(100 * time.Millisecond):
-			// No data yet, check again
-			return v.readNextLine(containerIndex)()
 		}
+		return listed
 	}
 }
 
-// Message types
+// syncPods has the pods a workload gained join the stream, and those it
+// lost leave it, each with a notice
+func (v *LogView) syncPods(msg logPodsListedMsg) tea.Cmd {
+	if msg.err != nil {
+		// Keep the streams as they are until the pods can be listed again
+		return nil
+	}
+	added := 0
+	for _, pod := range v.pods {
+		if slices.Contains(msg.pods, pod) {
+			continue
+		}
+		for i, stream := range v.containers {
+			if strings.HasPrefix(stream, pod+"/") && v.scanners[i] != nil {
+				v.endStream(i)
+			}
+		}
+		v.appendMessage(fmt.Sprintf("=== Pod %s is gone and left the stream ===", pod))
+		added++
+	}
+
+	var cmds []tea.Cmd
+	var joined []string
+	for _, stream := range msg.joined {
+		v.logReaders = append(v.logReaders, stream.reader)
+		v.scanners = append(v.scanners, bufio.NewScanner(stream.reader))
+		v.containers = append(v.containers, stream.name)
+		cmds = append(cmds, v.readNextLine(len(v.scanners)-1))
+		if pod, _, _ := strings.Cut(stream.name, "/"); !slices.Contains(joined, pod) {
+			joined = append(joined, pod)
+		}
+	}
+	for _, pod := range joined {
+		v.appendMessage(fmt.Sprintf("=== Pod %s joined the stream ===", pod))
+		added++
+	}
+	for _, failure := range msg.failed {
+		v.appendMessage(failure)
+		added++
+	}
+	v.pods = msg.pods
+
+	if added > 0 {
+		if len(msg.joined) > 0 && len(v.containers) > 1 {
+			v.layoutPrefixes()
+		}
+		v.showNewLines(added)
+	}
+	return tea.Batch(cmds...)
+}
+
+// Message types. Those of streams carry the context the streams were
+// opened in, so a log view ignores the streams of another, as with two
+// panes side by side, or of logs it has since closed.
 type logLineMsg struct {
+	ctx       context.Context
 	container string
 	line      string
 }
 type logStreamStartedMsg struct {
+	ctx            context.Context
 	containerCount int
 }
+
+// logStreamEndedMsg is sent when a stream ends, with the error that ended it
+// if it did not just run out
+type logStreamEndedMsg struct {
+	ctx       context.Context
+	container string
+	err       error
+}
+
+// logPodsResyncMsg asks for the pods of the workload whose logs ctx streams
+type logPodsResyncMsg struct {
+	ctx context.Context
+}
+
+// logPodsListedMsg lists the pods of the workload whose logs ctx streams,
+// with the streams of those that joined opened
+type logPodsListedMsg struct {
+	ctx    context.Context
+	pods   []string
+	joined []logPodStream
+	failed []string // Notices of the streams that could not be opened
+	err    error
+}
+
+// logPodStream is the stream of a pod's container, named pod/container
+type logPodStream struct {
+	name   string
+	reader io.ReadCloser
+}
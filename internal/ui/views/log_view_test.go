@@ -1,9 +1,24 @@
 package views
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/testutil/fixtures"
 	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
 )
 
 func createTestLogView(t *testing.T) *LogView {
@@ -166,7 +181,7 @@ func TestLogViewContainerCycling(t *testing.T) {
 	lv := createTestLogView(t)
 
 	// Add test containers (need more than 1 for cycling to work)
-	lv.containers = []string{"container1", "container2", "container3"}
+	lv.containerChoices = []string{"container1", "container2", "container3"}
 	lv.selectedContainer = -1 // Start with all containers
 
 	// Test container cycling
@@ -202,6 +217,81 @@ func TestLogViewContainerCycling(t *testing.T) {
 		t.Errorf("Expected selectedContainer -1 (all), got %d", lv.selectedContainer)
 	}
 }
+
+func TestLogViewContainerPicker(t *testing.T) {
+	lv := createTestLogView(t)
+	lv.SetSize(100, 30)
+	lv.containerChoices = []string{"trainer", "proxy", "metrics", "loader", "proxy-sidecar", "uploader", "scheduler"}
+	lv.selectedContainer = -1
+
+	press := func(keys ...tea.KeyMsg) {
+		for _, k := range keys {
+			model, _ := lv.Update(k)
+			lv = model.(*LogView)
+		}
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// Above the threshold, c opens the picker instead of cycling
+	press(runes("c"))
+	if !lv.IsPickingContainer() || lv.selectedContainer != -1 {
+		t.Fatalf("Expected the picker to open, picking=%v selected=%d", lv.IsPickingContainer(), lv.selectedContainer)
+	}
+	if view := lv.View(); !strings.Contains(view, "All containers") || !strings.Contains(view, "scheduler") {
+		t.Errorf("Expected every container listed, got:\n%s", view)
+	}
+
+	// Typing filters; keys such as c and p are typed rather than acted on
+	press(runes("p"), runes("r"), runes("o"))
+	view := lv.View()
+	if !strings.Contains(view, "proxy-sidecar") || strings.Contains(view, "trainer") || strings.Contains(view, "All containers") {
+		t.Errorf("Expected only the proxy containers, got:\n%s", view)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyEnter})
+	if lv.IsPickingContainer() || lv.selectedContainer != 4 {
+		t.Errorf("Expected proxy-sidecar (4) picked, got picking=%v selected=%d", lv.IsPickingContainer(), lv.selectedContainer)
+	}
+
+	// Esc closes the picker without changing the stream
+	press(runes("c"), runes("x"), tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyEsc})
+	if lv.IsPickingContainer() || lv.selectedContainer != 4 {
+		t.Errorf("Expected Esc to keep proxy-sidecar, got picking=%v selected=%d", lv.IsPickingContainer(), lv.selectedContainer)
+	}
+
+	// A query matching nothing says so and Enter does nothing
+	press(runes("c"), runes("z"), runes("z"), tea.KeyMsg{Type: tea.KeyEnter})
+	if !lv.IsPickingContainer() || !strings.Contains(lv.View(), "No container matches") {
+		t.Errorf("Expected the picker to stay open with no match, got:\n%s", lv.View())
+	}
+}
+
+func TestLogViewContainerUsage(t *testing.T) {
+	lv := createTestLogView(t)
+	lv.SetSize(100, 30)
+	lv.containerChoices = []string{"trainer", "proxy", "metrics", "loader", "uploader", "scheduler"}
+	lv.SetPodMetrics(&k8s.PodMetrics{CPU: "2", Memory: "3Gi", Containers: []k8s.ContainerMetrics{
+		{Name: "trainer", CPU: "1", Memory: "2Gi"},
+		{Name: "proxy", CPU: "40m", Memory: "64Mi"},
+	}})
+
+	model, _ := lv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	lv = model.(*LogView)
+	view := lv.View()
+	if !strings.Contains(view, "trainer  1/2Gi") || !strings.Contains(view, "proxy  40m/64Mi") {
+		t.Errorf("Expected each container's usage in the picker, got:\n%s", view)
+	}
+	if strings.Contains(view, "loader  ") {
+		t.Errorf("Expected no usage for containers the metrics lack, got:\n%s", view)
+	}
+
+	lv.pickingContainer = false
+	lv.selectedContainer = 1
+	if view := lv.View(); !strings.Contains(view, "Container: proxy (40m/64Mi)") {
+		t.Errorf("Expected the streamed container's usage in the header, got:\n%s", view)
+	}
+}
+
 func TestLogViewPodCycling(t *testing.T) {
 	lv := createTestLogView(t)
 
@@ -354,3 +444,534 @@ func TestLogViewStopStreaming(t *testing.T) {
 		t.Error("Tailing should be stopped")
 	}
 }
+
+func TestLogViewGroupsMultiLineRecords(t *testing.T) {
+	lv := createTestLogView(t)
+	lv.containers = []string{"pod-a", "pod-b"}
+
+	// Two pods fail at once and their stack traces arrive interleaved
+	arrivals := []logLineMsg{
+		{container: "pod-a", line: "ERROR a failed"},
+		{container: "pod-b", line: "ERROR b failed"},
+		{container: "pod-a", line: "\tat a.frame1"},
+		{container: "pod-b", line: "\tat b.frame1"},
+		{container: "pod-a", line: "\tat a.frame2"},
+		{container: "pod-b", line: "INFO b recovered"},
+	}
+	for _, msg := range arrivals {
+		model, _ := lv.Update(msg)
+		lv = model.(*LogView)
+	}
+
+	grouped := []string{
+		"[pod-a] ERROR a failed",
+		"[pod-a] \tat a.frame1",
+		"[pod-a] \tat a.frame2",
+		"[pod-b] ERROR b failed",
+		"[pod-b] \tat b.frame1",
+		"[pod-b] INFO b recovered",
+	}
+	if strings.Join(lv.content, "\n") != strings.Join(grouped, "\n") {
+		t.Fatalf("Expected records grouped by pod, got:\n%s", strings.Join(lv.content, "\n"))
+	}
+
+	// Search steps over whole records
+	lv.searchQuery = "frame"
+	lv.performSearch()
+	if len(lv.searchResults) != 2 || lv.searchResults[0] != 1 || lv.searchResults[1] != 4 {
+		t.Errorf("Expected one match per record at lines 1 and 4, got %v", lv.searchResults)
+	}
+
+	// Turning grouping off restores arrival order and searches by line
+	model, _ := lv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	lv = model.(*LogView)
+	for i, msg := range arrivals {
+		if want := "[" + msg.container + "] " + msg.line; lv.content[i] != want {
+			t.Errorf("Line %d: expected %q in arrival order, got %q", i, want, lv.content[i])
+		}
+	}
+	if len(lv.searchResults) != 3 {
+		t.Errorf("Expected a match per line when ungrouped, got %v", lv.searchResults)
+	}
+
+	// And back on again
+	model, _ = lv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	lv = model.(*LogView)
+	if strings.Join(lv.content, "\n") != strings.Join(grouped, "\n") {
+		t.Errorf("Expected records regrouped, got:\n%s", strings.Join(lv.content, "\n"))
+	}
+}
+
+func TestLogViewSearchWhileLinesArrive(t *testing.T) {
+	lv := createTestLogView(t)
+	lv.following = true
+	for _, line := range []string{"INFO started", "ERROR disk full"} {
+		model, _ := lv.Update(logLineMsg{container: "app", line: line})
+		lv = model.(*LogView)
+	}
+
+	// Lines arriving between keystrokes leave the query as typed
+	typed := []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("/")},
+		{Type: tea.KeyRunes, Runes: []rune("err")},
+		{Type: tea.KeyRunes, Runes: []rune("o")},
+		{Type: tea.KeyRunes, Runes: []rune("x")},
+		{Type: tea.KeyBackspace},
+		{Type: tea.KeyRunes, Runes: []rune("r")},
+	}
+	for i, key := range typed {
+		model, _ := lv.Update(key)
+		lv = model.(*LogView)
+		model, _ = lv.Update(logLineMsg{container: "app", line: fmt.Sprintf("INFO tick %d", i)})
+		lv = model.(*LogView)
+	}
+	if !lv.IsSearchMode() || lv.searchInput.Value() != "error" {
+		t.Fatalf("Expected the typed query kept, got %q", lv.searchInput.Value())
+	}
+	if !strings.Contains(lv.View(), "Search: error_") {
+		t.Errorf("Expected the query in the status line, got:\n%s", lv.View())
+	}
+
+	model, _ := lv.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	lv = model.(*LogView)
+	if len(lv.searchResults) != 1 {
+		t.Fatalf("Expected one match, got %v", lv.searchResults)
+	}
+
+	// Matching lines that arrive later are found without moving the current match
+	model, _ = lv.Update(logLineMsg{container: "app", line: "ERROR disk still full"})
+	lv = model.(*LogView)
+	if len(lv.searchResults) != 2 || lv.currentMatch != 0 || lv.searchResults[0] != 1 {
+		t.Errorf("Expected the new line added to the matches, got %v at %d", lv.searchResults, lv.currentMatch)
+	}
+}
+
+func TestLogViewSetRecordGrouping(t *testing.T) {
+	lv := createTestLogView(t)
+
+	if err := lv.SetRecordGrouping("^(bad", true); err == nil {
+		t.Error("Expected an invalid pattern to be rejected")
+	}
+	if err := lv.SetRecordGrouping(`^>>`, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if lv.groupRecords {
+		t.Error("Expected grouping to be disabled")
+	}
+	if !strings.Contains(lv.View(), "| Lines") {
+		t.Error("Expected header to show ungrouped lines")
+	}
+}
+
+// followingLogView is a log view following 100 lines, 21 of which show
+func followingLogView(t *testing.T) *LogView {
+	lv := createTestLogView(t)
+	lv.following = true
+	for i := 0; i < 100; i++ {
+		model, _ := lv.Update(logLineMsg{container: "app", line: fmt.Sprintf("line %d", i)})
+		lv = model.(*LogView)
+	}
+	if !lv.viewport.AtBottom() || lv.followIndicator() != "▼ LIVE" {
+		t.Fatalf("Expected the newest line followed, got %q", lv.followIndicator())
+	}
+	return lv
+}
+
+func TestLogViewScrollingUpPausesFollowing(t *testing.T) {
+	inputs := map[string][]tea.Msg{
+		"PgUp":        {tea.KeyMsg{Type: tea.KeyPgUp}},
+		"up":          {tea.KeyMsg{Type: tea.KeyUp}},
+		"mouse wheel": {tea.MouseMsg{Button: tea.MouseButtonWheelUp, Action: tea.MouseActionPress}},
+		"home":        {tea.KeyMsg{Type: tea.KeyHome}},
+		"search": {
+			tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")},
+			tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("line 1")},
+			tea.KeyMsg{Type: tea.KeyEnter},
+			tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")},
+			tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")},
+		},
+	}
+	for name, msgs := range inputs {
+		t.Run(name, func(t *testing.T) {
+			lv := followingLogView(t)
+			for _, msg := range msgs {
+				model, _ := lv.Update(msg)
+				lv = model.(*LogView)
+			}
+			if lv.following || lv.viewport.AtBottom() {
+				t.Fatalf("Expected following paused away from the bottom, at %d", lv.viewport.YOffset)
+			}
+			if indicator := lv.followIndicator(); indicator != "⏸ paused — press F/End to resume" {
+				t.Errorf("Expected nothing new below yet, got %q", indicator)
+			}
+
+			// Lines arriving while paused are counted, and the view stays put
+			offset := lv.viewport.YOffset
+			for i := 0; i < 3; i++ {
+				model, _ := lv.Update(logLineMsg{container: "app", line: "new"})
+				lv = model.(*LogView)
+			}
+			if lv.viewport.YOffset != offset {
+				t.Errorf("Expected the view kept at %d while paused, got %d", offset, lv.viewport.YOffset)
+			}
+			expected := "⏸ 3 new lines below — press F/End to resume"
+			if indicator := lv.followIndicator(); indicator != expected {
+				t.Errorf("Expected %q, got %q", expected, indicator)
+			}
+			if !strings.Contains(lv.View(), expected) {
+				t.Errorf("Expected the indicator in the header, got:\n%s", lv.View())
+			}
+		})
+	}
+}
+
+func TestLogViewResumingFollowJumpsToBottom(t *testing.T) {
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("F")},
+		{Type: tea.KeyEnd},
+		{Type: tea.KeyRunes, Runes: []rune("G")},
+	} {
+		t.Run(key.String(), func(t *testing.T) {
+			lv := followingLogView(t)
+			model, _ := lv.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+			lv = model.(*LogView)
+			model, _ = lv.Update(logLineMsg{container: "app", line: "new"})
+			lv = model.(*LogView)
+
+			model, _ = lv.Update(key)
+			lv = model.(*LogView)
+			if !lv.following || !lv.viewport.AtBottom() || lv.newBelow != 0 {
+				t.Fatalf("Expected following from the bottom, at %d with %d new", lv.viewport.YOffset, lv.newBelow)
+			}
+			if !strings.Contains(lv.View(), "📜 Logs [▼ LIVE]") {
+				t.Errorf("Expected the live indicator in the header, got:\n%s", lv.View())
+			}
+		})
+	}
+}
+
+func TestLogViewScrollingAtBottomKeepsFollowing(t *testing.T) {
+	lv := followingLogView(t)
+	for _, msg := range []tea.Msg{
+		tea.KeyMsg{Type: tea.KeyPgDown},
+		tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress},
+	} {
+		model, _ := lv.Update(msg)
+		lv = model.(*LogView)
+	}
+	if !lv.following || lv.followIndicator() != "▼ LIVE" {
+		t.Errorf("Expected following kept at the bottom, got %q", lv.followIndicator())
+	}
+}
+
+// logServer serves each container's logs as "line from <container>",
+// recording the containers asked for
+func logServer(t *testing.T) (*k8s.Client, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var containers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/pods/web/log") {
+			http.NotFound(w, r)
+			return
+		}
+		container := r.URL.Query().Get("container")
+		mu.Lock()
+		containers = append(containers, container)
+		mu.Unlock()
+		fmt.Fprintf(w, "line from %s\n", container)
+	}))
+	t.Cleanup(server.Close)
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	return client, &containers
+}
+
+func TestLogViewStreamsPickedContainer(t *testing.T) {
+	client, requested := logServer(t)
+	pod := fixtures.NewPod("web", "default").WithContainers(2).Build()
+	pod.Spec.InitContainers = []v1.Container{{Name: "migrate"}}
+	state := createTestState(core.ResourceTypePod, "default", "")
+	state.UpdatePods([]v1.Pod{pod})
+
+	lv := createTestLogView(t)
+	lv.SetContainer("")
+	if _, ok := lv.StartStreaming(context.Background(), client, state, "default", "web")().(logStreamStartedMsg); !ok {
+		t.Fatal("Expected the streams started")
+	}
+	if got := strings.Join(*requested, ","); got != "web,sidecar-1" {
+		t.Errorf("Expected the app containers streamed, got %s", got)
+	}
+	if got := strings.Join(lv.containerChoices, ","); got != "migrate,web,sidecar-1" {
+		t.Errorf("Expected every container to pick from, init ones first, got %s", got)
+	}
+
+	*requested = nil
+	lv.SetContainer("sidecar-1")
+	lv.StartStreaming(context.Background(), client, state, "default", "web")()
+	if got := strings.Join(*requested, ","); got != "sidecar-1" || lv.selectedContainer != 2 {
+		t.Errorf("Expected only sidecar-1 streamed and selected, got %s selected %d", got, lv.selectedContainer)
+	}
+	if view := lv.View(); !strings.Contains(view, "Container: sidecar-1") {
+		t.Errorf("Expected the container in the header, got:\n%s", view)
+	}
+}
+
+func TestLogViewCyclingKeepsFollowAndSearch(t *testing.T) {
+	client, requested := logServer(t)
+	pod := fixtures.NewPod("web", "default").WithContainers(2).Build()
+	pod.Spec.InitContainers = []v1.Container{{Name: "migrate"}}
+	state := createTestState(core.ResourceTypePod, "default", "")
+	state.UpdatePods([]v1.Pod{pod})
+
+	lv := createTestLogView(t)
+	lv.StartStreaming(context.Background(), client, state, "default", "web")()
+	lv.detach()
+	lv.searchQuery = "line"
+
+	*requested = nil
+	_, cmd := lv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if cmd == nil {
+		t.Fatal("Expected c to restart the stream")
+	}
+	msg := cmd()
+	if got := strings.Join(*requested, ","); got != "migrate" {
+		t.Errorf("Expected the init container streamed first, got %s", got)
+	}
+	if lv.following || lv.searchQuery != "line" {
+		t.Errorf("Expected following off and the search kept, got following %v, search %q", lv.following, lv.searchQuery)
+	}
+
+	// The search finds the new container's lines as they arrive
+	model, readCmd := lv.Update(msg)
+	lv = model.(*LogView)
+	if readCmd != nil {
+		lv.Update(readCmd())
+	}
+	if len(lv.searchResults) == 0 {
+		t.Errorf("Expected the search to match the restarted stream's lines, got:\n%s", strings.Join(lv.content, "\n"))
+	}
+}
+
+func TestLogPickerView(t *testing.T) {
+	pod := fixtures.NewPod("web", "default").WithContainers(2).CrashLooping(4).Build()
+	pod.Spec.InitContainers = []v1.Container{{Name: "migrate"}}
+	picker := NewLogPickerView("prod", "default", "web", core.PodContainers(&pod))
+	picker.SetSize(100, 30)
+
+	view := picker.View()
+	for _, want := range []string{"Show the logs of web", "All containers", "migrate (init)", "Pending", "CrashLoopBackOff, 4 restarts"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the picker, got:\n%s", want, view)
+		}
+	}
+
+	// Enter on all containers names none
+	_, cmd := picker.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if picked, ok := cmd().(LogContainerPickedMsg); !ok || picked.Container != "" || picked.Context != "prod" {
+		t.Errorf("Expected all containers picked in prod, got %#v", picked)
+	}
+
+	picker.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("side")})
+	_, cmd = picker.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if picked, ok := cmd().(LogContainerPickedMsg); !ok || picked.Container != "sidecar-1" || picked.Pod != "web" {
+		t.Errorf("Expected sidecar-1 of web picked, got %#v", picked)
+	}
+}
+
+func TestLogPickerViewPendingPod(t *testing.T) {
+	pod := fixtures.NewPod("web", "default").WithContainers(3).WithPhase(v1.PodPending).WithNode("").Build()
+	if len(pod.Status.ContainerStatuses) != 0 {
+		t.Fatal("Expected a pod with no container statuses")
+	}
+	picker := NewLogPickerView("", "default", "web", core.PodContainers(&pod))
+	picker.SetSize(100, 30)
+	if view := picker.View(); strings.Count(view, "Pending") != 3 {
+		t.Errorf("Expected the three containers pending, got:\n%s", view)
+	}
+}
+
+// workloadLogServer serves the deployment api in namespace web, the pods it
+// selects, set by the returned func, and each pod's logs as "line from <pod>"
+func workloadLogServer(t *testing.T) (*k8s.Client, func(pods ...v1.Pod)) {
+	t.Helper()
+	var mu sync.Mutex
+	var pods []v1.Pod
+	deployment := fixtures.NewDeployment("api", "web").Build()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch path := r.URL.Path; {
+		case path == "/apis/apps/v1/namespaces/web/deployments/api":
+			json.NewEncoder(w).Encode(deployment)
+		case path == "/api/v1/namespaces/web/pods":
+			json.NewEncoder(w).Encode(v1.PodList{Items: pods})
+		case strings.HasSuffix(path, "/log"):
+			pod := strings.TrimPrefix(strings.TrimSuffix(path, "/log"), "/api/v1/namespaces/web/pods/")
+			fmt.Fprintf(w, "line from %s\n", pod)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	return client, func(listed ...v1.Pod) {
+		mu.Lock()
+		defer mu.Unlock()
+		pods = listed
+	}
+}
+
+func TestLogViewWorkloadPodsJoinAndLeave(t *testing.T) {
+	client, setPods := workloadLogServer(t)
+	rollout := fixtures.CrashloopingRollout("web")
+	setPods(rollout.Pods[:2]...)
+	state := createTestState(core.ResourceTypeDeployment, "web", "")
+	state.UpdateDeployments(rollout.Deployments)
+
+	lv := createTestLogView(t)
+	msg := lv.StartStreaming(context.Background(), client, state, "web", "api")()
+	if _, ok := msg.(logStreamStartedMsg); !ok {
+		t.Fatalf("Expected the streams started, got %#v", msg)
+	}
+	if _, cmd := lv.Update(msg); cmd == nil {
+		t.Fatal("Expected reads and a resync of the pods")
+	}
+
+	// The rollout replaces the first pod with a new one
+	setPods(rollout.Pods[1:]...)
+	_, cmd := lv.Update(logPodsResyncMsg{ctx: lv.ctx})
+	if cmd == nil {
+		t.Fatal("Expected the pods listed again")
+	}
+	listed, ok := cmd().(logPodsListedMsg)
+	if !ok || len(listed.joined) != 1 {
+		t.Fatalf("Expected one pod to join, got %#v", listed)
+	}
+	_, cmd = lv.Update(listed)
+
+	content := strings.Join(lv.content, "\n")
+	for _, want := range []string{"Pod api-6b8d9-k2x7p is gone", "Pod api-7f4c2-z8r5w joined the stream"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected %q, got:\n%s", want, content)
+		}
+	}
+	if got := strings.Join(lv.pods, ","); got != "api-6b8d9-q9m4t,api-7f4c2-z8r5w" {
+		t.Errorf("Expected the pods listed now, got %s", got)
+	}
+	if lv.scanners[0] != nil {
+		t.Error("Expected the stream of the pod that is gone closed")
+	}
+
+	// The new pod's lines arrive prefixed with it
+	i := slices.Index(lv.containers, "api-7f4c2-z8r5w/api")
+	if i < 0 {
+		t.Fatalf("Expected the new pod streamed, got %v", lv.containers)
+	}
+	lv.Update(lv.readNextLine(i)())
+	last := lv.content[len(lv.content)-1]
+	if !strings.Contains(last, "line from api-7f4c2-z8r5w") || !strings.Contains(last, "z8r5w") || !strings.HasPrefix(plainLogText(last), "[") {
+		t.Errorf("Expected the new pod's line prefixed with it, got %q", last)
+	}
+	if cmd == nil {
+		t.Error("Expected the new pod's stream read and the next resync")
+	}
+}
+
+func TestLogViewIgnoresResyncOfEarlierStream(t *testing.T) {
+	client, setPods := workloadLogServer(t)
+	setPods(fixtures.NewPod("api-6b8d9-k2x7p", "web").Build())
+	state := createTestState(core.ResourceTypeDeployment, "web", "")
+	state.UpdateDeployments([]appsv1.Deployment{fixtures.NewDeployment("api", "web").Build()})
+
+	lv := createTestLogView(t)
+	lv.StartStreaming(context.Background(), client, state, "web", "api")()
+	earlier := lv.ctx
+	lv.StopStreaming()
+	lv.StartStreaming(context.Background(), client, state, "web", "api")()
+
+	if _, cmd := lv.Update(logPodsResyncMsg{ctx: earlier}); cmd != nil {
+		t.Error("Expected the resync of closed streams ignored")
+	}
+}
+
+func TestLogViewStreamEndsOnce(t *testing.T) {
+	lv := createTestLogView(t)
+	lv.ctx = context.Background()
+	lv.containers = []string{"web-1/app", "web-2/app"}
+	lv.scanners = []*bufio.Scanner{
+		bufio.NewScanner(strings.NewReader("")),
+		bufio.NewScanner(strings.NewReader("")),
+	}
+
+	msg := lv.readNextLine(0)()
+	if ended, ok := msg.(logStreamEndedMsg); !ok || ended.container != "web-1/app" {
+		t.Fatalf("Expected the stream ended, got %#v", msg)
+	}
+	lv.Update(msg)
+	if _, cmd := lv.Update(msg); cmd != nil || lv.readNextLine(0) != nil {
+		t.Error("Expected nothing read from an ended stream")
+	}
+	if got := strings.Count(strings.Join(lv.content, "\n"), "End of logs"); got != 1 {
+		t.Errorf("Expected the end noted once, got %d times:\n%s", got, strings.Join(lv.content, "\n"))
+	}
+}
+
+func TestLogViewBufferLines(t *testing.T) {
+	lv := createTestLogView(t)
+	for i := range minLogBufferLines + 50 {
+		lv.appendLogLine("app", fmt.Sprintf("line %d", i))
+	}
+	if len(lv.content) != minLogBufferLines || !strings.HasSuffix(lv.content[0], "line 50") {
+		t.Errorf("Expected the last %d lines kept, got %d from %q", minLogBufferLines, len(lv.content), lv.content[0])
+	}
+
+	// The tail lines of each stream merged are kept, up to the maximum
+	lv.SetTailLines(4000)
+	lv.containers = []string{"web-1/app", "web-2/app", "web-3/app"}
+	if got := lv.bufferLines(); got != 12000 {
+		t.Errorf("Expected 4000 lines for each of 3 streams, got %d", got)
+	}
+	lv.SetTailLines(50000)
+	if got := lv.bufferLines(); got != maxLogBufferLines {
+		t.Errorf("Expected the buffer capped at %d lines, got %d", maxLogBufferLines, got)
+	}
+}
+
+func TestLogViewRendersOnlyNewLines(t *testing.T) {
+	lv := createTestLogView(t)
+	lv.containers = []string{"web-1/app", "web-2/app"}
+	lv.Update(logStreamStartedMsg{})
+	for i := range minLogBufferLines {
+		lv.appendLogLine(lv.containers[i%2], fmt.Sprintf("line %d", i))
+	}
+	lv.refreshContent()
+
+	// A line already rendered is not rendered again when another arrives
+	last := minLogBufferLines - 1
+	lv.rendered[last] = "already rendered"
+	lv.Update(logLineMsg{container: "web-2/app", line: "newest"})
+	if len(lv.rendered) != minLogBufferLines || lv.rendered[last-1] != "already rendered" {
+		t.Fatalf("Expected the oldest line evicted and the others kept, got %d lines ending %q", len(lv.rendered), lv.rendered[last-1:])
+	}
+	if !strings.HasSuffix(lv.rendered[last], "newest") {
+		t.Errorf("Expected the new line rendered, got %q", lv.rendered[last])
+	}
+
+	// Rendering it all again gives the same lines as rendering each once
+	lv.rendered[last-1] = lv.renderContentLine(last - 1)
+	incremental := append([]string(nil), lv.rendered...)
+	lv.refreshContent()
+	if strings.Join(incremental, "\n") != strings.Join(lv.rendered, "\n") {
+		t.Errorf("Expected the lines as a full render gives them:\n%q\ngot:\n%q", lv.rendered, incremental)
+	}
+	if len(lv.prefixStyles) != 2 {
+		t.Errorf("Expected one prefix style per stream, got %d", len(lv.prefixStyles))
+	}
+}
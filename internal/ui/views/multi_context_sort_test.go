@@ -1,14 +1,18 @@
 package views
 
 import (
+	"math/rand"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/HamStudy/kubewatch/internal/k8s"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func TestMultiContextSortOrderConsistency(t *testing.T) {
@@ -67,8 +71,8 @@ func TestMultiContextSortOrderConsistency(t *testing.T) {
 		view.updateTableWithPodsMultiContext(podsRefresh1)
 
 		// Capture the order after first refresh
-		firstOrder := make([]string, len(view.rows))
-		for i, row := range view.rows {
+		firstOrder := make([]string, view.table.GetRowCount())
+		for i, row := range view.table.Values() {
 			firstOrder[i] = row[0] + ":" + row[1] // context:name
 		}
 
@@ -126,8 +130,8 @@ func TestMultiContextSortOrderConsistency(t *testing.T) {
 		view.updateTableWithPodsMultiContext(podsRefresh2)
 
 		// Capture the order after second refresh
-		secondOrder := make([]string, len(view.rows))
-		for i, row := range view.rows {
+		secondOrder := make([]string, view.table.GetRowCount())
+		for i, row := range view.table.Values() {
 			secondOrder[i] = row[0] + ":" + row[1] // context:name
 		}
 
@@ -136,8 +140,8 @@ func TestMultiContextSortOrderConsistency(t *testing.T) {
 
 		// Selection should be maintained
 		assert.Equal(t, 1, view.selectedRow, "Selection should remain on the same item")
-		assert.Equal(t, "context-b", view.rows[view.selectedRow][0])
-		assert.Equal(t, "pod-1", view.rows[view.selectedRow][1])
+		assert.Equal(t, "context-b", view.table.RowValues(view.selectedRow)[0])
+		assert.Equal(t, "pod-1", view.table.RowValues(view.selectedRow)[1])
 	})
 
 	t.Run("sort by name column maintains consistency", func(t *testing.T) {
@@ -194,8 +198,8 @@ func TestMultiContextSortOrderConsistency(t *testing.T) {
 
 		// When sorting by name, capture the initial order for consistency testing
 		// The exact order may vary based on implementation, but should be consistent
-		initialOrder := make([]string, len(view.rows))
-		for i, row := range view.rows {
+		initialOrder := make([]string, view.table.GetRowCount())
+		for i, row := range view.table.Values() {
 			initialOrder[i] = row[0] + ":" + row[1] // context:name
 		}
 
@@ -214,8 +218,8 @@ func TestMultiContextSortOrderConsistency(t *testing.T) {
 		view.updateTableWithPodsMultiContext(podsRefresh2)
 
 		// Capture the order after second refresh
-		secondOrder := make([]string, len(view.rows))
-		for i, row := range view.rows {
+		secondOrder := make([]string, view.table.GetRowCount())
+		for i, row := range view.table.Values() {
 			secondOrder[i] = row[0] + ":" + row[1] // context:name
 		}
 
@@ -265,8 +269,8 @@ func TestMultiContextSortOrderConsistency(t *testing.T) {
 		}
 
 		view.updateTableWithPodsMultiContext(podsRefresh1)
-		assert.Equal(t, "context-a", view.rows[0][0])
-		assert.Equal(t, "context-b", view.rows[1][0])
+		assert.Equal(t, "context-a", view.table.RowValues(0)[0])
+		assert.Equal(t, "context-b", view.table.RowValues(1)[0])
 
 		// Select context-b pod
 		view.selectedRow = 1
@@ -293,13 +297,13 @@ func TestMultiContextSortOrderConsistency(t *testing.T) {
 		view.updateTableWithPodsMultiContext(podsRefresh2)
 
 		// Verify order is maintained alphabetically
-		assert.Equal(t, "context-a", view.rows[0][0])
-		assert.Equal(t, "context-ab", view.rows[1][0])
-		assert.Equal(t, "context-b", view.rows[2][0])
+		assert.Equal(t, "context-a", view.table.RowValues(0)[0])
+		assert.Equal(t, "context-ab", view.table.RowValues(1)[0])
+		assert.Equal(t, "context-b", view.table.RowValues(2)[0])
 
 		// Selection should move with the item
 		assert.Equal(t, 2, view.selectedRow, "Selection should follow context-b to its new position")
-		assert.Equal(t, "context-b", view.rows[view.selectedRow][0])
+		assert.Equal(t, "context-b", view.table.RowValues(view.selectedRow)[0])
 	})
 }
 
@@ -374,12 +378,130 @@ func TestMultiContextSortStability(t *testing.T) {
 
 			// When all have same status, should fall back to context then name
 			// So order should always be: ctx-1/pod-a, ctx-2/pod-b, ctx-3/pod-c
-			assert.Equal(t, "ctx-1", view.rows[0][0], "First row context in iteration %d", i)
-			assert.Equal(t, "pod-a", view.rows[0][1], "First row name in iteration %d", i)
-			assert.Equal(t, "ctx-2", view.rows[1][0], "Second row context in iteration %d", i)
-			assert.Equal(t, "pod-b", view.rows[1][1], "Second row name in iteration %d", i)
-			assert.Equal(t, "ctx-3", view.rows[2][0], "Third row context in iteration %d", i)
-			assert.Equal(t, "pod-c", view.rows[2][1], "Third row name in iteration %d", i)
+			assert.Equal(t, "ctx-1", view.table.RowValues(0)[0], "First row context in iteration %d", i)
+			assert.Equal(t, "pod-a", view.table.RowValues(0)[1], "First row name in iteration %d", i)
+			assert.Equal(t, "ctx-2", view.table.RowValues(1)[0], "Second row context in iteration %d", i)
+			assert.Equal(t, "pod-b", view.table.RowValues(1)[1], "Second row name in iteration %d", i)
+			assert.Equal(t, "ctx-3", view.table.RowValues(2)[0], "Third row context in iteration %d", i)
+			assert.Equal(t, "pod-c", view.table.RowValues(2)[1], "Third row name in iteration %d", i)
 		}
 	})
 }
+
+// mergedPods returns pods from two contexts with ties in every column but
+// the ones that set them apart: the same name in both contexts and in two
+// namespaces, equal ages and restarts, and IPs missing from some
+func mergedPods() []k8s.PodWithContext {
+	created := metav1.Time{Time: time.Now().Add(-time.Hour)}
+	var pods []k8s.PodWithContext
+	for _, context := range []string{"prod", "staging"} {
+		for _, namespace := range []string{"api", "web"} {
+			for i, name := range []string{"web-1", "Web-1", "worker"} {
+				pod := v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              name,
+						Namespace:         namespace,
+						UID:               types.UID(context + "-" + namespace + "-" + name),
+						CreationTimestamp: created,
+					},
+					Status: v1.PodStatus{Phase: v1.PodRunning},
+				}
+				if i != 1 {
+					pod.Status.PodIP = "10.0.0.1"
+				}
+				pods = append(pods, k8s.PodWithContext{Context: context, Pod: pod})
+			}
+		}
+	}
+	return pods
+}
+
+func TestMultiContextMergedOrderIsTotal(t *testing.T) {
+	pods := mergedPods()
+	state := core.NewState(&core.Config{})
+	state.CurrentResourceType = core.ResourceTypePod
+	state.CurrentNamespace = "all"
+	state.CurrentContexts = []string{"prod", "staging"}
+	view := NewResourceView(state, nil)
+	view.isMultiContext = true
+	view.showContextColumn = true
+	view.SetSize(200, 40)
+	view.updateTableWithPodsMultiContext(pods)
+	headers := view.table.Titles()
+
+	random := rand.New(rand.NewSource(1))
+	for _, column := range headers {
+		for _, ascending := range []bool{true, false} {
+			state.SetSortState(column, ascending)
+			view.updateTableWithPodsMultiContext(pods)
+			want := view.View()
+			for i := 0; i < 20; i++ {
+				shuffled := slices.Clone(pods)
+				random.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+				view.updateTableWithPodsMultiContext(shuffled)
+				if got := view.View(); got != want {
+					t.Fatalf("Expected the same order sorting by %s (ascending %v) whatever order the pods arrive in, got:\n%s\nthen:\n%s", column, ascending, want, got)
+				}
+			}
+		}
+	}
+
+	// Equal names are ordered by namespace, then context
+	state.SetSortState("NAME", true)
+	view.updateTableWithPodsMultiContext(pods)
+	contextColumn, namespaceColumn := slices.Index(headers, "CONTEXT"), slices.Index(headers, "NAMESPACE")
+	var order []string
+	for _, row := range view.table.Values()[:4] {
+		order = append(order, row[namespaceColumn]+"/"+row[contextColumn])
+	}
+	assert.Equal(t, []string{"api/prod", "api/staging", "web/prod", "web/staging"}, order)
+
+	// Missing values come last whichever way the column is sorted
+	ipColumn := slices.Index(headers, "IP")
+	if ipColumn < 0 {
+		t.Fatalf("Expected an IP column, got %v", headers)
+	}
+	for _, ascending := range []bool{true, false} {
+		state.SetSortState("IP", ascending)
+		view.updateTableWithPodsMultiContext(pods)
+		rows := view.table.Values()
+		for _, row := range rows[len(rows)-4:] {
+			assert.Equal(t, "-", row[ipColumn], "Expected pods without an IP last (ascending %v)", ascending)
+		}
+	}
+}
+
+func TestMultiContextRefreshIsByteIdentical(t *testing.T) {
+	state := core.NewState(&core.Config{})
+	state.CurrentResourceType = core.ResourceTypeDeployment
+	state.CurrentNamespace = "all"
+	state.CurrentContexts = []string{"prod", "staging", "dev"}
+	state.SetSortState("READY", false)
+	view := NewResourceView(state, nil)
+	view.isMultiContext = true
+	view.showContextColumn = true
+	view.SetSize(200, 40)
+
+	replicas := int32(2)
+	var deployments []k8s.DeploymentWithContext
+	for _, context := range []string{"prod", "staging", "dev"} {
+		for _, name := range []string{"api", "web", "worker"} {
+			deployments = append(deployments, k8s.DeploymentWithContext{Context: context, Deployment: appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(context + "-" + name)},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: &replicas,
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name, "tier": "backend", "track": "stable"}},
+				},
+				Status: appsv1.DeploymentStatus{ReadyReplicas: 1},
+			}})
+		}
+	}
+
+	view.updateTableWithDeploymentsMultiContext(deployments)
+	first := view.View()
+	slices.Reverse(deployments)
+	view.updateTableWithDeploymentsMultiContext(deployments)
+	if second := view.View(); second != first {
+		t.Errorf("Expected an unchanged refresh to render the same table, got:\n%s\nthen:\n%s", first, second)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/HamStudy/kubewatch/internal/k8s"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	v1 "k8s.io/api/core/v1"
@@ -22,6 +23,7 @@ type NamespaceView struct {
 	currentNamespace string
 	loading          bool
 	loadingMessage   string
+	loadError        string // Why the namespaces could not be loaded
 }
 
 // NewNamespaceView creates a new namespace selector view
@@ -95,6 +97,12 @@ func (v *NamespaceView) SetNamespaces(namespaces []v1.Namespace) {
 	}
 }
 
+// SetLoadError shows why the namespaces could not be loaded, leaving only "all"
+func (v *NamespaceView) SetLoadError(err error) {
+	v.SetNamespaces([]v1.Namespace{})
+	v.loadError = k8s.UserMessage(err)
+}
+
 // Init initializes the view
 func (v *NamespaceView) Init() tea.Cmd {
 	return nil
@@ -249,6 +257,12 @@ func (v *NamespaceView) View() string {
 		)
 	}
 
+	if v.loadError != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Width(44)
+		content.WriteString(errorStyle.Render("✗ " + v.loadError))
+		content.WriteString("\n\n")
+	}
+
 	// Show filter if active or in filter mode
 	if v.filter != "" || v.filterMode {
 		filterText := fmt.Sprintf("Filter: %s", v.filter)
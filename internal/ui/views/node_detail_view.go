@@ -0,0 +1,418 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// NodeDetailView shows one node: the pods scheduled on it with their requests
+// summed against its allocatable resources, its conditions and its taints
+type NodeDetailView struct {
+	nodeName string
+	context  string
+
+	node     *v1.Node
+	pods     []v1.Pod
+	requests k8s.NodeRequests
+	loaded   bool
+	err      error
+	selected int
+	status   string
+
+	width  int
+	height int
+}
+
+// NewNodeDetailView creates a node detail overlay
+func NewNodeDetailView(nodeName, context string) *NodeDetailView {
+	return &NodeDetailView{
+		nodeName: nodeName,
+		context:  context,
+	}
+}
+
+// Init initializes the view
+func (v *NodeDetailView) Init() tea.Cmd {
+	return nil
+}
+
+// LoadNodeWithClient fetches the node and the pods scheduled on it
+func (v *NodeDetailView) LoadNodeWithClient(ctx context.Context, client *k8s.Client) tea.Cmd {
+	name := v.nodeName
+	return func() tea.Msg {
+		node, err := client.GetNode(ctx, name)
+		if err != nil {
+			return nodeLoadedMsg{err: err}
+		}
+		pods, err := client.ListPodsOnNode(ctx, name)
+		if err != nil {
+			return nodeLoadedMsg{err: err}
+		}
+		return nodeLoadedMsg{node: node, pods: pods}
+	}
+}
+
+// Update handles messages. Esc and cordoning are handled by the node mode.
+func (v *NodeDetailView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case nodeLoadedMsg:
+		v.setNode(msg.node, msg.pods, msg.err)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if v.selected > 0 {
+				v.selected--
+			}
+		case "down", "j":
+			if v.selected < len(v.pods)-1 {
+				v.selected++
+			}
+		case "home", "g":
+			v.selected = 0
+		case "end", "G":
+			v.selected = max(len(v.pods)-1, 0)
+		case "enter":
+			if pod, ok := v.SelectedPod(); ok {
+				return v, func() tea.Msg { return NodePodSelectedMsg{Namespace: pod.Namespace, Name: pod.Name} }
+			}
+		}
+	}
+	return v, nil
+}
+
+// setNode replaces the shown node and pods, keeping the same pod selected
+func (v *NodeDetailView) setNode(node *v1.Node, pods []v1.Pod, err error) {
+	v.loaded = true
+	v.err = err
+	if err != nil {
+		return
+	}
+
+	previous, hadSelection := v.SelectedPod()
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	v.node = node
+	v.pods = pods
+	v.requests = k8s.ComputeNodeRequests(node, pods)
+
+	v.selected = min(v.selected, max(len(pods)-1, 0))
+	if hadSelection {
+		for i, pod := range pods {
+			if pod.Namespace == previous.Namespace && pod.Name == previous.Name {
+				v.selected = i
+				break
+			}
+		}
+	}
+}
+
+// SelectedPod returns the highlighted pod
+func (v *NodeDetailView) SelectedPod() (v1.Pod, bool) {
+	if v.selected < 0 || v.selected >= len(v.pods) {
+		return v1.Pod{}, false
+	}
+	return v.pods[v.selected], true
+}
+
+// IsCordoned reports whether the loaded node is marked unschedulable
+func (v *NodeDetailView) IsCordoned() bool {
+	return v.node != nil && v.node.Spec.Unschedulable
+}
+
+// IsLoaded reports whether the node has loaded without error
+func (v *NodeDetailView) IsLoaded() bool {
+	return v.node != nil && v.err == nil
+}
+
+// NodeName returns the name of the node being shown
+func (v *NodeDetailView) NodeName() string {
+	return v.nodeName
+}
+
+// GetContext returns the context of the node being shown
+func (v *NodeDetailView) GetContext() string {
+	return v.context
+}
+
+// SetStatus shows the outcome of an action under the pod list
+func (v *NodeDetailView) SetStatus(status string) {
+	v.status = status
+}
+
+// View renders the node detail overlay
+func (v *NodeDetailView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	sectionStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("214"))
+
+	warnStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(true)
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Node: " + v.nodeName))
+	content.WriteString("\n")
+	if v.context != "" {
+		content.WriteString(labelStyle.Render("Context: " + v.context))
+		content.WriteString("\n")
+	}
+
+	switch {
+	case v.err != nil:
+		content.WriteString("\n")
+		content.WriteString(fmt.Sprintf("Error: %s", k8s.UserMessage(v.err)))
+		content.WriteString("\n")
+	case !v.loaded:
+		content.WriteString("\nLoading node...\n")
+	default:
+		content.WriteString(v.renderState(warnStyle))
+		content.WriteString("\n\n")
+
+		content.WriteString(sectionStyle.Render("Requests"))
+		content.WriteString("\n")
+		content.WriteString(v.renderCapacity())
+
+		content.WriteString("\n")
+		content.WriteString(sectionStyle.Render("Conditions"))
+		content.WriteString("\n")
+		content.WriteString(v.renderConditions(warnStyle))
+
+		content.WriteString("\n")
+		content.WriteString(sectionStyle.Render("Taints"))
+		content.WriteString("\n")
+		content.WriteString(v.renderTaints(labelStyle))
+
+		content.WriteString("\n")
+		content.WriteString(sectionStyle.Render(fmt.Sprintf("Pods (%d)", len(v.pods))))
+		content.WriteString("\n")
+		content.WriteString(v.renderPods(labelStyle, selectedStyle))
+	}
+
+	if v.status != "" {
+		content.WriteString("\n")
+		content.WriteString(statusStyle(v.status).Render(v.status))
+		content.WriteString("\n")
+	}
+
+	cordon := "Cordon"
+	if v.IsCordoned() {
+		cordon = "Uncordon"
+	}
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render(fmt.Sprintf("[↑/↓] Select  [Enter] Show pod  [c] %s...  [r] Refresh  [Esc/N] Close", cordon)))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// renderState summarizes readiness and schedulability, e.g. "Ready, cordoned"
+func (v *NodeDetailView) renderState(warnStyle lipgloss.Style) string {
+	var parts []string
+	if k8s.IsNodeReady(v.node) {
+		parts = append(parts, "Ready")
+	} else {
+		parts = append(parts, warnStyle.Render("NotReady"))
+	}
+	if v.node.Spec.Unschedulable {
+		parts = append(parts, warnStyle.Render("cordoned (SchedulingDisabled)"))
+	}
+	if kubelet := v.node.Status.NodeInfo.KubeletVersion; kubelet != "" {
+		parts = append(parts, "kubelet "+kubelet)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderCapacity draws a bar per resource for the requests against allocatable
+func (v *NodeDetailView) renderCapacity() string {
+	r := v.requests
+	var b strings.Builder
+	b.WriteString(capacityLine("CPU", k8s.RequestFraction(r.CPU, r.AllocatableCPU), formatQuantity(r.CPU), formatQuantity(r.AllocatableCPU)))
+	b.WriteString(capacityLine("Memory", k8s.RequestFraction(r.Memory, r.AllocatableMemory), formatQuantity(r.Memory), formatQuantity(r.AllocatableMemory)))
+
+	podFraction := 0.0
+	if r.AllocatablePods > 0 {
+		podFraction = float64(r.Pods) / float64(r.AllocatablePods)
+	}
+	b.WriteString(capacityLine("Pods", podFraction, fmt.Sprint(r.Pods), fmt.Sprint(r.AllocatablePods)))
+
+	if r.WithoutRequests > 0 {
+		b.WriteString(fmt.Sprintf("  %d pod(s) request nothing and are not counted\n", r.WithoutRequests))
+	}
+	return b.String()
+}
+
+// capacityBarWidth is the number of cells in a capacity bar
+const capacityBarWidth = 20
+
+// capacityLine renders one labelled capacity bar, e.g.
+// "CPU     [█████████░░░░░░░░░░░]  44%  1750m / 4"
+func capacityLine(label string, fraction float64, used, total string) string {
+	filled := int(fraction*capacityBarWidth + 0.5)
+	filled = min(max(filled, 0), capacityBarWidth)
+
+	color := lipgloss.Color("2")
+	switch {
+	case fraction >= 0.9:
+		color = lipgloss.Color("1")
+	case fraction >= 0.75:
+		color = lipgloss.Color("3")
+	}
+	bar := lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) +
+		strings.Repeat("░", capacityBarWidth-filled)
+
+	return fmt.Sprintf("  %-7s [%s] %3.0f%%  %s / %s\n", label, bar, fraction*100, used, total)
+}
+
+// formatQuantity renders a quantity, or "-" for none
+func formatQuantity(q resource.Quantity) string {
+	if q.IsZero() {
+		return "-"
+	}
+	return q.String()
+}
+
+// renderConditions lists the node conditions, marking the unhealthy ones
+func (v *NodeDetailView) renderConditions(warnStyle lipgloss.Style) string {
+	if len(v.node.Status.Conditions) == 0 {
+		return "  none reported\n"
+	}
+	var b strings.Builder
+	for _, condition := range v.node.Status.Conditions {
+		line := fmt.Sprintf("%s=%s", condition.Type, condition.Status)
+		if condition.Reason != "" {
+			line += " (" + condition.Reason + ")"
+		}
+		if k8s.IsNodeConditionHealthy(condition) {
+			b.WriteString("  " + line + "\n")
+			continue
+		}
+		if condition.Message != "" {
+			line += ": " + condition.Message
+		}
+		b.WriteString(warnStyle.Render("⚠ "+line) + "\n")
+	}
+	return b.String()
+}
+
+// renderTaints lists the node taints as key=value:effect
+func (v *NodeDetailView) renderTaints(labelStyle lipgloss.Style) string {
+	if len(v.node.Spec.Taints) == 0 {
+		return labelStyle.Render("  none") + "\n"
+	}
+	var b strings.Builder
+	for _, taint := range v.node.Spec.Taints {
+		b.WriteString("  " + taint.ToString() + "\n")
+	}
+	return b.String()
+}
+
+// renderPods lists the pods on the node with what each requests, keeping the
+// selection on screen when the list is taller than the panel
+func (v *NodeDetailView) renderPods(labelStyle, selectedStyle lipgloss.Style) string {
+	if len(v.pods) == 0 {
+		return labelStyle.Render("  No pods scheduled") + "\n"
+	}
+
+	start, end := 0, len(v.pods)
+	if maxItems := v.height - 26 - len(v.node.Status.Conditions) - len(v.node.Spec.Taints); len(v.pods) > max(maxItems, 3) {
+		maxItems = max(maxItems, 3)
+		start = min(max(v.selected-maxItems/2, 0), len(v.pods)-maxItems)
+		end = start + maxItems
+	}
+
+	now := time.Now()
+	var b strings.Builder
+	b.WriteString(labelStyle.Render(fmt.Sprintf("  %-20s %-40s %-18s %8s %8s", "NAMESPACE", "NAME", "STATUS", "CPU", "MEMORY")))
+	b.WriteString("\n")
+	if start > 0 {
+		b.WriteString(labelStyle.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+	}
+	for i := start; i < end; i++ {
+		pod := &v.pods[i]
+		requests := k8s.PodRequests(pod)
+		line := fmt.Sprintf("%-20s %-40s %-18s %8s %8s",
+			truncateCell(pod.Namespace, 20), truncateCell(pod.Name, 40), truncateCell(core.PodStatus(pod, now), 18),
+			formatQuantity(requests[v1.ResourceCPU]), formatQuantity(requests[v1.ResourceMemory]))
+		if i == v.selected {
+			b.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("\n")
+	}
+	if end < len(v.pods) {
+		b.WriteString(labelStyle.Render(fmt.Sprintf("  ↓ %d more", len(v.pods)-end)) + "\n")
+	}
+	return b.String()
+}
+
+// truncateCell shortens s to fit a column of width characters
+func truncateCell(s string, width int) string {
+	if len(s) <= width {
+		return s
+	}
+	return s[:width-3] + "..."
+}
+
+// SetSize updates the view size
+func (v *NodeDetailView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// nodeLoadedMsg is sent when a node and its pods have been fetched
+type nodeLoadedMsg struct {
+	node *v1.Node
+	pods []v1.Pod
+	err  error
+}
+
+// NodePodSelectedMsg is sent when the user picks a pod on the node to show
+type NodePodSelectedMsg struct {
+	Namespace string
+	Name      string
+}
@@ -0,0 +1,105 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func nodeDetailTestPod(namespace, name, cpu string) v1.Pod {
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Name: "main"}}},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+	if cpu != "" {
+		pod.Spec.Containers[0].Resources.Requests = v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)}
+	}
+	return pod
+}
+
+func nodeDetailTestNode() *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Spec: v1.NodeSpec{
+			Unschedulable: true,
+			Taints:        []v1.Taint{{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}},
+		},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("2"),
+				v1.ResourceMemory: resource.MustParse("4Gi"),
+				v1.ResourcePods:   resource.MustParse("110"),
+			},
+			Conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionTrue, Reason: "KubeletReady"},
+				{Type: v1.NodeMemoryPressure, Status: v1.ConditionTrue, Reason: "KubeletHasInsufficientMemory", Message: "memory available is low"},
+			},
+		},
+	}
+}
+
+func TestNodeDetailViewRendersNode(t *testing.T) {
+	v := NewNodeDetailView("node-1", "prod")
+	v.SetSize(140, 60)
+	if view := v.View(); !strings.Contains(view, "Loading node") {
+		t.Errorf("Expected loading before the node arrives, got:\n%s", view)
+	}
+
+	v.Update(nodeLoadedMsg{node: nodeDetailTestNode(), pods: []v1.Pod{
+		nodeDetailTestPod("shop", "web", "1500m"),
+		nodeDetailTestPod("kube-system", "proxy", ""),
+	}})
+
+	view := v.View()
+	for _, want := range []string{
+		"Node: node-1", "Context: prod", "cordoned",
+		"75%", "1500m / 2", "1 pod(s) request nothing",
+		"⚠ MemoryPressure=True", "memory available is low",
+		"dedicated=gpu:NoSchedule",
+		"Pods (2)", "kube-system", "web", "[c] Uncordon",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the node view, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "⚠ Ready") {
+		t.Errorf("Expected a healthy Ready condition unmarked, got:\n%s", view)
+	}
+	if !v.IsCordoned() || !v.IsLoaded() {
+		t.Error("Expected a loaded, cordoned node")
+	}
+}
+
+func TestNodeDetailViewSelectsPod(t *testing.T) {
+	v := NewNodeDetailView("node-1", "")
+	v.SetSize(140, 60)
+	v.Update(nodeLoadedMsg{node: nodeDetailTestNode(), pods: []v1.Pod{
+		nodeDetailTestPod("shop", "web", ""),
+		nodeDetailTestPod("kube-system", "proxy", ""),
+	}})
+
+	// Pods are sorted by namespace, then name
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	_, cmd := v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected Enter to pick the pod")
+	}
+	if msg, ok := cmd().(NodePodSelectedMsg); !ok || msg.Namespace != "shop" || msg.Name != "web" {
+		t.Errorf("Expected shop/web picked, got %#v", msg)
+	}
+
+	// A refresh keeps the same pod selected as the list changes
+	v.Update(nodeLoadedMsg{node: nodeDetailTestNode(), pods: []v1.Pod{
+		nodeDetailTestPod("shop", "web", ""),
+		nodeDetailTestPod("default", "batch", ""),
+		nodeDetailTestPod("kube-system", "proxy", ""),
+	}})
+	if pod, _ := v.SelectedPod(); pod.Name != "web" {
+		t.Errorf("Expected web to stay selected, got %q", pod.Name)
+	}
+}
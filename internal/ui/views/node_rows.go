@@ -0,0 +1,69 @@
+package views
+
+import (
+	"context"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	v1 "k8s.io/api/core/v1"
+)
+
+// refreshNodeMetrics fetches node metrics, keeping the last ones while
+// metrics are paused. Without the metrics API the CPU and MEMORY cells
+// show "-".
+func (v *ResourceView) refreshNodeMetrics(ctx context.Context, client *k8s.Client) {
+	v.mu.RLock()
+	paused := v.metricsPaused && v.nodeMetrics != nil
+	v.mu.RUnlock()
+	if paused {
+		return
+	}
+
+	metrics, _ := client.GetNodeMetrics(ctx)
+
+	v.mu.Lock()
+	v.nodeMetrics = metrics
+	v.mu.Unlock()
+}
+
+func (v *ResourceView) updateTableWithNodes(nodes []v1.Node) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.updateColumnsForResourceType()
+
+	// Preserve the currently selected resource
+	var selected core.ResourceRef
+	previousSelectedRow := v.selectedRow
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		selected = v.rowRef(v.table.RowValues(v.selectedRow))
+	}
+
+	rows := [][]string{}
+	newSelectedRow := -1
+	now := v.now()
+	for i := range nodes {
+		node := &nodes[i]
+		cpu, memory := "-", "-"
+		if metrics, ok := v.nodeMetrics[node.Name]; ok {
+			cpu, memory = metrics.CPU, metrics.Memory
+		}
+		rows = append(rows, []string{
+			node.Name,
+			core.NodeStatus(node),
+			core.NodeRoles(node),
+			core.AgeOrStuck(node.ObjectMeta, now),
+			node.Status.NodeInfo.KubeletVersion,
+			core.NodeInternalIP(node),
+			cpu,
+			memory,
+		})
+
+		if !selected.IsZero() && selected.Matches("", "", node.Name) {
+			newSelectedRow = len(rows) - 1
+		}
+	}
+	v.table.SetValues(rows)
+
+	v.restoreSelection(newSelectedRow, previousSelectedRow)
+	v.sortRows()
+}
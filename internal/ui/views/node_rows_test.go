@@ -0,0 +1,55 @@
+package views
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestNodeRows tests that nodes are listed with their state and usage, "-"
+// for a node the metrics API has nothing on, and without a namespace
+func TestNodeRows(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypeNode, "payments", "prod"), nil)
+	rv.nodeMetrics = map[string]*k8s.NodeMetrics{"node-a": {Name: "node-a", CPU: "250m", Memory: "1.5Gi"}}
+	rv.updateTableWithNodes([]v1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"node-role.kubernetes.io/control-plane": ""}},
+			Status: v1.NodeStatus{
+				Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+				Addresses:  []v1.NodeAddress{{Type: v1.NodeInternalIP, Address: "10.0.0.4"}},
+				NodeInfo:   v1.NodeSystemInfo{KubeletVersion: "v1.29.2"},
+			},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}},
+	})
+
+	expectedColumns := []string{"NAME", "STATUS", "ROLES", "AGE", "VERSION", "INTERNAL-IP", "CPU", "MEMORY"}
+	if !reflect.DeepEqual(rv.table.Titles(), expectedColumns) {
+		t.Errorf("Expected columns %v, got %v", expectedColumns, rv.table.Titles())
+	}
+	rows := map[string][]string{}
+	for i := 0; i < rv.table.GetRowCount(); i++ {
+		row := rv.table.RowValues(i)
+		rows[row[0]] = row
+	}
+	if row := rows["node-a"]; row[1] != "Ready" || row[2] != "control-plane" || row[4] != "v1.29.2" ||
+		row[5] != "10.0.0.4" || row[6] != "250m" || row[7] != "1.5Gi" {
+		t.Errorf("Expected node-a's state and usage, got %v", row)
+	}
+	if row := rows["node-b"]; row[1] != "Unknown" || row[6] != "-" || row[7] != "-" {
+		t.Errorf("Expected node-b without usage, got %v", row)
+	}
+
+	if header := rv.renderHeader(); strings.Contains(header, "Namespace:") {
+		t.Errorf("Expected no namespace in the header for nodes, got %q", header)
+	}
+	rv.state.CurrentResourceType = core.ResourceTypePod
+	if header := rv.renderHeader(); !strings.Contains(header, "Namespace: payments") {
+		t.Errorf("Expected the namespace in the header for pods, got %q", header)
+	}
+}
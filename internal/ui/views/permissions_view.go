@@ -0,0 +1,195 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PermissionsView shows what the user may do in the current namespace: the
+// resource types kubewatch lists against the verbs it uses, for each
+// context. What it shows comes from the app's permission cache, which is
+// also what rules out actions the user may not take, so the two agree.
+type PermissionsView struct {
+	namespace string   // Empty for all namespaces
+	contexts  []string // [""] for a single client
+	cache     *k8s.PermissionCache
+	checking  map[string]bool
+
+	width  int
+	height int
+}
+
+// NewPermissionsView creates a permissions overlay of namespace over
+// contexts; a single client is passed as the one context ""
+func NewPermissionsView(namespace string, contexts []string, cache *k8s.PermissionCache) *PermissionsView {
+	return &PermissionsView{
+		namespace: namespace,
+		contexts:  contexts,
+		cache:     cache,
+		checking:  make(map[string]bool),
+	}
+}
+
+// Init initializes the view
+func (v *PermissionsView) Init() tea.Cmd {
+	return nil
+}
+
+// LoadWithClients reviews the permissions of each context not reviewed yet,
+// or of every context when recheck is set
+func (v *PermissionsView) LoadWithClients(ctx context.Context, clients map[string]*k8s.Client, recheck bool) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, contextName := range v.contexts {
+		client := clients[contextName]
+		if client == nil || v.checking[contextName] {
+			continue
+		}
+		if !recheck && v.cache.Get(contextName, v.namespace) != nil {
+			continue
+		}
+		v.checking[contextName] = true
+		contextName, cache, namespace := contextName, v.cache, v.namespace
+		cmds = append(cmds, func() tea.Msg {
+			cache.Review(ctx, client, contextName, namespace)
+			return permissionsReviewedMsg{context: contextName}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// Update handles messages. Esc and r are handled by the permissions mode.
+func (v *PermissionsView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case permissionsReviewedMsg:
+		delete(v.checking, msg.context)
+	}
+	return v, nil
+}
+
+// View renders the permissions overlay
+func (v *PermissionsView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	warnStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		Bold(true)
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	var content strings.Builder
+	namespace := "all namespaces"
+	if v.namespace != "" {
+		namespace = "namespace " + v.namespace
+	}
+	content.WriteString(titleStyle.Render("What can I do here?"))
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render("Permissions in " + namespace))
+	content.WriteString("\n")
+
+	for _, contextName := range v.contexts {
+		content.WriteString("\n")
+		if contextName != "" {
+			content.WriteString(titleStyle.Render(contextName))
+			content.WriteString("\n")
+		}
+		content.WriteString(v.renderMatrix(contextName, labelStyle, warnStyle))
+	}
+
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render("✓ allowed  ✗ denied  ? unknown"))
+	content.WriteString("\n\n")
+	content.WriteString(labelStyle.Render("[r] Check again  [Esc/Ctrl+A] Close"))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// renderMatrix renders a context's permissions, one row per resource type,
+// with when and how they were checked and why any are unknown
+func (v *PermissionsView) renderMatrix(contextName string, labelStyle, warnStyle lipgloss.Style) string {
+	permissions := v.cache.Get(contextName, v.namespace)
+	if permissions == nil {
+		if v.checking[contextName] {
+			return "Checking permissions...\n"
+		}
+		return labelStyle.Render("No cluster connection") + "\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(labelStyle.Render(fmt.Sprintf("%-14s", "KIND")))
+	for _, verb := range k8s.PermissionVerbs {
+		b.WriteString(labelStyle.Render(fmt.Sprintf(" %-7s", strings.ToUpper(verb))))
+	}
+	b.WriteString("\n")
+
+	for _, kind := range core.AllResourceTypes {
+		b.WriteString(fmt.Sprintf("%-14s", kind))
+		for _, verb := range k8s.PermissionVerbs {
+			b.WriteString(" " + permissionCell(permissions.Can(kind, verb)))
+		}
+		b.WriteString("\n")
+	}
+
+	status := "Checked " + permissions.CheckedAt.Format("15:04:05")
+	if permissions.Method != "" {
+		status += " by " + permissions.Method
+	}
+	if v.checking[contextName] {
+		status += "; checking again..."
+	}
+	b.WriteString(labelStyle.Render(status))
+	b.WriteString("\n")
+	if permissions.Err != nil {
+		b.WriteString(warnStyle.Render("⚠ Unknown permissions: " + k8s.UserMessage(permissions.Err)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// permissionCell renders one permission, padded to its column
+func permissionCell(permission k8s.Permission) string {
+	switch permission {
+	case k8s.PermissionAllowed:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Render(fmt.Sprintf("%-7s", "✓"))
+	case k8s.PermissionDenied:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(fmt.Sprintf("%-7s", "✗"))
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(fmt.Sprintf("%-7s", "?"))
+}
+
+// SetSize updates the view size
+func (v *PermissionsView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// permissionsReviewedMsg is sent when a context's permissions are cached
+type permissionsReviewedMsg struct {
+	context string
+}
@@ -0,0 +1,284 @@
+package views
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// podEventsInterval is how often the events of a pod being diagnosed
+	// are read again
+	podEventsInterval = 10 * time.Second
+
+	// podEventsTimeout bounds one read of a pod's events. A read whose
+	// result never arrives is given up after it.
+	podEventsTimeout = 15 * time.Second
+
+	// maxPodEventsInFlight bounds how many pods' events are read at once
+	maxPodEventsInFlight = 4
+)
+
+// podEventTarget is a pod whose events are read; key identifies it the way
+// podDetailKey does
+type podEventTarget struct {
+	key       string
+	context   string
+	namespace string
+	name      string
+}
+
+// podEventEntry is what the cache holds for one pod
+type podEventEntry struct {
+	events    []v1.Event // Oldest first, one copy of each recurring event
+	newest    time.Time  // When the newest event was last seen
+	fetchedAt time.Time  // When the last read finished
+	started   time.Time  // When the read in flight started; zero when none is
+}
+
+// podEventCache keeps the events of the pods a list is diagnosing, such as
+// pods waiting on an image. Each pod's events are read at most once per
+// podEventsInterval, and after the first read only the events seen since
+// the newest held are asked for. Pods no longer diagnosed are forgotten.
+type podEventCache struct {
+	mu   sync.Mutex
+	pods map[string]*podEventEntry
+}
+
+func newPodEventCache() *podEventCache {
+	return &podEventCache{pods: make(map[string]*podEventEntry)}
+}
+
+// Events returns the events held for a pod, oldest first
+func (c *podEventCache) Events(key string) []v1.Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.pods[key]; ok {
+		return entry.events
+	}
+	return nil
+}
+
+// podEventFetch is a read of a pod's events the caller should run
+type podEventFetch struct {
+	target podEventTarget
+	since  time.Time
+}
+
+// Due returns the reads to run now, for the targets not read within
+// podEventsInterval, within the in-flight limit, and forgets the pods not
+// among targets
+func (c *podEventCache) Due(targets []podEventTarget, now time.Time) []podEventFetch {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wanted := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		wanted[target.key] = true
+	}
+	inFlight := 0
+	for key, entry := range c.pods {
+		if !wanted[key] {
+			delete(c.pods, key)
+			continue
+		}
+		if !entry.started.IsZero() && now.Sub(entry.started) >= podEventsTimeout {
+			entry.started = time.Time{}
+		}
+		if !entry.started.IsZero() {
+			inFlight++
+		}
+	}
+
+	var fetches []podEventFetch
+	for _, target := range targets {
+		if inFlight >= maxPodEventsInFlight {
+			break
+		}
+		entry, ok := c.pods[target.key]
+		if !ok {
+			entry = &podEventEntry{}
+			c.pods[target.key] = entry
+		}
+		if !entry.started.IsZero() || (!entry.fetchedAt.IsZero() && now.Sub(entry.fetchedAt) < podEventsInterval) {
+			continue
+		}
+		entry.started = now
+		inFlight++
+		fetches = append(fetches, podEventFetch{target: target, since: entry.newest})
+	}
+	return fetches
+}
+
+// Record merges a read's events into a pod's; a recurring event replaces
+// its earlier copy. A failed read is tried again after the interval.
+func (c *podEventCache) Record(key string, events []v1.Event, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.pods[key]
+	if !ok {
+		return
+	}
+	entry.started = time.Time{}
+	entry.fetchedAt = now
+
+	for _, event := range events {
+		if seen := k8s.EventLastSeen(event); seen.After(entry.newest) {
+			entry.newest = seen
+		}
+		replaced := false
+		for i := range entry.events {
+			if entry.events[i].UID == event.UID {
+				entry.events[i] = event
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			entry.events = append(entry.events, event)
+		}
+	}
+	sort.SliceStable(entry.events, func(i, j int) bool {
+		return k8s.EventLastSeen(entry.events[i]).Before(k8s.EventLastSeen(entry.events[j]))
+	})
+}
+
+// podEventsMsg carries the events read for one pod
+type podEventsMsg struct {
+	key    string
+	events []v1.Event
+	err    error
+}
+
+// FetchPodEvents starts the reads of events that are due for the pods the
+// list is diagnosing. It returns nil when there are none.
+func (v *ResourceView) FetchPodEvents() tea.Cmd {
+	v.mu.RLock()
+	var targets []podEventTarget
+	if v.state.CurrentResourceType == core.ResourceTypePod && v.scrub == nil {
+		for key, pod := range v.pullPods {
+			targets = append(targets, podEventTarget{key: key, context: pod.context, namespace: pod.pod.Namespace, name: pod.pod.Name})
+		}
+	}
+	v.mu.RUnlock()
+	// The same pods come first every time
+	sort.Slice(targets, func(i, j int) bool { return targets[i].key < targets[j].key })
+
+	var cmds []tea.Cmd
+	for _, fetch := range v.podEvents.Due(targets, time.Now()) {
+		cmds = append(cmds, v.fetchPodEvents(fetch))
+	}
+	return tea.Batch(cmds...)
+}
+
+// fetchPodEvents returns a command that reads one pod's events
+func (v *ResourceView) fetchPodEvents(fetch podEventFetch) tea.Cmd {
+	target := fetch.target
+	return func() tea.Msg {
+		client, err := v.clientForContext(target.context)
+		if err != nil {
+			return podEventsMsg{key: target.key, err: err}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), podEventsTimeout)
+		defer cancel()
+		events, err := client.ListEventsFor(ctx, "pods", target.namespace, target.name, fetch.since)
+		return podEventsMsg{key: target.key, events: events, err: err}
+	}
+}
+
+// pullPod is a listed pod with a container waiting on an image
+type pullPod struct {
+	context string
+	pod     *v1.Pod
+}
+
+// pullDiagnosis explains why a listed pod waits on an image, from its
+// status and the events held for it. The caller must hold v.mu.
+func (v *ResourceView) pullDiagnosis(key string, now time.Time) (k8s.PullDiagnosis, bool) {
+	pod, ok := v.pullPods[key]
+	if !ok {
+		return k8s.PullDiagnosis{}, false
+	}
+	return k8s.DiagnosePull(pod.pod, v.podEvents.Events(key), now)
+}
+
+// pullSeverity ranks how badly a pod waits on an image, for the color of
+// its STATUS cell
+type pullSeverity int
+
+const (
+	pullFine    pullSeverity = iota // No pull, or one still within PullSlowAfter
+	pullSlow                        // A pull running past PullSlowAfter
+	pullFailing                     // An image that cannot be pulled
+)
+
+// rowPullSeverity ranks a row's pull. The caller must hold v.mu.
+func (v *ResourceView) rowPullSeverity(row []string) pullSeverity {
+	if len(v.pullPods) == 0 {
+		return pullFine
+	}
+	ref := v.rowRef(row)
+	now := time.Now()
+	if d, ok := v.pullDiagnosis(podKey(ref.Context, ref.Namespace, ref.Name), now); ok {
+		return severityOf(d, now)
+	}
+	return pullFine
+}
+
+// severityOf ranks a pull at now
+func severityOf(d k8s.PullDiagnosis, now time.Time) pullSeverity {
+	switch {
+	case d.Failing():
+		return pullFailing
+	case d.Slow(now):
+		return pullSlow
+	}
+	return pullFine
+}
+
+// pullSeverityColor is the color a pull's detail line is shown in
+func pullSeverityColor(severity pullSeverity) lipgloss.Color {
+	switch severity {
+	case pullFailing:
+		return lipgloss.Color("196")
+	case pullSlow:
+		return lipgloss.Color("208")
+	}
+	return lipgloss.Color("3")
+}
+
+// selectedPullDetail returns the line saying how the selected pod's image
+// pull is going, e.g. "web-1 — pulling registry…/svc:v1.42 for 3m12s", and
+// how bad that is; "" when it waits on no image. The caller must hold v.mu.
+func (v *ResourceView) selectedPullDetail() (string, pullSeverity) {
+	if v.state.CurrentResourceType != core.ResourceTypePod || v.scrub != nil || len(v.pullPods) == 0 {
+		return "", pullFine
+	}
+	identity, ok := v.resourceMap[v.selectedRow]
+	if !ok || identity == nil {
+		return "", pullFine
+	}
+	now := time.Now()
+	d, ok := v.pullDiagnosis(podDetailKey(identity), now)
+	if !ok {
+		return "", pullFine
+	}
+	return identity.Name + " — " + d.Describe(now), severityOf(d, now)
+}
+
+// stylePullStatusCell colors the STATUS cell of a pod whose pull is slow or
+// failing, whatever the status says
+func stylePullStatusCell(status string, width int, severity pullSeverity) string {
+	style := lipgloss.NewStyle().Width(width)
+	if severity == pullFailing {
+		return style.Foreground(lipgloss.Color("1")).Bold(true).Render(status) // Red
+	}
+	return style.Foreground(lipgloss.Color("208")).Render(status) // Orange
+}
@@ -0,0 +1,81 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceViewShowsImagePulls(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "default", ""), nil)
+	rv.SetSize(160, 20)
+	waiting := func(reason string) v1.PodStatus {
+		return v1.PodStatus{Phase: v1.PodPending, ContainerStatuses: []v1.ContainerStatus{{
+			Name:  "app",
+			Image: "registry/team/svc:v1.42",
+			State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: reason}},
+		}}}
+	}
+	pods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}, Status: waiting("ContainerCreating")},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+	}
+	rv.updateTableWithPods(pods)
+
+	// Only the pod waiting on its image has its events read
+	if cmd := rv.FetchPodEvents(); cmd == nil {
+		t.Fatal("Expected the events of web-1 read")
+	}
+	if targets := len(rv.podEvents.pods); targets != 1 {
+		t.Fatalf("Expected one pod's events read, got %d", targets)
+	}
+
+	// Until its events are in, nothing is known of the pull
+	rv.selectedRow = 0
+	if view := rv.View(); strings.Contains(view, "pulling") {
+		t.Errorf("Expected no pull detail without events, got:\n%s", view)
+	}
+
+	started := time.Now().Add(-3 * time.Minute)
+	rv.Update(podEventsMsg{key: podKey("", "default", "web-1"), events: []v1.Event{{
+		ObjectMeta:     metav1.ObjectMeta{UID: "a"},
+		InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "web-1", FieldPath: "spec.containers{app}"},
+		Reason:         "Pulling",
+		Message:        `Pulling image "registry/team/svc:v1.42"`,
+		LastTimestamp:  metav1.NewTime(started),
+	}}})
+	if view := rv.View(); !strings.Contains(view, "web-1 — pulling registry…/svc:v1.42 for 3m") {
+		t.Errorf("Expected the pull in progress under the header, got:\n%s", view)
+	}
+	rv.mu.Lock()
+	severity := rv.rowPullSeverity(rv.table.RowValues(0))
+	rv.mu.Unlock()
+	if severity != pullSlow {
+		t.Errorf("Expected web-1's pull slow, got %v", severity)
+	}
+
+	// Read again only after the interval
+	if cmd := rv.FetchPodEvents(); cmd != nil {
+		t.Error("Expected no read again before the interval")
+	}
+
+	// A failing pull is ranked worst, and a pod no longer waiting is forgotten
+	pods[0].Status = waiting("ImagePullBackOff")
+	rv.updateTableWithPods(pods)
+	rv.mu.Lock()
+	severity = rv.rowPullSeverity(rv.table.RowValues(0))
+	rv.mu.Unlock()
+	if severity != pullFailing {
+		t.Errorf("Expected web-1's pull failing, got %v", severity)
+	}
+	pods[0].Status = v1.PodStatus{Phase: v1.PodRunning}
+	rv.updateTableWithPods(pods)
+	rv.FetchPodEvents()
+	if targets := len(rv.podEvents.pods); targets != 0 {
+		t.Errorf("Expected no pod's events kept, got %d", targets)
+	}
+}
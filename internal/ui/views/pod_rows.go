@@ -0,0 +1,233 @@
+package views
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/components/selection"
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxInternedCells bounds the cell strings a podRowCache keeps for reuse
+const maxInternedCells = 4096
+
+// podRowCache keeps what the rows of the pod list need between refreshes,
+// so refreshing thousands of unchanged pods builds almost nothing. Cells
+// that depend only on the pod are kept until its resourceVersion changes;
+// ages and metrics are recomputed every refresh, and a row whose cells all
+// came out the same is reused as is. Rows are never written to once built,
+// since the table history keeps them.
+type podRowCache struct {
+	entries    map[podRowKey]*podRowEntry
+	generation uint64 // Incremented by every refresh
+
+	scratch []string          // The row being built
+	buf     []byte            // Bytes of the cell being formatted
+	cells   map[string]string // Formatted cells, so equal ones share a string
+}
+
+// podRowKey identifies a pod across contexts; the context is "" in
+// single-context mode
+type podRowKey struct {
+	context string
+	uid     types.UID
+}
+
+// podRowEntry is what a pod's row keeps between refreshes
+type podRowEntry struct {
+	version    string // The resourceVersion the cells below were built from
+	generation uint64 // The last refresh that used the entry
+
+	identity     *selection.ResourceIdentity
+	ready        string
+	restarts     int32
+	lastRestart  time.Time // Zero when no container has terminated
+	detail       string
+	logContainer string
+	pulling      bool // A container waits on an image; see k8s.AwaitingImage
+	security     string
+	hasSecurity  bool
+
+	row []string // The row last built for the pod
+}
+
+func newPodRowCache() *podRowCache {
+	return &podRowCache{
+		entries: make(map[podRowKey]*podRowEntry),
+		cells:   make(map[string]string),
+	}
+}
+
+// begin starts a refresh
+func (c *podRowCache) begin() {
+	c.generation++
+}
+
+// end finishes a refresh, forgetting pods it did not list
+func (c *podRowCache) end() {
+	for key, entry := range c.entries {
+		if entry.generation != c.generation {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// entry returns the cached cells of a pod, building them when the pod is
+// new or has changed. Pods without a UID or resourceVersion, as built by
+// hand in tests, are never cached.
+func (c *podRowCache) entry(context string, pod *v1.Pod) *podRowEntry {
+	if pod.UID == "" || pod.ResourceVersion == "" {
+		return newPodRowEntry(context, pod)
+	}
+	key := podRowKey{context: context, uid: pod.UID}
+	entry, ok := c.entries[key]
+	if !ok || entry.version != pod.ResourceVersion {
+		entry = newPodRowEntry(context, pod)
+		c.entries[key] = entry
+	}
+	entry.generation = c.generation
+	return entry
+}
+
+// newPodRowEntry builds the cells of a pod that only change with the pod
+func newPodRowEntry(context string, pod *v1.Pod) *podRowEntry {
+	entry := &podRowEntry{
+		version: pod.ResourceVersion,
+		identity: &selection.ResourceIdentity{
+			Context:   context,
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			UID:       string(pod.UID),
+			Kind:      "Pod",
+		},
+		detail:  podDetail(pod),
+		pulling: k8s.AwaitingImage(pod),
+	}
+
+	ready := 0
+	var notReadyNames []string
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Ready {
+			ready++
+		} else {
+			notReadyNames = append(notReadyNames, cs.Name)
+		}
+		entry.restarts += cs.RestartCount
+		if cs.LastTerminationState.Terminated != nil {
+			if t := cs.LastTerminationState.Terminated.FinishedAt.Time; t.After(entry.lastRestart) {
+				entry.lastRestart = t
+			}
+		}
+	}
+	entry.ready = podReadyCell(ready, len(pod.Status.ContainerStatuses), notReadyNames)
+	return entry
+}
+
+// keep returns the row for entry with the cells just built, reusing the
+// previous row when none of them changed
+func (c *podRowCache) keep(entry *podRowEntry, cells []string) []string {
+	if !slices.Equal(entry.row, cells) {
+		entry.row = slices.Clone(cells)
+	}
+	return entry.row
+}
+
+// intern returns the bytes as a string, shared with earlier equal cells
+func (c *podRowCache) intern(b []byte) string {
+	if s, ok := c.cells[string(b)]; ok {
+		return s
+	}
+	if len(c.cells) >= maxInternedCells {
+		clear(c.cells)
+	}
+	s := string(b)
+	c.cells[s] = s
+	return s
+}
+
+// age returns the AGE cell for an object created at t
+func (c *podRowCache) age(t, now time.Time) string {
+	c.buf = core.AppendAge(c.buf[:0], t, now)
+	return c.intern(c.buf)
+}
+
+// restartsCell returns the RESTARTS cell, e.g. "3 (10m ago)"
+func (c *podRowCache) restartsCell(entry *podRowEntry, now time.Time) string {
+	if entry.restarts == 0 || entry.lastRestart.IsZero() {
+		return strconv.Itoa(int(entry.restarts))
+	}
+	c.buf = strconv.AppendInt(c.buf[:0], int64(entry.restarts), 10)
+	c.buf = append(c.buf, " ("...)
+	c.buf = core.AppendAge(c.buf, entry.lastRestart, now)
+	c.buf = append(c.buf, " ago)"...)
+	return c.intern(c.buf)
+}
+
+// podRow returns the row of a pod and the entry holding its identity and
+// detail line. The caller must hold v.mu.
+func (v *ResourceView) podRow(context string, showContext, showNamespace bool, pod *v1.Pod, now time.Time) ([]string, *podRowEntry) {
+	c := v.podRows
+	entry := c.entry(context, pod)
+
+	cells := c.scratch[:0]
+	if showContext {
+		cells = append(cells, context)
+	}
+	cells = append(cells, pod.Name)
+	if showNamespace {
+		cells = append(cells, pod.Namespace)
+	}
+
+	// Get metrics if available
+	cpu := "-"
+	memory := "-"
+	if metrics, ok := v.podMetricsFor(context, pod.Namespace, pod.Name); ok {
+		cpu, memory = metrics.Headline(v.metricsHeadline, v.sidecars)
+	}
+
+	ip := pod.Status.PodIP
+	if ip == "" {
+		ip = "-"
+	}
+	node := pod.Spec.NodeName
+	if node == "" {
+		node = "-"
+	}
+
+	status := core.PodStatus(pod, now)
+	cells = append(cells,
+		entry.ready,
+		status,
+		c.restartsCell(entry, now),
+		c.age(pod.CreationTimestamp.Time, now),
+		cpu, memory, ip, node)
+	if v.logRates != nil {
+		key := podKey(context, pod.Namespace, pod.Name)
+		cells = append(cells, v.logRates.Cell(key))
+		if entry.logContainer == "" {
+			entry.logContainer = k8s.DefaultLogContainer(pod)
+		}
+		v.logContainers[key] = entry.logContainer
+	}
+	if v.showSecurity {
+		if !entry.hasSecurity {
+			entry.security = securityCell(&pod.Spec)
+			entry.hasSecurity = true
+		}
+		cells = append(cells, entry.security)
+	}
+	v.podCounts.Add(pod)
+	if status == "CrashLoopBackOff" {
+		v.crashLooping = append(v.crashLooping, core.ResourceRef{Context: context, Namespace: pod.Namespace, Name: pod.Name})
+	}
+	if entry.pulling {
+		v.pullPods[podKey(context, pod.Namespace, pod.Name)] = pullPod{context: context, pod: pod}
+	}
+	c.scratch = cells
+
+	return c.keep(entry, cells), entry
+}
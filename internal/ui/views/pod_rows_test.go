@@ -0,0 +1,134 @@
+package views
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// benchmarkPods returns n running pods with two containers, some restarted
+func benchmarkPods(n int) []v1.Pod {
+	created := metav1.NewTime(time.Now().Add(-3 * time.Hour))
+	finished := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	pods := make([]v1.Pod, n)
+	for i := range pods {
+		app := v1.ContainerStatus{Name: "app", Ready: i%10 != 0, RestartCount: int32(i % 4)}
+		if app.RestartCount > 0 {
+			app.LastTerminationState.Terminated = &v1.ContainerStateTerminated{FinishedAt: finished}
+		}
+		pods[i] = v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("web-%05d", i),
+				Namespace:         "default",
+				UID:               types.UID(fmt.Sprintf("uid-%05d", i)),
+				ResourceVersion:   "1",
+				CreationTimestamp: created,
+			},
+			Spec: v1.PodSpec{
+				NodeName:   fmt.Sprintf("node-%d", i%50),
+				Containers: []v1.Container{{Name: "app"}, {Name: "istio-proxy"}},
+			},
+			Status: v1.PodStatus{
+				Phase:             v1.PodRunning,
+				PodIP:             fmt.Sprintf("10.0.%d.%d", i/250, i%250),
+				ContainerStatuses: []v1.ContainerStatus{app, {Name: "istio-proxy", Ready: true}},
+			},
+		}
+	}
+	return pods
+}
+
+func TestPodRowsReuseUnchangedPods(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "", "test-context"), nil)
+	pods := benchmarkPods(3)
+	rv.updateTableWithPods(pods)
+	first := rv.table.Values()
+
+	// Unchanged pods keep their rows
+	rv.updateTableWithPods(pods)
+	second := rv.table.Values()
+	for i := range first {
+		if &first[i][0] != &second[i][0] {
+			t.Errorf("Expected row %d reused", i)
+		}
+	}
+
+	// A pod whose resourceVersion changed gets its cells rebuilt, and the
+	// old row is left as it was for the table history
+	pods[1].ResourceVersion = "2"
+	pods[1].Status.ContainerStatuses[0].RestartCount = 7
+	rv.updateTableWithPods(pods)
+	if restarts := podRowCell(rv, "", "default", "web-00001", "RESTARTS"); restarts != "7 (10m ago)" {
+		t.Errorf("Expected the new restart count, got %q", restarts)
+	}
+	if restarts := first[1][4]; restarts != "1 (10m ago)" {
+		t.Errorf("Expected the earlier row untouched, got %q", restarts)
+	}
+
+	// Ages move on without the pod changing
+	pods[0].CreationTimestamp = metav1.NewTime(time.Now().Add(-5 * time.Minute))
+	rv.updateTableWithPods(pods)
+	if age := podRowCell(rv, "", "default", "web-00000", "AGE"); age != "5m" {
+		t.Errorf("Expected the age recomputed, got %q", age)
+	}
+
+	// Pods no longer listed are forgotten
+	rv.updateTableWithPods(pods[:1])
+	if len(rv.podRows.entries) != 1 {
+		t.Errorf("Expected 1 cached pod, got %d", len(rv.podRows.entries))
+	}
+}
+
+func TestPodRowsSameUIDAcrossContexts(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "", "east"), nil)
+	rv.state.CurrentContexts = []string{"east", "west"}
+	rv.isMultiContext = true
+	pod := benchmarkPods(1)[0]
+	west := pod
+	west.Status.PodIP = "10.9.9.9"
+
+	rv.updateTableWithPodsMultiContext([]k8s.PodWithContext{{Context: "east", Pod: pod}, {Context: "west", Pod: west}})
+	if ip := podRowCell(rv, "west", "default", "web-00000", "IP"); ip != "10.9.9.9" {
+		t.Errorf("Expected west's own row, got IP %q", ip)
+	}
+	if ip := podRowCell(rv, "east", "default", "web-00000", "IP"); ip != "10.0.0.0" {
+		t.Errorf("Expected east's own row, got IP %q", ip)
+	}
+}
+
+// TestUpdateTableWithPodsAllocationBudget keeps refreshing a list of
+// unchanged pods from allocating per pod
+func TestUpdateTableWithPodsAllocationBudget(t *testing.T) {
+	const pods = 1000
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "default", "test-context"), nil)
+	list := benchmarkPods(pods)
+	rv.updateTableWithPods(list)
+
+	allocs := testing.AllocsPerRun(5, func() { rv.updateTableWithPods(list) })
+	if budget := float64(pods / 10); allocs > budget {
+		t.Errorf("Refreshing %d unchanged pods made %.0f allocations, budget is %.0f", pods, allocs, budget)
+	}
+}
+
+func benchmarkUpdateTableWithPods(b *testing.B, n int) {
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "default", "test-context"), nil)
+	rv.SetSize(200, 60)
+	pods := benchmarkPods(n)
+	rv.updateTableWithPods(pods)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rv.updateTableWithPods(pods)
+	}
+}
+
+func BenchmarkUpdateTableWithPods1k(b *testing.B)  { benchmarkUpdateTableWithPods(b, 1000) }
+func BenchmarkUpdateTableWithPods5k(b *testing.B)  { benchmarkUpdateTableWithPods(b, 5000) }
+func BenchmarkUpdateTableWithPods10k(b *testing.B) { benchmarkUpdateTableWithPods(b, 10000) }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/HamStudy/kubewatch/internal/core"
 	"github.com/HamStudy/kubewatch/internal/k8s"
+	tea "github.com/charmbracelet/bubbletea"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -248,3 +249,68 @@ func TestConcurrentResourceMapAccess(t *testing.T) {
 		t.Fatalf("Concurrent resourceMap access test failed with %d errors", errorCount)
 	}
 }
+
+// TestConcurrentUpdateAndRender hammers table updates from background
+// goroutines, as refresh commands do, while the UI goroutine renders and
+// navigates. Run with -race to verify the locking invariant on ResourceView.
+func TestConcurrentUpdateAndRender(t *testing.T) {
+	state := &core.State{
+		CurrentResourceType: core.ResourceTypePod,
+		CurrentNamespace:    "default",
+		SortColumn:          "NAME",
+		SortAscending:       true,
+	}
+	rv := NewResourceView(state, nil)
+	rv.SetSize(120, 20)
+
+	// Alternate between long and short lists so rows shrink under the renderer
+	makePods := func(n int) []v1.Pod {
+		pods := make([]v1.Pod, n)
+		for i := range pods {
+			pods[i] = v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("pod-%03d", i),
+					Namespace: "default",
+					UID:       types.UID(fmt.Sprintf("uid-%d", i)),
+				},
+				Status: v1.PodStatus{Phase: v1.PodRunning},
+			}
+		}
+		return pods
+	}
+	long, short := makePods(50), makePods(2)
+	rv.updateTableWithPods(long)
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; ; j++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if (id+j)%2 == 0 {
+					rv.updateTableWithPods(long)
+				} else {
+					rv.updateTableWithPods(short)
+				}
+			}
+		}(i)
+	}
+
+	deadline := time.Now().Add(300 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		rv.Update(tea.KeyMsg{Type: tea.KeyEnd})
+		_ = rv.View()
+		_ = rv.GetSelectedResourceName()
+		rv.Update(tea.KeyMsg{Type: tea.KeyUp})
+		_ = rv.View()
+	}
+
+	close(done)
+	wg.Wait()
+}
@@ -0,0 +1,100 @@
+package views
+
+import (
+	"strconv"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (v *ResourceView) updateTableWithServiceAccounts(serviceAccounts []v1.ServiceAccount) {
+	rows := make([]objectRow, len(serviceAccounts))
+	for i := range serviceAccounts {
+		sa := &serviceAccounts[i]
+		rows[i] = objectRow{sa.ObjectMeta, []string{strconv.Itoa(len(sa.Secrets))}}
+	}
+	v.updateTableWithObjectRows(rows)
+}
+
+func (v *ResourceView) updateTableWithRoles(roles []rbacv1.Role) {
+	rows := make([]objectRow, len(roles))
+	for i := range roles {
+		rows[i] = objectRow{roles[i].ObjectMeta, []string{strconv.Itoa(len(roles[i].Rules))}}
+	}
+	v.updateTableWithObjectRows(rows)
+}
+
+func (v *ResourceView) updateTableWithClusterRoles(roles []rbacv1.ClusterRole) {
+	rows := make([]objectRow, len(roles))
+	for i := range roles {
+		rows[i] = objectRow{roles[i].ObjectMeta, []string{strconv.Itoa(len(roles[i].Rules))}}
+	}
+	v.updateTableWithObjectRows(rows)
+}
+
+func (v *ResourceView) updateTableWithRoleBindings(bindings []rbacv1.RoleBinding) {
+	rows := make([]objectRow, len(bindings))
+	for i := range bindings {
+		b := &bindings[i]
+		rows[i] = objectRow{b.ObjectMeta, []string{core.RoleRefName(b.RoleRef), core.SubjectsSummary(b.Subjects)}}
+	}
+	v.updateTableWithObjectRows(rows)
+}
+
+func (v *ResourceView) updateTableWithClusterRoleBindings(bindings []rbacv1.ClusterRoleBinding) {
+	rows := make([]objectRow, len(bindings))
+	for i := range bindings {
+		b := &bindings[i]
+		rows[i] = objectRow{b.ObjectMeta, []string{core.RoleRefName(b.RoleRef), core.SubjectsSummary(b.Subjects)}}
+	}
+	v.updateTableWithObjectRows(rows)
+}
+
+// objectRow is an object's metadata and the cells shown between its
+// NAMESPACE and AGE columns
+type objectRow struct {
+	meta  metav1.ObjectMeta
+	cells []string
+}
+
+// updateTableWithObjectRows lists objects that show a few cells between
+// their name and age, such as RBAC's and jobs. Cluster-scoped kinds have no
+// NAMESPACE column.
+func (v *ResourceView) updateTableWithObjectRows(objects []objectRow) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.updateColumnsForResourceType()
+
+	showNamespace := (v.state.CurrentNamespace == "" || v.state.CurrentNamespace == "all") &&
+		!core.IsClusterScoped(v.state.CurrentResourceType)
+
+	// Preserve the currently selected resource
+	var selected core.ResourceRef
+	previousSelectedRow := v.selectedRow
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		selected = v.rowRef(v.table.RowValues(v.selectedRow))
+	}
+
+	rows := [][]string{}
+	newSelectedRow := -1
+	now := v.now()
+	for _, obj := range objects {
+		rowData := []string{obj.meta.Name}
+		if showNamespace {
+			rowData = append(rowData, obj.meta.Namespace)
+		}
+		rowData = append(rowData, obj.cells...)
+		rowData = append(rowData, core.AgeOrStuck(obj.meta, now))
+		rows = append(rows, rowData)
+
+		if !selected.IsZero() && selected.Matches("", obj.meta.Namespace, obj.meta.Name) {
+			newSelectedRow = len(rows) - 1
+		}
+	}
+	v.table.SetValues(rows)
+
+	v.restoreSelection(newSelectedRow, previousSelectedRow)
+	v.sortRows()
+}
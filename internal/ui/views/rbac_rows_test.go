@@ -0,0 +1,40 @@
+package views
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestClusterScopedListsHaveNoNamespace tests that cluster role bindings
+// show no NAMESPACE column, even across all namespaces, and that their rows
+// refer to no namespace
+func TestClusterScopedListsHaveNoNamespace(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypeClusterRoleBinding, "", "prod"), nil)
+	rv.updateTableWithClusterRoleBindings([]rbacv1.ClusterRoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+		RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "admins"}},
+	}})
+
+	if expected := []string{"NAME", "ROLE", "SUBJECTS", "AGE"}; !reflect.DeepEqual(rv.table.Titles(), expected) {
+		t.Errorf("Expected columns %v, got %v", expected, rv.table.Titles())
+	}
+	if row := rv.table.RowValues(0); row[1] != "ClusterRole/cluster-admin" || row[2] != "Group/admins" {
+		t.Errorf("Expected the role and subjects shown, got %v", row)
+	}
+
+	rv.state.CurrentNamespace = "payments"
+	rv.SetSelectedRow(0)
+	if ns := rv.GetSelectedResourceNamespace(); ns != "" {
+		t.Errorf("Expected no namespace for a cluster role binding, got %q", ns)
+	}
+
+	// Namespaced RBAC kinds keep theirs
+	if columns := rv.Columns(core.ResourceTypeRoleBinding, ""); columns[1] != "NAMESPACE" {
+		t.Errorf("Expected role bindings to show their namespace, got %v", columns)
+	}
+}
@@ -0,0 +1,208 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RelationsView lists a resource's immediate neighbours in the relationship
+// graph, grouped by relation, so the user can jump to one
+type RelationsView struct {
+	resourceType string
+	resourceName string
+	namespace    string
+
+	related  []core.RelatedResource
+	err      error
+	selected int
+	status   string
+
+	width  int
+	height int
+}
+
+// NewRelationsView creates a relationships panel for a resource from its
+// resolved neighbours, or the error resolving them
+func NewRelationsView(resourceType, resourceName, namespace string, related []core.RelatedResource, err error) *RelationsView {
+	return &RelationsView{
+		resourceType: resourceType,
+		resourceName: resourceName,
+		namespace:    namespace,
+		related:      related,
+		err:          err,
+	}
+}
+
+// Init initializes the view
+func (v *RelationsView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages. Esc is handled by the relations mode.
+func (v *RelationsView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case tea.KeyMsg:
+		v.status = ""
+		switch msg.String() {
+		case "up", "k":
+			if v.selected > 0 {
+				v.selected--
+			}
+		case "down", "j":
+			if v.selected < len(v.related)-1 {
+				v.selected++
+			}
+		case "enter":
+			r, ok := v.SelectedResource()
+			if !ok {
+				return v, nil
+			}
+			if r.Type == "" {
+				v.status = fmt.Sprintf("✗ kubewatch has no list of %ss", r.Kind)
+				return v, nil
+			}
+			return v, func() tea.Msg { return RelatedResourceSelectedMsg{Resource: r} }
+		}
+	}
+	return v, nil
+}
+
+// SelectedResource returns the highlighted neighbour
+func (v *RelationsView) SelectedResource() (core.RelatedResource, bool) {
+	if v.selected < 0 || v.selected >= len(v.related) {
+		return core.RelatedResource{}, false
+	}
+	return v.related[v.selected], true
+}
+
+// View renders the relationships panel
+func (v *RelationsView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	sectionStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("214"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(true)
+
+	missingStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196"))
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(fmt.Sprintf("Relationships: %s/%s", strings.ToLower(FormatResourceType(v.resourceType)), v.resourceName)))
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render("Namespace: " + v.namespace))
+	content.WriteString("\n")
+
+	switch {
+	case v.err != nil:
+		content.WriteString("\n")
+		content.WriteString(missingStyle.Render("✗ " + v.err.Error()))
+		content.WriteString("\n")
+	case len(v.related) == 0:
+		content.WriteString("\n")
+		content.WriteString(labelStyle.Render("No related resources among the cached objects."))
+		content.WriteString("\n")
+	}
+
+	// Keep the selection on screen when the list is taller than the panel
+	start, end := 0, len(v.related)
+	if maxItems := v.height - 14; maxItems > 3 && len(v.related) > maxItems {
+		start = v.selected - maxItems/2
+		if start < 0 {
+			start = 0
+		}
+		if start+maxItems > len(v.related) {
+			start = len(v.related) - maxItems
+		}
+		end = start + maxItems
+	}
+
+	var relation core.Relation
+	for i := start; i < end; i++ {
+		r := v.related[i]
+		if i == start || r.Relation != relation {
+			relation = r.Relation
+			content.WriteString("\n")
+			content.WriteString(sectionStyle.Render(string(relation)))
+			content.WriteString("\n")
+		}
+
+		// Always name the namespace: a neighbour may live in another one, and
+		// the same name may exist in several
+		line := r.String()
+		if r.Namespace != "" {
+			line += "  -n " + r.Namespace
+		}
+		var note string
+		switch {
+		case r.Missing:
+			note = missingStyle.Render("  missing")
+		case r.Type == "":
+			note = labelStyle.Render("  no list")
+		}
+		if r.Problem != "" {
+			note += missingStyle.Render("  " + r.Problem)
+		}
+		if i == v.selected {
+			content.WriteString(selectedStyle.Render("> "+line) + note)
+		} else if r.Missing {
+			content.WriteString("  " + missingStyle.Render(line) + note)
+		} else {
+			content.WriteString("  " + line + note)
+		}
+		content.WriteString("\n")
+	}
+
+	if v.status != "" {
+		content.WriteString("\n")
+		content.WriteString(statusStyle(v.status).Render(v.status))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render("[↑/↓] Select  [Enter] Jump to resource  [Esc/x] Close"))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// SetSize updates the view size
+func (v *RelationsView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// RelatedResourceSelectedMsg is sent when the user picks a neighbour to jump to
+type RelatedResourceSelectedMsg struct {
+	Resource core.RelatedResource
+}
@@ -0,0 +1,67 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRelationsView(t *testing.T) {
+	related := []core.RelatedResource{
+		{Relation: core.RelationOwners, Kind: "Deployment", Type: core.ResourceTypeDeployment, Namespace: "default", Name: "web"},
+		{Relation: core.RelationConfigMap, Kind: "ConfigMap", Type: core.ResourceTypeConfigMap, Namespace: "default", Name: "flags", Missing: true},
+		{Relation: core.RelationPVCs, Kind: "PersistentVolumeClaim", Namespace: "default", Name: "data"},
+	}
+	view := NewRelationsView("Pods", "web-1", "default", related, nil)
+	view.SetSize(100, 40)
+
+	output := view.View()
+	for _, want := range []string{"Relationships: pod/web-1", "Owners", "deployment/web  -n default", "ConfigMaps", "configmap/flags  -n default  missing", "persistentvolumeclaim/data  -n default  no list"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output:\n%s", want, output)
+		}
+	}
+
+	// Enter jumps to the highlighted resource
+	_, cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected enter to jump to the deployment")
+	}
+	if msg, ok := cmd().(RelatedResourceSelectedMsg); !ok || msg.Resource.Name != "web" {
+		t.Errorf("Expected a jump to deployment/web, got %v", cmd())
+	}
+
+	// Kinds without a list cannot be jumped to
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if r, _ := view.SelectedResource(); r.Name != "data" {
+		t.Fatalf("Expected the selection to stop at the last item, got %q", r.Name)
+	}
+	if _, cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("Expected no jump to a PersistentVolumeClaim")
+	}
+	if !strings.Contains(view.View(), "no list of PersistentVolumeClaims") {
+		t.Errorf("Expected a note explaining the refused jump, got:\n%s", view.View())
+	}
+}
+
+func TestResourceViewSelectAfterRefresh(t *testing.T) {
+	view := createTestResourceView(t)
+	view.SelectAfterRefresh(core.ResourceRef{Name: "db"})
+	view.SetTestData([]string{"NAME"}, [][]string{{"api"}, {"db"}, {"web"}})
+
+	view.applyPendingSelection()
+	if name := view.GetSelectedResourceName(); name != "db" {
+		t.Errorf("Expected db selected after the refresh, got %q", name)
+	}
+
+	// The request is used up by the refresh
+	view.SetSelectedRow(2)
+	view.applyPendingSelection()
+	if name := view.GetSelectedResourceName(); name != "web" {
+		t.Errorf("Expected the selection left alone by later refreshes, got %q", name)
+	}
+}
@@ -0,0 +1,106 @@
+package views
+
+import "hash/fnv"
+
+// maxStyledCellCacheEntries bounds the per-cell style cache; it is cleared
+// wholesale when exceeded since statuses and metrics repeat heavily anyway.
+const maxStyledCellCacheEntries = 4096
+
+// styledCellKey identifies a rendered cell by everything that affects its output
+type styledCellKey struct {
+	column      string
+	value       string
+	width       int
+	selected    bool
+	pull        pullSeverity // How badly a STATUS cell's pod waits on an image
+	terminating bool         // Whether a NAME cell's row is being deleted
+	ghost       bool         // Whether the cell's row is kept for a deleted pod
+}
+
+// tableRenderCache remembers the last rendered table frame and styled cells
+// so idle re-renders (key repeat, ticks without data changes) are cheap
+type tableRenderCache struct {
+	frameKey   uint64
+	frame      string
+	frameValid bool
+
+	cells map[styledCellKey]string
+}
+
+// newTableRenderCache creates an empty render cache
+func newTableRenderCache() *tableRenderCache {
+	return &tableRenderCache{
+		cells: make(map[styledCellKey]string),
+	}
+}
+
+// lookupFrame returns the cached frame if it was rendered with the same key
+func (c *tableRenderCache) lookupFrame(key uint64) (string, bool) {
+	if c.frameValid && c.frameKey == key {
+		return c.frame, true
+	}
+	return "", false
+}
+
+// storeFrame remembers the frame rendered for key
+func (c *tableRenderCache) storeFrame(key uint64, frame string) {
+	c.frameKey = key
+	c.frame = frame
+	c.frameValid = true
+}
+
+// styledCell returns a cached styled cell or renders and stores it
+func (c *tableRenderCache) styledCell(key styledCellKey, render func() string) string {
+	if s, ok := c.cells[key]; ok {
+		return s
+	}
+	if len(c.cells) >= maxStyledCellCacheEntries {
+		c.cells = make(map[styledCellKey]string)
+	}
+	s := render()
+	c.cells[key] = s
+	return s
+}
+
+const fnvPrime64 = 1099511628211
+
+// frameHasher builds a cheap FNV-1a hash over the inputs of a table frame
+type frameHasher struct {
+	sum uint64
+}
+
+func newFrameHasher() *frameHasher {
+	return &frameHasher{sum: fnv.New64a().Sum64()}
+}
+
+// writeString mixes s into the hash followed by a separator so that
+// ("ab","c") and ("a","bc") hash differently
+func (h *frameHasher) writeString(s string) {
+	for i := 0; i < len(s); i++ {
+		h.sum ^= uint64(s[i])
+		h.sum *= fnvPrime64
+	}
+	h.sum ^= 0xff
+	h.sum *= fnvPrime64
+}
+
+func (h *frameHasher) writeInt(n int) {
+	u := uint64(n)
+	for i := 0; i < 8; i++ {
+		h.sum ^= u & 0xff
+		h.sum *= fnvPrime64
+		u >>= 8
+	}
+}
+
+func (h *frameHasher) writeBool(b bool) {
+	if b {
+		h.writeString("1")
+	} else {
+		h.writeString("0")
+	}
+}
+
+func (h *frameHasher) Sum64() uint64 {
+	return h.sum
+}
@@ -0,0 +1,150 @@
+package views
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func createLargeTestResourceView(rowCount int) *ResourceView {
+	state := createTestState(core.ResourceTypePod, "default", "test-context")
+	rv := NewResourceView(state, nil)
+	rv.SetSize(200, 60)
+
+	statuses := []string{"Running", "Pending", "CrashLoopBackOff", "Completed"}
+	headers := []string{"NAME", "READY", "STATUS", "RESTARTS", "AGE"}
+	rows := make([][]string, rowCount)
+	for i := range rows {
+		rows[i] = []string{
+			fmt.Sprintf("pod-%04d", i),
+			"1/1",
+			statuses[i%len(statuses)],
+			fmt.Sprintf("%d", i%7),
+			"5m",
+		}
+	}
+	rv.SetTestData(headers, rows)
+	return rv
+}
+
+func TestRenderCacheReusesIdenticalFrames(t *testing.T) {
+	rv := createLargeTestResourceView(50)
+
+	first := rv.renderCustomTable()
+	key := rv.renderCache.frameKey
+	second := rv.renderCustomTable()
+
+	if first != second {
+		t.Error("identical inputs should produce identical frames")
+	}
+	if rv.renderCache.frameKey != key {
+		t.Error("frame key should not change when nothing changed")
+	}
+}
+
+func TestRenderCacheInvalidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(rv *ResourceView)
+	}{
+		{
+			name:   "selection moves down",
+			mutate: func(rv *ResourceView) { rv.selectedRow++ },
+		},
+		{
+			name:   "row content changes",
+			mutate: func(rv *ResourceView) { rv.table.SetCell(0, 2, "Terminating") },
+		},
+		{
+			name: "width changes",
+			mutate: func(rv *ResourceView) {
+				rv.SetSize(rv.width-10, rv.height)
+			},
+		},
+		{
+			name:   "word wrap toggles",
+			mutate: func(rv *ResourceView) { rv.wordWrap = !rv.wordWrap },
+		},
+		{
+			name:   "sort direction changes",
+			mutate: func(rv *ResourceView) { rv.state.SetSortState("NAME", false) },
+		},
+		{
+			name:   "viewport scrolls",
+			mutate: func(rv *ResourceView) { rv.selectedRow = 40 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rv := createLargeTestResourceView(50)
+			rv.renderCustomTable()
+			before := rv.renderCache.frameKey
+
+			tt.mutate(rv)
+			rv.renderCustomTable()
+
+			if rv.renderCache.frameKey == before {
+				t.Error("expected frame key to change after mutation")
+			}
+		})
+	}
+}
+
+func TestRenderCacheSelectionAlwaysRerenders(t *testing.T) {
+	rv := createLargeTestResourceView(10)
+	rv.renderCustomTable()
+
+	for i := 0; i < 5; i++ {
+		before := rv.renderCache.frameKey
+		_, _ = rv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+		cached := rv.renderCustomTable()
+
+		if rv.renderCache.frameKey == before {
+			t.Fatalf("frame key unchanged after moving selection to row %d", rv.selectedRow)
+		}
+
+		// A fresh render must match what the cache handed back
+		endRow := rv.viewportStart + rv.viewportHeight
+		if endRow > rv.table.GetRowCount() {
+			endRow = rv.table.GetRowCount()
+		}
+		uncached := rv.renderTableFrame(endRow)
+		if cached != uncached {
+			t.Fatalf("stale frame returned after moving selection to row %d", rv.selectedRow)
+		}
+	}
+}
+
+func TestStyledCellCacheIsBounded(t *testing.T) {
+	cache := newTableRenderCache()
+	for i := 0; i < maxStyledCellCacheEntries+10; i++ {
+		key := styledCellKey{column: "NAME", value: fmt.Sprintf("v%d", i), width: 10}
+		cache.styledCell(key, func() string { return key.value })
+	}
+	if len(cache.cells) > maxStyledCellCacheEntries {
+		t.Errorf("cell cache grew to %d entries, limit is %d", len(cache.cells), maxStyledCellCacheEntries)
+	}
+}
+
+func BenchmarkRenderCustomTableIdle(b *testing.B) {
+	rv := createLargeTestResourceView(500)
+	rv.renderCustomTable()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rv.renderCustomTable()
+	}
+}
+
+func BenchmarkRenderCustomTableUncached(b *testing.B) {
+	rv := createLargeTestResourceView(500)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rv.renderCache = nil
+		rv.renderCustomTable()
+	}
+}
@@ -0,0 +1,133 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ResourcePickerView asks which resource a bare name meant when it matches
+// several, e.g. the same pod name in several namespaces
+type ResourcePickerView struct {
+	ref      core.ResourceRef
+	matches  []core.ResourceRef
+	selected int
+
+	width  int
+	height int
+}
+
+// NewResourcePickerView creates a picker over the resources ref matched
+func NewResourcePickerView(ref core.ResourceRef, matches []core.ResourceRef) *ResourcePickerView {
+	return &ResourcePickerView{
+		ref:     ref,
+		matches: matches,
+	}
+}
+
+// Init initializes the view
+func (v *ResourcePickerView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages. Esc is handled by the picker mode.
+func (v *ResourcePickerView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if v.selected > 0 {
+				v.selected--
+			}
+		case "down", "j":
+			if v.selected < len(v.matches)-1 {
+				v.selected++
+			}
+		case "enter":
+			if ref, ok := v.SelectedResource(); ok {
+				return v, func() tea.Msg { return ResourcePickedMsg{Ref: ref} }
+			}
+		}
+	}
+	return v, nil
+}
+
+// SelectedResource returns the highlighted resource
+func (v *ResourcePickerView) SelectedResource() (core.ResourceRef, bool) {
+	if v.selected < 0 || v.selected >= len(v.matches) {
+		return core.ResourceRef{}, false
+	}
+	return v.matches[v.selected], true
+}
+
+// View renders the picker
+func (v *ResourcePickerView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(true)
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(fmt.Sprintf("%d resources are named %q", len(v.matches), v.ref.Name)))
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render("Pick the one you meant:"))
+	content.WriteString("\n\n")
+
+	for i, match := range v.matches {
+		line := match.String()
+		if match.Context != "" {
+			line += "  (" + match.Context + ")"
+		}
+		if i == v.selected {
+			content.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render("[↑/↓] Select  [Enter] Select resource  [Esc] Cancel"))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// SetSize updates the view size
+func (v *ResourcePickerView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// ResourcePickedMsg is sent when the user picks one of several resources
+// sharing a name
+type ResourcePickedMsg struct {
+	Ref core.ResourceRef
+}
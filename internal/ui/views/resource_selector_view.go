@@ -22,10 +22,18 @@ func NewResourceSelectorView() *ResourceSelectorView {
 		{Label: "Pods", Value: core.ResourceTypePod},
 		{Label: "Deployments", Value: core.ResourceTypeDeployment},
 		{Label: "StatefulSets", Value: core.ResourceTypeStatefulSet},
+		{Label: "Jobs", Value: core.ResourceTypeJob},
+		{Label: "CronJobs", Value: core.ResourceTypeCronJob},
 		{Label: "Services", Value: core.ResourceTypeService},
 		{Label: "Ingresses", Value: core.ResourceTypeIngress},
 		{Label: "ConfigMaps", Value: core.ResourceTypeConfigMap},
 		{Label: "Secrets", Value: core.ResourceTypeSecret},
+		{Label: "ServiceAccounts", Value: core.ResourceTypeServiceAccount},
+		{Label: "Roles", Value: core.ResourceTypeRole},
+		{Label: "ClusterRoles", Value: core.ResourceTypeClusterRole},
+		{Label: "RoleBindings", Value: core.ResourceTypeRoleBinding},
+		{Label: "ClusterRoleBindings", Value: core.ResourceTypeClusterRoleBinding},
+		{Label: "Nodes", Value: core.ResourceTypeNode},
 	}
 
 	// Calculate optimal width based on content
@@ -60,6 +68,15 @@ func (v *ResourceSelectorView) SetSize(width, height int) {
 	// Keep the dropdown at its optimal size - don't resize it
 }
 
+// SetResourceTypes sets the resource types offered, in display order
+func (v *ResourceSelectorView) SetResourceTypes(types []core.ResourceType) {
+	options := make([]dropdown.Option, len(types))
+	for i, t := range types {
+		options[i] = dropdown.Option{Label: string(t), Value: t}
+	}
+	v.dropdown.SetOptions(options)
+}
+
 // SetCurrentResourceType sets the currently selected resource type
 func (v *ResourceSelectorView) SetCurrentResourceType(resourceType core.ResourceType) {
 	v.dropdown.SetSelectedValue(resourceType)
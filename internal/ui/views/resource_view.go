@@ -1,8 +1,11 @@
 package views
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -22,22 +25,167 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// clockSkewWarningDuration is how long the clock skew warning stays in the header
+	clockSkewWarningDuration = 30 * time.Second
+
+	// noticeDuration is how long other notices stay in the header
+	noticeDuration = 5 * time.Second
+
+	// errorNoticeDuration is how long a failed action stays in the header
+	errorNoticeDuration = 10 * time.Second
+
+	// defaultStaleAfterIntervals is how many refresh intervals may pass
+	// without a successful update before the data is marked stale
+	defaultStaleAfterIntervals = 3
+
+	// maxRetryBackoff bounds how long failed refreshes back off between retries
+	maxRetryBackoff = time.Minute
 )
 
 // ResourceView displays a list of Kubernetes resources
 type ResourceView struct {
-	mu               sync.RWMutex // Protects concurrent access to all fields
+	// mu guards the table data (headers, rows, columnWidths, resourceMap,
+	// selection, viewport, metrics and render cache). Refresh commands run on
+	// background goroutines, so every method that touches that data either
+	// takes mu itself (exported entry points: Update, View, setters, getters,
+	// and the updateTableWith* family) or documents that its caller holds it.
+	mu               sync.RWMutex
 	state            *core.State
 	k8sClient        *k8s.Client
 	width            int
 	height           int
 	wordWrap         bool
 	showMetrics      bool
-	podMetrics       map[string]*k8s.PodMetrics
+	podMetrics       map[string]k8s.PodMetricsSet // By context; "" in single-context mode
+	nodeMetrics      map[string]*k8s.NodeMetrics  // By node name; nil without the metrics API
+	metricsHeadline  k8s.MetricsHeadline          // What the CPU/MEMORY cells of a pod show
+	sidecars         []string                     // Containers k8s.HeadlineMain leaves out
 	horizontalOffset int
 	lastRefresh      time.Time
 	compactMode      bool // For split view with logs
 
+	// Runtime-tunable limits (see core.Settings)
+	maxResources         int           // 0 means unlimited
+	metricsInterval      time.Duration // 0 fetches metrics on every refresh
+	metricsPaused        bool          // Keep the last metrics, as while unfocused
+	lastMetricsFetch     time.Time
+	lastMetricsNamespace string
+	lastRefreshRequested time.Time
+	clock                func() time.Time // Tells ages and the header the time; nil for the wall clock
+
+	// List filter, parsed from state.FilterString when it changes
+	filter        *core.Filter
+	filterHidden  int      // Rows dropped by the filter on the last update
+	filterSkipped []string // Columns a global filter names that this list lacks, so it is not applied
+	truncatedOf   int      // Rows left by the filter when maxResources cut them, 0 when not cut
+	unlistedPods  int      // Pods the last list stopped short of reading, -1 when it did not learn how many; see podListLimit
+
+	// The last update's rows before the filter, so the filter bar's
+	// expression can be previewed against them as it is typed. The
+	// incremental filter over them is made on the first preview.
+	unfiltered     [][]string
+	unfilteredMap  map[int]*selection.ResourceIdentity
+	previewFilter  *core.Filter
+	previewMatcher *core.IncrementalFilter
+
+	// The workload whose pods the pod list is narrowed to, in a linked split
+	podScope *core.PodScope
+
+	// Noise, such as completed pods, hidden while hideNoise is on. The last
+	// update counts what it hid by reason, and notes every noisy resource it
+	// listed, hidden or not, by its row's reference.
+	noiseRules  core.NoiseRules
+	hideNoise   bool
+	noiseHidden map[string]int
+	noisy       map[core.ResourceRef]bool
+
+	// Clock skew between the cluster and this machine, detected from
+	// creation timestamps in the future
+	clockSkew        *core.ClockSkewDetector
+	correctClockSkew bool
+
+	// Transient notice shown on the line under the header
+	notice      string
+	noticeUntil time.Time
+
+	// Kubeconfig files skipped as broken, shown under the header when
+	// nothing else is; a newer release is shown after them
+	kubeconfigNotice string
+	updateNotice     string
+
+	// Says the namespace shown is being deleted or is gone; it outranks
+	// every other notice
+	namespaceBanner string
+
+	// Unready containers of pods with many of them, keyed by podDetailKey;
+	// the selected pod's are shown under the header
+	podDetails map[string]string
+	podRows    *podRowCache
+
+	// Pods with a container waiting on an image, keyed by podDetailKey, and
+	// the events read to tell how their pulls are going
+	pullPods  map[string]pullPod
+	podEvents *podEventCache
+
+	// The pods listed, tallied for the header; terminating pods count apart
+	podCounts core.PodCounts
+	// The pods listed in CrashLoopBackOff, for alerts
+	crashLooping []core.ResourceRef
+	alerts       *core.AlertWatcher
+
+	// The pods of the selected deployment, read for its replica math
+	deploymentPods *deploymentPodCache
+
+	// Why the last refresh failed, shown under the header until one succeeds,
+	// and when the next one is due. Failed refreshes back off: retryTicksLeft
+	// refresh ticks are skipped before the next automatic retry.
+	refreshErr      error
+	refreshInterval time.Duration
+	refreshFailures int
+	retryTicksLeft  int
+	nextRetry       time.Time
+
+	// Set when the type listed is not served by the cluster, so the empty
+	// list says why; in multi-context mode notServedIn says which contexts
+	// do not serve it
+	notServed   *k8s.NotServedError
+	notServedIn string
+
+	// Pod Security admission levels by namespace, fetched once per namespace
+	// for the header badge, and whether pods and deployments get a SECURITY
+	// column flagging violations in their specs
+	podSecurity  map[string]core.PodSecurityLabels
+	showSecurity bool
+
+	// Log rates of the visible pods when sampling is on (nil when off), and
+	// the container sampled for each pod, keyed by podKey
+	logRates      *LogRateSampler
+	logContainers map[string]string
+
+	// Data not updated for staleAfter refresh intervals is marked stale. In
+	// multi-context mode each context's last successful update is tracked.
+	staleAfter       int
+	contextRefreshed map[string]time.Time
+
+	// Recent table states, and the one being viewed while scrubbing
+	history *TableHistory
+	scrub   *scrubState
+
+	// READY and STATUS transitions seen between refreshes, shown under the
+	// table while on (nil when off), and the entry picked to jump to, -1
+	// when none is
+	activity         *core.ActivityFeed
+	activitySelected int
+
+	// Without colors (NO_COLOR), every state is told in text; the
+	// accessible mode also reads out the selected row under the table
+	noColor    bool
+	accessible bool
+
 	// Multi-context support
 	multiClient       *k8s.MultiContextClient
 	isMultiContext    bool
@@ -48,25 +196,58 @@ type ResourceView struct {
 	groupedResources map[string][]interface{} // uniq_key -> resources
 
 	// New refactored components
-	tableComponent      *table.Model
 	selectionTracker    *selection.Tracker
 	styleManager        *style.Manager
 	templateEngine      *template.Engine
 	transformerRegistry *transformers.Registry
 	config              *config.Config
-	useNewComponents    bool // Toggle for gradual rollout
 
-	// Legacy table data (will be phased out)
-	headers        []string
-	rows           [][]string
-	columnWidths   []int
+	// The list's columns and rows; the table component renders them
+	table *table.Model
+
+	// Selection and scroll position, handed to the table when it renders
 	selectedRow    int
 	viewportStart  int
 	viewportHeight int
 
+	// The full list's scroll position, put back when compact mode ends
+	fullViewportStart    int
+	fullHorizontalOffset int
+
 	// Selection tracking
 	selectedIdentity *selection.ResourceIdentity         // Track the actual selected resource
 	resourceMap      map[int]*selection.ResourceIdentity // Map row index to resource identity
+
+	// The place held while another mode has the keyboard, and a count of
+	// the selections made on purpose, which win over it
+	heldFocus      *heldFocus
+	selectionMoves uint64
+
+	// Render caching for idle re-renders
+	renderCache *tableRenderCache
+
+	// Namespace-qualified names listed in the other pane of a linked
+	// comparison; rows missing there are marked. Nil when not comparing.
+	compareNames map[string]bool
+
+	// Deletions of the listed type still followed, as of deletionsAt; their
+	// rows are marked. Nil when there are none.
+	deletions   map[core.ResourceRef]core.PendingDeletion
+	deletionsAt time.Time
+
+	// Pods created and deleted between updates of a pod list, and the rows
+	// of deleted pods kept, dimmed, for churnGrace while the pods churn
+	churn      churnTracker
+	churnGrace time.Duration
+	ghosts     map[core.ResourceRef]bool
+
+	// The identities of the rows in order before the last update, to find
+	// the nearest remaining one when the selected resource goes
+	priorRows []*selection.ResourceIdentity
+
+	// Resource to select once the next refresh lists it, e.g. after jumping
+	// to a related resource of another type
+	pendingSelect core.ResourceRef
 }
 
 // NewResourceView creates a new resource view
@@ -76,13 +257,28 @@ func NewResourceView(state *core.State, k8sClient *k8s.Client) *ResourceView {
 		k8sClient:         k8sClient,
 		wordWrap:          false,
 		showMetrics:       true,
-		podMetrics:        make(map[string]*k8s.PodMetrics),
+		podMetrics:        make(map[string]k8s.PodMetricsSet),
+		metricsHeadline:   k8s.HeadlinePod,
+		sidecars:          k8s.DefaultSidecars,
+		podRows:           newPodRowCache(),
+		podDetails:        make(map[string]string),
+		pullPods:          make(map[string]pullPod),
+		deploymentPods:    &deploymentPodCache{},
+		alerts:            core.NewAlertWatcher(),
+		podEvents:         newPodEventCache(),
+		logContainers:     make(map[string]string),
+		noiseRules:        core.DefaultNoiseRules(),
+		noiseHidden:       make(map[string]int),
+		noisy:             make(map[core.ResourceRef]bool),
 		selectedRow:       0,
 		isMultiContext:    false,
 		showContextColumn: false,
 		resourceMap:       make(map[int]*selection.ResourceIdentity),
 		enableGrouping:    true, // Enable grouping by default
 		groupedResources:  make(map[string][]interface{}),
+		clockSkew:         core.NewClockSkewDetector(),
+		history:           NewTableHistory(0),
+		table:             newResourceTable(),
 	}
 
 	// Initialize new refactored components
@@ -113,17 +309,6 @@ func (rv *ResourceView) initializeNewComponents() {
 		Theme:   "default",
 		Columns: make(map[string]*config.ColumnConfig),
 	}
-
-	// Initialize table component with basic columns
-	columns := []table.Column{
-		{Title: "NAME", Width: 20, Flex: true},
-		{Title: "STATUS", Width: 15},
-		{Title: "AGE", Width: 10},
-	}
-	rv.tableComponent = table.New(columns)
-
-	// Disable new components for now (they're not fully implemented)
-	rv.useNewComponents = false
 }
 
 // NewResourceViewWithMultiContext creates a new resource view with multi-context support
@@ -133,11 +318,26 @@ func NewResourceViewWithMultiContext(state *core.State, multiClient *k8s.MultiCo
 		multiClient:       multiClient,
 		wordWrap:          false,
 		showMetrics:       true,
-		podMetrics:        make(map[string]*k8s.PodMetrics),
+		podMetrics:        make(map[string]k8s.PodMetricsSet),
+		metricsHeadline:   k8s.HeadlinePod,
+		sidecars:          k8s.DefaultSidecars,
+		podRows:           newPodRowCache(),
+		podDetails:        make(map[string]string),
+		pullPods:          make(map[string]pullPod),
+		deploymentPods:    &deploymentPodCache{},
+		alerts:            core.NewAlertWatcher(),
+		podEvents:         newPodEventCache(),
+		logContainers:     make(map[string]string),
+		noiseRules:        core.DefaultNoiseRules(),
+		noiseHidden:       make(map[string]int),
+		noisy:             make(map[core.ResourceRef]bool),
 		selectedRow:       0,
 		isMultiContext:    true,
 		showContextColumn: true,
 		resourceMap:       make(map[int]*selection.ResourceIdentity),
+		clockSkew:         core.NewClockSkewDetector(),
+		history:           NewTableHistory(0),
+		table:             newResourceTable(),
 	}
 
 	// Set initial columns based on resource type
@@ -153,12 +353,35 @@ func (v *ResourceView) Init() tea.Cmd {
 
 // Update handles messages
 func (v *ResourceView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
 	switch msg := msg.(type) {
+	case errMsg:
+		v.setNotice("✗ "+k8s.UserMessage(msg.err), errorNoticeDuration)
+		return v, nil
+
+	case deploymentPodsMsg:
+		v.deploymentPods.Record(msg.key, msg.counts, msg.err, v.now())
+		return v, nil
+
+	case podEventsMsg:
+		// A failed read is tried again later; the pod's status still tells
+		// what it can
+		v.podEvents.Record(msg.key, msg.events, v.now())
+		return v, nil
+
+	case logRateSampledMsg:
+		if v.logRates != nil && v.logRates.Record(msg.key, msg.id, msg.sample, msg.err, v.now()) {
+			v.updateLogCell(msg.key)
+		}
+		return v, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "j", "down":
 			// Move down
-			if v.selectedRow < len(v.rows)-1 {
+			if v.selectedRow < v.table.GetRowCount()-1 {
 				v.selectedRow++
 				v.updateSelectedIdentity()
 			}
@@ -179,8 +402,8 @@ func (v *ResourceView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			v.updateSelectedIdentity()
 			return v, nil
 		case "end":
-			if len(v.rows) > 0 {
-				v.selectedRow = len(v.rows) - 1
+			if v.table.GetRowCount() > 0 {
+				v.selectedRow = v.table.GetRowCount() - 1
 				v.updateSelectedIdentity()
 			}
 			return v, nil
@@ -195,10 +418,10 @@ func (v *ResourceView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return v, nil
 		case "pgdown":
 			// Page down
-			if v.selectedRow < len(v.rows)-v.viewportHeight {
+			if v.selectedRow < v.table.GetRowCount()-v.viewportHeight {
 				v.selectedRow += v.viewportHeight
-			} else if len(v.rows) > 0 {
-				v.selectedRow = len(v.rows) - 1
+			} else if v.table.GetRowCount() > 0 {
+				v.selectedRow = v.table.GetRowCount() - 1
 			}
 			v.updateSelectedIdentity()
 			return v, nil
@@ -220,78 +443,98 @@ func (v *ResourceView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View renders the view
 func (v *ResourceView) View() string {
+	// Rendering clamps the selection and fills caches, so take the write lock
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.collectDeletions(v.now())
 	header := v.renderHeader()
 
-	// Use new table component if enabled and available
-	if v.useNewComponents && v.tableComponent != nil && v.config != nil {
-		tableView := v.renderWithNewComponents()
-		if tableView != "" {
-			return lipgloss.JoinVertical(lipgloss.Left, header, tableView)
-		}
+	// While scrubbing, render the historical table in place of the live one
+	if v.scrub != nil {
+		restore := v.swapInScrubSnapshot()
+		defer restore()
 	}
 
-	// Fall back to legacy custom renderer
 	tableView := v.renderCustomTable()
 	return lipgloss.JoinVertical(lipgloss.Left, header, tableView)
 }
 
-// renderWithNewComponents renders the table using the new refactored components
-func (v *ResourceView) renderWithNewComponents() string {
-	if v.tableComponent == nil {
-		return ""
-	}
-
-	// Set table size to match our dimensions
-	v.tableComponent.SetSize(v.width, v.height-6) // Account for header space
+// SetClock fixes the time the list shows ages and the refresh status
+// against, so that what it renders can be compared from one run to the
+// next. Nil goes back to the wall clock.
+func (v *ResourceView) SetClock(clock func() time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.clock = clock
+}
 
-	// Sync selection with new table component
-	if v.selectionTracker != nil {
-		selectedRow := v.selectionTracker.GetSelectedRow()
-		if selectedRow != v.selectedRow {
-			v.tableComponent.SetSelectedIndex(v.selectedRow)
-		}
+// now returns the time by the list's clock
+func (v *ResourceView) now() time.Time {
+	if v.clock != nil {
+		return v.clock()
 	}
-
-	// Render the new table component
-	return v.tableComponent.View()
+	return time.Now()
 }
 
 // SetSize updates the view size
 func (v *ResourceView) SetSize(width, height int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
 	v.width = width
 	v.height = height
+	v.setViewportHeight()
+}
+
+// setViewportHeight sets how many rows fit, fewer in compact mode. The
+// caller must hold v.mu.
+func (v *ResourceView) setViewportHeight() {
 	if v.compactMode {
-		// In compact mode, ensure selected item stays visible with minimal context
-		v.viewportHeight = height - 3 // Less space for header in compact mode
+		v.viewportHeight = v.height - 3 // Less space for header in compact mode
 	} else {
-		v.viewportHeight = height - 6 // Account for header and borders
+		v.viewportHeight = v.height - 6 // Account for header and borders
 	}
 }
 
-// SetCompactMode enables/disables compact mode for split view
+// SetCompactMode enables/disables compact mode for split view. Only the
+// number of rows shown changes: columns keep their widths and the list its
+// horizontal scroll, and leaving compact mode puts the list back where it
+// was scrolled. Rendering scrolls no further than needed to keep the
+// selected row in view.
 func (v *ResourceView) SetCompactMode(compact bool) {
-	v.compactMode = compact
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if compact == v.compactMode {
+		return
+	}
 	if compact {
-		// Adjust viewport to keep selected item visible
-		v.ensureSelectedVisible()
+		v.fullViewportStart, v.fullHorizontalOffset = v.viewportStart, v.horizontalOffset
+	} else {
+		v.viewportStart, v.horizontalOffset = v.fullViewportStart, v.fullHorizontalOffset
+	}
+	v.compactMode = compact
+	if v.height > 0 {
+		v.setViewportHeight()
 	}
 }
 
 // ensureSelectedVisible adjusts viewport to keep selected item in view
 func (v *ResourceView) ensureSelectedVisible() {
 	// First ensure selectedRow is within bounds
-	if v.selectedRow >= len(v.rows) && len(v.rows) > 0 {
-		v.selectedRow = len(v.rows) - 1
+	if v.selectedRow >= v.table.GetRowCount() && v.table.GetRowCount() > 0 {
+		v.selectedRow = v.table.GetRowCount() - 1
 	}
-	if v.selectedRow < 0 && len(v.rows) > 0 {
+	if v.selectedRow < 0 && v.table.GetRowCount() > 0 {
 		v.selectedRow = 0
 	}
 
 	// Ensure viewportStart is within bounds
-	if v.viewportStart >= len(v.rows) {
+	if v.viewportStart >= v.table.GetRowCount() {
 		v.viewportStart = 0
-		if len(v.rows) > v.viewportHeight {
-			v.viewportStart = len(v.rows) - v.viewportHeight
+		if v.table.GetRowCount() > v.viewportHeight {
+			v.viewportStart = v.table.GetRowCount() - v.viewportHeight
 		}
 	}
 	if v.viewportStart < 0 {
@@ -307,9 +550,9 @@ func (v *ResourceView) ensureSelectedVisible() {
 
 	// Ensure we show at least 3 items around selected if possible
 	contextRows := 3
-	if v.viewportHeight > contextRows*2 && len(v.rows) > 0 {
+	if v.viewportHeight > contextRows*2 && v.table.GetRowCount() > 0 {
 		idealStart := v.selectedRow - contextRows
-		if idealStart >= 0 && idealStart+v.viewportHeight <= len(v.rows) {
+		if idealStart >= 0 && idealStart+v.viewportHeight <= v.table.GetRowCount() {
 			v.viewportStart = idealStart
 		}
 	}
@@ -317,260 +560,1561 @@ func (v *ResourceView) ensureSelectedVisible() {
 
 // RefreshResources fetches and updates the resource list
 func (v *ResourceView) RefreshResources() tea.Cmd {
+	v.lastRefreshRequested = v.now()
+	v.dropStalePodScope()
+
+	v.mu.RLock()
+	client := v.k8sClient
+	v.mu.RUnlock()
+
+	// A list that finishes after the contexts or type are switched is
+	// dropped rather than shown over the new one's
+	generation := v.state.Generation()
+
 	return func() tea.Msg {
 		ctx := context.Background()
 
 		if v.isMultiContext && v.multiClient != nil {
-			return v.refreshMultiContextResources(ctx)
+			return v.refreshMultiContextResources(ctx, generation)
 		}
 
 		// Check if we have a valid client
-		if v.k8sClient == nil {
-			return errMsg{fmt.Errorf("no kubernetes client available")}
+		if client == nil {
+			return v.refreshFailed(fmt.Errorf("no kubernetes client available"))
 		}
 
-		switch v.state.CurrentResourceType {
-		case core.ResourceTypePod:
-			pods, err := v.k8sClient.ListPods(ctx, v.state.CurrentNamespace)
-			if err != nil {
-				return errMsg{err}
-			}
+		return v.refreshSingleContextResources(ctx, client, generation)
+	}
+}
 
-			// Try to get metrics (don't fail if not available)
-			metrics, _ := v.k8sClient.GetPodMetrics(ctx, v.state.CurrentNamespace)
-			v.podMetrics = metrics
+// loadNamespacePodSecurity fetches the Pod Security levels of the current
+// namespace the first time it is listed. A namespace that cannot be read is
+// remembered without levels rather than fetched again. Contexts may label
+// the same namespace differently, so multi-context mode shows no levels.
+func (v *ResourceView) loadNamespacePodSecurity(ctx context.Context, client *k8s.Client) {
+	namespace := v.state.CurrentNamespace
+	if namespace == "" || namespace == "all" || v.isMultiContext {
+		return
+	}
 
-			v.state.UpdatePods(pods)
-			v.updateTableWithPods(pods)
+	v.mu.RLock()
+	_, known := v.podSecurity[namespace]
+	v.mu.RUnlock()
+	if known {
+		return
+	}
 
-		case core.ResourceTypeDeployment:
-			deployments, err := v.k8sClient.ListDeployments(ctx, v.state.CurrentNamespace)
-			if err != nil {
-				return errMsg{err}
-			}
-			v.state.UpdateDeployments(deployments)
-			v.updateTableWithDeployments(deployments)
+	var labels core.PodSecurityLabels
+	if ns, err := client.GetNamespace(ctx, namespace); err == nil {
+		labels = core.NamespacePodSecurity(ns.Labels)
+	}
+	v.SetNamespacePodSecurity(namespace, labels)
+}
 
-		case core.ResourceTypeStatefulSet:
-			statefulsets, err := v.k8sClient.ListStatefulSets(ctx, v.state.CurrentNamespace)
-			if err != nil {
-				return errMsg{err}
-			}
-			v.state.UpdateStatefulSets(statefulsets)
-			v.updateTableWithStatefulSets(statefulsets)
+// SetNamespacePodSecurity records the Pod Security levels of a namespace
+func (v *ResourceView) SetNamespacePodSecurity(namespace string, labels core.PodSecurityLabels) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.podSecurity == nil {
+		v.podSecurity = make(map[string]core.PodSecurityLabels)
+	}
+	v.podSecurity[namespace] = labels
+}
 
-		case core.ResourceTypeService:
-			services, err := v.k8sClient.ListServices(ctx, v.state.CurrentNamespace)
-			if err != nil {
-				return errMsg{err}
-			}
-			v.state.UpdateServices(services)
-			v.updateTableWithServices(services)
+// ToggleSecurityColumn shows or hides the SECURITY column of pods and
+// deployments, returning whether it is now shown
+func (v *ResourceView) ToggleSecurityColumn() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.showSecurity = !v.showSecurity
+	return v.showSecurity
+}
 
-		case core.ResourceTypeIngress:
-			ingresses, err := v.k8sClient.ListIngresses(ctx, v.state.CurrentNamespace)
-			if err != nil {
-				return errMsg{err}
-			}
-			v.state.UpdateIngresses(ingresses)
-			v.updateTableWithIngresses(ingresses)
+// SetNoiseRules sets which resources count as noise
+func (v *ResourceView) SetNoiseRules(rules core.NoiseRules) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.noiseRules = rules
+}
 
-		case core.ResourceTypeConfigMap:
-			configmaps, err := v.k8sClient.ListConfigMaps(ctx, v.state.CurrentNamespace)
-			if err != nil {
-				return errMsg{err}
-			}
-			v.state.UpdateConfigMaps(configmaps)
-			v.updateTableWithConfigMaps(configmaps)
+// SetHideNoise sets whether the resources the noise rules match are hidden.
+// When the selected row is about to be hidden, the selection moves to the
+// nearest row that stays, where the next refresh finds it.
+func (v *ResourceView) SetHideNoise(hide bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.hideNoise == hide {
+		return
+	}
+	v.hideNoise = hide
+	if !hide || v.selectedRow < 0 || v.selectedRow >= v.table.GetRowCount() {
+		return
+	}
 
-		case core.ResourceTypeSecret:
-			secrets, err := v.k8sClient.ListSecrets(ctx, v.state.CurrentNamespace)
-			if err != nil {
-				return errMsg{err}
+	quiet := func(row int) bool {
+		return row >= 0 && row < v.table.GetRowCount() && !v.noisy[v.rowRef(v.table.RowValues(row))]
+	}
+	if quiet(v.selectedRow) {
+		return
+	}
+	for distance := 1; distance < v.table.GetRowCount(); distance++ {
+		for _, row := range []int{v.selectedRow + distance, v.selectedRow - distance} {
+			if quiet(row) {
+				v.selectedRow = row
+				v.selectedIdentity = v.resourceMap[row]
+				return
 			}
-			v.state.UpdateSecrets(secrets)
-			v.updateTableWithSecrets(secrets)
 		}
+	}
+}
 
-		// Update last refresh time
-		v.lastRefresh = time.Now()
+// hidesNoise notes a resource the noise rules match for reason, and reports
+// whether it is hidden; a reason of "" is not noise. The caller must hold
+// v.mu.
+func (v *ResourceView) hidesNoise(context, namespace, name, reason string) bool {
+	if reason == "" {
+		return false
+	}
+	if !v.showContextColumn {
+		context = ""
+	}
+	v.noisy[core.ResourceRef{Context: context, Namespace: namespace, Name: name}] = true
+	if !v.hideNoise {
+		return false
+	}
+	v.noiseHidden[reason]++
+	return true
+}
 
-		return refreshCompleteMsg{}
+// noiseHiddenCount returns how many resources the last update hid as noise.
+// The caller must hold v.mu.
+func (v *ResourceView) noiseHiddenCount() int {
+	total := 0
+	for _, n := range v.noiseHidden {
+		total += n
 	}
+	return total
 }
 
-// refreshMultiContextResources fetches resources from all active contexts
-func (v *ResourceView) refreshMultiContextResources(ctx context.Context) tea.Msg {
-	switch v.state.CurrentResourceType {
-	case core.ResourceTypePod:
-		podsWithContext, err := v.multiClient.ListPodsAllContexts(ctx, v.state.CurrentNamespace)
-		if err != nil {
-			return errMsg{err}
-		}
+// securityCell returns the SECURITY cell for the pod specs of one row
+func securityCell(specs ...*v1.PodSpec) string {
+	var findings []core.SecurityFinding
+	for _, spec := range specs {
+		findings = append(findings, core.CheckPodSecurity(spec)...)
+	}
+	return core.SecurityBadges(findings)
+}
 
-		// Update state with aggregated pods
-		var allPods []v1.Pod
-		for _, pwc := range podsWithContext {
-			allPods = append(allPods, pwc.Pod)
-			// Store pods by context
-			v.state.UpdatePodsByContext(pwc.Context, []v1.Pod{pwc.Pod})
-		}
+// refreshPodMetrics fetches pod metrics unless they were fetched for the
+// same namespace within the metrics interval
+func (v *ResourceView) refreshPodMetrics(ctx context.Context, client *k8s.Client) {
+	namespace := v.state.CurrentNamespace
 
-		v.state.UpdatePods(allPods)
-		v.updateTableWithPodsMultiContext(podsWithContext)
+	if v.metricsFresh(namespace) {
+		return
+	}
 
-	case core.ResourceTypeDeployment:
-		deploymentsWithContext, err := v.multiClient.ListDeploymentsAllContexts(ctx, v.state.CurrentNamespace)
-		if err != nil {
-			return errMsg{err}
-		}
+	metrics, _ := client.GetPodMetrics(ctx, namespace)
 
-		// Update state with aggregated deployments
-		var allDeployments []appsv1.Deployment
-		for _, dwc := range deploymentsWithContext {
-			allDeployments = append(allDeployments, dwc.Deployment)
-			v.state.UpdateDeploymentsByContext(dwc.Context, []appsv1.Deployment{dwc.Deployment})
-		}
+	v.mu.Lock()
+	v.podMetrics = map[string]k8s.PodMetricsSet{"": metrics}
+	v.lastMetricsFetch = v.now()
+	v.lastMetricsNamespace = namespace
+	v.mu.Unlock()
+}
 
-		v.state.UpdateDeployments(allDeployments)
-		v.updateTableWithDeploymentsMultiContext(deploymentsWithContext)
+// metricsFresh returns whether the metrics last fetched for a namespace can
+// be shown again rather than fetched
+func (v *ResourceView) metricsFresh(namespace string) bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if namespace != v.lastMetricsNamespace || v.lastMetricsFetch.IsZero() {
+		return false
+	}
+	return v.metricsPaused || (v.metricsInterval > 0 && time.Since(v.lastMetricsFetch) < v.metricsInterval)
+}
 
-	// Add other resource types as needed
-	default:
-		// For now, fall back to single context for unsupported resource types
-		if len(v.state.CurrentContexts) > 0 {
-			client, err := v.multiClient.GetClient(v.state.CurrentContexts[0])
-			if err != nil {
-				return errMsg{err}
-			}
-			v.k8sClient = client
-			return v.refreshSingleContextResources(ctx)
-		}
+// refreshMultiContextPodMetrics fetches pod metrics from every context, on
+// the same interval as refreshPodMetrics
+func (v *ResourceView) refreshMultiContextPodMetrics(ctx context.Context) {
+	namespace := v.state.CurrentNamespace
+
+	if v.metricsFresh(namespace) {
+		return
 	}
 
-	// Update last refresh time
-	v.lastRefresh = time.Now()
-	return refreshCompleteMsg{}
+	// Contexts without the metrics API are simply missing from the result
+	metrics, _ := v.multiClient.GetPodMetricsAllContexts(ctx, namespace)
+
+	v.mu.Lock()
+	v.podMetrics = metrics
+	v.lastMetricsFetch = v.now()
+	v.lastMetricsNamespace = namespace
+	v.mu.Unlock()
 }
 
-// refreshSingleContextResources is the original single-context refresh logic
-func (v *ResourceView) refreshSingleContextResources(ctx context.Context) tea.Msg {
-	switch v.state.CurrentResourceType {
-	case core.ResourceTypePod:
-		pods, err := v.k8sClient.ListPods(ctx, v.state.CurrentNamespace)
-		if err != nil {
-			return errMsg{err}
-		}
+// podMetricsFor returns the metrics of a pod in a context ("" in
+// single-context mode). The caller must hold v.mu.
+func (v *ResourceView) podMetricsFor(contextName, namespace, name string) (*k8s.PodMetrics, bool) {
+	return v.podMetrics[contextName].Get(namespace, name)
+}
 
-		// Try to get metrics (don't fail if not available)
-		metrics, _ := v.k8sClient.GetPodMetrics(ctx, v.state.CurrentNamespace)
-		v.podMetrics = metrics
+// Loaded reports whether a refresh has completed since the list was created
+func (v *ResourceView) Loaded() bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return !v.lastRefresh.IsZero()
+}
 
-		v.state.UpdatePods(pods)
-		v.updateTableWithPods(pods)
+// markRefreshed records the completion time of a refresh
+func (v *ResourceView) markRefreshed() {
+	v.markRefreshedExcept(nil, nil)
+}
 
-	case core.ResourceTypeDeployment:
-		deployments, err := v.k8sClient.ListDeployments(ctx, v.state.CurrentNamespace)
-		if err != nil {
-			return errMsg{err}
-		}
-		v.state.UpdateDeployments(deployments)
-		v.updateTableWithDeployments(deployments)
+// markRefreshedExcept records the completion time of a multi-context refresh
+// that could not reach the contexts in failed; err says why. Their data keeps
+// its last update time, so it goes stale while the others stay current.
+func (v *ResourceView) markRefreshedExcept(failed map[string]bool, err error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
 
-	case core.ResourceTypeStatefulSet:
-		statefulsets, err := v.k8sClient.ListStatefulSets(ctx, v.state.CurrentNamespace)
-		if err != nil {
-			return errMsg{err}
-		}
-		v.state.UpdateStatefulSets(statefulsets)
-		v.updateTableWithStatefulSets(statefulsets)
+	now := v.now()
+	v.lastRefresh = now
+	v.refreshErr = err
+	v.refreshFailures = 0
+	v.retryTicksLeft = 0
+	v.nextRetry = time.Time{}
 
-	case core.ResourceTypeService:
-		services, err := v.k8sClient.ListServices(ctx, v.state.CurrentNamespace)
-		if err != nil {
-			return errMsg{err}
+	if v.contextRefreshed == nil {
+		v.contextRefreshed = make(map[string]time.Time)
+	}
+	for _, name := range v.state.CurrentContexts {
+		if !failed[name] {
+			v.contextRefreshed[name] = now
 		}
-		v.state.UpdateServices(services)
-		v.updateTableWithServices(services)
+	}
+}
 
-	case core.ResourceTypeIngress:
-		ingresses, err := v.k8sClient.ListIngresses(ctx, v.state.CurrentNamespace)
-		if err != nil {
-			return errMsg{err}
-		}
-		v.state.UpdateIngresses(ingresses)
-		v.updateTableWithIngresses(ingresses)
+// refreshFailed records why a refresh failed so the header can explain it,
+// and backs off the automatic retries
+func (v *ResourceView) refreshFailed(err error) tea.Msg {
+	v.mu.Lock()
+	v.refreshErr = err
+	v.refreshFailures++
+	intervals := retryBackoffIntervals(v.refreshInterval, v.refreshFailures)
+	v.retryTicksLeft = intervals - 1
+	v.nextRetry = v.now().Add(time.Duration(intervals) * v.refreshInterval)
+	v.mu.Unlock()
+	return refreshFailedMsg{err}
+}
 
-	case core.ResourceTypeConfigMap:
-		configmaps, err := v.k8sClient.ListConfigMaps(ctx, v.state.CurrentNamespace)
-		if err != nil {
-			return errMsg{err}
-		}
-		v.state.UpdateConfigMaps(configmaps)
-		v.updateTableWithConfigMaps(configmaps)
+// listFailed is refreshFailed for a list started in generation, which is
+// ignored once the contexts or type have been switched: its failure says
+// nothing about what is listed now
+func (v *ResourceView) listFailed(generation uint64, err error) tea.Msg {
+	if v.state.Generation() != generation {
+		return nil
+	}
+	return v.refreshFailed(err)
+}
 
-	case core.ResourceTypeSecret:
-		secrets, err := v.k8sClient.ListSecrets(ctx, v.state.CurrentNamespace)
-		if err != nil {
-			return errMsg{err}
-		}
-		v.state.UpdateSecrets(secrets)
-		v.updateTableWithSecrets(secrets)
+// retryBackoffIntervals returns how many refresh intervals to wait before
+// retrying after failures failed refreshes in a row: one, doubling with each
+// further failure up to maxRetryBackoff
+func retryBackoffIntervals(interval time.Duration, failures int) int {
+	limit := 1
+	if interval > 0 {
+		limit = max(1, int(maxRetryBackoff/interval))
+	}
+	intervals := 1
+	for i := 1; i < failures && intervals < limit; i++ {
+		intervals *= 2
 	}
+	return min(intervals, limit)
+}
 
-	// Update last refresh time
-	v.lastRefresh = time.Now()
-	return refreshCompleteMsg{}
+// RetryDue is called on each refresh tick and reports whether the automatic
+// refresh should run. After failed refreshes it holds ticks back so retries
+// back off; a manual refresh is never held back.
+func (v *ResourceView) RetryDue() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.retryTicksLeft > 0 {
+		v.retryTicksLeft--
+		return false
+	}
+	return true
 }
 
-// GetSelectedResourceName returns the name of the currently selected resource
-func (v *ResourceView) GetSelectedResourceName() string {
-	if v.selectedRow >= 0 && v.selectedRow < len(v.rows) && len(v.rows) > 0 {
-		selectedRow := v.rows[v.selectedRow]
-		if v.isMultiContext && v.showContextColumn && len(selectedRow) >= 2 {
-			return selectedRow[1] // Second column is NAME in multi-context mode
-		} else if len(selectedRow) >= 1 {
-			return selectedRow[0] // First column is NAME in single context mode
-		}
+// failedContexts returns the contexts a multi-context list could not reach.
+// It returns false when the refresh failed as a whole: no context was
+// reached, or err does not say which failed.
+func (v *ResourceView) failedContexts(err error) (map[string]bool, bool) {
+	if err == nil {
+		return nil, true
 	}
-	return ""
+	names := k8s.FailedContexts(err)
+	if len(names) == 0 || len(names) >= len(v.state.CurrentContexts) {
+		return nil, false
+	}
+	failed := make(map[string]bool, len(names))
+	for _, name := range names {
+		failed[name] = true
+	}
+	return failed, true
 }
 
-// GetSelectedResourceContext returns the context of the currently selected resource (multi-context mode)
-func (v *ResourceView) GetSelectedResourceContext() string {
+// staleContext is a context whose data has not been updated for too long;
+// the name is empty in single-context mode
+type staleContext struct {
+	name    string
+	updated time.Time
+}
+
+// staleContexts returns the contexts whose data is stale, least recently
+// updated first. The caller must hold v.mu.
+func (v *ResourceView) staleContexts(now time.Time) []staleContext {
+	if v.refreshInterval <= 0 {
+		return nil
+	}
+	intervals := v.staleAfter
+	if intervals <= 0 {
+		intervals = defaultStaleAfterIntervals
+	}
+	threshold := time.Duration(intervals) * v.refreshInterval
+
+	var stale []staleContext
 	if !v.isMultiContext {
+		// Nothing is shown before the first successful refresh
+		if !v.lastRefresh.IsZero() && now.Sub(v.lastRefresh) > threshold {
+			stale = append(stale, staleContext{updated: v.lastRefresh})
+		}
+		return stale
+	}
+	for _, name := range v.state.CurrentContexts {
+		if updated, ok := v.contextRefreshed[name]; ok && now.Sub(updated) > threshold {
+			stale = append(stale, staleContext{name: name, updated: updated})
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].updated.Before(stale[j].updated) })
+	return stale
+}
+
+// renderStaleBanner warns that the table shows old data, or returns "" when
+// it is current. The caller must hold v.mu.
+func (v *ResourceView) renderStaleBanner() string {
+	if v.scrub != nil {
+		return ""
+	}
+	now := v.now()
+	stale := v.staleContexts(now)
+	if len(stale) == 0 {
 		return ""
 	}
 
-	if v.selectedIdentity != nil {
-		return v.selectedIdentity.Context
+	var banner string
+	if stale[0].name == "" {
+		banner = fmt.Sprintf("⚠ DATA STALE — last update %s ago, reconnecting…", core.FormatDuration(now.Sub(stale[0].updated)))
+	} else {
+		parts := make([]string, len(stale))
+		for i, c := range stale {
+			parts[i] = fmt.Sprintf("%s (last update %s ago)", c.name, core.FormatDuration(now.Sub(c.updated)))
+		}
+		banner = fmt.Sprintf("⚠ DATA STALE in %s — reconnecting…", strings.Join(parts, ", "))
 	}
 
-	// Fallback to old method if identity not available
-	if v.selectedRow >= 0 && v.selectedRow < len(v.rows) && len(v.rows) > 0 {
-		selectedRow := v.rows[v.selectedRow]
-		if len(selectedRow) >= 1 {
-			return selectedRow[0] // First column is CONTEXT in multi-context mode
+	style := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("231")).Background(lipgloss.Color("160"))
+	if v.width > 0 {
+		style = style.MaxWidth(v.width)
+	}
+	return style.Render(banner)
+}
+
+// checkClockSkew warns once when the cluster's clock runs ahead of ours,
+// and corrects displayed ages for it when enabled
+func (v *ResourceView) checkClockSkew() {
+	offset, detected := v.clockSkew.Observe(v.state.CurrentCreationTimestamps(), v.now())
+	if !detected {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	offset = offset.Round(time.Second)
+	if v.correctClockSkew {
+		core.SetAgeOffset(offset)
+		v.setNotice(fmt.Sprintf("⚠ Cluster clock is %s ahead of this machine; ages are corrected for it. Check NTP on both sides.", offset), clockSkewWarningDuration)
+	} else {
+		v.setNotice(fmt.Sprintf("⚠ Cluster clock is %s ahead of this machine, so ages may read 0s. Check NTP, or run with --correct-clock-skew.", offset), clockSkewWarningDuration)
+	}
+}
+
+// setNotice shows a notice under the header for duration; caller holds v.mu
+func (v *ResourceView) setNotice(notice string, duration time.Duration) {
+	v.notice = notice
+	v.noticeUntil = v.now().Add(duration)
+}
+
+// ShowError explains a failed action in the header for a few seconds
+func (v *ResourceView) ShowError(err error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.setNotice("✗ "+k8s.UserMessage(err), errorNoticeDuration)
+}
+
+// ShowNotice shows an informational notice under the header
+func (v *ResourceView) ShowNotice(notice string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.setNotice(notice, noticeDuration)
+}
+
+// SetUpdateNotice sets the line announcing a newer release; "" removes it
+func (v *ResourceView) SetUpdateNotice(notice string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.updateNotice = notice
+}
+
+// SetKubeconfigNotice sets the line naming kubeconfig files skipped as
+// broken; "" removes it
+func (v *ResourceView) SetKubeconfigNotice(notice string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.kubeconfigNotice = notice
+}
+
+// SetNamespaceBanner sets the warning that the namespace shown is being
+// deleted or is gone; "" removes it
+func (v *ResourceView) SetNamespaceBanner(banner string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.namespaceBanner = banner
+}
+
+// SetRefreshInterval sets how often resources are refreshed, which is when a
+// failed refresh will be retried, or 0 when they are refreshed on watch
+// events and r only
+func (v *ResourceView) SetRefreshInterval(interval time.Duration) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.refreshInterval = interval
+}
+
+// SetStaleAfter sets how many refresh intervals may pass without a
+// successful update before the data is marked stale; 0 uses the default
+func (v *ResourceView) SetStaleAfter(intervals int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.staleAfter = intervals
+}
+
+// SetClockSkewCorrection sets whether detected clock skew is added to displayed ages
+func (v *ResourceView) SetClockSkewCorrection(enabled bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.correctClockSkew = enabled
+}
+
+// RefreshedWithin reports whether a refresh was started within window
+func (v *ResourceView) RefreshedWithin(window time.Duration) bool {
+	return !v.lastRefreshRequested.IsZero() && time.Since(v.lastRefreshRequested) < window
+}
+
+// SetMaxResources limits the number of rows shown; 0 means unlimited
+func (v *ResourceView) SetMaxResources(max int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.maxResources = max
+}
+
+// podListLimit returns how many pods the list may stop after, or 0 to
+// list them all. The API server lists a namespace's pods by name, so when
+// the rows are sorted by name with nothing filtering, hiding or scoping
+// them, the rows maxResources keeps are the first pods listed and the rest
+// need not be read.
+func (v *ResourceView) podListLimit() int {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	namespace := v.state.GetCurrentNamespace()
+	sortColumn, ascending := v.state.GetSortState()
+	expression, _ := v.state.GetFilter()
+	switch {
+	case v.maxResources <= 0, namespace == "", namespace == "all":
+		return 0
+	case sortColumn != "" && sortColumn != "NAME", !ascending:
+		return 0
+	case expression != "", v.previewFilter != nil, v.hideNoise, v.podScope != nil:
+		return 0
+	}
+	return v.maxResources
+}
+
+// SetMetricsInterval sets the minimum time between pod metrics fetches
+func (v *ResourceView) SetMetricsInterval(interval time.Duration) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.metricsInterval = interval
+}
+
+// SetMetricsPaused stops fetching pod metrics, keeping the last ones shown,
+// or starts again. Metrics for a namespace not yet fetched are still fetched.
+func (v *ResourceView) SetMetricsPaused(paused bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.metricsPaused = paused
+}
+
+// SetMetricsHeadline sets what a pod's CPU and MEMORY cells show when its
+// metrics break usage down by container; see k8s.PodMetrics.Headline
+func (v *ResourceView) SetMetricsHeadline(mode k8s.MetricsHeadline, sidecars []string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.metricsHeadline = mode
+	v.sidecars = sidecars
+}
+
+// SetHistoryRetention sets how long table states are kept for scrubbing; 0 turns history off
+func (v *ResourceView) SetHistoryRetention(retention time.Duration) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.history.SetRetention(retention)
+}
+
+// historyKey identifies the table being shown, so each resource type and
+// namespace has its own history
+func (v *ResourceView) historyKey() string {
+	return fmt.Sprintf("%s/%s", v.state.CurrentResourceType, v.state.GetCurrentNamespace())
+}
+
+// recordHistory adds the current table to the history
+func (v *ResourceView) recordHistory() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.recordHistoryLocked()
+}
+
+// recordHistoryLocked adds the current table to the history; caller holds v.mu
+func (v *ResourceView) recordHistoryLocked() {
+	if !v.history.Enabled() || len(v.table.Columns()) == 0 {
+		return
+	}
+	snapshot := &TableSnapshot{
+		At:      v.now(),
+		Headers: v.table.Titles(),
+		Rows:    v.table.Values(),
+	}
+	v.history.Record(v.historyKey(), snapshot)
+}
+
+// scrubState is the position of the history scrubber
+type scrubState struct {
+	snapshots     []*TableSnapshot
+	index         int
+	selectedRow   int
+	viewportStart int
+}
+
+// StartScrub freezes the view on the latest recorded table state. It returns
+// false, and shows why, when there is no history to scrub through.
+func (v *ResourceView) StartScrub() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if !v.history.Enabled() {
+		v.setNotice("Table history is off; set Table history in Settings (,) to keep it", noticeDuration)
+		return false
+	}
+	snapshots := v.history.Snapshots(v.historyKey())
+	if len(snapshots) == 0 {
+		v.setNotice("No table history recorded for this view yet", noticeDuration)
+		return false
+	}
+
+	v.scrub = &scrubState{
+		snapshots:     snapshots,
+		index:         len(snapshots) - 1,
+		selectedRow:   v.selectedRow,
+		viewportStart: v.viewportStart,
+	}
+	return true
+}
+
+// StopScrub returns the view to the live table
+func (v *ResourceView) StopScrub() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.scrub = nil
+}
+
+// IsScrubbing returns true while a historical table state is shown
+func (v *ResourceView) IsScrubbing() bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.scrub != nil
+}
+
+// ScrubStep moves the scrubber by delta table states; negative is older
+func (v *ResourceView) ScrubStep(delta int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.scrub == nil {
+		return
+	}
+	v.scrub.index += delta
+	if v.scrub.index < 0 {
+		v.scrub.index = 0
+	}
+	if v.scrub.index >= len(v.scrub.snapshots) {
+		v.scrub.index = len(v.scrub.snapshots) - 1
+	}
+}
+
+// ScrubMoveSelection moves the selection within the historical table
+func (v *ResourceView) ScrubMoveSelection(delta int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.scrub == nil {
+		return
+	}
+	rows := len(v.scrub.snapshots[v.scrub.index].Rows)
+	v.scrub.selectedRow += delta
+	if v.scrub.selectedRow >= rows {
+		v.scrub.selectedRow = rows - 1
+	}
+	if v.scrub.selectedRow < 0 {
+		v.scrub.selectedRow = 0
+	}
+}
+
+// swapInScrubSnapshot puts the scrubbed table in place of the live one for
+// rendering and returns a function that puts the live table back. Caller holds v.mu.
+func (v *ResourceView) swapInScrubSnapshot() func() {
+	snapshot := v.scrub.snapshots[v.scrub.index]
+	headers, rows := v.table.Titles(), v.table.Values()
+	selectedRow, viewportStart := v.selectedRow, v.viewportStart
+
+	v.setHeaders(snapshot.Headers)
+	v.table.SetValues(snapshot.Rows)
+	v.selectedRow, v.viewportStart = v.scrub.selectedRow, v.scrub.viewportStart
+
+	return func() {
+		// Keep the clamped selection and scroll position for the next render
+		v.scrub.selectedRow, v.scrub.viewportStart = v.selectedRow, v.viewportStart
+		v.setHeaders(headers)
+		v.table.SetValues(rows)
+		v.selectedRow, v.viewportStart = selectedRow, viewportStart
+	}
+}
+
+// renderScrubStatus describes the table state being shown. Caller holds v.mu.
+func (v *ResourceView) renderScrubStatus() string {
+	snapshot := v.scrub.snapshots[v.scrub.index]
+	ago := time.Since(snapshot.At).Round(time.Second)
+
+	status := fmt.Sprintf("⏪ Viewing state as of %s, %s ago (%d/%d)",
+		core.FormatClock(snapshot.At), ago, v.scrub.index+1, len(v.scrub.snapshots))
+	hint := "  [←/→] Older/newer  [y] Copy  [d] Describe  [Esc] Back to live"
+
+	statusStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	return statusStyle.Render(status) + hintStyle.Render(hint)
+}
+
+// refreshMultiContextResources fetches resources from all active contexts
+func (v *ResourceView) refreshMultiContextResources(ctx context.Context, generation uint64) tea.Msg {
+	// Contexts that could not be reached when others were
+	var failed map[string]bool
+	var partialErr error
+	var ok bool
+
+	v.checkServedAllContexts()
+	switch v.state.CurrentResourceType {
+	case core.ResourceTypePod:
+		podsWithContext, err := v.multiClient.ListPodsAllContextsMatching(ctx, v.state.CurrentNamespace, v.state.ListLabelSelector(), v.podFieldSelector())
+		if failed, ok = v.failedContexts(err); !ok {
+			return v.listFailed(generation, err)
+		}
+		partialErr = err
+		v.refreshMultiContextPodMetrics(ctx)
+		if !v.applyMultiContextPods(generation, podsWithContext, failed) {
+			return nil
+		}
+
+	case core.ResourceTypeDeployment:
+		deploymentsWithContext, err := v.multiClient.ListDeploymentsAllContextsWithLabels(ctx, v.state.CurrentNamespace, v.state.ListLabelSelector())
+		if failed, ok = v.failedContexts(err); !ok {
+			return v.listFailed(generation, err)
+		}
+		partialErr = err
+		if !v.applyMultiContextDeployments(generation, deploymentsWithContext, failed) {
+			return nil
+		}
+
+	// Add other resource types as needed
+	default:
+		// For now, fall back to single context for unsupported resource types
+		if len(v.state.CurrentContexts) > 0 {
+			client, err := v.multiClient.GetClient(v.state.CurrentContexts[0])
+			if err != nil {
+				return v.listFailed(generation, err)
+			}
+			v.mu.Lock()
+			v.k8sClient = client
+			v.mu.Unlock()
+			return v.refreshSingleContextResources(ctx, client, generation)
+		}
+	}
+
+	v.checkClockSkew()
+	selected := v.refreshComplete()
+	v.recordHistory()
+	v.recordActivity()
+
+	// Update last refresh time
+	v.markRefreshedExcept(failed, partialErr)
+	return selected
+}
+
+// showNotServed empties the list of a type the cluster does not serve,
+// which renderTable explains with err
+func (v *ResourceView) showNotServed(err *k8s.NotServedError) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.notServed = err
+	v.table.SetValues(nil)
+	v.selectedRow = 0
+	v.viewportStart = 0
+}
+
+// checkServedAllContexts records which of the current contexts do not
+// serve the type listed, for the header
+func (v *ResourceView) checkServedAllContexts() {
+	var notServed *k8s.NotServedError
+	var contexts []string
+	for _, name := range v.state.CurrentContexts {
+		client, err := v.multiClient.GetClient(name)
+		if err != nil {
+			continue
+		}
+		if errors.As(client.CheckServed(v.state.CurrentResourceType), &notServed) {
+			contexts = append(contexts, name)
+		}
+	}
+	var notServedIn string
+	if len(contexts) > 0 {
+		notServedIn = fmt.Sprintf("%s %s is not served by %s", notServed.GroupVersion, notServed.Kind, strings.Join(contexts, ", "))
+	}
+	v.mu.Lock()
+	v.notServedIn = notServedIn
+	v.mu.Unlock()
+}
+
+// applyMultiContextPods stores the pods listed from every context in
+// generation, replacing what each context had. Contexts that could not be
+// reached keep their last pods, which the stale banner flags once they are
+// old. It returns false, storing nothing, when the list is out of date.
+func (v *ResourceView) applyMultiContextPods(generation uint64, podsWithContext []k8s.PodWithContext, failed map[string]bool) bool {
+	return v.state.ApplyList(generation, func() {
+		for _, name := range v.state.CurrentContexts {
+			if failed[name] {
+				for _, pod := range v.state.ContextPods(name) {
+					podsWithContext = append(podsWithContext, k8s.PodWithContext{Context: name, Pod: pod})
+				}
+			}
+		}
+		podsWithContext = upsertByContext(podsWithContext, func(p k8s.PodWithContext) string {
+			return contextObjectKey(p.Context, p.Pod.ObjectMeta)
+		})
+
+		// Update state with aggregated pods, and store them by context
+		var allPods []v1.Pod
+		podsByContext := make(map[string][]v1.Pod)
+		for _, pwc := range podsWithContext {
+			allPods = append(allPods, pwc.Pod)
+			podsByContext[pwc.Context] = append(podsByContext[pwc.Context], pwc.Pod)
+		}
+		for _, name := range v.state.CurrentContexts {
+			v.state.UpdatePodsByContext(name, podsByContext[name])
+		}
+
+		v.state.UpdatePods(allPods)
+		v.updateTableWithPodsMultiContext(podsWithContext)
+	})
+}
+
+// applyMultiContextDeployments is applyMultiContextPods for deployments
+func (v *ResourceView) applyMultiContextDeployments(generation uint64, deploymentsWithContext []k8s.DeploymentWithContext, failed map[string]bool) bool {
+	return v.state.ApplyList(generation, func() {
+		for _, name := range v.state.CurrentContexts {
+			if failed[name] {
+				for _, deployment := range v.state.ContextDeployments(name) {
+					deploymentsWithContext = append(deploymentsWithContext, k8s.DeploymentWithContext{Context: name, Deployment: deployment})
+				}
+			}
+		}
+		deploymentsWithContext = upsertByContext(deploymentsWithContext, func(d k8s.DeploymentWithContext) string {
+			return contextObjectKey(d.Context, d.Deployment.ObjectMeta)
+		})
+
+		// Update state with aggregated deployments, and store them by context
+		var allDeployments []appsv1.Deployment
+		deploymentsByContext := make(map[string][]appsv1.Deployment)
+		for _, dwc := range deploymentsWithContext {
+			allDeployments = append(allDeployments, dwc.Deployment)
+			deploymentsByContext[dwc.Context] = append(deploymentsByContext[dwc.Context], dwc.Deployment)
+		}
+		for _, name := range v.state.CurrentContexts {
+			v.state.UpdateDeploymentsByContext(name, deploymentsByContext[name])
+		}
+
+		v.state.UpdateDeployments(allDeployments)
+		v.updateTableWithDeploymentsMultiContext(deploymentsWithContext)
+	})
+}
+
+// contextObjectKey identifies an object across contexts: by its UID, or by
+// namespace and name for an object without one
+func contextObjectKey(contextName string, meta metav1.ObjectMeta) string {
+	if meta.UID != "" {
+		return contextName + "/" + string(meta.UID)
+	}
+	return contextName + "/" + meta.Namespace + "/" + meta.Name
+}
+
+// upsertByContext keeps one item per key, so an object listed twice, such
+// as by overlapping lists of the same context, is one row. A later item
+// replaces an earlier one in its place.
+func upsertByContext[T any](items []T, key func(T) string) []T {
+	index := make(map[string]int, len(items))
+	unique := items[:0:0]
+	for _, item := range items {
+		k := key(item)
+		if i, ok := index[k]; ok {
+			unique[i] = item
+			continue
+		}
+		index[k] = len(unique)
+		unique = append(unique, item)
+	}
+	return unique
+}
+
+// refreshSingleContextResources is the original single-context refresh logic
+func (v *ResourceView) refreshSingleContextResources(ctx context.Context, client *k8s.Client, generation uint64) tea.Msg {
+	v.loadNamespacePodSecurity(ctx, client)
+
+	// A type the cluster does not serve is not listed
+	var notServed *k8s.NotServedError
+	if errors.As(client.CheckServed(v.state.CurrentResourceType), &notServed) {
+		if !v.state.ApplyList(generation, func() { v.showNotServed(notServed) }) {
+			return nil
+		}
+		v.markRefreshed()
+		return v.refreshComplete()
+	}
+
+	// The list is stored by apply, once it is known to be up to date
+	var apply func()
+	switch v.state.CurrentResourceType {
+	case core.ResourceTypePod:
+		pods, unlisted, err := client.ListPodsUpTo(ctx, v.state.CurrentNamespace, v.state.ListLabelSelector(), v.podFieldSelector(), v.podListLimit())
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+
+		// Try to get metrics (don't fail if not available)
+		v.refreshPodMetrics(ctx, client)
+
+		apply = func() {
+			v.state.UpdatePods(pods)
+			v.mu.Lock()
+			v.unlistedPods = unlisted
+			v.mu.Unlock()
+			v.updateTableWithPods(pods)
+		}
+
+	case core.ResourceTypeDeployment:
+		deployments, err := client.ListDeploymentsWithLabels(ctx, v.state.CurrentNamespace, v.state.ListLabelSelector())
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateDeployments(deployments)
+			v.updateTableWithDeployments(deployments)
+		}
+
+	case core.ResourceTypeStatefulSet:
+		statefulsets, err := client.ListStatefulSetsWithLabels(ctx, v.state.CurrentNamespace, v.state.ListLabelSelector())
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateStatefulSets(statefulsets)
+			v.updateTableWithStatefulSets(statefulsets)
+		}
+
+	case core.ResourceTypeJob:
+		jobs, err := client.ListJobsWithLabels(ctx, v.state.CurrentNamespace, v.state.ListLabelSelector())
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateJobs(jobs)
+			v.updateTableWithJobs(jobs)
+		}
+
+	case core.ResourceTypeCronJob:
+		cronJobs, err := client.ListCronJobsWithLabels(ctx, v.state.CurrentNamespace, v.state.ListLabelSelector())
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateCronJobs(cronJobs)
+			v.updateTableWithCronJobs(cronJobs)
+		}
+
+	case core.ResourceTypeService:
+		services, err := client.ListServicesWithLabels(ctx, v.state.CurrentNamespace, v.state.ListLabelSelector())
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateServices(services)
+			v.updateTableWithServices(services)
+		}
+
+	case core.ResourceTypeIngress:
+		ingresses, err := client.ListIngresses(ctx, v.state.CurrentNamespace)
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateIngresses(ingresses)
+			v.updateTableWithIngresses(ingresses)
+		}
+
+	case core.ResourceTypeGateway:
+		gateways, err := client.ListGateways(ctx, v.state.CurrentNamespace)
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateGateways(gateways)
+			v.updateTableWithGateways(gateways)
+		}
+
+	case core.ResourceTypeHTTPRoute:
+		routes, err := client.ListHTTPRoutes(ctx, v.state.CurrentNamespace)
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateHTTPRoutes(routes)
+			v.updateTableWithHTTPRoutes(routes)
+		}
+
+	case core.ResourceTypeConfigMap:
+		configmaps, err := client.ListConfigMaps(ctx, v.state.CurrentNamespace)
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateConfigMaps(configmaps)
+			v.updateTableWithConfigMaps(configmaps)
+		}
+
+	case core.ResourceTypeSecret:
+		secrets, err := client.ListSecrets(ctx, v.state.CurrentNamespace)
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateSecrets(secrets)
+			v.updateTableWithSecrets(secrets)
+		}
+
+	case core.ResourceTypeServiceAccount:
+		serviceAccounts, err := client.ListServiceAccounts(ctx, v.state.CurrentNamespace)
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateServiceAccounts(serviceAccounts)
+			v.updateTableWithServiceAccounts(serviceAccounts)
+		}
+
+	case core.ResourceTypeRole:
+		roles, err := client.ListRoles(ctx, v.state.CurrentNamespace)
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateRoles(roles)
+			v.updateTableWithRoles(roles)
+		}
+
+	case core.ResourceTypeClusterRole:
+		roles, err := client.ListClusterRoles(ctx)
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateClusterRoles(roles)
+			v.updateTableWithClusterRoles(roles)
+		}
+
+	case core.ResourceTypeRoleBinding:
+		bindings, err := client.ListRoleBindings(ctx, v.state.CurrentNamespace)
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateRoleBindings(bindings)
+			v.updateTableWithRoleBindings(bindings)
+		}
+
+	case core.ResourceTypeClusterRoleBinding:
+		bindings, err := client.ListClusterRoleBindings(ctx)
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		apply = func() {
+			v.state.UpdateClusterRoleBindings(bindings)
+			v.updateTableWithClusterRoleBindings(bindings)
+		}
+
+	case core.ResourceTypeNode:
+		nodes, err := client.ListNodes(ctx)
+		if err != nil {
+			return v.listFailed(generation, err)
+		}
+		v.refreshNodeMetrics(ctx, client)
+		apply = func() {
+			v.state.UpdateNodes(nodes)
+			v.updateTableWithNodes(nodes)
+		}
+	}
+	if apply != nil && !v.state.ApplyList(generation, func() {
+		v.mu.Lock()
+		v.notServed = nil
+		v.mu.Unlock()
+		apply()
+	}) {
+		return nil
+	}
+
+	v.checkClockSkew()
+	selected := v.refreshComplete()
+	v.recordHistory()
+	v.recordActivity()
+
+	// Update last refresh time
+	v.markRefreshed()
+	return selected
+}
+
+// podFieldSelector returns the field selector pods are listed by: the one
+// given with --field-selector, and the list filter's terms the API server
+// can apply, so large namespaces are cut down before they are fetched. The
+// filter is still applied to the pods listed.
+func (v *ResourceView) podFieldSelector() string {
+	var selectors []string
+	for _, selector := range []string{v.state.PodFieldSelector(), v.filterFieldSelector()} {
+		if selector != "" {
+			selectors = append(selectors, selector)
+		}
+	}
+	return strings.Join(selectors, ",")
+}
+
+// filterFieldSelector returns a field selector for the list filter's terms
+// the API server can apply, or "" when it has none
+func (v *ResourceView) filterFieldSelector() string {
+	expression, _ := v.state.GetFilter()
+	filter, err := core.ParseFilter(expression)
+	if err != nil {
+		return ""
+	}
+	// A global filter naming columns pods lack does not apply to them
+	if v.state.IsFilterGlobal() && len(filter.UnknownColumns(v.Columns(core.ResourceTypePod, v.state.CurrentNamespace))) > 0 {
+		return ""
+	}
+	return filter.FieldSelector(core.ResourceTypePod)
+}
+
+// GetSelectedResourceName returns the name of the currently selected resource
+func (v *ResourceView) GetSelectedResourceName() string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() && v.table.GetRowCount() > 0 {
+		return v.rowName(v.table.RowValues(v.selectedRow))
+	}
+	return ""
+}
+
+// rowName returns the NAME cell of a row. The caller must hold v.mu.
+func (v *ResourceView) rowName(row []string) string {
+	if v.isMultiContext && v.showContextColumn && len(row) >= 2 {
+		return row[1] // Second column is NAME in multi-context mode
+	} else if len(row) >= 1 {
+		return row[0] // First column is NAME in single context mode
+	}
+	return ""
+}
+
+// rowRef returns the reference of a row: its name, with the namespace and
+// context from their columns or, for a list of one namespace, the state's
+// namespace. The caller must hold v.mu.
+func (v *ResourceView) rowRef(row []string) core.ResourceRef {
+	return v.rowRefUnder(v.table.Titles(), row)
+}
+
+// rowRefUnder is rowRef for a row shown under the given headers, such as
+// one of a recorded table state. The caller must hold v.mu.
+func (v *ResourceView) rowRefUnder(headers []string, row []string) core.ResourceRef {
+	ref := core.ResourceRef{Name: v.rowName(row)}
+	for i, header := range headers {
+		if i >= len(row) {
+			break
+		}
+		switch header {
+		case "NAMESPACE":
+			ref.Namespace = row[i]
+		case "CONTEXT":
+			ref.Context = row[i]
+		}
+	}
+	if ref.Namespace == "" && v.state.CurrentNamespace != "all" && !core.IsClusterScoped(v.state.CurrentResourceType) {
+		ref.Namespace = v.state.CurrentNamespace
+	}
+	return ref
+}
+
+// compareKey is what rows are compared by across the panes of a
+// comparison: the namespace-qualified name, as the panes show different
+// contexts. The caller must hold v.mu.
+func (v *ResourceView) compareKey(row []string) string {
+	ref := v.rowRef(row)
+	ref.Context = ""
+	return ref.String()
+}
+
+// ColumnOrder returns the list's columns in the order shown, and in their
+// usual order
+func (v *ResourceView) ColumnOrder() (shown, usual []string) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	usual = v.table.Titles()
+	return core.ApplyColumnOrder(usual, v.state.GetColumnOrder(v.state.CurrentResourceType)), usual
+}
+
+// ResourceNames returns the namespace-qualified names of the listed
+// resources, e.g. "prod/web"
+func (v *ResourceView) ResourceNames() map[string]bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	names := make(map[string]bool, v.table.GetRowCount())
+	for _, row := range v.table.Values() {
+		names[v.compareKey(row)] = true
+	}
+	return names
+}
+
+// MatchingResources returns the listed resources ref refers to, in list
+// order. A bare name may match one in each namespace.
+func (v *ResourceView) MatchingResources(ref core.ResourceRef) []core.ResourceRef {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	_, matches := v.findRows(ref)
+	return matches
+}
+
+// findRows returns the rows ref refers to and their references. The caller
+// must hold v.mu.
+func (v *ResourceView) findRows(ref core.ResourceRef) ([]int, []core.ResourceRef) {
+	var rows []int
+	var matches []core.ResourceRef
+	for i, row := range v.table.Values() {
+		rowRef := v.rowRef(row)
+		if ref.Matches(rowRef.Context, rowRef.Namespace, rowRef.Name) {
+			rows = append(rows, i)
+			matches = append(matches, rowRef)
+		}
+	}
+	return rows, matches
+}
+
+// SelectResource selects the row ref refers to. It returns false and leaves
+// the selection alone when no row, or more than one, matches.
+func (v *ResourceView) SelectResource(ref core.ResourceRef) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	rows, _ := v.findRows(ref)
+	if len(rows) != 1 {
+		return false
+	}
+	v.selectedRow = rows[0]
+	v.selectionMoves++
+	v.updateSelectedIdentity()
+	v.ensureSelectedVisible()
+	return true
+}
+
+// SelectFirstMatching selects the first listed resource match accepts, and
+// reports whether there was one
+func (v *ResourceView) SelectFirstMatching(match func(ref core.ResourceRef) bool) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for row := 0; row < v.table.GetRowCount(); row++ {
+		if match(v.rowRef(v.table.RowValues(row))) {
+			v.selectedRow = row
+			v.selectionMoves++
+			v.updateSelectedIdentity()
+			v.ensureSelectedVisible()
+			return true
+		}
+	}
+	return false
+}
+
+// SetPodScope narrows the pod list to the pods in scope, or lists every pod
+// when scope is nil. A new scope starts the selection at the top and shows
+// the pods last listed again right away, without waiting for a refresh.
+func (v *ResourceView) SetPodScope(scope *core.PodScope) {
+	v.mu.Lock()
+	if v.podScope.Equal(scope) {
+		v.mu.Unlock()
+		return
+	}
+	v.podScope = scope
+	v.selectedRow = 0
+	v.selectionMoves++
+	v.viewportStart = 0
+	v.selectedIdentity = nil
+	v.resourceMap = make(map[int]*selection.ResourceIdentity)
+	relist := v.state.CurrentResourceType == core.ResourceTypePod && !v.isMultiContext
+	v.mu.Unlock()
+
+	if relist {
+		v.updateTableWithPods(v.state.GetAggregatedPods())
+	}
+}
+
+// dropStalePodScope lists every pod again once the list has moved on from
+// the scope: to another resource type, or to a namespace other than the
+// scope's. The all-namespaces view keeps it.
+func (v *ResourceView) dropStalePodScope() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.podScope == nil {
+		return
+	}
+	namespace := v.state.GetCurrentNamespace()
+	if v.state.CurrentResourceType != core.ResourceTypePod ||
+		(namespace != "" && namespace != "all" && namespace != v.podScope.Namespace) {
+		v.podScope = nil
+	}
+}
+
+// PodScope returns the scope the pod list is narrowed to, or nil
+func (v *ResourceView) PodScope() *core.PodScope {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.podScope
+}
+
+// heldFocus is the list's place when another mode took the keyboard
+type heldFocus struct {
+	key              string
+	moves            uint64
+	identity         *selection.ResourceIdentity
+	selectedRow      int
+	viewportStart    int
+	horizontalOffset int
+}
+
+// HoldFocus notes the list's selection and scroll position as another mode
+// takes the keyboard. Refreshes meanwhile still update the rows.
+func (v *ResourceView) HoldFocus() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.heldFocus = &heldFocus{
+		key:              v.historyKey(),
+		moves:            v.selectionMoves,
+		identity:         v.selectedIdentity,
+		selectedRow:      v.selectedRow,
+		viewportStart:    v.viewportStart,
+		horizontalOffset: v.horizontalOffset,
+	}
+}
+
+// RestoreFocus puts back the selection and scroll position noted by
+// HoldFocus as the list gets the keyboard again. It leaves them alone when
+// the list now shows another type or namespace, a resource was selected on
+// purpose meanwhile, or the resource selected is no longer listed.
+func (v *ResourceView) RestoreFocus() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	held := v.heldFocus
+	v.heldFocus = nil
+	if held == nil || held.key != v.historyKey() || held.moves != v.selectionMoves {
+		return
+	}
+	row := held.selectedRow
+	if held.identity != nil && !sameResource(v.resourceMap[row], held.identity) {
+		if row = v.findResourceByIdentity(held.identity); row < 0 {
+			return
+		}
+	}
+	v.selectedRow = row
+	v.updateSelectedIdentity()
+	v.viewportStart, v.horizontalOffset = held.viewportStart, held.horizontalOffset
+	// Scroll only as far as the selection, moved by rows listed meanwhile,
+	// needs to stay in view; rendering clamps the rest
+	if v.selectedRow < v.viewportStart {
+		v.viewportStart = v.selectedRow
+	} else if v.viewportHeight > 0 && v.selectedRow >= v.viewportStart+v.viewportHeight {
+		v.viewportStart = v.selectedRow - v.viewportHeight + 1
+	}
+}
+
+// Position returns the selected row, and the first row and column the list
+// is scrolled to
+func (v *ResourceView) Position() (selectedRow, viewportStart, horizontalOffset int) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.selectedRow, v.viewportStart, v.horizontalOffset
+}
+
+// SelectAfterRefresh selects the resource ref refers to once a refresh
+// lists it
+func (v *ResourceView) SelectAfterRefresh(ref core.ResourceRef) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.pendingSelect = ref
+}
+
+// applyPendingSelection selects the resource requested by SelectAfterRefresh
+// if it is now listed. When the request matches several resources nothing
+// is selected and they are returned, for the user to pick one. The request
+// is dropped either way, so a later refresh does not move the selection.
+func (v *ResourceView) applyPendingSelection() []core.ResourceRef {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.pendingSelect.IsZero() {
+		return nil
+	}
+	rows, matches := v.findRows(v.pendingSelect)
+	v.pendingSelect = core.ResourceRef{}
+	if len(rows) > 1 {
+		return matches
+	}
+	if len(rows) == 1 {
+		v.selectedRow = rows[0]
+		v.selectionMoves++
+		v.updateSelectedIdentity()
+		v.ensureSelectedVisible()
+	}
+	return nil
+}
+
+// refreshComplete ends a refresh: it applies a pending selection, and asks
+// the user to pick when the selection is ambiguous
+func (v *ResourceView) refreshComplete() tea.Msg {
+	v.mu.RLock()
+	ref := v.pendingSelect
+	v.mu.RUnlock()
+	if matches := v.applyPendingSelection(); len(matches) > 0 {
+		return AmbiguousSelectionMsg{Ref: ref, Matches: matches}
+	}
+	return refreshCompleteMsg{}
+}
+
+// SetCompareNames marks rows whose name is not in names, for comparing with
+// another list. Nil turns the marks off.
+func (v *ResourceView) SetCompareNames(names map[string]bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.compareNames = names
+}
+
+// GetSelectedResourceContext returns the context of the currently selected resource (multi-context mode)
+func (v *ResourceView) GetSelectedResourceContext() string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if !v.isMultiContext {
+		return ""
+	}
+
+	if v.selectedIdentity != nil {
+		return v.selectedIdentity.Context
+	}
+
+	// Fallback to old method if identity not available
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() && v.table.GetRowCount() > 0 {
+		selectedRow := v.table.RowValues(v.selectedRow)
+		if len(selectedRow) >= 1 {
+			return selectedRow[0] // First column is CONTEXT in multi-context mode
+		}
+	}
+	return ""
+}
+
+// GetSelectedResourceColumn returns the selected row's value in the named
+// column, or "" when the list has no such column
+func (v *ResourceView) GetSelectedResourceColumn(column string) string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if v.selectedRow < 0 || v.selectedRow >= v.table.GetRowCount() {
+		return ""
+	}
+	row := v.table.RowValues(v.selectedRow)
+	for i, header := range v.table.Titles() {
+		if header == column && i < len(row) {
+			return row[i]
+		}
+	}
+	return ""
+}
+
+// SelectedResourceRef returns the reference of the selected resource,
+// qualified by its namespace and, across contexts, its context. While
+// scrubbing it is the row selected in the table state shown.
+func (v *ResourceView) SelectedResourceRef() core.ResourceRef {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if v.scrub != nil {
+		snapshot := v.scrub.snapshots[v.scrub.index]
+		if v.scrub.selectedRow < 0 || v.scrub.selectedRow >= len(snapshot.Rows) {
+			return core.ResourceRef{}
+		}
+		return v.rowRefUnder(snapshot.Headers, snapshot.Rows[v.scrub.selectedRow])
+	}
+
+	if v.selectedRow < 0 || v.selectedRow >= v.table.GetRowCount() {
+		return core.ResourceRef{}
+	}
+	return v.rowRef(v.table.RowValues(v.selectedRow))
+}
+
+// SelectedResourceUID returns the UID of the selected resource, or "" when
+// its row does not record one
+func (v *ResourceView) SelectedResourceUID() string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if identity, ok := v.resourceMap[v.selectedRow]; ok && identity != nil {
+		return identity.UID
+	}
+	return ""
+}
+
+// SelectedPodMetrics returns the metrics of the selected pod, or nil when
+// the list is not of pods or has none for it
+func (v *ResourceView) SelectedPodMetrics() *k8s.PodMetrics {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if v.state.CurrentResourceType != core.ResourceTypePod {
+		return nil
+	}
+	identity, ok := v.resourceMap[v.selectedRow]
+	if !ok || identity == nil {
+		return nil
+	}
+	metrics, _ := v.podMetricsFor(identity.Context, identity.Namespace, identity.Name)
+	return metrics
+}
+
+// GetSelectedResourceNamespace returns the namespace of the currently selected
+// resource, falling back to the current namespace when it is not tracked
+func (v *ResourceView) GetSelectedResourceNamespace() string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if v.selectedIdentity != nil && v.selectedIdentity.Namespace != "" {
+		return v.selectedIdentity.Namespace
+	}
+	if identity, exists := v.resourceMap[v.selectedRow]; exists && identity != nil && identity.Namespace != "" {
+		return identity.Namespace
+	}
+	if core.IsClusterScoped(v.state.CurrentResourceType) {
+		return ""
+	}
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		if namespace := v.rowRef(v.table.RowValues(v.selectedRow)).Namespace; namespace != "" {
+			return namespace
 		}
 	}
-	return ""
+	return v.state.CurrentNamespace
 }
 
-// saveSelectedIdentity stores the identity of the currently selected resource
+// saveSelectedIdentity stores the identity of the currently selected
+// resource, and the rows around it to fall back on if it goes
 func (v *ResourceView) saveSelectedIdentity() {
-	if v.selectedRow >= 0 && v.selectedRow < len(v.rows) {
+	v.priorRows = v.priorRows[:0]
+	for i := range v.table.GetRowCount() {
+		v.priorRows = append(v.priorRows, v.resourceMap[i])
+	}
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
 		if identity, exists := v.resourceMap[v.selectedRow]; exists {
 			v.selectedIdentity = identity
 		}
 	}
 }
 
-// updateSelectedIdentity updates the selected identity when selection changes
-func (v *ResourceView) updateSelectedIdentity() {
-	v.mu.Lock()
-	defer v.mu.Unlock()
+// nearestRemaining returns the row of the resource that followed the
+// selected one before the last update and is still listed, or else of the
+// one that preceded it, as a deleted row's place is taken by the rows below
+// it; -1 when there is none. The caller must hold v.mu.
+func (v *ResourceView) nearestRemaining() int {
+	at := -1
+	for i, identity := range v.priorRows {
+		if sameResource(identity, v.selectedIdentity) {
+			at = i
+			break
+		}
+	}
+	if at < 0 {
+		return -1
+	}
+	rows := make(map[selection.ResourceIdentity]int, len(v.resourceMap))
+	for row, identity := range v.resourceMap {
+		if identity != nil {
+			rows[*identity] = row
+		}
+	}
+	for i := at + 1; i < len(v.priorRows); i++ {
+		if row, ok := v.remainingRow(rows, i); ok {
+			return row
+		}
+	}
+	for i := at - 1; i >= 0; i-- {
+		if row, ok := v.remainingRow(rows, i); ok {
+			return row
+		}
+	}
+	return -1
+}
 
-	if v.selectedRow >= 0 && v.selectedRow < len(v.rows) {
+// updateSelectedIdentity updates the selected identity when selection changes.
+// The caller must hold v.mu.
+func (v *ResourceView) updateSelectedIdentity() {
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
 		if identity, exists := v.resourceMap[v.selectedRow]; exists {
 			v.selectedIdentity = identity
 		}
@@ -584,17 +2128,28 @@ func (v *ResourceView) findResourceByIdentity(identity *selection.ResourceIdenti
 	}
 
 	for rowIndex, resourceIdentity := range v.resourceMap {
-		if resourceIdentity != nil &&
-			resourceIdentity.UID == identity.UID &&
-			resourceIdentity.Context == identity.Context &&
-			resourceIdentity.Namespace == identity.Namespace &&
-			resourceIdentity.Name == identity.Name {
+		if sameResource(resourceIdentity, identity) {
 			return rowIndex
 		}
 	}
 	return -1
 }
 
+// sameResource reports whether two identities are of the same resource
+func sameResource(a, b *selection.ResourceIdentity) bool {
+	return a != nil && b != nil &&
+		a.UID == b.UID && a.Context == b.Context && a.Namespace == b.Namespace && a.Name == b.Name
+}
+
+// remainingRow returns the row the resource of prior row i is listed in
+func (v *ResourceView) remainingRow(rows map[selection.ResourceIdentity]int, i int) (int, bool) {
+	if v.priorRows[i] == nil {
+		return 0, false
+	}
+	row, ok := rows[*v.priorRows[i]]
+	return row, ok
+}
+
 // restoreSelectionByIdentity attempts to restore the previously selected resource by identity
 func (v *ResourceView) restoreSelectionByIdentity() {
 	if v.selectedIdentity == nil {
@@ -627,7 +2182,14 @@ func (v *ResourceView) restoreSelectionByIdentity() {
 		}
 	}
 
-	// Resource not found by UID or name
+	// Resource not found by UID or name: stay on a resource that is still
+	// listed, the nearest to it, rather than on whatever took its index
+	if row := v.nearestRemaining(); row >= 0 {
+		v.selectedRow = row
+		v.selectedIdentity = v.resourceMap[row]
+		return
+	}
+
 	// Check if this looks like a complete refresh (all resources changed)
 	// by seeing if the selected resource name pattern has completely changed
 	allNewResources := true
@@ -648,17 +2210,17 @@ func (v *ResourceView) restoreSelectionByIdentity() {
 	}
 
 	// If resource not found, handle intelligently
-	if len(v.rows) > 0 {
+	if v.table.GetRowCount() > 0 {
 		if allNewResources {
 			// All resources appear to be new, reset to top
 			v.selectedRow = 0
-		} else if previousRow < len(v.rows) && previousRow >= 0 {
+		} else if previousRow < v.table.GetRowCount() && previousRow >= 0 {
 			// The previous index is still valid, stay there
 			// This handles the case where a single resource is deleted
 			v.selectedRow = previousRow
 		} else {
 			// Previous index out of bounds, select the last item
-			v.selectedRow = len(v.rows) - 1
+			v.selectedRow = v.table.GetRowCount() - 1
 		}
 
 		// Update selectedIdentity to match new selection
@@ -673,138 +2235,72 @@ func (v *ResourceView) restoreSelectionByIdentity() {
 	}
 }
 
-// DeleteSelected deletes the selected resource(s)
-func (v *ResourceView) DeleteSelected() tea.Cmd {
-	return func() tea.Msg {
-		ctx := context.Background()
-
-		// Check if we have a selected row
-		if v.selectedRow >= len(v.rows) || v.selectedRow < 0 {
-			return nil
-		}
-
-		selectedRow := v.rows[v.selectedRow]
-		if len(selectedRow) == 0 {
-			return nil
-		}
-
-		// Extract name and context based on multi-context mode
-		var name, contextName string
-		var client *k8s.Client
-
-		if v.isMultiContext && v.showContextColumn {
-			// In multi-context mode: [CONTEXT, NAME, ...]
-			if len(selectedRow) < 2 {
-				return nil
-			}
-			contextName = selectedRow[0]
-			name = selectedRow[1]
-
-			// Get the appropriate client for this context
-			var err error
-			client, err = v.multiClient.GetClient(contextName)
-			if err != nil {
-				return errMsg{err}
-			}
-		} else {
-			// Single context mode: [NAME, ...]
-			name = selectedRow[0]
-			client = v.k8sClient
-		}
+// DeleteRequestedMsg is sent when the API server accepted the delete of a
+// resource, which may take a while to go: see core.DeletionTracker
+type DeleteRequestedMsg struct {
+	Kind core.ResourceType
+	Ref  core.ResourceRef
+}
 
-		namespace := v.state.CurrentNamespace
+// renderCustomTable renders the table using lipgloss styling. The rendered
+// frame is cached and reused while none of its inputs have changed.
+func (v *ResourceView) renderCustomTable() string {
+	// A stale-data banner takes the first line of the table, and the
+	// activity feed its last lines
+	var above, below string
+	reserved := 0
+	if banner := v.renderStaleBanner(); banner != "" {
+		above = banner + "\n"
+		reserved++
+	}
+	if feed := v.renderActivityFeed(); feed != "" {
+		below = "\n" + feed
+		reserved += strings.Count(feed, "\n") + 1
+	}
+	if !v.accessible {
+		return above + v.renderTable(reserved) + below
+	}
+	// The readout follows the columns as the table orders them
+	table := v.renderTable(reserved + 1)
+	return above + table + below + "\n" + v.renderSelectionReadout()
+}
 
-		// Check if client is nil (for testing scenarios)
-		if client == nil {
-			// Return a delete command that simulates success for testing
-			return deleteCompleteMsg{name}
-		}
-
-		var err error
-		switch v.state.CurrentResourceType {
-		case core.ResourceTypePod:
-			err = client.DeletePod(ctx, namespace, name)
-		case core.ResourceTypeDeployment:
-			err = client.DeleteDeployment(ctx, namespace, name)
-		case core.ResourceTypeStatefulSet:
-			err = client.DeleteStatefulSet(ctx, namespace, name)
-		case core.ResourceTypeService:
-			err = client.DeleteService(ctx, namespace, name)
-		case core.ResourceTypeIngress:
-			err = client.DeleteIngress(ctx, namespace, name)
-		case core.ResourceTypeConfigMap:
-			err = client.DeleteConfigMap(ctx, namespace, name)
-		case core.ResourceTypeSecret:
-			err = client.DeleteSecret(ctx, namespace, name)
+// renderTable renders the table with reserved lines of its height taken by
+// other content. The caller must hold v.mu.
+func (v *ResourceView) renderTable(reserved int) string {
+	rowCount := v.table.GetRowCount()
+	if len(v.table.Columns()) == 0 || rowCount == 0 {
+		if v.notServed != nil && !v.isMultiContext {
+			return v.notServed.Error()
 		}
-
-		if err != nil {
-			return errMsg{err}
+		if v.state.CurrentResourceType == core.ResourceTypePod && v.state.PodFieldSelector() != "" {
+			return "No resources match field selector"
 		}
-
-		return deleteCompleteMsg{name}
-	}
-}
-
-// renderCustomTable renders the table using lipgloss styling
-func (v *ResourceView) renderCustomTable() string {
-	if len(v.headers) == 0 || len(v.rows) == 0 {
 		return "No resources found"
 	}
 
 	// Ensure selectedRow is within bounds
-	if v.selectedRow >= len(v.rows) {
-		v.selectedRow = len(v.rows) - 1
+	if v.selectedRow >= rowCount {
+		v.selectedRow = rowCount - 1
 	}
 	if v.selectedRow < 0 {
 		v.selectedRow = 0
 	}
 
-	// Ensure columnWidths is initialized and matches headers
-	if len(v.columnWidths) != len(v.headers) {
-		v.calculateColumnWidths()
-	}
-
-	// Render header
-	var headerCells []string
-	for i, header := range v.headers {
-		width := 15 // default width
-		if i < len(v.columnWidths) {
-			width = v.columnWidths[i]
-		}
-		cell := v.styleHeaderCell(header, width)
-		headerCells = append(headerCells, cell)
-	}
-	headerRow := strings.Join(headerCells, " ")
-
-	// Style the header with border
-	// Don't set a fixed width constraint that might truncate the header
-	headerStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("7")).
-		BorderBottom(true).
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240"))
-	styledHeader := headerStyle.Render(headerRow)
-
 	// Calculate viewport
 	if v.viewportHeight == 0 {
 		v.viewportHeight = v.height - 6 // Account for header and borders
 	}
-
-	// Ensure selected row is within bounds
-	if v.selectedRow >= len(v.rows) && len(v.rows) > 0 {
-		v.selectedRow = len(v.rows) - 1
-	}
-	if v.selectedRow < 0 && len(v.rows) > 0 {
-		v.selectedRow = 0
+	viewportHeight := v.viewportHeight - reserved
+	if viewportHeight < 1 {
+		viewportHeight = 1
 	}
 
 	// Ensure viewportStart is within bounds
-	if v.viewportStart >= len(v.rows) {
+	if v.viewportStart >= rowCount {
 		v.viewportStart = 0
-		if len(v.rows) > v.viewportHeight {
-			v.viewportStart = len(v.rows) - v.viewportHeight
+		if rowCount > viewportHeight {
+			v.viewportStart = rowCount - viewportHeight
 		}
 	}
 	if v.viewportStart < 0 {
@@ -814,97 +2310,278 @@ func (v *ResourceView) renderCustomTable() string {
 	// Ensure selected row is visible
 	if v.selectedRow < v.viewportStart {
 		v.viewportStart = v.selectedRow
-	} else if v.selectedRow >= v.viewportStart+v.viewportHeight {
-		v.viewportStart = v.selectedRow - v.viewportHeight + 1
+	} else if v.selectedRow >= v.viewportStart+viewportHeight {
+		v.viewportStart = v.selectedRow - viewportHeight + 1
+	}
+
+	endRow := v.viewportStart + viewportHeight
+	if endRow > rowCount {
+		endRow = rowCount
+	}
+
+	// Sample log rates of the rows now on screen, and stop sampling the
+	// rows scrolled away
+	if v.logRates != nil {
+		v.logRates.SetVisible(v.visibleLogTargets(viewportHeight))
+	}
+
+	v.configureColumns()
+	v.layoutTable(endRow)
+	cache := v.getRenderCache()
+	key := v.tableFrameKey(endRow)
+	if frame, ok := cache.lookupFrame(key); ok {
+		return frame
+	}
+
+	frame := v.renderTableFrame(endRow)
+	cache.storeFrame(key, frame)
+	return frame
+}
+
+// getRenderCache returns the render cache, creating it on first use
+func (v *ResourceView) getRenderCache() *tableRenderCache {
+	if v.renderCache == nil {
+		v.renderCache = newTableRenderCache()
 	}
+	return v.renderCache
+}
 
-	// Render visible rows
-	var renderedRows []string
-	endRow := v.viewportStart + v.viewportHeight
-	if endRow > len(v.rows) {
-		endRow = len(v.rows)
+// tableFrameKey hashes every input that affects the rendered table frame
+func (v *ResourceView) tableFrameKey(endRow int) uint64 {
+	h := newFrameHasher()
+	h.writeInt(v.width)
+	h.writeInt(v.viewportHeight)
+	h.writeInt(v.viewportStart)
+	h.writeInt(v.selectedRow)
+	h.writeInt(v.table.GetRowCount())
+	h.writeInt(v.horizontalOffset)
+	h.writeBool(v.compactMode)
+	h.writeBool(v.wordWrap)
+	h.writeBool(v.textOnly())
+
+	sortColumn, sortAscending := v.state.GetSortState()
+	h.writeString(sortColumn)
+	h.writeBool(sortAscending)
+	if v.config != nil {
+		h.writeString(v.config.Theme)
 	}
 
-	for i := v.viewportStart; i < endRow && i < len(v.rows); i++ {
-		if i < 0 || i >= len(v.rows) {
-			continue // Skip invalid indices
+	// Widths cover hidden columns and every row's content, not only the
+	// visible rows
+	widths := v.table.ColumnWidths()
+	for i, column := range v.table.Columns() {
+		h.writeString(column.Title)
+		if i < len(widths) {
+			h.writeInt(widths[i])
 		}
-		row := v.rows[i]
-		isSelected := i == v.selectedRow
-		var cells []string
+	}
+	for _, i := range v.table.ColumnOrder() {
+		h.writeInt(i)
+	}
+	h.writeBool(v.compareNames != nil)
+	h.writeBool(v.deletions != nil)
+	for i := v.viewportStart; i < endRow && i < v.table.GetRowCount(); i++ {
+		if i < 0 {
+			continue
+		}
+		row := v.table.RowValues(i)
+		h.writeInt(len(row))
+		for _, cell := range row {
+			h.writeString(cell)
+		}
+		if v.compareNames != nil {
+			h.writeBool(v.compareNames[v.compareKey(row)])
+		}
+		if d, ok := v.rowDeletion(row); ok {
+			h.writeInt(int(d.Phase(v.deletionsAt)) + 1)
+		}
+		h.writeBool(v.rowGhost(row))
+		h.writeInt(int(v.rowPullSeverity(row)))
+	}
+	return h.Sum64()
+}
 
-		for j, cell := range row {
-			if j < len(v.headers) {
-				width := 15 // default width
-				if j < len(v.columnWidths) {
-					width = v.columnWidths[j]
-				}
-				styledCell := v.styleCellByColumn(v.headers[j], cell, width, isSelected)
-				cells = append(cells, styledCell)
-			}
+// renderTableFrame renders the header and visible rows [viewportStart, endRow)
+// through the table component
+func (v *ResourceView) renderTableFrame(endRow int) string {
+	cache := v.getRenderCache()
+	v.table.SetCellStyler(func(values []string, column table.Column, value string, width int, selected bool) string {
+		key := styledCellKey{column: column.Title, value: value, width: width, selected: selected}
+		if column.Title == "STATUS" && !selected {
+			key.pull = v.rowPullSeverity(values)
+		}
+		if column.Title == "NAME" && !selected {
+			key.terminating = v.rowTerminating(values)
 		}
+		if !selected {
+			key.ghost = v.rowGhost(values)
+		}
+		return cache.styledCell(key, func() string {
+			if key.ghost {
+				return styleGhostCell(value, width)
+			}
+			if key.pull != pullFine {
+				return stylePullStatusCell(value, width, key.pull)
+			}
+			if key.terminating {
+				return styleTerminatingName(value, width)
+			}
+			return v.styleCellByColumn(column.Title, value, width, selected)
+		})
+	})
+
+	if v.compareNames != nil || v.deletions != nil {
+		// Mark rows missing from the other side of a comparison, and rows
+		// being deleted
+		v.table.SetRowMarker(2, func(values []string) string {
+			if v.compareNames != nil && !v.compareNames[v.compareKey(values)] {
+				return lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("≠ ")
+			}
+			if d, ok := v.rowDeletion(values); ok {
+				phase := d.Phase(v.deletionsAt)
+				return lipgloss.NewStyle().Foreground(deletionColor(phase)).Render(deletionMark(phase))
+			}
+			return "  "
+		})
+	} else {
+		v.table.SetRowMarker(0, nil)
+	}
+
+	v.table.SetSelectionMarker(v.textOnly())
+	v.layoutTable(endRow)
+	return v.table.View()
+}
 
-		rowStr := strings.Join(cells, " ")
-		renderedRows = append(renderedRows, rowStr)
+// layoutTable sizes and scrolls the table to show rows [viewportStart,
+// endRow). The caller must hold v.mu.
+func (v *ResourceView) layoutTable(endRow int) {
+	// The header, its rule outside compact mode, the rows, and a scroll
+	// indicator when not every row fits
+	rows := endRow - v.viewportStart
+	scrolled := v.viewportStart > 0 || endRow < v.table.GetRowCount()
+	height := rows + 1
+	if !v.compactMode {
+		height++
+	}
+	if scrolled {
+		height++
+	}
+	v.table.SetCompact(v.compactMode)
+	v.table.SetScrollIndicator(scrolled)
+	v.table.SetSize(v.width, height)
+	v.table.SetSelectedIndex(v.selectedRow)
+	v.table.SetViewportStart(v.viewportStart)
+	v.table.SetHorizontalOffset(v.horizontalOffset)
+	v.horizontalOffset = v.table.HorizontalOffset()
+}
+
+// newResourceTable creates the table component the list renders through
+func newResourceTable() *table.Model {
+	t := table.New(nil)
+	plain := lipgloss.NewStyle()
+	t.SetStyles(
+		lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("7")),
+		plain, plain, plain,
+		lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+	)
+	t.SetHeaderRule(true)
+	return t
+}
+
+// setHeaders sets the list's columns. The caller must hold v.mu.
+func (v *ResourceView) setHeaders(headers []string) {
+	columns := make([]table.Column, len(headers))
+	for i, header := range headers {
+		columns[i] = resourceColumn(header)
 	}
+	v.table.SetColumns(columns)
+}
 
-	// Join all rows
-	tableContent := strings.Join(renderedRows, "\n")
+// configureColumns applies word wrap, the chosen columns and their order to
+// the table. Columns are only replaced when they change, so column widths
+// are not recalculated on every render. The caller must hold v.mu.
+func (v *ResourceView) configureColumns() {
+	v.table.SetColumnOrder(core.OrderColumns(v.table.Titles(), v.state.GetColumnOrder(v.state.CurrentResourceType)))
 
-	// Add scroll indicators if needed
-	if v.viewportStart > 0 || endRow < len(v.rows) {
-		scrollInfo := fmt.Sprintf(" [%d-%d of %d]", v.viewportStart+1, endRow, len(v.rows))
-		scrollStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-		tableContent += "\n" + scrollStyle.Render(scrollInfo)
+	shown := v.shownColumns()
+	current := v.table.Columns()
+	columns := make([]table.Column, len(current))
+	for i, column := range current {
+		columns[i] = resourceColumn(column.Title)
+		columns[i].Hidden = shown != nil && !shown[i]
+		if v.wordWrap {
+			columns[i].MaxWidth = wrappedColumnWidth(column.Title)
+		}
+	}
+	if !slices.Equal(columns, current) {
+		v.table.SetColumns(columns)
 	}
+}
 
-	// Combine header and content
-	return lipgloss.JoinVertical(lipgloss.Left, styledHeader, tableContent)
+// resourceColumn returns the column for a header: sized to its content,
+// with numeric columns right-aligned
+func resourceColumn(header string) table.Column {
+	column := table.Column{Title: header, FitContent: true, MinWidth: 7}
+	switch header {
+	case "CPU", "MEMORY", "READY", "RESTARTS", "DATA", "UP-TO-DATE", "AVAILABLE":
+		column.Align = lipgloss.Right
+	}
+	return column
 }
 
-// styleHeaderCell styles a header cell
-func (v *ResourceView) styleHeaderCell(header string, width int) string {
-	style := lipgloss.NewStyle().Width(width).Bold(true)
+// wrappedColumnWidth returns how wide a column may grow while word wrap is
+// on; longer values are truncated
+func wrappedColumnWidth(header string) int {
+	switch header {
+	case "NAME":
+		return 25
+	case "STATUS":
+		return 20
+	case "READY", "RESTARTS", "AGE", "CPU", "MEMORY":
+		return 15
+	}
+	return 30
+}
 
-	// Right-align numeric columns
-	if header == "CPU" || header == "MEMORY" || header == "READY" ||
-		header == "RESTARTS" || header == "DATA" || header == "UP-TO-DATE" ||
-		header == "AVAILABLE" {
-		style = style.Align(lipgloss.Right)
+// shownColumns reports which headers the list shows, or nil when it shows
+// them all. NAME is always shown so rows can be told apart, and a table with
+// none of the chosen columns shows everything rather than names alone. The
+// caller must hold v.mu.
+func (v *ResourceView) shownColumns() []bool {
+	columns := v.state.GetColumns()
+	if len(columns) == 0 {
+		return nil
+	}
+	chosen := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		chosen[column] = true
 	}
 
-	return style.Render(header)
+	shown := make([]bool, len(v.table.Titles()))
+	matched := false
+	for i, header := range v.table.Titles() {
+		if chosen[header] {
+			shown[i] = true
+			matched = true
+		}
+		if header == "NAME" {
+			shown[i] = true
+		}
+	}
+	if !matched {
+		return nil
+	}
+	return shown
 }
 
 // styleCellByColumn applies appropriate styling based on column type
 func (v *ResourceView) styleCellByColumn(columnName, value string, width int, isSelected bool) string {
-	// Handle word wrap
 	displayValue := value
 	actualWidth := width
 
-	// Debug output for testing
-	if len(value) > 30 { // Only for long values
-		fmt.Printf("DEBUG styleCellByColumn: column=%s, wordWrap=%v, value_len=%d, width=%d\n",
-			columnName, v.wordWrap, len(value), width)
-	}
-
-	if v.wordWrap {
-		// When wrap is ON, truncate content to fit within column width
-		// (since we can't do multi-line wrapping in table cells)
-		if len(value) > width-2 && width > 5 {
-			displayValue = value[:width-5] + "..."
-			if len(value) > 30 {
-				fmt.Printf("DEBUG: Truncated to: %s\n", displayValue)
-			}
-		}
-	} else {
-		// When wrap is OFF, show full content by expanding column width
-		if len(value) > width {
-			actualWidth = len(value) + 2
-			if len(value) > 30 {
-				fmt.Printf("DEBUG: Expanded width to: %d\n", actualWidth)
-			}
-		}
+	// Kinds without a status column show a stuck deletion in AGE
+	if columnName == "AGE" && core.IsStuckTerminating(displayValue) {
+		return v.styleStatusCell(displayValue, actualWidth, isSelected)
 	}
 
 	switch columnName {
@@ -916,6 +2593,24 @@ func (v *ResourceView) styleCellByColumn(columnName, value string, width int, is
 		return v.styleMetricCell(displayValue, actualWidth, isSelected, false)
 	case "RESTARTS":
 		return v.styleRestartsCell(displayValue, actualWidth, isSelected)
+	case "LOG":
+		// Spikes and silences stand out
+		style := lipgloss.NewStyle().Width(actualWidth)
+		if isSelected {
+			style = style.Background(lipgloss.Color("57")).Foreground(lipgloss.Color("229"))
+		} else if core.IsLogRateAlert(displayValue) {
+			style = style.Foreground(lipgloss.Color("196")).Bold(true)
+		}
+		return style.Render(displayValue)
+	case "SECURITY":
+		// Flagged violations stand out; clean rows show "-"
+		style := lipgloss.NewStyle().Width(actualWidth)
+		if isSelected {
+			style = style.Background(lipgloss.Color("57")).Foreground(lipgloss.Color("229"))
+		} else if displayValue != "-" {
+			style = style.Foreground(lipgloss.Color("214"))
+		}
+		return style.Render(displayValue)
 	case "READY", "UP-TO-DATE", "AVAILABLE", "DATA":
 		// Right-align numeric columns
 		style := lipgloss.NewStyle().Width(actualWidth).Align(lipgloss.Right)
@@ -933,6 +2628,19 @@ func (v *ResourceView) styleCellByColumn(columnName, value string, width int, is
 	}
 }
 
+// podSecurityStyle colors a namespace's Pod Security badge by its enforced
+// level: the less it enforces, the more it stands out
+func podSecurityStyle(enforce string) lipgloss.Style {
+	switch enforce {
+	case core.PodSecurityRestricted:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("2")) // Green
+	case core.PodSecurityBaseline:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("3")) // Yellow
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("1")) // Red
+	}
+}
+
 // styleStatusCell applies color based on pod status
 func (v *ResourceView) styleStatusCell(status string, width int, isSelected bool) string {
 	style := lipgloss.NewStyle().Width(width)
@@ -956,12 +2664,33 @@ func (v *ResourceView) styleStatusCell(status string, width int, isSelected bool
 	case "Terminating":
 		style = style.Foreground(lipgloss.Color("5")) // Magenta
 	default:
-		style = style.Foreground(lipgloss.Color("7")) // Default
+		if core.IsStuckTerminating(status) {
+			style = style.Foreground(lipgloss.Color("1")).Bold(true) // Red
+		} else {
+			style = style.Foreground(lipgloss.Color("7")) // Default
+		}
 	}
 
 	return style.Render(status)
 }
 
+// rowTerminating returns true when a row's STATUS says it is being deleted.
+// The caller must hold v.mu.
+func (v *ResourceView) rowTerminating(row []string) bool {
+	for i, header := range v.table.Titles() {
+		if header == "STATUS" && i < len(row) {
+			return core.IsTerminating(row[i])
+		}
+	}
+	return false
+}
+
+// styleTerminatingName dims and strikes through the name of a resource being
+// deleted, so it does not pass for a healthy row until it is gone
+func styleTerminatingName(name string, width int) string {
+	return lipgloss.NewStyle().Width(width).Foreground(lipgloss.Color("241")).Strikethrough(true).Render(name)
+}
+
 // styleMetricCell applies color based on resource usage
 func (v *ResourceView) styleMetricCell(value string, width int, isSelected bool, isCPU bool) string {
 	style := lipgloss.NewStyle().Width(width).Align(lipgloss.Right)
@@ -978,19 +2707,22 @@ func (v *ResourceView) styleMetricCell(value string, width int, isSelected bool,
 		return style.Render(value)
 	}
 
+	// Color by the usage shown, not the "+N more" hint after it
+	usage := strings.Split(value, " ")[0]
+
 	// Parse the numeric value
 	var numValue float64
 	if isCPU {
 		// CPU values like "100m", "1", "2500m"
-		if strings.HasSuffix(value, "m") {
+		if strings.HasSuffix(usage, "m") {
 			// Millicores
-			numStr := strings.TrimSuffix(value, "m")
+			numStr := strings.TrimSuffix(usage, "m")
 			if val, err := strconv.ParseFloat(numStr, 64); err == nil {
 				numValue = val / 1000.0 // Convert to cores
 			}
 		} else {
 			// Cores
-			if val, err := strconv.ParseFloat(value, 64); err == nil {
+			if val, err := strconv.ParseFloat(usage, 64); err == nil {
 				numValue = val
 			}
 		}
@@ -1006,17 +2738,17 @@ func (v *ResourceView) styleMetricCell(value string, width int, isSelected bool,
 	} else {
 		// Memory values like "128Mi", "1Gi", "512Ki"
 		var multiplier float64 = 1
-		cleanValue := value
+		cleanValue := usage
 
-		if strings.HasSuffix(value, "Gi") {
+		if strings.HasSuffix(usage, "Gi") {
 			multiplier = 1024
-			cleanValue = strings.TrimSuffix(value, "Gi")
-		} else if strings.HasSuffix(value, "Mi") {
+			cleanValue = strings.TrimSuffix(usage, "Gi")
+		} else if strings.HasSuffix(usage, "Mi") {
 			multiplier = 1
-			cleanValue = strings.TrimSuffix(value, "Mi")
-		} else if strings.HasSuffix(value, "Ki") {
+			cleanValue = strings.TrimSuffix(usage, "Mi")
+		} else if strings.HasSuffix(usage, "Ki") {
 			multiplier = 1.0 / 1024.0
-			cleanValue = strings.TrimSuffix(value, "Ki")
+			cleanValue = strings.TrimSuffix(usage, "Ki")
 		}
 
 		if val, err := strconv.ParseFloat(cleanValue, 64); err == nil {
@@ -1068,12 +2800,12 @@ func (v *ResourceView) restoreSelection(newSelectedRow, previousSelectedRow int)
 	if newSelectedRow >= 0 {
 		// Found the same resource, select it
 		v.selectedRow = newSelectedRow
-	} else if previousSelectedRow < len(v.rows) {
+	} else if previousSelectedRow < v.table.GetRowCount() {
 		// Keep the same position if possible
 		v.selectedRow = previousSelectedRow
-	} else if len(v.rows) > 0 {
+	} else if v.table.GetRowCount() > 0 {
 		// Select the last item if previous position is out of bounds
-		v.selectedRow = len(v.rows) - 1
+		v.selectedRow = v.table.GetRowCount() - 1
 	} else {
 		// No items left
 		v.selectedRow = 0
@@ -1082,10 +2814,10 @@ func (v *ResourceView) restoreSelection(newSelectedRow, previousSelectedRow int)
 	}
 
 	// Ensure viewport is within bounds first
-	if v.viewportStart >= len(v.rows) {
+	if v.viewportStart >= v.table.GetRowCount() {
 		v.viewportStart = 0
-		if len(v.rows) > v.viewportHeight {
-			v.viewportStart = len(v.rows) - v.viewportHeight
+		if v.table.GetRowCount() > v.viewportHeight {
+			v.viewportStart = v.table.GetRowCount() - v.viewportHeight
 		}
 	}
 	if v.viewportStart < 0 {
@@ -1103,75 +2835,17 @@ func (v *ResourceView) restoreSelection(newSelectedRow, previousSelectedRow int)
 	}
 }
 
-// calculateColumnWidths calculates the width for each column based on content
-func (v *ResourceView) calculateColumnWidths() {
-	if len(v.headers) == 0 {
-		return
-	}
-
-	// Debug output
-	if len(v.headers) >= 5 {
-		fmt.Printf("DEBUG calculateColumnWidths: wordWrap=%v\n", v.wordWrap)
-	}
-
-	// Initialize with header widths
-	v.columnWidths = make([]int, len(v.headers))
-	for i, header := range v.headers {
-		v.columnWidths[i] = len(header) + 2
-	}
-
-	// Check all rows for max width
-	for _, row := range v.rows {
-		for i, cell := range row {
-			if i < len(v.columnWidths) {
-				cellLen := len(cell) + 2
-
-				// If word wrap is enabled, don't expand columns beyond a reasonable limit
-				if v.wordWrap {
-					// Set reasonable maximum widths for different column types
-					maxWidth := 30 // Default max width when word wrap is on
-					if i < len(v.headers) {
-						header := v.headers[i]
-						switch header {
-						case "NAME":
-							maxWidth = 25
-						case "STATUS":
-							maxWidth = 20
-						case "READY", "RESTARTS", "AGE", "CPU", "MEMORY":
-							maxWidth = 15
-						default:
-							maxWidth = 30
-						}
-					}
-					if cellLen > maxWidth {
-						cellLen = maxWidth
-					}
-				}
-
-				if cellLen > v.columnWidths[i] {
-					v.columnWidths[i] = cellLen
-				}
-			}
-		}
-	}
-
-	// Apply limits based on word wrap setting
-	for i := range v.columnWidths {
-		if v.columnWidths[i] < 7 {
-			v.columnWidths[i] = 7
-		}
-		// If word wrap is enabled, limit column width to prevent overly wide columns
-		if v.wordWrap && v.columnWidths[i] > 50 {
-			v.columnWidths[i] = 50
-		}
-		// When word wrap is off, no maximum limit - show full content
-	}
-}
-
 func (v *ResourceView) renderHeader() string {
 	title := fmt.Sprintf("KubeWatch TUI - %s", v.state.CurrentResourceType)
 	namespace := fmt.Sprintf("Namespace: %s", v.state.CurrentNamespace)
-	count := fmt.Sprintf("Count: %d", v.state.GetCurrentResourceCount())
+	if origin := v.state.NamespaceOrigin(); origin != "" {
+		namespace += " (from " + origin + ")"
+	}
+	count := fmt.Sprintf("Count: %d", v.state.GetCurrentResourceCount()-v.noiseHiddenCount())
+	if v.podScope != nil {
+		// Only the scoped pods are listed
+		count = fmt.Sprintf("Count: %d", v.table.GetRowCount())
+	}
 
 	// Add context information
 	var contextInfo string
@@ -1206,16 +2880,29 @@ func (v *ResourceView) renderHeader() string {
 	}
 	sortStatus := fmt.Sprintf("Sort: %s %s", v.state.SortColumn, sortDirection)
 
-	// Add last refresh time
-	refreshStatus := "Never"
-	if !v.lastRefresh.IsZero() {
-		elapsed := time.Since(v.lastRefresh)
+	// Add last refresh time. The accessible mode shows when it was, which
+	// changes only on a refresh, rather than a count a screen reader would
+	// read out every second.
+	refreshIcon, refreshStatus := "↻ ", "Never"
+	if v.accessible {
+		refreshIcon = "Refreshed: "
+	}
+	if v.accessible && !v.lastRefresh.IsZero() {
+		refreshStatus = core.FormatClock(v.lastRefresh)
+	} else if !v.lastRefresh.IsZero() {
+		elapsed := v.now().Sub(v.lastRefresh)
 		if elapsed < time.Minute {
 			refreshStatus = fmt.Sprintf("%ds ago", int(elapsed.Seconds()))
 		} else {
 			refreshStatus = fmt.Sprintf("%dm ago", int(elapsed.Minutes()))
 		}
 	}
+	// How often the kind listed is refreshed, which varies by kind
+	if v.refreshInterval > 0 {
+		refreshStatus += fmt.Sprintf(" (every %s)", core.FormatDuration(v.refreshInterval))
+	} else {
+		refreshStatus += " (auto-refresh off)"
+	}
 
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86"))
 	infoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
@@ -1223,95 +2910,320 @@ func (v *ResourceView) renderHeader() string {
 	wrapStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("3"))    // Yellow for wrap status
 	sortStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("4"))    // Blue for sort status
 	refreshStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("2")) // Green for refresh
+	if len(v.staleContexts(v.now())) > 0 {
+		refreshStyle = refreshStyle.Foreground(lipgloss.Color("196")) // Red while data is stale
+	}
 
-	header := lipgloss.JoinHorizontal(
-		lipgloss.Top,
+	parts := []string{
 		titleStyle.Render(title),
 		strings.Repeat(" ", 10),
 		contextStyle.Render(contextInfo),
-		strings.Repeat(" ", 5),
-		infoStyle.Render(namespace),
+	}
+	// A cluster-scoped kind is listed whatever namespace is chosen
+	if !core.IsClusterScoped(v.state.CurrentResourceType) {
+		parts = append(parts, strings.Repeat(" ", 5), infoStyle.Render(namespace))
+		// The namespace's Pod Security levels, when it sets any
+		if security := v.podSecurity[v.state.CurrentNamespace]; security.Badge() != "" {
+			parts = append(parts, " ", podSecurityStyle(security.Enforce).Render("["+security.Badge()+"]"))
+		}
+	}
+	parts = append(parts,
 		strings.Repeat(" ", 5),
 		infoStyle.Render(count),
+	)
+	if summary := core.NoiseSummary(v.noiseHidden); summary != "" {
+		parts = append(parts, " ", infoStyle.Render("("+summary+")"))
+	}
+	if v.state.CurrentResourceType == core.ResourceTypePod && v.scrub == nil {
+		// Pods being deleted are counted apart from the running ones
+		parts = append(parts, "  ", lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Render(fmt.Sprintf("Running %d", v.podCounts.Running)))
+		if v.podCounts.Terminating > 0 {
+			parts = append(parts, " ", lipgloss.NewStyle().Foreground(lipgloss.Color("5")).Render(fmt.Sprintf("Terminating %d", v.podCounts.Terminating)))
+		}
+	}
+	if v.truncatedOf > 0 {
+		truncated := fmt.Sprintf("(showing %s of %s", formatThousands(v.table.GetRowCount()), formatThousands(v.truncatedOf))
+		if expression, _ := v.state.GetFilter(); expression != "" {
+			truncated += " matching"
+		}
+		parts = append(parts, " ", lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(truncated+")"))
+	} else if v.unlistedPods != 0 && v.state.CurrentResourceType == core.ResourceTypePod && !v.isMultiContext {
+		// The list stopped at the rows shown; see podListLimit
+		truncated := fmt.Sprintf("(showing the first %s", formatThousands(v.table.GetRowCount()))
+		if v.unlistedPods > 0 {
+			truncated = fmt.Sprintf("(showing %s of %s", formatThousands(v.table.GetRowCount()), formatThousands(v.table.GetRowCount()+v.unlistedPods))
+		}
+		parts = append(parts, " ", lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(truncated+")"))
+	}
+	if v.state.CurrentResourceType == core.ResourceTypePod {
+		if churn := v.churn.indicator(v.now()); churn != "" {
+			parts = append(parts, " ", lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("("+churn+")"))
+		}
+	}
+	if v.isMultiContext && v.notServedIn != "" {
+		parts = append(parts, " ", lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("("+v.notServedIn+")"))
+	}
+	parts = append(parts,
 		strings.Repeat(" ", 5),
 		sortStyle.Render(sortStatus),
+	)
+
+	// Add filter indicator, naming the saved filter when one is active, or
+	// the filter bar's expression while it is previewed
+	expression, savedFilter := v.state.GetFilter()
+	global := v.state.IsFilterGlobal()
+	if v.previewFilter != nil {
+		expression, savedFilter, global = v.previewFilter.Expression, "", false
+	}
+	if expression != "" {
+		filterStatus := fmt.Sprintf("Filter: %s", expression)
+		if savedFilter != "" {
+			filterStatus = fmt.Sprintf("Filter: [%s]", savedFilter)
+		}
+		if global {
+			filterStatus += " (all types)"
+		}
+		if len(v.filterSkipped) > 0 {
+			filterStatus += fmt.Sprintf(" (not applied: no %s column)", strings.Join(v.filterSkipped, ", "))
+		} else if v.filterHidden > 0 {
+			filterStatus += fmt.Sprintf(" (%d hidden)", v.filterHidden)
+		}
+		filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6")) // Cyan for filter
+		parts = append(parts, strings.Repeat(" ", 5), filterStyle.Render(filterStatus))
+	}
+
+	// The label selector the server lists by, which types without labels
+	// support ignore
+	if selector := v.state.GetLabelSelector(); selector != "" {
+		selectorStatus := "Labels: " + selector
+		if !core.SupportsLabelSelector(v.state.CurrentResourceType) {
+			selectorStatus += " (not applied)"
+		}
+		selectorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+		parts = append(parts, strings.Repeat(" ", 5), selectorStyle.Render(selectorStatus))
+	}
+
+	// The workload the pods are scoped to, as a chip Esc removes
+	if v.podScope != nil {
+		chipStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("230")).Background(lipgloss.Color("24"))
+		parts = append(parts, strings.Repeat(" ", 5), chipStyle.Render(" pods of: "+v.podScope.Name+" "))
+	}
+
+	// The field selector given with --field-selector, which only pods
+	// are listed by
+	if selector := v.state.PodFieldSelector(); selector != "" {
+		selectorStatus := "Fields: " + selector
+		if v.state.CurrentResourceType != core.ResourceTypePod {
+			selectorStatus += " (not applied)"
+		}
+		selectorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+		parts = append(parts, strings.Repeat(" ", 5), selectorStyle.Render(selectorStatus))
+	}
+
+	parts = append(parts,
 		strings.Repeat(" ", 5),
 		wrapStyle.Render(wrapStatus),
 		strings.Repeat(" ", 5),
-		refreshStyle.Render("↻ "+refreshStatus),
+		refreshStyle.Render(refreshIcon+refreshStatus),
 	)
 
+	header := lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+
+	// The scrubber position, a failed refresh or a notice takes the blank
+	// line under the header
+	var notice string
+	if v.scrub != nil {
+		notice = v.renderScrubStatus()
+	} else if v.namespaceBanner != "" {
+		notice = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15")).Background(lipgloss.Color("160")).Render(v.namespaceBanner)
+	} else if v.refreshErr != nil {
+		retryIn := v.refreshInterval
+		if !v.nextRetry.IsZero() {
+			retryIn = time.Until(v.nextRetry)
+		}
+		notice = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("✗ " + k8s.RetryMessage(v.refreshErr, retryIn))
+	} else if v.notice != "" && v.now().Before(v.noticeUntil) {
+		notice = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(v.notice)
+	} else if status, phase := v.deletionStatus(); status != "" {
+		notice = lipgloss.NewStyle().Foreground(deletionColor(phase)).Render(status)
+	} else if pull, severity := v.selectedPullDetail(); pull != "" {
+		notice = lipgloss.NewStyle().Foreground(pullSeverityColor(severity)).Render(pull)
+	} else if detail := v.selectedPodDetail(); detail != "" {
+		notice = lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Render(detail)
+	} else if usage := v.selectedPodUsage(); usage != "" {
+		notice = lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Render(usage)
+	} else if detail := v.selectedDeploymentDetail(v.now()); detail != "" {
+		notice = lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Render(detail)
+	} else if v.kubeconfigNotice != "" {
+		notice = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(v.kubeconfigNotice)
+	} else if v.updateNotice != "" {
+		notice = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(v.updateNotice)
+	}
+	if notice != "" {
+		if v.width > 0 {
+			notice = lipgloss.NewStyle().MaxWidth(v.width).Render(notice)
+		}
+		return header + "\n" + notice
+	}
+
 	return header + "\n"
 }
 
-// updateColumnsForResourceType sets the appropriate columns for the current resource type
-func (v *ResourceView) updateColumnsForResourceType() {
-	// Check if we're viewing all namespaces or a specific one
-	showNamespace := v.state.CurrentNamespace == "" || v.state.CurrentNamespace == "all"
+// maxReadyNamesWidth bounds the unready container names added to a READY
+// cell, so the column stays narrow
+const maxReadyNamesWidth = 20
 
-	// Determine if we should show context column
-	// Only show context column if we're in multi-context mode AND have multiple contexts
-	shouldShowContext := v.isMultiContext && len(v.state.CurrentContexts) > 1
-	v.showContextColumn = shouldShowContext
+// podReadyCell formats a pod's READY cell. For pods with more containers than
+// core.ManyContainersThreshold, the unready ones are named when they fit,
+// e.g. "13/15 ✖proxy".
+func podReadyCell(ready, total int, notReady []string) string {
+	cell := strconv.Itoa(ready) + "/" + strconv.Itoa(total)
+	if total <= core.ManyContainersThreshold || len(notReady) == 0 {
+		return cell
+	}
+	names := strings.Join(notReady, ",")
+	if len(names) > maxReadyNamesWidth {
+		return cell
+	}
+	return cell + " ✖" + names
+}
 
-	// Start with context column if needed
-	var baseHeaders []string
-	if shouldShowContext {
-		baseHeaders = []string{"CONTEXT", "NAME"}
-	} else {
-		baseHeaders = []string{"NAME"}
+// podDetail describes the unready containers of a pod with more containers
+// than core.ManyContainersThreshold, or returns "" for other pods
+func podDetail(pod *v1.Pod) string {
+	if core.ContainerCount(pod) <= core.ManyContainersThreshold {
+		return ""
+	}
+	notReady := core.NotReadyContainers(pod)
+	if len(notReady) == 0 {
+		return ""
 	}
+	return "not ready: " + strings.Join(notReady, ", ")
+}
 
-	switch v.state.CurrentResourceType {
-	case core.ResourceTypePod:
-		v.headers = baseHeaders
-		if showNamespace {
-			v.headers = append(v.headers, "NAMESPACE")
-		}
-		v.headers = append(v.headers, "READY", "STATUS", "RESTARTS", "AGE", "CPU", "MEMORY", "IP", "NODE")
+// podDetailKey identifies a pod in v.podDetails
+func podDetailKey(identity *selection.ResourceIdentity) string {
+	return podKey(identity.Context, identity.Namespace, identity.Name)
+}
 
-	case core.ResourceTypeDeployment:
-		v.headers = baseHeaders
-		if showNamespace {
-			v.headers = append(v.headers, "NAMESPACE")
+// podKey identifies a pod across contexts; the context is "" in
+// single-context mode
+func podKey(context, namespace, name string) string {
+	return context + "/" + namespace + "/" + name
+}
+
+// selectedPodDetail returns the line describing the selected pod's unready
+// containers, or "". The caller must hold v.mu.
+func (v *ResourceView) selectedPodDetail() string {
+	if v.state.CurrentResourceType != core.ResourceTypePod || v.scrub != nil {
+		return ""
+	}
+	identity, ok := v.resourceMap[v.selectedRow]
+	if !ok || identity == nil {
+		return ""
+	}
+	if detail := v.podDetails[podDetailKey(identity)]; detail != "" {
+		return identity.Name + " — " + detail
+	}
+	return ""
+}
+
+// selectedPodUsage returns the line breaking the selected pod's usage down
+// by container while the CPU/MEMORY cells show a single container, or "".
+// The caller must hold v.mu.
+func (v *ResourceView) selectedPodUsage() string {
+	if v.state.CurrentResourceType != core.ResourceTypePod || v.scrub != nil || v.metricsHeadline == k8s.HeadlinePod {
+		return ""
+	}
+	identity, ok := v.resourceMap[v.selectedRow]
+	if !ok || identity == nil {
+		return ""
+	}
+	metrics, ok := v.podMetricsFor(identity.Context, identity.Namespace, identity.Name)
+	if !ok || len(metrics.Containers) < 2 {
+		return ""
+	}
+	return identity.Name + " — " + containerUsage(metrics.Containers)
+}
+
+// containerUsage lists each container's usage, e.g. "app 120m/256Mi,
+// istio-proxy 5m/40Mi"
+func containerUsage(containers []k8s.ContainerMetrics) string {
+	parts := make([]string, len(containers))
+	for i, c := range containers {
+		parts[i] = fmt.Sprintf("%s %s/%s", c.Name, c.CPU, c.Memory)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// selectedDeploymentDetail returns the line describing the selected
+// deployment's pods while some are starting or terminating, and its latest
+// image change, or "". The caller must hold v.mu.
+func (v *ResourceView) selectedDeploymentDetail(now time.Time) string {
+	context, deployment, ok := v.selectedDeployment()
+	if !ok {
+		return ""
+	}
+	var details []string
+	if math := v.replicaMath(context, deployment); math != "" {
+		details = append(details, math)
+	}
+	if v.state.Images != nil {
+		if change, ok := v.state.Images.Latest(deployment.UID); ok {
+			details = append(details, change.Describe(now))
 		}
-		v.headers = append(v.headers, "READY", "UP-TO-DATE", "AVAILABLE", "AGE", "CONTAINERS", "IMAGES", "SELECTOR")
+	}
+	if len(details) == 0 {
+		return ""
+	}
+	return deployment.Name + " — " + strings.Join(details, "; ")
+}
 
-	case core.ResourceTypeStatefulSet:
-		v.headers = baseHeaders
-		if showNamespace {
-			v.headers = append(v.headers, "NAMESPACE")
-		}
-		v.headers = append(v.headers, "READY", "AGE", "CONTAINERS", "IMAGES")
+// updateColumnsForResourceType sets the appropriate columns for the current resource type
+func (v *ResourceView) updateColumnsForResourceType() {
+	// Every table update starts here, so forget the noise of the last one
+	clear(v.noiseHidden)
+	clear(v.noisy)
 
-	case core.ResourceTypeService:
-		v.headers = baseHeaders
-		if showNamespace {
-			v.headers = append(v.headers, "NAMESPACE")
-		}
-		v.headers = append(v.headers, "TYPE", "CLUSTER-IP", "EXTERNAL-IP", "PORT(S)", "AGE")
+	// Check if we're viewing all namespaces or a specific one
+	showNamespace := v.state.CurrentNamespace == "" || v.state.CurrentNamespace == "all"
 
-	case core.ResourceTypeIngress:
-		v.headers = baseHeaders
-		if showNamespace {
-			v.headers = append(v.headers, "NAMESPACE")
-		}
-		v.headers = append(v.headers, "CLASS", "HOSTS", "ADDRESS", "PORTS", "AGE")
+	// Determine if we should show context column
+	// Only show context column if we're in multi-context mode AND have multiple contexts
+	v.showContextColumn = v.isMultiContext && len(v.state.CurrentContexts) > 1
 
-	case core.ResourceTypeConfigMap:
-		v.headers = baseHeaders
-		if showNamespace {
-			v.headers = append(v.headers, "NAMESPACE")
-		}
-		v.headers = append(v.headers, "DATA", "AGE")
+	if headers := v.columnsFor(v.state.CurrentResourceType, showNamespace); headers != nil {
+		v.setHeaders(headers)
+	}
+}
 
-	case core.ResourceTypeSecret:
-		v.headers = baseHeaders
-		if showNamespace {
-			v.headers = append(v.headers, "NAMESPACE")
+// columnsFor returns the column headers shown for a resource type, or nil
+// for an unknown type
+func (v *ResourceView) columnsFor(resourceType core.ResourceType, showNamespace bool) []string {
+	// Start with context column if needed
+	var headers []string
+	if v.showContextColumn {
+		headers = []string{"CONTEXT", "NAME"}
+	} else {
+		headers = []string{"NAME"}
+	}
+	if showNamespace && !core.IsClusterScoped(resourceType) {
+		headers = append(headers, "NAMESPACE")
+	}
+
+	columns, ok := core.ResourceColumns[resourceType]
+	if !ok {
+		return nil
+	}
+	for _, column := range columns {
+		switch {
+		case column == "LOG" && v.logRates == nil:
+		case column == "SECURITY" && !v.showSecurity:
+		default:
+			headers = append(headers, column)
 		}
-		v.headers = append(v.headers, "TYPE", "DATA", "AGE")
 	}
+	return headers
 }
 
 func (v *ResourceView) updateTableWithPods(pods []v1.Pod) {
@@ -1331,102 +3243,38 @@ func (v *ResourceView) updateTableWithPods(pods []v1.Pod) {
 	v.saveSelectedIdentity()
 
 	// Clear and rebuild rows and resource map
-	v.rows = [][]string{}
-	v.resourceMap = make(map[int]*selection.ResourceIdentity)
-
-	for _, pod := range pods {
-		// Calculate ready containers
-		readyContainers := 0
-		totalContainers := len(pod.Status.ContainerStatuses)
-		restartCount := int32(0)
-		var lastRestartTime *time.Time
-
-		for _, cs := range pod.Status.ContainerStatuses {
-			if cs.Ready {
-				readyContainers++
-			}
-			restartCount += cs.RestartCount
-			if cs.LastTerminationState.Terminated != nil {
-				t := cs.LastTerminationState.Terminated.FinishedAt.Time
-				if lastRestartTime == nil || t.After(*lastRestartTime) {
-					lastRestartTime = &t
-				}
-			}
-		}
-
-		ready := fmt.Sprintf("%d/%d", readyContainers, totalContainers)
-		status := string(pod.Status.Phase)
-
-		// Get more detailed status if available
-		for _, condition := range pod.Status.Conditions {
-			if condition.Type == v1.PodReady && condition.Status != v1.ConditionTrue {
-				if condition.Reason != "" {
-					status = condition.Reason
-				}
-			}
-		}
-
-		// Check container statuses for more specific states
-		for _, cs := range pod.Status.ContainerStatuses {
-			if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
-				status = cs.State.Waiting.Reason
-				break
-			}
-			if cs.State.Terminated != nil && cs.State.Terminated.Reason != "" {
-				status = cs.State.Terminated.Reason
-				break
-			}
-		}
-
-		// Format restart count with time if available
-		restartStr := fmt.Sprintf("%d", restartCount)
-		if restartCount > 0 && lastRestartTime != nil {
-			restartAge := getAge(*lastRestartTime)
-			restartStr = fmt.Sprintf("%d (%s ago)", restartCount, restartAge)
-		}
-
-		age := getAge(pod.CreationTimestamp.Time)
-
-		// Get metrics if available
-		cpu := "-"
-		memory := "-"
-		if v.podMetrics != nil {
-			if metrics, ok := v.podMetrics[pod.Name]; ok {
-				cpu = metrics.CPU
-				memory = metrics.Memory
-			}
-		}
-
-		// Get IP and Node
-		ip := pod.Status.PodIP
-		if ip == "" {
-			ip = "-"
-		}
-		node := pod.Spec.NodeName
-		if node == "" {
-			node = "-"
+	rows := make([][]string, 0, len(pods))
+	v.resourceMap = make(map[int]*selection.ResourceIdentity, len(pods))
+	clear(v.podDetails)
+	clear(v.pullPods)
+	clear(v.logContainers)
+	v.podCounts = core.PodCounts{}
+	v.crashLooping = v.crashLooping[:0]
+
+	now := v.now()
+	v.podRows.begin()
+	v.churn.begin(v.churnScope(), now)
+	for i := range pods {
+		pod := &pods[i]
+		v.churn.list("", pod)
+		if v.podScope != nil && !v.podScope.Matches(pod) {
+			continue
 		}
-
-		// Build row data
-		rowData := []string{pod.Name}
-		if showNamespace {
-			rowData = append(rowData, pod.Namespace)
+		if v.hidesNoise("", pod.Namespace, pod.Name, v.noiseRules.PodNoise(pod, now)) {
+			continue
 		}
-		rowData = append(rowData, ready, status, restartStr, age, cpu, memory, ip, node)
-		v.rows = append(v.rows, rowData)
 
-		// Create resource identity for this row
-		rowIndex := len(v.rows) - 1
-		identity := &selection.ResourceIdentity{
-			Context:   "", // Single context mode
-			Namespace: pod.Namespace,
-			Name:      pod.Name,
-			UID:       string(pod.UID),
-			Kind:      "Pod",
+		row, entry := v.podRow("", false, showNamespace, pod, now)
+		rows = append(rows, row)
+		v.resourceMap[len(rows)-1] = entry.identity
+		v.churn.show(entry.identity, row)
+		if entry.detail != "" {
+			v.podDetails[podDetailKey(entry.identity)] = entry.detail
 		}
-		v.resourceMap[rowIndex] = identity
-
 	}
+	v.podRows.end()
+	rows = v.addGhostRows(rows, v.churn.end(now, v.churnGrace))
+	v.table.SetValues(rows)
 
 	// Sort the rows BEFORE restoring selection
 	v.sortRowsWithState(sortColumn, sortAscending)
@@ -1440,9 +3288,6 @@ func (v *ResourceView) updateTableWithPods(pods []v1.Pod) {
 	} else if v.selectedRow < v.viewportStart {
 		v.viewportStart = v.selectedRow
 	}
-
-	// Calculate column widths
-	v.calculateColumnWidths()
 }
 
 func (v *ResourceView) updateTableWithDeployments(deployments []appsv1.Deployment) {
@@ -1453,25 +3298,28 @@ func (v *ResourceView) updateTableWithDeployments(deployments []appsv1.Deploymen
 
 	showNamespace := v.state.CurrentNamespace == "" || v.state.CurrentNamespace == "all"
 
-	// Preserve the currently selected resource name
-	var selectedResourceName string
-	if v.selectedRow >= 0 && v.selectedRow < len(v.rows) && len(v.rows) > 0 {
-		selectedResourceName = v.rows[v.selectedRow][0] // First column is always NAME
+	// Preserve the currently selected resource
+	var selected core.ResourceRef
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		selected = v.rowRef(v.table.RowValues(v.selectedRow))
 	}
 
 	// Clear and rebuild rows
-	v.rows = [][]string{}
+	rows := [][]string{}
 	newSelectedRow := 0 // Will update this if we find the previously selected resource
 
 	for _, dep := range deployments {
+		if v.hidesNoise("", dep.Namespace, dep.Name, v.noiseRules.DeploymentNoise(&dep)) {
+			continue
+		}
 		replicas := int32(0)
 		if dep.Spec.Replicas != nil {
 			replicas = *dep.Spec.Replicas
 		}
-		ready := fmt.Sprintf("%d/%d", dep.Status.ReadyReplicas, replicas)
+		ready := core.DeploymentReady(dep.Status.ReadyReplicas, replicas, dep.Spec.Paused)
 		upToDate := fmt.Sprintf("%d", dep.Status.UpdatedReplicas)
 		available := fmt.Sprintf("%d", dep.Status.AvailableReplicas)
-		age := getAge(dep.CreationTimestamp.Time)
+		age := core.AgeOrStuck(dep.ObjectMeta, v.now())
 
 		// Get containers and images
 		var containers []string
@@ -1496,13 +3344,17 @@ func (v *ResourceView) updateTableWithDeployments(deployments []appsv1.Deploymen
 			rowData = append(rowData, dep.Namespace)
 		}
 		rowData = append(rowData, ready, upToDate, available, age, containersStr, imagesStr, selectorStr)
-		v.rows = append(v.rows, rowData)
+		if v.showSecurity {
+			rowData = append(rowData, securityCell(&dep.Spec.Template.Spec))
+		}
+		rows = append(rows, rowData)
 
 		// Check if this was the previously selected resource
-		if selectedResourceName != "" && dep.Name == selectedResourceName {
-			newSelectedRow = len(v.rows) - 1
+		if !selected.IsZero() && selected.Matches("", dep.Namespace, dep.Name) {
+			newSelectedRow = len(rows) - 1
 		}
 	}
+	v.table.SetValues(rows)
 
 	// Restore selection
 	v.selectedRow = newSelectedRow
@@ -1516,9 +3368,6 @@ func (v *ResourceView) updateTableWithDeployments(deployments []appsv1.Deploymen
 
 	// Sort the rows
 	v.sortRows()
-
-	// Calculate column widths
-	v.calculateColumnWidths()
 }
 
 func (v *ResourceView) updateTableWithStatefulSets(statefulsets []appsv1.StatefulSet) {
@@ -1529,23 +3378,27 @@ func (v *ResourceView) updateTableWithStatefulSets(statefulsets []appsv1.Statefu
 
 	showNamespace := v.state.CurrentNamespace == "" || v.state.CurrentNamespace == "all"
 
-	// Preserve the currently selected resource name
-	var selectedResourceName string
-	if v.selectedRow >= 0 && v.selectedRow < len(v.rows) && len(v.rows) > 0 {
-		selectedResourceName = v.rows[v.selectedRow][0] // First column is always NAME
+	// Preserve the currently selected resource
+	var selected core.ResourceRef
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		selected = v.rowRef(v.table.RowValues(v.selectedRow))
 	}
 
 	// Clear and rebuild rows
-	v.rows = [][]string{}
+	rows := [][]string{}
 	newSelectedRow := 0 // Will update this if we find the previously selected resource
 
 	for _, sts := range statefulsets {
+		if v.hidesNoise("", sts.Namespace, sts.Name, v.noiseRules.StatefulSetNoise(&sts)) {
+			continue
+		}
 		replicas := int32(0)
 		if sts.Spec.Replicas != nil {
 			replicas = *sts.Spec.Replicas
 		}
-		ready := fmt.Sprintf("%d/%d", sts.Status.ReadyReplicas, replicas)
-		age := getAge(sts.CreationTimestamp.Time)
+		rolling := sts.Status.UpdateRevision != "" && sts.Status.UpdateRevision != sts.Status.CurrentRevision
+		ready := core.StatefulSetReady(sts.Status.ReadyReplicas, sts.Status.UpdatedReplicas, replicas, rolling)
+		age := core.AgeOrStuck(sts.ObjectMeta, v.now())
 
 		// Get containers and images
 		var containers []string
@@ -1563,13 +3416,14 @@ func (v *ResourceView) updateTableWithStatefulSets(statefulsets []appsv1.Statefu
 			rowData = append(rowData, sts.Namespace)
 		}
 		rowData = append(rowData, ready, age, containersStr, imagesStr)
-		v.rows = append(v.rows, rowData)
+		rows = append(rows, rowData)
 
 		// Check if this was the previously selected resource
-		if selectedResourceName != "" && sts.Name == selectedResourceName {
-			newSelectedRow = len(v.rows) - 1
+		if !selected.IsZero() && selected.Matches("", sts.Namespace, sts.Name) {
+			newSelectedRow = len(rows) - 1
 		}
 	}
+	v.table.SetValues(rows)
 
 	// Restore selection
 	v.selectedRow = newSelectedRow
@@ -1583,9 +3437,6 @@ func (v *ResourceView) updateTableWithStatefulSets(statefulsets []appsv1.Statefu
 
 	// Sort the rows
 	v.sortRows()
-
-	// Calculate column widths
-	v.calculateColumnWidths()
 }
 
 func (v *ResourceView) updateTableWithServices(services []v1.Service) {
@@ -1596,14 +3447,14 @@ func (v *ResourceView) updateTableWithServices(services []v1.Service) {
 
 	showNamespace := v.state.CurrentNamespace == "" || v.state.CurrentNamespace == "all"
 
-	// Preserve the currently selected resource name
-	var selectedResourceName string
-	if v.selectedRow >= 0 && v.selectedRow < len(v.rows) && len(v.rows) > 0 {
-		selectedResourceName = v.rows[v.selectedRow][0] // First column is always NAME
+	// Preserve the currently selected resource
+	var selected core.ResourceRef
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		selected = v.rowRef(v.table.RowValues(v.selectedRow))
 	}
 
 	// Clear and rebuild rows
-	v.rows = [][]string{}
+	rows := [][]string{}
 	newSelectedRow := 0 // Will update this if we find the previously selected resource
 
 	for _, svc := range services {
@@ -1652,7 +3503,7 @@ func (v *ResourceView) updateTableWithServices(services []v1.Service) {
 			// Don't truncate - show full port information
 		}
 
-		age := getAge(svc.CreationTimestamp.Time)
+		age := core.AgeOrStuck(svc.ObjectMeta, v.now())
 
 		// Build row data
 		rowData := []string{svc.Name}
@@ -1660,13 +3511,14 @@ func (v *ResourceView) updateTableWithServices(services []v1.Service) {
 			rowData = append(rowData, svc.Namespace)
 		}
 		rowData = append(rowData, svcType, clusterIP, externalIP, portStr, age)
-		v.rows = append(v.rows, rowData)
+		rows = append(rows, rowData)
 
 		// Check if this was the previously selected resource
-		if selectedResourceName != "" && svc.Name == selectedResourceName {
-			newSelectedRow = len(v.rows) - 1
+		if !selected.IsZero() && selected.Matches("", svc.Namespace, svc.Name) {
+			newSelectedRow = len(rows) - 1
 		}
 	}
+	v.table.SetValues(rows)
 
 	// Restore selection
 	v.selectedRow = newSelectedRow
@@ -1680,9 +3532,6 @@ func (v *ResourceView) updateTableWithServices(services []v1.Service) {
 
 	// Sort the rows
 	v.sortRows()
-
-	// Calculate column widths
-	v.calculateColumnWidths()
 }
 
 func (v *ResourceView) updateTableWithIngresses(ingresses []networkingv1.Ingress) {
@@ -1693,15 +3542,15 @@ func (v *ResourceView) updateTableWithIngresses(ingresses []networkingv1.Ingress
 
 	showNamespace := v.state.CurrentNamespace == "" || v.state.CurrentNamespace == "all"
 
-	// Preserve the currently selected resource name
-	var selectedResourceName string
+	// Preserve the currently selected resource
+	var selected core.ResourceRef
 	previousSelectedRow := v.selectedRow
-	if v.selectedRow >= 0 && v.selectedRow < len(v.rows) && len(v.rows) > 0 {
-		selectedResourceName = v.rows[v.selectedRow][0] // First column is always NAME
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		selected = v.rowRef(v.table.RowValues(v.selectedRow))
 	}
 
 	// Clear and rebuild rows
-	v.rows = [][]string{}
+	rows := [][]string{}
 	newSelectedRow := -1 // Will update this if we find the previously selected resource
 
 	for _, ing := range ingresses {
@@ -1743,7 +3592,7 @@ func (v *ResourceView) updateTableWithIngresses(ingresses []networkingv1.Ingress
 			ports = "80, 443"
 		}
 
-		age := getAge(ing.CreationTimestamp.Time)
+		age := core.AgeOrStuck(ing.ObjectMeta, v.now())
 
 		// Build row data
 		rowData := []string{ing.Name}
@@ -1751,22 +3600,20 @@ func (v *ResourceView) updateTableWithIngresses(ingresses []networkingv1.Ingress
 			rowData = append(rowData, ing.Namespace)
 		}
 		rowData = append(rowData, className, hostsStr, addressStr, ports, age)
-		v.rows = append(v.rows, rowData)
+		rows = append(rows, rowData)
 
 		// Check if this was the previously selected resource
-		if selectedResourceName != "" && ing.Name == selectedResourceName {
-			newSelectedRow = len(v.rows) - 1
+		if !selected.IsZero() && selected.Matches("", ing.Namespace, ing.Name) {
+			newSelectedRow = len(rows) - 1
 		}
 	}
+	v.table.SetValues(rows)
 
 	// Restore selection intelligently
 	v.restoreSelection(newSelectedRow, previousSelectedRow)
 
 	// Sort the rows
 	v.sortRows()
-
-	// Calculate column widths
-	v.calculateColumnWidths()
 }
 
 func (v *ResourceView) updateTableWithConfigMaps(configmaps []v1.ConfigMap) {
@@ -1777,19 +3624,19 @@ func (v *ResourceView) updateTableWithConfigMaps(configmaps []v1.ConfigMap) {
 
 	showNamespace := v.state.CurrentNamespace == "" || v.state.CurrentNamespace == "all"
 
-	// Preserve the currently selected resource name
-	var selectedResourceName string
-	if v.selectedRow >= 0 && v.selectedRow < len(v.rows) && len(v.rows) > 0 {
-		selectedResourceName = v.rows[v.selectedRow][0] // First column is always NAME
+	// Preserve the currently selected resource
+	var selected core.ResourceRef
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		selected = v.rowRef(v.table.RowValues(v.selectedRow))
 	}
 
 	// Clear and rebuild rows
-	v.rows = [][]string{}
+	rows := [][]string{}
 	newSelectedRow := 0 // Will update this if we find the previously selected resource
 
 	for _, cm := range configmaps {
 		dataCount := fmt.Sprintf("%d", len(cm.Data)+len(cm.BinaryData))
-		age := getAge(cm.CreationTimestamp.Time)
+		age := core.AgeOrStuck(cm.ObjectMeta, v.now())
 
 		// Build row data
 		rowData := []string{cm.Name}
@@ -1797,13 +3644,14 @@ func (v *ResourceView) updateTableWithConfigMaps(configmaps []v1.ConfigMap) {
 			rowData = append(rowData, cm.Namespace)
 		}
 		rowData = append(rowData, dataCount, age)
-		v.rows = append(v.rows, rowData)
+		rows = append(rows, rowData)
 
 		// Check if this was the previously selected resource
-		if selectedResourceName != "" && cm.Name == selectedResourceName {
-			newSelectedRow = len(v.rows) - 1
+		if !selected.IsZero() && selected.Matches("", cm.Namespace, cm.Name) {
+			newSelectedRow = len(rows) - 1
 		}
 	}
+	v.table.SetValues(rows)
 
 	// Restore selection
 	v.selectedRow = newSelectedRow
@@ -1817,9 +3665,6 @@ func (v *ResourceView) updateTableWithConfigMaps(configmaps []v1.ConfigMap) {
 
 	// Sort the rows
 	v.sortRows()
-
-	// Calculate column widths
-	v.calculateColumnWidths()
 }
 
 func (v *ResourceView) updateTableWithSecrets(secrets []v1.Secret) {
@@ -1830,20 +3675,20 @@ func (v *ResourceView) updateTableWithSecrets(secrets []v1.Secret) {
 
 	showNamespace := v.state.CurrentNamespace == "" || v.state.CurrentNamespace == "all"
 
-	// Preserve the currently selected resource name
-	var selectedResourceName string
-	if v.selectedRow >= 0 && v.selectedRow < len(v.rows) && len(v.rows) > 0 {
-		selectedResourceName = v.rows[v.selectedRow][0] // First column is always NAME
+	// Preserve the currently selected resource
+	var selected core.ResourceRef
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		selected = v.rowRef(v.table.RowValues(v.selectedRow))
 	}
 
 	// Clear and rebuild rows
-	v.rows = [][]string{}
+	rows := [][]string{}
 	newSelectedRow := 0 // Will update this if we find the previously selected resource
 
 	for _, secret := range secrets {
 		secretType := string(secret.Type)
 		dataCount := fmt.Sprintf("%d", len(secret.Data))
-		age := getAge(secret.CreationTimestamp.Time)
+		age := core.AgeOrStuck(secret.ObjectMeta, v.now())
 
 		// Build row data
 		rowData := []string{secret.Name}
@@ -1851,13 +3696,14 @@ func (v *ResourceView) updateTableWithSecrets(secrets []v1.Secret) {
 			rowData = append(rowData, secret.Namespace)
 		}
 		rowData = append(rowData, secretType, dataCount, age)
-		v.rows = append(v.rows, rowData)
+		rows = append(rows, rowData)
 
 		// Check if this was the previously selected resource
-		if selectedResourceName != "" && secret.Name == selectedResourceName {
-			newSelectedRow = len(v.rows) - 1
+		if !selected.IsZero() && selected.Matches("", secret.Namespace, secret.Name) {
+			newSelectedRow = len(rows) - 1
 		}
 	}
+	v.table.SetValues(rows)
 
 	// Restore selection
 	v.selectedRow = newSelectedRow
@@ -1871,9 +3717,6 @@ func (v *ResourceView) updateTableWithSecrets(secrets []v1.Secret) {
 
 	// Sort the rows
 	v.sortRows()
-
-	// Calculate column widths
-	v.calculateColumnWidths()
 }
 
 // rowWithIdentity pairs a table row with its resource identity for sorting
@@ -1887,7 +3730,18 @@ func (v *ResourceView) sortRowsWithState(sortColumn string, sortAscending bool)
 	// Note: This method is called from within updateTableWithPodsMultiContext which already holds the lock
 	// So we don't need to acquire the lock here to avoid deadlock
 
-	if len(v.rows) <= 1 {
+	v.unfiltered = v.table.Values()
+	v.unfilteredMap = v.resourceMap
+	v.previewMatcher = nil
+	v.filterRows()
+	v.sortFilteredRows(sortColumn, sortAscending)
+}
+
+// sortFilteredRows sorts the rows the filter left. The caller must hold v.mu.
+func (v *ResourceView) sortFilteredRows(sortColumn string, sortAscending bool) {
+	v.truncatedOf = 0
+
+	if v.table.GetRowCount() <= 1 {
 		return
 	}
 
@@ -1898,7 +3752,7 @@ func (v *ResourceView) sortRowsWithState(sortColumn string, sortAscending bool)
 
 	// Find the column index
 	sortColumnIndex := -1
-	for i, header := range v.headers {
+	for i, header := range v.table.Titles() {
 		if header == sortColumn {
 			sortColumnIndex = i
 			break
@@ -1911,56 +3765,189 @@ func (v *ResourceView) sortRowsWithState(sortColumn string, sortAscending bool)
 	}
 
 	// Build the slice with rows and their identities
-	rowsWithIdentities := make([]rowWithIdentity, len(v.rows))
-	for i, row := range v.rows {
+	rows := v.table.Values()
+	rowsWithIdentities := make([]rowWithIdentity, len(rows))
+	for i, row := range rows {
 		rowsWithIdentities[i] = rowWithIdentity{
 			row:      row,
 			identity: v.resourceMap[i],
 		}
 	}
 
-	// Sort the rows with their identities
+	// Sort the rows with their identities. The order is total, so rows
+	// listed in any order, or from contexts answering at different times,
+	// always come out the same way.
+	tiebreaks := v.tiebreakColumns()
 	sort.Slice(rowsWithIdentities, func(i, j int) bool {
-		if sortColumnIndex >= len(rowsWithIdentities[i].row) || sortColumnIndex >= len(rowsWithIdentities[j].row) {
-			return false
+		return compareRows(rowsWithIdentities[i], rowsWithIdentities[j], sortColumn, sortColumnIndex, sortAscending, tiebreaks) < 0
+	})
+
+	// Rebuild rows and resourceMap with the sorted order
+	v.resourceMap = make(map[int]*selection.ResourceIdentity)
+	for i, item := range rowsWithIdentities {
+		rows[i] = item.row
+		if item.identity != nil {
+			v.resourceMap[i] = item.identity
 		}
+	}
+	v.table.SetValues(rows)
 
-		valueI := rowsWithIdentities[i].row[sortColumnIndex]
-		valueJ := rowsWithIdentities[j].row[sortColumnIndex]
+	v.truncateRows()
+}
 
-		// Handle numeric columns specially
-		if sortColumn == "READY" || sortColumn == "RESTARTS" || sortColumn == "AGE" {
-			result := v.compareNumericValues(valueI, valueJ, sortAscending)
-			// If values are equal, use secondary sort
-			if valueI == valueJ {
-				return v.secondarySort(rowsWithIdentities[i], rowsWithIdentities[j])
-			}
-			return result
+// filterRows drops rows that do not match the state's filter expression.
+// Invalid expressions are rejected before they reach the state, so a parse
+// error here just shows everything.
+func (v *ResourceView) filterRows() {
+	expression, _ := v.state.GetFilter()
+	if v.filter == nil || v.filter.Expression != strings.TrimSpace(expression) {
+		v.filter, _ = core.ParseFilter(expression)
+	}
+
+	v.filterHidden = 0
+	v.filterSkipped = nil
+	filter := v.filter
+	if v.previewFilter != nil {
+		filter = v.previewFilter
+	}
+	if filter.IsEmpty() {
+		return
+	}
+
+	headers := v.table.Titles()
+	// A global filter only applies to the types with the columns it names
+	if v.previewFilter == nil && v.state.IsFilterGlobal() {
+		if unknown := filter.UnknownColumns(headers); len(unknown) > 0 {
+			v.filterSkipped = unknown
+			return
 		}
+	}
 
-		// String comparison
-		compareResult := strings.ToLower(valueI) < strings.ToLower(valueJ)
-		if !sortAscending {
-			compareResult = strings.ToLower(valueI) > strings.ToLower(valueJ)
+	namespace := v.state.CurrentNamespace
+	if namespace == "all" {
+		namespace = ""
+	}
+	var rows [][]string
+	resourceMap := make(map[int]*selection.ResourceIdentity)
+	keep := func(i int, row []string) {
+		if identity := v.resourceMap[i]; identity != nil {
+			resourceMap[len(rows)] = identity
+		}
+		rows = append(rows, row)
+	}
+	values := v.table.Values()
+	if v.previewFilter != nil {
+		// The preview changes with every keystroke over the same rows, so
+		// it only rechecks the rows that can still match
+		if v.previewMatcher == nil {
+			v.previewMatcher = core.NewIncrementalFilter(namespace, headers, values)
+		}
+		for _, i := range v.previewMatcher.Apply(filter) {
+			keep(i, values[i])
+		}
+		v.filterHidden = len(values) - len(rows)
+	} else {
+		for i, row := range values {
+			if !filter.MatchInNamespace(namespace, headers, row) {
+				v.filterHidden++
+				continue
+			}
+			keep(i, row)
 		}
+	}
+	v.table.SetValues(rows)
+	v.resourceMap = resourceMap
+}
+
+// PreviewFilter shows the list filtered by filter rather than the state's
+// filter, as the filter bar's expression is typed, until EndFilterPreview.
+// The last update's rows are filtered again without a refresh.
+func (v *ResourceView) PreviewFilter(filter *core.Filter) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.previewFilter = filter
+	v.refilter()
+}
+
+// EndFilterPreview goes back to the state's filter
+func (v *ResourceView) EndFilterPreview() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.previewFilter == nil {
+		return
+	}
+	v.previewFilter = nil
+	v.refilter()
+}
+
+// refilter filters and sorts the last update's rows again, keeping the
+// selected resource selected when it is still listed. The caller must hold
+// v.mu.
+func (v *ResourceView) refilter() {
+	if v.unfiltered == nil {
+		return
+	}
+	var selected core.ResourceRef
+	previousSelectedRow := v.selectedRow
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		selected = v.rowRef(v.table.RowValues(v.selectedRow))
+	}
+
+	v.table.SetValues(v.unfiltered)
+	v.resourceMap = v.unfilteredMap
+	v.filterRows()
+	sortColumn, sortAscending := v.state.GetSortState()
+	v.sortFilteredRows(sortColumn, sortAscending)
 
-		// If primary sort values are equal, use secondary sort
-		if strings.EqualFold(valueI, valueJ) {
-			return v.secondarySort(rowsWithIdentities[i], rowsWithIdentities[j])
+	newSelectedRow := -1
+	if !selected.IsZero() {
+		for i, row := range v.table.Values() {
+			if v.rowRef(row) == selected {
+				newSelectedRow = i
+				break
+			}
 		}
+	}
+	v.restoreSelection(newSelectedRow, previousSelectedRow)
+}
 
-		return compareResult
-	})
+// Columns returns the headers the list would show for resourceType in namespace
+func (v *ResourceView) Columns(resourceType core.ResourceType, namespace string) []string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	showNamespace := namespace == "" || namespace == "all"
+	return v.columnsFor(resourceType, showNamespace)
+}
 
-	// Rebuild rows and resourceMap with the sorted order
-	v.rows = make([][]string, len(rowsWithIdentities))
-	v.resourceMap = make(map[int]*selection.ResourceIdentity)
-	for i, item := range rowsWithIdentities {
-		v.rows[i] = item.row
-		if item.identity != nil {
-			v.resourceMap[i] = item.identity
+// truncateRows drops rows beyond the configured maximum, keeping the first
+// rows in sort order. It runs last, after the filter and the sort, so the
+// rows cut are never ones the filter or sort would have brought to the top.
+func (v *ResourceView) truncateRows() {
+	if v.maxResources <= 0 || v.table.GetRowCount() <= v.maxResources {
+		return
+	}
+
+	v.truncatedOf = v.table.GetRowCount()
+	for i := v.maxResources; i < v.table.GetRowCount(); i++ {
+		delete(v.resourceMap, i)
+	}
+	v.table.SetValues(v.table.Values()[:v.maxResources])
+}
+
+// formatThousands renders n with comma thousands separators, e.g. "1,204"
+func formatThousands(n int) string {
+	if n < 0 {
+		return "-" + formatThousands(-n)
+	}
+	digits := strconv.Itoa(n)
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
 		}
+		b.WriteRune(digit)
 	}
+	return b.String()
 }
 
 // sortRows is a wrapper that reads state and calls sortRowsWithState
@@ -1970,59 +3957,96 @@ func (v *ResourceView) sortRows() {
 	v.sortRowsWithState(sortColumn, sortAscending)
 }
 
-// secondarySort provides a stable secondary sort when primary values are equal
-// It sorts by context (if multi-context), then by name
-func (v *ResourceView) secondarySort(itemI, itemJ rowWithIdentity) bool {
-	// In multi-context mode, first compare by context if not already sorting by context
-	if v.isMultiContext && v.showContextColumn && v.state.SortColumn != "CONTEXT" {
-		// Context is always the first column in multi-context mode
-		contextI := itemI.row[0]
-		contextJ := itemJ.row[0]
-		if contextI != contextJ {
-			return strings.ToLower(contextI) < strings.ToLower(contextJ)
+// tiebreakColumns returns the indexes of the NAMESPACE, CONTEXT and NAME
+// columns, in the order rows equal in the sort column are ordered by, or -1
+// for a column not shown. The caller must hold v.mu.
+func (v *ResourceView) tiebreakColumns() [3]int {
+	columns := [3]int{-1, -1, -1}
+	for i, header := range v.table.Titles() {
+		switch header {
+		case "NAMESPACE":
+			columns[0] = i
+		case "CONTEXT":
+			columns[1] = i
+		case "NAME":
+			columns[2] = i
+		}
+	}
+	return columns
+}
+
+// compareRows orders two rows by the sort column, compared by its type,
+// then by namespace, context, name and UID. Rows missing the sort column's
+// value come last whichever way the column is sorted.
+func compareRows(a, b rowWithIdentity, sortColumn string, sortColumnIndex int, ascending bool, tiebreaks [3]int) int {
+	valueA, valueB := rowCell(a.row, sortColumnIndex), rowCell(b.row, sortColumnIndex)
+	missingA, missingB := missingValue(valueA), missingValue(valueB)
+	switch {
+	case missingA && !missingB:
+		return 1
+	case missingB && !missingA:
+		return -1
+	}
+	if result := compareValues(sortColumn, valueA, valueB); result != 0 {
+		if !ascending {
+			return -result
 		}
+		return result
 	}
 
-	// Then compare by name if not already sorting by name
-	if v.state.SortColumn != "NAME" {
-		nameColIndex := 0
-		if v.isMultiContext && v.showContextColumn {
-			nameColIndex = 1 // Name is second column in multi-context mode
-		}
-		if nameColIndex < len(itemI.row) && nameColIndex < len(itemJ.row) {
-			nameI := itemI.row[nameColIndex]
-			nameJ := itemJ.row[nameColIndex]
-			if nameI != nameJ {
-				return strings.ToLower(nameI) < strings.ToLower(nameJ)
-			}
+	for _, column := range tiebreaks {
+		if result := compareText(rowCell(a.row, column), rowCell(b.row, column)); result != 0 {
+			return result
 		}
 	}
-
-	// Finally, use UID as the ultimate tiebreaker for absolute stability
-	if itemI.identity != nil && itemJ.identity != nil {
-		return itemI.identity.UID < itemJ.identity.UID
+	var uidA, uidB string
+	if a.identity != nil {
+		uidA = a.identity.UID
 	}
+	if b.identity != nil {
+		uidB = b.identity.UID
+	}
+	return strings.Compare(uidA, uidB)
+}
 
-	return false
+// compareValues compares two values of the named column: READY, RESTARTS
+// and AGE by the number they show, the rest as text
+func compareValues(column, a, b string) int {
+	if column == "READY" || column == "RESTARTS" || column == "AGE" {
+		if result := cmp.Compare(extractNumericValue(a), extractNumericValue(b)); result != 0 {
+			return result
+		}
+	}
+	return compareText(a, b)
 }
 
-// compareNumericValues compares two values that might be numeric
-func (v *ResourceView) compareNumericValues(valueI, valueJ string, ascending bool) bool {
-	// Try to extract numeric values for comparison
-	numI := v.extractNumericValue(valueI)
-	numJ := v.extractNumericValue(valueJ)
+// compareText compares case-insensitively, then by case so values differing
+// only in case still have an order
+func compareText(a, b string) int {
+	if result := strings.Compare(strings.ToLower(a), strings.ToLower(b)); result != 0 {
+		return result
+	}
+	return strings.Compare(a, b)
+}
 
-	if ascending {
-		return numI < numJ
+// rowCell returns the row's value in column, or "" for a column it lacks
+func rowCell(row []string, column int) string {
+	if column < 0 || column >= len(row) {
+		return ""
 	}
-	return numI > numJ
+	return row[column]
+}
+
+// missingValue reports whether a cell shows no value
+func missingValue(value string) bool {
+	return value == "" || value == "-" || value == "<none>"
 }
 
 // extractNumericValue extracts a numeric value from a string for sorting
-func (v *ResourceView) extractNumericValue(value string) float64 {
-	// Handle ready format "1/2"
+func extractNumericValue(value string) float64 {
+	// Handle ready format "1/2", possibly followed by unready container names
 	if strings.Contains(value, "/") {
-		parts := strings.Split(value, "/")
+		parts := strings.Split(strings.Fields(value)[0], "/")
 		if len(parts) == 2 {
 			ready, err1 := strconv.ParseFloat(parts[0], 64)
 			total, err2 := strconv.ParseFloat(parts[1], 64)
@@ -2046,7 +4070,7 @@ func (v *ResourceView) extractNumericValue(value string) float64 {
 	if strings.HasSuffix(value, "s") || strings.HasSuffix(value, "m") ||
 		strings.HasSuffix(value, "h") || strings.HasSuffix(value, "d") ||
 		strings.HasSuffix(value, "mo") || strings.HasSuffix(value, "y") {
-		return v.parseAgeToSeconds(value)
+		return parseAgeToSeconds(value)
 	}
 
 	// Try direct numeric conversion
@@ -2059,7 +4083,7 @@ func (v *ResourceView) extractNumericValue(value string) float64 {
 }
 
 // parseAgeToSeconds converts age string to seconds for sorting
-func (v *ResourceView) parseAgeToSeconds(age string) float64 {
+func parseAgeToSeconds(age string) float64 {
 	if len(age) < 2 {
 		return 0
 	}
@@ -2154,121 +4178,53 @@ func (v *ResourceView) updateTableWithPodsMultiContext(podsWithContext []k8s.Pod
 	// Save the currently selected resource identity
 	v.saveSelectedIdentity()
 
-	// Preserve the currently selected resource name and position (for fallback)
-	var selectedResourceName string
+	// Preserve the currently selected resource and position (for fallback)
+	var selected core.ResourceRef
 	previousSelectedRow := v.selectedRow
-	if v.selectedRow >= 0 && v.selectedRow < len(v.rows) && len(v.rows) > 0 {
-		// In multi-context mode, first column is CONTEXT, second is NAME
-		if len(v.rows[v.selectedRow]) > 1 {
-			selectedResourceName = v.rows[v.selectedRow][1]
-		}
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		selected = v.rowRef(v.table.RowValues(v.selectedRow))
 	}
 
 	// Clear and rebuild rows and resource map
-	v.rows = [][]string{}
-	v.resourceMap = make(map[int]*selection.ResourceIdentity)
+	rows := make([][]string, 0, len(podsWithContext))
+	v.resourceMap = make(map[int]*selection.ResourceIdentity, len(podsWithContext))
+	clear(v.podDetails)
+	clear(v.pullPods)
+	clear(v.logContainers)
+	v.podCounts = core.PodCounts{}
+	v.crashLooping = v.crashLooping[:0]
 	newSelectedRow := -1
 
-	for _, pwc := range podsWithContext {
-		pod := pwc.Pod
-		context := pwc.Context
-
-		// Calculate ready containers
-		readyContainers := 0
-		totalContainers := len(pod.Status.ContainerStatuses)
-		restartCount := int32(0)
-		var lastRestartTime *time.Time
-
-		for _, cs := range pod.Status.ContainerStatuses {
-			if cs.Ready {
-				readyContainers++
-			}
-			restartCount += cs.RestartCount
-			if cs.LastTerminationState.Terminated != nil {
-				t := cs.LastTerminationState.Terminated.FinishedAt.Time
-				if lastRestartTime == nil || t.After(*lastRestartTime) {
-					lastRestartTime = &t
-				}
-			}
-		}
-
-		ready := fmt.Sprintf("%d/%d", readyContainers, totalContainers)
-		status := string(pod.Status.Phase)
-
-		// Get more detailed status if available
-		for _, condition := range pod.Status.Conditions {
-			if condition.Type == v1.PodReady && condition.Status != v1.ConditionTrue {
-				if condition.Reason != "" {
-					status = condition.Reason
-				}
-			}
-		}
-
-		// Check container statuses for more specific states
-		for _, cs := range pod.Status.ContainerStatuses {
-			if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
-				status = cs.State.Waiting.Reason
-				break
-			}
-			if cs.State.Terminated != nil && cs.State.Terminated.Reason != "" {
-				status = cs.State.Terminated.Reason
-				break
-			}
-		}
-
-		// Format restart count with time if available
-		restartStr := fmt.Sprintf("%d", restartCount)
-		if restartCount > 0 && lastRestartTime != nil {
-			restartAge := getAge(*lastRestartTime)
-			restartStr = fmt.Sprintf("%d (%s ago)", restartCount, restartAge)
-		}
-
-		age := getAge(pod.CreationTimestamp.Time)
-
-		// Get metrics if available
-		cpu := "-"
-		memory := "-"
-		if v.podMetrics != nil {
-			if metrics, ok := v.podMetrics[pod.Name]; ok {
-				cpu = metrics.CPU
-				memory = metrics.Memory
-			}
-		}
-
-		// Get IP and Node
-		ip := pod.Status.PodIP
-		if ip == "" {
-			ip = "-"
-		}
-		node := pod.Spec.NodeName
-		if node == "" {
-			node = "-"
+	now := v.now()
+	v.podRows.begin()
+	v.churn.begin(v.churnScope(), now)
+	for i := range podsWithContext {
+		pod := &podsWithContext[i].Pod
+		context := podsWithContext[i].Context
+		v.churn.list(context, pod)
+		if v.podScope != nil && !v.podScope.MatchesIn(context, pod) {
+			continue
 		}
-
-		// Build row data with context column first
-		rowData := []string{context, pod.Name}
-		if showNamespace {
-			rowData = append(rowData, pod.Namespace)
+		if v.hidesNoise(context, pod.Namespace, pod.Name, v.noiseRules.PodNoise(pod, now)) {
+			continue
 		}
-		rowData = append(rowData, ready, status, restartStr, age, cpu, memory, ip, node)
-		v.rows = append(v.rows, rowData)
 
-		// Create resource identity for this row
-		rowIndex := len(v.rows) - 1
-		identity := &selection.ResourceIdentity{
-			Context:   context,
-			Namespace: pod.Namespace,
-			Name:      pod.Name,
-			UID:       string(pod.UID),
-			Kind:      "Pod",
+		row, entry := v.podRow(context, true, showNamespace, pod, now)
+		rows = append(rows, row)
+		v.resourceMap[len(rows)-1] = entry.identity
+		v.churn.show(entry.identity, row)
+		if entry.detail != "" {
+			v.podDetails[podDetailKey(entry.identity)] = entry.detail
 		}
-		v.resourceMap[rowIndex] = identity
 
 		// Check if this was the previously selected resource
-		if selectedResourceName != "" && pod.Name == selectedResourceName {
-			newSelectedRow = len(v.rows) - 1
+		if !selected.IsZero() && selected.Matches(context, pod.Namespace, pod.Name) {
+			newSelectedRow = len(rows) - 1
 		}
 	}
+	v.podRows.end()
+	rows = v.addGhostRows(rows, v.churn.end(now, v.churnGrace))
+	v.table.SetValues(rows)
 
 	// Sort the rows BEFORE restoring selection
 	v.sortRows()
@@ -2287,9 +4243,6 @@ func (v *ResourceView) updateTableWithPodsMultiContext(podsWithContext []k8s.Pod
 	} else if v.selectedRow < v.viewportStart {
 		v.viewportStart = v.selectedRow
 	}
-
-	// Calculate column widths
-	v.calculateColumnWidths()
 }
 
 func (v *ResourceView) updateTableWithDeploymentsMultiContext(deploymentsWithContext []k8s.DeploymentWithContext) {
@@ -2303,8 +4256,17 @@ func (v *ResourceView) updateTableWithDeploymentsMultiContext(deploymentsWithCon
 	// Save the currently selected resource identity
 	v.saveSelectedIdentity()
 
+	// Drop the noise before grouping, so groups only count what is shown
+	listed := make([]k8s.DeploymentWithContext, 0, len(deploymentsWithContext))
+	for _, dwc := range deploymentsWithContext {
+		if !v.hidesNoise(dwc.Context, dwc.Deployment.Namespace, dwc.Deployment.Name, v.noiseRules.DeploymentNoise(&dwc.Deployment)) {
+			listed = append(listed, dwc)
+		}
+	}
+	deploymentsWithContext = listed
+
 	// Clear and rebuild rows and resource map
-	v.rows = [][]string{}
+	rows := [][]string{}
 	v.resourceMap = make(map[int]*selection.ResourceIdentity)
 
 	// Convert to interface slice for grouping
@@ -2352,20 +4314,32 @@ func (v *ResourceView) updateTableWithDeploymentsMultiContext(deploymentsWithCon
 				row = append([]string{context}, row...)
 				identity.Context = context
 			}
+			if v.showSecurity {
+				row = append(row, securityCell(&deployment.Spec.Template.Spec))
+			}
 
-			v.rows = append(v.rows, row)
-			v.resourceMap[len(v.rows)-1] = identity
+			rows = append(rows, row)
+			v.resourceMap[len(rows)-1] = identity
 		} else {
 			// Multiple resources - use aggregation
 			row, identity, err := transformer.AggregateResources(group, showNamespace, v.showContextColumn, v.templateEngine)
 			if err != nil {
 				continue
 			}
+			if v.showSecurity {
+				var specs []*v1.PodSpec
+				for _, member := range group {
+					deployment := member.(map[string]interface{})["resource"].(appsv1.Deployment)
+					specs = append(specs, &deployment.Spec.Template.Spec)
+				}
+				row = append(row, securityCell(specs...))
+			}
 
-			v.rows = append(v.rows, row)
-			v.resourceMap[len(v.rows)-1] = identity
+			rows = append(rows, row)
+			v.resourceMap[len(rows)-1] = identity
 		}
 	}
+	v.table.SetValues(rows)
 
 	// Sort the rows BEFORE restoring selection
 	v.sortRows()
@@ -2379,34 +4353,31 @@ func (v *ResourceView) updateTableWithDeploymentsMultiContext(deploymentsWithCon
 	} else if v.selectedRow < v.viewportStart {
 		v.viewportStart = v.selectedRow
 	}
-
-	// Calculate column widths
-	v.calculateColumnWidths()
 }
 
 // Fallback method for when grouping fails
 func (v *ResourceView) updateTableWithDeploymentsMultiContextFallback(deploymentsWithContext []k8s.DeploymentWithContext) {
 	showNamespace := v.state.CurrentNamespace == "" || v.state.CurrentNamespace == "all"
 
-	// Preserve the currently selected resource name
-	var selectedResourceName string
+	// Preserve the currently selected resource
+	var selected core.ResourceRef
 	previousSelectedRow := v.selectedRow
-	if v.selectedRow >= 0 && v.selectedRow < len(v.rows) && len(v.rows) > 0 {
-		selectedResourceName = v.rows[v.selectedRow][0] // First column is always NAME
+	if v.selectedRow >= 0 && v.selectedRow < v.table.GetRowCount() {
+		selected = v.rowRef(v.table.RowValues(v.selectedRow))
 	}
 
 	// Clear and rebuild rows
-	v.rows = [][]string{}
+	rows := [][]string{}
 	newSelectedRow := -1
 
 	for _, dwc := range deploymentsWithContext {
 		deployment := dwc.Deployment
 		context := dwc.Context
 
-		ready := fmt.Sprintf("%d/%d", deployment.Status.ReadyReplicas, deployment.Status.Replicas)
+		ready := core.DeploymentReady(deployment.Status.ReadyReplicas, deployment.Status.Replicas, deployment.Spec.Paused)
 		upToDate := fmt.Sprintf("%d", deployment.Status.UpdatedReplicas)
 		available := fmt.Sprintf("%d", deployment.Status.AvailableReplicas)
-		age := getAge(deployment.CreationTimestamp.Time)
+		age := core.AgeOrStuck(deployment.ObjectMeta, v.now())
 
 		// Build row data with context column first
 		rowData := []string{context, deployment.Name}
@@ -2414,13 +4385,14 @@ func (v *ResourceView) updateTableWithDeploymentsMultiContextFallback(deployment
 			rowData = append(rowData, deployment.Namespace)
 		}
 		rowData = append(rowData, ready, upToDate, available, age)
-		v.rows = append(v.rows, rowData)
+		rows = append(rows, rowData)
 
 		// Check if this was the previously selected resource
-		if selectedResourceName != "" && deployment.Name == selectedResourceName {
-			newSelectedRow = len(v.rows) - 1
+		if !selected.IsZero() && selected.Matches(context, deployment.Namespace, deployment.Name) {
+			newSelectedRow = len(rows) - 1
 		}
 	}
+	v.table.SetValues(rows)
 
 	// Restore selection intelligently
 	v.restoreSelection(newSelectedRow, previousSelectedRow)
@@ -2434,36 +4406,17 @@ func (v *ResourceView) updateTableWithDeploymentsMultiContextFallback(deployment
 
 	// Sort the rows
 	v.sortRows()
-
-	// Calculate column widths
-	v.calculateColumnWidths()
 }
 
 // Helper functions
 
-func getAge(t time.Time) string {
-	duration := time.Since(t)
-	if duration.Hours() > 24*365 {
-		years := int(duration.Hours() / (24 * 365))
-		return fmt.Sprintf("%dy", years)
-	} else if duration.Hours() > 24*30 {
-		months := int(duration.Hours() / (24 * 30))
-		return fmt.Sprintf("%dmo", months)
-	} else if duration.Hours() > 24 {
-		days := int(duration.Hours() / 24)
-		return fmt.Sprintf("%dd", days)
-	} else if duration.Hours() > 1 {
-		return fmt.Sprintf("%dh", int(duration.Hours()))
-	} else if duration.Minutes() > 1 {
-		return fmt.Sprintf("%dm", int(duration.Minutes()))
-	}
-	return fmt.Sprintf("%ds", int(duration.Seconds()))
-}
-
 // SetTestData sets test data for the ResourceView (for testing purposes)
 func (v *ResourceView) SetTestData(headers []string, rows [][]string) {
-	v.headers = headers
-	v.rows = rows
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.setHeaders(headers)
+	v.table.SetValues(rows)
 
 	// Initialize resource map if needed
 	if v.resourceMap == nil {
@@ -2482,6 +4435,11 @@ func (v *ResourceView) SetTestData(headers []string, rows [][]string) {
 			}
 		}
 	}
+
+	// Record the table as a refresh would
+	v.unfiltered, v.unfilteredMap, v.previewMatcher = rows, v.resourceMap, nil
+	v.recordHistoryLocked()
+	v.recordActivityLocked()
 }
 
 // SetSelectedRow sets the selected row index (for testing purposes)
@@ -2490,134 +4448,22 @@ func (v *ResourceView) SetSelectedRow(row int) {
 	defer v.mu.Unlock()
 
 	v.selectedRow = row
+	v.selectionMoves++
 	if row >= 0 && row < len(v.resourceMap) {
 		v.selectedIdentity = v.resourceMap[row]
 	}
 }
 
-// updateTableWithNewComponents uses the new refactored components to update the table
-func (rv *ResourceView) updateTableWithNewComponents(resources []interface{}) error {
-	rv.mu.Lock()
-	defer rv.mu.Unlock()
-	if rv.transformerRegistry == nil || rv.templateEngine == nil {
-		// Fall back to legacy method if new components aren't initialized
-		return fmt.Errorf("new components not initialized")
-	}
-
-	resourceType := string(rv.state.CurrentResourceType)
-	// Convert plural resource type to singular for transformer lookup
-	transformerKey := resourceType
-	switch resourceType {
-	case "Pods":
-		transformerKey = "Pod"
-	case "Deployments":
-		transformerKey = "Deployment"
-	case "StatefulSets":
-		transformerKey = "StatefulSet"
-	case "Services":
-		transformerKey = "Service"
-	case "Ingresses":
-		transformerKey = "Ingress"
-	case "ConfigMaps":
-		transformerKey = "ConfigMap"
-	case "Secrets":
-		transformerKey = "Secret"
-	}
-
-	transformer, exists := rv.transformerRegistry.Get(transformerKey)
-	if !exists {
-		return fmt.Errorf("no transformer found for resource type: %s", transformerKey)
-	}
-
-	// Determine display options
-	showNamespace := rv.state.CurrentNamespace == "" || rv.state.CurrentNamespace == "all"
-	multiContext := rv.isMultiContext
-
-	// Get headers from transformer
-	headers := transformer.GetHeaders(showNamespace, multiContext)
-
-	// Convert resources to table rows using transformers
-	var tableRows []table.Row
-	var resourceIdentities []*selection.ResourceIdentity
-
-	for i, resource := range resources {
-		rowData, identity, err := transformer.TransformToRow(resource, showNamespace, rv.templateEngine)
-		if err != nil {
-			// Log error but continue with other resources
-			continue
-		}
-
-		// Create table row
-		tableRow := table.Row{
-			ID:     fmt.Sprintf("resource-%d", i),
-			Values: rowData,
-			Style:  lipgloss.NewStyle(), // Default style, can be customized later
-		}
-
-		tableRows = append(tableRows, tableRow)
-		resourceIdentities = append(resourceIdentities, identity)
-	}
-
-	// Update the new table component
-	rv.tableComponent.SetRows(tableRows)
-
-	// Update selection tracker
-	resourceMap := make(map[int]*selection.ResourceIdentity)
-	for i, identity := range resourceIdentities {
-		resourceMap[i] = identity
-	}
-	rv.selectionTracker.SetResourceMap(resourceMap)
-
-	// Update legacy structures for backward compatibility
-	rv.headers = headers
-	rv.rows = make([][]string, len(tableRows))
-	rv.resourceMap = make(map[int]*selection.ResourceIdentity)
-
-	for i, row := range tableRows {
-		rv.rows[i] = row.Values
-		if i < len(resourceIdentities) {
-			rv.resourceMap[i] = resourceIdentities[i]
-		}
-	}
-
-	// Calculate column widths for legacy compatibility
-	rv.calculateColumnWidths()
-
-	return nil
-}
-
-// EnableNewComponents enables the new refactored components for rendering
-func (rv *ResourceView) EnableNewComponents() {
-	rv.useNewComponents = true
-}
-
-// DisableNewComponents disables the new refactored components, falling back to legacy rendering
-func (rv *ResourceView) DisableNewComponents() {
-	rv.useNewComponents = false
-}
-
-// IsUsingNewComponents returns whether new components are currently enabled
-func (rv *ResourceView) IsUsingNewComponents() bool {
-	return rv.useNewComponents
-}
-
-// TryUpdateWithNewComponents attempts to update the table using new components
-// Returns true if successful, false if it falls back to legacy methods
-func (rv *ResourceView) TryUpdateWithNewComponents(resources []interface{}) bool {
-	if !rv.useNewComponents {
-		return false
-	}
-
-	err := rv.updateTableWithNewComponents(resources)
-	if err != nil {
-		// Log error and fall back to legacy method
-		return false
-	}
-
-	return true
-}
-
 // Message types
 type refreshCompleteMsg struct{}
-type deleteCompleteMsg struct{ name string }
+
+// AmbiguousSelectionMsg is sent when a refresh lists several resources a
+// requested selection may refer to, e.g. a bare name in several namespaces
+type AmbiguousSelectionMsg struct {
+	Ref     core.ResourceRef
+	Matches []core.ResourceRef
+}
 type errMsg struct{ err error }
+
+// refreshFailedMsg reports a failed refresh, already recorded by the view
+type refreshFailedMsg struct{ err error }
@@ -0,0 +1,336 @@
+package views
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/testutil/fixtures"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// goldenNow is the clock every golden snapshot is rendered at, so ages and
+// the header never change between runs
+var goldenNow = fixtures.Epoch
+
+// goldenPods are a running and a pending pod of namespace
+func goldenPods(namespace string) []v1.Pod {
+	return []v1.Pod{
+		fixtures.NewPod("api-7d9f", namespace).WithIP("10.0.0.12").WithRestarts(1).Build(),
+		fixtures.NewPod("worker-5c2b", namespace).WithNode("node-b").WithPhase(v1.PodPending).Build(),
+	}
+}
+
+// goldenLoaders fills a resource view with the fixture objects of each
+// resource type
+var goldenLoaders = map[core.ResourceType]func(rv *ResourceView){
+	core.ResourceTypePod: func(rv *ResourceView) {
+		pods := goldenPods("web")
+		rv.state.UpdatePods(pods)
+		rv.updateTableWithPods(pods)
+	},
+	core.ResourceTypeDeployment: func(rv *ResourceView) {
+		deployments := []appsv1.Deployment{
+			fixtures.NewDeployment("api", "web").WithImage("example/api:1.4").WithReady(2).Build(),
+		}
+		rv.state.UpdateDeployments(deployments)
+		rv.updateTableWithDeployments(deployments)
+	},
+	core.ResourceTypeStatefulSet: func(rv *ResourceView) {
+		statefulSets := []appsv1.StatefulSet{fixtures.NewStatefulSet("db", "web").WithReplicas(2).Build()}
+		rv.state.UpdateStatefulSets(statefulSets)
+		rv.updateTableWithStatefulSets(statefulSets)
+	},
+	core.ResourceTypeJob: func(rv *ResourceView) {
+		completions := int32(1)
+		started := metav1.NewTime(goldenNow.Add(-30 * time.Minute))
+		completed := metav1.NewTime(goldenNow.Add(-28 * time.Minute))
+		jobs := []batchv1.Job{{
+			ObjectMeta: fixtures.Meta("migrate", "web"),
+			Spec:       batchv1.JobSpec{Completions: &completions},
+			Status:     batchv1.JobStatus{Succeeded: 1, StartTime: &started, CompletionTime: &completed},
+		}}
+		rv.state.UpdateJobs(jobs)
+		rv.updateTableWithJobs(jobs)
+	},
+	core.ResourceTypeCronJob: func(rv *ResourceView) {
+		lastRun := metav1.NewTime(goldenNow.Add(-10 * time.Minute))
+		cronJobs := []batchv1.CronJob{{
+			ObjectMeta: fixtures.Meta("backup", "web"),
+			Spec:       batchv1.CronJobSpec{Schedule: "0 * * * *"},
+			Status:     batchv1.CronJobStatus{LastScheduleTime: &lastRun},
+		}}
+		rv.state.UpdateCronJobs(cronJobs)
+		rv.updateTableWithCronJobs(cronJobs)
+	},
+	core.ResourceTypeService: func(rv *ResourceView) {
+		services := []v1.Service{{
+			ObjectMeta: fixtures.Meta("api", "web"),
+			Spec: v1.ServiceSpec{
+				Type:      v1.ServiceTypeClusterIP,
+				ClusterIP: "10.96.0.20",
+				Ports:     []v1.ServicePort{{Port: 80, Protocol: v1.ProtocolTCP}},
+			},
+		}}
+		rv.state.UpdateServices(services)
+		rv.updateTableWithServices(services)
+	},
+	core.ResourceTypeIngress: func(rv *ResourceView) {
+		ingresses := []networkingv1.Ingress{{
+			ObjectMeta: fixtures.Meta("api", "web"),
+			Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "api.example.com"}}},
+		}}
+		rv.state.UpdateIngresses(ingresses)
+		rv.updateTableWithIngresses(ingresses)
+	},
+	core.ResourceTypeGateway: func(rv *ResourceView) {
+		gateways := []core.Gateway{{
+			ObjectMeta: fixtures.Meta("edge", "web"),
+			Spec:       core.GatewaySpec{GatewayClassName: "envoy"},
+		}}
+		rv.state.UpdateGateways(gateways)
+		rv.updateTableWithGateways(gateways)
+	},
+	core.ResourceTypeHTTPRoute: func(rv *ResourceView) {
+		routes := []core.HTTPRoute{{
+			ObjectMeta: fixtures.Meta("api", "web"),
+			Spec:       core.HTTPRouteSpec{Hostnames: []string{"api.example.com"}},
+		}}
+		rv.state.UpdateHTTPRoutes(routes)
+		rv.updateTableWithHTTPRoutes(routes)
+	},
+	core.ResourceTypeConfigMap: func(rv *ResourceView) {
+		configMaps := []v1.ConfigMap{{
+			ObjectMeta: fixtures.Meta("settings", "web"),
+			Data:       map[string]string{"mode": "prod", "region": "us"},
+		}}
+		rv.state.UpdateConfigMaps(configMaps)
+		rv.updateTableWithConfigMaps(configMaps)
+	},
+	core.ResourceTypeSecret: func(rv *ResourceView) {
+		secrets := []v1.Secret{{
+			ObjectMeta: fixtures.Meta("tls", "web"),
+			Type:       v1.SecretTypeTLS,
+			Data:       map[string][]byte{"tls.crt": nil, "tls.key": nil},
+		}}
+		rv.state.UpdateSecrets(secrets)
+		rv.updateTableWithSecrets(secrets)
+	},
+	core.ResourceTypeServiceAccount: func(rv *ResourceView) {
+		accounts := []v1.ServiceAccount{{ObjectMeta: fixtures.Meta("deployer", "web")}}
+		rv.state.UpdateServiceAccounts(accounts)
+		rv.updateTableWithServiceAccounts(accounts)
+	},
+	core.ResourceTypeRole: func(rv *ResourceView) {
+		roles := []rbacv1.Role{{ObjectMeta: fixtures.Meta("reader", "web")}}
+		rv.state.UpdateRoles(roles)
+		rv.updateTableWithRoles(roles)
+	},
+	core.ResourceTypeClusterRole: func(rv *ResourceView) {
+		roles := []rbacv1.ClusterRole{{ObjectMeta: fixtures.Meta("view", "")}}
+		rv.state.UpdateClusterRoles(roles)
+		rv.updateTableWithClusterRoles(roles)
+	},
+	core.ResourceTypeRoleBinding: func(rv *ResourceView) {
+		bindings := []rbacv1.RoleBinding{{
+			ObjectMeta: fixtures.Meta("reader", "web"),
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "reader"},
+			Subjects:   []rbacv1.Subject{{Kind: "ServiceAccount", Name: "deployer", Namespace: "web"}},
+		}}
+		rv.state.UpdateRoleBindings(bindings)
+		rv.updateTableWithRoleBindings(bindings)
+	},
+	core.ResourceTypeClusterRoleBinding: func(rv *ResourceView) {
+		bindings := []rbacv1.ClusterRoleBinding{{
+			ObjectMeta: fixtures.Meta("view", ""),
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+			Subjects:   []rbacv1.Subject{{Kind: "Group", Name: "developers"}},
+		}}
+		rv.state.UpdateClusterRoleBindings(bindings)
+		rv.updateTableWithClusterRoleBindings(bindings)
+	},
+	core.ResourceTypeNode: func(rv *ResourceView) {
+		nodes := []v1.Node{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "node-a",
+				UID:               "uid-node-a",
+				Labels:            map[string]string{"node-role.kubernetes.io/control-plane": ""},
+				CreationTimestamp: metav1.NewTime(goldenNow.Add(-48 * time.Hour)),
+			},
+			Status: v1.NodeStatus{
+				Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+				Addresses:  []v1.NodeAddress{{Type: v1.NodeInternalIP, Address: "192.168.1.10"}},
+				NodeInfo:   v1.NodeSystemInfo{KubeletVersion: "v1.29.2"},
+			},
+		}}
+		rv.state.UpdateNodes(nodes)
+		rv.updateTableWithNodes(nodes)
+	},
+}
+
+// newGoldenResourceView returns a view of the given type at a fixed size
+// and clock with colors off
+func newGoldenResourceView(resourceType core.ResourceType, namespace string) *ResourceView {
+	state := core.NewState(&core.Config{CurrentNamespace: namespace, CurrentContext: "prod"})
+	state.CurrentResourceType = resourceType
+	state.SetSortState("NAME", true)
+	rv := NewResourceView(state, nil)
+	rv.SetClock(func() time.Time { return goldenNow })
+	rv.SetAccessibility(true, false)
+	rv.SetSize(120, 20)
+	return rv
+}
+
+// assertGolden compares a rendering with testdata/golden/<name>.golden,
+// rewriting the file instead when UPDATE_GOLDEN is set
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".golden")
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s (run with UPDATE_GOLDEN=1 to create it): %v", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("Rendering differs from %s (run with UPDATE_GOLDEN=1 to accept it)\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+// TestResourceViewGolden tests each resource type's table, and the pod
+// table's layouts, against checked-in snapshots
+func TestResourceViewGolden(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.Ascii)
+	defer lipgloss.SetColorProfile(profile)
+
+	for _, resourceType := range core.AllResourceTypes {
+		load, ok := goldenLoaders[resourceType]
+		if !ok {
+			t.Errorf("No golden fixture for %s", resourceType)
+			continue
+		}
+		t.Run(string(resourceType), func(t *testing.T) {
+			rv := newGoldenResourceView(resourceType, "web")
+			load(rv)
+			assertGolden(t, strings.ToLower(string(resourceType)), rv.View())
+		})
+	}
+
+	cases := []struct {
+		name   string
+		render func() string
+	}{
+		{"pods-all-namespaces", func() string {
+			rv := newGoldenResourceView(core.ResourceTypePod, "all")
+			pods := append(goldenPods("web"), goldenPods("jobs")...)
+			rv.updateTableWithPods(pods)
+			return rv.View()
+		}},
+		{"pods-multi-context", func() string {
+			state := core.NewState(&core.Config{CurrentNamespace: "web", CurrentContext: "prod"})
+			state.SetSortState("NAME", true)
+			state.SetCurrentContexts([]string{"prod", "staging"})
+			rv := NewResourceViewWithMultiContext(state, nil)
+			rv.SetClock(func() time.Time { return goldenNow })
+			rv.SetAccessibility(true, false)
+			rv.SetSize(120, 20)
+			var pods []k8s.PodWithContext
+			for _, context := range []string{"prod", "staging"} {
+				state.UpdatePodsByContext(context, goldenPods("web"))
+				for _, pod := range goldenPods("web") {
+					pods = append(pods, k8s.PodWithContext{Context: context, Pod: pod})
+				}
+			}
+			rv.updateTableWithPodsMultiContext(pods)
+			return rv.View()
+		}},
+		{"scenario-crashlooping-rollout", func() string {
+			rv := newGoldenResourceView(core.ResourceTypePod, "web")
+			pods := fixtures.CrashloopingRollout("web").Pods
+			rv.state.UpdatePods(pods)
+			rv.updateTableWithPods(pods)
+			return rv.View()
+		}},
+		{"scenario-crashlooping-rollout-deployments", func() string {
+			rv := newGoldenResourceView(core.ResourceTypeDeployment, "web")
+			deployments := fixtures.CrashloopingRollout("web").Deployments
+			rv.state.UpdateDeployments(deployments)
+			rv.updateTableWithDeployments(deployments)
+			return rv.View()
+		}},
+		{"scenario-multi-namespace-mix", func() string {
+			rv := newGoldenResourceView(core.ResourceTypePod, "all")
+			pods := fixtures.MultiNamespaceMix().Pods
+			rv.state.UpdatePods(pods)
+			rv.updateTableWithPods(pods)
+			return rv.View()
+		}},
+		{"scenario-multi-context-pair", func() string {
+			state := core.NewState(&core.Config{CurrentNamespace: "web", CurrentContext: "prod"})
+			state.SetSortState("NAME", true)
+			state.SetCurrentContexts([]string{"prod", "staging"})
+			rv := NewResourceViewWithMultiContext(state, nil)
+			rv.SetClock(func() time.Time { return goldenNow })
+			rv.SetAccessibility(true, false)
+			rv.SetSize(120, 20)
+			var pods []k8s.PodWithContext
+			for context, scenario := range fixtures.MultiContextPair() {
+				state.UpdatePodsByContext(context, scenario.Pods)
+				for _, pod := range scenario.Pods {
+					pods = append(pods, k8s.PodWithContext{Context: context, Pod: pod})
+				}
+			}
+			rv.updateTableWithPodsMultiContext(pods)
+			return rv.View()
+		}},
+		{"pods-compact", func() string {
+			rv := newGoldenResourceView(core.ResourceTypePod, "web")
+			rv.SetCompactMode(true)
+			goldenLoaders[core.ResourceTypePod](rv)
+			return rv.View()
+		}},
+		{"pods-narrow", func() string {
+			rv := newGoldenResourceView(core.ResourceTypePod, "web")
+			rv.SetSize(60, 12)
+			goldenLoaders[core.ResourceTypePod](rv)
+			return rv.View()
+		}},
+		{"pods-filtered", func() string {
+			rv := newGoldenResourceView(core.ResourceTypePod, "web")
+			rv.state.SetFilter("status=Running", "")
+			goldenLoaders[core.ResourceTypePod](rv)
+			return rv.View()
+		}},
+		{"pods-empty", func() string {
+			rv := newGoldenResourceView(core.ResourceTypePod, "web")
+			rv.updateTableWithPods(nil)
+			return rv.View()
+		}},
+		{"secrets-empty", func() string {
+			rv := newGoldenResourceView(core.ResourceTypeSecret, "web")
+			rv.updateTableWithSecrets(nil)
+			return rv.View()
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assertGolden(t, tc.name, tc.render())
+		})
+	}
+}
@@ -1,17 +1,26 @@
 package views
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/HamStudy/kubewatch/internal/components/selection"
-	"github.com/HamStudy/kubewatch/internal/components/table"
 	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/testutil/fixtures"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 )
 
 // createTestState creates a State with consistent defaults for testing
@@ -44,12 +53,12 @@ func createTestResourceViewWithData(t *testing.T) *ResourceView {
 	rv := createTestResourceView(t)
 
 	// Add some test data
-	rv.headers = []string{"NAME", "READY", "STATUS", "RESTARTS", "AGE"}
-	rv.rows = [][]string{
+	rv.setHeaders([]string{"NAME", "READY", "STATUS", "RESTARTS", "AGE"})
+	rv.table.SetValues([][]string{
 		{"test-pod-1", "1/1", "Running", "0", "5m"},
 		{"test-pod-2", "0/1", "Pending", "0", "2m"},
 		{"test-pod-3", "1/1", "Running", "1", "10m"},
-	}
+	})
 
 	// Set up resource map for selection tracking
 	rv.resourceMap = make(map[int]*selection.ResourceIdentity)
@@ -78,16 +87,6 @@ func createTestResourceViewWithData(t *testing.T) *ResourceView {
 	rv.selectedRow = 0
 	rv.selectedIdentity = rv.resourceMap[0]
 
-	// If new components are enabled, populate the table component with test data
-	if rv.useNewComponents && rv.tableComponent != nil {
-		tableRows := []table.Row{
-			{ID: "resource-0", Values: []string{"test-pod-1", "1/1", "Running", "0", "5m"}, Style: lipgloss.NewStyle()},
-			{ID: "resource-1", Values: []string{"test-pod-2", "0/1", "Pending", "0", "2m"}, Style: lipgloss.NewStyle()},
-			{ID: "resource-2", Values: []string{"test-pod-3", "1/1", "Running", "1", "10m"}, Style: lipgloss.NewStyle()},
-		}
-		rv.tableComponent.SetRows(tableRows)
-	}
-
 	return rv
 }
 
@@ -185,11 +184,11 @@ func TestResourceViewSelectionTracking(t *testing.T) {
 	originalIdentity := rv.selectedIdentity
 
 	// Simulate data refresh that changes order
-	rv.rows = [][]string{
+	rv.table.SetValues([][]string{
 		{"test-pod-3", "1/1", "Running", "1", "10m"},
 		{"test-pod-2", "0/1", "Pending", "0", "2m"}, // This was selected
 		{"test-pod-1", "1/1", "Running", "0", "5m"},
-	}
+	})
 
 	// Update resource map to reflect new order
 	rv.resourceMap[0] = &selection.ResourceIdentity{
@@ -251,6 +250,22 @@ func TestResourceViewViewRendering(t *testing.T) {
 	}
 }
 
+// TestResourceViewNamespaceOrigin tests that the header says where the
+// starting namespace came from until another namespace is listed
+func TestResourceViewNamespaceOrigin(t *testing.T) {
+	state := core.NewState(&core.Config{CurrentNamespace: "payments", NamespaceOrigin: core.NamespaceFromKubeconfig})
+	rv := NewResourceView(state, nil)
+	rv.SetSize(200, 20)
+	if view := rv.View(); !strings.Contains(view, "Namespace: payments (from kubeconfig)") {
+		t.Errorf("Expected the namespace's origin in the header, got:\n%s", view)
+	}
+
+	state.SetNamespace("orders")
+	if view := rv.View(); !strings.Contains(view, "Namespace: orders") || strings.Contains(view, "from kubeconfig") {
+		t.Errorf("Expected no origin for a namespace picked later, got:\n%s", view)
+	}
+}
+
 func TestResourceViewCompactMode(t *testing.T) {
 	rv := createTestResourceViewWithData(t)
 
@@ -279,6 +294,69 @@ func TestResourceViewCompactMode(t *testing.T) {
 		t.Error("Normal view should not be empty")
 	}
 }
+
+// TestResourceViewCompactModeKeepsScroll tests that switching to compact
+// mode and back, as opening and closing logs does, changes only how many
+// rows show: column widths and horizontal scroll stay put, and the full
+// list comes back scrolled where it was
+func TestResourceViewCompactModeKeepsScroll(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "default", ""), nil)
+	rv.SetSize(60, 30)
+	rv.setHeaders([]string{"NAME", "READY", "STATUS", "RESTARTS", "AGE", "NODE"})
+	var rows [][]string
+	for i := 0; i < 60; i++ {
+		rows = append(rows, []string{fmt.Sprintf("web-frontend-%02d", i), "1/1", "Running", "0", "5m", "worker-node-pool-a-" + fmt.Sprint(i)})
+	}
+	rv.table.SetValues(rows)
+
+	// Scroll down past a screen, right, and back up within the screen
+	for i := 0; i < 40; i++ {
+		rv.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	for i := 0; i < 3; i++ {
+		rv.Update(tea.KeyMsg{Type: tea.KeyRight})
+	}
+	for i := 0; i < 10; i++ {
+		rv.Update(tea.KeyMsg{Type: tea.KeyUp})
+	}
+	rv.View()
+	start, offset, selected := rv.viewportStart, rv.horizontalOffset, rv.selectedRow
+	widths := rv.table.ColumnWidths()
+	if start == 0 || offset == 0 {
+		t.Fatalf("Expected the list scrolled, got start %d and offset %d", start, offset)
+	}
+
+	for i := 0; i < 3; i++ {
+		rv.SetCompactMode(true)
+		rv.SetSize(60, 10)
+		rv.View()
+		if rv.selectedRow < rv.viewportStart || rv.selectedRow >= rv.viewportStart+rv.viewportHeight {
+			t.Fatalf("Expected the selected row in view in compact mode, got row %d from %d", rv.selectedRow, rv.viewportStart)
+		}
+		if rv.horizontalOffset != offset || !slices.Equal(rv.table.ColumnWidths(), widths) {
+			t.Errorf("Expected compact mode to keep offset %d and widths %v, got %d and %v", offset, widths, rv.horizontalOffset, rv.table.ColumnWidths())
+		}
+
+		// Rendering again leaves the compact list where it is
+		compactStart := rv.viewportStart
+		rv.View()
+		if rv.viewportStart != compactStart {
+			t.Errorf("Expected the compact list to stay at %d, got %d", compactStart, rv.viewportStart)
+		}
+
+		rv.SetCompactMode(false)
+		rv.SetSize(60, 30)
+		rv.View()
+		if rv.viewportStart != start || rv.horizontalOffset != offset || rv.selectedRow != selected {
+			t.Errorf("Expected start %d, offset %d and row %d back, got %d, %d and %d",
+				start, offset, selected, rv.viewportStart, rv.horizontalOffset, rv.selectedRow)
+		}
+		if !slices.Equal(rv.table.ColumnWidths(), widths) {
+			t.Errorf("Expected widths %v, got %v", widths, rv.table.ColumnWidths())
+		}
+	}
+}
+
 func TestResourceViewMultiContext(t *testing.T) {
 	state := &core.State{
 		CurrentResourceType: core.ResourceTypePod,
@@ -364,13 +442,13 @@ func TestResourceViewPagination(t *testing.T) {
 
 	// Add more rows to test pagination
 	for i := 4; i <= 20; i++ {
-		rv.rows = append(rv.rows, []string{
+		rv.table.SetValues(append(rv.table.Values(), []string{
 			"test-pod-" + string(rune('0'+i)),
 			"1/1",
 			"Running",
 			"0",
 			"1m",
-		})
+		}))
 		rv.resourceMap[i-1] = &selection.ResourceIdentity{
 			Context:   "test-context",
 			Namespace: "default",
@@ -488,11 +566,11 @@ func TestResourceViewSelectionJumpingBugDuringRefresh(t *testing.T) {
 			expectedSelection: 2,
 			refreshDataModifier: func(rv *ResourceView) {
 				// Simulate refresh with same pods but updated status
-				rv.rows = [][]string{
+				rv.table.SetValues([][]string{
 					{"test-pod-1", "1/1", "Running", "0", "6m"},  // Age changed
 					{"test-pod-2", "1/1", "Running", "0", "3m"},  // Status changed to Running
 					{"test-pod-3", "1/1", "Running", "2", "11m"}, // Restarts increased
-				}
+				})
 				// Resource map stays the same (same UIDs)
 			},
 			shouldFail:     true,
@@ -504,11 +582,11 @@ func TestResourceViewSelectionJumpingBugDuringRefresh(t *testing.T) {
 			expectedSelection: 2, // test-pod-2 should now be at index 2
 			refreshDataModifier: func(rv *ResourceView) {
 				// Simulate refresh where pods are reordered (e.g., by age)
-				rv.rows = [][]string{
+				rv.table.SetValues([][]string{
 					{"test-pod-3", "1/1", "Running", "1", "10m"},
 					{"test-pod-1", "1/1", "Running", "0", "5m"},
 					{"test-pod-2", "0/1", "Pending", "0", "2m"}, // Selected pod moved to index 2
-				}
+				})
 				// Update resource map to reflect new order
 				rv.resourceMap[0] = &selection.ResourceIdentity{
 					Context:   "test-context",
@@ -541,10 +619,10 @@ func TestResourceViewSelectionJumpingBugDuringRefresh(t *testing.T) {
 			expectedSelection: 1, // Should select next available pod at same index
 			refreshDataModifier: func(rv *ResourceView) {
 				// Simulate refresh where test-pod-2 is deleted
-				rv.rows = [][]string{
+				rv.table.SetValues([][]string{
 					{"test-pod-1", "1/1", "Running", "0", "5m"},
 					{"test-pod-3", "1/1", "Running", "1", "10m"},
-				}
+				})
 				// Update resource map
 				rv.resourceMap = make(map[int]*selection.ResourceIdentity)
 				rv.resourceMap[0] = &selection.ResourceIdentity{
@@ -571,12 +649,12 @@ func TestResourceViewSelectionJumpingBugDuringRefresh(t *testing.T) {
 			expectedSelection: 2, // Should stay on test-pod-3 which remains at index 2
 			refreshDataModifier: func(rv *ResourceView) {
 				// Add a new pod
-				rv.rows = [][]string{
+				rv.table.SetValues([][]string{
 					{"test-pod-1", "1/1", "Running", "0", "5m"},
 					{"test-pod-2", "0/1", "Pending", "0", "2m"},
 					{"test-pod-3", "1/1", "Running", "1", "10m"},
 					{"test-pod-4", "1/1", "Running", "0", "1m"}, // New pod
-				}
+				})
 				// Update resource map - keep existing ones
 				rv.resourceMap[3] = &selection.ResourceIdentity{
 					Context:   "test-context",
@@ -595,11 +673,11 @@ func TestResourceViewSelectionJumpingBugDuringRefresh(t *testing.T) {
 			expectedSelection: 0, // Should reset to top when all pods are new
 			refreshDataModifier: func(rv *ResourceView) {
 				// Replace all pods with new ones
-				rv.rows = [][]string{
+				rv.table.SetValues([][]string{
 					{"new-pod-1", "1/1", "Running", "0", "1m"},
 					{"new-pod-2", "0/1", "Pending", "0", "30s"},
 					{"new-pod-3", "1/1", "Running", "0", "15s"},
-				}
+				})
 				// Update resource map with all new UIDs
 				rv.resourceMap = make(map[int]*selection.ResourceIdentity)
 				rv.resourceMap[0] = &selection.ResourceIdentity{
@@ -717,7 +795,7 @@ func TestResourceViewRefreshWithRealTimeUpdates(t *testing.T) {
 		{
 			description: "Pod status update",
 			updateFunc: func() {
-				rv.rows[1][2] = "Running" // test-pod-2 becomes Running
+				rv.table.SetCell(1, 2, "Running") // test-pod-2 becomes Running
 			},
 		},
 		{
@@ -725,7 +803,7 @@ func TestResourceViewRefreshWithRealTimeUpdates(t *testing.T) {
 			updateFunc: func() {
 				// Insert new pod at beginning
 				newRow := []string{"test-pod-0", "1/1", "Running", "0", "1s"}
-				rv.rows = append([][]string{newRow}, rv.rows...)
+				rv.table.SetValues(append([][]string{newRow}, rv.table.Values()...))
 
 				// Shift resource map indices
 				newMap := make(map[int]*selection.ResourceIdentity)
@@ -746,9 +824,9 @@ func TestResourceViewRefreshWithRealTimeUpdates(t *testing.T) {
 			description: "Pod restart count increases",
 			updateFunc: func() {
 				// Find test-pod-2 and update its restart count
-				for i, row := range rv.rows {
+				for i, row := range rv.table.Values() {
 					if row[0] == "test-pod-2" {
-						rv.rows[i][3] = "1" // Increment restart count
+						rv.table.SetCell(i, 3, "1") // Increment restart count
 						break
 					}
 				}
@@ -786,66 +864,11 @@ func TestResourceViewUpdateTableWithPodsSelectionBug(t *testing.T) {
 	// Create test pods using the actual Pod type
 	createTestPods := func() []v1.Pod {
 		return []v1.Pod{
-			{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-pod-1",
-					Namespace: "default",
-					UID:       "uid-1",
-					CreationTimestamp: metav1.Time{
-						Time: time.Now().Add(-5 * time.Minute),
-					},
-				},
-				Status: v1.PodStatus{
-					Phase: v1.PodRunning,
-					ContainerStatuses: []v1.ContainerStatus{
-						{Ready: true, RestartCount: 0},
-					},
-					PodIP: "10.0.0.1",
-				},
-				Spec: v1.PodSpec{
-					NodeName: "node-1",
-				},
-			},
-			{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-pod-2",
-					Namespace: "default",
-					UID:       "uid-2",
-					CreationTimestamp: metav1.Time{
-						Time: time.Now().Add(-2 * time.Minute),
-					},
-				},
-				Status: v1.PodStatus{
-					Phase: v1.PodPending,
-					ContainerStatuses: []v1.ContainerStatus{
-						{Ready: false, RestartCount: 0},
-					},
-					PodIP: "",
-				},
-				Spec: v1.PodSpec{
-					NodeName: "",
-				},
-			},
-			{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-pod-3",
-					Namespace: "default",
-					UID:       "uid-3",
-					CreationTimestamp: metav1.Time{
-						Time: time.Now().Add(-10 * time.Minute),
-					},
-				},
-				Status: v1.PodStatus{
-					Phase: v1.PodRunning,
-					ContainerStatuses: []v1.ContainerStatus{
-						{Ready: true, RestartCount: 1},
-					},
-					PodIP: "10.0.0.3",
-				},
-				Spec: v1.PodSpec{
-					NodeName: "node-2",
-				},
-			},
+			fixtures.NewPod("test-pod-1", "default").WithUID("uid-1").CreatedAgo(5 * time.Minute).Build(),
+			fixtures.NewPod("test-pod-2", "default").WithUID("uid-2").CreatedAgo(2 * time.Minute).
+				WithPhase(v1.PodPending).Build(),
+			fixtures.NewPod("test-pod-3", "default").WithUID("uid-3").CreatedAgo(10 * time.Minute).
+				WithNode("node-b").WithRestarts(1).Build(),
 		}
 	}
 
@@ -967,9 +990,9 @@ func TestResourceViewUpdateTableWithPodsSelectionBug(t *testing.T) {
 		rv.updateTableWithPods(remainingPods)
 
 		// Selection should stay at row 1 (which is now test-pod-3) or move to a valid row
-		if rv.selectedRow >= len(rv.rows) {
+		if rv.selectedRow >= rv.table.GetRowCount() {
 			t.Errorf("BUG: Selected row %d is out of bounds (only %d rows)",
-				rv.selectedRow, len(rv.rows))
+				rv.selectedRow, rv.table.GetRowCount())
 		}
 
 		// Should select something valid
@@ -986,26 +1009,12 @@ func TestResourceViewUpdateTableWithPodsSelectionBug(t *testing.T) {
 func TestResourceViewSelectionBugComprehensive(t *testing.T) {
 	// Helper to create a pod with specific attributes
 	createPod := func(name, namespace, uid string, phase v1.PodPhase, ready bool, restarts int32, ageMinutes int) v1.Pod {
-		return v1.Pod{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      name,
-				Namespace: namespace,
-				UID:       types.UID(uid),
-				CreationTimestamp: metav1.Time{
-					Time: time.Now().Add(-time.Duration(ageMinutes) * time.Minute),
-				},
-			},
-			Status: v1.PodStatus{
-				Phase: phase,
-				ContainerStatuses: []v1.ContainerStatus{
-					{Ready: ready, RestartCount: restarts},
-				},
-				PodIP: "10.0.0." + uid[len(uid)-1:], // Use last char of UID for IP
-			},
-			Spec: v1.PodSpec{
-				NodeName: "node-" + uid[len(uid)-1:],
-			},
+		pod := fixtures.NewPod(name, namespace).WithUID(uid).WithPhase(phase).WithRestarts(restarts).
+			CreatedAgo(time.Duration(ageMinutes) * time.Minute)
+		if !ready {
+			pod.NotReady()
 		}
+		return pod.Build()
 	}
 
 	t.Run("CRITICAL: Selection must persist through refresh cycles", func(t *testing.T) {
@@ -1081,8 +1090,8 @@ func TestResourceViewSelectionBugComprehensive(t *testing.T) {
 				rv.updateTableWithPods(basePods)
 
 				// Verify initial state
-				if len(rv.rows) != 5 {
-					t.Fatalf("Expected 5 rows, got %d", len(rv.rows))
+				if rv.table.GetRowCount() != 5 {
+					t.Fatalf("Expected 5 rows, got %d", rv.table.GetRowCount())
 				}
 
 				// Navigate to pod-charlie (which will be at row 2 after alphabetical sorting)
@@ -1177,19 +1186,19 @@ func TestResourceViewSelectionPersistenceAcrossContextSwitch(t *testing.T) {
 	rv.SetSize(80, 24)
 
 	// Add test data
-	rv.headers = []string{"NAME", "READY", "STATUS", "RESTARTS", "AGE"}
-	rv.rows = [][]string{
+	rv.setHeaders([]string{"NAME", "READY", "STATUS", "RESTARTS", "AGE"})
+	rv.table.SetValues([][]string{
 		{"test-pod-1", "1/1", "Running", "0", "5m"},
 		{"test-pod-2", "0/1", "Pending", "0", "2m"},
 		{"test-pod-3", "1/1", "Running", "1", "10m"},
-	}
+	})
 
 	rv.resourceMap = make(map[int]*selection.ResourceIdentity)
 	for i := 0; i < 3; i++ {
 		rv.resourceMap[i] = &selection.ResourceIdentity{
 			Context:   "test-context",
 			Namespace: "default",
-			Name:      rv.rows[i][0],
+			Name:      rv.table.RowValues(i)[0],
 			UID:       "uid-" + string(rune('1'+i)),
 			Kind:      "Pod",
 		}
@@ -1207,10 +1216,12 @@ func TestResourceViewSelectionPersistenceAcrossContextSwitch(t *testing.T) {
 	rv.showContextColumn = true
 
 	// Add context column to data
-	for i := range rv.rows {
-		rv.rows[i] = append([]string{"test-context"}, rv.rows[i]...)
+	rows := rv.table.Values()
+	for i := range rows {
+		rows[i] = append([]string{"test-context"}, rows[i]...)
 	}
-	rv.headers = append([]string{"CONTEXT"}, rv.headers...)
+	rv.table.SetValues(rows)
+	rv.setHeaders(append([]string{"CONTEXT"}, rv.table.Titles()...))
 
 	// Try to maintain selection
 	rv.restoreSelectionByIdentity()
@@ -1232,30 +1243,12 @@ func TestResourceViewSelectionPersistenceAcrossContextSwitch(t *testing.T) {
 func TestMultiContextSelectionJumpingBug(t *testing.T) {
 	// Helper to create a pod with context information
 	createMultiContextPod := func(name, context, namespace, uid string, phase v1.PodPhase, ready bool, restarts int32, ageMinutes int) v1.Pod {
-		return v1.Pod{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      name,
-				Namespace: namespace,
-				UID:       types.UID(uid),
-				CreationTimestamp: metav1.Time{
-					Time: time.Now().Add(-time.Duration(ageMinutes) * time.Minute),
-				},
-				// Store context in annotations for multi-context scenarios
-				Annotations: map[string]string{
-					"context": context,
-				},
-			},
-			Status: v1.PodStatus{
-				Phase: phase,
-				ContainerStatuses: []v1.ContainerStatus{
-					{Ready: ready, RestartCount: restarts},
-				},
-				PodIP: "10.0.0." + uid[len(uid)-1:],
-			},
-			Spec: v1.PodSpec{
-				NodeName: "node-" + context + "-" + uid[len(uid)-1:],
-			},
+		pod := fixtures.NewPod(name, namespace).WithUID(uid).WithPhase(phase).WithRestarts(restarts).
+			WithNode("node-" + context + "-" + uid[len(uid)-1:]).CreatedAgo(time.Duration(ageMinutes) * time.Minute)
+		if !ready {
+			pod.NotReady()
 		}
+		return pod.Build()
 	}
 
 	t.Run("Multi-context: Selection jumps when pods from different contexts are interleaved", func(t *testing.T) {
@@ -1296,8 +1289,8 @@ func TestMultiContextSelectionJumpingBug(t *testing.T) {
 
 		// Manually set up the table data to simulate what updateTableWithPods would do
 		// In multi-context mode, pods are typically sorted by name then context
-		rv.headers = []string{"CONTEXT", "NAME", "READY", "STATUS", "RESTARTS", "AGE", "NODE"}
-		rv.rows = [][]string{
+		rv.setHeaders([]string{"CONTEXT", "NAME", "READY", "STATUS", "RESTARTS", "AGE", "NODE"})
+		rv.table.SetValues([][]string{
 			{"prod-cluster", "api-server-1", "1/1", "Running", "0", "2h", "node-prod-cluster-1"},
 			{"staging-cluster", "api-server-2", "1/1", "Running", "1", "1h30m", "node-staging-cluster-1"},
 			{"dev-cluster", "api-server-3", "0/1", "Pending", "0", "1h", "node-dev-cluster-1"},
@@ -1307,7 +1300,7 @@ func TestMultiContextSelectionJumpingBug(t *testing.T) {
 			{"prod-cluster", "worker-1", "1/1", "Running", "0", "3h20m", "node-prod-cluster-3"},
 			{"staging-cluster", "worker-2", "0/1", "Failed", "5", "1h20m", "node-staging-cluster-3"},
 			{"dev-cluster", "worker-3", "1/1", "Running", "0", "30m", "node-dev-cluster-3"},
-		}
+		})
 
 		// Set up resource map with context information
 		rv.resourceMap = make(map[int]*selection.ResourceIdentity)
@@ -1365,7 +1358,7 @@ func TestMultiContextSelectionJumpingBug(t *testing.T) {
 			{"dev-cluster", "worker-3", "1/1", "Running", "0", "31m", "node-dev-cluster-3"},
 		}
 
-		rv.rows = refreshedRows
+		rv.table.SetValues(refreshedRows)
 
 		// Attempt to restore selection
 		rv.restoreSelectionByIdentity()
@@ -1406,13 +1399,13 @@ func TestMultiContextSelectionJumpingBug(t *testing.T) {
 		rv.SetSize(100, 25)
 
 		// Initial setup with pods from two clusters
-		rv.headers = []string{"CONTEXT", "NAME", "READY", "STATUS", "AGE"}
-		rv.rows = [][]string{
+		rv.setHeaders([]string{"CONTEXT", "NAME", "READY", "STATUS", "AGE"})
+		rv.table.SetValues([][]string{
 			{"cluster-a", "pod-1", "1/1", "Running", "10m"},
 			{"cluster-b", "pod-2", "1/1", "Running", "8m"},
 			{"cluster-a", "pod-3", "1/1", "Running", "6m"},
 			{"cluster-b", "pod-4", "1/1", "Running", "4m"},
-		}
+		})
 
 		// Store original resource map for later reference
 		originalMap := make(map[int]*selection.ResourceIdentity)
@@ -1442,14 +1435,14 @@ func TestMultiContextSelectionJumpingBug(t *testing.T) {
 			selectedBefore.Name, selectedBefore.Context, rv.selectedRow)
 
 		// Add new pods from both clusters (simulating new deployments)
-		rv.rows = [][]string{
+		rv.table.SetValues([][]string{
 			{"cluster-a", "pod-0", "1/1", "Running", "1m"}, // New pod that sorts first
 			{"cluster-a", "pod-1", "1/1", "Running", "11m"},
 			{"cluster-b", "pod-2", "1/1", "Running", "9m"},
 			{"cluster-a", "pod-3", "1/1", "Running", "7m"}, // Our selected pod
 			{"cluster-b", "pod-4", "1/1", "Running", "5m"},
 			{"cluster-b", "pod-5", "1/1", "Running", "1m"}, // New pod from cluster-b
-		}
+		})
 
 		// Update resource map
 		newMap := make(map[int]*selection.ResourceIdentity)
@@ -1486,7 +1479,7 @@ func TestMultiContextSelectionJumpingBug(t *testing.T) {
 		rv.SetSize(100, 25)
 
 		// Set up initial data
-		rv.headers = []string{"CONTEXT", "NAME", "STATUS"}
+		rv.setHeaders([]string{"CONTEXT", "NAME", "STATUS"})
 		initialRows := [][]string{
 			{"context-1", "app-1", "Running"},
 			{"context-2", "app-2", "Running"},
@@ -1495,12 +1488,12 @@ func TestMultiContextSelectionJumpingBug(t *testing.T) {
 			{"context-2", "db-2", "Running"},
 			{"context-3", "db-3", "Running"},
 		}
-		rv.rows = initialRows
+		rv.table.SetValues(initialRows)
 
 		rv.resourceMap = make(map[int]*selection.ResourceIdentity)
 		for i := 0; i < 6; i++ {
 			ctx := []string{"context-1", "context-2", "context-3"}[i%3]
-			name := rv.rows[i][1]
+			name := rv.table.RowValues(i)[1]
 			rv.resourceMap[i] = &selection.ResourceIdentity{
 				Context:   ctx,
 				Namespace: "default",
@@ -1584,7 +1577,7 @@ func TestMultiContextSelectionJumpingBug(t *testing.T) {
 					}
 				}
 
-				rv.rows = filteredRows
+				rv.table.SetValues(filteredRows)
 				rv.resourceMap = filteredMap
 
 				// Try to restore selection
@@ -1606,3 +1599,1145 @@ func TestMultiContextSelectionJumpingBug(t *testing.T) {
 		}
 	})
 }
+
+func TestResourceViewMaxResources(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxResources int
+		expectRows   int
+	}{
+		{"unlimited", 0, 5},
+		{"capped", 3, 3},
+		{"above count", 10, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &core.State{
+				CurrentResourceType: core.ResourceTypePod,
+				CurrentNamespace:    "default",
+				SortColumn:          "NAME",
+				SortAscending:       true,
+			}
+			rv := NewResourceView(state, nil)
+			rv.SetSize(120, 40)
+			rv.SetMaxResources(tt.maxResources)
+
+			var pods []v1.Pod
+			for i := 5; i >= 1; i-- {
+				pods = append(pods, fixtures.NewPod(fmt.Sprintf("pod-%d", i), "default").Build())
+			}
+			rv.updateTableWithPods(pods)
+
+			if rv.table.GetRowCount() != tt.expectRows {
+				t.Fatalf("Expected %d rows, got %d", tt.expectRows, rv.table.GetRowCount())
+			}
+			if len(rv.resourceMap) != tt.expectRows {
+				t.Errorf("Expected %d resource identities, got %d", tt.expectRows, len(rv.resourceMap))
+			}
+			// Truncation happens after sorting, so the first rows by name are kept
+			if rv.GetSelectedResourceName() != "pod-1" {
+				t.Errorf("Expected pod-1 first, got %s", rv.GetSelectedResourceName())
+			}
+		})
+	}
+}
+
+func TestResourceViewPodListLimit(t *testing.T) {
+	state := createTestState(core.ResourceTypePod, "default", "")
+	rv := NewResourceView(state, nil)
+	rv.SetSize(200, 40)
+	rv.SetMaxResources(500)
+
+	if limit := rv.podListLimit(); limit != 500 {
+		t.Fatalf("Expected the list to stop at the 500 shown, got %d", limit)
+	}
+	state.SetFilter("worker-", "")
+	if limit := rv.podListLimit(); limit != 0 {
+		t.Errorf("Expected a filter to need every pod listed, got %d", limit)
+	}
+	state.SetFilter("", "")
+	state.SetSortState("AGE", true)
+	if limit := rv.podListLimit(); limit != 0 {
+		t.Errorf("Expected a sort other than by name to need every pod listed, got %d", limit)
+	}
+	state.SetSortState("NAME", true)
+	state.SetNamespace("")
+	if limit := rv.podListLimit(); limit != 0 {
+		t.Errorf("Expected all namespaces to need every pod listed, got %d", limit)
+	}
+	state.SetNamespace("default")
+
+	var pods []v1.Pod
+	for i := 0; i < 500; i++ {
+		pods = append(pods, fixtures.NewPod(fmt.Sprintf("pod-%04d", i), "default").Build())
+	}
+	rv.unlistedPods = 1500
+	rv.updateTableWithPods(pods)
+	if header := rv.renderHeader(); !strings.Contains(header, "(showing 500 of 2,000)") {
+		t.Errorf("Expected the pods left unlisted in the header, got:\n%s", header)
+	}
+	rv.unlistedPods = -1
+	if header := rv.renderHeader(); !strings.Contains(header, "(showing the first 500)") {
+		t.Errorf("Expected the first pods noted when the total is unknown, got:\n%s", header)
+	}
+}
+
+func TestResourceViewFilterAndSortBeforeTruncation(t *testing.T) {
+	state := createTestState(core.ResourceTypePod, "default", "")
+	rv := NewResourceView(state, nil)
+	rv.SetSize(200, 40)
+	rv.SetMaxResources(500)
+
+	// 1,000 pods, listed newest first; pod-0900 is the one being looked for
+	var pods []v1.Pod
+	for i := 999; i >= 0; i-- {
+		pod := fixtures.NewPod(fmt.Sprintf("pod-%04d", i), "default").Build()
+		pod.Spec.NodeName = fmt.Sprintf("worker-%d", i%3)
+		pods = append(pods, pod)
+	}
+	pods[99].Spec.NodeName = "worker-special"
+	pods[0].Spec.NodeName = "worker-special"
+	shown := func(name string) bool {
+		for _, row := range rv.table.Values() {
+			if rv.rowName(row) == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	rv.updateTableWithPods(pods)
+	if shown("pod-0900") {
+		t.Fatal("Expected pod-0900 cut from the unfiltered list sorted by name")
+	}
+	if header := rv.renderHeader(); !strings.Contains(header, "(showing 500 of 1,000)") {
+		t.Errorf("Expected the truncation in the header, got:\n%s", header)
+	}
+
+	// A filter runs on all 1,000 pods, not on the 500 shown
+	state.SetFilter("node=worker-special", "")
+	rv.updateTableWithPods(pods)
+	if rv.table.GetRowCount() != 2 || !shown("pod-0900") {
+		t.Fatalf("Expected the filter to find pod-0900, got %d rows", rv.table.GetRowCount())
+	}
+	if header := rv.renderHeader(); strings.Contains(header, "showing") {
+		t.Errorf("Expected no truncation once filtered, got:\n%s", header)
+	}
+
+	// So does the sort: descending by name puts the last pods first
+	state.SetFilter("worker-", "")
+	state.SetSortState("NAME", false)
+	rv.updateTableWithPods(pods)
+	if rv.rowName(rv.table.RowValues(0)) != "pod-0999" || !shown("pod-0900") {
+		t.Errorf("Expected the sort over every pod, got %s first", rv.rowName(rv.table.RowValues(0)))
+	}
+	if header := rv.renderHeader(); !strings.Contains(header, "(showing 500 of 1,000 matching)") {
+		t.Errorf("Expected the post-filter total in the header, got:\n%s", header)
+	}
+}
+
+func TestResourceViewPushesFilterToServer(t *testing.T) {
+	var selectors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		selectors = append(selectors, r.URL.Query().Get("fieldSelector"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[{"metadata":{"name":"web","namespace":"default"},"spec":{"nodeName":"worker-1"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	state := createTestState(core.ResourceTypePod, "default", "")
+	rv := NewResourceView(state, client)
+	rv.SetSize(200, 30)
+
+	state.SetFilter("node=Worker-1 status!=Failed web", "")
+	rv.RefreshResources()()
+	if !slices.Contains(selectors, "spec.nodeName=worker-1") {
+		t.Errorf("Expected the node term pushed to the server, got %q", selectors)
+	}
+	if rv.table.GetRowCount() != 1 || rv.rowName(rv.table.RowValues(0)) != "web" {
+		t.Error("Expected the pod listed")
+	}
+
+	// A global filter pods lack a column of does not apply to them at all
+	selectors = nil
+	state.SetGlobalFilter("node=worker-1 type=ClusterIP")
+	rv.RefreshResources()()
+	if len(selectors) == 0 || selectors[len(selectors)-1] != "" {
+		t.Errorf("Expected no field selector for a global filter pods cannot match, got %q", selectors)
+	}
+}
+
+func TestResourceViewFilter(t *testing.T) {
+	tests := []struct {
+		name         string
+		filter       string
+		savedFilter  string
+		global       bool
+		expectNames  []string
+		expectHeader string
+	}{
+		{"no filter", "", "", false, []string{"api-1", "web-1", "web-2"}, ""},
+		{"bare text", "web", "", false, []string{"web-1", "web-2"}, "Filter: web (1 hidden)"},
+		{"column equals", "status=Pending", "", false, []string{"web-2"}, "Filter: status=Pending (2 hidden)"},
+		{"saved filter shows name", "status!=Pending", "healthy", false, []string{"api-1", "web-1"}, "Filter: [healthy] (1 hidden)"},
+		{"unknown column hides all", "zone=a", "", false, nil, "(3 hidden)"},
+		{"global filter", "status=Pending", "", true, []string{"web-2"}, "Filter: status=Pending (all types) (2 hidden)"},
+		{"global filter without its column", "zone=a", "", true, []string{"api-1", "web-1", "web-2"}, "Filter: zone=a (all types) (not applied: no ZONE column)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &core.State{
+				CurrentResourceType: core.ResourceTypePod,
+				CurrentNamespace:    "default",
+				SortColumn:          "NAME",
+				SortAscending:       true,
+			}
+			if tt.global {
+				state.SetGlobalFilter(tt.filter)
+			} else {
+				state.SetFilter(tt.filter, tt.savedFilter)
+			}
+			rv := NewResourceView(state, nil)
+			rv.SetSize(200, 40)
+
+			pods := []v1.Pod{
+				fixtures.NewPod("web-2", "default").WithPhase(v1.PodPending).WithNode("").Build(),
+				fixtures.NewPod("api-1", "default").Build(),
+				fixtures.NewPod("web-1", "default").Build(),
+			}
+			rv.updateTableWithPods(pods)
+
+			var names []string
+			for i := range rv.table.Values() {
+				names = append(names, rv.resourceMap[i].Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expectNames, ",") {
+				t.Errorf("Expected rows %v, got %v", tt.expectNames, names)
+			}
+
+			header := rv.renderHeader()
+			if tt.expectHeader == "" {
+				if strings.Contains(header, "Filter:") {
+					t.Errorf("Expected no filter in header, got %q", header)
+				}
+			} else if !strings.Contains(header, tt.expectHeader) {
+				t.Errorf("Expected header to contain %q, got %q", tt.expectHeader, header)
+			}
+		})
+	}
+}
+
+// TestResourceViewPreviewFilter tests that the filter bar's expression
+// filters the last update's rows as it is typed, and that ending the preview
+// goes back to the state's filter
+func TestResourceViewPreviewFilter(t *testing.T) {
+	state := &core.State{
+		CurrentResourceType: core.ResourceTypePod,
+		CurrentNamespace:    "default",
+		SortColumn:          "NAME",
+		SortAscending:       true,
+	}
+	state.SetFilter("status=Running", "")
+	rv := NewResourceView(state, nil)
+	rv.SetSize(200, 40)
+	rv.updateTableWithPods([]v1.Pod{
+		fixtures.NewPod("web-2", "default").WithPhase(v1.PodPending).WithNode("").Build(),
+		fixtures.NewPod("api-1", "default").Build(),
+		fixtures.NewPod("web-1", "default").Build(),
+	})
+	rows := func() string {
+		var names []string
+		for i := range rv.table.Values() {
+			names = append(names, rv.resourceMap[i].Name)
+		}
+		return strings.Join(names, ",")
+	}
+	if got := rows(); got != "api-1,web-1" {
+		t.Fatalf("Expected the state's filter applied, got %s", got)
+	}
+
+	for _, step := range []struct{ expression, expected string }{
+		{"we", "web-1,web-2"},
+		{"web-2", "web-2"},
+		{"web-", "web-1,web-2"},
+		{"", "api-1,web-1,web-2"},
+	} {
+		filter, _ := core.ParseFilter(step.expression)
+		rv.PreviewFilter(filter)
+		if got := rows(); got != step.expected {
+			t.Errorf("Expected %q to preview %s, got %s", step.expression, step.expected, got)
+		}
+	}
+
+	filter, _ := core.ParseFilter("web-2")
+	rv.PreviewFilter(filter)
+	if header := rv.renderHeader(); !strings.Contains(header, "Filter: web-2 (2 hidden)") {
+		t.Errorf("Expected the previewed filter in the header, got %q", header)
+	}
+
+	rv.EndFilterPreview()
+	if got := rows(); got != "api-1,web-1" {
+		t.Errorf("Expected the state's filter back, got %s", got)
+	}
+}
+
+func TestResourceViewColumns(t *testing.T) {
+	rv := NewResourceView(&core.State{CurrentResourceType: core.ResourceTypePod}, nil)
+
+	columns := rv.Columns(core.ResourceTypeService, "default")
+	if strings.Join(columns, ",") != "NAME,TYPE,CLUSTER-IP,EXTERNAL-IP,PORT(S),AGE" {
+		t.Errorf("Unexpected service columns %v", columns)
+	}
+	if columns := rv.Columns(core.ResourceTypeService, ""); columns[1] != "NAMESPACE" {
+		t.Errorf("Expected NAMESPACE column across all namespaces, got %v", columns)
+	}
+	// Asking about another type does not change what the list shows
+	if rv.table.Titles()[len(rv.table.Titles())-1] != "NODE" {
+		t.Errorf("Expected pod headers to be unchanged, got %v", rv.table.Titles())
+	}
+}
+
+func TestResourceViewClockSkewWarning(t *testing.T) {
+	defer core.SetAgeOffset(0)
+
+	for _, correct := range []bool{false, true} {
+		t.Run(fmt.Sprintf("correct=%v", correct), func(t *testing.T) {
+			core.SetAgeOffset(0)
+			state := &core.State{
+				CurrentResourceType: core.ResourceTypePod,
+				CurrentNamespace:    "default",
+				SortColumn:          "NAME",
+				SortAscending:       true,
+			}
+			rv := NewResourceView(state, nil)
+			rv.SetSize(300, 40)
+			rv.SetClockSkewCorrection(correct)
+
+			// The cluster runs two minutes ahead, so fresh pods are dated in our future
+			created := metav1.NewTime(time.Now().Add(2 * time.Minute))
+			var pods []v1.Pod
+			for _, name := range []string{"web-1", "web-2", "web-3"} {
+				pod := fixtures.NewPod(name, "default").Build()
+				pod.CreationTimestamp = created
+				pods = append(pods, pod)
+			}
+			state.UpdatePods(pods)
+			rv.checkClockSkew()
+			rv.updateTableWithPods(pods)
+
+			view := rv.View()
+			if !strings.Contains(view, "Cluster clock is 2m0s ahead") {
+				t.Errorf("Expected clock skew warning in view, got:\n%s", view)
+			}
+			if correct != (core.AgeOffset() == 2*time.Minute) {
+				t.Errorf("Expected correction applied=%v, got offset %s", correct, core.AgeOffset())
+			}
+		})
+	}
+}
+
+func TestResourceViewRefreshError(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "default", ""), nil)
+	rv.SetSize(300, 40)
+	rv.SetRefreshInterval(8 * time.Second)
+
+	unreachable := &k8s.Error{
+		Kind:     k8s.ErrConnectionFailed,
+		Op:       k8s.OpList,
+		Resource: "pods",
+		Server:   "https://10.0.0.1:6443",
+		Err:      errors.New("connection refused"),
+	}
+	msg := rv.refreshFailed(unreachable)
+	if _, ok := msg.(refreshFailedMsg); !ok {
+		t.Fatalf("Expected refreshFailedMsg, got %T", msg)
+	}
+
+	view := rv.View()
+	if !strings.Contains(view, "API server unreachable at https://10.0.0.1:6443 — retrying in 8s") {
+		t.Errorf("Expected the refresh error in the header, got:\n%s", view)
+	}
+
+	rv.markRefreshed()
+	if strings.Contains(rv.View(), "unreachable") {
+		t.Error("Expected a successful refresh to clear the error")
+	}
+
+	// Errors from other actions show as a notice
+	rv.Update(errMsg{errors.New("delete failed")})
+	if !strings.Contains(rv.View(), "✗ delete failed") {
+		t.Error("Expected the action error as a notice")
+	}
+}
+
+func TestResourceViewStaleDataBanner(t *testing.T) {
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			http.Error(w, "apiserver is restarting", http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path != "/api/v1/namespaces/default/pods" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[{"metadata":{"name":"web","namespace":"default"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "default", ""), client)
+	rv.SetSize(200, 30)
+	rv.SetRefreshInterval(time.Second)
+
+	if msg := rv.RefreshResources()(); msg != (refreshCompleteMsg{}) {
+		t.Fatalf("Expected the first refresh to succeed, got %#v", msg)
+	}
+	if strings.Contains(rv.View(), "DATA STALE") {
+		t.Fatal("Expected no stale banner right after a refresh")
+	}
+
+	// The client starts failing; within the stale threshold only the error shows
+	failing.Store(true)
+	if _, ok := rv.RefreshResources()().(refreshFailedMsg); !ok {
+		t.Fatal("Expected the refresh to fail")
+	}
+	if strings.Contains(rv.View(), "DATA STALE") {
+		t.Error("Expected no stale banner before three intervals pass")
+	}
+
+	// Three intervals later the rows are kept but flagged
+	rv.mu.Lock()
+	rv.lastRefresh = time.Now().Add(-5 * time.Second)
+	rv.mu.Unlock()
+	rv.RefreshResources()()
+	view := rv.View()
+	if !strings.Contains(view, "⚠ DATA STALE — last update 5s ago, reconnecting…") {
+		t.Errorf("Expected the stale banner, got:\n%s", view)
+	}
+	if !strings.Contains(view, "web") {
+		t.Error("Expected the last rows to stay while stale")
+	}
+
+	// Retries back off: the second failure in a row waits two intervals
+	if rv.RetryDue() {
+		t.Error("Expected the next tick to be skipped while backing off")
+	}
+	if !rv.RetryDue() {
+		t.Error("Expected the tick after that to retry")
+	}
+
+	// The banner clears on the next successful update
+	failing.Store(false)
+	if msg := rv.RefreshResources()(); msg != (refreshCompleteMsg{}) {
+		t.Fatalf("Expected the refresh to recover, got %#v", msg)
+	}
+	if view := rv.View(); strings.Contains(view, "DATA STALE") || strings.Contains(view, "✗") {
+		t.Errorf("Expected the banner and error cleared, got:\n%s", view)
+	}
+	if !rv.RetryDue() {
+		t.Error("Expected a success to reset the backoff")
+	}
+}
+
+func TestRetryBackoffIntervals(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		failures int
+		expected int
+	}{
+		{2 * time.Second, 1, 1},
+		{2 * time.Second, 2, 2},
+		{2 * time.Second, 4, 8},
+		{2 * time.Second, 10, 30}, // capped at a minute
+		{2 * time.Minute, 3, 1},   // intervals longer than the cap never back off
+	}
+	for _, tt := range tests {
+		if got := retryBackoffIntervals(tt.interval, tt.failures); got != tt.expected {
+			t.Errorf("retryBackoffIntervals(%s, %d) = %d, want %d", tt.interval, tt.failures, got, tt.expected)
+		}
+	}
+}
+
+func TestResourceViewStaleContexts(t *testing.T) {
+	state := createTestState(core.ResourceTypePod, "default", "")
+	state.CurrentContexts = []string{"prod-eu", "prod-us", "staging"}
+	rv := NewResourceView(state, nil)
+	rv.isMultiContext = true
+	rv.SetSize(200, 30)
+	rv.SetRefreshInterval(time.Minute)
+	rv.SetStaleAfter(2)
+
+	// prod-us answered, the others did not
+	partial := fmt.Errorf("errors from 2 contexts: %w", errors.Join(
+		&k8s.ContextError{Context: "prod-eu", Err: errors.New("connection refused")},
+		&k8s.ContextError{Context: "staging", Err: errors.New("connection refused")},
+	))
+	failed, ok := rv.failedContexts(partial)
+	if !ok || !failed["prod-eu"] || !failed["staging"] || failed["prod-us"] {
+		t.Fatalf("Expected prod-eu and staging to have failed, got %v (ok=%v)", failed, ok)
+	}
+	if _, ok := rv.failedContexts(errors.New("boom")); ok {
+		t.Error("Expected an error naming no context to fail the refresh as a whole")
+	}
+
+	rv.markRefreshed()
+	rv.mu.Lock()
+	rv.contextRefreshed["prod-eu"] = time.Now().Add(-12 * time.Minute)
+	rv.contextRefreshed["staging"] = time.Now().Add(-3 * time.Minute)
+	rv.mu.Unlock()
+	rv.markRefreshedExcept(failed, partial)
+
+	view := rv.View()
+	if !strings.Contains(view, "DATA STALE in prod-eu (last update 12m ago), staging (last update 3m ago)") {
+		t.Errorf("Expected the stale contexts oldest first, got:\n%s", view)
+	}
+	if strings.Contains(view, "prod-us (") {
+		t.Error("Expected prod-us, which answered, not to be stale")
+	}
+
+	rv.markRefreshed()
+	if strings.Contains(rv.View(), "DATA STALE") {
+		t.Error("Expected the banner to clear once every context answers")
+	}
+}
+
+func TestResourceViewSecurityColumn(t *testing.T) {
+	rv := NewResourceView(createTestState(core.ResourceTypePod, "payments", ""), nil)
+	rv.SetSize(250, 20)
+	privileged := true
+	pods := []v1.Pod{
+		fixtures.NewPod("agent", "payments").Build(),
+		fixtures.NewPod("web", "payments").Build(),
+	}
+	pods[0].Spec.HostNetwork = true
+	pods[0].Spec.Containers[0].SecurityContext = &v1.SecurityContext{Privileged: &privileged}
+	pods[1].Spec.Containers[0].SecurityContext = &v1.SecurityContext{}
+
+	rv.updateTableWithPods(pods)
+	if strings.Contains(strings.Join(rv.table.Titles(), " "), "SECURITY") {
+		t.Fatal("Expected the security column off by default")
+	}
+
+	if !rv.ToggleSecurityColumn() {
+		t.Fatal("Expected the toggle to turn the column on")
+	}
+	rv.updateTableWithPods(pods)
+	if rv.table.Titles()[len(rv.table.Titles())-1] != "SECURITY" {
+		t.Fatalf("Expected SECURITY as the last column, got %v", rv.table.Titles())
+	}
+	cells := map[string]string{}
+	for _, row := range rv.table.Values() {
+		cells[row[0]] = row[len(row)-1]
+	}
+	if cells["agent"] != "PRIV,HOSTNET" || cells["web"] != "-" {
+		t.Errorf("Expected agent flagged and web clean, got %v", cells)
+	}
+
+	// The namespace's enforced level shows beside it in the header
+	rv.SetNamespacePodSecurity("payments", core.PodSecurityLabels{Enforce: core.PodSecurityBaseline, Warn: core.PodSecurityRestricted})
+	if view := rv.View(); !strings.Contains(view, "[PSS: baseline (warn: restricted)]") {
+		t.Errorf("Expected the namespace security badge in the header, got:\n%s", view)
+	}
+}
+
+func TestPodReadyCell(t *testing.T) {
+	tests := []struct {
+		name     string
+		ready    int
+		total    int
+		notReady []string
+		expected string
+	}{
+		{"few containers", 1, 3, []string{"proxy", "metrics"}, "1/3"},
+		{"many containers", 13, 15, []string{"proxy", "metrics"}, "13/15 ✖proxy,metrics"},
+		{"all ready", 15, 15, nil, "15/15"},
+		{"names too long", 12, 15, []string{"model-server", "feature-cache", "proxy"}, "12/15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := podReadyCell(tt.ready, tt.total, tt.notReady); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestResourceViewNamesUnreadyContainers(t *testing.T) {
+	rv := createTestResourceView(t)
+	rv.SetSize(160, 24)
+
+	statuses := func(n int, unready ...string) []v1.ContainerStatus {
+		var list []v1.ContainerStatus
+		for i := 0; i < n; i++ {
+			list = append(list, v1.ContainerStatus{Name: fmt.Sprintf("worker-%d", i), Ready: true})
+		}
+		for _, name := range unready {
+			list = append(list, v1.ContainerStatus{Name: name})
+		}
+		return list
+	}
+	trainer := fixtures.NewPod("trainer-0", "default").Build()
+	trainer.Status.ContainerStatuses = statuses(13, "proxy", "metrics")
+	web := fixtures.NewPod("web-0", "default").Build()
+	web.Status.ContainerStatuses = statuses(1, "proxy")
+	rv.updateTableWithPods([]v1.Pod{trainer, web})
+
+	if ready := rv.GetSelectedResourceColumn("READY"); ready != "13/15 ✖proxy,metrics" {
+		t.Errorf("Expected the unready names in READY, got %q", ready)
+	}
+	if view := rv.View(); !strings.Contains(view, "trainer-0 — not ready: proxy, metrics") {
+		t.Errorf("Expected the unready containers under the header, got:\n%s", view)
+	}
+
+	// Pods with few containers keep the plain count and no detail line
+	rv.SetSelectedRow(1)
+	if ready := rv.GetSelectedResourceColumn("READY"); ready != "1/2" {
+		t.Errorf("Expected a plain count, got %q", ready)
+	}
+	if view := rv.View(); strings.Contains(view, "not ready") {
+		t.Errorf("Expected no detail line for a small pod, got:\n%s", view)
+	}
+}
+
+// podRowCell returns a column of the row whose NAME, NAMESPACE and CONTEXT
+// (when shown) match
+func podRowCell(rv *ResourceView, contextName, namespace, name, column string) string {
+	index := make(map[string]int)
+	for i, header := range rv.table.Titles() {
+		index[header] = i
+	}
+	for _, row := range rv.table.Values() {
+		if row[index["NAME"]] != name || row[index["NAMESPACE"]] != namespace {
+			continue
+		}
+		if i, ok := index["CONTEXT"]; ok && row[i] != contextName {
+			continue
+		}
+		return row[index[column]]
+	}
+	return ""
+}
+
+func TestResourceViewPodMetricsKeyedByNamespace(t *testing.T) {
+	rv := createTestResourceView(t)
+	rv.state.CurrentNamespace = "" // All namespaces shows the NAMESPACE column
+	rv.podMetrics = map[string]k8s.PodMetricsSet{"": {
+		{Namespace: "prod", Name: "web"}:    {Name: "web", Namespace: "prod", CPU: "900m", Memory: "2Gi"},
+		{Namespace: "staging", Name: "web"}: {Name: "web", Namespace: "staging", CPU: "10m", Memory: "64Mi"},
+	}}
+
+	rv.updateTableWithPods([]v1.Pod{
+		fixtures.NewPod("web", "prod").Build(),
+		fixtures.NewPod("web", "staging").Build(),
+		fixtures.NewPod("web", "dev").Build(),
+	})
+
+	if cpu := podRowCell(rv, "", "prod", "web", "CPU"); cpu != "900m" {
+		t.Errorf("Expected prod/web CPU 900m, got %q", cpu)
+	}
+	if mem := podRowCell(rv, "", "staging", "web", "MEMORY"); mem != "64Mi" {
+		t.Errorf("Expected staging/web memory 64Mi, got %q", mem)
+	}
+	if cpu := podRowCell(rv, "", "dev", "web", "CPU"); cpu != "-" {
+		t.Errorf("Expected no metrics for dev/web, got %q", cpu)
+	}
+}
+
+func TestResourceViewPodMetricsKeyedByContext(t *testing.T) {
+	rv := createTestResourceView(t)
+	rv.state.CurrentNamespace = ""
+	rv.state.CurrentContexts = []string{"east", "west", "north"}
+	rv.isMultiContext = true
+	rv.podMetrics = map[string]k8s.PodMetricsSet{
+		"east": {{Namespace: "prod", Name: "web"}: {Name: "web", Namespace: "prod", CPU: "250m", Memory: "512Mi"}},
+		"west": {{Namespace: "prod", Name: "web"}: {Name: "web", Namespace: "prod", CPU: "3", Memory: "4Gi"}},
+	}
+
+	pod := fixtures.NewPod("web", "prod").Build()
+	rv.updateTableWithPodsMultiContext([]k8s.PodWithContext{
+		{Context: "east", Pod: pod},
+		{Context: "west", Pod: pod},
+		{Context: "north", Pod: pod},
+	})
+
+	if cpu := podRowCell(rv, "east", "prod", "web", "CPU"); cpu != "250m" {
+		t.Errorf("Expected east CPU 250m, got %q", cpu)
+	}
+	if mem := podRowCell(rv, "west", "prod", "web", "MEMORY"); mem != "4Gi" {
+		t.Errorf("Expected west memory 4Gi, got %q", mem)
+	}
+	if cpu := podRowCell(rv, "north", "prod", "web", "CPU"); cpu != "-" {
+		t.Errorf("Expected no metrics for a context without them, got %q", cpu)
+	}
+}
+
+func TestResourceViewPodMetricsHeadline(t *testing.T) {
+	rv := createTestResourceView(t)
+	rv.SetSize(160, 24)
+	rv.podMetrics = map[string]k8s.PodMetricsSet{"": {
+		{Namespace: "default", Name: "web-0"}: {Name: "web-0", Namespace: "default", CPU: "425m", Memory: "336Mi", Containers: []k8s.ContainerMetrics{
+			{Name: "istio-proxy", CPU: "300m", Memory: "64Mi", MilliCPU: 300, MemoryBytes: 64 * k8s.Mi},
+			{Name: "app", CPU: "120m", Memory: "256Mi", MilliCPU: 120, MemoryBytes: 256 * k8s.Mi},
+			{Name: "fluent-bit", CPU: "5m", Memory: "16Mi", MilliCPU: 5, MemoryBytes: 16 * k8s.Mi},
+		}},
+	}}
+	pods := []v1.Pod{fixtures.NewPod("web-0", "default").Build()}
+
+	// The default sums the containers, with no breakdown
+	rv.updateTableWithPods(pods)
+	if cpu := rv.GetSelectedResourceColumn("CPU"); cpu != "425m" {
+		t.Errorf("Expected the pod total, got %q", cpu)
+	}
+	if view := rv.View(); strings.Contains(view, "istio-proxy 300m/64Mi") {
+		t.Errorf("Expected no breakdown while showing totals, got:\n%s", view)
+	}
+
+	rv.SetMetricsHeadline(k8s.HeadlineMain, []string{"istio-proxy", "fluent-*"})
+	rv.updateTableWithPods(pods)
+	if cpu := rv.GetSelectedResourceColumn("CPU"); cpu != "120m +2 more" {
+		t.Errorf("Expected the main container's CPU, got %q", cpu)
+	}
+	if mem := rv.GetSelectedResourceColumn("MEMORY"); mem != "256Mi +2 more" {
+		t.Errorf("Expected the main container's memory, got %q", mem)
+	}
+	if view := rv.View(); !strings.Contains(view, "web-0 — istio-proxy 300m/64Mi, app 120m/256Mi, fluent-bit 5m/16Mi") {
+		t.Errorf("Expected the per-container breakdown under the header, got:\n%s", view)
+	}
+
+	rv.SetMetricsHeadline(k8s.HeadlineLargest, nil)
+	rv.updateTableWithPods(pods)
+	if cpu := rv.GetSelectedResourceColumn("CPU"); cpu != "300m +2 more" {
+		t.Errorf("Expected the largest container's CPU, got %q", cpu)
+	}
+
+	if m := rv.SelectedPodMetrics(); m == nil || len(m.Containers) != 3 {
+		t.Errorf("Expected the selected pod's metrics, got %+v", m)
+	}
+}
+
+func TestResourceViewShowsChosenColumns(t *testing.T) {
+	rv := createTestResourceView(t)
+	rv.SetSize(120, 20)
+	rv.SetTestData([]string{"NAME", "READY", "STATUS", "RESTARTS", "AGE"}, [][]string{
+		{"checkout-7f9c", "0/1", "CrashLoopBackOff", "42", "1h"},
+	})
+
+	rv.state.SetColumns([]string{"STATUS", "RESTARTS"})
+	table := rv.renderCustomTable()
+	for _, want := range []string{"NAME", "STATUS", "RESTARTS", "checkout-7f9c", "CrashLoopBackOff"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("Expected %q in the table, got:\n%s", want, table)
+		}
+	}
+	for _, hidden := range []string{"READY", "AGE", "0/1"} {
+		if strings.Contains(table, hidden) {
+			t.Errorf("Expected %q hidden, got:\n%s", hidden, table)
+		}
+	}
+
+	// Columns this table does not have leave it whole
+	rv.state.SetColumns([]string{"DATA"})
+	if table := rv.renderCustomTable(); !strings.Contains(table, "READY") {
+		t.Errorf("Expected every column when none chosen exist, got:\n%s", table)
+	}
+}
+
+func TestDeploymentImageChangeDetail(t *testing.T) {
+	state := core.NewState(&core.Config{CurrentNamespace: "prod"})
+	state.SetResourceType(core.ResourceTypeDeployment)
+	rv := NewResourceView(state, nil)
+	rv.SetSize(160, 20)
+
+	deployment := func(image string) []appsv1.Deployment {
+		return []appsv1.Deployment{fixtures.NewDeployment("web", "prod").WithUID("web-uid").WithImage(image).Build()}
+	}
+	for _, image := range []string{"registry/web:v1.41.2", "registry/web:v1.42.0"} {
+		deployments := deployment(image)
+		state.UpdateDeployments(deployments)
+		rv.updateTableWithDeployments(deployments)
+	}
+
+	if header := rv.renderHeader(); !strings.Contains(header, "web — image changed 0s ago: v1.41.2 → v1.42.0") {
+		t.Errorf("Expected the latest image change under the header, got:\n%s", header)
+	}
+}
+
+func TestSameNamedResourcesAcrossNamespaces(t *testing.T) {
+	state := core.NewState(&core.Config{CurrentNamespace: ""})
+	state.SetResourceType(core.ResourceTypePod)
+	rv := NewResourceView(state, nil)
+	rv.SetSize(160, 20)
+
+	var pods []v1.Pod
+	for _, namespace := range []string{"alpha", "beta", "gamma"} {
+		pods = append(pods, fixtures.NewPod("web", namespace).WithUID("web-"+namespace).Build())
+	}
+	state.UpdatePods(pods)
+	rv.updateTableWithPods(pods)
+
+	// A bare name is ambiguous: nothing is picked for the user
+	rv.SetSelectedRow(0)
+	before := rv.SelectedResourceRef()
+	rv.SelectAfterRefresh(core.ResourceRef{Name: "web"})
+	msg, ok := rv.refreshComplete().(AmbiguousSelectionMsg)
+	if !ok || len(msg.Matches) != 3 {
+		t.Fatalf("Expected an ambiguous selection of 3 pods, got %#v", msg)
+	}
+	if rv.SelectedResourceRef() != before {
+		t.Errorf("Expected the selection left alone, got %v", rv.SelectedResourceRef())
+	}
+	if rv.SelectResource(core.ResourceRef{Name: "web"}) {
+		t.Error("Expected SelectResource to refuse a bare name matching three pods")
+	}
+
+	// A qualified reference picks exactly one, after a refresh or right away
+	rv.SelectAfterRefresh(core.ParseResourceRef("beta/web"))
+	if msg := rv.refreshComplete(); msg != (refreshCompleteMsg{}) {
+		t.Errorf("Expected beta/web to be unambiguous, got %#v", msg)
+	}
+	if ref := rv.SelectedResourceRef(); ref.Namespace != "beta" || rv.GetSelectedResourceNamespace() != "beta" {
+		t.Errorf("Expected beta/web selected, got %v", ref)
+	}
+	if !rv.SelectResource(core.ResourceRef{Namespace: "gamma", Name: "web"}) || rv.GetSelectedResourceNamespace() != "gamma" {
+		t.Errorf("Expected gamma/web selected, got %v", rv.SelectedResourceRef())
+	}
+
+	// Comparisons tell the pods apart by namespace
+	names := rv.ResourceNames()
+	for _, want := range []string{"alpha/web", "beta/web", "gamma/web"} {
+		if !names[want] {
+			t.Errorf("Expected %q among %v", want, names)
+		}
+	}
+
+	// The filter accepts namespace/name
+	state.SetFilter("gamma/web", "")
+	rv.updateTableWithPods(pods)
+	if rv.table.GetRowCount() != 1 || rv.GetSelectedResourceNamespace() != "gamma" {
+		t.Errorf("Expected only gamma/web listed, got %d rows", rv.table.GetRowCount())
+	}
+}
+
+func TestSameNamedDeploymentsKeepSelection(t *testing.T) {
+	state := core.NewState(&core.Config{CurrentNamespace: ""})
+	state.SetResourceType(core.ResourceTypeDeployment)
+	rv := NewResourceView(state, nil)
+	rv.SetSize(160, 20)
+
+	var deployments []appsv1.Deployment
+	for _, namespace := range []string{"alpha", "beta", "gamma"} {
+		deployments = append(deployments, fixtures.NewDeployment("web", namespace).Build())
+	}
+	rv.updateTableWithDeployments(deployments)
+	if !rv.SelectResource(core.ResourceRef{Namespace: "beta", Name: "web"}) {
+		t.Fatal("Expected beta/web to be selectable")
+	}
+
+	// Deployment rows carry no identity; the refresh keeps the namespace
+	rv.updateTableWithDeployments(deployments)
+	if namespace := rv.GetSelectedResourceNamespace(); namespace != "beta" {
+		t.Errorf("Expected beta/web still selected after a refresh, got %q", namespace)
+	}
+}
+
+func TestResourceViewHidesNoise(t *testing.T) {
+	rv := createTestResourceView(t)
+	rv.SetSize(200, 24)
+	pod := func(name string, phase v1.PodPhase) v1.Pod {
+		return fixtures.NewPod(name, "default").WithPhase(phase).CreatedAgo(time.Hour).Build()
+	}
+	pods := []v1.Pod{
+		pod("api", v1.PodRunning),
+		pod("backup-1", v1.PodSucceeded),
+		pod("backup-2", v1.PodSucceeded),
+		pod("worker", v1.PodRunning),
+	}
+	rv.state.UpdatePods(pods)
+	rv.updateTableWithPods(pods)
+	if rows := rv.table.GetRowCount(); rows != 4 {
+		t.Fatalf("Expected every pod listed while noise is shown, got %d", rows)
+	}
+
+	// Hiding the noise moves the selection off a completed pod to the
+	// nearest pod that stays
+	rv.SetSelectedRow(2)
+	if name := rv.GetSelectedResourceName(); name != "backup-2" {
+		t.Fatalf("Expected backup-2 selected, got %q", name)
+	}
+	rv.SetHideNoise(true)
+	rv.updateTableWithPods(pods)
+	if rows := rv.table.GetRowCount(); rows != 2 {
+		t.Fatalf("Expected the completed pods hidden, got %d rows", rows)
+	}
+	if name := rv.GetSelectedResourceName(); name != "worker" {
+		t.Errorf("Expected the selection moved to worker, got %q", name)
+	}
+	view := rv.View()
+	if !strings.Contains(view, "Count: 2") || !strings.Contains(view, "hiding 2 completed") {
+		t.Errorf("Expected the count to leave out the hidden pods, got:\n%s", view)
+	}
+
+	// The user's filter applies to what is left
+	rv.state.SetFilter("NAME=api", "")
+	rv.updateTableWithPods(pods)
+	if rows := rv.table.GetRowCount(); rows != 1 || rv.filterHidden != 1 {
+		t.Errorf("Expected the filter to hide worker only, got %d rows and %d filtered", rows, rv.filterHidden)
+	}
+	rv.state.SetFilter("", "")
+
+	rv.SetHideNoise(false)
+	rv.updateTableWithPods(pods)
+	if rows := rv.table.GetRowCount(); rows != 4 {
+		t.Errorf("Expected the completed pods back, got %d rows", rows)
+	}
+	if strings.Contains(rv.View(), "hiding") {
+		t.Error("Expected no noise summary once noise is shown")
+	}
+}
+
+func TestResourceViewNoiseRulesByResourceType(t *testing.T) {
+	rv := createTestResourceView(t)
+	rv.SetSize(200, 24)
+	rv.state.SetResourceType(core.ResourceTypeDeployment)
+	deployments := []appsv1.Deployment{
+		fixtures.NewDeployment("idle", "default").WithReplicas(0).Build(),
+		fixtures.NewDeployment("web", "default").WithReplicas(2).Build(),
+	}
+	rv.SetHideNoise(true)
+
+	// Deployments are not noise under the default rules
+	rv.updateTableWithDeployments(deployments)
+	if rows := rv.table.GetRowCount(); rows != 2 {
+		t.Fatalf("Expected both deployments with the default rules, got %d", rows)
+	}
+
+	rules := core.DefaultNoiseRules()
+	rules.ScaledToZeroDeployments = true
+	rv.SetNoiseRules(rules)
+	rv.updateTableWithDeployments(deployments)
+	if rows := rv.table.GetRowCount(); rows != 1 || rv.GetSelectedResourceName() != "web" {
+		t.Errorf("Expected only web listed, got %d rows", rows)
+	}
+	if !strings.Contains(rv.View(), "hiding 1 scaled to zero") {
+		t.Error("Expected the scaled-down deployment counted as hidden")
+	}
+}
+
+func TestResourceViewDropsStaleLists(t *testing.T) {
+	pod := func(contextName, name, uid string) k8s.PodWithContext {
+		return k8s.PodWithContext{Context: contextName, Pod: fixtures.NewPod(name, "default").WithUID(uid).Build()}
+	}
+	rowNames := func(rv *ResourceView) []string {
+		var names []string
+		for i := 0; i < rv.table.GetRowCount(); i++ {
+			values := rv.table.RowValues(i)
+			names = append(names, values[0]+"/"+rv.rowName(values))
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	state := core.NewState(&core.Config{CurrentNamespace: "default"})
+	state.SetCurrentContexts([]string{"east"})
+	rv := NewResourceView(state, nil)
+	rv.isMultiContext = true
+	rv.showContextColumn = true
+	rv.SetSize(200, 30)
+
+	before := state.Generation()
+	staleList := []k8s.PodWithContext{pod("east", "web-1", "uid-1"), pod("east", "web-2", "uid-2")}
+	if !rv.applyMultiContextPods(before, staleList, nil) {
+		t.Fatal("Expected a list of the current generation applied")
+	}
+
+	// Lists of the old contexts keep landing while the switch happens
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rv.applyMultiContextPods(before, slices.Clone(staleList), nil)
+		}()
+	}
+	state.SetCurrentContexts([]string{"east", "west"})
+	after := state.Generation()
+
+	// web-2 was deleted; overlapping lists of east report web-1 twice
+	list := []k8s.PodWithContext{pod("east", "web-1", "uid-1"), pod("west", "api-1", "uid-9"), pod("east", "web-1", "uid-1")}
+	if !rv.applyMultiContextPods(after, list, nil) {
+		t.Fatal("Expected the new generation's list applied")
+	}
+	wg.Wait()
+
+	// And after it
+	if rv.applyMultiContextPods(before, staleList, nil) {
+		t.Error("Expected a list from before the switch dropped")
+	}
+
+	want := []string{"east/web-1", "west/api-1"}
+	if got := rowNames(rv); !slices.Equal(got, want) {
+		t.Errorf("Expected rows %v with no duplicates or deleted pods, got %v", want, got)
+	}
+	if pods := state.ContextPods("east"); len(pods) != 1 || pods[0].Name != "web-1" {
+		t.Errorf("Expected east's pods replaced by the new list, got %d", len(pods))
+	}
+}
+
+func TestResourceViewDropsListAfterTypeSwitch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[{"metadata":{"name":"web","namespace":"default"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	state := createTestState(core.ResourceTypePod, "default", "")
+	rv := NewResourceView(state, client)
+	rv.SetSize(200, 30)
+
+	// The pod list finishes after the switch to services
+	refresh := rv.RefreshResources()
+	state.SetResourceType(core.ResourceTypeService)
+	if msg := refresh(); msg != nil {
+		t.Errorf("Expected the out-of-date list dropped, got %#v", msg)
+	}
+	if rv.table.GetRowCount() != 0 || len(state.Pods) != 0 {
+		t.Error("Expected nothing stored from the pod list")
+	}
+}
+
+func TestResourceViewDropsListAfterNamespaceSwitch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[{"metadata":{"name":"web","namespace":"default"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	state := createTestState(core.ResourceTypePod, "default", "")
+	rv := NewResourceView(state, client)
+	rv.SetSize(200, 30)
+
+	// The list of default finishes after the switch to payments
+	refresh := rv.RefreshResources()
+	state.SetNamespace("payments")
+	if msg := refresh(); msg != nil {
+		t.Errorf("Expected the out-of-date list dropped, got %#v", msg)
+	}
+	if rv.table.GetRowCount() != 0 || len(state.Pods) != 0 {
+		t.Error("Expected nothing stored from the list of default")
+	}
+}
+
+func TestResourceViewMarksDeletions(t *testing.T) {
+	rv := createTestResourceView(t)
+	rv.SetSize(120, 20)
+	rv.state.Deletions = core.NewDeletionTracker()
+	rv.SetTestData([]string{"NAME", "READY", "STATUS", "RESTARTS", "AGE"}, [][]string{
+		{"cart-1", "1/1", "Running", "0", "1h"},
+		{"web-1", "1/1", "Terminating", "0", "1h"},
+	})
+
+	// Nothing deleted leaves the rows unmarked
+	if view := rv.View(); strings.Contains(view, "✕") || strings.Contains(view, "delete requested") {
+		t.Errorf("Expected no deletion marks, got:\n%s", view)
+	}
+
+	rv.state.Deletions.Requested(core.ResourceTypePod, core.ResourceRef{Namespace: "default", Name: "web-1"}, time.Now())
+	view := rv.View()
+	if !strings.Contains(view, "✕ web-1") {
+		t.Errorf("Expected web-1 marked as being deleted, got:\n%s", view)
+	}
+	if strings.Contains(view, "✕ cart-1") {
+		t.Errorf("Expected cart-1 unmarked, got:\n%s", view)
+	}
+	if !strings.Contains(view, "Pod default/web-1: delete requested") {
+		t.Errorf("Expected the deletion's status under the header, got:\n%s", view)
+	}
+
+	// Once listed no more, the deletion is reported done
+	rv.state.UpdatePods([]v1.Pod{fixtures.NewPod("cart-1", "default").Build()})
+	if view := rv.View(); !strings.Contains(view, "web-1: deleted") {
+		t.Errorf("Expected web-1 reported deleted, got:\n%s", view)
+	}
+}
+
+func TestResourceViewPodFieldSelector(t *testing.T) {
+	state := core.NewState(&core.Config{FieldSelector: "spec.nodeName=worker-3"})
+	state.SetResourceType(core.ResourceTypePod)
+	state.SetNamespace("default")
+	rv := NewResourceView(state, nil)
+	rv.SetSize(160, 20)
+
+	// The filter's own field selector terms narrow the list further
+	state.SetFilter("name=web-1", "")
+	if got := rv.podFieldSelector(); got != "spec.nodeName=worker-3,metadata.name=web-1" {
+		t.Errorf("Expected both selectors sent, got %q", got)
+	}
+	state.SetFilter("", "")
+
+	rv.updateTableWithPods(nil)
+	if view := rv.View(); !strings.Contains(view, "No resources match field selector") {
+		t.Errorf("Expected the empty list blamed on the field selector, got:\n%s", view)
+	}
+	if header := rv.renderHeader(); !strings.Contains(header, "Fields: spec.nodeName=worker-3") || strings.Contains(header, "not applied") {
+		t.Errorf("Expected the field selector in the header, got %q", header)
+	}
+
+	// Other types are listed in full
+	state.SetResourceType(core.ResourceTypeService)
+	if header := rv.renderHeader(); !strings.Contains(header, "Fields: spec.nodeName=worker-3 (not applied)") {
+		t.Errorf("Expected the field selector marked not applied, got %q", header)
+	}
+}
+
+func TestResourceViewTypeNotServed(t *testing.T) {
+	var listed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/apis/networking.k8s.io/v1" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+			return
+		}
+		listed = append(listed, r.URL.Path)
+		w.Write([]byte(`{"kind":"IngressList","apiVersion":"networking.k8s.io/v1","items":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	state := createTestState(core.ResourceTypeIngress, "default", "")
+	rv := NewResourceView(state, client)
+	rv.SetSize(200, 30)
+
+	rv.RefreshResources()()
+	if view := rv.View(); !strings.Contains(view, "networking.k8s.io/v1 Ingress is not served by this cluster") || strings.Contains(view, "No resources found") {
+		t.Errorf("Expected the unserved type explained, got:\n%s", view)
+	}
+	for _, path := range listed {
+		if strings.HasSuffix(path, "/ingresses") {
+			t.Errorf("Expected ingresses not listed, got %v", listed)
+		}
+	}
+}
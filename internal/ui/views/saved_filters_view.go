@@ -0,0 +1,186 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SavedFiltersView lists the saved filters from the config file so one can be applied
+type SavedFiltersView struct {
+	filters  []*config.SavedFilter
+	active   string
+	selected int
+
+	status string
+
+	width  int
+	height int
+}
+
+// NewSavedFiltersView creates a picker over filters, highlighting the active one
+func NewSavedFiltersView(filters []*config.SavedFilter, active string) *SavedFiltersView {
+	v := &SavedFiltersView{
+		filters: filters,
+		active:  active,
+	}
+	for i, f := range filters {
+		if f.Name == active {
+			v.selected = i
+			break
+		}
+	}
+	return v
+}
+
+// Init initializes the view
+func (v *SavedFiltersView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (v *SavedFiltersView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if v.selected > 0 {
+				v.selected--
+			}
+		case "down", "j":
+			if v.selected < len(v.filters)-1 {
+				v.selected++
+			}
+		case "enter":
+			if f := v.SelectedFilter(); f != nil {
+				v.status = ""
+				return v, func() tea.Msg { return SavedFilterSelectedMsg{Filter: f} }
+			}
+		}
+	}
+	return v, nil
+}
+
+// SelectedFilter returns the highlighted filter
+func (v *SavedFiltersView) SelectedFilter() *config.SavedFilter {
+	if v.selected < 0 || v.selected >= len(v.filters) {
+		return nil
+	}
+	return v.filters[v.selected]
+}
+
+// SetStatus shows an error below the list, e.g. when a filter cannot be applied
+func (v *SavedFiltersView) SetStatus(status string) {
+	v.status = status
+}
+
+// View renders the saved filters picker
+func (v *SavedFiltersView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(true)
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196"))
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	nameWidth := 0
+	for _, f := range v.filters {
+		if len(f.Name) > nameWidth {
+			nameWidth = len(f.Name)
+		}
+	}
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Saved Filters"))
+	content.WriteString("\n\n")
+
+	if len(v.filters) == 0 {
+		content.WriteString(labelStyle.Render("No saved filters. Press / to filter, then Ctrl+S to save it."))
+		content.WriteString("\n")
+	}
+
+	for i, f := range v.filters {
+		marker := " "
+		if f.Name == v.active {
+			marker = "*"
+		}
+		line := fmt.Sprintf("%s %-*s  %-12s  %s", marker, nameWidth, f.Name, f.ResourceType, f.Expression)
+		if i == v.selected {
+			content.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n")
+	}
+
+	if f := v.SelectedFilter(); f != nil {
+		var scope []string
+		if f.Namespace != "" {
+			scope = append(scope, "namespace "+f.Namespace)
+		}
+		if f.Context != "" {
+			scope = append(scope, "context "+f.Context)
+		}
+		if f.Sort != nil && f.Sort.Column != "" {
+			direction := "↓"
+			if f.Sort.Ascending {
+				direction = "↑"
+			}
+			scope = append(scope, fmt.Sprintf("sort %s %s", f.Sort.Column, direction))
+		}
+		if len(scope) > 0 {
+			content.WriteString("\n")
+			content.WriteString(labelStyle.Render(strings.Join(scope, ", ")))
+		}
+	}
+
+	if v.status != "" {
+		content.WriteString("\n")
+		content.WriteString(errorStyle.Render(v.status))
+	}
+
+	content.WriteString("\n\n")
+	content.WriteString(labelStyle.Render("[↑/↓] Select  [Enter] Apply  [Esc/F] Close"))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// SetSize updates the view size
+func (v *SavedFiltersView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// SavedFilterSelectedMsg is sent when the user picks a saved filter to apply
+type SavedFilterSelectedMsg struct {
+	Filter *config.SavedFilter
+}
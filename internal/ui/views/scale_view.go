@@ -0,0 +1,143 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ScaleView asks how many replicas to scale a deployment or statefulset
+// to, starting from the count it has
+type ScaleView struct {
+	kind    string
+	name    string
+	current int32
+	input   string
+	err     error
+
+	width  int
+	height int
+}
+
+// NewScaleView creates the prompt for scaling the named resource of kind,
+// e.g. "deployment", from its current replicas
+func NewScaleView(kind, name string, current int32) *ScaleView {
+	return &ScaleView{
+		kind:    kind,
+		name:    name,
+		current: current,
+		input:   strconv.Itoa(int(current)),
+	}
+}
+
+// Init initializes the view
+func (v *ScaleView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages. Esc is handled by the scale mode.
+func (v *ScaleView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "+", "up":
+			v.step(1)
+		case "-", "down":
+			v.step(-1)
+		case "backspace":
+			if len(v.input) > 0 {
+				v.input = v.input[:len(v.input)-1]
+			}
+			v.err = nil
+		case "enter":
+			replicas, err := parseReplicas(v.input)
+			if err != nil {
+				v.err = err
+				return v, nil
+			}
+			return v, func() tea.Msg { return ScaleRequestedMsg{Replicas: replicas} }
+		default:
+			if msg.Type == tea.KeyRunes {
+				v.input += string(msg.Runes)
+				v.err = nil
+			}
+		}
+	}
+	return v, nil
+}
+
+// step adds delta to the replicas typed, or to the current count when what
+// is typed is not a number, stopping at zero
+func (v *ScaleView) step(delta int32) {
+	replicas, err := parseReplicas(v.input)
+	if err != nil {
+		replicas = v.current
+	}
+	v.input = strconv.Itoa(int(max(replicas+delta, 0)))
+	v.err = nil
+}
+
+// parseReplicas reads a replica count, refusing anything but a whole
+// number of zero or more
+func parseReplicas(input string) (int32, error) {
+	replicas, err := strconv.ParseInt(strings.TrimSpace(input), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number of replicas", input)
+	}
+	if replicas < 0 {
+		return 0, fmt.Errorf("replicas cannot be negative")
+	}
+	return int32(replicas), nil
+}
+
+// View renders the prompt
+func (v *ScaleView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2).
+		Width(60)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(fmt.Sprintf("Scale %s %s", v.kind, v.name)))
+	content.WriteString("\n\n")
+	content.WriteString(fmt.Sprintf("Current replicas: %d\n", v.current))
+	content.WriteString("New replicas:     ")
+	content.WriteString(lipgloss.NewStyle().Reverse(true).Padding(0, 1).Render(v.input + "▏"))
+	if v.err != nil {
+		content.WriteString("\n\n")
+		content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true).Render("✗ " + v.err.Error()))
+	}
+
+	helpText := "\n\n[+/-] Change  [Enter] Scale  [Esc] Cancel"
+	content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(helpText))
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// SetSize updates the view size
+func (v *ScaleView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// ScaleRequestedMsg is sent when the user enters the replicas to scale to
+type ScaleRequestedMsg struct {
+	Replicas int32
+}
@@ -0,0 +1,70 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// enterReplicas presses Enter and returns the replicas requested, if any
+func enterReplicas(t *testing.T, view *ScaleView) (int32, bool) {
+	t.Helper()
+	_, cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		return 0, false
+	}
+	msg, ok := cmd().(ScaleRequestedMsg)
+	if !ok {
+		t.Fatalf("Expected a ScaleRequestedMsg, got %T", cmd())
+	}
+	return msg.Replicas, true
+}
+
+func TestScaleViewSteps(t *testing.T) {
+	view := NewScaleView("deployment", "web", 1)
+	view.SetSize(80, 20)
+	if !strings.Contains(view.View(), "Current replicas: 1") {
+		t.Errorf("Expected the current replicas shown, got:\n%s", view.View())
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	view.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if replicas, ok := enterReplicas(t, view); !ok || replicas != 3 {
+		t.Errorf("Expected 3 replicas after two steps up, got %d", replicas)
+	}
+
+	// Stepping down stops at zero
+	for i := 0; i < 5; i++ {
+		view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")})
+	}
+	if replicas, ok := enterReplicas(t, view); !ok || replicas != 0 {
+		t.Errorf("Expected 0 replicas at the bottom, got %d", replicas)
+	}
+}
+
+func TestScaleViewValidation(t *testing.T) {
+	view := NewScaleView("statefulset", "db", 3)
+	view.SetSize(80, 20)
+
+	view.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("two")})
+	if _, ok := enterReplicas(t, view); ok {
+		t.Fatal("Expected non-numeric replicas refused")
+	}
+	if !strings.Contains(view.View(), `"two" is not a number of replicas`) {
+		t.Errorf("Expected the refusal explained, got:\n%s", view.View())
+	}
+
+	if _, err := parseReplicas("-2"); err == nil {
+		t.Error("Expected negative replicas refused")
+	}
+
+	for range "two" {
+		view.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("12")})
+	if replicas, ok := enterReplicas(t, view); !ok || replicas != 12 {
+		t.Errorf("Expected the 12 typed, got %d", replicas)
+	}
+}
@@ -0,0 +1,30 @@
+package views
+
+import (
+	"github.com/charmbracelet/bubbles/viewport"
+)
+
+// resizeViewport resizes vp, letting relayout (if any) re-render its content
+// for the new size, and keeps the same part of the content in view: the top
+// line keeps its relative position in the content, so re-wrapped text stays
+// put, and a viewport scrolled to the bottom stays at the bottom
+func resizeViewport(vp *viewport.Model, width, height int, relayout func()) {
+	total := vp.TotalLineCount()
+	atBottom := vp.YOffset > 0 && vp.AtBottom()
+	position := 0.0
+	if total > 0 {
+		position = float64(vp.YOffset) / float64(total)
+	}
+
+	vp.Width = width
+	vp.Height = max(height, 0)
+	if relayout != nil {
+		relayout()
+	}
+
+	if atBottom {
+		vp.GotoBottom()
+		return
+	}
+	vp.SetYOffset(int(position*float64(vp.TotalLineCount()) + 0.5))
+}
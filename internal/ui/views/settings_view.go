@@ -0,0 +1,258 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/components/textinput"
+	"github.com/HamStudy/kubewatch/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SettingsView lists the runtime-tunable settings and edits them in place
+type SettingsView struct {
+	config   *core.Config
+	settings []*core.Setting
+	selected int
+	offset   int // the first setting shown when they do not all fit
+
+	editing bool
+	input   textinput.Model
+
+	status   string
+	statusOK bool
+
+	width  int
+	height int
+}
+
+// NewSettingsView creates a settings overlay for every registered setting
+func NewSettingsView(config *core.Config) *SettingsView {
+	return &SettingsView{
+		config:   config,
+		settings: core.Settings(),
+	}
+}
+
+// Init initializes the view
+func (v *SettingsView) Init() tea.Cmd {
+	return nil
+}
+
+// IsEditing returns true while a value is being typed
+func (v *SettingsView) IsEditing() bool {
+	return v.editing
+}
+
+// Update handles messages
+func (v *SettingsView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case tea.KeyMsg:
+		if v.editing {
+			return v, v.handleEditKey(msg)
+		}
+
+		switch msg.String() {
+		case "up", "k":
+			if v.selected > 0 {
+				v.selected--
+			}
+		case "down", "j":
+			if v.selected < len(v.settings)-1 {
+				v.selected++
+			}
+		case "enter", "e":
+			if s := v.selectedSetting(); s != nil {
+				v.editing = true
+				v.input = textinput.New(textinput.IntRange(s.Min, s.Max))
+				v.input.SetValue(fmt.Sprintf("%d", s.Get(v.config)))
+				v.status = ""
+			}
+		case "s", "ctrl+s":
+			return v, func() tea.Msg { return SettingsSaveRequestedMsg{} }
+		}
+	}
+	return v, nil
+}
+
+// handleEditKey handles keys while a value is being typed
+func (v *SettingsView) handleEditKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		v.editing = false
+		v.input.Reset()
+		return nil
+
+	case tea.KeyEnter:
+		s := v.selectedSetting()
+		if s == nil {
+			v.editing = false
+			return nil
+		}
+		if !v.input.Submit() {
+			return nil
+		}
+		if err := s.Set(v.config, v.input.Value()); err != nil {
+			v.status = err.Error()
+			v.statusOK = false
+			return nil
+		}
+		v.editing = false
+		v.input.Reset()
+		v.status = fmt.Sprintf("%s set to %s", s.Name, s.FormatValue(v.config))
+		v.statusOK = true
+		return func() tea.Msg { return SettingAppliedMsg{Key: s.Key} }
+
+	default:
+		v.input.HandleKey(msg)
+	}
+	return nil
+}
+
+// selectedSetting returns the highlighted setting
+func (v *SettingsView) selectedSetting() *core.Setting {
+	if v.selected < 0 || v.selected >= len(v.settings) {
+		return nil
+	}
+	return v.settings[v.selected]
+}
+
+// SetStatus shows a message below the settings list
+func (v *SettingsView) SetStatus(status string, ok bool) {
+	v.status = status
+	v.statusOK = ok
+}
+
+// View renders the settings overlay
+func (v *SettingsView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(true)
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196"))
+
+	okStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("82"))
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	nameWidth := 0
+	for _, s := range v.settings {
+		if len(s.Name) > nameWidth {
+			nameWidth = len(s.Name)
+		}
+	}
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Settings"))
+	content.WriteString("\n\n")
+
+	// On a short terminal the list scrolls to keep the selection in view
+	first, last := v.visibleRange()
+	if first > 0 {
+		content.WriteString(labelStyle.Render(fmt.Sprintf("  ↑ %d more", first)) + "\n")
+	}
+	for i := first; i < last; i++ {
+		s := v.settings[i]
+		value := s.FormatValue(v.config)
+		if i == v.selected && v.editing {
+			value = v.input.View()
+		}
+		line := fmt.Sprintf("%-*s  %s", nameWidth, s.Name, value)
+		if i == v.selected {
+			content.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n")
+		if i == v.selected && v.editing {
+			if errView := v.input.ErrorView(); errView != "" {
+				content.WriteString(strings.Repeat(" ", nameWidth+4) + errView + "\n")
+			}
+		}
+	}
+
+	if last < len(v.settings) {
+		content.WriteString(labelStyle.Render(fmt.Sprintf("  ↓ %d more", len(v.settings)-last)) + "\n")
+	}
+
+	if s := v.selectedSetting(); s != nil {
+		content.WriteString("\n")
+		content.WriteString(labelStyle.Render(fmt.Sprintf("%s (%d-%d)", s.Description, s.Min, s.Max)))
+	}
+
+	if v.status != "" {
+		content.WriteString("\n")
+		if v.statusOK {
+			content.WriteString(okStyle.Render(v.status))
+		} else {
+			content.WriteString(errorStyle.Render(v.status))
+		}
+	}
+
+	content.WriteString("\n\n")
+	if v.editing {
+		content.WriteString(labelStyle.Render("[Enter] Apply  [Esc] Cancel"))
+	} else {
+		content.WriteString(labelStyle.Render("[↑/↓] Select  [Enter] Edit  [s] Save  [Esc/,] Close"))
+	}
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// settingsChromeLines is how many lines of the overlay are not settings:
+// the border, padding, title, description, status, hints and scroll markers
+const settingsChromeLines = 13
+
+// visibleRange returns the settings shown, from first up to last, scrolled
+// as little as keeps the selection in view
+func (v *SettingsView) visibleRange() (first, last int) {
+	rows := v.height - settingsChromeLines
+	if v.height == 0 || rows >= len(v.settings) {
+		return 0, len(v.settings)
+	}
+	rows = max(rows, 1)
+	v.offset = min(v.offset, v.selected)
+	v.offset = max(v.offset, v.selected-rows+1)
+	return v.offset, v.offset + rows
+}
+
+// SetSize updates the view size
+func (v *SettingsView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// SettingAppliedMsg is sent after a setting has been changed in the config
+type SettingAppliedMsg struct {
+	Key string
+}
+
+// SettingsSaveRequestedMsg is sent when the user asks to persist the settings
+type SettingsSaveRequestedMsg struct{}
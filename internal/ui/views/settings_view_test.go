@@ -0,0 +1,148 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// typeValue replaces the edit buffer of the selected setting with value and presses Enter
+func typeValue(v *SettingsView, value string) tea.Cmd {
+	v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	for v.input.Value() != "" {
+		v.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(value)})
+	_, cmd := v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return cmd
+}
+
+func TestSettingsViewEditing(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expectApplied bool
+		expectValue   int
+		expectEditing bool
+		expectStatus  string
+	}{
+		{"valid value", "10", true, 10, false, "Refresh interval set to 10 s"},
+		{"below minimum", "0", false, 2, true, "must be between 1 and 300"},
+		{"above maximum", "301", false, 2, true, "must be between 1 and 300"},
+		{"letters ignored", "abc", false, 2, true, "must be a whole number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &core.Config{RefreshInterval: 2}
+			v := NewSettingsView(config)
+			v.SetSize(100, 30)
+
+			cmd := typeValue(v, tt.value)
+
+			if config.RefreshInterval != tt.expectValue {
+				t.Errorf("Expected refresh interval %d, got %d", tt.expectValue, config.RefreshInterval)
+			}
+			if v.IsEditing() != tt.expectEditing {
+				t.Errorf("Expected editing=%v, got %v", tt.expectEditing, v.IsEditing())
+			}
+			if tt.expectApplied {
+				if cmd == nil {
+					t.Fatal("Expected a command after applying")
+				}
+				if msg, ok := cmd().(SettingAppliedMsg); !ok || msg.Key != "refreshInterval" {
+					t.Errorf("Expected SettingAppliedMsg for refreshInterval, got %#v", cmd())
+				}
+			} else if cmd != nil {
+				t.Error("Expected no command when validation fails")
+			}
+			if !strings.Contains(v.View(), tt.expectStatus) {
+				t.Errorf("Expected view to contain %q", tt.expectStatus)
+			}
+		})
+	}
+}
+
+func TestSettingsViewInlineError(t *testing.T) {
+	config := &core.Config{RefreshInterval: 2}
+	v := NewSettingsView(config)
+	v.SetSize(100, 30)
+
+	// The error follows the value as it is typed, under the field
+	v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("000")})
+	lines := strings.Split(v.View(), "\n")
+	for i, line := range lines {
+		if strings.Contains(line, "Refresh interval") {
+			if i+1 >= len(lines) || !strings.Contains(lines[i+1], "must be between 1 and 300") {
+				t.Fatalf("Expected the error under the field, got:\n%s", v.View())
+			}
+			break
+		}
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("30")})
+	if strings.Contains(v.View(), "must be") {
+		t.Errorf("Expected the error gone once the value is valid, got:\n%s", v.View())
+	}
+}
+
+func TestSettingsViewListsRegisteredSettings(t *testing.T) {
+	v := NewSettingsView(&core.Config{})
+	v.SetSize(120, 60)
+	view := v.View()
+
+	for _, s := range core.Settings() {
+		if !strings.Contains(view, s.Name) {
+			t.Errorf("Expected settings overlay to list %q", s.Name)
+		}
+	}
+}
+
+func TestSettingsViewScrollsToSelection(t *testing.T) {
+	v := NewSettingsView(&core.Config{})
+	v.SetSize(120, 24)
+	settings := core.Settings()
+
+	if view := v.View(); !strings.Contains(view, settings[0].Name) || strings.Contains(view, settings[len(settings)-1].Name) {
+		t.Fatal("Expected the overlay to show the first settings of a list too long for it")
+	}
+	for range settings {
+		v.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	view := v.View()
+	if !strings.Contains(view, settings[len(settings)-1].Name) || !strings.Contains(view, "more") {
+		t.Errorf("Expected the overlay scrolled to the last setting, got:\n%s", view)
+	}
+}
+
+func TestSettingsViewEscapeCancelsEdit(t *testing.T) {
+	config := &core.Config{RefreshInterval: 2}
+	v := NewSettingsView(config)
+
+	v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("9")})
+	v.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if v.IsEditing() {
+		t.Error("Expected Esc to cancel editing")
+	}
+	if config.RefreshInterval != 2 {
+		t.Errorf("Expected refresh interval to be unchanged, got %d", config.RefreshInterval)
+	}
+}
+
+func TestSettingsViewSaveRequest(t *testing.T) {
+	v := NewSettingsView(&core.Config{})
+
+	_, cmd := v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if cmd == nil {
+		t.Fatal("Expected save key to return a command")
+	}
+	if _, ok := cmd().(SettingsSaveRequestedMsg); !ok {
+		t.Error("Expected SettingsSaveRequestedMsg")
+	}
+}
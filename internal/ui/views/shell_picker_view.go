@@ -0,0 +1,109 @@
+package views
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ShellPickerView asks which of a pod's containers to open a shell in,
+// with the container picker the log view uses
+type ShellPickerView struct {
+	context   string
+	namespace string
+	pod       string
+	picker    containerPicker
+
+	width  int
+	height int
+}
+
+// NewShellPickerView creates a picker over a pod's containers
+func NewShellPickerView(context, namespace, pod string, containers []string) *ShellPickerView {
+	return &ShellPickerView{
+		context:   context,
+		namespace: namespace,
+		pod:       pod,
+		picker:    newContainerPicker(containers, false),
+	}
+}
+
+// Init initializes the view
+func (v *ShellPickerView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages. Esc is handled by the shell picker mode.
+func (v *ShellPickerView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case tea.KeyMsg:
+		if result, choice := v.picker.handleKey(msg); result == pickerPicked {
+			picked := ShellContainerPickedMsg{
+				Context:   v.context,
+				Namespace: v.namespace,
+				Pod:       v.pod,
+				Container: v.picker.containers[choice],
+			}
+			return v, func() tea.Msg { return picked }
+		}
+	}
+	return v, nil
+}
+
+// View renders the container picker
+func (v *ShellPickerView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	// Room for the title, prompt, border and padding
+	height := len(v.picker.containers)
+	if room := v.height - 10; room > 0 && height > room {
+		height = room
+	}
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Open a shell in " + v.pod))
+	content.WriteString("\n\n")
+	content.WriteString(v.picker.render(height, func(choice int) string {
+		return v.picker.containers[choice]
+	}))
+	content.WriteString("\n\n")
+	content.WriteString(v.picker.prompt("open a shell"))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// SetSize updates the view size
+func (v *ShellPickerView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// ShellContainerPickedMsg is sent when the user picks the container to open
+// a shell in
+type ShellContainerPickedMsg struct {
+	Context   string
+	Namespace string
+	Pod       string
+	Container string
+}
@@ -0,0 +1,204 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// Panes of a SplitView
+const (
+	splitTop    = 0
+	splitBottom = 1
+)
+
+// SplitView stacks two resource types: deployments on top and, below, the
+// pods of the deployment selected on top. The top pane's selection publishes
+// a core.PodScope that the bottom pane lists pods within, so the bottom
+// follows as the selection moves. Each pane has its own state, so it sorts,
+// filters and scrolls on its own; keys go to the focused pane.
+type SplitView struct {
+	panes   [2]*ResourceView
+	states  [2]*core.State
+	context string // "" in single-context mode
+	focused int
+
+	width  int
+	height int
+}
+
+// NewSplitView creates a split of state's namespace in one context, linked
+// to the pods of deployment. The client may be nil in tests.
+func NewSplitView(state *core.State, context string, client *k8s.Client, deployment *appsv1.Deployment) (*SplitView, error) {
+	scope, err := core.DeploymentPodScope(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &SplitView{context: context}
+	for i := range v.panes {
+		v.states[i] = state.ForContext(context)
+	}
+	v.states[splitTop].SetResourceType(core.ResourceTypeDeployment)
+
+	// The pods pane starts unfiltered, with every pod column, sorted by name
+	pods := v.states[splitBottom]
+	pods.SetResourceType(core.ResourceTypePod)
+	pods.SetFilter("", "")
+	pods.SetColumns(nil)
+	pods.SetSortState("NAME", true)
+
+	for i := range v.panes {
+		v.panes[i] = NewResourceView(v.states[i], client)
+	}
+	v.panes[splitTop].SelectAfterRefresh(core.ResourceRef{Namespace: deployment.Namespace, Name: deployment.Name})
+	v.panes[splitBottom].SetPodScope(scope)
+	return v, nil
+}
+
+// Init initializes the view
+func (v *SplitView) Init() tea.Cmd {
+	return v.RefreshResources()
+}
+
+// Update handles messages. Keys, and the results of actions such as a
+// failed delete, go to the focused pane; any message may have moved the top
+// selection, so the link is synced after each.
+func (v *SplitView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		v.SetSize(msg.Width, msg.Height)
+		return v, nil
+	}
+
+	_, cmd := v.panes[v.focused].Update(msg)
+	v.SyncLink()
+	return v, cmd
+}
+
+// RefreshResources refreshes both panes
+func (v *SplitView) RefreshResources() tea.Cmd {
+	return tea.Batch(v.panes[splitTop].RefreshResources(), v.panes[splitBottom].RefreshResources())
+}
+
+// Panes returns the top and bottom resource views
+func (v *SplitView) Panes() [2]*ResourceView {
+	return v.panes
+}
+
+// Focused returns the resource view that keys and actions apply to
+func (v *SplitView) Focused() *ResourceView {
+	return v.panes[v.focused]
+}
+
+// FocusedState returns the focused pane's state
+func (v *SplitView) FocusedState() *core.State {
+	return v.states[v.focused]
+}
+
+// Context returns the context both panes show, "" in single-context mode
+func (v *SplitView) Context() string {
+	return v.context
+}
+
+// ToggleFocus moves the focus to the other pane
+func (v *SplitView) ToggleFocus() {
+	v.focused = 1 - v.focused
+}
+
+// SyncLink scopes the bottom pane to the pods of the deployment selected on
+// top. While the top pane lists nothing, the bottom keeps its last scope.
+func (v *SplitView) SyncLink() {
+	ref := v.panes[splitTop].SelectedResourceRef()
+	if ref.IsZero() {
+		return
+	}
+	deployment, ok := v.states[splitTop].FindDeployment("", ref.Namespace, ref.Name)
+	if !ok {
+		return
+	}
+	if scope, err := core.DeploymentPodScope(deployment); err == nil {
+		v.panes[splitBottom].SetPodScope(scope)
+	}
+}
+
+// View renders the panes one above the other
+func (v *SplitView) View() string {
+	focusedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57"))
+
+	paneTitleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	separatorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240"))
+
+	scope := v.panes[splitBottom].PodScope()
+	title := "Split: Deployments"
+	if scope != nil {
+		title = fmt.Sprintf("Split: Deployments ⇅ %s", scope)
+	}
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86")).Render(title) +
+		"   " + infoStyle.Render("[Tab] Switch pane  [Esc] Close")
+
+	labels := [2]string{" Deployments ", " Pods "}
+	if scope != nil {
+		labels[splitBottom] = " Pods of " + scope.Name + " "
+	}
+
+	heights := v.paneHeights()
+	var rendered [2]string
+	for i, pane := range v.panes {
+		label := labels[i]
+		if i == v.focused {
+			label = focusedStyle.Render("▶" + label)
+		} else {
+			label = paneTitleStyle.Render(" " + label)
+		}
+		body := lipgloss.JoinVertical(lipgloss.Left, label, pane.View())
+		rendered[i] = lipgloss.NewStyle().
+			Height(heights[i] + 1).
+			MaxHeight(heights[i] + 1).
+			Render(body)
+	}
+
+	width := v.width
+	if width < 1 {
+		width = 1
+	}
+	separator := separatorStyle.Render(strings.Repeat("─", width))
+
+	if v.width > 0 {
+		header = lipgloss.NewStyle().MaxWidth(v.width).Render(header)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, rendered[splitTop], separator, rendered[splitBottom])
+}
+
+// paneHeights returns the height of each pane's resource view, leaving room
+// for the header, the pane titles and the separator. The deployments get
+// two fifths, the pods the rest.
+func (v *SplitView) paneHeights() [2]int {
+	available := v.height - 4
+	top := available * 2 / 5
+	bottom := available - top
+	return [2]int{max(top, 1), max(bottom, 1)}
+}
+
+// SetSize updates the view size and splits it between the panes
+func (v *SplitView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	heights := v.paneHeights()
+	for i, pane := range v.panes {
+		pane.SetSize(width, heights[i])
+	}
+}
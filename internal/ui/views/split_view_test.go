@@ -0,0 +1,105 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func splitTestDeployment(name string) appsv1.Deployment {
+	return appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID("uid-" + name)},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+		},
+	}
+}
+
+func splitTestPod(name, app string) v1.Pod {
+	return v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: "default",
+		UID:       types.UID("uid-" + name),
+		Labels:    map[string]string{"app": app},
+	}}
+}
+
+// createTestSplitView returns a split of api and web, entered from web, with
+// both panes listed
+func createTestSplitView(t *testing.T) *SplitView {
+	t.Helper()
+	state := createTestState(core.ResourceTypeDeployment, "default", "")
+	deployments := []appsv1.Deployment{splitTestDeployment("api"), splitTestDeployment("web")}
+
+	view, err := NewSplitView(state, "", nil, &deployments[1])
+	if err != nil {
+		t.Fatalf("NewSplitView() error = %v", err)
+	}
+	view.SetSize(140, 30)
+
+	top, bottom := view.Panes()[splitTop], view.Panes()[splitBottom]
+	view.states[splitTop].UpdateDeployments(deployments)
+	top.updateTableWithDeployments(deployments)
+	top.applyPendingSelection()
+
+	pods := []v1.Pod{splitTestPod("api-1", "api"), splitTestPod("web-1", "web"), splitTestPod("web-2", "web")}
+	view.states[splitBottom].UpdatePods(pods)
+	bottom.updateTableWithPods(pods)
+	return view
+}
+
+func splitPodNames(pane *ResourceView) []string {
+	var names []string
+	for _, row := range pane.table.Values() {
+		names = append(names, pane.rowName(row))
+	}
+	return names
+}
+
+func TestSplitViewFollowsTopSelection(t *testing.T) {
+	view := createTestSplitView(t)
+	top, bottom := view.Panes()[splitTop], view.Panes()[splitBottom]
+
+	if top.GetSelectedResourceName() != "web" {
+		t.Fatalf("Expected the split to start on web, got %q", top.GetSelectedResourceName())
+	}
+	if got := strings.Join(splitPodNames(bottom), ","); got != "web-1,web-2" {
+		t.Fatalf("Expected the pods of web, got %s", got)
+	}
+
+	// Moving the top selection relinks the bottom pane right away
+	view.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if got := strings.Join(splitPodNames(bottom), ","); got != "api-1" {
+		t.Errorf("Expected the pods of api after moving up, got %s", got)
+	}
+	if output := view.View(); !strings.Contains(output, "pods of deployment api") || !strings.Contains(output, "Pods of api") {
+		t.Errorf("Expected the link in the header and pane title, got:\n%s", output)
+	}
+
+	// Keys go to the focused pane; moving in the pods keeps the link
+	view.Update(tea.KeyMsg{Type: tea.KeyDown})
+	view.ToggleFocus()
+	if view.Focused() != bottom || view.FocusedState().CurrentResourceType != core.ResourceTypePod {
+		t.Fatal("Expected focus on the pods pane")
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if bottom.GetSelectedResourceName() != "web-2" || top.GetSelectedResourceName() != "web" {
+		t.Errorf("Expected web-2 selected below and web kept on top, got %q and %q",
+			bottom.GetSelectedResourceName(), top.GetSelectedResourceName())
+	}
+}
+
+func TestSplitViewPanesKeepTheirOwnSort(t *testing.T) {
+	view := createTestSplitView(t)
+
+	view.states[splitTop].SetSortState("AGE", false)
+	if column, ascending := view.states[splitBottom].GetSortState(); column != "NAME" || !ascending {
+		t.Errorf("Expected the pods pane to keep sorting by name, got %s ascending=%v", column, ascending)
+	}
+}
@@ -0,0 +1,155 @@
+package views
+
+import (
+	"time"
+)
+
+const (
+	// maxHistorySnapshots caps the snapshots kept per table
+	maxHistorySnapshots = 1000
+
+	// maxHistoryCells caps the cells kept across all tables, so a large
+	// cluster cannot make the history grow with its size
+	maxHistoryCells = 1000000
+)
+
+// TableSnapshot is a resource table as it stood after a refresh
+type TableSnapshot struct {
+	At      time.Time
+	Headers []string
+	Rows    [][]string
+}
+
+// cells returns how many cells the snapshot holds, its unit of memory
+func (s *TableSnapshot) cells() int {
+	n := len(s.Headers)
+	for _, row := range s.Rows {
+		n += len(row)
+	}
+	return n
+}
+
+// sameTable reports whether two snapshots show the same table
+func (s *TableSnapshot) sameTable(other *TableSnapshot) bool {
+	if len(s.Headers) != len(other.Headers) || len(s.Rows) != len(other.Rows) {
+		return false
+	}
+	for i := range s.Headers {
+		if s.Headers[i] != other.Headers[i] {
+			return false
+		}
+	}
+	for i := range s.Rows {
+		if len(s.Rows[i]) != len(other.Rows[i]) {
+			return false
+		}
+		for j := range s.Rows[i] {
+			if s.Rows[i][j] != other.Rows[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// TableHistory keeps the recent states of each table (a resource type in a
+// namespace) so they can be scrubbed through. A snapshot is only kept when
+// the table changed. Memory is bounded by the retention window, a snapshot
+// count per table and a total cell count. A zero retention keeps nothing.
+//
+// TableHistory is not safe for concurrent use; ResourceView guards it with mu.
+type TableHistory struct {
+	retention time.Duration
+	tables    map[string][]*TableSnapshot
+	cells     int
+}
+
+// NewTableHistory creates a history keeping snapshots for retention
+func NewTableHistory(retention time.Duration) *TableHistory {
+	return &TableHistory{
+		retention: retention,
+		tables:    make(map[string][]*TableSnapshot),
+	}
+}
+
+// Enabled returns true when snapshots are being kept
+func (h *TableHistory) Enabled() bool {
+	return h.retention > 0
+}
+
+// SetRetention changes how long snapshots are kept, dropping any now too old
+func (h *TableHistory) SetRetention(retention time.Duration) {
+	h.retention = retention
+	if retention <= 0 {
+		h.tables = make(map[string][]*TableSnapshot)
+		h.cells = 0
+		return
+	}
+	h.prune(time.Now())
+}
+
+// Record adds a snapshot of the table identified by key, unless the table
+// is unchanged since the last one
+func (h *TableHistory) Record(key string, snapshot *TableSnapshot) {
+	if !h.Enabled() {
+		return
+	}
+
+	snapshots := h.tables[key]
+	if n := len(snapshots); n > 0 && snapshots[n-1].sameTable(snapshot) {
+		return
+	}
+
+	h.tables[key] = append(snapshots, snapshot)
+	h.cells += snapshot.cells()
+	h.prune(snapshot.At)
+}
+
+// Snapshots returns the snapshots of a table, oldest first
+func (h *TableHistory) Snapshots(key string) []*TableSnapshot {
+	snapshots := h.tables[key]
+	result := make([]*TableSnapshot, len(snapshots))
+	copy(result, snapshots)
+	return result
+}
+
+// prune drops snapshots outside the retention window or over the limits
+func (h *TableHistory) prune(now time.Time) {
+	cutoff := now.Add(-h.retention)
+	for key, snapshots := range h.tables {
+		drop := 0
+		for drop < len(snapshots) && (snapshots[drop].At.Before(cutoff) || len(snapshots)-drop > maxHistorySnapshots) {
+			h.cells -= snapshots[drop].cells()
+			snapshots[drop] = nil // Let the dropped snapshot be collected
+			drop++
+		}
+		h.setTable(key, snapshots[drop:])
+	}
+
+	// Over the cell limit, drop the oldest snapshot of any table
+	for h.cells > maxHistoryCells {
+		oldestKey := ""
+		for key, snapshots := range h.tables {
+			if oldestKey == "" || snapshots[0].At.Before(h.tables[oldestKey][0].At) {
+				oldestKey = key
+			}
+		}
+		if oldestKey == "" {
+			h.cells = 0
+			return
+		}
+		snapshots := h.tables[oldestKey]
+		h.cells -= snapshots[0].cells()
+		snapshots[0] = nil
+		h.setTable(oldestKey, snapshots[1:])
+	}
+}
+
+// setTable stores a table's snapshots, forgetting the table when there are none
+func (h *TableHistory) setTable(key string, snapshots []*TableSnapshot) {
+	if len(snapshots) == 0 {
+		delete(h.tables, key)
+		return
+	}
+	h.tables[key] = snapshots
+}
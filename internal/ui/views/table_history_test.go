@@ -0,0 +1,108 @@
+package views
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func historySnapshot(at time.Time, names ...string) *TableSnapshot {
+	s := &TableSnapshot{At: at, Headers: []string{"NAME"}}
+	for _, name := range names {
+		s.Rows = append(s.Rows, []string{name})
+	}
+	return s
+}
+
+func TestTableHistoryRecord(t *testing.T) {
+	now := time.Now()
+
+	t.Run("off by default", func(t *testing.T) {
+		h := NewTableHistory(0)
+		h.Record("pods", historySnapshot(now, "web-1"))
+		if len(h.Snapshots("pods")) != 0 {
+			t.Error("Expected nothing recorded with zero retention")
+		}
+	})
+
+	t.Run("skips unchanged tables", func(t *testing.T) {
+		h := NewTableHistory(time.Minute)
+		h.Record("pods", historySnapshot(now, "web-1"))
+		h.Record("pods", historySnapshot(now.Add(time.Second), "web-1"))
+		h.Record("pods", historySnapshot(now.Add(2*time.Second), "web-1", "web-2"))
+
+		snapshots := h.Snapshots("pods")
+		if len(snapshots) != 2 {
+			t.Fatalf("Expected 2 snapshots, got %d", len(snapshots))
+		}
+		if !snapshots[0].At.Equal(now) {
+			t.Error("Expected an unchanged table to keep the time it first appeared")
+		}
+	})
+
+	t.Run("tables are kept apart", func(t *testing.T) {
+		h := NewTableHistory(time.Minute)
+		h.Record("Pods/default", historySnapshot(now, "web-1"))
+		h.Record("Pods/prod", historySnapshot(now, "api-1"))
+		if len(h.Snapshots("Pods/default")) != 1 || len(h.Snapshots("Pods/prod")) != 1 {
+			t.Error("Expected one snapshot per table")
+		}
+	})
+}
+
+func TestTableHistoryBounds(t *testing.T) {
+	now := time.Now()
+
+	t.Run("retention window", func(t *testing.T) {
+		h := NewTableHistory(time.Minute)
+		h.Record("pods", historySnapshot(now, "a"))
+		h.Record("pods", historySnapshot(now.Add(30*time.Second), "b"))
+		h.Record("pods", historySnapshot(now.Add(90*time.Second), "c"))
+
+		snapshots := h.Snapshots("pods")
+		if len(snapshots) != 2 || snapshots[0].Rows[0][0] != "b" {
+			t.Errorf("Expected snapshots older than a minute to be dropped, got %d", len(snapshots))
+		}
+	})
+
+	t.Run("snapshot count", func(t *testing.T) {
+		h := NewTableHistory(time.Hour)
+		for i := 0; i < maxHistorySnapshots+10; i++ {
+			h.Record("pods", historySnapshot(now, fmt.Sprintf("pod-%d", i)))
+		}
+		if got := len(h.Snapshots("pods")); got != maxHistorySnapshots {
+			t.Errorf("Expected %d snapshots, got %d", maxHistorySnapshots, got)
+		}
+	})
+
+	t.Run("cell count", func(t *testing.T) {
+		h := NewTableHistory(time.Hour)
+		rows := make([]string, maxHistoryCells/4)
+		for i := range rows {
+			rows[i] = fmt.Sprintf("pod-%d", i)
+		}
+		for i := 0; i < 6; i++ {
+			key := fmt.Sprintf("table-%d", i%2)
+			h.Record(key, historySnapshot(now.Add(time.Duration(i)*time.Second), append(rows[:len(rows)-1:len(rows)-1], fmt.Sprint(i))...))
+		}
+		if h.cells > maxHistoryCells {
+			t.Errorf("Expected at most %d cells, got %d", maxHistoryCells, h.cells)
+		}
+		// The oldest snapshots go first, whichever table they belong to
+		if got := len(h.Snapshots("table-0")) + len(h.Snapshots("table-1")); got != 3 {
+			t.Errorf("Expected 3 snapshots to fit, got %d", got)
+		}
+		if s := h.Snapshots("table-1"); len(s) == 0 || !s[len(s)-1].At.Equal(now.Add(5*time.Second)) {
+			t.Error("Expected the newest snapshot to be kept")
+		}
+	})
+
+	t.Run("turning history off frees it", func(t *testing.T) {
+		h := NewTableHistory(time.Minute)
+		h.Record("pods", historySnapshot(now, "a"))
+		h.SetRetention(0)
+		if len(h.Snapshots("pods")) != 0 || h.cells != 0 {
+			t.Error("Expected history to be cleared")
+		}
+	})
+}
@@ -103,36 +103,36 @@ func TestBug002ColumnMisalignmentSingleContext(t *testing.T) {
 			// Debug: Print current state
 			t.Logf("isMultiContext: %v, showContextColumn: %v, currentContexts: %v",
 				rv.isMultiContext, rv.showContextColumn, tt.currentContexts)
-			t.Logf("Headers: %v", rv.headers)
-			if len(rv.rows) > 0 {
-				t.Logf("First row: %v", rv.rows[0])
+			t.Logf("Headers: %v", rv.table.Titles())
+			if rv.table.GetRowCount() > 0 {
+				t.Logf("First row: %v", rv.table.RowValues(0))
 			}
 			t.Logf("Viewport: start=%d, height=%d, selectedRow=%d, totalRows=%d",
-				rv.viewportStart, rv.viewportHeight, rv.selectedRow, len(rv.rows))
-			t.Logf("Column widths: %v", rv.columnWidths)
+				rv.viewportStart, rv.viewportHeight, rv.selectedRow, rv.table.GetRowCount())
+			t.Logf("Column widths: %v", rv.table.ColumnWidths())
 			t.Logf("View width: %d, height: %d", rv.width, rv.height) // Check headers
-			if len(rv.headers) != len(tt.expectedHeaders) {
-				t.Errorf("Expected %d headers, got %d", len(tt.expectedHeaders), len(rv.headers))
+			if len(rv.table.Titles()) != len(tt.expectedHeaders) {
+				t.Errorf("Expected %d headers, got %d", len(tt.expectedHeaders), len(rv.table.Titles()))
 				t.Errorf("Expected headers: %v", tt.expectedHeaders)
-				t.Errorf("Actual headers: %v", rv.headers)
+				t.Errorf("Actual headers: %v", rv.table.Titles())
 			}
 
 			for i, expectedHeader := range tt.expectedHeaders {
-				if i >= len(rv.headers) {
+				if i >= len(rv.table.Titles()) {
 					t.Errorf("Missing header at index %d: expected %s", i, expectedHeader)
 					continue
 				}
-				if rv.headers[i] != expectedHeader {
-					t.Errorf("Header mismatch at index %d: expected %s, got %s", i, expectedHeader, rv.headers[i])
+				if rv.table.Titles()[i] != expectedHeader {
+					t.Errorf("Header mismatch at index %d: expected %s, got %s", i, expectedHeader, rv.table.Titles()[i])
 				}
 			}
 
 			// Check row structure
-			if len(rv.rows) == 0 {
+			if rv.table.GetRowCount() == 0 {
 				t.Fatal("No rows found")
 			}
 
-			firstRow := rv.rows[0]
+			firstRow := rv.table.RowValues(0)
 			if len(firstRow) != len(tt.expectedRowStructure) {
 				t.Errorf("Expected row to have %d columns, got %d", len(tt.expectedRowStructure), len(firstRow))
 				t.Errorf("Expected row structure: %v", tt.expectedRowStructure)
@@ -140,9 +140,9 @@ func TestBug002ColumnMisalignmentSingleContext(t *testing.T) {
 			}
 
 			// Check that headers and rows have the same number of columns
-			if len(rv.headers) != len(firstRow) {
-				t.Errorf("Column count mismatch: headers have %d columns, row has %d columns", len(rv.headers), len(firstRow))
-				t.Errorf("Headers: %v", rv.headers)
+			if len(rv.table.Titles()) != len(firstRow) {
+				t.Errorf("Column count mismatch: headers have %d columns, row has %d columns", len(rv.table.Titles()), len(firstRow))
+				t.Errorf("Headers: %v", rv.table.Titles())
 				t.Errorf("Row: %v", firstRow)
 			}
 
@@ -211,11 +211,11 @@ func TestBug006WordWrapBroken(t *testing.T) {
 	longPodName := "very-long-pod-name-that-should-be-wrapped-or-truncated-based-on-setting"
 	longStatus := "ContainerCreatingWithVeryLongReasonThatShouldBeHandledProperly"
 
-	rv.headers = []string{"NAME", "READY", "STATUS", "RESTARTS", "AGE"}
-	rv.rows = [][]string{
+	rv.setHeaders([]string{"NAME", "READY", "STATUS", "RESTARTS", "AGE"})
+	rv.table.SetValues([][]string{
 		{longPodName, "1/1", longStatus, "0", "5m"},
 		{"short-pod", "1/1", "Running", "0", "2m"},
-	}
+	})
 
 	// Set up resource map
 	rv.resourceMap = make(map[int]*selection.ResourceIdentity)
@@ -298,30 +298,14 @@ func TestBug006WordWrapBroken(t *testing.T) {
 				}
 			}
 
-			// Test the styleCellByColumn method directly
-			testCases := []struct {
-				columnName string
-				value      string
-				width      int
-			}{
-				{"NAME", longPodName, 20},
-				{"STATUS", longStatus, 15},
-			}
-
-			for _, tc := range testCases {
-				styledCell := rv.styleCellByColumn(tc.columnName, tc.value, tc.width, false)
-
-				if tt.wordWrap {
-					// When word wrap is ON, content should be truncated to fit width
-					if len(styledCell) > tc.width+10 { // Allow some tolerance for styling
-						t.Errorf("Word wrap ON but cell not truncated. Column: %s, Width: %d, Styled length: %d",
-							tc.columnName, tc.width, len(styledCell))
-					}
-				} else {
-					// When word wrap is OFF, content should not be truncated
-					if !strings.Contains(styledCell, tc.value) && len(tc.value) <= tc.width {
-						t.Errorf("Word wrap OFF but short content truncated. Column: %s", tc.columnName)
-					}
+			// Word wrap caps how wide the table lets each column grow
+			for i, column := range rv.table.Columns() {
+				width := rv.table.ColumnWidths()[i]
+				if tt.wordWrap && width > wrappedColumnWidth(column.Title) {
+					t.Errorf("Word wrap ON but column %s is %d wide", column.Title, width)
+				}
+				if !tt.wordWrap && column.MaxWidth != 0 {
+					t.Errorf("Word wrap OFF but column %s is capped at %d", column.Title, column.MaxWidth)
 				}
 			}
 		})
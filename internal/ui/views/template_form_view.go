@@ -0,0 +1,253 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/components/textinput"
+	"github.com/HamStudy/kubewatch/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TemplateFormView fills in a manifest template's parameters, showing the
+// rendered manifest as it is typed and any error from creating it
+type TemplateFormView struct {
+	template *config.ManifestTemplate
+	inputs   []textinput.Model // One per parameter, in order
+	focused  int
+	errMsg   string // Why the last attempt failed, shown inline
+	creating bool
+
+	width  int
+	height int
+}
+
+// NewTemplateFormView creates a form for template's parameters, prefilled
+// with defaults where one is given
+func NewTemplateFormView(template *config.ManifestTemplate, defaults map[string]string) *TemplateFormView {
+	inputs := make([]textinput.Model, len(template.Parameters))
+	for i, p := range template.Parameters {
+		inputs[i] = textinput.New(parameterValidator(p))
+		inputs[i].SetValue(defaults[p])
+	}
+	return &TemplateFormView{
+		template: template,
+		inputs:   inputs,
+	}
+}
+
+// parameterValidator returns how a parameter is checked as it is typed,
+// going by its name: Name and Namespace must be valid Kubernetes names and
+// anything ending in Port a port number. Other parameters are free text.
+func parameterValidator(parameter string) textinput.Validator {
+	lower := strings.ToLower(parameter)
+	switch {
+	case lower == "name" || lower == "namespace":
+		return textinput.KubernetesName()
+	case strings.HasSuffix(lower, "port"):
+		return textinput.IntRange(1, 65535)
+	}
+	return nil
+}
+
+// Init initializes the view
+func (v *TemplateFormView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages. Esc is handled by the form mode.
+func (v *TemplateFormView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case tea.KeyMsg:
+		if v.creating {
+			return v, nil
+		}
+		params := v.template.Parameters
+		switch msg.Type {
+		case tea.KeyUp, tea.KeyShiftTab:
+			if v.focused > 0 {
+				v.focused--
+			}
+		case tea.KeyDown, tea.KeyTab:
+			if v.focused < len(params)-1 {
+				v.focused++
+			}
+		case tea.KeyEnter:
+			// Nothing is created while a field is invalid; the first one
+			// gets the focus with its error showing
+			for i := range v.inputs {
+				if !v.inputs[i].Submit() {
+					v.focused = i
+					return v, nil
+				}
+			}
+			values := v.trimmedValues()
+			template := v.template
+			return v, func() tea.Msg { return TemplateFormSubmittedMsg{Template: template, Values: values} }
+		default:
+			if v.focused >= 0 && v.focused < len(v.inputs) && v.inputs[v.focused].HandleKey(msg) {
+				v.errMsg = ""
+			}
+		}
+	}
+	return v, nil
+}
+
+// values returns every parameter's value as typed
+func (v *TemplateFormView) values() map[string]string {
+	values := make(map[string]string, len(v.inputs))
+	for i, p := range v.template.Parameters {
+		values[p] = v.inputs[i].Value()
+	}
+	return values
+}
+
+// trimmedValues returns every parameter's value without surrounding spaces
+func (v *TemplateFormView) trimmedValues() map[string]string {
+	values := v.values()
+	for p, val := range values {
+		values[p] = strings.TrimSpace(val)
+	}
+	return values
+}
+
+// Value returns the current value of a parameter
+func (v *TemplateFormView) Value(parameter string) string {
+	for i, p := range v.template.Parameters {
+		if p == parameter {
+			return v.inputs[i].Value()
+		}
+	}
+	return ""
+}
+
+// SetError shows why creating the resources failed and re-enables editing
+func (v *TemplateFormView) SetError(msg string) {
+	v.errMsg = msg
+	v.creating = false
+}
+
+// SetCreating marks the form as waiting for the API server
+func (v *TemplateFormView) SetCreating(creating bool) {
+	v.creating = creating
+	if creating {
+		v.errMsg = ""
+	}
+}
+
+// Error returns the error shown in the form, if any
+func (v *TemplateFormView) Error() string {
+	return v.errMsg
+}
+
+// View renders the form with a preview of the manifest
+func (v *TemplateFormView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	focusedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Bold(true)
+
+	previewStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("250"))
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196"))
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	nameWidth := 0
+	for _, p := range v.template.Parameters {
+		if len(p) > nameWidth {
+			nameWidth = len(p)
+		}
+	}
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Create: " + v.template.Name))
+	content.WriteString("\n\n")
+
+	for i, p := range v.template.Parameters {
+		label := p + ":" + strings.Repeat(" ", nameWidth-len(p)+1)
+		if i == v.focused {
+			content.WriteString(focusedStyle.Render("> "+label) + v.inputs[i].View())
+		} else {
+			content.WriteString("  " + label + v.inputs[i].Value())
+		}
+		content.WriteString("\n")
+		if errView := v.inputs[i].ErrorView(); errView != "" {
+			content.WriteString("  " + strings.Repeat(" ", nameWidth+2) + errView + "\n")
+		}
+	}
+	if len(v.template.Parameters) > 0 {
+		content.WriteString("\n")
+	}
+
+	// Preview the manifest, leaving room for the form around it
+	rendered, err := v.template.Render(v.values())
+	if err != nil {
+		content.WriteString(errorStyle.Render(err.Error()))
+	} else {
+		lines := strings.Split(strings.TrimRight(rendered, "\n"), "\n")
+		if maxLines := v.height - len(v.template.Parameters) - 16; maxLines > 3 && len(lines) > maxLines {
+			lines = append(lines[:maxLines], "...")
+		}
+		content.WriteString(previewStyle.Render(strings.Join(lines, "\n")))
+	}
+	content.WriteString("\n")
+
+	switch {
+	case v.creating:
+		content.WriteString("\n")
+		content.WriteString(labelStyle.Render("Creating..."))
+		content.WriteString("\n")
+	case v.errMsg != "":
+		// API validation errors are long; wrap rather than truncate them
+		style := errorStyle
+		if wrapWidth := v.width - 12; wrapWidth > 20 {
+			style = style.Width(wrapWidth)
+		}
+		content.WriteString("\n")
+		content.WriteString(style.Render("✗ " + v.errMsg))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render("[↑/↓/Tab] Field  [Enter] Create  [Ctrl+U] Clear field  [Esc] Back"))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// SetSize updates the view size
+func (v *TemplateFormView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// TemplateFormSubmittedMsg is sent when the user asks to create the resources
+// from a filled-in template
+type TemplateFormSubmittedMsg struct {
+	Template *config.ManifestTemplate
+	Values   map[string]string
+}
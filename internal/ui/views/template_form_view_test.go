@@ -0,0 +1,53 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTemplateFormValidation(t *testing.T) {
+	template := &config.ManifestTemplate{
+		Name:       "Echo",
+		Parameters: []string{"Namespace", "Port", "Message"},
+		Manifest:   "kind: Pod\n",
+	}
+	v := NewTemplateFormView(template, map[string]string{"Namespace": "Default"})
+	v.SetSize(120, 40)
+
+	// Nothing is shown until a field is edited or submitted
+	if strings.Contains(v.View(), "✗") {
+		t.Fatalf("Expected no errors before submitting, got:\n%s", v.View())
+	}
+
+	// Enter refuses invalid fields, focusing the first with its error
+	v.Update(tea.KeyMsg{Type: tea.KeyTab})
+	v.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if _, cmd := v.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Fatal("Expected no submission while Namespace is invalid")
+	}
+	if v.focused != 0 || !strings.Contains(v.View(), "must be lowercase") {
+		t.Fatalf("Expected the focus back on Namespace with its error, got:\n%s", v.View())
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("default")})
+	v.Update(tea.KeyMsg{Type: tea.KeyTab})
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("65536")})
+	if !strings.Contains(v.View(), "must be between 1 and 65535") {
+		t.Fatalf("Expected the port checked as it is typed, got:\n%s", v.View())
+	}
+	v.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+
+	// Free-text parameters take anything, even nothing
+	_, cmd := v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("Expected the form submitted once valid, got:\n%s", v.View())
+	}
+	msg, ok := cmd().(TemplateFormSubmittedMsg)
+	if !ok || msg.Values["Namespace"] != "default" || msg.Values["Port"] != "6553" || msg.Values["Message"] != "" {
+		t.Errorf("Expected the typed values submitted, got %#v", cmd())
+	}
+}
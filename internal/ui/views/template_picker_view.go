@@ -0,0 +1,166 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TemplatePickerView lists the manifest templates resources can be created
+// from, and offers to clean up what was created earlier
+type TemplatePickerView struct {
+	templates []*config.ManifestTemplate
+	selected  int
+	status    string
+
+	width  int
+	height int
+}
+
+// NewTemplatePickerView creates a picker over templates
+func NewTemplatePickerView(templates []*config.ManifestTemplate) *TemplatePickerView {
+	return &TemplatePickerView{
+		templates: templates,
+	}
+}
+
+// Init initializes the view
+func (v *TemplatePickerView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (v *TemplatePickerView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if v.selected > 0 {
+				v.selected--
+			}
+		case "down", "j":
+			if v.selected < len(v.templates)-1 {
+				v.selected++
+			}
+		case "enter":
+			if t := v.SelectedTemplate(); t != nil {
+				return v, func() tea.Msg { return TemplateSelectedMsg{Template: t} }
+			}
+		case "x":
+			return v, func() tea.Msg { return CleanupRequestedMsg{} }
+		}
+	}
+	return v, nil
+}
+
+// SelectedTemplate returns the highlighted template
+func (v *TemplatePickerView) SelectedTemplate() *config.ManifestTemplate {
+	if v.selected < 0 || v.selected >= len(v.templates) {
+		return nil
+	}
+	return v.templates[v.selected]
+}
+
+// SetStatus shows a message under the list, e.g. the outcome of a cleanup
+func (v *TemplatePickerView) SetStatus(status string) {
+	v.status = status
+}
+
+// View renders the template picker
+func (v *TemplatePickerView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(true)
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	nameWidth := 0
+	for _, t := range v.templates {
+		if len(t.Name) > nameWidth {
+			nameWidth = len(t.Name)
+		}
+	}
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Create from Template"))
+	content.WriteString("\n\n")
+
+	if len(v.templates) == 0 {
+		content.WriteString(labelStyle.Render("No templates. Add them under manifestTemplates: in the config file."))
+		content.WriteString("\n")
+	}
+
+	for i, t := range v.templates {
+		line := fmt.Sprintf("%-*s", nameWidth, t.Name)
+		if t.Description != "" {
+			line += "  " + t.Description
+		}
+		if i == v.selected {
+			content.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n")
+	}
+
+	if v.status != "" {
+		content.WriteString("\n")
+		content.WriteString(statusStyle(v.status).Render(v.status))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render("[↑/↓] Select  [Enter] Fill in  [x] Clean up created resources  [Esc] Close"))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// SetSize updates the view size
+func (v *TemplatePickerView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// statusStyle colors a status line red when it reports a failure
+func statusStyle(status string) lipgloss.Style {
+	if strings.HasPrefix(status, "✗") {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("82"))
+}
+
+// TemplateSelectedMsg is sent when the user picks a template to fill in
+type TemplateSelectedMsg struct {
+	Template *config.ManifestTemplate
+}
+
+// CleanupRequestedMsg is sent when the user asks to delete the resources
+// created from kubewatch
+type CleanupRequestedMsg struct{}
@@ -0,0 +1,199 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
+)
+
+// TopologyView shows how a workload's pods are spread across nodes and zones
+type TopologyView struct {
+	resourceType string
+	resourceName string
+	namespace    string
+	context      string
+
+	spread    k8s.TopologySpread
+	podCount  int
+	threshold int
+	loaded    bool
+	err       error
+
+	width  int
+	height int
+}
+
+// NewTopologyView creates a new topology overlay for a resource
+func NewTopologyView(resourceType, resourceName, namespace, context string) *TopologyView {
+	return &TopologyView{
+		resourceType: resourceType,
+		resourceName: resourceName,
+		namespace:    namespace,
+		context:      context,
+		threshold:    k8s.DefaultTopologySkewThreshold,
+	}
+}
+
+// SupportsTopology reports whether a topology summary can be shown for the resource type
+func SupportsTopology(resourceType core.ResourceType) bool {
+	switch resourceType {
+	case core.ResourceTypePod, core.ResourceTypeDeployment, core.ResourceTypeStatefulSet:
+		return true
+	}
+	return false
+}
+
+// Init initializes the view
+func (v *TopologyView) Init() tea.Cmd {
+	return nil
+}
+
+// LoadTopologyWithClient fetches the resource's pods and joins them with cached node labels
+func (v *TopologyView) LoadTopologyWithClient(ctx context.Context, client *k8s.Client, nodes *k8s.NodeInfoCache) tea.Cmd {
+	resourceType := v.resourceType
+	namespace := v.namespace
+	name := v.resourceName
+
+	return func() tea.Msg {
+		var pods []v1.Pod
+		var err error
+
+		switch core.ResourceType(resourceType) {
+		case core.ResourceTypeDeployment:
+			pods, err = client.GetPodsForDeployment(ctx, namespace, name)
+		case core.ResourceTypeStatefulSet:
+			pods, err = client.GetPodsForStatefulSet(ctx, namespace, name)
+		case core.ResourceTypePod:
+			pods, err = client.GetSiblingPods(ctx, namespace, name)
+		default:
+			err = fmt.Errorf("topology is not available for %s", resourceType)
+		}
+		if err != nil {
+			return topologyLoadedMsg{err: err}
+		}
+
+		nodeLabels, err := nodes.NodeLabels(ctx)
+		if err != nil {
+			return topologyLoadedMsg{err: err}
+		}
+
+		return topologyLoadedMsg{
+			spread:   k8s.ComputeTopologySpread(pods, nodeLabels),
+			podCount: len(pods),
+		}
+	}
+}
+
+// Update handles messages
+func (v *TopologyView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case topologyLoadedMsg:
+		v.loaded = true
+		v.err = msg.err
+		if msg.err == nil {
+			v.spread = msg.spread
+			v.podCount = msg.podCount
+		}
+	}
+	return v, nil
+}
+
+// View renders the topology overlay
+func (v *TopologyView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	warnStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		Bold(true)
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(fmt.Sprintf("Topology: %s/%s", strings.ToLower(FormatResourceType(v.resourceType)), v.resourceName)))
+	content.WriteString("\n")
+	if v.context != "" {
+		content.WriteString(labelStyle.Render(fmt.Sprintf("Context: %s  Namespace: %s", v.context, v.namespace)))
+	} else {
+		content.WriteString(labelStyle.Render(fmt.Sprintf("Namespace: %s", v.namespace)))
+	}
+	content.WriteString("\n\n")
+
+	switch {
+	case v.err != nil:
+		content.WriteString(fmt.Sprintf("Error: %s", k8s.UserMessage(v.err)))
+	case !v.loaded:
+		content.WriteString("Loading topology...")
+	default:
+		content.WriteString(fmt.Sprintf("%d pods", v.podCount))
+		if v.spread.Unscheduled > 0 {
+			content.WriteString(fmt.Sprintf(", %d unscheduled", v.spread.Unscheduled))
+		}
+		content.WriteString("\n\n")
+		content.WriteString(v.renderSummary(v.spread.ZoneSummary(v.threshold), warnStyle))
+		content.WriteString("\n")
+		content.WriteString(v.renderSummary(v.spread.NodeSummary(v.threshold), warnStyle))
+	}
+
+	content.WriteString("\n\n")
+	content.WriteString(labelStyle.Render("[r] Refresh  [Esc/T] Close"))
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// renderSummary highlights summaries that carry a skew warning
+func (v *TopologyView) renderSummary(summary string, warnStyle lipgloss.Style) string {
+	if strings.HasSuffix(summary, "⚠") {
+		return warnStyle.Render(summary)
+	}
+	return summary
+}
+
+// SetSize updates the view size
+func (v *TopologyView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// GetContext returns the context of the resource being shown
+func (v *TopologyView) GetContext() string {
+	return v.context
+}
+
+// GetSpread returns the most recently loaded topology spread
+func (v *TopologyView) GetSpread() k8s.TopologySpread {
+	return v.spread
+}
+
+// topologyLoadedMsg is sent when the topology has been computed
+type topologyLoadedMsg struct {
+	spread   k8s.TopologySpread
+	podCount int
+	err      error
+}
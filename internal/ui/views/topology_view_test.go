@@ -0,0 +1,85 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestTopologyViewRendering(t *testing.T) {
+	nodeLabels := map[string]map[string]string{
+		"node-a": {k8s.ZoneLabel: "us-east-1a"},
+		"node-b": {k8s.ZoneLabel: "us-east-1b"},
+	}
+	skewed := []v1.Pod{
+		{Spec: v1.PodSpec{NodeName: "node-a"}},
+		{Spec: v1.PodSpec{NodeName: "node-a"}},
+		{Spec: v1.PodSpec{NodeName: "node-a"}},
+		{Spec: v1.PodSpec{NodeName: "node-b"}},
+	}
+
+	tests := []struct {
+		name     string
+		msg      *topologyLoadedMsg
+		contains []string
+	}{
+		{
+			name:     "loading",
+			msg:      nil,
+			contains: []string{"Topology: deployment/web", "Loading topology..."},
+		},
+		{
+			name: "skewed spread",
+			msg: &topologyLoadedMsg{
+				spread:   k8s.ComputeTopologySpread(skewed, nodeLabels),
+				podCount: len(skewed),
+			},
+			contains: []string{"4 pods", "zone us-east-1a: 3, us-east-1b: 1 ⚠", "node node-a: 3, node-b: 1 ⚠"},
+		},
+		{
+			name:     "error",
+			msg:      &topologyLoadedMsg{err: errors.New("forbidden")},
+			contains: []string{"Error: forbidden"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := NewTopologyView("Deployments", "web", "default", "")
+			view.SetSize(120, 30)
+			if tt.msg != nil {
+				view.Update(*tt.msg)
+			}
+
+			output := view.View()
+			for _, want := range tt.contains {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestSupportsTopology(t *testing.T) {
+	tests := []struct {
+		resourceType core.ResourceType
+		expected     bool
+	}{
+		{core.ResourceTypePod, true},
+		{core.ResourceTypeDeployment, true},
+		{core.ResourceTypeStatefulSet, true},
+		{core.ResourceTypeService, false},
+		{core.ResourceTypeConfigMap, false},
+	}
+
+	for _, tt := range tests {
+		if got := SupportsTopology(tt.resourceType); got != tt.expected {
+			t.Errorf("SupportsTopology(%s) = %v, expected %v", tt.resourceType, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,446 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
+)
+
+// UsageView shows CPU and memory use by namespace, like kubectl top, next to
+// what the namespace's pods request and what its quotas allow. A namespace
+// can be drilled into to see its pods sorted the same way. With several
+// contexts, each context's namespaces are their own rows.
+type UsageView struct {
+	contexts    []string // In the order rows are labelled; [""] for a single client
+	reports     map[string]*k8s.UsageReport
+	errs        map[string]error
+	requestedAt time.Time
+
+	sortBy   k8s.UsageSort
+	rows     []k8s.NamespaceUsage
+	selected int
+
+	// The namespace drilled into, and its pods
+	drilled     *k8s.NamespaceUsage
+	pods        []k8s.PodUsage
+	podSelected int
+
+	width  int
+	height int
+}
+
+// NewUsageView creates a usage overlay over contexts; a single client is
+// passed as the one context ""
+func NewUsageView(contexts []string) *UsageView {
+	return &UsageView{
+		contexts: contexts,
+		reports:  make(map[string]*k8s.UsageReport),
+		errs:     make(map[string]error),
+	}
+}
+
+// Init initializes the view
+func (v *UsageView) Init() tea.Cmd {
+	return nil
+}
+
+// LoadUsageWithClients fetches the usage report of each context
+func (v *UsageView) LoadUsageWithClients(ctx context.Context, clients map[string]*k8s.Client) tea.Cmd {
+	v.requestedAt = time.Now()
+	var cmds []tea.Cmd
+	for _, contextName := range v.contexts {
+		client := clients[contextName]
+		if client == nil {
+			continue
+		}
+		contextName := contextName
+		cmds = append(cmds, func() tea.Msg {
+			report, err := client.GetUsageReport(ctx)
+			return usageLoadedMsg{context: contextName, report: report, err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// Due reports whether the usage was last fetched at least interval ago, so
+// the overlay follows the metrics polling cadence
+func (v *UsageView) Due(interval time.Duration) bool {
+	return time.Since(v.requestedAt) >= interval
+}
+
+// Update handles messages. Esc is handled by the usage mode.
+func (v *UsageView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.SetSize(msg.Width, msg.Height)
+
+	case usageLoadedMsg:
+		v.setReport(msg.context, msg.report, msg.err)
+
+	case tea.KeyMsg:
+		selected, count := &v.selected, len(v.rows)
+		if v.drilled != nil {
+			selected, count = &v.podSelected, len(v.pods)
+		}
+		switch msg.String() {
+		case "up", "k":
+			if *selected > 0 {
+				*selected--
+			}
+		case "down", "j":
+			if *selected < count-1 {
+				*selected++
+			}
+		case "home", "g":
+			*selected = 0
+		case "end", "G":
+			*selected = max(count-1, 0)
+		case "s":
+			v.sortBy = v.sortBy.Next()
+			v.sortRows()
+		case "enter":
+			if v.drilled == nil {
+				v.drillIn()
+				break
+			}
+			if v.podSelected < len(v.pods) {
+				pod := v.pods[v.podSelected].Pod
+				selected := UsagePodSelectedMsg{Context: v.drilled.Context, Namespace: pod.Namespace, Name: pod.Name}
+				return v, func() tea.Msg { return selected }
+			}
+		}
+	}
+	return v, nil
+}
+
+// setReport replaces a context's report and rebuilds the rows, keeping the
+// same namespace and pod selected
+func (v *UsageView) setReport(contextName string, report *k8s.UsageReport, err error) {
+	v.errs[contextName] = err
+	if err == nil {
+		v.reports[contextName] = report
+	}
+	v.sortRows()
+}
+
+// sortRows gathers the namespaces of every context in the current order,
+// and the pods of the namespace drilled into, keeping the selections
+func (v *UsageView) sortRows() {
+	previous, hadSelection := v.SelectedNamespace()
+	v.rows = nil
+	for _, contextName := range v.contexts {
+		report := v.reports[contextName]
+		if report == nil {
+			continue
+		}
+		for _, usage := range report.Namespaces {
+			usage.Context = contextName
+			v.rows = append(v.rows, usage)
+		}
+	}
+	k8s.SortNamespaceUsage(v.rows, v.sortBy)
+	v.selected = min(v.selected, max(len(v.rows)-1, 0))
+	if hadSelection {
+		if i := v.rowIndex(previous.Context, previous.Namespace); i >= 0 {
+			v.selected = i
+		}
+	}
+
+	if v.drilled == nil {
+		return
+	}
+	var previousPod string
+	if v.podSelected < len(v.pods) {
+		previousPod = v.pods[v.podSelected].Pod.Name
+	}
+	i := v.rowIndex(v.drilled.Context, v.drilled.Namespace)
+	if i < 0 {
+		// The namespace is gone; keep showing what was last seen
+		return
+	}
+	drilled := v.rows[i]
+	v.drilled = &drilled
+	v.pods = append([]k8s.PodUsage(nil), v.reports[v.drilled.Context].Pods[v.drilled.Namespace]...)
+	k8s.SortPodUsage(v.pods, v.sortBy)
+	v.podSelected = min(v.podSelected, max(len(v.pods)-1, 0))
+	for i, pod := range v.pods {
+		if pod.Pod.Name == previousPod {
+			v.podSelected = i
+			break
+		}
+	}
+}
+
+// rowIndex returns the row of a context's namespace, or -1
+func (v *UsageView) rowIndex(contextName, namespace string) int {
+	for i, row := range v.rows {
+		if row.Context == contextName && row.Namespace == namespace {
+			return i
+		}
+	}
+	return -1
+}
+
+// drillIn shows the pods of the selected namespace
+func (v *UsageView) drillIn() {
+	usage, ok := v.SelectedNamespace()
+	if !ok {
+		return
+	}
+	v.drilled = &usage
+	v.podSelected = 0
+	v.sortRows()
+}
+
+// Back returns from a namespace's pods to the namespaces, reporting whether
+// there was a namespace to return from
+func (v *UsageView) Back() bool {
+	if v.drilled == nil {
+		return false
+	}
+	v.drilled = nil
+	v.pods = nil
+	return true
+}
+
+// SelectedNamespace returns the highlighted namespace row
+func (v *UsageView) SelectedNamespace() (k8s.NamespaceUsage, bool) {
+	if v.selected < 0 || v.selected >= len(v.rows) {
+		return k8s.NamespaceUsage{}, false
+	}
+	return v.rows[v.selected], true
+}
+
+// multiContext reports whether rows are labelled by context
+func (v *UsageView) multiContext() bool {
+	return len(v.contexts) > 1
+}
+
+// View renders the usage overlay
+func (v *UsageView) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("86"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241"))
+
+	warnStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(true)
+
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2)
+
+	var content strings.Builder
+	if v.drilled != nil {
+		title := "Usage: " + v.drilled.Namespace
+		if v.drilled.Context != "" {
+			title += " (" + v.drilled.Context + ")"
+		}
+		content.WriteString(titleStyle.Render(title))
+	} else {
+		content.WriteString(titleStyle.Render("Usage by namespace"))
+	}
+	content.WriteString("\n")
+	direction := "↓"
+	if v.sortBy == k8s.UsageSortName {
+		direction = "↑"
+	}
+	content.WriteString(labelStyle.Render(fmt.Sprintf("Sort: %s %s", v.sortBy, direction)))
+	content.WriteString("\n\n")
+
+	switch {
+	case len(v.reports) == 0 && len(v.errs) == 0:
+		content.WriteString("Loading usage...\n")
+	case v.drilled != nil:
+		content.WriteString(v.renderPods(labelStyle, selectedStyle))
+	default:
+		content.WriteString(v.renderNamespaces(labelStyle, selectedStyle))
+	}
+	content.WriteString(v.renderProblems(labelStyle, warnStyle))
+
+	content.WriteString("\n")
+	if v.drilled != nil {
+		content.WriteString(labelStyle.Render("[↑/↓] Select  [Enter] Show pod  [s] Sort  [r] Refresh  [Esc] Namespaces"))
+	} else {
+		content.WriteString(labelStyle.Render("[↑/↓] Select  [Enter] Pods  [s] Sort  [r] Refresh  [Esc/U] Close"))
+	}
+
+	if maxWidth := v.width - 4; maxWidth > 20 {
+		borderStyle = borderStyle.MaxWidth(maxWidth)
+	}
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		borderStyle.Render(content.String()),
+	)
+}
+
+// renderNamespaces lists the namespaces with their usage, requests and quotas
+func (v *UsageView) renderNamespaces(labelStyle, selectedStyle lipgloss.Style) string {
+	if len(v.rows) == 0 {
+		return labelStyle.Render("  No running pods") + "\n"
+	}
+
+	contextColumn := func(contextName string) string {
+		if !v.multiContext() {
+			return ""
+		}
+		return fmt.Sprintf("%-16s ", truncateCell(contextName, 16))
+	}
+
+	var b strings.Builder
+	b.WriteString(labelStyle.Render(fmt.Sprintf("  %s%-24s %5s %8s %18s %8s %20s",
+		contextColumn("CONTEXT"), "NAMESPACE", "PODS", "CPU", "CPU REQ / QUOTA", "MEMORY", "MEM REQ / QUOTA")))
+	b.WriteString("\n")
+
+	partial := false
+	start, end := visibleWindow(v.selected, len(v.rows), v.height-16)
+	if start > 0 {
+		b.WriteString(labelStyle.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+	}
+	for i := start; i < end; i++ {
+		usage := v.rows[i]
+		cpu, memory := usage.CPU(), usage.Memory()
+		if usage.Partial() && usage.Measured > 0 {
+			// What is measured is a floor on what the namespace uses
+			cpu, memory = "≥"+cpu, "≥"+memory
+			partial = true
+		}
+		line := fmt.Sprintf("%s%-24s %5d %8s %18s %8s %20s",
+			contextColumn(usage.Context), truncateCell(usage.Namespace, 24), usage.Pods, cpu,
+			k8s.FormatRequestsOfQuota(usage.RequestsCPU, usage.QuotaCPU), memory,
+			k8s.FormatRequestsOfQuota(usage.RequestsMemory, usage.QuotaMemory))
+		if i == v.selected {
+			b.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("\n")
+	}
+	if end < len(v.rows) {
+		b.WriteString(labelStyle.Render(fmt.Sprintf("  ↓ %d more", len(v.rows)-end)) + "\n")
+	}
+	if partial {
+		b.WriteString(labelStyle.Render("  ≥ Some pods have no metrics yet and are not counted") + "\n")
+	}
+	return b.String()
+}
+
+// renderPods lists the pods of the namespace drilled into with their usage
+// and requests
+func (v *UsageView) renderPods(labelStyle, selectedStyle lipgloss.Style) string {
+	if len(v.pods) == 0 {
+		return labelStyle.Render("  No running pods") + "\n"
+	}
+
+	now := time.Now()
+	var b strings.Builder
+	b.WriteString(labelStyle.Render(fmt.Sprintf("  %-40s %-18s %8s %8s %8s %8s", "NAME", "STATUS", "CPU", "MEMORY", "CPU REQ", "MEM REQ")))
+	b.WriteString("\n")
+
+	start, end := visibleWindow(v.podSelected, len(v.pods), v.height-16)
+	if start > 0 {
+		b.WriteString(labelStyle.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+	}
+	for i := start; i < end; i++ {
+		usage := v.pods[i]
+		cpu, memory := usage.CPU(), usage.Memory()
+		if !usage.Measured {
+			cpu, memory = "?", "?"
+		}
+		line := fmt.Sprintf("%-40s %-18s %8s %8s %8s %8s",
+			truncateCell(usage.Pod.Name, 40), truncateCell(core.PodStatus(usage.Pod, now), 18), cpu, memory,
+			formatQuantity(usage.Requests[v1.ResourceCPU]), formatQuantity(usage.Requests[v1.ResourceMemory]))
+		if i == v.podSelected {
+			b.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("\n")
+	}
+	if end < len(v.pods) {
+		b.WriteString(labelStyle.Render(fmt.Sprintf("  ↓ %d more", len(v.pods)-end)) + "\n")
+	}
+	return b.String()
+}
+
+// renderProblems says which contexts failed to load and which have no
+// metrics or quotas
+func (v *UsageView) renderProblems(labelStyle, warnStyle lipgloss.Style) string {
+	var b strings.Builder
+	for _, contextName := range v.contexts {
+		prefix := ""
+		if contextName != "" {
+			prefix = contextName + ": "
+		}
+		if err := v.errs[contextName]; err != nil {
+			b.WriteString(warnStyle.Render(fmt.Sprintf("✗ %s%s", prefix, k8s.UserMessage(err))) + "\n")
+			continue
+		}
+		report := v.reports[contextName]
+		if report == nil {
+			continue
+		}
+		if report.MetricsErr != nil {
+			b.WriteString(warnStyle.Render(fmt.Sprintf("⚠ %sNo usage: %v", prefix, report.MetricsErr)) + "\n")
+		}
+		if report.QuotaErr != nil {
+			b.WriteString(labelStyle.Render(fmt.Sprintf("  %sQuotas not shown: %s", prefix, k8s.UserMessage(report.QuotaErr))) + "\n")
+		}
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return "\n" + b.String()
+}
+
+// visibleWindow returns the rows to show of count so the selected one stays
+// on screen when there is room for only maxItems, and at least a few
+func visibleWindow(selected, count, maxItems int) (start, end int) {
+	maxItems = max(maxItems, 3)
+	if count <= maxItems {
+		return 0, count
+	}
+	start = min(max(selected-maxItems/2, 0), count-maxItems)
+	return start, start + maxItems
+}
+
+// SetSize updates the view size
+func (v *UsageView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// usageLoadedMsg is sent when a context's usage report has been fetched
+type usageLoadedMsg struct {
+	context string
+	report  *k8s.UsageReport
+	err     error
+}
+
+// UsagePodSelectedMsg is sent when the user picks a pod of a namespace to
+// show in the list
+type UsagePodSelectedMsg struct {
+	Context   string
+	Namespace string
+	Name      string
+}
@@ -0,0 +1,108 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// usageTestReport is a report of one namespace's pods, each using cpu
+// millicores as given and measured unless its usage is negative
+func usageTestReport(namespace string, milliCPU map[string]int64) *k8s.UsageReport {
+	var pods []v1.Pod
+	metrics := k8s.PodMetricsSet{}
+	for name, cpu := range milliCPU {
+		pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		pod.Status.Phase = v1.PodRunning
+		pod.Spec.Containers = []v1.Container{{Name: "app", Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+		}}}
+		pods = append(pods, pod)
+		if cpu >= 0 {
+			metrics[k8s.PodKey{Namespace: namespace, Name: name}] = &k8s.PodMetrics{
+				Containers: []k8s.ContainerMetrics{{Name: "app", MilliCPU: cpu, MemoryBytes: k8s.Mi}},
+			}
+		}
+	}
+	return k8s.BuildUsageReport(pods, metrics, nil)
+}
+
+func TestUsageViewLabelsRowsByContext(t *testing.T) {
+	v := NewUsageView([]string{"prod", "staging"})
+	v.SetSize(160, 50)
+	if view := v.View(); !strings.Contains(view, "Loading usage") {
+		t.Errorf("Expected loading before any report, got:\n%s", view)
+	}
+
+	v.Update(usageLoadedMsg{context: "prod", report: usageTestReport("web", map[string]int64{"web-1": 300, "web-2": -1})})
+	v.Update(usageLoadedMsg{context: "staging", report: usageTestReport("web", map[string]int64{"web-1": 500})})
+
+	if len(v.rows) != 2 {
+		t.Fatalf("Expected the same namespace of each context as its own row, got %+v", v.rows)
+	}
+	if v.rows[0].Context != "staging" || v.rows[1].Context != "prod" {
+		t.Errorf("Expected the busiest first, got %s then %s", v.rows[0].Context, v.rows[1].Context)
+	}
+	view := v.View()
+	for _, want := range []string{"CONTEXT", "staging", "≥300m", "200m", "Some pods have no metrics"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the view, got:\n%s", want, view)
+		}
+	}
+
+	// Sorting by name keeps the selected namespace selected
+	v.Update(tea.KeyMsg{Type: tea.KeyDown})
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if selected, _ := v.SelectedNamespace(); selected.Context != "prod" || v.rows[0].Context != "prod" {
+		t.Errorf("Expected prod first by name and still selected, got %+v", selected)
+	}
+
+	v.Update(usageLoadedMsg{context: "staging", err: errors.New("connection refused")})
+	if view := v.View(); !strings.Contains(view, "staging: connection refused") {
+		t.Errorf("Expected the failing context named, got:\n%s", view)
+	}
+}
+
+func TestUsageViewDrillsIntoNamespace(t *testing.T) {
+	v := NewUsageView([]string{""})
+	v.SetSize(160, 50)
+	v.Update(usageLoadedMsg{report: usageTestReport("web", map[string]int64{"quiet": 5, "busy": 800, "new": -1})})
+
+	v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	var names []string
+	for _, pod := range v.pods {
+		names = append(names, pod.Pod.Name)
+	}
+	if strings.Join(names, ",") != "busy,quiet,new" {
+		t.Fatalf("Expected the pods by cpu like kubectl top, got %v", names)
+	}
+	if view := v.View(); !strings.Contains(view, "Usage: web") || strings.Contains(view, "CONTEXT") {
+		t.Errorf("Expected the namespace's pods without contexts, got:\n%s", view)
+	}
+
+	// A refresh keeps the pod selected as the order changes
+	v.Update(tea.KeyMsg{Type: tea.KeyDown})
+	v.Update(usageLoadedMsg{report: usageTestReport("web", map[string]int64{"quiet": 900, "busy": 800, "new": 1})})
+	if v.pods[v.podSelected].Pod.Name != "quiet" || v.podSelected != 0 {
+		t.Errorf("Expected quiet still selected at the top, got %d", v.podSelected)
+	}
+
+	_, cmd := v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected Enter on a pod to show it")
+	}
+	if msg, ok := cmd().(UsagePodSelectedMsg); !ok || msg.Namespace != "web" || msg.Name != "quiet" {
+		t.Errorf("Expected web/quiet picked, got %+v", cmd())
+	}
+
+	if !v.Back() || v.Back() {
+		t.Error("Expected Back to leave the namespace once")
+	}
+}
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/client-go/rest"
+
+	"github.com/HamStudy/kubewatch/internal/core"
+	"github.com/HamStudy/kubewatch/internal/k8s"
+	"github.com/HamStudy/kubewatch/internal/ui/views"
+)
+
+// workloadPodsTestApp lists the deployments of namespace shop: checkout,
+// whose selector is a matchExpression, and cart. Its pods are checkout's
+// two, one a canary the selector leaves out, and cart's one.
+func workloadPodsTestApp(t *testing.T) *App {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/apis/apps/v1":
+			w.Write([]byte(`{"kind":"APIResourceList","apiVersion":"v1","groupVersion":"apps/v1","resources":[
+				{"name":"deployments","namespaced":true,"kind":"Deployment","verbs":["list"]}]}`))
+		case "/apis/apps/v1/namespaces/shop/deployments":
+			w.Write([]byte(`{"kind":"DeploymentList","apiVersion":"apps/v1","items":[
+				{"metadata":{"name":"cart","namespace":"shop"},"spec":{"selector":{"matchLabels":{"app":"cart"}}}},
+				{"metadata":{"name":"checkout","namespace":"shop"},"spec":{"selector":{"matchExpressions":[
+					{"key":"app","operator":"In","values":["checkout"]},
+					{"key":"track","operator":"NotIn","values":["canary"]}]}}}]}`))
+		case "/api/v1/namespaces/shop/pods":
+			w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[
+				{"metadata":{"name":"cart-6d8b9-xk2lp","namespace":"shop","labels":{"app":"cart"}},"spec":{"containers":[{"name":"app"}]},"status":{"phase":"Running"}},
+				{"metadata":{"name":"checkout-7f9c5-abcde","namespace":"shop","labels":{"app":"checkout"}},"spec":{"containers":[{"name":"app"}]},"status":{"phase":"Running"}},
+				{"metadata":{"name":"checkout-canary-q7wzt","namespace":"shop","labels":{"app":"checkout","track":"canary"}},"spec":{"containers":[{"name":"app"}]},"status":{"phase":"Running"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := k8s.NewClientFromConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+
+	state := &core.State{CurrentResourceType: core.ResourceTypeDeployment, CurrentNamespace: "shop", CurrentContext: "prod"}
+	app := NewApp(context.Background(), client, state, &core.Config{RefreshInterval: 5})
+	app.isMultiContext = false
+	app.k8sClient = client
+	app.resourceView = views.NewResourceView(state, client)
+	app.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	return app
+}
+
+// loadList runs a refresh and shows its result
+func loadList(app *App, cmd tea.Cmd) {
+	if cmd == nil {
+		cmd = app.resourceView.RefreshResources()
+	}
+	if msg := cmd(); msg != nil {
+		app.Update(msg)
+	}
+}
+
+func TestWorkloadPodsRoundTrip(t *testing.T) {
+	app := workloadPodsTestApp(t)
+	loadList(app, nil)
+	if !app.resourceView.SelectResource(core.ResourceRef{Namespace: "shop", Name: "checkout"}) {
+		t.Fatal("Expected the checkout deployment listed")
+	}
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if app.state.CurrentResourceType != core.ResourceTypePod || len(app.navStack) != 1 {
+		t.Fatalf("Expected p to list the pods, got %s with %d jumps", app.state.CurrentResourceType, len(app.navStack))
+	}
+	loadList(app, cmd)
+	view := app.View()
+	if !strings.Contains(view, "pods of: checkout") || !strings.Contains(view, "checkout-7f9c5-abcde") {
+		t.Errorf("Expected checkout's pod listed under its chip, got:\n%s", view)
+	}
+	for _, other := range []string{"cart-6d8b9-xk2lp", "checkout-canary-q7wzt"} {
+		if strings.Contains(view, other) {
+			t.Errorf("Expected %s left out by the selector, got:\n%s", other, view)
+		}
+	}
+
+	// Backspace returns to the deployments, checkout still selected
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if app.state.CurrentResourceType != core.ResourceTypeDeployment || len(app.navStack) != 0 {
+		t.Fatalf("Expected Backspace to return to the deployments, got %s", app.state.CurrentResourceType)
+	}
+	loadList(app, cmd)
+	if got := app.resourceView.SelectedResourceRef().Name; got != "checkout" {
+		t.Errorf("Expected checkout selected again, got %q", got)
+	}
+
+	// Pods listed another way are not scoped
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	app.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	loadList(app, app.switchResourceType(core.ResourceTypePod))
+	if scope := app.resourceView.PodScope(); scope != nil {
+		t.Errorf("Expected the pod list unscoped, got %s", scope)
+	}
+}
+
+func TestWorkloadPodsChipRemovedByEsc(t *testing.T) {
+	app := workloadPodsTestApp(t)
+	loadList(app, nil)
+	app.resourceView.SelectResource(core.ResourceRef{Namespace: "shop", Name: "checkout"})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	loadList(app, cmd)
+
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.resourceView.PodScope() != nil {
+		t.Fatal("Expected Esc to remove the chip")
+	}
+	loadList(app, cmd)
+	if view := app.View(); !strings.Contains(view, "cart-6d8b9-xk2lp") || strings.Contains(view, "pods of: checkout") {
+		t.Errorf("Expected every pod listed without the chip, got:\n%s", view)
+	}
+	// The way back is kept
+	if len(app.navStack) != 1 {
+		t.Errorf("Expected Backspace still to return to the deployments, got %d jumps", len(app.navStack))
+	}
+}
+
+func TestWorkloadPodsNeedsWorkload(t *testing.T) {
+	app := createTestApp(t)
+	app.resourceView.SetTestData([]string{"NAME", "STATUS"}, [][]string{{"web-1", "Running"}})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if len(app.navStack) != 0 || app.resourceView.PodScope() != nil {
+		t.Error("Expected p to do nothing in the pod list")
+	}
+	if view := app.View(); !strings.Contains(view, "Select a deployment or statefulset") {
+		t.Errorf("Expected a notice saying what p needs, got:\n%s", view)
+	}
+}
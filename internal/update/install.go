@@ -0,0 +1,172 @@
+package update
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// binaryName is the kubewatch binary's name inside a release archive
+const binaryName = "kubewatch"
+
+// AssetName returns the name of the release archive for a platform, as the
+// release build names it: kubewatch_Linux_x86_64.tar.gz and the like
+func AssetName(goos, goarch string) string {
+	arch := goarch
+	switch goarch {
+	case "amd64":
+		arch = "x86_64"
+	case "386":
+		arch = "i386"
+	}
+	osName := goos
+	if osName != "" {
+		osName = strings.ToUpper(goos[:1]) + goos[1:]
+	}
+	return fmt.Sprintf("kubewatch_%s_%s.tar.gz", osName, arch)
+}
+
+// Install downloads release's archive for goos/goarch, checks it against
+// the release's checksums, and replaces the binary at exePath with the one
+// inside. The binary replaced is kept beside it; its path is returned. The
+// new binary is written next to the old and renamed over it, so exePath is
+// never left half-written.
+func Install(ctx context.Context, client *http.Client, release *Release, goos, goarch, exePath string) (backup string, err error) {
+	userAgent := "kubewatch-self-update"
+	name := AssetName(goos, goarch)
+	archive := release.asset(name)
+	if archive == nil {
+		return "", fmt.Errorf("release %s has no %s", release.Tag, name)
+	}
+	checksums := release.asset(ChecksumsAsset)
+	if checksums == nil {
+		return "", fmt.Errorf("release %s has no %s to verify %s against", release.Tag, ChecksumsAsset, name)
+	}
+
+	sums, err := get(ctx, client, checksums.URL, userAgent)
+	if err != nil {
+		return "", fmt.Errorf("downloading checksums: %w", err)
+	}
+	want, err := checksumFor(sums, name)
+	if err != nil {
+		return "", err
+	}
+	data, err := get(ctx, client, archive.URL, userAgent)
+	if err != nil {
+		return "", fmt.Errorf("downloading %s: %w", name, err)
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return "", fmt.Errorf("%s does not match its checksum (got %s, want %s)", name, got, want)
+	}
+
+	binary, err := extractBinary(data)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", name, err)
+	}
+	return replaceBinary(exePath, binary)
+}
+
+// checksumFor finds name's SHA-256 in a checksums file of "SUM  NAME" lines
+func checksumFor(checksums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s lists no checksum for %s", ChecksumsAsset, name)
+}
+
+// extractBinary returns the kubewatch binary from a .tar.gz archive
+func extractBinary(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("no %s binary in the archive", binaryName)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag == tar.TypeReg && path.Base(header.Name) == binaryName {
+			return io.ReadAll(io.LimitReader(tr, maxDownload))
+		}
+	}
+}
+
+// replaceBinary puts binary at exePath, keeping a copy of what was there at
+// exePath.bak
+func replaceBinary(exePath string, binary []byte) (backup string, err error) {
+	info, err := os.Stat(exePath)
+	if err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(exePath), ".kubewatch-update-*")
+	if err != nil {
+		return "", fmt.Errorf("cannot write next to %s: %w", exePath, err)
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+	}()
+	if _, err = tmp.Write(binary); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err = tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err = tmp.Close(); err != nil {
+		return "", err
+	}
+
+	backup = exePath + ".bak"
+	if err = copyFile(exePath, backup, info.Mode().Perm()); err != nil {
+		return "", fmt.Errorf("backing up %s: %w", exePath, err)
+	}
+	if err = os.Rename(tmp.Name(), exePath); err != nil {
+		return "", err
+	}
+	return backup, nil
+}
+
+// copyFile copies src to dst, replacing dst
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
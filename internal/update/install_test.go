@@ -0,0 +1,107 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// releaseArchive builds a .tar.gz holding files, as a release archive does
+func releaseArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+func TestAssetName(t *testing.T) {
+	for goos, goarch := range map[string]string{"linux": "amd64", "darwin": "arm64"} {
+		want := map[string]string{"linux": "kubewatch_Linux_x86_64.tar.gz", "darwin": "kubewatch_Darwin_arm64.tar.gz"}[goos]
+		if got := AssetName(goos, goarch); got != want {
+			t.Errorf("AssetName(%s, %s) = %q, want %q", goos, goarch, got, want)
+		}
+	}
+}
+
+func TestInstall(t *testing.T) {
+	name := AssetName("linux", "amd64")
+	archive := releaseArchive(t, map[string]string{"README.md": "readme", "kubewatch": "new binary"})
+	sum := sha256.Sum256(archive)
+	checksums := fmt.Sprintf("%s  %s\n%s  other.tar.gz\n", hex.EncodeToString(sum[:]), name, strings.Repeat("0", 64))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + name:
+			w.Write(archive)
+		case "/tampered.tar.gz":
+			w.Write(append(archive, 0))
+		case "/" + ChecksumsAsset:
+			w.Write([]byte(checksums))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	release := func(archiveURL string) *Release {
+		return &Release{Tag: "v0.9.3", Assets: []Asset{
+			{Name: name, URL: server.URL + archiveURL},
+			{Name: ChecksumsAsset, URL: server.URL + "/" + ChecksumsAsset},
+		}}
+	}
+	exePath := filepath.Join(t.TempDir(), "kubewatch")
+	if err := os.WriteFile(exePath, []byte("old binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// An archive that does not match its checksum changes nothing
+	if _, err := Install(ctx, server.Client(), release("/tampered.tar.gz"), "linux", "amd64", exePath); err == nil || !strings.Contains(err.Error(), "does not match its checksum") {
+		t.Fatalf("Expected a checksum mismatch, got %v", err)
+	}
+	if data, _ := os.ReadFile(exePath); string(data) != "old binary" {
+		t.Fatalf("Expected the binary untouched, got %q", data)
+	}
+
+	// No archive for the platform
+	if _, err := Install(ctx, server.Client(), release("/"+name), "darwin", "arm64", exePath); err == nil || !strings.Contains(err.Error(), "has no kubewatch_Darwin_arm64.tar.gz") {
+		t.Errorf("Expected the missing archive named, got %v", err)
+	}
+
+	backup, err := Install(ctx, server.Client(), release("/"+name), "linux", "amd64", exePath)
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	if data, _ := os.ReadFile(exePath); string(data) != "new binary" {
+		t.Errorf("Expected the new binary installed, got %q", data)
+	}
+	if data, _ := os.ReadFile(backup); string(data) != "old binary" {
+		t.Errorf("Expected the old binary kept at %s, got %q", backup, data)
+	}
+	if info, err := os.Stat(exePath); err != nil || info.Mode().Perm() != 0755 {
+		t.Errorf("Expected the new binary executable, got %v", info.Mode())
+	}
+	entries, _ := os.ReadDir(filepath.Dir(exePath))
+	if len(entries) != 2 {
+		t.Errorf("Expected only the binary and its backup left, got %d files", len(entries))
+	}
+}
@@ -0,0 +1,152 @@
+// Package update finds newer kubewatch releases on GitHub and installs them
+// over the running binary
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// LatestReleaseURL is the GitHub API endpoint of the latest release
+const LatestReleaseURL = "https://api.github.com/repos/HamStudy/kubewatch/releases/latest"
+
+// ReleasesPage is where releases are listed for people
+const ReleasesPage = "https://github.com/HamStudy/kubewatch/releases"
+
+// ChecksumsAsset names the release asset holding every archive's SHA-256
+const ChecksumsAsset = "checksums.txt"
+
+// maxDownload bounds what is read from a response, well above a release
+// archive's size
+const maxDownload = 256 << 20
+
+// Release is a published release as the GitHub API describes it
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	URL    string  `json:"html_url"`
+	Assets []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Version returns the release's version without the tag's leading v, as
+// builds embed it
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// asset returns the asset named name, or nil
+func (r *Release) asset(name string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// Latest fetches the latest release from url, the GitHub API's latest
+// release endpoint. userAgent identifies the build asking, as GitHub requires.
+func Latest(ctx context.Context, client *http.Client, url, userAgent string) (*Release, error) {
+	body, err := get(ctx, client, url, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	var release Release
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("reading the latest release: %w", err)
+	}
+	if release.Tag == "" {
+		return nil, fmt.Errorf("the latest release has no tag")
+	}
+	return &release, nil
+}
+
+// Check returns the latest release's version when it is newer than current,
+// or "" when current is up to date or is not a release build
+func Check(ctx context.Context, client *http.Client, url, current string) (string, error) {
+	release, err := Latest(ctx, client, url, "kubewatch/"+current)
+	if err != nil {
+		return "", err
+	}
+	if !Newer(release.Version(), current) {
+		return "", nil
+	}
+	return release.Version(), nil
+}
+
+// get fetches url, failing on any status but 200 OK
+func get(ctx context.Context, client *http.Client, url, userAgent string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxDownload))
+}
+
+// Newer reports whether version is a later release than current. Versions
+// are MAJOR.MINOR.PATCH with an optional leading v and -prerelease suffix; a
+// prerelease comes before its release. A current version that is not of
+// that form, such as a dev build's, is never reported as out of date.
+func Newer(version, current string) bool {
+	v, vPre, ok := parseVersion(version)
+	if !ok {
+		return false
+	}
+	c, cPre, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	for i := range v {
+		if v[i] != c[i] {
+			return v[i] > c[i]
+		}
+	}
+	switch {
+	case vPre == cPre:
+		return false
+	case vPre == "":
+		return true
+	case cPre == "":
+		return false
+	}
+	return vPre > cPre
+}
+
+// parseVersion splits a version into its numbers and prerelease suffix
+func parseVersion(version string) (numbers [3]int, prerelease string, ok bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, prerelease, _ = strings.Cut(version, "-")
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return numbers, "", false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return numbers, "", false
+		}
+		numbers[i] = n
+	}
+	return numbers, prerelease, true
+}
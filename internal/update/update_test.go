@@ -0,0 +1,84 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		version string
+		current string
+		expect  bool
+	}{
+		{"0.9.3", "0.9.2", true},
+		{"v0.9.3", "0.9.2", true},
+		{"0.10.0", "0.9.9", true},
+		{"1.0.0", "0.99.99", true},
+		{"0.9.3", "0.9.3", false},
+		{"0.9.2", "0.9.3", false},
+		{"0.9.3", "0.9.3-rc.1", true},
+		{"0.9.3-rc.1", "0.9.3", false},
+		{"0.9.3-rc.2", "0.9.3-rc.1", true},
+		{"0.9.3", "dev", false},
+		{"0.9.3", "0.9.3-next", true},
+		{"latest", "0.9.2", false},
+		{"0.9", "0.8.0", false},
+	}
+	for _, tt := range tests {
+		if got := Newer(tt.version, tt.current); got != tt.expect {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.version, tt.current, got, tt.expect)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"tag_name":"v0.9.3","html_url":"https://example.com/v0.9.3"}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	if version, err := Check(ctx, server.Client(), server.URL, "0.9.2"); err != nil || version != "0.9.3" {
+		t.Errorf("Expected 0.9.3 reported, got %q, %v", version, err)
+	}
+	if userAgent != "kubewatch/0.9.2" {
+		t.Errorf("Expected the build named in the User-Agent, got %q", userAgent)
+	}
+	for _, current := range []string{"0.9.3", "1.0.0", "dev"} {
+		if version, err := Check(ctx, server.Client(), server.URL, current); err != nil || version != "" {
+			t.Errorf("Expected nothing reported for %s, got %q, %v", current, version, err)
+		}
+	}
+}
+
+func TestCheckFailures(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer slow.Close()
+	limited := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusForbidden)
+	}))
+	defer limited.Close()
+	garbled := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html>`))
+	}))
+	defer garbled.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := Check(ctx, slow.Client(), slow.URL, "0.9.2"); err == nil {
+		t.Error("Expected a timeout")
+	}
+	for _, url := range []string{limited.URL, garbled.URL} {
+		if version, err := Check(context.Background(), http.DefaultClient, url, "0.9.2"); err == nil || version != "" {
+			t.Errorf("Expected %s to fail, got %q", url, version)
+		}
+	}
+}